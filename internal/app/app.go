@@ -30,6 +30,7 @@ import (
 	"github.com/ekaya-inc/ekaya-engine/pkg/database"
 	"github.com/ekaya-inc/ekaya-engine/pkg/handlers"
 	"github.com/ekaya-inc/ekaya-engine/pkg/llm"
+	"github.com/ekaya-inc/ekaya-engine/pkg/logging"
 	"github.com/ekaya-inc/ekaya-engine/pkg/mcp"
 	mcpauth "github.com/ekaya-inc/ekaya-engine/pkg/mcp/auth"
 	mcptools "github.com/ekaya-inc/ekaya-engine/pkg/mcp/tools"
@@ -38,6 +39,7 @@ import (
 	"github.com/ekaya-inc/ekaya-engine/pkg/servercontrol"
 	"github.com/ekaya-inc/ekaya-engine/pkg/services"
 	etlservice "github.com/ekaya-inc/ekaya-engine/pkg/services/etl"
+	"github.com/ekaya-inc/ekaya-engine/pkg/tracing"
 	"github.com/ekaya-inc/ekaya-engine/pkg/tunnel"
 	"github.com/ekaya-inc/ekaya-engine/ui"
 	_ "github.com/jackc/pgx/v5/stdlib" // PostgreSQL driver for database/sql (migrations)
@@ -121,7 +123,16 @@ func Run(version string) error {
 
 	// Connect to database
 	ctx := context.Background()
-	db, err := setupDatabase(ctx, &cfg.EngineDatabase, logger)
+
+	// Tracing exports spans only when enabled; otherwise instrumentation is a no-op
+	shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing, cfg.Version)
+	if err != nil {
+		return fmt.Errorf("failed to set up tracing: %w", err)
+	}
+	if cfg.Tracing.Enabled {
+		logger.Info("Tracing enabled", zap.String("otlp_endpoint", cfg.Tracing.OTLPEndpoint))
+	}
+	db, migrationStatus, err := setupDatabase(ctx, &cfg.EngineDatabase, logger)
 	if err != nil {
 		return fmt.Errorf("failed to setup database: %w", err)
 	}
@@ -150,6 +161,7 @@ func Run(version string) error {
 	knowledgeRepo := repositories.NewKnowledgeRepository()
 	ontologyQuestionRepo := repositories.NewOntologyQuestionRepository()
 	ontologyDAGRepo := repositories.NewOntologyDAGRepository()
+	relationshipCheckpointRepo := repositories.NewRelationshipCheckpointRepository()
 	pendingChangeRepo := repositories.NewPendingChangeRepository()
 	columnMetadataRepo := repositories.NewColumnMetadataRepository()
 	tableMetadataRepo := repositories.NewTableMetadataRepository()
@@ -165,8 +177,13 @@ func Run(version string) error {
 	connManager := datasource.NewConnectionManager(connManagerCfg, logger)
 	defer connManager.Close()
 
-	// Create adapter factory for datasource connections
-	adapterFactory := datasource.NewDatasourceAdapterFactory(connManager)
+	// Create adapter factory for datasource connections. Secret placeholders in
+	// datasource configs are resolved here, at connection time, and never stored.
+	var secretResolver datasource.SecretResolver
+	if cfg.Datasource.SecretsProvider == "env" {
+		secretResolver = datasource.NewEnvSecretResolver(cfg.Datasource.SecretsEnvPrefix)
+	}
+	adapterFactory := datasource.WithSecretResolution(datasource.NewDatasourceAdapterFactory(connManager), secretResolver)
 
 	// Create security auditor for SIEM logging
 	securityAuditor := audit.NewSecurityAuditor(logger)
@@ -177,7 +194,7 @@ func Run(version string) error {
 	// Create services
 	nonceStore := services.NewNonceStore(nonceRepo, 15*time.Minute)
 	installedAppService := services.NewInstalledAppService(installedAppRepo, centralClient, nonceStore, cfg.BaseURL, logger)
-	projectService := services.NewProjectService(db, projectRepo, userRepo, mcpConfigRepo, installedAppService, centralClient, nonceStore, cfg.BaseURL, logger)
+	projectService := services.NewProjectService(db, projectRepo, datasourceRepo, userRepo, mcpConfigRepo, installedAppService, centralClient, nonceStore, cfg.BaseURL, logger)
 	userService := services.NewUserService(userRepo, logger)
 	datasourceService := services.NewDatasourceService(datasourceRepo, credentialEncryptor, adapterFactory, projectService, logger)
 	schemaService := services.NewSchemaService(schemaRepo, columnMetadataRepo, datasourceService, adapterFactory, logger)
@@ -191,17 +208,27 @@ func Run(version string) error {
 
 	// LLM factory for creating clients per project configuration
 	llmFactory := llm.NewClientFactory(aiConfigService, logger)
+	llmFactory.SetRateLimiter(llm.NewRateLimiter(llm.RateLimitConfig{
+		RequestsPerMinute: cfg.LLM.RequestsPerMinute,
+		TokensPerMinute:   cfg.LLM.TokensPerMinute,
+	}))
 
 	// Ontology services
 	knowledgeService := services.NewKnowledgeService(knowledgeRepo, projectRepo, logger)
 	ontologyBuilderService := services.NewOntologyBuilderService(llmFactory, logger)
+	tableQualityService := services.NewTableQualityService(
+		schemaRepo, tableMetadataRepo, columnMetadataRepo, ontologyQuestionRepo, logger)
 	ontologyQuestionService := services.NewOntologyQuestionService(
 		ontologyQuestionRepo, columnMetadataRepo, schemaRepo, knowledgeRepo,
-		ontologyBuilderService, logger)
+		ontologyBuilderService, tableQualityService, logger)
 	getTenantCtx := services.NewTenantContextFunc(db)
 
 	// Set up LLM conversation recording for debugging
-	convRepo := repositories.NewConversationRepository()
+	convRedactor, err := logging.NewRedactor(cfg.Conversations.RedactDetectors, cfg.Conversations.RedactPatterns)
+	if err != nil {
+		return fmt.Errorf("failed to initialize conversation redaction: %w", err)
+	}
+	convRepo := repositories.NewConversationRepository(convRedactor)
 	convRecorder := llm.NewAsyncConversationRecorder(convRepo, llm.TenantContextFunc(getTenantCtx), logger, 100)
 	llmFactory.SetRecorder(convRecorder)
 
@@ -210,11 +237,20 @@ func Run(version string) error {
 		schemaRepo, ontologyDAGRepo, projectService,
 		llmFactory, datasourceService, adapterFactory, logger)
 	relationshipBootstrapService := services.NewRelationshipBootstrapService(
-		datasourceService, adapterFactory, schemaRepo, columnMetadataRepo, logger)
+		datasourceService, adapterFactory, schemaRepo, columnMetadataRepo, projectRepo, cfg.Ontology.TrustDeclaredFKs, logger)
 	ontologyFinalizationService := services.NewOntologyFinalizationService(
-		projectRepo, schemaRepo, columnMetadataRepo, convRepo, llmFactory, getTenantCtx, logger)
+		projectRepo, schemaRepo, columnMetadataRepo, tableMetadataRepo, convRepo,
+		ontologyQuestionService, cfg.Ontology.MaxQuestionsPerTable, tableQualityService,
+		llmFactory, getTenantCtx, logger)
 	ontologyContextService := services.NewOntologyContextService(
 		schemaRepo, columnMetadataRepo, tableMetadataRepo, projectService, logger)
+	ontologyHealthService := services.NewOntologyHealthService(
+		schemaRepo, projectRepo, ontologyDAGRepo, cfg.Ontology.GraphNodeMatchDistance,
+		cfg.Ontology.StatsStaleAfterDays, logger)
+	ontologyEntityService := services.NewOntologyEntityService(schemaRepo, tableMetadataRepo, logger)
+	ontologyContextDocumentService := services.NewOntologyContextDocumentService(
+		projectRepo, schemaRepo, tableMetadataRepo, columnMetadataRepo, glossaryRepo,
+		cfg.Ontology.ContextDocumentTokenBudget, logger)
 	ontologyExportService := services.NewOntologyExportService(
 		projectRepo,
 		datasourceService,
@@ -238,6 +274,7 @@ func Run(version string) error {
 
 	// Create worker pool for parallel LLM calls
 	workerPoolConfig := llm.DefaultWorkerPoolConfig()
+	workerPoolConfig.MaxConcurrent = cfg.LLM.MaxConcurrent
 	llmWorkerPool := llm.NewWorkerPool(workerPoolConfig, logger)
 
 	// Create circuit breaker for LLM resilience
@@ -246,7 +283,9 @@ func Run(version string) error {
 
 	columnEnrichmentService := services.NewColumnEnrichmentService(
 		schemaRepo, columnMetadataRepo, convRepo, projectRepo, ontologyQuestionService,
-		datasourceService, adapterFactory, llmFactory, llmWorkerPool, llmCircuitBreaker, getTenantCtx, logger)
+		datasourceService, adapterFactory, llmFactory, llmWorkerPool, llmCircuitBreaker, getTenantCtx,
+		cfg.Ontology.QuestionCategories, logger)
+	extractionEstimateService := services.NewExtractionEstimateService(columnEnrichmentService, convRepo, llmFactory, logger)
 	glossaryService := services.NewGlossaryService(glossaryRepo, columnMetadataRepo, knowledgeRepo, schemaRepo, projectService, datasourceService, adapterFactory, llmFactory, getTenantCtx, logger, cfg.Env)
 
 	// Ontology DAG service for orchestrated workflow execution
@@ -254,28 +293,33 @@ func Run(version string) error {
 		ontologyDAGRepo, schemaRepo,
 		ontologyQuestionRepo, ontologyChatRepo, knowledgeRepo,
 		glossaryRepo, getTenantCtx, logger)
+	ontologyDAGService.SetTokenBudgets(services.NewTokenBudgetTracker(), projectRepo)
 
 	// Wire DAG adapters using setter pattern (avoids import cycles)
-	knowledgeSeedingService := services.NewKnowledgeSeedingService(knowledgeService, schemaService, llmFactory, logger)
+	knowledgeSeedingService := services.NewKnowledgeSeedingService(knowledgeService, schemaService, llmFactory,
+		cfg.Ontology.DescriptionPromptTemplate, cfg.Ontology.DomainTaxonomy, logger)
 	ontologyDAGService.SetKnowledgeSeedingMethods(knowledgeSeedingService)
 	columnFeatureExtractionService := services.NewColumnFeatureExtractionServiceFull(
 		schemaRepo, columnMetadataRepo, datasourceService, adapterFactory, llmFactory, llmWorkerPool, getTenantCtx,
-		ontologyQuestionService, logger)
+		ontologyQuestionService, cfg.Ontology.DistinctEstimateRowThreshold,
+		datasource.DistinctValueStrategy(cfg.Ontology.EnumSampleStrategy), cfg.Ontology.EnumSampleLimit, logger)
 	ontologyDAGService.SetColumnFeatureExtractionMethods(columnFeatureExtractionService)
 	ontologyDAGService.SetFKDiscoveryMethods(services.NewFKDiscoveryAdapter(relationshipBootstrapService))
 	// LLM-validated relationship discovery powers the RelationshipDiscovery DAG stage.
 	relationshipCandidateCollector := services.NewRelationshipCandidateCollector(
-		schemaRepo, columnMetadataRepo, adapterFactory, datasourceService, logger)
+		schemaRepo, columnMetadataRepo, adapterFactory, datasourceService, relationshipCheckpointRepo,
+		cfg.Ontology.FKExcludedPurposes, cfg.Ontology.UUIDTextFKMatching, logger)
 	relationshipValidator := services.NewRelationshipValidator(
 		llmFactory, llmWorkerPool, llmCircuitBreaker, convRepo, getTenantCtx, logger)
 	llmRelationshipDiscoveryService := services.NewLLMRelationshipDiscoveryService(
 		relationshipCandidateCollector, relationshipValidator, datasourceService, adapterFactory,
-		schemaRepo, columnMetadataRepo, logger)
+		schemaRepo, columnMetadataRepo, projectRepo, logger)
 	ontologyDAGService.SetLLMRelationshipDiscoveryMethods(services.NewLLMRelationshipDiscoveryAdapter(llmRelationshipDiscoveryService))
 	ontologyDAGService.SetFinalizationMethods(services.NewOntologyFinalizationAdapter(ontologyFinalizationService))
 	ontologyDAGService.SetColumnEnrichmentMethods(services.NewColumnEnrichmentAdapter(columnEnrichmentService))
 	tableFeatureExtractionSvc := services.NewTableFeatureExtractionService(
-		schemaRepo, columnMetadataRepo, tableMetadataRepo, llmFactory, llmWorkerPool, getTenantCtx, logger)
+		schemaRepo, columnMetadataRepo, tableMetadataRepo, llmFactory, llmWorkerPool, getTenantCtx,
+		cfg.Ontology.WideTableColumnThreshold, cfg.Ontology.MinPromptRelationshipConfidence, logger)
 	ontologyDAGService.SetTableFeatureExtractionMethods(tableFeatureExtractionSvc)
 
 	// Incremental DAG service for targeted LLM enrichment after changes
@@ -306,6 +350,9 @@ func Run(version string) error {
 
 	// Register health handler
 	healthHandler := handlers.NewHealthHandler(cfg, connManager, logger)
+	if migrationStatus != nil {
+		healthHandler.SetSchemaVersion(migrationStatus.Version)
+	}
 	healthHandler.RegisterRoutes(mux)
 
 	// Register auth handler (public - no auth required)
@@ -469,13 +516,17 @@ func Run(version string) error {
 	ontologyChatHandler.RegisterRoutes(mux, authMiddleware, tenantMiddleware)
 
 	// Register ontology DAG handler (protected) - unified workflow execution
-	ontologyDAGHandler := handlers.NewOntologyDAGHandler(ontologyDAGService, projectService, logger)
+	ontologyDAGHandler := handlers.NewOntologyDAGHandler(ontologyDAGService, projectService, schemaService, schemaChangeDetectionService, logger)
 	ontologyDAGHandler.RegisterRoutes(mux, authMiddleware, tenantMiddleware)
 
 	// Register ontology export handler (protected) - raw export bundle download
 	ontologyExportHandler := handlers.NewOntologyExportHandler(ontologyExportService, logger)
 	ontologyExportHandler.RegisterRoutes(mux, authMiddleware, tenantMiddleware)
 
+	// Register ontology context document handler (protected) - text-to-SQL prompt context
+	ontologyContextDocumentHandler := handlers.NewOntologyContextDocumentHandler(ontologyContextDocumentService, logger)
+	ontologyContextDocumentHandler.RegisterRoutes(mux, authMiddleware, tenantMiddleware)
+
 	// Register ontology import handler (protected) - raw bundle upload for manual/provisioning reuse
 	ontologyImportHandler := handlers.NewOntologyImportHandler(ontologyImportService, logger)
 	ontologyImportHandler.RegisterRoutes(mux, authMiddleware, tenantMiddleware)
@@ -484,6 +535,22 @@ func Run(version string) error {
 	ontologyEnrichmentHandler := handlers.NewOntologyEnrichmentHandler(schemaService, projectService, logger)
 	ontologyEnrichmentHandler.RegisterRoutes(mux, authMiddleware, tenantMiddleware)
 
+	// Register ontology health handler (protected) - deterministic integrity checks
+	ontologyHealthHandler := handlers.NewOntologyHealthHandler(ontologyHealthService, logger)
+	ontologyHealthHandler.RegisterRoutes(mux, authMiddleware, tenantMiddleware)
+
+	// Register ontology entities handler (protected) - entity provenance and purge by source
+	ontologyEntitiesHandler := handlers.NewOntologyEntitiesHandler(ontologyEntityService, logger)
+	ontologyEntitiesHandler.RegisterRoutes(mux, authMiddleware, tenantMiddleware)
+
+	// Register ontology domain summary handler (protected) - regenerate the tier0 summary on demand
+	ontologyDomainSummaryHandler := handlers.NewOntologyDomainSummaryHandler(ontologyFinalizationService, logger)
+	ontologyDomainSummaryHandler.RegisterRoutes(mux, authMiddleware, tenantMiddleware)
+
+	// Register extraction estimate handler (protected) - dry-run token and cost projection
+	extractionEstimateHandler := handlers.NewExtractionEstimateHandler(extractionEstimateService, logger)
+	extractionEstimateHandler.RegisterRoutes(mux, authMiddleware, tenantMiddleware)
+
 	// Register glossary handler (protected) - business glossary for MCP clients
 	glossaryHandler := handlers.NewGlossaryHandler(glossaryService, ontologyQuestionService, logger)
 	glossaryHandler.RegisterRoutes(mux, authMiddleware, tenantMiddleware)
@@ -561,6 +628,7 @@ func Run(version string) error {
 			InstalledAppService: installedAppService,
 		},
 		GlossaryService: glossaryService,
+		QuestionService: ontologyQuestionService,
 	}
 	mcptools.RegisterGlossaryTools(mcpServer.MCP(), glossaryToolDeps)
 
@@ -603,9 +671,10 @@ func Run(version string) error {
 			Logger:              logger,
 			InstalledAppService: installedAppService,
 		},
-		SchemaRepo:         schemaRepo,
-		ColumnMetadataRepo: columnMetadataRepo,
-		ProjectService:     projectService,
+		SchemaRepo:          schemaRepo,
+		ColumnMetadataRepo:  columnMetadataRepo,
+		ProjectService:      projectService,
+		TableQualityService: tableQualityService,
 	}
 	mcptools.RegisterColumnTools(mcpServer.MCP(), columnToolDeps)
 	mcptools.RegisterBatchTools(mcpServer.MCP(), columnToolDeps)
@@ -618,9 +687,10 @@ func Run(version string) error {
 			Logger:              logger,
 			InstalledAppService: installedAppService,
 		},
-		SchemaRepo:        schemaRepo,
-		TableMetadataRepo: tableMetadataRepo,
-		ProjectService:    projectService,
+		SchemaRepo:          schemaRepo,
+		TableMetadataRepo:   tableMetadataRepo,
+		ProjectService:      projectService,
+		TableQualityService: tableQualityService,
 	}
 	mcptools.RegisterTableTools(mcpServer.MCP(), tableToolDeps)
 
@@ -822,6 +892,11 @@ func Run(version string) error {
 		// 5. Close conversation recorder (drain pending writes)
 		convRecorder.Close()
 
+		// 6. Flush pending spans
+		if err := shutdownTracing(shutdownCtx); err != nil {
+			logger.Error("Tracing shutdown error", zap.Error(err))
+		}
+
 		cleanupRuntimeControl()
 
 		close(shutdownComplete)
@@ -851,24 +926,31 @@ func Run(version string) error {
 	return nil
 }
 
-func setupDatabase(ctx context.Context, cfg *config.EngineDatabaseConfig, logger *zap.Logger) (*database.DB, error) {
+// setupDatabase runs pending migrations and connects the engine database pool. The
+// returned migration status is nil when the schema version could not be read.
+func setupDatabase(ctx context.Context, cfg *config.EngineDatabaseConfig, logger *zap.Logger) (*database.DB, *database.MigrationStatus, error) {
 	logger.Info("Connecting to database",
 		zap.String("user", cfg.User),
 		zap.String("host", cfg.Host),
 		zap.Int("port", cfg.Port),
 		zap.String("database", cfg.Database))
 
-	// Build database URL with URL-encoded password
-	databaseURL := fmt.Sprintf("postgresql://%s:%s@%s:%d/%s?sslmode=%s",
-		cfg.User, url.QueryEscape(cfg.Password), cfg.Host, cfg.Port, cfg.Database, cfg.SSLMode)
+	databaseURL := engineDatabaseURL(cfg)
 
 	// Run database migrations first, using a separate connection with timeout.
 	// This avoids the hang issue with stdlib.OpenDBFromPool + golang-migrate.
 	logger.Info("Running database migrations")
-	if err := runMigrations(databaseURL, logger); err != nil {
-		return nil, err // Error already formatted with helpful guidance
+	migrationStatus, err := runMigrations(databaseURL, logger)
+	if err != nil {
+		return nil, nil, err // Error already formatted with helpful guidance
+	}
+	if migrationStatus != nil {
+		logger.Info("Database migrations completed successfully",
+			zap.Uint("schema_version", migrationStatus.Version),
+			zap.Uint("latest_version", migrationStatus.Latest))
+	} else {
+		logger.Info("Database migrations completed successfully")
 	}
-	logger.Info("Database migrations completed successfully")
 
 	// Now establish the main connection pool
 	db, err := database.NewConnection(ctx, &database.Config{
@@ -876,7 +958,7 @@ func setupDatabase(ctx context.Context, cfg *config.EngineDatabaseConfig, logger
 		MaxConnections: cfg.MaxConnections,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to database: %w", err)
+		return nil, nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
 	// Check if connection has superuser privileges (bypasses RLS)
@@ -889,14 +971,42 @@ func setupDatabase(ctx context.Context, cfg *config.EngineDatabaseConfig, logger
 			"non-superuser database role to ensure RLS enforcement.")
 	}
 
-	return db, nil
+	return db, migrationStatus, nil
+}
+
+// engineDatabaseURL builds the engine database URL with a URL-encoded password.
+func engineDatabaseURL(cfg *config.EngineDatabaseConfig) string {
+	return fmt.Sprintf("postgresql://%s:%s@%s:%d/%s?sslmode=%s",
+		cfg.User, url.QueryEscape(cfg.Password), cfg.Host, cfg.Port, cfg.Database, cfg.SSLMode)
 }
 
 // migrationTimeout is the maximum time to wait for migrations to complete.
 // This prevents indefinite hangs when the database user lacks schema permissions.
 const migrationTimeout = 30 * time.Second
 
-func runMigrations(databaseURL string, logger *zap.Logger) error {
+// runMigrations applies pending migrations and returns the resulting schema status,
+// or nil status when it cannot be read after a successful run.
+func runMigrations(databaseURL string, logger *zap.Logger) (*database.MigrationStatus, error) {
+	db, err := openMigrationDB(databaseURL)
+	if err != nil {
+		return nil, formatMigrationError(err)
+	}
+	defer db.Close()
+
+	if err := database.RunMigrations(db, logger); err != nil {
+		return nil, formatMigrationError(err)
+	}
+
+	status, err := database.GetMigrationStatus(db, logger)
+	if err != nil {
+		logger.Warn("Failed to read schema version after migrations", zap.Error(err))
+		return nil, nil
+	}
+	return status, nil
+}
+
+// openMigrationDB opens and verifies the connection migrations run on.
+func openMigrationDB(databaseURL string) (*sql.DB, error) {
 	// Create a separate connection for migrations with statement_timeout set in the URL.
 	// This is critical: using stdlib.OpenDBFromPool + golang-migrate can hang indefinitely
 	// on permission errors. A direct sql.Open connection with timeout avoids this issue.
@@ -911,20 +1021,15 @@ func runMigrations(databaseURL string, logger *zap.Logger) error {
 
 	db, err := sql.Open("pgx", migrationURL)
 	if err != nil {
-		return formatMigrationError(fmt.Errorf("failed to open migration connection: %w", err))
+		return nil, fmt.Errorf("failed to open migration connection: %w", err)
 	}
-	defer db.Close()
 
 	// Verify connection before running migrations
 	if err := db.Ping(); err != nil {
-		return formatMigrationError(fmt.Errorf("failed to connect for migrations: %w", err))
+		db.Close()
+		return nil, fmt.Errorf("failed to connect for migrations: %w", err)
 	}
-
-	err = database.RunMigrations(db, logger)
-	if err != nil {
-		return formatMigrationError(err)
-	}
-	return nil
+	return db, nil
 }
 
 // formatMigrationError wraps migration errors with helpful guidance for common issues.
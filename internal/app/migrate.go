@@ -0,0 +1,69 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/config"
+	"github.com/ekaya-inc/ekaya-engine/pkg/database"
+)
+
+// Migrate applies pending engine database migrations out-of-band, without starting
+// the server, then writes the schema status to w. With statusOnly it only reports.
+func Migrate(version string, statusOnly bool, w io.Writer) error {
+	cfg, err := config.Load(version)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	logger, err := zap.NewProduction()
+	if err != nil {
+		return fmt.Errorf("failed to initialize logger: %w", err)
+	}
+	defer func() { _ = logger.Sync() }()
+
+	db, err := openMigrationDB(engineDatabaseURL(&cfg.EngineDatabase))
+	if err != nil {
+		return formatMigrationError(err)
+	}
+	defer db.Close()
+
+	if !statusOnly {
+		if err := database.RunMigrations(db, logger); err != nil {
+			return formatMigrationError(err)
+		}
+	}
+
+	status, err := database.GetMigrationStatus(db, logger)
+	if err != nil {
+		return err
+	}
+	writeMigrationStatus(w, status)
+	return nil
+}
+
+func writeMigrationStatus(w io.Writer, status *database.MigrationStatus) {
+	fmt.Fprintf(w, "Schema version: %d\n", status.Version)
+	fmt.Fprintf(w, "Latest version: %d\n", status.Latest)
+	if status.Dirty {
+		fmt.Fprintf(w, "State: dirty (migration %d failed part way)\n", status.Version)
+	} else if status.UpToDate() {
+		fmt.Fprintln(w, "State: up to date")
+	}
+	fmt.Fprintf(w, "Applied: %d\n", len(status.Applied))
+	fmt.Fprintf(w, "Pending: %s\n", formatVersions(status.Pending))
+}
+
+func formatVersions(versions []uint) string {
+	if len(versions) == 0 {
+		return "none"
+	}
+	parts := make([]string, len(versions))
+	for i, v := range versions {
+		parts[i] = fmt.Sprintf("%03d", v)
+	}
+	return strings.Join(parts, ", ")
+}
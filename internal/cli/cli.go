@@ -11,7 +11,9 @@ import (
 	"github.com/ekaya-inc/ekaya-engine/pkg/servercontrol"
 )
 
-func Run(args []string, version string, serve func() error) error {
+// Run dispatches a command line. serve starts the server; migrate applies pending
+// engine database migrations, or only reports their status when statusOnly is set.
+func Run(args []string, version string, serve func() error, migrate func(statusOnly bool) error) error {
 	command := ""
 	if len(args) > 0 {
 		command = args[0]
@@ -26,6 +28,8 @@ func Run(args []string, version string, serve func() error) error {
 		return runStopCommand(version)
 	case "restart":
 		return runRestartCommand(version, serve)
+	case "migrate":
+		return runMigrateCommand(args[1:], migrate)
 	case "help", "-h", "--help":
 		printUsage(os.Stdout)
 		return nil
@@ -111,6 +115,18 @@ func runRestartCommand(version string, serve func() error) error {
 	return serve()
 }
 
+func runMigrateCommand(args []string, migrate func(statusOnly bool) error) error {
+	switch {
+	case len(args) == 0:
+		return migrate(false)
+	case len(args) == 1 && args[0] == "status":
+		return migrate(true)
+	default:
+		printUsage(os.Stderr)
+		return fmt.Errorf("unknown migrate arguments %q", args)
+	}
+}
+
 func printUsage(w io.Writer) {
 	fmt.Fprintln(w, "Usage:")
 	fmt.Fprintln(w, "  ekaya-engine             Start the server")
@@ -118,6 +134,9 @@ func printUsage(w io.Writer) {
 	fmt.Fprintln(w, "  ekaya-engine status      Show status for the resolved config")
 	fmt.Fprintln(w, "  ekaya-engine stop        Stop the running server for the resolved config")
 	fmt.Fprintln(w, "  ekaya-engine restart     Stop then start the server for the resolved config")
+	fmt.Fprintln(w, "  ekaya-engine migrate     Apply pending database migrations without starting the server")
+	fmt.Fprintln(w, "  ekaya-engine migrate status")
+	fmt.Fprintln(w, "                           Show applied and pending database migrations")
 }
 
 func loadResolvedConfig(version string) (*config.Config, error) {
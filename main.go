@@ -14,6 +14,8 @@ var Version = "dev"
 func main() {
 	if err := cli.Run(os.Args[1:], Version, func() error {
 		return app.Run(Version)
+	}, func(statusOnly bool) error {
+		return app.Migrate(Version, statusOnly, os.Stdout)
 	}); err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
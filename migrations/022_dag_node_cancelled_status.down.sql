@@ -0,0 +1,7 @@
+UPDATE engine_dag_nodes SET status = 'skipped' WHERE status = 'cancelled';
+
+ALTER TABLE engine_dag_nodes DROP CONSTRAINT engine_dag_nodes_status_check;
+
+ALTER TABLE engine_dag_nodes
+    ADD CONSTRAINT engine_dag_nodes_status_check
+        CHECK ((status)::text = ANY (ARRAY['pending'::text, 'running'::text, 'completed'::text, 'failed'::text, 'skipped'::text]));
@@ -0,0 +1,9 @@
+-- 022_dag_node_cancelled_status.up.sql
+-- Allow a DAG node that was running when its DAG was cancelled to be recorded as
+-- 'cancelled', distinct from pending nodes that were never started ('skipped').
+
+ALTER TABLE engine_dag_nodes DROP CONSTRAINT engine_dag_nodes_status_check;
+
+ALTER TABLE engine_dag_nodes
+    ADD CONSTRAINT engine_dag_nodes_status_check
+        CHECK ((status)::text = ANY (ARRAY['pending'::text, 'running'::text, 'completed'::text, 'failed'::text, 'skipped'::text, 'cancelled'::text]));
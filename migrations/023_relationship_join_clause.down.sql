@@ -0,0 +1,4 @@
+-- 023_relationship_join_clause.down.sql
+
+ALTER TABLE engine_schema_relationships
+    DROP COLUMN IF EXISTS join_clause;
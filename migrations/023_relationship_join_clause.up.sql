@@ -0,0 +1,37 @@
+-- 023_relationship_join_clause.up.sql
+-- Store the canonical ON clause for each relationship so consumers don't rebuild it
+-- from table and column names. Existing single-column relationships are backfilled
+-- with identifiers quoted in their datasource's dialect: [brackets] for SQL Server,
+-- "double quotes" for everything else, as datasource.IdentifierQuoter does. Composite
+-- FK clauses are written on the next schema refresh.
+
+ALTER TABLE engine_schema_relationships
+    ADD COLUMN join_clause text;
+
+WITH quoting (datasource_type, open_quote, close_quote) AS (
+    VALUES ('mssql', '[', ']')
+),
+parts AS (
+    SELECT r.id,
+           COALESCE(q.open_quote, '"') AS oq,
+           COALESCE(q.close_quote, '"') AS cq,
+           st.schema_name AS source_schema, st.table_name AS source_table, sc.column_name AS source_column,
+           tt.schema_name AS target_schema, tt.table_name AS target_table, tc.column_name AS target_column
+    FROM engine_schema_relationships r
+    JOIN engine_schema_columns sc ON sc.id = r.source_column_id
+    JOIN engine_schema_tables st ON st.id = sc.schema_table_id
+    JOIN engine_datasources d ON d.id = st.datasource_id
+    JOIN engine_schema_columns tc ON tc.id = r.target_column_id
+    JOIN engine_schema_tables tt ON tt.id = tc.schema_table_id
+    LEFT JOIN quoting q ON q.datasource_type = d.datasource_type
+)
+UPDATE engine_schema_relationships r
+SET join_clause =
+        CASE WHEN p.source_schema = '' THEN '' ELSE p.oq || replace(p.source_schema, p.cq, p.cq || p.cq) || p.cq || '.' END ||
+        p.oq || replace(p.source_table, p.cq, p.cq || p.cq) || p.cq || '.' ||
+        p.oq || replace(p.source_column, p.cq, p.cq || p.cq) || p.cq || ' = ' ||
+        CASE WHEN p.target_schema = '' THEN '' ELSE p.oq || replace(p.target_schema, p.cq, p.cq || p.cq) || p.cq || '.' END ||
+        p.oq || replace(p.target_table, p.cq, p.cq || p.cq) || p.cq || '.' ||
+        p.oq || replace(p.target_column, p.cq, p.cq || p.cq) || p.cq
+FROM parts p
+WHERE p.id = r.id;
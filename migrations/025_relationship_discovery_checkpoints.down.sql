@@ -0,0 +1,2 @@
+DROP POLICY IF EXISTS relationship_discovery_checkpoints_access ON engine_relationship_discovery_checkpoints;
+DROP TABLE IF EXISTS engine_relationship_discovery_checkpoints;
@@ -0,0 +1,33 @@
+-- 025_relationship_discovery_checkpoints.up.sql
+-- Join analysis results of relationship candidate pairs, recorded as discovery
+-- validates them so a run interrupted by a datasource connection drop resumes
+-- without re-running the join queries of pairs it already validated. Rows are
+-- cleared once candidate collection completes.
+
+CREATE TABLE engine_relationship_discovery_checkpoints (
+    project_id uuid NOT NULL REFERENCES engine_projects(id) ON DELETE CASCADE,
+    datasource_id uuid NOT NULL REFERENCES engine_datasources(id) ON DELETE CASCADE,
+    source_column_id uuid NOT NULL,
+    target_column_id uuid NOT NULL,
+    join_count bigint NOT NULL,
+    source_matched bigint NOT NULL,
+    target_matched bigint NOT NULL,
+    orphan_count bigint NOT NULL,
+    reverse_orphan_count bigint NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (datasource_id, source_column_id, target_column_id)
+);
+
+CREATE INDEX idx_engine_relationship_discovery_checkpoints_project
+    ON engine_relationship_discovery_checkpoints (project_id);
+
+COMMENT ON TABLE engine_relationship_discovery_checkpoints IS 'Join analysis results of candidate pairs validated by an unfinished relationship discovery run';
+COMMENT ON COLUMN engine_relationship_discovery_checkpoints.source_column_id IS 'engine_schema_columns id of the candidate FK column';
+COMMENT ON COLUMN engine_relationship_discovery_checkpoints.target_column_id IS 'engine_schema_columns id of the referenced column';
+
+ALTER TABLE engine_relationship_discovery_checkpoints ENABLE ROW LEVEL SECURITY;
+ALTER TABLE engine_relationship_discovery_checkpoints FORCE ROW LEVEL SECURITY;
+
+CREATE POLICY relationship_discovery_checkpoints_access ON engine_relationship_discovery_checkpoints FOR ALL
+    USING (rls_tenant_id() IS NULL OR project_id = rls_tenant_id())
+    WITH CHECK (rls_tenant_id() IS NULL OR project_id = rls_tenant_id());
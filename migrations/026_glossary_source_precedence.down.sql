@@ -0,0 +1,19 @@
+-- 026_glossary_source_precedence.down.sql
+
+ALTER TABLE engine_business_glossary
+    DROP COLUMN IF EXISTS alternate_definitions;
+
+UPDATE engine_business_glossary SET source = 'manual' WHERE source = 'import';
+UPDATE engine_business_glossary SET last_edit_source = 'manual' WHERE last_edit_source = 'import';
+
+ALTER TABLE engine_business_glossary
+    DROP CONSTRAINT engine_business_glossary_source_check,
+    DROP CONSTRAINT engine_business_glossary_last_edit_source_check;
+
+ALTER TABLE engine_business_glossary
+    ADD CONSTRAINT engine_business_glossary_source_check
+        CHECK (source IN ('inferred', 'mcp', 'manual')),
+    ADD CONSTRAINT engine_business_glossary_last_edit_source_check
+        CHECK (last_edit_source IS NULL OR last_edit_source IN ('inferred', 'mcp', 'manual'));
+
+COMMENT ON COLUMN engine_business_glossary.source IS 'How this term was created: inferred (Engine), mcp (Claude), manual (UI)';
@@ -0,0 +1,20 @@
+-- 026_glossary_source_precedence.up.sql
+-- Glossary terms can now be imported from an authoritative source. When the same term
+-- arrives from several sources the highest-precedence definition (manual > import >
+-- mcp > inferred) is kept on the term and the others are recorded as alternates.
+
+ALTER TABLE engine_business_glossary
+    DROP CONSTRAINT engine_business_glossary_source_check,
+    DROP CONSTRAINT engine_business_glossary_last_edit_source_check;
+
+ALTER TABLE engine_business_glossary
+    ADD CONSTRAINT engine_business_glossary_source_check
+        CHECK (source IN ('inferred', 'mcp', 'import', 'manual')),
+    ADD CONSTRAINT engine_business_glossary_last_edit_source_check
+        CHECK (last_edit_source IS NULL OR last_edit_source IN ('inferred', 'mcp', 'import', 'manual'));
+
+ALTER TABLE engine_business_glossary
+    ADD COLUMN alternate_definitions jsonb;
+
+COMMENT ON COLUMN engine_business_glossary.source IS 'How this term was created: inferred (Engine), mcp (Claude), import (authoritative import), manual (UI)';
+COMMENT ON COLUMN engine_business_glossary.alternate_definitions IS 'Definitions of this term from lower-precedence sources, one per source';
@@ -0,0 +1,12 @@
+-- 027_dag_token_budget_stop.down.sql
+
+ALTER TABLE engine_ontology_dag
+    DROP COLUMN IF EXISTS tokens_spent;
+
+UPDATE engine_dag_nodes SET status = 'failed' WHERE status = 'budget_exceeded';
+
+ALTER TABLE engine_dag_nodes DROP CONSTRAINT engine_dag_nodes_status_check;
+
+ALTER TABLE engine_dag_nodes
+    ADD CONSTRAINT engine_dag_nodes_status_check
+        CHECK ((status)::text = ANY (ARRAY['pending'::text, 'running'::text, 'completed'::text, 'failed'::text, 'skipped'::text, 'cancelled'::text]));
@@ -0,0 +1,15 @@
+-- 027_dag_token_budget_stop.up.sql
+-- A DAG node stopped by its run's token budget is recorded as 'budget_exceeded', so
+-- the run can be told apart from other failures and resumed from that node. The DAG
+-- keeps the tokens its runs have spent so a resumed run continues from that total.
+
+ALTER TABLE engine_dag_nodes DROP CONSTRAINT engine_dag_nodes_status_check;
+
+ALTER TABLE engine_dag_nodes
+    ADD CONSTRAINT engine_dag_nodes_status_check
+        CHECK ((status)::text = ANY (ARRAY['pending'::text, 'running'::text, 'completed'::text, 'failed'::text, 'skipped'::text, 'cancelled'::text, 'budget_exceeded'::text]));
+
+ALTER TABLE engine_ontology_dag
+    ADD COLUMN tokens_spent integer NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN engine_ontology_dag.tokens_spent IS 'LLM tokens spent by this DAG, including runs before it was resumed';
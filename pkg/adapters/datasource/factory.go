@@ -46,7 +46,11 @@ func (f *registryFactory) NewSchemaDiscoverer(ctx context.Context, dsType string
 	if factory == nil {
 		return nil, fmt.Errorf("schema discovery not supported for type: %s", dsType)
 	}
-	return factory(ctx, config, f.connMgr, projectID, datasourceID, userID)
+	discoverer, err := factory(ctx, config, f.connMgr, projectID, datasourceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return withTracing(discoverer), nil
 }
 
 func (f *registryFactory) NewQueryExecutor(ctx context.Context, dsType string, config map[string]any, projectID, datasourceID uuid.UUID, userID string) (QueryExecutor, error) {
@@ -66,7 +66,11 @@ func (m *mockSchemaDiscoverer) GetDistinctValues(ctx context.Context, schemaName
 	return []string{}, nil
 }
 
-func (m *mockSchemaDiscoverer) GetEnumValueDistribution(ctx context.Context, schemaName, tableName, columnName string, completionTimestampCol string, limit int) (*EnumDistributionResult, error) {
+func (m *mockSchemaDiscoverer) SampleDistinctValues(ctx context.Context, schemaName, tableName, columnName string, opts DistinctValueOptions) ([]string, error) {
+	return []string{}, nil
+}
+
+func (m *mockSchemaDiscoverer) GetEnumValueDistribution(ctx context.Context, schemaName, tableName, columnName string, opts EnumDistributionOptions) (*EnumDistributionResult, error) {
 	return &EnumDistributionResult{}, nil
 }
 
@@ -316,3 +320,14 @@ func TestFactoryNilConnectionManager(t *testing.T) {
 	require.True(t, ok)
 	assert.Nil(t, regFactory.connMgr, "connection manager can be nil for testing scenarios")
 }
+
+func TestIdentifierQuoter(t *testing.T) {
+	Register(DatasourceAdapterRegistration{
+		Info:            DatasourceAdapterInfo{Type: "test-bracket-dialect"},
+		QuoteIdentifier: func(name string) string { return "[" + name + "]" },
+	})
+
+	assert.Equal(t, "[Orders]", IdentifierQuoter("test-bracket-dialect")("Orders"))
+	assert.Equal(t, `"Weird""Name"`, IdentifierQuoter("test-unregistered-dialect")(`Weird"Name`),
+		"unregistered types fall back to ANSI double quotes")
+}
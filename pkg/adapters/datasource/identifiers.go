@@ -0,0 +1,131 @@
+package datasource
+
+import "strings"
+
+// Identifiers arrive in several forms: exactly as stored in the catalog ("OrderItems"),
+// quoted by a user or LLM (`"OrderItems"`, `[OrderItems]`), or case-folded (orderitems).
+// Catalog names are kept as the display name and are always quoted when generating
+// SQL; the normalized form is only used as a lookup key.
+
+// UnquoteIdentifier strips one level of SQL identifier quoting ("...", [...] or `...`)
+// and unescapes doubled quote characters. Unquoted names are returned trimmed but
+// otherwise unchanged.
+func UnquoteIdentifier(name string) string {
+	name = strings.TrimSpace(name)
+	if len(name) < 2 {
+		return name
+	}
+	var closing string
+	switch name[0] {
+	case '"':
+		closing = `"`
+	case '`':
+		closing = "`"
+	case '[':
+		closing = "]"
+	default:
+		return name
+	}
+	if !strings.HasSuffix(name, closing) {
+		return name
+	}
+	return strings.ReplaceAll(name[1:len(name)-1], closing+closing, closing)
+}
+
+// NormalizeIdentifier returns the case-folded, unquoted form of an identifier for use
+// as a lookup key. Two distinct catalog names may share a normalized form (e.g. a
+// quoted "Orders" next to orders); use IdentifierIndex to resolve those safely.
+func NormalizeIdentifier(name string) string {
+	return strings.ToLower(UnquoteIdentifier(name))
+}
+
+// SplitQualifiedIdentifier splits "schema.table" into its parts, honoring quotes so a
+// dot inside a quoted identifier ("my.schema"."Orders") is not treated as a separator.
+// The parts are returned unquoted. schema is empty for an unqualified name.
+func SplitQualifiedIdentifier(name string) (schema, table string) {
+	name = strings.TrimSpace(name)
+	var quote byte
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '`':
+			quote = c
+		case c == '[':
+			quote = ']'
+		case c == '.':
+			return UnquoteIdentifier(name[:i]), UnquoteIdentifier(name[i+1:])
+		}
+	}
+	return "", UnquoteIdentifier(name)
+}
+
+// QualifiedIdentifierKey returns the normalized "schema.table" lookup key.
+// An empty schema yields just the normalized table name.
+func QualifiedIdentifierKey(schemaName, tableName string) string {
+	if schemaName == "" {
+		return NormalizeIdentifier(tableName)
+	}
+	return NormalizeIdentifier(schemaName) + "." + NormalizeIdentifier(tableName)
+}
+
+// IdentifierIndex looks values up by identifier, preferring an exact (unquoted) match
+// and falling back to the normalized form. Normalized keys shared by more than one
+// value are treated as ambiguous and only resolve through an exact match, so
+// case-sensitive names such as "Orders" and "orders" never shadow each other.
+type IdentifierIndex[T any] struct {
+	exact      map[string]T
+	normalized map[string]T
+	ambiguous  map[string]struct{}
+}
+
+// NewIdentifierIndex creates an empty index.
+func NewIdentifierIndex[T any]() *IdentifierIndex[T] {
+	return &IdentifierIndex[T]{
+		exact:      make(map[string]T),
+		normalized: make(map[string]T),
+		ambiguous:  make(map[string]struct{}),
+	}
+}
+
+// Add indexes value under name, which should be the catalog (display) name, either
+// bare ("OrderItems") or schema-qualified ("public.OrderItems").
+func (idx *IdentifierIndex[T]) Add(name string, value T) {
+	exact := canonicalIdentifier(name)
+	_, reindexed := idx.exact[exact]
+	idx.exact[exact] = value
+
+	key := strings.ToLower(exact)
+	if _, isAmbiguous := idx.ambiguous[key]; isAmbiguous {
+		return
+	}
+	if _, exists := idx.normalized[key]; exists && !reindexed {
+		delete(idx.normalized, key)
+		idx.ambiguous[key] = struct{}{}
+		return
+	}
+	idx.normalized[key] = value
+}
+
+// Get resolves name, which may be quoted (`public."OrderItems"`) or differ in case
+// from the catalog name.
+func (idx *IdentifierIndex[T]) Get(name string) (T, bool) {
+	exact := canonicalIdentifier(name)
+	if v, ok := idx.exact[exact]; ok {
+		return v, true
+	}
+	v, ok := idx.normalized[strings.ToLower(exact)]
+	return v, ok
+}
+
+// canonicalIdentifier unquotes each part of a possibly schema-qualified name.
+func canonicalIdentifier(name string) string {
+	schema, table := SplitQualifiedIdentifier(name)
+	if schema == "" {
+		return table
+	}
+	return schema + "." + table
+}
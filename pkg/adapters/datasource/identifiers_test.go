@@ -0,0 +1,93 @@
+package datasource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnquoteIdentifier(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"OrderItems", "OrderItems"},
+		{`"OrderItems"`, "OrderItems"},
+		{`"Say ""hi"""`, `Say "hi"`},
+		{"[Order]]Items]", "Order]Items"},
+		{"`OrderItems`", "OrderItems"},
+		{`  "OrderItems"  `, "OrderItems"},
+		{`"unterminated`, `"unterminated`},
+		{`"`, `"`},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, UnquoteIdentifier(tt.in), "input %q", tt.in)
+	}
+}
+
+func TestNormalizeIdentifier(t *testing.T) {
+	assert.Equal(t, "orderitems", NormalizeIdentifier(`"OrderItems"`))
+	assert.Equal(t, "orderitems", NormalizeIdentifier("OrderItems"))
+	assert.Equal(t, "public.orderitems", QualifiedIdentifierKey(`"Public"`, "[OrderItems]"))
+	assert.Equal(t, "orderitems", QualifiedIdentifierKey("", "OrderItems"))
+}
+
+func TestSplitQualifiedIdentifier(t *testing.T) {
+	schema, table := SplitQualifiedIdentifier(`public."OrderItems"`)
+	assert.Equal(t, "public", schema)
+	assert.Equal(t, "OrderItems", table)
+
+	schema, table = SplitQualifiedIdentifier(`"my.schema"."Order.Items"`)
+	assert.Equal(t, "my.schema", schema)
+	assert.Equal(t, "Order.Items", table)
+
+	schema, table = SplitQualifiedIdentifier("[dbo].[OrderItems]")
+	assert.Equal(t, "dbo", schema)
+	assert.Equal(t, "OrderItems", table)
+
+	schema, table = SplitQualifiedIdentifier(`"OrderItems"`)
+	assert.Equal(t, "", schema)
+	assert.Equal(t, "OrderItems", table)
+}
+
+func TestIdentifierIndex_ResolvesQuotedAndCaseFoldedNames(t *testing.T) {
+	idx := NewIdentifierIndex[int]()
+	idx.Add("public.OrderItems", 1)
+	idx.Add("OrderItems", 1)
+
+	for _, name := range []string{"OrderItems", `"OrderItems"`, "orderitems", `public."OrderItems"`, "PUBLIC.ORDERITEMS"} {
+		got, ok := idx.Get(name)
+		assert.True(t, ok, "expected %q to resolve", name)
+		assert.Equal(t, 1, got, "name %q", name)
+	}
+
+	_, ok := idx.Get("order_items")
+	assert.False(t, ok)
+}
+
+func TestIdentifierIndex_CaseSensitiveNamesDoNotShadowEachOther(t *testing.T) {
+	idx := NewIdentifierIndex[string]()
+	idx.Add("Orders", "quoted")
+	idx.Add("orders", "folded")
+
+	got, ok := idx.Get(`"Orders"`)
+	assert.True(t, ok)
+	assert.Equal(t, "quoted", got)
+
+	got, ok = idx.Get("orders")
+	assert.True(t, ok)
+	assert.Equal(t, "folded", got)
+
+	// Only the normalized form matches, and it is shared: refuse to guess
+	_, ok = idx.Get("ORDERS")
+	assert.False(t, ok)
+}
+
+func TestIdentifierIndex_ReAddingSameNameIsNotAmbiguous(t *testing.T) {
+	idx := NewIdentifierIndex[string]()
+	idx.Add("Orders", "first")
+	idx.Add("Orders", "second")
+
+	got, ok := idx.Get("orders")
+	assert.True(t, ok)
+	assert.Equal(t, "second", got)
+}
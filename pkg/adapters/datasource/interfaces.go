@@ -93,17 +93,43 @@ type SchemaDiscoverer interface {
 	// Used during the scanning phase to collect sample values for enum detection.
 	GetDistinctValues(ctx context.Context, schemaName, tableName, columnName string, limit int) ([]string, error)
 
+	// SampleDistinctValues returns distinct non-null values from a column selected by
+	// opts.Strategy (alphabetical, random, most frequent, or most recent), up to opts.Limit.
+	// Values are returned as strings in strategy order.
+	SampleDistinctValues(ctx context.Context, schemaName, tableName, columnName string, opts DistinctValueOptions) ([]string, error)
+
 	// GetEnumValueDistribution analyzes value distribution for an enum column.
 	// Returns count and percentage for each distinct value, sorted by count descending.
-	// If completionTimestampCol is non-empty, also computes completion rate per value
-	// to identify initial vs terminal states in state machine columns.
+	// If opts.CompletionTimestampCol is set, also computes completion rate per value
+	// to identify initial vs terminal states in state machine columns. If opts requests
+	// activity, also counts each value's rows written since opts.ActiveSince.
 	GetEnumValueDistribution(ctx context.Context, schemaName, tableName, columnName string,
-		completionTimestampCol string, limit int) (*EnumDistributionResult, error)
+		opts EnumDistributionOptions) (*EnumDistributionResult, error)
 
 	// Close releases the database connection.
 	Close() error
 }
 
+// DistinctCountEstimator is implemented by schema discoverers that can estimate
+// distinct counts from the database's planner statistics. Exact COUNT(DISTINCT)
+// sorts or hashes the whole column, which dominates discovery time on large tables.
+type DistinctCountEstimator interface {
+	// EstimateColumnStats returns the same statistics as AnalyzeColumnStats, except
+	// that DistinctCount is taken from planner statistics and DistinctEstimated is
+	// set. Columns without planner statistics fall back to exact counts.
+	EstimateColumnStats(ctx context.Context, schemaName, tableName string, columnNames []string) ([]ColumnStats, error)
+}
+
+// ArrayJoinAnalyzer is implemented by schema discoverers for databases with array
+// columns, where a column such as tag_ids bigint[] can reference tags.id element by
+// element. Use ArrayJoinAnalyzerFor to find it behind a tracing wrapper.
+type ArrayJoinAnalyzer interface {
+	// AnalyzeArrayJoin performs AnalyzeJoin over the unnested elements of sourceColumn.
+	// NULL arrays, empty arrays and NULL elements contribute no values.
+	AnalyzeArrayJoin(ctx context.Context, sourceSchema, sourceTable, sourceColumn,
+		targetSchema, targetTable, targetColumn string) (*JoinAnalysis, error)
+}
+
 // MaxQueryLimit is the hard cap on rows returned by Query methods.
 // This protects against unbounded queries that could crash the server.
 const MaxQueryLimit = 1000
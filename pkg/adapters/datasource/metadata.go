@@ -1,5 +1,10 @@
 package datasource
 
+import (
+	"strings"
+	"time"
+)
+
 // TableMetadata represents a discovered database table.
 type TableMetadata struct {
 	SchemaName string
@@ -16,7 +21,10 @@ type ColumnMetadata struct {
 	IsUnique        bool
 	OrdinalPosition int
 	DefaultValue    *string
-	EnumValues      []string // Postgres enum type values from pg_enum (nil for non-enum columns)
+	EnumValues      []string // Postgres enum type values from pg_enum, or the values an IN-list check constraint allows
+	// CheckConstraints are the definitions of the check constraints covering the column,
+	// e.g. "CHECK (amount >= 0)". A multi-column constraint is listed on each column.
+	CheckConstraints []string
 }
 
 // ForeignKeyMetadata represents a discovered foreign key constraint.
@@ -32,12 +40,13 @@ type ForeignKeyMetadata struct {
 
 // ColumnStats contains statistics for a column.
 type ColumnStats struct {
-	ColumnName    string
-	RowCount      int64
-	NonNullCount  int64
-	DistinctCount int64
-	MinLength     *int64 // For text columns: minimum string length (nil for non-text)
-	MaxLength     *int64 // For text columns: maximum string length (nil for non-text)
+	ColumnName        string
+	RowCount          int64
+	NonNullCount      int64
+	DistinctCount     int64
+	DistinctEstimated bool   // DistinctCount came from planner statistics, not COUNT(DISTINCT)
+	MinLength         *int64 // For text columns: minimum string length (nil for non-text)
+	MaxLength         *int64 // For text columns: maximum string length (nil for non-text)
 }
 
 // ValueOverlapResult contains results from value overlap analysis.
@@ -48,6 +57,33 @@ type ValueOverlapResult struct {
 	MatchRate      float64
 }
 
+// DefaultMinOverlapSamples is the fewest distinct source values a value overlap must
+// be computed from before its match rate is trusted. A handful of small integers
+// matches almost any auto-increment key.
+const DefaultMinOverlapSamples = 10
+
+// Inconclusive reports whether the overlap was computed from fewer than minSamples
+// distinct source values, too few to confirm or rule out a relationship.
+func (r *ValueOverlapResult) Inconclusive(minSamples int) bool {
+	return r.SourceDistinct < int64(minSamples)
+}
+
+// MinOverlapSamplesFromMap reads the optional "min_overlap_samples" entry from a
+// datasource config map, defaulting to DefaultMinOverlapSamples. Zero disables the guard.
+func MinOverlapSamplesFromMap(config map[string]any) int {
+	switch v := config["min_overlap_samples"].(type) {
+	case float64: // JSON numbers decode as float64
+		if v >= 0 {
+			return int(v)
+		}
+	case int:
+		if v >= 0 {
+			return v
+		}
+	}
+	return DefaultMinOverlapSamples
+}
+
 // JoinAnalysis contains results from join analysis.
 type JoinAnalysis struct {
 	JoinCount          int64
@@ -58,6 +94,90 @@ type JoinAnalysis struct {
 	MaxSourceValue     *int64 // Maximum value in source column (for semantic validation)
 }
 
+// ArrayElementType returns the element type of an array data type such as "int8[]",
+// and false for scalar types.
+func ArrayElementType(dataType string) (string, bool) {
+	elem, ok := strings.CutSuffix(strings.TrimSpace(dataType), "[]")
+	if !ok || elem == "" {
+		return "", false
+	}
+	return elem, true
+}
+
+// DistinctValueStrategy controls which distinct values SampleDistinctValues returns.
+type DistinctValueStrategy string
+
+const (
+	// DistinctValuesAlphabetical returns the first values in sort order (GetDistinctValues behavior).
+	DistinctValuesAlphabetical DistinctValueStrategy = "alphabetical"
+	// DistinctValuesRandom returns a random spread of values; best for free-text columns.
+	DistinctValuesRandom DistinctValueStrategy = "random"
+	// DistinctValuesMostFrequent returns the most common values; best for enum-like columns.
+	DistinctValuesMostFrequent DistinctValueStrategy = "most_frequent"
+	// DistinctValuesMostRecent returns values from the most recently written rows.
+	// Requires DistinctValueOptions.RecencyColumn.
+	DistinctValuesMostRecent DistinctValueStrategy = "most_recent"
+)
+
+// DefaultDistinctValueLimit is used when DistinctValueOptions.Limit is not positive.
+const DefaultDistinctValueLimit = 50
+
+// DistinctValueOptions configures distinct value sampling for a single column.
+type DistinctValueOptions struct {
+	Strategy      DistinctValueStrategy // Empty means DistinctValuesAlphabetical
+	Limit         int                   // Max values returned; <= 0 means DefaultDistinctValueLimit
+	RecencyColumn string                // Timestamp/sequence column ordering rows for DistinctValuesMostRecent
+}
+
+// EffectiveLimit returns Limit, or DefaultDistinctValueLimit when Limit is not positive.
+func (o DistinctValueOptions) EffectiveLimit() int {
+	if o.Limit <= 0 {
+		return DefaultDistinctValueLimit
+	}
+	return o.Limit
+}
+
+// RecentSamplingFromMap reads the optional "sample_recent_rows" entry from a datasource
+// config map. When true, sample values come from a table's most recently written rows
+// (DistinctValuesMostRecent) whenever the table has a recency timestamp, so samples
+// reflect current usage rather than historical data.
+func RecentSamplingFromMap(config map[string]any) bool {
+	enabled, _ := config["sample_recent_rows"].(bool)
+	return enabled
+}
+
+// SampleLimitsFromMap reads the optional "sample_limits" entry from a datasource config
+// map: per-column caps on sampled distinct values that override the extraction-wide
+// limit, keyed by "table.column" or "schema.table.column". Entries that are not
+// positive numbers are ignored.
+func SampleLimitsFromMap(config map[string]any) map[string]int {
+	raw, _ := config["sample_limits"].(map[string]any)
+	limits := make(map[string]int, len(raw))
+	for key, value := range raw {
+		var limit int
+		switch v := value.(type) {
+		case float64:
+			limit = int(v)
+		case int:
+			limit = v
+		}
+		if limit > 0 {
+			limits[strings.ToLower(key)] = limit
+		}
+	}
+	return limits
+}
+
+// SampleLimitFor returns the cap limits sets for a column, preferring a
+// schema-qualified key, or 0 when it sets none. Keys match case-insensitively.
+func SampleLimitFor(limits map[string]int, schemaName, tableName, columnName string) int {
+	qualified := strings.ToLower(tableName + "." + columnName)
+	if limit, ok := limits[strings.ToLower(schemaName)+"."+qualified]; ok {
+		return limit
+	}
+	return limits[qualified]
+}
+
 // EnumValueDistribution contains distribution statistics for a single enum value.
 // Used for inferring state machine semantics (initial, terminal, error states).
 type EnumValueDistribution struct {
@@ -76,6 +196,9 @@ type EnumValueDistribution struct {
 	IsLikelyInitialState  bool    `json:"is_likely_initial_state,omitempty"`  // ~0% completion, highest count among low-completion states
 	IsLikelyTerminalState bool    `json:"is_likely_terminal_state,omitempty"` // ~100% completion rate
 	IsLikelyErrorState    bool    `json:"is_likely_error_state,omitempty"`    // Low count relative to others
+
+	// Activity (populated when EnumDistributionOptions.ActivityTimestampCol is set)
+	RecentCount int64 `json:"recent_count,omitempty"` // Records with this value written at or after ActiveSince
 }
 
 // EnumDistributionResult contains complete distribution analysis for an enum column.
@@ -87,4 +210,26 @@ type EnumDistributionResult struct {
 	Distributions          []EnumValueDistribution `json:"distributions"`                      // Sorted by count descending
 	CompletionTimestampCol string                  `json:"completion_timestamp_col,omitempty"` // Name of timestamp column used for terminal state detection
 	HasStateSemantics      bool                    `json:"has_state_semantics"`                // True if initial/terminal states were identified
+	ActivityTimestampCol   string                  `json:"activity_timestamp_col,omitempty"`   // Name of timestamp column used to count recent rows
+	ActiveSince            *time.Time              `json:"active_since,omitempty"`             // Start of the window RecentCount covers
+}
+
+// EnumDistributionOptions configures GetEnumValueDistribution.
+type EnumDistributionOptions struct {
+	Limit int // Max distinct values returned
+
+	// CompletionTimestampCol, when set, adds a per-value completion rate used to
+	// identify initial and terminal states in state machine columns.
+	CompletionTimestampCol string
+
+	// ActivityTimestampCol and ActiveSince, when both set, add a per-value count of rows
+	// whose timestamp is at or after ActiveSince. Values with no recent rows are legacy
+	// values no longer written by the application.
+	ActivityTimestampCol string
+	ActiveSince          time.Time
+}
+
+// CountsActivity reports whether recent-row counts were requested.
+func (o EnumDistributionOptions) CountsActivity() bool {
+	return o.ActivityTimestampCol != "" && !o.ActiveSince.IsZero()
 }
@@ -2,6 +2,8 @@ package mssql
 
 import (
 	"fmt"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/adapters/datasource"
 )
 
 // Config contains SQL Server-specific connection options.
@@ -30,6 +32,9 @@ type Config struct {
 	Encrypt                bool
 	TrustServerCertificate bool
 	ConnectionTimeout      int
+
+	// SchemaFilter overrides the default system schema/table exclusions during discovery
+	SchemaFilter datasource.SchemaFilter
 }
 
 // DefaultPort returns the default SQL Server port.
@@ -90,6 +95,8 @@ func FromMap(config map[string]any) (*Config, error) {
 		cfg.ConnectionTimeout = timeout
 	}
 
+	cfg.SchemaFilter = datasource.SchemaFilterFromMap(config)
+
 	// Auto-detect auth method or use explicitly provided
 	if authMethod, ok := config["auth_method"].(string); ok && authMethod != "" {
 		cfg.AuthMethod = authMethod
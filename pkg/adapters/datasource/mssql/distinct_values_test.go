@@ -0,0 +1,32 @@
+package mssql
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/adapters/datasource"
+)
+
+func TestBuildSampleDistinctValuesQuery_MostRecentOrdersByRecencyColumnDescending(t *testing.T) {
+	query, err := buildSampleDistinctValuesQuery("dbo", "orders", "status", datasource.DistinctValueOptions{
+		Strategy:      datasource.DistinctValuesMostRecent,
+		RecencyColumn: "updated_at",
+		Limit:         25,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"TOP (25)", "GROUP BY [status]", "ORDER BY MAX([updated_at]) DESC, 1"} {
+		if !strings.Contains(query, want) {
+			t.Errorf("query missing %q:\n%s", want, query)
+		}
+	}
+}
+
+func TestBuildSampleDistinctValuesQuery_MostRecentRequiresRecencyColumn(t *testing.T) {
+	if _, err := buildSampleDistinctValuesQuery("dbo", "orders", "status", datasource.DistinctValueOptions{
+		Strategy: datasource.DistinctValuesMostRecent,
+	}); err == nil {
+		t.Error("expected error for most_recent without a recency column")
+	}
+}
@@ -3,6 +3,8 @@ package mssql
 import (
 	"fmt"
 	"strings"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/adapters/datasource"
 )
 
 // parseSchemaTable parses a table name that may include schema.
@@ -37,6 +39,13 @@ func quoteName(identifier string) string {
 	return fmt.Sprintf("[%s]", escaped)
 }
 
+// quoteIdent quotes an identifier from schema metadata or an LLM response. Names that
+// arrive already quoted ([OrderItems] or "OrderItems") are unquoted first so they are
+// not double-quoted.
+func quoteIdent(identifier string) string {
+	return quoteName(datasource.UnquoteIdentifier(identifier))
+}
+
 // quoteNameForSQL returns a QUOTENAME() function call for use in SQL statements.
 // This is safer than building the quote manually as it uses SQL Server's built-in function.
 func quoteNameForSQL(identifier string) string {
@@ -47,7 +56,7 @@ func quoteNameForSQL(identifier string) string {
 
 // buildFullyQualifiedName builds a fully qualified table name: [schema].[table]
 func buildFullyQualifiedName(schema, table string) string {
-	return fmt.Sprintf("%s.%s", quoteName(schema), quoteName(table))
+	return fmt.Sprintf("%s.%s", quoteIdent(schema), quoteIdent(table))
 }
 
 // mapSQLServerType maps SQL Server type names to standard type names.
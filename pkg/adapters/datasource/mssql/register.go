@@ -33,6 +33,7 @@ func init() {
 			// Pass nil logger - a no-op logger will be used internally
 			return NewSchemaDiscoverer(ctx, cfg, connMgr, projectID, datasourceID, userID, nil)
 		},
+		QuoteIdentifier: quoteName,
 		QueryExecutorFactory: func(ctx context.Context, config map[string]any, connMgr *datasource.ConnectionManager, projectID, datasourceID uuid.UUID, userID string) (datasource.QueryExecutor, error) {
 			cfg, err := FromMap(config)
 			if err != nil {
@@ -51,7 +51,9 @@ func NewSchemaDiscoverer(ctx context.Context, cfg *Config, connMgr *datasource.C
 	}, nil
 }
 
-// DiscoverTables returns all user tables (excludes system schemas).
+// DiscoverTables returns all user tables. System schemas are excluded by default, and
+// ekaya's own engine_* tables when the datasource sets exclude_engine_tables; see
+// datasource.SchemaFilter for overrides.
 func (s *SchemaDiscoverer) DiscoverTables(ctx context.Context) ([]datasource.TableMetadata, error) {
 	query := `
 	SET NOCOUNT ON;
@@ -80,6 +82,9 @@ func (s *SchemaDiscoverer) DiscoverTables(ctx context.Context) ([]datasource.Tab
 		if err != nil {
 			return nil, fmt.Errorf("scan table row: %w", err)
 		}
+		if !s.config.SchemaFilter.Includes(table.SchemaName, table.TableName) {
+			continue
+		}
 		tables = append(tables, table)
 	}
 
@@ -243,7 +248,7 @@ func (s *SchemaDiscoverer) AnalyzeColumnStats(ctx context.Context, schemaName, t
 	var stats []datasource.ColumnStats
 	var retriedColumns []string
 	for _, colName := range columnNames {
-		quotedCol := quoteName(colName)
+		quotedCol := quoteIdent(colName)
 
 		var stat datasource.ColumnStats
 		stat.ColumnName = colName
@@ -326,7 +331,7 @@ func (s *SchemaDiscoverer) AnalyzeColumnStats(ctx context.Context, schemaName, t
 // analyzeColumnStatsSimplified runs a simplified stats query without length calculation.
 // Used as a fallback when the main query fails.
 func (s *SchemaDiscoverer) analyzeColumnStatsSimplified(ctx context.Context, schemaName, tableName, colName, fullyQualifiedTable string, originalErr error) datasource.ColumnStats {
-	quotedCol := quoteName(colName)
+	quotedCol := quoteIdent(colName)
 
 	stat := datasource.ColumnStats{
 		ColumnName: colName,
@@ -393,12 +398,12 @@ func (s *SchemaDiscoverer) CheckValueOverlap(ctx context.Context,
 	    (SELECT COUNT(*) FROM target_sample) AS target_distinct,
 	    (SELECT matched FROM overlap) AS matched_count
 	`,
-		sampleLimit, quoteName(sourceColumn),
+		sampleLimit, quoteIdent(sourceColumn),
 		buildFullyQualifiedName(sourceSchema, sourceTable),
-		quoteName(sourceColumn),
-		sampleLimit, quoteName(targetColumn),
+		quoteIdent(sourceColumn),
+		sampleLimit, quoteIdent(targetColumn),
 		buildFullyQualifiedName(targetSchema, targetTable),
-		quoteName(targetColumn),
+		quoteIdent(targetColumn),
 	)
 
 	var result datasource.ValueOverlapResult
@@ -461,23 +466,23 @@ func (s *SchemaDiscoverer) AnalyzeJoin(ctx context.Context,
 	    (SELECT COUNT(DISTINCT tgt_val) FROM reverse_join WHERE src_val IS NULL) AS reverse_orphan_count
 	`,
 		// join_result CTE
-		quoteName(sourceColumn),
-		quoteName(targetColumn),
+		quoteIdent(sourceColumn),
+		quoteIdent(targetColumn),
 		buildFullyQualifiedName(sourceSchema, sourceTable),
 		buildFullyQualifiedName(targetSchema, targetTable),
-		quoteName(sourceColumn), quoteName(targetColumn),
-		quoteName(sourceColumn),
+		quoteIdent(sourceColumn), quoteIdent(targetColumn),
+		quoteIdent(sourceColumn),
 		// reverse_join CTE
-		quoteName(targetColumn),
-		quoteName(sourceColumn),
+		quoteIdent(targetColumn),
+		quoteIdent(sourceColumn),
 		buildFullyQualifiedName(targetSchema, targetTable),
 		buildFullyQualifiedName(sourceSchema, sourceTable),
-		quoteName(targetColumn), quoteName(sourceColumn),
-		quoteName(targetColumn),
+		quoteIdent(targetColumn), quoteIdent(sourceColumn),
+		quoteIdent(targetColumn),
 		// SELECT
-		quoteName(targetColumn),
+		quoteIdent(targetColumn),
 		buildFullyQualifiedName(targetSchema, targetTable),
-		quoteName(targetColumn),
+		quoteIdent(targetColumn),
 	)
 
 	var result datasource.JoinAnalysis
@@ -507,9 +512,9 @@ func (s *SchemaDiscoverer) GetDistinctValues(ctx context.Context, schemaName, ta
 	ORDER BY 1
 	`,
 		limit,
-		quoteName(columnName),
+		quoteIdent(columnName),
 		buildFullyQualifiedName(schemaName, tableName),
-		quoteName(columnName),
+		quoteIdent(columnName),
 	)
 
 	rows, err := s.db.QueryContext(ctx, query)
@@ -534,12 +539,100 @@ func (s *SchemaDiscoverer) GetDistinctValues(ctx context.Context, schemaName, ta
 	return values, nil
 }
 
+// SampleDistinctValues returns distinct non-null values from a column selected by opts.Strategy.
+func (s *SchemaDiscoverer) SampleDistinctValues(ctx context.Context, schemaName, tableName, columnName string, opts datasource.DistinctValueOptions) ([]string, error) {
+	query, err := buildSampleDistinctValuesQuery(schemaName, tableName, columnName, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("sample distinct values for %s.%s.%s: %w", schemaName, tableName, columnName, err)
+	}
+	defer rows.Close()
+
+	var values []string
+	for rows.Next() {
+		var val string
+		if err := rows.Scan(&val); err != nil {
+			return nil, fmt.Errorf("scan distinct value: %w", err)
+		}
+		values = append(values, val)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate distinct values: %w", err)
+	}
+
+	return values, nil
+}
+
+// buildSampleDistinctValuesQuery builds the sampling query for a strategy.
+func buildSampleDistinctValuesQuery(schemaName, tableName, columnName string, opts datasource.DistinctValueOptions) (string, error) {
+	limit := opts.EffectiveLimit()
+	tableRef := buildFullyQualifiedName(schemaName, tableName)
+	quotedCol := quoteIdent(columnName)
+
+	switch opts.Strategy {
+	case "", datasource.DistinctValuesAlphabetical:
+		return fmt.Sprintf(`
+	SET NOCOUNT ON;
+	SELECT DISTINCT TOP (%d) CAST(%s AS NVARCHAR(MAX)) AS val
+	FROM %s WITH (NOLOCK)
+	WHERE %s IS NOT NULL
+	ORDER BY 1
+	`, limit, quotedCol, tableRef, quotedCol), nil
+
+	case datasource.DistinctValuesRandom:
+		return fmt.Sprintf(`
+	SET NOCOUNT ON;
+	SELECT TOP (%d) val
+	FROM (
+		SELECT DISTINCT CAST(%s AS NVARCHAR(MAX)) AS val
+		FROM %s WITH (NOLOCK)
+		WHERE %s IS NOT NULL
+	) AS distinct_values
+	ORDER BY NEWID()
+	`, limit, quotedCol, tableRef, quotedCol), nil
+
+	case datasource.DistinctValuesMostFrequent:
+		return fmt.Sprintf(`
+	SET NOCOUNT ON;
+	SELECT TOP (%d) CAST(%s AS NVARCHAR(MAX)) AS val
+	FROM %s WITH (NOLOCK)
+	WHERE %s IS NOT NULL
+	GROUP BY %s
+	ORDER BY COUNT(*) DESC, 1
+	`, limit, quotedCol, tableRef, quotedCol, quotedCol), nil
+
+	case datasource.DistinctValuesMostRecent:
+		if opts.RecencyColumn == "" {
+			return "", fmt.Errorf("sample distinct values for %s.%s.%s: %s strategy requires a recency column",
+				schemaName, tableName, columnName, opts.Strategy)
+		}
+		return fmt.Sprintf(`
+	SET NOCOUNT ON;
+	SELECT TOP (%d) CAST(%s AS NVARCHAR(MAX)) AS val
+	FROM %s WITH (NOLOCK)
+	WHERE %s IS NOT NULL
+	GROUP BY %s
+	ORDER BY MAX(%s) DESC, 1
+	`, limit, quotedCol, tableRef, quotedCol, quotedCol, quoteIdent(opts.RecencyColumn)), nil
+
+	default:
+		return "", fmt.Errorf("sample distinct values for %s.%s.%s: unknown strategy %q",
+			schemaName, tableName, columnName, opts.Strategy)
+	}
+}
+
 // GetEnumValueDistribution analyzes value distribution for an enum column.
 // Returns count and percentage for each distinct value, sorted by count descending.
-// If completionTimestampCol is provided, also computes completion rate per value.
-func (s *SchemaDiscoverer) GetEnumValueDistribution(ctx context.Context, schemaName, tableName, columnName string, completionTimestampCol string, limit int) (*datasource.EnumDistributionResult, error) {
+// If opts.CompletionTimestampCol is provided, also computes completion rate per value.
+// If opts requests activity, also counts each value's rows written since opts.ActiveSince.
+func (s *SchemaDiscoverer) GetEnumValueDistribution(ctx context.Context, schemaName, tableName, columnName string, opts datasource.EnumDistributionOptions) (*datasource.EnumDistributionResult, error) {
 	quotedTable := buildFullyQualifiedName(schemaName, tableName)
-	quotedCol := quoteName(columnName)
+	quotedCol := quoteIdent(columnName)
 
 	// Get total row count and null count first
 	totalQuery := fmt.Sprintf(`
@@ -556,47 +649,23 @@ func (s *SchemaDiscoverer) GetEnumValueDistribution(ctx context.Context, schemaN
 	}
 
 	result := &datasource.EnumDistributionResult{
-		ColumnName:    columnName,
-		TotalRows:     totalRows,
-		DistinctCount: distinctCount,
-		NullCount:     nullCount,
-		Distributions: []datasource.EnumValueDistribution{},
+		ColumnName:             columnName,
+		TotalRows:              totalRows,
+		DistinctCount:          distinctCount,
+		NullCount:              nullCount,
+		Distributions:          []datasource.EnumValueDistribution{},
+		CompletionTimestampCol: opts.CompletionTimestampCol,
 	}
 
-	// Build the distribution query - with or without completion timestamp analysis
-	var query string
-	if completionTimestampCol != "" {
-		quotedCompletionCol := quoteName(completionTimestampCol)
-		result.CompletionTimestampCol = completionTimestampCol
-
-		query = fmt.Sprintf(`
-			SET NOCOUNT ON;
-			SELECT TOP (%d) CAST(%s AS NVARCHAR(MAX)) as value,
-			       COUNT(*) as count,
-			       ROUND(100.0 * COUNT(*) / NULLIF(CAST(%d AS FLOAT), 0), 2) as percentage,
-			       SUM(CASE WHEN %s IS NOT NULL THEN 1 ELSE 0 END) as has_completion_at,
-			       ROUND(100.0 * SUM(CASE WHEN %s IS NOT NULL THEN 1 ELSE 0 END) / NULLIF(CAST(COUNT(*) AS FLOAT), 0), 2) as completion_rate
-			FROM %s WITH (NOLOCK)
-			WHERE %s IS NOT NULL
-			GROUP BY %s
-			ORDER BY count DESC
-		`, limit, quotedCol, totalRows, quotedCompletionCol, quotedCompletionCol, quotedTable, quotedCol, quotedCol)
-	} else {
-		query = fmt.Sprintf(`
-			SET NOCOUNT ON;
-			SELECT TOP (%d) CAST(%s AS NVARCHAR(MAX)) as value,
-			       COUNT(*) as count,
-			       ROUND(100.0 * COUNT(*) / NULLIF(CAST(%d AS FLOAT), 0), 2) as percentage,
-			       0 as has_completion_at,
-			       0.0 as completion_rate
-			FROM %s WITH (NOLOCK)
-			WHERE %s IS NOT NULL
-			GROUP BY %s
-			ORDER BY count DESC
-		`, limit, quotedCol, totalRows, quotedTable, quotedCol, quotedCol)
+	var args []any
+	if opts.CountsActivity() {
+		result.ActivityTimestampCol = opts.ActivityTimestampCol
+		since := opts.ActiveSince
+		result.ActiveSince = &since
+		args = append(args, sql.Named("since", since))
 	}
 
-	rows, err := s.db.QueryContext(ctx, query)
+	rows, err := s.db.QueryContext(ctx, buildEnumDistributionQuery(schemaName, tableName, columnName, totalRows, opts), args...)
 	if err != nil {
 		return nil, fmt.Errorf("get enum distribution for %s.%s.%s: %w", schemaName, tableName, columnName, err)
 	}
@@ -605,7 +674,7 @@ func (s *SchemaDiscoverer) GetEnumValueDistribution(ctx context.Context, schemaN
 	for rows.Next() {
 		var dist datasource.EnumValueDistribution
 		var percentage, completionRate float64
-		if err := rows.Scan(&dist.Value, &dist.Count, &percentage, &dist.HasCompletionAt, &completionRate); err != nil {
+		if err := rows.Scan(&dist.Value, &dist.Count, &percentage, &dist.HasCompletionAt, &completionRate, &dist.RecentCount); err != nil {
 			return nil, fmt.Errorf("scan distribution row: %w", err)
 		}
 		dist.Percentage = percentage
@@ -619,13 +688,48 @@ func (s *SchemaDiscoverer) GetEnumValueDistribution(ctx context.Context, schemaN
 	}
 
 	// Infer state semantics if completion timestamp was provided and we have data
-	if completionTimestampCol != "" && len(result.Distributions) > 0 {
+	if opts.CompletionTimestampCol != "" && len(result.Distributions) > 0 {
 		result.HasStateSemantics = inferStateSemantics(result.Distributions)
 	}
 
 	return result, nil
 }
 
+// buildEnumDistributionQuery returns the per-value distribution query. When opts
+// requests activity, the window start is bound as the @since named parameter.
+// Completion and activity columns are zero when not requested so the scan is fixed.
+func buildEnumDistributionQuery(schemaName, tableName, columnName string, totalRows int64, opts datasource.EnumDistributionOptions) string {
+	quotedTable := buildFullyQualifiedName(schemaName, tableName)
+	quotedCol := quoteIdent(columnName)
+
+	completionExprs := `0 as has_completion_at,
+			       0.0 as completion_rate`
+	if opts.CompletionTimestampCol != "" {
+		quotedCompletionCol := quoteIdent(opts.CompletionTimestampCol)
+		completionExprs = fmt.Sprintf(`SUM(CASE WHEN %s IS NOT NULL THEN 1 ELSE 0 END) as has_completion_at,
+			       ROUND(100.0 * SUM(CASE WHEN %s IS NOT NULL THEN 1 ELSE 0 END) / NULLIF(CAST(COUNT(*) AS FLOAT), 0), 2) as completion_rate`,
+			quotedCompletionCol, quotedCompletionCol)
+	}
+
+	recentExpr := "0 as recent_count"
+	if opts.CountsActivity() {
+		recentExpr = fmt.Sprintf("SUM(CASE WHEN %s >= @since THEN 1 ELSE 0 END) as recent_count", quoteIdent(opts.ActivityTimestampCol))
+	}
+
+	return fmt.Sprintf(`
+			SET NOCOUNT ON;
+			SELECT TOP (%d) CAST(%s AS NVARCHAR(MAX)) as value,
+			       COUNT(*) as count,
+			       ROUND(100.0 * COUNT(*) / NULLIF(CAST(%d AS FLOAT), 0), 2) as percentage,
+			       %s,
+			       %s
+			FROM %s WITH (NOLOCK)
+			WHERE %s IS NOT NULL
+			GROUP BY %s
+			ORDER BY count DESC
+		`, opts.Limit, quotedCol, totalRows, completionExprs, recentExpr, quotedTable, quotedCol, quotedCol)
+}
+
 // inferStateSemantics analyzes distribution data to classify values as initial/terminal/error states.
 // Sets the Is* flags on each EnumValueDistribution.
 func inferStateSemantics(distributions []datasource.EnumValueDistribution) bool {
@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// reAnyArray matches the form postgres stores an IN-list check as:
+// ((status)::text = ANY ((ARRAY['a'::character varying, 'b'::character varying])::text[]))
+var reAnyArray = regexp.MustCompile(`(?is)^\(*\s*\(?\s*"?([a-z_][a-z0-9_$]*)"?\s*\)?(?:::[a-z ]+(?:\[\])?)?\s*=\s*any\s*\(\s*\(?\s*array\s*\[(.*?)\]\s*\)?(?:::[a-z ]+\[\])?\s*\)\s*\)*$`)
+
+// reQuotedLiteral matches one single-quoted SQL literal; a doubled quote inside it
+// is an escaped quote.
+var reQuotedLiteral = regexp.MustCompile(`'((?:[^']|'')*)'`)
+
+// checkConstraint is one check constraint and the columns it references.
+type checkConstraint struct {
+	definition string
+	columns    []string
+}
+
+// discoverCheckConstraints returns the check constraints of a table keyed by column
+// name. A constraint referencing several columns is listed under each of them.
+func (d *SchemaDiscoverer) discoverCheckConstraints(ctx context.Context, schemaName, tableName string) (map[string][]checkConstraint, error) {
+	const query = `
+		SELECT pg_get_constraintdef(con.oid), array_agg(a.attname ORDER BY a.attnum)
+		FROM pg_constraint con
+		JOIN pg_class t ON t.oid = con.conrelid
+		JOIN pg_namespace n ON n.oid = t.relnamespace
+		JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = ANY(con.conkey)
+		WHERE con.contype = 'c'
+		  AND n.nspname = $1
+		  AND t.relname = $2
+		GROUP BY con.oid, con.conname
+		ORDER BY con.conname
+	`
+
+	rows, err := d.pool.Query(ctx, query, schemaName, tableName)
+	if err != nil {
+		return nil, fmt.Errorf("query check constraints: %w", err)
+	}
+	defer rows.Close()
+
+	byColumn := make(map[string][]checkConstraint)
+	for rows.Next() {
+		var c checkConstraint
+		if err := rows.Scan(&c.definition, &c.columns); err != nil {
+			return nil, fmt.Errorf("scan check constraint: %w", err)
+		}
+		for _, col := range c.columns {
+			byColumn[col] = append(byColumn[col], c)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate check constraints: %w", err)
+	}
+	return byColumn, nil
+}
+
+// CheckConstraintAllowedValues returns the values an IN-list check constraint allows
+// for column, e.g. 'active' and 'inactive' for CHECK (status IN ('active', 'inactive')),
+// which postgres stores as CHECK (((status)::text = ANY ((ARRAY['active'::character
+// varying, 'inactive'::character varying])::text[]))). Returns false for any other
+// kind of check, including one that combines the list with other conditions.
+func CheckConstraintAllowedValues(definition, column string) ([]string, bool) {
+	def := strings.TrimSpace(definition)
+	if len(def) < len("CHECK") || !strings.EqualFold(def[:len("CHECK")], "CHECK") {
+		return nil, false
+	}
+	def = strings.TrimSpace(def[len("CHECK"):])
+	def = strings.TrimSuffix(def, " NOT VALID")
+
+	m := reAnyArray.FindStringSubmatch(def)
+	if m == nil || !strings.EqualFold(m[1], column) {
+		return nil, false
+	}
+
+	var values []string
+	for _, lit := range reQuotedLiteral.FindAllStringSubmatch(m[2], -1) {
+		values = append(values, strings.ReplaceAll(lit[1], "''", "'"))
+	}
+	if len(values) == 0 {
+		// Numeric lists are stored unquoted: ARRAY[1, 2, 3]
+		for _, item := range strings.Split(m[2], ",") {
+			item = strings.TrimSpace(item)
+			if _, err := strconv.ParseFloat(item, 64); err != nil {
+				return nil, false
+			}
+			values = append(values, item)
+		}
+	}
+	if len(values) == 0 {
+		return nil, false
+	}
+	return values, true
+}
@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckConstraintAllowedValues(t *testing.T) {
+	tests := []struct {
+		name       string
+		definition string
+		column     string
+		want       []string
+		wantOK     bool
+	}{
+		{
+			name:       "varchar IN list",
+			definition: "CHECK (((status)::text = ANY ((ARRAY['active'::character varying, 'inactive'::character varying])::text[])))",
+			column:     "status",
+			want:       []string{"active", "inactive"},
+			wantOK:     true,
+		},
+		{
+			name:       "text IN list",
+			definition: "CHECK ((kind = ANY (ARRAY['a'::text, 'b'::text, 'c'::text])))",
+			column:     "kind",
+			want:       []string{"a", "b", "c"},
+			wantOK:     true,
+		},
+		{
+			name:       "numeric IN list",
+			definition: "CHECK ((priority = ANY (ARRAY[1, 2, 3])))",
+			column:     "priority",
+			want:       []string{"1", "2", "3"},
+			wantOK:     true,
+		},
+		{
+			name:       "escaped quote",
+			definition: "CHECK ((label = ANY (ARRAY['it''s'::text, 'plain'::text])))",
+			column:     "label",
+			want:       []string{"it's", "plain"},
+			wantOK:     true,
+		},
+		{
+			name:       "not valid",
+			definition: "CHECK ((kind = ANY (ARRAY['a'::text, 'b'::text]))) NOT VALID",
+			column:     "kind",
+			want:       []string{"a", "b"},
+			wantOK:     true,
+		},
+		{
+			name:       "other column",
+			definition: "CHECK ((kind = ANY (ARRAY['a'::text, 'b'::text])))",
+			column:     "status",
+			wantOK:     false,
+		},
+		{
+			name:       "range check",
+			definition: "CHECK ((amount >= (0)::numeric))",
+			column:     "amount",
+			wantOK:     false,
+		},
+		{
+			name:       "IN list combined with another condition",
+			definition: "CHECK (((kind = ANY (ARRAY['a'::text, 'b'::text])) AND (amount > 0)))",
+			column:     "kind",
+			wantOK:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := CheckConstraintAllowedValues(tt.definition, tt.column)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
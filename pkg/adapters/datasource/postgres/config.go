@@ -1,6 +1,10 @@
 package postgres
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/adapters/datasource"
+)
 
 // Config contains PostgreSQL-specific connection options.
 type Config struct {
@@ -10,6 +14,9 @@ type Config struct {
 	Password string
 	Database string
 	SSLMode  string // "disable", "require", "verify-ca", "verify-full"
+
+	// SchemaFilter overrides the default system schema/table exclusions during discovery
+	SchemaFilter datasource.SchemaFilter
 }
 
 // DefaultPort returns the default PostgreSQL port.
@@ -64,5 +71,7 @@ func FromMap(config map[string]any) (*Config, error) {
 		cfg.SSLMode = sslMode
 	}
 
+	cfg.SchemaFilter = datasource.SchemaFilterFromMap(config)
+
 	return cfg, nil
 }
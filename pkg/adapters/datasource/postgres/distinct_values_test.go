@@ -0,0 +1,109 @@
+//go:build postgres || all_adapters
+
+package postgres
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/adapters/datasource"
+)
+
+func TestBuildSampleDistinctValuesQuery(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         datasource.DistinctValueOptions
+		wantContains []string
+		wantAbsent   []string
+	}{
+		{
+			name: "default is alphabetical",
+			opts: datasource.DistinctValueOptions{},
+			wantContains: []string{
+				`SELECT DISTINCT "status"::text`,
+				`FROM "public"."orders"`,
+				"ORDER BY 1",
+				"LIMIT $1",
+			},
+			wantAbsent: []string{"GROUP BY", "random()"},
+		},
+		{
+			name: "random",
+			opts: datasource.DistinctValueOptions{Strategy: datasource.DistinctValuesRandom},
+			wantContains: []string{
+				`SELECT DISTINCT "status"::text AS value`,
+				"ORDER BY random()",
+				"LIMIT $1",
+			},
+			wantAbsent: []string{"GROUP BY"},
+		},
+		{
+			name: "most frequent groups and orders by count",
+			opts: datasource.DistinctValueOptions{Strategy: datasource.DistinctValuesMostFrequent},
+			wantContains: []string{
+				`GROUP BY "status"`,
+				"ORDER BY COUNT(*) DESC, 1",
+				"LIMIT $1",
+			},
+			wantAbsent: []string{"DISTINCT", "random()"},
+		},
+		{
+			name: "most recent orders by latest recency value",
+			opts: datasource.DistinctValueOptions{Strategy: datasource.DistinctValuesMostRecent, RecencyColumn: "created_at"},
+			wantContains: []string{
+				`GROUP BY "status"`,
+				`ORDER BY MAX("created_at") DESC NULLS LAST, 1`,
+				"LIMIT $1",
+			},
+			wantAbsent: []string{"DISTINCT", "random()"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := buildSampleDistinctValuesQuery("public", "orders", "status", tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(query, want) {
+					t.Errorf("query missing %q:\n%s", want, query)
+				}
+			}
+			for _, absent := range tt.wantAbsent {
+				if strings.Contains(query, absent) {
+					t.Errorf("query should not contain %q:\n%s", absent, query)
+				}
+			}
+			if !strings.Contains(query, `WHERE "status" IS NOT NULL`) {
+				t.Errorf("query should exclude NULLs:\n%s", query)
+			}
+		})
+	}
+}
+
+func TestBuildSampleDistinctValuesQuery_Errors(t *testing.T) {
+	if _, err := buildSampleDistinctValuesQuery("public", "orders", "status", datasource.DistinctValueOptions{
+		Strategy: datasource.DistinctValuesMostRecent,
+	}); err == nil {
+		t.Error("expected error for most_recent without a recency column")
+	}
+
+	if _, err := buildSampleDistinctValuesQuery("public", "orders", "status", datasource.DistinctValueOptions{
+		Strategy: "newest-first",
+	}); err == nil {
+		t.Error("expected error for unknown strategy")
+	}
+}
+
+func TestBuildSampleDistinctValuesQuery_QuotesIdentifiers(t *testing.T) {
+	query, err := buildSampleDistinctValuesQuery("public", `weird"table`, `col"; DROP TABLE x; --`, datasource.DistinctValueOptions{
+		Strategy: datasource.DistinctValuesMostFrequent,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(query, `"weird""table"`) || !strings.Contains(query, `"col""; DROP TABLE x; --"`) {
+		t.Errorf("identifiers not quoted:\n%s", query)
+	}
+}
@@ -0,0 +1,77 @@
+//go:build postgres || all_adapters
+
+package postgres
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/adapters/datasource"
+)
+
+func TestBuildEnumDistributionQuery(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         datasource.EnumDistributionOptions
+		wantContains []string
+		wantAbsent   []string
+	}{
+		{
+			name: "plain distribution",
+			opts: datasource.EnumDistributionOptions{Limit: 100},
+			wantContains: []string{
+				`SELECT "status"::text as value`,
+				"0 as has_completion_at",
+				"0 as recent_count",
+				"LIMIT $2",
+			},
+			wantAbsent: []string{"FILTER", "$3"},
+		},
+		{
+			name: "completion rate",
+			opts: datasource.EnumDistributionOptions{Limit: 100, CompletionTimestampCol: "completed_at"},
+			wantContains: []string{
+				`COUNT(*) FILTER (WHERE "completed_at" IS NOT NULL) as has_completion_at`,
+				"0 as recent_count",
+			},
+			wantAbsent: []string{"$3"},
+		},
+		{
+			name: "recent activity uses the activity column",
+			opts: datasource.EnumDistributionOptions{
+				Limit:                100,
+				ActivityTimestampCol: "updated_at",
+				ActiveSince:          time.Now().AddDate(0, 0, -90),
+			},
+			wantContains: []string{
+				`COUNT(*) FILTER (WHERE "updated_at" >= $3) as recent_count`,
+				"0 as has_completion_at",
+			},
+		},
+		{
+			name: "activity column without a window is ignored",
+			opts: datasource.EnumDistributionOptions{Limit: 100, ActivityTimestampCol: "updated_at"},
+			wantContains: []string{
+				"0 as recent_count",
+			},
+			wantAbsent: []string{"updated_at", "$3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := buildEnumDistributionQuery("public", "orders", "status", tt.opts)
+			for _, want := range tt.wantContains {
+				if !strings.Contains(query, want) {
+					t.Errorf("query missing %q:\n%s", want, query)
+				}
+			}
+			for _, absent := range tt.wantAbsent {
+				if strings.Contains(query, absent) {
+					t.Errorf("query should not contain %q:\n%s", absent, query)
+				}
+			}
+		})
+	}
+}
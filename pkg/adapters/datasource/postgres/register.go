@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 
 	"github.com/ekaya-inc/ekaya-engine/pkg/adapters/datasource"
 )
@@ -31,6 +32,9 @@ func init() {
 			// Pass nil logger - a no-op logger will be used internally
 			return NewSchemaDiscoverer(ctx, cfg, connMgr, projectID, datasourceID, userID, nil)
 		},
+		QuoteIdentifier: func(name string) string {
+			return pgx.Identifier{name}.Sanitize()
+		},
 		QueryExecutorFactory: func(ctx context.Context, config map[string]any, connMgr *datasource.ConnectionManager, projectID, datasourceID uuid.UUID, userID string) (datasource.QueryExecutor, error) {
 			cfg, err := FromMap(config)
 			if err != nil {
@@ -3,6 +3,8 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"math"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -12,16 +14,22 @@ import (
 	"github.com/ekaya-inc/ekaya-engine/pkg/adapters/datasource"
 )
 
+// quoteIdent returns a double-quoted identifier. Names that arrive already quoted
+// (e.g. "OrderItems" from an LLM response) are unquoted first so they are not
+// double-quoted; mixed-case names keep their case.
+func quoteIdent(name string) string {
+	return pgx.Identifier{datasource.UnquoteIdentifier(name)}.Sanitize()
+}
+
 // qualifiedTableName returns a properly quoted table reference.
 // If schemaName is empty, returns just the quoted table name.
 // Otherwise returns "schema"."table".
 func qualifiedTableName(schemaName, tableName string) string {
-	quotedTable := pgx.Identifier{tableName}.Sanitize()
+	quotedTable := quoteIdent(tableName)
 	if schemaName == "" {
 		return quotedTable
 	}
-	quotedSchema := pgx.Identifier{schemaName}.Sanitize()
-	return quotedSchema + "." + quotedTable
+	return quoteIdent(schemaName) + "." + quotedTable
 }
 
 // SchemaDiscoverer provides PostgreSQL schema discovery.
@@ -32,6 +40,7 @@ type SchemaDiscoverer struct {
 	userID       string
 	datasourceID uuid.UUID
 	ownedPool    bool // true if we created the pool (for tests or direct instantiation)
+	filter       datasource.SchemaFilter
 	logger       *zap.Logger
 }
 
@@ -55,6 +64,7 @@ func NewSchemaDiscoverer(ctx context.Context, cfg *Config, connMgr *datasource.C
 		return &SchemaDiscoverer{
 			pool:      pool,
 			ownedPool: true,
+			filter:    cfg.SchemaFilter,
 			logger:    logger,
 		}, nil
 	}
@@ -78,6 +88,7 @@ func NewSchemaDiscoverer(ctx context.Context, cfg *Config, connMgr *datasource.C
 		userID:       userID,
 		datasourceID: datasourceID,
 		ownedPool:    false,
+		filter:       cfg.SchemaFilter,
 		logger:       logger,
 	}, nil
 }
@@ -96,7 +107,9 @@ func (d *SchemaDiscoverer) SupportsForeignKeys() bool {
 	return true
 }
 
-// DiscoverTables returns all user tables (excludes system schemas).
+// DiscoverTables returns all user tables. System schemas are excluded by default, and
+// ekaya's own engine_* tables when the datasource sets exclude_engine_tables; see
+// datasource.SchemaFilter for overrides.
 // For tables where pg_class.reltuples is unavailable or stale (e.g. never ANALYZEd),
 // falls back to SELECT COUNT(*) to get accurate row counts.
 func (d *SchemaDiscoverer) DiscoverTables(ctx context.Context) ([]datasource.TableMetadata, error) {
@@ -125,6 +138,9 @@ func (d *SchemaDiscoverer) DiscoverTables(ctx context.Context) ([]datasource.Tab
 		if err := rows.Scan(&t.SchemaName, &t.TableName, &t.RowCount); err != nil {
 			return nil, fmt.Errorf("scan table: %w", err)
 		}
+		if !d.filter.Includes(t.SchemaName, t.TableName) {
+			continue
+		}
 		tables = append(tables, t)
 	}
 
@@ -190,6 +206,8 @@ func (d *SchemaDiscoverer) discoverEnumTypes(ctx context.Context, schemaName str
 // Uses pg_index for primary key and unique detection, which correctly identifies
 // primary keys even when created as unique indexes (common with GORM/ORMs).
 // For USER-DEFINED columns, queries pg_enum to populate EnumValues with actual Postgres enum values.
+// Check constraints are attached to the columns they reference; a single-column IN-list
+// check populates EnumValues when the column has no enum type.
 func (d *SchemaDiscoverer) DiscoverColumns(ctx context.Context, schemaName, tableName string) ([]datasource.ColumnMetadata, error) {
 	// Discover enum types for this schema
 	enumTypes, err := d.discoverEnumTypes(ctx, schemaName)
@@ -197,6 +215,11 @@ func (d *SchemaDiscoverer) DiscoverColumns(ctx context.Context, schemaName, tabl
 		return nil, fmt.Errorf("discover enum types: %w", err)
 	}
 
+	checksByColumn, err := d.discoverCheckConstraints(ctx, schemaName, tableName)
+	if err != nil {
+		return nil, fmt.Errorf("discover check constraints: %w", err)
+	}
+
 	const query = `
 		SELECT
 			c.column_name,
@@ -253,12 +276,26 @@ func (d *SchemaDiscoverer) DiscoverColumns(ctx context.Context, schemaName, tabl
 		if err := rows.Scan(&c.ColumnName, &c.DataType, &c.IsNullable, &c.IsPrimaryKey, &c.IsUnique, &c.OrdinalPosition, &c.DefaultValue, &udtName); err != nil {
 			return nil, fmt.Errorf("scan column: %w", err)
 		}
+		// information_schema reports every array as ARRAY; the element type is the
+		// udt_name without its leading underscore (_int8 -> int8[])
+		if c.DataType == "ARRAY" && strings.HasPrefix(udtName, "_") {
+			c.DataType = udtName[1:] + "[]"
+		}
 		// For USER-DEFINED columns, check if the udt_name matches a known Postgres enum type
 		if c.DataType == "USER-DEFINED" {
 			if values, ok := enumTypes[udtName]; ok {
 				c.EnumValues = values
 			}
 		}
+		for _, check := range checksByColumn[c.ColumnName] {
+			c.CheckConstraints = append(c.CheckConstraints, check.definition)
+			if len(c.EnumValues) > 0 || len(check.columns) != 1 {
+				continue
+			}
+			if values, ok := CheckConstraintAllowedValues(check.definition, c.ColumnName); ok {
+				c.EnumValues = values
+			}
+		}
 		columns = append(columns, c)
 	}
 
@@ -319,6 +356,86 @@ func (d *SchemaDiscoverer) DiscoverForeignKeys(ctx context.Context) ([]datasourc
 // If the main query fails, retries with a simplified query (without length calculation).
 // Failed columns are included in results with zero/nil stats.
 func (d *SchemaDiscoverer) AnalyzeColumnStats(ctx context.Context, schemaName, tableName string, columnNames []string) ([]datasource.ColumnStats, error) {
+	return d.analyzeColumnStats(ctx, schemaName, tableName, columnNames, nil)
+}
+
+// EstimateColumnStats gathers the same statistics as AnalyzeColumnStats but takes
+// distinct counts from pg_stats.n_distinct, which ANALYZE maintains from a sample.
+// Row, non-null and length stats are still exact, but skipping COUNT(DISTINCT)
+// avoids sorting or hashing the whole column. Columns without usable planner
+// statistics (never analyzed, or n_distinct = 0) get exact counts.
+func (d *SchemaDiscoverer) EstimateColumnStats(ctx context.Context, schemaName, tableName string, columnNames []string) ([]datasource.ColumnStats, error) {
+	if len(columnNames) == 0 {
+		return nil, nil
+	}
+
+	estimates, err := d.plannerDistinctEstimates(ctx, schemaName, tableName, columnNames)
+	if err != nil {
+		return nil, err
+	}
+	return d.analyzeColumnStats(ctx, schemaName, tableName, columnNames, estimates)
+}
+
+// plannerDistinctEstimates returns pg_stats.n_distinct keyed by the requested column
+// name. Positive values are distinct counts; negative values are the negated ratio of
+// distinct values to rows. Columns with no statistics or n_distinct = 0 are omitted.
+func (d *SchemaDiscoverer) plannerDistinctEstimates(ctx context.Context, schemaName, tableName string, columnNames []string) (map[string]float64, error) {
+	requested := make(map[string]string, len(columnNames))
+	attNames := make([]string, 0, len(columnNames))
+	for _, colName := range columnNames {
+		attName := datasource.UnquoteIdentifier(colName)
+		requested[attName] = colName
+		attNames = append(attNames, attName)
+	}
+
+	// Partitioned tables only have inherited statistics; prefer them when both exist
+	query := `
+		SELECT DISTINCT ON (attname) attname, n_distinct
+		FROM pg_stats
+		WHERE schemaname = COALESCE(NULLIF($1, ''), current_schema())
+		  AND tablename = $2
+		  AND attname = ANY($3)
+		ORDER BY attname, inherited DESC`
+
+	rows, err := d.pool.Query(ctx, query, schemaName, datasource.UnquoteIdentifier(tableName), attNames)
+	if err != nil {
+		return nil, fmt.Errorf("query pg_stats: %w", err)
+	}
+	defer rows.Close()
+
+	estimates := make(map[string]float64)
+	for rows.Next() {
+		var attName string
+		var nDistinct float64
+		if err := rows.Scan(&attName, &nDistinct); err != nil {
+			return nil, fmt.Errorf("scan pg_stats: %w", err)
+		}
+		if nDistinct != 0 {
+			estimates[requested[attName]] = nDistinct
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate pg_stats: %w", err)
+	}
+	return estimates, nil
+}
+
+// estimatedDistinctCount converts pg_stats.n_distinct to a distinct count for a
+// column with rowCount rows, capped at the column's non-null count.
+func estimatedDistinctCount(nDistinct float64, rowCount, nonNullCount int64) int64 {
+	distinct := int64(math.Round(nDistinct))
+	if nDistinct < 0 {
+		distinct = int64(math.Round(-nDistinct * float64(rowCount)))
+	}
+	if distinct > nonNullCount {
+		distinct = nonNullCount
+	}
+	return distinct
+}
+
+// analyzeColumnStats implements AnalyzeColumnStats and EstimateColumnStats. Columns
+// with an entry in estimates skip COUNT(DISTINCT) and derive the distinct count from it.
+func (d *SchemaDiscoverer) analyzeColumnStats(ctx context.Context, schemaName, tableName string, columnNames []string, estimates map[string]float64) ([]datasource.ColumnStats, error) {
 	if len(columnNames) == 0 {
 		return nil, nil
 	}
@@ -329,7 +446,13 @@ func (d *SchemaDiscoverer) AnalyzeColumnStats(ctx context.Context, schemaName, t
 	var stats []datasource.ColumnStats
 	var retriedColumns []string
 	for _, colName := range columnNames {
-		quotedCol := pgx.Identifier{colName}.Sanitize()
+		quotedCol := quoteIdent(colName)
+
+		nDistinct, estimated := estimates[colName]
+		distinctExpr := fmt.Sprintf("COUNT(DISTINCT %s)", quotedCol)
+		if estimated {
+			distinctExpr = "0::bigint"
+		}
 
 		// Query includes length stats for text-compatible columns (used to detect uniform-length IDs like UUIDs).
 		// For non-text types (arrays, bytea, json, etc.), length is set to NULL to avoid cast errors.
@@ -344,7 +467,7 @@ func (d *SchemaDiscoverer) AnalyzeColumnStats(ctx context.Context, schemaName, t
 			SELECT
 				COUNT(*) as row_count,
 				COUNT(%s) as non_null_count,
-				COUNT(DISTINCT %s) as distinct_count,
+				%s as distinct_count,
 				CASE
 					WHEN (SELECT dtype FROM col_type) IN ('text', 'character varying', 'character', 'uuid', 'name', 'bpchar')
 					THEN MIN(LENGTH(%s::text))
@@ -357,7 +480,7 @@ func (d *SchemaDiscoverer) AnalyzeColumnStats(ctx context.Context, schemaName, t
 				END as max_length
 			FROM %s
 		`, quotedCol, tableRef, quotedCol,
-			quotedCol, quotedCol, quotedCol, quotedCol, tableRef)
+			quotedCol, distinctExpr, quotedCol, quotedCol, tableRef)
 
 		var s datasource.ColumnStats
 		s.ColumnName = colName
@@ -372,9 +495,9 @@ func (d *SchemaDiscoverer) AnalyzeColumnStats(ctx context.Context, schemaName, t
 				SELECT
 					COUNT(*) as row_count,
 					COUNT(%s) as non_null_count,
-					COUNT(DISTINCT %s) as distinct_count
+					%s as distinct_count
 				FROM %s
-			`, quotedCol, quotedCol, tableRef)
+			`, quotedCol, distinctExpr, tableRef)
 
 			retryRow := d.pool.QueryRow(ctx, simplifiedQuery)
 			if retryErr := retryRow.Scan(&s.RowCount, &s.NonNullCount, &s.DistinctCount); retryErr != nil {
@@ -406,6 +529,11 @@ func (d *SchemaDiscoverer) AnalyzeColumnStats(ctx context.Context, schemaName, t
 				zap.Int64("distinct_count", s.DistinctCount))
 		}
 
+		if estimated {
+			s.DistinctCount = estimatedDistinctCount(nDistinct, s.RowCount, s.NonNullCount)
+			s.DistinctEstimated = true
+		}
+
 		stats = append(stats, s)
 	}
 
@@ -429,8 +557,8 @@ func (d *SchemaDiscoverer) CheckValueOverlap(ctx context.Context,
 	// Build qualified table names (handles empty schema)
 	srcTableRef := qualifiedTableName(sourceSchema, sourceTable)
 	tgtTableRef := qualifiedTableName(targetSchema, targetTable)
-	srcCol := pgx.Identifier{sourceColumn}.Sanitize()
-	tgtCol := pgx.Identifier{targetColumn}.Sanitize()
+	srcCol := quoteIdent(sourceColumn)
+	tgtCol := quoteIdent(targetColumn)
 
 	query := fmt.Sprintf(`
 		WITH source_vals AS (
@@ -472,11 +600,32 @@ func (d *SchemaDiscoverer) AnalyzeJoin(ctx context.Context,
 	sourceSchema, sourceTable, sourceColumn,
 	targetSchema, targetTable, targetColumn string) (*datasource.JoinAnalysis, error) {
 	// Quote identifiers to prevent SQL injection
-	srcTableRef := qualifiedTableName(sourceSchema, sourceTable)
-	tgtTableRef := qualifiedTableName(targetSchema, targetTable)
-	srcCol := pgx.Identifier{sourceColumn}.Sanitize()
-	tgtCol := pgx.Identifier{targetColumn}.Sanitize()
+	return d.analyzeJoin(ctx,
+		qualifiedTableName(sourceSchema, sourceTable), quoteIdent(sourceColumn),
+		qualifiedTableName(targetSchema, targetTable), quoteIdent(targetColumn))
+}
+
+// AnalyzeArrayJoin performs AnalyzeJoin over the elements of an array column, so
+// tag_ids bigint[] can be validated against tags.id. The source becomes one row per
+// non-NULL element; NULL and empty arrays unnest to no rows.
+func (d *SchemaDiscoverer) AnalyzeArrayJoin(ctx context.Context,
+	sourceSchema, sourceTable, sourceColumn,
+	targetSchema, targetTable, targetColumn string) (*datasource.JoinAnalysis, error) {
+	return d.analyzeJoin(ctx,
+		unnestedArraySource(sourceSchema, sourceTable, sourceColumn), quoteIdent("element"),
+		qualifiedTableName(targetSchema, targetTable), quoteIdent(targetColumn))
+}
 
+// unnestedArraySource returns a derived table with one "element" row per non-NULL
+// element of the array column.
+func unnestedArraySource(schemaName, tableName, columnName string) string {
+	return fmt.Sprintf(`(SELECT u.element FROM %s a CROSS JOIN LATERAL unnest(a.%s) AS u(element) WHERE u.element IS NOT NULL)`,
+		qualifiedTableName(schemaName, tableName), quoteIdent(columnName))
+}
+
+// analyzeJoin runs the join analysis query. srcTableRef and tgtTableRef are table
+// references (or derived tables); srcCol and tgtCol are quoted column names.
+func (d *SchemaDiscoverer) analyzeJoin(ctx context.Context, srcTableRef, srcCol, tgtTableRef, tgtCol string) (*datasource.JoinAnalysis, error) {
 	// Cast columns to text to handle cross-type comparisons (e.g., text vs bigint)
 	// Computes:
 	// - orphan_count: source values that don't exist in target (source→target)
@@ -543,7 +692,7 @@ func (d *SchemaDiscoverer) AnalyzeJoin(ctx context.Context,
 func (d *SchemaDiscoverer) GetDistinctValues(ctx context.Context, schemaName, tableName, columnName string, limit int) ([]string, error) {
 	// Quote identifiers to prevent SQL injection
 	tableRef := qualifiedTableName(schemaName, tableName)
-	quotedCol := pgx.Identifier{columnName}.Sanitize()
+	quotedCol := quoteIdent(columnName)
 
 	query := fmt.Sprintf(`
 		SELECT DISTINCT %s::text
@@ -575,13 +724,102 @@ func (d *SchemaDiscoverer) GetDistinctValues(ctx context.Context, schemaName, ta
 	return values, nil
 }
 
+// SampleDistinctValues returns distinct non-null values from a column selected by opts.Strategy.
+func (d *SchemaDiscoverer) SampleDistinctValues(ctx context.Context, schemaName, tableName, columnName string, opts datasource.DistinctValueOptions) ([]string, error) {
+	query, err := buildSampleDistinctValuesQuery(schemaName, tableName, columnName, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.pool.Query(ctx, query, opts.EffectiveLimit())
+	if err != nil {
+		return nil, fmt.Errorf("sample distinct values for %s.%s.%s: %w", schemaName, tableName, columnName, err)
+	}
+	defer rows.Close()
+
+	var values []string
+	for rows.Next() {
+		var val string
+		if err := rows.Scan(&val); err != nil {
+			return nil, fmt.Errorf("scan distinct value: %w", err)
+		}
+		values = append(values, val)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate distinct values: %w", err)
+	}
+
+	return values, nil
+}
+
+// buildSampleDistinctValuesQuery builds the sampling query for a strategy.
+// The limit is always bound as $1.
+func buildSampleDistinctValuesQuery(schemaName, tableName, columnName string, opts datasource.DistinctValueOptions) (string, error) {
+	tableRef := qualifiedTableName(schemaName, tableName)
+	quotedCol := quoteIdent(columnName)
+
+	switch opts.Strategy {
+	case "", datasource.DistinctValuesAlphabetical:
+		return fmt.Sprintf(`
+		SELECT DISTINCT %s::text
+		FROM %s
+		WHERE %s IS NOT NULL
+		ORDER BY 1
+		LIMIT $1
+	`, quotedCol, tableRef, quotedCol), nil
+
+	case datasource.DistinctValuesRandom:
+		return fmt.Sprintf(`
+		SELECT value
+		FROM (
+			SELECT DISTINCT %s::text AS value
+			FROM %s
+			WHERE %s IS NOT NULL
+		) AS distinct_values
+		ORDER BY random()
+		LIMIT $1
+	`, quotedCol, tableRef, quotedCol), nil
+
+	case datasource.DistinctValuesMostFrequent:
+		return fmt.Sprintf(`
+		SELECT %s::text
+		FROM %s
+		WHERE %s IS NOT NULL
+		GROUP BY %s
+		ORDER BY COUNT(*) DESC, 1
+		LIMIT $1
+	`, quotedCol, tableRef, quotedCol, quotedCol), nil
+
+	case datasource.DistinctValuesMostRecent:
+		if opts.RecencyColumn == "" {
+			return "", fmt.Errorf("sample distinct values for %s.%s.%s: %s strategy requires a recency column",
+				schemaName, tableName, columnName, opts.Strategy)
+		}
+		quotedRecency := quoteIdent(opts.RecencyColumn)
+		return fmt.Sprintf(`
+		SELECT %s::text
+		FROM %s
+		WHERE %s IS NOT NULL
+		GROUP BY %s
+		ORDER BY MAX(%s) DESC NULLS LAST, 1
+		LIMIT $1
+	`, quotedCol, tableRef, quotedCol, quotedCol, quotedRecency), nil
+
+	default:
+		return "", fmt.Errorf("sample distinct values for %s.%s.%s: unknown strategy %q",
+			schemaName, tableName, columnName, opts.Strategy)
+	}
+}
+
 // GetEnumValueDistribution analyzes value distribution for an enum column.
 // Returns count and percentage for each distinct value, sorted by count descending.
-// If completionTimestampCol is provided, also computes completion rate per value.
-func (d *SchemaDiscoverer) GetEnumValueDistribution(ctx context.Context, schemaName, tableName, columnName string, completionTimestampCol string, limit int) (*datasource.EnumDistributionResult, error) {
+// If opts.CompletionTimestampCol is provided, also computes completion rate per value.
+// If opts requests activity, also counts each value's rows written since opts.ActiveSince.
+func (d *SchemaDiscoverer) GetEnumValueDistribution(ctx context.Context, schemaName, tableName, columnName string, opts datasource.EnumDistributionOptions) (*datasource.EnumDistributionResult, error) {
 	// Build qualified table name (handles empty schema)
 	tableRef := qualifiedTableName(schemaName, tableName)
-	quotedCol := pgx.Identifier{columnName}.Sanitize()
+	quotedCol := quoteIdent(columnName)
 
 	// Get total row count and null count first
 	totalQuery := fmt.Sprintf(`
@@ -597,47 +835,23 @@ func (d *SchemaDiscoverer) GetEnumValueDistribution(ctx context.Context, schemaN
 	}
 
 	result := &datasource.EnumDistributionResult{
-		ColumnName:    columnName,
-		TotalRows:     totalRows,
-		DistinctCount: distinctCount,
-		NullCount:     nullCount,
-		Distributions: []datasource.EnumValueDistribution{},
-	}
-
-	// Build the distribution query - with or without completion timestamp analysis
-	var query string
-	if completionTimestampCol != "" {
-		quotedCompletionCol := pgx.Identifier{completionTimestampCol}.Sanitize()
-		result.CompletionTimestampCol = completionTimestampCol
-
-		query = fmt.Sprintf(`
-			SELECT %s::text as value,
-			       COUNT(*) as count,
-			       ROUND(100.0 * COUNT(*) / NULLIF($1::numeric, 0), 2) as percentage,
-			       COUNT(*) FILTER (WHERE %s IS NOT NULL) as has_completion_at,
-			       ROUND(100.0 * COUNT(*) FILTER (WHERE %s IS NOT NULL) / NULLIF(COUNT(*), 0), 2) as completion_rate
-			FROM %s
-			WHERE %s IS NOT NULL
-			GROUP BY %s
-			ORDER BY count DESC
-			LIMIT $2
-		`, quotedCol, quotedCompletionCol, quotedCompletionCol, tableRef, quotedCol, quotedCol)
-	} else {
-		query = fmt.Sprintf(`
-			SELECT %s::text as value,
-			       COUNT(*) as count,
-			       ROUND(100.0 * COUNT(*) / NULLIF($1::numeric, 0), 2) as percentage,
-			       0 as has_completion_at,
-			       0.0 as completion_rate
-			FROM %s
-			WHERE %s IS NOT NULL
-			GROUP BY %s
-			ORDER BY count DESC
-			LIMIT $2
-		`, quotedCol, tableRef, quotedCol, quotedCol)
+		ColumnName:             columnName,
+		TotalRows:              totalRows,
+		DistinctCount:          distinctCount,
+		NullCount:              nullCount,
+		Distributions:          []datasource.EnumValueDistribution{},
+		CompletionTimestampCol: opts.CompletionTimestampCol,
+	}
+
+	args := []any{totalRows, opts.Limit}
+	if opts.CountsActivity() {
+		result.ActivityTimestampCol = opts.ActivityTimestampCol
+		since := opts.ActiveSince
+		result.ActiveSince = &since
+		args = append(args, since)
 	}
 
-	rows, err := d.pool.Query(ctx, query, totalRows, limit)
+	rows, err := d.pool.Query(ctx, buildEnumDistributionQuery(schemaName, tableName, columnName, opts), args...)
 	if err != nil {
 		return nil, fmt.Errorf("get enum distribution for %s.%s.%s: %w", schemaName, tableName, columnName, err)
 	}
@@ -646,7 +860,7 @@ func (d *SchemaDiscoverer) GetEnumValueDistribution(ctx context.Context, schemaN
 	for rows.Next() {
 		var dist datasource.EnumValueDistribution
 		var percentage, completionRate float64
-		if err := rows.Scan(&dist.Value, &dist.Count, &percentage, &dist.HasCompletionAt, &completionRate); err != nil {
+		if err := rows.Scan(&dist.Value, &dist.Count, &percentage, &dist.HasCompletionAt, &completionRate, &dist.RecentCount); err != nil {
 			return nil, fmt.Errorf("scan distribution row: %w", err)
 		}
 		dist.Percentage = percentage
@@ -660,13 +874,48 @@ func (d *SchemaDiscoverer) GetEnumValueDistribution(ctx context.Context, schemaN
 	}
 
 	// Infer state semantics if completion timestamp was provided and we have data
-	if completionTimestampCol != "" && len(result.Distributions) > 0 {
+	if opts.CompletionTimestampCol != "" && len(result.Distributions) > 0 {
 		result.HasStateSemantics = inferStateSemantics(result.Distributions)
 	}
 
 	return result, nil
 }
 
+// buildEnumDistributionQuery returns the per-value distribution query. Parameters are
+// $1 total rows, $2 limit and, when opts requests activity, $3 the activity window start.
+// Completion and activity columns are zero when not requested so the scan is fixed.
+func buildEnumDistributionQuery(schemaName, tableName, columnName string, opts datasource.EnumDistributionOptions) string {
+	tableRef := qualifiedTableName(schemaName, tableName)
+	quotedCol := quoteIdent(columnName)
+
+	completionExprs := `0 as has_completion_at,
+		       0.0 as completion_rate`
+	if opts.CompletionTimestampCol != "" {
+		quotedCompletionCol := quoteIdent(opts.CompletionTimestampCol)
+		completionExprs = fmt.Sprintf(`COUNT(*) FILTER (WHERE %s IS NOT NULL) as has_completion_at,
+		       ROUND(100.0 * COUNT(*) FILTER (WHERE %s IS NOT NULL) / NULLIF(COUNT(*), 0), 2) as completion_rate`,
+			quotedCompletionCol, quotedCompletionCol)
+	}
+
+	recentExpr := "0 as recent_count"
+	if opts.CountsActivity() {
+		recentExpr = fmt.Sprintf("COUNT(*) FILTER (WHERE %s >= $3) as recent_count", quoteIdent(opts.ActivityTimestampCol))
+	}
+
+	return fmt.Sprintf(`
+		SELECT %s::text as value,
+		       COUNT(*) as count,
+		       ROUND(100.0 * COUNT(*) / NULLIF($1::numeric, 0), 2) as percentage,
+		       %s,
+		       %s
+		FROM %s
+		WHERE %s IS NOT NULL
+		GROUP BY %s
+		ORDER BY count DESC
+		LIMIT $2
+	`, quotedCol, completionExprs, recentExpr, tableRef, quotedCol, quotedCol)
+}
+
 // inferStateSemantics analyzes distribution data to classify values as initial/terminal/error states.
 // Sets the Is* flags on each EnumValueDistribution.
 func inferStateSemantics(distributions []datasource.EnumValueDistribution) bool {
@@ -724,3 +973,5 @@ func inferStateSemantics(distributions []datasource.EnumValueDistribution) bool
 
 // Ensure SchemaDiscoverer implements datasource.SchemaDiscoverer at compile time.
 var _ datasource.SchemaDiscoverer = (*SchemaDiscoverer)(nil)
+var _ datasource.DistinctCountEstimator = (*SchemaDiscoverer)(nil)
+var _ datasource.ArrayJoinAnalyzer = (*SchemaDiscoverer)(nil)
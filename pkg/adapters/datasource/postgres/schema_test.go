@@ -464,6 +464,44 @@ func TestSchemaDiscoverer_AnalyzeColumnStats(t *testing.T) {
 	}
 }
 
+func TestSchemaDiscoverer_EstimateColumnStats(t *testing.T) {
+	tc := setupSchemaDiscovererTest(t)
+	ctx := context.Background()
+
+	// Make sure planner statistics exist for the table
+	if _, err := tc.discoverer.pool.Exec(ctx, "ANALYZE public.events"); err != nil {
+		t.Fatalf("ANALYZE failed: %v", err)
+	}
+
+	columns, err := tc.discoverer.DiscoverColumns(ctx, "public", "events")
+	if err != nil {
+		t.Fatalf("DiscoverColumns failed: %v", err)
+	}
+	if len(columns) == 0 {
+		t.Fatal("no columns found in events table")
+	}
+
+	columnNames := []string{columns[0].ColumnName}
+	stats, err := tc.discoverer.EstimateColumnStats(ctx, "public", "events", columnNames)
+	if err != nil {
+		t.Fatalf("EstimateColumnStats failed: %v", err)
+	}
+	if len(stats) != 1 {
+		t.Fatalf("expected 1 stat result, got %d", len(stats))
+	}
+
+	stat := stats[0]
+	if !stat.DistinctEstimated {
+		t.Error("expected distinct count to be marked as estimated")
+	}
+	if stat.RowCount != 100 {
+		t.Errorf("expected exact row count 100, got %d", stat.RowCount)
+	}
+	if stat.DistinctCount <= 0 || stat.DistinctCount > stat.NonNullCount {
+		t.Errorf("estimated distinct count %d outside (0, %d]", stat.DistinctCount, stat.NonNullCount)
+	}
+}
+
 func TestSchemaDiscoverer_AnalyzeColumnStats_MultipleColumns(t *testing.T) {
 	tc := setupSchemaDiscovererTest(t)
 	ctx := context.Background()
@@ -951,6 +989,44 @@ func TestSchemaDiscoverer_AnalyzeJoin_CrossTypeComparison(t *testing.T) {
 	}
 }
 
+func TestSchemaDiscoverer_AnalyzeArrayJoin(t *testing.T) {
+	tc := setupSchemaDiscovererTest(t)
+	ctx := context.Background()
+
+	// NULL arrays, empty arrays and NULL elements must not count as orphans
+	setupSQL := `
+		CREATE TEMP TABLE test_array_tags (id BIGINT PRIMARY KEY);
+		CREATE TEMP TABLE test_array_posts (id SERIAL PRIMARY KEY, tag_ids BIGINT[]);
+		INSERT INTO test_array_tags (id) VALUES (1), (2), (3), (4);
+		INSERT INTO test_array_posts (tag_ids) VALUES
+			(ARRAY[1, 2]), (ARRAY[2, 3]), ('{}'), (NULL), (ARRAY[3, NULL]::BIGINT[]);
+	`
+	if _, err := tc.discoverer.pool.Exec(ctx, setupSQL); err != nil {
+		t.Fatalf("failed to create test tables: %v", err)
+	}
+
+	result, err := tc.discoverer.AnalyzeArrayJoin(ctx,
+		"pg_temp", "test_array_posts", "tag_ids",
+		"pg_temp", "test_array_tags", "id")
+	if err != nil {
+		t.Fatalf("AnalyzeArrayJoin failed: %v", err)
+	}
+
+	if result.JoinCount != 5 {
+		t.Errorf("expected 5 matched elements, got %d", result.JoinCount)
+	}
+	if result.SourceMatched != 3 {
+		t.Errorf("expected 3 distinct matched elements, got %d", result.SourceMatched)
+	}
+	if result.OrphanCount != 0 {
+		t.Errorf("expected 0 orphans, got %d", result.OrphanCount)
+	}
+	// Tag 4 is never referenced
+	if result.ReverseOrphanCount != 1 {
+		t.Errorf("expected 1 reverse orphan, got %d", result.ReverseOrphanCount)
+	}
+}
+
 // TestSchemaDiscoverer_AnalyzeJoin_ReverseOrphans tests the bidirectional validation
 // that catches false positive relationships like identity_provider → jobs.id.
 // When source has few values that coincidentally exist in target (which has many more values),
@@ -1488,3 +1564,81 @@ func TestSchemaDiscoverer_DiscoverColumns_DetectsEnumValues(t *testing.T) {
 		}
 	}
 }
+
+// TestSchemaDiscoverer_MixedCaseQuotedIdentifiers verifies discovery and join analysis
+// against tables created with quoted mixed-case names, which only resolve when every
+// generated query quotes them and preserves their case.
+func TestSchemaDiscoverer_MixedCaseQuotedIdentifiers(t *testing.T) {
+	tc := setupSchemaDiscovererTest(t)
+	ctx := context.Background()
+
+	setupSQL := `
+		CREATE TABLE public."MixedProducts" ("Id" INT PRIMARY KEY, "Name" TEXT);
+		CREATE TABLE public."MixedOrderItems" ("Id" INT PRIMARY KEY, "ProductId" INT REFERENCES public."MixedProducts"("Id"));
+		INSERT INTO public."MixedProducts" VALUES (1, 'a'), (2, 'b'), (3, 'c');
+		INSERT INTO public."MixedOrderItems" VALUES (1, 1), (2, 1), (3, 2), (4, NULL);
+	`
+	if _, err := tc.discoverer.pool.Exec(ctx, setupSQL); err != nil {
+		t.Fatalf("failed to create mixed-case tables: %v", err)
+	}
+	t.Cleanup(func() {
+		_, _ = tc.discoverer.pool.Exec(context.Background(),
+			`DROP TABLE IF EXISTS public."MixedOrderItems", public."MixedProducts"`)
+	})
+
+	tables, err := tc.discoverer.DiscoverTables(ctx)
+	if err != nil {
+		t.Fatalf("DiscoverTables failed: %v", err)
+	}
+	found := false
+	for _, table := range tables {
+		if table.SchemaName == "public" && table.TableName == "MixedOrderItems" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatal("expected MixedOrderItems to be discovered with its original case")
+	}
+
+	columns, err := tc.discoverer.DiscoverColumns(ctx, "public", "MixedOrderItems")
+	if err != nil {
+		t.Fatalf("DiscoverColumns failed: %v", err)
+	}
+	if len(columns) != 2 || columns[1].ColumnName != "ProductId" {
+		t.Fatalf("expected columns Id, ProductId; got %+v", columns)
+	}
+
+	stats, err := tc.discoverer.AnalyzeColumnStats(ctx, "public", "MixedOrderItems", []string{"ProductId"})
+	if err != nil {
+		t.Fatalf("AnalyzeColumnStats failed: %v", err)
+	}
+	if len(stats) != 1 || stats[0].DistinctCount != 2 || stats[0].RowCount-stats[0].NonNullCount != 1 {
+		t.Errorf("unexpected stats for ProductId: %+v", stats)
+	}
+
+	// Names from an LLM response may already be quoted; they must not be double-quoted
+	result, err := tc.discoverer.AnalyzeJoin(ctx,
+		"public", `"MixedOrderItems"`, `"ProductId"`,
+		"public", "MixedProducts", "Id")
+	if err != nil {
+		t.Fatalf("AnalyzeJoin failed: %v", err)
+	}
+	if result.OrphanCount != 0 || result.SourceMatched != 2 || result.ReverseOrphanCount != 1 {
+		t.Errorf("unexpected join analysis: %+v", result)
+	}
+
+	fks, err := tc.discoverer.DiscoverForeignKeys(ctx)
+	if err != nil {
+		t.Fatalf("DiscoverForeignKeys failed: %v", err)
+	}
+	fkFound := false
+	for _, fk := range fks {
+		if fk.SourceTable == "MixedOrderItems" && fk.SourceColumn == "ProductId" &&
+			fk.TargetTable == "MixedProducts" && fk.TargetColumn == "Id" {
+			fkFound = true
+		}
+	}
+	if !fkFound {
+		t.Error("expected FK MixedOrderItems.ProductId -> MixedProducts.Id with original case")
+	}
+}
@@ -2,6 +2,7 @@ package datasource
 
 import (
 	"context"
+	"strings"
 	"sync"
 
 	"github.com/google/uuid"
@@ -22,6 +23,10 @@ type DatasourceAdapterRegistration struct {
 	Factory                 func(ctx context.Context, config map[string]any, connMgr *ConnectionManager, projectID, datasourceID uuid.UUID, userID string) (ConnectionTester, error)
 	SchemaDiscovererFactory func(ctx context.Context, config map[string]any, connMgr *ConnectionManager, projectID, datasourceID uuid.UUID, userID string) (SchemaDiscoverer, error)
 	QueryExecutorFactory    func(ctx context.Context, config map[string]any, connMgr *ConnectionManager, projectID, datasourceID uuid.UUID, userID string) (QueryExecutor, error)
+
+	// QuoteIdentifier quotes an identifier in the adapter's SQL dialect without a
+	// connection, for SQL the engine stores for later use (e.g. relationship join clauses).
+	QuoteIdentifier func(name string) string
 }
 
 var (
@@ -50,6 +55,23 @@ func RegisteredAdapters() []DatasourceAdapterInfo {
 	return result
 }
 
+// IdentifierQuoter returns the identifier quoting of a datasource type's SQL dialect.
+// Types that are not registered, or register no quoting, get ANSI double quotes.
+func IdentifierQuoter(dsType string) func(name string) string {
+	registryMu.RLock()
+	reg, ok := registry[dsType]
+	registryMu.RUnlock()
+	if !ok || reg.QuoteIdentifier == nil {
+		return QuoteIdentifierANSI
+	}
+	return reg.QuoteIdentifier
+}
+
+// QuoteIdentifierANSI double-quotes an identifier, doubling embedded quotes.
+func QuoteIdentifierANSI(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
 // GetFactory returns the factory for a datasource type.
 // Returns nil if type is not registered.
 func GetFactory(dsType string) func(ctx context.Context, config map[string]any, connMgr *ConnectionManager, projectID, datasourceID uuid.UUID, userID string) (ConnectionTester, error) {
@@ -0,0 +1,111 @@
+package datasource
+
+import (
+	"path"
+	"strings"
+)
+
+// DefaultExcludedSchemaPatterns lists DBMS system schemas that no adapter should discover.
+// Patterns are matched case-insensitively against the schema name using path.Match syntax.
+var DefaultExcludedSchemaPatterns = []string{
+	"pg_catalog",
+	"pg_toast",
+	"pg_temp_*",
+	"pg_toast_temp_*",
+	"information_schema",
+	"sys",
+}
+
+// EngineTablePatterns matches ekaya's own metadata tables, matched against the table
+// name in every schema. They are excluded only for datasources that set
+// ExcludeEngineTables, since customer databases may have tables named engine_*.
+var EngineTablePatterns = []string{
+	"engine_*",
+}
+
+// SchemaFilter controls which discovered tables are kept.
+//
+// Entries are either a schema pattern ("audit", "pg_*") or a schema-qualified table
+// pattern ("public.engine_*"). Allow overrides both the default exclusions and Deny,
+// so users can opt back into a table the defaults would skip. Deny adds exclusions on
+// top of the defaults.
+type SchemaFilter struct {
+	Allow []string
+	Deny  []string
+
+	// ExcludeEngineTables skips tables matching EngineTablePatterns, for a datasource
+	// that points at the engine's own metadata database.
+	ExcludeEngineTables bool
+}
+
+// SchemaFilterFromMap reads optional "schema_allowlist" and "schema_denylist" entries
+// (lists of strings) and the "exclude_engine_tables" flag from a datasource config map.
+func SchemaFilterFromMap(config map[string]any) SchemaFilter {
+	exclude, _ := config["exclude_engine_tables"].(bool)
+	return SchemaFilter{
+		Allow:               stringList(config["schema_allowlist"]),
+		Deny:                stringList(config["schema_denylist"]),
+		ExcludeEngineTables: exclude,
+	}
+}
+
+// Includes reports whether a table should be discovered.
+func (f SchemaFilter) Includes(schemaName, tableName string) bool {
+	if matchesAnyTable(f.Allow, schemaName, tableName) {
+		return true
+	}
+	if matchesAnyTable(f.Deny, schemaName, tableName) {
+		return false
+	}
+	if matchesAny(DefaultExcludedSchemaPatterns, schemaName) {
+		return false
+	}
+	return !f.ExcludeEngineTables || !matchesAny(EngineTablePatterns, tableName)
+}
+
+// matchesAnyTable matches entries against either the schema name or "schema.table".
+func matchesAnyTable(patterns []string, schemaName, tableName string) bool {
+	qualified := schemaName + "." + tableName
+	for _, p := range patterns {
+		if strings.Contains(p, ".") {
+			if matchPattern(p, qualified) {
+				return true
+			}
+		} else if matchPattern(p, schemaName) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesAny(patterns []string, name string) bool {
+	for _, p := range patterns {
+		if matchPattern(p, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchPattern(pattern, name string) bool {
+	ok, err := path.Match(strings.ToLower(pattern), strings.ToLower(name))
+	return err == nil && ok
+}
+
+// stringList converts a config value decoded from JSON ([]any) or set in Go ([]string).
+func stringList(v any) []string {
+	switch list := v.(type) {
+	case []string:
+		return list
+	case []any:
+		out := make([]string, 0, len(list))
+		for _, item := range list {
+			if s, ok := item.(string); ok && s != "" {
+				out = append(out, s)
+			}
+		}
+		return out
+	default:
+		return nil
+	}
+}
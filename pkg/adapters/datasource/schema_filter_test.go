@@ -0,0 +1,74 @@
+package datasource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaFilter_ExcludesSystemSchemasByDefault(t *testing.T) {
+	tables := []TableMetadata{
+		{SchemaName: "public", TableName: "orders"},
+		{SchemaName: "pg_catalog", TableName: "pg_class"},
+		{SchemaName: "information_schema", TableName: "tables"},
+		{SchemaName: "INFORMATION_SCHEMA", TableName: "COLUMNS"},
+		{SchemaName: "pg_toast", TableName: "pg_toast_2619"},
+		{SchemaName: "pg_temp_3", TableName: "scratch"},
+		{SchemaName: "sys", TableName: "objects"},
+		{SchemaName: "public", TableName: "engine_parts"},
+		{SchemaName: "sales", TableName: "customers"},
+	}
+
+	var kept []TableMetadata
+	for _, table := range tables {
+		if (SchemaFilter{}).Includes(table.SchemaName, table.TableName) {
+			kept = append(kept, table)
+		}
+	}
+
+	assert.Equal(t, []TableMetadata{
+		{SchemaName: "public", TableName: "orders"},
+		{SchemaName: "public", TableName: "engine_parts"},
+		{SchemaName: "sales", TableName: "customers"},
+	}, kept, "customer tables named engine_* are kept unless the datasource excludes engine tables")
+}
+
+func TestSchemaFilter_ExcludeEngineTables(t *testing.T) {
+	filter := SchemaFilter{ExcludeEngineTables: true}
+
+	assert.False(t, filter.Includes("public", "engine_projects"))
+	assert.True(t, filter.Includes("public", "orders"))
+}
+
+func TestSchemaFilter_AllowlistOverridesDefaults(t *testing.T) {
+	filter := SchemaFilter{Allow: []string{"public.engine_projects"}, ExcludeEngineTables: true}
+
+	assert.True(t, filter.Includes("public", "engine_projects"))
+	assert.False(t, filter.Includes("public", "engine_users"), "allowlist entries should not widen beyond their pattern")
+	assert.False(t, filter.Includes("pg_catalog", "pg_class"))
+}
+
+func TestSchemaFilter_DenylistAddsExclusions(t *testing.T) {
+	filter := SchemaFilter{
+		Allow: []string{"audit.keep_me"},
+		Deny:  []string{"audit", "public.tmp_*"},
+	}
+
+	assert.False(t, filter.Includes("audit", "events"))
+	assert.True(t, filter.Includes("audit", "keep_me"), "allowlist should win over denylist")
+	assert.False(t, filter.Includes("public", "tmp_import"))
+	assert.True(t, filter.Includes("public", "orders"))
+}
+
+func TestSchemaFilterFromMap(t *testing.T) {
+	filter := SchemaFilterFromMap(map[string]any{
+		"schema_allowlist":      []any{"public.engine_*", ""},
+		"schema_denylist":       []string{"staging"},
+		"exclude_engine_tables": true,
+	})
+
+	assert.Equal(t, []string{"public.engine_*"}, filter.Allow)
+	assert.Equal(t, []string{"staging"}, filter.Deny)
+	assert.True(t, filter.ExcludeEngineTables)
+	assert.Equal(t, SchemaFilter{}, SchemaFilterFromMap(map[string]any{"host": "localhost"}))
+}
@@ -0,0 +1,179 @@
+package datasource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// secretPlaceholder matches ${secret:path} references in datasource config values.
+var secretPlaceholder = regexp.MustCompile(`\$\{secret:([^}]+)\}`)
+
+// ErrSecretNotFound is returned by a SecretResolver when a referenced secret does not exist.
+var ErrSecretNotFound = errors.New("secret not found")
+
+// SecretResolver looks up secrets referenced from datasource configs as ${secret:path}.
+// Implementations back onto a secrets manager such as Vault or AWS Secrets Manager, or
+// onto the environment (EnvSecretResolver). Resolved values are only used to connect
+// and are never written back to the stored config.
+type SecretResolver interface {
+	// ResolveSecret returns the secret stored at path, or an error wrapping
+	// ErrSecretNotFound when there is none.
+	ResolveSecret(ctx context.Context, path string) (string, error)
+}
+
+// EnvSecretResolver resolves secrets from environment variables. The variable name is
+// Prefix followed by the path upper-cased with every character other than a letter or
+// digit replaced by '_', so with the prefix "EKAYA_DATASOURCE_SECRET_" the placeholder
+// ${secret:warehouse/password} reads EKAYA_DATASOURCE_SECRET_WAREHOUSE_PASSWORD.
+// The prefix keeps datasource configs from reading the server's own settings.
+type EnvSecretResolver struct {
+	Prefix string
+}
+
+// NewEnvSecretResolver creates an environment-based secret resolver.
+func NewEnvSecretResolver(prefix string) *EnvSecretResolver {
+	return &EnvSecretResolver{Prefix: prefix}
+}
+
+// ResolveSecret implements SecretResolver.
+func (r *EnvSecretResolver) ResolveSecret(_ context.Context, path string) (string, error) {
+	name := r.Prefix + strings.Map(func(c rune) rune {
+		switch {
+		case c >= 'a' && c <= 'z':
+			return c - 'a' + 'A'
+		case c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+			return c
+		default:
+			return '_'
+		}
+	}, path)
+
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("%w: environment variable %s is not set", ErrSecretNotFound, name)
+	}
+	return value, nil
+}
+
+// ResolveConfigSecrets returns a copy of config with every ${secret:path} placeholder in
+// a string value, including values nested in maps and lists, replaced by its secret.
+// config itself is never modified. A nil resolver fails on the first placeholder.
+func ResolveConfigSecrets(ctx context.Context, config map[string]any, resolver SecretResolver) (map[string]any, error) {
+	resolved, err := resolveSecretValue(ctx, config, resolver)
+	if err != nil {
+		return nil, err
+	}
+	if resolved == nil {
+		return nil, nil
+	}
+	return resolved.(map[string]any), nil
+}
+
+func resolveSecretValue(ctx context.Context, value any, resolver SecretResolver) (any, error) {
+	switch v := value.(type) {
+	case map[string]any:
+		if v == nil {
+			return v, nil
+		}
+		out := make(map[string]any, len(v))
+		for key, item := range v {
+			resolved, err := resolveSecretValue(ctx, item, resolver)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+			out[key] = resolved
+		}
+		return out, nil
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			resolved, err := resolveSecretValue(ctx, item, resolver)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			out[i] = resolved
+		}
+		return out, nil
+	case string:
+		return resolveSecretString(ctx, v, resolver)
+	default:
+		return value, nil
+	}
+}
+
+// resolveSecretString substitutes the placeholders in s. Errors name the secret path
+// but never the resolved value.
+func resolveSecretString(ctx context.Context, s string, resolver SecretResolver) (string, error) {
+	matches := secretPlaceholder.FindAllStringSubmatchIndex(s, -1)
+	if len(matches) == 0 {
+		return s, nil
+	}
+	if resolver == nil {
+		return "", fmt.Errorf("config references a secret but no secrets provider is configured")
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		path := strings.TrimSpace(s[m[2]:m[3]])
+		secret, err := resolver.ResolveSecret(ctx, path)
+		if err != nil {
+			return "", fmt.Errorf("resolve secret %q: %w", path, err)
+		}
+		b.WriteString(s[last:m[0]])
+		b.WriteString(secret)
+		last = m[1]
+	}
+	b.WriteString(s[last:])
+	return b.String(), nil
+}
+
+// secretResolvingFactory resolves secret placeholders in the config handed to adapters
+// at connection time, so only the placeholder is ever stored.
+type secretResolvingFactory struct {
+	DatasourceAdapterFactory
+	resolver SecretResolver
+}
+
+// WithSecretResolution wraps factory so that ${secret:path} placeholders in datasource
+// configs are resolved with resolver each time an adapter is created. A nil resolver
+// rejects configs that reference secrets instead of connecting with the literal text.
+func WithSecretResolution(factory DatasourceAdapterFactory, resolver SecretResolver) DatasourceAdapterFactory {
+	return &secretResolvingFactory{
+		DatasourceAdapterFactory: factory,
+		resolver:                 resolver,
+	}
+}
+
+func (f *secretResolvingFactory) NewConnectionTester(ctx context.Context, dsType string, config map[string]any, projectID, datasourceID uuid.UUID, userID string) (ConnectionTester, error) {
+	resolved, err := ResolveConfigSecrets(ctx, config, f.resolver)
+	if err != nil {
+		return nil, fmt.Errorf("datasource config: %w", err)
+	}
+	return f.DatasourceAdapterFactory.NewConnectionTester(ctx, dsType, resolved, projectID, datasourceID, userID)
+}
+
+func (f *secretResolvingFactory) NewSchemaDiscoverer(ctx context.Context, dsType string, config map[string]any, projectID, datasourceID uuid.UUID, userID string) (SchemaDiscoverer, error) {
+	resolved, err := ResolveConfigSecrets(ctx, config, f.resolver)
+	if err != nil {
+		return nil, fmt.Errorf("datasource config: %w", err)
+	}
+	return f.DatasourceAdapterFactory.NewSchemaDiscoverer(ctx, dsType, resolved, projectID, datasourceID, userID)
+}
+
+func (f *secretResolvingFactory) NewQueryExecutor(ctx context.Context, dsType string, config map[string]any, projectID, datasourceID uuid.UUID, userID string) (QueryExecutor, error) {
+	resolved, err := ResolveConfigSecrets(ctx, config, f.resolver)
+	if err != nil {
+		return nil, fmt.Errorf("datasource config: %w", err)
+	}
+	return f.DatasourceAdapterFactory.NewQueryExecutor(ctx, dsType, resolved, projectID, datasourceID, userID)
+}
+
+// Ensure secretResolvingFactory implements DatasourceAdapterFactory at compile time.
+var _ DatasourceAdapterFactory = (*secretResolvingFactory)(nil)
@@ -0,0 +1,160 @@
+package datasource
+
+import (
+	"context"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/tracing"
+)
+
+// tracedSchemaDiscoverer wraps a SchemaDiscoverer so every database round trip is a
+// child span of the extraction phase that issued it.
+type tracedSchemaDiscoverer struct {
+	inner SchemaDiscoverer
+}
+
+// tracedEstimatingSchemaDiscoverer is a tracedSchemaDiscoverer whose inner
+// discoverer also implements DistinctCountEstimator, keeping that capability visible
+// to type assertions.
+type tracedEstimatingSchemaDiscoverer struct {
+	tracedSchemaDiscoverer
+	estimator DistinctCountEstimator
+}
+
+// withTracing wraps discoverer in spans when tracing is enabled and returns it
+// unchanged otherwise.
+func withTracing(discoverer SchemaDiscoverer) SchemaDiscoverer {
+	if !tracing.Enabled() {
+		return discoverer
+	}
+	traced := tracedSchemaDiscoverer{inner: discoverer}
+	if estimator, ok := discoverer.(DistinctCountEstimator); ok {
+		return &tracedEstimatingSchemaDiscoverer{tracedSchemaDiscoverer: traced, estimator: estimator}
+	}
+	return &traced
+}
+
+func (d *tracedSchemaDiscoverer) DiscoverTables(ctx context.Context) ([]TableMetadata, error) {
+	ctx, span := tracing.Start(ctx, "discoverer.discover_tables")
+	tables, err := d.inner.DiscoverTables(ctx)
+	span.SetAttributes(tracing.AttrTableCount.Int(len(tables)))
+	tracing.End(span, err)
+	return tables, err
+}
+
+func (d *tracedSchemaDiscoverer) DiscoverColumns(ctx context.Context, schemaName, tableName string) ([]ColumnMetadata, error) {
+	ctx, span := tracing.Start(ctx, "discoverer.discover_columns", tracing.AttrTable.String(tableName))
+	columns, err := d.inner.DiscoverColumns(ctx, schemaName, tableName)
+	span.SetAttributes(tracing.AttrColumnCount.Int(len(columns)))
+	tracing.End(span, err)
+	return columns, err
+}
+
+func (d *tracedSchemaDiscoverer) DiscoverForeignKeys(ctx context.Context) ([]ForeignKeyMetadata, error) {
+	ctx, span := tracing.Start(ctx, "discoverer.discover_foreign_keys")
+	fks, err := d.inner.DiscoverForeignKeys(ctx)
+	tracing.End(span, err)
+	return fks, err
+}
+
+func (d *tracedSchemaDiscoverer) SupportsForeignKeys() bool {
+	return d.inner.SupportsForeignKeys()
+}
+
+func (d *tracedSchemaDiscoverer) AnalyzeColumnStats(ctx context.Context, schemaName, tableName string, columnNames []string) ([]ColumnStats, error) {
+	ctx, span := tracing.Start(ctx, "discoverer.analyze_column_stats",
+		tracing.AttrTable.String(tableName), tracing.AttrColumnCount.Int(len(columnNames)))
+	stats, err := d.inner.AnalyzeColumnStats(ctx, schemaName, tableName, columnNames)
+	tracing.End(span, err)
+	return stats, err
+}
+
+func (d *tracedSchemaDiscoverer) CheckValueOverlap(ctx context.Context, sourceSchema, sourceTable, sourceColumn,
+	targetSchema, targetTable, targetColumn string, sampleLimit int) (*ValueOverlapResult, error) {
+	ctx, span := tracing.Start(ctx, "discoverer.check_value_overlap",
+		tracing.AttrTable.String(sourceTable), tracing.AttrTargetTable.String(targetTable))
+	result, err := d.inner.CheckValueOverlap(ctx, sourceSchema, sourceTable, sourceColumn, targetSchema, targetTable, targetColumn, sampleLimit)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (d *tracedSchemaDiscoverer) AnalyzeJoin(ctx context.Context, sourceSchema, sourceTable, sourceColumn,
+	targetSchema, targetTable, targetColumn string) (*JoinAnalysis, error) {
+	ctx, span := tracing.Start(ctx, "discoverer.analyze_join",
+		tracing.AttrTable.String(sourceTable), tracing.AttrTargetTable.String(targetTable))
+	result, err := d.inner.AnalyzeJoin(ctx, sourceSchema, sourceTable, sourceColumn, targetSchema, targetTable, targetColumn)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (d *tracedSchemaDiscoverer) GetDistinctValues(ctx context.Context, schemaName, tableName, columnName string, limit int) ([]string, error) {
+	ctx, span := tracing.Start(ctx, "discoverer.get_distinct_values", tracing.AttrTable.String(tableName))
+	values, err := d.inner.GetDistinctValues(ctx, schemaName, tableName, columnName, limit)
+	tracing.End(span, err)
+	return values, err
+}
+
+func (d *tracedSchemaDiscoverer) SampleDistinctValues(ctx context.Context, schemaName, tableName, columnName string, opts DistinctValueOptions) ([]string, error) {
+	ctx, span := tracing.Start(ctx, "discoverer.sample_distinct_values", tracing.AttrTable.String(tableName))
+	values, err := d.inner.SampleDistinctValues(ctx, schemaName, tableName, columnName, opts)
+	tracing.End(span, err)
+	return values, err
+}
+
+func (d *tracedSchemaDiscoverer) GetEnumValueDistribution(ctx context.Context, schemaName, tableName, columnName string,
+	opts EnumDistributionOptions) (*EnumDistributionResult, error) {
+	ctx, span := tracing.Start(ctx, "discoverer.get_enum_value_distribution", tracing.AttrTable.String(tableName))
+	result, err := d.inner.GetEnumValueDistribution(ctx, schemaName, tableName, columnName, opts)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (d *tracedSchemaDiscoverer) Close() error {
+	return d.inner.Close()
+}
+
+func (d *tracedEstimatingSchemaDiscoverer) EstimateColumnStats(ctx context.Context, schemaName, tableName string, columnNames []string) ([]ColumnStats, error) {
+	ctx, span := tracing.Start(ctx, "discoverer.estimate_column_stats",
+		tracing.AttrTable.String(tableName), tracing.AttrColumnCount.Int(len(columnNames)))
+	stats, err := d.estimator.EstimateColumnStats(ctx, schemaName, tableName, columnNames)
+	tracing.End(span, err)
+	return stats, err
+}
+
+// tracedArrayJoinAnalyzer traces AnalyzeArrayJoin the way AnalyzeJoin is traced.
+type tracedArrayJoinAnalyzer struct {
+	inner ArrayJoinAnalyzer
+}
+
+func (a *tracedArrayJoinAnalyzer) AnalyzeArrayJoin(ctx context.Context, sourceSchema, sourceTable, sourceColumn,
+	targetSchema, targetTable, targetColumn string) (*JoinAnalysis, error) {
+	ctx, span := tracing.Start(ctx, "discoverer.analyze_array_join",
+		tracing.AttrTable.String(sourceTable), tracing.AttrTargetTable.String(targetTable))
+	result, err := a.inner.AnalyzeArrayJoin(ctx, sourceSchema, sourceTable, sourceColumn, targetSchema, targetTable, targetColumn)
+	tracing.End(span, err)
+	return result, err
+}
+
+// ArrayJoinAnalyzerFor returns discoverer's array join analysis, looking through the
+// tracing wrapper, and false when its database has no array columns.
+func ArrayJoinAnalyzerFor(discoverer SchemaDiscoverer) (ArrayJoinAnalyzer, bool) {
+	switch d := discoverer.(type) {
+	case *tracedSchemaDiscoverer:
+		if inner, ok := d.inner.(ArrayJoinAnalyzer); ok {
+			return &tracedArrayJoinAnalyzer{inner: inner}, true
+		}
+		return nil, false
+	case *tracedEstimatingSchemaDiscoverer:
+		if inner, ok := d.inner.(ArrayJoinAnalyzer); ok {
+			return &tracedArrayJoinAnalyzer{inner: inner}, true
+		}
+		return nil, false
+	}
+	analyzer, ok := discoverer.(ArrayJoinAnalyzer)
+	return analyzer, ok
+}
+
+var (
+	_ SchemaDiscoverer       = (*tracedSchemaDiscoverer)(nil)
+	_ DistinctCountEstimator = (*tracedEstimatingSchemaDiscoverer)(nil)
+	_ ArrayJoinAnalyzer      = (*tracedArrayJoinAnalyzer)(nil)
+)
@@ -9,4 +9,14 @@ var (
 	ErrInvalidRole            = errors.New("invalid role")
 	ErrLastAdmin              = errors.New("cannot remove last admin")
 	ErrCredentialsKeyMismatch = errors.New("datasource credentials were encrypted with a different key")
+
+	// ErrAmbiguousDefaultDatasource is returned when a project has several datasources
+	// and none is marked as the default, so callers cannot pick one implicitly.
+	ErrAmbiguousDefaultDatasource = errors.New("ambiguous default datasource")
+	// ErrNoSelectedTables is returned when extraction is started on a datasource with
+	// every table deselected, which would otherwise build an empty ontology.
+	ErrNoSelectedTables = errors.New("no tables selected for extraction")
+	// ErrTokenBudgetExhausted is returned when an extraction stopped by its token budget
+	// is started again without raising the budget above what it has already spent.
+	ErrTokenBudgetExhausted = errors.New("token budget exhausted")
 )
@@ -92,6 +92,142 @@ type Config struct {
 
 	// Tunnel configuration (MCP Tunnel app — outbound WebSocket to ekaya-tunnel relay)
 	Tunnel TunnelConfig `yaml:"tunnel"`
+
+	// LLM conversation record configuration
+	Conversations ConversationsConfig `yaml:"conversations"`
+
+	// Ontology extraction configuration
+	Ontology OntologyConfig `yaml:"ontology"`
+
+	// LLM call concurrency and provider account limits
+	LLM LLMConfig `yaml:"llm"`
+
+	// OpenTelemetry tracing of extraction and discovery
+	Tracing TracingConfig `yaml:"tracing"`
+}
+
+// LLMConfig bounds how hard the engine drives the LLM provider.
+type LLMConfig struct {
+	// MaxConcurrent is the number of LLM calls extraction runs in parallel. 0 uses
+	// the worker pool's default.
+	MaxConcurrent int `yaml:"max_concurrent" env:"LLM_MAX_CONCURRENT" env-default:"8"`
+
+	// RequestsPerMinute and TokensPerMinute are the provider account's rate tier.
+	// Calls from all workers wait for capacity so the account stays just under its
+	// limits. Tokens are estimated from the prompt before a call and corrected with
+	// the reported usage after it. 0 disables the limit.
+	RequestsPerMinute int `yaml:"requests_per_minute" env:"LLM_REQUESTS_PER_MINUTE" env-default:"0"`
+	TokensPerMinute   int `yaml:"tokens_per_minute" env:"LLM_TOKENS_PER_MINUTE" env-default:"0"`
+}
+
+// TracingConfig controls OpenTelemetry tracing of ontology extraction and discovery.
+type TracingConfig struct {
+	// Enabled installs a tracer provider that exports spans over OTLP/HTTP. When false
+	// spans go to the no-op global provider and cost nothing beyond the call.
+	Enabled bool `yaml:"enabled" env:"TRACING_ENABLED" env-default:"false"`
+
+	// OTLPEndpoint is the collector's OTLP/HTTP address, as host:port or a URL.
+	OTLPEndpoint string `yaml:"otlp_endpoint" env:"TRACING_OTLP_ENDPOINT" env-default:"localhost:4318"`
+
+	// Insecure exports over plain HTTP instead of HTTPS.
+	Insecure bool `yaml:"insecure" env:"TRACING_INSECURE" env-default:"false"`
+
+	// SampleRatio is the fraction of traces recorded, from 0 to 1.
+	SampleRatio float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO" env-default:"1"`
+}
+
+// OntologyConfig tunes ontology extraction.
+type OntologyConfig struct {
+	// MaxQuestionsPerTable caps the pending questions kept per table after extraction.
+	// Required and higher-priority questions are kept first; the rest are dismissed.
+	// Set to 0 to keep every question. A project's extraction profile or settings may
+	// set its own cap.
+	MaxQuestionsPerTable int `yaml:"max_questions_per_table" env:"ONTOLOGY_MAX_QUESTIONS_PER_TABLE" env-default:"5"`
+
+	// DescriptionPromptTemplate replaces the prompt that extracts knowledge facts and
+	// entity hints from the project description. It is a Go text/template executed with
+	// .Overview, .SchemaContext and .Domains. Empty uses the built-in prompt.
+	DescriptionPromptTemplate string `yaml:"description_prompt_template" env:"ONTOLOGY_DESCRIPTION_PROMPT_TEMPLATE" env-default:""`
+
+	// DomainTaxonomy lists the business domains entity hints may be assigned to.
+	// When set, the description prompt asks for one of these domains and hints naming
+	// any other domain are flagged. Empty leaves hint domains unchecked.
+	DomainTaxonomy []string `yaml:"domain_taxonomy" env:"ONTOLOGY_DOMAIN_TAXONOMY" env-default:""`
+
+	// QuestionCategories is the closed set of categories enrichment questions may carry.
+	// The prompt lists them, and categories the LLM returns are snapped onto them;
+	// unmappable ones are kept and logged. Empty uses the built-in categories
+	// (business_rules, relationship, terminology, enumeration, temporal, data_quality).
+	QuestionCategories []string `yaml:"question_categories" env:"ONTOLOGY_QUESTION_CATEGORIES" env-default:""`
+
+	// FKExcludedPurposes lists column purposes (identifier, timestamp, flag, measure,
+	// enum, text, json) that are never considered foreign key sources during relationship
+	// discovery, for schemas whose column features are mislabeled. Empty excludes none.
+	FKExcludedPurposes []string `yaml:"fk_excluded_purposes" env:"ONTOLOGY_FK_EXCLUDED_PURPOSES" env-default:""`
+
+	// DistinctEstimateRowThreshold makes column stats gathering take distinct counts from
+	// the database's planner statistics (postgres pg_stats) instead of COUNT(DISTINCT)
+	// for tables with at least this many rows. Smaller tables are always counted exactly.
+	// 0 always counts exactly.
+	DistinctEstimateRowThreshold int64 `yaml:"distinct_estimate_row_threshold" env:"ONTOLOGY_DISTINCT_ESTIMATE_ROW_THRESHOLD" env-default:"0"`
+
+	// EnumSampleStrategy is how column feature extraction samples the values of
+	// enum-like columns for their prompts: most_frequent, alphabetical or random.
+	// Datasources that opt into recent-row sampling still sample recent rows.
+	EnumSampleStrategy string `yaml:"enum_sample_strategy" env:"ONTOLOGY_ENUM_SAMPLE_STRATEGY" env-default:"most_frequent"`
+
+	// EnumSampleLimit is how many distinct values are sampled per enum-like column.
+	// A datasource's "sample_limits" config overrides it for individual columns.
+	EnumSampleLimit int `yaml:"enum_sample_limit" env:"ONTOLOGY_ENUM_SAMPLE_LIMIT" env-default:"50"`
+
+	// GraphNodeMatchDistance is how many edits apart a domain graph node and a table name
+	// may be for the ontology health check to suggest the table for the node. Singular
+	// and plural forms also match when it is above 0. 0 only matches case differences.
+	GraphNodeMatchDistance int `yaml:"graph_node_match_distance" env:"ONTOLOGY_GRAPH_NODE_MATCH_DISTANCE" env-default:"2"`
+
+	// StatsStaleAfterDays is the age in days after which the ontology health check
+	// reports gathered column stats as stale, meaning a re-sync is overdue. 0 disables
+	// the age check; a schema change since the last extraction is still reported.
+	StatsStaleAfterDays int `yaml:"stats_stale_after_days" env:"ONTOLOGY_STATS_STALE_AFTER_DAYS" env-default:"30"`
+
+	// WideTableColumnThreshold is the column count above which table analysis summarizes
+	// groups of related columns separately before describing the table, instead of
+	// listing every column in one prompt. 0 always uses one prompt.
+	WideTableColumnThreshold int `yaml:"wide_table_column_threshold" env:"ONTOLOGY_WIDE_TABLE_COLUMN_THRESHOLD" env-default:"120"`
+
+	// MinPromptRelationshipConfidence is the confidence an inferred relationship needs
+	// to be given to table analysis prompts as context. Declared foreign keys, manual
+	// and approved relationships are always included. The default keeps only high
+	// confidence inferences; 0 includes every relationship.
+	MinPromptRelationshipConfidence float64 `yaml:"min_prompt_relationship_confidence" env:"ONTOLOGY_MIN_PROMPT_RELATIONSHIP_CONFIDENCE" env-default:"0.8"`
+
+	// ContextDocumentTokenBudget is the default size, in estimated tokens, of the
+	// ontology context document assembled for text-to-SQL prompts. Callers may ask for
+	// a different budget per request. 0 uses the built-in default.
+	ContextDocumentTokenBudget int `yaml:"context_document_token_budget" env:"ONTOLOGY_CONTEXT_DOCUMENT_TOKEN_BUDGET" env-default:"8000"`
+
+	// TrustDeclaredFKs records declared foreign keys as validated at full confidence
+	// without running join analysis against the datasource for each one. Cardinality
+	// is taken from the source column's uniqueness instead. Inferred relationships are
+	// still validated.
+	TrustDeclaredFKs bool `yaml:"trust_declared_fks" env:"ONTOLOGY_TRUST_DECLARED_FKS" env-default:"false"`
+
+	// UUIDTextFKMatching lets relationship discovery pair a uuid column with a text
+	// column (and vice versa) when the text column's sampled values all parse as UUIDs,
+	// for schemas that store UUID foreign keys as text. Other type mismatches are
+	// still rejected.
+	UUIDTextFKMatching bool `yaml:"uuid_text_fk_matching" env:"ONTOLOGY_UUID_TEXT_FK_MATCHING" env-default:"true"`
+}
+
+// ConversationsConfig controls how stored LLM conversations are exposed.
+type ConversationsConfig struct {
+	// RedactDetectors names built-in detectors applied when conversations are stored
+	// and again when they are read back: email, phone, ssn, credit_card, ip_address, secret.
+	// Applying them on read means adding a detector also masks existing records.
+	RedactDetectors []string `yaml:"redact_detectors" env:"CONVERSATIONS_REDACT_DETECTORS" env-default:""`
+
+	// RedactPatterns are additional regular expressions whose matches are masked on write and read.
+	RedactPatterns []string `yaml:"redact_patterns"`
 }
 
 // TunnelConfig holds configuration for the MCP Tunnel outbound WebSocket connection.
@@ -155,6 +291,13 @@ type DatasourceConfig struct {
 	PoolMaxConns int32 `yaml:"pool_max_conns" env:"DATASOURCE_POOL_MAX_CONNS" env-default:"10"`
 	// PoolMinConns is the minimum number of connections per datasource pool.
 	PoolMinConns int32 `yaml:"pool_min_conns" env:"DATASOURCE_POOL_MIN_CONNS" env-default:"1"`
+	// SecretsProvider resolves ${secret:path} placeholders in datasource configs when
+	// connecting: "env" reads them from environment variables, "none" (or empty) rejects
+	// configs that reference secrets.
+	SecretsProvider string `yaml:"secrets_provider" env:"DATASOURCE_SECRETS_PROVIDER" env-default:"env"`
+	// SecretsEnvPrefix is prepended to the upper-cased secret path to name the environment
+	// variable the "env" provider reads, so configs cannot read other server settings.
+	SecretsEnvPrefix string `yaml:"secrets_env_prefix" env:"DATASOURCE_SECRETS_ENV_PREFIX" env-default:"EKAYA_DATASOURCE_SECRET_"`
 }
 
 // CommunityAIConfig holds endpoints for free community AI models.
@@ -301,6 +444,11 @@ func Load(version string) (*Config, error) {
 		)
 	}
 
+	// Fail fast on contradictory settings before the server starts serving
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
 	return cfg, nil
 }
 
@@ -0,0 +1,190 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/logging"
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+)
+
+// validEnumSampleStrategies are the distinct value strategies ontology.enum_sample_strategy
+// accepts. Most-recent sampling needs a per-table recency column, so it is enabled per
+// datasource instead.
+var validEnumSampleStrategies = []string{"most_frequent", "alphabetical", "random"}
+
+// Validate checks the loaded configuration for contradictory or incomplete settings
+// so they fail at startup instead of surfacing as runtime errors. All problems are
+// reported together.
+//
+// The "local" environment is more permissive: it allows auth verification to be
+// disabled and plain-http JWKS endpoints for a locally running auth server.
+func (c *Config) Validate() error {
+	var errs []error
+
+	if port, err := strconv.Atoi(c.Port); err != nil || port < 1 || port > 65535 {
+		errs = append(errs, fmt.Errorf("port %q must be a number between 1 and 65535", c.Port))
+	}
+
+	if err := validateHTTPURL(c.BaseURL); err != nil {
+		errs = append(errs, fmt.Errorf("base_url %q %w (e.g. https://engine.example.com)", c.BaseURL, err))
+	}
+	if c.AuthServerURL != "" {
+		if err := validateHTTPURL(c.AuthServerURL); err != nil {
+			errs = append(errs, fmt.Errorf("auth_server_url %q %w", c.AuthServerURL, err))
+		}
+	}
+
+	errs = append(errs, c.validateAuth()...)
+
+	db := c.EngineDatabase
+	if db.Host != "" && (db.Port < 1 || db.Port > 65535) {
+		errs = append(errs, fmt.Errorf("engine_database.pg_host is set but pg_port %d is not a valid port", db.Port))
+	}
+	if db.MaxConnections < 1 {
+		errs = append(errs, fmt.Errorf("engine_database.pg_max_connections must be at least 1, got %d", db.MaxConnections))
+	} else if db.MaxIdleConns > db.MaxConnections {
+		errs = append(errs, fmt.Errorf("engine_database.pg_max_idle_conns (%d) must not exceed pg_max_connections (%d)",
+			db.MaxIdleConns, db.MaxConnections))
+	}
+
+	ds := c.Datasource
+	if ds.PoolMaxConns < 1 {
+		errs = append(errs, fmt.Errorf("datasource.pool_max_conns must be at least 1, got %d", ds.PoolMaxConns))
+	} else if ds.PoolMinConns > ds.PoolMaxConns {
+		errs = append(errs, fmt.Errorf("datasource.pool_min_conns (%d) must not exceed pool_max_conns (%d)",
+			ds.PoolMinConns, ds.PoolMaxConns))
+	}
+	switch ds.SecretsProvider {
+	case "", "none":
+	case "env":
+		if ds.SecretsEnvPrefix == "" {
+			errs = append(errs, fmt.Errorf("datasource.secrets_env_prefix must be set when secrets_provider is \"env\""))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("datasource.secrets_provider %q must be \"env\" or \"none\"", ds.SecretsProvider))
+	}
+
+	if _, err := logging.NewRedactor(c.Conversations.RedactDetectors, c.Conversations.RedactPatterns); err != nil {
+		errs = append(errs, fmt.Errorf("conversations: %w", err))
+	}
+
+	if c.Ontology.MaxQuestionsPerTable < 0 {
+		errs = append(errs, fmt.Errorf("ontology.max_questions_per_table must not be negative, got %d", c.Ontology.MaxQuestionsPerTable))
+	}
+	if c.Ontology.DistinctEstimateRowThreshold < 0 {
+		errs = append(errs, fmt.Errorf("ontology.distinct_estimate_row_threshold must not be negative, got %d", c.Ontology.DistinctEstimateRowThreshold))
+	}
+	if c.Ontology.EnumSampleStrategy != "" && !slices.Contains(validEnumSampleStrategies, c.Ontology.EnumSampleStrategy) {
+		errs = append(errs, fmt.Errorf("ontology.enum_sample_strategy: unknown strategy %q (valid: %s)",
+			c.Ontology.EnumSampleStrategy, strings.Join(validEnumSampleStrategies, ", ")))
+	}
+	if c.Ontology.EnumSampleLimit < 0 {
+		errs = append(errs, fmt.Errorf("ontology.enum_sample_limit must not be negative, got %d", c.Ontology.EnumSampleLimit))
+	}
+	if c.Ontology.GraphNodeMatchDistance < 0 {
+		errs = append(errs, fmt.Errorf("ontology.graph_node_match_distance must not be negative, got %d", c.Ontology.GraphNodeMatchDistance))
+	}
+	if c.Ontology.StatsStaleAfterDays < 0 {
+		errs = append(errs, fmt.Errorf("ontology.stats_stale_after_days must not be negative, got %d", c.Ontology.StatsStaleAfterDays))
+	}
+	if c.Ontology.WideTableColumnThreshold < 0 {
+		errs = append(errs, fmt.Errorf("ontology.wide_table_column_threshold must not be negative, got %d", c.Ontology.WideTableColumnThreshold))
+	}
+	if c.Ontology.MinPromptRelationshipConfidence < 0 || c.Ontology.MinPromptRelationshipConfidence > 1 {
+		errs = append(errs, fmt.Errorf("ontology.min_prompt_relationship_confidence must be between 0 and 1, got %g", c.Ontology.MinPromptRelationshipConfidence))
+	}
+	if c.Ontology.ContextDocumentTokenBudget < 0 {
+		errs = append(errs, fmt.Errorf("ontology.context_document_token_budget must not be negative, got %d", c.Ontology.ContextDocumentTokenBudget))
+	}
+	if c.LLM.MaxConcurrent < 0 {
+		errs = append(errs, fmt.Errorf("llm.max_concurrent must not be negative, got %d", c.LLM.MaxConcurrent))
+	}
+	if c.LLM.RequestsPerMinute < 0 {
+		errs = append(errs, fmt.Errorf("llm.requests_per_minute must not be negative, got %d", c.LLM.RequestsPerMinute))
+	}
+	if c.LLM.TokensPerMinute < 0 {
+		errs = append(errs, fmt.Errorf("llm.tokens_per_minute must not be negative, got %d", c.LLM.TokensPerMinute))
+	}
+	if c.Tracing.Enabled {
+		if c.Tracing.OTLPEndpoint == "" {
+			errs = append(errs, errors.New("tracing.otlp_endpoint is required when tracing is enabled"))
+		}
+		if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+			errs = append(errs, fmt.Errorf("tracing.sample_ratio must be between 0 and 1, got %g", c.Tracing.SampleRatio))
+		}
+	}
+	for _, purpose := range c.Ontology.FKExcludedPurposes {
+		if !slices.Contains(models.ValidPurposes, purpose) {
+			errs = append(errs, fmt.Errorf("ontology.fk_excluded_purposes: unknown purpose %q (valid: %s)",
+				purpose, strings.Join(models.ValidPurposes, ", ")))
+		}
+	}
+	if c.Ontology.DescriptionPromptTemplate != "" {
+		if _, err := template.New("description_prompt").Parse(c.Ontology.DescriptionPromptTemplate); err != nil {
+			errs = append(errs, fmt.Errorf("ontology.description_prompt_template: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateAuth checks JWT verification settings against the configured JWKS endpoints.
+func (c *Config) validateAuth() []error {
+	var errs []error
+	local := c.Env == "local"
+
+	if !c.Auth.EnableVerification {
+		if !local {
+			errs = append(errs, fmt.Errorf("auth.enable_verification may only be disabled when env is \"local\" (env is %q)", c.Env))
+		}
+		return errs
+	}
+
+	if len(c.Auth.JWKSEndpoints) == 0 {
+		errs = append(errs, fmt.Errorf("auth.enable_verification is true but jwks_endpoints is empty; set JWKS_ENDPOINTS to issuer=jwks_url pairs"))
+		return errs
+	}
+
+	// Sort issuers so errors are reported in a stable order
+	issuers := make([]string, 0, len(c.Auth.JWKSEndpoints))
+	for issuer := range c.Auth.JWKSEndpoints {
+		issuers = append(issuers, issuer)
+	}
+	sort.Strings(issuers)
+
+	for _, issuer := range issuers {
+		jwksURL := c.Auth.JWKSEndpoints[issuer]
+		u, err := url.Parse(jwksURL)
+		if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+			errs = append(errs, fmt.Errorf("jwks_endpoints entry for %q has invalid URL %q", issuer, jwksURL))
+			continue
+		}
+		if u.Scheme == "http" && !local {
+			errs = append(errs, fmt.Errorf("jwks_endpoints entry for %q must use https outside the local environment", issuer))
+		}
+	}
+
+	return errs
+}
+
+// validateHTTPURL returns an error describing why s is not an absolute http(s) URL.
+func validateHTTPURL(s string) error {
+	u, err := url.Parse(s)
+	if err != nil {
+		return fmt.Errorf("is not a valid URL: %v", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("must start with http:// or https://")
+	}
+	if u.Host == "" {
+		return fmt.Errorf("must include a host")
+	}
+	return nil
+}
@@ -0,0 +1,257 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+// validConfig returns a configuration that passes Validate in a non-local environment.
+func validConfig() *Config {
+	return &Config{
+		Port:          "3443",
+		Env:           "prod",
+		BaseURL:       "https://engine.example.com",
+		AuthServerURL: "https://auth.ekaya.ai",
+		Auth: AuthConfig{
+			EnableVerification: true,
+			JWKSEndpoints: map[string]string{
+				"https://auth.ekaya.ai": "https://auth.ekaya.ai/.well-known/jwks.json",
+			},
+		},
+		EngineDatabase: EngineDatabaseConfig{
+			Host:           "localhost",
+			Port:           5432,
+			MaxConnections: 25,
+			MaxIdleConns:   5,
+		},
+		Datasource: DatasourceConfig{
+			PoolMaxConns: 10,
+			PoolMinConns: 1,
+		},
+	}
+}
+
+func TestValidate_ValidConfig(t *testing.T) {
+	if err := validConfig().Validate(); err != nil {
+		t.Fatalf("expected valid config, got: %v", err)
+	}
+}
+
+func TestValidate_Contradictions(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(c *Config)
+		wantErr string
+	}{
+		{
+			name:    "verification enabled without JWKS endpoints",
+			mutate:  func(c *Config) { c.Auth.JWKSEndpoints = map[string]string{} },
+			wantErr: "jwks_endpoints is empty",
+		},
+		{
+			name:    "JWKS endpoint without scheme",
+			mutate:  func(c *Config) { c.Auth.JWKSEndpoints["https://auth.ekaya.ai"] = "auth.ekaya.ai/jwks.json" },
+			wantErr: "invalid URL",
+		},
+		{
+			name:    "plain-http JWKS endpoint outside local",
+			mutate:  func(c *Config) { c.Auth.JWKSEndpoints["https://auth.ekaya.ai"] = "http://auth.ekaya.ai/jwks.json" },
+			wantErr: "must use https",
+		},
+		{
+			name:    "verification disabled outside local",
+			mutate:  func(c *Config) { c.Auth.EnableVerification = false },
+			wantErr: "may only be disabled",
+		},
+		{
+			name:    "base URL missing scheme",
+			mutate:  func(c *Config) { c.BaseURL = "engine.example.com:3443" },
+			wantErr: "base_url",
+		},
+		{
+			name:    "auth server URL missing host",
+			mutate:  func(c *Config) { c.AuthServerURL = "https://" },
+			wantErr: "auth_server_url",
+		},
+		{
+			name:    "non-numeric port",
+			mutate:  func(c *Config) { c.Port = "https" },
+			wantErr: "port \"https\"",
+		},
+		{
+			name:    "database host set but port zero",
+			mutate:  func(c *Config) { c.EngineDatabase.Port = 0 },
+			wantErr: "pg_port 0",
+		},
+		{
+			name:    "database idle connections exceed max",
+			mutate:  func(c *Config) { c.EngineDatabase.MaxIdleConns = 30 },
+			wantErr: "pg_max_idle_conns (30)",
+		},
+		{
+			name:    "datasource pool min exceeds max",
+			mutate:  func(c *Config) { c.Datasource.PoolMinConns = 20 },
+			wantErr: "pool_min_conns (20)",
+		},
+		{
+			name:    "datasource pool max zero",
+			mutate:  func(c *Config) { c.Datasource.PoolMaxConns = 0 },
+			wantErr: "pool_max_conns must be at least 1",
+		},
+		{
+			name:    "unknown datasource secrets provider",
+			mutate:  func(c *Config) { c.Datasource.SecretsProvider = "vault" },
+			wantErr: "secrets_provider \"vault\"",
+		},
+		{
+			name:    "env secrets provider without prefix",
+			mutate:  func(c *Config) { c.Datasource.SecretsProvider = "env" },
+			wantErr: "secrets_env_prefix must be set",
+		},
+		{
+			name:    "unknown conversation redaction detector",
+			mutate:  func(c *Config) { c.Conversations.RedactDetectors = []string{"email", "passport"} },
+			wantErr: "unknown redaction detector \"passport\"",
+		},
+		{
+			name:    "invalid conversation redaction pattern",
+			mutate:  func(c *Config) { c.Conversations.RedactPatterns = []string{"([a-z"} },
+			wantErr: "invalid redaction pattern",
+		},
+		{
+			name:    "negative question cap",
+			mutate:  func(c *Config) { c.Ontology.MaxQuestionsPerTable = -1 },
+			wantErr: "max_questions_per_table must not be negative",
+		},
+		{
+			name:    "negative distinct estimate threshold",
+			mutate:  func(c *Config) { c.Ontology.DistinctEstimateRowThreshold = -1 },
+			wantErr: "distinct_estimate_row_threshold must not be negative",
+		},
+		{
+			name:    "negative graph node match distance",
+			mutate:  func(c *Config) { c.Ontology.GraphNodeMatchDistance = -1 },
+			wantErr: "graph_node_match_distance must not be negative",
+		},
+		{
+			name:    "negative stats stale window",
+			mutate:  func(c *Config) { c.Ontology.StatsStaleAfterDays = -1 },
+			wantErr: "stats_stale_after_days must not be negative",
+		},
+		{
+			name:    "negative context document token budget",
+			mutate:  func(c *Config) { c.Ontology.ContextDocumentTokenBudget = -1 },
+			wantErr: "context_document_token_budget must not be negative",
+		},
+		{
+			name:    "negative wide table column threshold",
+			mutate:  func(c *Config) { c.Ontology.WideTableColumnThreshold = -1 },
+			wantErr: "wide_table_column_threshold must not be negative",
+		},
+		{
+			name:    "prompt relationship confidence above one",
+			mutate:  func(c *Config) { c.Ontology.MinPromptRelationshipConfidence = 1.2 },
+			wantErr: "min_prompt_relationship_confidence must be between 0 and 1",
+		},
+		{
+			name:    "negative LLM tokens per minute",
+			mutate:  func(c *Config) { c.LLM.TokensPerMinute = -1 },
+			wantErr: "llm.tokens_per_minute must not be negative",
+		},
+		{
+			name: "tracing sample ratio above one",
+			mutate: func(c *Config) {
+				c.Tracing.Enabled = true
+				c.Tracing.OTLPEndpoint = "localhost:4318"
+				c.Tracing.SampleRatio = 1.5
+			},
+			wantErr: "tracing.sample_ratio must be between 0 and 1",
+		},
+		{
+			name:    "unknown FK excluded purpose",
+			mutate:  func(c *Config) { c.Ontology.FKExcludedPurposes = []string{"measure", "free_text"} },
+			wantErr: `unknown purpose "free_text"`,
+		},
+		{
+			name:    "unknown enum sample strategy",
+			mutate:  func(c *Config) { c.Ontology.EnumSampleStrategy = "most_recent" },
+			wantErr: `ontology.enum_sample_strategy: unknown strategy "most_recent"`,
+		},
+		{
+			name:    "negative enum sample limit",
+			mutate:  func(c *Config) { c.Ontology.EnumSampleLimit = -1 },
+			wantErr: "ontology.enum_sample_limit must not be negative",
+		},
+		{
+			name:    "unparseable description prompt template",
+			mutate:  func(c *Config) { c.Ontology.DescriptionPromptTemplate = "{{.Overview" },
+			wantErr: "ontology.description_prompt_template",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			tt.mutate(cfg)
+
+			err := cfg.Validate()
+			if err == nil {
+				t.Fatal("expected validation error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidate_LocalIsPermissive(t *testing.T) {
+	cfg := validConfig()
+	cfg.Env = "local"
+	cfg.Auth.JWKSEndpoints = map[string]string{"http://localhost:5002": "http://localhost:5002/.well-known/jwks.json"}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("expected plain-http JWKS to be allowed locally, got: %v", err)
+	}
+
+	cfg.Auth.EnableVerification = false
+	cfg.Auth.JWKSEndpoints = nil
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("expected disabled verification to be allowed locally, got: %v", err)
+	}
+}
+
+func TestValidate_ReportsAllProblems(t *testing.T) {
+	cfg := validConfig()
+	cfg.BaseURL = "engine.example.com"
+	cfg.Datasource.PoolMinConns = 20
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("expected validation error, got nil")
+	}
+	for _, want := range []string{"base_url", "pool_min_conns"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to mention %q, got: %v", want, err)
+		}
+	}
+}
+
+func TestLoad_FailsFastOnInvalidConfig(t *testing.T) {
+	unsetEnvVars(t, "BASE_URL", "PGPORT", "JWKS_ENDPOINTS", "AUTH_ENABLE_VERIFICATION", "ENVIRONMENT")
+
+	setupConfigTest(t, `
+port: "3443"
+env: "test"
+base_url: "engine.example.com"
+engine_database:
+  pg_host: "localhost"
+`)
+
+	_, err := Load("test-version")
+	if err == nil {
+		t.Fatal("expected Load to fail on invalid base_url, got nil")
+	}
+	if !strings.Contains(err.Error(), "invalid configuration") || !strings.Contains(err.Error(), "base_url") {
+		t.Errorf("expected invalid configuration error mentioning base_url, got: %v", err)
+	}
+}
@@ -0,0 +1,105 @@
+//go:build integration
+
+package database_test
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/testhelpers"
+)
+
+func Test_023_RelationshipJoinClauseBackfill(t *testing.T) {
+	testDB := testhelpers.GetTestDB(t)
+
+	dbName := fmt.Sprintf("test_relationship_join_clause_%d", time.Now().UnixNano())
+
+	_, err := testDB.Pool.Exec(t.Context(), "CREATE DATABASE "+dbName)
+	require.NoError(t, err, "Failed to create test database")
+
+	defer func() {
+		_, _ = testDB.Pool.Exec(t.Context(), `
+			SELECT pg_terminate_backend(pg_stat_activity.pid)
+			FROM pg_stat_activity
+			WHERE pg_stat_activity.datname = $1
+			AND pid <> pg_backend_pid()
+		`, dbName)
+		time.Sleep(100 * time.Millisecond)
+		_, _ = testDB.Pool.Exec(t.Context(), "DROP DATABASE IF EXISTS "+dbName)
+	}()
+
+	host, err := testDB.Container.Host(t.Context())
+	require.NoError(t, err)
+	port, err := testDB.Container.MappedPort(t.Context(), "5432")
+	require.NoError(t, err)
+
+	connStr := fmt.Sprintf("postgres://ekaya:test_password@%s:%s/%s?sslmode=disable",
+		host, port.Port(), dbName)
+
+	runMigrationsToVersion(t, connStr, 22)
+
+	db := openMigrationTestDB(t, connStr)
+	defer db.Close()
+
+	projectID := uuid.New()
+	_, err = db.Exec(`
+		INSERT INTO engine_projects (id, name, created_at, updated_at)
+		VALUES ($1, 'test-project', NOW(), NOW())
+	`, projectID)
+	require.NoError(t, err, "Failed to create test project")
+
+	postgresRel := insertMigrationTestRelationship(t, db, projectID, "postgres", "public", `order"s`)
+	mssqlRel := insertMigrationTestRelationship(t, db, projectID, "mssql", "dbo", "order]s")
+
+	runPendingMigrations(t, connStr)
+
+	var joinClause string
+	require.NoError(t, db.QueryRow(`SELECT join_clause FROM engine_schema_relationships WHERE id = $1`, postgresRel).Scan(&joinClause))
+	assert.Equal(t, `"public"."order""s"."user_id" = "public"."users"."id"`, joinClause)
+
+	require.NoError(t, db.QueryRow(`SELECT join_clause FROM engine_schema_relationships WHERE id = $1`, mssqlRel).Scan(&joinClause))
+	assert.Equal(t, `[dbo].[order]]s].[user_id] = [dbo].[users].[id]`, joinClause)
+}
+
+// insertMigrationTestRelationship creates a datasource of dsType with a
+// sourceTable.user_id -> users.id relationship and returns the relationship ID.
+func insertMigrationTestRelationship(t *testing.T, db *sql.DB, projectID uuid.UUID, dsType, schemaName, sourceTable string) uuid.UUID {
+	t.Helper()
+
+	datasourceID := uuid.New()
+	_, err := db.Exec(`
+		INSERT INTO engine_datasources (id, project_id, name, datasource_type, datasource_config)
+		VALUES ($1, $2, $3, $4, '{}')
+	`, datasourceID, projectID, dsType+"-ds", dsType)
+	require.NoError(t, err, "Failed to create test datasource")
+
+	sourceTableID, targetTableID := uuid.New(), uuid.New()
+	_, err = db.Exec(`
+		INSERT INTO engine_schema_tables (id, project_id, datasource_id, schema_name, table_name)
+		VALUES ($1, $3, $4, $5, $6), ($2, $3, $4, $5, 'users')
+	`, sourceTableID, targetTableID, projectID, datasourceID, schemaName, sourceTable)
+	require.NoError(t, err, "Failed to create test tables")
+
+	sourceColumnID, targetColumnID := uuid.New(), uuid.New()
+	_, err = db.Exec(`
+		INSERT INTO engine_schema_columns (id, project_id, schema_table_id, column_name, data_type, is_nullable, ordinal_position)
+		VALUES ($1, $3, $4, 'user_id', 'uuid', false, 1), ($2, $3, $5, 'id', 'uuid', false, 1)
+	`, sourceColumnID, targetColumnID, projectID, sourceTableID, targetTableID)
+	require.NoError(t, err, "Failed to create test columns")
+
+	relationshipID := uuid.New()
+	_, err = db.Exec(`
+		INSERT INTO engine_schema_relationships
+			(id, project_id, source_table_id, source_column_id, target_table_id, target_column_id, relationship_type)
+		VALUES ($1, $2, $3, $4, $5, $6, 'fk')
+	`, relationshipID, projectID, sourceTableID, sourceColumnID, targetTableID, targetColumnID)
+	require.NoError(t, err, "Failed to create test relationship")
+
+	return relationshipID
+}
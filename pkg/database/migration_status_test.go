@@ -0,0 +1,74 @@
+package database
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func writeMigrations(t *testing.T, names ...string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("SELECT 1;"), 0o600); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func TestBuildMigrationStatus_SplitsAppliedAndPending(t *testing.T) {
+	dir := writeMigrations(t,
+		"001_foundation.up.sql", "001_foundation.down.sql",
+		"002_datasources.up.sql", "002_datasources.down.sql",
+		"004_queries.up.sql", "004_queries.down.sql",
+	)
+
+	status, err := buildMigrationStatus(os.DirFS(dir), 2, false)
+	if err != nil {
+		t.Fatalf("buildMigrationStatus() error = %v", err)
+	}
+
+	if !slices.Equal(status.Applied, []uint{1, 2}) {
+		t.Errorf("Applied = %v, want [1 2]", status.Applied)
+	}
+	if !slices.Equal(status.Pending, []uint{4}) {
+		t.Errorf("Pending = %v, want [4]", status.Pending)
+	}
+	if status.Version != 2 || status.Latest != 4 {
+		t.Errorf("Version = %d, Latest = %d, want 2 and 4", status.Version, status.Latest)
+	}
+	if status.UpToDate() {
+		t.Error("UpToDate() = true with a pending migration")
+	}
+}
+
+func TestBuildMigrationStatus_FreshDatabaseHasEverythingPending(t *testing.T) {
+	dir := writeMigrations(t, "001_foundation.up.sql", "002_datasources.up.sql")
+
+	status, err := buildMigrationStatus(os.DirFS(dir), 0, false)
+	if err != nil {
+		t.Fatalf("buildMigrationStatus() error = %v", err)
+	}
+
+	if len(status.Applied) != 0 || !slices.Equal(status.Pending, []uint{1, 2}) {
+		t.Errorf("Applied = %v, Pending = %v, want none applied and [1 2] pending", status.Applied, status.Pending)
+	}
+}
+
+func TestBuildMigrationStatus_DirtyIsNotUpToDate(t *testing.T) {
+	dir := writeMigrations(t, "001_foundation.up.sql")
+
+	status, err := buildMigrationStatus(os.DirFS(dir), 1, true)
+	if err != nil {
+		t.Fatalf("buildMigrationStatus() error = %v", err)
+	}
+
+	if len(status.Pending) != 0 {
+		t.Errorf("Pending = %v, want none", status.Pending)
+	}
+	if status.UpToDate() {
+		t.Error("UpToDate() = true for a dirty schema")
+	}
+}
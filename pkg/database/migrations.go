@@ -1,8 +1,12 @@
 package database
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"io/fs"
+	"os"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
@@ -12,34 +16,42 @@ import (
 	"github.com/ekaya-inc/ekaya-engine/migrations"
 )
 
+// MigrationStatus reports the engine database's schema version against the
+// migrations shipped with the binary.
+type MigrationStatus struct {
+	Version uint   `json:"version"`         // Last applied migration; 0 when none has run
+	Dirty   bool   `json:"dirty,omitempty"` // Version failed part way and must be repaired by hand
+	Latest  uint   `json:"latest"`          // Newest migration available
+	Applied []uint `json:"applied"`
+	Pending []uint `json:"pending"`
+}
+
+// UpToDate reports whether every available migration has been applied cleanly.
+func (s *MigrationStatus) UpToDate() bool {
+	return !s.Dirty && len(s.Pending) == 0
+}
+
 // RunMigrations executes pending database migrations from the embedded filesystem.
 // It is idempotent and safe to call multiple times - only pending migrations will be executed.
+//
+// Each migration is recorded as it completes, so a run that is interrupted resumes
+// from the last applied version. The migrate driver holds a Postgres advisory lock
+// for the whole run, so instances starting at the same time apply migrations one
+// after another instead of racing; the later ones find nothing left to do.
 func RunMigrations(db *sql.DB, logger *zap.Logger) error {
-	driver, err := postgres.WithInstance(db, &postgres.Config{})
-	if err != nil {
-		return fmt.Errorf("failed to create migration driver: %w", err)
-	}
-
-	sourceDriver, err := iofs.New(migrations.FS, ".")
+	m, err := newMigrator(db, migrations.FS)
 	if err != nil {
-		return fmt.Errorf("failed to create migration source: %w", err)
+		return err
 	}
+	defer closeMigrator(m, logger)
 
-	m, err := migrate.NewWithInstance("iofs", sourceDriver, "postgres", driver)
-	if err != nil {
-		return fmt.Errorf("failed to create migration instance: %w", err)
+	// A dirty version means a migration failed part way; running Up again would
+	// fail with an opaque error, so say what happened and how to recover.
+	if version, dirty, err := m.Version(); err == nil && dirty {
+		return fmt.Errorf("database schema is dirty at migration %d: a previous run failed part way; "+
+			"repair the schema by hand, then clear the dirty flag in schema_migrations", version)
 	}
 
-	defer func() {
-		srcErr, dbErr := m.Close()
-		if srcErr != nil {
-			logger.Warn("Failed to close migration source", zap.Error(srcErr))
-		}
-		if dbErr != nil {
-			logger.Warn("Failed to close migration database", zap.Error(dbErr))
-		}
-	}()
-
 	err = m.Up()
 	if err == migrate.ErrNoChange {
 		logger.Info("No migrations to apply (database up-to-date)")
@@ -53,3 +65,89 @@ func RunMigrations(db *sql.DB, logger *zap.Logger) error {
 	logger.Info("Applied migrations successfully", zap.Uint("version", newVersion))
 	return nil
 }
+
+// GetMigrationStatus reports the applied and pending migrations without running any.
+func GetMigrationStatus(db *sql.DB, logger *zap.Logger) (*MigrationStatus, error) {
+	m, err := newMigrator(db, migrations.FS)
+	if err != nil {
+		return nil, err
+	}
+	defer closeMigrator(m, logger)
+
+	version, dirty, err := m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return nil, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return buildMigrationStatus(migrations.FS, version, dirty)
+}
+
+// buildMigrationStatus splits the migrations in fsys into those at or below the
+// applied version and those still pending.
+func buildMigrationStatus(fsys fs.FS, version uint, dirty bool) (*MigrationStatus, error) {
+	sourceDriver, err := iofs.New(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create migration source: %w", err)
+	}
+	defer sourceDriver.Close()
+
+	status := &MigrationStatus{
+		Version: version,
+		Dirty:   dirty,
+		Applied: []uint{},
+		Pending: []uint{},
+	}
+	v, err := sourceDriver.First()
+	for err == nil {
+		if v <= version {
+			status.Applied = append(status.Applied, v)
+		} else {
+			status.Pending = append(status.Pending, v)
+		}
+		status.Latest = v
+		v, err = sourceDriver.Next(v)
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to list migrations: %w", err)
+	}
+	return status, nil
+}
+
+// newMigrator creates a migrator on a single connection taken from db. Closing the
+// migrator releases that connection but leaves db open, so callers can keep using
+// it (postgres.WithInstance would close db along with the migrator).
+func newMigrator(db *sql.DB, fsys fs.FS) (*migrate.Migrate, error) {
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire migration connection: %w", err)
+	}
+
+	driver, err := postgres.WithConnection(ctx, conn, &postgres.Config{})
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to create migration driver: %w", err)
+	}
+
+	sourceDriver, err := iofs.New(fsys, ".")
+	if err != nil {
+		_ = driver.Close()
+		return nil, fmt.Errorf("failed to create migration source: %w", err)
+	}
+
+	m, err := migrate.NewWithInstance("iofs", sourceDriver, "postgres", driver)
+	if err != nil {
+		_ = driver.Close()
+		return nil, fmt.Errorf("failed to create migration instance: %w", err)
+	}
+	return m, nil
+}
+
+func closeMigrator(m *migrate.Migrate, logger *zap.Logger) {
+	srcErr, dbErr := m.Close()
+	if srcErr != nil {
+		logger.Warn("Failed to close migration source", zap.Error(srcErr))
+	}
+	if dbErr != nil {
+		logger.Warn("Failed to close migration database", zap.Error(dbErr))
+	}
+}
@@ -204,7 +204,6 @@ func Test_Migrations_SuccessWithProperPermissions(t *testing.T) {
 	}
 
 	// Verify migrations actually ran by checking for a table
-	// Note: RunMigrations closes the connection, so we need a fresh one for verification
 	verifyDB, err := sql.Open("pgx", connStr)
 	require.NoError(t, err, "Failed to open verification connection")
 	defer verifyDB.Close()
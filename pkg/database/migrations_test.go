@@ -0,0 +1,37 @@
+//go:build integration
+
+package database_test
+
+import (
+	"database/sql"
+	"testing"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/database"
+	"github.com/ekaya-inc/ekaya-engine/pkg/testhelpers"
+)
+
+// Test_GetMigrationStatus_AfterRunMigrations reads the schema status on the same
+// connection RunMigrations used, as the server startup and migrate command do.
+func Test_GetMigrationStatus_AfterRunMigrations(t *testing.T) {
+	engineDB := testhelpers.GetEngineDB(t)
+
+	db, err := sql.Open("pgx", engineDB.ConnStr)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, database.RunMigrations(db, zap.NewNop()))
+
+	status, err := database.GetMigrationStatus(db, zap.NewNop())
+	require.NoError(t, err, "RunMigrations must leave the connection open")
+	assert.True(t, status.UpToDate())
+	assert.Equal(t, status.Latest, status.Version)
+	assert.Empty(t, status.Pending)
+
+	// The status read must not close the connection either
+	require.NoError(t, db.Ping())
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/auth"
+	"github.com/ekaya-inc/ekaya-engine/pkg/services"
+)
+
+// ExtractionEstimateHandler handles dry-run extraction cost estimates.
+type ExtractionEstimateHandler struct {
+	estimateService services.ExtractionEstimateService
+	logger          *zap.Logger
+}
+
+// NewExtractionEstimateHandler creates a new extraction estimate handler.
+func NewExtractionEstimateHandler(estimateService services.ExtractionEstimateService, logger *zap.Logger) *ExtractionEstimateHandler {
+	return &ExtractionEstimateHandler{
+		estimateService: estimateService,
+		logger:          logger,
+	}
+}
+
+// RegisterRoutes registers extraction estimate routes.
+func (h *ExtractionEstimateHandler) RegisterRoutes(mux *http.ServeMux, authMiddleware *auth.Middleware, tenantMiddleware TenantMiddleware) {
+	mux.HandleFunc("GET /api/projects/{pid}/extract/estimate",
+		authMiddleware.RequireAuthWithPathValidation("pid")(tenantMiddleware(h.Estimate)))
+}
+
+// Estimate handles GET /api/projects/{pid}/extract/estimate.
+// Returns the projected token usage and cost of extracting the selected tables, per
+// extraction phase, without sending anything to the LLM, so users can trim the
+// selection first. Phases that cannot be projected before a run are listed as not
+// estimated, with the reason, and left out of the totals.
+func (h *ExtractionEstimateHandler) Estimate(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := ParseProjectID(w, r, h.logger)
+	if !ok {
+		return
+	}
+
+	estimate, err := h.estimateService.Estimate(r.Context(), projectID)
+	if err != nil {
+		h.logger.Error("Failed to estimate extraction",
+			zap.String("project_id", projectID.String()),
+			zap.Error(err))
+		if err := ErrorResponse(w, http.StatusInternalServerError, "estimate_failed", "Failed to estimate extraction"); err != nil {
+			h.logger.Error("Failed to write error response", zap.Error(err))
+		}
+		return
+	}
+
+	if err := WriteJSON(w, http.StatusOK, ApiResponse{Success: true, Data: estimate}); err != nil {
+		h.logger.Error("Failed to write response", zap.Error(err))
+	}
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+)
+
+type mockExtractionEstimateService struct {
+	estimateFn func(ctx context.Context, projectID uuid.UUID) (*models.ExtractionEstimate, error)
+}
+
+func (m *mockExtractionEstimateService) Estimate(ctx context.Context, projectID uuid.UUID) (*models.ExtractionEstimate, error) {
+	return m.estimateFn(ctx, projectID)
+}
+
+func newExtractionEstimateRequest(projectID uuid.UUID) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/api/projects/"+projectID.String()+"/extract/estimate", nil)
+	req.SetPathValue("pid", projectID.String())
+	return req
+}
+
+func TestExtractionEstimateHandler_Estimate_ReturnsEstimate(t *testing.T) {
+	projectID := uuid.New()
+	handler := NewExtractionEstimateHandler(&mockExtractionEstimateService{
+		estimateFn: func(ctx context.Context, gotProjectID uuid.UUID) (*models.ExtractionEstimate, error) {
+			if gotProjectID != projectID {
+				t.Fatalf("unexpected project id: %s", gotProjectID)
+			}
+			return &models.ExtractionEstimate{
+				TableCount: 3,
+				Phases: []models.ExtractionPhaseEstimate{
+					{Phase: models.DAGNodeColumnEnrichment, Status: models.ExtractionPhaseEstimated, PromptCount: 3, InputTokens: 4200},
+					{Phase: models.DAGNodeTableFeatureExtraction, Status: models.ExtractionPhaseNotEstimated, Reason: "built during the run"},
+				},
+				InputTokens: 4200,
+				Model:       "gpt-4o",
+				Cost:        &models.ExtractionCostUSD{Low: 0.01, High: 0.02},
+			}, nil
+		},
+	}, zap.NewNop())
+
+	rec := httptest.NewRecorder()
+	handler.Estimate(rec, newExtractionEstimateRequest(projectID))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp struct {
+		Success bool                      `json:"success"`
+		Data    models.ExtractionEstimate `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !resp.Success || resp.Data.InputTokens != 4200 || resp.Data.Cost == nil || resp.Data.Cost.High != 0.02 {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+	if len(resp.Data.Phases) != 2 || resp.Data.Phases[1].Status != models.ExtractionPhaseNotEstimated {
+		t.Errorf("expected the per-phase breakdown with the unestimated phase, got %+v", resp.Data.Phases)
+	}
+}
+
+func TestExtractionEstimateHandler_Estimate_ServiceError(t *testing.T) {
+	handler := NewExtractionEstimateHandler(&mockExtractionEstimateService{
+		estimateFn: func(ctx context.Context, projectID uuid.UUID) (*models.ExtractionEstimate, error) {
+			return nil, errors.New("boom")
+		},
+	}, zap.NewNop())
+
+	rec := httptest.NewRecorder()
+	handler.Estimate(rec, newExtractionEstimateRequest(uuid.New()))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected status 500, got %d", rec.Code)
+	}
+}
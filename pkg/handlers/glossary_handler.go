@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 
 	"github.com/ekaya-inc/ekaya-engine/pkg/apperrors"
@@ -196,6 +197,8 @@ func (h *GlossaryHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.answerQuestionsFromTerm(r.Context(), projectID, term)
+
 	if err := WriteJSON(w, http.StatusCreated, ApiResponse{Success: true, Data: term}); err != nil {
 		h.logger.Error("Failed to write response", zap.Error(err))
 	}
@@ -307,11 +310,32 @@ func (h *GlossaryHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.answerQuestionsFromTerm(r.Context(), projectID, updatedTerm)
+
 	if err := WriteJSON(w, http.StatusOK, ApiResponse{Success: true, Data: updatedTerm}); err != nil {
 		h.logger.Error("Failed to write response", zap.Error(err))
 	}
 }
 
+// answerQuestionsFromTerm auto-answers pending questions the saved term resolves.
+// Failures are logged; the term itself has already been saved.
+func (h *GlossaryHandler) answerQuestionsFromTerm(ctx context.Context, projectID uuid.UUID, term *models.BusinessGlossaryTerm) {
+	answered, err := h.questionService.AnswerFromGlossary(ctx, projectID, []*models.BusinessGlossaryTerm{term})
+	if err != nil {
+		h.logger.Warn("Failed to answer questions from glossary term",
+			zap.String("project_id", projectID.String()),
+			zap.String("term", term.Term),
+			zap.Error(err))
+		return
+	}
+	if answered > 0 {
+		h.logger.Info("Answered pending questions from glossary term",
+			zap.String("project_id", projectID.String()),
+			zap.String("term", term.Term),
+			zap.Int("answered", answered))
+	}
+}
+
 // Delete handles DELETE /api/projects/{pid}/glossary/{tid}
 func (h *GlossaryHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	_, ok := ParseProjectID(w, r, h.logger)
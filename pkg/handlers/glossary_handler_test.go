@@ -39,6 +39,9 @@ func (m *mockGlossaryServiceForHandler) CreateTerm(ctx context.Context, projectI
 func (m *mockGlossaryServiceForHandler) UpdateTerm(ctx context.Context, term *models.BusinessGlossaryTerm) error {
 	return nil
 }
+func (m *mockGlossaryServiceForHandler) UpsertTerm(ctx context.Context, projectID uuid.UUID, term *models.BusinessGlossaryTerm) (bool, error) {
+	return true, nil
+}
 func (m *mockGlossaryServiceForHandler) DeleteTerm(ctx context.Context, termID uuid.UUID) error {
 	return nil
 }
@@ -86,6 +89,7 @@ func (m *mockGlossaryServiceForHandler) RunAutoGenerate(ctx context.Context, pro
 type mockQuestionServiceForHandler struct {
 	pendingCounts    *repositories.QuestionCounts
 	pendingCountsErr error
+	glossaryTerms    []*models.BusinessGlossaryTerm // Terms passed to AnswerFromGlossary
 }
 
 func (m *mockQuestionServiceForHandler) GetNextQuestion(ctx context.Context, projectID uuid.UUID, includeSkipped bool) (*models.OntologyQuestion, error) {
@@ -94,6 +98,9 @@ func (m *mockQuestionServiceForHandler) GetNextQuestion(ctx context.Context, pro
 func (m *mockQuestionServiceForHandler) GetPendingQuestions(ctx context.Context, projectID uuid.UUID) ([]*models.OntologyQuestion, error) {
 	return nil, nil
 }
+func (m *mockQuestionServiceForHandler) ListQuestions(ctx context.Context, projectID uuid.UUID, filters repositories.QuestionListFilters) (*repositories.QuestionListResult, error) {
+	return &repositories.QuestionListResult{}, nil
+}
 func (m *mockQuestionServiceForHandler) GetPendingCount(ctx context.Context, projectID uuid.UUID) (int, error) {
 	return 0, nil
 }
@@ -109,6 +116,19 @@ func (m *mockQuestionServiceForHandler) AnswerQuestion(ctx context.Context, ques
 func (m *mockQuestionServiceForHandler) SkipQuestion(ctx context.Context, questionID uuid.UUID) error {
 	return nil
 }
+func (m *mockQuestionServiceForHandler) AnswerFromGlossary(ctx context.Context, projectID uuid.UUID, terms []*models.BusinessGlossaryTerm) (int, error) {
+	m.glossaryTerms = append(m.glossaryTerms, terms...)
+	return 0, nil
+}
+func (m *mockQuestionServiceForHandler) TrimQuestionsPerTable(ctx context.Context, projectID uuid.UUID, maxPerTable int) (int, error) {
+	return 0, nil
+}
+func (m *mockQuestionServiceForHandler) FindOrphanedQuestions(ctx context.Context, projectID uuid.UUID) ([]*models.OntologyQuestion, error) {
+	return nil, nil
+}
+func (m *mockQuestionServiceForHandler) CleanupOrphanedQuestions(ctx context.Context, projectID uuid.UUID, deleteQuestions bool) (int, error) {
+	return 0, nil
+}
 func (m *mockQuestionServiceForHandler) DeleteQuestion(ctx context.Context, questionID uuid.UUID) error {
 	return nil
 }
@@ -158,6 +178,31 @@ func TestGlossaryHandler_List_IncludesGenerationStatus(t *testing.T) {
 	assert.Equal(t, "Discovering glossary terms from ontology...", listResponse.GenerationStatus.Message)
 }
 
+// ============================================================================
+// Create Handler Tests
+// ============================================================================
+
+func TestGlossaryHandler_Create_AnswersQuestionsFromTerm(t *testing.T) {
+	projectID := uuid.New()
+
+	mockQuestions := &mockQuestionServiceForHandler{}
+	handler := NewGlossaryHandler(&mockGlossaryServiceForHandler{}, mockQuestions, zap.NewNop())
+
+	body := `{"term": "MRR", "definition": "Monthly recurring revenue.", "defining_sql": "SELECT SUM(mrr) FROM subscriptions"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/projects/"+projectID.String()+"/glossary",
+		bytes.NewReader([]byte(body)))
+	req.SetPathValue("pid", projectID.String())
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	handler.Create(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	require.Len(t, mockQuestions.glossaryTerms, 1)
+	assert.Equal(t, "MRR", mockQuestions.glossaryTerms[0].Term)
+	assert.Equal(t, "Monthly recurring revenue.", mockQuestions.glossaryTerms[0].Definition)
+}
+
 // ============================================================================
 // AutoGenerate Handler Tests
 // ============================================================================
@@ -96,7 +96,7 @@ func setupGlossaryTest(t *testing.T) *glossaryTestContext {
 	// Create handler
 	questionService := services.NewOntologyQuestionService(
 		repositories.NewOntologyQuestionRepository(), columnMetadataRepo, schemaRepo,
-		knowledgeRepo, nil, zap.NewNop())
+		knowledgeRepo, nil, nil, zap.NewNop())
 	handler := NewGlossaryHandler(service, questionService, zap.NewNop())
 
 	// Use a unique project ID for consistent testing
@@ -164,6 +164,10 @@ func (c *mockLLMClient) GetEndpoint() string {
 	return "https://test.endpoint"
 }
 
+func (c *mockLLMClient) GenerateResponseStream(ctx context.Context, prompt string, systemMessage string, temperature float64) (*llm.ResponseStream, error) {
+	return llm.CompletedResponseStream(c.GenerateResponse(ctx, prompt, systemMessage, temperature, false))
+}
+
 // doRequest creates an HTTP request with proper context and executes the handler.
 func (tc *glossaryTestContext) doRequest(method, path string, body any, handler http.HandlerFunc, pathValues map[string]string) *httptest.ResponseRecorder {
 	tc.t.Helper()
@@ -24,15 +24,17 @@ type PingResponse struct {
 // HealthResponse contains comprehensive health check information including
 // connection manager statistics for monitoring connection pool health.
 type HealthResponse struct {
-	Status      string                      `json:"status"`
-	Connections *datasource.ConnectionStats `json:"connections,omitempty"`
+	Status        string                      `json:"status"`
+	SchemaVersion *uint                       `json:"schema_version,omitempty"` // Engine database migration version
+	Connections   *datasource.ConnectionStats `json:"connections,omitempty"`
 }
 
 // HealthHandler handles health check and ping endpoints.
 type HealthHandler struct {
-	cfg         *config.Config
-	connManager *datasource.ConnectionManager
-	logger      *zap.Logger
+	cfg           *config.Config
+	connManager   *datasource.ConnectionManager
+	schemaVersion *uint
+	logger        *zap.Logger
 }
 
 // NewHealthHandler creates a new HealthHandler with the given configuration.
@@ -45,6 +47,11 @@ func NewHealthHandler(cfg *config.Config, connManager *datasource.ConnectionMana
 	}
 }
 
+// SetSchemaVersion records the engine database's migration version, reported by /health.
+func (h *HealthHandler) SetSchemaVersion(version uint) {
+	h.schemaVersion = &version
+}
+
 // RegisterRoutes registers the health handler's routes on the given mux.
 func (h *HealthHandler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("/health", h.Health)
@@ -56,7 +63,8 @@ func (h *HealthHandler) RegisterRoutes(mux *http.ServeMux) {
 // Returns comprehensive health status including connection manager statistics.
 func (h *HealthHandler) Health(w http.ResponseWriter, r *http.Request) {
 	response := HealthResponse{
-		Status: "ok",
+		Status:        "ok",
+		SchemaVersion: h.schemaVersion,
 	}
 
 	// Include connection manager stats if available
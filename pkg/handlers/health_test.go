@@ -44,6 +44,22 @@ func TestHealthHandler_Health_WithoutConnManager(t *testing.T) {
 	}
 }
 
+func TestHealthHandler_Health_ReportsSchemaVersion(t *testing.T) {
+	handler := NewHealthHandler(&config.Config{Version: "test-version"}, nil, zap.NewNop())
+	handler.SetSchemaVersion(32)
+
+	rec := httptest.NewRecorder()
+	handler.Health(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	var response HealthResponse
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.SchemaVersion == nil || *response.SchemaVersion != 32 {
+		t.Errorf("expected schema_version 32, got %v", response.SchemaVersion)
+	}
+}
+
 func TestHealthHandler_Health_WithConnManager(t *testing.T) {
 	cfg := &config.Config{
 		Version: "test-version",
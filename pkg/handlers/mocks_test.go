@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/ekaya-inc/ekaya-engine/pkg/apperrors"
 	"github.com/ekaya-inc/ekaya-engine/pkg/auth"
 	"github.com/ekaya-inc/ekaya-engine/pkg/models"
 	"github.com/ekaya-inc/ekaya-engine/pkg/services"
@@ -247,13 +248,15 @@ func (m *mockDatasourceService) Rename(ctx context.Context, id uuid.UUID, name s
 
 // mockSchemaService is a configurable mock for schema handler tests.
 type mockSchemaService struct {
-	schema        *models.DatasourceSchema
-	table         *models.DatasourceTable
-	relationships []*models.SchemaRelationship
-	relationship  *models.SchemaRelationship
-	refreshResult *models.RefreshResult
-	prompt        string
-	err           error
+	schema            *models.DatasourceSchema
+	table             *models.DatasourceTable
+	relationships     []*models.SchemaRelationship
+	relationship      *models.SchemaRelationship
+	relationshipStats *models.RelationshipStats
+	refreshResult     *models.RefreshResult
+	tables            []*models.SchemaTable
+	prompt            string
+	err               error
 }
 
 func (m *mockSchemaService) RefreshDatasourceSchema(ctx context.Context, projectID, datasourceID uuid.UUID, autoSelect bool) (*models.RefreshResult, error) {
@@ -270,6 +273,23 @@ func (m *mockSchemaService) RefreshDatasourceSchema(ctx context.Context, project
 	}, nil
 }
 
+func (m *mockSchemaService) InventoryDatasourceSchema(ctx context.Context, projectID, datasourceID uuid.UUID) (*models.SchemaInventoryResult, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &models.SchemaInventoryResult{Tables: []models.SchemaInventoryTable{}}, nil
+}
+
+func (m *mockSchemaService) IntrospectTables(ctx context.Context, projectID, datasourceID uuid.UUID, req *models.IntrospectTablesRequest) (*models.RefreshResult, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if m.refreshResult != nil {
+		return m.refreshResult, nil
+	}
+	return &models.RefreshResult{}, nil
+}
+
 func (m *mockSchemaService) GetDatasourceSchema(ctx context.Context, projectID, datasourceID uuid.UUID) (*models.DatasourceSchema, error) {
 	if m.err != nil {
 		return nil, m.err
@@ -320,6 +340,26 @@ func (m *mockSchemaService) AddManualRelationship(ctx context.Context, projectID
 	}, nil
 }
 
+func (m *mockSchemaService) AddManualRelationshipByColumns(ctx context.Context, projectID uuid.UUID, req *models.AddColumnRelationshipRequest) (*models.SchemaRelationship, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if m.relationship != nil {
+		return m.relationship, nil
+	}
+	return &models.SchemaRelationship{
+		ID:               uuid.New(),
+		ProjectID:        projectID,
+		SourceTableID:    uuid.New(),
+		SourceColumnID:   req.SourceColumnID,
+		TargetTableID:    uuid.New(),
+		TargetColumnID:   req.TargetColumnID,
+		RelationshipType: "manual",
+		Cardinality:      "N:1",
+		Confidence:       1.0,
+	}, nil
+}
+
 func (m *mockSchemaService) UpdateRelationship(ctx context.Context, projectID, relationshipID uuid.UUID, req *models.UpdateRelationshipRequest) (*models.SchemaRelationship, error) {
 	if m.err != nil {
 		return nil, m.err
@@ -392,12 +432,31 @@ func (m *mockSchemaService) GetRelationshipsResponse(ctx context.Context, projec
 	}, nil
 }
 
+func (m *mockSchemaService) GetRelationshipStats(ctx context.Context, projectID, datasourceID uuid.UUID) (*models.RelationshipStats, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.relationshipStats, nil
+}
+
+func (m *mockSchemaService) GetRelationshipMetrics(ctx context.Context, projectID, relationshipID uuid.UUID) (*models.RelationshipMetrics, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	for _, rel := range m.relationships {
+		if rel.ID == relationshipID {
+			return models.NewRelationshipMetrics(rel), nil
+		}
+	}
+	return nil, apperrors.ErrNotFound
+}
+
 func (m *mockSchemaService) SelectAllTables(ctx context.Context, projectID, datasourceID uuid.UUID) error {
 	return m.err
 }
 
 func (m *mockSchemaService) ListTablesByDatasource(ctx context.Context, projectID, datasourceID uuid.UUID) ([]*models.SchemaTable, error) {
-	return nil, m.err
+	return m.tables, m.err
 }
 
 func (m *mockSchemaService) ListAllTablesByDatasource(ctx context.Context, projectID, datasourceID uuid.UUID) ([]*models.SchemaTable, error) {
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"go.uber.org/zap"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/apperrors"
+	"github.com/ekaya-inc/ekaya-engine/pkg/auth"
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+	"github.com/ekaya-inc/ekaya-engine/pkg/services"
+)
+
+// OntologyContextDocumentHandler serves the ontology as a single text document sized
+// for a text-to-SQL prompt.
+type OntologyContextDocumentHandler struct {
+	documentService services.OntologyContextDocumentService
+	logger          *zap.Logger
+}
+
+// NewOntologyContextDocumentHandler creates a new ontology context document handler.
+func NewOntologyContextDocumentHandler(documentService services.OntologyContextDocumentService, logger *zap.Logger) *OntologyContextDocumentHandler {
+	return &OntologyContextDocumentHandler{
+		documentService: documentService,
+		logger:          logger,
+	}
+}
+
+// RegisterRoutes registers ontology context document routes.
+func (h *OntologyContextDocumentHandler) RegisterRoutes(mux *http.ServeMux, authMiddleware *auth.Middleware, tenantMiddleware TenantMiddleware) {
+	mux.HandleFunc("GET /api/projects/{pid}/ontology/context",
+		authMiddleware.RequireAuthWithPathValidation("pid")(
+			auth.RequireRole(models.RoleAdmin, models.RoleData)(tenantMiddleware(h.Get))))
+}
+
+// Get handles GET /api/projects/{pid}/ontology/context?max_tokens=N.
+// Without max_tokens the configured default budget is used.
+func (h *OntologyContextDocumentHandler) Get(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := ParseProjectID(w, r, h.logger)
+	if !ok {
+		return
+	}
+
+	maxTokens := 0
+	if raw := r.URL.Query().Get("max_tokens"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < services.MinContextDocumentTokenBudget || n > services.MaxContextDocumentTokenBudget {
+			if err := ErrorResponse(w, http.StatusBadRequest, "invalid_max_tokens",
+				fmt.Sprintf("max_tokens must be a number between %d and %d",
+					services.MinContextDocumentTokenBudget, services.MaxContextDocumentTokenBudget)); err != nil {
+				h.logger.Error("Failed to write error response", zap.Error(err))
+			}
+			return
+		}
+		maxTokens = n
+	}
+
+	doc, err := h.documentService.BuildDocument(r.Context(), projectID, maxTokens)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNoSelectedTables) {
+			if err := ErrorResponse(w, http.StatusBadRequest, "no_selected_tables",
+				"No tables to describe: select at least one table before building the context document"); err != nil {
+				h.logger.Error("Failed to write error response", zap.Error(err))
+			}
+			return
+		}
+
+		h.logger.Error("Failed to build ontology context document",
+			zap.String("project_id", projectID.String()),
+			zap.Error(err))
+		if err := ErrorResponse(w, http.StatusInternalServerError, "context_document_failed", "Failed to build ontology context document"); err != nil {
+			h.logger.Error("Failed to write error response", zap.Error(err))
+		}
+		return
+	}
+
+	if err := WriteJSON(w, http.StatusOK, ApiResponse{Success: true, Data: doc}); err != nil {
+		h.logger.Error("Failed to write response", zap.Error(err))
+	}
+}
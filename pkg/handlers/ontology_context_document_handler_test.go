@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+)
+
+type mockOntologyContextDocumentService struct {
+	gotMaxTokens int
+}
+
+func (m *mockOntologyContextDocumentService) BuildDocument(ctx context.Context, projectID uuid.UUID, maxTokens int) (*models.OntologyContextDocument, error) {
+	m.gotMaxTokens = maxTokens
+	return &models.OntologyContextDocument{Content: "# Shop context\n", TokenBudget: maxTokens}, nil
+}
+
+func newContextDocumentRequest(projectID uuid.UUID, query string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/api/projects/"+projectID.String()+"/ontology/context"+query, nil)
+	req.SetPathValue("pid", projectID.String())
+	return req
+}
+
+func TestOntologyContextDocumentHandler_Get_PassesMaxTokens(t *testing.T) {
+	svc := &mockOntologyContextDocumentService{}
+	handler := NewOntologyContextDocumentHandler(svc, zap.NewNop())
+
+	rec := httptest.NewRecorder()
+	handler.Get(rec, newContextDocumentRequest(uuid.New(), "?max_tokens=4000"))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if svc.gotMaxTokens != 4000 {
+		t.Fatalf("expected max_tokens 4000 passed to the service, got %d", svc.gotMaxTokens)
+	}
+	var resp struct {
+		Success bool                           `json:"success"`
+		Data    models.OntologyContextDocument `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !resp.Success || resp.Data.Content != "# Shop context\n" {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+}
+
+func TestOntologyContextDocumentHandler_Get_RejectsInvalidMaxTokens(t *testing.T) {
+	for _, query := range []string{"?max_tokens=abc", "?max_tokens=10", "?max_tokens=100000000"} {
+		svc := &mockOntologyContextDocumentService{}
+		handler := NewOntologyContextDocumentHandler(svc, zap.NewNop())
+
+		rec := httptest.NewRecorder()
+		handler.Get(rec, newContextDocumentRequest(uuid.New(), query))
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %d", query, rec.Code)
+		}
+	}
+}
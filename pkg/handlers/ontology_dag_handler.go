@@ -2,11 +2,15 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 
+	"github.com/ekaya-inc/ekaya-engine/pkg/apperrors"
 	"github.com/ekaya-inc/ekaya-engine/pkg/auth"
 	"github.com/ekaya-inc/ekaya-engine/pkg/jsonutil"
 	"github.com/ekaya-inc/ekaya-engine/pkg/models"
@@ -28,6 +32,7 @@ type DAGStatusResponse struct {
 	Nodes         []DAGNodeResponse     `json:"nodes"`
 	StartedAt     *string               `json:"started_at,omitempty"`
 	CompletedAt   *string               `json:"completed_at,omitempty"`
+	TokenSpend    *services.TokenSpend  `json:"token_spend,omitempty"` // Spend of the project's current or latest run
 }
 
 // DAGNodeResponse represents a single node within the DAG.
@@ -40,9 +45,10 @@ type DAGNodeResponse struct {
 
 // DAGProgressResponse represents progress within a node.
 type DAGProgressResponse struct {
-	Current int    `json:"current"`
-	Total   int    `json:"total"`
-	Message string `json:"message,omitempty"`
+	Current int             `json:"current"`
+	Total   int             `json:"total"`
+	Message string          `json:"message,omitempty"`
+	Summary json.RawMessage `json:"summary,omitempty"` // Node result counts once the node finishes
 }
 
 // StartExtractionRequest is the request body for starting ontology extraction.
@@ -50,27 +56,52 @@ type StartExtractionRequest struct {
 	ProjectOverview string `json:"project_overview"`
 }
 
+// ExtractionJobResponse is returned when a project-level extraction is started.
+// The job ID is the DAG ID; poll StatusURL for progress.
+type ExtractionJobResponse struct {
+	JobID        string `json:"job_id"`
+	DatasourceID string `json:"datasource_id"`
+	Status       string `json:"status"`
+	StatusURL    string `json:"status_url"`
+}
+
+// ResyncResponse is returned by the resync-and-update endpoint. Job is nil when the
+// refreshed schema matches the current ontology and nothing needs re-analysis.
+type ResyncResponse struct {
+	Refresh       RefreshSchemaResponse  `json:"refresh"`
+	UpToDate      bool                   `json:"up_to_date"`
+	IsIncremental bool                   `json:"is_incremental"`
+	ChangeSummary *models.ChangeSummary  `json:"change_summary,omitempty"`
+	Job           *ExtractionJobResponse `json:"job,omitempty"`
+}
+
 // ============================================================================
 // Handler
 // ============================================================================
 
 // OntologyDAGHandler handles ontology DAG workflow HTTP requests.
 type OntologyDAGHandler struct {
-	dagService     services.OntologyDAGService
-	projectService services.ProjectService
-	logger         *zap.Logger
+	dagService                   services.OntologyDAGService
+	projectService               services.ProjectService
+	schemaService                services.SchemaService
+	schemaChangeDetectionService services.SchemaChangeDetectionService
+	logger                       *zap.Logger
 }
 
 // NewOntologyDAGHandler creates a new ontology DAG handler.
 func NewOntologyDAGHandler(
 	dagService services.OntologyDAGService,
 	projectService services.ProjectService,
+	schemaService services.SchemaService,
+	schemaChangeDetectionService services.SchemaChangeDetectionService,
 	logger *zap.Logger,
 ) *OntologyDAGHandler {
 	return &OntologyDAGHandler{
-		dagService:     dagService,
-		projectService: projectService,
-		logger:         logger,
+		dagService:                   dagService,
+		projectService:               projectService,
+		schemaService:                schemaService,
+		schemaChangeDetectionService: schemaChangeDetectionService,
+		logger:                       logger,
 	}
 }
 
@@ -78,6 +109,21 @@ func NewOntologyDAGHandler(
 func (h *OntologyDAGHandler) RegisterRoutes(mux *http.ServeMux, authMiddleware *auth.Middleware, tenantMiddleware TenantMiddleware) {
 	base := "/api/projects/{pid}/datasources/{dsid}/ontology"
 
+	// Start extraction on the project's default datasource - returns a job handle
+	mux.HandleFunc("POST /api/projects/{pid}/extract",
+		authMiddleware.RequireAuthWithPathValidation("pid")(
+			auth.RequireRole(models.RoleAdmin, models.RoleData)(tenantMiddleware(h.StartProjectExtraction))))
+
+	// Refresh the schema and re-extract only what changed - returns a job handle
+	mux.HandleFunc("POST /api/projects/{pid}/resync-and-update",
+		authMiddleware.RequireAuthWithPathValidation("pid")(
+			auth.RequireRole(models.RoleAdmin, models.RoleData)(tenantMiddleware(h.ResyncAndUpdate))))
+
+	// Cancel a job returned by the extract endpoint
+	mux.HandleFunc("POST /api/projects/{pid}/jobs/{jid}/cancel",
+		authMiddleware.RequireAuthWithPathValidation("pid")(
+			auth.RequireRole(models.RoleAdmin, models.RoleData)(tenantMiddleware(h.CancelJob))))
+
 	// Start/Refresh extraction - triggers DAG execution
 	mux.HandleFunc("POST "+base+"/extract",
 		authMiddleware.RequireAuthWithPathValidation("pid")(
@@ -121,17 +167,284 @@ func (h *OntologyDAGHandler) StartExtraction(w http.ResponseWriter, r *http.Requ
 
 	dag, err := h.dagService.Start(r.Context(), projectID, datasourceID, req.ProjectOverview)
 	if err != nil {
-		h.logger.Error("Failed to start ontology DAG",
+		h.writeStartError(w, projectID, datasourceID, err)
+		return
+	}
+
+	response := ApiResponse{Success: true, Data: h.toDAGResponse(dag)}
+	if err := WriteJSON(w, http.StatusOK, response); err != nil {
+		h.logger.Error("Failed to write response", zap.Error(err))
+	}
+}
+
+// writeStartError reports a failed dagService.Start. Starting with no selected tables
+// is the caller's to fix, so it is a 400 rather than a server error, and resuming an
+// extraction whose token budget is still spent is a 409 until the budget is raised.
+func (h *OntologyDAGHandler) writeStartError(w http.ResponseWriter, projectID, datasourceID uuid.UUID, err error) {
+	if errors.Is(err, apperrors.ErrNoSelectedTables) {
+		if err := ErrorResponse(w, http.StatusBadRequest, "no_selected_tables",
+			"No tables selected for extraction: select at least one table in the datasource schema before extracting"); err != nil {
+			h.logger.Error("Failed to write error response", zap.Error(err))
+		}
+		return
+	}
+	if errors.Is(err, apperrors.ErrTokenBudgetExhausted) {
+		if err := ErrorResponse(w, http.StatusConflict, "token_budget_exhausted", err.Error()); err != nil {
+			h.logger.Error("Failed to write error response", zap.Error(err))
+		}
+		return
+	}
+
+	h.logger.Error("Failed to start ontology DAG",
+		zap.String("project_id", projectID.String()),
+		zap.String("datasource_id", datasourceID.String()),
+		zap.Error(err))
+	if err := ErrorResponse(w, http.StatusInternalServerError, "start_failed", err.Error()); err != nil {
+		h.logger.Error("Failed to write error response", zap.Error(err))
+	}
+}
+
+// StartProjectExtraction handles POST /api/projects/{pid}/extract
+// Starts the extraction workflow on the project's default datasource and returns a job
+// handle immediately; the DAG runs asynchronously. Unlike StartExtraction, an extraction
+// that is already running is rejected instead of returned.
+func (h *OntologyDAGHandler) StartProjectExtraction(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := ParseProjectID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	ctx := r.Context()
+
+	var req StartExtractionRequest
+	if r.Body != nil {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+			if err := ErrorResponse(w, http.StatusBadRequest, "invalid_request", "Invalid request body"); err != nil {
+				h.logger.Error("Failed to write error response", zap.Error(err))
+			}
+			return
+		}
+	}
+
+	datasourceID, ok := h.resolveDefaultDatasource(w, r, projectID)
+	if !ok {
+		return
+	}
+
+	tables, err := h.schemaService.ListTablesByDatasource(ctx, projectID, datasourceID)
+	if err != nil {
+		h.logger.Error("Failed to list selected tables",
 			zap.String("project_id", projectID.String()),
 			zap.String("datasource_id", datasourceID.String()),
 			zap.Error(err))
-		if err := ErrorResponse(w, http.StatusInternalServerError, "start_failed", err.Error()); err != nil {
+		if err := ErrorResponse(w, http.StatusInternalServerError, "list_tables_failed", err.Error()); err != nil {
 			h.logger.Error("Failed to write error response", zap.Error(err))
 		}
 		return
 	}
+	if len(tables) == 0 {
+		h.writeStartError(w, projectID, datasourceID, apperrors.ErrNoSelectedTables)
+		return
+	}
 
-	response := ApiResponse{Success: true, Data: h.toDAGResponse(dag)}
+	if !h.ensureNoRunningExtraction(w, r, datasourceID) {
+		return
+	}
+
+	dag, err := h.dagService.Start(ctx, projectID, datasourceID, req.ProjectOverview)
+	if err != nil {
+		h.writeStartError(w, projectID, datasourceID, err)
+		return
+	}
+
+	response := ApiResponse{Success: true, Data: newExtractionJobResponse(projectID, datasourceID, dag)}
+	if err := WriteJSON(w, http.StatusAccepted, response); err != nil {
+		h.logger.Error("Failed to write response", zap.Error(err))
+	}
+}
+
+// ResyncAndUpdate handles POST /api/projects/{pid}/resync-and-update
+// Refreshes the default datasource's schema, then starts an extraction that re-analyzes
+// only the tables and columns that were added or changed since the last build and
+// re-discovers relationships on changed columns; unchanged tables keep their ontology.
+// Projects without an ontology get a full extraction. When the refresh finds nothing
+// new, no job is started. Pass ?auto_select=true to select newly discovered tables.
+func (h *OntologyDAGHandler) ResyncAndUpdate(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := ParseProjectID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	ctx := r.Context()
+
+	datasourceID, ok := h.resolveDefaultDatasource(w, r, projectID)
+	if !ok {
+		return
+	}
+	if !h.ensureNoRunningExtraction(w, r, datasourceID) {
+		return
+	}
+
+	autoSelect := r.URL.Query().Get("auto_select") == "true"
+	refresh, err := services.RefreshSchemaWithChangeDetection(
+		ctx,
+		h.schemaService,
+		h.schemaChangeDetectionService,
+		h.logger,
+		projectID, datasourceID,
+		autoSelect,
+	)
+	if err != nil {
+		h.logger.Error("Failed to refresh schema",
+			zap.String("project_id", projectID.String()),
+			zap.String("datasource_id", datasourceID.String()),
+			zap.Error(err))
+		if err := ErrorResponse(w, http.StatusInternalServerError, "refresh_schema_failed", "Failed to refresh schema"); err != nil {
+			h.logger.Error("Failed to write error response", zap.Error(err))
+		}
+		return
+	}
+
+	status, err := h.dagService.GetOntologyStatus(ctx, projectID, datasourceID)
+	if err != nil {
+		h.logger.Error("Failed to get ontology status",
+			zap.String("project_id", projectID.String()),
+			zap.String("datasource_id", datasourceID.String()),
+			zap.Error(err))
+		if err := ErrorResponse(w, http.StatusInternalServerError, "get_ontology_status_failed", err.Error()); err != nil {
+			h.logger.Error("Failed to write error response", zap.Error(err))
+		}
+		return
+	}
+
+	data := ResyncResponse{
+		Refresh:       newRefreshSchemaResponse(refresh),
+		IsIncremental: status.HasOntology,
+		ChangeSummary: status.ChangeSummary,
+	}
+	if status.HasOntology && !status.SchemaChangedSinceBuild {
+		data.UpToDate = true
+		if err := WriteJSON(w, http.StatusOK, ApiResponse{Success: true, Data: data}); err != nil {
+			h.logger.Error("Failed to write response", zap.Error(err))
+		}
+		return
+	}
+
+	dag, err := h.dagService.Start(ctx, projectID, datasourceID, "")
+	if err != nil {
+		h.writeStartError(w, projectID, datasourceID, err)
+		return
+	}
+
+	job := newExtractionJobResponse(projectID, datasourceID, dag)
+	data.Job = &job
+	data.IsIncremental = dag.IsIncremental
+	if dag.ChangeSummary != nil {
+		data.ChangeSummary = dag.ChangeSummary
+	}
+	if err := WriteJSON(w, http.StatusAccepted, ApiResponse{Success: true, Data: data}); err != nil {
+		h.logger.Error("Failed to write response", zap.Error(err))
+	}
+}
+
+// resolveDefaultDatasource returns the project's default datasource, writing an error
+// response and returning false when there is none or it is ambiguous.
+func (h *OntologyDAGHandler) resolveDefaultDatasource(w http.ResponseWriter, r *http.Request, projectID uuid.UUID) (uuid.UUID, bool) {
+	datasourceID, err := h.projectService.GetDefaultDatasourceID(r.Context(), projectID)
+	if errors.Is(err, apperrors.ErrAmbiguousDefaultDatasource) {
+		if err := ErrorResponse(w, http.StatusConflict, "ambiguous_default_datasource", err.Error()); err != nil {
+			h.logger.Error("Failed to write error response", zap.Error(err))
+		}
+		return uuid.Nil, false
+	}
+	if err != nil {
+		h.logger.Error("Failed to get default datasource",
+			zap.String("project_id", projectID.String()),
+			zap.Error(err))
+		if err := ErrorResponse(w, http.StatusInternalServerError, "get_default_datasource_failed", err.Error()); err != nil {
+			h.logger.Error("Failed to write error response", zap.Error(err))
+		}
+		return uuid.Nil, false
+	}
+	if datasourceID == uuid.Nil {
+		if err := ErrorResponse(w, http.StatusBadRequest, "no_datasource", "Project has no datasource configured"); err != nil {
+			h.logger.Error("Failed to write error response", zap.Error(err))
+		}
+		return uuid.Nil, false
+	}
+	return datasourceID, true
+}
+
+// ensureNoRunningExtraction writes a 409 and returns false when an extraction is
+// already running on the datasource.
+func (h *OntologyDAGHandler) ensureNoRunningExtraction(w http.ResponseWriter, r *http.Request, datasourceID uuid.UUID) bool {
+	current, err := h.dagService.GetStatus(r.Context(), datasourceID)
+	if err != nil {
+		h.logger.Error("Failed to check for running extraction",
+			zap.String("datasource_id", datasourceID.String()),
+			zap.Error(err))
+		if err := ErrorResponse(w, http.StatusInternalServerError, "get_status_failed", err.Error()); err != nil {
+			h.logger.Error("Failed to write error response", zap.Error(err))
+		}
+		return false
+	}
+	if current != nil && !current.Status.IsTerminal() {
+		if err := ErrorResponse(w, http.StatusConflict, "extraction_running",
+			fmt.Sprintf("Extraction %s is already running for this project", current.ID)); err != nil {
+			h.logger.Error("Failed to write error response", zap.Error(err))
+		}
+		return false
+	}
+	return true
+}
+
+func newExtractionJobResponse(projectID, datasourceID uuid.UUID, dag *models.OntologyDAG) ExtractionJobResponse {
+	return ExtractionJobResponse{
+		JobID:        dag.ID.String(),
+		DatasourceID: datasourceID.String(),
+		Status:       string(dag.Status),
+		StatusURL:    fmt.Sprintf("/api/projects/%s/datasources/%s/ontology/dag", projectID, datasourceID),
+	}
+}
+
+// CancelJob handles POST /api/projects/{pid}/jobs/{jid}/cancel
+// Cancels a running extraction job. Nodes that already completed keep their results;
+// the running node is marked cancelled and pending nodes are skipped.
+func (h *OntologyDAGHandler) CancelJob(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := ParseProjectID(w, r, h.logger)
+	if !ok {
+		return
+	}
+	jobID, ok := ParseJobID(w, r, h.logger)
+	if !ok {
+		return
+	}
+
+	dag, err := h.dagService.CancelJob(r.Context(), projectID, jobID)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrNotFound):
+			if err := ErrorResponse(w, http.StatusNotFound, "job_not_found", "Job not found"); err != nil {
+				h.logger.Error("Failed to write error response", zap.Error(err))
+			}
+		case errors.Is(err, apperrors.ErrConflict):
+			if err := ErrorResponse(w, http.StatusConflict, "job_not_running", err.Error()); err != nil {
+				h.logger.Error("Failed to write error response", zap.Error(err))
+			}
+		default:
+			h.logger.Error("Failed to cancel job",
+				zap.String("project_id", projectID.String()),
+				zap.String("job_id", jobID.String()),
+				zap.Error(err))
+			if err := ErrorResponse(w, http.StatusInternalServerError, "cancel_failed", err.Error()); err != nil {
+				h.logger.Error("Failed to write error response", zap.Error(err))
+			}
+		}
+		return
+	}
+
+	response := ApiResponse{Success: true, Data: map[string]string{
+		"job_id": dag.ID.String(),
+		"status": string(dag.Status),
+	}}
 	if err := WriteJSON(w, http.StatusOK, response); err != nil {
 		h.logger.Error("Failed to write response", zap.Error(err))
 	}
@@ -165,7 +478,10 @@ func (h *OntologyDAGHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response := ApiResponse{Success: true, Data: h.toDAGResponse(dag)}
+	data := h.toDAGResponse(dag)
+	data.TokenSpend = h.dagService.GetTokenSpend(datasourceID)
+
+	response := ApiResponse{Success: true, Data: data}
 	if err := WriteJSON(w, http.StatusOK, response); err != nil {
 		h.logger.Error("Failed to write response", zap.Error(err))
 	}
@@ -314,6 +630,7 @@ func (h *OntologyDAGHandler) toNodeResponse(node *models.DAGNode) DAGNodeRespons
 			Current: node.Progress.Current,
 			Total:   node.Progress.Total,
 			Message: node.Progress.Message,
+			Summary: node.Progress.Summary,
 		}
 	}
 
@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -13,6 +14,7 @@ import (
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 
+	"github.com/ekaya-inc/ekaya-engine/pkg/apperrors"
 	"github.com/ekaya-inc/ekaya-engine/pkg/models"
 	"github.com/ekaya-inc/ekaya-engine/pkg/services"
 )
@@ -22,7 +24,11 @@ type mockOntologyDAGService struct {
 	startFunc     func(ctx context.Context, projectID, datasourceID uuid.UUID, projectOverview string) (*models.OntologyDAG, error)
 	getStatusFunc func(ctx context.Context, datasourceID uuid.UUID) (*models.OntologyDAG, error)
 	cancelFunc    func(ctx context.Context, dagID uuid.UUID) error
+	cancelJobFunc func(ctx context.Context, projectID, jobID uuid.UUID) (*models.OntologyDAG, error)
 	deleteFunc    func(ctx context.Context, projectID uuid.UUID) error
+	tokenSpend    *services.TokenSpend
+
+	getOntologyStatusFunc func(ctx context.Context, projectID, datasourceID uuid.UUID) (*models.OntologyStatusResponse, error)
 }
 
 func (m *mockOntologyDAGService) Start(ctx context.Context, projectID, datasourceID uuid.UUID, projectOverview string) (*models.OntologyDAG, error) {
@@ -39,6 +45,10 @@ func (m *mockOntologyDAGService) GetStatus(ctx context.Context, datasourceID uui
 	return nil, nil
 }
 
+func (m *mockOntologyDAGService) GetTokenSpend(projectID uuid.UUID) *services.TokenSpend {
+	return m.tokenSpend
+}
+
 func (m *mockOntologyDAGService) Cancel(ctx context.Context, dagID uuid.UUID) error {
 	if m.cancelFunc != nil {
 		return m.cancelFunc(ctx, dagID)
@@ -46,6 +56,13 @@ func (m *mockOntologyDAGService) Cancel(ctx context.Context, dagID uuid.UUID) er
 	return nil
 }
 
+func (m *mockOntologyDAGService) CancelJob(ctx context.Context, projectID, jobID uuid.UUID) (*models.OntologyDAG, error) {
+	if m.cancelJobFunc != nil {
+		return m.cancelJobFunc(ctx, projectID, jobID)
+	}
+	return nil, nil
+}
+
 func (m *mockOntologyDAGService) Delete(ctx context.Context, projectID uuid.UUID) error {
 	if m.deleteFunc != nil {
 		return m.deleteFunc(ctx, projectID)
@@ -54,6 +71,9 @@ func (m *mockOntologyDAGService) Delete(ctx context.Context, projectID uuid.UUID
 }
 
 func (m *mockOntologyDAGService) GetOntologyStatus(ctx context.Context, projectID, datasourceID uuid.UUID) (*models.OntologyStatusResponse, error) {
+	if m.getOntologyStatusFunc != nil {
+		return m.getOntologyStatusFunc(ctx, projectID, datasourceID)
+	}
 	return &models.OntologyStatusResponse{}, nil
 }
 
@@ -127,7 +147,7 @@ func TestOntologyDAGHandler_StartExtraction_Success(t *testing.T) {
 		},
 	}
 
-	handler := NewOntologyDAGHandler(mockService, nil, zap.NewNop())
+	handler := NewOntologyDAGHandler(mockService, nil, nil, nil, zap.NewNop())
 
 	url := fmt.Sprintf("/api/projects/%s/datasources/%s/ontology/extract", projectID, datasourceID)
 	req := httptest.NewRequest(http.MethodPost, url, nil)
@@ -177,7 +197,7 @@ func TestOntologyDAGHandler_StartExtraction_ServiceError(t *testing.T) {
 		},
 	}
 
-	handler := NewOntologyDAGHandler(mockService, nil, zap.NewNop())
+	handler := NewOntologyDAGHandler(mockService, nil, nil, nil, zap.NewNop())
 
 	url := fmt.Sprintf("/api/projects/%s/datasources/%s/ontology/extract", projectID, datasourceID)
 	req := httptest.NewRequest(http.MethodPost, url, nil)
@@ -192,6 +212,63 @@ func TestOntologyDAGHandler_StartExtraction_ServiceError(t *testing.T) {
 	}
 }
 
+func TestOntologyDAGHandler_StartExtraction_NoSelectedTables(t *testing.T) {
+	projectID := uuid.New()
+	datasourceID := uuid.New()
+
+	mockService := &mockOntologyDAGService{
+		startFunc: func(ctx context.Context, pID, dsID uuid.UUID, overview string) (*models.OntologyDAG, error) {
+			return nil, apperrors.ErrNoSelectedTables
+		},
+	}
+
+	handler := NewOntologyDAGHandler(mockService, nil, nil, nil, zap.NewNop())
+
+	url := fmt.Sprintf("/api/projects/%s/datasources/%s/ontology/extract", projectID, datasourceID)
+	req := httptest.NewRequest(http.MethodPost, url, nil)
+	req.SetPathValue("pid", projectID.String())
+	req.SetPathValue("dsid", datasourceID.String())
+	rec := httptest.NewRecorder()
+
+	handler.StartExtraction(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusBadRequest, rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), "no_selected_tables") ||
+		!strings.Contains(rec.Body.String(), "No tables selected for extraction") {
+		t.Errorf("expected a no_selected_tables error, got %s", rec.Body.String())
+	}
+}
+
+func TestOntologyDAGHandler_StartExtraction_TokenBudgetExhausted(t *testing.T) {
+	projectID := uuid.New()
+	datasourceID := uuid.New()
+
+	mockService := &mockOntologyDAGService{
+		startFunc: func(ctx context.Context, pID, dsID uuid.UUID, overview string) (*models.OntologyDAG, error) {
+			return nil, fmt.Errorf("%w: extraction has spent 1000 tokens of its 1000 token budget", apperrors.ErrTokenBudgetExhausted)
+		},
+	}
+
+	handler := NewOntologyDAGHandler(mockService, nil, nil, nil, zap.NewNop())
+
+	url := fmt.Sprintf("/api/projects/%s/datasources/%s/ontology/extract", projectID, datasourceID)
+	req := httptest.NewRequest(http.MethodPost, url, nil)
+	req.SetPathValue("pid", projectID.String())
+	req.SetPathValue("dsid", datasourceID.String())
+	rec := httptest.NewRecorder()
+
+	handler.StartExtraction(rec, req)
+
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusConflict, rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), "token_budget_exhausted") {
+		t.Errorf("expected a token_budget_exhausted error, got %s", rec.Body.String())
+	}
+}
+
 func TestOntologyDAGHandler_StartExtraction_WithProjectOverview(t *testing.T) {
 	projectID := uuid.New()
 	datasourceID := uuid.New()
@@ -218,7 +295,7 @@ func TestOntologyDAGHandler_StartExtraction_WithProjectOverview(t *testing.T) {
 		},
 	}
 
-	handler := NewOntologyDAGHandler(mockService, nil, zap.NewNop())
+	handler := NewOntologyDAGHandler(mockService, nil, nil, nil, zap.NewNop())
 
 	url := fmt.Sprintf("/api/projects/%s/datasources/%s/ontology/extract", projectID, datasourceID)
 	body := strings.NewReader(fmt.Sprintf(`{"project_overview": "%s"}`, expectedOverview))
@@ -264,7 +341,7 @@ func TestOntologyDAGHandler_StartExtraction_EmptyBody(t *testing.T) {
 		},
 	}
 
-	handler := NewOntologyDAGHandler(mockService, nil, zap.NewNop())
+	handler := NewOntologyDAGHandler(mockService, nil, nil, nil, zap.NewNop())
 
 	url := fmt.Sprintf("/api/projects/%s/datasources/%s/ontology/extract", projectID, datasourceID)
 	// Send request with nil body (empty POST)
@@ -310,7 +387,7 @@ func TestOntologyDAGHandler_StartExtraction_MalformedJSON(t *testing.T) {
 		},
 	}
 
-	handler := NewOntologyDAGHandler(mockService, nil, zap.NewNop())
+	handler := NewOntologyDAGHandler(mockService, nil, nil, nil, zap.NewNop())
 
 	url := fmt.Sprintf("/api/projects/%s/datasources/%s/ontology/extract", projectID, datasourceID)
 	// Send malformed JSON - extraction should still proceed without overview
@@ -333,6 +410,61 @@ func TestOntologyDAGHandler_StartExtraction_MalformedJSON(t *testing.T) {
 	}
 }
 
+func TestOntologyDAGHandler_GetStatus_IncludesNodeSummary(t *testing.T) {
+	projectID := uuid.New()
+	datasourceID := uuid.New()
+	summary := json.RawMessage(`{"relationships_created":2,"relationships_by_method":{"fk":3,"relationship_discovery":2}}`)
+
+	mockService := &mockOntologyDAGService{
+		getStatusFunc: func(ctx context.Context, dsID uuid.UUID) (*models.OntologyDAG, error) {
+			return &models.OntologyDAG{
+				ID:           uuid.New(),
+				ProjectID:    projectID,
+				DatasourceID: datasourceID,
+				Status:       models.DAGStatusCompleted,
+				Nodes: []models.DAGNode{
+					{ID: uuid.New(), NodeName: "RelationshipDiscovery", NodeOrder: 5, Status: models.DAGNodeStatusCompleted,
+						Progress: &models.DAGNodeProgress{Current: 1, Total: 1, Message: "Discovery complete", Summary: summary}},
+				},
+			}, nil
+		},
+	}
+
+	handler := NewOntologyDAGHandler(mockService, nil, nil, nil, zap.NewNop())
+
+	url := fmt.Sprintf("/api/projects/%s/datasources/%s/ontology/dag", projectID, datasourceID)
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+	req.SetPathValue("pid", projectID.String())
+	req.SetPathValue("dsid", datasourceID.String())
+	rec := httptest.NewRecorder()
+
+	handler.GetStatus(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var response struct {
+		Data DAGStatusResponse `json:"data"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response.Data.Nodes) != 1 || response.Data.Nodes[0].Progress == nil {
+		t.Fatalf("expected one node with progress, got %+v", response.Data.Nodes)
+	}
+
+	var got struct {
+		RelationshipsByMethod map[string]int `json:"relationships_by_method"`
+	}
+	if err := json.Unmarshal(response.Data.Nodes[0].Progress.Summary, &got); err != nil {
+		t.Fatalf("failed to decode node summary: %v", err)
+	}
+	if got.RelationshipsByMethod["fk"] != 3 || got.RelationshipsByMethod["relationship_discovery"] != 2 {
+		t.Errorf("expected per-method counts in node summary, got %v", got.RelationshipsByMethod)
+	}
+}
+
 func TestOntologyDAGHandler_GetStatus_Success(t *testing.T) {
 	projectID := uuid.New()
 	datasourceID := uuid.New()
@@ -365,7 +497,7 @@ func TestOntologyDAGHandler_GetStatus_Success(t *testing.T) {
 		},
 	}
 
-	handler := NewOntologyDAGHandler(mockService, nil, zap.NewNop())
+	handler := NewOntologyDAGHandler(mockService, nil, nil, nil, zap.NewNop())
 
 	url := fmt.Sprintf("/api/projects/%s/datasources/%s/ontology/dag", projectID, datasourceID)
 	req := httptest.NewRequest(http.MethodGet, url, nil)
@@ -431,6 +563,47 @@ func TestOntologyDAGHandler_GetStatus_Success(t *testing.T) {
 	}
 }
 
+func TestOntologyDAGHandler_GetStatus_IncludesTokenSpend(t *testing.T) {
+	projectID := uuid.New()
+	datasourceID := uuid.New()
+
+	mockService := &mockOntologyDAGService{
+		getStatusFunc: func(ctx context.Context, dsID uuid.UUID) (*models.OntologyDAG, error) {
+			return &models.OntologyDAG{ID: uuid.New(), ProjectID: projectID, DatasourceID: dsID, Status: models.DAGStatusFailed}, nil
+		},
+		tokenSpend: &services.TokenSpend{Spent: 120_500, Budget: 100_000, Exceeded: true},
+	}
+
+	handler := NewOntologyDAGHandler(mockService, nil, nil, nil, zap.NewNop())
+
+	url := fmt.Sprintf("/api/projects/%s/datasources/%s/ontology/dag", projectID, datasourceID)
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+	req.SetPathValue("pid", projectID.String())
+	req.SetPathValue("dsid", datasourceID.String())
+	rec := httptest.NewRecorder()
+
+	handler.GetStatus(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var response struct {
+		Data DAGStatusResponse `json:"data"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	spend := response.Data.TokenSpend
+	if spend == nil {
+		t.Fatal("expected token_spend in the status response")
+	}
+	if spend.Spent != 120_500 || spend.Budget != 100_000 || !spend.Exceeded || spend.Running {
+		t.Errorf("unexpected token spend: %+v", spend)
+	}
+}
+
 func TestOntologyDAGHandler_GetStatus_NoDAGExists(t *testing.T) {
 	projectID := uuid.New()
 	datasourceID := uuid.New()
@@ -441,7 +614,7 @@ func TestOntologyDAGHandler_GetStatus_NoDAGExists(t *testing.T) {
 		},
 	}
 
-	handler := NewOntologyDAGHandler(mockService, nil, zap.NewNop())
+	handler := NewOntologyDAGHandler(mockService, nil, nil, nil, zap.NewNop())
 
 	url := fmt.Sprintf("/api/projects/%s/datasources/%s/ontology/dag", projectID, datasourceID)
 	req := httptest.NewRequest(http.MethodGet, url, nil)
@@ -479,7 +652,7 @@ func TestOntologyDAGHandler_GetStatus_ServiceError(t *testing.T) {
 		},
 	}
 
-	handler := NewOntologyDAGHandler(mockService, nil, zap.NewNop())
+	handler := NewOntologyDAGHandler(mockService, nil, nil, nil, zap.NewNop())
 
 	url := fmt.Sprintf("/api/projects/%s/datasources/%s/ontology/dag", projectID, datasourceID)
 	req := httptest.NewRequest(http.MethodGet, url, nil)
@@ -521,7 +694,7 @@ func TestOntologyDAGHandler_Cancel_Success(t *testing.T) {
 		},
 	}
 
-	handler := NewOntologyDAGHandler(mockService, nil, zap.NewNop())
+	handler := NewOntologyDAGHandler(mockService, nil, nil, nil, zap.NewNop())
 
 	url := fmt.Sprintf("/api/projects/%s/datasources/%s/ontology/dag/cancel", projectID, datasourceID)
 	req := httptest.NewRequest(http.MethodPost, url, nil)
@@ -559,7 +732,7 @@ func TestOntologyDAGHandler_Cancel_NoDAGFound(t *testing.T) {
 		},
 	}
 
-	handler := NewOntologyDAGHandler(mockService, nil, zap.NewNop())
+	handler := NewOntologyDAGHandler(mockService, nil, nil, nil, zap.NewNop())
 
 	url := fmt.Sprintf("/api/projects/%s/datasources/%s/ontology/dag/cancel", projectID, datasourceID)
 	req := httptest.NewRequest(http.MethodPost, url, nil)
@@ -593,7 +766,7 @@ func TestOntologyDAGHandler_Cancel_DAGNotRunning(t *testing.T) {
 		},
 	}
 
-	handler := NewOntologyDAGHandler(mockService, nil, zap.NewNop())
+	handler := NewOntologyDAGHandler(mockService, nil, nil, nil, zap.NewNop())
 
 	url := fmt.Sprintf("/api/projects/%s/datasources/%s/ontology/dag/cancel", projectID, datasourceID)
 	req := httptest.NewRequest(http.MethodPost, url, nil)
@@ -630,7 +803,7 @@ func TestOntologyDAGHandler_Cancel_CancelError(t *testing.T) {
 		},
 	}
 
-	handler := NewOntologyDAGHandler(mockService, nil, zap.NewNop())
+	handler := NewOntologyDAGHandler(mockService, nil, nil, nil, zap.NewNop())
 
 	url := fmt.Sprintf("/api/projects/%s/datasources/%s/ontology/dag/cancel", projectID, datasourceID)
 	req := httptest.NewRequest(http.MethodPost, url, nil)
@@ -674,7 +847,7 @@ func TestOntologyDAGHandler_CompletedDAG(t *testing.T) {
 		},
 	}
 
-	handler := NewOntologyDAGHandler(mockService, nil, zap.NewNop())
+	handler := NewOntologyDAGHandler(mockService, nil, nil, nil, zap.NewNop())
 
 	url := fmt.Sprintf("/api/projects/%s/datasources/%s/ontology/dag", projectID, datasourceID)
 	req := httptest.NewRequest(http.MethodGet, url, nil)
@@ -741,7 +914,7 @@ func TestOntologyDAGHandler_FailedDAG(t *testing.T) {
 		},
 	}
 
-	handler := NewOntologyDAGHandler(mockService, nil, zap.NewNop())
+	handler := NewOntologyDAGHandler(mockService, nil, nil, nil, zap.NewNop())
 
 	url := fmt.Sprintf("/api/projects/%s/datasources/%s/ontology/dag", projectID, datasourceID)
 	req := httptest.NewRequest(http.MethodGet, url, nil)
@@ -795,7 +968,7 @@ func TestOntologyDAGHandler_Delete_Success(t *testing.T) {
 		},
 	}
 
-	handler := NewOntologyDAGHandler(mockService, nil, zap.NewNop())
+	handler := NewOntologyDAGHandler(mockService, nil, nil, nil, zap.NewNop())
 
 	url := fmt.Sprintf("/api/projects/%s/datasources/%s/ontology", projectID, datasourceID)
 	req := httptest.NewRequest(http.MethodDelete, url, nil)
@@ -838,7 +1011,7 @@ func TestOntologyDAGHandler_Delete_ServiceError(t *testing.T) {
 		},
 	}
 
-	handler := NewOntologyDAGHandler(mockService, nil, zap.NewNop())
+	handler := NewOntologyDAGHandler(mockService, nil, nil, nil, zap.NewNop())
 
 	url := fmt.Sprintf("/api/projects/%s/datasources/%s/ontology", projectID, datasourceID)
 	req := httptest.NewRequest(http.MethodDelete, url, nil)
@@ -863,7 +1036,7 @@ func TestOntologyDAGHandler_Delete_RunningDAGError(t *testing.T) {
 		},
 	}
 
-	handler := NewOntologyDAGHandler(mockService, nil, zap.NewNop())
+	handler := NewOntologyDAGHandler(mockService, nil, nil, nil, zap.NewNop())
 
 	url := fmt.Sprintf("/api/projects/%s/datasources/%s/ontology", projectID, datasourceID)
 	req := httptest.NewRequest(http.MethodDelete, url, nil)
@@ -891,7 +1064,7 @@ func TestOntologyDAGHandler_Delete_InvalidProjectID(t *testing.T) {
 	datasourceID := uuid.New()
 
 	mockService := &mockOntologyDAGService{}
-	handler := NewOntologyDAGHandler(mockService, nil, zap.NewNop())
+	handler := NewOntologyDAGHandler(mockService, nil, nil, nil, zap.NewNop())
 
 	url := fmt.Sprintf("/api/projects/invalid-uuid/datasources/%s/ontology", datasourceID)
 	req := httptest.NewRequest(http.MethodDelete, url, nil)
@@ -905,3 +1078,371 @@ func TestOntologyDAGHandler_Delete_InvalidProjectID(t *testing.T) {
 		t.Errorf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
 	}
 }
+
+// newProjectExtractionRequest builds a POST /api/projects/{pid}/extract request.
+func newProjectExtractionRequest(projectID uuid.UUID, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/projects/%s/extract", projectID), strings.NewReader(body))
+	req.SetPathValue("pid", projectID.String())
+	return req
+}
+
+func TestOntologyDAGHandler_StartProjectExtraction_ReturnsJobHandle(t *testing.T) {
+	projectID := uuid.New()
+	datasourceID := uuid.New()
+	dagID := uuid.New()
+
+	var startedWith uuid.UUID
+	var overview string
+	mockService := &mockOntologyDAGService{
+		getStatusFunc: func(ctx context.Context, dsID uuid.UUID) (*models.OntologyDAG, error) {
+			// Previous extraction finished - a new one may start
+			return &models.OntologyDAG{ID: uuid.New(), Status: models.DAGStatusCompleted}, nil
+		},
+		startFunc: func(ctx context.Context, pID, dsID uuid.UUID, projectOverview string) (*models.OntologyDAG, error) {
+			startedWith = dsID
+			overview = projectOverview
+			return &models.OntologyDAG{ID: dagID, ProjectID: pID, DatasourceID: dsID, Status: models.DAGStatusPending}, nil
+		},
+	}
+	handler := NewOntologyDAGHandler(mockService,
+		&mockProjectService{defaultDatasourceID: datasourceID},
+		&mockSchemaService{tables: []*models.SchemaTable{{ID: uuid.New(), TableName: "orders"}}},
+		nil,
+		zap.NewNop())
+
+	rec := httptest.NewRecorder()
+	handler.StartProjectExtraction(rec, newProjectExtractionRequest(projectID, `{"project_overview": "An online store"}`))
+
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusAccepted, rec.Code, rec.Body.String())
+	}
+	if startedWith != datasourceID {
+		t.Errorf("expected extraction on default datasource %s, got %s", datasourceID, startedWith)
+	}
+	if overview != "An online store" {
+		t.Errorf("expected project overview to be passed through, got %q", overview)
+	}
+
+	var response struct {
+		Success bool                  `json:"success"`
+		Data    ExtractionJobResponse `json:"data"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Data.JobID != dagID.String() {
+		t.Errorf("expected job_id %s, got %s", dagID, response.Data.JobID)
+	}
+	if response.Data.Status != "pending" {
+		t.Errorf("expected status pending, got %s", response.Data.Status)
+	}
+	wantURL := fmt.Sprintf("/api/projects/%s/datasources/%s/ontology/dag", projectID, datasourceID)
+	if response.Data.StatusURL != wantURL {
+		t.Errorf("expected status_url %s, got %s", wantURL, response.Data.StatusURL)
+	}
+}
+
+func TestOntologyDAGHandler_StartProjectExtraction_RejectsRunningExtraction(t *testing.T) {
+	projectID := uuid.New()
+	runningID := uuid.New()
+
+	mockService := &mockOntologyDAGService{
+		getStatusFunc: func(ctx context.Context, dsID uuid.UUID) (*models.OntologyDAG, error) {
+			return &models.OntologyDAG{ID: runningID, Status: models.DAGStatusRunning}, nil
+		},
+		startFunc: func(ctx context.Context, pID, dsID uuid.UUID, projectOverview string) (*models.OntologyDAG, error) {
+			t.Error("Start should not be called while an extraction is running")
+			return nil, nil
+		},
+	}
+	handler := NewOntologyDAGHandler(mockService,
+		&mockProjectService{defaultDatasourceID: uuid.New()},
+		&mockSchemaService{tables: []*models.SchemaTable{{ID: uuid.New(), TableName: "orders"}}},
+		nil,
+		zap.NewNop())
+
+	rec := httptest.NewRecorder()
+	handler.StartProjectExtraction(rec, newProjectExtractionRequest(projectID, ""))
+
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusConflict, rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), "extraction_running") || !strings.Contains(rec.Body.String(), runningID.String()) {
+		t.Errorf("expected extraction_running error naming the running job, got %s", rec.Body.String())
+	}
+}
+
+func TestOntologyDAGHandler_StartProjectExtraction_Preconditions(t *testing.T) {
+	tests := []struct {
+		name           string
+		projectService *mockProjectService
+		schemaService  *mockSchemaService
+		body           string
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:           "no datasource",
+			projectService: &mockProjectService{},
+			schemaService:  &mockSchemaService{},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "no_datasource",
+		},
+		{
+			name:           "ambiguous default datasource",
+			projectService: &mockProjectService{err: apperrors.ErrAmbiguousDefaultDatasource},
+			schemaService:  &mockSchemaService{},
+			expectedStatus: http.StatusConflict,
+			expectedError:  "ambiguous_default_datasource",
+		},
+		{
+			name:           "no selected tables",
+			projectService: &mockProjectService{defaultDatasourceID: uuid.New()},
+			schemaService:  &mockSchemaService{},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "no_selected_tables",
+		},
+		{
+			name:           "invalid body",
+			projectService: &mockProjectService{defaultDatasourceID: uuid.New()},
+			schemaService:  &mockSchemaService{},
+			body:           "{not json",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid_request",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &mockOntologyDAGService{
+				startFunc: func(ctx context.Context, pID, dsID uuid.UUID, projectOverview string) (*models.OntologyDAG, error) {
+					t.Error("Start should not be called when preconditions fail")
+					return nil, nil
+				},
+			}
+			handler := NewOntologyDAGHandler(mockService, tt.projectService, tt.schemaService, nil, zap.NewNop())
+
+			rec := httptest.NewRecorder()
+			handler.StartProjectExtraction(rec, newProjectExtractionRequest(uuid.New(), tt.body))
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), tt.expectedError) {
+				t.Errorf("expected error %q, got %s", tt.expectedError, rec.Body.String())
+			}
+		})
+	}
+}
+
+// newCancelJobRequest builds a POST /api/projects/{pid}/jobs/{jid}/cancel request.
+func newCancelJobRequest(projectID uuid.UUID, jobID string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/projects/%s/jobs/%s/cancel", projectID, jobID), nil)
+	req.SetPathValue("pid", projectID.String())
+	req.SetPathValue("jid", jobID)
+	return req
+}
+
+func TestOntologyDAGHandler_CancelJob_Success(t *testing.T) {
+	projectID := uuid.New()
+	jobID := uuid.New()
+
+	mockService := &mockOntologyDAGService{
+		cancelJobFunc: func(ctx context.Context, pID, jID uuid.UUID) (*models.OntologyDAG, error) {
+			if pID != projectID || jID != jobID {
+				t.Errorf("unexpected ids: project %s, job %s", pID, jID)
+			}
+			return &models.OntologyDAG{ID: jID, ProjectID: pID, Status: models.DAGStatusCancelled}, nil
+		},
+	}
+	handler := NewOntologyDAGHandler(mockService, &mockProjectService{}, &mockSchemaService{}, nil, zap.NewNop())
+
+	rec := httptest.NewRecorder()
+	handler.CancelJob(rec, newCancelJobRequest(projectID, jobID.String()))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var response struct {
+		Success bool              `json:"success"`
+		Data    map[string]string `json:"data"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Data["job_id"] != jobID.String() || response.Data["status"] != "cancelled" {
+		t.Errorf("unexpected response data: %v", response.Data)
+	}
+}
+
+func TestOntologyDAGHandler_CancelJob_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		jobID          string
+		err            error
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:           "invalid job id",
+			jobID:          "not-a-uuid",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid_job_id",
+		},
+		{
+			name:           "job not found",
+			jobID:          uuid.New().String(),
+			err:            apperrors.ErrNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "job_not_found",
+		},
+		{
+			name:           "job already finished",
+			jobID:          uuid.New().String(),
+			err:            fmt.Errorf("job is already completed: %w", apperrors.ErrConflict),
+			expectedStatus: http.StatusConflict,
+			expectedError:  "job_not_running",
+		},
+		{
+			name:           "service error",
+			jobID:          uuid.New().String(),
+			err:            errors.New("database down"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedError:  "cancel_failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &mockOntologyDAGService{
+				cancelJobFunc: func(ctx context.Context, pID, jID uuid.UUID) (*models.OntologyDAG, error) {
+					return nil, tt.err
+				},
+			}
+			handler := NewOntologyDAGHandler(mockService, &mockProjectService{}, &mockSchemaService{}, nil, zap.NewNop())
+
+			rec := httptest.NewRecorder()
+			handler.CancelJob(rec, newCancelJobRequest(uuid.New(), tt.jobID))
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), tt.expectedError) {
+				t.Errorf("expected error %q, got %s", tt.expectedError, rec.Body.String())
+			}
+		})
+	}
+}
+
+func newResyncRequest(projectID uuid.UUID) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/projects/%s/resync-and-update", projectID), nil)
+	req.SetPathValue("pid", projectID.String())
+	return req
+}
+
+func TestOntologyDAGHandler_ResyncAndUpdate_StartsIncrementalJobForAddedTable(t *testing.T) {
+	projectID := uuid.New()
+	datasourceID := uuid.New()
+	dagID := uuid.New()
+	summary := &models.ChangeSummary{TablesAdded: 1, ColumnsAdded: 2}
+
+	mockService := &mockOntologyDAGService{
+		getOntologyStatusFunc: func(ctx context.Context, pID, dsID uuid.UUID) (*models.OntologyStatusResponse, error) {
+			return &models.OntologyStatusResponse{HasOntology: true, SchemaChangedSinceBuild: true, ChangeSummary: summary}, nil
+		},
+		startFunc: func(ctx context.Context, pID, dsID uuid.UUID, projectOverview string) (*models.OntologyDAG, error) {
+			return &models.OntologyDAG{
+				ID:            dagID,
+				ProjectID:     pID,
+				DatasourceID:  dsID,
+				Status:        models.DAGStatusPending,
+				IsIncremental: true,
+				ChangeSummary: summary,
+			}, nil
+		},
+	}
+	handler := NewOntologyDAGHandler(mockService,
+		&mockProjectService{defaultDatasourceID: datasourceID},
+		&mockSchemaService{refreshResult: &models.RefreshResult{TablesUpserted: 3, NewTableNames: []string{"public.products"}}},
+		nil,
+		zap.NewNop())
+
+	rec := httptest.NewRecorder()
+	handler.ResyncAndUpdate(rec, newResyncRequest(projectID))
+
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusAccepted, rec.Code, rec.Body.String())
+	}
+
+	var response struct {
+		Success bool           `json:"success"`
+		Data    ResyncResponse `json:"data"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Data.Job == nil || response.Data.Job.JobID != dagID.String() {
+		t.Fatalf("expected job %s, got %+v", dagID, response.Data.Job)
+	}
+	if !response.Data.IsIncremental || response.Data.UpToDate {
+		t.Errorf("expected an incremental, not up-to-date response, got %+v", response.Data)
+	}
+	if response.Data.ChangeSummary == nil || response.Data.ChangeSummary.TablesAdded != 1 {
+		t.Errorf("expected change summary with 1 added table, got %+v", response.Data.ChangeSummary)
+	}
+	if len(response.Data.Refresh.NewTableNames) != 1 || response.Data.Refresh.NewTableNames[0] != "public.products" {
+		t.Errorf("expected refresh to report the new table, got %+v", response.Data.Refresh)
+	}
+}
+
+func TestOntologyDAGHandler_ResyncAndUpdate_UpToDateStartsNoJob(t *testing.T) {
+	mockService := &mockOntologyDAGService{
+		getOntologyStatusFunc: func(ctx context.Context, pID, dsID uuid.UUID) (*models.OntologyStatusResponse, error) {
+			return &models.OntologyStatusResponse{HasOntology: true}, nil
+		},
+		startFunc: func(ctx context.Context, pID, dsID uuid.UUID, projectOverview string) (*models.OntologyDAG, error) {
+			t.Error("Start should not be called when the ontology is up to date")
+			return nil, nil
+		},
+	}
+	handler := NewOntologyDAGHandler(mockService,
+		&mockProjectService{defaultDatasourceID: uuid.New()},
+		&mockSchemaService{},
+		nil,
+		zap.NewNop())
+
+	rec := httptest.NewRecorder()
+	handler.ResyncAndUpdate(rec, newResyncRequest(uuid.New()))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), `"up_to_date":true`) || strings.Contains(rec.Body.String(), `"job"`) {
+		t.Errorf("expected up_to_date response without a job, got %s", rec.Body.String())
+	}
+}
+
+func TestOntologyDAGHandler_ResyncAndUpdate_RejectsRunningExtraction(t *testing.T) {
+	mockService := &mockOntologyDAGService{
+		getStatusFunc: func(ctx context.Context, dsID uuid.UUID) (*models.OntologyDAG, error) {
+			return &models.OntologyDAG{ID: uuid.New(), Status: models.DAGStatusRunning}, nil
+		},
+		startFunc: func(ctx context.Context, pID, dsID uuid.UUID, projectOverview string) (*models.OntologyDAG, error) {
+			t.Error("Start should not be called while an extraction is running")
+			return nil, nil
+		},
+	}
+	handler := NewOntologyDAGHandler(mockService,
+		&mockProjectService{defaultDatasourceID: uuid.New()},
+		&mockSchemaService{err: errors.New("schema should not be refreshed while an extraction is running")},
+		nil,
+		zap.NewNop())
+
+	rec := httptest.NewRecorder()
+	handler.ResyncAndUpdate(rec, newResyncRequest(uuid.New()))
+
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusConflict, rec.Code, rec.Body.String())
+	}
+}
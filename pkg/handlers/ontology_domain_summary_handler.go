@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/apperrors"
+	"github.com/ekaya-inc/ekaya-engine/pkg/auth"
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+	"github.com/ekaya-inc/ekaya-engine/pkg/services"
+)
+
+// OntologyDomainSummaryHandler handles regenerating the project's domain summary
+// outside a full extraction.
+type OntologyDomainSummaryHandler struct {
+	finalizationService services.OntologyFinalizationService
+	logger              *zap.Logger
+}
+
+// NewOntologyDomainSummaryHandler creates a new ontology domain summary handler.
+func NewOntologyDomainSummaryHandler(finalizationService services.OntologyFinalizationService, logger *zap.Logger) *OntologyDomainSummaryHandler {
+	return &OntologyDomainSummaryHandler{
+		finalizationService: finalizationService,
+		logger:              logger,
+	}
+}
+
+// RegisterRoutes registers ontology domain summary routes.
+func (h *OntologyDomainSummaryHandler) RegisterRoutes(mux *http.ServeMux, authMiddleware *auth.Middleware, tenantMiddleware TenantMiddleware) {
+	mux.HandleFunc("POST /api/projects/{pid}/ontology/domain-summary/regenerate",
+		authMiddleware.RequireAuthWithPathValidation("pid")(
+			auth.RequireRole(models.RoleAdmin, models.RoleData)(tenantMiddleware(h.Regenerate))))
+}
+
+// Regenerate handles POST /api/projects/{pid}/ontology/domain-summary/regenerate.
+// The domain summary is rebuilt from the current table summaries and relationships and
+// returned; table and column metadata are left as they are.
+func (h *OntologyDomainSummaryHandler) Regenerate(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := ParseProjectID(w, r, h.logger)
+	if !ok {
+		return
+	}
+
+	summary, err := h.finalizationService.RegenerateDomainSummary(r.Context(), projectID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNoSelectedTables) {
+			if err := ErrorResponse(w, http.StatusBadRequest, "no_selected_tables",
+				"No tables to summarize: select at least one table before regenerating the domain summary"); err != nil {
+				h.logger.Error("Failed to write error response", zap.Error(err))
+			}
+			return
+		}
+
+		h.logger.Error("Failed to regenerate domain summary",
+			zap.String("project_id", projectID.String()),
+			zap.Error(err))
+		if err := ErrorResponse(w, http.StatusInternalServerError, "regenerate_failed", "Failed to regenerate domain summary"); err != nil {
+			h.logger.Error("Failed to write error response", zap.Error(err))
+		}
+		return
+	}
+
+	if err := WriteJSON(w, http.StatusOK, ApiResponse{Success: true, Data: summary}); err != nil {
+		h.logger.Error("Failed to write response", zap.Error(err))
+	}
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/apperrors"
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+)
+
+type mockOntologyFinalizationService struct {
+	regenerateFn func(ctx context.Context, projectID uuid.UUID) (*models.DomainSummary, error)
+}
+
+func (m *mockOntologyFinalizationService) Finalize(ctx context.Context, projectID uuid.UUID) error {
+	return nil
+}
+
+func (m *mockOntologyFinalizationService) RegenerateDomainSummary(ctx context.Context, projectID uuid.UUID) (*models.DomainSummary, error) {
+	return m.regenerateFn(ctx, projectID)
+}
+
+func newRegenerateDomainSummaryRequest(projectID uuid.UUID) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/api/projects/"+projectID.String()+"/ontology/domain-summary/regenerate", nil)
+	req.SetPathValue("pid", projectID.String())
+	return req
+}
+
+func TestOntologyDomainSummaryHandler_Regenerate_ReturnsSummary(t *testing.T) {
+	projectID := uuid.New()
+	handler := NewOntologyDomainSummaryHandler(&mockOntologyFinalizationService{
+		regenerateFn: func(ctx context.Context, gotProjectID uuid.UUID) (*models.DomainSummary, error) {
+			if gotProjectID != projectID {
+				t.Fatalf("unexpected project id: %s", gotProjectID)
+			}
+			return &models.DomainSummary{Description: "A billing system."}, nil
+		},
+	}, zap.NewNop())
+
+	rec := httptest.NewRecorder()
+	handler.Regenerate(rec, newRegenerateDomainSummaryRequest(projectID))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp struct {
+		Success bool                 `json:"success"`
+		Data    models.DomainSummary `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !resp.Success || resp.Data.Description != "A billing system." {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+}
+
+func TestOntologyDomainSummaryHandler_Regenerate_NoTables(t *testing.T) {
+	handler := NewOntologyDomainSummaryHandler(&mockOntologyFinalizationService{
+		regenerateFn: func(ctx context.Context, projectID uuid.UUID) (*models.DomainSummary, error) {
+			return nil, apperrors.ErrNoSelectedTables
+		},
+	}, zap.NewNop())
+
+	rec := httptest.NewRecorder()
+	handler.Regenerate(rec, newRegenerateDomainSummaryRequest(uuid.New()))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", rec.Code)
+	}
+}
@@ -1,11 +1,13 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 
+	"github.com/ekaya-inc/ekaya-engine/pkg/apperrors"
 	"github.com/ekaya-inc/ekaya-engine/pkg/auth"
 	"github.com/ekaya-inc/ekaya-engine/pkg/models"
 	"github.com/ekaya-inc/ekaya-engine/pkg/services"
@@ -136,6 +138,10 @@ func (h *OntologyEnrichmentHandler) GetEnrichment(w http.ResponseWriter, r *http
 
 	// Get default datasource
 	dsID, err := h.projectService.GetDefaultDatasourceID(ctx, projectID)
+	if errors.Is(err, apperrors.ErrAmbiguousDefaultDatasource) {
+		_ = ErrorResponse(w, http.StatusConflict, "ambiguous_default_datasource", err.Error())
+		return
+	}
 	if err != nil {
 		h.logger.Error("Failed to get default datasource", zap.Error(err))
 		_ = ErrorResponse(w, http.StatusInternalServerError, "get_default_datasource_failed", err.Error())
@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"go.uber.org/zap"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/auth"
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+	"github.com/ekaya-inc/ekaya-engine/pkg/services"
+)
+
+// OntologyEntitiesHandler handles listing ontology entities by provenance and purging
+// them by source.
+type OntologyEntitiesHandler struct {
+	entityService services.OntologyEntityService
+	logger        *zap.Logger
+}
+
+// NewOntologyEntitiesHandler creates a new ontology entities handler.
+func NewOntologyEntitiesHandler(entityService services.OntologyEntityService, logger *zap.Logger) *OntologyEntitiesHandler {
+	return &OntologyEntitiesHandler{
+		entityService: entityService,
+		logger:        logger,
+	}
+}
+
+// RegisterRoutes registers ontology entity routes.
+func (h *OntologyEntitiesHandler) RegisterRoutes(mux *http.ServeMux, authMiddleware *auth.Middleware, tenantMiddleware TenantMiddleware) {
+	base := "/api/projects/{pid}/datasources/{dsid}/ontology/entities"
+
+	mux.HandleFunc("GET "+base,
+		authMiddleware.RequireAuthWithPathValidation("pid")(tenantMiddleware(h.List)))
+	mux.HandleFunc("GET "+base+"/review",
+		authMiddleware.RequireAuthWithPathValidation("pid")(tenantMiddleware(h.Review)))
+	mux.HandleFunc("DELETE "+base,
+		authMiddleware.RequireAuthWithPathValidation("pid")(
+			auth.RequireRole(models.RoleAdmin, models.RoleData)(tenantMiddleware(h.Purge))))
+}
+
+// List handles GET /api/projects/{pid}/datasources/{dsid}/ontology/entities.
+// The optional source query parameter (inferred, mcp, manual) keeps only the entities
+// that source created.
+func (h *OntologyEntitiesHandler) List(w http.ResponseWriter, r *http.Request) {
+	projectID, datasourceID, ok := ParseProjectAndDatasourceIDs(w, r, h.logger)
+	if !ok {
+		return
+	}
+
+	source := models.ProvenanceSource(r.URL.Query().Get("source"))
+	if source != "" && !source.IsValid() {
+		if err := ErrorResponse(w, http.StatusBadRequest, "invalid_source", "source must be one of inferred, mcp, manual"); err != nil {
+			h.logger.Error("Failed to write error response", zap.Error(err))
+		}
+		return
+	}
+
+	entities, err := h.entityService.List(r.Context(), projectID, datasourceID, source)
+	if err != nil {
+		h.logger.Error("Failed to list ontology entities",
+			zap.String("project_id", projectID.String()),
+			zap.String("datasource_id", datasourceID.String()),
+			zap.Error(err))
+		if err := ErrorResponse(w, http.StatusInternalServerError, "list_entities_failed", "Failed to list ontology entities"); err != nil {
+			h.logger.Error("Failed to write error response", zap.Error(err))
+		}
+		return
+	}
+
+	if err := WriteJSON(w, http.StatusOK, ApiResponse{Success: true, Data: entities}); err != nil {
+		h.logger.Error("Failed to write response", zap.Error(err))
+	}
+}
+
+// Review handles GET /api/projects/{pid}/datasources/{dsid}/ontology/entities/review.
+// Returns the entities worst-first by quality score, for working through the review queue.
+func (h *OntologyEntitiesHandler) Review(w http.ResponseWriter, r *http.Request) {
+	projectID, datasourceID, ok := ParseProjectAndDatasourceIDs(w, r, h.logger)
+	if !ok {
+		return
+	}
+
+	entities, err := h.entityService.ListForReview(r.Context(), projectID, datasourceID)
+	if err != nil {
+		h.logger.Error("Failed to list ontology entities for review",
+			zap.String("project_id", projectID.String()),
+			zap.String("datasource_id", datasourceID.String()),
+			zap.Error(err))
+		if err := ErrorResponse(w, http.StatusInternalServerError, "list_entities_failed", "Failed to list ontology entities"); err != nil {
+			h.logger.Error("Failed to write error response", zap.Error(err))
+		}
+		return
+	}
+
+	if err := WriteJSON(w, http.StatusOK, ApiResponse{Success: true, Data: entities}); err != nil {
+		h.logger.Error("Failed to write response", zap.Error(err))
+	}
+}
+
+// Purge handles DELETE /api/projects/{pid}/datasources/{dsid}/ontology/entities?source=inferred.
+// The source query parameter is required. With dry_run=true nothing is deleted and the
+// response reports what would be.
+func (h *OntologyEntitiesHandler) Purge(w http.ResponseWriter, r *http.Request) {
+	projectID, datasourceID, ok := ParseProjectAndDatasourceIDs(w, r, h.logger)
+	if !ok {
+		return
+	}
+
+	source := models.ProvenanceSource(r.URL.Query().Get("source"))
+	if !source.IsValid() {
+		if err := ErrorResponse(w, http.StatusBadRequest, "invalid_source", "source is required and must be one of inferred, mcp, manual"); err != nil {
+			h.logger.Error("Failed to write error response", zap.Error(err))
+		}
+		return
+	}
+	dryRun := false
+	if raw := r.URL.Query().Get("dry_run"); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			if err := ErrorResponse(w, http.StatusBadRequest, "invalid_dry_run", "dry_run must be true or false"); err != nil {
+				h.logger.Error("Failed to write error response", zap.Error(err))
+			}
+			return
+		}
+		dryRun = parsed
+	}
+
+	result, err := h.entityService.PurgeBySource(r.Context(), projectID, datasourceID, source, dryRun)
+	if err != nil {
+		h.logger.Error("Failed to purge ontology entities",
+			zap.String("project_id", projectID.String()),
+			zap.String("datasource_id", datasourceID.String()),
+			zap.String("source", source.String()),
+			zap.Error(err))
+		if err := ErrorResponse(w, http.StatusInternalServerError, "purge_entities_failed", "Failed to purge ontology entities"); err != nil {
+			h.logger.Error("Failed to write error response", zap.Error(err))
+		}
+		return
+	}
+
+	if err := WriteJSON(w, http.StatusOK, ApiResponse{Success: true, Data: result}); err != nil {
+		h.logger.Error("Failed to write response", zap.Error(err))
+	}
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+)
+
+type mockOntologyEntityService struct {
+	listFn   func(ctx context.Context, projectID, datasourceID uuid.UUID, source models.ProvenanceSource) ([]*models.OntologyEntity, error)
+	reviewFn func(ctx context.Context, projectID, datasourceID uuid.UUID) ([]*models.OntologyEntity, error)
+	purgeFn  func(ctx context.Context, projectID, datasourceID uuid.UUID, source models.ProvenanceSource, dryRun bool) (*models.OntologyEntityPurgeResult, error)
+}
+
+func (m *mockOntologyEntityService) List(ctx context.Context, projectID, datasourceID uuid.UUID, source models.ProvenanceSource) ([]*models.OntologyEntity, error) {
+	return m.listFn(ctx, projectID, datasourceID, source)
+}
+
+func (m *mockOntologyEntityService) ListForReview(ctx context.Context, projectID, datasourceID uuid.UUID) ([]*models.OntologyEntity, error) {
+	return m.reviewFn(ctx, projectID, datasourceID)
+}
+
+func (m *mockOntologyEntityService) PurgeBySource(ctx context.Context, projectID, datasourceID uuid.UUID, source models.ProvenanceSource, dryRun bool) (*models.OntologyEntityPurgeResult, error) {
+	return m.purgeFn(ctx, projectID, datasourceID, source, dryRun)
+}
+
+func newOntologyEntitiesRequest(method string, projectID, datasourceID uuid.UUID, query string) *http.Request {
+	req := httptest.NewRequest(method, "/api/projects/"+projectID.String()+"/datasources/"+datasourceID.String()+"/ontology/entities"+query, nil)
+	req.SetPathValue("pid", projectID.String())
+	req.SetPathValue("dsid", datasourceID.String())
+	return req
+}
+
+func TestOntologyEntitiesHandler_List_FiltersBySource(t *testing.T) {
+	var gotSource models.ProvenanceSource
+	handler := NewOntologyEntitiesHandler(&mockOntologyEntityService{
+		listFn: func(ctx context.Context, projectID, datasourceID uuid.UUID, source models.ProvenanceSource) ([]*models.OntologyEntity, error) {
+			gotSource = source
+			return []*models.OntologyEntity{{TableName: "orders", Source: models.ProvenanceInferred}}, nil
+		},
+	}, zap.NewNop())
+
+	rec := httptest.NewRecorder()
+	handler.List(rec, newOntologyEntitiesRequest(http.MethodGet, uuid.New(), uuid.New(), "?source=inferred"))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if gotSource != models.SourceInferred {
+		t.Fatalf("expected source filter inferred, got %q", gotSource)
+	}
+	var resp struct {
+		Data []models.OntologyEntity `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Data) != 1 || resp.Data[0].Source != models.ProvenanceInferred {
+		t.Fatalf("expected one inferred entity, got %+v", resp.Data)
+	}
+}
+
+func TestOntologyEntitiesHandler_RejectsInvalidSource(t *testing.T) {
+	handler := NewOntologyEntitiesHandler(&mockOntologyEntityService{}, zap.NewNop())
+
+	rec := httptest.NewRecorder()
+	handler.List(rec, newOntologyEntitiesRequest(http.MethodGet, uuid.New(), uuid.New(), "?source=llm"))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 listing an unknown source, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.Purge(rec, newOntologyEntitiesRequest(http.MethodDelete, uuid.New(), uuid.New(), ""))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 purging without a source, got %d", rec.Code)
+	}
+}
+
+func TestOntologyEntitiesHandler_Purge_DryRun(t *testing.T) {
+	var gotDryRun bool
+	handler := NewOntologyEntitiesHandler(&mockOntologyEntityService{
+		purgeFn: func(ctx context.Context, projectID, datasourceID uuid.UUID, source models.ProvenanceSource, dryRun bool) (*models.OntologyEntityPurgeResult, error) {
+			gotDryRun = dryRun
+			return &models.OntologyEntityPurgeResult{Source: source, DryRun: dryRun, Count: 2, Tables: []string{"orders", "users"}}, nil
+		},
+	}, zap.NewNop())
+
+	rec := httptest.NewRecorder()
+	handler.Purge(rec, newOntologyEntitiesRequest(http.MethodDelete, uuid.New(), uuid.New(), "?source=inferred&dry_run=true"))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if !gotDryRun {
+		t.Fatal("expected a dry run")
+	}
+}
@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
 
@@ -32,6 +34,9 @@ func (h *OntologyExportHandler) RegisterRoutes(mux *http.ServeMux, authMiddlewar
 	mux.HandleFunc("GET "+base+"/export",
 		authMiddleware.RequireAuthWithPathValidation("pid")(
 			auth.RequireRole(models.RoleAdmin, models.RoleData)(tenantMiddleware(h.Export))))
+	mux.HandleFunc("POST "+base+"/diff",
+		authMiddleware.RequireAuthWithPathValidation("pid")(
+			auth.RequireRole(models.RoleAdmin, models.RoleData)(tenantMiddleware(h.Diff))))
 }
 
 // Export handles GET /api/projects/{pid}/datasources/{dsid}/ontology/export.
@@ -74,3 +79,47 @@ func (h *OntologyExportHandler) Export(w http.ResponseWriter, r *http.Request) {
 		h.logger.Error("Failed to write ontology export bundle", zap.Error(err))
 	}
 }
+
+// Diff handles POST /api/projects/{pid}/datasources/{dsid}/ontology/diff.
+// The request body is a bundle saved from the export endpoint; the response lists what
+// changed between it and the current ontology, e.g. after a re-extraction.
+func (h *OntologyExportHandler) Diff(w http.ResponseWriter, r *http.Request) {
+	projectID, datasourceID, ok := ParseProjectAndDatasourceIDs(w, r, h.logger)
+	if !ok {
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, models.OntologyImportMaxBytes)
+	baseline, err := io.ReadAll(r.Body)
+	if err != nil {
+		if err := ErrorResponse(w, http.StatusBadRequest, "invalid_request", "Ontology bundle exceeds the 5 MB maximum size"); err != nil {
+			h.logger.Error("Failed to write error response", zap.Error(err))
+		}
+		return
+	}
+
+	diff, err := h.exportService.DiffAgainstBundle(r.Context(), projectID, datasourceID, baseline)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidOntologyBaseline) {
+			if err := ErrorResponse(w, http.StatusBadRequest, "invalid_bundle", err.Error()); err != nil {
+				h.logger.Error("Failed to write error response", zap.Error(err))
+			}
+			return
+		}
+		h.logger.Error("Failed to diff ontology bundle",
+			zap.String("project_id", projectID.String()),
+			zap.String("datasource_id", datasourceID.String()),
+			zap.Error(err))
+		if err := ErrorResponse(w, http.StatusInternalServerError, "diff_failed", "Failed to diff ontology bundle"); err != nil {
+			h.logger.Error("Failed to write error response", zap.Error(err))
+		}
+		return
+	}
+
+	if err := WriteJSON(w, http.StatusOK, ApiResponse{
+		Success: true,
+		Data:    diff,
+	}); err != nil {
+		h.logger.Error("Failed to write response", zap.Error(err))
+	}
+}
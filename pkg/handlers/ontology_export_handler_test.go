@@ -2,8 +2,11 @@ package handlers
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -11,12 +14,14 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+	"github.com/ekaya-inc/ekaya-engine/pkg/services"
 )
 
 type mockOntologyExportService struct {
 	buildBundleFn       func(ctx context.Context, projectID, datasourceID uuid.UUID) (*models.OntologyExportBundle, error)
 	marshalBundleFn     func(bundle *models.OntologyExportBundle) ([]byte, error)
 	suggestedFilenameFn func(bundle *models.OntologyExportBundle) string
+	diffAgainstBundleFn func(ctx context.Context, projectID, datasourceID uuid.UUID, baseline []byte) (*models.OntologyDiff, error)
 }
 
 func (m *mockOntologyExportService) BuildBundle(ctx context.Context, projectID, datasourceID uuid.UUID) (*models.OntologyExportBundle, error) {
@@ -34,6 +39,10 @@ func (m *mockOntologyExportService) SuggestedFilename(bundle *models.OntologyExp
 	return "ontology-export.json"
 }
 
+func (m *mockOntologyExportService) DiffAgainstBundle(ctx context.Context, projectID, datasourceID uuid.UUID, baseline []byte) (*models.OntologyDiff, error) {
+	return m.diffAgainstBundleFn(ctx, projectID, datasourceID, baseline)
+}
+
 func TestOntologyExportHandler_Export_Success(t *testing.T) {
 	projectID := uuid.New()
 	datasourceID := uuid.New()
@@ -117,3 +126,51 @@ func TestOntologyExportHandler_Export_BuildError(t *testing.T) {
 		t.Fatalf("expected status 500, got %d", rec.Code)
 	}
 }
+
+func TestOntologyExportHandler_Diff(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "success", wantStatus: http.StatusOK},
+		{name: "invalid baseline", err: fmt.Errorf("%w: bad json", services.ErrInvalidOntologyBaseline), wantStatus: http.StatusBadRequest},
+		{name: "unexpected", err: errors.New("boom"), wantStatus: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectID := uuid.New()
+			datasourceID := uuid.New()
+			baseline := `{"format":"ekaya-ontology-export"}`
+
+			handler := NewOntologyExportHandler(&mockOntologyExportService{
+				diffAgainstBundleFn: func(ctx context.Context, gotProjectID, gotDatasourceID uuid.UUID, gotBaseline []byte) (*models.OntologyDiff, error) {
+					if gotProjectID != projectID || gotDatasourceID != datasourceID {
+						t.Fatalf("unexpected ids: %s %s", gotProjectID, gotDatasourceID)
+					}
+					if string(gotBaseline) != baseline {
+						t.Fatalf("unexpected baseline: %q", gotBaseline)
+					}
+					if tt.err != nil {
+						return nil, tt.err
+					}
+					return &models.OntologyDiff{AddedEntities: []string{"public.invoices"}}, nil
+				},
+			}, zap.NewNop())
+
+			req := httptest.NewRequest(http.MethodPost, "/api/projects/"+projectID.String()+"/datasources/"+datasourceID.String()+"/ontology/diff", strings.NewReader(baseline))
+			req.SetPathValue("pid", projectID.String())
+			req.SetPathValue("dsid", datasourceID.String())
+			rec := httptest.NewRecorder()
+
+			handler.Diff(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, rec.Code, rec.Body.String())
+			}
+			if tt.err == nil && !strings.Contains(rec.Body.String(), `"added_entities":["public.invoices"]`) {
+				t.Fatalf("unexpected body: %s", rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/auth"
+	"github.com/ekaya-inc/ekaya-engine/pkg/services"
+)
+
+// OntologyHealthHandler handles ontology integrity check requests.
+type OntologyHealthHandler struct {
+	healthService services.OntologyHealthService
+	logger        *zap.Logger
+}
+
+// NewOntologyHealthHandler creates a new ontology health handler.
+func NewOntologyHealthHandler(healthService services.OntologyHealthService, logger *zap.Logger) *OntologyHealthHandler {
+	return &OntologyHealthHandler{
+		healthService: healthService,
+		logger:        logger,
+	}
+}
+
+// RegisterRoutes registers ontology health routes.
+func (h *OntologyHealthHandler) RegisterRoutes(mux *http.ServeMux, authMiddleware *auth.Middleware, tenantMiddleware TenantMiddleware) {
+	mux.HandleFunc("GET /api/projects/{pid}/datasources/{dsid}/ontology/health",
+		authMiddleware.RequireAuthWithPathValidation("pid")(tenantMiddleware(h.Check)))
+}
+
+// Check handles GET /api/projects/{pid}/datasources/{dsid}/ontology/health.
+// Problems found are reported in the body; the status is 200 whether or not the ontology is healthy.
+func (h *OntologyHealthHandler) Check(w http.ResponseWriter, r *http.Request) {
+	projectID, datasourceID, ok := ParseProjectAndDatasourceIDs(w, r, h.logger)
+	if !ok {
+		return
+	}
+
+	report, err := h.healthService.Check(r.Context(), projectID, datasourceID)
+	if err != nil {
+		h.logger.Error("Failed to check ontology health",
+			zap.String("project_id", projectID.String()),
+			zap.String("datasource_id", datasourceID.String()),
+			zap.Error(err))
+		if err := ErrorResponse(w, http.StatusInternalServerError, "health_check_failed", "Failed to check ontology health"); err != nil {
+			h.logger.Error("Failed to write error response", zap.Error(err))
+		}
+		return
+	}
+
+	if err := WriteJSON(w, http.StatusOK, ApiResponse{Success: true, Data: report}); err != nil {
+		h.logger.Error("Failed to write response", zap.Error(err))
+	}
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+)
+
+type mockOntologyHealthService struct {
+	checkFn func(ctx context.Context, projectID, datasourceID uuid.UUID) (*models.OntologyHealthReport, error)
+}
+
+func (m *mockOntologyHealthService) Check(ctx context.Context, projectID, datasourceID uuid.UUID) (*models.OntologyHealthReport, error) {
+	return m.checkFn(ctx, projectID, datasourceID)
+}
+
+func newOntologyHealthRequest(projectID, datasourceID uuid.UUID) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/api/projects/"+projectID.String()+"/datasources/"+datasourceID.String()+"/ontology/health", nil)
+	req.SetPathValue("pid", projectID.String())
+	req.SetPathValue("dsid", datasourceID.String())
+	return req
+}
+
+func TestOntologyHealthHandler_Check_ReturnsReport(t *testing.T) {
+	projectID := uuid.New()
+	datasourceID := uuid.New()
+	handler := NewOntologyHealthHandler(&mockOntologyHealthService{
+		checkFn: func(ctx context.Context, gotProjectID, gotDatasourceID uuid.UUID) (*models.OntologyHealthReport, error) {
+			if gotProjectID != projectID || gotDatasourceID != datasourceID {
+				t.Fatalf("unexpected ids: %s %s", gotProjectID, gotDatasourceID)
+			}
+			return &models.OntologyHealthReport{
+				RelationshipsChecked: 1,
+				IncompatibleRelationships: []models.RelationshipTypeMismatch{
+					{SourceTable: "payments", SourceColumn: "account_id", SourceType: "uuid", TargetTable: "accounts", TargetColumn: "id", TargetType: "bigint"},
+				},
+			}, nil
+		},
+	}, zap.NewNop())
+
+	rec := httptest.NewRecorder()
+	handler.Check(rec, newOntologyHealthRequest(projectID, datasourceID))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp struct {
+		Success bool                        `json:"success"`
+		Data    models.OntologyHealthReport `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !resp.Success || resp.Data.Healthy || len(resp.Data.IncompatibleRelationships) != 1 {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+}
+
+func TestOntologyHealthHandler_Check_ServiceError(t *testing.T) {
+	handler := NewOntologyHealthHandler(&mockOntologyHealthService{
+		checkFn: func(ctx context.Context, projectID, datasourceID uuid.UUID) (*models.OntologyHealthReport, error) {
+			return nil, errors.New("boom")
+		},
+	}, zap.NewNop())
+
+	rec := httptest.NewRecorder()
+	handler.Check(rec, newOntologyHealthRequest(uuid.New(), uuid.New()))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected status 500, got %d", rec.Code)
+	}
+}
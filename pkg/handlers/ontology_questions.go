@@ -3,36 +3,45 @@ package handlers
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 
 	"go.uber.org/zap"
 
 	"github.com/ekaya-inc/ekaya-engine/pkg/auth"
 	"github.com/ekaya-inc/ekaya-engine/pkg/jsonutil"
 	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+	"github.com/ekaya-inc/ekaya-engine/pkg/repositories"
 	"github.com/ekaya-inc/ekaya-engine/pkg/services"
 )
 
+const (
+	defaultQuestionPageSize = 20
+	maxQuestionPageSize     = 100
+)
+
 // ============================================================================
 // Request/Response Types
 // ============================================================================
 
 // QuestionResponse for question endpoints.
 type QuestionResponse struct {
-	ID              string   `json:"id"`
-	ProjectID       string   `json:"project_id"`
-	WorkflowID      *string  `json:"workflow_id,omitempty"`
-	Text            string   `json:"text"`
-	Priority        int      `json:"priority"`
-	IsRequired      bool     `json:"is_required"`
-	Category        string   `json:"category,omitempty"`
-	Reasoning       string   `json:"reasoning,omitempty"`
-	AffectedTables  []string `json:"affected_tables,omitempty"`
-	AffectedColumns []string `json:"affected_columns,omitempty"`
-	DetectedPattern string   `json:"detected_pattern,omitempty"`
-	Status          string   `json:"status"`
-	Answer          string   `json:"answer,omitempty"`
-	AnsweredAt      *string  `json:"answered_at,omitempty"`
-	CreatedAt       string   `json:"created_at"`
+	ID               string   `json:"id"`
+	ProjectID        string   `json:"project_id"`
+	WorkflowID       *string  `json:"workflow_id,omitempty"`
+	Text             string   `json:"text"`
+	Priority         int      `json:"priority"`
+	IsRequired       bool     `json:"is_required"`
+	Category         string   `json:"category,omitempty"`
+	Reasoning        string   `json:"reasoning,omitempty"`
+	AffectedTables   []string `json:"affected_tables,omitempty"`
+	AffectedColumns  []string `json:"affected_columns,omitempty"`
+	DetectedPattern  string   `json:"detected_pattern,omitempty"`
+	SourceEntityType string   `json:"source_entity_type,omitempty"`
+	SourceEntityName string   `json:"source_entity_name,omitempty"`
+	Status           string   `json:"status"`
+	Answer           string   `json:"answer,omitempty"`
+	AnsweredAt       *string  `json:"answered_at,omitempty"`
+	CreatedAt        string   `json:"created_at"`
 }
 
 // QuestionCountsResponse for question counts.
@@ -47,6 +56,15 @@ type ListQuestionsResponse struct {
 	Total     int                `json:"total"`
 }
 
+// SearchQuestionsResponse for GET /questions/search endpoint.
+type SearchQuestionsResponse struct {
+	Questions      []QuestionResponse `json:"questions"`
+	TotalCount     int                `json:"total_count"`
+	CountsByStatus map[string]int     `json:"counts_by_status"`
+	Limit          int                `json:"limit"`
+	Offset         int                `json:"offset"`
+}
+
 // AnswerQuestionRequest for POST /questions/{id}/answer
 type AnswerQuestionRequest struct {
 	Answer string `json:"answer"`
@@ -85,6 +103,8 @@ func (h *OntologyQuestionsHandler) RegisterRoutes(mux *http.ServeMux, authMiddle
 
 	mux.HandleFunc("GET "+base,
 		authMiddleware.RequireAuthWithPathValidation("pid")(tenantMiddleware(h.List)))
+	mux.HandleFunc("GET "+base+"/search",
+		authMiddleware.RequireAuthWithPathValidation("pid")(tenantMiddleware(h.Search)))
 	mux.HandleFunc("GET "+base+"/next",
 		authMiddleware.RequireAuthWithPathValidation("pid")(tenantMiddleware(h.GetNext)))
 	mux.HandleFunc("POST "+base+"/{qid}/answer",
@@ -98,6 +118,11 @@ func (h *OntologyQuestionsHandler) RegisterRoutes(mux *http.ServeMux, authMiddle
 	mux.HandleFunc("DELETE "+base+"/{qid}",
 		authMiddleware.RequireAuthWithPathValidation("pid")(
 			auth.RequireRole(models.RoleAdmin, models.RoleData)(tenantMiddleware(h.Delete))))
+	mux.HandleFunc("GET "+base+"/orphans",
+		authMiddleware.RequireAuthWithPathValidation("pid")(tenantMiddleware(h.ListOrphans)))
+	mux.HandleFunc("POST "+base+"/orphans/cleanup",
+		authMiddleware.RequireAuthWithPathValidation("pid")(
+			auth.RequireRole(models.RoleAdmin, models.RoleData)(tenantMiddleware(h.CleanupOrphans))))
 }
 
 // List handles GET /api/projects/{pid}/ontology/questions
@@ -132,6 +157,53 @@ func (h *OntologyQuestionsHandler) List(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// Search handles GET /api/projects/{pid}/ontology/questions/search
+// Query params: status, required, category, entity, limit, offset.
+func (h *OntologyQuestionsHandler) Search(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := ParseProjectID(w, r, h.logger)
+	if !ok {
+		return
+	}
+
+	filters, errMsg := parseQuestionSearchFilters(r)
+	if errMsg != "" {
+		if err := ErrorResponse(w, http.StatusBadRequest, "invalid_request", errMsg); err != nil {
+			h.logger.Error("Failed to write error response", zap.Error(err))
+		}
+		return
+	}
+
+	result, err := h.questionService.ListQuestions(r.Context(), projectID, filters)
+	if err != nil {
+		h.logger.Error("Failed to search questions",
+			zap.String("project_id", projectID.String()),
+			zap.Error(err))
+		if err := ErrorResponse(w, http.StatusInternalServerError, "internal_error", "Failed to search questions"); err != nil {
+			h.logger.Error("Failed to write error response", zap.Error(err))
+		}
+		return
+	}
+
+	data := SearchQuestionsResponse{
+		Questions:      make([]QuestionResponse, len(result.Questions)),
+		TotalCount:     result.TotalCount,
+		CountsByStatus: make(map[string]int, len(result.CountsByStatus)),
+		Limit:          filters.Limit,
+		Offset:         filters.Offset,
+	}
+	for i, q := range result.Questions {
+		data.Questions[i] = h.toQuestionResponse(q)
+	}
+	for status, count := range result.CountsByStatus {
+		data.CountsByStatus[string(status)] = count
+	}
+
+	response := ApiResponse{Success: true, Data: data}
+	if err := WriteJSON(w, http.StatusOK, response); err != nil {
+		h.logger.Error("Failed to write response", zap.Error(err))
+	}
+}
+
 // Counts handles GET /api/projects/{pid}/ontology/questions/counts
 func (h *OntologyQuestionsHandler) Counts(w http.ResponseWriter, r *http.Request) {
 	projectID, ok := ParseProjectID(w, r, h.logger)
@@ -354,23 +426,85 @@ func (h *OntologyQuestionsHandler) Delete(w http.ResponseWriter, r *http.Request
 	}
 }
 
+// ListOrphans handles GET /api/projects/{pid}/ontology/questions/orphans
+// Returns pending questions about tables that are no longer selected.
+func (h *OntologyQuestionsHandler) ListOrphans(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := ParseProjectID(w, r, h.logger)
+	if !ok {
+		return
+	}
+
+	questions, err := h.questionService.FindOrphanedQuestions(r.Context(), projectID)
+	if err != nil {
+		h.logger.Error("Failed to find orphaned questions",
+			zap.String("project_id", projectID.String()),
+			zap.Error(err))
+		if err := ErrorResponse(w, http.StatusInternalServerError, "internal_error", "Failed to find orphaned questions"); err != nil {
+			h.logger.Error("Failed to write error response", zap.Error(err))
+		}
+		return
+	}
+
+	data := ListQuestionsResponse{
+		Questions: make([]QuestionResponse, 0, len(questions)),
+		Total:     len(questions),
+	}
+	for _, q := range questions {
+		data.Questions = append(data.Questions, h.toQuestionResponse(q))
+	}
+
+	response := ApiResponse{Success: true, Data: data}
+	if err := WriteJSON(w, http.StatusOK, response); err != nil {
+		h.logger.Error("Failed to write response", zap.Error(err))
+	}
+}
+
+// CleanupOrphans handles POST /api/projects/{pid}/ontology/questions/orphans/cleanup
+// Orphaned questions are dismissed, or deleted with ?delete=true.
+func (h *OntologyQuestionsHandler) CleanupOrphans(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := ParseProjectID(w, r, h.logger)
+	if !ok {
+		return
+	}
+
+	deleteQuestions := r.URL.Query().Get("delete") == "true"
+
+	cleaned, err := h.questionService.CleanupOrphanedQuestions(r.Context(), projectID, deleteQuestions)
+	if err != nil {
+		h.logger.Error("Failed to clean up orphaned questions",
+			zap.String("project_id", projectID.String()),
+			zap.Error(err))
+		if err := ErrorResponse(w, http.StatusInternalServerError, "internal_error", "Failed to clean up orphaned questions"); err != nil {
+			h.logger.Error("Failed to write error response", zap.Error(err))
+		}
+		return
+	}
+
+	response := ApiResponse{Success: true, Data: map[string]any{"cleaned": cleaned, "deleted": deleteQuestions}}
+	if err := WriteJSON(w, http.StatusOK, response); err != nil {
+		h.logger.Error("Failed to write response", zap.Error(err))
+	}
+}
+
 // ============================================================================
 // Helper Methods
 // ============================================================================
 
 func (h *OntologyQuestionsHandler) toQuestionResponse(q *models.OntologyQuestion) QuestionResponse {
 	resp := QuestionResponse{
-		ID:              q.ID.String(),
-		ProjectID:       q.ProjectID.String(),
-		Text:            q.Text,
-		Priority:        q.Priority,
-		IsRequired:      q.IsRequired,
-		Category:        q.Category,
-		Reasoning:       q.Reasoning,
-		DetectedPattern: q.DetectedPattern,
-		Status:          string(q.Status),
-		Answer:          q.Answer,
-		CreatedAt:       jsonutil.FormatUTCTime(q.CreatedAt),
+		ID:               q.ID.String(),
+		ProjectID:        q.ProjectID.String(),
+		Text:             q.Text,
+		Priority:         q.Priority,
+		IsRequired:       q.IsRequired,
+		Category:         q.Category,
+		Reasoning:        q.Reasoning,
+		DetectedPattern:  q.DetectedPattern,
+		SourceEntityType: q.SourceEntityType,
+		SourceEntityName: q.SourceEntityKey,
+		Status:           string(q.Status),
+		Answer:           q.Answer,
+		CreatedAt:        jsonutil.FormatUTCTime(q.CreatedAt),
 	}
 
 	if q.WorkflowID != nil {
@@ -389,3 +523,65 @@ func (h *OntologyQuestionsHandler) toQuestionResponse(q *models.OntologyQuestion
 
 	return resp
 }
+
+// parseQuestionSearchFilters extracts question search filters from query params.
+// Returns a non-empty error message when a parameter is present but invalid.
+func parseQuestionSearchFilters(r *http.Request) (repositories.QuestionListFilters, string) {
+	query := r.URL.Query()
+	filters := repositories.QuestionListFilters{
+		Limit:  defaultQuestionPageSize,
+		Offset: 0,
+	}
+
+	if v := query.Get("status"); v != "" {
+		status := models.QuestionStatus(v)
+		if !models.IsValidQuestionStatus(status) {
+			return filters, "Invalid status filter"
+		}
+		filters.Status = &status
+	}
+
+	if v := query.Get("required"); v != "" {
+		required, err := strconv.ParseBool(v)
+		if err != nil {
+			return filters, "Invalid required filter, expected true or false"
+		}
+		filters.IsRequired = &required
+	}
+
+	if v := query.Get("category"); v != "" {
+		valid := false
+		for _, c := range models.ValidQuestionCategories {
+			if c == v {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return filters, "Invalid category filter"
+		}
+		filters.Category = &v
+	}
+
+	if v := query.Get("entity"); v != "" {
+		filters.SourceEntityKey = &v
+	}
+
+	if v := query.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return filters, "Invalid limit, expected a positive integer"
+		}
+		filters.Limit = min(n, maxQuestionPageSize)
+	}
+
+	if v := query.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return filters, "Invalid offset, expected a non-negative integer"
+		}
+		filters.Offset = n
+	}
+
+	return filters, ""
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
 
 	"github.com/google/uuid"
@@ -19,6 +20,12 @@ import (
 type mockQuestionService struct {
 	pendingCounts    *repositories.QuestionCounts
 	pendingCountsErr error
+	listResult       *repositories.QuestionListResult
+	listErr          error
+	lastListFilters  *repositories.QuestionListFilters
+
+	orphaned          []*models.OntologyQuestion
+	lastCleanupDelete *bool
 }
 
 func (m *mockQuestionService) GetNextQuestion(_ context.Context, _ uuid.UUID, _ bool) (*models.OntologyQuestion, error) {
@@ -29,6 +36,17 @@ func (m *mockQuestionService) GetPendingQuestions(_ context.Context, _ uuid.UUID
 	return nil, nil
 }
 
+func (m *mockQuestionService) ListQuestions(_ context.Context, _ uuid.UUID, filters repositories.QuestionListFilters) (*repositories.QuestionListResult, error) {
+	m.lastListFilters = &filters
+	if m.listErr != nil {
+		return nil, m.listErr
+	}
+	if m.listResult == nil {
+		return &repositories.QuestionListResult{}, nil
+	}
+	return m.listResult, nil
+}
+
 func (m *mockQuestionService) GetPendingCount(_ context.Context, _ uuid.UUID) (int, error) {
 	return 0, nil
 }
@@ -53,6 +71,23 @@ func (m *mockQuestionService) CreateQuestions(_ context.Context, _ []*models.Ont
 	return nil
 }
 
+func (m *mockQuestionService) AnswerFromGlossary(_ context.Context, _ uuid.UUID, _ []*models.BusinessGlossaryTerm) (int, error) {
+	return 0, nil
+}
+
+func (m *mockQuestionService) TrimQuestionsPerTable(_ context.Context, _ uuid.UUID, _ int) (int, error) {
+	return 0, nil
+}
+
+func (m *mockQuestionService) FindOrphanedQuestions(_ context.Context, _ uuid.UUID) ([]*models.OntologyQuestion, error) {
+	return m.orphaned, nil
+}
+
+func (m *mockQuestionService) CleanupOrphanedQuestions(_ context.Context, _ uuid.UUID, deleteQuestions bool) (int, error) {
+	m.lastCleanupDelete = &deleteQuestions
+	return len(m.orphaned), nil
+}
+
 func TestCounts_Success(t *testing.T) {
 	svc := &mockQuestionService{
 		pendingCounts: &repositories.QuestionCounts{Required: 3, Optional: 5},
@@ -191,3 +226,224 @@ func TestCounts_InvalidProjectID(t *testing.T) {
 		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body.String())
 	}
 }
+
+func TestSearch_FilterCombinations(t *testing.T) {
+	boolPtr := func(b bool) *bool { return &b }
+	strPtr := func(s string) *string { return &s }
+	statusPtr := func(s models.QuestionStatus) *models.QuestionStatus { return &s }
+
+	tests := []struct {
+		name     string
+		query    string
+		expected repositories.QuestionListFilters
+	}{
+		{
+			name:     "no filters uses defaults",
+			query:    "",
+			expected: repositories.QuestionListFilters{Limit: 20},
+		},
+		{
+			name:  "status and required",
+			query: "?status=pending&required=true",
+			expected: repositories.QuestionListFilters{
+				Status:     statusPtr(models.QuestionStatusPending),
+				IsRequired: boolPtr(true),
+				Limit:      20,
+			},
+		},
+		{
+			name:  "category and source entity with pagination",
+			query: "?category=enumeration&entity=orders&limit=5&offset=10",
+			expected: repositories.QuestionListFilters{
+				Category:        strPtr(models.QuestionCategoryEnumeration),
+				SourceEntityKey: strPtr("orders"),
+				Limit:           5,
+				Offset:          10,
+			},
+		},
+		{
+			name:  "optional only with limit capped",
+			query: "?required=false&limit=1000",
+			expected: repositories.QuestionListFilters{
+				IsRequired: boolPtr(false),
+				Limit:      100,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &mockQuestionService{}
+			handler := NewOntologyQuestionsHandler(svc, zap.NewNop())
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /api/projects/{pid}/ontology/questions/search", handler.Search)
+
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/projects/%s/ontology/questions/search%s", uuid.New(), tt.query), nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+			}
+			if svc.lastListFilters == nil {
+				t.Fatal("expected ListQuestions to be called")
+			}
+			if !reflect.DeepEqual(*svc.lastListFilters, tt.expected) {
+				t.Errorf("filters mismatch:\n got: %+v\nwant: %+v", *svc.lastListFilters, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSearch_InvalidFilters(t *testing.T) {
+	queries := []string{
+		"?status=bogus",
+		"?required=maybe",
+		"?category=unknown",
+		"?limit=0",
+		"?offset=-1",
+	}
+
+	for _, query := range queries {
+		t.Run(query, func(t *testing.T) {
+			svc := &mockQuestionService{}
+			handler := NewOntologyQuestionsHandler(svc, zap.NewNop())
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /api/projects/{pid}/ontology/questions/search", handler.Search)
+
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/projects/%s/ontology/questions/search%s", uuid.New(), query), nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("expected 400, got %d: %s", w.Code, w.Body.String())
+			}
+			if svc.lastListFilters != nil {
+				t.Error("ListQuestions should not be called for invalid filters")
+			}
+		})
+	}
+}
+
+func TestSearch_ReturnsSourceEntityAndTotals(t *testing.T) {
+	projectID := uuid.New()
+	svc := &mockQuestionService{
+		listResult: &repositories.QuestionListResult{
+			Questions: []*models.OntologyQuestion{
+				{
+					ID:               uuid.New(),
+					ProjectID:        projectID,
+					Text:             "What does status=3 mean?",
+					IsRequired:       true,
+					Priority:         1,
+					SourceEntityType: "table",
+					SourceEntityKey:  "orders",
+					Status:           models.QuestionStatusPending,
+				},
+			},
+			TotalCount: 7,
+			CountsByStatus: map[models.QuestionStatus]int{
+				models.QuestionStatusPending:  7,
+				models.QuestionStatusAnswered: 2,
+			},
+		},
+	}
+	handler := NewOntologyQuestionsHandler(svc, zap.NewNop())
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/projects/{pid}/ontology/questions/search", handler.Search)
+
+	req := httptest.NewRequest("GET", fmt.Sprintf("/api/projects/%s/ontology/questions/search?status=pending&limit=1", projectID), nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Success bool                    `json:"success"`
+		Data    SearchQuestionsResponse `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if resp.Data.TotalCount != 7 {
+		t.Errorf("expected total_count=7, got %d", resp.Data.TotalCount)
+	}
+	if resp.Data.CountsByStatus["answered"] != 2 {
+		t.Errorf("expected 2 answered, got %d", resp.Data.CountsByStatus["answered"])
+	}
+	if resp.Data.Limit != 1 {
+		t.Errorf("expected limit=1, got %d", resp.Data.Limit)
+	}
+	if len(resp.Data.Questions) != 1 {
+		t.Fatalf("expected 1 question, got %d", len(resp.Data.Questions))
+	}
+	if resp.Data.Questions[0].SourceEntityName != "orders" {
+		t.Errorf("expected source_entity_name=orders, got %q", resp.Data.Questions[0].SourceEntityName)
+	}
+	if resp.Data.Questions[0].SourceEntityType != "table" {
+		t.Errorf("expected source_entity_type=table, got %q", resp.Data.Questions[0].SourceEntityType)
+	}
+}
+
+func TestSearch_ServiceError(t *testing.T) {
+	svc := &mockQuestionService{listErr: fmt.Errorf("database connection failed")}
+	handler := NewOntologyQuestionsHandler(svc, zap.NewNop())
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/projects/{pid}/ontology/questions/search", handler.Search)
+
+	req := httptest.NewRequest("GET", fmt.Sprintf("/api/projects/%s/ontology/questions/search", uuid.New()), nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", w.Code)
+	}
+}
+
+func TestCleanupOrphans_DeleteFlag(t *testing.T) {
+	projectID := uuid.New()
+	svc := &mockQuestionService{
+		orphaned: []*models.OntologyQuestion{
+			{ID: uuid.New(), ProjectID: projectID, Text: "What does orders.status mean?", SourceEntityType: "table", SourceEntityKey: "orders"},
+		},
+	}
+	handler := NewOntologyQuestionsHandler(svc, zap.NewNop())
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/projects/{pid}/ontology/questions/orphans/cleanup", handler.CleanupOrphans)
+
+	for _, tc := range []struct {
+		query      string
+		wantDelete bool
+	}{
+		{query: "", wantDelete: false},
+		{query: "?delete=true", wantDelete: true},
+	} {
+		req := httptest.NewRequest("POST", fmt.Sprintf("/api/projects/%s/ontology/questions/orphans/cleanup%s", projectID, tc.query), nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("query %q: expected 200, got %d: %s", tc.query, w.Code, w.Body.String())
+		}
+		if svc.lastCleanupDelete == nil || *svc.lastCleanupDelete != tc.wantDelete {
+			t.Errorf("query %q: expected deleteQuestions=%v, got %v", tc.query, tc.wantDelete, svc.lastCleanupDelete)
+		}
+
+		var resp ApiResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		data := resp.Data.(map[string]any)
+		if int(data["cleaned"].(float64)) != 1 {
+			t.Errorf("query %q: expected cleaned=1, got %v", tc.query, data["cleaned"])
+		}
+	}
+}
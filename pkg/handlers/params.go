@@ -61,6 +61,14 @@ func ParseKnowledgeID(w http.ResponseWriter, r *http.Request, logger *zap.Logger
 	return parseUUID(w, r, "kid", "invalid_knowledge_id", "Invalid knowledge ID format", logger)
 }
 
+// ParseJobID extracts and validates the job ID from the request path.
+// Returns the parsed UUID and true on success, or uuid.Nil and false on error
+// (after writing an error response).
+// Expects path parameter: jid
+func ParseJobID(w http.ResponseWriter, r *http.Request, logger *zap.Logger) (uuid.UUID, bool) {
+	return parseUUID(w, r, "jid", "invalid_job_id", "Invalid job ID format", logger)
+}
+
 // ParseProjectAndDatasourceIDs extracts and validates both project and datasource IDs.
 // Returns both UUIDs and true on success, or uuid.Nil values and false on error.
 // Expects path parameters: pid, dsid
@@ -492,6 +492,9 @@ func (m *mockGlossaryServiceForRBAC) CreateTerm(ctx context.Context, projectID u
 func (m *mockGlossaryServiceForRBAC) UpdateTerm(ctx context.Context, term *models.BusinessGlossaryTerm) error {
 	return nil
 }
+func (m *mockGlossaryServiceForRBAC) UpsertTerm(ctx context.Context, projectID uuid.UUID, term *models.BusinessGlossaryTerm) (bool, error) {
+	return true, nil
+}
 func (m *mockGlossaryServiceForRBAC) DeleteTerm(ctx context.Context, termID uuid.UUID) error {
 	return nil
 }
@@ -538,6 +541,9 @@ func (m *mockQuestionServiceForRBAC) GetNextQuestion(ctx context.Context, projec
 func (m *mockQuestionServiceForRBAC) GetPendingQuestions(ctx context.Context, projectID uuid.UUID) ([]*models.OntologyQuestion, error) {
 	return nil, nil
 }
+func (m *mockQuestionServiceForRBAC) ListQuestions(ctx context.Context, projectID uuid.UUID, filters repositories.QuestionListFilters) (*repositories.QuestionListResult, error) {
+	return &repositories.QuestionListResult{}, nil
+}
 func (m *mockQuestionServiceForRBAC) GetPendingCount(ctx context.Context, projectID uuid.UUID) (int, error) {
 	return 0, nil
 }
@@ -550,6 +556,18 @@ func (m *mockQuestionServiceForRBAC) AnswerQuestion(ctx context.Context, questio
 func (m *mockQuestionServiceForRBAC) SkipQuestion(ctx context.Context, questionID uuid.UUID) error {
 	return nil
 }
+func (m *mockQuestionServiceForRBAC) AnswerFromGlossary(ctx context.Context, projectID uuid.UUID, terms []*models.BusinessGlossaryTerm) (int, error) {
+	return 0, nil
+}
+func (m *mockQuestionServiceForRBAC) TrimQuestionsPerTable(ctx context.Context, projectID uuid.UUID, maxPerTable int) (int, error) {
+	return 0, nil
+}
+func (m *mockQuestionServiceForRBAC) FindOrphanedQuestions(ctx context.Context, projectID uuid.UUID) ([]*models.OntologyQuestion, error) {
+	return nil, nil
+}
+func (m *mockQuestionServiceForRBAC) CleanupOrphanedQuestions(ctx context.Context, projectID uuid.UUID, deleteQuestions bool) (int, error) {
+	return 0, nil
+}
 func (m *mockQuestionServiceForRBAC) DeleteQuestion(ctx context.Context, questionID uuid.UUID) error {
 	return nil
 }
@@ -600,9 +618,15 @@ func (m *mockOntologyDAGServiceForRBAC) Start(ctx context.Context, projectID, da
 func (m *mockOntologyDAGServiceForRBAC) GetStatus(ctx context.Context, datasourceID uuid.UUID) (*models.OntologyDAG, error) {
 	return nil, nil
 }
+func (m *mockOntologyDAGServiceForRBAC) GetTokenSpend(projectID uuid.UUID) *services.TokenSpend {
+	return nil
+}
 func (m *mockOntologyDAGServiceForRBAC) Cancel(ctx context.Context, dagID uuid.UUID) error {
 	return nil
 }
+func (m *mockOntologyDAGServiceForRBAC) CancelJob(ctx context.Context, projectID, jobID uuid.UUID) (*models.OntologyDAG, error) {
+	return &models.OntologyDAG{}, nil
+}
 func (m *mockOntologyDAGServiceForRBAC) Delete(ctx context.Context, projectID uuid.UUID) error {
 	return nil
 }
@@ -639,6 +663,10 @@ func (m *mockOntologyExportServiceForRBAC) SuggestedFilename(bundle *models.Onto
 	return "ontology-export.json"
 }
 
+func (m *mockOntologyExportServiceForRBAC) DiffAgainstBundle(ctx context.Context, projectID, datasourceID uuid.UUID, baseline []byte) (*models.OntologyDiff, error) {
+	return &models.OntologyDiff{}, nil
+}
+
 // mockOntologyImportServiceForRBAC implements services.OntologyImportService.
 type mockOntologyImportServiceForRBAC struct{}
 
@@ -878,6 +906,11 @@ func TestRBAC_SchemaHandler(t *testing.T) {
 		{name: "POST_relationship_data_allowed", method: http.MethodPost, path: schemaBase + "/relationships", roles: []string{models.RoleData}, expectedStatus: http.StatusBadRequest},
 		{name: "POST_relationship_user_denied", method: http.MethodPost, path: schemaBase + "/relationships", roles: []string{models.RoleUser}, expectedStatus: http.StatusForbidden},
 
+		// POST project-level relationship - admin + data (400 = past RBAC, bad body)
+		{name: "POST_project_relationship_admin_allowed", method: http.MethodPost, path: "/api/projects/" + projectID.String() + "/relationships", roles: []string{models.RoleAdmin}, expectedStatus: http.StatusBadRequest},
+		{name: "POST_project_relationship_data_allowed", method: http.MethodPost, path: "/api/projects/" + projectID.String() + "/relationships", roles: []string{models.RoleData}, expectedStatus: http.StatusBadRequest},
+		{name: "POST_project_relationship_user_denied", method: http.MethodPost, path: "/api/projects/" + projectID.String() + "/relationships", roles: []string{models.RoleUser}, expectedStatus: http.StatusForbidden},
+
 		// DELETE relationship - admin + data (200 = past RBAC, handler returns JSON)
 		{name: "DELETE_relationship_admin_allowed", method: http.MethodDelete, path: schemaBase + "/relationships/" + relID.String(), roles: []string{models.RoleAdmin}, expectedStatus: http.StatusOK},
 		{name: "DELETE_relationship_data_allowed", method: http.MethodDelete, path: schemaBase + "/relationships/" + relID.String(), roles: []string{models.RoleData}, expectedStatus: http.StatusOK},
@@ -945,7 +978,7 @@ func TestRBAC_KnowledgeHandler(t *testing.T) {
 func TestRBAC_OntologyDAGHandler(t *testing.T) {
 	projectID := uuid.New()
 	dsID := uuid.New()
-	handler := NewOntologyDAGHandler(&mockOntologyDAGServiceForRBAC{}, &mockProjectService{}, zap.NewNop())
+	handler := NewOntologyDAGHandler(&mockOntologyDAGServiceForRBAC{}, &mockProjectService{}, &mockSchemaService{}, nil, zap.NewNop())
 
 	base := "/api/projects/" + projectID.String() + "/datasources/" + dsID.String() + "/ontology"
 
@@ -958,6 +991,21 @@ func TestRBAC_OntologyDAGHandler(t *testing.T) {
 		{name: "POST_extract_data_allowed", method: http.MethodPost, path: base + "/extract", roles: []string{models.RoleData}, expectedStatus: http.StatusOK},
 		{name: "POST_extract_user_denied", method: http.MethodPost, path: base + "/extract", roles: []string{models.RoleUser}, expectedStatus: http.StatusForbidden},
 
+		// POST project extract - admin + data (400 = past RBAC, project has no datasource)
+		{name: "POST_project_extract_admin_allowed", method: http.MethodPost, path: "/api/projects/" + projectID.String() + "/extract", roles: []string{models.RoleAdmin}, expectedStatus: http.StatusBadRequest},
+		{name: "POST_project_extract_data_allowed", method: http.MethodPost, path: "/api/projects/" + projectID.String() + "/extract", roles: []string{models.RoleData}, expectedStatus: http.StatusBadRequest},
+		{name: "POST_project_extract_user_denied", method: http.MethodPost, path: "/api/projects/" + projectID.String() + "/extract", roles: []string{models.RoleUser}, expectedStatus: http.StatusForbidden},
+
+		// POST resync-and-update - admin + data (400 = past RBAC, project has no datasource)
+		{name: "POST_resync_admin_allowed", method: http.MethodPost, path: "/api/projects/" + projectID.String() + "/resync-and-update", roles: []string{models.RoleAdmin}, expectedStatus: http.StatusBadRequest},
+		{name: "POST_resync_data_allowed", method: http.MethodPost, path: "/api/projects/" + projectID.String() + "/resync-and-update", roles: []string{models.RoleData}, expectedStatus: http.StatusBadRequest},
+		{name: "POST_resync_user_denied", method: http.MethodPost, path: "/api/projects/" + projectID.String() + "/resync-and-update", roles: []string{models.RoleUser}, expectedStatus: http.StatusForbidden},
+
+		// POST job cancel - admin + data (200 = past RBAC, mock returns cancelled job)
+		{name: "POST_job_cancel_admin_allowed", method: http.MethodPost, path: "/api/projects/" + projectID.String() + "/jobs/" + uuid.New().String() + "/cancel", roles: []string{models.RoleAdmin}, expectedStatus: http.StatusOK},
+		{name: "POST_job_cancel_data_allowed", method: http.MethodPost, path: "/api/projects/" + projectID.String() + "/jobs/" + uuid.New().String() + "/cancel", roles: []string{models.RoleData}, expectedStatus: http.StatusOK},
+		{name: "POST_job_cancel_user_denied", method: http.MethodPost, path: "/api/projects/" + projectID.String() + "/jobs/" + uuid.New().String() + "/cancel", roles: []string{models.RoleUser}, expectedStatus: http.StatusForbidden},
+
 		// POST cancel - admin + data (404 = past RBAC, mock returns nil DAG)
 		{name: "POST_cancel_admin_allowed", method: http.MethodPost, path: base + "/dag/cancel", roles: []string{models.RoleAdmin}, expectedStatus: http.StatusNotFound},
 		{name: "POST_cancel_data_allowed", method: http.MethodPost, path: base + "/dag/cancel", roles: []string{models.RoleData}, expectedStatus: http.StatusNotFound},
@@ -982,11 +1030,48 @@ func TestRBAC_OntologyExportHandler(t *testing.T) {
 	handler := NewOntologyExportHandler(&mockOntologyExportServiceForRBAC{}, zap.NewNop())
 
 	path := "/api/projects/" + projectID.String() + "/datasources/" + dsID.String() + "/ontology/export"
+	diffPath := "/api/projects/" + projectID.String() + "/datasources/" + dsID.String() + "/ontology/diff"
 
 	tests := []rbacTestCase{
 		{name: "GET_export_admin_allowed", method: http.MethodGet, path: path, roles: []string{models.RoleAdmin}, expectedStatus: http.StatusOK},
 		{name: "GET_export_data_allowed", method: http.MethodGet, path: path, roles: []string{models.RoleData}, expectedStatus: http.StatusOK},
 		{name: "GET_export_user_denied", method: http.MethodGet, path: path, roles: []string{models.RoleUser}, expectedStatus: http.StatusForbidden},
+		{name: "POST_diff_admin_allowed", method: http.MethodPost, path: diffPath, roles: []string{models.RoleAdmin}, expectedStatus: http.StatusOK},
+		{name: "POST_diff_data_allowed", method: http.MethodPost, path: diffPath, roles: []string{models.RoleData}, expectedStatus: http.StatusOK},
+		{name: "POST_diff_user_denied", method: http.MethodPost, path: diffPath, roles: []string{models.RoleUser}, expectedStatus: http.StatusForbidden},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			runRBACTest(t, projectID, handler.RegisterRoutes, tc)
+		})
+	}
+}
+
+func TestRBAC_OntologyEntitiesHandler(t *testing.T) {
+	projectID := uuid.New()
+	dsID := uuid.New()
+	handler := NewOntologyEntitiesHandler(&mockOntologyEntityService{
+		listFn: func(ctx context.Context, projectID, datasourceID uuid.UUID, source models.ProvenanceSource) ([]*models.OntologyEntity, error) {
+			return []*models.OntologyEntity{}, nil
+		},
+		reviewFn: func(ctx context.Context, projectID, datasourceID uuid.UUID) ([]*models.OntologyEntity, error) {
+			return []*models.OntologyEntity{}, nil
+		},
+		purgeFn: func(ctx context.Context, projectID, datasourceID uuid.UUID, source models.ProvenanceSource, dryRun bool) (*models.OntologyEntityPurgeResult, error) {
+			return &models.OntologyEntityPurgeResult{Source: source, DryRun: dryRun, Tables: []string{}}, nil
+		},
+	}, zap.NewNop())
+
+	path := "/api/projects/" + projectID.String() + "/datasources/" + dsID.String() + "/ontology/entities"
+	purgePath := path + "?source=inferred&dry_run=true"
+
+	tests := []rbacTestCase{
+		{name: "GET_entities_user_allowed", method: http.MethodGet, path: path, roles: []string{models.RoleUser}, expectedStatus: http.StatusOK},
+		{name: "GET_review_user_allowed", method: http.MethodGet, path: path + "/review", roles: []string{models.RoleUser}, expectedStatus: http.StatusOK},
+		{name: "DELETE_entities_admin_allowed", method: http.MethodDelete, path: purgePath, roles: []string{models.RoleAdmin}, expectedStatus: http.StatusOK},
+		{name: "DELETE_entities_data_allowed", method: http.MethodDelete, path: purgePath, roles: []string{models.RoleData}, expectedStatus: http.StatusOK},
+		{name: "DELETE_entities_user_denied", method: http.MethodDelete, path: purgePath, roles: []string{models.RoleUser}, expectedStatus: http.StatusForbidden},
 	}
 
 	for _, tc := range tests {
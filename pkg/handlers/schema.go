@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
 
@@ -82,6 +83,12 @@ type RelationshipResponse struct {
 	UpdatedBy        *string `json:"updated_by,omitempty"`
 }
 
+// CreateRelationshipResponse is a created relationship plus any join analysis warnings.
+type CreateRelationshipResponse struct {
+	RelationshipResponse
+	Warnings []string `json:"warnings,omitempty"`
+}
+
 // RefreshSchemaResponse contains statistics from a schema refresh operation.
 type RefreshSchemaResponse struct {
 	TablesUpserted        int      `json:"tables_upserted"`
@@ -95,6 +102,21 @@ type RefreshSchemaResponse struct {
 	RemovedTableNames     []string `json:"removed_table_names"`
 }
 
+// newRefreshSchemaResponse converts a refresh result to its API representation.
+func newRefreshSchemaResponse(result *models.RefreshResultWithChanges) RefreshSchemaResponse {
+	return RefreshSchemaResponse{
+		TablesUpserted:        result.TablesUpserted,
+		TablesDeleted:         result.TablesDeleted,
+		ColumnsUpserted:       result.ColumnsUpserted,
+		ColumnsDeleted:        result.ColumnsDeleted,
+		RelationshipsCreated:  result.RelationshipsCreated,
+		RelationshipsDeleted:  result.RelationshipsDeleted,
+		PendingChangesCreated: result.PendingChangesCreated,
+		NewTableNames:         result.NewTableNames,
+		RemovedTableNames:     result.RemovedTableNames,
+	}
+}
+
 // PendingChangeInfo represents a pending change for a table or column in the schema response.
 type PendingChangeInfo struct {
 	ChangeID   string `json:"change_id"`
@@ -179,6 +201,12 @@ func (h *SchemaHandler) RegisterRoutes(mux *http.ServeMux, authMiddleware *auth.
 	mux.HandleFunc("POST /api/projects/{pid}/datasources/{dsid}/schema/refresh",
 		authMiddleware.RequireAuthWithPathValidation("pid")(
 			auth.RequireRole(models.RoleAdmin, models.RoleData)(tenantMiddleware(h.RefreshSchema))))
+	mux.HandleFunc("POST /api/projects/{pid}/datasources/{dsid}/schema/inventory",
+		authMiddleware.RequireAuthWithPathValidation("pid")(
+			auth.RequireRole(models.RoleAdmin, models.RoleData)(tenantMiddleware(h.InventorySchema))))
+	mux.HandleFunc("POST /api/projects/{pid}/datasources/{dsid}/schema/introspect",
+		authMiddleware.RequireAuthWithPathValidation("pid")(
+			auth.RequireRole(models.RoleAdmin, models.RoleData)(tenantMiddleware(h.IntrospectTables))))
 
 	// Table operations
 	mux.HandleFunc("GET /api/projects/{pid}/datasources/{dsid}/schema/tables/{tableName}",
@@ -199,6 +227,8 @@ func (h *SchemaHandler) RegisterRoutes(mux *http.ServeMux, authMiddleware *auth.
 	// Relationship operations (datasource-level)
 	mux.HandleFunc("GET /api/projects/{pid}/datasources/{dsid}/schema/relationships",
 		authMiddleware.RequireAuthWithPathValidation("pid")(tenantMiddleware(h.GetRelationships)))
+	mux.HandleFunc("GET /api/projects/{pid}/datasources/{dsid}/schema/relationships/stats",
+		authMiddleware.RequireAuthWithPathValidation("pid")(tenantMiddleware(h.GetRelationshipStats)))
 	mux.HandleFunc("POST /api/projects/{pid}/datasources/{dsid}/schema/relationships",
 		authMiddleware.RequireAuthWithPathValidation("pid")(
 			auth.RequireRole(models.RoleAdmin, models.RoleData)(tenantMiddleware(h.AddRelationship))))
@@ -209,6 +239,11 @@ func (h *SchemaHandler) RegisterRoutes(mux *http.ServeMux, authMiddleware *auth.
 	// Project-level relationship operations (aggregates across all datasources)
 	mux.HandleFunc("GET /api/projects/{pid}/relationships",
 		authMiddleware.RequireAuthWithPathValidation("pid")(tenantMiddleware(h.GetProjectRelationships)))
+	mux.HandleFunc("POST /api/projects/{pid}/relationships",
+		authMiddleware.RequireAuthWithPathValidation("pid")(
+			auth.RequireRole(models.RoleAdmin, models.RoleData)(tenantMiddleware(h.CreateProjectRelationship))))
+	mux.HandleFunc("GET /api/projects/{pid}/relationships/{id}/metrics",
+		authMiddleware.RequireAuthWithPathValidation("pid")(tenantMiddleware(h.GetRelationshipMetrics)))
 }
 
 // GetSchema handles GET /api/projects/{pid}/datasources/{dsid}/schema
@@ -354,23 +389,94 @@ func (h *SchemaHandler) RefreshSchema(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	data := RefreshSchemaResponse{
-		TablesUpserted:        result.TablesUpserted,
-		TablesDeleted:         result.TablesDeleted,
-		ColumnsUpserted:       result.ColumnsUpserted,
-		ColumnsDeleted:        result.ColumnsDeleted,
-		RelationshipsCreated:  result.RelationshipsCreated,
-		RelationshipsDeleted:  result.RelationshipsDeleted,
-		PendingChangesCreated: result.PendingChangesCreated,
-		NewTableNames:         result.NewTableNames,
-		RemovedTableNames:     result.RemovedTableNames,
-	}
-	response := ApiResponse{Success: true, Data: data}
+	response := ApiResponse{Success: true, Data: newRefreshSchemaResponse(result)}
 	if err := WriteJSON(w, http.StatusOK, response); err != nil {
 		h.logger.Error("Failed to write response", zap.Error(err))
 	}
 }
 
+// InventorySchema handles POST /api/projects/{pid}/datasources/{dsid}/schema/inventory
+// Lists every table with its row count without introspecting columns, so very large
+// databases can be scoped before deep introspection.
+func (h *SchemaHandler) InventorySchema(w http.ResponseWriter, r *http.Request) {
+	projectID, datasourceID, ok := ParseProjectAndDatasourceIDs(w, r, h.logger)
+	if !ok {
+		return
+	}
+
+	result, err := h.schemaService.InventoryDatasourceSchema(r.Context(), projectID, datasourceID)
+	if err != nil {
+		h.logger.Error("Failed to inventory schema",
+			zap.String("project_id", projectID.String()),
+			zap.String("datasource_id", datasourceID.String()),
+			zap.Error(err))
+		if err := ErrorResponse(w, http.StatusInternalServerError, "inventory_schema_failed", "Failed to inventory schema"); err != nil {
+			h.logger.Error("Failed to write error response", zap.Error(err))
+		}
+		return
+	}
+
+	if err := WriteJSON(w, http.StatusOK, ApiResponse{Success: true, Data: result}); err != nil {
+		h.logger.Error("Failed to write response", zap.Error(err))
+	}
+}
+
+// IntrospectTables handles POST /api/projects/{pid}/datasources/{dsid}/schema/introspect
+// Syncs columns and foreign keys for the named tables and/or the top N by row count or
+// reference count. An empty body introspects the currently selected tables.
+func (h *SchemaHandler) IntrospectTables(w http.ResponseWriter, r *http.Request) {
+	projectID, datasourceID, ok := ParseProjectAndDatasourceIDs(w, r, h.logger)
+	if !ok {
+		return
+	}
+
+	var req models.IntrospectTablesRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			if err := ErrorResponse(w, http.StatusBadRequest, "invalid_request", "Invalid request body"); err != nil {
+				h.logger.Error("Failed to write error response", zap.Error(err))
+			}
+			return
+		}
+	}
+	if req.TopN < 0 || !models.IsValidIntrospectionRank(req.RankBy) {
+		if err := ErrorResponse(w, http.StatusBadRequest, "invalid_request",
+			"top_n must not be negative and rank_by must be row_count or reference_count"); err != nil {
+			h.logger.Error("Failed to write error response", zap.Error(err))
+		}
+		return
+	}
+
+	result, err := h.schemaService.IntrospectTables(r.Context(), projectID, datasourceID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrNotFound):
+			if err := ErrorResponse(w, http.StatusNotFound, "table_not_found", err.Error()); err != nil {
+				h.logger.Error("Failed to write error response", zap.Error(err))
+			}
+			return
+		case errors.Is(err, apperrors.ErrNoSelectedTables):
+			if err := ErrorResponse(w, http.StatusBadRequest, "no_selected_tables",
+				"No tables to introspect: name tables, set top_n, or select tables first"); err != nil {
+				h.logger.Error("Failed to write error response", zap.Error(err))
+			}
+			return
+		}
+		h.logger.Error("Failed to introspect tables",
+			zap.String("project_id", projectID.String()),
+			zap.String("datasource_id", datasourceID.String()),
+			zap.Error(err))
+		if err := ErrorResponse(w, http.StatusInternalServerError, "introspect_tables_failed", "Failed to introspect tables"); err != nil {
+			h.logger.Error("Failed to write error response", zap.Error(err))
+		}
+		return
+	}
+
+	if err := WriteJSON(w, http.StatusOK, ApiResponse{Success: true, Data: result}); err != nil {
+		h.logger.Error("Failed to write response", zap.Error(err))
+	}
+}
+
 // GetTable handles GET /api/projects/{pid}/datasources/{dsid}/schema/tables/{tableName}
 // Returns a single table with its columns.
 func (h *SchemaHandler) GetTable(w http.ResponseWriter, r *http.Request) {
@@ -604,6 +710,73 @@ func (h *SchemaHandler) GetProjectRelationships(w http.ResponseWriter, r *http.R
 	}
 }
 
+// GetRelationshipMetrics handles GET /api/projects/{pid}/relationships/{id}/metrics
+// Returns the stored discovery metrics explaining how a relationship was scored.
+func (h *SchemaHandler) GetRelationshipMetrics(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := ParseProjectID(w, r, h.logger)
+	if !ok {
+		return
+	}
+
+	relationshipID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		if err := ErrorResponse(w, http.StatusBadRequest, "invalid_relationship_id", "Invalid relationship ID format"); err != nil {
+			h.logger.Error("Failed to write error response", zap.Error(err))
+		}
+		return
+	}
+
+	metrics, err := h.schemaService.GetRelationshipMetrics(r.Context(), projectID, relationshipID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			if err := ErrorResponse(w, http.StatusNotFound, "relationship_not_found", "Relationship not found"); err != nil {
+				h.logger.Error("Failed to write error response", zap.Error(err))
+			}
+			return
+		}
+		h.logger.Error("Failed to get relationship metrics",
+			zap.String("project_id", projectID.String()),
+			zap.String("relationship_id", relationshipID.String()),
+			zap.Error(err))
+		if err := ErrorResponse(w, http.StatusInternalServerError, "get_relationship_metrics_failed", "Failed to get relationship metrics"); err != nil {
+			h.logger.Error("Failed to write error response", zap.Error(err))
+		}
+		return
+	}
+
+	response := ApiResponse{Success: true, Data: metrics}
+	if err := WriteJSON(w, http.StatusOK, response); err != nil {
+		h.logger.Error("Failed to write response", zap.Error(err))
+	}
+}
+
+// GetRelationshipStats handles GET /api/projects/{pid}/datasources/{dsid}/schema/relationships/stats
+// Returns relationship counts by inference method, confidence and approval status,
+// and how many tables remain without relationships.
+func (h *SchemaHandler) GetRelationshipStats(w http.ResponseWriter, r *http.Request) {
+	projectID, datasourceID, ok := ParseProjectAndDatasourceIDs(w, r, h.logger)
+	if !ok {
+		return
+	}
+
+	stats, err := h.schemaService.GetRelationshipStats(r.Context(), projectID, datasourceID)
+	if err != nil {
+		h.logger.Error("Failed to get relationship stats",
+			zap.String("project_id", projectID.String()),
+			zap.String("datasource_id", datasourceID.String()),
+			zap.Error(err))
+		if err := ErrorResponse(w, http.StatusInternalServerError, "get_relationship_stats_failed", "Failed to get relationship stats"); err != nil {
+			h.logger.Error("Failed to write error response", zap.Error(err))
+		}
+		return
+	}
+
+	response := ApiResponse{Success: true, Data: stats}
+	if err := WriteJSON(w, http.StatusOK, response); err != nil {
+		h.logger.Error("Failed to write response", zap.Error(err))
+	}
+}
+
 // AddRelationship handles POST /api/projects/{pid}/datasources/{dsid}/schema/relationships
 // Creates a user-defined relationship between two columns.
 func (h *SchemaHandler) AddRelationship(w http.ResponseWriter, r *http.Request) {
@@ -670,6 +843,102 @@ func (h *SchemaHandler) AddRelationship(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// CreateProjectRelationship handles POST /api/projects/{pid}/relationships
+// Creates a manual relationship between two columns picked by ID. When analyze_join is set,
+// the join is checked against the datasource and orphaned source values are reported as warnings.
+func (h *SchemaHandler) CreateProjectRelationship(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := ParseProjectID(w, r, h.logger)
+	if !ok {
+		return
+	}
+
+	var req models.AddColumnRelationshipRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		if err := ErrorResponse(w, http.StatusBadRequest, "invalid_request", "Invalid request body"); err != nil {
+			h.logger.Error("Failed to write error response", zap.Error(err))
+		}
+		return
+	}
+
+	if req.SourceColumnID == uuid.Nil || req.TargetColumnID == uuid.Nil {
+		if err := ErrorResponse(w, http.StatusBadRequest, "missing_fields", "source_column_id and target_column_id are required"); err != nil {
+			h.logger.Error("Failed to write error response", zap.Error(err))
+		}
+		return
+	}
+	if req.SourceColumnID == req.TargetColumnID {
+		if err := ErrorResponse(w, http.StatusBadRequest, "invalid_relationship", "Source and target columns must differ"); err != nil {
+			h.logger.Error("Failed to write error response", zap.Error(err))
+		}
+		return
+	}
+	if req.Cardinality != "" && !models.IsValidCardinality(req.Cardinality) {
+		if err := ErrorResponse(w, http.StatusBadRequest, "invalid_cardinality", "Invalid cardinality"); err != nil {
+			h.logger.Error("Failed to write error response", zap.Error(err))
+		}
+		return
+	}
+
+	manualCtx, provenanceErr := withManualRelationshipProvenance(r.Context())
+	if provenanceErr != nil {
+		h.logger.Error("Failed to set manual relationship provenance",
+			zap.String("project_id", projectID.String()),
+			zap.Error(provenanceErr))
+		if err := ErrorResponse(w, http.StatusInternalServerError, "relationship_provenance_failed", "Failed to record relationship provenance"); err != nil {
+			h.logger.Error("Failed to write error response", zap.Error(err))
+		}
+		return
+	}
+
+	relationship, err := h.schemaService.AddManualRelationshipByColumns(manualCtx, projectID, &req)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrConflict) {
+			if err := ErrorResponse(w, http.StatusConflict, "relationship_exists", "A relationship between these columns already exists"); err != nil {
+				h.logger.Error("Failed to write error response", zap.Error(err))
+			}
+			return
+		}
+		if errors.Is(err, apperrors.ErrNotFound) {
+			if err := ErrorResponse(w, http.StatusNotFound, "column_not_found", "Source or target column not found"); err != nil {
+				h.logger.Error("Failed to write error response", zap.Error(err))
+			}
+			return
+		}
+		if errors.Is(err, services.ErrCrossDatasourceRelationship) {
+			if err := ErrorResponse(w, http.StatusBadRequest, "invalid_relationship", err.Error()); err != nil {
+				h.logger.Error("Failed to write error response", zap.Error(err))
+			}
+			return
+		}
+		h.logger.Error("Failed to create relationship",
+			zap.String("project_id", projectID.String()),
+			zap.Error(err))
+		if err := ErrorResponse(w, http.StatusInternalServerError, "add_relationship_failed", "Failed to add relationship"); err != nil {
+			h.logger.Error("Failed to write error response", zap.Error(err))
+		}
+		return
+	}
+
+	data := CreateRelationshipResponse{
+		RelationshipResponse: h.toSchemaRelationshipResponse(relationship),
+		Warnings:             joinWarnings(relationship.ValidationResults),
+	}
+	response := ApiResponse{Success: true, Data: data}
+	if err := WriteJSON(w, http.StatusCreated, response); err != nil {
+		h.logger.Error("Failed to write response", zap.Error(err))
+	}
+}
+
+// joinWarnings describes problems found by a join analysis, if one ran.
+func joinWarnings(results *models.ValidationResults) []string {
+	if results == nil || results.OrphanCount == 0 {
+		return nil
+	}
+	return []string{fmt.Sprintf(
+		"%d of %d distinct source values have no match in the target column (%.1f%% orphaned)",
+		results.OrphanCount, results.SourceDistinct, results.OrphanRate*100)}
+}
+
 // RemoveRelationship handles DELETE /api/projects/{pid}/datasources/{dsid}/schema/relationships/{relId}
 // Marks a relationship as removed (is_approved=false).
 func (h *SchemaHandler) RemoveRelationship(w http.ResponseWriter, r *http.Request) {
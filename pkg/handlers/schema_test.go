@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -281,6 +282,40 @@ func TestSchemaHandler_RefreshSchema_Success(t *testing.T) {
 	}
 }
 
+func TestSchemaHandler_IntrospectTables_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		serviceErr error
+		wantStatus int
+	}{
+		{name: "unknown rank", body: `{"top_n": 5, "rank_by": "size"}`, wantStatus: http.StatusBadRequest},
+		{name: "negative top_n", body: `{"top_n": -1}`, wantStatus: http.StatusBadRequest},
+		{name: "nothing to introspect", body: ``, serviceErr: apperrors.ErrNoSelectedTables, wantStatus: http.StatusBadRequest},
+		{name: "unknown table", body: `{"table_names": ["public.missing"]}`, serviceErr: fmt.Errorf("table %q: %w", "public.missing", apperrors.ErrNotFound), wantStatus: http.StatusNotFound},
+		{name: "success", body: `{"table_names": ["public.users"]}`, wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectID := uuid.New()
+			datasourceID := uuid.New()
+			handler := NewSchemaHandler(&mockSchemaService{err: tt.serviceErr}, nil, zap.NewNop())
+
+			req := httptest.NewRequest(http.MethodPost, "/api/projects/"+projectID.String()+"/datasources/"+datasourceID.String()+"/schema/introspect", strings.NewReader(tt.body))
+			req.SetPathValue("pid", projectID.String())
+			req.SetPathValue("dsid", datasourceID.String())
+
+			rec := httptest.NewRecorder()
+			handler.IntrospectTables(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
 func TestSchemaHandler_GetTable_Success(t *testing.T) {
 	projectID := uuid.New()
 	datasourceID := uuid.New()
@@ -520,6 +555,103 @@ func TestSchemaHandler_AddRelationship_MissingFields(t *testing.T) {
 	}
 }
 
+func newCreateProjectRelationshipRequest(projectID uuid.UUID, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/api/projects/"+projectID.String()+"/relationships", bytes.NewBufferString(body))
+	req.SetPathValue("pid", projectID.String())
+	return req.WithContext(context.WithValue(req.Context(), auth.ClaimsKey, &auth.Claims{
+		ProjectID:        projectID.String(),
+		RegisteredClaims: jwt.RegisteredClaims{Subject: uuid.New().String()},
+	}))
+}
+
+func TestSchemaHandler_CreateProjectRelationship_ReturnsOrphanWarning(t *testing.T) {
+	projectID := uuid.New()
+	service := &mockSchemaService{
+		relationship: &models.SchemaRelationship{
+			ID:               uuid.New(),
+			ProjectID:        projectID,
+			RelationshipType: models.RelationshipTypeManual,
+			Cardinality:      models.CardinalityUnknown,
+			Confidence:       1.0,
+			IsValidated:      true,
+			ValidationResults: &models.ValidationResults{
+				OrphanCount:    5,
+				SourceDistinct: 50,
+				OrphanRate:     0.1,
+			},
+		},
+	}
+	handler := NewSchemaHandler(service, nil, zap.NewNop())
+
+	body := `{"source_column_id": "` + uuid.NewString() + `", "target_column_id": "` + uuid.NewString() + `", "analyze_join": true}`
+	rec := httptest.NewRecorder()
+	handler.CreateProjectRelationship(rec, newCreateProjectRelationshipRequest(projectID, body))
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp struct {
+		Data CreateRelationshipResponse `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Data.RelationshipType != models.RelationshipTypeManual {
+		t.Errorf("expected manual relationship, got %q", resp.Data.RelationshipType)
+	}
+	if len(resp.Data.Warnings) != 1 || !strings.Contains(resp.Data.Warnings[0], "5 of 50") {
+		t.Errorf("expected orphan warning, got %v", resp.Data.Warnings)
+	}
+}
+
+func TestSchemaHandler_CreateProjectRelationship_Validation(t *testing.T) {
+	projectID := uuid.New()
+	columnID := uuid.NewString()
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "missing target", body: `{"source_column_id": "` + columnID + `"}`},
+		{name: "same column", body: `{"source_column_id": "` + columnID + `", "target_column_id": "` + columnID + `"}`},
+		{name: "invalid cardinality", body: `{"source_column_id": "` + columnID + `", "target_column_id": "` + uuid.NewString() + `", "cardinality": "many"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewSchemaHandler(&mockSchemaService{}, nil, zap.NewNop())
+			rec := httptest.NewRecorder()
+			handler.CreateProjectRelationship(rec, newCreateProjectRelationshipRequest(projectID, tt.body))
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", rec.Code)
+			}
+		})
+	}
+}
+
+func TestSchemaHandler_CreateProjectRelationship_ServiceErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "exists", err: apperrors.ErrConflict, wantStatus: http.StatusConflict},
+		{name: "column not found", err: fmt.Errorf("source column not found: %w", apperrors.ErrNotFound), wantStatus: http.StatusNotFound},
+		{name: "cross datasource", err: services.ErrCrossDatasourceRelationship, wantStatus: http.StatusBadRequest},
+		{name: "unexpected", err: errors.New("boom"), wantStatus: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectID := uuid.New()
+			handler := NewSchemaHandler(&mockSchemaService{err: tt.err}, nil, zap.NewNop())
+			body := `{"source_column_id": "` + uuid.NewString() + `", "target_column_id": "` + uuid.NewString() + `"}`
+			rec := httptest.NewRecorder()
+			handler.CreateProjectRelationship(rec, newCreateProjectRelationshipRequest(projectID, body))
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+		})
+	}
+}
+
 func TestSchemaHandler_RemoveRelationship_Success(t *testing.T) {
 	service := &mockSchemaService{}
 	handler := NewSchemaHandler(service, nil, zap.NewNop())
@@ -591,6 +723,123 @@ func TestSchemaHandler_RemoveRelationship_InvalidID(t *testing.T) {
 	}
 }
 
+func TestSchemaHandler_GetRelationshipStats_Success(t *testing.T) {
+	service := &mockSchemaService{relationshipStats: &models.RelationshipStats{
+		TotalRelationships: 3,
+		ByMethod:           map[string]int{models.InferenceMethodFK: 2, models.InferenceMethodValueOverlap: 1},
+		Approval:           models.RelationshipApprovalCounts{Approved: 1, Pending: 2},
+		OrphanTables:       4,
+	}}
+	handler := NewSchemaHandler(service, nil, zap.NewNop())
+
+	projectID, datasourceID := uuid.New(), uuid.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/projects/"+projectID.String()+"/datasources/"+datasourceID.String()+"/schema/relationships/stats", nil)
+	req.SetPathValue("pid", projectID.String())
+	req.SetPathValue("dsid", datasourceID.String())
+
+	rec := httptest.NewRecorder()
+	handler.GetRelationshipStats(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+
+	var resp struct {
+		Success bool                     `json:"success"`
+		Data    models.RelationshipStats `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Data.ByMethod[models.InferenceMethodFK] != 2 || resp.Data.Approval.Pending != 2 || resp.Data.OrphanTables != 4 {
+		t.Errorf("unexpected stats: %+v", resp.Data)
+	}
+}
+
+func TestSchemaHandler_GetRelationshipMetrics_Success(t *testing.T) {
+	relID := uuid.New()
+	matchRate := 0.97
+	service := &mockSchemaService{
+		relationships: []*models.SchemaRelationship{
+			{
+				ID:         relID,
+				Confidence: 0.9,
+				MatchRate:  &matchRate,
+				ValidationResults: &models.ValidationResults{
+					SourceRowCount: 500,
+					OrphanCount:    3,
+				},
+			},
+		},
+	}
+	handler := NewSchemaHandler(service, nil, zap.NewNop())
+
+	projectID := uuid.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/projects/"+projectID.String()+"/relationships/"+relID.String()+"/metrics", nil)
+	req.SetPathValue("pid", projectID.String())
+	req.SetPathValue("id", relID.String())
+
+	rec := httptest.NewRecorder()
+	handler.GetRelationshipMetrics(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+
+	var resp struct {
+		Success bool                       `json:"success"`
+		Data    models.RelationshipMetrics `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Data.RelationshipID != relID {
+		t.Errorf("expected relationship_id %s, got %s", relID, resp.Data.RelationshipID)
+	}
+	if resp.Data.MatchRate == nil || *resp.Data.MatchRate != matchRate {
+		t.Errorf("expected match_rate %v, got %v", matchRate, resp.Data.MatchRate)
+	}
+	if resp.Data.OrphanCount == nil || *resp.Data.OrphanCount != 3 {
+		t.Errorf("expected orphan_count 3, got %v", resp.Data.OrphanCount)
+	}
+	if resp.Data.SampleSize == nil || *resp.Data.SampleSize != 500 {
+		t.Errorf("expected sample_size 500, got %v", resp.Data.SampleSize)
+	}
+}
+
+func TestSchemaHandler_GetRelationshipMetrics_NotFound(t *testing.T) {
+	handler := NewSchemaHandler(&mockSchemaService{}, nil, zap.NewNop())
+
+	projectID := uuid.New()
+	relID := uuid.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/projects/"+projectID.String()+"/relationships/"+relID.String()+"/metrics", nil)
+	req.SetPathValue("pid", projectID.String())
+	req.SetPathValue("id", relID.String())
+
+	rec := httptest.NewRecorder()
+	handler.GetRelationshipMetrics(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", rec.Code)
+	}
+}
+
+func TestSchemaHandler_GetRelationshipMetrics_InvalidID(t *testing.T) {
+	handler := NewSchemaHandler(&mockSchemaService{}, nil, zap.NewNop())
+
+	projectID := uuid.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/projects/"+projectID.String()+"/relationships/not-a-uuid/metrics", nil)
+	req.SetPathValue("pid", projectID.String())
+	req.SetPathValue("id", "not-a-uuid")
+
+	rec := httptest.NewRecorder()
+	handler.GetRelationshipMetrics(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", rec.Code)
+	}
+}
+
 func TestSchemaHandler_ServiceError(t *testing.T) {
 	service := &mockSchemaService{err: errors.New("database error")}
 	handler := NewSchemaHandler(service, nil, zap.NewNop())
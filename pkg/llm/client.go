@@ -10,6 +10,8 @@ import (
 
 	"github.com/sashabaranov/go-openai"
 	"go.uber.org/zap"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/tracing"
 )
 
 // DefaultRequestTimeout is the maximum time to wait for an LLM response.
@@ -23,6 +25,8 @@ const requestIDHeader = "X-Request-Id"
 // contextAwareTransport wraps an http.RoundTripper to inject headers from context.
 // It reads the conversation ID from context and sets it as X-Request-Id header,
 // enabling end-to-end request tracing between client and model gateway.
+// It also records the Retry-After delay of rate-limited responses so retries can
+// wait as long as the provider asked.
 type contextAwareTransport struct {
 	base http.RoundTripper
 }
@@ -34,16 +38,20 @@ func (t *contextAwareTransport) RoundTrip(req *http.Request) (*http.Response, er
 		req = req.Clone(req.Context())
 		req.Header.Set(requestIDHeader, id.String())
 	}
-	return t.base.RoundTrip(req)
+	resp, err := t.base.RoundTrip(req)
+	recordRetryAfter(req, resp)
+	return resp, err
 }
 
 // Client provides access to OpenAI-compatible LLM endpoints.
 type Client struct {
-	client    *openai.Client
-	endpoint  string
-	model     string
-	projectID string
-	logger    *zap.Logger
+	client      *openai.Client
+	endpoint    string
+	model       string
+	projectID   string
+	rateLimiter *RateLimiter
+	retryPolicy *RetryPolicy
+	logger      *zap.Logger
 }
 
 // Config holds configuration for creating an LLM client.
@@ -52,6 +60,14 @@ type Config struct {
 	Model     string // Model name, e.g., "gpt-4o"
 	APIKey    string // Optional for local endpoints
 	ProjectID string // For logging context
+
+	// RateLimiter, if set, is waited on before each call. Share one limiter across
+	// clients to keep all of them under the account's limits.
+	RateLimiter *RateLimiter
+
+	// RetryPolicy controls retries of failed GenerateResponse calls. Nil uses
+	// DefaultRetryPolicy; set MaxRetries to 0 to disable retrying.
+	RetryPolicy *RetryPolicy
 }
 
 // NewClient creates a new OpenAI-compatible LLM client.
@@ -78,18 +94,30 @@ func NewClient(cfg *Config, logger *zap.Logger) (*Client, error) {
 		},
 	}
 
+	retryPolicy := cfg.RetryPolicy
+	if retryPolicy == nil {
+		retryPolicy = DefaultRetryPolicy()
+	}
+
 	return &Client{
-		client:    openai.NewClientWithConfig(clientConfig),
-		endpoint:  cfg.Endpoint,
-		model:     cfg.Model,
-		projectID: cfg.ProjectID,
-		logger:    logger.Named("llm"),
+		client:      openai.NewClientWithConfig(clientConfig),
+		endpoint:    cfg.Endpoint,
+		model:       cfg.Model,
+		projectID:   cfg.ProjectID,
+		rateLimiter: cfg.RateLimiter,
+		retryPolicy: retryPolicy,
+		logger:      logger.Named("llm"),
 	}, nil
 }
 
 // GenerateResponse generates a chat completion response with usage stats.
 // Set thinking=true to enable chain-of-thought reasoning, false to disable it.
 // Uses chat_template_kwargs for vLLM/Nemotron/Qwen models that support it.
+// Transient failures are retried with exponential backoff per the client's RetryPolicy;
+// the result records how many attempts were made.
+// A TokenBudget attached to ctx (see WithTokenBudget) is checked before the request and
+// charged with its usage; a call that would exceed it fails with ErrTokenBudgetExceeded.
+// Each call is traced as a child span of the caller's span, carrying token usage.
 func (c *Client) GenerateResponse(
 	ctx context.Context,
 	prompt string,
@@ -97,11 +125,43 @@ func (c *Client) GenerateResponse(
 	temperature float64,
 	thinking bool,
 ) (*GenerateResponseResult, error) {
-	messages := []openai.ChatCompletionMessage{
-		{Role: openai.ChatMessageRoleSystem, Content: systemMessage},
-		{Role: openai.ChatMessageRoleUser, Content: prompt},
+	ctx, span := tracing.Start(ctx, "llm.generate_response", tracing.AttrLLMModel.String(c.model))
+	result, err := c.generateResponse(ctx, prompt, systemMessage, temperature, thinking)
+	if result != nil {
+		span.SetAttributes(
+			tracing.AttrPromptTokens.Int(result.PromptTokens),
+			tracing.AttrCompletionTokens.Int(result.CompletionTokens),
+			tracing.AttrTotalTokens.Int(result.TotalTokens),
+		)
+	}
+	tracing.End(span, err)
+	return result, err
+}
+
+func (c *Client) generateResponse(
+	ctx context.Context,
+	prompt string,
+	systemMessage string,
+	temperature float64,
+	thinking bool,
+) (*GenerateResponseResult, error) {
+	// Don't start a request for a cancelled job; in-flight requests are aborted
+	// by the HTTP client through ctx.
+	if err := ctx.Err(); err != nil {
+		return nil, c.parseError(err)
 	}
 
+	estimatedTokens := EstimatePromptTokens(prompt, systemMessage)
+
+	budget := TokenBudgetFrom(ctx)
+	if err := budget.Reserve(estimatedTokens); err != nil {
+		c.logger.Warn("LLM request refused by token budget", zap.Error(err))
+		return nil, err
+	}
+	// Failed requests release the reservation without charging the budget
+	usedTokens := 0
+	defer func() { budget.Add(estimatedTokens, usedTokens) }()
+
 	c.logger.Debug("LLM request",
 		zap.String("model", c.model),
 		zap.Int("prompt_len", len(prompt)),
@@ -109,29 +169,42 @@ func (c *Client) GenerateResponse(
 		zap.Bool("thinking", thinking))
 
 	start := time.Now()
-
-	req := openai.ChatCompletionRequest{
-		Model:       c.model,
-		Messages:    messages,
-		Temperature: float32(temperature),
-	}
-
-	// chat_template_kwargs is a vLLM extension for controlling thinking/reasoning mode.
-	// Only send it to vLLM-compatible endpoints; commercial APIs (OpenAI, etc.) reject it.
-	if c.supportsChatTemplateKwargs() {
-		req.ChatTemplateKwargs = map[string]any{
-			"enable_thinking": thinking,
+	req := c.chatRequest(prompt, systemMessage, temperature, thinking)
+
+	var resp openai.ChatCompletionResponse
+	attempts := 0
+	for {
+		attempts++
+		var err error
+		resp, err = c.createChatCompletion(ctx, req, estimatedTokens)
+		if err == nil {
+			break
+		}
+		if attempts > c.retryPolicy.MaxRetries || !c.retryPolicy.shouldRetry(err) || ctx.Err() != nil {
+			c.logger.Error("LLM request failed",
+				zap.Int("attempts", attempts),
+				zap.Duration("elapsed", time.Since(start)),
+				zap.Error(err))
+			return nil, err
 		}
-	}
 
-	resp, err := c.client.CreateChatCompletion(ctx, req)
-	if err != nil {
-		c.logger.Error("LLM request failed",
-			zap.Duration("elapsed", time.Since(start)),
+		wait := c.retryPolicy.delay(attempts, err)
+		c.logger.Warn("LLM request failed, retrying",
+			zap.Int("attempt", attempts),
+			zap.Int("max_retries", c.retryPolicy.MaxRetries),
+			zap.Duration("retry_in", wait),
 			zap.Error(err))
-		return nil, c.parseError(err)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, c.parseError(ctx.Err())
+		}
 	}
 
+	usedTokens = resp.Usage.TotalTokens
 	if len(resp.Choices) == 0 {
 		return nil, fmt.Errorf("no choices in response")
 	}
@@ -149,9 +222,56 @@ func (c *Client) GenerateResponse(
 		PromptTokens:     resp.Usage.PromptTokens,
 		CompletionTokens: resp.Usage.CompletionTokens,
 		TotalTokens:      resp.Usage.TotalTokens,
+		Attempts:         attempts,
 	}, nil
 }
 
+// chatRequest builds the chat completion request for a single prompt.
+func (c *Client) chatRequest(prompt, systemMessage string, temperature float64, thinking bool) openai.ChatCompletionRequest {
+	req := openai.ChatCompletionRequest{
+		Model: c.model,
+		Messages: []openai.ChatCompletionMessage{
+			{Role: openai.ChatMessageRoleSystem, Content: systemMessage},
+			{Role: openai.ChatMessageRoleUser, Content: prompt},
+		},
+		Temperature: float32(temperature),
+	}
+
+	// chat_template_kwargs is a vLLM extension for controlling thinking/reasoning mode.
+	// Only send it to vLLM-compatible endpoints; commercial APIs (OpenAI, etc.) reject it.
+	if c.supportsChatTemplateKwargs() {
+		req.ChatTemplateKwargs = map[string]any{
+			"enable_thinking": thinking,
+		}
+	}
+	return req
+}
+
+// createChatCompletion makes a single chat completion request, waiting on the rate
+// limiter first and settling the attempt with it after: a failed attempt's estimate is
+// released and a successful one is charged its actual usage. Errors are classified by
+// parseError.
+func (c *Client) createChatCompletion(ctx context.Context, req openai.ChatCompletionRequest, estimatedTokens int) (openai.ChatCompletionResponse, error) {
+	waitStart := time.Now()
+	if err := c.rateLimiter.Wait(ctx, estimatedTokens); err != nil {
+		return openai.ChatCompletionResponse{}, c.parseError(err)
+	}
+	if waited := time.Since(waitStart); waited > time.Second {
+		c.logger.Debug("Waited for LLM rate limit",
+			zap.Duration("waited", waited),
+			zap.Int("estimated_tokens", estimatedTokens))
+	}
+
+	ctx, retryAfter := withRetryAfterCapture(ctx)
+	resp, err := c.client.CreateChatCompletion(ctx, req)
+	if err != nil {
+		c.rateLimiter.Release(estimatedTokens)
+		return resp, retryAfter.apply(c.parseError(err))
+	}
+	c.rateLimiter.Settle(estimatedTokens, resp.Usage.TotalTokens)
+	return resp, nil
+}
+
 // CreateEmbedding generates an embedding vector for the input text.
 func (c *Client) CreateEmbedding(ctx context.Context, input string, model string) ([]float32, error) {
 	if model == "" {
@@ -8,6 +8,11 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/zap"
 )
 
 func TestContextAwareTransport_InjectsRequestID(t *testing.T) {
@@ -106,3 +111,45 @@ func TestSupportsChatTemplateKwargs(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateResponse_EmitsSpanWithTokenUsage(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"id": "chatcmpl-1", "object": "chat.completion", "model": "test-model",
+			"choices": [{"index": 0, "message": {"role": "assistant", "content": "ok"}, "finish_reason": "stop"}],
+			"usage": {"prompt_tokens": 12, "completion_tokens": 3, "total_tokens": 15}
+		}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&Config{Endpoint: server.URL, Model: "test-model"}, zap.NewNop())
+	require.NoError(t, err)
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "column_enrichment.table")
+	_, err = client.GenerateResponse(ctx, "prompt", "system", 0.2, false)
+	parent.End()
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	llmSpan := spans[0]
+	assert.Equal(t, "llm.generate_response", llmSpan.Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), llmSpan.Parent().SpanID(), "LLM span should be a child of the caller's span")
+
+	attrs := make(map[string]any)
+	for _, kv := range llmSpan.Attributes() {
+		attrs[string(kv.Key)] = kv.Value.AsInterface()
+	}
+	assert.Equal(t, "test-model", attrs["llm.model"])
+	assert.Equal(t, int64(12), attrs["llm.prompt_tokens"])
+	assert.Equal(t, int64(3), attrs["llm.completion_tokens"])
+	assert.Equal(t, int64(15), attrs["llm.total_tokens"])
+	assert.Positive(t, llmSpan.EndTime().Sub(llmSpan.StartTime()))
+}
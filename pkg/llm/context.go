@@ -57,6 +57,20 @@ func WithTaskContext(ctx context.Context, workflowID uuid.UUID, taskID, taskName
 	return WithContext(ctx, values)
 }
 
+// PromptTypeKey is the recording context key naming the kind of prompt a call sends,
+// so recorded conversations can be filtered by it.
+const PromptTypeKey = "prompt_type"
+
+// Prompt types recorded under PromptTypeKey.
+const (
+	PromptTypeColumnEnrichment = "column_enrichment"
+)
+
+// WithPromptType records on the conversation context which kind of prompt the call sends.
+func WithPromptType(ctx context.Context, promptType string) context.Context {
+	return WithContext(ctx, map[string]any{PromptTypeKey: promptType})
+}
+
 // WithConversationID attaches a conversation ID to the context for HTTP request tracing.
 // The ID will be sent as X-Request-Id header to the model gateway.
 func WithConversationID(ctx context.Context, id uuid.UUID) context.Context {
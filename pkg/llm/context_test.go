@@ -34,6 +34,15 @@ func TestWithContext_MergesValues(t *testing.T) {
 	}
 }
 
+func TestWithPromptType_RecordsPromptType(t *testing.T) {
+	ctx := WithPromptType(context.Background(), PromptTypeColumnEnrichment)
+
+	c := GetContext(ctx)
+	if c[PromptTypeKey] != PromptTypeColumnEnrichment {
+		t.Errorf("expected prompt_type %q, got %v", PromptTypeColumnEnrichment, c[PromptTypeKey])
+	}
+}
+
 func TestWithTaskContext_AddsAllFields(t *testing.T) {
 	ctx := context.Background()
 	workflowID := uuid.New()
@@ -6,6 +6,7 @@ import (
 	"net/url"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/sashabaranov/go-openai"
 )
@@ -19,6 +20,8 @@ type Error struct {
 	StatusCode int       // HTTP status code if applicable
 	Model      string    // Model name if known
 	Endpoint   string    // Endpoint URL if known
+
+	RetryAfter time.Duration // Delay requested by the provider's Retry-After header; 0 if none
 }
 
 // Error implements the error interface.
@@ -60,6 +63,12 @@ func (e *Error) IsRetryable() bool {
 	return e.Retryable
 }
 
+// RetryDelay implements the retry.RetryAfterError interface, so retries wait as
+// long as a throttled provider asked instead of using exponential backoff.
+func (e *Error) RetryDelay() time.Duration {
+	return e.RetryAfter
+}
+
 // NewError creates a new structured LLM error.
 func NewError(errType ErrorType, message string, retryable bool, cause error) *Error {
 	return &Error{
@@ -213,7 +222,8 @@ func ClassifyError(err error) *Error {
 	}
 
 	// Connection errors (may be retryable)
-	if strings.Contains(lower, "connection refused") || strings.Contains(lower, "no such host") {
+	if strings.Contains(lower, "connection refused") || strings.Contains(lower, "no such host") ||
+		strings.Contains(lower, "connection reset") || strings.Contains(lower, "broken pipe") {
 		llmErr := NewError(ErrorTypeEndpoint, "connection failed", true, err)
 		llmErr.StatusCode = statusCode
 		return llmErr
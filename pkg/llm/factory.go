@@ -29,6 +29,7 @@ type LLMClientFactory interface {
 type ClientFactory struct {
 	aiConfigProvider AIConfigProvider
 	recorder         ConversationRecorder // Optional: if set, wraps clients to record conversations
+	rateLimiter      *RateLimiter         // Optional: shared by every client the factory creates
 	logger           *zap.Logger
 }
 
@@ -38,6 +39,12 @@ func (f *ClientFactory) SetRecorder(recorder ConversationRecorder) {
 	f.recorder = recorder
 }
 
+// SetRateLimiter makes every client created by this factory wait on limiter before
+// each call. Pass nil to disable rate limiting.
+func (f *ClientFactory) SetRateLimiter(limiter *RateLimiter) {
+	f.rateLimiter = limiter
+}
+
 // NewClientFactory creates a new factory.
 func NewClientFactory(
 	aiConfigProvider AIConfigProvider,
@@ -60,10 +67,11 @@ func (f *ClientFactory) CreateForProject(ctx context.Context, projectID uuid.UUI
 	}
 
 	client, err := NewClient(&Config{
-		Endpoint:  effectiveConfig.LLMBaseURL,
-		Model:     effectiveConfig.LLMModel,
-		APIKey:    effectiveConfig.LLMAPIKey,
-		ProjectID: projectID.String(),
+		Endpoint:    effectiveConfig.LLMBaseURL,
+		Model:       effectiveConfig.LLMModel,
+		APIKey:      effectiveConfig.LLMAPIKey,
+		ProjectID:   projectID.String(),
+		RateLimiter: f.rateLimiter,
 	}, f.logger)
 	if err != nil {
 		return nil, fmt.Errorf("create client: %w", err)
@@ -108,10 +116,11 @@ func (f *ClientFactory) CreateStreamingClient(ctx context.Context, projectID uui
 	}
 
 	client, err := NewStreamingClient(&Config{
-		Endpoint:  effectiveConfig.LLMBaseURL,
-		Model:     effectiveConfig.LLMModel,
-		APIKey:    effectiveConfig.LLMAPIKey,
-		ProjectID: projectID.String(),
+		Endpoint:    effectiveConfig.LLMBaseURL,
+		Model:       effectiveConfig.LLMModel,
+		APIKey:      effectiveConfig.LLMAPIKey,
+		ProjectID:   projectID.String(),
+		RateLimiter: f.rateLimiter,
 	}, f.logger)
 	if err != nil {
 		return nil, fmt.Errorf("create streaming client: %w", err)
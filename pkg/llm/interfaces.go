@@ -14,6 +14,7 @@ type GenerateResponseResult struct {
 	CompletionTokens int
 	TotalTokens      int
 	ConversationID   uuid.UUID // For correlating with debug logs and database records
+	Attempts         int       // Requests made, including retries of transient failures
 }
 
 // LLMClient defines the interface for LLM operations.
@@ -24,6 +25,10 @@ type LLMClient interface {
 	// Set thinking=true to enable chain-of-thought reasoning, false to disable it.
 	GenerateResponse(ctx context.Context, prompt string, systemMessage string, temperature float64, thinking bool) (*GenerateResponseResult, error)
 
+	// GenerateResponseStream generates a chat completion with thinking disabled and
+	// delivers its content as it arrives. Result returns the aggregated response.
+	GenerateResponseStream(ctx context.Context, prompt string, systemMessage string, temperature float64) (*ResponseStream, error)
+
 	// CreateEmbedding generates an embedding vector for the input text.
 	CreateEmbedding(ctx context.Context, input string, model string) ([]float32, error)
 
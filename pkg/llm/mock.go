@@ -15,6 +15,10 @@ type MockLLMClient struct {
 	// If nil, returns empty result and nil error.
 	GenerateResponseFunc func(ctx context.Context, prompt string, systemMessage string, temperature float64, thinking bool) (*GenerateResponseResult, error)
 
+	// GenerateResponseStreamFunc is called when GenerateResponseStream is invoked.
+	// If nil, the result of GenerateResponse is delivered as a single delta.
+	GenerateResponseStreamFunc func(ctx context.Context, prompt string, systemMessage string, temperature float64) (*ResponseStream, error)
+
 	// CreateEmbeddingFunc is called when CreateEmbedding is invoked.
 	// If nil, returns nil slice and nil error.
 	CreateEmbeddingFunc func(ctx context.Context, input string, model string) ([]float32, error)
@@ -30,9 +34,10 @@ type MockLLMClient struct {
 	Endpoint string
 
 	// Call tracking for verification (use atomic operations for thread safety)
-	GenerateResponseCalls atomic.Int64
-	CreateEmbeddingCalls  atomic.Int64
-	CreateEmbeddingsCalls atomic.Int64
+	GenerateResponseCalls       atomic.Int64
+	GenerateResponseStreamCalls atomic.Int64
+	CreateEmbeddingCalls        atomic.Int64
+	CreateEmbeddingsCalls       atomic.Int64
 }
 
 // NewMockLLMClient creates a new mock with sensible defaults.
@@ -52,6 +57,15 @@ func (m *MockLLMClient) GenerateResponse(ctx context.Context, prompt string, sys
 	return &GenerateResponseResult{}, nil
 }
 
+// GenerateResponseStream implements LLMClient.
+func (m *MockLLMClient) GenerateResponseStream(ctx context.Context, prompt string, systemMessage string, temperature float64) (*ResponseStream, error) {
+	m.GenerateResponseStreamCalls.Add(1)
+	if m.GenerateResponseStreamFunc != nil {
+		return m.GenerateResponseStreamFunc(ctx, prompt, systemMessage, temperature)
+	}
+	return CompletedResponseStream(m.GenerateResponse(ctx, prompt, systemMessage, temperature, false))
+}
+
 // CreateEmbedding implements LLMClient.
 func (m *MockLLMClient) CreateEmbedding(ctx context.Context, input string, model string) ([]float32, error) {
 	m.CreateEmbeddingCalls.Add(1)
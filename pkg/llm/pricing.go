@@ -0,0 +1,54 @@
+package llm
+
+import "strings"
+
+// ModelPricing is the list price of a model in USD per million tokens.
+type ModelPricing struct {
+	InputPerMillion  float64
+	OutputPerMillion float64
+}
+
+// modelPricing maps model name prefixes to list prices. Dated and suffixed variants
+// (claude-sonnet-4-20250514, gpt-4o-2024-08-06) resolve through the longest matching
+// prefix, so only model families need an entry.
+var modelPricing = map[string]ModelPricing{
+	"claude-opus-4":     {InputPerMillion: 15, OutputPerMillion: 75},
+	"claude-sonnet-4":   {InputPerMillion: 3, OutputPerMillion: 15},
+	"claude-haiku-4":    {InputPerMillion: 1, OutputPerMillion: 5},
+	"claude-3-7-sonnet": {InputPerMillion: 3, OutputPerMillion: 15},
+	"claude-3-5-sonnet": {InputPerMillion: 3, OutputPerMillion: 15},
+	"claude-3-5-haiku":  {InputPerMillion: 0.8, OutputPerMillion: 4},
+	"gpt-4o":            {InputPerMillion: 2.5, OutputPerMillion: 10},
+	"gpt-4o-mini":       {InputPerMillion: 0.15, OutputPerMillion: 0.6},
+	"gpt-4.1":           {InputPerMillion: 2, OutputPerMillion: 8},
+	"gpt-4.1-mini":      {InputPerMillion: 0.4, OutputPerMillion: 1.6},
+	"gpt-4.1-nano":      {InputPerMillion: 0.1, OutputPerMillion: 0.4},
+	"o3-mini":           {InputPerMillion: 1.1, OutputPerMillion: 4.4},
+	"o4-mini":           {InputPerMillion: 1.1, OutputPerMillion: 4.4},
+}
+
+// LookupPricing returns the list price for model. A provider prefix such as
+// "anthropic/" is ignored. Returns false for models not in the table, including
+// self-hosted models, which have no per-token price.
+func LookupPricing(model string) (ModelPricing, bool) {
+	name := strings.ToLower(strings.TrimSpace(model))
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+
+	var best string
+	for prefix := range modelPricing {
+		if strings.HasPrefix(name, prefix) && len(prefix) > len(best) {
+			best = prefix
+		}
+	}
+	if best == "" {
+		return ModelPricing{}, false
+	}
+	return modelPricing[best], true
+}
+
+// Cost returns the USD cost of the given token counts.
+func (p ModelPricing) Cost(inputTokens, outputTokens int) float64 {
+	return (float64(inputTokens)*p.InputPerMillion + float64(outputTokens)*p.OutputPerMillion) / 1_000_000
+}
@@ -0,0 +1,37 @@
+package llm
+
+import "testing"
+
+func TestLookupPricing(t *testing.T) {
+	tests := []struct {
+		model     string
+		wantFound bool
+		wantInput float64
+	}{
+		{model: "claude-sonnet-4-20250514", wantFound: true, wantInput: 3},
+		{model: "anthropic/claude-sonnet-4", wantFound: true, wantInput: 3},
+		{model: "gpt-4o-mini-2024-07-18", wantFound: true, wantInput: 0.15},
+		{model: "GPT-4o", wantFound: true, wantInput: 2.5},
+		{model: "llama3.1:70b", wantFound: false},
+		{model: "", wantFound: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.model, func(t *testing.T) {
+			pricing, found := LookupPricing(tt.model)
+			if found != tt.wantFound {
+				t.Fatalf("expected found=%v, got %v", tt.wantFound, found)
+			}
+			if found && pricing.InputPerMillion != tt.wantInput {
+				t.Errorf("expected input price %v, got %v", tt.wantInput, pricing.InputPerMillion)
+			}
+		})
+	}
+}
+
+func TestModelPricing_Cost(t *testing.T) {
+	pricing := ModelPricing{InputPerMillion: 3, OutputPerMillion: 15}
+	if got := pricing.Cost(1_000_000, 100_000); got != 4.5 {
+		t.Errorf("expected cost 4.5, got %v", got)
+	}
+}
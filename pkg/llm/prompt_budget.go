@@ -0,0 +1,35 @@
+package llm
+
+import (
+	"context"
+)
+
+// charsPerToken approximates tokenizer output for English prose mixed with
+// identifiers and markdown tables. It errs on the side of overestimating.
+const charsPerToken = 3.5
+
+// EstimateTokens returns a rough token count for text without calling a tokenizer.
+// Use it for budget decisions, not billing.
+func EstimateTokens(text string) int {
+	if text == "" {
+		return 0
+	}
+	return int(float64(len(text))/charsPerToken) + 1
+}
+
+// EstimatePromptTokens estimates the tokens sent for a system message plus prompt.
+func EstimatePromptTokens(prompt, systemMessage string) int {
+	return EstimateTokens(prompt) + EstimateTokens(systemMessage)
+}
+
+// WithPromptTrim records on the conversation context that a prompt was reduced to fit
+// the token budget. The values are persisted with the conversation record so
+// assessments can tell trimmed prompts apart from complete ones.
+func WithPromptTrim(ctx context.Context, strategy string, estimatedTokens, budget int) context.Context {
+	return WithContext(ctx, map[string]any{
+		"prompt_trimmed":          true,
+		"prompt_trim_strategy":    strategy,
+		"untrimmed_prompt_tokens": estimatedTokens,
+		"prompt_token_budget":     budget,
+	})
+}
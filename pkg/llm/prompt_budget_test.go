@@ -0,0 +1,43 @@
+package llm
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestEstimateTokens(t *testing.T) {
+	if got := EstimateTokens(""); got != 0 {
+		t.Errorf("expected 0 tokens for empty text, got %d", got)
+	}
+
+	short := EstimateTokens("SELECT id FROM users")
+	long := EstimateTokens(strings.Repeat("SELECT id FROM users ", 100))
+	if short < 1 || long <= short*50 {
+		t.Errorf("expected estimate to scale with length, got short=%d long=%d", short, long)
+	}
+
+	// The estimate should not undercount typical English text (~4 chars/token)
+	text := strings.Repeat("a", 4000)
+	if got := EstimateTokens(text); got < 1000 {
+		t.Errorf("expected at least 1000 tokens for 4000 chars, got %d", got)
+	}
+
+	if got := EstimatePromptTokens("abc", "def"); got != EstimateTokens("abc")+EstimateTokens("def") {
+		t.Errorf("expected prompt estimate to include system message, got %d", got)
+	}
+}
+
+func TestWithPromptTrim_RecordsOnConversationContext(t *testing.T) {
+	ctx := WithContext(context.Background(), map[string]any{"table": "orders"})
+	ctx = WithPromptTrim(ctx, "sample_values", 150000, 100000)
+
+	got := GetContext(ctx)
+	if got["table"] != "orders" {
+		t.Errorf("expected existing context to be kept, got %v", got)
+	}
+	if got["prompt_trimmed"] != true || got["prompt_trim_strategy"] != "sample_values" ||
+		got["untrimmed_prompt_tokens"] != 150000 || got["prompt_token_budget"] != 100000 {
+		t.Errorf("unexpected trim context: %v", got)
+	}
+}
@@ -0,0 +1,178 @@
+package llm
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// RateLimitConfig holds the provider account's per-minute limits. A zero limit is
+// not enforced.
+type RateLimitConfig struct {
+	RequestsPerMinute int
+	TokensPerMinute   int
+}
+
+// Enabled reports whether any limit is set.
+func (c RateLimitConfig) Enabled() bool {
+	return c.RequestsPerMinute > 0 || c.TokensPerMinute > 0
+}
+
+// rateLimitClock is the time source of a RateLimiter, replaced in tests.
+type rateLimitClock interface {
+	Now() time.Time
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) Sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// tokenBucket holds up to capacity units and refills at capacity per minute.
+type tokenBucket struct {
+	capacity  float64
+	available float64
+	perSecond float64
+}
+
+func newTokenBucket(perMinute int) *tokenBucket {
+	if perMinute <= 0 {
+		return nil
+	}
+	return &tokenBucket{
+		capacity:  float64(perMinute),
+		available: float64(perMinute),
+		perSecond: float64(perMinute) / 60,
+	}
+}
+
+func (b *tokenBucket) refill(elapsed time.Duration) {
+	b.available = math.Min(b.capacity, b.available+elapsed.Seconds()*b.perSecond)
+}
+
+// wait returns how long until n units are available. A request larger than the
+// bucket waits for a full bucket rather than forever.
+func (b *tokenBucket) wait(n float64) time.Duration {
+	n = math.Min(n, b.capacity)
+	if b.available >= n {
+		return 0
+	}
+	return time.Duration((n - b.available) / b.perSecond * float64(time.Second))
+}
+
+// RateLimiter keeps LLM calls under the account's requests-per-minute and
+// tokens-per-minute limits. One limiter is shared by every client the factory
+// creates, so concurrent workers draw from the same budget instead of each
+// assuming the whole account is theirs and tripping cascading 429s.
+type RateLimiter struct {
+	mu       sync.Mutex
+	clock    rateLimitClock
+	last     time.Time
+	requests *tokenBucket
+	tokens   *tokenBucket
+}
+
+// NewRateLimiter creates a limiter for the given limits. Returns nil when no limit
+// is set; a nil limiter never waits.
+func NewRateLimiter(config RateLimitConfig) *RateLimiter {
+	return newRateLimiter(config, realClock{})
+}
+
+func newRateLimiter(config RateLimitConfig, clock rateLimitClock) *RateLimiter {
+	if !config.Enabled() {
+		return nil
+	}
+	return &RateLimiter{
+		clock:    clock,
+		last:     clock.Now(),
+		requests: newTokenBucket(config.RequestsPerMinute),
+		tokens:   newTokenBucket(config.TokensPerMinute),
+	}
+}
+
+// Wait blocks until one request and estimatedTokens tokens fit in the budget, then
+// takes them. Returns the context's error if it is cancelled while waiting.
+func (l *RateLimiter) Wait(ctx context.Context, estimatedTokens int) error {
+	if l == nil {
+		return nil
+	}
+	for {
+		l.mu.Lock()
+		l.refill()
+		var delay time.Duration
+		if l.requests != nil {
+			delay = max(delay, l.requests.wait(1))
+		}
+		if l.tokens != nil {
+			delay = max(delay, l.tokens.wait(float64(estimatedTokens)))
+		}
+		if delay == 0 {
+			if l.requests != nil {
+				l.requests.available--
+			}
+			if l.tokens != nil {
+				l.tokens.available -= math.Min(float64(estimatedTokens), l.tokens.capacity)
+			}
+			l.mu.Unlock()
+			return nil
+		}
+		l.mu.Unlock()
+
+		if err := l.clock.Sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// Settle charges the difference between a call's actual token usage and the
+// estimate taken by Wait, so completions count against the budget too. The bucket
+// may go negative, which delays the next calls until it has refilled.
+func (l *RateLimiter) Settle(estimatedTokens, actualTokens int) {
+	if l == nil || l.tokens == nil || actualTokens <= 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill()
+	l.tokens.available -= float64(actualTokens) - math.Min(float64(estimatedTokens), l.tokens.capacity)
+}
+
+// Release returns the estimate Wait took for a call that failed without using any
+// tokens, so a retried call is not charged for every failed attempt. The request
+// itself still counts against the requests-per-minute limit.
+func (l *RateLimiter) Release(estimatedTokens int) {
+	if l == nil || l.tokens == nil || estimatedTokens <= 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill()
+	l.tokens.available = math.Min(l.tokens.available+math.Min(float64(estimatedTokens), l.tokens.capacity), l.tokens.capacity)
+}
+
+func (l *RateLimiter) refill() {
+	now := l.clock.Now()
+	elapsed := now.Sub(l.last)
+	l.last = now
+	if elapsed <= 0 {
+		return
+	}
+	if l.requests != nil {
+		l.requests.refill(elapsed)
+	}
+	if l.tokens != nil {
+		l.tokens.refill(elapsed)
+	}
+}
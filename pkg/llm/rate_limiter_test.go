@@ -0,0 +1,132 @@
+package llm
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// fakeClock advances its time by each Sleep instead of blocking.
+type fakeClock struct {
+	now   time.Time
+	slept []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Sleep(ctx context.Context, d time.Duration) error {
+	c.slept = append(c.slept, d)
+	c.now = c.now.Add(d)
+	return ctx.Err()
+}
+
+func TestRateLimiter_ThrottlesWhenTokenBudgetExhausted(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	limiter := newRateLimiter(RateLimitConfig{TokensPerMinute: 6000}, clock)
+	ctx := context.Background()
+
+	// The first two calls fit in the full minute's budget.
+	if err := limiter.Wait(ctx, 4000); err != nil {
+		t.Fatalf("first wait: %v", err)
+	}
+	if err := limiter.Wait(ctx, 2000); err != nil {
+		t.Fatalf("second wait: %v", err)
+	}
+	if len(clock.slept) != 0 {
+		t.Fatalf("expected no throttling within budget, slept %v", clock.slept)
+	}
+
+	// The budget is spent; 1000 tokens refill at 100/s.
+	if err := limiter.Wait(ctx, 1000); err != nil {
+		t.Fatalf("third wait: %v", err)
+	}
+	if got := totalSleep(clock.slept); got != 10*time.Second {
+		t.Errorf("expected to wait 10s for 1000 tokens, waited %v", got)
+	}
+}
+
+func TestRateLimiter_SettleChargesActualUsage(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	limiter := newRateLimiter(RateLimitConfig{TokensPerMinute: 6000}, clock)
+	ctx := context.Background()
+
+	if err := limiter.Wait(ctx, 1000); err != nil {
+		t.Fatalf("wait: %v", err)
+	}
+	// The completion made the call cost 6000 tokens: the whole minute's budget.
+	limiter.Settle(1000, 6000)
+
+	if err := limiter.Wait(ctx, 600); err != nil {
+		t.Fatalf("wait: %v", err)
+	}
+	if got := totalSleep(clock.slept); got != 6*time.Second {
+		t.Errorf("expected to wait 6s after settling, waited %v", got)
+	}
+}
+
+func TestRateLimiter_ReleaseReturnsFailedAttemptEstimate(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	limiter := newRateLimiter(RateLimitConfig{TokensPerMinute: 6000}, clock)
+	ctx := context.Background()
+
+	// Three failed attempts of a 2000-token call, each released, then the successful one
+	for i := 0; i < 4; i++ {
+		if err := limiter.Wait(ctx, 2000); err != nil {
+			t.Fatalf("wait %d: %v", i, err)
+		}
+		if i < 3 {
+			limiter.Release(2000)
+		}
+	}
+	if got := totalSleep(clock.slept); got != 0 {
+		t.Errorf("expected released attempts not to throttle the retry, waited %v", got)
+	}
+}
+
+func TestRateLimiter_RequestsPerMinute(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	limiter := newRateLimiter(RateLimitConfig{RequestsPerMinute: 2}, clock)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if err := limiter.Wait(ctx, 0); err != nil {
+			t.Fatalf("wait %d: %v", i, err)
+		}
+	}
+	if got := totalSleep(clock.slept); got != 30*time.Second {
+		t.Errorf("expected the third request to wait 30s, waited %v", got)
+	}
+}
+
+func TestRateLimiter_CancelledWhileWaiting(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	limiter := newRateLimiter(RateLimitConfig{TokensPerMinute: 600}, clock)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := limiter.Wait(context.Background(), 600); err != nil {
+		t.Fatalf("wait: %v", err)
+	}
+	if err := limiter.Wait(ctx, 600); err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestNewRateLimiter_DisabledWithoutLimits(t *testing.T) {
+	limiter := NewRateLimiter(RateLimitConfig{})
+	if limiter != nil {
+		t.Fatal("expected nil limiter when no limit is set")
+	}
+	if err := limiter.Wait(context.Background(), 1_000_000); err != nil {
+		t.Errorf("nil limiter should never wait, got %v", err)
+	}
+	limiter.Settle(10, 100)
+}
+
+func totalSleep(slept []time.Duration) time.Duration {
+	var total time.Duration
+	for _, d := range slept {
+		total += d
+	}
+	return total
+}
@@ -34,6 +34,87 @@ func (c *RecordingClient) GenerateResponse(
 	temperature float64,
 	thinking bool,
 ) (*GenerateResponseResult, error) {
+	// A cancelled job must not leave pending conversation records behind
+	if err := ctx.Err(); err != nil {
+		return nil, ClassifyError(err)
+	}
+
+	call := c.startConversation(ctx, prompt, systemMessage, temperature)
+
+	// Add conversation ID to context for HTTP request tracing
+	// This enables correlation between client logs and model gateway logs
+	ctx = WithConversationID(ctx, call.conv.ID)
+
+	// Call the inner client
+	result, err := c.inner.GenerateResponse(ctx, prompt, systemMessage, temperature, thinking)
+	if err != nil {
+		// Return partial result with conversation ID for debugging
+		result = &GenerateResponseResult{}
+	}
+	c.finishConversation(call, result, err)
+
+	return result, err
+}
+
+// GenerateResponseStream calls the inner client's stream and records the conversation.
+// The pending record is inserted before the request and updated once with the
+// aggregated response when the stream ends, however it ends.
+func (c *RecordingClient) GenerateResponseStream(
+	ctx context.Context,
+	prompt string,
+	systemMessage string,
+	temperature float64,
+) (*ResponseStream, error) {
+	// A cancelled job must not leave pending conversation records behind
+	if err := ctx.Err(); err != nil {
+		return nil, ClassifyError(err)
+	}
+
+	call := c.startConversation(ctx, prompt, systemMessage, temperature)
+	ctx = WithConversationID(ctx, call.conv.ID)
+
+	inner, err := c.inner.GenerateResponseStream(ctx, prompt, systemMessage, temperature)
+	if err != nil {
+		c.finishConversation(call, &GenerateResponseResult{}, err)
+		return nil, err
+	}
+
+	deltas := make(chan string, streamDeltaBuffer)
+	rs := &ResponseStream{Deltas: deltas, done: make(chan struct{})}
+
+	go func() {
+		defer close(rs.done)
+
+		// Forward deltas until the inner stream ends or the reader's context is cancelled;
+		// Result then drains whatever the inner stream still holds
+	forward:
+		for delta := range inner.Deltas {
+			select {
+			case deltas <- delta:
+			case <-ctx.Done():
+				break forward
+			}
+		}
+		close(deltas)
+
+		result, err := inner.Result()
+		c.finishConversation(call, result, err)
+		rs.result, rs.err = result, err
+	}()
+
+	return rs, nil
+}
+
+// recordedCall is a conversation whose pending record has been started.
+type recordedCall struct {
+	conv         *models.LLMConversation
+	debugPrefix  string
+	pendingSaved bool
+	start        time.Time
+}
+
+// startConversation builds the conversation record for a request and inserts it as pending.
+func (c *RecordingClient) startConversation(ctx context.Context, prompt, systemMessage string, temperature float64) *recordedCall {
 	// Build request messages for recording (verbatim)
 	requestMessages := []any{
 		map[string]string{"role": "system", "content": systemMessage},
@@ -60,50 +141,43 @@ func (c *RecordingClient) GenerateResponse(
 	// If this fails, we still proceed with the LLM call - recording is best-effort
 	pendingSaved := c.recorder.SavePending(ctx, conv) == nil
 
-	start := time.Now()
-
-	// Add conversation ID to context for HTTP request tracing
-	// This enables correlation between client logs and model gateway logs
-	ctx = WithConversationID(ctx, conv.ID)
-
-	// Call the inner client
-	result, err := c.inner.GenerateResponse(ctx, prompt, systemMessage, temperature, thinking)
-
-	duration := time.Since(start)
+	return &recordedCall{conv: conv, debugPrefix: debugPrefix, pendingSaved: pendingSaved, start: time.Now()}
+}
 
-	// Update the conversation record with response data
-	conv.DurationMs = int(duration.Milliseconds())
+// finishConversation updates the call's record with the outcome of the request and
+// records it, stamping result (if any) with the conversation ID.
+func (c *RecordingClient) finishConversation(call *recordedCall, result *GenerateResponseResult, err error) {
+	conv := call.conv
+	conv.DurationMs = int(time.Since(call.start).Milliseconds())
 
 	if err != nil {
 		conv.Status = models.LLMConversationStatusError
 		conv.ErrorMessage = err.Error()
 		// Write error file (debug builds only)
-		debugWriteError(debugPrefix, conv.ID.String(), conv.Model, err.Error(), int64(conv.DurationMs))
-		// Return partial result with conversation ID for debugging
-		result = &GenerateResponseResult{ConversationID: conv.ID}
+		debugWriteError(call.debugPrefix, conv.ID.String(), conv.Model, err.Error(), int64(conv.DurationMs))
 	} else {
 		conv.Status = models.LLMConversationStatusSuccess
 		if result != nil {
-			result.ConversationID = conv.ID
 			conv.ResponseContent = result.Content
 			conv.PromptTokens = &result.PromptTokens
 			conv.CompletionTokens = &result.CompletionTokens
 			conv.TotalTokens = &result.TotalTokens
 			// Write response file (debug builds only)
-			debugWriteResponse(debugPrefix, conv.ID.String(), conv.Model, result.Content, int64(conv.DurationMs))
+			debugWriteResponse(call.debugPrefix, conv.ID.String(), conv.Model, result.Content, int64(conv.DurationMs))
 		}
 	}
+	if result != nil {
+		result.ConversationID = conv.ID
+	}
 
 	// Record completion asynchronously
-	if pendingSaved {
+	if call.pendingSaved {
 		// Update the existing pending record
 		c.recorder.RecordCompletion(conv)
 	} else {
 		// Fallback: insert as a new record (legacy behavior)
 		c.recorder.Record(conv)
 	}
-
-	return result, err
 }
 
 // CreateEmbedding delegates to the inner client (not recorded).
@@ -168,6 +168,33 @@ func TestRecordingClient_GenerateResponse_RecordsError(t *testing.T) {
 	}
 }
 
+func TestRecordingClient_GenerateResponse_CancelledContextSkipsCall(t *testing.T) {
+	called := false
+	mockClient := NewMockLLMClient()
+	mockClient.GenerateResponseFunc = func(ctx context.Context, prompt, systemMessage string, temperature float64, thinking bool) (*GenerateResponseResult, error) {
+		called = true
+		return &GenerateResponseResult{Content: "hello"}, nil
+	}
+
+	recorder := &mockRecorder{}
+	client := NewRecordingClient(mockClient, recorder, uuid.New())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := client.GenerateResponse(ctx, "Say hello", "You are helpful", 0.7, false)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if called {
+		t.Error("expected no LLM call for a cancelled context")
+	}
+	if len(recorder.pending) != 0 || len(recorder.completions) != 0 {
+		t.Errorf("expected no conversation records, got %d pending and %d completions",
+			len(recorder.pending), len(recorder.completions))
+	}
+}
+
 func TestRecordingClient_GenerateResponse_CapturesContext(t *testing.T) {
 	mockClient := NewMockLLMClient()
 	mockClient.GenerateResponseFunc = func(ctx context.Context, prompt, systemMessage string, temperature float64, thinking bool) (*GenerateResponseResult, error) {
@@ -243,6 +270,123 @@ func TestRecordingClient_GenerateResponse_CapturesTemperature(t *testing.T) {
 	}
 }
 
+// testResponseStream returns a stream that delivers deltas and then ends with result and err.
+func testResponseStream(deltas []string, result *GenerateResponseResult, err error) *ResponseStream {
+	ch := make(chan string, len(deltas))
+	for _, d := range deltas {
+		ch <- d
+	}
+	close(ch)
+	done := make(chan struct{})
+	close(done)
+	return &ResponseStream{Deltas: ch, done: done, result: result, err: err}
+}
+
+func TestRecordingClient_GenerateResponseStream_RecordsAggregateOnce(t *testing.T) {
+	mockClient := NewMockLLMClient()
+	mockClient.GenerateResponseStreamFunc = func(ctx context.Context, prompt, systemMessage string, temperature float64) (*ResponseStream, error) {
+		return testResponseStream([]string{"Hello, ", "world!"}, &GenerateResponseResult{
+			Content:          "Hello, world!",
+			PromptTokens:     10,
+			CompletionTokens: 5,
+			TotalTokens:      15,
+		}, nil), nil
+	}
+
+	recorder := &mockRecorder{}
+	client := NewRecordingClient(mockClient, recorder, uuid.New())
+
+	stream, err := client.GenerateResponseStream(context.Background(), "Say hello", "You are helpful", 0.7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got string
+	for delta := range stream.Deltas {
+		got += delta
+	}
+	if got != "Hello, world!" {
+		t.Errorf("expected deltas to be forwarded, got %q", got)
+	}
+
+	result, err := stream.Result()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(recorder.pending) != 1 {
+		t.Fatalf("expected 1 pending record, got %d", len(recorder.pending))
+	}
+	if len(recorder.completions) != 1 {
+		t.Fatalf("expected 1 completion record, got %d", len(recorder.completions))
+	}
+	completed := recorder.completions[0]
+	if completed.Status != models.LLMConversationStatusSuccess {
+		t.Errorf("expected status 'success', got '%s'", completed.Status)
+	}
+	if completed.ResponseContent != "Hello, world!" {
+		t.Errorf("expected aggregated content recorded, got %q", completed.ResponseContent)
+	}
+	if completed.TotalTokens == nil || *completed.TotalTokens != 15 {
+		t.Errorf("expected total tokens 15, got %v", completed.TotalTokens)
+	}
+	if result.ConversationID != completed.ID {
+		t.Errorf("expected result conversation ID %s, got %s", completed.ID, result.ConversationID)
+	}
+}
+
+func TestRecordingClient_GenerateResponseStream_RecordsStreamError(t *testing.T) {
+	streamErr := errors.New("connection reset")
+	mockClient := NewMockLLMClient()
+	mockClient.GenerateResponseStreamFunc = func(ctx context.Context, prompt, systemMessage string, temperature float64) (*ResponseStream, error) {
+		return testResponseStream([]string{"partial"}, &GenerateResponseResult{Content: "partial"}, streamErr), nil
+	}
+
+	recorder := &mockRecorder{}
+	client := NewRecordingClient(mockClient, recorder, uuid.New())
+
+	stream, err := client.GenerateResponseStream(context.Background(), "prompt", "system", 0.2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result, err := stream.Result()
+	if !errors.Is(err, streamErr) {
+		t.Fatalf("expected stream error, got %v", err)
+	}
+	if result.Content != "partial" {
+		t.Errorf("expected partial content returned, got %q", result.Content)
+	}
+
+	if len(recorder.completions) != 1 {
+		t.Fatalf("expected 1 completion record, got %d", len(recorder.completions))
+	}
+	completed := recorder.completions[0]
+	if completed.Status != models.LLMConversationStatusError {
+		t.Errorf("expected status 'error', got '%s'", completed.Status)
+	}
+	if completed.ErrorMessage != "connection reset" {
+		t.Errorf("expected error message recorded, got %q", completed.ErrorMessage)
+	}
+}
+
+func TestRecordingClient_GenerateResponseStream_RecordsStartError(t *testing.T) {
+	mockClient := NewMockLLMClient()
+	mockClient.GenerateResponseStreamFunc = func(ctx context.Context, prompt, systemMessage string, temperature float64) (*ResponseStream, error) {
+		return nil, errors.New("rate limited")
+	}
+
+	recorder := &mockRecorder{}
+	client := NewRecordingClient(mockClient, recorder, uuid.New())
+
+	if _, err := client.GenerateResponseStream(context.Background(), "prompt", "system", 0.2); err == nil {
+		t.Fatal("expected error")
+	}
+	if len(recorder.completions) != 1 || recorder.completions[0].Status != models.LLMConversationStatusError {
+		t.Errorf("expected one error completion, got %+v", recorder.completions)
+	}
+}
+
 func TestRecordingClient_CreateEmbedding_DelegatesToInner(t *testing.T) {
 	mockClient := NewMockLLMClient()
 	expectedEmbedding := []float32{0.1, 0.2, 0.3}
@@ -0,0 +1,214 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"go.uber.org/zap"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/tracing"
+)
+
+// streamDeltaBuffer is how many content deltas may be queued for a slow reader.
+const streamDeltaBuffer = 64
+
+// ResponseStream is a chat completion delivered as it is generated.
+// Read Deltas until it is closed, then call Result for the aggregated response.
+type ResponseStream struct {
+	// Deltas carries content fragments in order and is closed when the stream ends,
+	// fails or its context is cancelled.
+	Deltas <-chan string
+
+	done   chan struct{}
+	result *GenerateResponseResult
+	err    error
+}
+
+// Result waits for the stream to end and returns the accumulated response; ExtractJSON
+// works on its Content as it does for GenerateResponse. Deltas not yet read are
+// discarded. When the stream failed or its context was cancelled, the content received
+// so far is returned together with the error so callers can decide whether to salvage it.
+func (s *ResponseStream) Result() (*GenerateResponseResult, error) {
+	for range s.Deltas {
+	}
+	<-s.done
+	return s.result, s.err
+}
+
+// GenerateResponseStream generates a chat completion like GenerateResponse (with
+// thinking disabled) but delivers the content as it arrives. Endpoints serving the
+// OpenAI API under /v1 are streamed over SSE; others are requested in one piece and
+// delivered as a single delta. Errors starting the request are returned directly and
+// are not retried, since a stream cannot be replayed once deltas have been read. A
+// TokenBudget attached to ctx is checked and charged as for GenerateResponse; a stream
+// that ends without reporting usage is charged an estimate of what it used.
+func (c *Client) GenerateResponseStream(
+	ctx context.Context,
+	prompt string,
+	systemMessage string,
+	temperature float64,
+) (*ResponseStream, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, c.parseError(err)
+	}
+
+	if !c.supportsStreaming() {
+		return c.unstreamedResponse(ctx, prompt, systemMessage, temperature)
+	}
+
+	estimatedTokens := EstimatePromptTokens(prompt, systemMessage)
+	budget := TokenBudgetFrom(ctx)
+	if err := budget.Reserve(estimatedTokens); err != nil {
+		return nil, err
+	}
+	if err := c.rateLimiter.Wait(ctx, estimatedTokens); err != nil {
+		budget.Add(estimatedTokens, 0)
+		return nil, c.parseError(err)
+	}
+
+	ctx, span := tracing.Start(ctx, "llm.generate_response_stream", tracing.AttrLLMModel.String(c.model))
+
+	req := c.chatRequest(prompt, systemMessage, temperature, false)
+	req.Stream = true
+	req.StreamOptions = &openai.StreamOptions{IncludeUsage: true}
+
+	c.logger.Debug("LLM stream request",
+		zap.String("model", c.model),
+		zap.Int("prompt_len", len(prompt)),
+		zap.Float64("temperature", temperature))
+
+	start := time.Now()
+	captureCtx, retryAfter := withRetryAfterCapture(ctx)
+	stream, err := c.client.CreateChatCompletionStream(captureCtx, req)
+	if err != nil {
+		c.rateLimiter.Release(estimatedTokens)
+		budget.Add(estimatedTokens, 0)
+		err = retryAfter.apply(c.parseError(err))
+		tracing.End(span, err)
+		c.logger.Error("Failed to create LLM stream", zap.Error(err))
+		return nil, err
+	}
+
+	deltas := make(chan string, streamDeltaBuffer)
+	rs := &ResponseStream{Deltas: deltas, done: make(chan struct{})}
+
+	go func() {
+		defer close(rs.done)
+		defer close(deltas)
+		defer stream.Close()
+
+		var content strings.Builder
+		var usage openai.Usage
+		for {
+			chunk, recvErr := stream.Recv()
+			if errors.Is(recvErr, io.EOF) {
+				break
+			}
+			if recvErr != nil {
+				if ctx.Err() != nil {
+					recvErr = ctx.Err()
+				}
+				rs.err = c.parseError(recvErr)
+				break
+			}
+
+			if chunk.Usage != nil {
+				usage = *chunk.Usage
+			}
+			if len(chunk.Choices) == 0 || chunk.Choices[0].Delta.Content == "" {
+				continue
+			}
+
+			delta := chunk.Choices[0].Delta.Content
+			content.WriteString(delta)
+			select {
+			case deltas <- delta:
+			case <-ctx.Done():
+				rs.err = c.parseError(ctx.Err())
+			}
+			if rs.err != nil {
+				break
+			}
+		}
+
+		used := usage.TotalTokens
+		if used == 0 {
+			// Usage arrives in the final chunk, so a stream that failed or was cancelled
+			// has none: charge the prompt estimate plus the content received so far.
+			used = estimatedTokens + EstimateTokens(content.String())
+		}
+		c.rateLimiter.Settle(estimatedTokens, used)
+		budget.Add(estimatedTokens, used)
+		rs.result = &GenerateResponseResult{
+			Content:          content.String(),
+			PromptTokens:     usage.PromptTokens,
+			CompletionTokens: usage.CompletionTokens,
+			TotalTokens:      usage.TotalTokens,
+			Attempts:         1,
+		}
+
+		span.SetAttributes(
+			tracing.AttrPromptTokens.Int(usage.PromptTokens),
+			tracing.AttrCompletionTokens.Int(usage.CompletionTokens),
+			tracing.AttrTotalTokens.Int(usage.TotalTokens),
+		)
+		tracing.End(span, rs.err)
+
+		if rs.err != nil {
+			c.logger.Warn("LLM stream ended early",
+				zap.Int("content_length", content.Len()),
+				zap.Duration("elapsed", time.Since(start)),
+				zap.Error(rs.err))
+			return
+		}
+		c.logger.Info("LLM stream completed",
+			zap.Int("prompt_tokens", usage.PromptTokens),
+			zap.Int("completion_tokens", usage.CompletionTokens),
+			zap.Duration("elapsed", time.Since(start)))
+	}()
+
+	return rs, nil
+}
+
+// unstreamedResponse serves GenerateResponseStream for endpoints that are not known to
+// stream, delivering the whole completion as one delta.
+func (c *Client) unstreamedResponse(
+	ctx context.Context,
+	prompt string,
+	systemMessage string,
+	temperature float64,
+) (*ResponseStream, error) {
+	return CompletedResponseStream(c.GenerateResponse(ctx, prompt, systemMessage, temperature, false))
+}
+
+// CompletedResponseStream wraps the outcome of a GenerateResponse call as a stream that
+// delivers the whole content as one delta. An error is returned as is, so clients that
+// cannot stream can implement GenerateResponseStream in one line.
+func CompletedResponseStream(result *GenerateResponseResult, err error) (*ResponseStream, error) {
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		result = &GenerateResponseResult{}
+	}
+
+	deltas := make(chan string, 1)
+	if result.Content != "" {
+		deltas <- result.Content
+	}
+	close(deltas)
+
+	done := make(chan struct{})
+	close(done)
+	return &ResponseStream{Deltas: deltas, done: done, result: result}, nil
+}
+
+// supportsStreaming reports whether the endpoint serves the OpenAI-compatible API under
+// /v1, whose chat completions can be streamed over SSE.
+func (c *Client) supportsStreaming() bool {
+	return strings.HasSuffix(strings.TrimSuffix(c.endpoint, "/"), "/v1")
+}
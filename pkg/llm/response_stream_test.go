@@ -0,0 +1,153 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// writeSSEChunk writes one streamed chat completion chunk carrying content.
+func writeSSEChunk(w http.ResponseWriter, content string) {
+	fmt.Fprintf(w, "data: {\"id\":\"c1\",\"object\":\"chat.completion.chunk\",\"model\":\"test-model\","+
+		"\"choices\":[{\"index\":0,\"delta\":{\"content\":%q}}]}\n\n", content)
+	w.(http.Flusher).Flush()
+}
+
+// sseServer streams chunks from /v1/chat/completions and then, unless hang is set,
+// a usage chunk and [DONE]. With hang it blocks until the client goes away.
+func sseServer(t *testing.T, chunks []string, hang bool) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/chat/completions", r.URL.Path)
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, chunk := range chunks {
+			writeSSEChunk(w, chunk)
+		}
+		if hang {
+			<-r.Context().Done()
+			return
+		}
+		fmt.Fprint(w, "data: {\"id\":\"c1\",\"object\":\"chat.completion.chunk\",\"model\":\"test-model\",\"choices\":[],"+
+			"\"usage\":{\"prompt_tokens\":12,\"completion_tokens\":5,\"total_tokens\":17}}\n\n")
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGenerateResponseStream_DeliversDeltasAndAggregate(t *testing.T) {
+	server := sseServer(t, []string{"Here you go: ", `{"entities": `, `["orders"]}`}, false)
+
+	client, err := NewClient(&Config{Endpoint: server.URL + "/v1", Model: "test-model"}, zap.NewNop())
+	require.NoError(t, err)
+
+	stream, err := client.GenerateResponseStream(context.Background(), "prompt", "system", 0.2)
+	require.NoError(t, err)
+
+	var deltas []string
+	for delta := range stream.Deltas {
+		deltas = append(deltas, delta)
+	}
+	assert.Equal(t, []string{"Here you go: ", `{"entities": `, `["orders"]}`}, deltas)
+
+	result, err := stream.Result()
+	require.NoError(t, err)
+	assert.Equal(t, `Here you go: {"entities": ["orders"]}`, result.Content)
+	assert.Equal(t, 12, result.PromptTokens)
+	assert.Equal(t, 5, result.CompletionTokens)
+	assert.Equal(t, 17, result.TotalTokens)
+
+	extracted, err := ExtractJSON(result.Content)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"entities": ["orders"]}`, extracted)
+}
+
+func TestGenerateResponseStream_CancelReturnsPartialContent(t *testing.T) {
+	server := sseServer(t, []string{`{"entities": [`}, true)
+
+	client, err := NewClient(&Config{Endpoint: server.URL + "/v1", Model: "test-model"}, zap.NewNop())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := client.GenerateResponseStream(ctx, "prompt", "system", 0.2)
+	require.NoError(t, err)
+
+	assert.Equal(t, `{"entities": [`, <-stream.Deltas)
+	cancel()
+
+	for range stream.Deltas {
+	}
+	result, err := stream.Result()
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.Canceled)
+	require.NotNil(t, result)
+	assert.Equal(t, `{"entities": [`, result.Content, "content gathered before cancellation is kept")
+}
+
+func TestGenerateResponseStream_CancelChargesEstimatedUsage(t *testing.T) {
+	server := sseServer(t, []string{`{"entities": [`}, true)
+
+	client, err := NewClient(&Config{Endpoint: server.URL + "/v1", Model: "test-model"}, zap.NewNop())
+	require.NoError(t, err)
+
+	budget := NewTokenBudget(0)
+	ctx, cancel := context.WithCancel(WithTokenBudget(context.Background(), budget))
+	defer cancel()
+
+	stream, err := client.GenerateResponseStream(ctx, "prompt", "system", 0.2)
+	require.NoError(t, err)
+
+	<-stream.Deltas
+	cancel()
+	_, err = stream.Result()
+	require.Error(t, err)
+
+	want := EstimatePromptTokens("prompt", "system") + EstimateTokens(`{"entities": [`)
+	assert.Equal(t, want, budget.Spent(), "a cancelled stream is charged for its prompt and partial completion")
+}
+
+func TestGenerateResponseStream_ResultWithoutReadingDeltas(t *testing.T) {
+	server := sseServer(t, []string{"a", "b", "c"}, false)
+
+	client, err := NewClient(&Config{Endpoint: server.URL + "/v1", Model: "test-model"}, zap.NewNop())
+	require.NoError(t, err)
+
+	stream, err := client.GenerateResponseStream(context.Background(), "prompt", "system", 0.2)
+	require.NoError(t, err)
+
+	result, err := stream.Result()
+	require.NoError(t, err)
+	assert.Equal(t, "abc", result.Content)
+}
+
+func TestGenerateResponseStream_NonV1EndpointDeliversOneDelta(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(okCompletion))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(&Config{Endpoint: server.URL, Model: "test-model"}, zap.NewNop())
+	require.NoError(t, err)
+
+	stream, err := client.GenerateResponseStream(context.Background(), "prompt", "system", 0.2)
+	require.NoError(t, err)
+
+	var deltas []string
+	for delta := range stream.Deltas {
+		deltas = append(deltas, delta)
+	}
+	assert.Equal(t, []string{"ok"}, deltas)
+
+	result, err := stream.Result()
+	require.NoError(t, err)
+	assert.Equal(t, "ok", result.Content)
+}
@@ -0,0 +1,76 @@
+package llm
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// retryAfterHeader is the header a throttled provider uses to say when to try again.
+const retryAfterHeader = "Retry-After"
+
+// ParseRetryAfter parses a Retry-After header value, either delay-seconds ("30") or an
+// HTTP-date ("Wed, 21 Oct 2015 07:28:00 GMT") relative to now. Returns false when the
+// value is empty or malformed; a date in the past is a zero delay.
+func ParseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	at, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	if delay := at.Sub(now); delay > 0 {
+		return delay, true
+	}
+	return 0, true
+}
+
+type retryAfterKey struct{}
+
+// retryAfterCapture records the Retry-After delay of a throttled response. The
+// OpenAI client does not expose response headers on errors, so the transport stores
+// the delay here for the caller to attach to the classified error.
+type retryAfterCapture struct {
+	delay atomic.Int64 // nanoseconds; 0 when no throttled response carried the header
+}
+
+// withRetryAfterCapture returns a context whose requests record their Retry-After
+// delay in the returned capture.
+func withRetryAfterCapture(ctx context.Context) (context.Context, *retryAfterCapture) {
+	capture := &retryAfterCapture{}
+	return context.WithValue(ctx, retryAfterKey{}, capture), capture
+}
+
+// recordRetryAfter stores the Retry-After delay of a 429 response in the request's
+// capture, if it has one.
+func recordRetryAfter(req *http.Request, resp *http.Response) {
+	if resp == nil || resp.StatusCode != http.StatusTooManyRequests {
+		return
+	}
+	capture, ok := req.Context().Value(retryAfterKey{}).(*retryAfterCapture)
+	if !ok {
+		return
+	}
+	if delay, ok := ParseRetryAfter(resp.Header.Get(retryAfterHeader), time.Now()); ok {
+		capture.delay.Store(int64(delay))
+	}
+}
+
+// apply sets the captured delay on a rate-limit error.
+func (c *retryAfterCapture) apply(err error) error {
+	if llmErr, ok := err.(*Error); ok && llmErr.Type == ErrorTypeRateLimited {
+		llmErr.RetryAfter = time.Duration(c.delay.Load())
+	}
+	return err
+}
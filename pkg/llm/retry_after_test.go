@@ -0,0 +1,87 @@
+package llm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/retry"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 10, 21, 7, 28, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{name: "seconds", value: "30", want: 30 * time.Second, ok: true},
+		{name: "seconds with whitespace", value: " 2 ", want: 2 * time.Second, ok: true},
+		{name: "http date", value: "Tue, 21 Oct 2025 07:28:45 GMT", want: 45 * time.Second, ok: true},
+		{name: "http date in the past", value: "Tue, 21 Oct 2025 07:27:00 GMT", want: 0, ok: true},
+		{name: "empty", value: "", ok: false},
+		{name: "negative", value: "-5", ok: false},
+		{name: "garbage", value: "soon", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseRetryAfter(tt.value, now)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGenerateResponse_RetryWaitsForRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"error": {"message": "rate limit exceeded", "type": "rate_limit_error"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{
+			"id": "chatcmpl-1", "object": "chat.completion", "model": "test-model",
+			"choices": [{"index": 0, "message": {"role": "assistant", "content": "ok"}, "finish_reason": "stop"}],
+			"usage": {"prompt_tokens": 1, "completion_tokens": 1, "total_tokens": 2}
+		}`))
+	}))
+	defer server.Close()
+
+	// The client's own retries are disabled so the caller's retry loop sees the 429
+	client, err := NewClient(&Config{Endpoint: server.URL, Model: "test-model", RetryPolicy: &RetryPolicy{}}, zap.NewNop())
+	require.NoError(t, err)
+
+	// Backoff alone would retry after ~1ms; the header asks for a full second
+	cfg := &retry.Config{MaxRetries: 2, InitialDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond, Multiplier: 2}
+	var firstErr error
+	start := time.Now()
+	err = retry.Do(context.Background(), cfg, func() error {
+		_, err := client.GenerateResponse(context.Background(), "prompt", "system", 0.2, false)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		return err
+	})
+	elapsed := time.Since(start)
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+	assert.GreaterOrEqual(t, elapsed, time.Second, "retry should wait for the Retry-After delay")
+
+	llmErr := ClassifyError(firstErr)
+	assert.Equal(t, ErrorTypeRateLimited, llmErr.Type)
+	assert.Equal(t, time.Second, llmErr.RetryAfter)
+}
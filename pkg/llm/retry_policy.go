@@ -0,0 +1,60 @@
+package llm
+
+import (
+	"errors"
+	"slices"
+	"time"
+)
+
+// RetryPolicy controls how GenerateResponse retries a failed request.
+type RetryPolicy struct {
+	MaxRetries int           // Retries after the first attempt; 0 disables retrying
+	BaseDelay  time.Duration // Wait before the first retry, doubled for each later one
+	MaxDelay   time.Duration // Cap on any single wait, including a provider's Retry-After
+
+	// RetryableStatusCodes are the HTTP statuses worth retrying. Failures without an
+	// HTTP status (connection resets, refused connections, timeouts) are retried when
+	// classified as retryable.
+	RetryableStatusCodes []int
+}
+
+// DefaultRetryPolicy returns the policy used when Config.RetryPolicy is nil: 3 retries
+// starting at 1s and capped at 10s, for rate limits and gateway or server errors.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxRetries:           3,
+		BaseDelay:            time.Second,
+		MaxDelay:             10 * time.Second,
+		RetryableStatusCodes: []int{429, 500, 502, 503, 504},
+	}
+}
+
+// shouldRetry reports whether err, as returned by parseError, is worth another attempt.
+func (p *RetryPolicy) shouldRetry(err error) bool {
+	var llmErr *Error
+	if !errors.As(err, &llmErr) {
+		return false
+	}
+	if llmErr.StatusCode > 0 {
+		return slices.Contains(p.RetryableStatusCodes, llmErr.StatusCode)
+	}
+	return llmErr.Retryable
+}
+
+// delay returns how long to wait before retry number retry (1-based) after err. A
+// Retry-After requested by the provider replaces the exponential backoff.
+func (p *RetryPolicy) delay(retry int, err error) time.Duration {
+	wait := p.BaseDelay
+	for i := 1; i < retry && wait < p.MaxDelay; i++ {
+		wait *= 2
+	}
+
+	var llmErr *Error
+	if errors.As(err, &llmErr) && llmErr.RetryAfter > 0 {
+		wait = llmErr.RetryAfter
+	}
+	if p.MaxDelay > 0 && wait > p.MaxDelay {
+		wait = p.MaxDelay
+	}
+	return wait
+}
@@ -0,0 +1,138 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+const okCompletion = `{
+	"id": "chatcmpl-1", "object": "chat.completion", "model": "test-model",
+	"choices": [{"index": 0, "message": {"role": "assistant", "content": "ok"}, "finish_reason": "stop"}],
+	"usage": {"prompt_tokens": 1, "completion_tokens": 1, "total_tokens": 2}
+}`
+
+// failingServer answers the first failures requests with status and then succeeds.
+func failingServer(t *testing.T, failures int32, status int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if calls.Add(1) <= failures {
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"error": {"message": "upstream unavailable", "type": "server_error"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(okCompletion))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func fastRetryPolicy(maxRetries int) *RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.MaxRetries = maxRetries
+	policy.BaseDelay = time.Millisecond
+	policy.MaxDelay = 5 * time.Millisecond
+	return policy
+}
+
+func TestGenerateResponse_RetriesTransientStatus(t *testing.T) {
+	server, calls := failingServer(t, 2, http.StatusServiceUnavailable)
+	core, logs := observer.New(zapcore.WarnLevel)
+
+	client, err := NewClient(&Config{Endpoint: server.URL, Model: "test-model", RetryPolicy: fastRetryPolicy(3)}, zap.New(core))
+	require.NoError(t, err)
+
+	result, err := client.GenerateResponse(context.Background(), "prompt", "system", 0.2, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, "ok", result.Content)
+	assert.Equal(t, 3, result.Attempts)
+	assert.Equal(t, int32(3), calls.Load())
+	assert.Equal(t, 2, logs.FilterMessage("LLM request failed, retrying").Len(), "each retry is logged at Warn")
+}
+
+func TestGenerateResponse_GivesUpAfterMaxRetries(t *testing.T) {
+	server, calls := failingServer(t, 10, http.StatusBadGateway)
+
+	client, err := NewClient(&Config{Endpoint: server.URL, Model: "test-model", RetryPolicy: fastRetryPolicy(2)}, zap.NewNop())
+	require.NoError(t, err)
+
+	_, err = client.GenerateResponse(context.Background(), "prompt", "system", 0.2, false)
+	require.Error(t, err)
+	assert.Equal(t, int32(3), calls.Load(), "first attempt plus two retries")
+	assert.Equal(t, 502, ClassifyError(err).StatusCode)
+}
+
+func TestGenerateResponse_NonRetryableStatusReturnsImmediately(t *testing.T) {
+	server, calls := failingServer(t, 10, http.StatusBadRequest)
+
+	client, err := NewClient(&Config{Endpoint: server.URL, Model: "test-model", RetryPolicy: fastRetryPolicy(3)}, zap.NewNop())
+	require.NoError(t, err)
+
+	_, err = client.GenerateResponse(context.Background(), "prompt", "system", 0.2, false)
+	require.Error(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestGenerateResponse_RetryableStatusCodesAreConfigurable(t *testing.T) {
+	server, calls := failingServer(t, 10, http.StatusServiceUnavailable)
+
+	policy := fastRetryPolicy(3)
+	policy.RetryableStatusCodes = []int{http.StatusTooManyRequests}
+	client, err := NewClient(&Config{Endpoint: server.URL, Model: "test-model", RetryPolicy: policy}, zap.NewNop())
+	require.NoError(t, err)
+
+	_, err = client.GenerateResponse(context.Background(), "prompt", "system", 0.2, false)
+	require.Error(t, err)
+	assert.Equal(t, int32(1), calls.Load(), "503 is not retried when left out of the policy")
+}
+
+func TestGenerateResponse_CancellationStopsBackoff(t *testing.T) {
+	server, calls := failingServer(t, 10, http.StatusServiceUnavailable)
+
+	policy := fastRetryPolicy(3)
+	policy.BaseDelay = time.Minute
+	policy.MaxDelay = time.Minute
+	client, err := NewClient(&Config{Endpoint: server.URL, Model: "test-model", RetryPolicy: policy}, zap.NewNop())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = client.GenerateResponse(ctx, "prompt", "system", 0.2, false)
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), 10*time.Second, "cancellation must interrupt the backoff wait")
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	policy := &RetryPolicy{BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+
+	assert.Equal(t, time.Second, policy.delay(1, nil))
+	assert.Equal(t, 2*time.Second, policy.delay(2, nil))
+	assert.Equal(t, 4*time.Second, policy.delay(3, nil))
+	assert.Equal(t, 5*time.Second, policy.delay(4, nil), "capped at MaxDelay")
+
+	throttled := &Error{Type: ErrorTypeRateLimited, StatusCode: 429, Retryable: true, RetryAfter: 3 * time.Second}
+	assert.Equal(t, 3*time.Second, policy.delay(1, throttled), "Retry-After replaces the backoff")
+}
+
+func TestRetryPolicy_ShouldRetryConnectionReset(t *testing.T) {
+	policy := DefaultRetryPolicy()
+
+	assert.True(t, policy.shouldRetry(ClassifyError(errors.New("read tcp: connection reset by peer"))))
+	assert.False(t, policy.shouldRetry(ClassifyError(errors.New("context canceled"))))
+}
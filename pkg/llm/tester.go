@@ -19,6 +19,7 @@ const (
 	ErrorTypeAuth        ErrorType = "auth"
 	ErrorTypeModel       ErrorType = "model"
 	ErrorTypeRateLimited ErrorType = "rate_limited"
+	ErrorTypeTokenBudget ErrorType = "token_budget"
 	ErrorTypeUnknown     ErrorType = "unknown"
 )
 
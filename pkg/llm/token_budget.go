@@ -0,0 +1,120 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrTokenBudgetExceeded is returned instead of making a call that would take a run's
+// token spend past its budget.
+var ErrTokenBudgetExceeded = errors.New("token budget exceeded")
+
+const tokenBudgetKey contextKey = "token_budget"
+
+// TokenBudget caps the prompt and completion tokens spent by the LLM calls of one run.
+// Attach it with WithTokenBudget; every Client call made with that context reserves its
+// estimated size first and reconciles the reservation with its usage after. It is safe
+// for concurrent use by parallel workers.
+type TokenBudget struct {
+	ceiling int
+
+	mu      sync.Mutex
+	spent   int
+	pending int // estimated tokens reserved by calls still in flight
+	refused int // estimated size of the last call refused; 0 while none has been
+}
+
+// NewTokenBudget creates a budget allowing ceiling tokens. A ceiling of 0 or less
+// never refuses a call but still tracks the spend.
+func NewTokenBudget(ceiling int) *TokenBudget {
+	return &TokenBudget{ceiling: ceiling}
+}
+
+// Reserve checks that a call with the given estimated prompt size still fits the
+// budget alongside the spend and the calls already in flight, and if so reserves the
+// estimate until the call reconciles it with Add. When it does not fit, it returns a
+// non-retryable *Error wrapping ErrTokenBudgetExceeded and the budget counts as
+// exhausted from then on. Completion tokens are not known up front, so a run may end
+// slightly over budget.
+func (b *TokenBudget) Reserve(estimatedTokens int) error {
+	if b == nil {
+		return nil
+	}
+	estimatedTokens = max(estimatedTokens, 0)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.ceiling > 0 && b.spent+b.pending+estimatedTokens > b.ceiling {
+		b.refused = max(estimatedTokens, 1)
+		return b.errLocked()
+	}
+	b.pending += estimatedTokens
+	return nil
+}
+
+// Err returns the error Reserve gave for the last refused call, or nil if the budget
+// has refused none. Callers whose workers log and skip failed LLM calls use it to
+// tell that the run was stopped by the budget rather than finished.
+func (b *TokenBudget) Err() error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.refused == 0 {
+		return nil
+	}
+	return b.errLocked()
+}
+
+func (b *TokenBudget) errLocked() error {
+	msg := fmt.Sprintf("%d of %d tokens spent, next call needs about %d", b.spent, b.ceiling, b.refused)
+	if b.pending > 0 {
+		msg += fmt.Sprintf(" with %d reserved by calls in flight", b.pending)
+	}
+	return NewError(ErrorTypeTokenBudget, msg, false, ErrTokenBudgetExceeded)
+}
+
+// Add releases a call's reservation and records the tokens it used, which are 0 for a
+// call that failed without usage. Every successful Reserve must be followed by one Add
+// with the same reserved amount.
+func (b *TokenBudget) Add(reserved, used int) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	b.pending = max(b.pending-max(reserved, 0), 0)
+	b.spent += max(used, 0)
+	b.mu.Unlock()
+}
+
+// Spent returns the tokens recorded so far.
+func (b *TokenBudget) Spent() int {
+	if b == nil {
+		return 0
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.spent
+}
+
+// Ceiling returns the budget's ceiling; 0 means unlimited.
+func (b *TokenBudget) Ceiling() int {
+	if b == nil {
+		return 0
+	}
+	return max(b.ceiling, 0)
+}
+
+// WithTokenBudget attaches a token budget to the context. LLM calls made with the
+// returned context are checked against it and charged to it.
+func WithTokenBudget(ctx context.Context, budget *TokenBudget) context.Context {
+	return context.WithValue(ctx, tokenBudgetKey, budget)
+}
+
+// TokenBudgetFrom returns the token budget attached to the context, or nil.
+func TokenBudgetFrom(ctx context.Context) *TokenBudget {
+	budget, _ := ctx.Value(tokenBudgetKey).(*TokenBudget)
+	return budget
+}
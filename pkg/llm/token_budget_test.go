@@ -0,0 +1,134 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestTokenBudget_ReserveRefusesPastCeiling(t *testing.T) {
+	budget := NewTokenBudget(100)
+
+	require.NoError(t, budget.Reserve(60))
+	budget.Add(60, 60)
+	require.NoError(t, budget.Reserve(40), "a call that exactly fills the budget is allowed")
+
+	budget.Add(40, 0)
+
+	err := budget.Reserve(41)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrTokenBudgetExceeded)
+	assert.Contains(t, err.Error(), "60 of 100 tokens spent")
+	assert.False(t, IsRetryable(err), "an exhausted budget must not be retried")
+	assert.Equal(t, ErrorTypeTokenBudget, ClassifyError(err).Type)
+
+	assert.ErrorIs(t, budget.Err(), ErrTokenBudgetExceeded, "the budget stays exhausted after a refusal")
+	assert.NoError(t, NewTokenBudget(100).Err())
+}
+
+func TestTokenBudget_UnlimitedAndNil(t *testing.T) {
+	unlimited := NewTokenBudget(0)
+	unlimited.Add(0, 1_000_000)
+	assert.NoError(t, unlimited.Reserve(1_000_000))
+	assert.Equal(t, 1_000_000, unlimited.Spent(), "an unlimited budget still tracks spend")
+	assert.Equal(t, 0, unlimited.Ceiling())
+
+	var none *TokenBudget
+	assert.NoError(t, none.Reserve(1_000_000))
+	none.Add(10, 10)
+	assert.Equal(t, 0, none.Spent())
+	assert.Equal(t, 0, none.Ceiling())
+	assert.Nil(t, TokenBudgetFrom(context.Background()))
+}
+
+func TestTokenBudget_ConcurrentAdd(t *testing.T) {
+	budget := NewTokenBudget(0)
+
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			budget.Add(0, 3)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 150, budget.Spent())
+}
+
+func TestTokenBudget_ReservationsCountUntilReconciled(t *testing.T) {
+	budget := NewTokenBudget(100)
+
+	// Two calls in flight together hold the whole budget
+	require.NoError(t, budget.Reserve(50))
+	require.NoError(t, budget.Reserve(50))
+
+	err := budget.Reserve(1)
+	require.ErrorIs(t, err, ErrTokenBudgetExceeded, "reservations of calls in flight count against the budget")
+	assert.Contains(t, err.Error(), "100 reserved by calls in flight")
+	assert.Equal(t, 0, budget.Spent(), "reservations are not spend")
+
+	// The first call used less than estimated, the second failed without usage
+	budget.Add(50, 30)
+	budget.Add(50, 0)
+	assert.Equal(t, 30, budget.Spent())
+
+	assert.NoError(t, budget.Reserve(70), "reconciled reservations are released")
+}
+
+func TestTokenBudget_ConcurrentReserveNeverOvercommits(t *testing.T) {
+	budget := NewTokenBudget(100)
+
+	var admitted atomic.Int32
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if budget.Reserve(10) == nil {
+				admitted.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(10), admitted.Load(), "only as many calls as fit the ceiling are admitted")
+}
+
+func TestGenerateResponse_ChargesAndEnforcesTokenBudget(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(okCompletion))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(&Config{Endpoint: server.URL, Model: "test-model"}, zap.NewNop())
+	require.NoError(t, err)
+
+	budget := NewTokenBudget(2 + EstimatePromptTokens("prompt", "system") - 1)
+	ctx := WithTokenBudget(context.Background(), budget)
+
+	_, err = client.GenerateResponse(ctx, "prompt", "system", 0.2, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, budget.Spent(), "usage of the completed call is charged")
+
+	_, err = client.GenerateResponse(ctx, "prompt", "system", 0.2, false)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrTokenBudgetExceeded))
+	assert.Equal(t, int32(1), calls.Load(), "the refused call must not reach the endpoint")
+
+	_, err = client.GenerateResponseStream(ctx, "prompt", "system", 0.2)
+	assert.ErrorIs(t, err, ErrTokenBudgetExceeded)
+	assert.Equal(t, int32(1), calls.Load())
+}
@@ -199,6 +199,9 @@ func (m *mockSchemaRepo) GetEmptyTables(context.Context, uuid.UUID, uuid.UUID) (
 func (m *mockSchemaRepo) GetOrphanTables(context.Context, uuid.UUID, uuid.UUID) ([]string, error) {
 	return nil, nil
 }
+func (m *mockSchemaRepo) GetRelationshipStats(context.Context, uuid.UUID, uuid.UUID) (*models.RelationshipStats, error) {
+	return nil, nil
+}
 func (m *mockSchemaRepo) UpsertRelationshipWithMetrics(context.Context, *models.SchemaRelationship, *models.DiscoveryMetrics) error {
 	return nil
 }
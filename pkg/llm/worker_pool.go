@@ -2,30 +2,43 @@ package llm
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
 )
 
 // WorkerPoolConfig configures the LLM worker pool.
 type WorkerPoolConfig struct {
-	MaxConcurrent int // Maximum concurrent LLM calls (default: 8)
+	MaxConcurrent     int           // Maximum concurrent LLM calls (default: 8)
+	RateLimitPause    time.Duration // Pause for a rate limit without Retry-After (default: 1s)
+	MaxRateLimitPause time.Duration // Cap on a Retry-After pause (default: 30s)
 }
 
 // DefaultWorkerPoolConfig returns sensible defaults.
 func DefaultWorkerPoolConfig() WorkerPoolConfig {
 	return WorkerPoolConfig{
-		MaxConcurrent: 8,
+		MaxConcurrent:     8,
+		RateLimitPause:    time.Second,
+		MaxRateLimitPause: 30 * time.Second,
 	}
 }
 
 // WorkerPool manages concurrent LLM call execution with bounded parallelism.
 // It uses a semaphore to limit outstanding requests and processes results
 // as they complete, allowing new requests to start immediately.
+//
+// When a work item fails with a rate-limit error, every worker of the pool waits
+// before starting its next call, for the provider's Retry-After delay when given,
+// so workers back off together instead of each hammering the throttled provider.
 type WorkerPool struct {
 	config WorkerPoolConfig
 	logger *zap.Logger
+
+	mu          sync.Mutex
+	pausedUntil time.Time
 }
 
 // NewWorkerPool creates a new LLM worker pool.
@@ -33,6 +46,12 @@ func NewWorkerPool(config WorkerPoolConfig, logger *zap.Logger) *WorkerPool {
 	if config.MaxConcurrent < 1 {
 		config.MaxConcurrent = 8
 	}
+	if config.RateLimitPause <= 0 {
+		config.RateLimitPause = time.Second
+	}
+	if config.MaxRateLimitPause <= 0 {
+		config.MaxRateLimitPause = 30 * time.Second
+	}
 	return &WorkerPool{
 		config: config,
 		logger: logger.Named("llm-worker-pool"),
@@ -87,8 +106,25 @@ func Process[T any](
 				return
 			}
 
+			// The context may have been cancelled while waiting for a slot;
+			// select picks randomly when both cases are ready, so check again
+			// to avoid issuing a call for a cancelled job.
+			if err := ctx.Err(); err != nil {
+				var zero T
+				resultsChan <- WorkResult[T]{ID: item.ID, Result: zero, Err: err}
+				return
+			}
+
+			// Wait out a rate-limit pause started by any worker
+			if err := pool.waitForPause(ctx); err != nil {
+				var zero T
+				resultsChan <- WorkResult[T]{ID: item.ID, Result: zero, Err: err}
+				return
+			}
+
 			// Execute the work
 			result, err := item.Execute(ctx)
+			pool.pauseOnRateLimit(err)
 			resultsChan <- WorkResult[T]{
 				ID:     item.ID,
 				Result: result,
@@ -116,6 +152,43 @@ func Process[T any](
 	return results
 }
 
+// pauseOnRateLimit pauses the pool after a rate-limit error: for the error's
+// Retry-After delay (capped at MaxRateLimitPause) or RateLimitPause without one.
+// A pause never shortens one already in effect.
+func (p *WorkerPool) pauseOnRateLimit(err error) {
+	var llmErr *Error
+	if !errors.As(err, &llmErr) || llmErr.Type != ErrorTypeRateLimited {
+		return
+	}
+	pause := p.config.RateLimitPause
+	if llmErr.RetryAfter > 0 {
+		pause = min(llmErr.RetryAfter, p.config.MaxRateLimitPause)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if until := time.Now().Add(pause); until.After(p.pausedUntil) {
+		p.pausedUntil = until
+		p.logger.Warn("Rate limited, pausing all workers", zap.Duration("pause", pause))
+	}
+}
+
+// waitForPause blocks until the pool's rate-limit pause has passed.
+func (p *WorkerPool) waitForPause(ctx context.Context) error {
+	p.mu.Lock()
+	wait := time.Until(p.pausedUntil)
+	p.mu.Unlock()
+	if wait <= 0 {
+		return nil
+	}
+	select {
+	case <-time.After(wait):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // CheckResults returns an error if any work items failed.
 // Call this after Process() to fail fast when LLM calls fail.
 // The error includes the count and the first failure for diagnostics.
@@ -127,6 +127,53 @@ func TestWorkerPool_Process_ContextCancellation(t *testing.T) {
 	}
 }
 
+func TestWorkerPool_Process_CancellationStopsFurtherCalls(t *testing.T) {
+	pool := NewWorkerPool(WorkerPoolConfig{MaxConcurrent: 1}, zap.NewNop())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The job is cancelled while the second call is in flight; that call still
+	// completes, and no further calls may start.
+	var calls atomic.Int32
+	items := make([]WorkItem[string], 5)
+	for i := range items {
+		items[i] = WorkItem[string]{
+			ID: fmt.Sprintf("task%d", i),
+			Execute: func(ctx context.Context) (string, error) {
+				if calls.Add(1) == 2 {
+					cancel()
+				}
+				return "done", nil
+			},
+		}
+	}
+
+	results := Process(ctx, pool, items, nil)
+
+	if got := calls.Load(); got != 2 {
+		t.Errorf("expected 2 calls before cancellation took effect, got %d", got)
+	}
+	if len(results) != len(items) {
+		t.Fatalf("expected a result for every item, got %d", len(results))
+	}
+
+	var completed, cancelled int
+	for _, r := range results {
+		switch {
+		case r.Err == nil && r.Result == "done":
+			completed++
+		case errors.Is(r.Err, context.Canceled):
+			cancelled++
+		default:
+			t.Errorf("unexpected result for %s: %+v", r.ID, r)
+		}
+	}
+	if completed != 2 || cancelled != 3 {
+		t.Errorf("expected 2 completed and 3 cancelled results, got %d and %d", completed, cancelled)
+	}
+}
+
 func TestWorkerPool_Process_ConcurrencyLimit(t *testing.T) {
 	maxConcurrent := 3
 	pool := NewWorkerPool(WorkerPoolConfig{MaxConcurrent: maxConcurrent}, zap.NewNop())
@@ -238,4 +285,55 @@ func TestDefaultWorkerPoolConfig(t *testing.T) {
 	if config.MaxConcurrent != 8 {
 		t.Errorf("expected MaxConcurrent=8, got %d", config.MaxConcurrent)
 	}
+	if config.RateLimitPause != time.Second || config.MaxRateLimitPause != 30*time.Second {
+		t.Errorf("expected rate-limit pause 1s capped at 30s, got %v capped at %v", config.RateLimitPause, config.MaxRateLimitPause)
+	}
+}
+
+func TestWorkerPool_Process_RateLimitPausesAllWorkers(t *testing.T) {
+	pool := NewWorkerPool(WorkerPoolConfig{MaxConcurrent: 2}, zap.NewNop())
+	ctx := context.Background()
+
+	start := time.Now()
+	Process(ctx, pool, []WorkItem[string]{
+		{ID: "throttled", Execute: func(ctx context.Context) (string, error) {
+			return "", &Error{Type: ErrorTypeRateLimited, Retryable: true, StatusCode: 429, RetryAfter: 200 * time.Millisecond}
+		}},
+	}, nil)
+
+	// Later work on the same pool waits for the pause, even from another Process call
+	var started atomic.Int64
+	results := Process(ctx, pool, []WorkItem[string]{
+		{ID: "next", Execute: func(ctx context.Context) (string, error) {
+			started.Store(int64(time.Since(start)))
+			return "ok", nil
+		}},
+	}, nil)
+
+	if results[0].Err != nil {
+		t.Fatalf("unexpected error: %v", results[0].Err)
+	}
+	if waited := time.Duration(started.Load()); waited < 200*time.Millisecond {
+		t.Errorf("expected work to wait for the 200ms Retry-After pause, started after %v", waited)
+	}
+}
+
+func TestWorkerPool_PauseOnRateLimit(t *testing.T) {
+	pool := NewWorkerPool(WorkerPoolConfig{MaxConcurrent: 1, MaxRateLimitPause: 50 * time.Millisecond}, zap.NewNop())
+
+	pool.pauseOnRateLimit(&Error{Type: ErrorTypeEndpoint, StatusCode: 500})
+	if !pool.pausedUntil.IsZero() {
+		t.Error("expected non rate-limit errors not to pause the pool")
+	}
+
+	pool.pauseOnRateLimit(fmt.Errorf("wrapped: %w", &Error{Type: ErrorTypeRateLimited, RetryAfter: time.Hour}))
+	if wait := time.Until(pool.pausedUntil); wait <= 0 || wait > 50*time.Millisecond {
+		t.Errorf("expected the Retry-After pause capped at 50ms, got %v", wait)
+	}
+
+	pool = NewWorkerPool(WorkerPoolConfig{MaxConcurrent: 1, RateLimitPause: 20 * time.Millisecond}, zap.NewNop())
+	pool.pauseOnRateLimit(&Error{Type: ErrorTypeRateLimited})
+	if wait := time.Until(pool.pausedUntil); wait <= 0 || wait > 20*time.Millisecond {
+		t.Errorf("expected the default 20ms pause without Retry-After, got %v", wait)
+	}
 }
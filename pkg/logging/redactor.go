@@ -0,0 +1,127 @@
+package logging
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// detector is a pattern and the replacement for each of its matches.
+type detector struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// builtinDetectors are the named detectors a Redactor can be configured with.
+var builtinDetectors = map[string]detector{
+	"email":       {regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`), RedactedText},
+	"phone":       {regexp.MustCompile(`\+?\(?\d{3}\)?[\s.-]\d{3}[\s.-]\d{4}\b`), RedactedText},
+	"ssn":         {regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`), RedactedText},
+	"credit_card": {regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`), RedactedText},
+	"ip_address":  {regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`), RedactedText},
+	// Keeps the key so readers can still tell what was masked
+	"secret": {regexp.MustCompile(`(?i)\b(password|pwd|secret|token|api[_-]?key)(\s*[=:]\s*)("[^"]*"|'[^']*'|[^\s,;]+)`), "${1}${2}" + RedactedText},
+}
+
+// DetectorNames returns the names of the built-in detectors, sorted.
+func DetectorNames() []string {
+	names := make([]string, 0, len(builtinDetectors))
+	for name := range builtinDetectors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Redactor masks values matched by a configured set of detectors.
+// A nil *Redactor is valid and redacts nothing.
+type Redactor struct {
+	detectors []detector
+	names     []string // Per detector: the built-in name, or the pattern for custom patterns
+}
+
+// NewRedactor builds a Redactor from built-in detector names (see DetectorNames) and
+// additional regular expressions. Returns nil when nothing is configured.
+func NewRedactor(detectors, patterns []string) (*Redactor, error) {
+	r := &Redactor{}
+	for _, name := range detectors {
+		d, ok := builtinDetectors[strings.TrimSpace(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown redaction detector %q (available: %s)", name, strings.Join(DetectorNames(), ", "))
+		}
+		r.detectors = append(r.detectors, d)
+		r.names = append(r.names, strings.TrimSpace(name))
+	}
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", p, err)
+		}
+		r.detectors = append(r.detectors, detector{re, RedactedText})
+		r.names = append(r.names, p)
+	}
+	if len(r.detectors) == 0 {
+		return nil, nil
+	}
+	return r, nil
+}
+
+// RedactString replaces every detected value in s with RedactedText.
+func (r *Redactor) RedactString(s string) string {
+	if r == nil || s == "" {
+		return s
+	}
+	for _, d := range r.detectors {
+		s = d.pattern.ReplaceAllString(s, d.replacement)
+	}
+	return s
+}
+
+// Detect returns the names of the detectors with a match in s, in configuration
+// order: built-in detector names, or the pattern itself for custom patterns.
+// Returns nil when nothing would be redacted.
+func (r *Redactor) Detect(s string) []string {
+	if r == nil || s == "" {
+		return nil
+	}
+	var names []string
+	for i, d := range r.detectors {
+		if d.pattern.MatchString(s) {
+			names = append(names, r.names[i])
+		}
+	}
+	return names
+}
+
+// RedactValue returns a copy of a decoded JSON value (maps, slices, strings) with
+// every string redacted. Map keys are left as they are.
+func (r *Redactor) RedactValue(v any) any {
+	if r == nil {
+		return v
+	}
+	switch val := v.(type) {
+	case string:
+		return r.RedactString(val)
+	case map[string]any:
+		out := make(map[string]any, len(val))
+		for k, item := range val {
+			out[k] = r.RedactValue(item)
+		}
+		return out
+	case map[string]string:
+		out := make(map[string]string, len(val))
+		for k, item := range val {
+			out[k] = r.RedactString(item)
+		}
+		return out
+	case []any:
+		out := make([]any, len(val))
+		for i, item := range val {
+			out[i] = r.RedactValue(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
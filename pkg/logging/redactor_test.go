@@ -0,0 +1,120 @@
+package logging
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewRedactor_NothingConfigured(t *testing.T) {
+	r, err := NewRedactor(nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r != nil {
+		t.Fatal("expected nil redactor when no detectors are configured")
+	}
+	// A nil redactor is a no-op
+	if got := r.RedactString("alice@example.com"); got != "alice@example.com" {
+		t.Errorf("expected input unchanged, got %q", got)
+	}
+}
+
+func TestNewRedactor_RejectsUnknownDetectorAndBadPattern(t *testing.T) {
+	if _, err := NewRedactor([]string{"passport"}, nil); err == nil || !strings.Contains(err.Error(), "unknown redaction detector") {
+		t.Errorf("expected unknown detector error, got %v", err)
+	}
+	if _, err := NewRedactor(nil, []string{"([a-z"}); err == nil || !strings.Contains(err.Error(), "invalid redaction pattern") {
+		t.Errorf("expected invalid pattern error, got %v", err)
+	}
+}
+
+func TestRedactor_RedactString(t *testing.T) {
+	r, err := NewRedactor([]string{"email", "ssn", "secret"}, []string{`ACCT-\d{6}`})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "email",
+			input:    "Sample values: alice@example.com, bob@corp.io",
+			expected: "Sample values: [REDACTED], [REDACTED]",
+		},
+		{
+			name:     "ssn",
+			input:    "ssn column sample: 123-45-6789",
+			expected: "ssn column sample: [REDACTED]",
+		},
+		{
+			name:     "secret keeps key",
+			input:    `config: password="hunter2", api_key: abc123`,
+			expected: `config: password=[REDACTED], api_key: [REDACTED]`,
+		},
+		{
+			name:     "custom pattern",
+			input:    "account ACCT-004211 is active",
+			expected: "account [REDACTED] is active",
+		},
+		{
+			name:     "nothing detected",
+			input:    "status values: pending, paid",
+			expected: "status values: pending, paid",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.RedactString(tt.input); got != tt.expected {
+				t.Errorf("RedactString(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRedactor_Detect(t *testing.T) {
+	r, err := NewRedactor([]string{"email", "ssn"}, []string{`ACCT-\d{6}`})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := r.Detect("contact alice@example.com about ACCT-123456")
+	if strings.Join(got, ",") != `email,ACCT-\d{6}` {
+		t.Errorf("expected email and the custom pattern, got %v", got)
+	}
+	if got := r.Detect("nothing sensitive here"); got != nil {
+		t.Errorf("expected no detections, got %v", got)
+	}
+	var nilRedactor *Redactor
+	if got := nilRedactor.Detect("alice@example.com"); got != nil {
+		t.Errorf("expected a nil redactor to detect nothing, got %v", got)
+	}
+}
+
+func TestRedactor_RedactValue(t *testing.T) {
+	r, err := NewRedactor([]string{"email"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	input := []any{
+		map[string]any{"role": "user", "content": "emails: alice@example.com"},
+		map[string]any{"nested": []any{"bob@example.com", 42.0}},
+	}
+	got := r.RedactValue(input).([]any)
+
+	if content := got[0].(map[string]any)["content"]; content != "emails: [REDACTED]" {
+		t.Errorf("expected content redacted, got %v", content)
+	}
+	nested := got[1].(map[string]any)["nested"].([]any)
+	if nested[0] != "[REDACTED]" || nested[1] != 42.0 {
+		t.Errorf("expected nested string redacted and number kept, got %v", nested)
+	}
+	// The input is not modified
+	if input[0].(map[string]any)["content"] != "emails: alice@example.com" {
+		t.Error("expected RedactValue to leave the input unchanged")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/google/uuid"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"go.uber.org/zap"
@@ -18,9 +19,10 @@ import (
 // ColumnToolDeps contains dependencies for column metadata tools.
 type ColumnToolDeps struct {
 	BaseMCPToolDeps
-	SchemaRepo         repositories.SchemaRepository
-	ColumnMetadataRepo repositories.ColumnMetadataRepository
-	ProjectService     services.ProjectService
+	SchemaRepo          repositories.SchemaRepository
+	ColumnMetadataRepo  repositories.ColumnMetadataRepository
+	ProjectService      services.ProjectService
+	TableQualityService services.TableQualityService // Optional; rescores the column's table after an edit
 }
 
 // RegisterColumnTools registers column metadata MCP tools.
@@ -88,7 +90,7 @@ func registerGetColumnMetadataTool(s *server.MCPServer, deps *ColumnToolDeps) {
 		// Get datasource ID
 		datasourceID, err := deps.ProjectService.GetDefaultDatasourceID(tenantCtx, projectID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get datasource: %w", err)
+			return HandleServiceError(fmt.Errorf("failed to get datasource: %w", err), "get_default_datasource_failed")
 		}
 
 		// Validate table exists in schema registry
@@ -371,7 +373,7 @@ func registerUpdateColumnTool(s *server.MCPServer, deps *ColumnToolDeps) {
 		// Validate table and column exist in schema registry
 		datasourceID, err := deps.ProjectService.GetDefaultDatasourceID(tenantCtx, projectID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get datasource: %w", err)
+			return HandleServiceError(fmt.Errorf("failed to get datasource: %w", err), "get_default_datasource_failed")
 		}
 
 		schemaTable, err := deps.SchemaRepo.FindTableByName(tenantCtx, projectID, datasourceID, table)
@@ -459,6 +461,7 @@ func registerUpdateColumnTool(s *server.MCPServer, deps *ColumnToolDeps) {
 		if err := deps.ColumnMetadataRepo.Upsert(tenantCtx, colMeta); err != nil {
 			return HandleServiceError(err, "update_column_metadata_failed")
 		}
+		refreshTableQuality(tenantCtx, deps, projectID, table)
 
 		// Build response
 		response := updateColumnResponse{
@@ -540,7 +543,7 @@ func registerDeleteColumnMetadataTool(s *server.MCPServer, deps *ColumnToolDeps)
 		// Validate table exists and is selected for MCP access
 		datasourceID, err := deps.ProjectService.GetDefaultDatasourceID(tenantCtx, projectID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get datasource: %w", err)
+			return HandleServiceError(fmt.Errorf("failed to get datasource: %w", err), "get_default_datasource_failed")
 		}
 
 		schemaTable, err := deps.SchemaRepo.FindTableByName(tenantCtx, projectID, datasourceID, table)
@@ -578,6 +581,7 @@ func registerDeleteColumnMetadataTool(s *server.MCPServer, deps *ColumnToolDeps)
 				return HandleServiceError(err, "delete_column_metadata_failed")
 			}
 			deleted = true
+			refreshTableQuality(tenantCtx, deps, projectID, table)
 		}
 
 		// Build response
@@ -596,6 +600,19 @@ func registerDeleteColumnMetadataTool(s *server.MCPServer, deps *ColumnToolDeps)
 	})
 }
 
+// refreshTableQuality rescores table after metadata of one of its columns changed.
+// The score is advisory, so a failure is only logged.
+func refreshTableQuality(ctx context.Context, deps *ColumnToolDeps, projectID uuid.UUID, table string) {
+	if deps.TableQualityService == nil {
+		return
+	}
+	if err := deps.TableQualityService.RefreshTable(ctx, projectID, table); err != nil {
+		deps.Logger.Warn("Failed to refresh table quality score",
+			zap.String("table", table),
+			zap.Error(err))
+	}
+}
+
 // buildColumnMetadataInfo constructs a columnMetadataInfo response from ColumnMetadata.
 // This reads from the typed columns in engine_ontology_column_metadata.
 func buildColumnMetadataInfo(meta *models.ColumnMetadata) *columnMetadataInfo {
@@ -644,11 +661,7 @@ func buildColumnMetadataInfo(meta *models.ColumnMetadata) *columnMetadataInfo {
 	if enumFeatures := meta.GetEnumFeatures(); enumFeatures != nil && len(enumFeatures.Values) > 0 {
 		enumStrings := make([]string, len(enumFeatures.Values))
 		for i, ev := range enumFeatures.Values {
-			if ev.Label != "" {
-				enumStrings[i] = ev.Value + " - " + ev.Label
-			} else {
-				enumStrings[i] = ev.Value
-			}
+			enumStrings[i] = formatEnumValue(ev)
 		}
 		info.EnumValues = enumStrings
 	}
@@ -145,7 +145,7 @@ func registerGetContextTool(s *server.MCPServer, deps *ContextToolDeps) {
 		result, err = handleContext(tenantCtx, deps, projectID, depth, tables, includeRelationships, glossary, includeOptions)
 
 		if err != nil {
-			return nil, fmt.Errorf("failed to get context at depth '%s': %w", depth, err)
+			return HandleServiceError(fmt.Errorf("failed to get context at depth '%s': %w", depth, err), "get_context_failed")
 		}
 
 		jsonResult, err := json.Marshal(result)
@@ -474,11 +474,7 @@ func buildColumnDetails(
 					// Convert ColumnEnumValue to simple strings for display
 					enumStrings := make([]string, len(enumFeatures.Values))
 					for i, ev := range enumFeatures.Values {
-						if ev.Label != "" {
-							enumStrings[i] = ev.Value + " - " + ev.Label
-						} else {
-							enumStrings[i] = ev.Value
-						}
+						enumStrings[i] = formatEnumValue(ev)
 					}
 					colDetail["enum_values"] = enumStrings
 				}
@@ -620,3 +616,9 @@ func addStatisticsToColumnDetail(colDetail map[string]any, schemaCol *models.Sch
 		colDetail["joinability_reason"] = *schemaCol.JoinabilityReason
 	}
 }
+
+// formatEnumValue renders an enum value as "value - label" for display (see
+// models.ColumnEnumValue.Display).
+func formatEnumValue(ev models.ColumnEnumValue) string {
+	return ev.Display()
+}
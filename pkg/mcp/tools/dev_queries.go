@@ -732,7 +732,7 @@ If datasource_id is not provided, the project's default datasource will be used.
 			// Auto-detect default datasource (same pattern as suggest_approved_query)
 			datasourceID, err = deps.ProjectService.GetDefaultDatasourceID(tenantCtx, projectID)
 			if err != nil {
-				return nil, fmt.Errorf("failed to get default datasource: %w", err)
+				return HandleServiceError(fmt.Errorf("failed to get default datasource: %w", err), "get_default_datasource_failed")
 			}
 		}
 
@@ -531,7 +531,7 @@ func registerQueryTool(s *server.MCPServer, deps *MCPToolDeps) {
 		// Get datasource config and create executor
 		dsType, dsConfig, err := getDefaultDatasourceConfig(tenantCtx, deps, projectID)
 		if err != nil {
-			return nil, err
+			return HandleServiceError(err, "get_default_datasource_failed")
 		}
 
 		// TODO: Extract userID from context and datasourceID from getDefaultDatasourceConfig when step 8 is implemented
@@ -680,7 +680,7 @@ func registerSampleTool(s *server.MCPServer, deps *MCPToolDeps) {
 		// Verify table is selected by admin before allowing access
 		dsID, err := deps.ProjectService.GetDefaultDatasourceID(tenantCtx, projectID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get default datasource: %w", err)
+			return HandleServiceError(fmt.Errorf("failed to get default datasource: %w", err), "get_default_datasource_failed")
 		}
 
 		selectedTables, err := deps.SchemaService.ListTablesByDatasource(tenantCtx, projectID, dsID)
@@ -695,7 +695,7 @@ func registerSampleTool(s *server.MCPServer, deps *MCPToolDeps) {
 		// Get datasource config and create executor
 		dsType, dsConfig, err := getDefaultDatasourceConfig(tenantCtx, deps, projectID)
 		if err != nil {
-			return nil, err
+			return HandleServiceError(err, "get_default_datasource_failed")
 		}
 
 		// TODO: Extract userID from context and datasourceID from getDefaultDatasourceConfig when step 8 is implemented
@@ -786,7 +786,7 @@ func registerExecuteTool(s *server.MCPServer, deps *MCPToolDeps) {
 		// Get datasource config and create executor
 		dsType, dsConfig, err := getDefaultDatasourceConfig(tenantCtx, deps, projectID)
 		if err != nil {
-			return nil, err
+			return HandleServiceError(err, "get_default_datasource_failed")
 		}
 
 		// TODO: Extract userID from context and datasourceID from getDefaultDatasourceConfig when step 8 is implemented
@@ -927,7 +927,7 @@ func registerValidateTool(s *server.MCPServer, deps *MCPToolDeps) {
 		// Get datasource config and create executor
 		dsType, dsConfig, err := getDefaultDatasourceConfig(tenantCtx, deps, projectID)
 		if err != nil {
-			return nil, err
+			return HandleServiceError(err, "get_default_datasource_failed")
 		}
 
 		// TODO: Extract userID from context and datasourceID from getDefaultDatasourceConfig when step 8 is implemented
@@ -1016,7 +1016,7 @@ func registerExplainQueryTool(s *server.MCPServer, deps *MCPToolDeps) {
 		// Get datasource config and create executor
 		dsType, dsConfig, err := getDefaultDatasourceConfig(tenantCtx, deps, projectID)
 		if err != nil {
-			return nil, err
+			return HandleServiceError(err, "get_default_datasource_failed")
 		}
 
 		executor, err := deps.AdapterFactory.NewQueryExecutor(tenantCtx, dsType, dsConfig, projectID, uuid.Nil, "")
@@ -1099,7 +1099,7 @@ func registerRefreshSchemaTool(s *server.MCPServer, deps *MCPToolDeps) {
 		// Get default datasource
 		dsID, err := deps.ProjectService.GetDefaultDatasourceID(tenantCtx, projectID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get default datasource: %w", err)
+			return HandleServiceError(fmt.Errorf("failed to get default datasource: %w", err), "get_default_datasource_failed")
 		}
 		if dsID == uuid.Nil {
 			return NewErrorResult("no_datasource", "no default datasource configured for project"), nil
@@ -1562,7 +1562,7 @@ func registerScanDataChangesTool(s *server.MCPServer, deps *MCPToolDeps) {
 		// Get default datasource
 		dsID, err := deps.ProjectService.GetDefaultDatasourceID(tenantCtx, projectID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get default datasource: %w", err)
+			return HandleServiceError(fmt.Errorf("failed to get default datasource: %w", err), "get_default_datasource_failed")
 		}
 		if dsID == uuid.Nil {
 			return NewErrorResult("no_datasource", "no default datasource configured for project"), nil
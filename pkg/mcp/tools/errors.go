@@ -8,6 +8,8 @@ import (
 
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/apperrors"
 )
 
 // ErrorResponse represents a structured error in tool results.
@@ -279,6 +281,11 @@ func HandleServiceError(err error, code string) (*mcp.CallToolResult, error) {
 		return NewSQLErrorResult(err), nil
 	}
 
+	// An ambiguous default datasource is fixed by marking one datasource as the default
+	if errors.Is(err, apperrors.ErrAmbiguousDefaultDatasource) {
+		return NewErrorResult("ambiguous_default_datasource", err.Error()), nil
+	}
+
 	// Application-level errors (validation, not found, etc.) become JSON responses
 	if IsInputError(err) {
 		return NewErrorResult(code, err.Error()), nil
@@ -306,6 +313,7 @@ var inputErrorPatterns = []string{
 //   - SQL user errors (syntax, constraint, missing table)
 //   - Validation failures
 //   - Resource not found (user provided invalid ID)
+//   - An ambiguous default datasource (several datasources, none marked default)
 //
 // These errors should be logged at DEBUG level, not ERROR level.
 func IsInputError(err error) bool {
@@ -318,6 +326,11 @@ func IsInputError(err error) bool {
 		return true
 	}
 
+	// A project with several datasources and no default needs the user to pick one
+	if errors.Is(err, apperrors.ErrAmbiguousDefaultDatasource) {
+		return true
+	}
+
 	// Check for common input error patterns in the error message
 	errStr := strings.ToLower(err.Error())
 	for _, pattern := range inputErrorPatterns {
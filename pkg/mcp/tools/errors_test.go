@@ -10,6 +10,8 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/apperrors"
 )
 
 // getTextContent extracts the text string from the first text content item
@@ -626,6 +628,11 @@ func TestIsInputError(t *testing.T) {
 			err:      errors.New("missing required parameter"),
 			expected: true,
 		},
+		{
+			name:     "ambiguous default datasource",
+			err:      fmt.Errorf("failed to get default datasource: %w", apperrors.ErrAmbiguousDefaultDatasource),
+			expected: true,
+		},
 		{
 			name:     "server error (connection timeout)",
 			err:      errors.New("connection timeout"),
@@ -645,3 +652,18 @@ func TestIsInputError(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleServiceError_AmbiguousDefaultDatasource(t *testing.T) {
+	err := fmt.Errorf("failed to get default datasource: %w: project has 2 datasources", apperrors.ErrAmbiguousDefaultDatasource)
+
+	result, goErr := HandleServiceError(err, "get_default_datasource_failed")
+
+	require.NoError(t, goErr)
+	require.NotNil(t, result)
+	assert.True(t, result.IsError)
+
+	var resp ErrorResponse
+	require.NoError(t, json.Unmarshal([]byte(getTextContent(result)), &resp))
+	assert.Equal(t, "ambiguous_default_datasource", resp.Code)
+	assert.Contains(t, resp.Message, "ambiguous default datasource")
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/google/uuid"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"go.uber.org/zap"
@@ -18,6 +19,7 @@ import (
 type GlossaryToolDeps struct {
 	BaseMCPToolDeps
 	GlossaryService services.GlossaryService
+	QuestionService services.OntologyQuestionService // Optional; auto-answers questions a saved term resolves
 }
 
 // RegisterGlossaryTools registers glossary-related MCP tools.
@@ -287,6 +289,7 @@ func registerCreateGlossaryTermTool(s *server.MCPServer, deps *GlossaryToolDeps)
 		deps.Logger.Info("Created glossary term via MCP",
 			zap.String("project_id", projectID.String()),
 			zap.String("term", term))
+		answerQuestionsFromGlossaryTerm(tenantCtx, deps, projectID, glossaryTerm)
 
 		// Return the created term
 		response := struct {
@@ -449,7 +452,7 @@ func registerUpdateGlossaryTermTool(s *server.MCPServer, deps *GlossaryToolDeps)
 			if !canModifyGlossaryTerm(existing.Source, models.GlossarySourceMCP) {
 				return NewErrorResult("precedence_blocked",
 					fmt.Sprintf("Cannot modify glossary term: precedence blocked (existing: %s, modifier: %s). "+
-						"Manual terms cannot be overridden by MCP. Use the UI to modify or delete this term.",
+						"Manual and imported terms cannot be overridden by MCP. Use the UI to modify or delete this term.",
 						existing.Source, models.GlossarySourceMCP)), nil
 			}
 
@@ -493,6 +496,8 @@ func registerUpdateGlossaryTermTool(s *server.MCPServer, deps *GlossaryToolDeps)
 				zap.String("term", termName))
 		}
 
+		answerQuestionsFromGlossaryTerm(tenantCtx, deps, projectID, term)
+
 		// Build response
 		response := struct {
 			Term          string                `json:"term"`
@@ -520,7 +525,7 @@ func registerUpdateGlossaryTermTool(s *server.MCPServer, deps *GlossaryToolDeps)
 }
 
 // canModifyGlossaryTerm checks if a source can modify a glossary term based on precedence.
-// Precedence hierarchy: Manual (3) > MCP (2) > Inferred (1)
+// Precedence hierarchy: Manual (4) > Import (3) > MCP (2) > Inferred (1)
 // Returns true if the modification is allowed, false if blocked by higher precedence.
 func canModifyGlossaryTerm(termSource string, modifierSource string) bool {
 	modifierLevel := precedenceLevelGlossary(modifierSource)
@@ -535,6 +540,8 @@ func canModifyGlossaryTerm(termSource string, modifierSource string) bool {
 func precedenceLevelGlossary(source string) int {
 	switch source {
 	case models.GlossarySourceManual:
+		return 4
+	case models.GlossarySourceImport:
 		return 3
 	case models.GlossarySourceMCP:
 		return 2
@@ -635,3 +642,25 @@ func registerDeleteGlossaryTermTool(s *server.MCPServer, deps *GlossaryToolDeps)
 		return mcp.NewToolResultText(string(jsonResult)), nil
 	})
 }
+
+// answerQuestionsFromGlossaryTerm auto-answers pending questions the saved term
+// resolves. Failures are logged; the term itself has already been saved.
+func answerQuestionsFromGlossaryTerm(ctx context.Context, deps *GlossaryToolDeps, projectID uuid.UUID, term *models.BusinessGlossaryTerm) {
+	if deps.QuestionService == nil {
+		return
+	}
+	answered, err := deps.QuestionService.AnswerFromGlossary(ctx, projectID, []*models.BusinessGlossaryTerm{term})
+	if err != nil {
+		deps.Logger.Warn("Failed to answer questions from glossary term",
+			zap.String("project_id", projectID.String()),
+			zap.String("term", term.Term),
+			zap.Error(err))
+		return
+	}
+	if answered > 0 {
+		deps.Logger.Info("Answered pending questions from glossary term",
+			zap.String("project_id", projectID.String()),
+			zap.String("term", term.Term),
+			zap.Int("answered", answered))
+	}
+}
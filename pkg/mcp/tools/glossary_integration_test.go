@@ -129,6 +129,18 @@ func (s *testGlossaryService) UpdateTerm(ctx context.Context, term *models.Busin
 	return s.repo.Update(ctx, term)
 }
 
+func (s *testGlossaryService) UpsertTerm(ctx context.Context, projectID uuid.UUID, term *models.BusinessGlossaryTerm) (bool, error) {
+	existing, err := s.repo.GetByTerm(ctx, projectID, term.Term)
+	if err != nil {
+		return false, err
+	}
+	if existing == nil {
+		return true, s.CreateTerm(ctx, projectID, term)
+	}
+	term.ID = existing.ID
+	return true, s.UpdateTerm(ctx, term)
+}
+
 func (s *testGlossaryService) DeleteTerm(ctx context.Context, termID uuid.UUID) error {
 	return s.repo.Delete(ctx, termID)
 }
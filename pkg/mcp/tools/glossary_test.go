@@ -32,6 +32,10 @@ func (m *mockGlossaryService) UpdateTerm(ctx context.Context, term *models.Busin
 	return nil
 }
 
+func (m *mockGlossaryService) UpsertTerm(ctx context.Context, projectID uuid.UUID, term *models.BusinessGlossaryTerm) (bool, error) {
+	return true, nil
+}
+
 func (m *mockGlossaryService) DeleteTerm(ctx context.Context, termID uuid.UUID) error {
 	return nil
 }
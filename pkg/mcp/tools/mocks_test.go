@@ -301,6 +301,14 @@ func (m *mockSchemaService) RefreshDatasourceSchema(ctx context.Context, project
 	return m.refreshResult, nil
 }
 
+func (m *mockSchemaService) InventoryDatasourceSchema(ctx context.Context, projectID, datasourceID uuid.UUID) (*models.SchemaInventoryResult, error) {
+	return nil, nil
+}
+
+func (m *mockSchemaService) IntrospectTables(ctx context.Context, projectID, datasourceID uuid.UUID, req *models.IntrospectTablesRequest) (*models.RefreshResult, error) {
+	return nil, nil
+}
+
 func (m *mockSchemaService) GetDatasourceSchema(ctx context.Context, projectID, datasourceID uuid.UUID) (*models.DatasourceSchema, error) {
 	return nil, nil
 }
@@ -313,6 +321,10 @@ func (m *mockSchemaService) AddManualRelationship(ctx context.Context, projectID
 	return nil, nil
 }
 
+func (m *mockSchemaService) AddManualRelationshipByColumns(ctx context.Context, projectID uuid.UUID, req *models.AddColumnRelationshipRequest) (*models.SchemaRelationship, error) {
+	return nil, nil
+}
+
 func (m *mockSchemaService) UpdateRelationship(ctx context.Context, projectID, relationshipID uuid.UUID, req *models.UpdateRelationshipRequest) (*models.SchemaRelationship, error) {
 	return nil, nil
 }
@@ -329,6 +341,14 @@ func (m *mockSchemaService) GetRelationshipsResponse(ctx context.Context, projec
 	return m.relationshipsResponse, nil
 }
 
+func (m *mockSchemaService) GetRelationshipStats(ctx context.Context, projectID, datasourceID uuid.UUID) (*models.RelationshipStats, error) {
+	return nil, nil
+}
+
+func (m *mockSchemaService) GetRelationshipMetrics(ctx context.Context, projectID, relationshipID uuid.UUID) (*models.RelationshipMetrics, error) {
+	return nil, nil
+}
+
 func (m *mockSchemaService) UpdateColumnMetadata(ctx context.Context, projectID, columnID uuid.UUID, businessName, description *string) error {
 	return nil
 }
@@ -479,6 +499,9 @@ func (m *mockSchemaRepository) GetEmptyTables(ctx context.Context, projectID, da
 func (m *mockSchemaRepository) GetOrphanTables(ctx context.Context, projectID, datasourceID uuid.UUID) ([]string, error) {
 	return nil, nil
 }
+func (m *mockSchemaRepository) GetRelationshipStats(ctx context.Context, projectID, datasourceID uuid.UUID) (*models.RelationshipStats, error) {
+	return nil, nil
+}
 func (m *mockSchemaRepository) UpsertRelationshipWithMetrics(ctx context.Context, rel *models.SchemaRelationship, metrics *models.DiscoveryMetrics) error {
 	return nil
 }
@@ -113,7 +113,7 @@ func registerUpdateColumnsTool(s *server.MCPServer, deps *ColumnToolDeps) {
 		// Get datasource ID for schema validation
 		datasourceID, err := deps.ProjectService.GetDefaultDatasourceID(tenantCtx, projectID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get datasource: %w", err)
+			return HandleServiceError(fmt.Errorf("failed to get datasource: %w", err), "get_default_datasource_failed")
 		}
 
 		// Phase 1: Validate all updates before applying any
@@ -150,6 +150,7 @@ func registerUpdateColumnsTool(s *server.MCPServer, deps *ColumnToolDeps) {
 		// Phase 2: Apply all updates via ColumnMetadataRepo
 		results := make([]ColumnUpdateResult, len(updates))
 		updatedCount := 0
+		updatedTables := make(map[string]bool)
 
 		for i, update := range updates {
 			results[i] = ColumnUpdateResult{
@@ -175,8 +176,12 @@ func registerUpdateColumnsTool(s *server.MCPServer, deps *ColumnToolDeps) {
 				results[i].Status = "success"
 				results[i].Created = isNew
 				updatedCount++
+				updatedTables[update.Table] = true
 			}
 		}
+		for table := range updatedTables {
+			refreshTableQuality(tenantCtx, deps, projectID, table)
+		}
 
 		response := UpdateColumnsResponse{
 			Updated: updatedCount,
@@ -3,6 +3,7 @@ package tools
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -12,6 +13,7 @@ import (
 	"github.com/mark3labs/mcp-go/server"
 	"go.uber.org/zap"
 
+	"github.com/ekaya-inc/ekaya-engine/pkg/apperrors"
 	"github.com/ekaya-inc/ekaya-engine/pkg/audit"
 	"github.com/ekaya-inc/ekaya-engine/pkg/auth"
 	"github.com/ekaya-inc/ekaya-engine/pkg/database"
@@ -127,7 +129,7 @@ func registerListApprovedQueriesTool(s *server.MCPServer, deps *QueryToolDeps) {
 		// Get default datasource
 		dsID, err := deps.ProjectService.GetDefaultDatasourceID(tenantCtx, projectID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get default datasource: %w", err)
+			return HandleServiceError(fmt.Errorf("failed to get default datasource: %w", err), "get_default_datasource_failed")
 		}
 
 		// Parse and validate optional tags filter
@@ -284,9 +286,7 @@ func registerExecuteApprovedQueryTool(s *server.MCPServer, deps *QueryToolDeps)
 		// Get query metadata before execution
 		query, err := deps.QueryService.Get(tenantCtx, projectID, queryID)
 		if err != nil {
-			// Check if this is a "not found" error
-			errMsg := err.Error()
-			if strings.Contains(errMsg, "not found") || strings.Contains(errMsg, "no rows") {
+			if errors.Is(err, apperrors.ErrNotFound) {
 				return NewErrorResult("QUERY_NOT_FOUND",
 					fmt.Sprintf("query with ID %q not found. Use list_approved_queries to see available queries.", queryID)), nil
 			}
@@ -663,7 +663,7 @@ func registerSuggestApprovedQueryTool(s *server.MCPServer, deps *QueryToolDeps)
 		// Get default datasource
 		dsID, err := deps.ProjectService.GetDefaultDatasourceID(tenantCtx, projectID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get default datasource: %w", err)
+			return HandleServiceError(fmt.Errorf("failed to get default datasource: %w", err), "get_default_datasource_failed")
 		}
 
 		// Parse optional parameters
@@ -868,8 +868,7 @@ func registerSuggestQueryUpdateTool(s *server.MCPServer, deps *QueryToolDeps) {
 		// First, fetch the original query to validate it exists and get its datasource
 		originalQuery, err := deps.QueryService.Get(tenantCtx, projectID, queryID)
 		if err != nil {
-			errMsg := err.Error()
-			if strings.Contains(errMsg, "not found") || strings.Contains(errMsg, "no rows") {
+			if errors.Is(err, apperrors.ErrNotFound) {
 				return NewErrorResult("QUERY_NOT_FOUND",
 					fmt.Sprintf("query with ID %q not found. Use list_approved_queries to see available queries.", queryID)), nil
 			}
@@ -971,8 +970,7 @@ func registerSuggestQueryUpdateTool(s *server.MCPServer, deps *QueryToolDeps) {
 		// Create the suggestion via the service
 		suggestion, err := deps.QueryService.SuggestUpdate(tenantCtx, projectID, updateReq)
 		if err != nil {
-			errMsg := err.Error()
-			if strings.Contains(errMsg, "not found") {
+			if errors.Is(err, apperrors.ErrNotFound) {
 				return NewErrorResult("QUERY_NOT_FOUND",
 					fmt.Sprintf("query with ID %q not found", queryID)), nil
 			}
@@ -1535,7 +1533,7 @@ func registerRecordQueryFeedbackTool(s *server.MCPServer, deps *QueryToolDeps) {
 
 		err = deps.QueryHistoryService.RecordFeedback(tenantCtx, projectID, queryID, userID, feedback, comment)
 		if err != nil {
-			if strings.Contains(err.Error(), "not found") || strings.Contains(err.Error(), "not owned") {
+			if errors.Is(err, apperrors.ErrNotFound) {
 				return NewErrorResult("not_found", "query history entry not found or not accessible"), nil
 			}
 			return HandleServiceError(err, "record_feedback_failed")
@@ -62,7 +62,7 @@ func registerListRelationshipsTool(s *server.MCPServer, deps *RelationshipToolDe
 
 		dsID, err := deps.ProjectService.GetDefaultDatasourceID(tenantCtx, projectID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get default datasource: %w", err)
+			return HandleServiceError(fmt.Errorf("failed to get default datasource: %w", err), "get_default_datasource_failed")
 		}
 
 		tableFilter := trimString(getOptionalString(req, "table"))
@@ -150,7 +150,7 @@ func registerCreateRelationshipTool(s *server.MCPServer, deps *RelationshipToolD
 
 		dsID, err := deps.ProjectService.GetDefaultDatasourceID(tenantCtx, projectID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get default datasource: %w", err)
+			return HandleServiceError(fmt.Errorf("failed to get default datasource: %w", err), "get_default_datasource_failed")
 		}
 
 		reqModel := &models.AddRelationshipRequest{
@@ -58,7 +58,7 @@ func registerGetSchemaContextTool(s *server.MCPServer, deps *SchemaToolDeps) {
 		// Get default datasource
 		dsID, err := deps.ProjectService.GetDefaultDatasourceID(tenantCtx, projectID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get default datasource: %w", err)
+			return HandleServiceError(fmt.Errorf("failed to get default datasource: %w", err), "get_default_datasource_failed")
 		}
 
 		// Get selected-only schema context
@@ -8,6 +8,7 @@ import (
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"go.uber.org/zap"
 
 	"github.com/ekaya-inc/ekaya-engine/pkg/models"
 	"github.com/ekaya-inc/ekaya-engine/pkg/repositories"
@@ -17,9 +18,10 @@ import (
 // TableToolDeps contains dependencies for table metadata tools.
 type TableToolDeps struct {
 	BaseMCPToolDeps
-	SchemaRepo        repositories.SchemaRepository
-	TableMetadataRepo repositories.TableMetadataRepository
-	ProjectService    services.ProjectService
+	SchemaRepo          repositories.SchemaRepository
+	TableMetadataRepo   repositories.TableMetadataRepository
+	ProjectService      services.ProjectService
+	TableQualityService services.TableQualityService // Optional; rescores the table after an edit
 }
 
 // RegisterTableTools registers table metadata MCP tools.
@@ -123,7 +125,7 @@ func registerUpdateTableTool(s *server.MCPServer, deps *TableToolDeps) {
 		// Get datasource ID
 		datasourceID, err := deps.ProjectService.GetDefaultDatasourceID(tenantCtx, projectID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get datasource: %w", err)
+			return HandleServiceError(fmt.Errorf("failed to get datasource: %w", err), "get_default_datasource_failed")
 		}
 
 		// Validate table exists in schema registry
@@ -197,6 +199,14 @@ func registerUpdateTableTool(s *server.MCPServer, deps *TableToolDeps) {
 			return HandleServiceError(err, "update_table_metadata_failed")
 		}
 
+		if deps.TableQualityService != nil {
+			if err := deps.TableQualityService.RefreshTable(tenantCtx, projectID, table); err != nil {
+				deps.Logger.Warn("Failed to refresh table quality score",
+					zap.String("table", table),
+					zap.Error(err))
+			}
+		}
+
 		// Build response
 		isNew := existing == nil
 		response := updateTableResponse{
@@ -280,7 +290,7 @@ func registerDeleteTableMetadataTool(s *server.MCPServer, deps *TableToolDeps) {
 		// Get datasource ID and look up the schema table
 		datasourceID, err := deps.ProjectService.GetDefaultDatasourceID(tenantCtx, projectID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get datasource: %w", err)
+			return HandleServiceError(fmt.Errorf("failed to get datasource: %w", err), "get_default_datasource_failed")
 		}
 
 		// Look up schema table to get its ID
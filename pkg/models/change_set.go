@@ -24,8 +24,9 @@ type ChangeSet struct {
 	DeletedColumns  []SchemaColumn // deleted_at > built_at
 
 	// Computed helpers
-	AffectedTableIDs map[uuid.UUID]bool // union of all table IDs that need re-processing
-	UserEditedIDs    map[uuid.UUID]bool // column/table metadata IDs to skip (last_edit_source != NULL)
+	AffectedTableIDs map[uuid.UUID]bool   // union of all table IDs that need re-processing
+	TableNames       map[uuid.UUID]string // names of the tables in AffectedTableIDs
+	UserEditedIDs    map[uuid.UUID]bool   // column/table metadata IDs to skip (last_edit_source != NULL)
 }
 
 // IsEmpty returns true if no schema changes were detected.
@@ -124,25 +125,34 @@ func (cs *ChangeSet) ChangedColumnIDs() map[uuid.UUID]bool {
 	return result
 }
 
-// AffectedTableNames returns the names of tables in the affected set.
+// AffectedTableNames returns the names of tables in the affected set: added and
+// modified tables, and the tables owning added or modified columns. It returns nil
+// for a nil ChangeSet (full extraction) and an empty, non-nil slice when nothing
+// changed, so callers that treat nil as "all tables" can tell the two apart.
 // Used by nodes that accept table name filters (e.g., ColumnEnrichment).
 func (cs *ChangeSet) AffectedTableNames() []string {
 	if cs == nil {
 		return nil
 	}
 	seen := make(map[string]bool)
-	var names []string
-	for _, t := range cs.AddedTables {
-		if !seen[t.TableName] {
-			seen[t.TableName] = true
-			names = append(names, t.TableName)
+	names := []string{}
+	add := func(name string) {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
 		}
 	}
+	for _, t := range cs.AddedTables {
+		add(t.TableName)
+	}
 	for _, t := range cs.ModifiedTables {
-		if !seen[t.TableName] {
-			seen[t.TableName] = true
-			names = append(names, t.TableName)
-		}
+		add(t.TableName)
+	}
+	for _, col := range cs.AddedColumns {
+		add(cs.TableNames[col.SchemaTableID])
+	}
+	for _, col := range cs.ModifiedColumns {
+		add(cs.TableNames[col.SchemaTableID])
 	}
 	return names
 }
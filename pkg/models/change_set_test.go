@@ -224,6 +224,28 @@ func TestChangeSet_AffectedTableNames(t *testing.T) {
 	}
 }
 
+func TestChangeSet_AffectedTableNames_ColumnOnlyChange(t *testing.T) {
+	ordersID := uuid.New()
+	usersID := uuid.New()
+	cs := &ChangeSet{
+		AddedColumns:    []SchemaColumn{{SchemaTableID: ordersID, ColumnName: "discount"}},
+		ModifiedColumns: []SchemaColumn{{SchemaTableID: usersID}, {SchemaTableID: ordersID}},
+		TableNames:      map[uuid.UUID]string{ordersID: "orders", usersID: "users"},
+	}
+
+	names := cs.AffectedTableNames()
+	if len(names) != 2 || names[0] != "orders" || names[1] != "users" {
+		t.Errorf("expected the tables owning changed columns [orders users], got %v", names)
+	}
+}
+
+func TestChangeSet_AffectedTableNames_NoChangesIsEmptyNotNil(t *testing.T) {
+	names := (&ChangeSet{}).AffectedTableNames()
+	if names == nil || len(names) != 0 {
+		t.Errorf("empty ChangeSet should return an empty non-nil slice, got %#v", names)
+	}
+}
+
 func TestChangeSet_ToSummary(t *testing.T) {
 	cs := &ChangeSet{
 		AddedTables:     []SchemaTable{{}, {}},
@@ -70,6 +70,10 @@ type ColumnDataProfile struct {
 	NullRate      float64 `json:"null_rate"`   // null_count / row_count (0.0 - 1.0)
 	Cardinality   float64 `json:"cardinality"` // distinct_count / row_count (0.0 - 1.0)
 
+	// DistinctCountEstimated is true when DistinctCount came from planner statistics
+	// rather than an exact count; cardinality checks allow for its error.
+	DistinctCountEstimated bool `json:"distinct_count_estimated,omitempty"`
+
 	// For numeric columns
 	MinValue *float64 `json:"min_value,omitempty"`
 	MaxValue *float64 `json:"max_value,omitempty"`
@@ -213,6 +217,7 @@ type ColumnFeatures struct {
 	EnumFeatures       *EnumFeatures       `json:"enum_features,omitempty"`
 	IdentifierFeatures *IdentifierFeatures `json:"identifier_features,omitempty"`
 	MonetaryFeatures   *MonetaryFeatures   `json:"monetary_features,omitempty"`
+	DatePartFeatures   *DatePartFeatures   `json:"date_part_features,omitempty"`
 
 	// Flags for follow-up phases (set during Phase 2)
 	NeedsEnumAnalysis     bool `json:"needs_enum_analysis"`      // Enqueue to Phase 3
@@ -239,6 +244,17 @@ const (
 	PurposeJSON       = "json"
 )
 
+// ValidPurposes contains all valid column purpose values.
+var ValidPurposes = []string{
+	PurposeIdentifier,
+	PurposeTimestamp,
+	PurposeFlag,
+	PurposeMeasure,
+	PurposeEnum,
+	PurposeText,
+	PurposeJSON,
+}
+
 // Role constants for column classification.
 const (
 	RolePrimaryKey = "primary_key"
@@ -335,6 +351,11 @@ type EnumFeatures struct {
 
 	// StateDescription describes the state machine workflow (if applicable).
 	StateDescription string `json:"state_description,omitempty"`
+
+	// ActivityTimestampColumn is the timestamp column used to count each value's recent
+	// rows, and ActiveSince the start of that window. Empty when activity was not measured.
+	ActivityTimestampColumn string     `json:"activity_timestamp_column,omitempty"`
+	ActiveSince             *time.Time `json:"active_since,omitempty"`
 }
 
 // ColumnEnumValue represents a single enum value with its label and category
@@ -356,6 +377,27 @@ type ColumnEnumValue struct {
 
 	// Percentage is the percentage of rows with this value (0.0 - 100.0).
 	Percentage float64 `json:"percentage"`
+
+	// RecentCount is the number of rows with this value written since
+	// EnumFeatures.ActiveSince.
+	RecentCount int64 `json:"recent_count,omitempty"`
+
+	// IsStale is true when activity was measured and no recent row has this value,
+	// meaning it is likely a legacy value the application no longer writes.
+	IsStale bool `json:"is_stale,omitempty"`
+}
+
+// Display renders the value as "value - label" for prompts and agents. Values no
+// recent row uses are marked stale so consumers don't filter on legacy states.
+func (v ColumnEnumValue) Display() string {
+	s := v.Value
+	if v.Label != "" {
+		s += " - " + v.Label
+	}
+	if v.IsStale {
+		s += " (stale)"
+	}
+	return s
 }
 
 // Enum value category constants (for state machines).
@@ -444,6 +486,37 @@ const (
 	CurrencyUnitBasisPoints = "basis_points"
 )
 
+// ============================================================================
+// Date Part Features
+// ============================================================================
+
+// SemanticTypeDatePart marks integer columns holding one component of a date.
+const SemanticTypeDatePart = "date_part"
+
+// DatePartFeatures holds classification results for denormalized date-part columns
+// such as order_year or order_month, which are derived from a timestamp.
+type DatePartFeatures struct {
+	// Part is the date component the column holds.
+	// Values: "year", "quarter", "month", "week", "day", "day_of_week", "day_of_year", "hour"
+	Part string `json:"part"`
+
+	// SourceColumn is the timestamp column in the same table the part was derived
+	// from. Empty if no matching timestamp column was found.
+	SourceColumn string `json:"source_column,omitempty"`
+}
+
+// Date part constants.
+const (
+	DatePartYear      = "year"
+	DatePartQuarter   = "quarter"
+	DatePartMonth     = "month"
+	DatePartWeek      = "week"
+	DatePartDay       = "day"
+	DatePartDayOfWeek = "day_of_week"
+	DatePartDayOfYear = "day_of_year"
+	DatePartHour      = "hour"
+)
+
 // ============================================================================
 // Feature Extraction Progress
 // ============================================================================
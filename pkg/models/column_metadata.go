@@ -53,13 +53,14 @@ type ColumnMetadata struct {
 }
 
 // ColumnMetadataFeatures holds type-specific features as JSONB.
-// This structure supports timestamp, boolean, enum, identifier, and monetary features.
+// This structure supports timestamp, boolean, enum, identifier, monetary, and date-part features.
 type ColumnMetadataFeatures struct {
 	TimestampFeatures  *TimestampFeatures  `json:"timestamp_features,omitempty"`
 	BooleanFeatures    *BooleanFeatures    `json:"boolean_features,omitempty"`
 	EnumFeatures       *EnumFeatures       `json:"enum_features,omitempty"`
 	IdentifierFeatures *IdentifierFeatures `json:"identifier_features,omitempty"`
 	MonetaryFeatures   *MonetaryFeatures   `json:"monetary_features,omitempty"`
+	DatePartFeatures   *DatePartFeatures   `json:"date_part_features,omitempty"`
 
 	// Cross-cutting features (not tied to a specific classification path)
 	Synonyms []string `json:"synonyms,omitempty"` // Alternative names for this column (e.g., "revenue", "sales")
@@ -116,6 +117,11 @@ func (m *ColumnMetadata) GetMonetaryFeatures() *MonetaryFeatures {
 	return m.Features.MonetaryFeatures
 }
 
+// GetDatePartFeatures returns date-part features, or nil if not available.
+func (m *ColumnMetadata) GetDatePartFeatures() *DatePartFeatures {
+	return m.Features.DatePartFeatures
+}
+
 // SetFeatures populates ColumnMetadata fields from a ColumnFeatures struct.
 // This is used by the extraction pipeline to convert analysis results into
 // the format stored in engine_ontology_column_metadata.
@@ -151,6 +157,7 @@ func (m *ColumnMetadata) SetFeatures(features *ColumnFeatures) {
 	m.Features.EnumFeatures = features.EnumFeatures
 	m.Features.IdentifierFeatures = features.IdentifierFeatures
 	m.Features.MonetaryFeatures = features.MonetaryFeatures
+	m.Features.DatePartFeatures = features.DatePartFeatures
 
 	// Copy processing flags
 	m.NeedsEnumAnalysis = features.NeedsEnumAnalysis
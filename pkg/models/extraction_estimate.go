@@ -0,0 +1,64 @@
+package models
+
+// ExtractionEstimate is a dry-run projection of the LLM usage of extracting a
+// project's selected tables, broken down by extraction phase. Nothing is sent to the
+// LLM to produce it. The totals cover only the phases that could be estimated;
+// Complete reports whether those are all the phases that call the LLM.
+type ExtractionEstimate struct {
+	TableCount int                       `json:"table_count"`
+	Phases     []ExtractionPhaseEstimate `json:"phases"`
+	Complete   bool                      `json:"complete"`
+
+	InputTokens      int `json:"input_tokens"`
+	OutputTokens     int `json:"output_tokens"`
+	OutputTokensLow  int `json:"output_tokens_low"`
+	OutputTokensHigh int `json:"output_tokens_high"`
+
+	// Model is the project's configured LLM model. Cost is nil when the model
+	// has no entry in the pricing table.
+	Model string             `json:"model,omitempty"`
+	Cost  *ExtractionCostUSD `json:"cost,omitempty"`
+}
+
+// ExtractionPhaseEstimate is the projected LLM usage of one extraction phase (DAG
+// node). Token counts and cost are only set when Status is "estimated".
+type ExtractionPhaseEstimate struct {
+	Phase  DAGNodeName `json:"phase"`
+	Status string      `json:"status"`
+	// Reason says why a phase that calls the LLM could not be estimated.
+	Reason string `json:"reason,omitempty"`
+
+	PromptCount int `json:"prompt_count"`
+
+	// InputTokens is estimated from the prompts the phase would send.
+	InputTokens int `json:"input_tokens"`
+
+	// OutputTokens is projected from the completion/prompt token ratio of the phase's
+	// past calls, or a default ratio when there is too little history (OutputBasis "default").
+	OutputTokens     int    `json:"output_tokens"`
+	OutputTokensLow  int    `json:"output_tokens_low"`
+	OutputTokensHigh int    `json:"output_tokens_high"`
+	OutputBasis      string `json:"output_basis,omitempty"`
+	HistorySamples   int    `json:"history_samples"`
+
+	Cost *ExtractionCostUSD `json:"cost,omitempty"`
+}
+
+// ExtractionCostUSD is a projected cost range in US dollars.
+type ExtractionCostUSD struct {
+	Low  float64 `json:"low"`
+	High float64 `json:"high"`
+}
+
+// Phase statuses for ExtractionPhaseEstimate.Status.
+const (
+	ExtractionPhaseEstimated    = "estimated"
+	ExtractionPhaseNotEstimated = "not_estimated"
+	ExtractionPhaseNoLLMCalls   = "no_llm_calls"
+)
+
+// Output projection bases for ExtractionPhaseEstimate.OutputBasis.
+const (
+	ExtractionOutputBasisHistory = "history"
+	ExtractionOutputBasisDefault = "default"
+)
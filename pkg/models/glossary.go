@@ -21,6 +21,10 @@ const (
 	GlossarySourceMCP      = "mcp"      // Deprecated: use ProvenanceMCP
 )
 
+// GlossarySourceImport marks glossary terms loaded from an authoritative import. Only
+// glossary terms accept it, so ProvenanceSource.IsValid does not.
+const GlossarySourceImport = "import"
+
 // Enrichment status values for glossary terms
 const (
 	GlossaryEnrichmentPending = "pending" // Term discovered, awaiting SQL enrichment
@@ -44,6 +48,9 @@ type BusinessGlossaryTerm struct {
 	NeedsReview      bool           `json:"needs_review,omitempty"`      // Flagged for domain expert review
 	ReviewReason     string         `json:"review_reason,omitempty"`     // Why the term needs review (e.g., "Formula may not match term semantics")
 
+	// Definitions from lower-precedence sources that did not replace this one (one per source)
+	Alternates []GlossaryAlternateDefinition `json:"alternates,omitempty"`
+
 	// Provenance: source tracking (how it was created/modified)
 	Source         string  `json:"source"`                     // 'inferred', 'mcp', 'manual'
 	LastEditSource *string `json:"last_edit_source,omitempty"` // How last modified (nil if never edited)
@@ -55,3 +62,12 @@ type BusinessGlossaryTerm struct {
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
+
+// GlossaryAlternateDefinition is a definition of a term from a source that lost to a
+// higher-precedence one, kept so it can still be reviewed or promoted.
+type GlossaryAlternateDefinition struct {
+	Source      string    `json:"source"`
+	Definition  string    `json:"definition"`
+	DefiningSQL string    `json:"defining_sql,omitempty"`
+	RecordedAt  time.Time `json:"recorded_at"`
+}
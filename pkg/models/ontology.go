@@ -2,6 +2,7 @@ package models
 
 import (
 	"encoding/json"
+	"strings"
 
 	"github.com/ekaya-inc/ekaya-engine/pkg/jsonutil"
 )
@@ -28,6 +29,139 @@ type RelationshipEdge struct {
 	Cardinality string `json:"cardinality,omitempty"`
 }
 
+// Canonical cardinalities for RelationshipEdge.Cardinality. The domain graph is
+// undirected in meaning, so many-to-one edges are labeled one_to_many.
+const (
+	GraphCardinalityOneToOne   = "one_to_one"
+	GraphCardinalityOneToMany  = "one_to_many"
+	GraphCardinalityManyToMany = "many_to_many"
+)
+
+// graphCardinalityAliases maps cardinality spellings, lowercased with spaces,
+// hyphens and underscores removed, to their canonical value.
+var graphCardinalityAliases = map[string]string{
+	"1:1":      GraphCardinalityOneToOne,
+	"1to1":     GraphCardinalityOneToOne,
+	"one:one":  GraphCardinalityOneToOne,
+	"onetoone": GraphCardinalityOneToOne,
+
+	"1:n":       GraphCardinalityOneToMany,
+	"1:m":       GraphCardinalityOneToMany,
+	"1:*":       GraphCardinalityOneToMany,
+	"1:many":    GraphCardinalityOneToMany,
+	"1ton":      GraphCardinalityOneToMany,
+	"1tomany":   GraphCardinalityOneToMany,
+	"one:many":  GraphCardinalityOneToMany,
+	"onetomany": GraphCardinalityOneToMany,
+	"n:1":       GraphCardinalityOneToMany,
+	"m:1":       GraphCardinalityOneToMany,
+	"*:1":       GraphCardinalityOneToMany,
+	"many:1":    GraphCardinalityOneToMany,
+	"many:one":  GraphCardinalityOneToMany,
+	"manytoone": GraphCardinalityOneToMany,
+	"manyto1":   GraphCardinalityOneToMany,
+	"many":      GraphCardinalityOneToMany,
+	"hasmany":   GraphCardinalityOneToMany,
+
+	"n:m":        GraphCardinalityManyToMany,
+	"m:n":        GraphCardinalityManyToMany,
+	"n:n":        GraphCardinalityManyToMany,
+	"m:m":        GraphCardinalityManyToMany,
+	"*:*":        GraphCardinalityManyToMany,
+	"many:many":  GraphCardinalityManyToMany,
+	"manytomany": GraphCardinalityManyToMany,
+}
+
+// NormalizeGraphCardinality maps a free-form cardinality ("1:N", "one-to-many",
+// "Many to Many") to its canonical value. Returns false when the string has no
+// recognizable meaning.
+func NormalizeGraphCardinality(cardinality string) (string, bool) {
+	key := strings.ToLower(cardinality)
+	key = strings.NewReplacer(" ", "", "-", "", "_", "").Replace(key)
+	canonical, ok := graphCardinalityAliases[key]
+	return canonical, ok
+}
+
+// NormalizeCardinalities rewrites each edge's cardinality to its canonical value
+// and returns the edges whose cardinality could not be mapped. Those are left
+// unchanged; edges without a cardinality are skipped.
+func (s *DomainSummary) NormalizeCardinalities() []RelationshipEdge {
+	var unmappable []RelationshipEdge
+	for i := range s.RelationshipGraph {
+		edge := &s.RelationshipGraph[i]
+		if edge.Cardinality == "" {
+			continue
+		}
+		if canonical, ok := NormalizeGraphCardinality(edge.Cardinality); ok {
+			edge.Cardinality = canonical
+		} else {
+			unmappable = append(unmappable, *edge)
+		}
+	}
+	return unmappable
+}
+
+// CollapseBridgeNodes replaces each bridge table node in the graph with a
+// many-to-many edge between the two tables it links, so the graph shows the
+// relationship rather than the table implementing it. bridges maps a bridge table
+// name to its linked tables; nodes match case-insensitively. An existing edge
+// between the linked tables is kept instead of adding another. Returns the bridge
+// nodes removed from the graph.
+func (s *DomainSummary) CollapseBridgeNodes(bridges map[string][]string) []string {
+	if len(bridges) == 0 || len(s.RelationshipGraph) == 0 {
+		return nil
+	}
+	linkedByNode := make(map[string][]string, len(bridges))
+	for bridge, linked := range bridges {
+		linkedByNode[strings.ToLower(bridge)] = linked
+	}
+
+	edges := s.RelationshipGraph[:0:0]
+	var removed []string
+	seen := make(map[string]bool)
+	for _, edge := range s.RelationshipGraph {
+		bridgeNode := ""
+		for _, node := range []string{edge.From, edge.To} {
+			if _, ok := linkedByNode[strings.ToLower(node)]; ok {
+				bridgeNode = node
+			}
+		}
+		if bridgeNode == "" {
+			edges = append(edges, edge)
+			continue
+		}
+		if key := strings.ToLower(bridgeNode); !seen[key] {
+			seen[key] = true
+			removed = append(removed, bridgeNode)
+		}
+	}
+
+	for _, node := range removed {
+		linked := linkedByNode[strings.ToLower(node)]
+		if len(linked) != 2 || hasGraphEdge(edges, linked[0], linked[1]) {
+			continue
+		}
+		edges = append(edges, RelationshipEdge{
+			From:        linked[0],
+			To:          linked[1],
+			Cardinality: GraphCardinalityManyToMany,
+		})
+	}
+	s.RelationshipGraph = edges
+	return removed
+}
+
+// hasGraphEdge reports whether edges connect a and b in either direction.
+func hasGraphEdge(edges []RelationshipEdge, a, b string) bool {
+	for _, edge := range edges {
+		if strings.EqualFold(edge.From, a) && strings.EqualFold(edge.To, b) ||
+			strings.EqualFold(edge.From, b) && strings.EqualFold(edge.To, a) {
+			return true
+		}
+	}
+	return false
+}
+
 // ProjectConventions captures database-wide patterns that affect all queries.
 // Only patterns appearing in >50% of tables are reported as conventions.
 type ProjectConventions struct {
@@ -73,6 +207,9 @@ type EnumValue struct {
 	IsLikelyInitialState  *bool `json:"is_likely_initial_state,omitempty"`  // High count, low completion rate
 	IsLikelyTerminalState *bool `json:"is_likely_terminal_state,omitempty"` // High completion rate (~100%)
 	IsLikelyErrorState    *bool `json:"is_likely_error_state,omitempty"`    // Low count relative to others
+
+	// Activity (populated when recent rows were counted against an updated timestamp)
+	IsStale *bool `json:"is_stale,omitempty"` // No recent rows use this value; likely legacy
 }
 
 // UnmarshalJSON handles LLM responses that may return enum values in different formats:
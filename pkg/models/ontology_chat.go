@@ -181,6 +181,11 @@ const (
 	FactTypeConvention   = "convention"
 	FactTypeEnumeration  = "enumeration"
 	FactTypeRelationship = "relationship"
+
+	// FactTypeEntityHint is a hint from the user's project description about what
+	// specific tables represent. Context holds the comma-separated table names it
+	// describes; the hint is injected only into those tables' analysis prompts.
+	FactTypeEntityHint = "entity_hint"
 )
 
 // ValidFactTypes contains all valid fact type values.
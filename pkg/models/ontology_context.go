@@ -37,12 +37,14 @@ type TableSummary struct {
 	ColumnCount   int                 `json:"column_count"`
 	Synonyms      []string            `json:"synonyms,omitempty"`
 	Columns       []ColumnOverview    `json:"columns"`
+	Measures      []string            `json:"measures,omitempty"` // Columns safe to SUM/AVG
 	Relationships []TableRelationship `json:"relationships,omitempty"`
 
 	// Table metadata (from engine_ontology_table_metadata)
 	UsageNotes           string `json:"usage_notes,omitempty"`
 	IsEphemeral          bool   `json:"is_ephemeral,omitempty"`
 	PreferredAlternative string `json:"preferred_alternative,omitempty"`
+	QualityScore         *int   `json:"quality_score,omitempty"` // 0-100; lowest scores are the best review candidates
 }
 
 // ColumnOverview provides basic column information for table summary.
@@ -54,8 +56,17 @@ type ColumnOverview struct {
 	HasEnumValues  bool   `json:"has_enum_values"`
 	HasDescription bool   `json:"has_description"`
 	FKAssociation  string `json:"fk_association,omitempty"` // e.g., host, visitor, payer, payee
+	NumericClass   string `json:"numeric_class,omitempty"`  // measure, identifier or dimension; numeric columns only
 }
 
+// Numeric column classes for ColumnOverview.NumericClass. Only measures are meaningful
+// to aggregate; summing an identifier or a dimension such as a year produces nonsense.
+const (
+	NumericClassMeasure    = "measure"
+	NumericClassIdentifier = "identifier"
+	NumericClassDimension  = "dimension"
+)
+
 // TableRelationship represents a relationship at table level.
 type TableRelationship struct {
 	Column      string `json:"column"`
@@ -96,3 +107,19 @@ type ColumnDetailInfo struct {
 	IsForeignKey  bool        `json:"is_foreign_key"`
 	ForeignTable  string      `json:"foreign_table,omitempty"`
 }
+
+// OntologyContextDocument is the ontology rendered as one plain-text document to paste
+// into a text-to-SQL prompt. Content is trimmed to TokenBudget: business-critical
+// tables and trusted joins are kept first, and the counts record what was left out.
+type OntologyContextDocument struct {
+	Content         string `json:"content"`
+	EstimatedTokens int    `json:"estimated_tokens"`
+	TokenBudget     int    `json:"token_budget"`
+
+	TablesIncluded        int `json:"tables_included"`
+	TablesOmitted         int `json:"tables_omitted"`
+	JoinsIncluded         int `json:"joins_included"`
+	JoinsOmitted          int `json:"joins_omitted"`
+	GlossaryTermsIncluded int `json:"glossary_terms_included"`
+	GlossaryTermsOmitted  int `json:"glossary_terms_omitted"`
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -63,6 +64,11 @@ const (
 	DAGNodeStatusCompleted DAGNodeStatus = "completed"
 	DAGNodeStatusFailed    DAGNodeStatus = "failed"
 	DAGNodeStatusSkipped   DAGNodeStatus = "skipped"
+	DAGNodeStatusCancelled DAGNodeStatus = "cancelled" // Was running when the DAG was cancelled
+
+	// DAGNodeStatusBudgetExceeded marks the node the DAG stopped at when its token
+	// budget ran out; the DAG can be resumed from it once the budget is raised.
+	DAGNodeStatusBudgetExceeded DAGNodeStatus = "budget_exceeded"
 )
 
 // ValidDAGNodeStatuses contains all valid node status values.
@@ -72,6 +78,8 @@ var ValidDAGNodeStatuses = []DAGNodeStatus{
 	DAGNodeStatusCompleted,
 	DAGNodeStatusFailed,
 	DAGNodeStatusSkipped,
+	DAGNodeStatusCancelled,
+	DAGNodeStatusBudgetExceeded,
 }
 
 // IsValidDAGNodeStatus checks if the given status is valid.
@@ -86,7 +94,8 @@ func IsValidDAGNodeStatus(s DAGNodeStatus) bool {
 
 // IsTerminal returns true if the node status is terminal.
 func (s DAGNodeStatus) IsTerminal() bool {
-	return s == DAGNodeStatusCompleted || s == DAGNodeStatusFailed || s == DAGNodeStatusSkipped
+	return s == DAGNodeStatusCompleted || s == DAGNodeStatusFailed || s == DAGNodeStatusSkipped ||
+		s == DAGNodeStatusCancelled || s == DAGNodeStatusBudgetExceeded
 }
 
 // ============================================================================
@@ -139,6 +148,8 @@ type DAGNodeProgress struct {
 	Current int    `json:"current"`
 	Total   int    `json:"total"`
 	Message string `json:"message,omitempty"`
+	// Summary holds node-specific result counts, recorded when the node finishes.
+	Summary json.RawMessage `json:"summary,omitempty"`
 }
 
 // Percentage returns the completion percentage (0-100).
@@ -174,6 +185,9 @@ type OntologyDAG struct {
 	IsIncremental bool           `json:"is_incremental"`
 	ChangeSummary *ChangeSummary `json:"change_summary,omitempty"`
 
+	// LLM tokens spent across all runs of this DAG, including those before a resume
+	TokensSpent int `json:"tokens_spent"`
+
 	// Timing
 	StartedAt   *time.Time `json:"started_at,omitempty"`
 	CompletedAt *time.Time `json:"completed_at,omitempty"`
@@ -0,0 +1,30 @@
+package models
+
+// OntologyDiff is the structured difference between two ontology export bundles: a
+// saved baseline ("from") and a later state ("to"). Entities are tables, keyed as
+// "schema.table"; relationships are keyed as "schema.table.column -> schema.table.column".
+type OntologyDiff struct {
+	AddedEntities        []string                     `json:"added_entities"`
+	RemovedEntities      []string                     `json:"removed_entities"`
+	ChangedEntities      []OntologyEntityChange       `json:"changed_entities"`
+	AddedDomains         []string                     `json:"added_domains"`
+	RemovedDomains       []string                     `json:"removed_domains"`
+	AddedRelationships   []string                     `json:"added_relationships"`
+	RemovedRelationships []string                     `json:"removed_relationships"`
+	QuestionCounts       map[QuestionStatus]CountDiff `json:"question_counts"`
+}
+
+// OntologyEntityChange is one changed field of an entity present in both bundles.
+type OntologyEntityChange struct {
+	Entity string `json:"entity"`
+	Field  string `json:"field"` // description, usage_notes or table_type
+	From   string `json:"from"`
+	To     string `json:"to"`
+}
+
+// CountDiff is a count in both bundles and the change between them.
+type CountDiff struct {
+	From  int `json:"from"`
+	To    int `json:"to"`
+	Delta int `json:"delta"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OntologyEntity is a selected table with the description extraction or a user gave
+// it, and the provenance of that description. A table without metadata has no
+// entity yet.
+type OntologyEntity struct {
+	SchemaTableID  uuid.UUID  `json:"schema_table_id"`
+	SchemaName     string     `json:"schema_name"`
+	TableName      string     `json:"table_name"`
+	TableType      *string    `json:"table_type,omitempty"`
+	IsBridge       bool       `json:"is_bridge,omitempty"`     // Pure join table implementing a many-to-many relationship
+	LinkedTables   []string   `json:"linked_tables,omitempty"` // For a bridge: the two tables it relates
+	Description    *string    `json:"description,omitempty"`
+	Source         string     `json:"source"`                     // 'inferred', 'mcp', 'manual'
+	LastEditSource *string    `json:"last_edit_source,omitempty"` // How last modified (nil if never edited)
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      *time.Time `json:"updated_at,omitempty"`
+
+	Quality *TableQualityFeatures `json:"quality,omitempty"` // Nil until the table has been scored
+}
+
+// OntologyEntityPurgeResult reports the entities a purge by source removed, or would
+// remove on a dry run. Entities created by the source but since edited through another
+// source are kept so the edit is not lost, and counted in KeptEdited.
+type OntologyEntityPurgeResult struct {
+	Source     ProvenanceSource `json:"source"`
+	DryRun     bool             `json:"dry_run"`
+	Count      int              `json:"count"`
+	Tables     []string         `json:"tables"`
+	KeptEdited int              `json:"kept_edited"`
+}
@@ -66,6 +66,7 @@ type OntologyExportColumn struct {
 	MinLength         *int64   `json:"min_length,omitempty"`
 	MaxLength         *int64   `json:"max_length,omitempty"`
 	EnumValues        []string `json:"enum_values,omitempty"`
+	CheckConstraints  []string `json:"check_constraints,omitempty"`
 	RowCount          *int64   `json:"row_count,omitempty"`
 	NonNullCount      *int64   `json:"non_null_count,omitempty"`
 	IsJoinable        *bool    `json:"is_joinable,omitempty"`
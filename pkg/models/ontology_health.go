@@ -0,0 +1,142 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OntologyHealthReport is the result of the deterministic integrity checks run over a
+// datasource's stored ontology. Healthy is true when no check found a problem.
+type OntologyHealthReport struct {
+	Healthy              bool `json:"healthy"`
+	RelationshipsChecked int  `json:"relationships_checked"`
+
+	// IncompatibleRelationships join columns whose types cannot be compared,
+	// so SQL generated over them would fail.
+	IncompatibleRelationships []RelationshipTypeMismatch `json:"incompatible_relationships"`
+
+	// UnknownMethodRelationships have no inference method, or one the engine does not
+	// know. Discovery deduplicates by method precedence and provenance is audited by
+	// method, so both silently go wrong for these relationships.
+	UnknownMethodRelationships []UnknownMethodRelationship `json:"unknown_method_relationships"`
+
+	// KeylessTargetRelationships point at tables without a primary key. The SQL still
+	// runs, but the target rows may not be unique, so the relationship cannot be
+	// validated reliably. These are warnings and do not affect Healthy.
+	KeylessTargetRelationships []KeylessTargetRelationship `json:"keyless_target_relationships"`
+
+	// NonUniqueTargetRelationships point at a column that is neither a primary key nor
+	// known to be unique, so joining through them can fan out rows.
+	NonUniqueTargetRelationships []NonUniqueTargetRelationship `json:"non_unique_target_relationships"`
+
+	// UnmappedGraphNodes are nodes of the project's domain relationship graph that do
+	// not name a selected table of the datasource, so consumers cannot resolve them.
+	UnmappedGraphNodes []UnmappedGraphNode `json:"unmapped_graph_nodes"`
+
+	// RelationshipCycles are groups of tables whose approved relationships lead from
+	// each table back to itself (orders -> customers -> accounts -> orders). Cycles
+	// make join paths ambiguous and often point at a misdirected relationship, but some
+	// schemas have them legitimately, so they are warnings and do not affect Healthy.
+	RelationshipCycles []RelationshipCycle `json:"relationship_cycles"`
+
+	// SelfReferencingRelationships point from a table to itself, such as a parent_id
+	// hierarchy. They are expected and listed only for completeness.
+	SelfReferencingRelationships []SelfReferencingRelationship `json:"self_referencing_relationships"`
+
+	// StatsFreshness reports how old the gathered column stats are. Stale stats are a
+	// warning that a re-sync is overdue and do not affect Healthy.
+	StatsFreshness StatsFreshness `json:"stats_freshness"`
+}
+
+// StatsFreshness is the age of the column stats of a datasource's selected columns.
+// OldestStatsAt and NewestStatsAt are nil when no stats have been gathered.
+type StatsFreshness struct {
+	ColumnsWithStats    int        `json:"columns_with_stats"`
+	ColumnsWithoutStats int        `json:"columns_without_stats"`
+	OldestStatsAt       *time.Time `json:"oldest_stats_at,omitempty"`
+	NewestStatsAt       *time.Time `json:"newest_stats_at,omitempty"`
+	OldestStatsAgeHours float64    `json:"oldest_stats_age_hours"`
+	NewestStatsAgeHours float64    `json:"newest_stats_age_hours"`
+
+	// StaleAfterDays is the configured window; 0 disables the age check.
+	StaleAfterDays int `json:"stale_after_days"`
+	// StaleColumns counts columns whose stats are older than the window.
+	StaleColumns int `json:"stale_columns"`
+	// SchemaChanged is set when the schema no longer matches the fingerprint recorded
+	// by the last extraction, so stats and descriptions may describe an older schema.
+	SchemaChanged bool `json:"schema_changed"`
+
+	// Stale is set when stats predate the window or the schema changed since.
+	Stale   bool     `json:"stale"`
+	Reasons []string `json:"reasons"`
+}
+
+// RelationshipCycle is a group of tables connected in a cycle by approved
+// relationships. Tables is sorted; Path is one cycle through them that starts and
+// ends at Tables[0]. RelationshipIDs are the relationships among the tables.
+type RelationshipCycle struct {
+	Tables          []string    `json:"tables"`
+	Path            []string    `json:"path"`
+	RelationshipIDs []uuid.UUID `json:"relationship_ids"`
+}
+
+// SelfReferencingRelationship is a relationship whose source and target table are the same.
+type SelfReferencingRelationship struct {
+	RelationshipID uuid.UUID `json:"relationship_id"`
+	Table          string    `json:"table"`
+	SourceColumn   string    `json:"source_column"`
+	TargetColumn   string    `json:"target_column"`
+}
+
+// UnmappedGraphNode is a domain graph node with no matching table. SuggestedTable is
+// the table the node fuzzy-matches (a case, plural or spelling difference), if any.
+type UnmappedGraphNode struct {
+	Node           string `json:"node"`
+	SuggestedTable string `json:"suggested_table,omitempty"`
+}
+
+// NonUniqueTargetRelationship is a stored relationship whose target column is not a
+// primary key and whose stats do not show it to be unique. DistinctCount and RowCount
+// are nil when the stats have not been collected.
+type NonUniqueTargetRelationship struct {
+	RelationshipID uuid.UUID `json:"relationship_id"`
+	SourceTable    string    `json:"source_table"`
+	SourceColumn   string    `json:"source_column"`
+	TargetTable    string    `json:"target_table"`
+	TargetColumn   string    `json:"target_column"`
+	DistinctCount  *int64    `json:"distinct_count,omitempty"`
+	RowCount       *int64    `json:"row_count,omitempty"`
+}
+
+// UnknownMethodRelationship is a stored relationship without a known inference method.
+// InferenceMethod is empty when none is recorded.
+type UnknownMethodRelationship struct {
+	RelationshipID  uuid.UUID `json:"relationship_id"`
+	SourceTable     string    `json:"source_table"`
+	SourceColumn    string    `json:"source_column"`
+	TargetTable     string    `json:"target_table"`
+	TargetColumn    string    `json:"target_column"`
+	InferenceMethod string    `json:"inference_method"`
+}
+
+// KeylessTargetRelationship is a stored relationship whose target table has no primary key.
+type KeylessTargetRelationship struct {
+	RelationshipID uuid.UUID `json:"relationship_id"`
+	SourceTable    string    `json:"source_table"`
+	SourceColumn   string    `json:"source_column"`
+	TargetTable    string    `json:"target_table"`
+	TargetColumn   string    `json:"target_column"`
+}
+
+// RelationshipTypeMismatch is a stored relationship whose source and target column
+// types are not compatible (see AreTypesCompatibleForFK).
+type RelationshipTypeMismatch struct {
+	RelationshipID uuid.UUID `json:"relationship_id"`
+	SourceTable    string    `json:"source_table"`
+	SourceColumn   string    `json:"source_column"`
+	SourceType     string    `json:"source_type"`
+	TargetTable    string    `json:"target_table"`
+	TargetColumn   string    `json:"target_column"`
+	TargetType     string    `json:"target_type"`
+}
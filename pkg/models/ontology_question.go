@@ -34,6 +34,14 @@ var ValidQuestionStatuses = []QuestionStatus{
 	QuestionStatusDeleted,
 }
 
+// QuestionStatusReasonTrimmed is the status reason for questions dismissed because
+// their table already had the maximum number of questions after extraction.
+const QuestionStatusReasonTrimmed = "trimmed: table exceeded the per-table question limit"
+
+// QuestionStatusReasonOrphaned is the status reason for questions cleaned up because the
+// table they were asked about was deselected or removed from the schema.
+const QuestionStatusReasonOrphaned = "orphaned: source table is no longer selected"
+
 // IsValidQuestionStatus checks if the given status is valid.
 func IsValidQuestionStatus(s QuestionStatus) bool {
 	for _, v := range ValidQuestionStatuses {
@@ -106,6 +114,8 @@ type OntologyQuestion struct {
 	Category         string           `json:"category,omitempty"`
 	Reasoning        string           `json:"reasoning,omitempty"`
 	Affects          *QuestionAffects `json:"affects,omitempty"`
+	SourceEntityType string           `json:"source_entity_type,omitempty"` // Kind of entity the question was sourced from (e.g. "table")
+	SourceEntityKey  string           `json:"source_entity_key,omitempty"`  // Name of the entity the question was sourced from
 	DetectedPattern  string           `json:"detected_pattern,omitempty"`
 	Status           QuestionStatus   `json:"status"`
 	StatusReason     string           `json:"status_reason,omitempty"` // Reason for skip/escalate/dismiss
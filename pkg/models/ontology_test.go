@@ -129,3 +129,88 @@ func TestEnumValue_UnmarshalJSON_MixedArray(t *testing.T) {
 		t.Errorf("evs[3] = %+v, want {0, Zero, }", evs[3])
 	}
 }
+
+func TestNormalizeGraphCardinality(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		ok       bool
+	}{
+		{"1:1", GraphCardinalityOneToOne, true},
+		{"one-to-one", GraphCardinalityOneToOne, true},
+		{"1:N", GraphCardinalityOneToMany, true},
+		{"one-to-many", GraphCardinalityOneToMany, true},
+		{"One To Many", GraphCardinalityOneToMany, true},
+		{"N:1", GraphCardinalityOneToMany, true},
+		{"many-to-one", GraphCardinalityOneToMany, true},
+		{"many", GraphCardinalityOneToMany, true},
+		{"one_to_many", GraphCardinalityOneToMany, true},
+		{"N:M", GraphCardinalityManyToMany, true},
+		{"many to many", GraphCardinalityManyToMany, true},
+		{"*:*", GraphCardinalityManyToMany, true},
+		{"several", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, ok := NormalizeGraphCardinality(tt.input)
+			if ok != tt.ok || got != tt.expected {
+				t.Errorf("NormalizeGraphCardinality(%q) = (%q, %v), want (%q, %v)", tt.input, got, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
+
+func TestDomainSummary_NormalizeCardinalities(t *testing.T) {
+	summary := &DomainSummary{RelationshipGraph: []RelationshipEdge{
+		{From: "users", To: "orders", Cardinality: "1:N"},
+		{From: "orders", To: "products", Cardinality: "many-to-many"},
+		{From: "users", To: "profiles", Cardinality: "sometimes"},
+		{From: "users", To: "accounts"},
+	}}
+
+	unmappable := summary.NormalizeCardinalities()
+
+	if len(unmappable) != 1 || unmappable[0].To != "profiles" {
+		t.Fatalf("expected users->profiles unmappable, got %+v", unmappable)
+	}
+	want := []string{GraphCardinalityOneToMany, GraphCardinalityManyToMany, "sometimes", ""}
+	for i, edge := range summary.RelationshipGraph {
+		if edge.Cardinality != want[i] {
+			t.Errorf("edge %d cardinality = %q, want %q", i, edge.Cardinality, want[i])
+		}
+	}
+}
+
+func TestDomainSummary_CollapseBridgeNodes(t *testing.T) {
+	summary := &DomainSummary{RelationshipGraph: []RelationshipEdge{
+		{From: "users", To: "orders", Cardinality: GraphCardinalityOneToMany},
+		{From: "Student_Courses", To: "students", Cardinality: GraphCardinalityOneToMany},
+		{From: "student_courses", To: "courses", Cardinality: GraphCardinalityOneToMany},
+		{From: "product_tags", To: "products"},
+		{From: "products", To: "tags", Label: "Each product has many tags", Cardinality: GraphCardinalityManyToMany},
+	}}
+
+	removed := summary.CollapseBridgeNodes(map[string][]string{
+		"student_courses": {"students", "courses"},
+		"product_tags":    {"products", "tags"},
+	})
+
+	if len(removed) != 2 || removed[0] != "Student_Courses" || removed[1] != "product_tags" {
+		t.Fatalf("removed = %v, want [Student_Courses product_tags]", removed)
+	}
+	want := []RelationshipEdge{
+		{From: "users", To: "orders", Cardinality: GraphCardinalityOneToMany},
+		{From: "products", To: "tags", Label: "Each product has many tags", Cardinality: GraphCardinalityManyToMany},
+		{From: "students", To: "courses", Cardinality: GraphCardinalityManyToMany},
+	}
+	if len(summary.RelationshipGraph) != len(want) {
+		t.Fatalf("graph = %+v, want %+v", summary.RelationshipGraph, want)
+	}
+	for i, edge := range summary.RelationshipGraph {
+		if edge != want[i] {
+			t.Errorf("edge %d = %+v, want %+v", i, edge, want[i])
+		}
+	}
+}
@@ -94,6 +94,15 @@ func WithMCPProvenance(ctx context.Context, userID uuid.UUID) context.Context {
 	})
 }
 
+// WithImportProvenance returns a context with import provenance set.
+// Use this only when loading glossary terms from an authoritative import.
+func WithImportProvenance(ctx context.Context, userID uuid.UUID) context.Context {
+	return WithProvenance(ctx, ProvenanceContext{
+		Source: ProvenanceSource(GlossarySourceImport),
+		UserID: userID,
+	})
+}
+
 // WithInferredProvenance returns a context with inferred provenance set.
 // Use this for DAG task handlers and automatic LLM-based operations.
 // The userID should be the user who triggered the extraction workflow.
@@ -0,0 +1,30 @@
+package models
+
+import "github.com/google/uuid"
+
+// RelationshipPairCheckpoint is the join analysis result of one relationship candidate
+// pair, recorded while candidate collection runs so a run interrupted by a datasource
+// connection drop can resume without analyzing the pair again.
+type RelationshipPairCheckpoint struct {
+	ProjectID          uuid.UUID
+	DatasourceID       uuid.UUID
+	SourceColumnID     uuid.UUID
+	TargetColumnID     uuid.UUID
+	JoinCount          int64
+	SourceMatched      int64
+	TargetMatched      int64
+	OrphanCount        int64
+	ReverseOrphanCount int64
+}
+
+// PairKey identifies the candidate pair by its source and target columns.
+func (c *RelationshipPairCheckpoint) PairKey() RelationshipPairKey {
+	return RelationshipPairKey{SourceColumnID: c.SourceColumnID, TargetColumnID: c.TargetColumnID}
+}
+
+// RelationshipPairKey identifies a candidate pair by its schema column IDs, so tables
+// with the same name in different schemas are kept apart.
+type RelationshipPairKey struct {
+	SourceColumnID uuid.UUID
+	TargetColumnID uuid.UUID
+}
@@ -0,0 +1,45 @@
+package models
+
+import "strings"
+
+// JoinColumnPair is one source/target column pairing of a relationship. Composite
+// relationships have one pair per key column.
+type JoinColumnPair struct {
+	SourceColumn string
+	TargetColumn string
+}
+
+// BuildJoinClause returns the canonical ON clause for a relationship, with every
+// column qualified by schema and table:
+//
+//	"public"."orders"."customer_id" = "public"."customers"."id"
+//
+// Composite relationships produce one predicate per pair joined with AND, in the
+// order given. Identifiers are always quoted, with quote in the datasource's dialect
+// (nil means ANSI double quotes), so mixed-case and reserved names ("order", "User")
+// are used verbatim. Returns "" when there are no pairs.
+func BuildJoinClause(quote func(string) string, sourceSchema, sourceTable, targetSchema, targetTable string, pairs []JoinColumnPair) string {
+	if quote == nil {
+		quote = quoteJoinIdentifier
+	}
+	predicates := make([]string, 0, len(pairs))
+	for _, pair := range pairs {
+		predicates = append(predicates,
+			qualifiedColumn(quote, sourceSchema, sourceTable, pair.SourceColumn)+" = "+
+				qualifiedColumn(quote, targetSchema, targetTable, pair.TargetColumn))
+	}
+	return strings.Join(predicates, " AND ")
+}
+
+// qualifiedColumn returns schema.table.column with each part quoted. An empty schema
+// is omitted.
+func qualifiedColumn(quote func(string) string, schema, table, column string) string {
+	if schema == "" {
+		return quote(table) + "." + quote(column)
+	}
+	return quote(schema) + "." + quote(table) + "." + quote(column)
+}
+
+func quoteJoinIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
@@ -0,0 +1,66 @@
+package models
+
+import "testing"
+
+func TestBuildJoinClause(t *testing.T) {
+	tests := []struct {
+		name        string
+		sourceTable string
+		targetTable string
+		pairs       []JoinColumnPair
+		expected    string
+	}{
+		{
+			name:        "single column",
+			sourceTable: "orders",
+			targetTable: "customers",
+			pairs:       []JoinColumnPair{{SourceColumn: "customer_id", TargetColumn: "id"}},
+			expected:    `"public"."orders"."customer_id" = "public"."customers"."id"`,
+		},
+		{
+			name:        "composite key",
+			sourceTable: "order_lines",
+			targetTable: "order_items",
+			pairs: []JoinColumnPair{
+				{SourceColumn: "order_id", TargetColumn: "order_id"},
+				{SourceColumn: "line_no", TargetColumn: "line_number"},
+			},
+			expected: `"public"."order_lines"."order_id" = "public"."order_items"."order_id" AND ` +
+				`"public"."order_lines"."line_no" = "public"."order_items"."line_number"`,
+		},
+		{
+			name:        "quotes reserved and mixed-case names",
+			sourceTable: "order",
+			targetTable: `Weird"Name`,
+			pairs:       []JoinColumnPair{{SourceColumn: "UserId", TargetColumn: "id"}},
+			expected:    `"public"."order"."UserId" = "public"."Weird""Name"."id"`,
+		},
+		{
+			name:        "no pairs",
+			sourceTable: "orders",
+			targetTable: "customers",
+			expected:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BuildJoinClause(nil, "public", tt.sourceTable, "public", tt.targetTable, tt.pairs)
+			if got != tt.expected {
+				t.Errorf("BuildJoinClause() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBuildJoinClause_UsesDialectQuoting(t *testing.T) {
+	brackets := func(name string) string { return "[" + name + "]" }
+
+	got := BuildJoinClause(brackets, "dbo", "Orders", "dbo", "Customers",
+		[]JoinColumnPair{{SourceColumn: "CustomerID", TargetColumn: "ID"}})
+
+	want := "[dbo].[Orders].[CustomerID] = [dbo].[Customers].[ID]"
+	if got != want {
+		t.Errorf("BuildJoinClause() = %q, want %q", got, want)
+	}
+}
@@ -42,6 +42,8 @@ type SchemaColumn struct {
 	UpdatedAt       time.Time `json:"updated_at"`
 	// Schema-defined enum values (from Postgres pg_enum, nil for non-enum columns)
 	EnumValues []string `json:"enum_values,omitempty"`
+	// Check constraint definitions covering the column, e.g. "CHECK (amount >= 0)"
+	CheckConstraints []string `json:"check_constraints,omitempty"`
 
 	// Discovery-related fields (populated by discovery process)
 	RowCount          *int64     `json:"row_count,omitempty"`          // Denormalized table row count
@@ -78,6 +80,10 @@ type SchemaRelationship struct {
 	TargetDistinct  *int64   `json:"target_distinct,omitempty"`  // Distinct values in target
 	MatchedCount    *int64   `json:"matched_count,omitempty"`    // Count of matched values
 	RejectionReason *string  `json:"rejection_reason,omitempty"` // Why candidate was rejected
+	// JoinClause is the canonical ON clause (see BuildJoinClause). Left nil on write,
+	// the repository derives it from the relationship's columns; set it to store a
+	// composite clause.
+	JoinClause *string `json:"join_clause,omitempty"`
 }
 
 // ValidationResults stores metrics from relationship validation analysis.
@@ -180,6 +186,18 @@ const (
 	InferenceMethodRelationshipDiscovery = "relationship_discovery" // Active: FK inferred from LLM relationship discovery
 )
 
+// IsKnownInferenceMethod reports whether method is one of the inference methods above,
+// or RelationshipTypeManual, which user-added relationships record as their method.
+func IsKnownInferenceMethod(method string) bool {
+	switch method {
+	case InferenceMethodNamingPattern, InferenceMethodValueOverlap, InferenceMethodTypeMatch,
+		InferenceMethodFK, InferenceMethodColumnFeatures, InferenceMethodRelationshipDiscovery,
+		RelationshipTypeManual:
+		return true
+	}
+	return false
+}
+
 // Rejection reasons for relationship candidates
 const (
 	RejectionLowMatchRate      = "low_match_rate"
@@ -223,6 +241,50 @@ type RefreshResult struct {
 	NewColumns      []RefreshColumnChange       `json:"new_columns,omitempty"`
 	RemovedColumns  []RefreshColumnChange       `json:"removed_columns,omitempty"`
 	ModifiedColumns []RefreshColumnModification `json:"modified_columns,omitempty"`
+	// Tables whose columns were synced by a scoped introspection (empty for a full refresh)
+	IntrospectedTableNames []string `json:"introspected_table_names,omitempty"`
+}
+
+// SchemaInventoryResult contains the outcome of a schema inventory pass: every table
+// in the datasource with its row count, without columns, statistics or foreign keys.
+type SchemaInventoryResult struct {
+	Tables            []SchemaInventoryTable `json:"tables"`
+	TablesUpserted    int                    `json:"tables_upserted"`
+	TablesDeleted     int64                  `json:"tables_deleted"`
+	NewTableNames     []string               `json:"new_table_names,omitempty"`
+	RemovedTableNames []string               `json:"removed_table_names,omitempty"`
+}
+
+// SchemaInventoryTable is one table listed by a schema inventory pass.
+type SchemaInventoryTable struct {
+	TableID    uuid.UUID `json:"table_id"`
+	SchemaName string    `json:"schema_name"`
+	TableName  string    `json:"table_name"`
+	RowCount   int64     `json:"row_count"`
+	IsSelected bool      `json:"is_selected"`
+}
+
+// Ways of ranking tables when picking the top N for deep introspection.
+const (
+	IntrospectionRankRowCount       = "row_count"
+	IntrospectionRankReferenceCount = "reference_count"
+)
+
+// IntrospectTablesRequest scopes deep introspection to part of an inventoried schema.
+// Named tables and the top N ranked tables are combined; when neither is given the
+// currently selected tables are introspected.
+type IntrospectTablesRequest struct {
+	// Tables to introspect as "schema.table"
+	TableNames []string `json:"table_names,omitempty"`
+	// Number of top-ranked tables to introspect in addition to TableNames
+	TopN int `json:"top_n,omitempty"`
+	// IntrospectionRankRowCount (default) or IntrospectionRankReferenceCount
+	RankBy string `json:"rank_by,omitempty"`
+}
+
+// IsValidIntrospectionRank reports whether rankBy is empty or a known ranking.
+func IsValidIntrospectionRank(rankBy string) bool {
+	return rankBy == "" || rankBy == IntrospectionRankRowCount || rankBy == IntrospectionRankReferenceCount
 }
 
 // RefreshColumnChange represents a column that was added or removed during refresh.
@@ -312,6 +374,15 @@ type AddRelationshipRequest struct {
 	Cardinality      string `json:"cardinality,omitempty"`
 }
 
+// AddColumnRelationshipRequest defines a manual relationship by the IDs of the columns
+// picked in the UI. The datasource is the one the columns belong to.
+type AddColumnRelationshipRequest struct {
+	SourceColumnID uuid.UUID `json:"source_column_id"`
+	TargetColumnID uuid.UUID `json:"target_column_id"`
+	Cardinality    string    `json:"cardinality,omitempty"`
+	AnalyzeJoin    bool      `json:"analyze_join,omitempty"` // Check the join for orphaned source values
+}
+
 // UpdateRelationshipRequest contains input for curating an existing relationship.
 type UpdateRelationshipRequest struct {
 	Cardinality *string `json:"cardinality,omitempty"`
@@ -340,6 +411,7 @@ type RelationshipDetail struct {
 	Source           string     `json:"source"`
 	LastEditSource   *string    `json:"last_edit_source,omitempty"`
 	EffectiveSource  string     `json:"effective_source"`
+	JoinClause       *string    `json:"join_clause,omitempty"`
 	CreatedBy        *uuid.UUID `json:"created_by,omitempty"`
 	UpdatedBy        *uuid.UUID `json:"updated_by,omitempty"`
 	CreatedAt        time.Time  `json:"created_at"`
@@ -362,6 +434,88 @@ type DiscoveryMetrics struct {
 	MatchedCount   int64
 }
 
+// Confidence bands used by RelationshipStats.
+const (
+	RelationshipConfidenceHigh   = 0.8 // Confidence at or above this is high
+	RelationshipConfidenceMedium = 0.5 // Confidence at or above this (and below high) is medium
+)
+
+// RelationshipStats rolls up a datasource's relationships for dashboards. Counts cover
+// active relationships; soft-deleted relationships are excluded and candidates rejected
+// during discovery are only counted in RejectedCandidates.
+type RelationshipStats struct {
+	TotalRelationships int `json:"total_relationships"`
+	// ByMethod counts relationships per inference method ("unknown" when none is recorded).
+	ByMethod   map[string]int               `json:"by_method"`
+	Confidence RelationshipConfidenceCounts `json:"confidence"`
+	Approval   RelationshipApprovalCounts   `json:"approval"`
+	// RejectedCandidates counts candidates discovery stored with a rejection reason.
+	RejectedCandidates int `json:"rejected_candidates"`
+	// OrphanTables counts tables with data but no active relationship (see GetOrphanTables).
+	OrphanTables int `json:"orphan_tables"`
+}
+
+// RelationshipConfidenceCounts buckets relationships by confidence band.
+type RelationshipConfidenceCounts struct {
+	High   int `json:"high"`
+	Medium int `json:"medium"`
+	Low    int `json:"low"`
+}
+
+// RelationshipApprovalCounts buckets relationships by review status. Pending
+// relationships have not been approved or rejected by a user.
+type RelationshipApprovalCounts struct {
+	Approved int `json:"approved"`
+	Rejected int `json:"rejected"`
+	Pending  int `json:"pending"`
+}
+
+// RelationshipMetrics exposes the stored discovery and validation metrics for a single
+// relationship so reviewers can see why it was scored the way it was.
+type RelationshipMetrics struct {
+	RelationshipID    uuid.UUID          `json:"relationship_id"`
+	InferenceMethod   *string            `json:"inference_method,omitempty"`
+	Confidence        float64            `json:"confidence"`
+	Cardinality       string             `json:"cardinality"`
+	IsValidated       bool               `json:"is_validated"`
+	MatchRate         *float64           `json:"match_rate,omitempty"`
+	SourceDistinct    *int64             `json:"source_distinct,omitempty"`
+	TargetDistinct    *int64             `json:"target_distinct,omitempty"`
+	MatchedCount      *int64             `json:"matched_count,omitempty"`
+	OrphanCount       *int64             `json:"orphan_count,omitempty"`
+	SampleSize        *int64             `json:"sample_size,omitempty"` // Source rows examined during validation
+	RejectionReason   *string            `json:"rejection_reason,omitempty"`
+	ValidationResults *ValidationResults `json:"validation_results,omitempty"`
+	DiscoveredAt      time.Time          `json:"discovered_at"`
+	LastScoredAt      time.Time          `json:"last_scored_at"`
+}
+
+// NewRelationshipMetrics builds the metrics view for a relationship.
+func NewRelationshipMetrics(rel *SchemaRelationship) *RelationshipMetrics {
+	m := &RelationshipMetrics{
+		RelationshipID:    rel.ID,
+		InferenceMethod:   rel.InferenceMethod,
+		Confidence:        rel.Confidence,
+		Cardinality:       rel.Cardinality,
+		IsValidated:       rel.IsValidated,
+		MatchRate:         rel.MatchRate,
+		SourceDistinct:    rel.SourceDistinct,
+		TargetDistinct:    rel.TargetDistinct,
+		MatchedCount:      rel.MatchedCount,
+		RejectionReason:   rel.RejectionReason,
+		ValidationResults: rel.ValidationResults,
+		DiscoveredAt:      rel.CreatedAt,
+		LastScoredAt:      rel.UpdatedAt,
+	}
+	if vr := rel.ValidationResults; vr != nil {
+		orphanCount := vr.OrphanCount
+		sampleSize := vr.SourceRowCount
+		m.OrphanCount = &orphanCount
+		m.SampleSize = &sampleSize
+	}
+	return m
+}
+
 // EffectiveProvenance returns the effective source for an ontology-backed row.
 // last_edit_source takes precedence when present.
 func EffectiveProvenance(source string, lastEditSource *string) string {
@@ -16,6 +16,15 @@ const (
 	TableTypeJunction      = "junction"      // Many-to-many relationship tables
 )
 
+// TableSplit role constants for tables that share a primary key in a 1:1 relationship.
+const (
+	TableSplitRolePrimary   = "primary"   // Holds the logical entity; other tables extend it
+	TableSplitRoleExtension = "extension" // Holds extension attributes keyed by the primary's PK
+)
+
+// TableNoPrimaryKeyNote is appended to the description of tables without a primary key.
+const TableNoPrimaryKeyNote = "No primary key — rows may not be uniquely addressable."
+
 // TableMetadata represents semantic annotations for a specific table.
 // Stored in engine_ontology_table_metadata table with provenance tracking.
 // Links to engine_schema_tables via SchemaTableID instead of datasource_id/table_name.
@@ -57,6 +66,10 @@ type TableMetadataFeatures struct {
 	RelationshipSummary *RelationshipSummaryFeatures `json:"relationship_summary,omitempty"`
 	TemporalFeatures    *TableTemporalFeatures       `json:"temporal_features,omitempty"`
 	SizeFeatures        *TableSizeFeatures           `json:"size_features,omitempty"`
+	TableSplit          *TableSplitFeatures          `json:"table_split,omitempty"`
+	Bridge              *TableBridgeFeatures         `json:"bridge,omitempty"`
+	Quality             *TableQualityFeatures        `json:"quality,omitempty"`
+	NoPrimaryKey        bool                         `json:"no_primary_key,omitempty"` // Table declares no primary key column
 }
 
 // RelationshipSummaryFeatures captures FK relationship statistics for a table.
@@ -77,6 +90,36 @@ type TableSizeFeatures struct {
 	GrowthPattern string `json:"growth_pattern"` // append_only, update_heavy, mixed
 }
 
+// TableSplitFeatures marks a table as part of a one-to-one vertical split, where a
+// logical entity is spread across tables sharing the same primary key
+// (e.g. users / user_profiles). Such pairs are documented as one primary entity
+// with linked extension tables rather than as independent entities.
+type TableSplitFeatures struct {
+	Role         string   `json:"role"`          // primary or extension
+	LinkedTables []string `json:"linked_tables"` // For primary: its extensions. For extension: its primary.
+}
+
+// TableBridgeFeatures marks a pure join (bridge) table: it holds only references to
+// the two tables it links, plus keys and audit timestamps. The table implements the
+// many-to-many relationship between LinkedTables and is not a business entity of its
+// own, so it is documented as part of that relationship.
+type TableBridgeFeatures struct {
+	LinkedTables []string `json:"linked_tables"` // The two tables related many-to-many
+}
+
+// TableQualityFeatures is a deterministic score of how well a table is documented,
+// used to order tables worst-first for review. Each component is 0-100 and Score is
+// their weighted average. Computed without an LLM, so it is always available.
+type TableQualityFeatures struct {
+	Score         int       `json:"score"`
+	Description   int       `json:"description"`   // Specific, non-generic description and usage notes
+	KeyColumns    int       `json:"key_columns"`   // Has a primary key; *_id columns resolve to FKs; columns documented
+	Relationships int       `json:"relationships"` // Takes part in at least one relationship (or is ephemeral)
+	Questions     int       `json:"questions"`     // Few pending questions about the table
+	Issues        []string  `json:"issues,omitempty"`
+	ComputedAt    time.Time `json:"computed_at"`
+}
+
 // Scan implements sql.Scanner for reading JSONB from database.
 func (f *TableMetadataFeatures) Scan(value interface{}) error {
 	if value == nil {
@@ -118,6 +161,21 @@ func (m *TableMetadata) GetSizeFeatures() *TableSizeFeatures {
 	return m.Features.SizeFeatures
 }
 
+// GetQuality returns the table's quality score, or nil if it has not been computed.
+func (m *TableMetadata) GetQuality() *TableQualityFeatures {
+	return m.Features.Quality
+}
+
+// GetBridge returns bridge table features, or nil if the table is not a pure join table.
+func (m *TableMetadata) GetBridge() *TableBridgeFeatures {
+	return m.Features.Bridge
+}
+
+// GetTableSplit returns one-to-one split features, or nil if the table is not part of a split.
+func (m *TableMetadata) GetTableSplit() *TableSplitFeatures {
+	return m.Features.TableSplit
+}
+
 // SetFromAnalysis populates TableMetadata fields from table analysis results.
 // This is used by the extraction pipeline to convert analysis results into
 // the format stored in engine_ontology_table_metadata.
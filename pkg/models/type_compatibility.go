@@ -1,11 +1,11 @@
-package services
+package models
 
 import "strings"
 
-// areTypesCompatibleForFK checks if source and target column types are compatible for FK relationships.
+// AreTypesCompatibleForFK reports whether source and target column types are compatible for FK relationships.
 // Supports exact match, UUID compatibility (text <-> uuid <-> varchar <-> character varying),
 // and integer compatibility (int <-> integer <-> bigint <-> smallint <-> serial).
-func areTypesCompatibleForFK(sourceType, targetType string) bool {
+func AreTypesCompatibleForFK(sourceType, targetType string) bool {
 	source := strings.ToLower(sourceType)
 	target := strings.ToLower(targetType)
 
@@ -132,7 +132,7 @@ func (r *agentRepository) GetByID(ctx context.Context, projectID, agentID uuid.U
 		&agent.MCPCallCount,
 	)
 	if err != nil {
-		if err == pgx.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, apperrors.ErrNotFound
 		}
 		return nil, fmt.Errorf("failed to get agent: %w", err)
@@ -3,12 +3,14 @@ package repositories
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 
+	"github.com/ekaya-inc/ekaya-engine/pkg/apperrors"
 	"github.com/ekaya-inc/ekaya-engine/pkg/crypto"
 	"github.com/ekaya-inc/ekaya-engine/pkg/database"
 	"github.com/ekaya-inc/ekaya-engine/pkg/models"
@@ -53,7 +55,7 @@ func (r *aiConfigRepository) Get(ctx context.Context, projectID uuid.UUID) (*mod
 	var jsonData []byte
 	err := scope.Conn.QueryRow(ctx, query, projectID).Scan(&jsonData)
 	if err != nil {
-		if err == pgx.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil // No project found
 		}
 		return nil, fmt.Errorf("query ai_config: %w", err)
@@ -156,7 +158,7 @@ func (r *aiConfigRepository) Upsert(ctx context.Context, projectID uuid.UUID, co
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("project not found")
+		return notFound("project")
 	}
 
 	return nil
@@ -195,7 +197,7 @@ func (r *aiConfigRepository) Delete(ctx context.Context, projectID uuid.UUID) er
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("project not found")
+		return notFound("project")
 	}
 
 	return nil
@@ -232,7 +234,7 @@ func (r *aiConfigRepository) UpdateTestResult(ctx context.Context, projectID uui
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("no ai_config to update or project not found")
+		return fmt.Errorf("no ai_config to update or project %w", apperrors.ErrNotFound)
 	}
 
 	return nil
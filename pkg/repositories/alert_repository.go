@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 
+	"github.com/ekaya-inc/ekaya-engine/pkg/apperrors"
 	"github.com/ekaya-inc/ekaya-engine/pkg/database"
 	"github.com/ekaya-inc/ekaya-engine/pkg/models"
 )
@@ -129,7 +131,7 @@ func (r *alertRepository) GetAlertByID(ctx context.Context, projectID uuid.UUID,
 		&alert.ResolutionNotes, &alert.CreatedAt, &alert.UpdatedAt,
 	)
 	if err != nil {
-		if err == pgx.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("failed to get alert: %w", err)
@@ -179,7 +181,7 @@ func (r *alertRepository) ResolveAlert(ctx context.Context, projectID uuid.UUID,
 	}
 
 	if tag.RowsAffected() == 0 {
-		return fmt.Errorf("alert not found or already resolved")
+		return fmt.Errorf("alert %w or already resolved", apperrors.ErrNotFound)
 	}
 
 	return nil
@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -201,7 +202,7 @@ func scanAuditLogEntry(row pgx.Row) (*models.AuditLogEntry, error) {
 		&entry.CreatedAt,
 	)
 	if err != nil {
-		if err == pgx.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, err
 		}
 		return nil, fmt.Errorf("failed to scan audit log entry: %w", err)
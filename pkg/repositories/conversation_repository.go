@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/jackc/pgx/v5"
 
 	"github.com/ekaya-inc/ekaya-engine/pkg/database"
+	"github.com/ekaya-inc/ekaya-engine/pkg/logging"
 	"github.com/ekaya-inc/ekaya-engine/pkg/models"
 )
 
@@ -24,11 +26,16 @@ type ConversationRepository interface {
 	DeleteByProject(ctx context.Context, projectID uuid.UUID) error
 }
 
-type conversationRepository struct{}
+type conversationRepository struct {
+	redactor *logging.Redactor
+}
 
 // NewConversationRepository creates a new ConversationRepository.
-func NewConversationRepository() ConversationRepository {
-	return &conversationRepository{}
+// redactor (may be nil) is applied when conversations are written, so tools that read
+// engine_llm_conversations directly never see the masked values, and again when they
+// are read back, so tightening the redaction config also covers already-stored records.
+func NewConversationRepository(redactor *logging.Redactor) ConversationRepository {
+	return &conversationRepository{redactor: redactor}
 }
 
 var _ ConversationRepository = (*conversationRepository)(nil)
@@ -43,9 +50,10 @@ func (r *conversationRepository) Save(ctx context.Context, conv *models.LLMConve
 	if conv.ID == uuid.Nil {
 		conv.ID = uuid.New()
 	}
+	stored := r.redactedCopy(conv)
 
 	// Marshal JSONB fields
-	requestMessagesJSON, err := json.Marshal(conv.RequestMessages)
+	requestMessagesJSON, err := json.Marshal(stored.RequestMessages)
 	if err != nil {
 		return fmt.Errorf("failed to marshal request_messages: %w", err)
 	}
@@ -57,8 +65,8 @@ func (r *conversationRepository) Save(ctx context.Context, conv *models.LLMConve
 			return fmt.Errorf("failed to marshal request_tools: %w", err)
 		}
 	}
-	if conv.ResponseToolCalls != nil {
-		responseToolCallsJSON, err = json.Marshal(conv.ResponseToolCalls)
+	if stored.ResponseToolCalls != nil {
+		responseToolCallsJSON, err = json.Marshal(stored.ResponseToolCalls)
 		if err != nil {
 			return fmt.Errorf("failed to marshal response_tool_calls: %w", err)
 		}
@@ -72,8 +80,8 @@ func (r *conversationRepository) Save(ctx context.Context, conv *models.LLMConve
 
 	// Use NULL for empty error_message (success cases)
 	var errorMessage *string
-	if conv.ErrorMessage != "" {
-		errorMessage = &conv.ErrorMessage
+	if stored.ErrorMessage != "" {
+		errorMessage = &stored.ErrorMessage
 	}
 
 	query := `
@@ -88,7 +96,7 @@ func (r *conversationRepository) Save(ctx context.Context, conv *models.LLMConve
 	_, err = scope.Conn.Exec(ctx, query,
 		conv.ID, conv.ProjectID, contextJSON, conv.ConversationID, conv.Iteration,
 		conv.Endpoint, conv.Model, requestMessagesJSON, requestToolsJSON, conv.Temperature,
-		conv.ResponseContent, responseToolCallsJSON,
+		stored.ResponseContent, responseToolCallsJSON,
 		conv.PromptTokens, conv.CompletionTokens, conv.TotalTokens, conv.DurationMs,
 		conv.Status, errorMessage, conv.CreatedAt,
 	)
@@ -105,11 +113,13 @@ func (r *conversationRepository) Update(ctx context.Context, conv *models.LLMCon
 		return fmt.Errorf("no tenant scope in context")
 	}
 
+	stored := r.redactedCopy(conv)
+
 	// Marshal JSONB fields for response
 	var responseToolCallsJSON []byte
 	var err error
-	if conv.ResponseToolCalls != nil {
-		responseToolCallsJSON, err = json.Marshal(conv.ResponseToolCalls)
+	if stored.ResponseToolCalls != nil {
+		responseToolCallsJSON, err = json.Marshal(stored.ResponseToolCalls)
 		if err != nil {
 			return fmt.Errorf("failed to marshal response_tool_calls: %w", err)
 		}
@@ -117,8 +127,8 @@ func (r *conversationRepository) Update(ctx context.Context, conv *models.LLMCon
 
 	// Use NULL for empty error_message (success cases)
 	var errorMessage *string
-	if conv.ErrorMessage != "" {
-		errorMessage = &conv.ErrorMessage
+	if stored.ErrorMessage != "" {
+		errorMessage = &stored.ErrorMessage
 	}
 
 	query := `
@@ -135,7 +145,7 @@ func (r *conversationRepository) Update(ctx context.Context, conv *models.LLMCon
 
 	result, err := scope.Conn.Exec(ctx, query,
 		conv.ID,
-		conv.ResponseContent, responseToolCallsJSON,
+		stored.ResponseContent, responseToolCallsJSON,
 		conv.PromptTokens, conv.CompletionTokens, conv.TotalTokens, conv.DurationMs,
 		conv.Status, errorMessage,
 	)
@@ -158,6 +168,7 @@ func (r *conversationRepository) UpdateStatus(ctx context.Context, id uuid.UUID,
 
 	// Use NULL for empty error_message
 	var errMsg *string
+	errorMessage = r.redactor.RedactString(errorMessage)
 	if errorMessage != "" {
 		errMsg = &errorMessage
 	}
@@ -202,7 +213,7 @@ func (r *conversationRepository) GetByProject(ctx context.Context, projectID uui
 	}
 	defer rows.Close()
 
-	return scanConversationRows(rows)
+	return r.scanConversationRows(rows)
 }
 
 // GetByContext queries conversations by a key-value pair in the context JSONB.
@@ -229,7 +240,7 @@ func (r *conversationRepository) GetByContext(ctx context.Context, projectID uui
 	}
 	defer rows.Close()
 
-	return scanConversationRows(rows)
+	return r.scanConversationRows(rows)
 }
 
 func (r *conversationRepository) GetByConversationID(ctx context.Context, conversationID uuid.UUID) ([]*models.LLMConversation, error) {
@@ -254,7 +265,7 @@ func (r *conversationRepository) GetByConversationID(ctx context.Context, conver
 	}
 	defer rows.Close()
 
-	return scanConversationRows(rows)
+	return r.scanConversationRows(rows)
 }
 
 func (r *conversationRepository) DeleteByProject(ctx context.Context, projectID uuid.UUID) error {
@@ -273,7 +284,7 @@ func (r *conversationRepository) DeleteByProject(ctx context.Context, projectID
 	return nil
 }
 
-func scanConversationRows(rows pgx.Rows) ([]*models.LLMConversation, error) {
+func (r *conversationRepository) scanConversationRows(rows pgx.Rows) ([]*models.LLMConversation, error) {
 	var conversations []*models.LLMConversation
 
 	for rows.Next() {
@@ -281,6 +292,7 @@ func scanConversationRows(rows pgx.Rows) ([]*models.LLMConversation, error) {
 		if err != nil {
 			return nil, err
 		}
+		r.redactConversation(conv)
 		conversations = append(conversations, conv)
 	}
 
@@ -291,6 +303,32 @@ func scanConversationRows(rows pgx.Rows) ([]*models.LLMConversation, error) {
 	return conversations, nil
 }
 
+// redactedCopy returns conv with its prompt, response and error message redacted for
+// storage, leaving the caller's record untouched.
+func (r *conversationRepository) redactedCopy(conv *models.LLMConversation) *models.LLMConversation {
+	if r.redactor == nil {
+		return conv
+	}
+	stored := *conv
+	r.redactConversation(&stored)
+	return &stored
+}
+
+// redactConversation masks detected values in the prompt, response and error message.
+func (r *conversationRepository) redactConversation(conv *models.LLMConversation) {
+	if r.redactor == nil {
+		return
+	}
+	if conv.RequestMessages != nil {
+		conv.RequestMessages, _ = r.redactor.RedactValue(conv.RequestMessages).([]any)
+	}
+	if conv.ResponseToolCalls != nil {
+		conv.ResponseToolCalls, _ = r.redactor.RedactValue(conv.ResponseToolCalls).([]any)
+	}
+	conv.ResponseContent = r.redactor.RedactString(conv.ResponseContent)
+	conv.ErrorMessage = r.redactor.RedactString(conv.ErrorMessage)
+}
+
 func scanConversationRow(row pgx.Row) (*models.LLMConversation, error) {
 	var conv models.LLMConversation
 	var contextJSON, requestMessagesJSON, requestToolsJSON, responseToolCallsJSON []byte
@@ -304,7 +342,7 @@ func scanConversationRow(row pgx.Row) (*models.LLMConversation, error) {
 		&conv.Status, &errorMessage, &conv.CreatedAt,
 	)
 	if err != nil {
-		if err == pgx.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, err
 		}
 		return nil, fmt.Errorf("failed to scan conversation: %w", err)
@@ -4,12 +4,14 @@ package repositories
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/ekaya-inc/ekaya-engine/pkg/database"
+	"github.com/ekaya-inc/ekaya-engine/pkg/logging"
 	"github.com/ekaya-inc/ekaya-engine/pkg/models"
 	"github.com/ekaya-inc/ekaya-engine/pkg/testhelpers"
 )
@@ -30,7 +32,7 @@ func setupConversationTest(t *testing.T) *conversationTestContext {
 	tc := &conversationTestContext{
 		t:            t,
 		engineDB:     engineDB,
-		repo:         NewConversationRepository(),
+		repo:         NewConversationRepository(nil),
 		projectID:    uuid.MustParse("00000000-0000-0000-0000-000000000050"),
 		dagID:        uuid.MustParse("00000000-0000-0000-0000-000000000051"),
 		datasourceID: uuid.MustParse("00000000-0000-0000-0000-000000000053"),
@@ -511,6 +513,117 @@ func TestConversationRepository_JSONBFieldsRoundTrip(t *testing.T) {
 	}
 }
 
+func TestConversationRepository_RedactsStoredConversationsOnRead(t *testing.T) {
+	tc := setupConversationTest(t)
+	defer tc.cleanup()
+
+	ctx, cleanup := tc.createTestContext()
+	defer cleanup()
+
+	// Stored before any detector was configured
+	conv := &models.LLMConversation{
+		ProjectID: tc.projectID,
+		Iteration: 1,
+		Endpoint:  "https://api.openai.com/v1",
+		Model:     "gpt-4",
+		RequestMessages: []any{
+			map[string]string{"role": "user", "content": "Sample values for users.email: alice@example.com, bob@example.com"},
+		},
+		ResponseContent: "The column holds customer emails such as alice@example.com",
+		DurationMs:      120,
+		Status:          models.LLMConversationStatusSuccess,
+	}
+	if err := tc.repo.Save(ctx, conv); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+
+	// The policy is tightened afterwards
+	redactor, err := logging.NewRedactor([]string{"email"}, nil)
+	if err != nil {
+		t.Fatalf("failed to create redactor: %v", err)
+	}
+	redactingRepo := NewConversationRepository(redactor)
+
+	conversations, err := redactingRepo.GetByProject(ctx, tc.projectID, 1)
+	if err != nil {
+		t.Fatalf("failed to get: %v", err)
+	}
+	if len(conversations) != 1 {
+		t.Fatalf("expected 1 conversation, got %d", len(conversations))
+	}
+
+	fetched := conversations[0]
+	content := fetched.RequestMessages[0].(map[string]any)["content"]
+	if content != "Sample values for users.email: [REDACTED], [REDACTED]" {
+		t.Errorf("expected emails masked in request messages, got %q", content)
+	}
+	if fetched.ResponseContent != "The column holds customer emails such as [REDACTED]" {
+		t.Errorf("expected emails masked in response, got %q", fetched.ResponseContent)
+	}
+
+	// The stored record itself is unchanged
+	raw, err := tc.repo.GetByProject(ctx, tc.projectID, 1)
+	if err != nil {
+		t.Fatalf("failed to get raw conversation: %v", err)
+	}
+	if raw[0].ResponseContent != conv.ResponseContent {
+		t.Errorf("expected stored response to be unchanged, got %q", raw[0].ResponseContent)
+	}
+}
+
+func TestConversationRepository_RedactsConversationsOnWrite(t *testing.T) {
+	tc := setupConversationTest(t)
+	defer tc.cleanup()
+
+	ctx, cleanup := tc.createTestContext()
+	defer cleanup()
+
+	redactor, err := logging.NewRedactor([]string{"email"}, nil)
+	if err != nil {
+		t.Fatalf("failed to create redactor: %v", err)
+	}
+	redactingRepo := NewConversationRepository(redactor)
+
+	conv := &models.LLMConversation{
+		ProjectID: tc.projectID,
+		Iteration: 1,
+		Endpoint:  "https://api.openai.com/v1",
+		Model:     "gpt-4",
+		RequestMessages: []any{
+			map[string]string{"role": "user", "content": "Sample values for users.email: alice@example.com"},
+		},
+		DurationMs: 120,
+		Status:     models.LLMConversationStatusPending,
+	}
+	if err := redactingRepo.Save(ctx, conv); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+	conv.ResponseContent = "The column holds customer emails such as bob@example.com"
+	conv.Status = models.LLMConversationStatusSuccess
+	if err := redactingRepo.Update(ctx, conv); err != nil {
+		t.Fatalf("failed to update: %v", err)
+	}
+
+	if conv.ResponseContent != "The column holds customer emails such as bob@example.com" {
+		t.Errorf("expected caller's record to be left unredacted, got %q", conv.ResponseContent)
+	}
+
+	// Read the row directly, the way the assess scripts do, bypassing read-time redaction
+	var requestMessages, responseContent string
+	err = tc.engineDB.DB.Pool.QueryRow(ctx,
+		`SELECT request_messages::text, response_content FROM engine_llm_conversations WHERE id = $1`,
+		conv.ID).Scan(&requestMessages, &responseContent)
+	if err != nil {
+		t.Fatalf("failed to read stored conversation: %v", err)
+	}
+	if strings.Contains(requestMessages, "alice@example.com") {
+		t.Errorf("expected email masked in stored request messages, got %s", requestMessages)
+	}
+	if responseContent != "The column holds customer emails such as [REDACTED]" {
+		t.Errorf("expected email masked in stored response, got %q", responseContent)
+	}
+}
+
 // ============================================================================
 // DeleteByProject Tests
 // ============================================================================
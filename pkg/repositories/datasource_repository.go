@@ -140,8 +140,8 @@ func (r *datasourceRepository) GetByID(ctx context.Context, projectID, id uuid.U
 		ds.Provider = *provider
 	}
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			return nil, "", fmt.Errorf("datasource not found")
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, "", notFound("datasource")
 		}
 		return nil, "", fmt.Errorf("failed to get datasource: %w", err)
 	}
@@ -178,8 +178,8 @@ func (r *datasourceRepository) GetByName(ctx context.Context, projectID uuid.UUI
 		ds.Provider = *provider
 	}
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			return nil, "", fmt.Errorf("datasource not found")
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, "", notFound("datasource")
 		}
 		return nil, "", fmt.Errorf("failed to get datasource: %w", err)
 	}
@@ -257,7 +257,7 @@ func (r *datasourceRepository) Update(ctx context.Context, id uuid.UUID, name, d
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("datasource not found")
+		return notFound("datasource")
 	}
 
 	return nil
@@ -278,7 +278,7 @@ func (r *datasourceRepository) Rename(ctx context.Context, id uuid.UUID, name st
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("datasource not found")
+		return notFound("datasource")
 	}
 
 	return nil
@@ -299,7 +299,7 @@ func (r *datasourceRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("datasource not found")
+		return notFound("datasource")
 	}
 
 	return nil
@@ -317,8 +317,8 @@ func (r *datasourceRepository) GetProjectID(ctx context.Context, id uuid.UUID) (
 	var projectID uuid.UUID
 	err := scope.Conn.QueryRow(ctx, query, id).Scan(&projectID)
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			return uuid.Nil, fmt.Errorf("datasource not found")
+		if errors.Is(err, pgx.ErrNoRows) {
+			return uuid.Nil, notFound("datasource")
 		}
 		return uuid.Nil, fmt.Errorf("failed to get project_id: %w", err)
 	}
@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/apperrors"
+)
+
+// notFound returns an error reading "<what> not found" that wraps
+// apperrors.ErrNotFound, so services can branch on errors.Is instead of
+// matching messages or pgx.ErrNoRows.
+func notFound(what string) error {
+	return fmt.Errorf("%s %w", what, apperrors.ErrNotFound)
+}
@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -168,8 +169,8 @@ func scanLoadStatus(row pgx.Row) (*models.LoadStatus, error) {
 		&s.StartedAt, &s.CompletedAt, &s.Status,
 	)
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("load status not found")
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, notFound("load status")
 		}
 		return nil, fmt.Errorf("failed to scan load status: %w", err)
 	}
@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -29,6 +30,7 @@ type GlossaryRepository interface {
 	GetByID(ctx context.Context, termID uuid.UUID) (*models.BusinessGlossaryTerm, error)
 	CreateAlias(ctx context.Context, glossaryID uuid.UUID, alias string) error
 	DeleteAlias(ctx context.Context, glossaryID uuid.UUID, alias string) error
+	UpdateAlternates(ctx context.Context, termID uuid.UUID, alternates []models.GlossaryAlternateDefinition) error
 }
 
 type glossaryRepository struct{}
@@ -102,7 +104,8 @@ func (r *glossaryRepository) Update(ctx context.Context, term *models.BusinessGl
 		UPDATE engine_business_glossary
 		SET term = $2, definition = $3, defining_sql = $4, base_table = $5,
 		    output_columns = $6, source = $7, enrichment_status = $8,
-		    enrichment_error = $9, last_edit_source = $10, updated_by = $11
+		    enrichment_error = $9, last_edit_source = $10, updated_by = $11,
+		    alternate_definitions = $12
 		WHERE id = $1
 		RETURNING updated_at`
 
@@ -118,9 +121,10 @@ func (r *glossaryRepository) Update(ctx context.Context, term *models.BusinessGl
 		nullString(term.EnrichmentError),
 		term.LastEditSource,
 		term.UpdatedBy,
+		jsonbValue(term.Alternates),
 	).Scan(&term.UpdatedAt)
 	if err != nil {
-		if err == pgx.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return apperrors.ErrNotFound
 		}
 		return fmt.Errorf("failed to update glossary term: %w", err)
@@ -290,7 +294,7 @@ func (r *glossaryRepository) GetByProject(ctx context.Context, projectID uuid.UU
 
 	query := `
 		SELECT g.id, g.project_id, g.term, g.definition, g.defining_sql, g.base_table,
-		       g.output_columns, g.source, g.last_edit_source, g.enrichment_status, g.enrichment_error,
+		       g.output_columns, g.source, g.last_edit_source, g.enrichment_status, g.enrichment_error, g.alternate_definitions,
 		       g.created_by, g.updated_by, g.created_at, g.updated_at,
 		       COALESCE(
 		           jsonb_agg(a.alias ORDER BY a.alias) FILTER (WHERE a.alias IS NOT NULL),
@@ -300,7 +304,7 @@ func (r *glossaryRepository) GetByProject(ctx context.Context, projectID uuid.UU
 		LEFT JOIN engine_glossary_aliases a ON g.id = a.glossary_id
 		WHERE g.project_id = $1
 		GROUP BY g.id, g.project_id, g.term, g.definition, g.defining_sql, g.base_table,
-		         g.output_columns, g.source, g.last_edit_source, g.enrichment_status, g.enrichment_error,
+		         g.output_columns, g.source, g.last_edit_source, g.enrichment_status, g.enrichment_error, g.alternate_definitions,
 		         g.created_by, g.updated_by, g.created_at, g.updated_at
 		ORDER BY g.term`
 
@@ -334,7 +338,7 @@ func (r *glossaryRepository) GetByTerm(ctx context.Context, projectID uuid.UUID,
 
 	query := `
 		SELECT g.id, g.project_id, g.term, g.definition, g.defining_sql, g.base_table,
-		       g.output_columns, g.source, g.last_edit_source, g.enrichment_status, g.enrichment_error,
+		       g.output_columns, g.source, g.last_edit_source, g.enrichment_status, g.enrichment_error, g.alternate_definitions,
 		       g.created_by, g.updated_by, g.created_at, g.updated_at,
 		       COALESCE(
 		           jsonb_agg(a.alias ORDER BY a.alias) FILTER (WHERE a.alias IS NOT NULL),
@@ -344,13 +348,13 @@ func (r *glossaryRepository) GetByTerm(ctx context.Context, projectID uuid.UUID,
 		LEFT JOIN engine_glossary_aliases a ON g.id = a.glossary_id
 		WHERE g.project_id = $1 AND g.term = $2
 		GROUP BY g.id, g.project_id, g.term, g.definition, g.defining_sql, g.base_table,
-		         g.output_columns, g.source, g.last_edit_source, g.enrichment_status, g.enrichment_error,
+		         g.output_columns, g.source, g.last_edit_source, g.enrichment_status, g.enrichment_error, g.alternate_definitions,
 		         g.created_by, g.updated_by, g.created_at, g.updated_at`
 
 	row := scope.Conn.QueryRow(ctx, query, projectID, termName)
 	term, err := scanGlossaryTerm(row)
 	if err != nil {
-		if err == pgx.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil // Term not found
 		}
 		return nil, err
@@ -367,7 +371,7 @@ func (r *glossaryRepository) GetByAlias(ctx context.Context, projectID uuid.UUID
 
 	query := `
 		SELECT g.id, g.project_id, g.term, g.definition, g.defining_sql, g.base_table,
-		       g.output_columns, g.source, g.last_edit_source, g.enrichment_status, g.enrichment_error,
+		       g.output_columns, g.source, g.last_edit_source, g.enrichment_status, g.enrichment_error, g.alternate_definitions,
 		       g.created_by, g.updated_by, g.created_at, g.updated_at,
 		       COALESCE(
 		           jsonb_agg(a2.alias ORDER BY a2.alias) FILTER (WHERE a2.alias IS NOT NULL),
@@ -378,13 +382,13 @@ func (r *glossaryRepository) GetByAlias(ctx context.Context, projectID uuid.UUID
 		LEFT JOIN engine_glossary_aliases a2 ON g.id = a2.glossary_id
 		WHERE g.project_id = $1 AND a.alias = $2
 		GROUP BY g.id, g.project_id, g.term, g.definition, g.defining_sql, g.base_table,
-		         g.output_columns, g.source, g.last_edit_source, g.enrichment_status, g.enrichment_error,
+		         g.output_columns, g.source, g.last_edit_source, g.enrichment_status, g.enrichment_error, g.alternate_definitions,
 		         g.created_by, g.updated_by, g.created_at, g.updated_at`
 
 	row := scope.Conn.QueryRow(ctx, query, projectID, alias)
 	term, err := scanGlossaryTerm(row)
 	if err != nil {
-		if err == pgx.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil // Term not found
 		}
 		return nil, err
@@ -401,7 +405,7 @@ func (r *glossaryRepository) GetByID(ctx context.Context, termID uuid.UUID) (*mo
 
 	query := `
 		SELECT g.id, g.project_id, g.term, g.definition, g.defining_sql, g.base_table,
-		       g.output_columns, g.source, g.last_edit_source, g.enrichment_status, g.enrichment_error,
+		       g.output_columns, g.source, g.last_edit_source, g.enrichment_status, g.enrichment_error, g.alternate_definitions,
 		       g.created_by, g.updated_by, g.created_at, g.updated_at,
 		       COALESCE(
 		           jsonb_agg(a.alias ORDER BY a.alias) FILTER (WHERE a.alias IS NOT NULL),
@@ -411,13 +415,13 @@ func (r *glossaryRepository) GetByID(ctx context.Context, termID uuid.UUID) (*mo
 		LEFT JOIN engine_glossary_aliases a ON g.id = a.glossary_id
 		WHERE g.id = $1
 		GROUP BY g.id, g.project_id, g.term, g.definition, g.defining_sql, g.base_table,
-		         g.output_columns, g.source, g.last_edit_source, g.enrichment_status, g.enrichment_error,
+		         g.output_columns, g.source, g.last_edit_source, g.enrichment_status, g.enrichment_error, g.alternate_definitions,
 		         g.created_by, g.updated_by, g.created_at, g.updated_at`
 
 	row := scope.Conn.QueryRow(ctx, query, termID)
 	term, err := scanGlossaryTerm(row)
 	if err != nil {
-		if err == pgx.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil // Term not found
 		}
 		return nil, err
@@ -426,6 +430,29 @@ func (r *glossaryRepository) GetByID(ctx context.Context, termID uuid.UUID) (*mo
 	return term, nil
 }
 
+// UpdateAlternates replaces a term's alternate definitions without touching its
+// definition or provenance, so a lower-precedence source can be recorded on a term
+// it is not allowed to modify.
+func (r *glossaryRepository) UpdateAlternates(ctx context.Context, termID uuid.UUID, alternates []models.GlossaryAlternateDefinition) error {
+	scope, ok := database.GetTenantScope(ctx)
+	if !ok {
+		return fmt.Errorf("no tenant scope in context")
+	}
+
+	query := `UPDATE engine_business_glossary SET alternate_definitions = $2 WHERE id = $1`
+
+	result, err := scope.Conn.Exec(ctx, query, termID, jsonbValue(alternates))
+	if err != nil {
+		return fmt.Errorf("failed to update glossary alternates: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperrors.ErrNotFound
+	}
+
+	return nil
+}
+
 // ============================================================================
 // Alias Operations
 // ============================================================================
@@ -466,7 +493,7 @@ func (r *glossaryRepository) DeleteAlias(ctx context.Context, glossaryID uuid.UU
 func scanGlossaryTerm(row pgx.Row) (*models.BusinessGlossaryTerm, error) {
 	var t models.BusinessGlossaryTerm
 	var baseTable, enrichmentStatus, enrichmentError *string
-	var outputColumns, aliases []byte
+	var outputColumns, alternates, aliases []byte
 
 	err := row.Scan(
 		&t.ID,
@@ -480,6 +507,7 @@ func scanGlossaryTerm(row pgx.Row) (*models.BusinessGlossaryTerm, error) {
 		&t.LastEditSource,
 		&enrichmentStatus,
 		&enrichmentError,
+		&alternates,
 		&t.CreatedBy,
 		&t.UpdatedBy,
 		&t.CreatedAt,
@@ -487,7 +515,7 @@ func scanGlossaryTerm(row pgx.Row) (*models.BusinessGlossaryTerm, error) {
 		&aliases,
 	)
 	if err != nil {
-		if err == pgx.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, err
 		}
 		return nil, fmt.Errorf("failed to scan glossary term: %w", err)
@@ -510,6 +538,11 @@ func scanGlossaryTerm(row pgx.Row) (*models.BusinessGlossaryTerm, error) {
 			return nil, fmt.Errorf("failed to unmarshal output_columns: %w", err)
 		}
 	}
+	if len(alternates) > 0 && string(alternates) != "null" {
+		if err := jsonUnmarshal(alternates, &t.Alternates); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal alternate_definitions: %w", err)
+		}
+	}
 	if len(aliases) > 0 && string(aliases) != "null" && string(aliases) != "[]" {
 		if err := jsonUnmarshal(aliases, &t.Aliases); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal aliases: %w", err)
@@ -536,6 +569,11 @@ func jsonbValue(v any) any {
 			return nil
 		}
 		return val
+	case []models.GlossaryAlternateDefinition:
+		if len(val) == 0 {
+			return nil
+		}
+		return val
 	default:
 		return v
 	}
@@ -551,8 +589,8 @@ func (r *glossaryRepository) createTermWithExec(ctx context.Context, execer glos
 		INSERT INTO engine_business_glossary (
 			project_id, term, definition, defining_sql, base_table,
 			output_columns, source, enrichment_status, enrichment_error,
-			created_by, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			alternate_definitions, created_by, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 		RETURNING id, created_at, updated_at`
 
 	err := execer.QueryRow(ctx, query,
@@ -565,6 +603,7 @@ func (r *glossaryRepository) createTermWithExec(ctx context.Context, execer glos
 		term.Source,
 		nullString(term.EnrichmentStatus),
 		nullString(term.EnrichmentError),
+		jsonbValue(term.Alternates),
 		term.CreatedBy,
 		now,
 		now,
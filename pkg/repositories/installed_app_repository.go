@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
@@ -94,7 +95,7 @@ func (r *installedAppRepository) Get(ctx context.Context, projectID uuid.UUID, a
 	row := scope.Conn.QueryRow(ctx, query, projectID, appID)
 	app, err := r.scanAppRow(row)
 	if err != nil {
-		if err == pgx.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("failed to get installed app: %w", err)
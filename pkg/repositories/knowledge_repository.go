@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -113,7 +114,7 @@ func (r *knowledgeRepository) Update(ctx context.Context, fact *models.Knowledge
 		fact.LastEditSource, fact.UpdatedBy, fact.UpdatedAt,
 	).Scan(&fact.CreatedAt)
 	if err != nil {
-		if err == pgx.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return fmt.Errorf("fact with id %s not found", fact.ID)
 		}
 		return fmt.Errorf("failed to update knowledge fact: %w", err)
@@ -136,7 +137,7 @@ func (r *knowledgeRepository) GetByID(ctx context.Context, id uuid.UUID) (*model
 
 	fact, err := scanKnowledgeFactRow(scope.Conn.QueryRow(ctx, query, id))
 	if err != nil {
-		if err == pgx.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, apperrors.ErrNotFound
 		}
 		return nil, err
@@ -280,7 +281,7 @@ func scanKnowledgeFactRow(row pgx.Row) (*models.KnowledgeFact, error) {
 		&f.Source, &f.LastEditSource, &f.CreatedBy, &f.UpdatedBy, &f.CreatedAt, &f.UpdatedAt,
 	)
 	if err != nil {
-		if err == pgx.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, err
 		}
 		return nil, fmt.Errorf("failed to scan knowledge fact: %w", err)
@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -67,7 +68,7 @@ func (r *mcpConfigRepository) Get(ctx context.Context, projectID uuid.UUID) (*mo
 		&config.UpdatedAt,
 	)
 	if err != nil {
-		if err == pgx.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil // Not found, return nil without error
 		}
 		return nil, fmt.Errorf("failed to get MCP config: %w", err)
@@ -125,7 +126,7 @@ func (r *mcpConfigRepository) GetAuditRetentionDays(ctx context.Context, project
 	var days *int
 	err := scope.Conn.QueryRow(ctx, query, projectID).Scan(&days)
 	if err != nil {
-		if err == pgx.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("failed to get audit retention days: %w", err)
@@ -168,7 +169,7 @@ func (r *mcpConfigRepository) GetAlertConfig(ctx context.Context, projectID uuid
 	var configJSON []byte
 	err := scope.Conn.QueryRow(ctx, query, projectID).Scan(&configJSON)
 	if err != nil {
-		if err == pgx.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("failed to get alert config: %w", err)
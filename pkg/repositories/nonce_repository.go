@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -62,7 +63,7 @@ func (r *nonceRepository) ValidateAndConsume(ctx context.Context, nonce, action
 	var valid bool
 	err := scope.Conn.QueryRow(ctx, query, nonce, action, projectID, appID).Scan(&valid)
 	if err != nil {
-		if err == pgx.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return false, nil
 		}
 		return false, fmt.Errorf("failed to validate nonce: %w", err)
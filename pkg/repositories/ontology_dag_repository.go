@@ -3,19 +3,28 @@ package repositories
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 
+	"github.com/ekaya-inc/ekaya-engine/pkg/apperrors"
 	"github.com/ekaya-inc/ekaya-engine/pkg/database"
 	"github.com/ekaya-inc/ekaya-engine/pkg/models"
 )
 
+// activeDAGIndex is the unique index allowing one pending or running DAG per datasource.
+const activeDAGIndex = "idx_engine_ontology_dag_unique_active"
+
 // OntologyDAGRepository provides data access for ontology DAGs.
 type OntologyDAGRepository interface {
 	// DAG operations
+
+	// Create inserts a DAG. Returns apperrors.ErrConflict if the datasource already has
+	// a pending or running DAG; a unique index allows only one.
 	Create(ctx context.Context, dag *models.OntologyDAG) error
 	GetByID(ctx context.Context, id uuid.UUID) (*models.OntologyDAG, error)
 	GetByIDWithNodes(ctx context.Context, id uuid.UUID) (*models.OntologyDAG, error)
@@ -25,6 +34,8 @@ type OntologyDAGRepository interface {
 	GetActiveByProject(ctx context.Context, projectID uuid.UUID) (*models.OntologyDAG, error)
 	Update(ctx context.Context, dag *models.OntologyDAG) error
 	UpdateStatus(ctx context.Context, id uuid.UUID, status models.DAGStatus, currentNode *string) error
+	// UpdateTokensSpent records the LLM tokens the DAG has spent so far.
+	UpdateTokensSpent(ctx context.Context, id uuid.UUID, tokensSpent int) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	DeleteByProject(ctx context.Context, projectID uuid.UUID) error
 
@@ -82,18 +93,22 @@ func (r *ontologyDAGRepository) Create(ctx context.Context, dag *models.Ontology
 			id, project_id, datasource_id,
 			status, current_node, schema_fingerprint,
 			owner_id, last_heartbeat,
-			is_incremental, change_summary,
+			is_incremental, change_summary, tokens_spent,
 			started_at, completed_at, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`
 
 	_, err := scope.Conn.Exec(ctx, query,
 		dag.ID, dag.ProjectID, dag.DatasourceID,
 		dag.Status, dag.CurrentNode, dag.SchemaFingerprint,
 		dag.OwnerID, dag.LastHeartbeat,
-		dag.IsIncremental, changeSummaryJSON,
+		dag.IsIncremental, changeSummaryJSON, dag.TokensSpent,
 		dag.StartedAt, dag.CompletedAt, dag.CreatedAt, dag.UpdatedAt,
 	)
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == activeDAGIndex {
+			return apperrors.ErrConflict
+		}
 		return fmt.Errorf("failed to create DAG: %w", err)
 	}
 
@@ -110,13 +125,20 @@ func (r *ontologyDAGRepository) GetByID(ctx context.Context, id uuid.UUID) (*mod
 		SELECT id, project_id, datasource_id,
 		       status, current_node, schema_fingerprint,
 		       owner_id, last_heartbeat,
-		       is_incremental, change_summary,
+		       is_incremental, change_summary, tokens_spent,
 		       started_at, completed_at, created_at, updated_at
 		FROM engine_ontology_dag
 		WHERE id = $1`
 
 	row := scope.Conn.QueryRow(ctx, query, id)
-	return scanDAGRow(row)
+	dag, err := scanDAGRow(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, notFound("DAG")
+		}
+		return nil, err
+	}
+	return dag, nil
 }
 
 func (r *ontologyDAGRepository) GetByIDWithNodes(ctx context.Context, id uuid.UUID) (*models.OntologyDAG, error) {
@@ -147,7 +169,7 @@ func (r *ontologyDAGRepository) GetLatestByDatasource(ctx context.Context, datas
 		SELECT id, project_id, datasource_id,
 		       status, current_node, schema_fingerprint,
 		       owner_id, last_heartbeat,
-		       is_incremental, change_summary,
+		       is_incremental, change_summary, tokens_spent,
 		       started_at, completed_at, created_at, updated_at
 		FROM engine_ontology_dag
 		WHERE datasource_id = $1
@@ -157,7 +179,7 @@ func (r *ontologyDAGRepository) GetLatestByDatasource(ctx context.Context, datas
 	row := scope.Conn.QueryRow(ctx, query, datasourceID)
 	dag, err := scanDAGRow(row)
 	if err != nil {
-		if err == pgx.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
 		}
 		return nil, err
@@ -175,7 +197,7 @@ func (r *ontologyDAGRepository) GetLatestByProject(ctx context.Context, projectI
 		SELECT id, project_id, datasource_id,
 		       status, current_node, schema_fingerprint,
 		       owner_id, last_heartbeat,
-		       is_incremental, change_summary,
+		       is_incremental, change_summary, tokens_spent,
 		       started_at, completed_at, created_at, updated_at
 		FROM engine_ontology_dag
 		WHERE project_id = $1
@@ -185,7 +207,7 @@ func (r *ontologyDAGRepository) GetLatestByProject(ctx context.Context, projectI
 	row := scope.Conn.QueryRow(ctx, query, projectID)
 	dag, err := scanDAGRow(row)
 	if err != nil {
-		if err == pgx.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
 		}
 		return nil, err
@@ -203,7 +225,7 @@ func (r *ontologyDAGRepository) GetActiveByDatasource(ctx context.Context, datas
 		SELECT id, project_id, datasource_id,
 		       status, current_node, schema_fingerprint,
 		       owner_id, last_heartbeat,
-		       is_incremental, change_summary,
+		       is_incremental, change_summary, tokens_spent,
 		       started_at, completed_at, created_at, updated_at
 		FROM engine_ontology_dag
 		WHERE datasource_id = $1 AND status IN ('pending', 'running')
@@ -213,7 +235,7 @@ func (r *ontologyDAGRepository) GetActiveByDatasource(ctx context.Context, datas
 	row := scope.Conn.QueryRow(ctx, query, datasourceID)
 	dag, err := scanDAGRow(row)
 	if err != nil {
-		if err == pgx.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
 		}
 		return nil, err
@@ -231,7 +253,7 @@ func (r *ontologyDAGRepository) GetActiveByProject(ctx context.Context, projectI
 		SELECT id, project_id, datasource_id,
 		       status, current_node, schema_fingerprint,
 		       owner_id, last_heartbeat,
-		       is_incremental, change_summary,
+		       is_incremental, change_summary, tokens_spent,
 		       started_at, completed_at, created_at, updated_at
 		FROM engine_ontology_dag
 		WHERE project_id = $1 AND status IN ('pending', 'running')
@@ -241,7 +263,7 @@ func (r *ontologyDAGRepository) GetActiveByProject(ctx context.Context, projectI
 	row := scope.Conn.QueryRow(ctx, query, projectID)
 	dag, err := scanDAGRow(row)
 	if err != nil {
-		if err == pgx.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
 		}
 		return nil, err
@@ -275,15 +297,16 @@ func (r *ontologyDAGRepository) Update(ctx context.Context, dag *models.Ontology
 		    last_heartbeat = $6,
 		    is_incremental = $7,
 		    change_summary = $8,
-		    started_at = $9,
-		    completed_at = $10,
-		    updated_at = $11
+		    tokens_spent = $9,
+		    started_at = $10,
+		    completed_at = $11,
+		    updated_at = $12
 		WHERE id = $1`
 
 	result, err := scope.Conn.Exec(ctx, query,
 		dag.ID, dag.Status, dag.CurrentNode, dag.SchemaFingerprint,
 		dag.OwnerID, dag.LastHeartbeat,
-		dag.IsIncremental, changeSummaryJSON,
+		dag.IsIncremental, changeSummaryJSON, dag.TokensSpent,
 		dag.StartedAt, dag.CompletedAt, dag.UpdatedAt,
 	)
 	if err != nil {
@@ -291,7 +314,7 @@ func (r *ontologyDAGRepository) Update(ctx context.Context, dag *models.Ontology
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("DAG not found")
+		return notFound("DAG")
 	}
 
 	return nil
@@ -331,7 +354,31 @@ func (r *ontologyDAGRepository) UpdateStatus(ctx context.Context, id uuid.UUID,
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("DAG not found")
+		return notFound("DAG")
+	}
+
+	return nil
+}
+
+func (r *ontologyDAGRepository) UpdateTokensSpent(ctx context.Context, id uuid.UUID, tokensSpent int) error {
+	scope, ok := database.GetTenantScope(ctx)
+	if !ok {
+		return fmt.Errorf("no tenant scope in context")
+	}
+
+	query := `
+		UPDATE engine_ontology_dag
+		SET tokens_spent = $2,
+		    updated_at = NOW()
+		WHERE id = $1`
+
+	result, err := scope.Conn.Exec(ctx, query, id, tokensSpent)
+	if err != nil {
+		return fmt.Errorf("failed to update DAG tokens spent: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return notFound("DAG")
 	}
 
 	return nil
@@ -351,7 +398,7 @@ func (r *ontologyDAGRepository) Delete(ctx context.Context, id uuid.UUID) error
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("DAG not found")
+		return notFound("DAG")
 	}
 
 	return nil
@@ -394,7 +441,7 @@ func (r *ontologyDAGRepository) ClaimOwnership(ctx context.Context, dagID, owner
 	var returnedID uuid.UUID
 	err := scope.Conn.QueryRow(ctx, query, dagID, ownerID).Scan(&returnedID)
 	if err != nil {
-		if err == pgx.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return false, nil
 		}
 		return false, fmt.Errorf("failed to claim ownership: %w", err)
@@ -421,7 +468,7 @@ func (r *ontologyDAGRepository) UpdateHeartbeat(ctx context.Context, dagID, owne
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("DAG not found or not owned by this server")
+		return fmt.Errorf("DAG %w or not owned by this server", apperrors.ErrNotFound)
 	}
 
 	return nil
@@ -581,7 +628,7 @@ func (r *ontologyDAGRepository) UpdateNodeStatus(ctx context.Context, nodeID uui
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("node not found")
+		return notFound("node")
 	}
 
 	return nil
@@ -614,7 +661,7 @@ func (r *ontologyDAGRepository) UpdateNodeProgress(ctx context.Context, nodeID u
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("node not found")
+		return notFound("node")
 	}
 
 	return nil
@@ -638,7 +685,7 @@ func (r *ontologyDAGRepository) IncrementNodeRetryCount(ctx context.Context, nod
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("node not found")
+		return notFound("node")
 	}
 
 	return nil
@@ -664,7 +711,7 @@ func (r *ontologyDAGRepository) GetNextPendingNode(ctx context.Context, dagID uu
 	row := scope.Conn.QueryRow(ctx, query, dagID)
 	node, err := scanNodeRow(row)
 	if err != nil {
-		if err == pgx.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
 		}
 		return nil, err
@@ -684,11 +731,11 @@ func scanDAGRow(row pgx.Row) (*models.OntologyDAG, error) {
 		&dag.ID, &dag.ProjectID, &dag.DatasourceID,
 		&dag.Status, &dag.CurrentNode, &dag.SchemaFingerprint,
 		&dag.OwnerID, &dag.LastHeartbeat,
-		&dag.IsIncremental, &changeSummaryJSON,
+		&dag.IsIncremental, &changeSummaryJSON, &dag.TokensSpent,
 		&dag.StartedAt, &dag.CompletedAt, &dag.CreatedAt, &dag.UpdatedAt,
 	)
 	if err != nil {
-		if err == pgx.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, err
 		}
 		return nil, fmt.Errorf("failed to scan DAG: %w", err)
@@ -720,7 +767,7 @@ func scanNodeRow(row nodeScanner) (*models.DAGNode, error) {
 		&node.CreatedAt, &node.UpdatedAt,
 	)
 	if err != nil {
-		if err == pgx.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, err
 		}
 		return nil, fmt.Errorf("failed to scan node: %w", err)
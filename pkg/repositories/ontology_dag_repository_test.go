@@ -4,11 +4,13 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 
+	"github.com/ekaya-inc/ekaya-engine/pkg/apperrors"
 	"github.com/ekaya-inc/ekaya-engine/pkg/database"
 	"github.com/ekaya-inc/ekaya-engine/pkg/models"
 	"github.com/ekaya-inc/ekaya-engine/pkg/testhelpers"
@@ -256,6 +258,36 @@ func TestDAGRepository_GetActiveByDatasource(t *testing.T) {
 	}
 }
 
+func TestDAGRepository_Create_RejectsSecondActiveDAG(t *testing.T) {
+	tc := setupDAGTest(t)
+	tc.cleanup()
+
+	ctx, cleanup := tc.createTestContext()
+	defer cleanup()
+
+	first := tc.createTestDAG(ctx)
+
+	second := &models.OntologyDAG{
+		ID:           uuid.New(),
+		ProjectID:    tc.projectID,
+		DatasourceID: tc.datasourceID,
+
+		Status: models.DAGStatusRunning,
+	}
+	err := tc.repo.Create(ctx, second)
+	if !errors.Is(err, apperrors.ErrConflict) {
+		t.Fatalf("expected ErrConflict for a second active DAG, got %v", err)
+	}
+
+	active, err := tc.repo.GetActiveByDatasource(ctx, tc.datasourceID)
+	if err != nil {
+		t.Fatalf("GetActiveByDatasource failed: %v", err)
+	}
+	if active == nil || active.ID != first.ID {
+		t.Errorf("expected the first DAG to stay the only active one, got %+v", active)
+	}
+}
+
 func TestDAGRepository_UpdateStatus(t *testing.T) {
 	tc := setupDAGTest(t)
 	tc.cleanup()
@@ -300,6 +332,28 @@ func TestDAGRepository_UpdateStatus(t *testing.T) {
 	}
 }
 
+func TestDAGRepository_UpdateTokensSpent(t *testing.T) {
+	tc := setupDAGTest(t)
+	tc.cleanup()
+
+	ctx, cleanup := tc.createTestContext()
+	defer cleanup()
+
+	dag := tc.createTestDAG(ctx)
+	if dag.TokensSpent != 0 {
+		t.Errorf("expected a new DAG to have spent no tokens, got %d", dag.TokensSpent)
+	}
+
+	if err := tc.repo.UpdateTokensSpent(ctx, dag.ID, 12345); err != nil {
+		t.Fatalf("UpdateTokensSpent failed: %v", err)
+	}
+
+	updated, _ := tc.repo.GetByID(ctx, dag.ID)
+	if updated.TokensSpent != 12345 {
+		t.Errorf("expected tokens_spent 12345, got %d", updated.TokensSpent)
+	}
+}
+
 // ============================================================================
 // Node Tests
 // ============================================================================
@@ -3,7 +3,9 @@ package repositories
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -21,12 +23,14 @@ type QuestionCounts struct {
 
 // QuestionListFilters contains filtering and pagination options for listing questions.
 type QuestionListFilters struct {
-	Status   *models.QuestionStatus // Filter by status (nil = all)
-	Category *string                // Filter by category (nil = all)
-	Entity   *string                // Filter by entity in affects (nil = all)
-	Priority *int                   // Filter by priority (nil = all)
-	Limit    int                    // Max number of results (default 20)
-	Offset   int                    // Offset for pagination (default 0)
+	Status          *models.QuestionStatus // Filter by status (nil = all)
+	Category        *string                // Filter by category (nil = all)
+	Entity          *string                // Filter by entity in affects (nil = all)
+	Priority        *int                   // Filter by priority (nil = all)
+	IsRequired      *bool                  // Filter by required-ness (nil = all)
+	SourceEntityKey *string                // Filter by exact source entity key (nil = all)
+	Limit           int                    // Max number of results (default 20)
+	Offset          int                    // Offset for pagination (default 0)
 }
 
 // QuestionListResult contains paginated question results and counts by status.
@@ -230,7 +234,7 @@ func (r *ontologyQuestionRepository) GetByID(ctx context.Context, id uuid.UUID)
 	row := scope.Conn.QueryRow(ctx, query, id)
 	q, err := scanQuestionRow(row)
 	if err != nil {
-		if err == pgx.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("get question by id: %w", err)
@@ -292,7 +296,7 @@ func (r *ontologyQuestionRepository) GetNextPending(ctx context.Context, project
 	row := scope.Conn.QueryRow(ctx, query, projectID)
 	q, err := scanQuestionRow(row)
 	if err != nil {
-		if err == pgx.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("get next pending question: %w", err)
@@ -413,44 +417,8 @@ func (r *ontologyQuestionRepository) List(ctx context.Context, projectID uuid.UU
 		return nil, fmt.Errorf("no tenant scope in context")
 	}
 
-	// Build WHERE clause
-	whereClauses := []string{"project_id = $1"}
-	args := []interface{}{projectID}
-	argIdx := 2
-
-	if filters.Status != nil {
-		whereClauses = append(whereClauses, fmt.Sprintf("status = $%d", argIdx))
-		args = append(args, string(*filters.Status))
-		argIdx++
-	}
-
-	if filters.Category != nil {
-		whereClauses = append(whereClauses, fmt.Sprintf("category = $%d", argIdx))
-		args = append(args, *filters.Category)
-		argIdx++
-	}
-
-	if filters.Priority != nil {
-		whereClauses = append(whereClauses, fmt.Sprintf("priority = $%d", argIdx))
-		args = append(args, *filters.Priority)
-		argIdx++
-	}
-
-	if filters.Entity != nil {
-		// Search for entity name in affects.tables array or as source_entity_key
-		whereClauses = append(whereClauses, fmt.Sprintf("(source_entity_key = $%d OR affects::text ILIKE $%d)", argIdx, argIdx+1))
-		args = append(args, *filters.Entity)
-		args = append(args, fmt.Sprintf("%%\"%s\"%%", *filters.Entity))
-		argIdx += 2
-	}
-
-	whereClause := ""
-	if len(whereClauses) > 0 {
-		whereClause = "WHERE " + whereClauses[0]
-		for i := 1; i < len(whereClauses); i++ {
-			whereClause += " AND " + whereClauses[i]
-		}
-	}
+	whereClause, args := buildQuestionListWhere(projectID, filters, true)
+	argIdx := len(args) + 1
 
 	// Get total count with filters
 	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM engine_ontology_questions %s`, whereClause)
@@ -461,23 +429,7 @@ func (r *ontologyQuestionRepository) List(ctx context.Context, projectID uuid.UU
 	}
 
 	// Get counts by status for all questions (not filtered by status)
-	statusWhereClause := "WHERE project_id = $1"
-	statusArgs := []interface{}{projectID}
-	if filters.Category != nil {
-		statusWhereClause += " AND category = $2"
-		statusArgs = append(statusArgs, *filters.Category)
-	}
-	if filters.Priority != nil {
-		idx := len(statusArgs) + 1
-		statusWhereClause += fmt.Sprintf(" AND priority = $%d", idx)
-		statusArgs = append(statusArgs, *filters.Priority)
-	}
-	if filters.Entity != nil {
-		idx := len(statusArgs) + 1
-		statusWhereClause += fmt.Sprintf(" AND (source_entity_key = $%d OR affects::text ILIKE $%d)", idx, idx+1)
-		statusArgs = append(statusArgs, *filters.Entity)
-		statusArgs = append(statusArgs, fmt.Sprintf("%%\"%s\"%%", *filters.Entity))
-	}
+	statusWhereClause, statusArgs := buildQuestionListWhere(projectID, filters, false)
 
 	statusCountQuery := fmt.Sprintf(`
 		SELECT status, COUNT(*)
@@ -521,7 +473,7 @@ func (r *ontologyQuestionRepository) List(ctx context.Context, projectID uuid.UU
 		       status, status_reason, answer, answered_by, answered_at, created_at, updated_at
 		FROM engine_ontology_questions
 		%s
-		ORDER BY priority ASC, created_at ASC
+		ORDER BY priority ASC, created_at ASC, id ASC
 		LIMIT $%d OFFSET $%d`, whereClause, argIdx, argIdx+1)
 
 	args = append(args, limit, offset)
@@ -555,6 +507,47 @@ func (r *ontologyQuestionRepository) List(ctx context.Context, projectID uuid.UU
 // Helper Functions
 // ============================================================================
 
+// buildQuestionListWhere builds the WHERE clause and positional args for List.
+// When includeStatus is false the status filter is omitted so that per-status
+// counts can be computed across the otherwise-filtered set.
+func buildQuestionListWhere(projectID uuid.UUID, filters QuestionListFilters, includeStatus bool) (string, []any) {
+	clauses := []string{"project_id = $1"}
+	args := []any{projectID}
+
+	if includeStatus && filters.Status != nil {
+		args = append(args, string(*filters.Status))
+		clauses = append(clauses, fmt.Sprintf("status = $%d", len(args)))
+	}
+
+	if filters.Category != nil {
+		args = append(args, *filters.Category)
+		clauses = append(clauses, fmt.Sprintf("category = $%d", len(args)))
+	}
+
+	if filters.Priority != nil {
+		args = append(args, *filters.Priority)
+		clauses = append(clauses, fmt.Sprintf("priority = $%d", len(args)))
+	}
+
+	if filters.IsRequired != nil {
+		args = append(args, *filters.IsRequired)
+		clauses = append(clauses, fmt.Sprintf("is_required = $%d", len(args)))
+	}
+
+	if filters.SourceEntityKey != nil {
+		args = append(args, *filters.SourceEntityKey)
+		clauses = append(clauses, fmt.Sprintf("source_entity_key = $%d", len(args)))
+	}
+
+	if filters.Entity != nil {
+		// Search for entity name in affects.tables array or as source_entity_key
+		args = append(args, *filters.Entity, fmt.Sprintf("%%\"%s\"%%", *filters.Entity))
+		clauses = append(clauses, fmt.Sprintf("(source_entity_key = $%d OR affects::text ILIKE $%d)", len(args)-1, len(args)))
+	}
+
+	return "WHERE " + strings.Join(clauses, " AND "), args
+}
+
 func nullableString(s string) *string {
 	if s == "" {
 		return nil
@@ -592,6 +585,12 @@ func scanQuestionRow(row pgx.Row) (*models.OntologyQuestion, error) {
 	if answer != nil {
 		q.Answer = *answer
 	}
+	if sourceEntityType != nil {
+		q.SourceEntityType = *sourceEntityType
+	}
+	if sourceEntityKey != nil {
+		q.SourceEntityKey = *sourceEntityKey
+	}
 	q.Status = models.QuestionStatus(status)
 
 	if len(affectsJSON) > 0 {
@@ -634,6 +633,12 @@ func scanQuestionRows(rows pgx.Rows) (*models.OntologyQuestion, error) {
 	if answer != nil {
 		q.Answer = *answer
 	}
+	if sourceEntityType != nil {
+		q.SourceEntityType = *sourceEntityType
+	}
+	if sourceEntityKey != nil {
+		q.SourceEntityKey = *sourceEntityKey
+	}
 	q.Status = models.QuestionStatus(status)
 
 	if len(affectsJSON) > 0 {
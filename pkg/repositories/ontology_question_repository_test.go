@@ -4,7 +4,9 @@ package repositories
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -222,3 +224,148 @@ func TestCreate_ContentHashIsReturned(t *testing.T) {
 		t.Errorf("ContentHash mismatch: got %s, want %s", retrieved.ContentHash, expectedHash)
 	}
 }
+
+func TestList_FilterCombinations(t *testing.T) {
+	tc := setupQuestionTest(t)
+	defer tc.cleanup()
+
+	ctx, cleanup := tc.createTestContext()
+	defer cleanup()
+
+	questions := []*models.OntologyQuestion{
+		{
+			ProjectID:  tc.projectID,
+			Category:   models.QuestionCategoryEnumeration,
+			Text:       "What do order status values mean?",
+			Priority:   1,
+			IsRequired: true,
+			Affects:    &models.QuestionAffects{Tables: []string{"orders"}},
+		},
+		{
+			ProjectID:  tc.projectID,
+			Category:   models.QuestionCategoryTerminology,
+			Text:       "What is a 'tik'?",
+			Priority:   2,
+			IsRequired: false,
+			Affects:    &models.QuestionAffects{Tables: []string{"orders"}},
+		},
+		{
+			ProjectID:  tc.projectID,
+			Category:   models.QuestionCategoryEnumeration,
+			Text:       "What do user role values mean?",
+			Priority:   1,
+			IsRequired: true,
+			Affects:    &models.QuestionAffects{Tables: []string{"users"}},
+		},
+	}
+	if err := tc.repo.CreateBatch(ctx, questions); err != nil {
+		t.Fatalf("CreateBatch failed: %v", err)
+	}
+	if err := tc.repo.UpdateStatus(ctx, questions[2].ID, models.QuestionStatusAnswered); err != nil {
+		t.Fatalf("UpdateStatus failed: %v", err)
+	}
+
+	required := true
+	optional := false
+	pending := models.QuestionStatusPending
+	enumeration := models.QuestionCategoryEnumeration
+	orders := "orders"
+
+	tests := []struct {
+		name      string
+		filters   QuestionListFilters
+		wantTotal int
+		wantIDs   []uuid.UUID
+	}{
+		{"required only", QuestionListFilters{IsRequired: &required}, 2, []uuid.UUID{questions[0].ID, questions[2].ID}},
+		{"optional only", QuestionListFilters{IsRequired: &optional}, 1, []uuid.UUID{questions[1].ID}},
+		{"pending and required", QuestionListFilters{Status: &pending, IsRequired: &required}, 1, []uuid.UUID{questions[0].ID}},
+		{"category and source entity", QuestionListFilters{Category: &enumeration, SourceEntityKey: &orders}, 1, []uuid.UUID{questions[0].ID}},
+		{"source entity", QuestionListFilters{SourceEntityKey: &orders}, 2, []uuid.UUID{questions[0].ID, questions[1].ID}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tc.repo.List(ctx, tc.projectID, tt.filters)
+			if err != nil {
+				t.Fatalf("List failed: %v", err)
+			}
+			if result.TotalCount != tt.wantTotal {
+				t.Errorf("expected total %d, got %d", tt.wantTotal, result.TotalCount)
+			}
+			gotIDs := make(map[uuid.UUID]bool, len(result.Questions))
+			for _, q := range result.Questions {
+				gotIDs[q.ID] = true
+			}
+			for _, id := range tt.wantIDs {
+				if !gotIDs[id] {
+					t.Errorf("expected question %s in results", id)
+				}
+			}
+		})
+	}
+
+	// Source entity is returned with the question
+	result, err := tc.repo.List(ctx, tc.projectID, QuestionListFilters{SourceEntityKey: &orders, Limit: 1})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(result.Questions) != 1 {
+		t.Fatalf("expected 1 question with limit=1, got %d", len(result.Questions))
+	}
+	if result.Questions[0].SourceEntityKey != "orders" || result.Questions[0].SourceEntityType != "table" {
+		t.Errorf("expected source entity table/orders, got %s/%s",
+			result.Questions[0].SourceEntityType, result.Questions[0].SourceEntityKey)
+	}
+}
+
+func TestList_PaginationOrderingIsStable(t *testing.T) {
+	tc := setupQuestionTest(t)
+	defer tc.cleanup()
+
+	ctx, cleanup := tc.createTestContext()
+	defer cleanup()
+
+	// Same priority and created_at forces the id tiebreaker to decide the order.
+	createdAt := time.Now().Add(-time.Hour)
+	var questions []*models.OntologyQuestion
+	for i := 0; i < 6; i++ {
+		questions = append(questions, &models.OntologyQuestion{
+			ProjectID: tc.projectID,
+			Category:  models.QuestionCategoryDataQuality,
+			Text:      fmt.Sprintf("Is column_%d expected to be mostly NULL?", i),
+			Priority:  3,
+			CreatedAt: createdAt,
+		})
+	}
+	if err := tc.repo.CreateBatch(ctx, questions); err != nil {
+		t.Fatalf("CreateBatch failed: %v", err)
+	}
+
+	var paged []uuid.UUID
+	for offset := 0; offset < len(questions); offset += 2 {
+		page, err := tc.repo.List(ctx, tc.projectID, QuestionListFilters{Limit: 2, Offset: offset})
+		if err != nil {
+			t.Fatalf("List failed: %v", err)
+		}
+		if page.TotalCount != len(questions) {
+			t.Errorf("expected total %d, got %d", len(questions), page.TotalCount)
+		}
+		for _, q := range page.Questions {
+			paged = append(paged, q.ID)
+		}
+	}
+
+	full, err := tc.repo.List(ctx, tc.projectID, QuestionListFilters{Limit: 100})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(full.Questions) != len(paged) {
+		t.Fatalf("expected %d questions across pages, got %d", len(full.Questions), len(paged))
+	}
+	for i, q := range full.Questions {
+		if paged[i] != q.ID {
+			t.Errorf("position %d: paged order %s differs from full order %s", i, paged[i], q.ID)
+		}
+	}
+}
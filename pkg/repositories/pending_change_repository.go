@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -223,7 +224,7 @@ func (r *pendingChangeRepository) UpdateStatus(ctx context.Context, changeID uui
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("pending change not found")
+		return notFound("pending change")
 	}
 
 	return nil
@@ -379,7 +380,7 @@ func scanPendingChange(row pgx.Row) (*models.PendingChange, error) {
 		&c.CreatedAt,
 	)
 	if err != nil {
-		if err == pgx.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("failed to scan pending change: %w", err)
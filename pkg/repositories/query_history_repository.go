@@ -9,6 +9,7 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/ekaya-inc/ekaya-engine/pkg/apperrors"
 	"github.com/ekaya-inc/ekaya-engine/pkg/database"
 	"github.com/ekaya-inc/ekaya-engine/pkg/models"
 )
@@ -200,7 +201,7 @@ func (r *queryHistoryRepository) UpdateFeedback(ctx context.Context, projectID u
 	}
 
 	if tag.RowsAffected() == 0 {
-		return fmt.Errorf("query history entry not found or not owned by user")
+		return fmt.Errorf("query history entry %w or not owned by user", apperrors.ErrNotFound)
 	}
 
 	return nil
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -98,8 +99,8 @@ func (r *queryRepository) GetByID(ctx context.Context, projectID, queryID uuid.U
 	row := scope.Conn.QueryRow(ctx, sql, projectID, queryID)
 	q, err := scanQueryRow(row)
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("query not found")
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, notFound("query")
 		}
 		return nil, fmt.Errorf("failed to get query: %w", err)
 	}
@@ -194,7 +195,7 @@ func (r *queryRepository) updateTx(ctx context.Context, tx pgx.Tx, query *models
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("query not found")
+		return notFound("query")
 	}
 
 	return nil
@@ -212,7 +213,7 @@ func (r *queryRepository) updateEnabledStatusTx(ctx context.Context, tx pgx.Tx,
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("query not found")
+		return notFound("query")
 	}
 
 	return nil
@@ -259,7 +260,7 @@ func (r *queryRepository) SoftDelete(ctx context.Context, projectID, queryID uui
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("query not found")
+		return notFound("query")
 	}
 
 	return nil
@@ -349,7 +350,7 @@ func (r *queryRepository) HasEnabledQueries(ctx context.Context, projectID, data
 	var exists int
 	err := scope.Conn.QueryRow(ctx, sql, projectID, datasourceID).Scan(&exists)
 	if err != nil {
-		if err == pgx.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return false, nil
 		}
 		return false, fmt.Errorf("failed to check enabled queries: %w", err)
@@ -391,7 +392,7 @@ func (r *queryRepository) IncrementUsageCount(ctx context.Context, queryID uuid.
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("query not found")
+		return notFound("query")
 	}
 
 	return nil
@@ -572,7 +573,7 @@ func (r *queryRepository) UpdateApprovalStatus(ctx context.Context, projectID, q
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("query not found")
+		return notFound("query")
 	}
 
 	return nil
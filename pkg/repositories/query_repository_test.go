@@ -4,12 +4,14 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 
+	"github.com/ekaya-inc/ekaya-engine/pkg/apperrors"
 	"github.com/ekaya-inc/ekaya-engine/pkg/database"
 	"github.com/ekaya-inc/ekaya-engine/pkg/models"
 	"github.com/ekaya-inc/ekaya-engine/pkg/testhelpers"
@@ -364,6 +366,9 @@ func TestQueryRepository_GetByID_NotFound(t *testing.T) {
 	if err.Error() != "query not found" {
 		t.Errorf("expected 'query not found' error, got %q", err.Error())
 	}
+	if !errors.Is(err, apperrors.ErrNotFound) {
+		t.Errorf("expected error to wrap apperrors.ErrNotFound, got %v", err)
+	}
 }
 
 func TestQueryRepository_ListByDatasource(t *testing.T) {
@@ -0,0 +1,110 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/database"
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+)
+
+// RelationshipCheckpointRepository stores the join analysis results of relationship
+// candidate pairs while discovery runs, so an interrupted run can resume.
+type RelationshipCheckpointRepository interface {
+	// Save records a pair's join analysis, replacing any earlier result for the pair.
+	Save(ctx context.Context, checkpoint *models.RelationshipPairCheckpoint) error
+	// ListByDatasource returns the checkpoints of the datasource's unfinished run.
+	ListByDatasource(ctx context.Context, projectID, datasourceID uuid.UUID) ([]*models.RelationshipPairCheckpoint, error)
+	// DeleteByDatasource clears the datasource's checkpoints once a run completes.
+	DeleteByDatasource(ctx context.Context, projectID, datasourceID uuid.UUID) error
+}
+
+type relationshipCheckpointRepository struct{}
+
+// NewRelationshipCheckpointRepository creates a new RelationshipCheckpointRepository.
+func NewRelationshipCheckpointRepository() RelationshipCheckpointRepository {
+	return &relationshipCheckpointRepository{}
+}
+
+var _ RelationshipCheckpointRepository = (*relationshipCheckpointRepository)(nil)
+
+func (r *relationshipCheckpointRepository) Save(ctx context.Context, checkpoint *models.RelationshipPairCheckpoint) error {
+	scope, ok := database.GetTenantScope(ctx)
+	if !ok {
+		return fmt.Errorf("no tenant scope in context")
+	}
+
+	query := `
+		INSERT INTO engine_relationship_discovery_checkpoints (
+			project_id, datasource_id, source_column_id, target_column_id,
+			join_count, source_matched, target_matched, orphan_count, reverse_orphan_count
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (datasource_id, source_column_id, target_column_id)
+		DO UPDATE SET
+			join_count = EXCLUDED.join_count,
+			source_matched = EXCLUDED.source_matched,
+			target_matched = EXCLUDED.target_matched,
+			orphan_count = EXCLUDED.orphan_count,
+			reverse_orphan_count = EXCLUDED.reverse_orphan_count,
+			created_at = now()`
+
+	_, err := scope.Conn.Exec(ctx, query,
+		checkpoint.ProjectID, checkpoint.DatasourceID,
+		checkpoint.SourceColumnID, checkpoint.TargetColumnID,
+		checkpoint.JoinCount, checkpoint.SourceMatched, checkpoint.TargetMatched,
+		checkpoint.OrphanCount, checkpoint.ReverseOrphanCount)
+	if err != nil {
+		return fmt.Errorf("failed to save relationship checkpoint: %w", err)
+	}
+	return nil
+}
+
+func (r *relationshipCheckpointRepository) ListByDatasource(ctx context.Context, projectID, datasourceID uuid.UUID) ([]*models.RelationshipPairCheckpoint, error) {
+	scope, ok := database.GetTenantScope(ctx)
+	if !ok {
+		return nil, fmt.Errorf("no tenant scope in context")
+	}
+
+	query := `
+		SELECT project_id, datasource_id, source_column_id, target_column_id,
+		       join_count, source_matched, target_matched, orphan_count, reverse_orphan_count
+		FROM engine_relationship_discovery_checkpoints
+		WHERE project_id = $1 AND datasource_id = $2
+		ORDER BY created_at, source_column_id, target_column_id`
+
+	rows, err := scope.Conn.Query(ctx, query, projectID, datasourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list relationship checkpoints: %w", err)
+	}
+	defer rows.Close()
+
+	var checkpoints []*models.RelationshipPairCheckpoint
+	for rows.Next() {
+		var c models.RelationshipPairCheckpoint
+		if err := rows.Scan(&c.ProjectID, &c.DatasourceID,
+			&c.SourceColumnID, &c.TargetColumnID,
+			&c.JoinCount, &c.SourceMatched, &c.TargetMatched, &c.OrphanCount, &c.ReverseOrphanCount); err != nil {
+			return nil, fmt.Errorf("failed to scan relationship checkpoint: %w", err)
+		}
+		checkpoints = append(checkpoints, &c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate relationship checkpoints: %w", err)
+	}
+	return checkpoints, nil
+}
+
+func (r *relationshipCheckpointRepository) DeleteByDatasource(ctx context.Context, projectID, datasourceID uuid.UUID) error {
+	scope, ok := database.GetTenantScope(ctx)
+	if !ok {
+		return fmt.Errorf("no tenant scope in context")
+	}
+
+	query := `DELETE FROM engine_relationship_discovery_checkpoints WHERE project_id = $1 AND datasource_id = $2`
+	if _, err := scope.Conn.Exec(ctx, query, projectID, datasourceID); err != nil {
+		return fmt.Errorf("failed to delete relationship checkpoints: %w", err)
+	}
+	return nil
+}
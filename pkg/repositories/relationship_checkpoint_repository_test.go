@@ -0,0 +1,129 @@
+//go:build integration
+
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/database"
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+	"github.com/ekaya-inc/ekaya-engine/pkg/testhelpers"
+)
+
+type relationshipCheckpointTestContext struct {
+	t            *testing.T
+	engineDB     *testhelpers.EngineDB
+	repo         RelationshipCheckpointRepository
+	projectID    uuid.UUID
+	datasourceID uuid.UUID
+}
+
+func setupRelationshipCheckpointTest(t *testing.T) *relationshipCheckpointTestContext {
+	t.Helper()
+
+	tc := &relationshipCheckpointTestContext{
+		t:            t,
+		engineDB:     testhelpers.GetEngineDB(t),
+		repo:         NewRelationshipCheckpointRepository(),
+		projectID:    uuid.MustParse("00000000-0000-0000-0000-000000000072"),
+		datasourceID: uuid.MustParse("00000000-0000-0000-0000-000000000073"),
+	}
+	tc.ensureTestDatasource()
+	tc.cleanup()
+	return tc
+}
+
+func (tc *relationshipCheckpointTestContext) ensureTestDatasource() {
+	tc.t.Helper()
+
+	ctx := context.Background()
+	scope, err := tc.engineDB.DB.WithoutTenant(ctx)
+	require.NoError(tc.t, err)
+	defer scope.Close()
+
+	_, err = scope.Conn.Exec(ctx, `
+		INSERT INTO engine_projects (id, name, status)
+		VALUES ($1, $2, 'active')
+		ON CONFLICT (id) DO NOTHING
+	`, tc.projectID, "Relationship Checkpoint Test Project")
+	require.NoError(tc.t, err)
+
+	_, err = scope.Conn.Exec(ctx, `
+		INSERT INTO engine_datasources (id, project_id, name, datasource_type, datasource_config)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (id) DO NOTHING
+	`, tc.datasourceID, tc.projectID, "Relationship Checkpoint Test DS", "postgres", "{}")
+	require.NoError(tc.t, err)
+}
+
+func (tc *relationshipCheckpointTestContext) cleanup() {
+	tc.t.Helper()
+
+	ctx := context.Background()
+	scope, err := tc.engineDB.DB.WithoutTenant(ctx)
+	require.NoError(tc.t, err)
+	defer scope.Close()
+
+	_, _ = scope.Conn.Exec(ctx, `DELETE FROM engine_relationship_discovery_checkpoints WHERE project_id = $1`, tc.projectID)
+}
+
+func (tc *relationshipCheckpointTestContext) createTenantContext() (context.Context, func()) {
+	tc.t.Helper()
+
+	ctx := context.Background()
+	scope, err := tc.engineDB.DB.WithTenant(ctx, tc.projectID)
+	require.NoError(tc.t, err)
+
+	return database.SetTenantScope(ctx, scope), func() {
+		scope.Close()
+	}
+}
+
+func (tc *relationshipCheckpointTestContext) checkpoint(sourceColumnID, targetColumnID uuid.UUID, sourceMatched int64) *models.RelationshipPairCheckpoint {
+	return &models.RelationshipPairCheckpoint{
+		ProjectID:      tc.projectID,
+		DatasourceID:   tc.datasourceID,
+		SourceColumnID: sourceColumnID,
+		TargetColumnID: targetColumnID,
+		JoinCount:      sourceMatched * 2,
+		SourceMatched:  sourceMatched,
+		TargetMatched:  sourceMatched,
+	}
+}
+
+func TestRelationshipCheckpointRepository_SaveListDelete(t *testing.T) {
+	tc := setupRelationshipCheckpointTest(t)
+	defer tc.cleanup()
+
+	ctx, cleanup := tc.createTenantContext()
+	defer cleanup()
+
+	usersID, ordersUserID, reviewsUserID := uuid.New(), uuid.New(), uuid.New()
+	require.NoError(t, tc.repo.Save(ctx, tc.checkpoint(ordersUserID, usersID, 10)))
+	require.NoError(t, tc.repo.Save(ctx, tc.checkpoint(reviewsUserID, usersID, 5)))
+	// Saving a pair again replaces its result
+	require.NoError(t, tc.repo.Save(ctx, tc.checkpoint(ordersUserID, usersID, 12)))
+
+	checkpoints, err := tc.repo.ListByDatasource(ctx, tc.projectID, tc.datasourceID)
+	require.NoError(t, err)
+	require.Len(t, checkpoints, 2)
+	byPair := make(map[models.RelationshipPairKey]*models.RelationshipPairCheckpoint)
+	for _, cp := range checkpoints {
+		byPair[cp.PairKey()] = cp
+	}
+	orders := byPair[models.RelationshipPairKey{SourceColumnID: ordersUserID, TargetColumnID: usersID}]
+	require.NotNil(t, orders)
+	assert.Equal(t, int64(12), orders.SourceMatched)
+	assert.Equal(t, int64(24), orders.JoinCount)
+	assert.NotNil(t, byPair[models.RelationshipPairKey{SourceColumnID: reviewsUserID, TargetColumnID: usersID}])
+
+	require.NoError(t, tc.repo.DeleteByDatasource(ctx, tc.projectID, tc.datasourceID))
+	checkpoints, err = tc.repo.ListByDatasource(ctx, tc.projectID, tc.datasourceID)
+	require.NoError(t, err)
+	assert.Empty(t, checkpoints)
+}
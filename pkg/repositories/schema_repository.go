@@ -3,12 +3,14 @@ package repositories
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 
+	"github.com/ekaya-inc/ekaya-engine/pkg/adapters/datasource"
 	"github.com/ekaya-inc/ekaya-engine/pkg/apperrors"
 	"github.com/ekaya-inc/ekaya-engine/pkg/database"
 	"github.com/ekaya-inc/ekaya-engine/pkg/models"
@@ -82,6 +84,9 @@ type SchemaRepository interface {
 	GetRelationshipDetails(ctx context.Context, projectID, datasourceID uuid.UUID) ([]*models.RelationshipDetail, error)
 	GetEmptyTables(ctx context.Context, projectID, datasourceID uuid.UUID) ([]string, error)
 	GetOrphanTables(ctx context.Context, projectID, datasourceID uuid.UUID) ([]string, error)
+	// GetRelationshipStats aggregates the datasource's non-deleted relationships into counts
+	// by inference method, confidence band and approval status, plus the orphan table count.
+	GetRelationshipStats(ctx context.Context, projectID, datasourceID uuid.UUID) (*models.RelationshipStats, error)
 	UpsertRelationshipWithMetrics(ctx context.Context, rel *models.SchemaRelationship, metrics *models.DiscoveryMetrics) error
 	GetJoinableColumns(ctx context.Context, projectID, tableID uuid.UUID) ([]*models.SchemaColumn, error)
 	UpdateColumnJoinability(ctx context.Context, columnID uuid.UUID, rowCount, nonNullCount, distinctCount *int64, isJoinable *bool, joinabilityReason *string) error
@@ -185,8 +190,8 @@ func (r *schemaRepository) GetTableByID(ctx context.Context, projectID, tableID
 	row := scope.Conn.QueryRow(ctx, query, projectID, tableID)
 	t, err := scanSchemaTableRow(row)
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("table not found")
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, notFound("table")
 		}
 		return nil, fmt.Errorf("failed to get table: %w", err)
 	}
@@ -210,8 +215,8 @@ func (r *schemaRepository) GetTableByName(ctx context.Context, projectID, dataso
 	row := scope.Conn.QueryRow(ctx, query, projectID, datasourceID, schemaName, tableName)
 	t, err := scanSchemaTableRow(row)
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("table not found")
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, notFound("table")
 		}
 		return nil, fmt.Errorf("failed to get table: %w", err)
 	}
@@ -235,7 +240,7 @@ func (r *schemaRepository) FindTableByName(ctx context.Context, projectID, datas
 	row := scope.Conn.QueryRow(ctx, query, projectID, datasourceID, tableName)
 	t, err := scanSchemaTableRow(row)
 	if err != nil {
-		if err == pgx.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, fmt.Errorf("table not found: %s", tableName)
 		}
 		return nil, fmt.Errorf("failed to find table: %w", err)
@@ -285,7 +290,7 @@ func (r *schemaRepository) UpsertTable(ctx context.Context, table *models.Schema
 		table.CreatedAt = existingCreatedAt
 		return nil
 	}
-	if err != pgx.ErrNoRows {
+	if !errors.Is(err, pgx.ErrNoRows) {
 		return fmt.Errorf("failed to reactivate table: %w", err)
 	}
 
@@ -388,7 +393,7 @@ func (r *schemaRepository) UpdateTableSelection(ctx context.Context, projectID,
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("table not found")
+		return notFound("table")
 	}
 
 	return nil
@@ -463,7 +468,7 @@ func (r *schemaRepository) listColumnsByTableInternal(ctx context.Context, proje
 		SELECT id, project_id, schema_table_id, column_name, data_type,
 		       is_nullable, is_primary_key, is_unique, is_selected, ordinal_position,
 		       default_value, distinct_count, null_count, min_length, max_length,
-		       enum_values,
+		       enum_values, check_constraints,
 		       created_at, updated_at
 		FROM engine_schema_columns
 		WHERE project_id = $1 AND schema_table_id = $2 AND deleted_at IS NULL`
@@ -504,7 +509,7 @@ func (r *schemaRepository) ListColumnsByDatasource(ctx context.Context, projectI
 		SELECT c.id, c.project_id, c.schema_table_id, c.column_name, c.data_type,
 		       c.is_nullable, c.is_primary_key, c.is_unique, c.is_selected, c.ordinal_position,
 		       c.default_value, c.distinct_count, c.null_count, c.min_length, c.max_length,
-		       c.enum_values,
+		       c.enum_values, c.check_constraints,
 		       c.created_at, c.updated_at,
 		       c.row_count, c.non_null_count, c.is_joinable, c.joinability_reason, c.stats_updated_at
 		FROM engine_schema_columns c
@@ -548,7 +553,7 @@ func (r *schemaRepository) GetColumnsByTables(ctx context.Context, projectID uui
 		SELECT c.id, c.project_id, c.schema_table_id, c.column_name, c.data_type,
 		       c.is_nullable, c.is_primary_key, c.is_unique, c.is_selected, c.ordinal_position,
 		       c.default_value, c.distinct_count, c.null_count, c.min_length, c.max_length,
-		       c.enum_values,
+		       c.enum_values, c.check_constraints,
 		       c.created_at, c.updated_at,
 		       t.table_name
 		FROM engine_schema_columns c
@@ -571,12 +576,12 @@ func (r *schemaRepository) GetColumnsByTables(ctx context.Context, projectID uui
 	for rows.Next() {
 		var c models.SchemaColumn
 		var tableName string
-		var enumValuesJSON []byte
+		var enumValuesJSON, checkConstraintsJSON []byte
 		err := rows.Scan(
 			&c.ID, &c.ProjectID, &c.SchemaTableID, &c.ColumnName, &c.DataType,
 			&c.IsNullable, &c.IsPrimaryKey, &c.IsUnique, &c.IsSelected, &c.OrdinalPosition,
 			&c.DefaultValue, &c.DistinctCount, &c.NullCount, &c.MinLength, &c.MaxLength,
-			&enumValuesJSON,
+			&enumValuesJSON, &checkConstraintsJSON,
 			&c.CreatedAt, &c.UpdatedAt,
 			&tableName,
 		)
@@ -588,6 +593,11 @@ func (r *schemaRepository) GetColumnsByTables(ctx context.Context, projectID uui
 				return nil, fmt.Errorf("failed to unmarshal enum_values: %w", err)
 			}
 		}
+		if len(checkConstraintsJSON) > 0 {
+			if err := json.Unmarshal(checkConstraintsJSON, &c.CheckConstraints); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal check_constraints: %w", err)
+			}
+		}
 		result[tableName] = append(result[tableName], &c)
 	}
 	if err := rows.Err(); err != nil {
@@ -687,7 +697,7 @@ func (r *schemaRepository) GetColumnByID(ctx context.Context, projectID, columnI
 		SELECT id, project_id, schema_table_id, column_name, data_type,
 		       is_nullable, is_primary_key, is_unique, is_selected, ordinal_position,
 		       default_value, distinct_count, null_count, min_length, max_length,
-		       enum_values,
+		       enum_values, check_constraints,
 		       created_at, updated_at
 		FROM engine_schema_columns
 		WHERE project_id = $1 AND id = $2 AND deleted_at IS NULL`
@@ -695,7 +705,7 @@ func (r *schemaRepository) GetColumnByID(ctx context.Context, projectID, columnI
 	row := scope.Conn.QueryRow(ctx, query, projectID, columnID)
 	c, err := scanSchemaColumnRow(row)
 	if err != nil {
-		if err == pgx.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, apperrors.ErrNotFound
 		}
 		return nil, fmt.Errorf("failed to get column: %w", err)
@@ -714,7 +724,7 @@ func (r *schemaRepository) GetColumnByName(ctx context.Context, tableID uuid.UUI
 		SELECT id, project_id, schema_table_id, column_name, data_type,
 		       is_nullable, is_primary_key, is_unique, is_selected, ordinal_position,
 		       default_value, distinct_count, null_count, min_length, max_length,
-		       enum_values,
+		       enum_values, check_constraints,
 		       created_at, updated_at
 		FROM engine_schema_columns
 		WHERE schema_table_id = $1 AND column_name = $2 AND deleted_at IS NULL`
@@ -722,7 +732,7 @@ func (r *schemaRepository) GetColumnByName(ctx context.Context, tableID uuid.UUI
 	row := scope.Conn.QueryRow(ctx, query, tableID, columnName)
 	c, err := scanSchemaColumnRow(row)
 	if err != nil {
-		if err == pgx.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, apperrors.ErrNotFound
 		}
 		return nil, fmt.Errorf("failed to get column: %w", err)
@@ -753,6 +763,14 @@ func (r *schemaRepository) UpsertColumn(ctx context.Context, column *models.Sche
 			return fmt.Errorf("marshal enum_values: %w", marshalErr)
 		}
 	}
+	var checkConstraintsJSON []byte
+	if column.CheckConstraints != nil {
+		var marshalErr error
+		checkConstraintsJSON, marshalErr = json.Marshal(column.CheckConstraints)
+		if marshalErr != nil {
+			return fmt.Errorf("marshal check_constraints: %w", marshalErr)
+		}
+	}
 
 	// First, try to reactivate a soft-deleted record.
 	// Reactivation IS ontology-relevant, so we explicitly set updated_at.
@@ -767,7 +785,8 @@ func (r *schemaRepository) UpsertColumn(ctx context.Context, column *models.Sche
 		    default_value = $9,
 		    is_selected = $10,
 		    updated_at = $11,
-		    enum_values = $12
+		    enum_values = $12,
+		    check_constraints = $13
 		WHERE schema_table_id = $1
 		  AND column_name = $2
 		  AND project_id = $3
@@ -780,7 +799,7 @@ func (r *schemaRepository) UpsertColumn(ctx context.Context, column *models.Sche
 	err := scope.Conn.QueryRow(ctx, reactivateQuery,
 		column.SchemaTableID, column.ColumnName, column.ProjectID,
 		column.DataType, column.IsNullable, column.IsPrimaryKey, column.IsUnique, column.OrdinalPosition,
-		column.DefaultValue, column.IsSelected, now, enumValuesJSON,
+		column.DefaultValue, column.IsSelected, now, enumValuesJSON, checkConstraintsJSON,
 	).Scan(&existingID, &existingCreatedAt,
 		&existingDistinctCount, &existingNullCount)
 
@@ -792,7 +811,7 @@ func (r *schemaRepository) UpsertColumn(ctx context.Context, column *models.Sche
 		column.NullCount = existingNullCount
 		return nil
 	}
-	if err != pgx.ErrNoRows {
+	if !errors.Is(err, pgx.ErrNoRows) {
 		return fmt.Errorf("failed to reactivate column: %w", err)
 	}
 
@@ -803,9 +822,9 @@ func (r *schemaRepository) UpsertColumn(ctx context.Context, column *models.Sche
 		INSERT INTO engine_schema_columns (
 			id, project_id, schema_table_id, column_name, data_type,
 			is_nullable, is_primary_key, is_unique, is_selected, ordinal_position,
-			default_value, distinct_count, null_count, enum_values,
+			default_value, distinct_count, null_count, enum_values, check_constraints,
 			created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
 		ON CONFLICT (schema_table_id, column_name)
 			WHERE deleted_at IS NULL
 		DO UPDATE SET
@@ -816,13 +835,14 @@ func (r *schemaRepository) UpsertColumn(ctx context.Context, column *models.Sche
 			ordinal_position = EXCLUDED.ordinal_position,
 			default_value = EXCLUDED.default_value,
 			enum_values = EXCLUDED.enum_values,
+			check_constraints = EXCLUDED.check_constraints,
 			updated_at = EXCLUDED.updated_at
 		RETURNING id, created_at, is_selected, distinct_count, null_count`
 
 	err = scope.Conn.QueryRow(ctx, upsertQuery,
 		column.ID, column.ProjectID, column.SchemaTableID, column.ColumnName, column.DataType,
 		column.IsNullable, column.IsPrimaryKey, column.IsUnique, column.IsSelected, column.OrdinalPosition,
-		column.DefaultValue, column.DistinctCount, column.NullCount, enumValuesJSON,
+		column.DefaultValue, column.DistinctCount, column.NullCount, enumValuesJSON, checkConstraintsJSON,
 		column.CreatedAt, column.UpdatedAt,
 	).Scan(&column.ID, &column.CreatedAt, &column.IsSelected,
 		&column.DistinctCount, &column.NullCount)
@@ -940,7 +960,8 @@ func (r *schemaRepository) ListRelationshipsByDatasource(ctx context.Context, pr
 		       r.target_table_id, r.target_column_id, r.relationship_type,
 		       r.cardinality, r.confidence, r.inference_method, r.is_validated,
 		       r.validation_results, r.is_approved, r.source, r.last_edit_source,
-		       r.created_by, r.updated_by, r.created_at, r.updated_at
+		       r.created_by, r.updated_by, r.created_at, r.updated_at,
+		       r.join_clause
 		FROM engine_schema_relationships r
 		JOIN engine_schema_tables st ON r.source_table_id = st.id
 		WHERE r.project_id = $1 AND st.datasource_id = $2
@@ -980,15 +1001,16 @@ func (r *schemaRepository) GetRelationshipByID(ctx context.Context, projectID, r
 		       cardinality, confidence, inference_method, is_validated,
 		       validation_results, is_approved, source, last_edit_source,
 		       created_by, updated_by, created_at, updated_at,
-		       match_rate, source_distinct, target_distinct, matched_count, rejection_reason
+		       match_rate, source_distinct, target_distinct, matched_count, rejection_reason,
+		       join_clause
 		FROM engine_schema_relationships
 		WHERE project_id = $1 AND id = $2 AND deleted_at IS NULL`
 
 	row := scope.Conn.QueryRow(ctx, query, projectID, relationshipID)
 	rel, err := scanSchemaRelationshipRowWithDiscovery(row)
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("relationship not found")
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, notFound("relationship")
 		}
 		return nil, fmt.Errorf("failed to get relationship: %w", err)
 	}
@@ -1008,14 +1030,15 @@ func (r *schemaRepository) GetRelationshipByColumns(ctx context.Context, sourceC
 		       cardinality, confidence, inference_method, is_validated,
 		       validation_results, is_approved, source, last_edit_source,
 		       created_by, updated_by, created_at, updated_at,
-		       match_rate, source_distinct, target_distinct, matched_count, rejection_reason
+		       match_rate, source_distinct, target_distinct, matched_count, rejection_reason,
+		       join_clause
 		FROM engine_schema_relationships
 		WHERE source_column_id = $1 AND target_column_id = $2 AND deleted_at IS NULL`
 
 	row := scope.Conn.QueryRow(ctx, query, sourceColumnID, targetColumnID)
 	rel, err := scanSchemaRelationshipRowWithDiscovery(row)
 	if err != nil {
-		if err == pgx.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil // Not found is not an error for this lookup
 		}
 		return nil, fmt.Errorf("failed to get relationship: %w", err)
@@ -1067,21 +1090,36 @@ func (r *schemaRepository) UpsertRelationship(ctx context.Context, rel *models.S
 		return nil
 	}
 
+	if rel.JoinClause == nil {
+		joinClause, err := relationshipJoinClause(ctx, scope, rel.SourceColumnID, rel.TargetColumnID)
+		if err != nil {
+			return err
+		}
+		rel.JoinClause = &joinClause
+	}
+
 	insertSource, createdBy, insertLastEditSource, insertUpdatedBy, updateEditSource, updateUpdatedBy, protectCuratedState := relationshipWriteMetadata(ctx, rel)
 
-	// No soft-deleted record exists, do standard upsert on active records
+	// No soft-deleted record exists, do standard upsert on active records.
+	// Engine writes never replace the type, confidence, inference method or rejection of a
+	// user-defined (manual) relationship, so re-discovery cannot overwrite it.
 	upsertQuery := `
 		INSERT INTO engine_schema_relationships (
 			id, project_id, source_table_id, source_column_id,
 			target_table_id, target_column_id, relationship_type,
 			cardinality, confidence, inference_method, is_validated,
 			validation_results, is_approved, source, last_edit_source,
-			created_by, updated_by, rejection_reason, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
+			created_by, updated_by, rejection_reason, created_at, updated_at,
+			join_clause
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $24)
 		ON CONFLICT (source_column_id, target_column_id)
 			WHERE deleted_at IS NULL
 		DO UPDATE SET
-			relationship_type = EXCLUDED.relationship_type,
+			relationship_type = CASE
+				WHEN $21 AND engine_schema_relationships.relationship_type = 'manual'
+				THEN engine_schema_relationships.relationship_type
+				ELSE EXCLUDED.relationship_type
+			END,
 			cardinality = CASE
 				WHEN $21 AND (
 					engine_schema_relationships.last_edit_source IN ('mcp', 'manual')
@@ -1091,8 +1129,16 @@ func (r *schemaRepository) UpsertRelationship(ctx context.Context, rel *models.S
 				THEN engine_schema_relationships.cardinality
 				ELSE EXCLUDED.cardinality
 			END,
-			confidence = EXCLUDED.confidence,
-			inference_method = EXCLUDED.inference_method,
+			confidence = CASE
+				WHEN $21 AND engine_schema_relationships.relationship_type = 'manual'
+				THEN engine_schema_relationships.confidence
+				ELSE EXCLUDED.confidence
+			END,
+			inference_method = CASE
+				WHEN $21 AND engine_schema_relationships.relationship_type = 'manual'
+				THEN engine_schema_relationships.inference_method
+				ELSE EXCLUDED.inference_method
+			END,
 			is_validated = EXCLUDED.is_validated,
 			validation_results = EXCLUDED.validation_results,
 			is_approved = CASE
@@ -1112,7 +1158,12 @@ func (r *schemaRepository) UpsertRelationship(ctx context.Context, rel *models.S
 				WHEN $23::uuid IS NULL THEN engine_schema_relationships.updated_by
 				ELSE $23::uuid
 			END,
-			rejection_reason = EXCLUDED.rejection_reason,
+			rejection_reason = CASE
+				WHEN $21 AND engine_schema_relationships.relationship_type = 'manual'
+				THEN engine_schema_relationships.rejection_reason
+				ELSE EXCLUDED.rejection_reason
+			END,
+			join_clause = EXCLUDED.join_clause,
 			updated_at = EXCLUDED.updated_at
 		RETURNING id, created_at`
 
@@ -1122,7 +1173,7 @@ func (r *schemaRepository) UpsertRelationship(ctx context.Context, rel *models.S
 		rel.Cardinality, rel.Confidence, rel.InferenceMethod, rel.IsValidated,
 		validationResultsJSON, rel.IsApproved, insertSource, insertLastEditSource,
 		createdBy, insertUpdatedBy, rel.RejectionReason, rel.CreatedAt, rel.UpdatedAt,
-		protectCuratedState, updateEditSource, updateUpdatedBy,
+		protectCuratedState, updateEditSource, updateUpdatedBy, rel.JoinClause,
 	).Scan(&rel.ID, &rel.CreatedAt)
 
 	if err != nil {
@@ -1142,6 +1193,34 @@ func (r *schemaRepository) UpsertRelationship(ctx context.Context, rel *models.S
 	return nil
 }
 
+// relationshipJoinClause builds the single-column ON clause for a relationship from
+// its columns' current schema, table and column names, quoted in the dialect of the
+// datasource they belong to. Renaming a column or table creates a new column record
+// (and so a new relationship), so a clause written here never goes stale.
+func relationshipJoinClause(ctx context.Context, scope *database.TenantScope, sourceColumnID, targetColumnID uuid.UUID) (string, error) {
+	query := `
+		SELECT d.datasource_type,
+		       st.schema_name, st.table_name, sc.column_name,
+		       tt.schema_name, tt.table_name, tc.column_name
+		FROM engine_schema_columns sc
+		JOIN engine_schema_tables st ON sc.schema_table_id = st.id
+		JOIN engine_datasources d ON st.datasource_id = d.id
+		CROSS JOIN engine_schema_columns tc
+		JOIN engine_schema_tables tt ON tc.schema_table_id = tt.id
+		WHERE sc.id = $1 AND tc.id = $2`
+
+	var dsType, sourceSchema, sourceTable, sourceColumn, targetSchema, targetTable, targetColumn string
+	err := scope.Conn.QueryRow(ctx, query, sourceColumnID, targetColumnID).Scan(
+		&dsType, &sourceSchema, &sourceTable, &sourceColumn, &targetSchema, &targetTable, &targetColumn,
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up relationship columns for join clause: %w", err)
+	}
+
+	return models.BuildJoinClause(datasource.IdentifierQuoter(dsType), sourceSchema, sourceTable, targetSchema, targetTable,
+		[]models.JoinColumnPair{{SourceColumn: sourceColumn, TargetColumn: targetColumn}}), nil
+}
+
 func (r *schemaRepository) UpdateRelationshipApproval(ctx context.Context, projectID, relationshipID uuid.UUID, isApproved bool) error {
 	scope, ok := database.GetTenantScope(ctx)
 	if !ok {
@@ -1170,7 +1249,7 @@ func (r *schemaRepository) UpdateRelationshipApproval(ctx context.Context, proje
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("relationship not found")
+		return notFound("relationship")
 	}
 
 	return nil
@@ -1203,7 +1282,7 @@ func (r *schemaRepository) SoftDeleteRelationship(ctx context.Context, projectID
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("relationship not found")
+		return notFound("relationship")
 	}
 
 	return nil
@@ -1252,7 +1331,8 @@ func (r *schemaRepository) GetRelationshipsByMethod(ctx context.Context, project
 		       r.cardinality, r.confidence, r.inference_method, r.is_validated,
 		       r.validation_results, r.is_approved, r.source, r.last_edit_source,
 		       r.created_by, r.updated_by, r.created_at, r.updated_at,
-		       r.match_rate, r.source_distinct, r.target_distinct, r.matched_count, r.rejection_reason
+		       r.match_rate, r.source_distinct, r.target_distinct, r.matched_count, r.rejection_reason,
+		       r.join_clause
 		FROM engine_schema_relationships r
 		JOIN engine_schema_tables st ON r.source_table_id = st.id
 		WHERE r.project_id = $1 AND st.datasource_id = $2
@@ -1310,6 +1390,7 @@ func (r *schemaRepository) GetRelationshipDetails(ctx context.Context, projectID
 			r.source,
 			r.last_edit_source,
 			COALESCE(r.last_edit_source, r.source) AS effective_source,
+			r.join_clause,
 			r.created_by,
 			r.updated_by,
 			r.created_at,
@@ -1353,7 +1434,7 @@ func (r *schemaRepository) GetRelationshipDetails(ctx context.Context, projectID
 			&d.TargetTableName, &d.TargetColumnName, &d.TargetColumnType,
 			&d.RelationshipType, &d.Cardinality, &d.Confidence,
 			&d.InferenceMethod, &d.IsValidated, &d.IsApproved,
-			&d.Source, &d.LastEditSource, &d.EffectiveSource, &d.CreatedBy, &d.UpdatedBy,
+			&d.Source, &d.LastEditSource, &d.EffectiveSource, &d.JoinClause, &d.CreatedBy, &d.UpdatedBy,
 			&d.CreatedAt, &d.UpdatedAt,
 		)
 		if err != nil {
@@ -1477,6 +1558,94 @@ func (r *schemaRepository) GetOrphanTables(ctx context.Context, projectID, datas
 	return tables, nil
 }
 
+func (r *schemaRepository) GetRelationshipStats(ctx context.Context, projectID, datasourceID uuid.UUID) (*models.RelationshipStats, error) {
+	scope, ok := database.GetTenantScope(ctx)
+	if !ok {
+		return nil, fmt.Errorf("no tenant scope in context")
+	}
+
+	// Group once by every dimension and roll up in Go, so each relationship is read once.
+	query := `
+		SELECT COALESCE(r.inference_method, 'unknown') AS method,
+		       CASE
+		           WHEN r.confidence >= $3 THEN 'high'
+		           WHEN r.confidence >= $4 THEN 'medium'
+		           ELSE 'low'
+		       END AS confidence_band,
+		       r.is_approved,
+		       r.rejection_reason IS NOT NULL AS is_rejected_candidate,
+		       COUNT(*)
+		FROM engine_schema_relationships r
+		JOIN engine_schema_tables st ON r.source_table_id = st.id
+		WHERE r.project_id = $1 AND st.datasource_id = $2
+		  AND r.deleted_at IS NULL AND st.deleted_at IS NULL
+		GROUP BY 1, 2, 3, 4`
+
+	rows, err := scope.Conn.Query(ctx, query, projectID, datasourceID,
+		models.RelationshipConfidenceHigh, models.RelationshipConfidenceMedium)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get relationship stats: %w", err)
+	}
+	defer rows.Close()
+
+	stats := &models.RelationshipStats{ByMethod: make(map[string]int)}
+	for rows.Next() {
+		var method, band string
+		var isApproved *bool
+		var rejectedCandidate bool
+		var count int
+		if err := rows.Scan(&method, &band, &isApproved, &rejectedCandidate, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan relationship stats: %w", err)
+		}
+		if rejectedCandidate {
+			stats.RejectedCandidates += count
+			continue
+		}
+
+		stats.TotalRelationships += count
+		stats.ByMethod[method] += count
+		switch band {
+		case "high":
+			stats.Confidence.High += count
+		case "medium":
+			stats.Confidence.Medium += count
+		default:
+			stats.Confidence.Low += count
+		}
+		switch {
+		case isApproved == nil:
+			stats.Approval.Pending += count
+		case *isApproved:
+			stats.Approval.Approved += count
+		default:
+			stats.Approval.Rejected += count
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating relationship stats: %w", err)
+	}
+
+	// Same classification as GetOrphanTables
+	orphanQuery := `
+		SELECT COUNT(*)
+		FROM engine_schema_tables t
+		WHERE t.project_id = $1
+		  AND t.datasource_id = $2
+		  AND t.deleted_at IS NULL
+		  AND t.row_count > 0
+		  AND NOT EXISTS (
+			  SELECT 1 FROM engine_schema_relationships r
+			  WHERE r.deleted_at IS NULL
+			    AND r.rejection_reason IS NULL
+			    AND (r.source_table_id = t.id OR r.target_table_id = t.id)
+		  )`
+	if err := scope.Conn.QueryRow(ctx, orphanQuery, projectID, datasourceID).Scan(&stats.OrphanTables); err != nil {
+		return nil, fmt.Errorf("failed to count orphan tables: %w", err)
+	}
+
+	return stats, nil
+}
+
 func (r *schemaRepository) UpsertRelationshipWithMetrics(ctx context.Context, rel *models.SchemaRelationship, metrics *models.DiscoveryMetrics) error {
 	scope, ok := database.GetTenantScope(ctx)
 	if !ok {
@@ -1528,9 +1697,21 @@ func (r *schemaRepository) UpsertRelationshipWithMetrics(ctx context.Context, re
 		return nil
 	}
 
+	if rel.JoinClause == nil {
+		joinClause, err := relationshipJoinClause(ctx, scope, rel.SourceColumnID, rel.TargetColumnID)
+		if err != nil {
+			return err
+		}
+		rel.JoinClause = &joinClause
+	}
+
 	insertSource, createdBy, insertLastEditSource, insertUpdatedBy, updateEditSource, updateUpdatedBy, protectCuratedState := relationshipWriteMetadata(ctx, rel)
 
-	// No soft-deleted record exists, do standard upsert on active records
+	// No soft-deleted record exists, do standard upsert on active records.
+	// Discovery metrics and validation results are only replaced when this pass measured
+	// them, so a re-discovery without metrics keeps the previously stored values.
+	// As in UpsertRelationship, engine writes leave manual relationships' type, confidence,
+	// inference method and rejection untouched.
 	upsertQuery := `
 		INSERT INTO engine_schema_relationships (
 			id, project_id, source_table_id, source_column_id,
@@ -1538,12 +1719,17 @@ func (r *schemaRepository) UpsertRelationshipWithMetrics(ctx context.Context, re
 			cardinality, confidence, inference_method, is_validated,
 			validation_results, is_approved, match_rate, source_distinct,
 			target_distinct, matched_count, source, last_edit_source,
-			created_by, updated_by, rejection_reason, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24)
+			created_by, updated_by, rejection_reason, created_at, updated_at,
+			join_clause
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $28)
 		ON CONFLICT (source_column_id, target_column_id)
 			WHERE deleted_at IS NULL
 		DO UPDATE SET
-			relationship_type = EXCLUDED.relationship_type,
+			relationship_type = CASE
+				WHEN $25 AND engine_schema_relationships.relationship_type = 'manual'
+				THEN engine_schema_relationships.relationship_type
+				ELSE EXCLUDED.relationship_type
+			END,
 			cardinality = CASE
 				WHEN $25 AND (
 					engine_schema_relationships.last_edit_source IN ('mcp', 'manual')
@@ -1553,10 +1739,18 @@ func (r *schemaRepository) UpsertRelationshipWithMetrics(ctx context.Context, re
 				THEN engine_schema_relationships.cardinality
 				ELSE EXCLUDED.cardinality
 			END,
-			confidence = EXCLUDED.confidence,
-			inference_method = EXCLUDED.inference_method,
+			confidence = CASE
+				WHEN $25 AND engine_schema_relationships.relationship_type = 'manual'
+				THEN engine_schema_relationships.confidence
+				ELSE EXCLUDED.confidence
+			END,
+			inference_method = CASE
+				WHEN $25 AND engine_schema_relationships.relationship_type = 'manual'
+				THEN engine_schema_relationships.inference_method
+				ELSE EXCLUDED.inference_method
+			END,
 			is_validated = EXCLUDED.is_validated,
-			validation_results = EXCLUDED.validation_results,
+			validation_results = COALESCE(EXCLUDED.validation_results, engine_schema_relationships.validation_results),
 			is_approved = CASE
 				WHEN $25 AND (
 					engine_schema_relationships.last_edit_source IN ('mcp', 'manual')
@@ -1566,10 +1760,10 @@ func (r *schemaRepository) UpsertRelationshipWithMetrics(ctx context.Context, re
 				THEN engine_schema_relationships.is_approved
 				ELSE EXCLUDED.is_approved
 			END,
-			match_rate = EXCLUDED.match_rate,
-			source_distinct = EXCLUDED.source_distinct,
-			target_distinct = EXCLUDED.target_distinct,
-			matched_count = EXCLUDED.matched_count,
+			match_rate = COALESCE(EXCLUDED.match_rate, engine_schema_relationships.match_rate),
+			source_distinct = COALESCE(EXCLUDED.source_distinct, engine_schema_relationships.source_distinct),
+			target_distinct = COALESCE(EXCLUDED.target_distinct, engine_schema_relationships.target_distinct),
+			matched_count = COALESCE(EXCLUDED.matched_count, engine_schema_relationships.matched_count),
 			last_edit_source = CASE
 				WHEN $26::text IS NULL THEN engine_schema_relationships.last_edit_source
 				ELSE $26::text
@@ -1578,7 +1772,12 @@ func (r *schemaRepository) UpsertRelationshipWithMetrics(ctx context.Context, re
 				WHEN $27::uuid IS NULL THEN engine_schema_relationships.updated_by
 				ELSE $27::uuid
 			END,
-			rejection_reason = EXCLUDED.rejection_reason,
+			rejection_reason = CASE
+				WHEN $25 AND engine_schema_relationships.relationship_type = 'manual'
+				THEN engine_schema_relationships.rejection_reason
+				ELSE EXCLUDED.rejection_reason
+			END,
+			join_clause = EXCLUDED.join_clause,
 			updated_at = EXCLUDED.updated_at
 		RETURNING id, created_at`
 
@@ -1589,7 +1788,7 @@ func (r *schemaRepository) UpsertRelationshipWithMetrics(ctx context.Context, re
 		validationResultsJSON, rel.IsApproved, rel.MatchRate, rel.SourceDistinct,
 		rel.TargetDistinct, rel.MatchedCount, insertSource, insertLastEditSource,
 		createdBy, insertUpdatedBy, rel.RejectionReason, rel.CreatedAt, rel.UpdatedAt,
-		protectCuratedState, updateEditSource, updateUpdatedBy,
+		protectCuratedState, updateEditSource, updateUpdatedBy, rel.JoinClause,
 	).Scan(&rel.ID, &rel.CreatedAt)
 
 	if err != nil {
@@ -1619,6 +1818,7 @@ func (r *schemaRepository) GetJoinableColumns(ctx context.Context, projectID, ta
 		SELECT id, project_id, schema_table_id, column_name, data_type,
 		       is_nullable, is_primary_key, is_unique, is_selected, ordinal_position,
 		       default_value, distinct_count, null_count, min_length, max_length,
+		       enum_values, check_constraints,
 		       created_at, updated_at,
 		       row_count, non_null_count, is_joinable, joinability_reason, stats_updated_at
 		FROM engine_schema_columns
@@ -1688,7 +1888,7 @@ func (r *schemaRepository) GetPrimaryKeyColumns(ctx context.Context, projectID,
 		SELECT c.id, c.project_id, c.schema_table_id, c.column_name, c.data_type,
 		       c.is_nullable, c.is_primary_key, c.is_unique, c.is_selected, c.ordinal_position,
 		       c.default_value, c.distinct_count, c.null_count, c.min_length, c.max_length,
-		       c.enum_values,
+		       c.enum_values, c.check_constraints,
 		       c.created_at, c.updated_at,
 		       c.row_count, c.non_null_count, c.is_joinable, c.joinability_reason, c.stats_updated_at
 		FROM engine_schema_columns c
@@ -1733,7 +1933,7 @@ func (r *schemaRepository) GetNonPKColumnsByExactType(ctx context.Context, proje
 		SELECT c.id, c.project_id, c.schema_table_id, c.column_name, c.data_type,
 		       c.is_nullable, c.is_primary_key, c.is_unique, c.is_selected, c.ordinal_position,
 		       c.default_value, c.distinct_count, c.null_count, c.min_length, c.max_length,
-		       c.enum_values,
+		       c.enum_values, c.check_constraints,
 		       c.created_at, c.updated_at,
 		       c.row_count, c.non_null_count, c.is_joinable, c.joinability_reason, c.stats_updated_at
 		FROM engine_schema_columns c
@@ -1797,12 +1997,12 @@ func scanSchemaTableRow(row pgx.Row) (*models.SchemaTable, error) {
 
 func scanSchemaColumn(rows pgx.Rows) (*models.SchemaColumn, error) {
 	var c models.SchemaColumn
-	var enumValuesJSON []byte
+	var enumValuesJSON, checkConstraintsJSON []byte
 	err := rows.Scan(
 		&c.ID, &c.ProjectID, &c.SchemaTableID, &c.ColumnName, &c.DataType,
 		&c.IsNullable, &c.IsPrimaryKey, &c.IsUnique, &c.IsSelected, &c.OrdinalPosition,
 		&c.DefaultValue, &c.DistinctCount, &c.NullCount, &c.MinLength, &c.MaxLength,
-		&enumValuesJSON,
+		&enumValuesJSON, &checkConstraintsJSON,
 		&c.CreatedAt, &c.UpdatedAt,
 	)
 	if err != nil {
@@ -1813,17 +2013,22 @@ func scanSchemaColumn(rows pgx.Rows) (*models.SchemaColumn, error) {
 			return nil, fmt.Errorf("failed to unmarshal enum_values: %w", err)
 		}
 	}
+	if len(checkConstraintsJSON) > 0 {
+		if err := json.Unmarshal(checkConstraintsJSON, &c.CheckConstraints); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal check_constraints: %w", err)
+		}
+	}
 	return &c, nil
 }
 
 func scanSchemaColumnRow(row pgx.Row) (*models.SchemaColumn, error) {
 	var c models.SchemaColumn
-	var enumValuesJSON []byte
+	var enumValuesJSON, checkConstraintsJSON []byte
 	err := row.Scan(
 		&c.ID, &c.ProjectID, &c.SchemaTableID, &c.ColumnName, &c.DataType,
 		&c.IsNullable, &c.IsPrimaryKey, &c.IsUnique, &c.IsSelected, &c.OrdinalPosition,
 		&c.DefaultValue, &c.DistinctCount, &c.NullCount, &c.MinLength, &c.MaxLength,
-		&enumValuesJSON,
+		&enumValuesJSON, &checkConstraintsJSON,
 		&c.CreatedAt, &c.UpdatedAt,
 	)
 	if err != nil {
@@ -1834,6 +2039,11 @@ func scanSchemaColumnRow(row pgx.Row) (*models.SchemaColumn, error) {
 			return nil, fmt.Errorf("failed to unmarshal enum_values: %w", err)
 		}
 	}
+	if len(checkConstraintsJSON) > 0 {
+		if err := json.Unmarshal(checkConstraintsJSON, &c.CheckConstraints); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal check_constraints: %w", err)
+		}
+	}
 	return &c, nil
 }
 
@@ -1846,6 +2056,7 @@ func scanSchemaRelationship(rows pgx.Rows) (*models.SchemaRelationship, error) {
 		&rel.Cardinality, &rel.Confidence, &rel.InferenceMethod, &rel.IsValidated,
 		&validationResultsJSON, &rel.IsApproved, &rel.Source, &rel.LastEditSource,
 		&rel.CreatedBy, &rel.UpdatedBy, &rel.CreatedAt, &rel.UpdatedAt,
+		&rel.JoinClause,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to scan relationship: %w", err)
@@ -1868,6 +2079,7 @@ func scanSchemaRelationshipRow(row pgx.Row) (*models.SchemaRelationship, error)
 		&rel.Cardinality, &rel.Confidence, &rel.InferenceMethod, &rel.IsValidated,
 		&validationResultsJSON, &rel.IsApproved, &rel.Source, &rel.LastEditSource,
 		&rel.CreatedBy, &rel.UpdatedBy, &rel.CreatedAt, &rel.UpdatedAt,
+		&rel.JoinClause,
 	)
 	if err != nil {
 		return nil, err
@@ -1892,6 +2104,7 @@ func scanSchemaRelationshipWithDiscovery(rows pgx.Rows) (*models.SchemaRelations
 		&validationResultsJSON, &rel.IsApproved, &rel.Source, &rel.LastEditSource,
 		&rel.CreatedBy, &rel.UpdatedBy, &rel.CreatedAt, &rel.UpdatedAt,
 		&rel.MatchRate, &rel.SourceDistinct, &rel.TargetDistinct, &rel.MatchedCount, &rel.RejectionReason,
+		&rel.JoinClause,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to scan relationship with discovery: %w", err)
@@ -1916,6 +2129,7 @@ func scanSchemaRelationshipRowWithDiscovery(row pgx.Row) (*models.SchemaRelation
 		&validationResultsJSON, &rel.IsApproved, &rel.Source, &rel.LastEditSource,
 		&rel.CreatedBy, &rel.UpdatedBy, &rel.CreatedAt, &rel.UpdatedAt,
 		&rel.MatchRate, &rel.SourceDistinct, &rel.TargetDistinct, &rel.MatchedCount, &rel.RejectionReason,
+		&rel.JoinClause,
 	)
 	if err != nil {
 		return nil, err
@@ -1932,12 +2146,12 @@ func scanSchemaRelationshipRowWithDiscovery(row pgx.Row) (*models.SchemaRelation
 // scanSchemaColumnWithDiscovery scans a column row including discovery fields.
 func scanSchemaColumnWithDiscovery(rows pgx.Rows) (*models.SchemaColumn, error) {
 	var c models.SchemaColumn
-	var enumValuesJSON []byte
+	var enumValuesJSON, checkConstraintsJSON []byte
 	err := rows.Scan(
 		&c.ID, &c.ProjectID, &c.SchemaTableID, &c.ColumnName, &c.DataType,
 		&c.IsNullable, &c.IsPrimaryKey, &c.IsUnique, &c.IsSelected, &c.OrdinalPosition,
 		&c.DefaultValue, &c.DistinctCount, &c.NullCount, &c.MinLength, &c.MaxLength,
-		&enumValuesJSON,
+		&enumValuesJSON, &checkConstraintsJSON,
 		&c.CreatedAt, &c.UpdatedAt,
 		&c.RowCount, &c.NonNullCount, &c.IsJoinable, &c.JoinabilityReason, &c.StatsUpdatedAt,
 	)
@@ -1949,6 +2163,11 @@ func scanSchemaColumnWithDiscovery(rows pgx.Rows) (*models.SchemaColumn, error)
 			return nil, fmt.Errorf("failed to unmarshal enum_values: %w", err)
 		}
 	}
+	if len(checkConstraintsJSON) > 0 {
+		if err := json.Unmarshal(checkConstraintsJSON, &c.CheckConstraints); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal check_constraints: %w", err)
+		}
+	}
 	return &c, nil
 }
 
@@ -1205,6 +1205,11 @@ func TestSchemaRepository_UpsertRelationship_Create(t *testing.T) {
 	if retrieved.Cardinality != models.CardinalityNTo1 {
 		t.Errorf("expected Cardinality 'N:1', got %q", retrieved.Cardinality)
 	}
+
+	expectedClause := `"public"."orders"."user_id" = "public"."users"."id"`
+	if retrieved.JoinClause == nil || *retrieved.JoinClause != expectedClause {
+		t.Errorf("expected JoinClause %q, got %v", expectedClause, retrieved.JoinClause)
+	}
 }
 
 func TestSchemaRepository_UpsertRelationship_Create_WithManualProvenance(t *testing.T) {
@@ -1346,6 +1351,190 @@ func TestSchemaRepository_UpsertRelationshipWithMetrics_PreservesCuratedFields(t
 	}
 }
 
+func TestSchemaRepository_ManualRelationshipSurvivesRediscovery(t *testing.T) {
+	tc := setupSchemaTest(t)
+	tc.cleanup()
+
+	inferredCtx, inferredCleanup := tc.createTestContextWithSource(models.SourceInferred, tc.userID)
+	defer inferredCleanup()
+
+	manualCtx, manualCleanup := tc.createTestContextWithSource(models.SourceManual, tc.userID)
+	defer manualCleanup()
+
+	accountsTable := tc.createTestTable(inferredCtx, "public", "accounts")
+	accountCodeCol := tc.createTestColumn(inferredCtx, accountsTable.ID, "code", 1)
+
+	paymentsTable := tc.createTestTable(inferredCtx, "public", "payments")
+	paymentRefCol := tc.createTestColumn(inferredCtx, paymentsTable.ID, "account_ref", 2)
+
+	manualType := models.RelationshipTypeManual
+	isApproved := true
+	manual := &models.SchemaRelationship{
+		ProjectID:        tc.projectID,
+		SourceTableID:    paymentsTable.ID,
+		SourceColumnID:   paymentRefCol.ID,
+		TargetTableID:    accountsTable.ID,
+		TargetColumnID:   accountCodeCol.ID,
+		RelationshipType: models.RelationshipTypeManual,
+		Cardinality:      models.CardinalityNTo1,
+		Confidence:       1.0,
+		InferenceMethod:  &manualType,
+		IsApproved:       &isApproved,
+	}
+	if err := tc.repo.UpsertRelationship(manualCtx, manual); err != nil {
+		t.Fatalf("manual UpsertRelationship failed: %v", err)
+	}
+
+	// A discovery run: reset engine-owned relationships, then rediscover the same column pair
+	if _, err := tc.repo.DeleteInferredRelationshipsByProject(inferredCtx, tc.projectID); err != nil {
+		t.Fatalf("DeleteInferredRelationshipsByProject failed: %v", err)
+	}
+	inferenceMethod := models.InferenceMethodColumnFeatures
+	rejected := "low match rate"
+	notApproved := false
+	rediscovered := &models.SchemaRelationship{
+		ProjectID:        tc.projectID,
+		SourceTableID:    paymentsTable.ID,
+		SourceColumnID:   paymentRefCol.ID,
+		TargetTableID:    accountsTable.ID,
+		TargetColumnID:   accountCodeCol.ID,
+		RelationshipType: models.RelationshipTypeInferred,
+		Cardinality:      models.Cardinality1To1,
+		Confidence:       0.3,
+		InferenceMethod:  &inferenceMethod,
+		IsApproved:       &notApproved,
+		RejectionReason:  &rejected,
+	}
+	metrics := &models.DiscoveryMetrics{MatchRate: 0.3, SourceDistinct: 10, TargetDistinct: 10, MatchedCount: 3}
+	if err := tc.repo.UpsertRelationshipWithMetrics(inferredCtx, rediscovered, metrics); err != nil {
+		t.Fatalf("UpsertRelationshipWithMetrics failed: %v", err)
+	}
+
+	retrieved, err := tc.repo.GetRelationshipByID(inferredCtx, tc.projectID, manual.ID)
+	if err != nil {
+		t.Fatalf("GetRelationshipByID failed: %v", err)
+	}
+	if retrieved.RelationshipType != models.RelationshipTypeManual {
+		t.Errorf("expected relationship_type %q, got %q", models.RelationshipTypeManual, retrieved.RelationshipType)
+	}
+	if retrieved.InferenceMethod == nil || *retrieved.InferenceMethod != models.RelationshipTypeManual {
+		t.Errorf("expected inference_method %q, got %v", models.RelationshipTypeManual, retrieved.InferenceMethod)
+	}
+	if retrieved.Confidence != 1.0 {
+		t.Errorf("expected confidence 1.0, got %.2f", retrieved.Confidence)
+	}
+	if retrieved.IsApproved == nil || !*retrieved.IsApproved {
+		t.Errorf("expected is_approved=true, got %v", retrieved.IsApproved)
+	}
+	if retrieved.Cardinality != models.CardinalityNTo1 {
+		t.Errorf("expected cardinality %q, got %q", models.CardinalityNTo1, retrieved.Cardinality)
+	}
+	if retrieved.RejectionReason != nil {
+		t.Errorf("expected no rejection reason, got %q", *retrieved.RejectionReason)
+	}
+}
+
+func TestSchemaRepository_UpsertRelationshipWithMetrics_RoundTripAndRetention(t *testing.T) {
+	tc := setupSchemaTest(t)
+	tc.cleanup()
+
+	ctx, cleanup := tc.createTestContext()
+	defer cleanup()
+
+	usersTable := tc.createTestTable(ctx, "public", "users")
+	userIDCol := tc.createTestColumn(ctx, usersTable.ID, "id", 1)
+
+	ordersTable := tc.createTestTable(ctx, "public", "orders")
+	orderUserIDCol := tc.createTestColumn(ctx, ordersTable.ID, "user_id", 2)
+
+	method := models.InferenceMethodValueOverlap
+	rel := &models.SchemaRelationship{
+		ProjectID:        tc.projectID,
+		SourceTableID:    ordersTable.ID,
+		SourceColumnID:   orderUserIDCol.ID,
+		TargetTableID:    usersTable.ID,
+		TargetColumnID:   userIDCol.ID,
+		RelationshipType: models.RelationshipTypeInferred,
+		Cardinality:      models.CardinalityNTo1,
+		Confidence:       0.88,
+		InferenceMethod:  &method,
+		IsValidated:      true,
+		ValidationResults: &models.ValidationResults{
+			SourceRowCount: 1200,
+			OrphanCount:    4,
+			MatchRate:      0.97,
+		},
+	}
+	metrics := &models.DiscoveryMetrics{
+		MatchRate:      0.97,
+		SourceDistinct: 150,
+		TargetDistinct: 160,
+		MatchedCount:   146,
+	}
+	if err := tc.repo.UpsertRelationshipWithMetrics(ctx, rel, metrics); err != nil {
+		t.Fatalf("UpsertRelationshipWithMetrics failed: %v", err)
+	}
+
+	retrieved, err := tc.repo.GetRelationshipByID(ctx, tc.projectID, rel.ID)
+	if err != nil {
+		t.Fatalf("GetRelationshipByID failed: %v", err)
+	}
+	got := models.NewRelationshipMetrics(retrieved)
+	if got.MatchRate == nil || *got.MatchRate != metrics.MatchRate {
+		t.Errorf("expected match_rate %.2f, got %v", metrics.MatchRate, got.MatchRate)
+	}
+	if got.SourceDistinct == nil || *got.SourceDistinct != metrics.SourceDistinct {
+		t.Errorf("expected source_distinct %d, got %v", metrics.SourceDistinct, got.SourceDistinct)
+	}
+	if got.TargetDistinct == nil || *got.TargetDistinct != metrics.TargetDistinct {
+		t.Errorf("expected target_distinct %d, got %v", metrics.TargetDistinct, got.TargetDistinct)
+	}
+	if got.MatchedCount == nil || *got.MatchedCount != metrics.MatchedCount {
+		t.Errorf("expected matched_count %d, got %v", metrics.MatchedCount, got.MatchedCount)
+	}
+	if got.OrphanCount == nil || *got.OrphanCount != 4 {
+		t.Errorf("expected orphan_count 4, got %v", got.OrphanCount)
+	}
+	if got.SampleSize == nil || *got.SampleSize != 1200 {
+		t.Errorf("expected sample_size 1200, got %v", got.SampleSize)
+	}
+	if got.InferenceMethod == nil || *got.InferenceMethod != method {
+		t.Errorf("expected inference_method %q, got %v", method, got.InferenceMethod)
+	}
+
+	// Re-discovery without metrics must not wipe the stored values
+	rediscovered := &models.SchemaRelationship{
+		ProjectID:        tc.projectID,
+		SourceTableID:    rel.SourceTableID,
+		SourceColumnID:   rel.SourceColumnID,
+		TargetTableID:    rel.TargetTableID,
+		TargetColumnID:   rel.TargetColumnID,
+		RelationshipType: models.RelationshipTypeInferred,
+		Cardinality:      models.CardinalityNTo1,
+		Confidence:       0.9,
+	}
+	if err := tc.repo.UpsertRelationshipWithMetrics(ctx, rediscovered, nil); err != nil {
+		t.Fatalf("second UpsertRelationshipWithMetrics failed: %v", err)
+	}
+
+	retrieved, err = tc.repo.GetRelationshipByID(ctx, tc.projectID, rel.ID)
+	if err != nil {
+		t.Fatalf("GetRelationshipByID after re-discovery failed: %v", err)
+	}
+	if retrieved.MatchRate == nil || *retrieved.MatchRate != metrics.MatchRate {
+		t.Errorf("expected retained match_rate %.2f, got %v", metrics.MatchRate, retrieved.MatchRate)
+	}
+	if retrieved.MatchedCount == nil || *retrieved.MatchedCount != metrics.MatchedCount {
+		t.Errorf("expected retained matched_count %d, got %v", metrics.MatchedCount, retrieved.MatchedCount)
+	}
+	if retrieved.ValidationResults == nil || retrieved.ValidationResults.OrphanCount != 4 {
+		t.Errorf("expected retained validation results, got %+v", retrieved.ValidationResults)
+	}
+	if retrieved.Confidence != rediscovered.Confidence {
+		t.Errorf("expected refreshed confidence %.2f, got %.2f", rediscovered.Confidence, retrieved.Confidence)
+	}
+}
+
 func TestSchemaRepository_UpsertRelationship_RespectsSoftDelete(t *testing.T) {
 	tc := setupSchemaTest(t)
 	tc.cleanup()
@@ -2250,6 +2439,89 @@ func TestSchemaRepository_GetRelationshipsByMethod(t *testing.T) {
 	}
 }
 
+func TestSchemaRepository_GetRelationshipStats(t *testing.T) {
+	tc := setupSchemaTest(t)
+	tc.cleanup()
+
+	ctx, cleanup := tc.createTestContext()
+	defer cleanup()
+
+	usersTable := tc.createTestTableWithRowCount(ctx, "public", "users", ptr(int64(10)))
+	userIDCol := tc.createTestColumn(ctx, usersTable.ID, "id", 1)
+	ordersTable := tc.createTestTableWithRowCount(ctx, "public", "orders", ptr(int64(10)))
+	orderUserIDCol := tc.createTestColumn(ctx, ordersTable.ID, "user_id", 1)
+	orderCreatorCol := tc.createTestColumn(ctx, ordersTable.ID, "created_by", 2)
+	orderApproverCol := tc.createTestColumn(ctx, ordersTable.ID, "approved_by", 3)
+	orderReviewerCol := tc.createTestColumn(ctx, ordersTable.ID, "reviewed_by", 4)
+	orderShipperCol := tc.createTestColumn(ctx, ordersTable.ID, "shipped_by", 5)
+	tc.createTestTableWithRowCount(ctx, "public", "audit_log", ptr(int64(10)))
+
+	approved, rejected := true, false
+	fkMethod := models.InferenceMethodFK
+	overlapMethod := models.InferenceMethodValueOverlap
+	rejectionReason := "low match rate"
+	create := func(sourceCol *models.SchemaColumn, method *string, confidence float64, isApproved *bool, rejection *string) *models.SchemaRelationship {
+		t.Helper()
+		rel := &models.SchemaRelationship{
+			ProjectID:        tc.projectID,
+			SourceTableID:    ordersTable.ID,
+			SourceColumnID:   sourceCol.ID,
+			TargetTableID:    usersTable.ID,
+			TargetColumnID:   userIDCol.ID,
+			RelationshipType: models.RelationshipTypeInferred,
+			Cardinality:      models.CardinalityNTo1,
+			Confidence:       confidence,
+			InferenceMethod:  method,
+			IsApproved:       isApproved,
+			RejectionReason:  rejection,
+		}
+		if err := tc.repo.UpsertRelationship(ctx, rel); err != nil {
+			t.Fatalf("UpsertRelationship failed: %v", err)
+		}
+		return rel
+	}
+
+	create(orderUserIDCol, &fkMethod, 1.0, &approved, nil)
+	create(orderCreatorCol, &overlapMethod, 0.6, nil, nil)
+	create(orderApproverCol, &overlapMethod, 0.3, &rejected, nil)
+	create(orderReviewerCol, &overlapMethod, 0.2, nil, &rejectionReason)
+	deleted := create(orderShipperCol, &fkMethod, 0.9, &approved, nil)
+	if err := tc.repo.SoftDeleteRelationship(ctx, tc.projectID, deleted.ID); err != nil {
+		t.Fatalf("SoftDeleteRelationship failed: %v", err)
+	}
+
+	stats, err := tc.repo.GetRelationshipStats(ctx, tc.projectID, tc.dsID)
+	if err != nil {
+		t.Fatalf("GetRelationshipStats failed: %v", err)
+	}
+
+	// The soft-deleted relationship is excluded and the rejected candidate counted apart
+	if stats.TotalRelationships != 3 {
+		t.Errorf("expected 3 relationships, got %d", stats.TotalRelationships)
+	}
+	wantMethods := map[string]int{models.InferenceMethodFK: 1, models.InferenceMethodValueOverlap: 2}
+	if len(stats.ByMethod) != len(wantMethods) {
+		t.Errorf("expected by_method %v, got %v", wantMethods, stats.ByMethod)
+	}
+	for method, want := range wantMethods {
+		if stats.ByMethod[method] != want {
+			t.Errorf("expected %d %s relationships, got %d", want, method, stats.ByMethod[method])
+		}
+	}
+	if want := (models.RelationshipApprovalCounts{Approved: 1, Rejected: 1, Pending: 1}); stats.Approval != want {
+		t.Errorf("expected approval %+v, got %+v", want, stats.Approval)
+	}
+	if want := (models.RelationshipConfidenceCounts{High: 1, Medium: 1, Low: 1}); stats.Confidence != want {
+		t.Errorf("expected confidence %+v, got %+v", want, stats.Confidence)
+	}
+	if stats.RejectedCandidates != 1 {
+		t.Errorf("expected 1 rejected candidate, got %d", stats.RejectedCandidates)
+	}
+	if stats.OrphanTables != 1 {
+		t.Errorf("expected audit_log to be the only orphan table, got %d", stats.OrphanTables)
+	}
+}
+
 func TestSchemaRepository_GetRelationshipsByMethod_IncludesDiscoveryMetrics(t *testing.T) {
 	tc := setupSchemaTest(t)
 	tc.cleanup()
@@ -2,6 +2,8 @@ package repositories
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -33,6 +35,10 @@ type TableMetadataRepository interface {
 	// Joins with engine_schema_tables to resolve schema_table_id to table names.
 	ListByTableNames(ctx context.Context, projectID uuid.UUID, tableNames []string) (map[string]*models.TableMetadata, error)
 
+	// UpdateQuality sets features.quality without touching any other field or the
+	// provenance columns. A table without metadata is left unchanged.
+	UpdateQuality(ctx context.Context, schemaTableID uuid.UUID, quality *models.TableQualityFeatures) error
+
 	// Delete removes table metadata by schema_table_id.
 	Delete(ctx context.Context, schemaTableID uuid.UUID) error
 }
@@ -305,6 +311,28 @@ func (r *tableMetadataRepository) ListByTableNames(ctx context.Context, projectI
 	return result, nil
 }
 
+func (r *tableMetadataRepository) UpdateQuality(ctx context.Context, schemaTableID uuid.UUID, quality *models.TableQualityFeatures) error {
+	scope, ok := database.GetTenantScope(ctx)
+	if !ok {
+		return fmt.Errorf("no tenant scope in context")
+	}
+
+	qualityJSON, err := json.Marshal(quality)
+	if err != nil {
+		return fmt.Errorf("failed to marshal table quality: %w", err)
+	}
+
+	query := `
+		UPDATE engine_ontology_table_metadata
+		SET features = jsonb_set(COALESCE(features, '{}'::jsonb), '{quality}', $2::jsonb)
+		WHERE schema_table_id = $1`
+	if _, err := scope.Conn.Exec(ctx, query, schemaTableID, qualityJSON); err != nil {
+		return fmt.Errorf("failed to update table quality: %w", err)
+	}
+
+	return nil
+}
+
 func (r *tableMetadataRepository) Delete(ctx context.Context, schemaTableID uuid.UUID) error {
 	scope, ok := database.GetTenantScope(ctx)
 	if !ok {
@@ -330,7 +358,7 @@ func scanTableMetadata(row pgx.Row) (*models.TableMetadata, error) {
 		&m.Source, &m.LastEditSource, &m.CreatedBy, &m.UpdatedBy, &m.CreatedAt, &m.UpdatedAt,
 	)
 	if err != nil {
-		if err == pgx.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("failed to scan table metadata: %w", err)
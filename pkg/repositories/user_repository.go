@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -86,7 +87,7 @@ func (r *userRepository) Remove(ctx context.Context, projectID, userID uuid.UUID
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("user not found")
+		return notFound("user")
 	}
 
 	return nil
@@ -110,7 +111,7 @@ func (r *userRepository) Update(ctx context.Context, projectID, userID uuid.UUID
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("user not found")
+		return notFound("user")
 	}
 
 	return nil
@@ -181,8 +182,8 @@ func (r *userRepository) GetByID(ctx context.Context, projectID, userID uuid.UUI
 		&user.UpdatedAt,
 	)
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("user not found")
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, notFound("user")
 		}
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
@@ -231,8 +232,8 @@ func (r *userRepository) RemoveWithOwnerCheck(ctx context.Context, projectID, us
 	getUserQuery := `SELECT role FROM engine_users WHERE project_id = $1 AND user_id = $2`
 	err = tx.QueryRow(ctx, getUserQuery, projectID, userID).Scan(&role)
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			return fmt.Errorf("user not found")
+		if errors.Is(err, pgx.ErrNoRows) {
+			return notFound("user")
 		}
 		return fmt.Errorf("failed to get user: %w", err)
 	}
@@ -259,7 +260,7 @@ func (r *userRepository) RemoveWithOwnerCheck(ctx context.Context, projectID, us
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("user not found")
+		return notFound("user")
 	}
 
 	err = tx.Commit(ctx)
@@ -293,8 +294,8 @@ func (r *userRepository) UpdateRoleWithOwnerCheck(ctx context.Context, projectID
 	getUserQuery := `SELECT role FROM engine_users WHERE project_id = $1 AND user_id = $2`
 	err = tx.QueryRow(ctx, getUserQuery, projectID, userID).Scan(&currentRole)
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			return fmt.Errorf("user not found")
+		if errors.Is(err, pgx.ErrNoRows) {
+			return notFound("user")
 		}
 		return fmt.Errorf("failed to get user: %w", err)
 	}
@@ -321,7 +322,7 @@ func (r *userRepository) UpdateRoleWithOwnerCheck(ctx context.Context, projectID
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("user not found")
+		return notFound("user")
 	}
 
 	err = tx.Commit(ctx)
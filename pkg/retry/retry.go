@@ -2,6 +2,7 @@ package retry
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
 	"strings"
@@ -14,10 +15,14 @@ type Config struct {
 	InitialDelay     time.Duration
 	MaxDelay         time.Duration
 	Multiplier       float64
-	JitterFactor     float64 // 0.0-1.0, default 0.1 for +/-10% jitter to prevent thundering herd
-	MaxSameErrorType int     // After N consecutive same-type errors, treat as permanent (default: 5)
+	JitterFactor     float64       // 0.0-1.0, default 0.1 for +/-10% jitter to prevent thundering herd
+	MaxSameErrorType int           // After N consecutive same-type errors, treat as permanent (default: 5)
+	MaxRetryAfter    time.Duration // Cap on a server-requested Retry-After wait (default: 60s)
 }
 
+// defaultMaxRetryAfter caps Retry-After waits when the config does not set MaxRetryAfter.
+const defaultMaxRetryAfter = 60 * time.Second
+
 // DefaultConfig returns sensible defaults for database operations
 // 3 retries with 100ms initial delay, capped at 5s, doubling each time, with 10% jitter
 func DefaultConfig() *Config {
@@ -28,6 +33,7 @@ func DefaultConfig() *Config {
 		Multiplier:       2.0,
 		JitterFactor:     0.1, // +/-10% jitter to prevent thundering herd
 		MaxSameErrorType: 5,   // Escalate to permanent after 5 consecutive same-type errors
+		MaxRetryAfter:    defaultMaxRetryAfter,
 	}
 }
 
@@ -43,6 +49,30 @@ func applyJitter(delay time.Duration, jitterFactor float64) time.Duration {
 	return time.Duration(float64(delay) + jitter)
 }
 
+// RetryAfterError is an interface for errors that carry a server-requested delay,
+// such as an HTTP 429 with a Retry-After header. LLM errors implement it.
+type RetryAfterError interface {
+	error
+	RetryDelay() time.Duration
+}
+
+// waitFor returns how long to wait before the next attempt. A Retry-After delay on
+// err replaces the backoff delay, capped at cfg.MaxRetryAfter and without jitter;
+// otherwise the backoff delay is used with jitter.
+func waitFor(err error, delay time.Duration, cfg *Config) time.Duration {
+	var retryAfter RetryAfterError
+	if errors.As(err, &retryAfter) {
+		if requested := retryAfter.RetryDelay(); requested > 0 {
+			maxWait := cfg.MaxRetryAfter
+			if maxWait <= 0 {
+				maxWait = defaultMaxRetryAfter
+			}
+			return min(requested, maxWait)
+		}
+	}
+	return applyJitter(delay, cfg.JitterFactor)
+}
+
 // Do executes fn with exponential backoff retry logic
 // Returns nil on success, or last error after all retries exhausted
 // Respects context cancellation during wait periods
@@ -62,7 +92,7 @@ func Do(ctx context.Context, cfg *Config, fn func() error) error {
 
 			if attempt < cfg.MaxRetries {
 				select {
-				case <-time.After(applyJitter(delay, cfg.JitterFactor)):
+				case <-time.After(waitFor(lastErr, delay, cfg)):
 					delay = time.Duration(float64(delay) * cfg.Multiplier)
 					if delay > cfg.MaxDelay {
 						delay = cfg.MaxDelay
@@ -100,7 +130,7 @@ func DoWithResult[T any](ctx context.Context, cfg *Config, fn func() (T, error))
 
 		if attempt < cfg.MaxRetries {
 			select {
-			case <-time.After(applyJitter(delay, cfg.JitterFactor)):
+			case <-time.After(waitFor(lastErr, delay, cfg)):
 				delay = time.Duration(float64(delay) * cfg.Multiplier)
 				if delay > cfg.MaxDelay {
 					delay = cfg.MaxDelay
@@ -271,7 +301,7 @@ func DoIfRetryable(ctx context.Context, cfg *Config, fn func() error) error {
 
 			if attempt < cfg.MaxRetries {
 				select {
-				case <-time.After(applyJitter(delay, cfg.JitterFactor)):
+				case <-time.After(waitFor(lastErr, delay, cfg)):
 					delay = time.Duration(float64(delay) * cfg.Multiplier)
 					if delay > cfg.MaxDelay {
 						delay = cfg.MaxDelay
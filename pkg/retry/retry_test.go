@@ -3,6 +3,7 @@ package retry
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -22,6 +23,9 @@ func TestDefaultConfig(t *testing.T) {
 	if cfg.Multiplier != 2.0 {
 		t.Errorf("expected Multiplier=2.0, got %f", cfg.Multiplier)
 	}
+	if cfg.MaxRetryAfter != 60*time.Second {
+		t.Errorf("expected MaxRetryAfter=60s, got %v", cfg.MaxRetryAfter)
+	}
 }
 
 func TestDo_Success(t *testing.T) {
@@ -969,3 +973,47 @@ func TestDefaultConfig_HasMaxSameErrorType(t *testing.T) {
 		t.Errorf("expected MaxSameErrorType=5, got %d", cfg.MaxSameErrorType)
 	}
 }
+
+// retryAfterErr is an error carrying a server-requested retry delay.
+type retryAfterErr struct{ delay time.Duration }
+
+func (e retryAfterErr) Error() string             { return "429 too many requests" }
+func (e retryAfterErr) RetryDelay() time.Duration { return e.delay }
+
+func TestDo_WaitsForRetryAfter(t *testing.T) {
+	cfg := &Config{MaxRetries: 1, InitialDelay: time.Millisecond, MaxDelay: time.Millisecond, Multiplier: 2}
+
+	attempts := 0
+	start := time.Now()
+	err := Do(context.Background(), cfg, func() error {
+		attempts++
+		if attempts == 1 {
+			return fmt.Errorf("call failed: %w", retryAfterErr{delay: 150 * time.Millisecond})
+		}
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("expected to wait the Retry-After delay of 150ms, waited %v", elapsed)
+	}
+}
+
+func TestWaitFor_CapsRetryAfter(t *testing.T) {
+	cfg := &Config{MaxRetryAfter: 2 * time.Second}
+	if got := waitFor(retryAfterErr{delay: time.Hour}, time.Millisecond, cfg); got != 2*time.Second {
+		t.Errorf("expected Retry-After capped at 2s, got %v", got)
+	}
+
+	// Configs without MaxRetryAfter still get the default cap
+	if got := waitFor(retryAfterErr{delay: time.Hour}, time.Millisecond, &Config{}); got != defaultMaxRetryAfter {
+		t.Errorf("expected default cap %v, got %v", defaultMaxRetryAfter, got)
+	}
+
+	// Without a Retry-After delay the backoff delay is used
+	if got := waitFor(errors.New("503"), 40*time.Millisecond, &Config{}); got != 40*time.Millisecond {
+		t.Errorf("expected backoff delay 40ms, got %v", got)
+	}
+}
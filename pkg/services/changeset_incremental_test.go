@@ -375,3 +375,70 @@ func TestComputeChangeSet_UserEditedColumnsSkipped(t *testing.T) {
 	assert.False(t, changeSet.ShouldSkipColumn(idCol.ID),
 		"ShouldSkipColumn should return false for column without metadata")
 }
+
+// ============================================================================
+// MarkStaleRelationships: Modified Columns
+// ============================================================================
+
+// TestMarkStaleRelationships_ModifiedColumn verifies that an inferred relationship on a
+// modified column is removed for re-discovery, and can be rediscovered, while FK
+// relationships are kept.
+func TestMarkStaleRelationships_ModifiedColumn(t *testing.T) {
+	tc := setupSchemaServiceTest(t)
+	tc.cleanup()
+
+	ctx, cleanup := tc.createTestContext()
+	defer cleanup()
+
+	usersTable := tc.createTestTable(ctx, "public", "users", true)
+	usersIDCol := tc.createTestColumn(ctx, usersTable.ID, "id", "uuid", 1, true)
+
+	ordersTable := tc.createTestTable(ctx, "public", "orders", true)
+	tc.createTestColumn(ctx, ordersTable.ID, "id", "uuid", 1, true)
+	ordersUserIDCol := tc.createTestColumn(ctx, ordersTable.ID, "user_id", "uuid", 2, true)
+	ordersBuyerCol := tc.createTestColumn(ctx, ordersTable.ID, "buyer_ref", "text", 3, true)
+
+	// FK constraint on user_id, inferred relationship on buyer_ref
+	tc.createTestRelationship(ctx, ordersTable.ID, ordersUserIDCol.ID, usersTable.ID, usersIDCol.ID)
+	inferred := &models.SchemaRelationship{
+		ProjectID:        tc.projectID,
+		SourceTableID:    ordersTable.ID,
+		SourceColumnID:   ordersBuyerCol.ID,
+		TargetTableID:    usersTable.ID,
+		TargetColumnID:   usersIDCol.ID,
+		RelationshipType: models.RelationshipTypeInferred,
+		Cardinality:      models.CardinalityNTo1,
+		Confidence:       0.8,
+	}
+	require.NoError(t, tc.repo.UpsertRelationship(ctx, inferred))
+
+	scope, ok := database.GetTenantScope(ctx)
+	require.True(t, ok)
+	_, err := scope.Conn.Exec(ctx,
+		`UPDATE engine_schema_relationships SET source = 'inferred', last_edit_source = NULL, is_approved = NULL
+		 WHERE source_column_id = $1`, ordersBuyerCol.ID)
+	require.NoError(t, err)
+
+	// Both columns changed type since the last extraction
+	changeSet := &models.ChangeSet{
+		ModifiedColumns: []models.SchemaColumn{*ordersUserIDCol, *ordersBuyerCol},
+	}
+
+	dagSvc := newDAGService(nil)
+	require.NoError(t, dagSvc.MarkStaleRelationships(ctx, tc.projectID, changeSet))
+
+	rel, err := tc.repo.GetRelationshipByColumns(ctx, ordersBuyerCol.ID, usersIDCol.ID)
+	require.NoError(t, err)
+	assert.Nil(t, rel, "inferred relationship on a modified column should be marked stale")
+
+	rel, err = tc.repo.GetRelationshipByColumns(ctx, ordersUserIDCol.ID, usersIDCol.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, rel, "FK relationship should be kept")
+
+	// Relationship discovery re-derives the pair on the next run
+	inferred.ID = uuid.Nil
+	require.NoError(t, tc.repo.UpsertRelationship(ctx, inferred))
+	rel, err = tc.repo.GetRelationshipByColumns(ctx, ordersBuyerCol.ID, usersIDCol.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, rel, "a stale relationship should be recreated when rediscovered")
+}
@@ -0,0 +1,173 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+)
+
+// datePartSpec is the value range a date-part column can hold.
+type datePartSpec struct {
+	part     string
+	min, max int64
+}
+
+// datePartNames maps the trailing name token(s) of a date-part column to its spec.
+// Longer suffixes are listed first so "order_day_of_week" is not read as a day.
+var datePartNames = []struct {
+	suffix string
+	spec   datePartSpec
+}{
+	{"day_of_week", datePartSpec{models.DatePartDayOfWeek, 0, 7}},
+	{"day_of_month", datePartSpec{models.DatePartDay, 1, 31}},
+	{"day_of_year", datePartSpec{models.DatePartDayOfYear, 1, 366}},
+	{"week_of_year", datePartSpec{models.DatePartWeek, 0, 53}},
+	{"dow", datePartSpec{models.DatePartDayOfWeek, 0, 7}},
+	{"doy", datePartSpec{models.DatePartDayOfYear, 1, 366}},
+	{"year", datePartSpec{models.DatePartYear, 1900, 2100}},
+	{"yr", datePartSpec{models.DatePartYear, 1900, 2100}},
+	{"quarter", datePartSpec{models.DatePartQuarter, 1, 4}},
+	{"qtr", datePartSpec{models.DatePartQuarter, 1, 4}},
+	{"month", datePartSpec{models.DatePartMonth, 1, 12}},
+	{"week", datePartSpec{models.DatePartWeek, 0, 53}},
+	{"day", datePartSpec{models.DatePartDay, 1, 31}},
+	{"hour", datePartSpec{models.DatePartHour, 0, 23}},
+}
+
+// matchDatePartName reports whether an integer column's name ends in a date-part
+// token, returning the spec and the remaining name prefix ("order" for order_month).
+// Rates such as hours_per_week are measures, not date parts.
+func matchDatePartName(columnName string) (datePartSpec, string, bool) {
+	name := strings.ToLower(columnName)
+	for _, n := range datePartNames {
+		if name == n.suffix {
+			return n.spec, "", true
+		}
+		if strings.HasSuffix(name, "_"+n.suffix) {
+			prefix := strings.TrimSuffix(name, "_"+n.suffix)
+			if prefix == "per" || strings.HasSuffix(prefix, "_per") {
+				return datePartSpec{}, "", false
+			}
+			return n.spec, prefix, true
+		}
+	}
+	return datePartSpec{}, "", false
+}
+
+// withinDatePartCardinality is the small-integer guard: a date part can have no more
+// distinct values than its range allows.
+func withinDatePartCardinality(spec datePartSpec, distinctCount int64) bool {
+	return distinctCount > 0 && distinctCount <= spec.max-spec.min+1
+}
+
+// isDatePartColumn reports whether a schema column looks like a date part from its
+// name, type and distinct count alone. It is used where sampled values are not
+// available, such as FK source selection for columns without metadata.
+func isDatePartColumn(col *models.SchemaColumn) bool {
+	if !isIntegerType(strings.ToLower(col.DataType)) || col.DistinctCount == nil {
+		return false
+	}
+	spec, _, ok := matchDatePartName(col.ColumnName)
+	return ok && withinDatePartCardinality(spec, *col.DistinctCount)
+}
+
+// detectDatePart classifies an integer column as a denormalized date part when its
+// name ends in a date-part token, its distinct count fits the part's range and every
+// observed value lies within that range. Columns with no observed values are not
+// classified. timestampColumns lists the timestamp columns of the profile's table in
+// ordinal order and is used to find the column the part was derived from.
+func detectDatePart(profile *models.ColumnDataProfile, timestampColumns []string) *models.DatePartFeatures {
+	if profile.IsPrimaryKey || !isIntegerType(strings.ToLower(profile.DataType)) {
+		return nil
+	}
+	spec, prefix, ok := matchDatePartName(profile.ColumnName)
+	if !ok || !withinDatePartCardinality(spec, profile.DistinctCount) {
+		return nil
+	}
+
+	observed := 0
+	inRange := func(v float64) bool { return v >= float64(spec.min) && v <= float64(spec.max) }
+	if profile.MinValue != nil && profile.MaxValue != nil {
+		if !inRange(*profile.MinValue) || !inRange(*profile.MaxValue) {
+			return nil
+		}
+		observed++
+	}
+	for _, raw := range profile.SampleValues {
+		v, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 64)
+		if err != nil || !inRange(float64(v)) {
+			return nil
+		}
+		observed++
+	}
+	if observed == 0 {
+		return nil
+	}
+
+	return &models.DatePartFeatures{
+		Part:         spec.part,
+		SourceColumn: findDatePartSource(prefix, timestampColumns),
+	}
+}
+
+// findDatePartSource returns the first timestamp column whose name starts with the
+// date part's prefix (order_month → order_date, ordered_at). An unprefixed part such
+// as "month" is not attributed to any column.
+func findDatePartSource(prefix string, timestampColumns []string) string {
+	if prefix == "" {
+		return ""
+	}
+	for _, col := range timestampColumns {
+		if strings.HasPrefix(strings.ToLower(col), prefix) {
+			return col
+		}
+	}
+	return ""
+}
+
+// datePartColumnFeatures builds the features for a detected date part. Date parts are
+// temporal dimensions rather than identifiers, so they carry the timestamp purpose and
+// are never queued for FK resolution.
+func datePartColumnFeatures(profile *models.ColumnDataProfile, datePart *models.DatePartFeatures) *models.ColumnFeatures {
+	description := fmt.Sprintf("%s stored as a denormalized date part.", datePartLabel(datePart.Part))
+	if datePart.SourceColumn != "" {
+		description = fmt.Sprintf("%s of %s, stored as a denormalized date part.", datePartLabel(datePart.Part), datePart.SourceColumn)
+	}
+	return &models.ColumnFeatures{
+		ColumnID:           profile.ColumnID,
+		ClassificationPath: models.ClassificationPathNumeric,
+		Purpose:            models.PurposeTimestamp,
+		SemanticType:       models.SemanticTypeDatePart,
+		Role:               models.RoleAttribute,
+		Description:        description,
+		Confidence:         0.9,
+		DatePartFeatures:   datePart,
+		AnalyzedAt:         time.Now(),
+	}
+}
+
+func datePartLabel(part string) string {
+	switch part {
+	case models.DatePartYear:
+		return "Year"
+	case models.DatePartQuarter:
+		return "Quarter (1-4)"
+	case models.DatePartMonth:
+		return "Month (1-12)"
+	case models.DatePartWeek:
+		return "Week of year"
+	case models.DatePartDay:
+		return "Day of month"
+	case models.DatePartDayOfWeek:
+		return "Day of week"
+	case models.DatePartDayOfYear:
+		return "Day of year"
+	case models.DatePartHour:
+		return "Hour of day"
+	default:
+		return "Date part"
+	}
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+)
+
+func monthSamples() []string {
+	return []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12"}
+}
+
+func TestDetectDatePart_ClassifiesOrderMonth(t *testing.T) {
+	profile := &models.ColumnDataProfile{
+		ColumnID:      uuid.New(),
+		ColumnName:    "order_month",
+		TableName:     "orders",
+		DataType:      "integer",
+		DistinctCount: 12,
+		SampleValues:  monthSamples(),
+	}
+
+	datePart := detectDatePart(profile, []string{"created_at", "order_date"})
+	require.NotNil(t, datePart)
+	assert.Equal(t, models.DatePartMonth, datePart.Part)
+	assert.Equal(t, "order_date", datePart.SourceColumn)
+
+	features := datePartColumnFeatures(profile, datePart)
+	assert.Equal(t, models.PurposeTimestamp, features.Purpose)
+	assert.Equal(t, models.SemanticTypeDatePart, features.SemanticType)
+	assert.Equal(t, "Month (1-12) of order_date, stored as a denormalized date part.", features.Description)
+	assert.Nil(t, features.IdentifierFeatures, "date parts must not be queued for FK resolution")
+}
+
+func TestDetectDatePart_RejectsNonDateParts(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile *models.ColumnDataProfile
+	}{
+		{
+			name:    "value outside month range",
+			profile: &models.ColumnDataProfile{ColumnName: "order_month", DataType: "integer", DistinctCount: 12, SampleValues: append(monthSamples()[:11], "13")},
+		},
+		{
+			name:    "too many distinct values",
+			profile: &models.ColumnDataProfile{ColumnName: "order_month", DataType: "integer", DistinctCount: 40, SampleValues: monthSamples()},
+		},
+		{
+			name:    "name does not end in a date part",
+			profile: &models.ColumnDataProfile{ColumnName: "month_total", DataType: "integer", DistinctCount: 12, SampleValues: monthSamples()},
+		},
+		{
+			name:    "rate rather than date part",
+			profile: &models.ColumnDataProfile{ColumnName: "hours_per_week", DataType: "integer", DistinctCount: 12, SampleValues: monthSamples()},
+		},
+		{
+			name:    "non-integer type",
+			profile: &models.ColumnDataProfile{ColumnName: "order_month", DataType: "text", DistinctCount: 12, SampleValues: monthSamples()},
+		},
+		{
+			name:    "no observed values",
+			profile: &models.ColumnDataProfile{ColumnName: "order_month", DataType: "integer", DistinctCount: 12},
+		},
+		{
+			name: "max value outside range",
+			profile: func() *models.ColumnDataProfile {
+				minV, maxV := 1.0, 40.0
+				return &models.ColumnDataProfile{ColumnName: "order_day", DataType: "integer", DistinctCount: 20, MinValue: &minV, MaxValue: &maxV}
+			}(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Nil(t, detectDatePart(tt.profile, []string{"order_date"}))
+		})
+	}
+}
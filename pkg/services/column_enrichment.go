@@ -14,8 +14,8 @@ import (
 	"github.com/ekaya-inc/ekaya-engine/pkg/llm"
 	"github.com/ekaya-inc/ekaya-engine/pkg/models"
 	"github.com/ekaya-inc/ekaya-engine/pkg/repositories"
-	"github.com/ekaya-inc/ekaya-engine/pkg/retry"
 	"github.com/ekaya-inc/ekaya-engine/pkg/services/dag"
+	"github.com/ekaya-inc/ekaya-engine/pkg/tracing"
 )
 
 // ColumnEnrichmentService provides semantic enrichment for database columns.
@@ -28,6 +28,17 @@ type ColumnEnrichmentService interface {
 	// Returns the enrichment result with success/failure counts.
 	// The progressCallback is called after each table to report progress (can be nil).
 	EnrichProject(ctx context.Context, projectID uuid.UUID, tableNames []string, progressCallback dag.ProgressCallback) (*EnrichColumnsResult, error)
+
+	// EstimatePrompts builds, without sending, the enrichment prompts for the given
+	// tables (or all selected tables if empty) and estimates their input tokens.
+	EstimatePrompts(ctx context.Context, projectID uuid.UUID, tableNames []string) (*ColumnPromptEstimate, error)
+}
+
+// ColumnPromptEstimate is the estimated LLM input of enriching a set of tables.
+type ColumnPromptEstimate struct {
+	TableCount  int
+	PromptCount int
+	InputTokens int
 }
 
 // EnrichColumnsResult holds the result of a column enrichment operation.
@@ -59,6 +70,7 @@ type columnEnrichmentService struct {
 	workerPool         *llm.WorkerPool
 	circuitBreaker     *llm.CircuitBreaker
 	getTenantCtx       TenantContextFunc
+	questionCategories []string
 	logger             *zap.Logger
 }
 
@@ -75,6 +87,7 @@ func NewColumnEnrichmentService(
 	workerPool *llm.WorkerPool,
 	circuitBreaker *llm.CircuitBreaker,
 	getTenantCtx TenantContextFunc,
+	questionCategories []string,
 	logger *zap.Logger,
 ) ColumnEnrichmentService {
 	return &columnEnrichmentService{
@@ -89,6 +102,7 @@ func NewColumnEnrichmentService(
 		workerPool:         workerPool,
 		circuitBreaker:     circuitBreaker,
 		getTenantCtx:       getTenantCtx,
+		questionCategories: questionCategoriesOrDefault(questionCategories),
 		logger:             logger.Named("column-enrichment"),
 	}
 }
@@ -185,7 +199,10 @@ func (s *columnEnrichmentService) EnrichProject(ctx context.Context, projectID u
 }
 
 // EnrichTable enriches all columns for a single table.
-func (s *columnEnrichmentService) EnrichTable(ctx context.Context, projectID uuid.UUID, tableName string) error {
+func (s *columnEnrichmentService) EnrichTable(ctx context.Context, projectID uuid.UUID, tableName string) (err error) {
+	ctx, span := tracing.Start(ctx, "column_enrichment.table", tracing.AttrTable.String(tableName))
+	defer func() { tracing.End(span, err) }()
+
 	s.logger.Debug("Enriching columns for table",
 		zap.String("project_id", projectID.String()),
 		zap.String("table", tableName))
@@ -209,31 +226,10 @@ func (s *columnEnrichmentService) EnrichTable(ctx context.Context, projectID uui
 	}
 
 	// Fetch column metadata for all columns in this table
-	columnIDs := make([]uuid.UUID, len(columns))
-	for i, col := range columns {
-		columnIDs[i] = col.ID
-	}
-	metadataList, err := s.columnMetadataRepo.GetBySchemaColumnIDs(ctx, columnIDs)
-	if err != nil {
-		s.logger.Warn("Failed to fetch column metadata, continuing without",
-			zap.String("table", tableName),
-			zap.Error(err))
-	}
-	// Build map for quick lookup
-	metadataByColumnID := make(map[uuid.UUID]*models.ColumnMetadata)
-	for _, meta := range metadataList {
-		metadataByColumnID[meta.SchemaColumnID] = meta
-	}
+	metadataByColumnID := s.getColumnMetadataByID(ctx, tableName, columns)
 
 	// Extract FK info from column metadata (populated by column_feature_extraction service)
-	fkInfo := make(map[string]string)
-	for _, col := range columns {
-		if meta, ok := metadataByColumnID[col.ID]; ok {
-			if idFeatures := meta.GetIdentifierFeatures(); idFeatures != nil && idFeatures.FKTargetTable != "" {
-				fkInfo[col.ColumnName] = idFeatures.FKTargetTable
-			}
-		}
-	}
+	fkInfo := fkInfoFromMetadata(columns, metadataByColumnID)
 
 	// Identify enum candidates
 	enumCandidates := s.identifyEnumCandidates(columns, metadataByColumnID)
@@ -316,6 +312,90 @@ func (s *columnEnrichmentService) EnrichTable(ctx context.Context, projectID uui
 	return nil
 }
 
+// EstimatePrompts builds the same prompts EnrichTable would send for each table and
+// sums their estimated tokens. Enum values are not sampled from the datasource, so
+// prompts for tables with enum columns come out slightly smaller than the real ones.
+func (s *columnEnrichmentService) EstimatePrompts(ctx context.Context, projectID uuid.UUID, tableNames []string) (*ColumnPromptEstimate, error) {
+	ctx = withLoadedProjectKnowledgeFactsForPrompt(ctx, projectID, s.logger)
+
+	if len(tableNames) == 0 {
+		tables, err := s.schemaRepo.ListTablesByDatasource(ctx, projectID, uuid.Nil)
+		if err != nil {
+			return nil, fmt.Errorf("fetch selected tables: %w", err)
+		}
+		for _, t := range tables {
+			tableNames = append(tableNames, t.TableName)
+		}
+	}
+
+	estimate := &ColumnPromptEstimate{TableCount: len(tableNames)}
+	systemMsg := s.columnEnrichmentSystemMessage()
+	knowledgeSection := buildRelevantProjectKnowledgeSection(ctx, projectID, s.logger)
+
+	for _, tableName := range tableNames {
+		tableCtx, err := s.getTableContext(ctx, projectID, tableName)
+		if err != nil {
+			return nil, fmt.Errorf("get table context for %s: %w", tableName, err)
+		}
+		columns, err := s.getColumnsForTable(ctx, projectID, tableName)
+		if err != nil {
+			return nil, fmt.Errorf("get columns for table %s: %w", tableName, err)
+		}
+		if len(columns) == 0 {
+			continue
+		}
+
+		metadataByColumnID := s.getColumnMetadataByID(ctx, tableName, columns)
+		columnsNeedingLLM, _ := s.filterColumnsForLLM(columns, metadataByColumnID)
+		fkInfo := fkInfoFromMetadata(columnsNeedingLLM, metadataByColumnID)
+
+		// Mirror enrichColumnsWithLLM: one prompt per chunk of columns
+		for i := 0; i < len(columnsNeedingLLM); i += maxColumnsPerChunk {
+			chunk := columnsNeedingLLM[i:min(i+maxColumnsPerChunk, len(columnsNeedingLLM))]
+			chunkFKInfo, _ := columnSubsetContext(chunk, fkInfo, nil)
+			prompt := prependProjectKnowledgeToPrompt(s.buildColumnEnrichmentPrompt(tableCtx, chunk, chunkFKInfo, nil), knowledgeSection)
+			estimate.PromptCount++
+			estimate.InputTokens += llm.EstimatePromptTokens(prompt, systemMsg)
+		}
+	}
+
+	return estimate, nil
+}
+
+// getColumnMetadataByID fetches column metadata for the given columns, keyed by
+// schema column ID. A lookup failure is logged and yields an empty map.
+func (s *columnEnrichmentService) getColumnMetadataByID(ctx context.Context, tableName string, columns []*models.SchemaColumn) map[uuid.UUID]*models.ColumnMetadata {
+	columnIDs := make([]uuid.UUID, len(columns))
+	for i, col := range columns {
+		columnIDs[i] = col.ID
+	}
+	metadataList, err := s.columnMetadataRepo.GetBySchemaColumnIDs(ctx, columnIDs)
+	if err != nil {
+		s.logger.Warn("Failed to fetch column metadata, continuing without",
+			zap.String("table", tableName),
+			zap.Error(err))
+	}
+	metadataByColumnID := make(map[uuid.UUID]*models.ColumnMetadata)
+	for _, meta := range metadataList {
+		metadataByColumnID[meta.SchemaColumnID] = meta
+	}
+	return metadataByColumnID
+}
+
+// fkInfoFromMetadata maps FK column names to their target tables using the
+// identifier features from column metadata.
+func fkInfoFromMetadata(columns []*models.SchemaColumn, metadataByColumnID map[uuid.UUID]*models.ColumnMetadata) map[string]string {
+	fkInfo := make(map[string]string)
+	for _, col := range columns {
+		if meta, ok := metadataByColumnID[col.ID]; ok {
+			if idFeatures := meta.GetIdentifierFeatures(); idFeatures != nil && idFeatures.FKTargetTable != "" {
+				fkInfo[col.ColumnName] = idFeatures.FKTargetTable
+			}
+		}
+	}
+	return fkInfo
+}
+
 // getTableContext retrieves table context for column enrichment.
 // It looks up the SchemaTable to get business name and description.
 func (s *columnEnrichmentService) getTableContext(ctx context.Context, projectID uuid.UUID, tableName string) (*TableContext, error) {
@@ -435,10 +515,11 @@ func (s *columnEnrichmentService) persistSampleValues(_ context.Context, _ []*mo
 const highConfidenceThreshold = 0.9
 
 // filterColumnsForLLM separates columns into those needing LLM enrichment and those
-// that already have high-confidence ColumnMetadata from the feature extraction pipeline.
+// that already have high-confidence ColumnMetadata from the feature extraction pipeline
+// or are audit/system columns.
 // Returns:
 //   - columnsNeedingLLM: columns that should be sent to the LLM for enrichment
-//   - syntheticEnrichments: pre-built enrichments for high-confidence columns
+//   - syntheticEnrichments: pre-built enrichments for high-confidence and audit/system columns
 func (s *columnEnrichmentService) filterColumnsForLLM(columns []*models.SchemaColumn, metadataByColumnID map[uuid.UUID]*models.ColumnMetadata) ([]*models.SchemaColumn, []columnEnrichment) {
 	var columnsNeedingLLM []*models.SchemaColumn
 	var syntheticEnrichments []columnEnrichment
@@ -446,6 +527,13 @@ func (s *columnEnrichmentService) filterColumnsForLLM(columns []*models.SchemaCo
 	for _, col := range columns {
 		meta := metadataByColumnID[col.ID]
 
+		// Audit/system columns mean the same thing in every table, so describing them
+		// with the LLM spends tokens without adding information.
+		if description, ok := auditColumnDescription(col, meta); ok {
+			syntheticEnrichments = append(syntheticEnrichments, auditColumnEnrichment(col, meta, description))
+			continue
+		}
+
 		// Enum-classified inferred columns still need LLM enrichment when they do not yet
 		// have stored enum values. This keeps descriptive metadata from Phase 2 while
 		// ensuring we still label and persist the actual categorical values.
@@ -507,6 +595,73 @@ func (s *columnEnrichmentService) filterColumnsForLLM(columns []*models.SchemaCo
 	return columnsNeedingLLM, syntheticEnrichments
 }
 
+// auditTimestampDescriptions describes audit timestamps by their classified purpose.
+var auditTimestampDescriptions = map[string]string{
+	models.TimestampPurposeAuditCreated: "When the row was created.",
+	models.TimestampPurposeAuditUpdated: "When the row was last modified.",
+	models.TimestampPurposeSoftDelete:   "When the row was soft-deleted; NULL while the row is active.",
+}
+
+// auditColumnNameDescriptions describes well-known audit column names, used when
+// column metadata has not classified the column. Names ending in "_at" only match
+// timestamp columns.
+var auditColumnNameDescriptions = map[string]string{
+	"created_at":  "When the row was created.",
+	"inserted_at": "When the row was created.",
+	"updated_at":  "When the row was last modified.",
+	"modified_at": "When the row was last modified.",
+	"deleted_at":  "When the row was soft-deleted; NULL while the row is active.",
+	"created_by":  "Who created the row.",
+	"updated_by":  "Who last modified the row.",
+	"modified_by": "Who last modified the row.",
+	"deleted_by":  "Who soft-deleted the row.",
+}
+
+// auditColumnDescription reports whether col is an audit/system column and returns
+// the description to store for it. An existing description is kept so re-extraction
+// never replaces curated or previously generated text.
+func auditColumnDescription(col *models.SchemaColumn, meta *models.ColumnMetadata) (string, bool) {
+	description := ""
+	if meta != nil {
+		if tsFeatures := meta.GetTimestampFeatures(); tsFeatures != nil {
+			description = auditTimestampDescriptions[tsFeatures.TimestampPurpose]
+		}
+	}
+	if description == "" {
+		name := strings.ToLower(col.ColumnName)
+		if strings.HasSuffix(name, "_at") && !isTimestampType(col.DataType) {
+			return "", false
+		}
+		description = auditColumnNameDescriptions[name]
+	}
+	if description == "" {
+		return "", false
+	}
+
+	if meta != nil && meta.Description != nil && *meta.Description != "" {
+		return *meta.Description, true
+	}
+	return description, true
+}
+
+// auditColumnEnrichment builds the enrichment for an audit/system column, keeping any
+// semantic type and role already classified for it.
+func auditColumnEnrichment(col *models.SchemaColumn, meta *models.ColumnMetadata, description string) columnEnrichment {
+	enrichment := columnEnrichment{
+		Name:        col.ColumnName,
+		Description: description,
+	}
+	if meta != nil {
+		if meta.SemanticType != nil {
+			enrichment.SemanticType = *meta.SemanticType
+		}
+		if meta.Role != nil {
+			enrichment.Role = *meta.Role
+		}
+	}
+	return enrichment
+}
+
 // identifyEnumCandidates identifies columns likely to contain enum values using ColumnMetadata
 // from the feature extraction pipeline (DAG step 2). Falls back to low-cardinality text heuristic
 // when metadata is not available for a column.
@@ -582,9 +737,23 @@ func (s *columnEnrichmentService) analyzeEnumDistributions(
 	// Find a completion timestamp column if one exists (for state machine detection)
 	completionCol := findCompletionTimestampColumn(columns, metadataByColumnID)
 
+	// Find a timestamp that moves when rows are written, so status-like columns can
+	// distinguish values still in use from legacy ones
+	activityCol := findActivityTimestampColumn(columns, metadataByColumnID)
+	activeSince := time.Now().AddDate(0, 0, -s.ontologySettings(ctx, projectID).EnumActivityWindowDays)
+
 	// Analyze distribution for each enum candidate
 	for _, col := range enumCandidates {
-		dist, err := adapter.GetEnumValueDistribution(ctx, tableCtx.SchemaName, tableCtx.TableName, col.ColumnName, completionCol, 100)
+		opts := datasource.EnumDistributionOptions{
+			Limit:                  100,
+			CompletionTimestampCol: completionCol,
+		}
+		if activityCol != "" && isStatusLikeColumn(col, metadataByColumnID[col.ID]) {
+			opts.ActivityTimestampCol = activityCol
+			opts.ActiveSince = activeSince
+		}
+
+		dist, err := adapter.GetEnumValueDistribution(ctx, tableCtx.SchemaName, tableCtx.TableName, col.ColumnName, opts)
 		if err != nil {
 			s.logger.Debug("Failed to get enum distribution for column, skipping",
 				zap.String("column", col.ColumnName),
@@ -618,6 +787,52 @@ func findCompletionTimestampColumn(columns []*models.SchemaColumn, metadataByCol
 	return ""
 }
 
+// activityTimestampNames are column names commonly written whenever a row changes,
+// used when column metadata has not classified the table's timestamps.
+var activityTimestampNames = []string{
+	"updated_at", "modified_at", "last_modified", "last_modified_at", "updated_on", "modified_on", "updated", "modified",
+}
+
+// findActivityTimestampColumn finds the column that best reflects when a row was last
+// written. An audit "updated" timestamp is preferred because it moves on every status
+// change; a well-known updated/modified name is the fallback. Completion timestamps are
+// not used because they are NULL for rows still in progress, which would make every
+// in-progress status look unused. Returns empty string if nothing suitable exists.
+func findActivityTimestampColumn(columns []*models.SchemaColumn, metadataByColumnID map[uuid.UUID]*models.ColumnMetadata) string {
+	for _, col := range columns {
+		if meta, ok := metadataByColumnID[col.ID]; ok {
+			if tsFeatures := meta.GetTimestampFeatures(); tsFeatures != nil && tsFeatures.TimestampPurpose == models.TimestampPurposeAuditUpdated {
+				return col.ColumnName
+			}
+		}
+	}
+	for _, name := range activityTimestampNames {
+		for _, col := range columns {
+			if strings.EqualFold(col.ColumnName, name) && isTimestampType(col.DataType) {
+				return col.ColumnName
+			}
+		}
+	}
+	return ""
+}
+
+// isStatusLikeColumn reports whether an enum column records a lifecycle state, where
+// values can fall out of use as the application evolves.
+func isStatusLikeColumn(col *models.SchemaColumn, meta *models.ColumnMetadata) bool {
+	if meta != nil {
+		if enumFeatures := meta.GetEnumFeatures(); enumFeatures != nil && enumFeatures.IsStateMachine {
+			return true
+		}
+	}
+	name := strings.ToLower(col.ColumnName)
+	for _, hint := range []string{"status", "state", "stage", "phase"} {
+		if strings.Contains(name, hint) {
+			return true
+		}
+	}
+	return false
+}
+
 // NOTE: detectSoftDeletePattern, monetaryColumnPatterns, and detectMonetaryColumnPattern
 // have been removed. Column classification is now handled by the column_feature_extraction
 // service in Phase 2 of the DAG. Features are stored in SchemaColumn.Metadata and retrieved
@@ -708,6 +923,10 @@ func applyEnumDistributions(enumValues []models.EnumValue, dist *datasource.Enum
 				isTrue := true
 				ev.IsLikelyErrorState = &isTrue
 			}
+			if dist.ActivityTimestampCol != "" {
+				isStale := d.RecentCount == 0
+				ev.IsStale = &isStale
+			}
 		}
 	}
 
@@ -757,7 +976,7 @@ type columnEnrichmentResponse struct {
 // columnOntologyQuestionInput represents a question generated by the LLM during column enrichment.
 // These questions identify areas of uncertainty where user clarification would improve accuracy.
 type columnOntologyQuestionInput struct {
-	Category string `json:"category"` // terminology | enumeration | relationship | business_rules | temporal | data_quality
+	Category string `json:"category"` // One of the question category taxonomy
 	Priority int    `json:"priority"` // 1=critical | 2=important | 3=nice-to-have
 	Question string `json:"question"` // Clear question for domain expert
 	Context  string `json:"context"`  // Relevant schema/data context
@@ -774,6 +993,10 @@ type columnEnrichment struct {
 	FKAssociation *string            `json:"fk_association"`
 }
 
+// maxColumnsPerChunk is the most columns sent to the LLM in one enrichment prompt.
+// Wider tables are enriched in chunks to avoid context limits.
+const maxColumnsPerChunk = 50
+
 // enrichColumnsWithLLM uses the LLM to generate semantic metadata for columns.
 // Implements chunking for large tables and retry logic for transient failures.
 func (s *columnEnrichmentService) enrichColumnsWithLLM(
@@ -790,7 +1013,6 @@ func (s *columnEnrichmentService) enrichColumnsWithLLM(
 	}
 
 	// Chunk columns if table has many columns to avoid context limits
-	const maxColumnsPerChunk = 50
 	if len(columns) > maxColumnsPerChunk {
 		s.logger.Info("Table has many columns, using chunked enrichment",
 			zap.String("table", tableCtx.TableName),
@@ -834,16 +1056,7 @@ func (s *columnEnrichmentService) enrichColumnsInChunks(
 		chunk := columns[i:end]
 
 		// Filter FK info and enum samples to only include columns in this chunk
-		chunkFKInfo := make(map[string]string)
-		chunkEnumSamples := make(map[string][]string)
-		for _, col := range chunk {
-			if target, ok := fkInfo[col.ColumnName]; ok {
-				chunkFKInfo[col.ColumnName] = target
-			}
-			if samples, ok := enumSamples[col.ColumnName]; ok {
-				chunkEnumSamples[col.ColumnName] = samples
-			}
-		}
+		chunkFKInfo, chunkEnumSamples := columnSubsetContext(chunk, fkInfo, enumSamples)
 
 		// Capture loop variables for closure
 		chunkIdx := len(workItems)
@@ -913,6 +1126,47 @@ func (s *columnEnrichmentService) enrichColumnBatch(
 	fkInfo map[string]string,
 	enumSamples map[string][]string,
 ) ([]columnEnrichment, error) {
+	// Tagged so the extraction estimate can learn this prompt's output ratio
+	ctx = llm.WithPromptType(ctx, llm.PromptTypeColumnEnrichment)
+
+	systemMsg := s.columnEnrichmentSystemMessage()
+	knowledgeSection := buildRelevantProjectKnowledgeSection(ctx, projectID, s.logger)
+	prompt := prependProjectKnowledgeToPrompt(s.buildColumnEnrichmentPrompt(tableCtx, columns, fkInfo, enumSamples), knowledgeSection)
+
+	// Keep the prompt within the project's token budget: drop sample values first,
+	// then split the batch. Trimming is recorded on the conversation context.
+	budget := s.promptTokenBudget(ctx, projectID)
+	if estimated := llm.EstimatePromptTokens(prompt, systemMsg); estimated > budget {
+		if len(enumSamples) > 0 {
+			trimmed := prependProjectKnowledgeToPrompt(s.buildColumnEnrichmentPrompt(tableCtx, columns, fkInfo, nil), knowledgeSection)
+			if llm.EstimatePromptTokens(trimmed, systemMsg) <= budget {
+				s.logger.Warn("Column enrichment prompt over token budget, dropped sample values",
+					zap.String("table", tableCtx.TableName),
+					zap.Int("column_count", len(columns)),
+					zap.Int("estimated_tokens", estimated),
+					zap.Int("budget", budget))
+				ctx = llm.WithPromptTrim(ctx, "sample_values", estimated, budget)
+				prompt = trimmed
+			}
+		}
+		if llm.EstimatePromptTokens(prompt, systemMsg) > budget {
+			if len(columns) > 1 {
+				s.logger.Warn("Column enrichment prompt over token budget, splitting batch",
+					zap.String("table", tableCtx.TableName),
+					zap.Int("column_count", len(columns)),
+					zap.Int("estimated_tokens", estimated),
+					zap.Int("budget", budget))
+				return s.enrichColumnBatchSplit(llm.WithPromptTrim(ctx, "split_columns", estimated, budget),
+					projectID, llmClient, tableCtx, columns, fkInfo, enumSamples)
+			}
+			s.logger.Warn("Column enrichment prompt over token budget for a single column, sending as is",
+				zap.String("table", tableCtx.TableName),
+				zap.String("column", columns[0].ColumnName),
+				zap.Int("estimated_tokens", estimated),
+				zap.Int("budget", budget))
+		}
+	}
+
 	// Check circuit breaker before attempting LLM call
 	allowed, err := s.circuitBreaker.Allow()
 	if !allowed {
@@ -924,44 +1178,8 @@ func (s *columnEnrichmentService) enrichColumnBatch(
 		return nil, err
 	}
 
-	systemMsg := s.columnEnrichmentSystemMessage()
-	prompt := s.buildColumnEnrichmentPrompt(tableCtx, columns, fkInfo, enumSamples)
-	prompt = prependProjectKnowledgeToPrompt(prompt, buildRelevantProjectKnowledgeSection(ctx, projectID, s.logger))
-
-	// Retry LLM call with exponential backoff
-	retryConfig := &retry.Config{
-		MaxRetries:   3,
-		InitialDelay: 500 * time.Millisecond,
-		MaxDelay:     10 * time.Second,
-		Multiplier:   2.0,
-	}
-
-	var result *llm.GenerateResponseResult
-	err = retry.Do(ctx, retryConfig, func() error {
-		var retryErr error
-		result, retryErr = llmClient.GenerateResponse(ctx, prompt, systemMsg, 0.3, false)
-		if retryErr != nil {
-			// Classify error to determine if retryable
-			classified := llm.ClassifyError(retryErr)
-			if classified.Retryable {
-				s.logger.Warn("LLM call failed, retrying",
-					zap.String("table", tableCtx.TableName),
-					zap.Int("column_count", len(columns)),
-					zap.String("error_type", string(classified.Type)),
-					zap.Error(retryErr))
-				return retryErr
-			}
-			// Non-retryable error, fail immediately
-			s.logger.Error("LLM call failed with non-retryable error",
-				zap.String("table", tableCtx.TableName),
-				zap.Int("column_count", len(columns)),
-				zap.String("error_type", string(classified.Type)),
-				zap.Error(retryErr))
-			return retryErr
-		}
-		return nil
-	})
-
+	// Transient failures are retried with backoff by the LLM client itself
+	result, err := llmClient.GenerateResponse(ctx, prompt, systemMsg, 0.3, false)
 	if err != nil {
 		// Record failure in circuit breaker
 		s.circuitBreaker.RecordFailure()
@@ -969,7 +1187,12 @@ func (s *columnEnrichmentService) enrichColumnBatch(
 			zap.String("table", tableCtx.TableName),
 			zap.String("circuit_state", s.circuitBreaker.State().String()),
 			zap.Int("consecutive_failures", s.circuitBreaker.ConsecutiveFailures()))
-		return nil, fmt.Errorf("LLM call failed after retries: %w", err)
+		s.logger.Error("LLM call failed",
+			zap.String("table", tableCtx.TableName),
+			zap.Int("column_count", len(columns)),
+			zap.String("error_type", string(llm.ClassifyError(err).Type)),
+			zap.Error(err))
+		return nil, fmt.Errorf("LLM call failed: %w", err)
 	}
 
 	// Record success in circuit breaker
@@ -1015,6 +1238,13 @@ func (s *columnEnrichmentService) enrichColumnBatch(
 			}
 		}
 		questionModels := ConvertQuestionInputs(questionInputs, projectID, nil)
+		taxonomy := questionCategoriesOrDefault(s.questionCategories)
+		if unmapped := SnapQuestionCategories(questionModels, taxonomy); len(unmapped) > 0 {
+			s.logger.Warn("LLM returned question categories outside the taxonomy",
+				zap.String("table", tableCtx.TableName),
+				zap.Strings("categories", unmapped),
+				zap.Strings("taxonomy", taxonomy))
+		}
 		if len(questionModels) > 0 {
 			if err := s.questionService.CreateQuestions(ctx, questionModels); err != nil {
 				s.logger.Error("failed to store ontology questions from column enrichment",
@@ -1033,6 +1263,70 @@ func (s *columnEnrichmentService) enrichColumnBatch(
 	return response.Columns, nil
 }
 
+// enrichColumnBatchSplit enriches the two halves of an over-budget batch in turn.
+// Each half is checked against the budget again, so splitting continues as needed.
+func (s *columnEnrichmentService) enrichColumnBatchSplit(
+	ctx context.Context,
+	projectID uuid.UUID,
+	llmClient llm.LLMClient,
+	tableCtx *TableContext,
+	columns []*models.SchemaColumn,
+	fkInfo map[string]string,
+	enumSamples map[string][]string,
+) ([]columnEnrichment, error) {
+	mid := len(columns) / 2
+	var enrichments []columnEnrichment
+	for _, half := range [][]*models.SchemaColumn{columns[:mid], columns[mid:]} {
+		halfFKInfo, halfEnumSamples := columnSubsetContext(half, fkInfo, enumSamples)
+		result, err := s.enrichColumnBatch(ctx, projectID, llmClient, tableCtx, half, halfFKInfo, halfEnumSamples)
+		if err != nil {
+			return nil, err
+		}
+		enrichments = append(enrichments, result...)
+	}
+	return enrichments, nil
+}
+
+// promptTokenBudget returns the project's prompt token budget.
+func (s *columnEnrichmentService) promptTokenBudget(ctx context.Context, projectID uuid.UUID) int {
+	return s.ontologySettings(ctx, projectID).MaxPromptTokens
+}
+
+// ontologySettings returns the project's ontology settings, falling back to the
+// defaults when the project cannot be read.
+func (s *columnEnrichmentService) ontologySettings(ctx context.Context, projectID uuid.UUID) *OntologySettings {
+	if s.projectRepo == nil {
+		return ontologySettingsFromParameters(nil)
+	}
+	project, err := s.projectRepo.Get(ctx, projectID)
+	if err != nil || project == nil {
+		s.logger.Debug("Using default ontology settings",
+			zap.String("project_id", projectID.String()),
+			zap.Error(err))
+		return ontologySettingsFromParameters(nil)
+	}
+	return ontologySettingsFromParameters(project.Parameters)
+}
+
+// columnSubsetContext filters FK info and enum samples down to the given columns.
+func columnSubsetContext(
+	columns []*models.SchemaColumn,
+	fkInfo map[string]string,
+	enumSamples map[string][]string,
+) (map[string]string, map[string][]string) {
+	subsetFKInfo := make(map[string]string)
+	subsetEnumSamples := make(map[string][]string)
+	for _, col := range columns {
+		if target, ok := fkInfo[col.ColumnName]; ok {
+			subsetFKInfo[col.ColumnName] = target
+		}
+		if samples, ok := enumSamples[col.ColumnName]; ok {
+			subsetEnumSamples[col.ColumnName] = samples
+		}
+	}
+	return subsetFKInfo, subsetEnumSamples
+}
+
 func (s *columnEnrichmentService) columnEnrichmentSystemMessage() string {
 	return `You are a database schema expert. Your task is to analyze database columns and provide semantic metadata that helps AI agents write accurate SQL queries.
 
@@ -1096,6 +1390,12 @@ func (s *columnEnrichmentService) buildColumnEnrichmentPrompt(
 	// FK context for role detection
 	s.writeFKContext(&sb, fkInfo)
 
+	// Defaults carry business meaning, e.g. the initial state of new rows
+	s.writeColumnDefaults(&sb, columns, enumSamples)
+
+	// Check constraints are business rules the database enforces
+	s.writeCheckConstraints(&sb, columns)
+
 	// Instructions
 	sb.WriteString("\n## For Each Column Provide:\n")
 	sb.WriteString("1. **description**: 1 sentence explaining business meaning\n")
@@ -1116,7 +1416,7 @@ func (s *columnEnrichmentService) buildColumnEnrichmentPrompt(
 	sb.WriteString("\n## Questions for Clarification\n\n")
 	sb.WriteString("Additionally, identify any areas of uncertainty where user clarification would improve accuracy.\n")
 	sb.WriteString("For each uncertainty, provide:\n")
-	sb.WriteString("- **category**: terminology | enumeration | relationship | business_rules | temporal | data_quality\n")
+	sb.WriteString("- **category**: exactly one of " + strings.Join(questionCategoriesOrDefault(s.questionCategories), " | ") + "\n")
 	sb.WriteString("- **priority**: 1 (critical) | 2 (important) | 3 (nice-to-have)\n")
 	sb.WriteString("- **question**: A clear question for the domain expert\n")
 	sb.WriteString("- **context**: Relevant schema/data context\n\n")
@@ -1181,6 +1481,72 @@ func (s *columnEnrichmentService) writeFKContext(sb *strings.Builder, fkInfo map
 	}
 }
 
+// writeColumnDefaults lists the columns' default values. Sequence defaults are left
+// out since they only generate keys. Defaults of enum-like columns are called out as
+// the initial state of new rows.
+func (s *columnEnrichmentService) writeColumnDefaults(sb *strings.Builder, columns []*models.SchemaColumn, enumSamples map[string][]string) {
+	var lines []string
+	for _, col := range columns {
+		if col.DefaultValue == nil {
+			continue
+		}
+		def := displayColumnDefault(*col.DefaultValue)
+		if def == "" || strings.HasPrefix(strings.ToLower(def), "nextval(") {
+			continue
+		}
+		if len(enumSamples[col.ColumnName]) > 0 {
+			lines = append(lines, fmt.Sprintf("- %s defaults to %s (initial state of new rows)", col.ColumnName, def))
+		} else {
+			lines = append(lines, fmt.Sprintf("- %s defaults to %s", col.ColumnName, def))
+		}
+	}
+	if len(lines) == 0 {
+		return
+	}
+
+	sb.WriteString("\n## Column Defaults\n")
+	sb.WriteString("Values assigned when a row is inserted without them - mention what the default means in the column's description:\n")
+	for _, line := range lines {
+		sb.WriteString(line + "\n")
+	}
+}
+
+// writeCheckConstraints lists the check constraints on the columns. A constraint
+// spanning several columns is listed once, under all of them.
+func (s *columnEnrichmentService) writeCheckConstraints(sb *strings.Builder, columns []*models.SchemaColumn) {
+	var definitions []string
+	columnsByDefinition := make(map[string][]string)
+	for _, col := range columns {
+		for _, def := range col.CheckConstraints {
+			if _, seen := columnsByDefinition[def]; !seen {
+				definitions = append(definitions, def)
+			}
+			columnsByDefinition[def] = append(columnsByDefinition[def], col.ColumnName)
+		}
+	}
+	if len(definitions) == 0 {
+		return
+	}
+
+	sb.WriteString("\n## Check Constraints\n")
+	sb.WriteString("Business rules the database enforces - reflect them in the column descriptions:\n")
+	for _, def := range definitions {
+		sb.WriteString(fmt.Sprintf("- %s: %s\n", strings.Join(columnsByDefinition[def], ", "), def))
+	}
+}
+
+// displayColumnDefault strips the type cast postgres appends to literal defaults,
+// so 'pending'::character varying reads as 'pending'.
+func displayColumnDefault(raw string) string {
+	def := strings.TrimSpace(raw)
+	if strings.HasPrefix(def, "'") {
+		if end := strings.LastIndex(def, "'::"); end > 0 {
+			def = def[:end+1]
+		}
+	}
+	return def
+}
+
 // saveEnrichments persists LLM enrichment results to column metadata, merging with
 // existing metadata from the feature extraction pipeline. Applies project-level enum
 // definitions and distribution metadata before saving.
@@ -1397,7 +1763,7 @@ func mergeSampledEnumValues(existing []models.ColumnEnumValue, sampledValues []s
 }
 
 // applyEnumDistributionsToColumnEnumValues applies distribution data (count, percentage,
-// state semantics) directly to ColumnEnumValue structs in EnumFeatures.
+// state semantics, recent activity) directly to ColumnEnumValue structs in EnumFeatures.
 func applyEnumDistributionsToColumnEnumValues(features *models.EnumFeatures, dist *datasource.EnumDistributionResult) {
 	if dist == nil || len(dist.Distributions) == 0 {
 		return
@@ -1408,11 +1774,20 @@ func applyEnumDistributionsToColumnEnumValues(features *models.EnumFeatures, dis
 		distMap[d.Value] = d
 	}
 
+	if dist.ActivityTimestampCol != "" {
+		features.ActivityTimestampColumn = dist.ActivityTimestampCol
+		features.ActiveSince = dist.ActiveSince
+	}
+
 	for i := range features.Values {
 		cev := &features.Values[i]
 		if d, ok := distMap[cev.Value]; ok {
 			cev.Count = d.Count
 			cev.Percentage = d.Percentage
+			if dist.ActivityTimestampCol != "" {
+				cev.RecentCount = d.RecentCount
+				cev.IsStale = d.RecentCount == 0
+			}
 			if d.IsLikelyInitialState {
 				cev.Category = models.EnumCategoryInitial
 			} else if d.IsLikelyErrorState {
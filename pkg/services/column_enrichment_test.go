@@ -143,6 +143,10 @@ func (r *testColEnrichmentSchemaRepo) GetOrphanTables(ctx context.Context, proje
 	return nil, nil
 }
 
+func (r *testColEnrichmentSchemaRepo) GetRelationshipStats(ctx context.Context, projectID, datasourceID uuid.UUID) (*models.RelationshipStats, error) {
+	return nil, nil
+}
+
 func (r *testColEnrichmentSchemaRepo) UpsertRelationshipWithMetrics(ctx context.Context, rel *models.SchemaRelationship, metrics *models.DiscoveryMetrics) error {
 	return nil
 }
@@ -294,6 +298,10 @@ func (s *testColEnrichmentQuestionService) GetPendingQuestions(ctx context.Conte
 	return nil, nil
 }
 
+func (s *testColEnrichmentQuestionService) ListQuestions(ctx context.Context, projectID uuid.UUID, filters repositories.QuestionListFilters) (*repositories.QuestionListResult, error) {
+	return &repositories.QuestionListResult{}, nil
+}
+
 func (s *testColEnrichmentQuestionService) GetPendingCount(ctx context.Context, projectID uuid.UUID) (int, error) {
 	return 0, nil
 }
@@ -310,6 +318,22 @@ func (s *testColEnrichmentQuestionService) SkipQuestion(ctx context.Context, que
 	return nil
 }
 
+func (s *testColEnrichmentQuestionService) AnswerFromGlossary(ctx context.Context, projectID uuid.UUID, terms []*models.BusinessGlossaryTerm) (int, error) {
+	return 0, nil
+}
+
+func (s *testColEnrichmentQuestionService) TrimQuestionsPerTable(ctx context.Context, projectID uuid.UUID, maxPerTable int) (int, error) {
+	return 0, nil
+}
+
+func (s *testColEnrichmentQuestionService) FindOrphanedQuestions(ctx context.Context, projectID uuid.UUID) ([]*models.OntologyQuestion, error) {
+	return nil, nil
+}
+
+func (s *testColEnrichmentQuestionService) CleanupOrphanedQuestions(ctx context.Context, projectID uuid.UUID, deleteQuestions bool) (int, error) {
+	return 0, nil
+}
+
 func (s *testColEnrichmentQuestionService) DeleteQuestion(ctx context.Context, questionID uuid.UUID) error {
 	return nil
 }
@@ -428,6 +452,10 @@ func (c *testColEnrichmentLLMClient) GetEndpoint() string {
 	return "http://test"
 }
 
+func (c *testColEnrichmentLLMClient) GenerateResponseStream(ctx context.Context, prompt string, systemMessage string, temperature float64) (*llm.ResponseStream, error) {
+	return llm.CompletedResponseStream(c.GenerateResponse(ctx, prompt, systemMessage, temperature, false))
+}
+
 func (c *testColEnrichmentLLMClient) Close() error {
 	return nil
 }
@@ -511,7 +539,9 @@ func TestColumnEnrichmentService_EnrichProject_Success(t *testing.T) {
 	assert.Equal(t, "email", *emailMeta.SemanticType)
 }
 
-func TestColumnEnrichmentService_EnrichProject_WithRetryOnTransientError(t *testing.T) {
+// Transient failures are retried by the LLM client (see llm.RetryPolicy); the service
+// makes one GenerateResponse call per prompt so retries do not multiply.
+func TestColumnEnrichmentService_EnrichProject_DoesNotRetryAboveClient(t *testing.T) {
 	projectID := uuid.New()
 
 	columns := []*models.SchemaColumn{
@@ -530,7 +560,7 @@ func TestColumnEnrichmentService_EnrichProject_WithRetryOnTransientError(t *test
 		]
 	}`
 
-	// Setup service with LLM that fails twice then succeeds
+	// Setup service with LLM that fails once then succeeds
 	schemaRepo := &testColEnrichmentSchemaRepo{
 		columnsByTable: map[string][]*models.SchemaColumn{
 			"users": columns,
@@ -539,7 +569,7 @@ func TestColumnEnrichmentService_EnrichProject_WithRetryOnTransientError(t *test
 	llmFactory := &testColEnrichmentLLMFactory{
 		client: &testColEnrichmentLLMClient{
 			response:     llmResponse,
-			failUntil:    2,
+			failUntil:    1,
 			errorType:    llm.ErrorTypeEndpoint,
 			errorMessage: "rate limited",
 		},
@@ -559,13 +589,12 @@ func TestColumnEnrichmentService_EnrichProject_WithRetryOnTransientError(t *test
 	result, err := service.EnrichProject(context.Background(), projectID, []string{"users"}, nil)
 
 	// Verify
-	require.NoError(t, err)
-	assert.Equal(t, 1, len(result.TablesEnriched))
-	assert.Equal(t, 0, len(result.TablesFailed))
+	require.Error(t, err)
+	assert.Equal(t, 0, len(result.TablesEnriched))
+	assert.Equal(t, 1, len(result.TablesFailed))
 
-	// Verify LLM was called with retries
 	client := llmFactory.client.(*testColEnrichmentLLMClient)
-	assert.Equal(t, 3, client.callCount, "Should have retried twice before success")
+	assert.Equal(t, 1, client.callCount, "the client's error is final; the service must not retry it again")
 }
 
 func TestColumnEnrichmentService_EnrichProject_NonRetryableError(t *testing.T) {
@@ -604,17 +633,14 @@ func TestColumnEnrichmentService_EnrichProject_NonRetryableError(t *testing.T) {
 	// Execute
 	result, err := service.EnrichProject(context.Background(), projectID, []string{"users"}, nil)
 
-	// Verify - should fail after retrying (retry.Do will still attempt retries)
-	// Note: retry.Do attempts all retries even for non-retryable errors,
-	// but logs them differently as "non-retryable"
+	// Verify - should fail without retrying
 	require.Error(t, err) // EnrichProject now fails fast on table failures
 	assert.Contains(t, err.Error(), "1 of 1 tables failed enrichment")
 	assert.Equal(t, 0, len(result.TablesEnriched))
 	assert.Equal(t, 1, len(result.TablesFailed))
 
-	// Verify LLM was called with retries
 	client := llmFactory.client.(*testColEnrichmentLLMClient)
-	assert.Equal(t, 4, client.callCount, "Will retry even on auth error (1 initial + 3 retries)")
+	assert.Equal(t, 1, client.callCount, "auth errors are not retried")
 }
 
 func TestColumnEnrichmentService_EnrichProject_LargeTable(t *testing.T) {
@@ -857,6 +883,10 @@ func (c *testColEnrichmentPartialFailureClient) GetEndpoint() string {
 	return "http://test"
 }
 
+func (c *testColEnrichmentPartialFailureClient) GenerateResponseStream(ctx context.Context, prompt string, systemMessage string, temperature float64) (*llm.ResponseStream, error) {
+	return llm.CompletedResponseStream(c.GenerateResponse(ctx, prompt, systemMessage, temperature, false))
+}
+
 func (c *testColEnrichmentPartialFailureClient) Close() error {
 	return nil
 }
@@ -1207,6 +1237,10 @@ func (c *testColEnrichmentRetryableFailureClient) GetEndpoint() string {
 	return "http://test"
 }
 
+func (c *testColEnrichmentRetryableFailureClient) GenerateResponseStream(ctx context.Context, prompt string, systemMessage string, temperature float64) (*llm.ResponseStream, error) {
+	return llm.CompletedResponseStream(c.GenerateResponse(ctx, prompt, systemMessage, temperature, false))
+}
+
 func (c *testColEnrichmentRetryableFailureClient) Close() error {
 	return nil
 }
@@ -1566,6 +1600,75 @@ func TestColumnEnrichmentService_buildColumnEnrichmentPrompt_EnumInstructions(t
 	assert.Contains(t, prompt, `"label":`)
 }
 
+// TestColumnEnrichmentService_buildColumnEnrichmentPrompt_ColumnDefaults verifies that
+// discovered defaults reach the prompt, with enum defaults noted as the initial state.
+func TestColumnEnrichmentService_buildColumnEnrichmentPrompt_ColumnDefaults(t *testing.T) {
+	service := &columnEnrichmentService{
+		circuitBreaker: llm.NewCircuitBreaker(llm.DefaultCircuitBreakerConfig()),
+		logger:         zap.NewNop(),
+	}
+
+	strPtr := func(s string) *string { return &s }
+	columns := []*models.SchemaColumn{
+		{ColumnName: "id", DataType: "integer", IsPrimaryKey: true, DefaultValue: strPtr("nextval('orders_id_seq'::regclass)")},
+		{ColumnName: "status", DataType: "character varying", DefaultValue: strPtr("'pending'::character varying")},
+		{ColumnName: "created_at", DataType: "timestamp with time zone", DefaultValue: strPtr("now()")},
+		{ColumnName: "item_count", DataType: "integer", DefaultValue: strPtr("0")},
+		{ColumnName: "notes", DataType: "text"},
+	}
+	enumSamples := map[string][]string{
+		"status": {"pending", "shipped", "delivered"},
+	}
+
+	prompt := service.buildColumnEnrichmentPrompt(&TableContext{TableName: "orders"}, columns, nil, enumSamples)
+
+	assert.Contains(t, prompt, "## Column Defaults")
+	assert.Contains(t, prompt, "- status defaults to 'pending' (initial state of new rows)")
+	assert.Contains(t, prompt, "- created_at defaults to now()\n")
+	assert.Contains(t, prompt, "- item_count defaults to 0\n")
+	assert.NotContains(t, prompt, "nextval", "sequence defaults are noise")
+	assert.NotContains(t, prompt, "notes defaults")
+}
+
+// TestColumnEnrichmentService_buildColumnEnrichmentPrompt_CheckConstraints verifies that
+// check constraints reach the prompt, with multi-column constraints listed once.
+func TestColumnEnrichmentService_buildColumnEnrichmentPrompt_CheckConstraints(t *testing.T) {
+	service := &columnEnrichmentService{
+		circuitBreaker: llm.NewCircuitBreaker(llm.DefaultCircuitBreakerConfig()),
+		logger:         zap.NewNop(),
+	}
+
+	dateRange := "CHECK ((ends_at > starts_at))"
+	columns := []*models.SchemaColumn{
+		{ColumnName: "id", DataType: "integer", IsPrimaryKey: true},
+		{ColumnName: "amount", DataType: "numeric", CheckConstraints: []string{"CHECK ((amount >= (0)::numeric))"}},
+		{ColumnName: "starts_at", DataType: "timestamp with time zone", CheckConstraints: []string{dateRange}},
+		{ColumnName: "ends_at", DataType: "timestamp with time zone", CheckConstraints: []string{dateRange}},
+	}
+
+	prompt := service.buildColumnEnrichmentPrompt(&TableContext{TableName: "bookings"}, columns, nil, nil)
+
+	assert.Contains(t, prompt, "## Check Constraints")
+	assert.Contains(t, prompt, "- amount: CHECK ((amount >= (0)::numeric))\n")
+	assert.Contains(t, prompt, "- starts_at, ends_at: CHECK ((ends_at > starts_at))\n")
+	assert.Equal(t, 1, strings.Count(prompt, dateRange))
+}
+
+// TestColumnEnrichmentService_buildColumnEnrichmentPrompt_QuestionCategories verifies that
+// the prompt offers the configured question categories as a closed set.
+func TestColumnEnrichmentService_buildColumnEnrichmentPrompt_QuestionCategories(t *testing.T) {
+	service := &columnEnrichmentService{
+		circuitBreaker:     llm.NewCircuitBreaker(llm.DefaultCircuitBreakerConfig()),
+		questionCategories: []string{"business_rules", "compliance"},
+		logger:             zap.NewNop(),
+	}
+	columns := []*models.SchemaColumn{{ColumnName: "id", DataType: "integer", IsPrimaryKey: true}}
+
+	prompt := service.buildColumnEnrichmentPrompt(&TableContext{TableName: "orders"}, columns, nil, nil)
+
+	assert.Contains(t, prompt, "- **category**: exactly one of business_rules | compliance\n")
+}
+
 // TestColumnEnrichmentService_mergeEnumDefinitions tests that project-level enum
 // definitions are correctly merged with sampled values.
 func TestColumnEnrichmentService_mergeEnumDefinitions(t *testing.T) {
@@ -2275,6 +2378,161 @@ func TestFindCompletionTimestampColumn(t *testing.T) {
 	}
 }
 
+// TestFindActivityTimestampColumn verifies that the row-activity timestamp prefers
+// audit_updated metadata, falls back to updated/modified names, and ignores completion.
+func TestFindActivityTimestampColumn(t *testing.T) {
+	completedAtID := uuid.New()
+	changedAtID := uuid.New()
+
+	tests := []struct {
+		name               string
+		columns            []*models.SchemaColumn
+		metadataByColumnID map[uuid.UUID]*models.ColumnMetadata
+		expected           string
+	}{
+		{
+			name: "prefers audit_updated metadata over names",
+			columns: []*models.SchemaColumn{
+				{ColumnName: "updated_at", DataType: "timestamp"},
+				{ID: changedAtID, ColumnName: "changed_at", DataType: "timestamp"},
+			},
+			metadataByColumnID: map[uuid.UUID]*models.ColumnMetadata{
+				changedAtID: {
+					SchemaColumnID: changedAtID,
+					Features: models.ColumnMetadataFeatures{
+						TimestampFeatures: &models.TimestampFeatures{TimestampPurpose: models.TimestampPurposeAuditUpdated},
+					},
+				},
+			},
+			expected: "changed_at",
+		},
+		{
+			name: "falls back to updated name",
+			columns: []*models.SchemaColumn{
+				{ColumnName: "created_at", DataType: "timestamp"},
+				{ColumnName: "modified_at", DataType: "datetime2"},
+			},
+			metadataByColumnID: map[uuid.UUID]*models.ColumnMetadata{},
+			expected:           "modified_at",
+		},
+		{
+			name: "ignores non-timestamp updated column",
+			columns: []*models.SchemaColumn{
+				{ColumnName: "updated", DataType: "boolean"},
+			},
+			metadataByColumnID: map[uuid.UUID]*models.ColumnMetadata{},
+			expected:           "",
+		},
+		{
+			name: "ignores completion timestamps",
+			columns: []*models.SchemaColumn{
+				{ID: completedAtID, ColumnName: "completed_at", DataType: "timestamp"},
+			},
+			metadataByColumnID: map[uuid.UUID]*models.ColumnMetadata{
+				completedAtID: {
+					SchemaColumnID: completedAtID,
+					Features: models.ColumnMetadataFeatures{
+						TimestampFeatures: &models.TimestampFeatures{TimestampPurpose: models.TimestampPurposeCompletion},
+					},
+				},
+			},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, findActivityTimestampColumn(tt.columns, tt.metadataByColumnID))
+		})
+	}
+}
+
+type enumDistributionCall struct {
+	columnName string
+	opts       datasource.EnumDistributionOptions
+}
+
+type testEnumDistributionDiscoverer struct {
+	datasource.SchemaDiscoverer
+	calls []enumDistributionCall
+}
+
+func (d *testEnumDistributionDiscoverer) GetEnumValueDistribution(ctx context.Context, schemaName, tableName, columnName string, opts datasource.EnumDistributionOptions) (*datasource.EnumDistributionResult, error) {
+	d.calls = append(d.calls, enumDistributionCall{columnName: columnName, opts: opts})
+	return &datasource.EnumDistributionResult{ColumnName: columnName}, nil
+}
+
+func (d *testEnumDistributionDiscoverer) Close() error {
+	return nil
+}
+
+// TestAnalyzeEnumDistributions_UsesDetectedActivityColumn verifies that the distribution
+// query for a status column counts recent rows against the table's updated timestamp,
+// using the project's configured activity window, and that other enums are left alone.
+func TestAnalyzeEnumDistributions_UsesDetectedActivityColumn(t *testing.T) {
+	projectID := uuid.New()
+	statusCol := &models.SchemaColumn{ID: uuid.New(), ColumnName: "order_status", DataType: "varchar"}
+	currencyCol := &models.SchemaColumn{ID: uuid.New(), ColumnName: "currency", DataType: "varchar"}
+	columns := []*models.SchemaColumn{
+		{ID: uuid.New(), ColumnName: "id", DataType: "bigint", IsPrimaryKey: true},
+		statusCol,
+		currencyCol,
+		{ID: uuid.New(), ColumnName: "updated_at", DataType: "timestamp with time zone"},
+	}
+
+	discoverer := &testEnumDistributionDiscoverer{}
+	service := &columnEnrichmentService{
+		dsSvc:          &testColEnrichmentDatasourceService{},
+		adapterFactory: &mockAdapterFactoryForFeatureExtraction{discoverer: discoverer},
+		projectRepo: &mockProjectRepoForDefaultDatasource{project: &models.Project{
+			ID:         projectID,
+			Parameters: map[string]interface{}{"ontology": map[string]interface{}{"enum_activity_window_days": float64(30)}},
+		}},
+		logger: zap.NewNop(),
+	}
+
+	before := time.Now()
+	_, err := service.analyzeEnumDistributions(context.Background(), projectID, &TableContext{TableName: "orders"},
+		columns, []*models.SchemaColumn{statusCol, currencyCol}, map[uuid.UUID]*models.ColumnMetadata{})
+	require.NoError(t, err)
+
+	require.Len(t, discoverer.calls, 2)
+	status, currency := discoverer.calls[0], discoverer.calls[1]
+	assert.Equal(t, "order_status", status.columnName)
+	assert.Equal(t, "updated_at", status.opts.ActivityTimestampCol)
+	assert.WithinDuration(t, before.AddDate(0, 0, -30), status.opts.ActiveSince, time.Minute)
+	assert.True(t, status.opts.CountsActivity())
+
+	assert.Equal(t, "currency", currency.columnName)
+	assert.False(t, currency.opts.CountsActivity(), "non-status enums should not count activity")
+}
+
+// TestApplyEnumDistributionsToColumnEnumValues_MarksStaleValues verifies that values
+// with no recent rows are marked stale and the activity window is recorded.
+func TestApplyEnumDistributionsToColumnEnumValues_MarksStaleValues(t *testing.T) {
+	since := time.Now().AddDate(0, 0, -90)
+	features := &models.EnumFeatures{Values: []models.ColumnEnumValue{
+		{Value: "active"}, {Value: "legacy_pending"}, {Value: "unsampled"},
+	}}
+	dist := &datasource.EnumDistributionResult{
+		ActivityTimestampCol: "updated_at",
+		ActiveSince:          &since,
+		Distributions: []datasource.EnumValueDistribution{
+			{Value: "active", Count: 900, RecentCount: 120},
+			{Value: "legacy_pending", Count: 40, RecentCount: 0},
+		},
+	}
+
+	applyEnumDistributionsToColumnEnumValues(features, dist)
+
+	assert.Equal(t, "updated_at", features.ActivityTimestampColumn)
+	require.NotNil(t, features.ActiveSince)
+	assert.False(t, features.Values[0].IsStale)
+	assert.Equal(t, int64(120), features.Values[0].RecentCount)
+	assert.True(t, features.Values[1].IsStale)
+	assert.False(t, features.Values[2].IsStale, "values outside the distribution are not judged")
+}
+
 // TestIsTimestampType verifies timestamp type detection.
 func TestIsTimestampType(t *testing.T) {
 	tests := []struct {
@@ -2952,3 +3210,175 @@ func TestEnrichProject_AllColumnsHighConfidence(t *testing.T) {
 	// Verify column metadata was saved
 	assert.Equal(t, 2, colMetadataRepo.upsertCalls)
 }
+
+// enrichmentEchoResponse returns an enrichment for every col_N that appears in the prompt.
+func enrichmentEchoResponse(prompt string, maxColumns int) string {
+	var enrichments []string
+	for i := 1; i <= maxColumns; i++ {
+		if strings.Contains(prompt, fmt.Sprintf(" col_%d ", i)) {
+			enrichments = append(enrichments, fmt.Sprintf(
+				`{"name": "col_%d", "description": "Column %d", "semantic_type": "text", "role": "attribute"}`, i, i))
+		}
+	}
+	return `{"columns": [` + strings.Join(enrichments, ",") + `]}`
+}
+
+// budgetedEnrichmentService returns a service whose project has the given prompt token budget.
+func budgetedEnrichmentService(budget int) *columnEnrichmentService {
+	return &columnEnrichmentService{
+		projectRepo: &mockProjectRepoForDefaultDatasource{project: &models.Project{
+			Parameters: map[string]interface{}{
+				"ontology": map[string]interface{}{"max_prompt_tokens": float64(budget)},
+			},
+		}},
+		circuitBreaker: llm.NewCircuitBreaker(llm.DefaultCircuitBreakerConfig()),
+		logger:         zap.NewNop(),
+	}
+}
+
+func TestColumnEnrichmentService_enrichColumnBatch_TrimsSampleValuesToFitBudget(t *testing.T) {
+	tableCtx := &TableContext{TableName: "events"}
+	columns := []*models.SchemaColumn{
+		{ColumnName: "col_1", DataType: "text"},
+		{ColumnName: "col_2", DataType: "text"},
+	}
+	longValue := strings.Repeat("x", 4000)
+	enumSamples := map[string][]string{
+		"col_1": {longValue, longValue, longValue},
+		"col_2": {longValue, longValue},
+	}
+
+	// Budget fits the prompt without sample values, but not with them
+	service := budgetedEnrichmentService(0)
+	systemMsg := service.columnEnrichmentSystemMessage()
+	withoutSamples := llm.EstimatePromptTokens(service.buildColumnEnrichmentPrompt(tableCtx, columns, nil, nil), systemMsg)
+	budget := withoutSamples + 100
+	service = budgetedEnrichmentService(budget)
+	require.Greater(t, llm.EstimatePromptTokens(service.buildColumnEnrichmentPrompt(tableCtx, columns, nil, enumSamples), systemMsg), budget)
+
+	var prompts []string
+	var recorded map[string]any
+	client := &testColEnrichmentLLMClient{
+		generateFunc: func(ctx context.Context, prompt, systemMsg string, temperature float64, thinking bool) (*llm.GenerateResponseResult, error) {
+			prompts = append(prompts, prompt)
+			recorded = llm.GetContext(ctx)
+			return &llm.GenerateResponseResult{Content: enrichmentEchoResponse(prompt, 2)}, nil
+		},
+	}
+
+	result, err := service.enrichColumnBatch(context.Background(), uuid.New(), client, tableCtx, columns, nil, enumSamples)
+	require.NoError(t, err)
+	assert.Len(t, result, 2)
+
+	require.Len(t, prompts, 1, "sample values alone should bring the prompt under budget")
+	assert.NotContains(t, prompts[0], longValue)
+	assert.LessOrEqual(t, llm.EstimatePromptTokens(prompts[0], systemMsg), budget)
+	assert.Equal(t, true, recorded["prompt_trimmed"])
+	assert.Equal(t, "sample_values", recorded["prompt_trim_strategy"])
+	assert.Equal(t, budget, recorded["prompt_token_budget"])
+}
+
+func TestColumnEnrichmentService_enrichColumnBatch_SplitsWideBatchToFitBudget(t *testing.T) {
+	tableCtx := &TableContext{TableName: "wide_table"}
+	columns := make([]*models.SchemaColumn, 40)
+	for i := range columns {
+		columns[i] = &models.SchemaColumn{ColumnName: fmt.Sprintf("col_%d", i+1), DataType: "varchar"}
+	}
+
+	// Budget fits roughly a quarter of the columns per prompt
+	service := budgetedEnrichmentService(0)
+	systemMsg := service.columnEnrichmentSystemMessage()
+	budget := llm.EstimatePromptTokens(service.buildColumnEnrichmentPrompt(tableCtx, columns[:10], nil, nil), systemMsg)
+	service = budgetedEnrichmentService(budget)
+
+	var prompts []string
+	client := &testColEnrichmentLLMClient{
+		generateFunc: func(ctx context.Context, prompt, systemMsg string, temperature float64, thinking bool) (*llm.GenerateResponseResult, error) {
+			prompts = append(prompts, prompt)
+			assert.Equal(t, "split_columns", llm.GetContext(ctx)["prompt_trim_strategy"])
+			return &llm.GenerateResponseResult{Content: enrichmentEchoResponse(prompt, 40)}, nil
+		},
+	}
+
+	result, err := service.enrichColumnBatch(context.Background(), uuid.New(), client, tableCtx, columns, nil, nil)
+	require.NoError(t, err)
+
+	assert.Greater(t, len(prompts), 1, "expected the batch to be split")
+	for _, p := range prompts {
+		assert.LessOrEqual(t, llm.EstimatePromptTokens(p, systemMsg), budget)
+	}
+	require.Len(t, result, 40, "every column should still be enriched")
+	for i, e := range result {
+		assert.Equal(t, fmt.Sprintf("col_%d", i+1), e.Name, "column order should be preserved")
+	}
+}
+
+// TestEnrichProject_DescribesColumnsAndPreservesCuratedDescriptions verifies that every
+// column ends up with a description, that audit/system columns are described without
+// the LLM, and that a human-edited description survives re-extraction.
+func TestEnrichProject_DescribesColumnsAndPreservesCuratedDescriptions(t *testing.T) {
+	projectID := uuid.New()
+	ptrStr := func(s string) *string { return &s }
+
+	statusID, notesID, createdAtID, updatedByID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	columns := []*models.SchemaColumn{
+		{ID: statusID, ColumnName: "status", DataType: "varchar"},
+		{ID: notesID, ColumnName: "notes", DataType: "text"},
+		{ID: createdAtID, ColumnName: "created_at", DataType: "timestamp with time zone"},
+		{ID: updatedByID, ColumnName: "updated_by", DataType: "uuid"},
+	}
+
+	colMetadataRepo := &testColEnrichmentColumnMetadataRepo{
+		metadataByColumnID: map[uuid.UUID]*models.ColumnMetadata{
+			notesID: {
+				SchemaColumnID: notesID,
+				Description:    ptrStr("Free-form notes entered by the support agent"),
+				Source:         models.ProvenanceInferred,
+				LastEditSource: ptrStr(models.ProvenanceManual),
+			},
+		},
+	}
+
+	var prompts []string
+	llmClient := &testColEnrichmentLLMClient{
+		generateFunc: func(_ context.Context, prompt, _ string, _ float64, _ bool) (*llm.GenerateResponseResult, error) {
+			prompts = append(prompts, prompt)
+			return &llm.GenerateResponseResult{Content: `{"columns": [
+				{"name": "status", "description": "Lifecycle state of the ticket", "role": "dimension"},
+				{"name": "notes", "description": "Notes", "role": "attribute", "synonyms": ["comments"]}
+			]}`}, nil
+		},
+	}
+
+	service := &columnEnrichmentService{
+		schemaRepo:         &testColEnrichmentSchemaRepo{columnsByTable: map[string][]*models.SchemaColumn{"tickets": columns}},
+		columnMetadataRepo: colMetadataRepo,
+		dsSvc:              &testColEnrichmentDatasourceService{},
+		llmFactory:         &testColEnrichmentLLMFactory{client: llmClient},
+		workerPool:         llm.NewWorkerPool(llm.WorkerPoolConfig{MaxConcurrent: 1}, zap.NewNop()),
+		circuitBreaker:     llm.NewCircuitBreaker(llm.DefaultCircuitBreakerConfig()),
+		logger:             zap.NewNop(),
+	}
+
+	result, err := service.EnrichProject(context.Background(), projectID, []string{"tickets"}, nil)
+	require.NoError(t, err)
+	require.Len(t, result.TablesEnriched, 1)
+
+	require.Len(t, prompts, 1, "columns of one table should be described in a single batch")
+	assert.NotContains(t, prompts[0], "created_at", "audit columns should not be sent to the LLM")
+	assert.NotContains(t, prompts[0], "updated_by", "audit columns should not be sent to the LLM")
+
+	descriptionOf := func(id uuid.UUID) string {
+		meta := colMetadataRepo.metadataByColumnID[id]
+		require.NotNil(t, meta)
+		require.NotNil(t, meta.Description)
+		return *meta.Description
+	}
+	assert.Equal(t, "Lifecycle state of the ticket", descriptionOf(statusID))
+	assert.Equal(t, "When the row was created.", descriptionOf(createdAtID))
+	assert.Equal(t, "Who last modified the row.", descriptionOf(updatedByID))
+	assert.Equal(t, "Free-form notes entered by the support agent", descriptionOf(notesID),
+		"a human-edited description must not be overwritten")
+	assert.Equal(t, []string{"comments"}, colMetadataRepo.metadataByColumnID[notesID].Features.Synonyms,
+		"additive enrichments still apply to curated columns")
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/adapters/datasource"
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+	"github.com/ekaya-inc/ekaya-engine/pkg/repositories"
+)
+
+// columnEntityOverrideKey normalizes a "table.column" override key so lookups do
+// not depend on how the user cased it.
+func columnEntityOverrideKey(key string) string {
+	return strings.ToLower(strings.TrimSpace(key))
+}
+
+// loadColumnEntityOverrides returns the project's column-to-table overrides. A project
+// that cannot be read has none, so discovery falls back to inference.
+func loadColumnEntityOverrides(
+	ctx context.Context,
+	projectRepo repositories.ProjectRepository,
+	projectID uuid.UUID,
+	logger *zap.Logger,
+) map[string]string {
+	if projectRepo == nil {
+		return nil
+	}
+	project, err := projectRepo.Get(ctx, projectID)
+	if err != nil || project == nil {
+		logger.Debug("No column entity overrides loaded",
+			zap.String("project_id", projectID.String()),
+			zap.Error(err))
+		return nil
+	}
+	return ontologySettingsFromParameters(project.Parameters).ColumnEntityOverrides
+}
+
+// columnEntityOverride returns the table a user pinned table.column to, if any.
+func columnEntityOverride(overrides map[string]string, table, column string) (string, bool) {
+	target, ok := overrides[columnEntityOverrideKey(table+"."+column)]
+	return target, ok
+}
+
+// primaryKeyColumnName returns the name of the table's single-column primary key,
+// defaulting to "id" when it has none or a composite one.
+func primaryKeyColumnName(columns []*models.SchemaColumn, tableID uuid.UUID) string {
+	name := ""
+	for _, column := range columns {
+		if column.SchemaTableID != tableID || !column.IsPrimaryKey {
+			continue
+		}
+		if name != "" {
+			return "id"
+		}
+		name = column.ColumnName
+	}
+	if name == "" {
+		return "id"
+	}
+	return name
+}
+
+// sameTableName reports whether two table references name the same table. A bare
+// name matches a schema-qualified one with the same table part.
+func sameTableName(a, b string) bool {
+	schemaA, tableA := datasource.SplitQualifiedIdentifier(a)
+	schemaB, tableB := datasource.SplitQualifiedIdentifier(b)
+	if !strings.EqualFold(tableA, tableB) {
+		return false
+	}
+	return schemaA == "" || schemaB == "" || strings.EqualFold(schemaA, schemaB)
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/ekaya-inc/ekaya-engine/pkg/models"
 	"github.com/ekaya-inc/ekaya-engine/pkg/repositories"
 	"github.com/ekaya-inc/ekaya-engine/pkg/services/dag"
+	"github.com/ekaya-inc/ekaya-engine/pkg/tracing"
 )
 
 // ColumnFeatureExtractionService extracts deterministic features from database columns.
@@ -49,6 +50,14 @@ type columnFeatureExtractionService struct {
 	getTenantCtx       TenantContextFunc
 	logger             *zap.Logger
 
+	// Tables with at least this many rows use planner distinct estimates (0 = never)
+	distinctEstimateRowThreshold int64
+
+	// How enum-like column values are sampled for prompts; zero values use
+	// defaultEnumSampleStrategy and the adapter's default limit
+	enumSampleStrategy datasource.DistinctValueStrategy
+	enumSampleLimit    int
+
 	// Dependencies for question creation when classifiers are uncertain
 	questionService OntologyQuestionService
 
@@ -94,6 +103,9 @@ func NewColumnFeatureExtractionServiceWithLLM(
 
 // NewColumnFeatureExtractionServiceFull creates a column feature extraction service with all dependencies.
 // Use this constructor for full Phase 2-4 functionality including FK resolution with data overlap queries.
+// Tables with at least distinctEstimateRowThreshold rows take distinct counts from planner
+// statistics when the datasource supports it; 0 always counts exactly. Enum-like columns
+// are sampled with enumSampleStrategy, up to enumSampleLimit values.
 func NewColumnFeatureExtractionServiceFull(
 	schemaRepo repositories.SchemaRepository,
 	columnMetadataRepo repositories.ColumnMetadataRepository,
@@ -103,19 +115,25 @@ func NewColumnFeatureExtractionServiceFull(
 	workerPool *llm.WorkerPool,
 	getTenantCtx TenantContextFunc,
 	questionService OntologyQuestionService,
+	distinctEstimateRowThreshold int64,
+	enumSampleStrategy datasource.DistinctValueStrategy,
+	enumSampleLimit int,
 	logger *zap.Logger,
 ) ColumnFeatureExtractionService {
 	return &columnFeatureExtractionService{
-		schemaRepo:         schemaRepo,
-		columnMetadataRepo: columnMetadataRepo,
-		datasourceService:  datasourceService,
-		adapterFactory:     adapterFactory,
-		llmFactory:         llmFactory,
-		workerPool:         workerPool,
-		getTenantCtx:       getTenantCtx,
-		questionService:    questionService,
-		logger:             logger.Named("column-feature-extraction"),
-		classifiers:        make(map[models.ClassificationPath]ColumnClassifier),
+		schemaRepo:                   schemaRepo,
+		columnMetadataRepo:           columnMetadataRepo,
+		datasourceService:            datasourceService,
+		adapterFactory:               adapterFactory,
+		llmFactory:                   llmFactory,
+		workerPool:                   workerPool,
+		getTenantCtx:                 getTenantCtx,
+		questionService:              questionService,
+		distinctEstimateRowThreshold: distinctEstimateRowThreshold,
+		enumSampleStrategy:           enumSampleStrategy,
+		enumSampleLimit:              enumSampleLimit,
+		logger:                       logger.Named("column-feature-extraction"),
+		classifiers:                  make(map[models.ClassificationPath]ColumnClassifier),
 	}
 }
 
@@ -431,7 +449,7 @@ func (s *columnFeatureExtractionService) refreshColumnDiscoveryStats(
 			columnNames = append(columnNames, col.ColumnName)
 		}
 
-		stats, err := discoverer.AnalyzeColumnStats(ctx, table.SchemaName, table.TableName, columnNames)
+		stats, err := s.analyzeColumnStats(ctx, discoverer, table, columnNames)
 		if err != nil {
 			s.logger.Warn("Failed to analyze column stats; leaving existing stats unchanged for table",
 				zap.String("schema_name", table.SchemaName),
@@ -481,9 +499,34 @@ func (s *columnFeatureExtractionService) refreshColumnDiscoveryStats(
 	return nil
 }
 
+// analyzeColumnStats gathers stats for a table's columns. Tables at or above the
+// distinct estimate row threshold use the discoverer's planner estimates when it
+// supports them, skipping COUNT(DISTINCT); everything else is counted exactly.
+func (s *columnFeatureExtractionService) analyzeColumnStats(
+	ctx context.Context,
+	discoverer datasource.SchemaDiscoverer,
+	table *models.SchemaTable,
+	columnNames []string,
+) (_ []datasource.ColumnStats, err error) {
+	ctx, span := tracing.Start(ctx, "column_stats.table",
+		tracing.AttrTable.String(table.TableName), tracing.AttrColumnCount.Int(len(columnNames)))
+	defer func() { tracing.End(span, err) }()
+
+	if s.distinctEstimateRowThreshold > 0 && table.RowCount != nil && *table.RowCount >= s.distinctEstimateRowThreshold {
+		if estimator, ok := discoverer.(datasource.DistinctCountEstimator); ok {
+			s.logger.Debug("Using planner distinct estimates for large table",
+				zap.String("table", table.TableName),
+				zap.Int64("row_count", *table.RowCount))
+			return estimator.EstimateColumnStats(ctx, table.SchemaName, table.TableName, columnNames)
+		}
+	}
+	return discoverer.AnalyzeColumnStats(ctx, table.SchemaName, table.TableName, columnNames)
+}
+
 func applyColumnStatsToProfile(profile *models.ColumnDataProfile, stat datasource.ColumnStats) {
 	profile.RowCount = stat.RowCount
 	profile.DistinctCount = stat.DistinctCount
+	profile.DistinctCountEstimated = stat.DistinctEstimated
 	profile.NullCount = nullCountFromColumnStats(stat)
 	profile.NullRate = 0
 	if profile.RowCount > 0 {
@@ -544,6 +587,11 @@ func (s *columnFeatureExtractionService) detectPatternsInSamples(sampleValues []
 // This is the core routing logic that replaces static column name pattern matching.
 // Columns are routed to paths based on their data type and sample value patterns.
 func (s *columnFeatureExtractionService) routeToClassificationPath(profile *models.ColumnDataProfile) models.ClassificationPath {
+	// Arrays carry their element type (int8[]); they are not scalars of that type
+	if _, isArray := datasource.ArrayElementType(profile.DataType); isArray {
+		return models.ClassificationPathUnknown
+	}
+
 	// Route based on data type hierarchy
 	switch {
 	case isTimestampType(profile.DataType):
@@ -761,10 +809,29 @@ func (s *columnFeatureExtractionService) runPhase2ColumnClassification(
 		progressCallback(0, len(profiles), "Classifying columns")
 	}
 
-	// Build work items - ONE LLM request per column
+	// Timestamp columns per table, for attributing date-part columns to their source
+	timestampColumnsByTable := make(map[string][]string)
+	for _, p := range profiles {
+		if p.ClassificationPath == models.ClassificationPathTimestamp || isTimestampType(p.DataType) {
+			timestampColumnsByTable[p.TableName] = append(timestampColumnsByTable[p.TableName], p.ColumnName)
+		}
+	}
+
+	// Build work items - ONE LLM request per column, except date parts which are
+	// classified deterministically
 	workItems := make([]llm.WorkItem[*models.ColumnFeatures], 0, len(profiles))
 	for _, profile := range profiles {
 		p := profile // capture for closure
+		if datePart := detectDatePart(p, timestampColumnsByTable[p.TableName]); datePart != nil {
+			features := datePartColumnFeatures(p, datePart)
+			workItems = append(workItems, llm.WorkItem[*models.ColumnFeatures]{
+				ID: p.ColumnID.String(),
+				Execute: func(context.Context) (*models.ColumnFeatures, error) {
+					return features, nil
+				},
+			})
+			continue
+		}
 		workItems = append(workItems, llm.WorkItem[*models.ColumnFeatures]{
 			ID: p.ColumnID.String(),
 			Execute: func(ctx context.Context) (*models.ColumnFeatures, error) {
@@ -855,6 +922,23 @@ func (s *columnFeatureExtractionService) runPhase2ColumnClassification(
 	return result, nil
 }
 
+// defaultEnumSampleStrategy samples the values that dominate an enum-like column.
+const defaultEnumSampleStrategy = datasource.DistinctValuesMostFrequent
+
+// enumSampleOptions returns the configured enum sampling options, falling back to
+// defaultEnumSampleStrategy and datasource.DefaultDistinctValueLimit.
+func (s *columnFeatureExtractionService) enumSampleOptions() datasource.DistinctValueOptions {
+	opts := datasource.DistinctValueOptions{
+		Strategy: s.enumSampleStrategy,
+		Limit:    s.enumSampleLimit,
+	}
+	if opts.Strategy == "" {
+		opts.Strategy = defaultEnumSampleStrategy
+	}
+	opts.Limit = opts.EffectiveLimit()
+	return opts
+}
+
 func (s *columnFeatureExtractionService) hydrateEnumSampleValues(
 	ctx context.Context,
 	projectID uuid.UUID,
@@ -904,13 +988,33 @@ func (s *columnFeatureExtractionService) hydrateEnumSampleValues(
 	}
 	defer discoverer.Close()
 
+	recentSampling := datasource.RecentSamplingFromMap(ds.Config)
+	sampleLimits := datasource.SampleLimitsFromMap(ds.Config)
+	profilesByTable := make(map[uuid.UUID][]*models.ColumnDataProfile)
+	for _, profile := range profiles {
+		profilesByTable[profile.TableID] = append(profilesByTable[profile.TableID], profile)
+	}
+
 	for _, profile := range enumProfiles {
 		table := tableByID[profile.TableID]
 		if table == nil {
 			return fmt.Errorf("table %s not found for enum sampling", profile.TableID)
 		}
 
-		values, err := discoverer.GetDistinctValues(ctx, table.SchemaName, table.TableName, profile.ColumnName, 50)
+		// The configured strategy (most-frequent by default, so the prompt shows the values
+		// that dominate the column), unless the datasource opted into sampling the most
+		// recently written rows. The datasource may also cap individual columns.
+		opts := s.enumSampleOptions()
+		if limit := datasource.SampleLimitFor(sampleLimits, table.SchemaName, table.TableName, profile.ColumnName); limit > 0 {
+			opts.Limit = limit
+		}
+		if recentSampling {
+			if recencyCol := findRecencyColumn(profilesByTable[profile.TableID]); recencyCol != "" {
+				opts.Strategy = datasource.DistinctValuesMostRecent
+				opts.RecencyColumn = recencyCol
+			}
+		}
+		values, err := discoverer.SampleDistinctValues(ctx, table.SchemaName, table.TableName, profile.ColumnName, opts)
 		if err != nil {
 			s.logger.Debug("Failed to sample enum values during feature extraction; continuing without samples",
 				zap.String("schema", table.SchemaName),
@@ -925,6 +1029,28 @@ func (s *columnFeatureExtractionService) hydrateEnumSampleValues(
 	return nil
 }
 
+// creationTimestampNames are column names commonly set when a row is inserted. They
+// order rows by recency when the table has no updated/modified timestamp.
+var creationTimestampNames = []string{
+	"created_at", "created_on", "inserted_at", "created", "creation_date",
+}
+
+// findRecencyColumn picks the timestamp column that orders a table's rows by recency:
+// a well-known updated/modified timestamp, or failing that a created timestamp.
+// Returns empty string if the table has neither.
+func findRecencyColumn(profiles []*models.ColumnDataProfile) string {
+	for _, names := range [][]string{activityTimestampNames, creationTimestampNames} {
+		for _, name := range names {
+			for _, p := range profiles {
+				if strings.EqualFold(p.ColumnName, name) && isTimestampType(p.DataType) {
+					return p.ColumnName
+				}
+			}
+		}
+	}
+	return ""
+}
+
 // createQuestionsFromUncertainClassifications collects questions from columns where the
 // classifier was uncertain and stores them in the ontology questions table.
 func (s *columnFeatureExtractionService) createQuestionsFromUncertainClassifications(
@@ -2618,6 +2744,11 @@ type FKResolutionResult struct {
 	FKTargetColumn string    `json:"fk_target_column"`
 	FKConfidence   float64   `json:"fk_confidence"`
 	LLMModelUsed   string    `json:"llm_model_used"`
+
+	// InconclusiveTargets lists "table.column" targets whose value overlap was computed
+	// from too few source values to count either way. They are worth re-checking once
+	// the source has more data.
+	InconclusiveTargets []string `json:"inconclusive_targets,omitempty"`
 }
 
 // phase4FKCandidate represents a potential FK target with overlap statistics for Phase 4.
@@ -2679,6 +2810,7 @@ func (s *columnFeatureExtractionService) runPhase4FKResolution(
 		return fmt.Errorf("create schema discoverer: %w", err)
 	}
 	defer discoverer.Close()
+	minOverlapSamples := datasource.MinOverlapSamplesFromMap(ds.Config)
 
 	// Get all PK columns as potential FK targets
 	pkColumns, err := s.schemaRepo.GetPrimaryKeyColumns(ctx, projectID, datasourceID)
@@ -2718,7 +2850,7 @@ func (s *columnFeatureExtractionService) runPhase4FKResolution(
 		workItems = append(workItems, llm.WorkItem[*FKResolutionResult]{
 			ID: cid.String(),
 			Execute: func(ctx context.Context) (*FKResolutionResult, error) {
-				return s.resolveFKTarget(ctx, projectID, profile, sourceTable, pkColumns, tableByID, discoverer)
+				return s.resolveFKTarget(ctx, projectID, profile, sourceTable, pkColumns, tableByID, discoverer, minOverlapSamples)
 			},
 		})
 	}
@@ -2736,7 +2868,7 @@ func (s *columnFeatureExtractionService) runPhase4FKResolution(
 	})
 
 	// Track outcomes for logging
-	var successCount, failureCount, noTargetCount int
+	var successCount, failureCount, noTargetCount, inconclusiveCount int
 	for _, r := range results {
 		if r.Err != nil {
 			s.logger.Error("FK resolution failed",
@@ -2745,6 +2877,13 @@ func (s *columnFeatureExtractionService) runPhase4FKResolution(
 			failureCount++
 			continue
 		}
+		if len(r.Result.InconclusiveTargets) > 0 {
+			inconclusiveCount += len(r.Result.InconclusiveTargets)
+			s.logger.Info("FK overlap inconclusive: too few sampled values",
+				zap.String("column_id", r.ID),
+				zap.Strings("targets", r.Result.InconclusiveTargets),
+				zap.Int("min_overlap_samples", minOverlapSamples))
+		}
 		if r.Result.FKTargetTable == "" {
 			noTargetCount++
 			continue
@@ -2756,11 +2895,15 @@ func (s *columnFeatureExtractionService) runPhase4FKResolution(
 	s.logger.Info("Phase 4 complete",
 		zap.Int("resolved", successCount),
 		zap.Int("no_target", noTargetCount),
-		zap.Int("failed", failureCount))
+		zap.Int("failed", failureCount),
+		zap.Int("inconclusive_pairs", inconclusiveCount))
 
 	// Report final progress
 	if progressCallback != nil {
 		summary := fmt.Sprintf("Resolved %d FK targets", successCount)
+		if inconclusiveCount > 0 {
+			summary += fmt.Sprintf(" (%d pairs inconclusive: too few sampled values)", inconclusiveCount)
+		}
 		progressCallback(len(fkQueue), len(fkQueue), summary)
 	}
 
@@ -2842,6 +2985,8 @@ func (s *columnFeatureExtractionService) runPhase4FKResolutionLLMOnly(
 }
 
 // resolveFKTarget resolves the FK target for a single column using overlap queries and LLM analysis.
+// Overlaps computed from fewer than minOverlapSamples distinct source values are inconclusive:
+// they neither resolve the column nor rule the target out, and are reported for a later re-check.
 func (s *columnFeatureExtractionService) resolveFKTarget(
 	ctx context.Context,
 	projectID uuid.UUID,
@@ -2850,9 +2995,11 @@ func (s *columnFeatureExtractionService) resolveFKTarget(
 	pkColumns []*models.SchemaColumn,
 	tableByID map[uuid.UUID]*models.SchemaTable,
 	discoverer datasource.SchemaDiscoverer,
+	minOverlapSamples int,
 ) (*FKResolutionResult, error) {
 	// Find candidate PK columns with compatible types
 	candidates := make([]phase4FKCandidate, 0)
+	var inconclusive []string
 
 	for _, pkCol := range pkColumns {
 		// Skip self-reference (same table)
@@ -2861,7 +3008,7 @@ func (s *columnFeatureExtractionService) resolveFKTarget(
 		}
 
 		// Skip incompatible types
-		if !areTypesCompatibleForFK(profile.DataType, pkCol.DataType) {
+		if !models.AreTypesCompatibleForFK(profile.DataType, pkCol.DataType) {
 			continue
 		}
 
@@ -2884,6 +3031,11 @@ func (s *columnFeatureExtractionService) resolveFKTarget(
 			continue
 		}
 
+		if overlap.Inconclusive(minOverlapSamples) {
+			inconclusive = append(inconclusive, fmt.Sprintf("%s.%s", pkTable.TableName, pkCol.ColumnName))
+			continue
+		}
+
 		// Only consider candidates with meaningful overlap
 		if overlap.MatchRate < 0.5 {
 			continue
@@ -2906,7 +3058,8 @@ func (s *columnFeatureExtractionService) resolveFKTarget(
 		s.logger.Debug("No FK candidates with sufficient overlap",
 			zap.String("column", fmt.Sprintf("%s.%s", sourceTable.TableName, profile.ColumnName)))
 		return &FKResolutionResult{
-			ColumnID: profile.ColumnID,
+			ColumnID:            profile.ColumnID,
+			InconclusiveTargets: inconclusive,
 		}, nil
 	}
 
@@ -2918,16 +3071,21 @@ func (s *columnFeatureExtractionService) resolveFKTarget(
 	// If only one candidate with high overlap (>90%), use it directly
 	if len(candidates) == 1 && candidates[0].OverlapRate >= 0.9 {
 		return &FKResolutionResult{
-			ColumnID:       profile.ColumnID,
-			FKTargetTable:  candidates[0].Table,
-			FKTargetColumn: candidates[0].Column,
-			FKConfidence:   candidates[0].OverlapRate,
-			LLMModelUsed:   "data_overlap",
+			ColumnID:            profile.ColumnID,
+			FKTargetTable:       candidates[0].Table,
+			FKTargetColumn:      candidates[0].Column,
+			FKConfidence:        candidates[0].OverlapRate,
+			LLMModelUsed:        "data_overlap",
+			InconclusiveTargets: inconclusive,
 		}, nil
 	}
 
 	// Multiple candidates or uncertain - use LLM to decide
-	return s.resolveFKTargetWithLLM(ctx, projectID, profile, candidates)
+	result, err := s.resolveFKTargetWithLLM(ctx, projectID, profile, candidates)
+	if result != nil {
+		result.InconclusiveTargets = inconclusive
+	}
+	return result, err
 }
 
 // resolveFKTargetWithLLM uses LLM to choose among FK candidates with overlap evidence.
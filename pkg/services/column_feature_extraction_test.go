@@ -936,6 +936,9 @@ func (m *mockSchemaRepoForFeatureExtraction) GetEmptyTables(ctx context.Context,
 func (m *mockSchemaRepoForFeatureExtraction) GetOrphanTables(ctx context.Context, projectID, datasourceID uuid.UUID) ([]string, error) {
 	return nil, nil
 }
+func (m *mockSchemaRepoForFeatureExtraction) GetRelationshipStats(ctx context.Context, projectID, datasourceID uuid.UUID) (*models.RelationshipStats, error) {
+	return nil, nil
+}
 func (m *mockSchemaRepoForFeatureExtraction) UpsertRelationshipWithMetrics(ctx context.Context, rel *models.SchemaRelationship, metrics *models.DiscoveryMetrics) error {
 	return nil
 }
@@ -1072,10 +1075,12 @@ type featureExtractionAnalyzeCall struct {
 }
 
 type featureExtractionDistinctValuesCall struct {
-	SchemaName string
-	TableName  string
-	ColumnName string
-	Limit      int
+	SchemaName    string
+	TableName     string
+	ColumnName    string
+	Limit         int
+	Strategy      datasource.DistinctValueStrategy
+	RecencyColumn string
 }
 
 func (m *mockSchemaDiscovererForFeatureExtraction) AnalyzeColumnStats(ctx context.Context, schemaName, tableName string, columnNames []string) ([]datasource.ColumnStats, error) {
@@ -1109,6 +1114,21 @@ func (m *mockSchemaDiscovererForFeatureExtraction) GetDistinctValues(ctx context
 	return m.distinctValuesByColumn[schemaName+"."+tableName+"."+columnName], nil
 }
 
+func (m *mockSchemaDiscovererForFeatureExtraction) SampleDistinctValues(ctx context.Context, schemaName, tableName, columnName string, opts datasource.DistinctValueOptions) ([]string, error) {
+	if m.distinctValuesErr != nil {
+		return nil, m.distinctValuesErr
+	}
+	m.distinctValueCalls = append(m.distinctValueCalls, featureExtractionDistinctValuesCall{
+		SchemaName:    schemaName,
+		TableName:     tableName,
+		ColumnName:    columnName,
+		Limit:         opts.Limit,
+		Strategy:      opts.Strategy,
+		RecencyColumn: opts.RecencyColumn,
+	})
+	return m.distinctValuesByColumn[schemaName+"."+tableName+"."+columnName], nil
+}
+
 func (m *mockSchemaDiscovererForFeatureExtraction) Close() error {
 	m.closed = true
 	return nil
@@ -1562,6 +1582,94 @@ func TestRunPhase1DataCollection_ContinuesWhenAnalyzeColumnStatsFailsForOneTable
 	}
 }
 
+// estimatingSchemaDiscovererForFeatureExtraction adds planner distinct estimates to the mock discoverer.
+type estimatingSchemaDiscovererForFeatureExtraction struct {
+	*mockSchemaDiscovererForFeatureExtraction
+	estimateCalls []featureExtractionAnalyzeCall
+}
+
+func (m *estimatingSchemaDiscovererForFeatureExtraction) EstimateColumnStats(ctx context.Context, schemaName, tableName string, columnNames []string) ([]datasource.ColumnStats, error) {
+	m.estimateCalls = append(m.estimateCalls, featureExtractionAnalyzeCall{
+		SchemaName:  schemaName,
+		TableName:   tableName,
+		ColumnNames: append([]string(nil), columnNames...),
+	})
+	stats := m.columnStatsByTable[schemaName+"."+tableName]
+	for i := range stats {
+		stats[i].DistinctEstimated = true
+	}
+	return stats, nil
+}
+
+func TestRunPhase1DataCollection_UsesDistinctEstimatesForLargeTables(t *testing.T) {
+	projectID := uuid.New()
+	datasourceID := uuid.New()
+	smallTableID := uuid.New()
+	largeTableID := uuid.New()
+	smallColumnID := uuid.New()
+	largeColumnID := uuid.New()
+	smallRows := int64(1000)
+	largeRows := int64(50_000_000)
+
+	mockRepo := &mockSchemaRepoForFeatureExtraction{
+		tables: []*models.SchemaTable{
+			{ID: smallTableID, ProjectID: projectID, DatasourceID: datasourceID, SchemaName: "public", TableName: "accounts", RowCount: &smallRows},
+			{ID: largeTableID, ProjectID: projectID, DatasourceID: datasourceID, SchemaName: "public", TableName: "events", RowCount: &largeRows},
+		},
+		columns: []*models.SchemaColumn{
+			{ID: smallColumnID, ProjectID: projectID, SchemaTableID: smallTableID, ColumnName: "id", DataType: "uuid", IsSelected: true},
+			{ID: largeColumnID, ProjectID: projectID, SchemaTableID: largeTableID, ColumnName: "session_id", DataType: "uuid", IsSelected: true},
+		},
+	}
+
+	discoverer := &estimatingSchemaDiscovererForFeatureExtraction{
+		mockSchemaDiscovererForFeatureExtraction: &mockSchemaDiscovererForFeatureExtraction{
+			columnStatsByTable: map[string][]datasource.ColumnStats{
+				"public.accounts": {{ColumnName: "id", RowCount: smallRows, NonNullCount: smallRows, DistinctCount: smallRows}},
+				// The estimate undercounts a unique column by 5%, within the allowed error
+				"public.events": {{ColumnName: "session_id", RowCount: largeRows, NonNullCount: largeRows, DistinctCount: largeRows / 100 * 95}},
+			},
+		},
+	}
+
+	svc := &columnFeatureExtractionService{
+		schemaRepo:        mockRepo,
+		datasourceService: &mockDatasourceServiceForFeatureExtraction{},
+		adapterFactory: &mockAdapterFactoryForFeatureExtraction{
+			discoverer: discoverer,
+		},
+		distinctEstimateRowThreshold: 1_000_000,
+		logger:                       zap.NewNop(),
+	}
+
+	result, err := svc.runPhase1DataCollection(context.Background(), projectID, datasourceID, nil)
+	if err != nil {
+		t.Fatalf("runPhase1DataCollection() error = %v", err)
+	}
+
+	if len(discoverer.estimateCalls) != 1 || discoverer.estimateCalls[0].TableName != "events" {
+		t.Fatalf("EstimateColumnStats calls = %+v, want one call for events", discoverer.estimateCalls)
+	}
+	if len(discoverer.analyzeCalls) != 1 || discoverer.analyzeCalls[0].TableName != "accounts" {
+		t.Fatalf("AnalyzeColumnStats calls = %+v, want one exact call for accounts", discoverer.analyzeCalls)
+	}
+
+	for _, profile := range result.Profiles {
+		wantEstimated := profile.ColumnID == largeColumnID
+		if profile.DistinctCountEstimated != wantEstimated {
+			t.Errorf("%s DistinctCountEstimated = %v, want %v", profile.TableName, profile.DistinctCountEstimated, wantEstimated)
+		}
+	}
+
+	largeUpdate, ok := mockRepo.updatedColumnJoinability[largeColumnID]
+	if !ok {
+		t.Fatal("UpdateColumnJoinability was not called for the large table")
+	}
+	if largeUpdate.JoinabilityReason == nil || *largeUpdate.JoinabilityReason != models.JoinabilityUniqueValues {
+		t.Errorf("large table joinability reason = %v, want %q", largeUpdate.JoinabilityReason, models.JoinabilityUniqueValues)
+	}
+}
+
 func TestExtractColumnFeatures_EmptySchema(t *testing.T) {
 	projectID := uuid.New()
 	datasourceID := uuid.New()
@@ -1858,11 +1966,14 @@ func TestRunPhase2ColumnClassification_HydratesEnumSampleValues(t *testing.T) {
 		t.Fatalf("SampleValues length = %d, want 3", len(profiles[0].SampleValues))
 	}
 	if len(discoverer.distinctValueCalls) != 1 {
-		t.Fatalf("GetDistinctValues calls = %d, want 1", len(discoverer.distinctValueCalls))
+		t.Fatalf("SampleDistinctValues calls = %d, want 1", len(discoverer.distinctValueCalls))
 	}
 	call := discoverer.distinctValueCalls[0]
 	if call.SchemaName != "public" || call.TableName != "orders" || call.ColumnName != "status" || call.Limit != 50 {
-		t.Errorf("GetDistinctValues call = %+v, want public.orders.status limit 50", call)
+		t.Errorf("SampleDistinctValues call = %+v, want public.orders.status limit 50", call)
+	}
+	if call.Strategy != datasource.DistinctValuesMostFrequent {
+		t.Errorf("SampleDistinctValues strategy = %q, want %q", call.Strategy, datasource.DistinctValuesMostFrequent)
 	}
 	for _, expected := range []string{"`approved`", "`pending`", "`rejected`"} {
 		if !strings.Contains(capturedPrompt, expected) {
@@ -1874,6 +1985,173 @@ func TestRunPhase2ColumnClassification_HydratesEnumSampleValues(t *testing.T) {
 	}
 }
 
+func TestHydrateEnumSampleValues_RecentSamplingOrdersByDetectedTimestamp(t *testing.T) {
+	projectID := uuid.New()
+	datasourceID := uuid.New()
+	ordersID := uuid.New()
+	tagsID := uuid.New()
+
+	mockRepo := &mockSchemaRepoForFeatureExtraction{
+		tables: []*models.SchemaTable{
+			{ID: ordersID, ProjectID: projectID, DatasourceID: datasourceID, SchemaName: "public", TableName: "orders"},
+			{ID: tagsID, ProjectID: projectID, DatasourceID: datasourceID, SchemaName: "public", TableName: "tags"},
+		},
+	}
+	discoverer := &mockSchemaDiscovererForFeatureExtraction{}
+
+	profiles := []*models.ColumnDataProfile{
+		{ColumnID: uuid.New(), ColumnName: "status", TableID: ordersID, TableName: "orders", DataType: "text", ClassificationPath: models.ClassificationPathEnum},
+		{ColumnID: uuid.New(), ColumnName: "created_at", TableID: ordersID, TableName: "orders", DataType: "timestamp with time zone"},
+		{ColumnID: uuid.New(), ColumnName: "updated_at", TableID: ordersID, TableName: "orders", DataType: "timestamp with time zone"},
+		{ColumnID: uuid.New(), ColumnName: "kind", TableID: tagsID, TableName: "tags", DataType: "text", ClassificationPath: models.ClassificationPathEnum},
+	}
+
+	svc := &columnFeatureExtractionService{
+		schemaRepo: mockRepo,
+		datasourceService: &mockDatasourceServiceForFeatureExtraction{
+			datasource: &models.Datasource{
+				ID:             datasourceID,
+				ProjectID:      projectID,
+				DatasourceType: "postgres",
+				Config:         map[string]any{"sample_recent_rows": true},
+			},
+		},
+		adapterFactory: &mockAdapterFactoryForFeatureExtraction{discoverer: discoverer},
+		logger:         zap.NewNop(),
+	}
+
+	if err := svc.hydrateEnumSampleValues(context.Background(), projectID, profiles); err != nil {
+		t.Fatalf("hydrateEnumSampleValues() error = %v", err)
+	}
+
+	if len(discoverer.distinctValueCalls) != 2 {
+		t.Fatalf("SampleDistinctValues calls = %d, want 2", len(discoverer.distinctValueCalls))
+	}
+	orders := discoverer.distinctValueCalls[0]
+	if orders.Strategy != datasource.DistinctValuesMostRecent || orders.RecencyColumn != "updated_at" {
+		t.Errorf("orders.status sampling = %q by %q, want %q by updated_at",
+			orders.Strategy, orders.RecencyColumn, datasource.DistinctValuesMostRecent)
+	}
+	// Without a recency timestamp the table falls back to most-frequent sampling
+	tags := discoverer.distinctValueCalls[1]
+	if tags.Strategy != datasource.DistinctValuesMostFrequent || tags.RecencyColumn != "" {
+		t.Errorf("tags.kind sampling = %q by %q, want %q", tags.Strategy, tags.RecencyColumn, datasource.DistinctValuesMostFrequent)
+	}
+}
+
+func TestHydrateEnumSampleValues_UsesConfiguredStrategyAndLimit(t *testing.T) {
+	projectID := uuid.New()
+	datasourceID := uuid.New()
+	ordersID := uuid.New()
+
+	discoverer := &mockSchemaDiscovererForFeatureExtraction{}
+	svc := &columnFeatureExtractionService{
+		schemaRepo: &mockSchemaRepoForFeatureExtraction{
+			tables: []*models.SchemaTable{
+				{ID: ordersID, ProjectID: projectID, DatasourceID: datasourceID, SchemaName: "public", TableName: "orders"},
+			},
+		},
+		datasourceService: &mockDatasourceServiceForFeatureExtraction{
+			datasource: &models.Datasource{ID: datasourceID, ProjectID: projectID, DatasourceType: "postgres"},
+		},
+		adapterFactory:     &mockAdapterFactoryForFeatureExtraction{discoverer: discoverer},
+		enumSampleStrategy: datasource.DistinctValuesRandom,
+		enumSampleLimit:    20,
+		logger:             zap.NewNop(),
+	}
+
+	profiles := []*models.ColumnDataProfile{
+		{ColumnID: uuid.New(), ColumnName: "status", TableID: ordersID, TableName: "orders", DataType: "text", ClassificationPath: models.ClassificationPathEnum},
+	}
+	if err := svc.hydrateEnumSampleValues(context.Background(), projectID, profiles); err != nil {
+		t.Fatalf("hydrateEnumSampleValues() error = %v", err)
+	}
+
+	if len(discoverer.distinctValueCalls) != 1 {
+		t.Fatalf("SampleDistinctValues calls = %d, want 1", len(discoverer.distinctValueCalls))
+	}
+	call := discoverer.distinctValueCalls[0]
+	if call.Strategy != datasource.DistinctValuesRandom || call.Limit != 20 {
+		t.Errorf("SampleDistinctValues call = %q limit %d, want %q limit 20", call.Strategy, call.Limit, datasource.DistinctValuesRandom)
+	}
+}
+
+func TestHydrateEnumSampleValues_DatasourceOverridesLimitPerColumn(t *testing.T) {
+	projectID := uuid.New()
+	datasourceID := uuid.New()
+	ordersID := uuid.New()
+
+	discoverer := &mockSchemaDiscovererForFeatureExtraction{}
+	svc := &columnFeatureExtractionService{
+		schemaRepo: &mockSchemaRepoForFeatureExtraction{
+			tables: []*models.SchemaTable{
+				{ID: ordersID, ProjectID: projectID, DatasourceID: datasourceID, SchemaName: "public", TableName: "orders"},
+			},
+		},
+		datasourceService: &mockDatasourceServiceForFeatureExtraction{
+			datasource: &models.Datasource{
+				ID:             datasourceID,
+				ProjectID:      projectID,
+				DatasourceType: "postgres",
+				Config:         map[string]any{"sample_limits": map[string]any{"Orders.Country": float64(250)}},
+			},
+		},
+		adapterFactory:  &mockAdapterFactoryForFeatureExtraction{discoverer: discoverer},
+		enumSampleLimit: 20,
+		logger:          zap.NewNop(),
+	}
+
+	profiles := []*models.ColumnDataProfile{
+		{ColumnID: uuid.New(), ColumnName: "status", TableID: ordersID, TableName: "orders", DataType: "text", ClassificationPath: models.ClassificationPathEnum},
+		{ColumnID: uuid.New(), ColumnName: "country", TableID: ordersID, TableName: "orders", DataType: "text", ClassificationPath: models.ClassificationPathEnum},
+	}
+	if err := svc.hydrateEnumSampleValues(context.Background(), projectID, profiles); err != nil {
+		t.Fatalf("hydrateEnumSampleValues() error = %v", err)
+	}
+
+	limits := make(map[string]int)
+	for _, call := range discoverer.distinctValueCalls {
+		limits[call.ColumnName] = call.Limit
+	}
+	if limits["status"] != 20 || limits["country"] != 250 {
+		t.Errorf("sample limits = %v, want status 20 and country 250", limits)
+	}
+}
+
+func TestFindRecencyColumn(t *testing.T) {
+	tests := []struct {
+		name     string
+		profiles []*models.ColumnDataProfile
+		want     string
+	}{
+		{
+			name: "prefers updated timestamp",
+			profiles: []*models.ColumnDataProfile{
+				{ColumnName: "created_at", DataType: "timestamp"},
+				{ColumnName: "modified_at", DataType: "timestamptz"},
+			},
+			want: "modified_at",
+		},
+		{
+			name:     "falls back to created timestamp",
+			profiles: []*models.ColumnDataProfile{{ColumnName: "Created_At", DataType: "datetime2"}},
+			want:     "Created_At",
+		},
+		{
+			name:     "ignores non-timestamp types",
+			profiles: []*models.ColumnDataProfile{{ColumnName: "updated_at", DataType: "text"}},
+			want:     "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := findRecencyColumn(tt.profiles); got != tt.want {
+				t.Errorf("findRecencyColumn() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestRunPhase2ColumnClassification_ContinuesWhenEnumSamplingFails(t *testing.T) {
 	projectID := uuid.New()
 	datasourceID := uuid.New()
@@ -3485,6 +3763,60 @@ func TestRunPhase4FKResolution_SkipsWhenEmpty(t *testing.T) {
 	}
 }
 
+func TestResolveFKTarget_TooFewSamplesIsInconclusive(t *testing.T) {
+	ordersTableID := uuid.New()
+	usersTableID := uuid.New()
+
+	tableByID := map[uuid.UUID]*models.SchemaTable{
+		ordersTableID: {ID: ordersTableID, SchemaName: "public", TableName: "orders"},
+		usersTableID:  {ID: usersTableID, SchemaName: "public", TableName: "users"},
+	}
+	pkColumns := []*models.SchemaColumn{
+		{ID: uuid.New(), SchemaTableID: usersTableID, ColumnName: "id", DataType: "bigint", IsPrimaryKey: true},
+	}
+	profile := &models.ColumnDataProfile{
+		ColumnID:   uuid.New(),
+		TableID:    ordersTableID,
+		ColumnName: "user_id",
+		TableName:  "orders",
+		DataType:   "bigint",
+	}
+
+	// A perfect match over three distinct values says nothing about the relationship.
+	disc := &mockDiscovererForDCD{
+		overlapResult: &datasource.ValueOverlapResult{
+			SourceDistinct: 3,
+			TargetDistinct: 3,
+			MatchedCount:   3,
+			MatchRate:      1.0,
+		},
+	}
+
+	svc := &columnFeatureExtractionService{logger: zap.NewNop()}
+
+	result, err := svc.resolveFKTarget(context.Background(), uuid.New(), profile,
+		tableByID[ordersTableID], pkColumns, tableByID, disc, datasource.DefaultMinOverlapSamples)
+	if err != nil {
+		t.Fatalf("resolveFKTarget() error = %v", err)
+	}
+	if result.FKTargetTable != "" {
+		t.Errorf("FKTargetTable = %q, want empty for inconclusive overlap", result.FKTargetTable)
+	}
+	if len(result.InconclusiveTargets) != 1 || result.InconclusiveTargets[0] != "users.id" {
+		t.Errorf("InconclusiveTargets = %v, want [users.id]", result.InconclusiveTargets)
+	}
+
+	// With the guard disabled the same overlap resolves directly.
+	result, err = svc.resolveFKTarget(context.Background(), uuid.New(), profile,
+		tableByID[ordersTableID], pkColumns, tableByID, disc, 0)
+	if err != nil {
+		t.Fatalf("resolveFKTarget() error = %v", err)
+	}
+	if result.FKTargetTable != "users" {
+		t.Errorf("FKTargetTable = %q, want users with guard disabled", result.FKTargetTable)
+	}
+}
+
 func TestRunPhase4FKResolution_FallsBackToLLMOnlyWithoutDatasource(t *testing.T) {
 	projectID := uuid.New()
 	fkColumnID := uuid.New()
@@ -4558,6 +4890,9 @@ func (m *mockQuestionServiceForFeatureExtraction) GetNextQuestion(ctx context.Co
 func (m *mockQuestionServiceForFeatureExtraction) GetPendingQuestions(ctx context.Context, projectID uuid.UUID) ([]*models.OntologyQuestion, error) {
 	return nil, nil
 }
+func (m *mockQuestionServiceForFeatureExtraction) ListQuestions(ctx context.Context, projectID uuid.UUID, filters repositories.QuestionListFilters) (*repositories.QuestionListResult, error) {
+	return &repositories.QuestionListResult{}, nil
+}
 func (m *mockQuestionServiceForFeatureExtraction) GetPendingCount(ctx context.Context, projectID uuid.UUID) (int, error) {
 	return 0, nil
 }
@@ -4570,6 +4905,18 @@ func (m *mockQuestionServiceForFeatureExtraction) AnswerQuestion(ctx context.Con
 func (m *mockQuestionServiceForFeatureExtraction) SkipQuestion(ctx context.Context, questionID uuid.UUID) error {
 	return nil
 }
+func (m *mockQuestionServiceForFeatureExtraction) AnswerFromGlossary(ctx context.Context, projectID uuid.UUID, terms []*models.BusinessGlossaryTerm) (int, error) {
+	return 0, nil
+}
+func (m *mockQuestionServiceForFeatureExtraction) TrimQuestionsPerTable(ctx context.Context, projectID uuid.UUID, maxPerTable int) (int, error) {
+	return 0, nil
+}
+func (m *mockQuestionServiceForFeatureExtraction) FindOrphanedQuestions(ctx context.Context, projectID uuid.UUID) ([]*models.OntologyQuestion, error) {
+	return nil, nil
+}
+func (m *mockQuestionServiceForFeatureExtraction) CleanupOrphanedQuestions(ctx context.Context, projectID uuid.UUID, deleteQuestions bool) (int, error) {
+	return 0, nil
+}
 func (m *mockQuestionServiceForFeatureExtraction) DeleteQuestion(ctx context.Context, questionID uuid.UUID) error {
 	return nil
 }
@@ -7,7 +7,13 @@ import (
 	"github.com/ekaya-inc/ekaya-engine/pkg/models"
 )
 
+// estimatedDistinctRelativeError is the error allowed for distinct counts taken from
+// planner statistics. pg_stats.n_distinct is derived from a sample and is commonly a
+// few percent off on large tables, so gates give estimates the benefit of the doubt.
+const estimatedDistinctRelativeError = 0.10
+
 // classifyJoinability determines if a column is suitable for join key consideration.
+// Estimated distinct counts (stats.DistinctEstimated) are treated as ±estimatedDistinctRelativeError.
 func classifyJoinability(col *models.SchemaColumn, stats *datasource.ColumnStats, tableRowCount int64) (bool, string) {
 	if col.IsPrimaryKey {
 		return true, models.JoinabilityPK
@@ -22,11 +28,19 @@ func classifyJoinability(col *models.SchemaColumn, stats *datasource.ColumnStats
 		return false, models.JoinabilityNoStats
 	}
 
-	if stats.DistinctCount == stats.NonNullCount && stats.NonNullCount > 0 {
-		return true, models.JoinabilityUniqueValues
+	if stats.NonNullCount > 0 {
+		if stats.DistinctCount == stats.NonNullCount {
+			return true, models.JoinabilityUniqueValues
+		}
+		if stats.DistinctEstimated && float64(stats.DistinctCount) >= float64(stats.NonNullCount)*(1-estimatedDistinctRelativeError) {
+			return true, models.JoinabilityUniqueValues
+		}
 	}
 
 	distinctRatio := float64(stats.DistinctCount) / float64(tableRowCount)
+	if stats.DistinctEstimated {
+		distinctRatio *= 1 + estimatedDistinctRelativeError
+	}
 	if distinctRatio < 0.01 {
 		return false, models.JoinabilityLowCardinality
 	}
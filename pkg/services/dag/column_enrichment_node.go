@@ -51,8 +51,12 @@ func (n *ColumnEnrichmentNode) Execute(ctx context.Context, dag *models.Ontology
 	n.Logger().Info("Starting column enrichment",
 		zap.String("project_id", dag.ProjectID.String()))
 
-	// During incremental extraction, skip if no columns changed
-	if changeSet != nil && !changeSet.HasChangedColumns() && !changeSet.HasChangedTables() {
+	// During incremental extraction, only enrich affected tables; skip if none are
+	var tableNames []string
+	if changeSet != nil {
+		tableNames = changeSet.AffectedTableNames()
+	}
+	if changeSet != nil && len(tableNames) == 0 {
 		n.Logger().Info("Skipping column enrichment (no changed columns or tables)",
 			zap.String("project_id", dag.ProjectID.String()))
 		if err := n.ReportProgress(ctx, 1, 1, "Skipped (no changed columns)"); err != nil {
@@ -73,10 +77,7 @@ func (n *ColumnEnrichmentNode) Execute(ctx context.Context, dag *models.Ontology
 		}
 	}
 
-	// During incremental extraction, only enrich affected tables
-	var tableNames []string
 	if changeSet != nil {
-		tableNames = changeSet.AffectedTableNames()
 		n.Logger().Info("Incremental column enrichment — processing affected tables only",
 			zap.String("project_id", dag.ProjectID.String()),
 			zap.Strings("tables", tableNames))
@@ -49,6 +49,9 @@ func (m *mockColumnEnrichmentDAGRepo) GetActiveByProject(ctx context.Context, pr
 func (m *mockColumnEnrichmentDAGRepo) Update(ctx context.Context, dag *models.OntologyDAG) error {
 	return nil
 }
+func (m *mockColumnEnrichmentDAGRepo) UpdateTokensSpent(ctx context.Context, id uuid.UUID, tokensSpent int) error {
+	return nil
+}
 func (m *mockColumnEnrichmentDAGRepo) UpdateStatus(ctx context.Context, dagID uuid.UUID, status models.DAGStatus, currentNode *string) error {
 	return nil
 }
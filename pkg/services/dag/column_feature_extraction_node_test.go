@@ -49,6 +49,9 @@ func (m *mockColumnFeatureDAGRepo) GetActiveByProject(ctx context.Context, proje
 func (m *mockColumnFeatureDAGRepo) Update(ctx context.Context, dag *models.OntologyDAG) error {
 	return nil
 }
+func (m *mockColumnFeatureDAGRepo) UpdateTokensSpent(ctx context.Context, id uuid.UUID, tokensSpent int) error {
+	return nil
+}
 func (m *mockColumnFeatureDAGRepo) UpdateStatus(ctx context.Context, dagID uuid.UUID, status models.DAGStatus, currentNode *string) error {
 	return nil
 }
@@ -49,6 +49,9 @@ func (m *mockFKDiscoveryDAGRepo) GetActiveByProject(ctx context.Context, project
 func (m *mockFKDiscoveryDAGRepo) Update(ctx context.Context, dag *models.OntologyDAG) error {
 	return nil
 }
+func (m *mockFKDiscoveryDAGRepo) UpdateTokensSpent(ctx context.Context, id uuid.UUID, tokensSpent int) error {
+	return nil
+}
 func (m *mockFKDiscoveryDAGRepo) UpdateStatus(ctx context.Context, dagID uuid.UUID, status models.DAGStatus, currentNode *string) error {
 	return nil
 }
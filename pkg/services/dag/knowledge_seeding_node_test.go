@@ -50,6 +50,9 @@ func (m *mockKnowledgeDAGRepo) GetActiveByProject(ctx context.Context, projectID
 func (m *mockKnowledgeDAGRepo) Update(ctx context.Context, dag *models.OntologyDAG) error {
 	return nil
 }
+func (m *mockKnowledgeDAGRepo) UpdateTokensSpent(ctx context.Context, id uuid.UUID, tokensSpent int) error {
+	return nil
+}
 func (m *mockKnowledgeDAGRepo) UpdateStatus(ctx context.Context, dagID uuid.UUID, status models.DAGStatus, currentNode *string) error {
 	return nil
 }
@@ -2,6 +2,8 @@ package dag
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -76,6 +78,28 @@ func (b *BaseNode) ReportProgress(ctx context.Context, current, total int, messa
 	return b.dagRepo.UpdateNodeProgress(ctx, b.currentNodeID, progress)
 }
 
+// ReportSummary records the node's final progress together with its result counts,
+// which the DAG status endpoint returns alongside the node.
+func (b *BaseNode) ReportSummary(ctx context.Context, message string, summary any) error {
+	if b.currentNodeID == uuid.Nil {
+		return nil // No node ID set, skip progress update
+	}
+
+	raw, err := json.Marshal(summary)
+	if err != nil {
+		return fmt.Errorf("marshal node summary: %w", err)
+	}
+
+	progress := &models.DAGNodeProgress{
+		Current: 1,
+		Total:   1,
+		Message: message,
+		Summary: raw,
+	}
+
+	return b.dagRepo.UpdateNodeProgress(ctx, b.currentNodeID, progress)
+}
+
 // Logger returns the node's logger.
 func (b *BaseNode) Logger() *zap.Logger {
 	return b.logger
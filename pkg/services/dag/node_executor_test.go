@@ -49,6 +49,9 @@ func (m *mockBaseNodeDAGRepo) GetActiveByProject(ctx context.Context, projectID
 func (m *mockBaseNodeDAGRepo) Update(ctx context.Context, dag *models.OntologyDAG) error {
 	return nil
 }
+func (m *mockBaseNodeDAGRepo) UpdateTokensSpent(ctx context.Context, id uuid.UUID, tokensSpent int) error {
+	return nil
+}
 func (m *mockBaseNodeDAGRepo) UpdateStatus(ctx context.Context, dagID uuid.UUID, status models.DAGStatus, currentNode *string) error {
 	return nil
 }
@@ -49,6 +49,9 @@ func (m *mockOntologyFinalizationDAGRepo) GetActiveByProject(ctx context.Context
 func (m *mockOntologyFinalizationDAGRepo) Update(ctx context.Context, dag *models.OntologyDAG) error {
 	return nil
 }
+func (m *mockOntologyFinalizationDAGRepo) UpdateTokensSpent(ctx context.Context, id uuid.UUID, tokensSpent int) error {
+	return nil
+}
 func (m *mockOntologyFinalizationDAGRepo) UpdateStatus(ctx context.Context, dagID uuid.UUID, status models.DAGStatus, currentNode *string) error {
 	return nil
 }
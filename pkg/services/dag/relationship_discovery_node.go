@@ -13,13 +13,34 @@ import (
 
 // LLMRelationshipDiscoveryResult contains the results of LLM-validated relationship discovery.
 // This mirrors the result type from services package to avoid circular imports.
+// It is recorded as the node's progress summary, so it carries JSON tags.
 type LLMRelationshipDiscoveryResult struct {
-	CandidatesEvaluated   int
-	RelationshipsCreated  int
-	RelationshipsRejected int
-	PreservedDBFKs        int
-	PreservedColumnFKs    int
-	DurationMs            int64
+	CandidatesEvaluated      int                        `json:"candidates_evaluated"`
+	CandidatesAlreadyRelated int                        `json:"candidates_already_related"`
+	CandidatesOverridden     int                        `json:"candidates_overridden"`
+	RelationshipsCreated     int                        `json:"relationships_created"`
+	RelationshipsRejected    int                        `json:"relationships_rejected"`
+	PreservedDBFKs           int                        `json:"preserved_db_fks"`
+	PreservedColumnFKs       int                        `json:"preserved_column_fks"`
+	RelationshipsByMethod    map[string]int             `json:"relationships_by_method"`
+	Candidates               RelationshipCandidateStats `json:"candidates"`
+	DurationMs               int64                      `json:"duration_ms"`
+}
+
+// RelationshipCandidateStats mirrors the services candidate collection counts.
+type RelationshipCandidateStats struct {
+	LikelyFKStats       int `json:"likely_fk_stats"`
+	FKSources           int `json:"fk_sources"`
+	FKTargets           int `json:"fk_targets"`
+	PairsGenerated      int `json:"pairs_generated"`
+	JoinAnalysisCalls   int `json:"join_analysis_calls"`
+	ResumedPairs        int `json:"resumed_pairs"`
+	RejectedJoinError   int `json:"rejected_join_error"`
+	RejectedNonUUID     int `json:"rejected_non_uuid"`
+	RejectedNoMatch     int `json:"rejected_no_match"`
+	RejectedOrphans     int `json:"rejected_orphans"`
+	RejectedMultiTarget int `json:"rejected_multi_target"`
+	Collected           int `json:"collected"`
 }
 
 // LLMRelationshipDiscoveryMethods defines the interface for LLM-validated relationship discovery.
@@ -80,8 +101,11 @@ func (n *RelationshipDiscoveryNode) Execute(ctx context.Context, dag *models.Ont
 		return fmt.Errorf("LLM relationship discovery failed: %w", err)
 	}
 
-	// Note: The service already reports "Discovery complete" - don't override it here
-	// as additional messages can accidentally match phase patterns in the UI.
+	// The service already reports "Discovery complete"; keep that message (other messages
+	// can accidentally match phase patterns in the UI) and attach the result summary.
+	if err := n.ReportSummary(ctx, "Discovery complete", result); err != nil {
+		n.Logger().Warn("Failed to record discovery summary", zap.Error(err))
+	}
 
 	n.Logger().Info("LLM relationship discovery complete",
 		zap.String("project_id", dag.ProjectID.String()),
@@ -90,6 +114,7 @@ func (n *RelationshipDiscoveryNode) Execute(ctx context.Context, dag *models.Ont
 		zap.Int("candidates_evaluated", result.CandidatesEvaluated),
 		zap.Int("relationships_created", result.RelationshipsCreated),
 		zap.Int("relationships_rejected", result.RelationshipsRejected),
+		zap.Any("relationships_by_method", result.RelationshipsByMethod),
 		zap.Int64("duration_ms", result.DurationMs))
 
 	return nil
@@ -2,6 +2,7 @@ package dag
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 
@@ -49,6 +50,9 @@ func (m *mockRelationshipDiscoveryDAGRepo) GetActiveByProject(ctx context.Contex
 func (m *mockRelationshipDiscoveryDAGRepo) Update(ctx context.Context, dag *models.OntologyDAG) error {
 	return nil
 }
+func (m *mockRelationshipDiscoveryDAGRepo) UpdateTokensSpent(ctx context.Context, id uuid.UUID, tokensSpent int) error {
+	return nil
+}
 func (m *mockRelationshipDiscoveryDAGRepo) UpdateStatus(ctx context.Context, dagID uuid.UUID, status models.DAGStatus, currentNode *string) error {
 	return nil
 }
@@ -101,7 +105,7 @@ func TestRelationshipDiscoveryNode_Execute_Success(t *testing.T) {
 	datasourceID := uuid.New()
 	nodeID := uuid.New()
 
-	var progressReports []string
+	var progressReports []*models.DAGNodeProgress
 
 	mockSvc := &mockLLMRelationshipDiscoveryMethods{
 		discoverFunc: func(ctx context.Context, pID, dsID uuid.UUID, progressCallback ProgressCallback) (*LLMRelationshipDiscoveryResult, error) {
@@ -113,7 +117,13 @@ func TestRelationshipDiscoveryNode_Execute_Success(t *testing.T) {
 				RelationshipsRejected: 5,
 				PreservedDBFKs:        3,
 				PreservedColumnFKs:    2,
-				DurationMs:            3000,
+				RelationshipsByMethod: map[string]int{
+					models.InferenceMethodFK:                    3,
+					models.InferenceMethodColumnFeatures:        2,
+					models.InferenceMethodRelationshipDiscovery: 10,
+				},
+				Candidates: RelationshipCandidateStats{PairsGenerated: 40, JoinAnalysisCalls: 40, RejectedOrphans: 25, Collected: 15},
+				DurationMs: 3000,
 			}, nil
 		},
 	}
@@ -121,7 +131,7 @@ func TestRelationshipDiscoveryNode_Execute_Success(t *testing.T) {
 	mockRepo := &mockRelationshipDiscoveryDAGRepo{
 		updateProgressFunc: func(ctx context.Context, nID uuid.UUID, progress *models.DAGNodeProgress) error {
 			assert.Equal(t, nodeID, nID)
-			progressReports = append(progressReports, progress.Message)
+			progressReports = append(progressReports, progress)
 			return nil
 		},
 	}
@@ -137,9 +147,20 @@ func TestRelationshipDiscoveryNode_Execute_Success(t *testing.T) {
 	err := node.Execute(ctx, dag, nil)
 	require.NoError(t, err)
 
-	// Only initial progress — the node doesn't report completion (service does)
-	require.Len(t, progressReports, 1)
-	assert.Equal(t, "Starting LLM-validated relationship discovery...", progressReports[0])
+	// Initial progress, then the completion message with the result summary attached
+	require.Len(t, progressReports, 2)
+	assert.Equal(t, "Starting LLM-validated relationship discovery...", progressReports[0].Message)
+	assert.Nil(t, progressReports[0].Summary)
+
+	final := progressReports[1]
+	assert.Equal(t, "Discovery complete", final.Message)
+	var summary LLMRelationshipDiscoveryResult
+	require.NoError(t, json.Unmarshal(final.Summary, &summary))
+	assert.Equal(t, 10, summary.RelationshipsByMethod[models.InferenceMethodRelationshipDiscovery])
+	assert.Equal(t, 3, summary.RelationshipsByMethod[models.InferenceMethodFK])
+	assert.Equal(t, 40, summary.Candidates.JoinAnalysisCalls)
+	assert.Equal(t, 25, summary.Candidates.RejectedOrphans)
+	assert.Contains(t, string(final.Summary), `"relationships_by_method"`)
 }
 
 func TestRelationshipDiscoveryNode_Execute_ServiceError(t *testing.T) {
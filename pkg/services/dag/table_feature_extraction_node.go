@@ -16,8 +16,9 @@ type TableFeatureExtractionMethods interface {
 	// ExtractTableFeatures generates table-level descriptions based on column features.
 	// Inputs per table: table name/schema, columns with ColumnFeatures, FK relationships, row count.
 	// Outputs per table (stored in engine_ontology_table_metadata): description, usage_notes, is_ephemeral.
+	// tableNames limits extraction to the named tables; nil processes every selected table.
 	// Returns the number of tables processed.
-	ExtractTableFeatures(ctx context.Context, projectID, datasourceID uuid.UUID, progressCallback ProgressCallback) (int, error)
+	ExtractTableFeatures(ctx context.Context, projectID, datasourceID uuid.UUID, tableNames []string, progressCallback ProgressCallback) (int, error)
 }
 
 // TableFeatureExtractionNode generates table-level descriptions based on column features.
@@ -53,8 +54,14 @@ func (n *TableFeatureExtractionNode) Execute(ctx context.Context, dag *models.On
 		zap.String("project_id", dag.ProjectID.String()),
 		zap.String("datasource_id", dag.DatasourceID.String()))
 
-	// During incremental extraction, skip if no tables were affected
-	if changeSet != nil && !changeSet.HasChangedTables() {
+	// During incremental extraction, only analyze affected tables (those added or
+	// modified, or whose columns were); unchanged tables keep the metadata from the
+	// previous extraction. Skip when nothing was affected.
+	var tableNames []string
+	if changeSet != nil {
+		tableNames = changeSet.AffectedTableNames()
+	}
+	if changeSet != nil && len(tableNames) == 0 {
 		n.Logger().Info("Skipping table feature extraction (no affected tables)",
 			zap.String("project_id", dag.ProjectID.String()))
 		if err := n.ReportProgress(ctx, 1, 1, "Skipped (no affected tables)"); err != nil {
@@ -85,8 +92,14 @@ func (n *TableFeatureExtractionNode) Execute(ctx context.Context, dag *models.On
 		}
 	}
 
-	// Call the underlying service method to extract table features
-	tablesProcessed, err := n.methods.ExtractTableFeatures(ctx, dag.ProjectID, dag.DatasourceID, progressCallback)
+	if changeSet != nil {
+		n.Logger().Info("Incremental table feature extraction — processing affected tables only",
+			zap.String("project_id", dag.ProjectID.String()),
+			zap.Strings("tables", tableNames))
+	}
+
+	// Call the underlying service method to extract table features (nil means all tables)
+	tablesProcessed, err := n.methods.ExtractTableFeatures(ctx, dag.ProjectID, dag.DatasourceID, tableNames, progressCallback)
 	if err != nil {
 		return err
 	}
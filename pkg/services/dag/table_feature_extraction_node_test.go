@@ -49,6 +49,9 @@ func (m *mockTableFeatureDAGRepo) GetActiveByProject(ctx context.Context, projec
 func (m *mockTableFeatureDAGRepo) Update(ctx context.Context, dag *models.OntologyDAG) error {
 	return nil
 }
+func (m *mockTableFeatureDAGRepo) UpdateTokensSpent(ctx context.Context, id uuid.UUID, tokensSpent int) error {
+	return nil
+}
 func (m *mockTableFeatureDAGRepo) UpdateStatus(ctx context.Context, dagID uuid.UUID, status models.DAGStatus, currentNode *string) error {
 	return nil
 }
@@ -87,6 +90,8 @@ type mockTableFeatureExtractionMethods struct {
 	extractErr             error
 	progressCallbackCalled bool
 	capturedProgressCalls  []progressCall
+	capturedTableNames     []string
+	extractCalled          bool
 }
 
 type progressCall struct {
@@ -95,7 +100,9 @@ type progressCall struct {
 	message string
 }
 
-func (m *mockTableFeatureExtractionMethods) ExtractTableFeatures(ctx context.Context, projectID, datasourceID uuid.UUID, progressCallback ProgressCallback) (int, error) {
+func (m *mockTableFeatureExtractionMethods) ExtractTableFeatures(ctx context.Context, projectID, datasourceID uuid.UUID, tableNames []string, progressCallback ProgressCallback) (int, error) {
+	m.extractCalled = true
+	m.capturedTableNames = tableNames
 	// Simulate progress callbacks if the progressCallback is provided
 	if progressCallback != nil {
 		m.progressCallbackCalled = true
@@ -175,6 +182,66 @@ func TestTableFeatureExtractionNode_Execute_WithExtraction(t *testing.T) {
 	assert.True(t, mockMethods.progressCallbackCalled)
 }
 
+func TestTableFeatureExtractionNode_Execute_IncrementalAnalyzesOnlyAddedTable(t *testing.T) {
+	mockRepo := &mockTableFeatureDAGRepo{}
+	mockMethods := &mockTableFeatureExtractionMethods{extractResult: 1}
+
+	node := NewTableFeatureExtractionNode(mockRepo, mockMethods, zap.NewNop())
+	node.SetCurrentNodeID(uuid.New())
+
+	dag := &models.OntologyDAG{
+		ID:           uuid.New(),
+		ProjectID:    uuid.New(),
+		DatasourceID: uuid.New(),
+	}
+	productsID := uuid.New()
+	changeSet := &models.ChangeSet{
+		AddedTables:      []models.SchemaTable{{ID: productsID, TableName: "products"}},
+		AffectedTableIDs: map[uuid.UUID]bool{productsID: true},
+	}
+
+	err := node.Execute(context.Background(), dag, changeSet)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"products"}, mockMethods.capturedTableNames)
+}
+
+func TestTableFeatureExtractionNode_Execute_IncrementalAnalyzesTablesOfChangedColumns(t *testing.T) {
+	mockMethods := &mockTableFeatureExtractionMethods{extractResult: 1}
+	node := NewTableFeatureExtractionNode(&mockTableFeatureDAGRepo{}, mockMethods, zap.NewNop())
+	node.SetCurrentNodeID(uuid.New())
+
+	ordersID := uuid.New()
+	changeSet := &models.ChangeSet{
+		ModifiedColumns:  []models.SchemaColumn{{ID: uuid.New(), SchemaTableID: ordersID, ColumnName: "status"}},
+		AffectedTableIDs: map[uuid.UUID]bool{ordersID: true},
+		TableNames:       map[uuid.UUID]string{ordersID: "orders"},
+	}
+
+	err := node.Execute(context.Background(), &models.OntologyDAG{ID: uuid.New()}, changeSet)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"orders"}, mockMethods.capturedTableNames, "only the table owning the changed column is analyzed")
+}
+
+func TestTableFeatureExtractionNode_Execute_IncrementalSkipsWhenNothingChanged(t *testing.T) {
+	mockMethods := &mockTableFeatureExtractionMethods{extractResult: 1}
+	node := NewTableFeatureExtractionNode(&mockTableFeatureDAGRepo{}, mockMethods, zap.NewNop())
+	node.SetCurrentNodeID(uuid.New())
+
+	err := node.Execute(context.Background(), &models.OntologyDAG{ID: uuid.New()}, &models.ChangeSet{})
+	assert.NoError(t, err)
+	assert.False(t, mockMethods.extractCalled, "no table is re-analyzed when nothing changed")
+}
+
+func TestTableFeatureExtractionNode_Execute_FullExtractionAnalyzesAllTables(t *testing.T) {
+	mockMethods := &mockTableFeatureExtractionMethods{extractResult: 3}
+	node := NewTableFeatureExtractionNode(&mockTableFeatureDAGRepo{}, mockMethods, zap.NewNop())
+	node.SetCurrentNodeID(uuid.New())
+
+	err := node.Execute(context.Background(), &models.OntologyDAG{ID: uuid.New()}, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, mockMethods.capturedTableNames, "nil table names means every selected table")
+}
+
 func TestTableFeatureExtractionNode_Execute_ProgressCallback(t *testing.T) {
 	progressMessages := make([]string, 0)
 	mockRepo := &mockTableFeatureDAGRepo{
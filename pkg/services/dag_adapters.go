@@ -49,12 +49,16 @@ func (a *LLMRelationshipDiscoveryAdapter) DiscoverRelationships(ctx context.Cont
 		return nil, err
 	}
 	return &dag.LLMRelationshipDiscoveryResult{
-		CandidatesEvaluated:   result.CandidatesEvaluated,
-		RelationshipsCreated:  result.RelationshipsCreated,
-		RelationshipsRejected: result.RelationshipsRejected,
-		PreservedDBFKs:        result.PreservedDBFKs,
-		PreservedColumnFKs:    result.PreservedColumnFKs,
-		DurationMs:            result.DurationMs,
+		CandidatesEvaluated:      result.CandidatesEvaluated,
+		CandidatesAlreadyRelated: result.CandidatesAlreadyRelated,
+		CandidatesOverridden:     result.CandidatesOverridden,
+		RelationshipsCreated:     result.RelationshipsCreated,
+		RelationshipsRejected:    result.RelationshipsRejected,
+		PreservedDBFKs:           result.PreservedDBFKs,
+		PreservedColumnFKs:       result.PreservedColumnFKs,
+		RelationshipsByMethod:    result.RelationshipsByMethod,
+		Candidates:               dag.RelationshipCandidateStats(result.Candidates),
+		DurationMs:               result.DurationMs,
 	}, nil
 }
 
@@ -126,6 +126,7 @@ func (s *dataChangeDetectionService) ScanTables(
 		return nil, fmt.Errorf("failed to create schema discoverer: %w", err)
 	}
 	defer discoverer.Close()
+	minOverlapSamples := datasource.MinOverlapSamplesFromMap(ds.Config)
 
 	// Get tables for schema info
 	tables, err := s.schemaRepo.ListTablesByDatasource(ctx, projectID, datasourceID)
@@ -208,7 +209,7 @@ func (s *dataChangeDetectionService) ScanTables(
 
 		// Detect potential FK patterns for non-FK columns
 		// Reuse metadataByColID built earlier for enum detection
-		fkChanges, err := s.detectPotentialFKs(ctx, discoverer, table, columns, tables, metadataByColID)
+		fkChanges, err := s.detectPotentialFKs(ctx, discoverer, table, columns, tables, metadataByColID, minOverlapSamples)
 		if err != nil {
 			s.logger.Warn("Failed to detect FK patterns",
 				zap.String("table", tableName),
@@ -327,6 +328,7 @@ func (s *dataChangeDetectionService) detectEnumChanges(
 
 // detectPotentialFKs looks for columns that look like FKs but aren't declared as such.
 // Uses stored ColumnMetadata.Purpose to identify identifier columns as FK candidates.
+// Overlaps drawn from fewer than minOverlapSamples distinct values are skipped as inconclusive.
 func (s *dataChangeDetectionService) detectPotentialFKs(
 	ctx context.Context,
 	discoverer datasource.SchemaDiscoverer,
@@ -334,6 +336,7 @@ func (s *dataChangeDetectionService) detectPotentialFKs(
 	columns []*models.SchemaColumn,
 	allTables []*models.SchemaTable,
 	metadataByColumnID map[uuid.UUID]*models.ColumnMetadata,
+	minOverlapSamples int,
 ) ([]*models.PendingChange, error) {
 	var changes []*models.PendingChange
 
@@ -452,6 +455,14 @@ func (s *dataChangeDetectionService) detectPotentialFKs(
 				zap.Error(err))
 			continue
 		}
+		if overlap.Inconclusive(minOverlapSamples) {
+			s.logger.Debug("FK overlap inconclusive: too few sampled values",
+				zap.String("source", fmt.Sprintf("%s.%s", table.TableName, col.ColumnName)),
+				zap.String("target", fmt.Sprintf("%s.%s", targetTable.TableName, targetPKColumn.ColumnName)),
+				zap.Int64("source_distinct", overlap.SourceDistinct),
+				zap.Int("min_samples", minOverlapSamples))
+			continue
+		}
 
 		// If high match rate, suggest FK relationship
 		if overlap.MatchRate >= s.config.MinMatchRateForFK {
@@ -132,6 +132,7 @@ func TestDetectPotentialFKs_PrefersMetadataOverNameHeuristic(t *testing.T) {
 		[]*models.SchemaColumn{creatorCol},
 		allTables,
 		metadataByColumnID,
+		datasource.DefaultMinOverlapSamples,
 	)
 
 	require.NoError(t, err)
@@ -212,6 +213,7 @@ func TestDetectPotentialFKs_FallsBackToNameHeuristicWhenNoMetadata(t *testing.T)
 		[]*models.SchemaColumn{userIDCol},
 		allTables,
 		metadataByColumnID,
+		datasource.DefaultMinOverlapSamples,
 	)
 
 	require.NoError(t, err)
@@ -306,6 +308,7 @@ func TestDetectPotentialFKs_SchemaQualifiedFKTargetTable(t *testing.T) {
 		[]*models.SchemaColumn{acctCol},
 		allTables,
 		metadataByColumnID,
+		datasource.DefaultMinOverlapSamples,
 	)
 
 	require.NoError(t, err)
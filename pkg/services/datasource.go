@@ -10,6 +10,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/ekaya-inc/ekaya-engine/pkg/adapters/datasource"
+	"github.com/ekaya-inc/ekaya-engine/pkg/apperrors"
 	"github.com/ekaya-inc/ekaya-engine/pkg/auth"
 	"github.com/ekaya-inc/ekaya-engine/pkg/crypto"
 	"github.com/ekaya-inc/ekaya-engine/pkg/models"
@@ -114,12 +115,16 @@ func (s *datasourceService) Create(ctx context.Context, projectID uuid.UUID, nam
 	// Auto-set as default datasource for project if none configured
 	if s.projectService != nil {
 		currentDefault, err := s.projectService.GetDefaultDatasourceID(ctx, projectID)
+		if errors.Is(err, apperrors.ErrAmbiguousDefaultDatasource) {
+			// Several datasources and none marked as default: this one becomes it
+			currentDefault, err = uuid.Nil, nil
+		}
 		if err != nil {
 			s.logger.Warn("Failed to check default datasource",
 				zap.String("project_id", projectID.String()),
 				zap.Error(err))
-		} else if currentDefault == uuid.Nil {
-			// No default set yet, set this datasource as default
+		} else if currentDefault == uuid.Nil || currentDefault == ds.ID {
+			// No explicit default yet (the only datasource resolves implicitly), persist this one
 			if err := s.projectService.SetDefaultDatasourceID(ctx, projectID, ds.ID); err != nil {
 				s.logger.Warn("Failed to auto-set default datasource",
 					zap.String("project_id", projectID.String()),
@@ -726,3 +726,32 @@ func TestDatasourceService_List_AllDecryptionFailures(t *testing.T) {
 		t.Errorf("unexpected error message: %s", datasources[0].ErrorMessage)
 	}
 }
+
+// TestDatasourceService_Create_SetsDefaultWhenSeveralDatasources verifies that a datasource
+// created in a project that already has datasources but no explicit default becomes the
+// default, rather than leaving the default ambiguous.
+func TestDatasourceService_Create_SetsDefaultWhenSeveralDatasources(t *testing.T) {
+	projectID := uuid.New()
+	project := &models.Project{ID: projectID}
+	repo := &mockDatasourceRepository{datasources: []*models.Datasource{{ID: uuid.New()}, {ID: uuid.New()}}}
+	projectService := &projectService{
+		projectRepo:    &mockProjectRepoForDefaultDatasource{project: project},
+		datasourceRepo: repo,
+		logger:         zap.NewNop(),
+	}
+	encryptor, _ := crypto.NewCredentialEncryptor(testEncryptionKey)
+	service := NewDatasourceService(repo, encryptor, &mockAdapterFactory{}, projectService, zap.NewNop())
+
+	ds, err := service.Create(context.Background(), projectID, "second", "postgres", "", map[string]any{"host": "b"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	defaultID, err := projectService.GetDefaultDatasourceID(context.Background(), projectID)
+	if err != nil {
+		t.Fatalf("expected an explicit default after create, got error: %v", err)
+	}
+	if defaultID != ds.ID {
+		t.Errorf("expected new datasource %s to be default, got %s", ds.ID, defaultID)
+	}
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/jinzhu/inflection"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+)
+
+// findUnmappedGraphNodes returns the domain graph nodes that do not name one of the
+// given tables exactly. Each is paired with the table it snaps to under
+// matchGraphNode, when there is one. maxDistance 0 disables fuzzy matching, so only
+// case and schema-qualifier differences are suggested.
+func findUnmappedGraphNodes(edges []models.RelationshipEdge, tableNames []string, maxDistance int) []models.UnmappedGraphNode {
+	tables := make(map[string]bool, len(tableNames))
+	for _, name := range tableNames {
+		tables[name] = true
+	}
+
+	unmapped := []models.UnmappedGraphNode{}
+	seen := make(map[string]bool)
+	for _, edge := range edges {
+		for _, node := range []string{edge.From, edge.To} {
+			if node == "" || tables[node] || seen[node] {
+				continue
+			}
+			seen[node] = true
+			suggested, _ := matchGraphNode(node, tableNames, maxDistance)
+			unmapped = append(unmapped, models.UnmappedGraphNode{Node: node, SuggestedTable: suggested})
+		}
+	}
+	sort.Slice(unmapped, func(i, j int) bool { return unmapped[i].Node < unmapped[j].Node })
+	return unmapped
+}
+
+// matchGraphNode maps an LLM-produced graph node to a table name. Nodes match when
+// they differ only in case or a schema qualifier ("public.Orders"). With maxDistance
+// above zero, singular/plural forms ("order" for orders) and names within maxDistance
+// edits ("ordrs") also match; an edit-distance tie between tables is not resolved.
+func matchGraphNode(node string, tableNames []string, maxDistance int) (string, bool) {
+	key := graphNodeKey(node)
+	if key == "" {
+		return "", false
+	}
+	for _, table := range tableNames {
+		if graphNodeKey(table) == key {
+			return table, true
+		}
+	}
+	if maxDistance <= 0 {
+		return "", false
+	}
+
+	singular := inflection.Singular(key)
+	for _, table := range tableNames {
+		if inflection.Singular(graphNodeKey(table)) == singular {
+			return table, true
+		}
+	}
+
+	best, bestDistance, tied := "", maxDistance+1, false
+	for _, table := range tableNames {
+		d := levenshteinDistance(key, graphNodeKey(table))
+		switch {
+		case d < bestDistance:
+			best, bestDistance, tied = table, d, false
+		case d == bestDistance:
+			tied = true
+		}
+	}
+	if best == "" || tied {
+		return "", false
+	}
+	return best, true
+}
+
+// graphNodeKey lowercases a node or table name and drops any schema qualifier.
+func graphNodeKey(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/llm"
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+	"github.com/ekaya-inc/ekaya-engine/pkg/repositories"
+)
+
+const (
+	// estimateHistoryLimit is how many recent LLM conversations of a phase are used to
+	// learn its completion/prompt token ratio.
+	estimateHistoryLimit = 500
+
+	// estimateMinHistorySamples is the fewest usable conversations needed before the
+	// historical ratio replaces the default.
+	estimateMinHistorySamples = 5
+)
+
+// Default completion/prompt ratios used when a project has too little history.
+// Enrichment responses are structured JSON and typically run well under the prompt size.
+const (
+	defaultOutputRatio     = 0.3
+	defaultOutputRatioLow  = 0.15
+	defaultOutputRatioHigh = 0.5
+)
+
+// unestimatedExtractionPhases gives, for each extraction phase that calls the LLM but
+// cannot be estimated before a run, the reason reported for it.
+var unestimatedExtractionPhases = map[models.DAGNodeName]string{
+	models.DAGNodeKnowledgeSeeding:        "its prompt is built from the project overview given when extraction starts",
+	models.DAGNodeColumnFeatureExtraction: "its prompts are built from column statistics sampled from the datasource during the run",
+	models.DAGNodeTableFeatureExtraction:  "its prompts are built from the column features extracted earlier in the run",
+	models.DAGNodeRelationshipDiscovery:   "its prompts are built from the candidate relationships found during the run",
+	models.DAGNodeOntologyFinalization:    "its prompt is built from the table descriptions written during the run",
+}
+
+// ExtractionEstimateService projects the LLM usage and cost of an extraction without
+// sending anything to the LLM.
+type ExtractionEstimateService interface {
+	// Estimate returns a per-phase projection for the project's selected tables. Column
+	// enrichment is estimated from the prompts it would send; the other LLM phases build
+	// their prompts from the output of the run itself and are reported as not estimated.
+	Estimate(ctx context.Context, projectID uuid.UUID) (*models.ExtractionEstimate, error)
+}
+
+type extractionEstimateService struct {
+	columnEnrichment ColumnEnrichmentService
+	conversationRepo repositories.ConversationRepository
+	llmFactory       llm.LLMClientFactory
+	logger           *zap.Logger
+}
+
+// NewExtractionEstimateService creates a new extraction estimate service.
+func NewExtractionEstimateService(
+	columnEnrichment ColumnEnrichmentService,
+	conversationRepo repositories.ConversationRepository,
+	llmFactory llm.LLMClientFactory,
+	logger *zap.Logger,
+) ExtractionEstimateService {
+	return &extractionEstimateService{
+		columnEnrichment: columnEnrichment,
+		conversationRepo: conversationRepo,
+		llmFactory:       llmFactory,
+		logger:           logger.Named("extraction-estimate"),
+	}
+}
+
+var _ ExtractionEstimateService = (*extractionEstimateService)(nil)
+
+func (s *extractionEstimateService) Estimate(ctx context.Context, projectID uuid.UUID) (*models.ExtractionEstimate, error) {
+	prompts, err := s.columnEnrichment.EstimatePrompts(ctx, projectID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("estimate column enrichment prompts: %w", err)
+	}
+
+	var pricing *llm.ModelPricing
+	estimate := &models.ExtractionEstimate{TableCount: prompts.TableCount, Complete: true}
+	client, err := s.llmFactory.CreateForProject(ctx, projectID)
+	if err != nil {
+		s.logger.Warn("Failed to resolve LLM model, returning estimate without cost",
+			zap.String("project_id", projectID.String()),
+			zap.Error(err))
+	} else {
+		estimate.Model = client.GetModel()
+		if p, ok := llm.LookupPricing(estimate.Model); ok {
+			pricing = &p
+		}
+	}
+
+	for _, node := range models.AllDAGNodes() {
+		phase := models.ExtractionPhaseEstimate{Phase: node}
+		switch {
+		case node == models.DAGNodeColumnEnrichment:
+			phase.PromptCount = prompts.PromptCount
+			phase.InputTokens = prompts.InputTokens
+			s.projectOutput(ctx, projectID, llm.PromptTypeColumnEnrichment, &phase)
+		case node == models.DAGNodeFKDiscovery:
+			phase.Status = models.ExtractionPhaseNoLLMCalls
+		default:
+			phase.Status = models.ExtractionPhaseNotEstimated
+			phase.Reason = unestimatedExtractionPhases[node]
+			estimate.Complete = false
+		}
+
+		if phase.Status == models.ExtractionPhaseEstimated {
+			if pricing != nil {
+				phase.Cost = &models.ExtractionCostUSD{
+					Low:  pricing.Cost(phase.InputTokens, phase.OutputTokensLow),
+					High: pricing.Cost(phase.InputTokens, phase.OutputTokensHigh),
+				}
+			}
+			estimate.InputTokens += phase.InputTokens
+			estimate.OutputTokens += phase.OutputTokens
+			estimate.OutputTokensLow += phase.OutputTokensLow
+			estimate.OutputTokensHigh += phase.OutputTokensHigh
+		}
+		estimate.Phases = append(estimate.Phases, phase)
+	}
+
+	if pricing != nil {
+		estimate.Cost = &models.ExtractionCostUSD{
+			Low:  pricing.Cost(estimate.InputTokens, estimate.OutputTokensLow),
+			High: pricing.Cost(estimate.InputTokens, estimate.OutputTokensHigh),
+		}
+	}
+
+	return estimate, nil
+}
+
+// projectOutput marks phase as estimated and projects its output tokens from the
+// completion/prompt ratio of the project's past calls of promptType.
+func (s *extractionEstimateService) projectOutput(ctx context.Context, projectID uuid.UUID, promptType string, phase *models.ExtractionPhaseEstimate) {
+	phase.Status = models.ExtractionPhaseEstimated
+	phase.OutputBasis = models.ExtractionOutputBasisDefault
+
+	ratio, low, high := defaultOutputRatio, defaultOutputRatioLow, defaultOutputRatioHigh
+	conversations, err := s.conversationRepo.GetByContext(ctx, projectID, llm.PromptTypeKey, promptType)
+	if err != nil {
+		s.logger.Warn("Failed to read LLM history, using default output ratio",
+			zap.String("project_id", projectID.String()),
+			zap.String("prompt_type", promptType),
+			zap.Error(err))
+	} else {
+		// Oldest first; keep the most recent calls
+		if len(conversations) > estimateHistoryLimit {
+			conversations = conversations[len(conversations)-estimateHistoryLimit:]
+		}
+		if samples := outputRatios(conversations); len(samples.ratios) >= estimateMinHistorySamples {
+			ratio = samples.average
+			low = samples.ratios[len(samples.ratios)/4]
+			high = samples.ratios[len(samples.ratios)*3/4]
+			phase.OutputBasis = models.ExtractionOutputBasisHistory
+			phase.HistorySamples = len(samples.ratios)
+		}
+	}
+
+	phase.OutputTokens = int(float64(phase.InputTokens) * ratio)
+	phase.OutputTokensLow = int(float64(phase.InputTokens) * low)
+	phase.OutputTokensHigh = int(float64(phase.InputTokens) * high)
+}
+
+// outputRatioSamples holds per-call completion/prompt ratios, sorted ascending,
+// and the token-weighted average across all calls.
+type outputRatioSamples struct {
+	ratios  []float64
+	average float64
+}
+
+// outputRatios collects completion/prompt token ratios from successful conversations
+// that recorded usage.
+func outputRatios(conversations []*models.LLMConversation) outputRatioSamples {
+	var samples outputRatioSamples
+	var promptTotal, completionTotal int
+	for _, conv := range conversations {
+		if conv.Status != models.LLMConversationStatusSuccess ||
+			conv.PromptTokens == nil || conv.CompletionTokens == nil || *conv.PromptTokens <= 0 {
+			continue
+		}
+		samples.ratios = append(samples.ratios, float64(*conv.CompletionTokens)/float64(*conv.PromptTokens))
+		promptTotal += *conv.PromptTokens
+		completionTotal += *conv.CompletionTokens
+	}
+	sort.Float64s(samples.ratios)
+	if promptTotal > 0 {
+		samples.average = float64(completionTotal) / float64(promptTotal)
+	}
+	return samples
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/llm"
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+	"github.com/ekaya-inc/ekaya-engine/pkg/repositories"
+)
+
+// estimateSchemaRepo reports every table with columns as selected.
+type estimateSchemaRepo struct {
+	testColEnrichmentSchemaRepo
+}
+
+func (r *estimateSchemaRepo) ListTablesByDatasource(ctx context.Context, projectID, datasourceID uuid.UUID) ([]*models.SchemaTable, error) {
+	var tables []*models.SchemaTable
+	for name := range r.columnsByTable {
+		tables = append(tables, &models.SchemaTable{ID: uuid.New(), ProjectID: projectID, TableName: name})
+	}
+	return tables, nil
+}
+
+// estimateConversationRepo returns the conversations recorded under a context value.
+type estimateConversationRepo struct {
+	repositories.ConversationRepository
+	byContext map[string][]*models.LLMConversation
+}
+
+func (r *estimateConversationRepo) GetByContext(ctx context.Context, projectID uuid.UUID, key, value string) ([]*models.LLMConversation, error) {
+	return r.byContext[key+"="+value], nil
+}
+
+type estimateLLMClient struct {
+	testColEnrichmentLLMClient
+	model string
+}
+
+func (c *estimateLLMClient) GetModel() string {
+	return c.model
+}
+
+func newEstimateService(tableCount int, history map[string][]*models.LLMConversation) ExtractionEstimateService {
+	columnsByTable := make(map[string][]*models.SchemaColumn)
+	for i := 0; i < tableCount; i++ {
+		columnsByTable[fmt.Sprintf("table_%d", i)] = []*models.SchemaColumn{
+			{ID: uuid.New(), ColumnName: "id", DataType: "bigint", IsPrimaryKey: true},
+			{ID: uuid.New(), ColumnName: "name", DataType: "text"},
+			{ID: uuid.New(), ColumnName: "created_at", DataType: "timestamp"},
+		}
+	}
+
+	llmFactory := &testColEnrichmentLLMFactory{client: &estimateLLMClient{model: "gpt-4o"}}
+	columnEnrichment := &columnEnrichmentService{
+		schemaRepo:         &estimateSchemaRepo{testColEnrichmentSchemaRepo{columnsByTable: columnsByTable}},
+		columnMetadataRepo: &testColEnrichmentColumnMetadataRepo{},
+		dsSvc:              &testColEnrichmentDatasourceService{},
+		llmFactory:         llmFactory,
+		logger:             zap.NewNop(),
+	}
+	return NewExtractionEstimateService(columnEnrichment,
+		&estimateConversationRepo{byContext: history}, llmFactory, zap.NewNop())
+}
+
+// estimatePhase returns the estimate's entry for phase.
+func estimatePhase(t *testing.T, estimate *models.ExtractionEstimate, phase models.DAGNodeName) models.ExtractionPhaseEstimate {
+	t.Helper()
+	for _, p := range estimate.Phases {
+		if p.Phase == phase {
+			return p
+		}
+	}
+	t.Fatalf("no estimate for phase %s", phase)
+	return models.ExtractionPhaseEstimate{}
+}
+
+func successfulConversations(n, promptTokens, completionTokens int) []*models.LLMConversation {
+	var conversations []*models.LLMConversation
+	for i := 0; i < n; i++ {
+		prompt, completion := promptTokens, completionTokens
+		conversations = append(conversations, &models.LLMConversation{
+			Status:           models.LLMConversationStatusSuccess,
+			PromptTokens:     &prompt,
+			CompletionTokens: &completion,
+		})
+	}
+	return conversations
+}
+
+func TestExtractionEstimate_ScalesWithSelectedTableCount(t *testing.T) {
+	small, err := newEstimateService(2, nil).Estimate(context.Background(), uuid.New())
+	require.NoError(t, err)
+	large, err := newEstimateService(4, nil).Estimate(context.Background(), uuid.New())
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, small.TableCount)
+	assert.Equal(t, 4, large.TableCount)
+	assert.Equal(t, 2, estimatePhase(t, small, models.DAGNodeColumnEnrichment).PromptCount)
+	assert.Equal(t, 4, estimatePhase(t, large, models.DAGNodeColumnEnrichment).PromptCount)
+	assert.Greater(t, small.InputTokens, 0)
+	assert.Equal(t, 2*small.InputTokens, large.InputTokens)
+	assert.Greater(t, large.OutputTokens, small.OutputTokens)
+
+	require.NotNil(t, small.Cost)
+	require.NotNil(t, large.Cost)
+	assert.Equal(t, "gpt-4o", large.Model)
+	assert.Less(t, small.Cost.Low, small.Cost.High)
+	assert.InDelta(t, 2*small.Cost.High, large.Cost.High, 1e-9)
+}
+
+func TestExtractionEstimate_ReportsEveryPhase(t *testing.T) {
+	estimate, err := newEstimateService(2, nil).Estimate(context.Background(), uuid.New())
+	require.NoError(t, err)
+
+	require.Len(t, estimate.Phases, len(models.AllDAGNodes()), "phases that cannot be estimated are listed, not dropped")
+	assert.False(t, estimate.Complete)
+
+	enrichment := estimatePhase(t, estimate, models.DAGNodeColumnEnrichment)
+	assert.Equal(t, models.ExtractionPhaseEstimated, enrichment.Status)
+	assert.Equal(t, enrichment.InputTokens, estimate.InputTokens, "totals cover the estimated phases only")
+	require.NotNil(t, enrichment.Cost)
+
+	assert.Equal(t, models.ExtractionPhaseNoLLMCalls, estimatePhase(t, estimate, models.DAGNodeFKDiscovery).Status)
+
+	tableFeatures := estimatePhase(t, estimate, models.DAGNodeTableFeatureExtraction)
+	assert.Equal(t, models.ExtractionPhaseNotEstimated, tableFeatures.Status)
+	assert.NotEmpty(t, tableFeatures.Reason)
+	assert.Zero(t, tableFeatures.InputTokens)
+	assert.Nil(t, tableFeatures.Cost)
+}
+
+func TestExtractionEstimate_ProjectsOutputFromColumnEnrichmentHistory(t *testing.T) {
+	enrichmentHistory := successfulConversations(8, 1000, 100)
+	// Failed calls don't count toward the ratio
+	enrichmentHistory = append(enrichmentHistory, &models.LLMConversation{Status: models.LLMConversationStatusError})
+
+	estimate, err := newEstimateService(3, map[string][]*models.LLMConversation{
+		llm.PromptTypeKey + "=" + llm.PromptTypeColumnEnrichment: enrichmentHistory,
+		// Calls of other prompts have a different ratio and must not be used
+		llm.PromptTypeKey + "=other": successfulConversations(8, 1000, 900),
+	}).Estimate(context.Background(), uuid.New())
+	require.NoError(t, err)
+
+	enrichment := estimatePhase(t, estimate, models.DAGNodeColumnEnrichment)
+	assert.Equal(t, models.ExtractionOutputBasisHistory, enrichment.OutputBasis)
+	assert.Equal(t, 8, enrichment.HistorySamples)
+	assert.Equal(t, int(float64(enrichment.InputTokens)*0.1), enrichment.OutputTokens)
+	assert.Equal(t, enrichment.OutputTokens, enrichment.OutputTokensHigh)
+}
+
+func TestExtractionEstimate_UnpricedModelHasNoCost(t *testing.T) {
+	svc := newEstimateService(1, nil).(*extractionEstimateService)
+	svc.llmFactory = &testColEnrichmentLLMFactory{client: &estimateLLMClient{model: "llama3.1:70b"}}
+
+	estimate, err := svc.Estimate(context.Background(), uuid.New())
+	require.NoError(t, err)
+
+	assert.Equal(t, models.ExtractionOutputBasisDefault, estimatePhase(t, estimate, models.DAGNodeColumnEnrichment).OutputBasis)
+	assert.Equal(t, "llama3.1:70b", estimate.Model)
+	assert.Nil(t, estimate.Cost)
+}
@@ -47,6 +47,10 @@ func (m *glossaryPipelineLLMClient) GetEndpoint() string {
 	return "test-endpoint"
 }
 
+func (m *glossaryPipelineLLMClient) GenerateResponseStream(ctx context.Context, prompt string, systemMessage string, temperature float64) (*llm.ResponseStream, error) {
+	return llm.CompletedResponseStream(m.GenerateResponse(ctx, prompt, systemMessage, temperature, false))
+}
+
 type glossaryPipelineQueryExecutor struct {
 	rowCounts    map[string]int64
 	nonNullCount map[string]int64
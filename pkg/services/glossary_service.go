@@ -68,6 +68,13 @@ type GlossaryService interface {
 	// UpdateTerm updates an existing glossary term.
 	UpdateTerm(ctx context.Context, term *models.BusinessGlossaryTerm) error
 
+	// UpsertTerm creates the term, or resolves it against an existing term of the same name
+	// by source precedence (manual > import > mcp > inferred). The source is taken from the
+	// provenance in ctx. A source of equal or higher precedence replaces the definition and
+	// the replaced one is kept as an alternate; a lower one is only recorded as an alternate.
+	// Returns whether term's definition was applied.
+	UpsertTerm(ctx context.Context, projectID uuid.UUID, term *models.BusinessGlossaryTerm) (bool, error)
+
 	// DeleteTerm deletes a glossary term by ID.
 	DeleteTerm(ctx context.Context, termID uuid.UUID) error
 
@@ -432,6 +439,84 @@ func (s *glossaryService) UpdateTerm(ctx context.Context, term *models.BusinessG
 	return nil
 }
 
+func (s *glossaryService) UpsertTerm(ctx context.Context, projectID uuid.UUID, term *models.BusinessGlossaryTerm) (bool, error) {
+	prov, ok := models.GetProvenance(ctx)
+	if !ok {
+		return false, fmt.Errorf("provenance context required")
+	}
+	source := prov.Source.String()
+	term.Source = source
+
+	existing, err := s.glossaryRepo.GetByTerm(ctx, projectID, term.Term)
+	if err != nil {
+		return false, fmt.Errorf("get existing term: %w", err)
+	}
+	if existing == nil {
+		if err := s.CreateTerm(ctx, projectID, term); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	if !NewGlossaryPrecedenceChecker().CanModify(existing.Source, source) {
+		existing.Alternates = withAlternateDefinition(existing.Alternates, models.GlossaryAlternateDefinition{
+			Source:      source,
+			Definition:  term.Definition,
+			DefiningSQL: term.DefiningSQL,
+			RecordedAt:  time.Now(),
+		})
+		if err := s.glossaryRepo.UpdateAlternates(ctx, existing.ID, existing.Alternates); err != nil {
+			return false, fmt.Errorf("record alternate definition: %w", err)
+		}
+
+		s.logger.Info("Kept higher-precedence glossary definition",
+			zap.String("term", existing.Term),
+			zap.String("existing_source", existing.Source),
+			zap.String("source", source))
+		return false, nil
+	}
+
+	term.ID = existing.ID
+	term.ProjectID = projectID
+	if term.Aliases == nil {
+		term.Aliases = existing.Aliases
+	}
+	// A source replacing its own definition has nothing to keep; otherwise the replaced
+	// definition becomes an alternate. The incoming source is never its own alternate.
+	term.Alternates = existing.Alternates
+	if existing.Source != source {
+		term.Alternates = withAlternateDefinition(term.Alternates, models.GlossaryAlternateDefinition{
+			Source:      existing.Source,
+			Definition:  existing.Definition,
+			DefiningSQL: existing.DefiningSQL,
+			RecordedAt:  time.Now(),
+		})
+	}
+	term.Alternates = withoutAlternateDefinition(term.Alternates, source)
+
+	if err := s.UpdateTerm(ctx, term); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// withAlternateDefinition returns alternates with alt added, replacing any earlier
+// alternate from the same source.
+func withAlternateDefinition(alternates []models.GlossaryAlternateDefinition, alt models.GlossaryAlternateDefinition) []models.GlossaryAlternateDefinition {
+	return append(withoutAlternateDefinition(alternates, alt.Source), alt)
+}
+
+// withoutAlternateDefinition returns alternates without the one from source, if any.
+func withoutAlternateDefinition(alternates []models.GlossaryAlternateDefinition, source string) []models.GlossaryAlternateDefinition {
+	out := make([]models.GlossaryAlternateDefinition, 0, len(alternates))
+	for _, alt := range alternates {
+		if alt.Source != source {
+			out = append(out, alt)
+		}
+	}
+	return out
+}
+
 func (s *glossaryService) DeleteTerm(ctx context.Context, termID uuid.UUID) error {
 	if err := s.glossaryRepo.Delete(ctx, termID); err != nil {
 		s.logger.Error("Failed to delete glossary term",
@@ -155,6 +155,15 @@ func (m *mockGlossaryRepo) DeleteAlias(ctx context.Context, glossaryID uuid.UUID
 	return nil
 }
 
+func (m *mockGlossaryRepo) UpdateAlternates(ctx context.Context, termID uuid.UUID, alternates []models.GlossaryAlternateDefinition) error {
+	term, exists := m.terms[termID]
+	if !exists {
+		return errors.New("term not found")
+	}
+	term.Alternates = alternates
+	return nil
+}
+
 // mockProjectServiceForGlossary implements a minimal ProjectService for glossary tests.
 type mockProjectServiceForGlossary struct {
 	project *models.Project
@@ -350,6 +359,9 @@ func (m *mockSchemaRepoForGlossary) GetEmptyTables(ctx context.Context, projectI
 func (m *mockSchemaRepoForGlossary) GetOrphanTables(ctx context.Context, projectID, datasourceID uuid.UUID) ([]string, error) {
 	return nil, nil
 }
+func (m *mockSchemaRepoForGlossary) GetRelationshipStats(ctx context.Context, projectID, datasourceID uuid.UUID) (*models.RelationshipStats, error) {
+	return nil, nil
+}
 func (m *mockSchemaRepoForGlossary) UpsertRelationshipWithMetrics(ctx context.Context, rel *models.SchemaRelationship, metrics *models.DiscoveryMetrics) error {
 	return nil
 }
@@ -405,6 +417,10 @@ func (m *mockLLMClientForGlossary) GetEndpoint() string {
 	return "https://test.endpoint"
 }
 
+func (m *mockLLMClientForGlossary) GenerateResponseStream(ctx context.Context, prompt string, systemMessage string, temperature float64) (*llm.ResponseStream, error) {
+	return llm.CompletedResponseStream(m.GenerateResponse(ctx, prompt, systemMessage, temperature, false))
+}
+
 type mockLLMFactoryForGlossary struct {
 	client    llm.LLMClient
 	createErr error
@@ -648,6 +664,94 @@ func TestGlossaryService_UpdateTerm(t *testing.T) {
 	assert.Equal(t, "Updated definition", updated.Definition)
 }
 
+func TestGlossaryService_UpsertTerm_SourcePrecedence(t *testing.T) {
+	projectID := uuid.New()
+	userID := uuid.New()
+	ctx := withTestAuth(context.Background(), projectID)
+
+	glossaryRepo := newMockGlossaryRepo()
+	svc := NewGlossaryService(glossaryRepo, &mockColumnMetadataRepoForGlossary{}, nil, &mockSchemaRepoForGlossary{}, &mockProjectServiceForGlossary{}, &mockDatasourceServiceForGlossary{}, &mockAdapterFactoryForGlossary{}, &mockLLMFactoryForGlossary{}, nil, zap.NewNop(), "test")
+
+	manualCtx := models.WithManualProvenance(ctx, userID)
+	inferredCtx := models.WithInferredProvenance(ctx, userID)
+
+	t.Run("auto-generated term does not overwrite a user-defined one", func(t *testing.T) {
+		userTerm := &models.BusinessGlossaryTerm{Term: "Active Users", Definition: "Users who logged in within 30 days"}
+		applied, err := svc.UpsertTerm(manualCtx, projectID, userTerm)
+		require.NoError(t, err)
+		require.True(t, applied)
+
+		applied, err = svc.UpsertTerm(inferredCtx, projectID, &models.BusinessGlossaryTerm{
+			Term:       "Active Users",
+			Definition: "Users with any activity",
+		})
+		require.NoError(t, err)
+		assert.False(t, applied)
+
+		stored, err := svc.GetTermByName(ctx, projectID, "Active Users")
+		require.NoError(t, err)
+		assert.Equal(t, "Users who logged in within 30 days", stored.Definition)
+		assert.Equal(t, models.GlossarySourceManual, stored.Source)
+		require.Len(t, stored.Alternates, 1)
+		assert.Equal(t, models.GlossarySourceInferred, stored.Alternates[0].Source)
+		assert.Equal(t, "Users with any activity", stored.Alternates[0].Definition)
+	})
+
+	t.Run("user edit overwrites an ontology-sourced term", func(t *testing.T) {
+		applied, err := svc.UpsertTerm(inferredCtx, projectID, &models.BusinessGlossaryTerm{
+			Term:       "Churn Rate",
+			Definition: "Share of customers lost",
+		})
+		require.NoError(t, err)
+		require.True(t, applied)
+
+		applied, err = svc.UpsertTerm(manualCtx, projectID, &models.BusinessGlossaryTerm{
+			Term:       "Churn Rate",
+			Definition: "Share of paying customers who cancelled this month",
+		})
+		require.NoError(t, err)
+		assert.True(t, applied)
+
+		stored, err := svc.GetTermByName(ctx, projectID, "Churn Rate")
+		require.NoError(t, err)
+		assert.Equal(t, "Share of paying customers who cancelled this month", stored.Definition)
+		assert.Equal(t, models.GlossarySourceManual, stored.Source)
+		require.Len(t, stored.Alternates, 1)
+		assert.Equal(t, models.GlossarySourceInferred, stored.Alternates[0].Source)
+		assert.Equal(t, "Share of customers lost", stored.Alternates[0].Definition)
+	})
+
+	t.Run("import outranks inferred but not manual", func(t *testing.T) {
+		importCtx := models.WithImportProvenance(ctx, userID)
+
+		applied, err := svc.UpsertTerm(importCtx, projectID, &models.BusinessGlossaryTerm{
+			Term:       "Churn Rate",
+			Definition: "Imported churn definition",
+		})
+		require.NoError(t, err)
+		assert.False(t, applied)
+
+		applied, err = svc.UpsertTerm(importCtx, projectID, &models.BusinessGlossaryTerm{
+			Term:       "Net Revenue",
+			Definition: "Imported net revenue definition",
+		})
+		require.NoError(t, err)
+		require.True(t, applied)
+
+		applied, err = svc.UpsertTerm(inferredCtx, projectID, &models.BusinessGlossaryTerm{
+			Term:       "Net Revenue",
+			Definition: "Revenue after refunds",
+		})
+		require.NoError(t, err)
+		assert.False(t, applied)
+
+		stored, err := svc.GetTermByName(ctx, projectID, "Net Revenue")
+		require.NoError(t, err)
+		assert.Equal(t, "Imported net revenue definition", stored.Definition)
+		assert.Equal(t, models.GlossarySourceImport, stored.Source)
+	})
+}
+
 func TestGlossaryService_UpdateTerm_NotFound(t *testing.T) {
 	ctx := context.Background()
 
@@ -1427,6 +1531,10 @@ func (m *mockLLMClientCapturingPrompt) GetEndpoint() string {
 	return "https://test.endpoint"
 }
 
+func (m *mockLLMClientCapturingPrompt) GenerateResponseStream(ctx context.Context, prompt string, systemMessage string, temperature float64) (*llm.ResponseStream, error) {
+	return llm.CompletedResponseStream(m.GenerateResponse(ctx, prompt, systemMessage, temperature, false))
+}
+
 func TestGlossaryService_SuggestTerms_WithDomainKnowledge_IncludesFactsInPrompt(t *testing.T) {
 	projectID := uuid.New()
 	ctx := withTestAuth(context.Background(), projectID)
@@ -3304,6 +3412,10 @@ func (m *mockLLMClientWithRetry) GetEndpoint() string {
 	return "https://test.endpoint"
 }
 
+func (m *mockLLMClientWithRetry) GenerateResponseStream(ctx context.Context, prompt string, systemMessage string, temperature float64) (*llm.ResponseStream, error) {
+	return llm.CompletedResponseStream(m.GenerateResponse(ctx, prompt, systemMessage, temperature, false))
+}
+
 func TestGlossaryService_EnrichSingleTerm_RetriesOnFailure(t *testing.T) {
 	projectID := uuid.New()
 	ctx := withTestAuth(context.Background(), projectID)
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"text/template"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -20,21 +21,42 @@ type knowledgeSeedingService struct {
 	knowledgeService KnowledgeService
 	schemaService    SchemaService
 	llmFactory       llm.LLMClientFactory
+	promptTemplate   *template.Template
+	domainTaxonomy   []string
 	logger           *zap.Logger
 }
 
 // NewKnowledgeSeedingService creates a new knowledge seeding service.
+// promptTemplate overrides the extraction prompt (see knowledgeExtractionPromptData);
+// an empty or unparseable template falls back to the built-in prompt.
+// domainTaxonomy, when non-empty, restricts the domains entity hints may use.
 func NewKnowledgeSeedingService(
 	knowledgeService KnowledgeService,
 	schemaService SchemaService,
 	llmFactory llm.LLMClientFactory,
+	promptTemplate string,
+	domainTaxonomy []string,
 	logger *zap.Logger,
 ) dag.KnowledgeSeedingMethods {
+	logger = logger.Named("knowledge-seeding")
+
+	tmpl := defaultKnowledgeExtractionPrompt
+	if promptTemplate != "" {
+		custom, err := template.New("knowledge_extraction").Parse(promptTemplate)
+		if err != nil {
+			logger.Warn("Invalid description prompt template, using the built-in prompt", zap.Error(err))
+		} else {
+			tmpl = custom
+		}
+	}
+
 	return &knowledgeSeedingService{
 		knowledgeService: knowledgeService,
 		schemaService:    schemaService,
 		llmFactory:       llmFactory,
-		logger:           logger.Named("knowledge-seeding"),
+		promptTemplate:   tmpl,
+		domainTaxonomy:   domainTaxonomy,
+		logger:           logger,
 	}
 }
 
@@ -100,7 +122,26 @@ func (s *knowledgeSeedingService) ExtractKnowledgeFromOverview(ctx context.Conte
 		return 0, fmt.Errorf("failed to extract knowledge facts: %w", err)
 	}
 
-	// 5. Store extracted facts with source='inferred'
+	// 5. Flag entity hints that name unknown tables or domains
+	var knownTables []string
+	tables, err := s.schemaService.ListTablesByDatasource(ctx, projectID, datasourceID)
+	if err != nil {
+		s.logger.Warn("Failed to list tables, skipping entity hint table validation",
+			zap.String("project_id", projectID.String()),
+			zap.Error(err))
+	}
+	for _, t := range tables {
+		knownTables = append(knownTables, t.TableName)
+	}
+	extractedFacts, warnings := validateEntityHints(extractedFacts, knownTables, s.domainTaxonomy)
+	for _, w := range warnings {
+		s.logger.Warn("Flagged entity hint from project overview",
+			zap.String("project_id", projectID.String()),
+			zap.String("hint", truncateForLog(w.Hint, 100)),
+			zap.String("reason", w.Reason))
+	}
+
+	// 6. Store extracted facts with source='inferred'
 	storedCount := 0
 	for _, fact := range extractedFacts {
 		if _, err := s.knowledgeService.StoreWithSource(
@@ -125,9 +166,18 @@ func (s *knowledgeSeedingService) ExtractKnowledgeFromOverview(ctx context.Conte
 
 // extractedFact represents a knowledge fact extracted from the overview.
 type extractedFact struct {
-	FactType string `json:"fact_type"`
-	Value    string `json:"value"`
-	Context  string `json:"context,omitempty"`
+	FactType string   `json:"fact_type"`
+	Value    string   `json:"value"`
+	Context  string   `json:"context,omitempty"`
+	Tables   []string `json:"tables,omitempty"` // Tables an entity_hint describes
+	Domain   string   `json:"domain,omitempty"` // Business domain of an entity_hint
+}
+
+// entityHintWarning flags an entity hint whose output did not match the schema or
+// the configured domain taxonomy.
+type entityHintWarning struct {
+	Hint   string
+	Reason string
 }
 
 // llmExtractionResponse represents the expected LLM response structure.
@@ -186,7 +236,9 @@ Focus on extracting:
 1. business_rule: Business logic or rules (e.g., "All timestamps are stored in UTC")
 2. convention: Data or naming conventions (e.g., "Currency amounts are stored in cents")
 3. terminology: Domain-specific terms and their meanings (e.g., "A 'channel' refers to a video creator")
-4. entity_hint: Distinctions between similar concepts (e.g., "Users are employee accounts, Customers are external businesses")
+4. entity_hint: What specific tables represent or how similar concepts differ (e.g., "Users are employee accounts, Customers are external businesses")
+
+For entity_hint facts, list the schema tables the hint describes in "tables" using the exact table names from the schema.
 
 Only extract facts that are clearly stated or strongly implied in the overview.
 Do not make assumptions or invent facts not supported by the text.
@@ -195,36 +247,56 @@ If you cannot extract any meaningful facts, return an empty facts array.
 Respond ONLY with valid JSON. No markdown, no explanations.`
 }
 
-// buildExtractionPrompt builds the prompt for knowledge extraction.
-func (s *knowledgeSeedingService) buildExtractionPrompt(overview string, schemaContext string) string {
-	var sb strings.Builder
+// knowledgeExtractionPromptData is the data a description prompt template is executed with.
+type knowledgeExtractionPromptData struct {
+	Overview      string   // The user's project description
+	SchemaContext string   // Selected tables and columns; empty when unavailable
+	Domains       []string // Configured domain taxonomy; empty when not configured
+}
 
-	sb.WriteString("Extract domain knowledge facts from the following project overview.\n\n")
+// defaultKnowledgeExtractionPrompt is the built-in description prompt.
+var defaultKnowledgeExtractionPrompt = template.Must(template.New("knowledge_extraction").Parse(`Extract domain knowledge facts from the following project overview.
 
-	sb.WriteString("## Project Overview\n")
-	sb.WriteString(overview)
-	sb.WriteString("\n\n")
+## Project Overview
+{{.Overview}}
 
-	if schemaContext != "" {
-		sb.WriteString("## Database Schema (for context)\n")
-		sb.WriteString(schemaContext)
-		sb.WriteString("\n\n")
-	}
+{{if .SchemaContext}}## Database Schema (for context)
+{{.SchemaContext}}
+
+{{end}}{{if .Domains}}## Business Domains
+For entity_hint facts, set "domain" to one of: {{range $i, $d := .Domains}}{{if $i}}, {{end}}{{$d}}{{end}}
 
-	sb.WriteString(`## Output Format
+{{end}}## Output Format
 Respond with a JSON object containing extracted facts:
 {
   "facts": [
     {
       "fact_type": "business_rule" | "convention" | "terminology" | "entity_hint",
       "value": "The actual fact or rule stated clearly",
-      "context": "Optional additional context"
+      "context": "Optional additional context",
+      "tables": ["Optional: for entity_hint, the schema tables this hint describes"]
     }
   ]
 }
 
-Return {"facts": []} if no clear facts can be extracted from the overview.`)
+Return {"facts": []} if no clear facts can be extracted from the overview.`))
+
+// buildExtractionPrompt builds the prompt for knowledge extraction from the configured
+// template. A template that fails to execute falls back to the built-in prompt.
+func (s *knowledgeSeedingService) buildExtractionPrompt(overview string, schemaContext string) string {
+	data := knowledgeExtractionPromptData{
+		Overview:      overview,
+		SchemaContext: schemaContext,
+		Domains:       s.domainTaxonomy,
+	}
 
+	var sb strings.Builder
+	if err := s.promptTemplate.Execute(&sb, data); err != nil {
+		s.logger.Warn("Description prompt template failed, using the built-in prompt", zap.Error(err))
+		sb.Reset()
+		// The built-in template only references knowledgeExtractionPromptData fields.
+		_ = defaultKnowledgeExtractionPrompt.Execute(&sb, data)
+	}
 	return sb.String()
 }
 
@@ -252,6 +324,16 @@ func (s *knowledgeSeedingService) parseExtractionResponse(content string) ([]ext
 			continue
 		}
 
+		// Entity hints that name tables are kept as entity_hint so table analysis can
+		// inject them into those tables' prompts. The table names become the context.
+		if tables := entityHintTables(fact); len(tables) > 0 {
+			fact.FactType = models.FactTypeEntityHint
+			fact.Tables = tables
+			fact.Context = strings.Join(tables, ", ")
+			validFacts = append(validFacts, fact)
+			continue
+		}
+
 		// Map fact_type to valid fact types from the model
 		mappedType := mapFactTypeToModel(fact.FactType)
 		if mappedType == "" {
@@ -284,6 +366,82 @@ func mapFactTypeToModel(factType string) string {
 	}
 }
 
+// entityHintTables returns the trimmed, non-empty table names of an entity_hint fact.
+// Other fact types and hints without tables return nil.
+func entityHintTables(fact extractedFact) []string {
+	if !strings.EqualFold(fact.FactType, models.FactTypeEntityHint) {
+		return nil
+	}
+	var tables []string
+	for _, table := range fact.Tables {
+		if table = strings.TrimSpace(table); table != "" {
+			tables = append(tables, table)
+		}
+	}
+	return tables
+}
+
+// validateEntityHints checks entity hints against the selected tables and the domain
+// taxonomy. Unknown tables are dropped from a hint; a hint left with no known table is
+// kept as terminology so it is not injected into any table's prompt. A domain outside
+// the taxonomy is cleared. Every such hint is reported as a warning. An empty
+// knownTables or taxonomy skips that check.
+func validateEntityHints(facts []extractedFact, knownTables []string, taxonomy []string) ([]extractedFact, []entityHintWarning) {
+	known := make(map[string]bool, len(knownTables))
+	for _, table := range knownTables {
+		known[entityHintTableKey(table)] = true
+	}
+
+	var warnings []entityHintWarning
+	for i := range facts {
+		fact := &facts[i]
+		if fact.FactType != models.FactTypeEntityHint {
+			continue
+		}
+
+		if len(known) > 0 {
+			var valid, unknown []string
+			for _, table := range fact.Tables {
+				if known[entityHintTableKey(table)] {
+					valid = append(valid, table)
+				} else {
+					unknown = append(unknown, table)
+				}
+			}
+			if len(unknown) > 0 {
+				reason := fmt.Sprintf("references unknown table(s): %s", strings.Join(unknown, ", "))
+				if len(valid) == 0 {
+					reason += "; kept as terminology"
+					fact.FactType = models.FactTypeTerminology
+				} else {
+					fact.Tables = valid
+					fact.Context = strings.Join(valid, ", ")
+				}
+				warnings = append(warnings, entityHintWarning{Hint: fact.Value, Reason: reason})
+			}
+		}
+
+		if len(taxonomy) > 0 && fact.Domain != "" && !containsFold(taxonomy, fact.Domain) {
+			warnings = append(warnings, entityHintWarning{
+				Hint:   fact.Value,
+				Reason: fmt.Sprintf("domain %q is not in the configured taxonomy", fact.Domain),
+			})
+			fact.Domain = ""
+		}
+	}
+	return facts, warnings
+}
+
+func containsFold(values []string, target string) bool {
+	target = strings.TrimSpace(target)
+	for _, v := range values {
+		if strings.EqualFold(strings.TrimSpace(v), target) {
+			return true
+		}
+	}
+	return false
+}
+
 // truncateForLog truncates a string to the specified length for logging purposes.
 func truncateForLog(s string, maxLen int) string {
 	if len(s) <= maxLen {
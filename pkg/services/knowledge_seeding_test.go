@@ -87,11 +87,18 @@ func (m *mockKnowledgeServiceForSeeding) DeleteAll(ctx context.Context, projectI
 type mockSchemaServiceForSeeding struct {
 	schemaForPrompt string
 	schemaErr       error
+	tables          []*models.SchemaTable
 }
 
 func (m *mockSchemaServiceForSeeding) RefreshDatasourceSchema(ctx context.Context, projectID, datasourceID uuid.UUID, autoSelect bool) (*models.RefreshResult, error) {
 	return nil, nil
 }
+func (m *mockSchemaServiceForSeeding) InventoryDatasourceSchema(ctx context.Context, projectID, datasourceID uuid.UUID) (*models.SchemaInventoryResult, error) {
+	return nil, nil
+}
+func (m *mockSchemaServiceForSeeding) IntrospectTables(ctx context.Context, projectID, datasourceID uuid.UUID, req *models.IntrospectTablesRequest) (*models.RefreshResult, error) {
+	return nil, nil
+}
 func (m *mockSchemaServiceForSeeding) GetDatasourceSchema(ctx context.Context, projectID, datasourceID uuid.UUID) (*models.DatasourceSchema, error) {
 	return nil, nil
 }
@@ -101,6 +108,9 @@ func (m *mockSchemaServiceForSeeding) GetDatasourceTable(ctx context.Context, pr
 func (m *mockSchemaServiceForSeeding) AddManualRelationship(ctx context.Context, projectID, datasourceID uuid.UUID, req *models.AddRelationshipRequest) (*models.SchemaRelationship, error) {
 	return nil, nil
 }
+func (m *mockSchemaServiceForSeeding) AddManualRelationshipByColumns(ctx context.Context, projectID uuid.UUID, req *models.AddColumnRelationshipRequest) (*models.SchemaRelationship, error) {
+	return nil, nil
+}
 func (m *mockSchemaServiceForSeeding) UpdateRelationship(ctx context.Context, projectID, relationshipID uuid.UUID, req *models.UpdateRelationshipRequest) (*models.SchemaRelationship, error) {
 	return nil, nil
 }
@@ -113,6 +123,12 @@ func (m *mockSchemaServiceForSeeding) GetRelationshipsForDatasource(ctx context.
 func (m *mockSchemaServiceForSeeding) GetRelationshipsResponse(ctx context.Context, projectID, datasourceID uuid.UUID) (*models.RelationshipsResponse, error) {
 	return nil, nil
 }
+func (m *mockSchemaServiceForSeeding) GetRelationshipStats(ctx context.Context, projectID, datasourceID uuid.UUID) (*models.RelationshipStats, error) {
+	return nil, nil
+}
+func (m *mockSchemaServiceForSeeding) GetRelationshipMetrics(ctx context.Context, projectID, relationshipID uuid.UUID) (*models.RelationshipMetrics, error) {
+	return nil, nil
+}
 func (m *mockSchemaServiceForSeeding) UpdateColumnMetadata(ctx context.Context, projectID, columnID uuid.UUID, businessName, description *string) error {
 	return nil
 }
@@ -133,7 +149,7 @@ func (m *mockSchemaServiceForSeeding) SelectAllTables(ctx context.Context, proje
 }
 
 func (m *mockSchemaServiceForSeeding) ListTablesByDatasource(ctx context.Context, projectID, datasourceID uuid.UUID) ([]*models.SchemaTable, error) {
-	return nil, nil
+	return m.tables, nil
 }
 
 func (m *mockSchemaServiceForSeeding) ListAllTablesByDatasource(ctx context.Context, projectID, datasourceID uuid.UUID) ([]*models.SchemaTable, error) {
@@ -204,6 +220,10 @@ func (m *mockLLMClientForSeeding) GetEndpoint() string {
 	return "http://localhost"
 }
 
+func (m *mockLLMClientForSeeding) GenerateResponseStream(ctx context.Context, prompt string, systemMessage string, temperature float64) (*llm.ResponseStream, error) {
+	return llm.CompletedResponseStream(m.GenerateResponse(ctx, prompt, systemMessage, temperature, false))
+}
+
 func TestKnowledgeSeedingService_ExtractKnowledgeFromOverview_NoOverview(t *testing.T) {
 	projectID := uuid.New()
 	datasourceID := uuid.New()
@@ -214,7 +234,7 @@ func TestKnowledgeSeedingService_ExtractKnowledgeFromOverview_NoOverview(t *test
 	schemaSvc := &mockSchemaServiceForSeeding{}
 	llmFactory := &mockLLMFactoryForSeeding{}
 
-	svc := NewKnowledgeSeedingService(knowledgeSvc, schemaSvc, llmFactory, zap.NewNop())
+	svc := NewKnowledgeSeedingService(knowledgeSvc, schemaSvc, llmFactory, "", nil, zap.NewNop())
 
 	count, err := svc.ExtractKnowledgeFromOverview(context.Background(), projectID, datasourceID)
 
@@ -266,7 +286,7 @@ func TestKnowledgeSeedingService_ExtractKnowledgeFromOverview_WithOverview(t *te
 	}
 	llmFactory := &mockLLMFactoryForSeeding{client: llmClient}
 
-	svc := NewKnowledgeSeedingService(knowledgeSvc, schemaSvc, llmFactory, zap.NewNop())
+	svc := NewKnowledgeSeedingService(knowledgeSvc, schemaSvc, llmFactory, "", nil, zap.NewNop())
 
 	count, err := svc.ExtractKnowledgeFromOverview(context.Background(), projectID, datasourceID)
 
@@ -304,7 +324,7 @@ func TestKnowledgeSeedingService_ExtractKnowledgeFromOverview_LLMError(t *testin
 	}
 	llmFactory := &mockLLMFactoryForSeeding{client: llmClient}
 
-	svc := NewKnowledgeSeedingService(knowledgeSvc, schemaSvc, llmFactory, zap.NewNop())
+	svc := NewKnowledgeSeedingService(knowledgeSvc, schemaSvc, llmFactory, "", nil, zap.NewNop())
 
 	count, err := svc.ExtractKnowledgeFromOverview(context.Background(), projectID, datasourceID)
 
@@ -338,7 +358,7 @@ func TestKnowledgeSeedingService_ExtractKnowledgeFromOverview_SchemaError(t *tes
 	}
 	llmFactory := &mockLLMFactoryForSeeding{client: llmClient}
 
-	svc := NewKnowledgeSeedingService(knowledgeSvc, schemaSvc, llmFactory, zap.NewNop())
+	svc := NewKnowledgeSeedingService(knowledgeSvc, schemaSvc, llmFactory, "", nil, zap.NewNop())
 
 	// Should not fail - schema is optional context
 	count, err := svc.ExtractKnowledgeFromOverview(context.Background(), projectID, datasourceID)
@@ -380,7 +400,7 @@ func TestKnowledgeSeedingService_ExtractKnowledgeFromOverview_EntityHintMapsToTe
 	}
 	llmFactory := &mockLLMFactoryForSeeding{client: llmClient}
 
-	svc := NewKnowledgeSeedingService(knowledgeSvc, schemaSvc, llmFactory, zap.NewNop())
+	svc := NewKnowledgeSeedingService(knowledgeSvc, schemaSvc, llmFactory, "", nil, zap.NewNop())
 
 	count, err := svc.ExtractKnowledgeFromOverview(context.Background(), projectID, datasourceID)
 
@@ -435,7 +455,7 @@ func TestKnowledgeSeedingService_ExtractKnowledgeFromOverview_InvalidFactsFilter
 	}
 	llmFactory := &mockLLMFactoryForSeeding{client: llmClient}
 
-	svc := NewKnowledgeSeedingService(knowledgeSvc, schemaSvc, llmFactory, zap.NewNop())
+	svc := NewKnowledgeSeedingService(knowledgeSvc, schemaSvc, llmFactory, "", nil, zap.NewNop())
 
 	count, err := svc.ExtractKnowledgeFromOverview(context.Background(), projectID, datasourceID)
 
@@ -514,7 +534,7 @@ func TestKnowledgeSeedingService_ExtractKnowledgeFromOverview_GetAllError(t *tes
 	}
 	llmFactory := &mockLLMFactoryForSeeding{client: llmClient}
 
-	svc := NewKnowledgeSeedingService(knowledgeSvc, schemaSvc, llmFactory, zap.NewNop())
+	svc := NewKnowledgeSeedingService(knowledgeSvc, schemaSvc, llmFactory, "", nil, zap.NewNop())
 
 	// Should not fail - GetAll error is logged and continues without existing facts
 	count, err := svc.ExtractKnowledgeFromOverview(context.Background(), projectID, datasourceID)
@@ -560,7 +580,7 @@ func TestKnowledgeSeedingService_ExtractKnowledgeFromOverview_SkipsWhenFactsExis
 	}
 	llmFactory := &mockLLMFactoryForSeeding{client: llmClient}
 
-	svc := NewKnowledgeSeedingService(knowledgeSvc, schemaSvc, llmFactory, zap.NewNop())
+	svc := NewKnowledgeSeedingService(knowledgeSvc, schemaSvc, llmFactory, "", nil, zap.NewNop())
 
 	count, err := svc.ExtractKnowledgeFromOverview(context.Background(), projectID, datasourceID)
 
@@ -604,7 +624,7 @@ func TestKnowledgeSeedingService_ExtractKnowledgeFromOverview_NoExistingFacts(t
 
 	llmFactory := &mockLLMFactoryForSeeding{client: llmClient}
 
-	svc := NewKnowledgeSeedingService(knowledgeSvc, schemaSvc, llmFactory, zap.NewNop())
+	svc := NewKnowledgeSeedingService(knowledgeSvc, schemaSvc, llmFactory, "", nil, zap.NewNop())
 
 	count, err := svc.ExtractKnowledgeFromOverview(context.Background(), projectID, datasourceID)
 
@@ -614,3 +634,153 @@ func TestKnowledgeSeedingService_ExtractKnowledgeFromOverview_NoExistingFacts(t
 	// Verify "Existing Project Knowledge" section is NOT included when only project_overview exists
 	assert.NotContains(t, llmClient.capturedPrompt, "Existing Project Knowledge")
 }
+
+func TestKnowledgeSeedingService_ExtractKnowledgeFromOverview_EntityHintWithTablesPersistsTables(t *testing.T) {
+	projectID := uuid.New()
+
+	knowledgeSvc := &mockKnowledgeServiceForSeeding{
+		getByTypeResult: []*models.KnowledgeFact{
+			{ID: uuid.New(), ProjectID: projectID, FactType: "project_overview", Value: "Users are internal employees."},
+		},
+	}
+	llmClient := &mockLLMClientForSeeding{
+		response: &llm.GenerateResponseResult{
+			Content: `{
+				"facts": [
+					{
+						"fact_type": "entity_hint",
+						"value": "Users are internal employees, not customers",
+						"context": "Entity distinction",
+						"tables": ["users", " ", "staff_users"]
+					}
+				]
+			}`,
+		},
+	}
+
+	svc := NewKnowledgeSeedingService(knowledgeSvc, &mockSchemaServiceForSeeding{}, &mockLLMFactoryForSeeding{client: llmClient}, "", nil, zap.NewNop())
+
+	count, err := svc.ExtractKnowledgeFromOverview(context.Background(), projectID, uuid.New())
+
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+	assert.Equal(t, models.FactTypeEntityHint, knowledgeSvc.storedFacts[0].factType)
+	assert.Equal(t, "Users are internal employees, not customers", knowledgeSvc.storedFacts[0].value)
+	assert.Equal(t, "users, staff_users", knowledgeSvc.storedFacts[0].context)
+	assert.Equal(t, "inferred", knowledgeSvc.storedFacts[0].source)
+}
+
+func TestKnowledgeSeedingService_ExtractKnowledgeFromOverview_FlagsHintForNonexistentTable(t *testing.T) {
+	projectID := uuid.New()
+
+	knowledgeSvc := &mockKnowledgeServiceForSeeding{
+		getByTypeResult: []*models.KnowledgeFact{
+			{ID: uuid.New(), ProjectID: projectID, FactType: "project_overview", Value: "Invoices are billed monthly to each user."},
+		},
+	}
+	schemaSvc := &mockSchemaServiceForSeeding{
+		tables: []*models.SchemaTable{{TableName: "users"}, {TableName: "orders"}},
+	}
+	llmClient := &mockLLMClientForSeeding{
+		response: &llm.GenerateResponseResult{
+			Content: `{
+				"facts": [
+					{"fact_type": "entity_hint", "value": "Invoices are billed monthly", "tables": ["invoices"]},
+					{"fact_type": "entity_hint", "value": "Users are the billed accounts", "tables": ["public.users", "invoices"]}
+				]
+			}`,
+		},
+	}
+
+	svc := NewKnowledgeSeedingService(knowledgeSvc, schemaSvc, &mockLLMFactoryForSeeding{client: llmClient}, "", nil, zap.NewNop())
+
+	count, err := svc.ExtractKnowledgeFromOverview(context.Background(), projectID, uuid.New())
+
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+	// The hint naming only a nonexistent table is not injected into any table prompt
+	assert.Equal(t, models.FactTypeTerminology, knowledgeSvc.storedFacts[0].factType)
+	// The partly valid hint keeps only its known table
+	assert.Equal(t, models.FactTypeEntityHint, knowledgeSvc.storedFacts[1].factType)
+	assert.Equal(t, "public.users", knowledgeSvc.storedFacts[1].context)
+}
+
+func TestValidateEntityHints(t *testing.T) {
+	facts := []extractedFact{
+		{FactType: models.FactTypeEntityHint, Value: "Invoices are billed monthly", Tables: []string{"invoices"}, Context: "invoices"},
+		{FactType: models.FactTypeEntityHint, Value: "Users are customers", Tables: []string{"Users"}, Context: "Users", Domain: "customer"},
+		{FactType: models.FactTypeEntityHint, Value: "Orders are sales", Tables: []string{"orders"}, Context: "orders", Domain: "logistics"},
+		{FactType: models.FactTypeConvention, Value: "Amounts are in cents"},
+	}
+
+	got, warnings := validateEntityHints(facts, []string{"users", "orders"}, []string{"sales", "Customer"})
+
+	require.Len(t, warnings, 2)
+	assert.Equal(t, "Invoices are billed monthly", warnings[0].Hint)
+	assert.Contains(t, warnings[0].Reason, "unknown table(s): invoices")
+	assert.Equal(t, models.FactTypeTerminology, got[0].FactType)
+
+	assert.Equal(t, models.FactTypeEntityHint, got[1].FactType)
+	assert.Equal(t, "customer", got[1].Domain)
+
+	assert.Equal(t, "Orders are sales", warnings[1].Hint)
+	assert.Contains(t, warnings[1].Reason, `domain "logistics" is not in the configured taxonomy`)
+	assert.Empty(t, got[2].Domain)
+	assert.Equal(t, models.FactTypeEntityHint, got[2].FactType)
+}
+
+func TestValidateEntityHints_SkipsChecksWithoutSchemaOrTaxonomy(t *testing.T) {
+	facts := []extractedFact{
+		{FactType: models.FactTypeEntityHint, Value: "Invoices are billed monthly", Tables: []string{"invoices"}, Domain: "billing"},
+	}
+
+	got, warnings := validateEntityHints(facts, nil, nil)
+
+	assert.Empty(t, warnings)
+	assert.Equal(t, models.FactTypeEntityHint, got[0].FactType)
+	assert.Equal(t, "billing", got[0].Domain)
+}
+
+func TestKnowledgeSeedingService_BuildExtractionPrompt_Template(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		taxonomy []string
+		contains []string
+	}{
+		{
+			name:     "built-in prompt lists taxonomy domains",
+			taxonomy: []string{"sales", "finance"},
+			contains: []string{"## Project Overview\nA store", "## Database Schema (for context)\nusers(id)", `set "domain" to one of: sales, finance`},
+		},
+		{
+			name:     "configured template",
+			template: "Overview: {{.Overview}} | Domains: {{range .Domains}}[{{.}}]{{end}}",
+			taxonomy: []string{"sales"},
+			contains: []string{"Overview: A store | Domains: [sales]"},
+		},
+		{
+			name:     "unparseable template falls back to built-in",
+			template: "{{.Overview",
+			contains: []string{"Extract domain knowledge facts"},
+		},
+		{
+			name:     "template referencing unknown field falls back to built-in",
+			template: "{{.Description}}",
+			contains: []string{"Extract domain knowledge facts"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewKnowledgeSeedingService(&mockKnowledgeServiceForSeeding{}, &mockSchemaServiceForSeeding{},
+				&mockLLMFactoryForSeeding{}, tt.template, tt.taxonomy, zap.NewNop()).(*knowledgeSeedingService)
+
+			prompt := svc.buildExtractionPrompt("A store", "users(id)")
+
+			for _, want := range tt.contains {
+				assert.Contains(t, prompt, want)
+			}
+		})
+	}
+}
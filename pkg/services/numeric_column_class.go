@@ -0,0 +1,81 @@
+package services
+
+import (
+	"strings"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+)
+
+// measureNameTokens mark a numeric column as a quantity when they lead or end its name
+// (num_users, total_amount, order_count).
+var measureNameTokens = map[string]bool{
+	"num": true, "total": true, "count": true, "sum": true, "avg": true, "qty": true, "quantity": true,
+	"amount": true, "price": true, "cost": true, "fee": true, "balance": true, "revenue": true,
+	"cents": true, "duration": true, "weight": true, "score": true,
+}
+
+// dimensionNameTokens mark a numeric column as a grouping attribute rather than a quantity.
+var dimensionNameTokens = map[string]bool{
+	"year": true, "month": true, "day": true, "week": true, "quarter": true, "hour": true,
+	"level": true, "rank": true, "tier": true, "type": true, "status": true, "code": true,
+	"priority": true, "version": true,
+}
+
+// numericBaseTypes are the PostgreSQL and SQL Server numeric types, without precision.
+var numericBaseTypes = map[string]bool{
+	"smallint": true, "integer": true, "int": true, "bigint": true, "tinyint": true,
+	"int2": true, "int4": true, "int8": true, "serial": true, "bigserial": true, "smallserial": true,
+	"decimal": true, "numeric": true, "real": true, "float": true, "float4": true, "float8": true,
+	"double precision": true, "money": true, "smallmoney": true,
+}
+
+// isNumericDataType reports whether dataType is numeric. Unlike a substring match it
+// does not mistake interval or point for integers.
+func isNumericDataType(dataType string) bool {
+	base, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(dataType)), "(")
+	return numericBaseTypes[strings.TrimSpace(base)]
+}
+
+// classifyNumericColumn tags a numeric column as a measure, identifier or dimension so
+// table summaries can tell SQL generation which columns are aggregatable. The column
+// features from extraction (keys, FK targets, Purpose) decide when present; otherwise the
+// column name is used. Returns empty for non-numeric columns and numeric columns with no
+// signal either way.
+func classifyNumericColumn(col *models.SchemaColumn, meta *models.ColumnMetadata) string {
+	if !isNumericDataType(col.DataType) {
+		return ""
+	}
+
+	if col.IsPrimaryKey {
+		return models.NumericClassIdentifier
+	}
+	if meta != nil {
+		if idFeatures := meta.GetIdentifierFeatures(); idFeatures != nil && idFeatures.FKTargetTable != "" {
+			return models.NumericClassIdentifier
+		}
+		if meta.Purpose != nil {
+			switch *meta.Purpose {
+			case models.PurposeMeasure:
+				return models.NumericClassMeasure
+			case models.PurposeIdentifier:
+				return models.NumericClassIdentifier
+			case models.PurposeEnum, models.PurposeFlag, models.PurposeTimestamp:
+				return models.NumericClassDimension
+			}
+		}
+	}
+
+	name := strings.ToLower(col.ColumnName)
+	if name == "id" || strings.HasSuffix(name, "_id") {
+		return models.NumericClassIdentifier
+	}
+	tokens := strings.Split(name, "_")
+	first, last := tokens[0], tokens[len(tokens)-1]
+	switch {
+	case measureNameTokens[first] || measureNameTokens[last]:
+		return models.NumericClassMeasure
+	case dimensionNameTokens[first] || dimensionNameTokens[last]:
+		return models.NumericClassDimension
+	}
+	return ""
+}
@@ -143,15 +143,21 @@ func (s *ontologyContextService) GetTablesContext(ctx context.Context, projectID
 
 		// Build column overview from schema columns, merging column metadata
 		columns := make([]models.ColumnOverview, 0, len(schemaColumns))
+		var measures []string
 		for _, col := range schemaColumns {
+			meta := metadataByColumnID[col.ID]
 			overview := models.ColumnOverview{
 				Name:         col.ColumnName,
 				Type:         col.DataType,
 				IsPrimaryKey: col.IsPrimaryKey,
+				NumericClass: classifyNumericColumn(col, meta),
+			}
+			if overview.NumericClass == models.NumericClassMeasure {
+				measures = append(measures, col.ColumnName)
 			}
 
 			// Merge column metadata if available
-			if meta, ok := metadataByColumnID[col.ID]; ok {
+			if meta != nil {
 				if meta.Role != nil {
 					overview.Role = *meta.Role
 				}
@@ -168,6 +174,7 @@ func (s *ontologyContextService) GetTablesContext(ctx context.Context, projectID
 		summary := models.TableSummary{
 			ColumnCount: len(schemaColumns),
 			Columns:     columns,
+			Measures:    measures,
 		}
 
 		// Populate row count from schema table
@@ -189,6 +196,9 @@ func (s *ontologyContextService) GetTablesContext(ctx context.Context, projectID
 			if meta.PreferredAlternative != nil && *meta.PreferredAlternative != "" {
 				summary.PreferredAlternative = *meta.PreferredAlternative
 			}
+			if quality := meta.GetQuality(); quality != nil {
+				summary.QualityScore = &quality.Score
+			}
 		}
 
 		tables[tableName] = summary
@@ -352,6 +362,10 @@ func columnEnumValuesToEnumValues(values []models.ColumnEnumValue) []models.Enum
 			pct := v.Percentage
 			ev.Percentage = &pct
 		}
+		if v.IsStale {
+			isStale := true
+			ev.IsStale = &isStale
+		}
 		result = append(result, ev)
 	}
 	return result
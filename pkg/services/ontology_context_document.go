@@ -0,0 +1,532 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/apperrors"
+	"github.com/ekaya-inc/ekaya-engine/pkg/llm"
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+)
+
+// Bounds on the token budget a caller may request for the context document, and the
+// budget used when neither the caller nor the configuration sets one.
+const (
+	MinContextDocumentTokenBudget     = 200
+	MaxContextDocumentTokenBudget     = 200000
+	DefaultContextDocumentTokenBudget = 8000
+)
+
+// contextDocumentFooterReserve is kept free for the footer listing what was omitted.
+var contextDocumentFooterReserve = llm.EstimateTokens(contextDocumentFooter(999999, 999999, 999999))
+
+// OntologyContextDocumentService renders the ontology as a single text document for
+// a text-to-SQL prompt, sized to a token budget.
+type OntologyContextDocumentService interface {
+	// BuildDocument renders the project's ontology in at most maxTokens estimated
+	// tokens. A maxTokens of 0 uses the configured default budget.
+	BuildDocument(ctx context.Context, projectID uuid.UUID, maxTokens int) (*models.OntologyContextDocument, error)
+}
+
+type contextDocumentProjectRepository interface {
+	Get(ctx context.Context, id uuid.UUID) (*models.Project, error)
+}
+
+type contextDocumentSchemaRepository interface {
+	ListTablesByDatasource(ctx context.Context, projectID, datasourceID uuid.UUID) ([]*models.SchemaTable, error)
+	GetColumnsByTables(ctx context.Context, projectID uuid.UUID, tableNames []string) (map[string][]*models.SchemaColumn, error)
+	GetRelationshipDetails(ctx context.Context, projectID, datasourceID uuid.UUID) ([]*models.RelationshipDetail, error)
+}
+
+type contextDocumentTableMetadataRepository interface {
+	ListByTableNames(ctx context.Context, projectID uuid.UUID, tableNames []string) (map[string]*models.TableMetadata, error)
+}
+
+type contextDocumentColumnMetadataRepository interface {
+	GetBySchemaColumnIDs(ctx context.Context, schemaColumnIDs []uuid.UUID) ([]*models.ColumnMetadata, error)
+}
+
+type contextDocumentGlossaryRepository interface {
+	GetByProject(ctx context.Context, projectID uuid.UUID) ([]*models.BusinessGlossaryTerm, error)
+}
+
+type ontologyContextDocumentService struct {
+	projectRepo        contextDocumentProjectRepository
+	schemaRepo         contextDocumentSchemaRepository
+	tableMetadataRepo  contextDocumentTableMetadataRepository
+	columnMetadataRepo contextDocumentColumnMetadataRepository
+	glossaryRepo       contextDocumentGlossaryRepository
+	defaultTokenBudget int
+	logger             *zap.Logger
+}
+
+// NewOntologyContextDocumentService creates a new ontology context document service.
+// defaultTokenBudget is used when a caller does not ask for a budget; 0 falls back to
+// DefaultContextDocumentTokenBudget.
+func NewOntologyContextDocumentService(
+	projectRepo contextDocumentProjectRepository,
+	schemaRepo contextDocumentSchemaRepository,
+	tableMetadataRepo contextDocumentTableMetadataRepository,
+	columnMetadataRepo contextDocumentColumnMetadataRepository,
+	glossaryRepo contextDocumentGlossaryRepository,
+	defaultTokenBudget int,
+	logger *zap.Logger,
+) OntologyContextDocumentService {
+	if defaultTokenBudget <= 0 {
+		defaultTokenBudget = DefaultContextDocumentTokenBudget
+	}
+	return &ontologyContextDocumentService{
+		projectRepo:        projectRepo,
+		schemaRepo:         schemaRepo,
+		tableMetadataRepo:  tableMetadataRepo,
+		columnMetadataRepo: columnMetadataRepo,
+		glossaryRepo:       glossaryRepo,
+		defaultTokenBudget: defaultTokenBudget,
+		logger:             logger.Named("ontology-context-document"),
+	}
+}
+
+var _ OntologyContextDocumentService = (*ontologyContextDocumentService)(nil)
+
+func (s *ontologyContextDocumentService) BuildDocument(ctx context.Context, projectID uuid.UUID, maxTokens int) (*models.OntologyContextDocument, error) {
+	if maxTokens == 0 {
+		maxTokens = s.defaultTokenBudget
+	}
+
+	project, err := s.projectRepo.Get(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("load project: %w", err)
+	}
+
+	tables, err := s.schemaRepo.ListTablesByDatasource(ctx, projectID, uuid.Nil) // uuid.Nil gets all datasources
+	if err != nil {
+		return nil, fmt.Errorf("list tables: %w", err)
+	}
+	if len(tables) == 0 {
+		return nil, apperrors.ErrNoSelectedTables
+	}
+	tableNames := make([]string, 0, len(tables))
+	for _, t := range tables {
+		tableNames = append(tableNames, t.TableName)
+	}
+
+	columnsByTable, err := s.schemaRepo.GetColumnsByTables(ctx, projectID, tableNames)
+	if err != nil {
+		return nil, fmt.Errorf("get columns by tables: %w", err)
+	}
+	var columnIDs []uuid.UUID
+	for _, columns := range columnsByTable {
+		for _, col := range columns {
+			columnIDs = append(columnIDs, col.ID)
+		}
+	}
+	metadataByColumnID := make(map[uuid.UUID]*models.ColumnMetadata)
+	if len(columnIDs) > 0 {
+		metadata, err := s.columnMetadataRepo.GetBySchemaColumnIDs(ctx, columnIDs)
+		if err != nil {
+			return nil, fmt.Errorf("get column metadata: %w", err)
+		}
+		for _, meta := range metadata {
+			metadataByColumnID[meta.SchemaColumnID] = meta
+		}
+	}
+
+	tableMetadata, err := s.tableMetadataRepo.ListByTableNames(ctx, projectID, tableNames)
+	if err != nil {
+		return nil, fmt.Errorf("get table metadata: %w", err)
+	}
+
+	relationships, err := s.schemaRepo.GetRelationshipDetails(ctx, projectID, uuid.Nil)
+	if err != nil {
+		return nil, fmt.Errorf("get relationships: %w", err)
+	}
+
+	terms, err := s.glossaryRepo.GetByProject(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("get glossary terms: %w", err)
+	}
+
+	var critical []string
+	var domainDescription string
+	projectName := "Database"
+	if project != nil {
+		critical = ontologySettingsFromParameters(project.Parameters).CriticalTables
+		if project.DomainSummary != nil {
+			domainDescription = project.DomainSummary.Description
+		}
+		if project.Name != "" {
+			projectName = project.Name
+		}
+	}
+
+	ordered, criticalIDs := prioritizeContextTables(tables, critical, relationships)
+	doc := renderContextDocument(contextDocumentInput{
+		title:              projectName + " context",
+		domainDescription:  domainDescription,
+		tables:             ordered,
+		criticalTableIDs:   criticalIDs,
+		columnsByTable:     columnsByTable,
+		metadataByColumnID: metadataByColumnID,
+		tableMetadata:      tableMetadata,
+		relationships:      relationships,
+		glossaryTerms:      terms,
+	}, maxTokens)
+
+	s.logger.Debug("Built ontology context document",
+		zap.String("project_id", projectID.String()),
+		zap.Int("estimated_tokens", doc.EstimatedTokens),
+		zap.Int("token_budget", doc.TokenBudget),
+		zap.Int("tables_omitted", doc.TablesOmitted),
+		zap.Int("joins_omitted", doc.JoinsOmitted),
+		zap.Int("glossary_terms_omitted", doc.GlossaryTermsOmitted))
+
+	return doc, nil
+}
+
+// contextDocumentInput is everything renderContextDocument draws on. tables are in
+// priority order.
+type contextDocumentInput struct {
+	title              string
+	domainDescription  string
+	tables             []*models.SchemaTable
+	criticalTableIDs   map[uuid.UUID]bool
+	columnsByTable     map[string][]*models.SchemaColumn
+	metadataByColumnID map[uuid.UUID]*models.ColumnMetadata
+	tableMetadata      map[string]*models.TableMetadata
+	relationships      []*models.RelationshipDetail
+	glossaryTerms      []*models.BusinessGlossaryTerm
+}
+
+// contextDocumentTableShare is the share of the budget tables may take before joins
+// and the glossary get theirs; whatever those leave goes to the remaining tables.
+const contextDocumentTableShare = 0.6
+
+// contextDocumentSection is one part of the document, written under its heading once
+// its first block is added.
+type contextDocumentSection struct {
+	heading string
+	sb      strings.Builder
+}
+
+// contextDocumentBuilder adds blocks to sections while they fit the budget. Each block
+// is estimated on its own; the estimates add up to at least the estimate of the whole
+// text, so the finished document never exceeds the budget.
+type contextDocumentBuilder struct {
+	remaining int
+}
+
+// add appends block to section if it fits within limit and the remaining budget, and
+// reports whether it did. The section heading counts towards the first block.
+func (b *contextDocumentBuilder) add(section *contextDocumentSection, block string, limit int) bool {
+	if section.sb.Len() == 0 {
+		block = section.heading + block
+	}
+	tokens := llm.EstimateTokens(block)
+	if tokens > b.remaining || tokens > limit {
+		return false
+	}
+	section.sb.WriteString(block)
+	b.remaining -= tokens
+	return true
+}
+
+// renderContextDocument lays the document out as title and domain description, then
+// tables in priority order, the joins between included tables (trusted first), and
+// the glossary. Tables first fill contextDocumentTableShare of the budget so joins
+// and glossary terms are not crowded out by low-priority tables, then take what is
+// left. Anything that does not fit maxTokens is left out and counted; a table whose
+// full block does not fit is reduced to its name and description.
+func renderContextDocument(in contextDocumentInput, maxTokens int) *models.OntologyContextDocument {
+	doc := &models.OntologyContextDocument{TokenBudget: maxTokens}
+	b := &contextDocumentBuilder{remaining: maxTokens - contextDocumentFooterReserve}
+	unlimited := maxTokens
+
+	header := &contextDocumentSection{}
+	tablesSection := &contextDocumentSection{heading: "\n## Tables\n"}
+	joinsSection := &contextDocumentSection{heading: "\n## Joins\n"}
+	glossarySection := &contextDocumentSection{heading: "\n## Glossary\n"}
+
+	b.add(header, "# "+in.title+"\n", unlimited)
+	if desc := collapseWhitespace(in.domainDescription); desc != "" {
+		b.add(header, "\n"+desc+"\n", unlimited)
+	}
+
+	included := make(map[string]bool, len(in.tables))
+	tableDone := make([]bool, len(in.tables))
+	addTables := func(limit int) {
+		for i, t := range in.tables {
+			if tableDone[i] {
+				continue
+			}
+			meta := in.tableMetadata[t.TableName]
+			critical := in.criticalTableIDs[t.ID]
+			before := b.remaining
+			full := contextDocumentTableBlock(t, critical, meta, in.columnsByTable[t.TableName], in.metadataByColumnID, true)
+			if b.add(tablesSection, full, limit) ||
+				b.add(tablesSection, contextDocumentTableBlock(t, critical, meta, nil, nil, false), limit) {
+				tableDone[i] = true
+				included[strings.ToLower(t.TableName)] = true
+				doc.TablesIncluded++
+				limit -= before - b.remaining
+			}
+		}
+	}
+
+	joins := prioritizeContextJoins(in.relationships)
+	joinDone := make([]bool, len(joins))
+	addJoins := func() {
+		for i, rel := range joins {
+			if joinDone[i] || !included[strings.ToLower(rel.SourceTableName)] || !included[strings.ToLower(rel.TargetTableName)] {
+				continue
+			}
+			if b.add(joinsSection, contextDocumentJoinLine(rel), unlimited) {
+				joinDone[i] = true
+				doc.JoinsIncluded++
+			}
+		}
+	}
+
+	addTables(int(float64(b.remaining) * contextDocumentTableShare))
+	addJoins()
+
+	terms := append([]*models.BusinessGlossaryTerm(nil), in.glossaryTerms...)
+	sort.SliceStable(terms, func(i, j int) bool {
+		return strings.ToLower(terms[i].Term) < strings.ToLower(terms[j].Term)
+	})
+	for _, term := range terms {
+		if b.add(glossarySection, contextDocumentGlossaryLine(term), unlimited) {
+			doc.GlossaryTermsIncluded++
+		}
+	}
+
+	addTables(unlimited)
+	addJoins()
+
+	doc.TablesOmitted = len(in.tables) - doc.TablesIncluded
+	doc.JoinsOmitted = len(joins) - doc.JoinsIncluded
+	doc.GlossaryTermsOmitted = len(terms) - doc.GlossaryTermsIncluded
+
+	var sb strings.Builder
+	for _, section := range []*contextDocumentSection{header, tablesSection, joinsSection, glossarySection} {
+		sb.WriteString(section.sb.String())
+	}
+	if doc.TablesOmitted+doc.JoinsOmitted+doc.GlossaryTermsOmitted > 0 {
+		sb.WriteString(contextDocumentFooter(doc.TablesOmitted, doc.JoinsOmitted, doc.GlossaryTermsOmitted))
+	}
+
+	doc.Content = sb.String()
+	doc.EstimatedTokens = llm.EstimateTokens(doc.Content)
+	return doc
+}
+
+// contextDocumentFooter notes what was left out to fit the budget, so the reader knows
+// the document is partial.
+func contextDocumentFooter(tables, joins, terms int) string {
+	return fmt.Sprintf("\n_Omitted to fit the token budget: %d tables, %d joins, %d glossary terms._\n", tables, joins, terms)
+}
+
+// contextDocumentTableBlock renders a table as its heading, description and usage
+// notes and, when withColumns is set, its columns and documented enum values.
+func contextDocumentTableBlock(
+	t *models.SchemaTable,
+	critical bool,
+	meta *models.TableMetadata,
+	columns []*models.SchemaColumn,
+	metadataByColumnID map[uuid.UUID]*models.ColumnMetadata,
+	withColumns bool,
+) string {
+	var sb strings.Builder
+	sb.WriteString("\n### " + qualifiedTableName(t))
+	if critical {
+		sb.WriteString(" (business-critical)")
+	}
+	sb.WriteString("\n")
+	if meta != nil {
+		if desc := collapseWhitespace(ptrString(meta.Description)); desc != "" {
+			sb.WriteString(desc + "\n")
+		}
+		if notes := collapseWhitespace(ptrString(meta.UsageNotes)); notes != "" {
+			sb.WriteString("Usage: " + notes + "\n")
+		}
+		if meta.IsEphemeral {
+			line := "Ephemeral table"
+			if alt := ptrString(meta.PreferredAlternative); alt != "" {
+				line += "; prefer " + alt
+			}
+			sb.WriteString(line + "\n")
+		}
+	}
+	if !withColumns || len(columns) == 0 {
+		return sb.String()
+	}
+
+	parts := make([]string, 0, len(columns))
+	var enums []string
+	for _, col := range columns {
+		part := col.ColumnName + " " + col.DataType
+		if col.IsPrimaryKey {
+			part += " PK"
+		}
+		parts = append(parts, part)
+
+		meta := metadataByColumnID[col.ID]
+		if meta == nil {
+			continue
+		}
+		enumFeatures := meta.GetEnumFeatures()
+		if enumFeatures == nil || len(enumFeatures.Values) == 0 {
+			continue
+		}
+		values := make([]string, 0, len(enumFeatures.Values))
+		for _, v := range enumFeatures.Values {
+			values = append(values, v.Display())
+		}
+		enums = append(enums, "- "+col.ColumnName+": "+strings.Join(values, "; "))
+	}
+	sb.WriteString("Columns: " + strings.Join(parts, ", ") + "\n")
+	if len(enums) > 0 {
+		sb.WriteString("Values:\n" + strings.Join(enums, "\n") + "\n")
+	}
+	return sb.String()
+}
+
+// contextDocumentJoinLine renders a relationship with its stored join clause, falling
+// back to the column pair when the clause was never recorded.
+func contextDocumentJoinLine(rel *models.RelationshipDetail) string {
+	line := fmt.Sprintf("- %s.%s -> %s.%s", rel.SourceTableName, rel.SourceColumnName, rel.TargetTableName, rel.TargetColumnName)
+	if rel.Cardinality != "" && rel.Cardinality != models.CardinalityUnknown {
+		line += " (" + rel.Cardinality + ")"
+	}
+	if clause := ptrString(rel.JoinClause); clause != "" {
+		line += ": ON " + clause
+	}
+	return line + "\n"
+}
+
+// contextDocumentGlossaryLine renders a glossary term with its aliases and defining SQL.
+func contextDocumentGlossaryLine(term *models.BusinessGlossaryTerm) string {
+	line := "- **" + term.Term + "**"
+	if len(term.Aliases) > 0 {
+		line += " (also: " + strings.Join(term.Aliases, ", ") + ")"
+	}
+	if def := collapseWhitespace(term.Definition); def != "" {
+		line += ": " + def
+	}
+	if sql := collapseWhitespace(term.DefiningSQL); sql != "" {
+		line += "\n  SQL: " + sql
+	}
+	return line + "\n"
+}
+
+// prioritizeContextTables orders the tables business-critical first (in the order the
+// settings list them), then by how many relationships touch them, then by row count
+// and name, and returns the IDs of the critical ones. Critical names match "table" or
+// "schema.table", ignoring case.
+func prioritizeContextTables(tables []*models.SchemaTable, critical []string, relationships []*models.RelationshipDetail) ([]*models.SchemaTable, map[uuid.UUID]bool) {
+	criticalRank := make(map[string]int, len(critical))
+	for i, name := range critical {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if _, ok := criticalRank[key]; !ok {
+			criticalRank[key] = i
+		}
+	}
+	rankOf := func(t *models.SchemaTable) (int, bool) {
+		if rank, ok := criticalRank[strings.ToLower(qualifiedTableName(t))]; ok {
+			return rank, true
+		}
+		rank, ok := criticalRank[strings.ToLower(t.TableName)]
+		return rank, ok
+	}
+
+	degree := make(map[string]int)
+	for _, rel := range relationships {
+		degree[strings.ToLower(rel.SourceTableName)]++
+		if !strings.EqualFold(rel.SourceTableName, rel.TargetTableName) {
+			degree[strings.ToLower(rel.TargetTableName)]++
+		}
+	}
+
+	ordered := append([]*models.SchemaTable(nil), tables...)
+	criticalIDs := make(map[uuid.UUID]bool)
+	for _, t := range tables {
+		if _, ok := rankOf(t); ok {
+			criticalIDs[t.ID] = true
+		}
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		a, b := ordered[i], ordered[j]
+		rankA, criticalA := rankOf(a)
+		rankB, criticalB := rankOf(b)
+		if criticalA != criticalB {
+			return criticalA
+		}
+		if criticalA && rankA != rankB {
+			return rankA < rankB
+		}
+		degreeA, degreeB := degree[strings.ToLower(a.TableName)], degree[strings.ToLower(b.TableName)]
+		if degreeA != degreeB {
+			return degreeA > degreeB
+		}
+		rowsA, rowsB := int64Value(a.RowCount), int64Value(b.RowCount)
+		if rowsA != rowsB {
+			return rowsA > rowsB
+		}
+		return a.TableName < b.TableName
+	})
+	return ordered, criticalIDs
+}
+
+// prioritizeContextJoins drops rejected relationships and orders the rest by how far
+// they can be trusted: declared FKs, manual and approved relationships first (as for
+// table analysis prompts, see includeRelationshipInPrompt), then inferred ones by
+// descending confidence.
+func prioritizeContextJoins(relationships []*models.RelationshipDetail) []*models.RelationshipDetail {
+	joins := make([]*models.RelationshipDetail, 0, len(relationships))
+	for _, rel := range relationships {
+		if rel.IsApproved != nil && !*rel.IsApproved {
+			continue
+		}
+		joins = append(joins, rel)
+	}
+	trust := func(rel *models.RelationshipDetail) float64 {
+		// No confidence reaches +Inf, so only the trusted kinds pass
+		if includeRelationshipInPrompt(rel, math.Inf(1)) {
+			return math.Inf(1)
+		}
+		return rel.Confidence
+	}
+	sort.SliceStable(joins, func(i, j int) bool {
+		trustI, trustJ := trust(joins[i]), trust(joins[j])
+		if trustI != trustJ {
+			return trustI > trustJ
+		}
+		return contextDocumentJoinLine(joins[i]) < contextDocumentJoinLine(joins[j])
+	})
+	return joins
+}
+
+// qualifiedTableName returns schema.table, or the bare table name without a schema.
+func qualifiedTableName(t *models.SchemaTable) string {
+	if t.SchemaName == "" {
+		return t.TableName
+	}
+	return t.SchemaName + "." + t.TableName
+}
+
+// collapseWhitespace joins s onto one line so multi-line text stays compact.
+func collapseWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func int64Value(v *int64) int64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/llm"
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+)
+
+type stubContextDocumentProjectRepo struct{ project *models.Project }
+
+func (s *stubContextDocumentProjectRepo) Get(_ context.Context, _ uuid.UUID) (*models.Project, error) {
+	return s.project, nil
+}
+
+type stubContextDocumentSchemaRepo struct {
+	tables         []*models.SchemaTable
+	columnsByTable map[string][]*models.SchemaColumn
+	relationships  []*models.RelationshipDetail
+}
+
+func (s *stubContextDocumentSchemaRepo) ListTablesByDatasource(_ context.Context, _, _ uuid.UUID) ([]*models.SchemaTable, error) {
+	return s.tables, nil
+}
+
+func (s *stubContextDocumentSchemaRepo) GetColumnsByTables(_ context.Context, _ uuid.UUID, _ []string) (map[string][]*models.SchemaColumn, error) {
+	return s.columnsByTable, nil
+}
+
+func (s *stubContextDocumentSchemaRepo) GetRelationshipDetails(_ context.Context, _, _ uuid.UUID) ([]*models.RelationshipDetail, error) {
+	return s.relationships, nil
+}
+
+type stubContextDocumentTableMetadataRepo struct {
+	byName map[string]*models.TableMetadata
+}
+
+func (s *stubContextDocumentTableMetadataRepo) ListByTableNames(_ context.Context, _ uuid.UUID, _ []string) (map[string]*models.TableMetadata, error) {
+	return s.byName, nil
+}
+
+type stubContextDocumentColumnMetadataRepo struct{ metadata []*models.ColumnMetadata }
+
+func (s *stubContextDocumentColumnMetadataRepo) GetBySchemaColumnIDs(_ context.Context, _ []uuid.UUID) ([]*models.ColumnMetadata, error) {
+	return s.metadata, nil
+}
+
+type stubContextDocumentGlossaryRepo struct {
+	terms []*models.BusinessGlossaryTerm
+}
+
+func (s *stubContextDocumentGlossaryRepo) GetByProject(_ context.Context, _ uuid.UUID) ([]*models.BusinessGlossaryTerm, error) {
+	return s.terms, nil
+}
+
+// newContextDocumentFixture builds a project with many described tables: "zz_ledger"
+// is business-critical, "orders" is the hub most relationships touch, and the rest
+// are filler that cannot all fit a small budget.
+func newContextDocumentFixture() OntologyContextDocumentService {
+	schemaRepo := &stubContextDocumentSchemaRepo{columnsByTable: map[string][]*models.SchemaColumn{}}
+	tableMeta := map[string]*models.TableMetadata{}
+	var columnMeta []*models.ColumnMetadata
+
+	addTable := func(name string) {
+		schemaRepo.tables = append(schemaRepo.tables, &models.SchemaTable{ID: uuid.New(), SchemaName: "public", TableName: name})
+		schemaRepo.columnsByTable[name] = []*models.SchemaColumn{
+			{ID: uuid.New(), ColumnName: "id", DataType: "bigint", IsPrimaryKey: true},
+			{ID: uuid.New(), ColumnName: "created_at", DataType: "timestamptz"},
+			{ID: uuid.New(), ColumnName: "notes", DataType: "text"},
+		}
+		desc := fmt.Sprintf("Records of %s kept by the back office for reporting and reconciliation.", name)
+		tableMeta[name] = &models.TableMetadata{Description: &desc}
+	}
+	addTable("orders")
+	addTable("customers")
+	addTable("zz_ledger")
+	for i := 0; i < 30; i++ {
+		addTable(fmt.Sprintf("filler_%02d", i))
+	}
+
+	statusID := uuid.New()
+	schemaRepo.columnsByTable["orders"] = append(schemaRepo.columnsByTable["orders"],
+		&models.SchemaColumn{ID: statusID, ColumnName: "status", DataType: "text"})
+	columnMeta = append(columnMeta, &models.ColumnMetadata{
+		SchemaColumnID: statusID,
+		Features: models.ColumnMetadataFeatures{EnumFeatures: &models.EnumFeatures{Values: []models.ColumnEnumValue{
+			{Value: "P", Label: "Pending"},
+			{Value: "S", Label: "Shipped"},
+		}}},
+	})
+
+	fkClause := `"public"."orders"."customer_id" = "public"."customers"."id"`
+	rejected := false
+	schemaRepo.relationships = []*models.RelationshipDetail{
+		{
+			SourceTableName: "orders", SourceColumnName: "ledger_ref", TargetTableName: "zz_ledger", TargetColumnName: "id",
+			RelationshipType: models.RelationshipTypeInferred, Confidence: 0.55, Cardinality: models.CardinalityNTo1,
+		},
+		{
+			SourceTableName: "orders", SourceColumnName: "customer_id", TargetTableName: "customers", TargetColumnName: "id",
+			RelationshipType: models.RelationshipTypeFK, Confidence: 1, Cardinality: models.CardinalityNTo1, JoinClause: &fkClause,
+		},
+		{
+			SourceTableName: "customers", SourceColumnName: "referrer_order_id", TargetTableName: "orders", TargetColumnName: "id",
+			RelationshipType: models.RelationshipTypeInferred, Confidence: 0.9, IsApproved: &rejected,
+		},
+	}
+
+	project := &models.Project{
+		Name:          "Shop",
+		Parameters:    map[string]interface{}{"ontology": map[string]interface{}{"critical_tables": []interface{}{"public.zz_ledger"}}},
+		DomainSummary: &models.DomainSummary{Description: "An online shop selling to consumers."},
+	}
+	glossary := &stubContextDocumentGlossaryRepo{terms: []*models.BusinessGlossaryTerm{
+		{Term: "Revenue", Definition: "Sum of shipped order totals.", DefiningSQL: "SELECT SUM(total)\nFROM orders\nWHERE status = 'S'"},
+	}}
+
+	return NewOntologyContextDocumentService(
+		&stubContextDocumentProjectRepo{project: project},
+		schemaRepo,
+		&stubContextDocumentTableMetadataRepo{byName: tableMeta},
+		&stubContextDocumentColumnMetadataRepo{metadata: columnMeta},
+		glossary,
+		0,
+		zap.NewNop(),
+	)
+}
+
+func TestOntologyContextDocument_StaysWithinBudgetAndKeepsPriorityTablesFirst(t *testing.T) {
+	svc := newContextDocumentFixture()
+	budget := 600
+
+	doc, err := svc.BuildDocument(context.Background(), uuid.New(), budget)
+	require.NoError(t, err)
+
+	assert.Equal(t, budget, doc.TokenBudget)
+	assert.LessOrEqual(t, doc.EstimatedTokens, budget)
+	assert.LessOrEqual(t, llm.EstimateTokens(doc.Content), budget)
+	assert.Positive(t, doc.TablesOmitted, "33 described tables cannot all fit %d tokens", budget)
+	assert.Contains(t, doc.Content, "_Omitted to fit the token budget:")
+
+	// The critical table leads even though it sorts last, then the hub table.
+	ledger := strings.Index(doc.Content, "### public.zz_ledger (business-critical)")
+	orders := strings.Index(doc.Content, "### public.orders\n")
+	filler := strings.Index(doc.Content, "### public.filler_")
+	require.GreaterOrEqual(t, ledger, 0, doc.Content)
+	require.Greater(t, orders, ledger, doc.Content)
+	if filler >= 0 {
+		assert.Greater(t, filler, orders)
+	}
+	assert.Contains(t, doc.Content, "- status: P - Pending; S - Shipped")
+
+	// The declared FK is listed before the low-confidence inference; the rejected one is dropped.
+	fk := strings.Index(doc.Content, `- orders.customer_id -> customers.id (N:1): ON "public"."orders"."customer_id" = "public"."customers"."id"`)
+	inferred := strings.Index(doc.Content, "- orders.ledger_ref -> zz_ledger.id")
+	require.GreaterOrEqual(t, fk, 0, doc.Content)
+	require.Greater(t, inferred, fk)
+	assert.NotContains(t, doc.Content, "referrer_order_id")
+}
+
+func TestOntologyContextDocument_LargeBudgetIncludesEverything(t *testing.T) {
+	svc := newContextDocumentFixture()
+
+	doc, err := svc.BuildDocument(context.Background(), uuid.New(), MaxContextDocumentTokenBudget)
+	require.NoError(t, err)
+
+	assert.Equal(t, 33, doc.TablesIncluded)
+	assert.Zero(t, doc.TablesOmitted)
+	assert.Equal(t, 2, doc.JoinsIncluded)
+	assert.Zero(t, doc.JoinsOmitted, "the rejected relationship is dropped, not omitted for budget")
+	assert.Equal(t, 1, doc.GlossaryTermsIncluded)
+	assert.Contains(t, doc.Content, "An online shop selling to consumers.")
+	assert.Contains(t, doc.Content, "- **Revenue**: Sum of shipped order totals.\n  SQL: SELECT SUM(total) FROM orders WHERE status = 'S'")
+	assert.NotContains(t, doc.Content, "_Omitted to fit the token budget:")
+}
@@ -66,6 +66,7 @@ func (m *mockColumnMetadataRepository) DeleteBySchemaColumnID(ctx context.Contex
 // mockTableMetadataRepository is a mock for TableMetadataRepository.
 type mockTableMetadataRepository struct {
 	metadataByTableName map[string]*models.TableMetadata
+	qualityByTableID    map[uuid.UUID]*models.TableQualityFeatures
 }
 
 func (m *mockTableMetadataRepository) GetBySchemaTableID(ctx context.Context, schemaTableID uuid.UUID) (*models.TableMetadata, error) {
@@ -97,6 +98,14 @@ func (m *mockTableMetadataRepository) ListByTableNames(ctx context.Context, proj
 	return result, nil
 }
 
+func (m *mockTableMetadataRepository) UpdateQuality(ctx context.Context, schemaTableID uuid.UUID, quality *models.TableQualityFeatures) error {
+	if m.qualityByTableID == nil {
+		m.qualityByTableID = make(map[uuid.UUID]*models.TableQualityFeatures)
+	}
+	m.qualityByTableID[schemaTableID] = quality
+	return nil
+}
+
 func (m *mockTableMetadataRepository) Delete(ctx context.Context, schemaTableID uuid.UUID) error {
 	return nil
 }
@@ -805,6 +814,65 @@ func TestGetTablesContext_HasDescriptionFlag(t *testing.T) {
 	assert.False(t, columnByName["status"].HasDescription)
 }
 
+func TestGetTablesContext_LabelsNumericMeasures(t *testing.T) {
+	ctx := context.Background()
+	projectID := uuid.New()
+	tableID := uuid.New()
+
+	accountColID := uuid.New()
+	numUsersColID := uuid.New()
+	planTierColID := uuid.New()
+
+	schemaRepo := &mockSchemaRepository{
+		columnsByTable: map[string][]*models.SchemaColumn{
+			"orders": {
+				{ID: uuid.New(), SchemaTableID: tableID, ColumnName: "id", DataType: "bigint", IsPrimaryKey: true},
+				{ID: accountColID, SchemaTableID: tableID, ColumnName: "account_ref", DataType: "bigint"},
+				{ID: uuid.New(), SchemaTableID: tableID, ColumnName: "total_amount", DataType: "numeric(12,2)"},
+				{ID: numUsersColID, SchemaTableID: tableID, ColumnName: "num_users", DataType: "integer"},
+				{ID: planTierColID, SchemaTableID: tableID, ColumnName: "plan_tier", DataType: "smallint"},
+				{ID: uuid.New(), SchemaTableID: tableID, ColumnName: "order_year", DataType: "integer"},
+				{ID: uuid.New(), SchemaTableID: tableID, ColumnName: "notes", DataType: "text"},
+			},
+		},
+	}
+
+	measurePurpose := models.PurposeMeasure
+	enumPurpose := models.PurposeEnum
+	columnMetadataRepo := &mockColumnMetadataRepository{
+		metadataByColumnID: map[uuid.UUID]*models.ColumnMetadata{
+			accountColID: {
+				SchemaColumnID: accountColID,
+				Features: models.ColumnMetadataFeatures{
+					IdentifierFeatures: &models.IdentifierFeatures{FKTargetTable: "accounts"},
+				},
+			},
+			numUsersColID: {SchemaColumnID: numUsersColID, Purpose: &measurePurpose},
+			planTierColID: {SchemaColumnID: planTierColID, Purpose: &enumPurpose},
+		},
+	}
+
+	svc := NewOntologyContextService(schemaRepo, columnMetadataRepo, &mockTableMetadataRepository{}, &mockProjectServiceForOntology{}, zap.NewNop())
+
+	result, err := svc.GetTablesContext(ctx, projectID, []string{"orders"})
+	assert.NoError(t, err)
+
+	orders := result.Tables["orders"]
+	classByName := make(map[string]string)
+	for _, col := range orders.Columns {
+		classByName[col.Name] = col.NumericClass
+	}
+
+	assert.Equal(t, models.NumericClassIdentifier, classByName["id"])
+	assert.Equal(t, models.NumericClassIdentifier, classByName["account_ref"], "FK columns are identifiers")
+	assert.Equal(t, models.NumericClassMeasure, classByName["total_amount"], "measure by name")
+	assert.Equal(t, models.NumericClassMeasure, classByName["num_users"], "measure by purpose")
+	assert.Equal(t, models.NumericClassDimension, classByName["plan_tier"])
+	assert.Equal(t, models.NumericClassDimension, classByName["order_year"])
+	assert.Empty(t, classByName["notes"], "non-numeric columns are not classified")
+	assert.Equal(t, []string{"total_amount", "num_users"}, orders.Measures)
+}
+
 func TestGetColumnsContext_FKAndEnumDetails(t *testing.T) {
 	// Test that FK info and enum values are properly conveyed in ColumnDetailInfo
 	ctx := context.Background()
@@ -3,10 +3,12 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
 
 	"github.com/ekaya-inc/ekaya-engine/pkg/database"
@@ -24,6 +26,7 @@ func (s *ontologyDAGService) ComputeChangeSet(ctx context.Context, projectID uui
 	cs := &models.ChangeSet{
 		BuiltAt:          builtAt,
 		AffectedTableIDs: make(map[uuid.UUID]bool),
+		TableNames:       make(map[uuid.UUID]string),
 		UserEditedIDs:    make(map[uuid.UUID]bool),
 	}
 
@@ -67,6 +70,11 @@ func (s *ontologyDAGService) ComputeChangeSet(ctx context.Context, projectID uui
 		return nil, err
 	}
 
+	// Name the tables affected only through their columns
+	if err := s.queryAffectedTableNames(ctx, scope, cs, projectID); err != nil {
+		return nil, err
+	}
+
 	// Query user-edited metadata IDs to skip during re-extraction
 	if err := s.queryUserEditedIDs(ctx, scope, cs, projectID); err != nil {
 		return nil, err
@@ -162,6 +170,56 @@ func (s *ontologyDAGService) CleanupDeletedItems(ctx context.Context, projectID
 	return nil
 }
 
+// MarkStaleRelationships deletes engine-inferred relationships that touch a modified
+// column so relationship discovery re-derives them from the new column definition.
+// The rows are hard-deleted: a soft-deleted pair is read by the upserts as a user
+// deletion and would never be recreated. FK constraints and relationships a user
+// created, edited or approved are left alone.
+func (s *ontologyDAGService) MarkStaleRelationships(ctx context.Context, projectID uuid.UUID, changeSet *models.ChangeSet) error {
+	columnIDs := staleRelationshipColumnIDs(changeSet)
+	if len(columnIDs) == 0 {
+		return nil
+	}
+
+	scope, ok := database.GetTenantScope(ctx)
+	if !ok {
+		return fmt.Errorf("no tenant scope in context")
+	}
+
+	result, err := scope.Conn.Exec(ctx,
+		`DELETE FROM engine_schema_relationships
+		 WHERE project_id = $1 AND deleted_at IS NULL
+		   AND relationship_type = $3
+		   AND source = $4
+		   AND last_edit_source IS NULL
+		   AND is_approved IS NOT TRUE
+		   AND (source_column_id = ANY($2) OR target_column_id = ANY($2))`,
+		projectID, columnIDs, models.RelationshipTypeInferred, models.ProvenanceInferred)
+	if err != nil {
+		return fmt.Errorf("mark stale relationships: %w", err)
+	}
+
+	s.logger.Info("Marked relationships on modified columns stale",
+		zap.String("project_id", projectID.String()),
+		zap.Int("modified_columns", len(columnIDs)),
+		zap.Int64("count", result.RowsAffected()))
+	return nil
+}
+
+// staleRelationshipColumnIDs returns the modified columns whose relationships need
+// re-discovery. Added columns have no relationships yet and deleted columns are
+// handled by CleanupDeletedItems.
+func staleRelationshipColumnIDs(changeSet *models.ChangeSet) []uuid.UUID {
+	if changeSet == nil {
+		return nil
+	}
+	ids := make([]uuid.UUID, 0, len(changeSet.ModifiedColumns))
+	for _, col := range changeSet.ModifiedColumns {
+		ids = append(ids, col.ID)
+	}
+	return ids
+}
+
 // GetLastCompletedDAG returns the most recently completed DAG for a datasource.
 func (s *ontologyDAGService) GetLastCompletedDAG(ctx context.Context, datasourceID uuid.UUID) (*models.OntologyDAG, error) {
 	scope, ok := database.GetTenantScope(ctx)
@@ -190,7 +248,7 @@ func (s *ontologyDAGService) GetLastCompletedDAG(ctx context.Context, datasource
 		&dag.StartedAt, &dag.CompletedAt, &dag.CreatedAt, &dag.UpdatedAt,
 	)
 	if err != nil {
-		if err.Error() == "no rows in result set" {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("query last completed DAG: %w", err)
@@ -416,6 +474,39 @@ func (s *ontologyDAGService) queryModifiedColumns(ctx context.Context, scope *da
 	return rows.Err()
 }
 
+// queryAffectedTableNames fills cs.TableNames for the affected tables, including
+// those that are affected only because columns were added or modified.
+func (s *ontologyDAGService) queryAffectedTableNames(ctx context.Context, scope *database.TenantScope, cs *models.ChangeSet, projectID uuid.UUID) error {
+	if len(cs.AffectedTableIDs) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, 0, len(cs.AffectedTableIDs))
+	for id := range cs.AffectedTableIDs {
+		ids = append(ids, id)
+	}
+
+	query := `
+		SELECT id, table_name
+		FROM engine_schema_tables
+		WHERE project_id = $1 AND id = ANY($2)`
+
+	rows, err := scope.Conn.Query(ctx, query, projectID, ids)
+	if err != nil {
+		return fmt.Errorf("query affected table names: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id uuid.UUID
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return fmt.Errorf("scan affected table name: %w", err)
+		}
+		cs.TableNames[id] = name
+	}
+	return rows.Err()
+}
+
 func (s *ontologyDAGService) queryDeletedColumns(ctx context.Context, scope *database.TenantScope, cs *models.ChangeSet, projectID uuid.UUID, builtAt time.Time) error {
 	query := `
 		SELECT c.id, c.project_id, c.schema_table_id, c.column_name, c.data_type,
@@ -10,6 +10,7 @@ import (
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 
+	"github.com/ekaya-inc/ekaya-engine/pkg/apperrors"
 	"github.com/ekaya-inc/ekaya-engine/pkg/auth"
 	"github.com/ekaya-inc/ekaya-engine/pkg/database"
 	"github.com/ekaya-inc/ekaya-engine/pkg/llm"
@@ -17,6 +18,7 @@ import (
 	"github.com/ekaya-inc/ekaya-engine/pkg/repositories"
 	"github.com/ekaya-inc/ekaya-engine/pkg/retry"
 	"github.com/ekaya-inc/ekaya-engine/pkg/services/dag"
+	"github.com/ekaya-inc/ekaya-engine/pkg/tracing"
 )
 
 // OntologyDAGService orchestrates the ontology extraction workflow as a DAG.
@@ -30,6 +32,10 @@ type OntologyDAGService interface {
 	// GetStatus returns the current DAG status with all node states.
 	GetStatus(ctx context.Context, datasourceID uuid.UUID) (*models.OntologyDAG, error)
 
+	// GetTokenSpend returns the LLM tokens spent by the datasource's current or latest
+	// extraction run against its token budget, or nil if none has run since startup.
+	GetTokenSpend(datasourceID uuid.UUID) *TokenSpend
+
 	// GetOntologyStatus returns the ontology status with change detection.
 	// Indicates whether the ontology exists, when it was last built, and whether
 	// the schema has changed since the last build.
@@ -38,6 +44,11 @@ type OntologyDAGService interface {
 	// Cancel cancels a running DAG.
 	Cancel(ctx context.Context, dagID uuid.UUID) error
 
+	// CancelJob cancels a running extraction job (DAG) owned by the project.
+	// Returns apperrors.ErrNotFound if the job does not belong to the project and
+	// apperrors.ErrConflict if it has already finished.
+	CancelJob(ctx context.Context, projectID, jobID uuid.UUID) (*models.OntologyDAG, error)
+
 	// Delete deletes all ontology data for a project including DAGs, ontologies, entities, and relationships.
 	Delete(ctx context.Context, projectID uuid.UUID) error
 
@@ -62,6 +73,10 @@ type ontologyDAGService struct {
 	finalizationMethods             dag.OntologyFinalizationMethods
 	columnEnrichmentMethods         dag.ColumnEnrichmentMethods
 
+	// Per-run token budgets; nil disables budgets and budget resume
+	tokenBudgets *TokenBudgetTracker
+	projectRepo  repositories.ProjectRepository
+
 	getTenantCtx TenantContextFunc
 	logger       *zap.Logger
 
@@ -138,7 +153,15 @@ func (s *ontologyDAGService) SetColumnEnrichmentMethods(methods dag.ColumnEnrich
 	s.columnEnrichmentMethods = methods
 }
 
+// SetTokenBudgets enables per-run token budgets. Each run's ceiling is read from the
+// project's ontology settings through projectRepo and its spend recorded in tracker.
+func (s *ontologyDAGService) SetTokenBudgets(tracker *TokenBudgetTracker, projectRepo repositories.ProjectRepository) {
+	s.tokenBudgets = tracker
+	s.projectRepo = projectRepo
+}
+
 // Start initiates a new DAG execution or returns an existing active DAG.
+// A DAG stopped by its token budget is resumed from the node it stopped at instead.
 // projectOverview is optional user-provided context about the application domain.
 // If provided, the overview is stored as project knowledge with source='manual'.
 // Knowledge facts have project-lifecycle scope and persist across re-extractions.
@@ -155,6 +178,16 @@ func (s *ontologyDAGService) Start(ctx context.Context, projectID, datasourceID
 		return nil, fmt.Errorf("user authentication required to start extraction: %w", err)
 	}
 
+	// Every extraction step reads only selected tables; with none selected the DAG
+	// would complete with an empty ontology.
+	selectedTables, err := s.schemaRepo.ListTablesByDatasource(ctx, projectID, datasourceID)
+	if err != nil {
+		return nil, fmt.Errorf("list selected tables: %w", err)
+	}
+	if len(selectedTables) == 0 {
+		return nil, apperrors.ErrNoSelectedTables
+	}
+
 	// Store project overview as knowledge if provided.
 	// Uses manual provenance since this is user-provided context.
 	// Knowledge facts have project-lifecycle scope and persist across re-extractions.
@@ -213,6 +246,14 @@ func (s *ontologyDAGService) Start(ctx context.Context, projectID, datasourceID
 		return existing, nil
 	}
 
+	resumed, err := s.resumeBudgetStoppedDAG(ctx, projectID, datasourceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if resumed != nil {
+		return resumed, nil
+	}
+
 	// Determine if this is an incremental extraction
 	var changeSet *models.ChangeSet
 	var isIncremental bool
@@ -261,6 +302,14 @@ func (s *ontologyDAGService) Start(ctx context.Context, projectID, datasourceID
 			zap.String("project_id", projectID.String()))
 	}
 
+	// Questions about tables deselected or removed since they were asked would otherwise
+	// stay pending and hold back the required-question counts.
+	if _, err := cleanupOrphanedQuestions(ctx, s.questionRepo, s.schemaRepo, projectID, false, s.logger); err != nil {
+		s.logger.Warn("Failed to clean up orphaned questions",
+			zap.String("project_id", projectID.String()),
+			zap.Error(err))
+	}
+
 	// Create new DAG
 	now := time.Now()
 	dagRecord := &models.OntologyDAG{
@@ -275,8 +324,35 @@ func (s *ontologyDAGService) Start(ctx context.Context, projectID, datasourceID
 		dagRecord.ChangeSummary = changeSet.ToSummary()
 	}
 
-	if err := s.dagRepo.Create(ctx, dagRecord); err != nil {
-		return nil, fmt.Errorf("create DAG: %w", err)
+	// Record the schema the run extracts from, so the health check can tell when the
+	// schema changed after its stats were gathered
+	if columns, err := s.schemaRepo.ListColumnsByDatasource(ctx, projectID, datasourceID); err != nil {
+		s.logger.Warn("Failed to list columns for the schema fingerprint",
+			zap.String("project_id", projectID.String()),
+			zap.Error(err))
+	} else {
+		fingerprint := schemaFingerprint(selectedTables, columns)
+		dagRecord.SchemaFingerprint = &fingerprint
+	}
+
+	concurrent, err := s.createDAG(ctx, dagRecord)
+	if err != nil {
+		return nil, err
+	}
+	if concurrent != nil {
+		return concurrent, nil
+	}
+
+	// Inferred relationships on columns whose type changed are re-evaluated by
+	// relationship discovery instead of being trusted from the last extraction.
+	// Done only once this request owns the new DAG, so a concurrently started run
+	// does not lose relationships it has already rediscovered.
+	if changeSet != nil && len(changeSet.ModifiedColumns) > 0 {
+		if err := s.MarkStaleRelationships(ctx, projectID, changeSet); err != nil {
+			s.logger.Error("Failed to mark stale relationships",
+				zap.String("project_id", projectID.String()),
+				zap.Error(err))
+		}
 	}
 
 	// Create nodes
@@ -314,6 +390,137 @@ func (s *ontologyDAGService) Start(ctx context.Context, projectID, datasourceID
 	return dagRecord, nil
 }
 
+// resumeBudgetStoppedDAG restarts the datasource's latest DAG if its token budget ran
+// out. The node that was stopped is reset to pending and the DAG runs again from there,
+// skipping the nodes it already completed. The tokens the DAG spent before count against
+// the project's current budget, so it is only resumed once the budget has been raised
+// above them; until then apperrors.ErrTokenBudgetExhausted is returned. Returns nil if
+// the latest DAG was not stopped by its budget.
+func (s *ontologyDAGService) resumeBudgetStoppedDAG(ctx context.Context, projectID, datasourceID, userID uuid.UUID) (*models.OntologyDAG, error) {
+	if s.tokenBudgets == nil {
+		return nil, nil
+	}
+
+	latest, err := s.dagRepo.GetLatestByDatasource(ctx, datasourceID)
+	if err != nil {
+		return nil, fmt.Errorf("get latest DAG: %w", err)
+	}
+	if latest == nil || latest.Status != models.DAGStatusFailed {
+		return nil, nil
+	}
+
+	dagRecord, err := s.dagRepo.GetByIDWithNodes(ctx, latest.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get latest DAG nodes: %w", err)
+	}
+	var stopped *models.DAGNode
+	for i := range dagRecord.Nodes {
+		node := &dagRecord.Nodes[i]
+		if node.Status == models.DAGNodeStatusBudgetExceeded {
+			stopped = node
+			break
+		}
+	}
+	if stopped == nil {
+		return nil, nil
+	}
+
+	if ceiling := s.tokenBudgetCeiling(ctx, projectID); ceiling > 0 && ceiling <= dagRecord.TokensSpent {
+		return nil, fmt.Errorf("%w: extraction has spent %d tokens of its %d token budget; raise the project's ontology token_budget to resume it",
+			apperrors.ErrTokenBudgetExhausted, dagRecord.TokensSpent, ceiling)
+	}
+
+	// An incremental run resumes with the changes it was started for: nothing has
+	// completed since, so they are still measured from the last completed DAG.
+	var changeSet *models.ChangeSet
+	if dagRecord.IsIncremental {
+		lastDAG, err := s.GetLastCompletedDAG(ctx, datasourceID)
+		if err != nil {
+			return nil, fmt.Errorf("get last completed DAG: %w", err)
+		}
+		if lastDAG != nil && lastDAG.CompletedAt != nil {
+			if changeSet, err = s.ComputeChangeSet(ctx, projectID, *lastDAG.CompletedAt); err != nil {
+				return nil, fmt.Errorf("compute change set: %w", err)
+			}
+		}
+	}
+
+	if err := s.dagRepo.UpdateNodeStatus(ctx, stopped.ID, models.DAGNodeStatusPending, nil); err != nil {
+		return nil, fmt.Errorf("reset stopped node: %w", err)
+	}
+	stopped.Status = models.DAGNodeStatusPending
+
+	claimed, err := s.dagRepo.ClaimOwnership(ctx, dagRecord.ID, s.serverInstanceID)
+	if err != nil {
+		return nil, fmt.Errorf("claim ownership: %w", err)
+	}
+	if !claimed {
+		return nil, fmt.Errorf("failed to claim ownership of DAG")
+	}
+
+	currentNode := stopped.NodeName
+	if err := s.dagRepo.UpdateStatus(ctx, dagRecord.ID, models.DAGStatusRunning, &currentNode); err != nil {
+		return nil, fmt.Errorf("update DAG status: %w", err)
+	}
+	dagRecord.Status = models.DAGStatusRunning
+	dagRecord.CurrentNode = &currentNode
+
+	s.logger.Info("Resuming DAG stopped by its token budget",
+		zap.String("dag_id", dagRecord.ID.String()),
+		zap.String("node_name", stopped.NodeName))
+
+	go s.executeDAG(projectID, dagRecord.ID, userID, changeSet)
+
+	return dagRecord, nil
+}
+
+// tokenBudgetCeiling returns the project's per-run token budget, or 0 (unlimited)
+// when it has none or the project cannot be read.
+func (s *ontologyDAGService) tokenBudgetCeiling(ctx context.Context, projectID uuid.UUID) int {
+	if s.projectRepo == nil {
+		return 0
+	}
+	project, err := s.projectRepo.Get(ctx, projectID)
+	if err != nil || project == nil {
+		s.logger.Warn("Running extraction without a token budget: project settings unavailable",
+			zap.String("project_id", projectID.String()),
+			zap.Error(err))
+		return 0
+	}
+	return ontologySettingsFromParameters(project.Parameters).TokenBudget
+}
+
+// createDAG inserts dagRecord. If another request started a DAG for the datasource
+// after Start checked for one, the database rejects the insert, since only one DAG
+// may be active, and the other request's DAG is returned instead.
+func (s *ontologyDAGService) createDAG(ctx context.Context, dagRecord *models.OntologyDAG) (*models.OntologyDAG, error) {
+	err := s.dagRepo.Create(ctx, dagRecord)
+	if err == nil {
+		return nil, nil
+	}
+	if errors.Is(err, apperrors.ErrConflict) {
+		existing, getErr := s.dagRepo.GetActiveByDatasource(ctx, dagRecord.DatasourceID)
+		if getErr != nil {
+			return nil, fmt.Errorf("get concurrently started DAG: %w", getErr)
+		}
+		if existing != nil {
+			s.logger.Info("Returning concurrently started DAG",
+				zap.String("dag_id", existing.ID.String()),
+				zap.String("status", string(existing.Status)))
+			return existing, nil
+		}
+	}
+	return nil, fmt.Errorf("create DAG: %w", err)
+}
+
+// GetTokenSpend returns the token spend of the datasource's current or latest run.
+func (s *ontologyDAGService) GetTokenSpend(datasourceID uuid.UUID) *TokenSpend {
+	if s.tokenBudgets == nil {
+		return nil
+	}
+	return s.tokenBudgets.Spend(datasourceID)
+}
+
 // GetStatus returns the current DAG status with all node states.
 func (s *ontologyDAGService) GetStatus(ctx context.Context, datasourceID uuid.UUID) (*models.OntologyDAG, error) {
 	dagRecord, err := s.dagRepo.GetLatestByDatasource(ctx, datasourceID)
@@ -350,20 +557,30 @@ func (s *ontologyDAGService) Cancel(ctx context.Context, dagID uuid.UUID) error
 		return fmt.Errorf("get nodes: %w", err)
 	}
 
-	// Mark all non-completed nodes as skipped
+	// Mark the running node as cancelled and pending nodes as skipped.
+	// Completed and failed nodes keep their status; work they persisted is preserved.
 	for _, node := range nodes {
-		if node.Status != models.DAGNodeStatusCompleted && node.Status != models.DAGNodeStatusFailed {
-			if err := s.dagRepo.UpdateNodeStatus(ctx, node.ID, models.DAGNodeStatusSkipped, nil); err != nil {
-				s.logger.Error("Failed to mark node as skipped",
-					zap.String("node_id", node.ID.String()),
-					zap.String("node_name", node.NodeName),
-					zap.Error(err))
-				// Continue with other nodes even if one fails
-			} else {
-				s.logger.Debug("Marked node as skipped",
-					zap.String("node_id", node.ID.String()),
-					zap.String("node_name", node.NodeName))
-			}
+		var status models.DAGNodeStatus
+		switch node.Status {
+		case models.DAGNodeStatusRunning:
+			status = models.DAGNodeStatusCancelled
+		case models.DAGNodeStatusPending:
+			status = models.DAGNodeStatusSkipped
+		default:
+			continue
+		}
+		if err := s.dagRepo.UpdateNodeStatus(ctx, node.ID, status, nil); err != nil {
+			s.logger.Error("Failed to update node status on cancel",
+				zap.String("node_id", node.ID.String()),
+				zap.String("node_name", node.NodeName),
+				zap.String("status", string(status)),
+				zap.Error(err))
+			// Continue with other nodes even if one fails
+		} else {
+			s.logger.Debug("Updated node status on cancel",
+				zap.String("node_id", node.ID.String()),
+				zap.String("node_name", node.NodeName),
+				zap.String("status", string(status)))
 		}
 	}
 
@@ -371,6 +588,28 @@ func (s *ontologyDAGService) Cancel(ctx context.Context, dagID uuid.UUID) error
 	return s.dagRepo.UpdateStatus(ctx, dagID, models.DAGStatusCancelled, nil)
 }
 
+// CancelJob cancels a running DAG after checking that it belongs to the project.
+// The executor's context is cancelled, which stops further LLM and datasource calls
+// at the next call boundary.
+func (s *ontologyDAGService) CancelJob(ctx context.Context, projectID, jobID uuid.UUID) (*models.OntologyDAG, error) {
+	dagRecord, err := s.dagRepo.GetByID(ctx, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("get DAG: %w", err)
+	}
+	if dagRecord == nil || dagRecord.ProjectID != projectID {
+		return nil, apperrors.ErrNotFound
+	}
+	if dagRecord.Status.IsTerminal() {
+		return nil, fmt.Errorf("job is already %s: %w", dagRecord.Status, apperrors.ErrConflict)
+	}
+
+	if err := s.Cancel(ctx, jobID); err != nil {
+		return nil, err
+	}
+	dagRecord.Status = models.DAGStatusCancelled
+	return dagRecord, nil
+}
+
 // Delete deletes all ontology data for a project including DAGs, ontologies, entities, and relationships.
 // This is a destructive operation that removes all extracted knowledge.
 //
@@ -508,6 +747,10 @@ func (s *ontologyDAGService) executeDAG(projectID, dagID, userID uuid.UUID, chan
 	ctx, cancel := context.WithCancel(context.Background())
 	s.activeDAGs.Store(dagID, cancel)
 
+	// Root span for the run; node, LLM and discoverer spans nest beneath it
+	ctx, span := tracing.Start(ctx, "ontology.extraction", tracing.AttrProjectID.String(projectID.String()))
+	defer span.End()
+
 	// Set up defer FIRST to ensure cleanup happens even if panic occurs
 	defer func() {
 		// Recover from panics and mark DAG as failed
@@ -558,6 +801,14 @@ func (s *ontologyDAGService) executeDAG(projectID, dagID, userID uuid.UUID, chan
 		return
 	}
 
+	// Every LLM call of the run is checked against and charged to the run's budget,
+	// which starts from what the DAG spent before it was resumed
+	if s.tokenBudgets != nil {
+		budget := s.tokenBudgets.Start(dagRecord.DatasourceID, s.tokenBudgetCeiling(tenantCtx, projectID), dagRecord.TokensSpent)
+		defer s.tokenBudgets.Finish(dagRecord.DatasourceID)
+		tenantCtx = llm.WithTokenBudget(tenantCtx, budget)
+	}
+
 	// Execute each node in sequence
 	for _, node := range dagRecord.Nodes {
 		// Check for cancellation
@@ -589,6 +840,13 @@ func (s *ontologyDAGService) executeDAG(projectID, dagID, userID uuid.UUID, chan
 				zap.String("node_name", node.NodeName),
 				zap.Error(err))
 
+			s.recordTokensSpent(tenantCtx, dagID)
+			if errors.Is(err, llm.ErrTokenBudgetExceeded) {
+				s.failDAG(projectID, dagID, models.DAGNodeStatusBudgetExceeded,
+					err.Error()+"; raise the project's ontology token_budget and start extraction again to resume from this step")
+				return
+			}
+
 			// Let markDAGFailed handle marking both the node and DAG as failed
 			s.markDAGFailed(projectID, dagID, err.Error())
 			return
@@ -596,12 +854,16 @@ func (s *ontologyDAGService) executeDAG(projectID, dagID, userID uuid.UUID, chan
 	}
 
 	// All nodes completed successfully
+	s.recordTokensSpent(tenantCtx, dagID)
 	s.markDAGCompleted(projectID, dagRecord.DatasourceID, dagID)
 }
 
 // executeNode runs a single node with retry logic.
 // changeSet is nil for full extraction, non-nil for incremental extraction.
-func (s *ontologyDAGService) executeNode(ctx context.Context, dagRecord *models.OntologyDAG, node *models.DAGNode, changeSet *models.ChangeSet) error {
+func (s *ontologyDAGService) executeNode(ctx context.Context, dagRecord *models.OntologyDAG, node *models.DAGNode, changeSet *models.ChangeSet) (err error) {
+	ctx, span := tracing.Start(ctx, "ontology.node", tracing.AttrDAGNode.String(node.NodeName))
+	defer func() { tracing.End(span, err) }()
+
 	s.logger.Info("Executing node",
 		zap.String("dag_id", dagRecord.ID.String()),
 		zap.String("node_name", node.NodeName))
@@ -626,7 +888,13 @@ func (s *ontologyDAGService) executeNode(ctx context.Context, dagRecord *models.
 	// Execute with retry
 	retryCfg := retry.DefaultConfig()
 	err = retry.DoIfRetryable(ctx, retryCfg, func() error {
-		return executor.Execute(ctx, dagRecord, changeSet)
+		execErr := executor.Execute(ctx, dagRecord, changeSet)
+		// Nodes whose workers log and skip failed LLM calls would otherwise complete
+		// with the calls the budget refused missing; the node is re-run on resume.
+		if budgetErr := llm.TokenBudgetFrom(ctx).Err(); budgetErr != nil {
+			return budgetErr
+		}
+		return execErr
 	})
 
 	if err != nil {
@@ -763,9 +1031,28 @@ func (s *ontologyDAGService) releaseOwnership(projectID, dagID uuid.UUID) {
 	}
 }
 
+// recordTokensSpent stores the spend of the run's token budget on the DAG, so a
+// resumed run continues from it. Runs without a budget record nothing.
+func (s *ontologyDAGService) recordTokensSpent(ctx context.Context, dagID uuid.UUID) {
+	budget := llm.TokenBudgetFrom(ctx)
+	if budget == nil {
+		return
+	}
+	if err := s.dagRepo.UpdateTokensSpent(ctx, dagID, budget.Spent()); err != nil {
+		s.logger.Error("Failed to record DAG token spend",
+			zap.String("dag_id", dagID.String()),
+			zap.Error(err))
+	}
+}
+
 // markDAGFailed marks the DAG as failed and stores the error message on the appropriate node.
 // If a current node is set, the error is stored there; otherwise it's stored on the first node.
 func (s *ontologyDAGService) markDAGFailed(projectID, dagID uuid.UUID, errMsg string) {
+	s.failDAG(projectID, dagID, models.DAGNodeStatusFailed, errMsg)
+}
+
+// failDAG marks the DAG as failed and the node it failed at with nodeStatus and errMsg.
+func (s *ontologyDAGService) failDAG(projectID, dagID uuid.UUID, nodeStatus models.DAGNodeStatus, errMsg string) {
 	ctx, cleanup, err := s.getTenantCtx(context.Background(), projectID)
 	if err != nil {
 		s.logger.Error("Failed to get tenant context for marking DAG failed", zap.Error(err))
@@ -817,7 +1104,7 @@ func (s *ontologyDAGService) markDAGFailed(projectID, dagID uuid.UUID, errMsg st
 
 	// Mark the target node as failed with the error message
 	if targetNode != nil {
-		if err := s.dagRepo.UpdateNodeStatus(ctx, targetNode.ID, models.DAGNodeStatusFailed, &errMsg); err != nil {
+		if err := s.dagRepo.UpdateNodeStatus(ctx, targetNode.ID, nodeStatus, &errMsg); err != nil {
 			s.logger.Error("Failed to update node status with error",
 				zap.String("node_id", targetNode.ID.String()),
 				zap.Error(err))
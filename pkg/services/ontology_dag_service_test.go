@@ -10,9 +10,12 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
+	"github.com/ekaya-inc/ekaya-engine/pkg/apperrors"
 	"github.com/ekaya-inc/ekaya-engine/pkg/auth"
+	"github.com/ekaya-inc/ekaya-engine/pkg/llm"
 	"github.com/ekaya-inc/ekaya-engine/pkg/models"
 	"github.com/ekaya-inc/ekaya-engine/pkg/services/dag"
 )
@@ -135,7 +138,7 @@ func (t *testColumnFeatureExtraction) ExtractColumnFeatures(_ context.Context, _
 
 type testTableFeatureExtraction struct{}
 
-func (t *testTableFeatureExtraction) ExtractTableFeatures(_ context.Context, _, _ uuid.UUID, _ dag.ProgressCallback) (int, error) {
+func (t *testTableFeatureExtraction) ExtractTableFeatures(_ context.Context, _, _ uuid.UUID, _ []string, _ dag.ProgressCallback) (int, error) {
 	return 0, nil
 }
 
@@ -158,8 +161,12 @@ type mockDAGRepository struct {
 	getNodesByDAGFunc         func(ctx context.Context, dagID uuid.UUID) ([]models.DAGNode, error)
 	updateNodeStatusFunc      func(ctx context.Context, nodeID uuid.UUID, status models.DAGNodeStatus, errorMessage *string) error
 	updateStatusFunc          func(ctx context.Context, dagID uuid.UUID, status models.DAGStatus, currentNode *string) error
+	getByIDFunc               func(ctx context.Context, id uuid.UUID) (*models.OntologyDAG, error)
 	getByIDWithNodesFunc      func(ctx context.Context, id uuid.UUID) (*models.OntologyDAG, error)
 	getActiveByDatasourceFunc func(ctx context.Context, datasourceID uuid.UUID) (*models.OntologyDAG, error)
+	getLatestByDatasourceFunc func(ctx context.Context, datasourceID uuid.UUID) (*models.OntologyDAG, error)
+	createFunc                func(ctx context.Context, dag *models.OntologyDAG) error
+	updateTokensSpentFunc     func(ctx context.Context, id uuid.UUID, tokensSpent int) error
 }
 
 func (m *mockDAGRepository) GetNodesByDAG(ctx context.Context, dagID uuid.UUID) ([]models.DAGNode, error) {
@@ -184,8 +191,16 @@ func (m *mockDAGRepository) UpdateStatus(ctx context.Context, dagID uuid.UUID, s
 }
 
 // Stub methods to satisfy the interface
-func (m *mockDAGRepository) Create(ctx context.Context, dag *models.OntologyDAG) error { return nil }
+func (m *mockDAGRepository) Create(ctx context.Context, dag *models.OntologyDAG) error {
+	if m.createFunc != nil {
+		return m.createFunc(ctx, dag)
+	}
+	return nil
+}
 func (m *mockDAGRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.OntologyDAG, error) {
+	if m.getByIDFunc != nil {
+		return m.getByIDFunc(ctx, id)
+	}
 	return nil, nil
 }
 func (m *mockDAGRepository) GetByIDWithNodes(ctx context.Context, id uuid.UUID) (*models.OntologyDAG, error) {
@@ -195,6 +210,9 @@ func (m *mockDAGRepository) GetByIDWithNodes(ctx context.Context, id uuid.UUID)
 	return nil, nil
 }
 func (m *mockDAGRepository) GetLatestByDatasource(ctx context.Context, datasourceID uuid.UUID) (*models.OntologyDAG, error) {
+	if m.getLatestByDatasourceFunc != nil {
+		return m.getLatestByDatasourceFunc(ctx, datasourceID)
+	}
 	return nil, nil
 }
 func (m *mockDAGRepository) GetLatestByProject(ctx context.Context, projectID uuid.UUID) (*models.OntologyDAG, error) {
@@ -210,7 +228,13 @@ func (m *mockDAGRepository) GetActiveByProject(ctx context.Context, projectID uu
 	return nil, nil
 }
 func (m *mockDAGRepository) Update(ctx context.Context, dag *models.OntologyDAG) error { return nil }
-func (m *mockDAGRepository) Delete(ctx context.Context, id uuid.UUID) error            { return nil }
+func (m *mockDAGRepository) UpdateTokensSpent(ctx context.Context, id uuid.UUID, tokensSpent int) error {
+	if m.updateTokensSpentFunc != nil {
+		return m.updateTokensSpentFunc(ctx, id, tokensSpent)
+	}
+	return nil
+}
+func (m *mockDAGRepository) Delete(ctx context.Context, id uuid.UUID) error { return nil }
 func (m *mockDAGRepository) DeleteByProject(ctx context.Context, projectID uuid.UUID) error {
 	return nil
 }
@@ -344,6 +368,7 @@ func TestStart_StoresProjectOverview(t *testing.T) {
 	service := &ontologyDAGService{
 		dagRepo:       mockDAGRepo,
 		knowledgeRepo: mockKnowledgeRepo,
+		schemaRepo:    &mockSchemaRepository{tables: []*models.SchemaTable{{TableName: "customers"}}},
 		logger:        logger,
 		// Provide getTenantCtx to prevent panic in background goroutine
 		getTenantCtx: func(ctx context.Context, _ uuid.UUID) (context.Context, func(), error) {
@@ -432,6 +457,7 @@ func TestStart_UpdatesExistingProjectOverview(t *testing.T) {
 	service := &ontologyDAGService{
 		dagRepo:       mockDAGRepo,
 		knowledgeRepo: mockKnowledgeRepo,
+		schemaRepo:    &mockSchemaRepository{tables: []*models.SchemaTable{{TableName: "customers"}}},
 		logger:        logger,
 		getTenantCtx: func(ctx context.Context, _ uuid.UUID) (context.Context, func(), error) {
 			return ctx, func() {}, nil
@@ -488,6 +514,7 @@ func TestStart_SkipsOverviewStorageWhenEmpty(t *testing.T) {
 	service := &ontologyDAGService{
 		dagRepo:       mockDAGRepo,
 		knowledgeRepo: mockKnowledgeRepo,
+		schemaRepo:    &mockSchemaRepository{tables: []*models.SchemaTable{{TableName: "customers"}}},
 		logger:        logger,
 		// Provide getTenantCtx to prevent panic in background goroutine
 		getTenantCtx: func(ctx context.Context, _ uuid.UUID) (context.Context, func(), error) {
@@ -505,6 +532,54 @@ func TestStart_SkipsOverviewStorageWhenEmpty(t *testing.T) {
 	assert.False(t, createCalled, "Create should NOT be called when overview is empty")
 }
 
+// TestCreateDAG_ReturnsConcurrentlyStartedDAG verifies that when another request creates
+// the datasource's active DAG between Start's existence check and the insert, the DAG
+// that won is returned instead of an error or a second active DAG.
+func TestCreateDAG_ReturnsConcurrentlyStartedDAG(t *testing.T) {
+	datasourceID := uuid.New()
+	concurrent := &models.OntologyDAG{ID: uuid.New(), DatasourceID: datasourceID, Status: models.DAGStatusRunning}
+
+	var lookedUp uuid.UUID
+	service := &ontologyDAGService{
+		dagRepo: &mockDAGRepository{
+			createFunc: func(_ context.Context, _ *models.OntologyDAG) error {
+				return apperrors.ErrConflict
+			},
+			getActiveByDatasourceFunc: func(_ context.Context, id uuid.UUID) (*models.OntologyDAG, error) {
+				lookedUp = id
+				return concurrent, nil
+			},
+		},
+		logger: zap.NewNop(),
+	}
+
+	existing, err := service.createDAG(context.Background(), &models.OntologyDAG{ID: uuid.New(), DatasourceID: datasourceID})
+
+	require.NoError(t, err)
+	require.NotNil(t, existing)
+	assert.Equal(t, concurrent.ID, existing.ID)
+	assert.Equal(t, datasourceID, lookedUp)
+}
+
+func TestCreateDAG_CreatesWhenNoneActive(t *testing.T) {
+	created := false
+	service := &ontologyDAGService{
+		dagRepo: &mockDAGRepository{
+			createFunc: func(_ context.Context, _ *models.OntologyDAG) error {
+				created = true
+				return nil
+			},
+		},
+		logger: zap.NewNop(),
+	}
+
+	existing, err := service.createDAG(context.Background(), &models.OntologyDAG{ID: uuid.New(), DatasourceID: uuid.New()})
+
+	require.NoError(t, err)
+	assert.Nil(t, existing)
+	assert.True(t, created)
+}
+
 // TestStart_ContinuesOnOverviewStorageError verifies that when Upsert fails,
 // the extraction still continues (non-fatal error handling).
 func TestStart_ContinuesOnOverviewStorageError(t *testing.T) {
@@ -545,6 +620,7 @@ func TestStart_ContinuesOnOverviewStorageError(t *testing.T) {
 	service := &ontologyDAGService{
 		dagRepo:       mockDAGRepo,
 		knowledgeRepo: mockKnowledgeRepo,
+		schemaRepo:    &mockSchemaRepository{tables: []*models.SchemaTable{{TableName: "customers"}}},
 		logger:        logger,
 		// Provide getTenantCtx to prevent panic in background goroutine
 		getTenantCtx: func(ctx context.Context, _ uuid.UUID) (context.Context, func(), error) {
@@ -565,9 +641,10 @@ func TestStart_ContinuesOnOverviewStorageError(t *testing.T) {
 	assert.True(t, getActiveByDatasourceCalled, "Extraction should continue after Create failure")
 }
 
-// TestCancel_MarksNonCompletedNodesAsSkipped verifies that canceling a DAG
-// marks all running and pending nodes as skipped.
-func TestCancel_MarksNonCompletedNodesAsSkipped(t *testing.T) {
+// TestCancel_MarksRunningNodeCancelledAndPendingSkipped verifies that canceling a DAG
+// marks the running node as cancelled, pending nodes as skipped, and leaves
+// completed nodes untouched.
+func TestCancel_MarksRunningNodeCancelledAndPendingSkipped(t *testing.T) {
 	dagID := uuid.New()
 	ctx := context.Background()
 
@@ -579,8 +656,8 @@ func TestCancel_MarksNonCompletedNodesAsSkipped(t *testing.T) {
 		{ID: uuid.New(), DAGID: dagID, NodeName: "RelationshipEnrichment", Status: models.DAGNodeStatusPending},
 	}
 
-	// Track which nodes were marked as skipped
-	skippedNodes := make(map[uuid.UUID]bool)
+	// Track the status each node was moved to
+	updated := make(map[uuid.UUID]models.DAGNodeStatus)
 
 	mockRepo := &mockDAGRepository{
 		getNodesByDAGFunc: func(_ context.Context, id uuid.UUID) ([]models.DAGNode, error) {
@@ -588,8 +665,7 @@ func TestCancel_MarksNonCompletedNodesAsSkipped(t *testing.T) {
 			return nodes, nil
 		},
 		updateNodeStatusFunc: func(_ context.Context, nodeID uuid.UUID, status models.DAGNodeStatus, _ *string) error {
-			assert.Equal(t, models.DAGNodeStatusSkipped, status)
-			skippedNodes[nodeID] = true
+			updated[nodeID] = status
 			return nil
 		},
 		updateStatusFunc: func(_ context.Context, id uuid.UUID, status models.DAGStatus, _ *string) error {
@@ -610,15 +686,88 @@ func TestCancel_MarksNonCompletedNodesAsSkipped(t *testing.T) {
 	err := service.Cancel(ctx, dagID)
 	assert.NoError(t, err)
 
-	// Verify only non-completed nodes were marked as skipped
-	// Node 0 (completed) should NOT be skipped
-	assert.False(t, skippedNodes[nodes[0].ID], "Completed node should not be skipped")
-	// Node 1 (running) should be skipped
-	assert.True(t, skippedNodes[nodes[1].ID], "Running node should be skipped")
-	// Node 2 (pending) should be skipped
-	assert.True(t, skippedNodes[nodes[2].ID], "Pending node should be skipped")
-	// Node 3 (pending) should be skipped
-	assert.True(t, skippedNodes[nodes[3].ID], "Pending node should be skipped")
+	_, touched := updated[nodes[0].ID]
+	assert.False(t, touched, "Completed node should keep its status")
+	assert.Equal(t, models.DAGNodeStatusCancelled, updated[nodes[1].ID], "Running node should be cancelled")
+	assert.Equal(t, models.DAGNodeStatusSkipped, updated[nodes[2].ID], "Pending node should be skipped")
+	assert.Equal(t, models.DAGNodeStatusSkipped, updated[nodes[3].ID], "Pending node should be skipped")
+}
+
+// TestCancel_StopsRunningExecution verifies that Cancel cancels the context of a
+// DAG executing in this process.
+func TestCancel_StopsRunningExecution(t *testing.T) {
+	dagID := uuid.New()
+	execCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	service := &ontologyDAGService{
+		dagRepo: &mockDAGRepository{},
+		logger:  zap.NewNop(),
+	}
+	service.activeDAGs.Store(dagID, cancel)
+
+	require.NoError(t, service.Cancel(context.Background(), dagID))
+	assert.ErrorIs(t, execCtx.Err(), context.Canceled)
+}
+
+func TestCancelJob(t *testing.T) {
+	projectID := uuid.New()
+	jobID := uuid.New()
+
+	newService := func(dag *models.OntologyDAG, cancelled *bool) *ontologyDAGService {
+		return &ontologyDAGService{
+			dagRepo: &mockDAGRepository{
+				getByIDFunc: func(_ context.Context, id uuid.UUID) (*models.OntologyDAG, error) {
+					assert.Equal(t, jobID, id)
+					return dag, nil
+				},
+				updateStatusFunc: func(_ context.Context, _ uuid.UUID, status models.DAGStatus, _ *string) error {
+					if status == models.DAGStatusCancelled {
+						*cancelled = true
+					}
+					return nil
+				},
+			},
+			logger: zap.NewNop(),
+		}
+	}
+
+	t.Run("running job is cancelled", func(t *testing.T) {
+		var cancelled bool
+		service := newService(&models.OntologyDAG{ID: jobID, ProjectID: projectID, Status: models.DAGStatusRunning}, &cancelled)
+
+		dag, err := service.CancelJob(context.Background(), projectID, jobID)
+		require.NoError(t, err)
+		assert.Equal(t, models.DAGStatusCancelled, dag.Status)
+		assert.True(t, cancelled)
+	})
+
+	t.Run("unknown job is not found", func(t *testing.T) {
+		var cancelled bool
+		service := newService(nil, &cancelled)
+
+		_, err := service.CancelJob(context.Background(), projectID, jobID)
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		assert.False(t, cancelled)
+	})
+
+	t.Run("job in another project is not found", func(t *testing.T) {
+		var cancelled bool
+		service := newService(&models.OntologyDAG{ID: jobID, ProjectID: uuid.New(), Status: models.DAGStatusRunning}, &cancelled)
+
+		_, err := service.CancelJob(context.Background(), projectID, jobID)
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		assert.False(t, cancelled)
+	})
+
+	t.Run("finished job conflicts", func(t *testing.T) {
+		var cancelled bool
+		service := newService(&models.OntologyDAG{ID: jobID, ProjectID: projectID, Status: models.DAGStatusCompleted}, &cancelled)
+
+		_, err := service.CancelJob(context.Background(), projectID, jobID)
+		assert.ErrorIs(t, err, apperrors.ErrConflict)
+		assert.False(t, cancelled)
+	})
 }
 
 // ============================================================================
@@ -1087,6 +1236,35 @@ func TestStart_RequiresAuthenticatedUser(t *testing.T) {
 	assert.Contains(t, err.Error(), "user authentication required")
 }
 
+// TestStart_RejectsEmptyTableSelection verifies that Start fails before storing the
+// overview or creating a DAG when no tables are selected.
+func TestStart_RejectsEmptyTableSelection(t *testing.T) {
+	mockKnowledgeRepo := &mockKnowledgeRepository{
+		createFunc: func(ctx context.Context, fact *models.KnowledgeFact) error {
+			t.Error("project overview should not be stored when no tables are selected")
+			return nil
+		},
+	}
+	mockDAGRepo := &mockDAGRepository{
+		getActiveByDatasourceFunc: func(_ context.Context, _ uuid.UUID) (*models.OntologyDAG, error) {
+			t.Error("no DAG lookup expected when no tables are selected")
+			return nil, nil
+		},
+	}
+	service := &ontologyDAGService{
+		dagRepo:       mockDAGRepo,
+		knowledgeRepo: mockKnowledgeRepo,
+		schemaRepo:    &mockSchemaRepository{},
+		logger:        zap.NewNop(),
+	}
+
+	dag, err := service.Start(createAuthenticatedContext(uuid.New()), uuid.New(), uuid.New(), "An overview")
+
+	assert.Nil(t, dag)
+	assert.ErrorIs(t, err, apperrors.ErrNoSelectedTables)
+	assert.Contains(t, err.Error(), "no tables selected for extraction")
+}
+
 // TestExecuteDAG_SetsInferenceProvenance verifies that executeDAG properly sets
 // inference provenance on the tenant context with the triggering user's ID.
 func TestExecuteDAG_SetsInferenceProvenance(t *testing.T) {
@@ -1147,3 +1325,176 @@ func TestExecuteDAG_SetsInferenceProvenance(t *testing.T) {
 	assert.Equal(t, models.SourceInferred, capturedProvenance.Source, "Source should be inferred")
 	assert.Equal(t, userID, capturedProvenance.UserID, "UserID should match the triggering user")
 }
+
+func TestResumeBudgetStoppedDAG_RestartsFromStoppedNode(t *testing.T) {
+	projectID := uuid.New()
+	datasourceID := uuid.New()
+	dagID := uuid.New()
+	stoppedNodeID := uuid.New()
+	budgetMsg := "token_budget 98000 of 100000 tokens spent, next call needs about 4000: token budget exceeded"
+
+	latest := &models.OntologyDAG{
+		ID:           dagID,
+		ProjectID:    projectID,
+		DatasourceID: datasourceID,
+		Status:       models.DAGStatusFailed,
+		TokensSpent:  98000,
+		Nodes: []models.DAGNode{
+			{ID: uuid.New(), NodeName: string(models.DAGNodeKnowledgeSeeding), Status: models.DAGNodeStatusCompleted},
+			{ID: stoppedNodeID, NodeName: string(models.DAGNodeColumnFeatureExtraction), Status: models.DAGNodeStatusBudgetExceeded, ErrorMessage: &budgetMsg},
+			{ID: uuid.New(), NodeName: string(models.DAGNodeFKDiscovery), Status: models.DAGNodeStatusPending},
+		},
+	}
+
+	var mu sync.Mutex
+	nodeStatuses := map[uuid.UUID]models.DAGNodeStatus{}
+	var dagStatuses []models.DAGStatus
+	mockRepo := &mockDAGRepository{
+		getLatestByDatasourceFunc: func(ctx context.Context, dsID uuid.UUID) (*models.OntologyDAG, error) {
+			return latest, nil
+		},
+		getByIDWithNodesFunc: func(ctx context.Context, id uuid.UUID) (*models.OntologyDAG, error) {
+			return latest, nil
+		},
+		updateNodeStatusFunc: func(ctx context.Context, nodeID uuid.UUID, status models.DAGNodeStatus, errorMessage *string) error {
+			mu.Lock()
+			defer mu.Unlock()
+			if _, seen := nodeStatuses[nodeID]; !seen {
+				nodeStatuses[nodeID] = status
+			}
+			return nil
+		},
+		updateStatusFunc: func(ctx context.Context, id uuid.UUID, status models.DAGStatus, currentNode *string) error {
+			mu.Lock()
+			defer mu.Unlock()
+			dagStatuses = append(dagStatuses, status)
+			return nil
+		},
+	}
+
+	service := &ontologyDAGService{
+		dagRepo:      mockRepo,
+		tokenBudgets: NewTokenBudgetTracker(),
+		// The budget has been raised above what the DAG spent
+		projectRepo: &mockProjectRepoForDefaultDatasource{project: &models.Project{
+			Parameters: map[string]interface{}{"ontology": map[string]interface{}{"token_budget": float64(150000)}},
+		}},
+		logger:           zap.NewNop(),
+		serverInstanceID: uuid.New(),
+		// The resumed run stops at once; only the resume itself is under test
+		getTenantCtx: func(ctx context.Context, pid uuid.UUID) (context.Context, func(), error) {
+			return nil, nil, fmt.Errorf("no tenant in test")
+		},
+	}
+
+	resumed, err := service.resumeBudgetStoppedDAG(context.Background(), projectID, datasourceID, uuid.New())
+	require.NoError(t, err)
+	require.NotNil(t, resumed)
+	assert.Equal(t, dagID, resumed.ID, "the stopped DAG is resumed rather than replaced")
+	assert.Equal(t, models.DAGStatusRunning, resumed.Status)
+	require.NotNil(t, resumed.CurrentNode)
+	assert.Equal(t, string(models.DAGNodeColumnFeatureExtraction), *resumed.CurrentNode)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, models.DAGNodeStatusPending, nodeStatuses[stoppedNodeID], "the stopped node is reset to pending")
+	require.NotEmpty(t, dagStatuses)
+	assert.Equal(t, models.DAGStatusRunning, dagStatuses[0])
+}
+
+func TestResumeBudgetStoppedDAG_RefusesUntilBudgetIsRaised(t *testing.T) {
+	stopped := &models.OntologyDAG{ID: uuid.New(), Status: models.DAGStatusFailed, TokensSpent: 100000, Nodes: []models.DAGNode{
+		{ID: uuid.New(), NodeName: string(models.DAGNodeColumnFeatureExtraction), Status: models.DAGNodeStatusBudgetExceeded},
+	}}
+	mockRepo := &mockDAGRepository{
+		getLatestByDatasourceFunc: func(ctx context.Context, dsID uuid.UUID) (*models.OntologyDAG, error) {
+			return stopped, nil
+		},
+		getByIDWithNodesFunc: func(ctx context.Context, id uuid.UUID) (*models.OntologyDAG, error) {
+			return stopped, nil
+		},
+		updateNodeStatusFunc: func(ctx context.Context, nodeID uuid.UUID, status models.DAGNodeStatus, errorMessage *string) error {
+			t.Errorf("a DAG whose budget is still spent must not be resumed")
+			return nil
+		},
+	}
+
+	service := &ontologyDAGService{
+		dagRepo:      mockRepo,
+		tokenBudgets: NewTokenBudgetTracker(),
+		projectRepo: &mockProjectRepoForDefaultDatasource{project: &models.Project{
+			Parameters: map[string]interface{}{"ontology": map[string]interface{}{"token_budget": float64(100000)}},
+		}},
+		logger: zap.NewNop(),
+	}
+
+	resumed, err := service.resumeBudgetStoppedDAG(context.Background(), uuid.New(), uuid.New(), uuid.New())
+	require.ErrorIs(t, err, apperrors.ErrTokenBudgetExhausted)
+	assert.Nil(t, resumed)
+}
+
+func TestFailDAG_RecordsBudgetStopOnCurrentNode(t *testing.T) {
+	dagID := uuid.New()
+	stoppedNodeID := uuid.New()
+	currentNode := string(models.DAGNodeColumnFeatureExtraction)
+
+	var nodeStatus models.DAGNodeStatus
+	var recordedSpend int
+	mockRepo := &mockDAGRepository{
+		getByIDWithNodesFunc: func(ctx context.Context, id uuid.UUID) (*models.OntologyDAG, error) {
+			return &models.OntologyDAG{ID: dagID, Status: models.DAGStatusRunning, CurrentNode: &currentNode, Nodes: []models.DAGNode{
+				{ID: uuid.New(), NodeName: string(models.DAGNodeKnowledgeSeeding), Status: models.DAGNodeStatusCompleted},
+				{ID: stoppedNodeID, NodeName: currentNode, Status: models.DAGNodeStatusRunning},
+			}}, nil
+		},
+		updateNodeStatusFunc: func(ctx context.Context, nodeID uuid.UUID, status models.DAGNodeStatus, errorMessage *string) error {
+			assert.Equal(t, stoppedNodeID, nodeID)
+			nodeStatus = status
+			return nil
+		},
+		updateTokensSpentFunc: func(ctx context.Context, id uuid.UUID, tokensSpent int) error {
+			recordedSpend = tokensSpent
+			return nil
+		},
+	}
+
+	service := &ontologyDAGService{
+		dagRepo: mockRepo,
+		logger:  zap.NewNop(),
+		getTenantCtx: func(ctx context.Context, pid uuid.UUID) (context.Context, func(), error) {
+			return ctx, func() {}, nil
+		},
+	}
+
+	budget := llm.NewTokenBudget(1000)
+	budget.Add(0, 950)
+	service.recordTokensSpent(llm.WithTokenBudget(context.Background(), budget), dagID)
+	service.failDAG(uuid.New(), dagID, models.DAGNodeStatusBudgetExceeded, "token budget exceeded")
+
+	assert.Equal(t, 950, recordedSpend, "the spend is kept on the DAG for a resumed run")
+	assert.Equal(t, models.DAGNodeStatusBudgetExceeded, nodeStatus, "the stop is persisted as the node status")
+}
+
+func TestResumeBudgetStoppedDAG_IgnoresOtherFailures(t *testing.T) {
+	otherMsg := "LLM request failed: model not found"
+	mockRepo := &mockDAGRepository{
+		getLatestByDatasourceFunc: func(ctx context.Context, dsID uuid.UUID) (*models.OntologyDAG, error) {
+			return &models.OntologyDAG{ID: uuid.New(), Status: models.DAGStatusFailed}, nil
+		},
+		getByIDWithNodesFunc: func(ctx context.Context, id uuid.UUID) (*models.OntologyDAG, error) {
+			return &models.OntologyDAG{ID: id, Status: models.DAGStatusFailed, Nodes: []models.DAGNode{
+				{ID: uuid.New(), NodeName: string(models.DAGNodeKnowledgeSeeding), Status: models.DAGNodeStatusFailed, ErrorMessage: &otherMsg},
+			}}, nil
+		},
+		updateStatusFunc: func(ctx context.Context, id uuid.UUID, status models.DAGStatus, currentNode *string) error {
+			t.Errorf("a DAG that failed for another reason must not be resumed")
+			return nil
+		},
+	}
+
+	service := &ontologyDAGService{dagRepo: mockRepo, tokenBudgets: NewTokenBudgetTracker(), logger: zap.NewNop()}
+
+	resumed, err := service.resumeBudgetStoppedDAG(context.Background(), uuid.New(), uuid.New(), uuid.New())
+	require.NoError(t, err)
+	assert.Nil(t, resumed)
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+)
+
+// ErrInvalidOntologyBaseline is returned when the baseline bundle given to DiffAgainstBundle
+// cannot be parsed as an ontology export.
+var ErrInvalidOntologyBaseline = errors.New("invalid ontology baseline bundle")
+
+// DiffAgainstBundle compares a previously exported bundle with the datasource's current
+// ontology. The engine keeps a single ontology per project that extraction and edits
+// update in place, so a saved export is the baseline a re-extraction is compared with.
+func (s *ontologyExportService) DiffAgainstBundle(ctx context.Context, projectID, datasourceID uuid.UUID, baseline []byte) (*models.OntologyDiff, error) {
+	from, err := decodeOntologyImportBundle(baseline)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidOntologyBaseline, err)
+	}
+
+	to, err := s.BuildBundle(ctx, projectID, datasourceID)
+	if err != nil {
+		return nil, fmt.Errorf("build current ontology bundle: %w", err)
+	}
+
+	return DiffOntologyBundles(from, to), nil
+}
+
+// DiffOntologyBundles returns what changed between two ontology export bundles: added
+// and removed entities and relationships, changed entity descriptions, added and removed
+// domains, and question counts per status. Lists are sorted so diffs are stable.
+func DiffOntologyBundles(from, to *models.OntologyExportBundle) *models.OntologyDiff {
+	diff := &models.OntologyDiff{
+		AddedEntities:        []string{},
+		RemovedEntities:      []string{},
+		ChangedEntities:      []models.OntologyEntityChange{},
+		AddedDomains:         []string{},
+		RemovedDomains:       []string{},
+		AddedRelationships:   []string{},
+		RemovedRelationships: []string{},
+		QuestionCounts:       make(map[models.QuestionStatus]models.CountDiff),
+	}
+
+	fromEntities, toEntities := bundleEntityKeys(from), bundleEntityKeys(to)
+	diff.AddedEntities, diff.RemovedEntities = diffKeySets(fromEntities, toEntities)
+
+	fromMetadata, toMetadata := bundleTableMetadata(from), bundleTableMetadata(to)
+	for key, before := range fromMetadata {
+		after, ok := toMetadata[key]
+		if !ok || !toEntities[key] {
+			continue
+		}
+		for _, field := range []struct {
+			name          string
+			before, after *string
+		}{
+			{"description", before.Description, after.Description},
+			{"usage_notes", before.UsageNotes, after.UsageNotes},
+			{"table_type", before.TableType, after.TableType},
+		} {
+			if ptrString(field.before) != ptrString(field.after) {
+				diff.ChangedEntities = append(diff.ChangedEntities, models.OntologyEntityChange{
+					Entity: key,
+					Field:  field.name,
+					From:   ptrString(field.before),
+					To:     ptrString(field.after),
+				})
+			}
+		}
+	}
+	sort.Slice(diff.ChangedEntities, func(i, j int) bool {
+		if diff.ChangedEntities[i].Entity != diff.ChangedEntities[j].Entity {
+			return diff.ChangedEntities[i].Entity < diff.ChangedEntities[j].Entity
+		}
+		return diff.ChangedEntities[i].Field < diff.ChangedEntities[j].Field
+	})
+
+	diff.AddedDomains, diff.RemovedDomains = diffKeySets(bundleDomains(from), bundleDomains(to))
+	diff.AddedRelationships, diff.RemovedRelationships = diffKeySets(bundleRelationshipKeys(from), bundleRelationshipKeys(to))
+
+	fromCounts, toCounts := bundleQuestionCounts(from), bundleQuestionCounts(to)
+	for _, status := range models.ValidQuestionStatuses {
+		diff.QuestionCounts[status] = models.CountDiff{
+			From:  fromCounts[status],
+			To:    toCounts[status],
+			Delta: toCounts[status] - fromCounts[status],
+		}
+	}
+
+	return diff
+}
+
+// diffKeySets returns the sorted keys only in to (added) and only in from (removed).
+func diffKeySets(from, to map[string]bool) (added, removed []string) {
+	added, removed = []string{}, []string{}
+	for key := range to {
+		if !from[key] {
+			added = append(added, key)
+		}
+	}
+	for key := range from {
+		if !to[key] {
+			removed = append(removed, key)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+func bundleEntityKeys(bundle *models.OntologyExportBundle) map[string]bool {
+	keys := make(map[string]bool)
+	for _, ds := range bundle.Datasources {
+		for _, table := range ds.SelectedSchema.Tables {
+			keys[exportTableKey(table.SchemaName, table.TableName)] = true
+		}
+	}
+	return keys
+}
+
+func bundleTableMetadata(bundle *models.OntologyExportBundle) map[string]models.OntologyExportTableMetadata {
+	metadata := make(map[string]models.OntologyExportTableMetadata, len(bundle.Ontology.TableMetadata))
+	for _, item := range bundle.Ontology.TableMetadata {
+		metadata[exportTableRefKey(item.Table)] = item
+	}
+	return metadata
+}
+
+func bundleDomains(bundle *models.OntologyExportBundle) map[string]bool {
+	domains := make(map[string]bool)
+	if bundle.Project.DomainSummary != nil {
+		for _, domain := range bundle.Project.DomainSummary.Domains {
+			domains[domain] = true
+		}
+	}
+	return domains
+}
+
+func bundleRelationshipKeys(bundle *models.OntologyExportBundle) map[string]bool {
+	keys := make(map[string]bool)
+	for _, ds := range bundle.Datasources {
+		for _, rel := range ds.SelectedSchema.Relationships {
+			keys[exportColumnRefKey(rel.Source)+" -> "+exportColumnRefKey(rel.Target)] = true
+		}
+	}
+	return keys
+}
+
+func bundleQuestionCounts(bundle *models.OntologyExportBundle) map[models.QuestionStatus]int {
+	counts := make(map[models.QuestionStatus]int)
+	for _, q := range bundle.Ontology.Questions {
+		counts[q.Status]++
+	}
+	return counts
+}
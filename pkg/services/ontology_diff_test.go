@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+)
+
+func diffTestBundle(tables []string, relationships [][2]string, descriptions map[string]string, domains []string, questionStatuses ...models.QuestionStatus) *models.OntologyExportBundle {
+	bundle := &models.OntologyExportBundle{
+		Format:      models.OntologyExportFormat,
+		Version:     models.OntologyExportVersion,
+		Project:     models.OntologyExportProject{DomainSummary: &models.DomainSummary{Domains: domains}},
+		Datasources: []models.OntologyExportDatasource{{Key: "primary"}},
+	}
+	for _, table := range tables {
+		bundle.Datasources[0].SelectedSchema.Tables = append(bundle.Datasources[0].SelectedSchema.Tables,
+			models.OntologyExportTable{SchemaName: "public", TableName: table})
+		if description, ok := descriptions[table]; ok {
+			bundle.Ontology.TableMetadata = append(bundle.Ontology.TableMetadata, models.OntologyExportTableMetadata{
+				Table:       models.OntologyExportTableRef{SchemaName: "public", TableName: table},
+				Description: &description,
+			})
+		}
+	}
+	for _, rel := range relationships {
+		bundle.Datasources[0].SelectedSchema.Relationships = append(bundle.Datasources[0].SelectedSchema.Relationships,
+			models.OntologyExportRelationship{
+				Source: models.OntologyExportColumnRef{Table: models.OntologyExportTableRef{SchemaName: "public", TableName: rel[0]}, ColumnName: rel[1] + "_id"},
+				Target: models.OntologyExportColumnRef{Table: models.OntologyExportTableRef{SchemaName: "public", TableName: rel[1] + "s"}, ColumnName: "id"},
+			})
+	}
+	for _, status := range questionStatuses {
+		bundle.Ontology.Questions = append(bundle.Ontology.Questions, models.OntologyExportQuestion{Status: status})
+	}
+	return bundle
+}
+
+func TestDiffOntologyBundles_OneEntityAndOneRelationship(t *testing.T) {
+	from := diffTestBundle(
+		[]string{"orders", "users"},
+		[][2]string{{"orders", "user"}},
+		map[string]string{"orders": "Customer orders", "users": "Accounts"},
+		[]string{"sales"},
+		models.QuestionStatusPending, models.QuestionStatusPending,
+	)
+	to := diffTestBundle(
+		[]string{"orders", "users", "invoices"},
+		[][2]string{{"orders", "user"}, {"invoices", "order"}},
+		map[string]string{"orders": "Customer orders", "users": "Accounts", "invoices": "Billing documents"},
+		[]string{"sales"},
+		models.QuestionStatusPending, models.QuestionStatusAnswered, models.QuestionStatusPending,
+	)
+
+	diff := DiffOntologyBundles(from, to)
+
+	assert.Equal(t, []string{"public.invoices"}, diff.AddedEntities)
+	assert.Empty(t, diff.RemovedEntities)
+	assert.Empty(t, diff.ChangedEntities)
+	assert.Equal(t, []string{"public.invoices.order_id -> public.orders.id"}, diff.AddedRelationships)
+	assert.Empty(t, diff.RemovedRelationships)
+	assert.Empty(t, diff.AddedDomains)
+	assert.Empty(t, diff.RemovedDomains)
+	assert.Equal(t, models.CountDiff{From: 2, To: 2, Delta: 0}, diff.QuestionCounts[models.QuestionStatusPending])
+	assert.Equal(t, models.CountDiff{From: 0, To: 1, Delta: 1}, diff.QuestionCounts[models.QuestionStatusAnswered])
+}
+
+func TestDiffOntologyBundles_ChangedDescriptionsAndDomains(t *testing.T) {
+	from := diffTestBundle(
+		[]string{"orders", "legacy_orders"},
+		[][2]string{{"legacy_orders", "order"}},
+		map[string]string{"orders": "Orders"},
+		[]string{"sales", "logistics"},
+	)
+	to := diffTestBundle(
+		[]string{"orders"},
+		nil,
+		map[string]string{"orders": "Customer purchase orders"},
+		[]string{"sales", "finance"},
+	)
+
+	diff := DiffOntologyBundles(from, to)
+
+	assert.Equal(t, []string{"public.legacy_orders"}, diff.RemovedEntities)
+	assert.Equal(t, []string{"public.legacy_orders.order_id -> public.orders.id"}, diff.RemovedRelationships)
+	assert.Equal(t, []models.OntologyEntityChange{
+		{Entity: "public.orders", Field: "description", From: "Orders", To: "Customer purchase orders"},
+	}, diff.ChangedEntities)
+	assert.Equal(t, []string{"finance"}, diff.AddedDomains)
+	assert.Equal(t, []string{"logistics"}, diff.RemovedDomains)
+}
+
+func TestOntologyExportService_DiffAgainstBundle_InvalidBaseline(t *testing.T) {
+	svc := &ontologyExportService{}
+
+	_, err := svc.DiffAgainstBundle(context.Background(), uuid.New(), uuid.New(), []byte(`{"format":"something-else"}`))
+
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrInvalidOntologyBaseline))
+}
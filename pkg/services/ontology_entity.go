@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+	"github.com/ekaya-inc/ekaya-engine/pkg/repositories"
+)
+
+// OntologyEntityService lists the ontology's entities (described tables) with their
+// provenance, and removes entities by the source that created them.
+type OntologyEntityService interface {
+	// List returns the entities of the datasource's selected tables, only those created
+	// by source when it is non-empty.
+	List(ctx context.Context, projectID, datasourceID uuid.UUID, source models.ProvenanceSource) ([]*models.OntologyEntity, error)
+
+	// ListForReview returns the datasource's entities worst-first by quality score, so
+	// reviewers start with the least documented tables. Unscored entities come last.
+	ListForReview(ctx context.Context, projectID, datasourceID uuid.UUID) ([]*models.OntologyEntity, error)
+
+	// PurgeBySource deletes the entities created by source. With dryRun it only counts them.
+	PurgeBySource(ctx context.Context, projectID, datasourceID uuid.UUID, source models.ProvenanceSource, dryRun bool) (*models.OntologyEntityPurgeResult, error)
+}
+
+type ontologyEntityService struct {
+	schemaRepo        repositories.SchemaRepository
+	tableMetadataRepo repositories.TableMetadataRepository
+	logger            *zap.Logger
+}
+
+// NewOntologyEntityService creates a new ontology entity service.
+func NewOntologyEntityService(
+	schemaRepo repositories.SchemaRepository,
+	tableMetadataRepo repositories.TableMetadataRepository,
+	logger *zap.Logger,
+) OntologyEntityService {
+	return &ontologyEntityService{
+		schemaRepo:        schemaRepo,
+		tableMetadataRepo: tableMetadataRepo,
+		logger:            logger.Named("ontology-entity"),
+	}
+}
+
+var _ OntologyEntityService = (*ontologyEntityService)(nil)
+
+func (s *ontologyEntityService) List(ctx context.Context, projectID, datasourceID uuid.UUID, source models.ProvenanceSource) ([]*models.OntologyEntity, error) {
+	tables, err := s.schemaRepo.ListTablesByDatasource(ctx, projectID, datasourceID)
+	if err != nil {
+		return nil, fmt.Errorf("list tables: %w", err)
+	}
+	metadata, err := s.tableMetadataRepo.List(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("list table metadata: %w", err)
+	}
+
+	metadataByTableID := make(map[uuid.UUID]*models.TableMetadata, len(metadata))
+	for _, m := range metadata {
+		metadataByTableID[m.SchemaTableID] = m
+	}
+
+	entities := make([]*models.OntologyEntity, 0, len(tables))
+	for _, table := range tables {
+		m, ok := metadataByTableID[table.ID]
+		if !ok || (source != "" && m.Source != source.String()) {
+			continue
+		}
+		entity := &models.OntologyEntity{
+			SchemaTableID:  table.ID,
+			SchemaName:     table.SchemaName,
+			TableName:      table.TableName,
+			TableType:      m.TableType,
+			Description:    m.Description,
+			Source:         m.Source,
+			LastEditSource: m.LastEditSource,
+			CreatedAt:      m.CreatedAt,
+			UpdatedAt:      m.UpdatedAt,
+			Quality:        m.GetQuality(),
+		}
+		if bridge := m.GetBridge(); bridge != nil {
+			entity.IsBridge = true
+			entity.LinkedTables = bridge.LinkedTables
+		}
+		entities = append(entities, entity)
+	}
+	return entities, nil
+}
+
+func (s *ontologyEntityService) ListForReview(ctx context.Context, projectID, datasourceID uuid.UUID) ([]*models.OntologyEntity, error) {
+	entities, err := s.List(ctx, projectID, datasourceID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(entities, func(i, j int) bool {
+		qi, qj := entities[i].Quality, entities[j].Quality
+		switch {
+		case qi == nil || qj == nil:
+			return qi != nil && qj == nil
+		case qi.Score != qj.Score:
+			return qi.Score < qj.Score
+		default:
+			return entities[i].TableName < entities[j].TableName
+		}
+	})
+	return entities, nil
+}
+
+func (s *ontologyEntityService) PurgeBySource(ctx context.Context, projectID, datasourceID uuid.UUID, source models.ProvenanceSource, dryRun bool) (*models.OntologyEntityPurgeResult, error) {
+	if !source.IsValid() {
+		return nil, fmt.Errorf("invalid provenance source %q", source)
+	}
+
+	entities, err := s.List(ctx, projectID, datasourceID, source)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.OntologyEntityPurgeResult{
+		Source: source,
+		DryRun: dryRun,
+		Tables: []string{},
+	}
+	for _, entity := range entities {
+		if entity.LastEditSource != nil && *entity.LastEditSource != source.String() {
+			result.KeptEdited++
+			continue
+		}
+		if !dryRun {
+			if err := s.tableMetadataRepo.Delete(ctx, entity.SchemaTableID); err != nil {
+				return nil, fmt.Errorf("delete entity %s: %w", entity.TableName, err)
+			}
+		}
+		result.Count++
+		result.Tables = append(result.Tables, entity.TableName)
+	}
+
+	if !dryRun && result.Count > 0 {
+		s.logger.Info("Purged ontology entities by source",
+			zap.String("project_id", projectID.String()),
+			zap.String("datasource_id", datasourceID.String()),
+			zap.String("source", source.String()),
+			zap.Int("count", result.Count),
+			zap.Int("kept_edited", result.KeptEdited))
+	}
+	return result, nil
+}
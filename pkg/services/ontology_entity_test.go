@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+	"github.com/ekaya-inc/ekaya-engine/pkg/repositories"
+)
+
+// mockSchemaRepoForEntities serves the selected tables of a datasource.
+type mockSchemaRepoForEntities struct {
+	repositories.SchemaRepository
+	tables []*models.SchemaTable
+}
+
+func (m *mockSchemaRepoForEntities) ListTablesByDatasource(_ context.Context, _, _ uuid.UUID) ([]*models.SchemaTable, error) {
+	return m.tables, nil
+}
+
+// mockTableMetadataRepoForEntities lists and deletes table metadata in memory.
+type mockTableMetadataRepoForEntities struct {
+	repositories.TableMetadataRepository
+	items   []*models.TableMetadata
+	deleted []uuid.UUID
+}
+
+func (m *mockTableMetadataRepoForEntities) List(_ context.Context, _ uuid.UUID) ([]*models.TableMetadata, error) {
+	return m.items, nil
+}
+
+func (m *mockTableMetadataRepoForEntities) Delete(_ context.Context, schemaTableID uuid.UUID) error {
+	m.deleted = append(m.deleted, schemaTableID)
+	return nil
+}
+
+func entityProvenanceFixture() (*mockSchemaRepoForEntities, *mockTableMetadataRepoForEntities) {
+	orders := &models.SchemaTable{ID: uuid.New(), SchemaName: "public", TableName: "orders"}
+	users := &models.SchemaTable{ID: uuid.New(), SchemaName: "public", TableName: "users"}
+	refunds := &models.SchemaTable{ID: uuid.New(), SchemaName: "public", TableName: "refunds"}
+	plans := &models.SchemaTable{ID: uuid.New(), SchemaName: "public", TableName: "plans"}
+	manual := models.ProvenanceManual
+
+	schemaRepo := &mockSchemaRepoForEntities{tables: []*models.SchemaTable{orders, users, refunds, plans}}
+	metadataRepo := &mockTableMetadataRepoForEntities{items: []*models.TableMetadata{
+		{SchemaTableID: orders.ID, Source: models.ProvenanceInferred},
+		{SchemaTableID: users.ID, Source: models.ProvenanceManual},
+		// Inferred, then corrected by a user
+		{SchemaTableID: refunds.ID, Source: models.ProvenanceInferred, LastEditSource: &manual},
+		// Metadata of a table of another datasource
+		{SchemaTableID: uuid.New(), Source: models.ProvenanceInferred},
+		// plans has no metadata yet
+	}}
+	return schemaRepo, metadataRepo
+}
+
+func TestOntologyEntityService_List_FiltersBySource(t *testing.T) {
+	schemaRepo, metadataRepo := entityProvenanceFixture()
+	svc := NewOntologyEntityService(schemaRepo, metadataRepo, zap.NewNop())
+
+	all, err := svc.List(context.Background(), uuid.New(), uuid.New(), "")
+	require.NoError(t, err)
+	require.Len(t, all, 3, "tables without metadata and other datasources are not entities")
+
+	inferred, err := svc.List(context.Background(), uuid.New(), uuid.New(), models.SourceInferred)
+	require.NoError(t, err)
+	require.Len(t, inferred, 2)
+	assert.Equal(t, "orders", inferred[0].TableName)
+	assert.Equal(t, "refunds", inferred[1].TableName)
+	assert.Equal(t, models.ProvenanceManual, *inferred[1].LastEditSource)
+
+	manual, err := svc.List(context.Background(), uuid.New(), uuid.New(), models.SourceManual)
+	require.NoError(t, err)
+	require.Len(t, manual, 1)
+	assert.Equal(t, "users", manual[0].TableName)
+	assert.Equal(t, models.ProvenanceManual, manual[0].Source)
+}
+
+func TestOntologyEntityService_List_MarksBridgeTables(t *testing.T) {
+	students := &models.SchemaTable{ID: uuid.New(), SchemaName: "public", TableName: "students"}
+	bridge := &models.SchemaTable{ID: uuid.New(), SchemaName: "public", TableName: "student_courses"}
+	junction := models.TableTypeJunction
+
+	schemaRepo := &mockSchemaRepoForEntities{tables: []*models.SchemaTable{students, bridge}}
+	metadataRepo := &mockTableMetadataRepoForEntities{items: []*models.TableMetadata{
+		{SchemaTableID: students.ID, Source: models.ProvenanceInferred},
+		{
+			SchemaTableID: bridge.ID,
+			Source:        models.ProvenanceInferred,
+			TableType:     &junction,
+			Features: models.TableMetadataFeatures{
+				Bridge: &models.TableBridgeFeatures{LinkedTables: []string{"courses", "students"}},
+			},
+		},
+	}}
+	svc := NewOntologyEntityService(schemaRepo, metadataRepo, zap.NewNop())
+
+	entities, err := svc.List(context.Background(), uuid.New(), uuid.New(), "")
+	require.NoError(t, err)
+	require.Len(t, entities, 2)
+
+	assert.False(t, entities[0].IsBridge, "students is a standalone entity")
+	assert.True(t, entities[1].IsBridge, "student_courses is the many-to-many relationship, not an entity")
+	assert.Equal(t, []string{"courses", "students"}, entities[1].LinkedTables)
+}
+
+func TestOntologyEntityService_ListForReview_WorstFirst(t *testing.T) {
+	orders := &models.SchemaTable{ID: uuid.New(), SchemaName: "public", TableName: "orders"}
+	users := &models.SchemaTable{ID: uuid.New(), SchemaName: "public", TableName: "users"}
+	plans := &models.SchemaTable{ID: uuid.New(), SchemaName: "public", TableName: "plans"}
+	scored := func(score int) models.TableMetadataFeatures {
+		return models.TableMetadataFeatures{Quality: &models.TableQualityFeatures{Score: score}}
+	}
+
+	schemaRepo := &mockSchemaRepoForEntities{tables: []*models.SchemaTable{orders, users, plans}}
+	metadataRepo := &mockTableMetadataRepoForEntities{items: []*models.TableMetadata{
+		{SchemaTableID: orders.ID, Source: models.ProvenanceInferred, Features: scored(85)},
+		{SchemaTableID: users.ID, Source: models.ProvenanceInferred, Features: scored(40)},
+		// plans has not been scored yet
+		{SchemaTableID: plans.ID, Source: models.ProvenanceInferred},
+	}}
+	svc := NewOntologyEntityService(schemaRepo, metadataRepo, zap.NewNop())
+
+	entities, err := svc.ListForReview(context.Background(), uuid.New(), uuid.New())
+	require.NoError(t, err)
+	require.Len(t, entities, 3)
+	assert.Equal(t, "users", entities[0].TableName)
+	assert.Equal(t, 40, entities[0].Quality.Score)
+	assert.Equal(t, "orders", entities[1].TableName)
+	assert.Equal(t, "plans", entities[2].TableName, "unscored entities come last")
+	assert.Nil(t, entities[2].Quality)
+}
+
+func TestOntologyEntityService_PurgeBySource(t *testing.T) {
+	schemaRepo, metadataRepo := entityProvenanceFixture()
+	svc := NewOntologyEntityService(schemaRepo, metadataRepo, zap.NewNop())
+
+	dryRun, err := svc.PurgeBySource(context.Background(), uuid.New(), uuid.New(), models.SourceInferred, true)
+	require.NoError(t, err)
+	assert.Equal(t, 1, dryRun.Count)
+	assert.Equal(t, []string{"orders"}, dryRun.Tables)
+	assert.Equal(t, 1, dryRun.KeptEdited, "manually corrected entities are kept")
+	assert.Empty(t, metadataRepo.deleted, "a dry run deletes nothing")
+
+	result, err := svc.PurgeBySource(context.Background(), uuid.New(), uuid.New(), models.SourceInferred, false)
+	require.NoError(t, err)
+	assert.False(t, result.DryRun)
+	assert.Equal(t, 1, result.Count)
+	assert.Equal(t, []uuid.UUID{schemaRepo.tables[0].ID}, metadataRepo.deleted)
+
+	_, err = svc.PurgeBySource(context.Background(), uuid.New(), uuid.New(), "llm", false)
+	assert.Error(t, err)
+}
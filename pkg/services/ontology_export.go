@@ -28,6 +28,8 @@ type OntologyExportService interface {
 	BuildBundle(ctx context.Context, projectID, datasourceID uuid.UUID) (*models.OntologyExportBundle, error)
 	MarshalBundle(bundle *models.OntologyExportBundle) ([]byte, error)
 	SuggestedFilename(bundle *models.OntologyExportBundle) string
+	// DiffAgainstBundle compares a saved export bundle with the current ontology.
+	DiffAgainstBundle(ctx context.Context, projectID, datasourceID uuid.UUID, baseline []byte) (*models.OntologyDiff, error)
 }
 
 type ontologyExportProjectRepository interface {
@@ -382,6 +384,7 @@ func buildExportTables(
 				MinLength:         column.MinLength,
 				MaxLength:         column.MaxLength,
 				EnumValues:        enumValues,
+				CheckConstraints:  column.CheckConstraints,
 				RowCount:          column.RowCount,
 				NonNullCount:      column.NonNullCount,
 				IsJoinable:        column.IsJoinable,
@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 
+	"github.com/ekaya-inc/ekaya-engine/pkg/apperrors"
 	"github.com/ekaya-inc/ekaya-engine/pkg/llm"
 	"github.com/ekaya-inc/ekaya-engine/pkg/models"
 	"github.com/ekaya-inc/ekaya-engine/pkg/repositories"
@@ -18,24 +19,39 @@ import (
 type OntologyFinalizationService interface {
 	// Finalize generates domain description and discovers project conventions from schema.
 	Finalize(ctx context.Context, projectID uuid.UUID) error
+
+	// RegenerateDomainSummary rebuilds only the domain summary from the current table
+	// summaries and relationships, leaving table and column metadata untouched.
+	RegenerateDomainSummary(ctx context.Context, projectID uuid.UUID) (*models.DomainSummary, error)
 }
 
 type ontologyFinalizationService struct {
 	projectRepo        repositories.ProjectRepository
 	schemaRepo         repositories.SchemaRepository
 	columnMetadataRepo repositories.ColumnMetadataRepository
+	tableMetadataRepo  repositories.TableMetadataRepository
 	conversationRepo   repositories.ConversationRepository
+	questionService    OntologyQuestionService
+	maxQuestions       int // Per-table cap on pending questions; 0 disables trimming
+	qualityService     TableQualityService
 	llmFactory         llm.LLMClientFactory
 	getTenantCtx       TenantContextFunc
 	logger             *zap.Logger
 }
 
 // NewOntologyFinalizationService creates a new ontology finalization service.
+// questionService may be nil, or maxQuestionsPerTable 0, to skip trimming questions;
+// qualityService may be nil, in which case table quality scores are not refreshed;
+// tableMetadataRepo may be nil, in which case table summaries are left out of the domain prompt.
 func NewOntologyFinalizationService(
 	projectRepo repositories.ProjectRepository,
 	schemaRepo repositories.SchemaRepository,
 	columnMetadataRepo repositories.ColumnMetadataRepository,
+	tableMetadataRepo repositories.TableMetadataRepository,
 	conversationRepo repositories.ConversationRepository,
+	questionService OntologyQuestionService,
+	maxQuestionsPerTable int,
+	qualityService TableQualityService,
 	llmFactory llm.LLMClientFactory,
 	getTenantCtx TenantContextFunc,
 	logger *zap.Logger,
@@ -44,7 +60,11 @@ func NewOntologyFinalizationService(
 		projectRepo:        projectRepo,
 		schemaRepo:         schemaRepo,
 		columnMetadataRepo: columnMetadataRepo,
+		tableMetadataRepo:  tableMetadataRepo,
 		conversationRepo:   conversationRepo,
+		questionService:    questionService,
+		maxQuestions:       maxQuestionsPerTable,
+		qualityService:     qualityService,
 		llmFactory:         llmFactory,
 		getTenantCtx:       getTenantCtx,
 		logger:             logger.Named("ontology-finalization"),
@@ -57,15 +77,83 @@ func (s *ontologyFinalizationService) Finalize(ctx context.Context, projectID uu
 	s.logger.Info("Starting ontology finalization", zap.String("project_id", projectID.String()))
 	ctx = withLoadedProjectKnowledgeFactsForPrompt(ctx, projectID, s.logger)
 
+	domainSummary, tableCount, err := s.buildDomainSummary(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	if domainSummary == nil {
+		s.logger.Info("No tables found, skipping finalization", zap.String("project_id", projectID.String()))
+		return nil
+	}
+
+	if err := s.projectRepo.UpdateDomainSummary(ctx, projectID, domainSummary); err != nil {
+		return fmt.Errorf("update domain summary: %w", err)
+	}
+
+	// Cap questions per table so a verbose model can't bury the useful ones. Runs
+	// before scoring so quality reflects the questions that remain.
+	if maxQuestions := s.maxQuestionsPerTable(ctx, projectID); s.questionService != nil && maxQuestions > 0 {
+		if _, err := s.questionService.TrimQuestionsPerTable(ctx, projectID, maxQuestions); err != nil {
+			s.logger.Warn("Failed to trim questions per table",
+				zap.String("project_id", projectID.String()),
+				zap.Error(err))
+		}
+	}
+
+	// Score every table now that extraction has produced its metadata. The score is
+	// advisory, so a failure here doesn't fail finalization.
+	if s.qualityService != nil {
+		if _, err := s.qualityService.RefreshProject(ctx, projectID); err != nil {
+			s.logger.Warn("Failed to refresh table quality scores",
+				zap.String("project_id", projectID.String()),
+				zap.Error(err))
+		}
+	}
+
+	s.logger.Info("Ontology finalization complete",
+		zap.String("project_id", projectID.String()),
+		zap.Int("table_count", tableCount),
+	)
+
+	return nil
+}
+
+func (s *ontologyFinalizationService) RegenerateDomainSummary(ctx context.Context, projectID uuid.UUID) (*models.DomainSummary, error) {
+	s.logger.Info("Regenerating domain summary", zap.String("project_id", projectID.String()))
+	ctx = withLoadedProjectKnowledgeFactsForPrompt(ctx, projectID, s.logger)
+
+	domainSummary, tableCount, err := s.buildDomainSummary(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if domainSummary == nil {
+		return nil, apperrors.ErrNoSelectedTables
+	}
+
+	if err := s.projectRepo.UpdateDomainSummary(ctx, projectID, domainSummary); err != nil {
+		return nil, fmt.Errorf("update domain summary: %w", err)
+	}
+
+	s.logger.Info("Domain summary regenerated",
+		zap.String("project_id", projectID.String()),
+		zap.Int("table_count", tableCount),
+	)
+
+	return domainSummary, nil
+}
+
+// buildDomainSummary generates the domain description and conventions from the project's
+// current tables, their summaries, and relationships. It returns a nil summary when the
+// project has no tables.
+func (s *ontologyFinalizationService) buildDomainSummary(ctx context.Context, projectID uuid.UUID) (*models.DomainSummary, int, error) {
 	// Get all tables for the project to build conventions
 	tables, err := s.schemaRepo.ListTablesByDatasource(ctx, projectID, uuid.Nil) // uuid.Nil gets all datasources
 	if err != nil {
-		return fmt.Errorf("list tables: %w", err)
+		return nil, 0, fmt.Errorf("list tables: %w", err)
 	}
 
 	if len(tables) == 0 {
-		s.logger.Info("No tables found, skipping finalization", zap.String("project_id", projectID.String()))
-		return nil
+		return nil, 0, nil
 	}
 
 	// Get table names for column lookup
@@ -77,7 +165,7 @@ func (s *ontologyFinalizationService) Finalize(ctx context.Context, projectID uu
 	// Get all columns for these tables (needed for ColumnFeatures analysis and convention discovery)
 	columnsByTable, err := s.schemaRepo.GetColumnsByTables(ctx, projectID, tableNames)
 	if err != nil {
-		return fmt.Errorf("get columns by tables: %w", err)
+		return nil, 0, fmt.Errorf("get columns by tables: %w", err)
 	}
 
 	// Collect all column IDs for metadata lookup
@@ -114,36 +202,90 @@ func (s *ontologyFinalizationService) Finalize(ctx context.Context, projectID uu
 		zap.Int("external_services", len(insights.externalServices)),
 	)
 
-	// Generate domain description via LLM based on tables
-	description, err := s.generateDomainDescription(ctx, projectID, tables, insights)
+	// Generate domain description via LLM based on tables, their summaries, and relationships
+	summaries := s.loadTableSummaries(ctx, projectID, tableNames)
+	relationships := s.loadRelationshipLines(ctx, projectID, tables)
+	description, err := s.generateDomainDescription(ctx, projectID, tables, summaries, relationships, insights)
 	if err != nil {
-		return fmt.Errorf("generate domain description: %w", err)
+		return nil, 0, fmt.Errorf("generate domain description: %w", err)
 	}
 
 	// Discover project conventions using pre-extracted insights
 	conventions, err := s.discoverConventionsWithInsights(ctx, projectID, tables, columnsByTable, insights)
 	if err != nil {
-		return fmt.Errorf("discover conventions: %w", err)
+		return nil, 0, fmt.Errorf("discover conventions: %w", err)
 	}
 
-	// Save to domain_summary JSONB
-	domainSummary := &models.DomainSummary{
+	return &models.DomainSummary{
 		Description:     description,
 		Domains:         nil, // No domains without entities
 		Conventions:     conventions,
 		SampleQuestions: nil, // Feature removed, may be reimplemented later
-	}
+	}, len(tables), nil
+}
 
-	if err := s.projectRepo.UpdateDomainSummary(ctx, projectID, domainSummary); err != nil {
-		return fmt.Errorf("update domain summary: %w", err)
+// loadTableSummaries returns the current description of each table keyed by table name.
+// Summaries only enrich the prompt, so a lookup failure yields none rather than an error.
+func (s *ontologyFinalizationService) loadTableSummaries(ctx context.Context, projectID uuid.UUID, tableNames []string) map[string]string {
+	if s.tableMetadataRepo == nil {
+		return nil
 	}
+	metaByName, err := s.tableMetadataRepo.ListByTableNames(ctx, projectID, tableNames)
+	if err != nil {
+		s.logger.Warn("Failed to load table summaries for domain summary, continuing without",
+			zap.Error(err))
+		return nil
+	}
+	summaries := make(map[string]string, len(metaByName))
+	for name, meta := range metaByName {
+		if meta != nil && meta.Description != nil && *meta.Description != "" {
+			summaries[name] = *meta.Description
+		}
+	}
+	return summaries
+}
 
-	s.logger.Info("Ontology finalization complete",
-		zap.String("project_id", projectID.String()),
-		zap.Int("table_count", len(tables)),
-	)
+// loadRelationshipLines describes the relationships of every datasource the tables belong
+// to as "source.column -> target.column" lines, sorted for a stable prompt.
+func (s *ontologyFinalizationService) loadRelationshipLines(ctx context.Context, projectID uuid.UUID, tables []*models.SchemaTable) []string {
+	seen := make(map[uuid.UUID]bool)
+	var lines []string
+	for _, t := range tables {
+		if seen[t.DatasourceID] {
+			continue
+		}
+		seen[t.DatasourceID] = true
 
-	return nil
+		details, err := s.schemaRepo.GetRelationshipDetails(ctx, projectID, t.DatasourceID)
+		if err != nil {
+			s.logger.Warn("Failed to load relationships for domain summary, continuing without",
+				zap.String("datasource_id", t.DatasourceID.String()),
+				zap.Error(err))
+			continue
+		}
+		for _, d := range details {
+			line := fmt.Sprintf("%s.%s -> %s.%s", d.SourceTableName, d.SourceColumnName, d.TargetTableName, d.TargetColumnName)
+			if d.Cardinality != "" {
+				line += fmt.Sprintf(" (%s)", d.Cardinality)
+			}
+			lines = append(lines, line)
+		}
+	}
+	sort.Strings(lines)
+	return lines
+}
+
+// maxQuestionsPerTable returns the project's question cap, falling back to the
+// server-wide cap when the project has none or cannot be read.
+func (s *ontologyFinalizationService) maxQuestionsPerTable(ctx context.Context, projectID uuid.UUID) int {
+	project, err := s.projectRepo.Get(ctx, projectID)
+	if err != nil || project == nil {
+		return s.maxQuestions
+	}
+	if v := ontologySettingsFromParameters(project.Parameters).MaxQuestionsPerTable; v > 0 {
+		return v
+	}
+	return s.maxQuestions
 }
 
 // generateDomainDescription calls the LLM to generate a business description based on tables.
@@ -151,6 +293,8 @@ func (s *ontologyFinalizationService) generateDomainDescription(
 	ctx context.Context,
 	projectID uuid.UUID,
 	tables []*models.SchemaTable,
+	summaries map[string]string,
+	relationships []string,
 	insights *columnFeatureInsights,
 ) (string, error) {
 	llmClient, err := s.llmFactory.CreateForProject(ctx, projectID)
@@ -159,7 +303,7 @@ func (s *ontologyFinalizationService) generateDomainDescription(
 	}
 
 	systemMessage := s.domainDescriptionSystemMessage()
-	prompt := s.buildDomainDescriptionPrompt(tables, insights)
+	prompt := s.buildDomainDescriptionPrompt(tables, summaries, relationships, insights)
 	prompt = prependProjectKnowledgeToPrompt(prompt, buildRelevantProjectKnowledgeSection(ctx, projectID, s.logger))
 
 	result, err := llmClient.GenerateResponse(ctx, prompt, systemMessage, 0.3, false)
@@ -202,6 +346,8 @@ func (s *ontologyFinalizationService) domainDescriptionSystemMessage() string {
 
 func (s *ontologyFinalizationService) buildDomainDescriptionPrompt(
 	tables []*models.SchemaTable,
+	summaries map[string]string,
+	relationships []string,
 	insights *columnFeatureInsights,
 ) string {
 	var sb strings.Builder
@@ -211,8 +357,18 @@ func (s *ontologyFinalizationService) buildDomainDescriptionPrompt(
 
 	sb.WriteString("## Tables\n\n")
 	for _, t := range tables {
-		// Note: Description now lives in TableMetadata (engine_ontology_table_metadata)
-		sb.WriteString(fmt.Sprintf("- **%s**\n", t.TableName))
+		if summary := summaries[t.TableName]; summary != "" {
+			sb.WriteString(fmt.Sprintf("- **%s**: %s\n", t.TableName, summary))
+		} else {
+			sb.WriteString(fmt.Sprintf("- **%s**\n", t.TableName))
+		}
+	}
+
+	if len(relationships) > 0 {
+		sb.WriteString("\n## Relationships\n\n")
+		for _, line := range relationships {
+			sb.WriteString(fmt.Sprintf("- %s\n", line))
+		}
 	}
 
 	// Include feature-derived insights if available
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
+	"github.com/ekaya-inc/ekaya-engine/pkg/apperrors"
 	"github.com/ekaya-inc/ekaya-engine/pkg/llm"
 	"github.com/ekaya-inc/ekaya-engine/pkg/models"
 	"github.com/ekaya-inc/ekaya-engine/pkg/repositories"
@@ -49,10 +50,11 @@ func (m *mockProjectRepoForFinalization) UpdateDomainSummary(ctx context.Context
 }
 
 type mockSchemaRepoForFinalization struct {
-	tables          []*models.SchemaTable
-	columnsByTable  map[string][]*models.SchemaColumn
-	listTablesErr   error
-	getColumnsByErr error
+	tables              []*models.SchemaTable
+	columnsByTable      map[string][]*models.SchemaColumn
+	relationshipDetails []*models.RelationshipDetail
+	listTablesErr       error
+	getColumnsByErr     error
 }
 
 func (m *mockSchemaRepoForFinalization) ListTablesByDatasource(ctx context.Context, projectID, datasourceID uuid.UUID) ([]*models.SchemaTable, error) {
@@ -157,7 +159,7 @@ func (m *mockSchemaRepoForFinalization) SoftDeleteOrphanedRelationships(ctx cont
 	return 0, nil
 }
 func (m *mockSchemaRepoForFinalization) GetRelationshipDetails(ctx context.Context, projectID, datasourceID uuid.UUID) ([]*models.RelationshipDetail, error) {
-	return nil, nil
+	return m.relationshipDetails, nil
 }
 func (m *mockSchemaRepoForFinalization) GetEmptyTables(ctx context.Context, projectID, datasourceID uuid.UUID) ([]string, error) {
 	return nil, nil
@@ -165,6 +167,9 @@ func (m *mockSchemaRepoForFinalization) GetEmptyTables(ctx context.Context, proj
 func (m *mockSchemaRepoForFinalization) GetOrphanTables(ctx context.Context, projectID, datasourceID uuid.UUID) ([]string, error) {
 	return nil, nil
 }
+func (m *mockSchemaRepoForFinalization) GetRelationshipStats(ctx context.Context, projectID, datasourceID uuid.UUID) (*models.RelationshipStats, error) {
+	return nil, nil
+}
 func (m *mockSchemaRepoForFinalization) UpsertRelationshipWithMetrics(ctx context.Context, rel *models.SchemaRelationship, metrics *models.DiscoveryMetrics) error {
 	return nil
 }
@@ -226,6 +231,10 @@ func (m *mockLLMClient) GetEndpoint() string {
 	return "https://test.endpoint"
 }
 
+func (m *mockLLMClient) GenerateResponseStream(ctx context.Context, prompt string, systemMessage string, temperature float64) (*llm.ResponseStream, error) {
+	return llm.CompletedResponseStream(m.GenerateResponse(ctx, prompt, systemMessage, temperature, false))
+}
+
 type mockLLMFactoryForFinalization struct {
 	client    llm.LLMClient
 	createErr error
@@ -347,8 +356,8 @@ func TestOntologyFinalization_GeneratesDomainDescription(t *testing.T) {
 	logger := zap.NewNop()
 
 	svc := NewOntologyFinalizationService(
-		projectRepo, schemaRepo, &mockColumnMetadataRepoForFinalization{},
-		&mockConversationRepoForFinalization{}, llmFactory, noopTenantCtxForFinalization(), logger,
+		projectRepo, schemaRepo, &mockColumnMetadataRepoForFinalization{}, nil,
+		&mockConversationRepoForFinalization{}, nil, 0, nil, llmFactory, noopTenantCtxForFinalization(), logger,
 	)
 
 	err := svc.Finalize(ctx, projectID)
@@ -378,8 +387,8 @@ func TestOntologyFinalization_IncludesRelevantProjectKnowledgeInPrompt(t *testin
 	llmFactory := &mockLLMFactoryForFinalization{client: llmClient}
 
 	svc := NewOntologyFinalizationService(
-		projectRepo, schemaRepo, &mockColumnMetadataRepoForFinalization{},
-		&mockConversationRepoForFinalization{}, llmFactory, noopTenantCtxForFinalization(), zap.NewNop(),
+		projectRepo, schemaRepo, &mockColumnMetadataRepoForFinalization{}, nil,
+		&mockConversationRepoForFinalization{}, nil, 0, nil, llmFactory, noopTenantCtxForFinalization(), zap.NewNop(),
 	)
 
 	err := svc.Finalize(ctx, projectID)
@@ -402,8 +411,8 @@ func TestOntologyFinalization_SkipsIfNoTables(t *testing.T) {
 	logger := zap.NewNop()
 
 	svc := NewOntologyFinalizationService(
-		projectRepo, schemaRepo, &mockColumnMetadataRepoForFinalization{},
-		&mockConversationRepoForFinalization{}, llmFactory, noopTenantCtxForFinalization(), logger,
+		projectRepo, schemaRepo, &mockColumnMetadataRepoForFinalization{}, nil,
+		&mockConversationRepoForFinalization{}, nil, 0, nil, llmFactory, noopTenantCtxForFinalization(), logger,
 	)
 
 	err := svc.Finalize(ctx, projectID)
@@ -426,8 +435,8 @@ func TestOntologyFinalization_ListTablesError(t *testing.T) {
 	logger := zap.NewNop()
 
 	svc := NewOntologyFinalizationService(
-		projectRepo, schemaRepo, &mockColumnMetadataRepoForFinalization{},
-		&mockConversationRepoForFinalization{}, llmFactory, noopTenantCtxForFinalization(), logger,
+		projectRepo, schemaRepo, &mockColumnMetadataRepoForFinalization{}, nil,
+		&mockConversationRepoForFinalization{}, nil, 0, nil, llmFactory, noopTenantCtxForFinalization(), logger,
 	)
 
 	err := svc.Finalize(ctx, projectID)
@@ -460,8 +469,8 @@ func TestOntologyFinalization_LLMFailure(t *testing.T) {
 	logger := zap.NewNop()
 
 	svc := NewOntologyFinalizationService(
-		projectRepo, schemaRepo, &mockColumnMetadataRepoForFinalization{},
-		&mockConversationRepoForFinalization{}, llmFactory, noopTenantCtxForFinalization(), logger,
+		projectRepo, schemaRepo, &mockColumnMetadataRepoForFinalization{}, nil,
+		&mockConversationRepoForFinalization{}, nil, 0, nil, llmFactory, noopTenantCtxForFinalization(), logger,
 	)
 
 	err := svc.Finalize(ctx, projectID)
@@ -506,7 +515,7 @@ func TestOntologyFinalization_DiscoversSoftDelete_Timestamp(t *testing.T) {
 	llmFactory := &mockLLMFactoryForFinalization{client: llmClient}
 	logger := zap.NewNop()
 
-	svc := NewOntologyFinalizationService(projectRepo, schemaRepo, &mockColumnMetadataRepoForFinalization{}, &mockConversationRepoForFinalization{}, llmFactory, noopTenantCtxForFinalization(), logger)
+	svc := NewOntologyFinalizationService(projectRepo, schemaRepo, &mockColumnMetadataRepoForFinalization{}, nil, &mockConversationRepoForFinalization{}, nil, 0, nil, llmFactory, noopTenantCtxForFinalization(), logger)
 
 	err := svc.Finalize(ctx, projectID)
 	require.NoError(t, err)
@@ -549,7 +558,7 @@ func TestOntologyFinalization_DiscoversSoftDelete_Boolean(t *testing.T) {
 	llmFactory := &mockLLMFactoryForFinalization{client: llmClient}
 	logger := zap.NewNop()
 
-	svc := NewOntologyFinalizationService(projectRepo, schemaRepo, &mockColumnMetadataRepoForFinalization{}, &mockConversationRepoForFinalization{}, llmFactory, noopTenantCtxForFinalization(), logger)
+	svc := NewOntologyFinalizationService(projectRepo, schemaRepo, &mockColumnMetadataRepoForFinalization{}, nil, &mockConversationRepoForFinalization{}, nil, 0, nil, llmFactory, noopTenantCtxForFinalization(), logger)
 
 	err := svc.Finalize(ctx, projectID)
 	require.NoError(t, err)
@@ -588,7 +597,7 @@ func TestOntologyFinalization_DiscoversSoftDelete_Coverage(t *testing.T) {
 	llmFactory := &mockLLMFactoryForFinalization{client: llmClient}
 	logger := zap.NewNop()
 
-	svc := NewOntologyFinalizationService(projectRepo, schemaRepo, &mockColumnMetadataRepoForFinalization{}, &mockConversationRepoForFinalization{}, llmFactory, noopTenantCtxForFinalization(), logger)
+	svc := NewOntologyFinalizationService(projectRepo, schemaRepo, &mockColumnMetadataRepoForFinalization{}, nil, &mockConversationRepoForFinalization{}, nil, 0, nil, llmFactory, noopTenantCtxForFinalization(), logger)
 
 	err := svc.Finalize(ctx, projectID)
 	require.NoError(t, err)
@@ -623,7 +632,7 @@ func TestOntologyFinalization_DiscoversCurrency_Cents(t *testing.T) {
 	llmFactory := &mockLLMFactoryForFinalization{client: llmClient}
 	logger := zap.NewNop()
 
-	svc := NewOntologyFinalizationService(projectRepo, schemaRepo, &mockColumnMetadataRepoForFinalization{}, &mockConversationRepoForFinalization{}, llmFactory, noopTenantCtxForFinalization(), logger)
+	svc := NewOntologyFinalizationService(projectRepo, schemaRepo, &mockColumnMetadataRepoForFinalization{}, nil, &mockConversationRepoForFinalization{}, nil, 0, nil, llmFactory, noopTenantCtxForFinalization(), logger)
 
 	err := svc.Finalize(ctx, projectID)
 	require.NoError(t, err)
@@ -660,7 +669,7 @@ func TestOntologyFinalization_DiscoversCurrency_Dollars(t *testing.T) {
 	llmFactory := &mockLLMFactoryForFinalization{client: llmClient}
 	logger := zap.NewNop()
 
-	svc := NewOntologyFinalizationService(projectRepo, schemaRepo, &mockColumnMetadataRepoForFinalization{}, &mockConversationRepoForFinalization{}, llmFactory, noopTenantCtxForFinalization(), logger)
+	svc := NewOntologyFinalizationService(projectRepo, schemaRepo, &mockColumnMetadataRepoForFinalization{}, nil, &mockConversationRepoForFinalization{}, nil, 0, nil, llmFactory, noopTenantCtxForFinalization(), logger)
 
 	err := svc.Finalize(ctx, projectID)
 	require.NoError(t, err)
@@ -702,7 +711,7 @@ func TestOntologyFinalization_DiscoversAuditColumns_WithCoverage(t *testing.T) {
 	llmFactory := &mockLLMFactoryForFinalization{client: llmClient}
 	logger := zap.NewNop()
 
-	svc := NewOntologyFinalizationService(projectRepo, schemaRepo, &mockColumnMetadataRepoForFinalization{}, &mockConversationRepoForFinalization{}, llmFactory, noopTenantCtxForFinalization(), logger)
+	svc := NewOntologyFinalizationService(projectRepo, schemaRepo, &mockColumnMetadataRepoForFinalization{}, nil, &mockConversationRepoForFinalization{}, nil, 0, nil, llmFactory, noopTenantCtxForFinalization(), logger)
 
 	err := svc.Finalize(ctx, projectID)
 	require.NoError(t, err)
@@ -754,7 +763,7 @@ func TestOntologyFinalization_NoConventions(t *testing.T) {
 	llmFactory := &mockLLMFactoryForFinalization{client: llmClient}
 	logger := zap.NewNop()
 
-	svc := NewOntologyFinalizationService(projectRepo, schemaRepo, &mockColumnMetadataRepoForFinalization{}, &mockConversationRepoForFinalization{}, llmFactory, noopTenantCtxForFinalization(), logger)
+	svc := NewOntologyFinalizationService(projectRepo, schemaRepo, &mockColumnMetadataRepoForFinalization{}, nil, &mockConversationRepoForFinalization{}, nil, 0, nil, llmFactory, noopTenantCtxForFinalization(), logger)
 
 	err := svc.Finalize(ctx, projectID)
 	require.NoError(t, err)
@@ -821,7 +830,7 @@ func TestOntologyFinalization_ExtractsColumnFeatureInsights_SoftDelete(t *testin
 	llmFactory := &mockLLMFactoryForFinalization{client: llmClient}
 	logger := zap.NewNop()
 
-	svc := NewOntologyFinalizationService(projectRepo, schemaRepo, colMetaRepo, &mockConversationRepoForFinalization{}, llmFactory, noopTenantCtxForFinalization(), logger)
+	svc := NewOntologyFinalizationService(projectRepo, schemaRepo, colMetaRepo, nil, &mockConversationRepoForFinalization{}, nil, 0, nil, llmFactory, noopTenantCtxForFinalization(), logger)
 
 	err := svc.Finalize(ctx, projectID)
 	require.NoError(t, err)
@@ -913,7 +922,7 @@ func TestOntologyFinalization_ExtractsColumnFeatureInsights_AuditColumns(t *test
 	llmFactory := &mockLLMFactoryForFinalization{client: llmClient}
 	logger := zap.NewNop()
 
-	svc := NewOntologyFinalizationService(projectRepo, schemaRepo, colMetaRepo, &mockConversationRepoForFinalization{}, llmFactory, noopTenantCtxForFinalization(), logger)
+	svc := NewOntologyFinalizationService(projectRepo, schemaRepo, colMetaRepo, nil, &mockConversationRepoForFinalization{}, nil, 0, nil, llmFactory, noopTenantCtxForFinalization(), logger)
 
 	err := svc.Finalize(ctx, projectID)
 	require.NoError(t, err)
@@ -968,7 +977,7 @@ func TestOntologyFinalization_FallsBackToPatternDetection_WhenNoColumnFeatures(t
 	llmFactory := &mockLLMFactoryForFinalization{client: llmClient}
 	logger := zap.NewNop()
 
-	svc := NewOntologyFinalizationService(projectRepo, schemaRepo, &mockColumnMetadataRepoForFinalization{}, &mockConversationRepoForFinalization{}, llmFactory, noopTenantCtxForFinalization(), logger)
+	svc := NewOntologyFinalizationService(projectRepo, schemaRepo, &mockColumnMetadataRepoForFinalization{}, nil, &mockConversationRepoForFinalization{}, nil, 0, nil, llmFactory, noopTenantCtxForFinalization(), logger)
 
 	err := svc.Finalize(ctx, projectID)
 	require.NoError(t, err)
@@ -984,3 +993,74 @@ func TestOntologyFinalization_FallsBackToPatternDetection_WhenNoColumnFeatures(t
 	// Both created_at and deleted_at are in the auditColumnNames list
 	require.Len(t, projectRepo.updatedDomainSummary.Conventions.AuditColumns, 2)
 }
+
+// tableMetadataRepoForRegenerate records writes so tests can assert table summaries are left alone.
+type tableMetadataRepoForRegenerate struct {
+	mockTableMetadataRepository
+	writes int
+}
+
+func (m *tableMetadataRepoForRegenerate) Upsert(ctx context.Context, meta *models.TableMetadata) error {
+	m.writes++
+	return nil
+}
+
+func (m *tableMetadataRepoForRegenerate) UpsertFromExtraction(ctx context.Context, meta *models.TableMetadata) error {
+	m.writes++
+	return nil
+}
+
+func TestOntologyFinalization_RegenerateDomainSummaryUsesCurrentEntities(t *testing.T) {
+	projectID := uuid.New()
+	datasourceID := uuid.New()
+
+	projectRepo := &mockProjectRepoForFinalization{}
+	schemaRepo := &mockSchemaRepoForFinalization{
+		tables: []*models.SchemaTable{
+			{ID: uuid.New(), ProjectID: projectID, DatasourceID: datasourceID, TableName: "accounts"},
+			{ID: uuid.New(), ProjectID: projectID, DatasourceID: datasourceID, TableName: "invoices"},
+		},
+		columnsByTable: map[string][]*models.SchemaColumn{},
+		relationshipDetails: []*models.RelationshipDetail{
+			{SourceTableName: "invoices", SourceColumnName: "account_id", TargetTableName: "accounts", TargetColumnName: "id", Cardinality: "N:1"},
+		},
+	}
+	accountsSummary := "Customer organizations billed monthly"
+	tableMetaRepo := &tableMetadataRepoForRegenerate{
+		mockTableMetadataRepository: mockTableMetadataRepository{
+			metadataByTableName: map[string]*models.TableMetadata{
+				"accounts": {Description: &accountsSummary},
+			},
+		},
+	}
+	llmClient := &mockLLMClient{
+		responseContent: `{"description": "A billing system that invoices customer accounts."}`,
+	}
+	llmFactory := &mockLLMFactoryForFinalization{client: llmClient}
+
+	svc := NewOntologyFinalizationService(projectRepo, schemaRepo, &mockColumnMetadataRepoForFinalization{}, tableMetaRepo, &mockConversationRepoForFinalization{}, nil, 0, nil, llmFactory, noopTenantCtxForFinalization(), zap.NewNop())
+
+	summary, err := svc.RegenerateDomainSummary(context.Background(), projectID)
+	require.NoError(t, err)
+
+	assert.Contains(t, llmClient.capturedPrompt, "- **accounts**: Customer organizations billed monthly")
+	assert.Contains(t, llmClient.capturedPrompt, "- **invoices**\n")
+	assert.Contains(t, llmClient.capturedPrompt, "invoices.account_id -> accounts.id (N:1)")
+
+	require.NotNil(t, projectRepo.updatedDomainSummary)
+	assert.Equal(t, "A billing system that invoices customer accounts.", projectRepo.updatedDomainSummary.Description)
+	assert.Equal(t, projectRepo.updatedDomainSummary, summary)
+	assert.Zero(t, tableMetaRepo.writes, "regenerating the domain summary must not touch table summaries")
+}
+
+func TestOntologyFinalization_RegenerateDomainSummaryWithoutTables(t *testing.T) {
+	projectRepo := &mockProjectRepoForFinalization{}
+	schemaRepo := &mockSchemaRepoForFinalization{columnsByTable: map[string][]*models.SchemaColumn{}}
+	llmFactory := &mockLLMFactoryForFinalization{client: &mockLLMClient{}}
+
+	svc := NewOntologyFinalizationService(projectRepo, schemaRepo, &mockColumnMetadataRepoForFinalization{}, nil, &mockConversationRepoForFinalization{}, nil, 0, nil, llmFactory, noopTenantCtxForFinalization(), zap.NewNop())
+
+	_, err := svc.RegenerateDomainSummary(context.Background(), uuid.New())
+	assert.ErrorIs(t, err, apperrors.ErrNoSelectedTables)
+	assert.Nil(t, projectRepo.updatedDomainSummary)
+}
@@ -0,0 +1,375 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+	"github.com/ekaya-inc/ekaya-engine/pkg/repositories"
+)
+
+// OntologyHealthService runs deterministic integrity checks over the stored ontology.
+// Unlike the assessment scripts it runs inside the engine, so problems are visible
+// to users before they surface as failing queries.
+type OntologyHealthService interface {
+	// Check runs every integrity check for the datasource.
+	Check(ctx context.Context, projectID, datasourceID uuid.UUID) (*models.OntologyHealthReport, error)
+}
+
+// ontologyHealthDAGRepository is the DAG lookup the stats freshness check needs.
+type ontologyHealthDAGRepository interface {
+	GetLatestByDatasource(ctx context.Context, datasourceID uuid.UUID) (*models.OntologyDAG, error)
+}
+
+type ontologyHealthService struct {
+	schemaRepo             repositories.SchemaRepository
+	projectRepo            repositories.ProjectRepository
+	dagRepo                ontologyHealthDAGRepository
+	graphNodeMatchDistance int // Max edits for suggesting a table for a graph node; 0 disables fuzzy matching
+	statsStaleAfterDays    int // Age in days after which column stats are stale; 0 disables the age check
+	now                    func() time.Time
+	logger                 *zap.Logger
+}
+
+// NewOntologyHealthService creates a new ontology health service.
+// graphNodeMatchDistance is the edit distance within which an unmapped domain graph
+// node is matched to a table for the suggestion in the report. Column stats older
+// than statsStaleAfterDays are reported as stale.
+func NewOntologyHealthService(
+	schemaRepo repositories.SchemaRepository,
+	projectRepo repositories.ProjectRepository,
+	dagRepo ontologyHealthDAGRepository,
+	graphNodeMatchDistance int,
+	statsStaleAfterDays int,
+	logger *zap.Logger,
+) OntologyHealthService {
+	return &ontologyHealthService{
+		schemaRepo:             schemaRepo,
+		projectRepo:            projectRepo,
+		dagRepo:                dagRepo,
+		graphNodeMatchDistance: graphNodeMatchDistance,
+		statsStaleAfterDays:    statsStaleAfterDays,
+		now:                    time.Now,
+		logger:                 logger.Named("ontology-health"),
+	}
+}
+
+var _ OntologyHealthService = (*ontologyHealthService)(nil)
+
+func (s *ontologyHealthService) Check(ctx context.Context, projectID, datasourceID uuid.UUID) (*models.OntologyHealthReport, error) {
+	relationships, err := s.schemaRepo.GetRelationshipDetails(ctx, projectID, datasourceID)
+	if err != nil {
+		return nil, fmt.Errorf("load relationships: %w", err)
+	}
+
+	targetTables := make([]string, 0, len(relationships))
+	seen := make(map[string]bool)
+	for _, rel := range relationships {
+		if !seen[rel.TargetTableName] {
+			seen[rel.TargetTableName] = true
+			targetTables = append(targetTables, rel.TargetTableName)
+		}
+	}
+	columnsByTable := map[string][]*models.SchemaColumn{}
+	tablesByName := map[string]*models.SchemaTable{}
+	if len(targetTables) > 0 {
+		columnsByTable, err = s.schemaRepo.GetColumnsByTables(ctx, projectID, targetTables)
+		if err != nil {
+			return nil, fmt.Errorf("load target table columns: %w", err)
+		}
+		tablesByName, err = s.schemaRepo.GetTablesByNames(ctx, projectID, targetTables)
+		if err != nil {
+			return nil, fmt.Errorf("load target tables: %w", err)
+		}
+	}
+
+	unmappedGraphNodes, err := s.findUnmappedGraphNodes(ctx, projectID, datasourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	cycles, selfRefs := findRelationshipCycles(relationships)
+
+	freshness, err := s.checkStatsFreshness(ctx, projectID, datasourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.OntologyHealthReport{
+		RelationshipsChecked:         len(relationships),
+		IncompatibleRelationships:    findIncompatibleRelationships(relationships),
+		UnknownMethodRelationships:   findUnknownMethodRelationships(relationships),
+		KeylessTargetRelationships:   findKeylessTargetRelationships(relationships, columnsByTable),
+		NonUniqueTargetRelationships: findNonUniqueTargetRelationships(relationships, columnsByTable, tablesByName),
+		UnmappedGraphNodes:           unmappedGraphNodes,
+		RelationshipCycles:           cycles,
+		SelfReferencingRelationships: selfRefs,
+		StatsFreshness:               freshness,
+	}
+	report.Healthy = len(report.IncompatibleRelationships) == 0 &&
+		len(report.UnknownMethodRelationships) == 0 &&
+		len(report.NonUniqueTargetRelationships) == 0 &&
+		len(report.UnmappedGraphNodes) == 0
+
+	if !report.Healthy {
+		s.logger.Warn("Ontology health check found problems",
+			zap.String("project_id", projectID.String()),
+			zap.String("datasource_id", datasourceID.String()),
+			zap.Int("incompatible_relationships", len(report.IncompatibleRelationships)),
+			zap.Int("unknown_method_relationships", len(report.UnknownMethodRelationships)),
+			zap.Int("non_unique_target_relationships", len(report.NonUniqueTargetRelationships)),
+			zap.Int("unmapped_graph_nodes", len(report.UnmappedGraphNodes)))
+	}
+	if len(report.KeylessTargetRelationships) > 0 {
+		s.logger.Warn("Relationships target tables without a primary key and cannot be validated reliably",
+			zap.String("project_id", projectID.String()),
+			zap.String("datasource_id", datasourceID.String()),
+			zap.Int("keyless_target_relationships", len(report.KeylessTargetRelationships)))
+	}
+	if len(report.RelationshipCycles) > 0 {
+		s.logger.Warn("Approved relationships form cycles, which make join paths ambiguous",
+			zap.String("project_id", projectID.String()),
+			zap.String("datasource_id", datasourceID.String()),
+			zap.Int("relationship_cycles", len(report.RelationshipCycles)))
+	}
+	if report.StatsFreshness.Stale {
+		s.logger.Warn("Column stats may be stale; a re-sync is overdue",
+			zap.String("project_id", projectID.String()),
+			zap.String("datasource_id", datasourceID.String()),
+			zap.Strings("reasons", report.StatsFreshness.Reasons))
+	}
+
+	return report, nil
+}
+
+// checkStatsFreshness measures the age of the stats of the datasource's selected
+// columns, and compares the current schema with the fingerprint the latest extraction
+// recorded when it started.
+func (s *ontologyHealthService) checkStatsFreshness(ctx context.Context, projectID, datasourceID uuid.UUID) (models.StatsFreshness, error) {
+	tables, err := s.schemaRepo.ListTablesByDatasource(ctx, projectID, datasourceID)
+	if err != nil {
+		return models.StatsFreshness{}, fmt.Errorf("load tables: %w", err)
+	}
+	columns, err := s.schemaRepo.ListColumnsByDatasource(ctx, projectID, datasourceID)
+	if err != nil {
+		return models.StatsFreshness{}, fmt.Errorf("load columns: %w", err)
+	}
+
+	var recordedFingerprint string
+	if s.dagRepo != nil {
+		latest, err := s.dagRepo.GetLatestByDatasource(ctx, datasourceID)
+		if err != nil {
+			return models.StatsFreshness{}, fmt.Errorf("load latest extraction: %w", err)
+		}
+		if latest != nil && latest.SchemaFingerprint != nil {
+			recordedFingerprint = *latest.SchemaFingerprint
+		}
+	}
+
+	return computeStatsFreshness(tables, columns, recordedFingerprint, s.statsStaleAfterDays, s.now()), nil
+}
+
+// computeStatsFreshness reports the oldest and newest stats among the selected columns
+// of tables and flags them stale when any predate staleAfterDays, or when the schema
+// no longer matches recordedFingerprint. An empty fingerprint (no extraction has run,
+// or it predates fingerprinting) skips the schema check.
+func computeStatsFreshness(
+	tables []*models.SchemaTable,
+	columns []*models.SchemaColumn,
+	recordedFingerprint string,
+	staleAfterDays int,
+	now time.Time,
+) models.StatsFreshness {
+	freshness := models.StatsFreshness{
+		StaleAfterDays: staleAfterDays,
+		Reasons:        []string{},
+	}
+
+	selectedTables := make(map[uuid.UUID]bool, len(tables))
+	for _, t := range tables {
+		selectedTables[t.ID] = true
+	}
+	cutoff := now.AddDate(0, 0, -staleAfterDays)
+	for _, col := range columns {
+		if !col.IsSelected || !selectedTables[col.SchemaTableID] {
+			continue
+		}
+		if col.StatsUpdatedAt == nil {
+			freshness.ColumnsWithoutStats++
+			continue
+		}
+		freshness.ColumnsWithStats++
+		at := *col.StatsUpdatedAt
+		if freshness.OldestStatsAt == nil || at.Before(*freshness.OldestStatsAt) {
+			freshness.OldestStatsAt = &at
+		}
+		if freshness.NewestStatsAt == nil || at.After(*freshness.NewestStatsAt) {
+			freshness.NewestStatsAt = &at
+		}
+		if staleAfterDays > 0 && at.Before(cutoff) {
+			freshness.StaleColumns++
+		}
+	}
+
+	if freshness.OldestStatsAt != nil {
+		freshness.OldestStatsAgeHours = now.Sub(*freshness.OldestStatsAt).Hours()
+		freshness.NewestStatsAgeHours = now.Sub(*freshness.NewestStatsAt).Hours()
+	}
+	if freshness.StaleColumns > 0 {
+		freshness.Reasons = append(freshness.Reasons, fmt.Sprintf(
+			"%d column(s) have stats older than %d days (oldest gathered %s)",
+			freshness.StaleColumns, staleAfterDays, freshness.OldestStatsAt.Format(time.RFC3339)))
+	}
+	if recordedFingerprint != "" && schemaFingerprint(tables, columns) != recordedFingerprint {
+		freshness.SchemaChanged = true
+		freshness.Reasons = append(freshness.Reasons, "the schema changed since the last extraction")
+	}
+	freshness.Stale = len(freshness.Reasons) > 0
+
+	return freshness
+}
+
+// findUnmappedGraphNodes checks the project's domain relationship graph against the
+// datasource's selected tables. Projects without a graph have nothing to check.
+func (s *ontologyHealthService) findUnmappedGraphNodes(ctx context.Context, projectID, datasourceID uuid.UUID) ([]models.UnmappedGraphNode, error) {
+	project, err := s.projectRepo.Get(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("load project: %w", err)
+	}
+	if project == nil || project.DomainSummary == nil || len(project.DomainSummary.RelationshipGraph) == 0 {
+		return []models.UnmappedGraphNode{}, nil
+	}
+
+	tables, err := s.schemaRepo.ListTablesByDatasource(ctx, projectID, datasourceID)
+	if err != nil {
+		return nil, fmt.Errorf("load tables: %w", err)
+	}
+	tableNames := make([]string, 0, len(tables))
+	for _, table := range tables {
+		tableNames = append(tableNames, table.TableName)
+	}
+
+	return findUnmappedGraphNodes(project.DomainSummary.RelationshipGraph, tableNames, s.graphNodeMatchDistance), nil
+}
+
+// findIncompatibleRelationships flags relationships joining column types that the
+// PK-match type matrix rejects. Discovery never creates these, but a loosened type
+// matcher or a manual edit can.
+func findIncompatibleRelationships(relationships []*models.RelationshipDetail) []models.RelationshipTypeMismatch {
+	mismatches := []models.RelationshipTypeMismatch{}
+	for _, rel := range relationships {
+		if models.AreTypesCompatibleForFK(rel.SourceColumnType, rel.TargetColumnType) {
+			continue
+		}
+		mismatches = append(mismatches, models.RelationshipTypeMismatch{
+			RelationshipID: rel.ID,
+			SourceTable:    rel.SourceTableName,
+			SourceColumn:   rel.SourceColumnName,
+			SourceType:     rel.SourceColumnType,
+			TargetTable:    rel.TargetTableName,
+			TargetColumn:   rel.TargetColumnName,
+			TargetType:     rel.TargetColumnType,
+		})
+	}
+	return mismatches
+}
+
+// findUnknownMethodRelationships flags relationships stored without an inference
+// method, or with one models.IsKnownInferenceMethod does not recognize. Every write
+// path sets one, so these come from an import, a manual SQL edit or a method that was
+// renamed without a migration.
+func findUnknownMethodRelationships(relationships []*models.RelationshipDetail) []models.UnknownMethodRelationship {
+	unknown := []models.UnknownMethodRelationship{}
+	for _, rel := range relationships {
+		var method string
+		if rel.InferenceMethod != nil {
+			method = *rel.InferenceMethod
+		}
+		if models.IsKnownInferenceMethod(method) {
+			continue
+		}
+		unknown = append(unknown, models.UnknownMethodRelationship{
+			RelationshipID:  rel.ID,
+			SourceTable:     rel.SourceTableName,
+			SourceColumn:    rel.SourceColumnName,
+			TargetTable:     rel.TargetTableName,
+			TargetColumn:    rel.TargetColumnName,
+			InferenceMethod: method,
+		})
+	}
+	return unknown
+}
+
+// findKeylessTargetRelationships flags relationships whose target table has no
+// primary key (see detectTablesWithoutPrimaryKey). Target tables whose columns were
+// not loaded are skipped rather than reported.
+func findKeylessTargetRelationships(relationships []*models.RelationshipDetail, columnsByTable map[string][]*models.SchemaColumn) []models.KeylessTargetRelationship {
+	noPrimaryKey := detectTablesWithoutPrimaryKey(columnsByTable)
+	keyless := []models.KeylessTargetRelationship{}
+	for _, rel := range relationships {
+		if !noPrimaryKey[rel.TargetTableName] {
+			continue
+		}
+		keyless = append(keyless, models.KeylessTargetRelationship{
+			RelationshipID: rel.ID,
+			SourceTable:    rel.SourceTableName,
+			SourceColumn:   rel.SourceColumnName,
+			TargetTable:    rel.TargetTableName,
+			TargetColumn:   rel.TargetColumnName,
+		})
+	}
+	return keyless
+}
+
+// findNonUniqueTargetRelationships flags relationships whose target column is not a
+// primary key, is not declared unique, and whose distinct count does not cover the
+// table's row count. SQL generation assumes the target side of a join is unique, so
+// these relationships can silently duplicate rows. Relationships into tables without
+// a primary key are left to findKeylessTargetRelationships, and target columns that
+// were not loaded are skipped rather than reported.
+func findNonUniqueTargetRelationships(
+	relationships []*models.RelationshipDetail,
+	columnsByTable map[string][]*models.SchemaColumn,
+	tablesByName map[string]*models.SchemaTable,
+) []models.NonUniqueTargetRelationship {
+	noPrimaryKey := detectTablesWithoutPrimaryKey(columnsByTable)
+	nonUnique := []models.NonUniqueTargetRelationship{}
+	for _, rel := range relationships {
+		if noPrimaryKey[rel.TargetTableName] {
+			continue
+		}
+		var col *models.SchemaColumn
+		for _, c := range columnsByTable[rel.TargetTableName] {
+			if c.ColumnName == rel.TargetColumnName {
+				col = c
+				break
+			}
+		}
+		if col == nil || col.IsPrimaryKey || col.IsUnique {
+			continue
+		}
+
+		rowCount := col.RowCount
+		if table := tablesByName[rel.TargetTableName]; table != nil && table.RowCount != nil {
+			rowCount = table.RowCount
+		}
+		if col.DistinctCount != nil && rowCount != nil && *rowCount > 0 && *col.DistinctCount >= *rowCount {
+			continue
+		}
+
+		nonUnique = append(nonUnique, models.NonUniqueTargetRelationship{
+			RelationshipID: rel.ID,
+			SourceTable:    rel.SourceTableName,
+			SourceColumn:   rel.SourceColumnName,
+			TargetTable:    rel.TargetTableName,
+			TargetColumn:   rel.TargetColumnName,
+			DistinctCount:  col.DistinctCount,
+			RowCount:       rowCount,
+		})
+	}
+	return nonUnique
+}
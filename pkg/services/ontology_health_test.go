@@ -0,0 +1,404 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+	"github.com/ekaya-inc/ekaya-engine/pkg/repositories"
+)
+
+// mockSchemaRepoForHealth implements only the methods used by the health checks.
+type mockSchemaRepoForHealth struct {
+	repositories.SchemaRepository
+	relationships  []*models.RelationshipDetail
+	columnsByTable map[string][]*models.SchemaColumn
+	tablesByName   map[string]*models.SchemaTable
+	tables         []*models.SchemaTable
+	columns        []*models.SchemaColumn
+}
+
+func (m *mockSchemaRepoForHealth) ListTablesByDatasource(_ context.Context, _, _ uuid.UUID) ([]*models.SchemaTable, error) {
+	return m.tables, nil
+}
+
+func (m *mockSchemaRepoForHealth) ListColumnsByDatasource(_ context.Context, _, _ uuid.UUID) ([]*models.SchemaColumn, error) {
+	return m.columns, nil
+}
+
+// mockDAGRepoForHealth serves the latest DAG, or none.
+type mockDAGRepoForHealth struct {
+	latest *models.OntologyDAG
+}
+
+func (m *mockDAGRepoForHealth) GetLatestByDatasource(_ context.Context, _ uuid.UUID) (*models.OntologyDAG, error) {
+	return m.latest, nil
+}
+
+// mockProjectRepoForHealth serves a single project, or none.
+type mockProjectRepoForHealth struct {
+	repositories.ProjectRepository
+	project *models.Project
+}
+
+func (m *mockProjectRepoForHealth) Get(_ context.Context, _ uuid.UUID) (*models.Project, error) {
+	return m.project, nil
+}
+
+func (m *mockSchemaRepoForHealth) GetRelationshipDetails(_ context.Context, _, _ uuid.UUID) ([]*models.RelationshipDetail, error) {
+	return m.relationships, nil
+}
+
+func (m *mockSchemaRepoForHealth) GetColumnsByTables(_ context.Context, _ uuid.UUID, tableNames []string) (map[string][]*models.SchemaColumn, error) {
+	result := make(map[string][]*models.SchemaColumn)
+	for _, name := range tableNames {
+		if cols, ok := m.columnsByTable[name]; ok {
+			result[name] = cols
+		}
+	}
+	return result, nil
+}
+
+func (m *mockSchemaRepoForHealth) GetTablesByNames(_ context.Context, _ uuid.UUID, tableNames []string) (map[string]*models.SchemaTable, error) {
+	result := make(map[string]*models.SchemaTable)
+	for _, name := range tableNames {
+		if table, ok := m.tablesByName[name]; ok {
+			result[name] = table
+		}
+	}
+	return result, nil
+}
+
+func TestOntologyHealthService_Check_FlagsIncompatibleRelationshipTypes(t *testing.T) {
+	badID := uuid.New()
+	repo := &mockSchemaRepoForHealth{relationships: []*models.RelationshipDetail{
+		{
+			ID:              uuid.New(),
+			SourceTableName: "orders", SourceColumnName: "customer_id", SourceColumnType: "int4",
+			TargetTableName: "customers", TargetColumnName: "id", TargetColumnType: "bigint",
+		},
+		{
+			ID:              uuid.New(),
+			SourceTableName: "sessions", SourceColumnName: "user_id", SourceColumnType: "character varying(36)",
+			TargetTableName: "users", TargetColumnName: "id", TargetColumnType: "uuid",
+		},
+		{
+			ID:              badID,
+			SourceTableName: "payments", SourceColumnName: "account_id", SourceColumnType: "uuid",
+			TargetTableName: "accounts", TargetColumnName: "id", TargetColumnType: "bigint",
+		},
+	}}
+	svc := NewOntologyHealthService(repo, &mockProjectRepoForHealth{}, nil, 2, 0, zap.NewNop())
+
+	report, err := svc.Check(context.Background(), uuid.New(), uuid.New())
+	require.NoError(t, err)
+
+	assert.False(t, report.Healthy)
+	assert.Equal(t, 3, report.RelationshipsChecked)
+	require.Len(t, report.IncompatibleRelationships, 1)
+	mismatch := report.IncompatibleRelationships[0]
+	assert.Equal(t, badID, mismatch.RelationshipID)
+	assert.Equal(t, "payments", mismatch.SourceTable)
+	assert.Equal(t, "uuid", mismatch.SourceType)
+	assert.Equal(t, "bigint", mismatch.TargetType)
+}
+
+func TestOntologyHealthService_Check_HealthyWithoutRelationships(t *testing.T) {
+	svc := NewOntologyHealthService(&mockSchemaRepoForHealth{}, &mockProjectRepoForHealth{}, nil, 2, 0, zap.NewNop())
+
+	report, err := svc.Check(context.Background(), uuid.New(), uuid.New())
+	require.NoError(t, err)
+
+	assert.True(t, report.Healthy)
+	assert.Empty(t, report.IncompatibleRelationships)
+}
+
+func TestOntologyHealthService_Check_FlagsRelationshipsWithoutInferenceMethod(t *testing.T) {
+	fk := models.InferenceMethodFK
+	manual := models.RelationshipTypeManual
+	legacy := "heuristic"
+	missingID, legacyID := uuid.New(), uuid.New()
+	repo := &mockSchemaRepoForHealth{relationships: []*models.RelationshipDetail{
+		{
+			ID:              uuid.New(),
+			SourceTableName: "orders", SourceColumnName: "customer_id", SourceColumnType: "bigint",
+			TargetTableName: "customers", TargetColumnName: "id", TargetColumnType: "bigint",
+			InferenceMethod: &fk,
+		},
+		{
+			ID:              uuid.New(),
+			SourceTableName: "invoices", SourceColumnName: "order_id", SourceColumnType: "bigint",
+			TargetTableName: "orders", TargetColumnName: "id", TargetColumnType: "bigint",
+			InferenceMethod: &manual,
+		},
+		{
+			ID:              missingID,
+			SourceTableName: "payments", SourceColumnName: "invoice_id", SourceColumnType: "bigint",
+			TargetTableName: "invoices", TargetColumnName: "id", TargetColumnType: "bigint",
+		},
+		{
+			ID:              legacyID,
+			SourceTableName: "refunds", SourceColumnName: "payment_id", SourceColumnType: "bigint",
+			TargetTableName: "payments", TargetColumnName: "id", TargetColumnType: "bigint",
+			InferenceMethod: &legacy,
+		},
+	}}
+	svc := NewOntologyHealthService(repo, &mockProjectRepoForHealth{}, nil, 2, 0, zap.NewNop())
+
+	report, err := svc.Check(context.Background(), uuid.New(), uuid.New())
+	require.NoError(t, err)
+
+	assert.False(t, report.Healthy, "relationships without a known inference method are integrity issues")
+	require.Len(t, report.UnknownMethodRelationships, 2)
+	assert.Equal(t, missingID, report.UnknownMethodRelationships[0].RelationshipID)
+	assert.Equal(t, "payments", report.UnknownMethodRelationships[0].SourceTable)
+	assert.Empty(t, report.UnknownMethodRelationships[0].InferenceMethod)
+	assert.Equal(t, legacyID, report.UnknownMethodRelationships[1].RelationshipID)
+	assert.Equal(t, "heuristic", report.UnknownMethodRelationships[1].InferenceMethod)
+}
+
+func TestOntologyHealthService_Check_WarnsOnKeylessTargetTables(t *testing.T) {
+	keylessID := uuid.New()
+	fk := models.InferenceMethodFK
+	repo := &mockSchemaRepoForHealth{
+		relationships: []*models.RelationshipDetail{
+			{
+				ID:              uuid.New(),
+				SourceTableName: "orders", SourceColumnName: "customer_id", SourceColumnType: "bigint",
+				TargetTableName: "customers", TargetColumnName: "id", TargetColumnType: "bigint",
+				InferenceMethod: &fk,
+			},
+			{
+				ID:              keylessID,
+				SourceTableName: "orders", SourceColumnName: "import_ref", SourceColumnType: "text",
+				TargetTableName: "legacy_imports", TargetColumnName: "ref", TargetColumnType: "text",
+				InferenceMethod: &fk,
+			},
+		},
+		columnsByTable: map[string][]*models.SchemaColumn{
+			"customers":      {{ColumnName: "id", IsPrimaryKey: true}},
+			"legacy_imports": {{ColumnName: "ref"}, {ColumnName: "payload"}},
+		},
+	}
+	svc := NewOntologyHealthService(repo, &mockProjectRepoForHealth{}, nil, 2, 0, zap.NewNop())
+
+	report, err := svc.Check(context.Background(), uuid.New(), uuid.New())
+	require.NoError(t, err)
+
+	assert.True(t, report.Healthy, "keyless targets are warnings, not failures")
+	require.Len(t, report.KeylessTargetRelationships, 1)
+	assert.Equal(t, keylessID, report.KeylessTargetRelationships[0].RelationshipID)
+	assert.Equal(t, "legacy_imports", report.KeylessTargetRelationships[0].TargetTable)
+}
+
+func TestOntologyHealthService_Check_FlagsNonUniqueTargetColumns(t *testing.T) {
+	int64Ptr := func(v int64) *int64 { return &v }
+	nonUniqueID := uuid.New()
+	repo := &mockSchemaRepoForHealth{
+		relationships: []*models.RelationshipDetail{
+			{
+				ID:              uuid.New(),
+				SourceTableName: "orders", SourceColumnName: "customer_id", SourceColumnType: "bigint",
+				TargetTableName: "customers", TargetColumnName: "id", TargetColumnType: "bigint",
+			},
+			{
+				ID:              uuid.New(),
+				SourceTableName: "orders", SourceColumnName: "customer_email", SourceColumnType: "text",
+				TargetTableName: "customers", TargetColumnName: "email", TargetColumnType: "text",
+			},
+			{
+				ID:              nonUniqueID,
+				SourceTableName: "orders", SourceColumnName: "region", SourceColumnType: "text",
+				TargetTableName: "customers", TargetColumnName: "region", TargetColumnType: "text",
+			},
+		},
+		columnsByTable: map[string][]*models.SchemaColumn{
+			"customers": {
+				{ColumnName: "id", IsPrimaryKey: true},
+				{ColumnName: "email", DistinctCount: int64Ptr(500)},
+				{ColumnName: "region", DistinctCount: int64Ptr(12)},
+			},
+		},
+		tablesByName: map[string]*models.SchemaTable{
+			"customers": {TableName: "customers", RowCount: int64Ptr(500)},
+		},
+	}
+	svc := NewOntologyHealthService(repo, &mockProjectRepoForHealth{}, nil, 2, 0, zap.NewNop())
+
+	report, err := svc.Check(context.Background(), uuid.New(), uuid.New())
+	require.NoError(t, err)
+
+	assert.False(t, report.Healthy)
+	assert.Empty(t, report.IncompatibleRelationships)
+	require.Len(t, report.NonUniqueTargetRelationships, 1, "PK and fully distinct targets should pass")
+	flagged := report.NonUniqueTargetRelationships[0]
+	assert.Equal(t, nonUniqueID, flagged.RelationshipID)
+	assert.Equal(t, "region", flagged.TargetColumn)
+	require.NotNil(t, flagged.DistinctCount)
+	assert.Equal(t, int64(12), *flagged.DistinctCount)
+	require.NotNil(t, flagged.RowCount)
+	assert.Equal(t, int64(500), *flagged.RowCount)
+}
+
+func TestOntologyHealthService_Check_FlagsGraphNodesWithoutTables(t *testing.T) {
+	repo := &mockSchemaRepoForHealth{tables: []*models.SchemaTable{
+		{TableName: "orders"}, {TableName: "customers"}, {TableName: "order_items"},
+	}}
+	projectRepo := &mockProjectRepoForHealth{project: &models.Project{
+		DomainSummary: &models.DomainSummary{RelationshipGraph: []models.RelationshipEdge{
+			{From: "customers", To: "order", Label: "places"},
+			{From: "order", To: "order_items", Label: "contains"},
+			{From: "orders", To: "shipments", Label: "ships"},
+		}},
+	}}
+	svc := NewOntologyHealthService(repo, projectRepo, nil, 2, 0, zap.NewNop())
+
+	report, err := svc.Check(context.Background(), uuid.New(), uuid.New())
+	require.NoError(t, err)
+
+	assert.False(t, report.Healthy)
+	assert.Equal(t, []models.UnmappedGraphNode{
+		{Node: "order", SuggestedTable: "orders"},
+		{Node: "shipments"},
+	}, report.UnmappedGraphNodes)
+}
+
+func TestOntologyHealthService_Check_ReportsRelationshipCycles(t *testing.T) {
+	rejected := false
+	fk := models.InferenceMethodFK
+	rel := func(source, sourceColumn, target string) *models.RelationshipDetail {
+		return &models.RelationshipDetail{
+			ID:              uuid.New(),
+			SourceTableName: source, SourceColumnName: sourceColumn, SourceColumnType: "uuid",
+			TargetTableName: target, TargetColumnName: "id", TargetColumnType: "uuid",
+			InferenceMethod: &fk,
+		}
+	}
+	ordersToCustomers := rel("orders", "customer_id", "customers")
+	customersToAccounts := rel("customers", "account_id", "accounts")
+	accountsToOrders := rel("accounts", "last_order_id", "orders")
+	managerRef := rel("employees", "manager_id", "employees")
+	rejectedBackRef := rel("invoices", "payment_id", "payments")
+	rejectedBackRef.IsApproved = &rejected
+
+	repo := &mockSchemaRepoForHealth{relationships: []*models.RelationshipDetail{
+		ordersToCustomers,
+		customersToAccounts,
+		accountsToOrders,
+		managerRef,
+		rel("line_items", "order_id", "orders"),
+		rel("payments", "invoice_id", "invoices"),
+		rejectedBackRef,
+	}}
+	svc := NewOntologyHealthService(repo, &mockProjectRepoForHealth{}, nil, 2, 0, zap.NewNop())
+
+	report, err := svc.Check(context.Background(), uuid.New(), uuid.New())
+	require.NoError(t, err)
+
+	require.Len(t, report.RelationshipCycles, 1, "the rejected invoices/payments back-reference must not form a cycle")
+	cycle := report.RelationshipCycles[0]
+	assert.Equal(t, []string{"accounts", "customers", "orders"}, cycle.Tables)
+	assert.Equal(t, []string{"accounts", "orders", "customers", "accounts"}, cycle.Path)
+	assert.ElementsMatch(t, []uuid.UUID{ordersToCustomers.ID, customersToAccounts.ID, accountsToOrders.ID}, cycle.RelationshipIDs)
+
+	require.Len(t, report.SelfReferencingRelationships, 1)
+	assert.Equal(t, managerRef.ID, report.SelfReferencingRelationships[0].RelationshipID)
+	assert.Equal(t, "employees", report.SelfReferencingRelationships[0].Table)
+
+	assert.True(t, report.Healthy, "cycles are warnings")
+}
+
+func TestMatchGraphNode(t *testing.T) {
+	tables := []string{"orders", "order_items", "customers", "users", "uses"}
+	tests := []struct {
+		node        string
+		maxDistance int
+		want        string
+	}{
+		{"orders", 2, "orders"},
+		{"public.Orders", 0, "orders"},
+		{"order", 2, "orders"},
+		{"order", 0, ""},
+		{"customer", 2, "customers"},
+		{"OrderItem", 2, "order_items"},
+		{"order_item", 2, "order_items"},
+		{"custmers", 2, "customers"},
+		{"usrs", 2, ""}, // one edit from both users and uses
+		{"shipments", 2, ""},
+	}
+	for _, tt := range tests {
+		got, _ := matchGraphNode(tt.node, tables, tt.maxDistance)
+		assert.Equal(t, tt.want, got, "node %q with distance %d", tt.node, tt.maxDistance)
+	}
+}
+
+func statsFreshnessSchema(statsAt time.Time) ([]*models.SchemaTable, []*models.SchemaColumn) {
+	table := &models.SchemaTable{ID: uuid.New(), SchemaName: "public", TableName: "orders"}
+	return []*models.SchemaTable{table}, []*models.SchemaColumn{
+		{SchemaTableID: table.ID, ColumnName: "id", DataType: "uuid", IsPrimaryKey: true, IsSelected: true, StatsUpdatedAt: &statsAt},
+		{SchemaTableID: table.ID, ColumnName: "status", DataType: "text", IsSelected: true},
+		{SchemaTableID: table.ID, ColumnName: "notes", DataType: "text", StatsUpdatedAt: &statsAt},
+	}
+}
+
+func TestOntologyHealthService_Check_ReportsStaleStats(t *testing.T) {
+	now := time.Date(2026, time.October, 17, 12, 0, 0, 0, time.UTC)
+	tables, columns := statsFreshnessSchema(now.AddDate(0, 0, -45))
+	fingerprint := schemaFingerprint(tables, columns)
+	svc := NewOntologyHealthService(
+		&mockSchemaRepoForHealth{tables: tables, columns: columns},
+		&mockProjectRepoForHealth{},
+		&mockDAGRepoForHealth{latest: &models.OntologyDAG{SchemaFingerprint: &fingerprint}},
+		2, 30, zap.NewNop()).(*ontologyHealthService)
+	svc.now = func() time.Time { return now }
+
+	report, err := svc.Check(context.Background(), uuid.New(), uuid.New())
+	require.NoError(t, err)
+
+	freshness := report.StatsFreshness
+	assert.True(t, report.Healthy, "stale stats are warnings, not failures")
+	assert.True(t, freshness.Stale)
+	assert.False(t, freshness.SchemaChanged)
+	assert.Equal(t, 1, freshness.ColumnsWithStats, "only selected columns are counted")
+	assert.Equal(t, 1, freshness.ColumnsWithoutStats)
+	assert.Equal(t, 1, freshness.StaleColumns)
+	assert.Equal(t, float64(45*24), freshness.OldestStatsAgeHours)
+	require.Len(t, freshness.Reasons, 1)
+	assert.Contains(t, freshness.Reasons[0], "older than 30 days")
+}
+
+func TestComputeStatsFreshness(t *testing.T) {
+	now := time.Date(2026, time.October, 17, 12, 0, 0, 0, time.UTC)
+	tables, columns := statsFreshnessSchema(now.AddDate(0, 0, -3))
+	fingerprint := schemaFingerprint(tables, columns)
+
+	t.Run("fresh stats and unchanged schema", func(t *testing.T) {
+		freshness := computeStatsFreshness(tables, columns, fingerprint, 30, now)
+		assert.False(t, freshness.Stale)
+		assert.Empty(t, freshness.Reasons)
+		assert.Equal(t, float64(72), freshness.NewestStatsAgeHours)
+	})
+
+	t.Run("schema changed since the last extraction", func(t *testing.T) {
+		changed := append([]*models.SchemaColumn{}, columns...)
+		changed = append(changed, &models.SchemaColumn{SchemaTableID: tables[0].ID, ColumnName: "total", DataType: "numeric", IsSelected: true})
+
+		freshness := computeStatsFreshness(tables, changed, fingerprint, 30, now)
+		assert.True(t, freshness.Stale)
+		assert.True(t, freshness.SchemaChanged)
+		assert.Equal(t, []string{"the schema changed since the last extraction"}, freshness.Reasons)
+	})
+
+	t.Run("age check disabled", func(t *testing.T) {
+		old, oldColumns := statsFreshnessSchema(now.AddDate(-1, 0, 0))
+		freshness := computeStatsFreshness(old, oldColumns, "", 0, now)
+		assert.False(t, freshness.Stale)
+		assert.Equal(t, 0, freshness.StaleColumns)
+	})
+}
@@ -756,6 +756,18 @@ func (s *ontologyImportService) updateProjectOntologyState(ctx context.Context,
 
 	var domainSummaryJSON *string
 	if plan.bundle.Project.DomainSummary != nil {
+		for _, edge := range plan.bundle.Project.DomainSummary.NormalizeCardinalities() {
+			s.logger.Warn("Domain graph edge has unrecognized cardinality",
+				zap.String("project_id", plan.project.ID.String()),
+				zap.String("from", edge.From),
+				zap.String("to", edge.To),
+				zap.String("cardinality", edge.Cardinality))
+		}
+		if removed := plan.bundle.Project.DomainSummary.CollapseBridgeNodes(bundleBridgeTables(plan.bundle)); len(removed) > 0 {
+			s.logger.Debug("Replaced bridge table nodes in domain graph with many-to-many edges",
+				zap.String("project_id", plan.project.ID.String()),
+				zap.Strings("bridge_tables", removed))
+		}
 		raw, err := marshalJSONText(plan.bundle.Project.DomainSummary)
 		if err != nil {
 			return fmt.Errorf("marshal domain summary: %w", err)
@@ -779,6 +791,18 @@ func (s *ontologyImportService) updateProjectOntologyState(ctx context.Context,
 	return nil
 }
 
+// bundleBridgeTables maps each bridge table in the bundle's table metadata to the
+// tables it links.
+func bundleBridgeTables(bundle *models.OntologyExportBundle) map[string][]string {
+	bridges := make(map[string][]string)
+	for _, item := range bundle.Ontology.TableMetadata {
+		if item.Features.Bridge != nil {
+			bridges[item.Table.TableName] = item.Features.Bridge.LinkedTables
+		}
+	}
+	return bridges
+}
+
 func decodeOntologyImportBundle(bundleBytes []byte) (*models.OntologyExportBundle, error) {
 	var bundle models.OntologyExportBundle
 	decoder := json.NewDecoder(strings.NewReader(string(bundleBytes)))
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Extraction profiles bundle coherent OntologySettings values so operators can pick
+// a cost/quality trade-off without tuning every field.
+const (
+	// OntologyProfileFast minimizes tokens: columns are batched into the largest
+	// prompts the budget allows, name patterns prune FK candidates before any data
+	// is read, and few questions are kept per table.
+	OntologyProfileFast = "fast"
+
+	// OntologyProfileBalanced is the default and matches the settings used before
+	// profiles existed.
+	OntologyProfileBalanced = "balanced"
+
+	// OntologyProfileThorough maximizes quality: smaller prompts keep each batch close
+	// to a single table, every column is judged on its data rather than its name, enum
+	// activity is measured over a full year and more questions are kept.
+	OntologyProfileThorough = "thorough"
+)
+
+// DefaultOntologyProfile is used when a project has not selected a profile.
+const DefaultOntologyProfile = OntologyProfileBalanced
+
+// ontologyProfiles holds the settings each profile applies before per-field overrides.
+// MaxQuestionsPerTable 0 defers to the server-wide ontology.max_questions_per_table.
+var ontologyProfiles = map[string]OntologySettings{
+	OntologyProfileFast: {
+		UseLegacyPatternMatching: true,
+		MaxPromptTokens:          DefaultMaxPromptTokens,
+		EnumActivityWindowDays:   30,
+		MaxQuestionsPerTable:     3,
+	},
+	OntologyProfileBalanced: {
+		UseLegacyPatternMatching: true,
+		MaxPromptTokens:          DefaultMaxPromptTokens,
+		EnumActivityWindowDays:   DefaultEnumActivityWindowDays,
+	},
+	OntologyProfileThorough: {
+		UseLegacyPatternMatching: false,
+		MaxPromptTokens:          40_000,
+		EnumActivityWindowDays:   365,
+		MaxQuestionsPerTable:     10,
+	},
+}
+
+// OntologyProfiles returns the names of the available extraction profiles, sorted.
+func OntologyProfiles() []string {
+	names := make([]string, 0, len(ontologyProfiles))
+	for name := range ontologyProfiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// OntologyProfileSettings returns a copy of the named profile's settings. An empty
+// name selects DefaultOntologyProfile. Callers selecting a profile start from these
+// settings and change only the fields they want to override before saving them.
+func OntologyProfileSettings(profile string) (*OntologySettings, error) {
+	if profile == "" {
+		profile = DefaultOntologyProfile
+	}
+	base, ok := ontologyProfiles[profile]
+	if !ok {
+		return nil, fmt.Errorf("unknown ontology profile %q (valid: %v)", profile, OntologyProfiles())
+	}
+	base.Profile = profile
+	return &base, nil
+}
+
+// ontologySettingsOverrides returns the fields of settings that differ from its
+// profile, in the form stored under the "ontology" project parameter. Fields that
+// match the profile are left out so they follow the profile if it changes.
+func ontologySettingsOverrides(settings *OntologySettings) (map[string]interface{}, error) {
+	base, err := OntologyProfileSettings(settings.Profile)
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]interface{}{"profile": base.Profile}
+	if settings.UseLegacyPatternMatching != base.UseLegacyPatternMatching {
+		params["use_legacy_pattern_matching"] = settings.UseLegacyPatternMatching
+	}
+	if settings.MaxPromptTokens > 0 && settings.MaxPromptTokens != base.MaxPromptTokens {
+		params["max_prompt_tokens"] = settings.MaxPromptTokens
+	}
+	if settings.EnumActivityWindowDays > 0 && settings.EnumActivityWindowDays != base.EnumActivityWindowDays {
+		params["enum_activity_window_days"] = settings.EnumActivityWindowDays
+	}
+	if settings.MaxQuestionsPerTable > 0 && settings.MaxQuestionsPerTable != base.MaxQuestionsPerTable {
+		params["max_questions_per_table"] = settings.MaxQuestionsPerTable
+	}
+	if settings.TokenBudget > 0 {
+		params["token_budget"] = settings.TokenBudget
+	}
+	if len(settings.ColumnEntityOverrides) > 0 {
+		overrides := make(map[string]interface{}, len(settings.ColumnEntityOverrides))
+		for key, table := range settings.ColumnEntityOverrides {
+			if !strings.Contains(key, ".") || strings.TrimSpace(table) == "" {
+				return nil, fmt.Errorf("invalid column entity override %q: expected \"table.column\" mapped to a table name", key)
+			}
+			overrides[columnEntityOverrideKey(key)] = strings.TrimSpace(table)
+		}
+		params["column_entity_overrides"] = overrides
+	}
+	if len(settings.CriticalTables) > 0 {
+		seen := make(map[string]bool, len(settings.CriticalTables))
+		tables := make([]string, 0, len(settings.CriticalTables))
+		for _, table := range settings.CriticalTables {
+			table = strings.TrimSpace(table)
+			if table == "" || seen[strings.ToLower(table)] {
+				continue
+			}
+			seen[strings.ToLower(table)] = true
+			tables = append(tables, table)
+		}
+		if len(tables) > 0 {
+			params["critical_tables"] = tables
+		}
+	}
+	return params, nil
+}
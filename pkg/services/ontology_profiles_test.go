@@ -0,0 +1,127 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// storedOntologyParameters round-trips settings through the JSONB form SetOntologySettings
+// writes, so numbers come back as float64 like they do from the database.
+func storedOntologyParameters(t *testing.T, settings *OntologySettings) map[string]interface{} {
+	t.Helper()
+	overrides, err := ontologySettingsOverrides(settings)
+	require.NoError(t, err)
+
+	raw, err := json.Marshal(map[string]interface{}{"ontology": overrides})
+	require.NoError(t, err)
+	var params map[string]interface{}
+	require.NoError(t, json.Unmarshal(raw, &params))
+	return params
+}
+
+func TestOntologySettings_ProfileAppliesBundle(t *testing.T) {
+	for _, name := range OntologyProfiles() {
+		t.Run(name, func(t *testing.T) {
+			selected, err := OntologyProfileSettings(name)
+			require.NoError(t, err)
+			params := storedOntologyParameters(t, selected)
+			assert.Equal(t, map[string]interface{}{"profile": name}, params["ontology"], "an unmodified profile stores no overrides")
+
+			settings := ontologySettingsFromParameters(params)
+
+			want := ontologyProfiles[name]
+			want.Profile = name
+			assert.Equal(t, &want, settings)
+		})
+	}
+
+	fast := ontologySettingsFromParameters(map[string]interface{}{"ontology": map[string]interface{}{"profile": OntologyProfileFast}})
+	assert.Equal(t, 3, fast.MaxQuestionsPerTable)
+	assert.Equal(t, 30, fast.EnumActivityWindowDays)
+
+	thorough := ontologySettingsFromParameters(map[string]interface{}{"ontology": map[string]interface{}{"profile": OntologyProfileThorough}})
+	assert.False(t, thorough.UseLegacyPatternMatching)
+	assert.Less(t, thorough.MaxPromptTokens, fast.MaxPromptTokens)
+}
+
+func TestOntologySettings_OverridesWinOverProfile(t *testing.T) {
+	selected, err := OntologyProfileSettings(OntologyProfileFast)
+	require.NoError(t, err)
+	selected.UseLegacyPatternMatching = false
+	selected.MaxQuestionsPerTable = 8
+	params := storedOntologyParameters(t, selected)
+
+	// Only the fields that differ from the profile are stored
+	assert.Equal(t, map[string]interface{}{
+		"profile":                     OntologyProfileFast,
+		"use_legacy_pattern_matching": false,
+		"max_questions_per_table":     float64(8),
+	}, params["ontology"])
+
+	settings := ontologySettingsFromParameters(params)
+	assert.Equal(t, OntologyProfileFast, settings.Profile)
+	assert.False(t, settings.UseLegacyPatternMatching, "override should win")
+	assert.Equal(t, 8, settings.MaxQuestionsPerTable, "override should win")
+	assert.Equal(t, 30, settings.EnumActivityWindowDays, "profile value should apply")
+}
+
+func TestOntologySettings_DefaultsToBalanced(t *testing.T) {
+	settings := ontologySettingsFromParameters(nil)
+	assert.Equal(t, OntologyProfileBalanced, settings.Profile)
+	assert.True(t, settings.UseLegacyPatternMatching)
+	assert.Equal(t, DefaultMaxPromptTokens, settings.MaxPromptTokens)
+	assert.Equal(t, DefaultEnumActivityWindowDays, settings.EnumActivityWindowDays)
+	assert.Zero(t, settings.MaxQuestionsPerTable)
+
+	// Settings stored before profiles existed, or naming a removed profile, keep working
+	settings = ontologySettingsFromParameters(map[string]interface{}{"ontology": map[string]interface{}{
+		"profile":                   "legacy",
+		"max_prompt_tokens":         float64(50_000),
+		"enum_activity_window_days": float64(90),
+	}})
+	assert.Equal(t, OntologyProfileBalanced, settings.Profile)
+	assert.Equal(t, 50_000, settings.MaxPromptTokens)
+}
+
+func TestOntologySettings_RejectsUnknownProfile(t *testing.T) {
+	_, err := ontologySettingsOverrides(&OntologySettings{Profile: "exhaustive"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown ontology profile "exhaustive"`)
+}
+
+func TestOntologySettings_ColumnEntityOverridesRoundTrip(t *testing.T) {
+	selected, err := OntologyProfileSettings(OntologyProfileBalanced)
+	require.NoError(t, err)
+	selected.ColumnEntityOverrides = map[string]string{"Payments.User_ID": " accounts "}
+	params := storedOntologyParameters(t, selected)
+
+	settings := ontologySettingsFromParameters(params)
+	assert.Equal(t, map[string]string{"payments.user_id": "accounts"}, settings.ColumnEntityOverrides)
+
+	_, err = ontologySettingsOverrides(&OntologySettings{ColumnEntityOverrides: map[string]string{"user_id": "accounts"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid column entity override "user_id"`)
+}
+
+func TestOntologySettings_CriticalTablesRoundTrip(t *testing.T) {
+	selected, err := OntologyProfileSettings(OntologyProfileBalanced)
+	require.NoError(t, err)
+	selected.CriticalTables = []string{" orders ", "customers", "Orders", ""}
+	params := storedOntologyParameters(t, selected)
+
+	settings := ontologySettingsFromParameters(params)
+	assert.Equal(t, []string{"orders", "customers"}, settings.CriticalTables)
+}
+
+func TestOntologySettings_TokenBudgetRoundTrip(t *testing.T) {
+	selected, err := OntologyProfileSettings(OntologyProfileFast)
+	require.NoError(t, err)
+	assert.Zero(t, selected.TokenBudget, "profiles leave the budget unlimited")
+
+	selected.TokenBudget = 250_000
+	settings := ontologySettingsFromParameters(storedOntologyParameters(t, selected))
+	assert.Equal(t, 250_000, settings.TokenBudget)
+}
@@ -3,6 +3,8 @@ package services
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -20,6 +22,9 @@ type OntologyQuestionService interface {
 	// GetPendingQuestions returns all pending questions for a project.
 	GetPendingQuestions(ctx context.Context, projectID uuid.UUID) ([]*models.OntologyQuestion, error)
 
+	// ListQuestions returns a filtered, paginated page of questions with a total count.
+	ListQuestions(ctx context.Context, projectID uuid.UUID, filters repositories.QuestionListFilters) (*repositories.QuestionListResult, error)
+
 	// GetPendingCount returns the count of pending questions.
 	GetPendingCount(ctx context.Context, projectID uuid.UUID) (int, error)
 
@@ -37,6 +42,22 @@ type OntologyQuestionService interface {
 
 	// CreateQuestions creates a batch of questions for a project/workflow.
 	CreateQuestions(ctx context.Context, questions []*models.OntologyQuestion) error
+
+	// AnswerFromGlossary answers pending questions resolved by the given glossary terms
+	// and returns how many were answered.
+	AnswerFromGlossary(ctx context.Context, projectID uuid.UUID, terms []*models.BusinessGlossaryTerm) (int, error)
+
+	// TrimQuestionsPerTable dismisses pending questions beyond maxPerTable for each table,
+	// keeping required and higher-priority questions, and returns how many were dismissed.
+	TrimQuestionsPerTable(ctx context.Context, projectID uuid.UUID, maxPerTable int) (int, error)
+
+	// FindOrphanedQuestions returns pending questions about a table that is no longer
+	// selected, because it was deselected or removed from the schema.
+	FindOrphanedQuestions(ctx context.Context, projectID uuid.UUID) ([]*models.OntologyQuestion, error)
+
+	// CleanupOrphanedQuestions dismisses the questions FindOrphanedQuestions returns, or
+	// deletes them when deleteQuestions is set, and returns how many were cleaned up.
+	CleanupOrphanedQuestions(ctx context.Context, projectID uuid.UUID, deleteQuestions bool) (int, error)
 }
 
 type ontologyQuestionService struct {
@@ -45,16 +66,20 @@ type ontologyQuestionService struct {
 	schemaRepo         repositories.SchemaRepository
 	knowledgeRepo      repositories.KnowledgeRepository
 	builder            OntologyBuilderService
+	qualityService     TableQualityService
 	logger             *zap.Logger
 }
 
 // NewOntologyQuestionService creates a new ontology question service.
+// qualityService may be nil, in which case table quality scores are not refreshed
+// when questions are answered, skipped or deleted.
 func NewOntologyQuestionService(
 	questionRepo repositories.OntologyQuestionRepository,
 	columnMetadataRepo repositories.ColumnMetadataRepository,
 	schemaRepo repositories.SchemaRepository,
 	knowledgeRepo repositories.KnowledgeRepository,
 	builder OntologyBuilderService,
+	qualityService TableQualityService,
 	logger *zap.Logger,
 ) OntologyQuestionService {
 	return &ontologyQuestionService{
@@ -63,6 +88,7 @@ func NewOntologyQuestionService(
 		schemaRepo:         schemaRepo,
 		knowledgeRepo:      knowledgeRepo,
 		builder:            builder,
+		qualityService:     qualityService,
 		logger:             logger.Named("ontology-question"),
 	}
 }
@@ -92,6 +118,17 @@ func (s *ontologyQuestionService) GetPendingQuestions(ctx context.Context, proje
 	return questions, nil
 }
 
+func (s *ontologyQuestionService) ListQuestions(ctx context.Context, projectID uuid.UUID, filters repositories.QuestionListFilters) (*repositories.QuestionListResult, error) {
+	result, err := s.questionRepo.List(ctx, projectID, filters)
+	if err != nil {
+		s.logger.Error("Failed to list questions",
+			zap.String("project_id", projectID.String()),
+			zap.Error(err))
+		return nil, err
+	}
+	return result, nil
+}
+
 func (s *ontologyQuestionService) GetPendingCount(ctx context.Context, projectID uuid.UUID) (int, error) {
 	counts, err := s.GetPendingCounts(ctx, projectID)
 	if err != nil {
@@ -207,6 +244,8 @@ func (s *ontologyQuestionService) AnswerQuestion(ctx context.Context, questionID
 		zap.Int("entity_updates", len(processingResult.EntityUpdates)),
 		zap.Int("knowledge_facts", len(processingResult.KnowledgeFacts)))
 
+	s.refreshQuality(ctx, question.ProjectID)
+
 	// Get next question
 	nextQuestion, err := s.GetNextQuestion(ctx, question.ProjectID, false)
 	if err != nil {
@@ -331,6 +370,8 @@ func (s *ontologyQuestionService) SkipQuestion(ctx context.Context, questionID u
 	s.logger.Info("Question skipped",
 		zap.String("question_id", questionID.String()))
 
+	s.refreshQualityForQuestion(ctx, questionID)
+
 	return nil
 }
 
@@ -345,12 +386,217 @@ func (s *ontologyQuestionService) DeleteQuestion(ctx context.Context, questionID
 	s.logger.Info("Question deleted",
 		zap.String("question_id", questionID.String()))
 
+	s.refreshQualityForQuestion(ctx, questionID)
+
 	return nil
 }
 
+// refreshQuality rescores the project's tables after answers edited their metadata or
+// a question stopped pending, since pending questions count against a table's score.
+// The score is advisory, so a failure is only logged.
+func (s *ontologyQuestionService) refreshQuality(ctx context.Context, projectID uuid.UUID) {
+	if s.qualityService == nil {
+		return
+	}
+	if _, err := s.qualityService.RefreshProject(ctx, projectID); err != nil {
+		s.logger.Warn("Failed to refresh table quality scores",
+			zap.String("project_id", projectID.String()),
+			zap.Error(err))
+	}
+}
+
+// refreshQualityForQuestion calls refreshQuality for the project the question belongs to.
+func (s *ontologyQuestionService) refreshQualityForQuestion(ctx context.Context, questionID uuid.UUID) {
+	if s.qualityService == nil {
+		return
+	}
+	question, err := s.questionRepo.GetByID(ctx, questionID)
+	if err != nil || question == nil {
+		s.logger.Warn("Failed to look up question to refresh table quality scores",
+			zap.String("question_id", questionID.String()),
+			zap.Error(err))
+		return
+	}
+	s.refreshQuality(ctx, question.ProjectID)
+}
+
 func (s *ontologyQuestionService) CreateQuestions(ctx context.Context, questions []*models.OntologyQuestion) error {
 	if len(questions) == 0 {
 		return nil
 	}
 	return s.questionRepo.CreateBatch(ctx, questions)
 }
+
+func (s *ontologyQuestionService) TrimQuestionsPerTable(ctx context.Context, projectID uuid.UUID, maxPerTable int) (int, error) {
+	if maxPerTable <= 0 {
+		return 0, nil
+	}
+
+	questions, err := s.questionRepo.ListPending(ctx, projectID)
+	if err != nil {
+		return 0, fmt.Errorf("list pending questions: %w", err)
+	}
+
+	byTable := make(map[string][]*models.OntologyQuestion)
+	var tables []string
+	for _, q := range questions {
+		table := questionPrimaryTable(q)
+		if table == "" {
+			continue // Project-level questions aren't capped
+		}
+		if _, ok := byTable[table]; !ok {
+			tables = append(tables, table)
+		}
+		byTable[table] = append(byTable[table], q)
+	}
+
+	trimmed := 0
+	for _, table := range tables {
+		tableQuestions := byTable[table]
+		if len(tableQuestions) <= maxPerTable {
+			continue
+		}
+		sort.SliceStable(tableQuestions, func(i, j int) bool {
+			a, b := tableQuestions[i], tableQuestions[j]
+			if a.IsRequired != b.IsRequired {
+				return a.IsRequired
+			}
+			return a.Priority < b.Priority
+		})
+		for _, q := range tableQuestions[maxPerTable:] {
+			if err := s.questionRepo.UpdateStatusWithReason(ctx, q.ID, models.QuestionStatusDismissed, models.QuestionStatusReasonTrimmed); err != nil {
+				return trimmed, fmt.Errorf("dismiss question %s: %w", q.ID, err)
+			}
+			trimmed++
+		}
+		s.logger.Debug("Trimmed questions for table",
+			zap.String("table", table),
+			zap.Int("kept", maxPerTable),
+			zap.Int("trimmed", len(tableQuestions)-maxPerTable))
+	}
+
+	if trimmed > 0 {
+		s.logger.Info("Trimmed questions exceeding per-table limit",
+			zap.String("project_id", projectID.String()),
+			zap.Int("max_per_table", maxPerTable),
+			zap.Int("trimmed", trimmed))
+	}
+	return trimmed, nil
+}
+
+func (s *ontologyQuestionService) FindOrphanedQuestions(ctx context.Context, projectID uuid.UUID) ([]*models.OntologyQuestion, error) {
+	return findOrphanedQuestions(ctx, s.questionRepo, s.schemaRepo, projectID)
+}
+
+func (s *ontologyQuestionService) CleanupOrphanedQuestions(ctx context.Context, projectID uuid.UUID, deleteQuestions bool) (int, error) {
+	return cleanupOrphanedQuestions(ctx, s.questionRepo, s.schemaRepo, projectID, deleteQuestions, s.logger)
+}
+
+// findOrphanedQuestions lists the project's pending questions and returns those whose
+// table is not among the selected tables. A project with no selected tables has nothing
+// to compare against, so no question is reported.
+func findOrphanedQuestions(
+	ctx context.Context,
+	questionRepo repositories.OntologyQuestionRepository,
+	schemaRepo repositories.SchemaRepository,
+	projectID uuid.UUID,
+) ([]*models.OntologyQuestion, error) {
+	selected, err := schemaRepo.GetSelectedTableNamesByProject(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("list selected tables: %w", err)
+	}
+	if len(selected) == 0 {
+		return nil, nil
+	}
+
+	questions, err := questionRepo.ListPending(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("list pending questions: %w", err)
+	}
+	return orphanedQuestions(questions, selected), nil
+}
+
+// orphanedQuestions returns the questions tied to a table missing from selectedTables.
+// Schema-qualified names match on the table part. Project-level questions are never orphaned.
+func orphanedQuestions(questions []*models.OntologyQuestion, selectedTables []string) []*models.OntologyQuestion {
+	selected := make(map[string]bool, len(selectedTables))
+	for _, name := range selectedTables {
+		selected[unqualifiedTableName(name)] = true
+	}
+
+	var orphaned []*models.OntologyQuestion
+	for _, q := range questions {
+		table := questionPrimaryTable(q)
+		if table != "" && !selected[unqualifiedTableName(table)] {
+			orphaned = append(orphaned, q)
+		}
+	}
+	return orphaned
+}
+
+// unqualifiedTableName lowercases name and drops any schema prefix.
+func unqualifiedTableName(name string) string {
+	name = strings.ToLower(name)
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
+
+// cleanupOrphanedQuestions dismisses, or with deleteQuestions soft-deletes, the orphaned
+// questions of a project, recording QuestionStatusReasonOrphaned so they stop counting
+// towards pending totals without losing track of why.
+func cleanupOrphanedQuestions(
+	ctx context.Context,
+	questionRepo repositories.OntologyQuestionRepository,
+	schemaRepo repositories.SchemaRepository,
+	projectID uuid.UUID,
+	deleteQuestions bool,
+	logger *zap.Logger,
+) (int, error) {
+	orphaned, err := findOrphanedQuestions(ctx, questionRepo, schemaRepo, projectID)
+	if err != nil {
+		return 0, err
+	}
+
+	status := models.QuestionStatusDismissed
+	if deleteQuestions {
+		status = models.QuestionStatusDeleted
+	}
+	cleaned := 0
+	for _, q := range orphaned {
+		if err := questionRepo.UpdateStatusWithReason(ctx, q.ID, status, models.QuestionStatusReasonOrphaned); err != nil {
+			return cleaned, fmt.Errorf("clean up question %s: %w", q.ID, err)
+		}
+		cleaned++
+	}
+
+	if cleaned > 0 {
+		logger.Info("Cleaned up questions about tables that are no longer selected",
+			zap.String("project_id", projectID.String()),
+			zap.String("status", string(status)),
+			zap.Int("questions", cleaned))
+	}
+	return cleaned, nil
+}
+
+// questionPrimaryTable returns the lowercased table a question counts against: the
+// table it was sourced from, else the first table or table-qualified column it
+// affects. Returns "" for questions not tied to a table.
+func questionPrimaryTable(q *models.OntologyQuestion) string {
+	if q.SourceEntityType == "table" && q.SourceEntityKey != "" {
+		return strings.ToLower(q.SourceEntityKey)
+	}
+	if q.Affects == nil {
+		return ""
+	}
+	if len(q.Affects.Tables) > 0 {
+		return strings.ToLower(q.Affects.Tables[0])
+	}
+	for _, c := range q.Affects.Columns {
+		if table, _, ok := strings.Cut(c, "."); ok {
+			return strings.ToLower(table)
+		}
+	}
+	return ""
+}
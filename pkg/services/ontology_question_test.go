@@ -25,6 +25,7 @@ type mockQuestionRepo struct {
 	updateStatusFunc     func(ctx context.Context, id uuid.UUID, status models.QuestionStatus) error
 	createBatchFunc      func(ctx context.Context, questions []*models.OntologyQuestion) error
 	listPendingFunc      func(ctx context.Context, projectID uuid.UUID) ([]*models.OntologyQuestion, error)
+	updateStatusReasonFn func(ctx context.Context, id uuid.UUID, status models.QuestionStatus, reason string) error
 }
 
 func (m *mockQuestionRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.OntologyQuestion, error) {
@@ -84,6 +85,9 @@ func (m *mockQuestionRepo) ListPending(ctx context.Context, projectID uuid.UUID)
 }
 
 func (m *mockQuestionRepo) UpdateStatusWithReason(ctx context.Context, id uuid.UUID, status models.QuestionStatus, reason string) error {
+	if m.updateStatusReasonFn != nil {
+		return m.updateStatusReasonFn(ctx, id, status, reason)
+	}
 	return nil
 }
 
@@ -150,6 +154,12 @@ type mockSchemaRepoForQuestion struct {
 	repositories.SchemaRepository
 	findTableByNameFunc func(ctx context.Context, projectID, datasourceID uuid.UUID, tableName string) (*models.SchemaTable, error)
 	getColumnByNameFunc func(ctx context.Context, tableID uuid.UUID, columnName string) (*models.SchemaColumn, error)
+
+	selectedTableNames []string
+}
+
+func (m *mockSchemaRepoForQuestion) GetSelectedTableNamesByProject(ctx context.Context, projectID uuid.UUID) ([]string, error) {
+	return m.selectedTableNames, nil
 }
 
 func (m *mockSchemaRepoForQuestion) FindTableByName(ctx context.Context, projectID, datasourceID uuid.UUID, tableName string) (*models.SchemaTable, error) {
@@ -764,3 +774,196 @@ func TestGetPendingCount_Error(t *testing.T) {
 	_, err := svc.GetPendingCount(context.Background(), uuid.New())
 	assert.Error(t, err)
 }
+
+// --- Tests for TrimQuestionsPerTable ---
+
+func TestTrimQuestionsPerTable_KeepsRequiredQuestionsFirst(t *testing.T) {
+	var questions []*models.OntologyQuestion
+	required := make(map[uuid.UUID]bool)
+	for i := 0; i < 12; i++ {
+		q := &models.OntologyQuestion{
+			ID:               uuid.New(),
+			Text:             fmt.Sprintf("Question %d about orders", i),
+			Priority:         1 + i%3,
+			SourceEntityType: "table",
+			SourceEntityKey:  "orders",
+			Status:           models.QuestionStatusPending,
+		}
+		// Required questions come last in the listing so ordering must be by importance.
+		if i >= 9 {
+			q.IsRequired = true
+			q.Priority = 3
+			required[q.ID] = true
+		}
+		questions = append(questions, q)
+	}
+	otherTable := &models.OntologyQuestion{
+		ID:      uuid.New(),
+		Text:    "What does users.tier mean?",
+		Affects: &models.QuestionAffects{Columns: []string{"users.tier"}},
+		Status:  models.QuestionStatusPending,
+	}
+	questions = append(questions, otherTable)
+
+	dismissed := make(map[uuid.UUID]string)
+	questionRepo := &mockQuestionRepo{
+		listPendingFunc: func(ctx context.Context, projectID uuid.UUID) ([]*models.OntologyQuestion, error) {
+			return questions, nil
+		},
+		updateStatusReasonFn: func(ctx context.Context, id uuid.UUID, status models.QuestionStatus, reason string) error {
+			assert.Equal(t, models.QuestionStatusDismissed, status)
+			dismissed[id] = reason
+			return nil
+		},
+	}
+	svc := newTestQuestionService(questionRepo, &mockKnowledgeRepo{}, &mockBuilder{})
+
+	trimmed, err := svc.TrimQuestionsPerTable(context.Background(), uuid.New(), 5)
+	require.NoError(t, err)
+
+	assert.Equal(t, 7, trimmed)
+	assert.Len(t, dismissed, 7)
+	for id := range required {
+		assert.NotContains(t, dismissed, id, "required questions are kept")
+	}
+	assert.NotContains(t, dismissed, otherTable.ID, "tables under the cap are untouched")
+	for _, reason := range dismissed {
+		assert.Equal(t, models.QuestionStatusReasonTrimmed, reason)
+	}
+
+	// The two optional questions kept are the highest-priority ones.
+	var keptOptional []*models.OntologyQuestion
+	for _, q := range questions[:12] {
+		if _, gone := dismissed[q.ID]; !gone && !q.IsRequired {
+			keptOptional = append(keptOptional, q)
+		}
+	}
+	require.Len(t, keptOptional, 2)
+	for _, q := range keptOptional {
+		assert.Equal(t, 1, q.Priority)
+	}
+}
+
+func TestTrimQuestionsPerTable_ZeroDisables(t *testing.T) {
+	questionRepo := &mockQuestionRepo{
+		listPendingFunc: func(ctx context.Context, projectID uuid.UUID) ([]*models.OntologyQuestion, error) {
+			t.Fatal("questions should not be listed when the cap is disabled")
+			return nil, nil
+		},
+	}
+	svc := newTestQuestionService(questionRepo, &mockKnowledgeRepo{}, &mockBuilder{})
+
+	trimmed, err := svc.TrimQuestionsPerTable(context.Background(), uuid.New(), 0)
+	require.NoError(t, err)
+	assert.Equal(t, 0, trimmed)
+}
+
+// --- Tests for orphaned question cleanup ---
+
+func TestOrphanedQuestions_DeselectedTableIsFlaggedForCleanup(t *testing.T) {
+	ordersStatus := &models.OntologyQuestion{
+		ID:               uuid.New(),
+		Text:             "What does orders.status mean?",
+		SourceEntityType: "table",
+		SourceEntityKey:  "orders",
+		Status:           models.QuestionStatusPending,
+	}
+	ordersTotal := &models.OntologyQuestion{
+		ID:      uuid.New(),
+		Text:    "Is orders.total in cents?",
+		Affects: &models.QuestionAffects{Columns: []string{"public.orders.total"}},
+		Status:  models.QuestionStatusPending,
+	}
+	usersTier := &models.OntologyQuestion{
+		ID:               uuid.New(),
+		Text:             "What does users.tier mean?",
+		SourceEntityType: "table",
+		SourceEntityKey:  "users",
+		Status:           models.QuestionStatusPending,
+	}
+	projectLevel := &models.OntologyQuestion{
+		ID:     uuid.New(),
+		Text:   "What industry is this business in?",
+		Status: models.QuestionStatusPending,
+	}
+	pending := []*models.OntologyQuestion{ordersStatus, ordersTotal, usersTier, projectLevel}
+
+	cleaned := make(map[uuid.UUID]models.QuestionStatus)
+	questionRepo := &mockQuestionRepo{
+		listPendingFunc: func(ctx context.Context, projectID uuid.UUID) ([]*models.OntologyQuestion, error) {
+			return pending, nil
+		},
+		updateStatusReasonFn: func(ctx context.Context, id uuid.UUID, status models.QuestionStatus, reason string) error {
+			assert.Equal(t, models.QuestionStatusReasonOrphaned, reason)
+			cleaned[id] = status
+			return nil
+		},
+	}
+	// orders has been deselected; only users remains.
+	schemaRepo := &mockSchemaRepoForQuestion{selectedTableNames: []string{"public.users"}}
+	svc := newTestQuestionServiceWithRepos(questionRepo, &mockKnowledgeRepo{}, &mockBuilder{}, schemaRepo, nil)
+	projectID := uuid.New()
+
+	orphaned, err := svc.FindOrphanedQuestions(context.Background(), projectID)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*models.OntologyQuestion{ordersStatus, ordersTotal}, orphaned)
+
+	n, err := svc.CleanupOrphanedQuestions(context.Background(), projectID, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, map[uuid.UUID]models.QuestionStatus{
+		ordersStatus.ID: models.QuestionStatusDismissed,
+		ordersTotal.ID:  models.QuestionStatusDismissed,
+	}, cleaned)
+
+	clear(cleaned)
+	n, err = svc.CleanupOrphanedQuestions(context.Background(), projectID, true)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, models.QuestionStatusDeleted, cleaned[ordersStatus.ID])
+	assert.Equal(t, models.QuestionStatusDeleted, cleaned[ordersTotal.ID])
+}
+
+func TestOrphanedQuestions_NoSelectedTables(t *testing.T) {
+	questionRepo := &mockQuestionRepo{
+		listPendingFunc: func(ctx context.Context, projectID uuid.UUID) ([]*models.OntologyQuestion, error) {
+			t.Fatal("questions should not be listed when no tables are selected")
+			return nil, nil
+		},
+	}
+	svc := newTestQuestionServiceWithRepos(questionRepo, &mockKnowledgeRepo{}, &mockBuilder{}, &mockSchemaRepoForQuestion{}, nil)
+
+	n, err := svc.CleanupOrphanedQuestions(context.Background(), uuid.New(), false)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+}
+
+// mockTableQualityService records the projects whose tables were rescored.
+type mockTableQualityService struct {
+	refreshedProjects []uuid.UUID
+}
+
+func (m *mockTableQualityService) RefreshProject(ctx context.Context, projectID uuid.UUID) (int, error) {
+	m.refreshedProjects = append(m.refreshedProjects, projectID)
+	return 0, nil
+}
+
+func (m *mockTableQualityService) RefreshTable(ctx context.Context, projectID uuid.UUID, tableName string) error {
+	return nil
+}
+
+func TestSkipQuestion_RefreshesTableQuality(t *testing.T) {
+	question := &models.OntologyQuestion{ID: uuid.New(), ProjectID: uuid.New(), Status: models.QuestionStatusPending}
+	questionRepo := &mockQuestionRepo{
+		getByIDFunc: func(ctx context.Context, id uuid.UUID) (*models.OntologyQuestion, error) {
+			return question, nil
+		},
+	}
+	quality := &mockTableQualityService{}
+	svc := newTestQuestionService(questionRepo, &mockKnowledgeRepo{}, &mockBuilder{})
+	svc.qualityService = quality
+
+	require.NoError(t, svc.SkipQuestion(context.Background(), question.ID))
+	assert.Equal(t, []uuid.UUID{question.ProjectID}, quality.refreshedProjects,
+		"a question that stops pending no longer counts against its table's score")
+}
@@ -63,7 +63,7 @@ func (s *precedenceChecker) GetEffectiveSource(createdBy string, updatedBy *stri
 }
 
 // GlossaryPrecedenceChecker validates if a modifier can update a glossary term based on source rules.
-// Maps glossary source values to precedence hierarchy: Manual (3) > MCP (2) > Inferred (1)
+// Maps glossary source values to precedence hierarchy: Manual (4) > Import (3) > MCP (2) > Inferred (1)
 type GlossaryPrecedenceChecker interface {
 	// CanModify checks if the modifier can update a glossary term based on source.
 	// Returns true if modification is allowed, false otherwise.
@@ -82,7 +82,7 @@ func NewGlossaryPrecedenceChecker() GlossaryPrecedenceChecker {
 }
 
 // CanModify checks if the modifier can update a glossary term based on source hierarchy.
-// Manual (3) > MCP (2) > Inferred (1)
+// Manual (4) > Import (3) > MCP (2) > Inferred (1)
 func (s *glossaryPrecedenceChecker) CanModify(termSource string, modifierSource string) bool {
 	modifierLevel := s.GetPrecedenceLevel(modifierSource)
 	existingLevel := s.GetPrecedenceLevel(termSource)
@@ -92,10 +92,12 @@ func (s *glossaryPrecedenceChecker) CanModify(termSource string, modifierSource
 }
 
 // GetPrecedenceLevel returns the numeric precedence level for a glossary source.
-// Manual: 3, MCP: 2, Inferred: 1, Unknown: 0
+// Manual: 4, Import: 3, MCP: 2, Inferred: 1, Unknown: 0
 func (s *glossaryPrecedenceChecker) GetPrecedenceLevel(source string) int {
 	switch source {
 	case models.GlossarySourceManual:
+		return 4
+	case models.GlossarySourceImport:
 		return 3
 	case models.GlossarySourceMCP:
 		return 2
@@ -110,7 +110,8 @@ func TestGlossaryPrecedenceChecker_GetPrecedenceLevel(t *testing.T) {
 		source string
 		want   int
 	}{
-		{"manual is 3", models.GlossarySourceManual, 3},
+		{"manual is 4", models.GlossarySourceManual, 4},
+		{"import is 3", models.GlossarySourceImport, 3},
 		{"mcp is 2", models.GlossarySourceMCP, 2},
 		{"inferred is 1", models.GlossarySourceInferred, 1},
 		{"unknown is 0", "unknown", 0},
@@ -141,6 +142,11 @@ func TestGlossaryPrecedenceChecker_CanModify(t *testing.T) {
 		{"mcp cannot modify manual", models.GlossarySourceManual, models.GlossarySourceMCP, false},
 		{"inferred cannot modify manual", models.GlossarySourceManual, models.GlossarySourceInferred, false},
 		{"inferred cannot modify mcp", models.GlossarySourceMCP, models.GlossarySourceInferred, false},
+		{"import modifies mcp", models.GlossarySourceMCP, models.GlossarySourceImport, true},
+		{"manual modifies import", models.GlossarySourceImport, models.GlossarySourceManual, true},
+		{"import cannot modify manual", models.GlossarySourceManual, models.GlossarySourceImport, false},
+		{"mcp cannot modify import", models.GlossarySourceImport, models.GlossarySourceMCP, false},
+		{"inferred cannot modify import", models.GlossarySourceImport, models.GlossarySourceInferred, false},
 	}
 
 	for _, tt := range tests {
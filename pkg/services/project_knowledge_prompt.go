@@ -147,7 +147,8 @@ func filterProjectKnowledgeFactsForPrompt(facts []*models.KnowledgeFact) []*mode
 		}
 		factType := strings.ToLower(strings.TrimSpace(fact.FactType))
 		value := strings.TrimSpace(fact.Value)
-		if factType == "" || value == "" || factType == projectOverviewFactType {
+		// Entity hints are injected per table by entityHintsByTable, not project-wide
+		if factType == "" || value == "" || factType == projectOverviewFactType || factType == models.FactTypeEntityHint {
 			continue
 		}
 
@@ -168,6 +169,48 @@ func filterProjectKnowledgeFactsForPrompt(facts []*models.KnowledgeFact) []*mode
 	return filtered
 }
 
+// entityHintsFromPromptContext returns the entity hints among the project knowledge
+// facts loaded into ctx, keyed by lowercased table name. Returns nil when no facts
+// were loaded.
+func entityHintsFromPromptContext(ctx context.Context) map[string][]string {
+	facts, _ := ctx.Value(projectKnowledgePromptFactsKey{}).([]*models.KnowledgeFact)
+	return entityHintsByTable(facts)
+}
+
+// entityHintsByTable indexes entity_hint facts by the tables named in their context.
+// Table names are matched case-insensitively and without a schema prefix.
+func entityHintsByTable(facts []*models.KnowledgeFact) map[string][]string {
+	var hints map[string][]string
+	for _, fact := range facts {
+		if fact == nil || !strings.EqualFold(strings.TrimSpace(fact.FactType), models.FactTypeEntityHint) {
+			continue
+		}
+		value := strings.TrimSpace(fact.Value)
+		if value == "" {
+			continue
+		}
+		for _, table := range strings.Split(fact.Context, ",") {
+			key := entityHintTableKey(table)
+			if key == "" {
+				continue
+			}
+			if hints == nil {
+				hints = make(map[string][]string)
+			}
+			hints[key] = append(hints[key], value)
+		}
+	}
+	return hints
+}
+
+func entityHintTableKey(table string) string {
+	table = strings.ToLower(strings.TrimSpace(table))
+	if i := strings.LastIndex(table, "."); i >= 0 {
+		table = table[i+1:]
+	}
+	return table
+}
+
 func normalizeProjectKnowledgePromptText(value string) string {
 	return strings.Join(strings.Fields(strings.ToLower(strings.TrimSpace(value))), " ")
 }
@@ -51,12 +51,103 @@ type AutoApproveSettings struct {
 }
 
 // OntologySettings contains ontology extraction configuration for a project.
+// Profile selects a bundle of values (see OntologyProfileFast and friends); the
+// remaining fields override the profile where they differ from it.
 type OntologySettings struct {
+	// Profile names the extraction profile the other fields start from. Empty means
+	// DefaultOntologyProfile.
+	Profile string `json:"profile"`
+
 	// UseLegacyPatternMatching controls whether column name pattern matching is used
 	// during FK candidate filtering. When true (default), columns are filtered based
 	// on naming patterns (e.g., _id suffix, is_ prefix). When false, filtering relies
 	// solely on data-based analysis (cardinality, join validation).
 	UseLegacyPatternMatching bool `json:"use_legacy_pattern_matching"`
+
+	// MaxPromptTokens is the estimated prompt size above which extraction trims or
+	// splits a prompt before sending it, so very wide tables do not exceed the model's
+	// context window. Zero means DefaultMaxPromptTokens.
+	MaxPromptTokens int `json:"max_prompt_tokens"`
+
+	// EnumActivityWindowDays is how far back enum distribution analysis looks when
+	// deciding whether a status value is still in active use. Values with no rows in
+	// the window are documented as stale. Zero means DefaultEnumActivityWindowDays.
+	EnumActivityWindowDays int `json:"enum_activity_window_days"`
+
+	// MaxQuestionsPerTable caps the pending questions kept per table after extraction
+	// for this project. Zero defers to the server-wide ontology.max_questions_per_table.
+	MaxQuestionsPerTable int `json:"max_questions_per_table"`
+
+	// ColumnEntityOverrides pins which table a column refers to, keyed by "table.column"
+	// with the owning table as the value. Relationship discovery uses an override in
+	// place of the inferred FK target, so a user correction survives re-extraction.
+	ColumnEntityOverrides map[string]string `json:"column_entity_overrides,omitempty"`
+
+	// CriticalTables names the tables analysts rely on most. Assessments weight gaps in
+	// these tables above gaps in peripheral ones and look at them first.
+	CriticalTables []string `json:"critical_tables,omitempty"`
+
+	// TokenBudget caps the LLM tokens one extraction may spend. An extraction that
+	// reaches it stops before the next LLM call; starting it again resumes from the
+	// step it stopped at, with its earlier spend still counted, once the budget has
+	// been raised above that spend. Zero means unlimited.
+	TokenBudget int `json:"token_budget"`
+}
+
+// DefaultMaxPromptTokens leaves headroom for the response in a 128k-token context window.
+const DefaultMaxPromptTokens = 100_000
+
+// DefaultEnumActivityWindowDays covers a quarter, long enough for seasonal statuses.
+const DefaultEnumActivityWindowDays = 90
+
+// ontologySettingsFromParameters reads ontology settings from project parameters:
+// the selected profile's values, with any stored per-field overrides applied on top.
+// Projects without a profile, or with one that no longer exists, use
+// DefaultOntologyProfile.
+func ontologySettingsFromParameters(params map[string]interface{}) *OntologySettings {
+	ontology, _ := params["ontology"].(map[string]interface{})
+
+	profile, _ := ontology["profile"].(string)
+	settings, err := OntologyProfileSettings(profile)
+	if err != nil {
+		settings, _ = OntologyProfileSettings(DefaultOntologyProfile)
+	}
+
+	if v, ok := ontology["use_legacy_pattern_matching"].(bool); ok {
+		settings.UseLegacyPatternMatching = v
+	}
+	// JSONB numbers decode as float64
+	if v, ok := ontology["max_prompt_tokens"].(float64); ok && v > 0 {
+		settings.MaxPromptTokens = int(v)
+	}
+	if v, ok := ontology["enum_activity_window_days"].(float64); ok && v > 0 {
+		settings.EnumActivityWindowDays = int(v)
+	}
+	if v, ok := ontology["max_questions_per_table"].(float64); ok && v > 0 {
+		settings.MaxQuestionsPerTable = int(v)
+	}
+	if v, ok := ontology["token_budget"].(float64); ok && v > 0 {
+		settings.TokenBudget = int(v)
+	}
+	if v, ok := ontology["column_entity_overrides"].(map[string]interface{}); ok {
+		for key, target := range v {
+			if table, ok := target.(string); ok && table != "" {
+				if settings.ColumnEntityOverrides == nil {
+					settings.ColumnEntityOverrides = make(map[string]string, len(v))
+				}
+				settings.ColumnEntityOverrides[columnEntityOverrideKey(key)] = table
+			}
+		}
+	}
+	if v, ok := ontology["critical_tables"].([]interface{}); ok {
+		for _, name := range v {
+			if table, ok := name.(string); ok && table != "" {
+				settings.CriticalTables = append(settings.CriticalTables, table)
+			}
+		}
+	}
+
+	return settings
 }
 
 // ProjectService defines the interface for project operations.
@@ -90,6 +181,7 @@ type ProjectService interface {
 type projectService struct {
 	db                   *database.DB
 	projectRepo          repositories.ProjectRepository
+	datasourceRepo       repositories.DatasourceRepository
 	userRepo             repositories.UserRepository
 	mcpConfigRepo        repositories.MCPConfigRepository
 	installedAppService  InstalledAppService
@@ -104,6 +196,7 @@ type projectService struct {
 func NewProjectService(
 	db *database.DB,
 	projectRepo repositories.ProjectRepository,
+	datasourceRepo repositories.DatasourceRepository,
 	userRepo repositories.UserRepository,
 	mcpConfigRepo repositories.MCPConfigRepository,
 	installedAppService InstalledAppService,
@@ -115,6 +208,7 @@ func NewProjectService(
 	return &projectService{
 		db:                   db,
 		projectRepo:          projectRepo,
+		datasourceRepo:       datasourceRepo,
 		userRepo:             userRepo,
 		mcpConfigRepo:        mcpConfigRepo,
 		installedAppService:  installedAppService,
@@ -396,14 +490,36 @@ func (s *projectService) getAuthContext(ctx context.Context) (token, papiURL str
 	return t, claims.PAPI, nil
 }
 
-// GetDefaultDatasourceID retrieves the default datasource ID from project parameters.
-// Returns uuid.Nil if no datasource is configured.
+// GetDefaultDatasourceID resolves the project's default datasource.
+// An explicitly configured default always wins. Otherwise, a project with exactly one
+// datasource treats it as the default. Returns uuid.Nil if the project has no datasources,
+// and an error wrapping apperrors.ErrAmbiguousDefaultDatasource if it has several and
+// none is marked as default.
 func (s *projectService) GetDefaultDatasourceID(ctx context.Context, projectID uuid.UUID) (uuid.UUID, error) {
 	project, err := s.projectRepo.Get(ctx, projectID)
 	if err != nil {
 		return uuid.Nil, fmt.Errorf("failed to get project: %w", err)
 	}
 
+	explicitID, err := explicitDefaultDatasourceID(project)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if explicitID != uuid.Nil || s.datasourceRepo == nil {
+		return explicitID, nil
+	}
+
+	datasources, _, err := s.datasourceRepo.List(ctx, projectID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to list datasources: %w", err)
+	}
+
+	return resolveDefaultDatasourceID(datasources)
+}
+
+// explicitDefaultDatasourceID reads default_datasource_id from project parameters.
+// Returns uuid.Nil if no default is configured.
+func explicitDefaultDatasourceID(project *models.Project) (uuid.UUID, error) {
 	if project.Parameters == nil {
 		return uuid.Nil, nil
 	}
@@ -421,6 +537,20 @@ func (s *projectService) GetDefaultDatasourceID(ctx context.Context, projectID u
 	return dsID, nil
 }
 
+// resolveDefaultDatasourceID picks the implicit default when none is configured:
+// the only datasource if there is exactly one, uuid.Nil if there are none.
+func resolveDefaultDatasourceID(datasources []*models.Datasource) (uuid.UUID, error) {
+	switch len(datasources) {
+	case 0:
+		return uuid.Nil, nil
+	case 1:
+		return datasources[0].ID, nil
+	default:
+		return uuid.Nil, fmt.Errorf("%w: project has %d datasources and none is set as default",
+			apperrors.ErrAmbiguousDefaultDatasource, len(datasources))
+	}
+}
+
 // SetDefaultDatasourceID updates the default datasource ID in project parameters.
 func (s *projectService) SetDefaultDatasourceID(ctx context.Context, projectID uuid.UUID, datasourceID uuid.UUID) error {
 	project, err := s.projectRepo.Get(ctx, projectID)
@@ -554,32 +684,27 @@ func (s *projectService) SetAutoApproveSettings(ctx context.Context, projectID u
 	return nil
 }
 
-// GetOntologySettings returns the ontology extraction settings for a project.
-// Returns default settings (UseLegacyPatternMatching=true) if not configured.
+// GetOntologySettings returns the ontology extraction settings for a project: its
+// profile's values (DefaultOntologyProfile when none is selected) with the project's
+// overrides applied.
 func (s *projectService) GetOntologySettings(ctx context.Context, projectID uuid.UUID) (*OntologySettings, error) {
 	project, err := s.projectRepo.Get(ctx, projectID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get project: %w", err)
 	}
 
-	// Default: use legacy pattern matching for backward compatibility
-	settings := &OntologySettings{
-		UseLegacyPatternMatching: true,
-	}
-
-	if project.Parameters != nil {
-		if ontology, ok := project.Parameters["ontology"].(map[string]interface{}); ok {
-			if v, ok := ontology["use_legacy_pattern_matching"].(bool); ok {
-				settings.UseLegacyPatternMatching = v
-			}
-		}
-	}
-
-	return settings, nil
+	return ontologySettingsFromParameters(project.Parameters), nil
 }
 
 // SetOntologySettings updates the ontology extraction settings in project parameters.
+// The profile is stored along with only the fields that differ from it, so fields
+// left at the profile's values keep following the profile.
 func (s *projectService) SetOntologySettings(ctx context.Context, projectID uuid.UUID, settings *OntologySettings) error {
+	overrides, err := ontologySettingsOverrides(settings)
+	if err != nil {
+		return err
+	}
+
 	project, err := s.projectRepo.Get(ctx, projectID)
 	if err != nil {
 		return fmt.Errorf("failed to get project: %w", err)
@@ -589,9 +714,7 @@ func (s *projectService) SetOntologySettings(ctx context.Context, projectID uuid
 		project.Parameters = make(map[string]interface{})
 	}
 
-	project.Parameters["ontology"] = map[string]interface{}{
-		"use_legacy_pattern_matching": settings.UseLegacyPatternMatching,
-	}
+	project.Parameters["ontology"] = overrides
 
 	if err := s.projectRepo.Update(ctx, project); err != nil {
 		return fmt.Errorf("failed to update project: %w", err)
@@ -599,7 +722,13 @@ func (s *projectService) SetOntologySettings(ctx context.Context, projectID uuid
 
 	s.logger.Info("Updated ontology settings for project",
 		zap.String("project_id", projectID.String()),
-		zap.Bool("use_legacy_pattern_matching", settings.UseLegacyPatternMatching))
+		zap.String("profile", settings.Profile),
+		zap.Bool("use_legacy_pattern_matching", settings.UseLegacyPatternMatching),
+		zap.Int("max_prompt_tokens", settings.MaxPromptTokens),
+		zap.Int("enum_activity_window_days", settings.EnumActivityWindowDays),
+		zap.Int("max_questions_per_table", settings.MaxQuestionsPerTable),
+		zap.Int("column_entity_overrides", len(settings.ColumnEntityOverrides)),
+		zap.Strings("critical_tables", settings.CriticalTables))
 
 	return nil
 }
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/apperrors"
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+)
+
+// mockProjectRepoForDefaultDatasource returns a fixed project from Get.
+type mockProjectRepoForDefaultDatasource struct {
+	mockProjectRepoForFinalization
+	project *models.Project
+}
+
+func (m *mockProjectRepoForDefaultDatasource) Get(ctx context.Context, id uuid.UUID) (*models.Project, error) {
+	return m.project, nil
+}
+
+func newDefaultDatasourceTestService(params map[string]interface{}, datasources []*models.Datasource) *projectService {
+	return &projectService{
+		projectRepo:    &mockProjectRepoForDefaultDatasource{project: &models.Project{Parameters: params}},
+		datasourceRepo: &mockDatasourceRepository{datasources: datasources},
+		logger:         zap.NewNop(),
+	}
+}
+
+func TestProjectService_GetDefaultDatasourceID_NoDatasources(t *testing.T) {
+	svc := newDefaultDatasourceTestService(nil, nil)
+
+	dsID, err := svc.GetDefaultDatasourceID(context.Background(), uuid.New())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dsID != uuid.Nil {
+		t.Errorf("expected uuid.Nil, got %s", dsID)
+	}
+}
+
+func TestProjectService_GetDefaultDatasourceID_SingleDatasourceFallback(t *testing.T) {
+	only := &models.Datasource{ID: uuid.New()}
+	svc := newDefaultDatasourceTestService(map[string]interface{}{}, []*models.Datasource{only})
+
+	dsID, err := svc.GetDefaultDatasourceID(context.Background(), uuid.New())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dsID != only.ID {
+		t.Errorf("expected the only datasource %s, got %s", only.ID, dsID)
+	}
+}
+
+func TestProjectService_GetDefaultDatasourceID_ManyDatasourcesAmbiguous(t *testing.T) {
+	svc := newDefaultDatasourceTestService(nil, []*models.Datasource{{ID: uuid.New()}, {ID: uuid.New()}})
+
+	dsID, err := svc.GetDefaultDatasourceID(context.Background(), uuid.New())
+	if !errors.Is(err, apperrors.ErrAmbiguousDefaultDatasource) {
+		t.Fatalf("expected ErrAmbiguousDefaultDatasource, got %v", err)
+	}
+	if dsID != uuid.Nil {
+		t.Errorf("expected uuid.Nil, got %s", dsID)
+	}
+}
+
+func TestProjectService_GetDefaultDatasourceID_ExplicitDefaultWins(t *testing.T) {
+	explicit := uuid.New()
+	svc := newDefaultDatasourceTestService(
+		map[string]interface{}{"default_datasource_id": explicit.String()},
+		[]*models.Datasource{{ID: uuid.New()}, {ID: uuid.New()}},
+	)
+
+	dsID, err := svc.GetDefaultDatasourceID(context.Background(), uuid.New())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dsID != explicit {
+		t.Errorf("expected explicit default %s, got %s", explicit, dsID)
+	}
+}
+
+func TestProjectService_GetDefaultDatasourceID_ListError(t *testing.T) {
+	svc := newDefaultDatasourceTestService(nil, nil)
+	svc.datasourceRepo = &mockDatasourceRepository{listErr: errors.New("db down")}
+
+	if _, err := svc.GetDefaultDatasourceID(context.Background(), uuid.New()); err == nil {
+		t.Fatal("expected error when listing datasources fails")
+	}
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"strconv"
@@ -291,7 +292,7 @@ func (s *queryService) Create(ctx context.Context, projectID, datasourceID uuid.
 func (s *queryService) Get(ctx context.Context, projectID, queryID uuid.UUID) (*models.Query, error) {
 	query, err := s.queryRepo.GetByID(ctx, projectID, queryID)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if errors.Is(err, apperrors.ErrNotFound) {
 			return nil, apperrors.ErrNotFound
 		}
 		return nil, fmt.Errorf("failed to get query: %w", err)
@@ -313,7 +314,7 @@ func (s *queryService) Update(ctx context.Context, projectID, queryID uuid.UUID,
 	// Get existing query
 	query, err := s.queryRepo.GetByID(ctx, projectID, queryID)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if errors.Is(err, apperrors.ErrNotFound) {
 			return nil, apperrors.ErrNotFound
 		}
 		return nil, fmt.Errorf("failed to get query: %w", err)
@@ -391,7 +392,7 @@ func (s *queryService) Delete(ctx context.Context, projectID, queryID uuid.UUID)
 	// Verify query exists
 	_, err := s.queryRepo.GetByID(ctx, projectID, queryID)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if errors.Is(err, apperrors.ErrNotFound) {
 			return apperrors.ErrNotFound
 		}
 		return fmt.Errorf("failed to get query: %w", err)
@@ -435,7 +436,7 @@ func (s *queryService) HasEnabledQueries(ctx context.Context, projectID, datasou
 // SetEnabledStatus updates the enabled status of a query.
 func (s *queryService) SetEnabledStatus(ctx context.Context, projectID, queryID uuid.UUID, isEnabled bool) error {
 	if err := s.queryRepo.UpdateEnabledStatus(ctx, projectID, queryID, isEnabled); err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if errors.Is(err, apperrors.ErrNotFound) {
 			return apperrors.ErrNotFound
 		}
 		return fmt.Errorf("failed to update enabled status: %w", err)
@@ -460,7 +461,7 @@ func (s *queryService) Execute(ctx context.Context, projectID, queryID uuid.UUID
 	// Get query
 	query, err := s.queryRepo.GetByID(ctx, projectID, queryID)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if errors.Is(err, apperrors.ErrNotFound) {
 			return nil, apperrors.ErrNotFound
 		}
 		return nil, fmt.Errorf("failed to get query: %w", err)
@@ -673,7 +674,7 @@ func (s *queryService) ExecuteWithParameters(
 	// 1. Get query
 	query, err := s.queryRepo.GetByID(ctx, projectID, queryID)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if errors.Is(err, apperrors.ErrNotFound) {
 			return nil, apperrors.ErrNotFound
 		}
 		return nil, fmt.Errorf("failed to get query: %w", err)
@@ -766,7 +767,7 @@ func (s *queryService) ExecuteModifyingWithParameters(
 	// 1. Get query
 	query, err := s.queryRepo.GetByID(ctx, projectID, queryID)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if errors.Is(err, apperrors.ErrNotFound) {
 			return nil, apperrors.ErrNotFound
 		}
 		return nil, fmt.Errorf("failed to get query: %w", err)
@@ -1149,7 +1150,7 @@ func (s *queryService) SuggestUpdate(ctx context.Context, projectID uuid.UUID, r
 	// Fetch the original query
 	original, err := s.queryRepo.GetByID(ctx, projectID, req.QueryID)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if errors.Is(err, apperrors.ErrNotFound) {
 			return nil, apperrors.ErrNotFound
 		}
 		return nil, fmt.Errorf("failed to get original query: %w", err)
@@ -1272,7 +1273,7 @@ func (s *queryService) ApproveQuery(ctx context.Context, projectID, queryID uuid
 	// Get the pending query
 	pending, err := s.queryRepo.GetByID(ctx, projectID, queryID)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if errors.Is(err, apperrors.ErrNotFound) {
 			return apperrors.ErrNotFound
 		}
 		return fmt.Errorf("failed to get query: %w", err)
@@ -1288,7 +1289,7 @@ func (s *queryService) ApproveQuery(ctx context.Context, projectID, queryID uuid
 		// This is an update suggestion - apply changes to the original query
 		original, err := s.queryRepo.GetByID(ctx, projectID, *pending.ParentQueryID)
 		if err != nil {
-			if strings.Contains(err.Error(), "not found") {
+			if errors.Is(err, apperrors.ErrNotFound) {
 				// Original was deleted - reject this suggestion instead
 				reason := "Original query was deleted"
 				return s.queryRepo.UpdateApprovalStatus(ctx, projectID, queryID, "rejected", reviewerID, &reason)
@@ -1346,7 +1347,7 @@ func (s *queryService) RejectQuery(ctx context.Context, projectID, queryID uuid.
 	// Get the pending query
 	pending, err := s.queryRepo.GetByID(ctx, projectID, queryID)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if errors.Is(err, apperrors.ErrNotFound) {
 			return apperrors.ErrNotFound
 		}
 		return fmt.Errorf("failed to get query: %w", err)
@@ -1375,7 +1376,7 @@ func (s *queryService) MoveToPending(ctx context.Context, projectID, queryID uui
 	// Get the rejected query
 	query, err := s.queryRepo.GetByID(ctx, projectID, queryID)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if errors.Is(err, apperrors.ErrNotFound) {
 			return apperrors.ErrNotFound
 		}
 		return fmt.Errorf("failed to get query: %w", err)
@@ -1422,7 +1423,7 @@ func (s *queryService) DeleteWithPendingRejection(ctx context.Context, projectID
 	// Verify query exists
 	_, err := s.queryRepo.GetByID(ctx, projectID, queryID)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if errors.Is(err, apperrors.ErrNotFound) {
 			return 0, apperrors.ErrNotFound
 		}
 		return 0, fmt.Errorf("failed to get query: %w", err)
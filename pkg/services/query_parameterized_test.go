@@ -13,6 +13,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/ekaya-inc/ekaya-engine/pkg/adapters/datasource"
+	"github.com/ekaya-inc/ekaya-engine/pkg/apperrors"
 	"github.com/ekaya-inc/ekaya-engine/pkg/audit"
 	"github.com/ekaya-inc/ekaya-engine/pkg/auth"
 	"github.com/ekaya-inc/ekaya-engine/pkg/models"
@@ -449,7 +450,7 @@ func (m *mockQueryRepository) GetByID(ctx context.Context, projectID, queryID uu
 	if m.query != nil {
 		return m.query, nil
 	}
-	return nil, fmt.Errorf("query not found")
+	return nil, fmt.Errorf("query %w", apperrors.ErrNotFound)
 }
 
 func (m *mockQueryRepository) ListByDatasource(ctx context.Context, projectID, datasourceID uuid.UUID) ([]*models.Query, error) {
@@ -560,6 +561,28 @@ func (m *mockDatasourceSvc) Rename(ctx context.Context, id uuid.UUID, name strin
 	return nil
 }
 
+// --- Tests for not-found error propagation ---
+
+// TestGet_SurfacesRepositoryNotFound verifies that a missing row reported by the
+// repository surfaces as apperrors.ErrNotFound, and that other errors whose text
+// merely mentions "not found" are not mistaken for it.
+func TestGet_SurfacesRepositoryNotFound(t *testing.T) {
+	svc := &queryService{
+		logger:    zap.NewNop(),
+		queryRepo: &mockQueryRepository{},
+	}
+
+	_, err := svc.Get(context.Background(), uuid.New(), uuid.New())
+	require.Error(t, err)
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+
+	svc.queryRepo = &mockQueryRepository{getErr: errors.New("relation \"engine_queries\" not found in search path")}
+	_, err = svc.Get(context.Background(), uuid.New(), uuid.New())
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, apperrors.ErrNotFound)
+	assert.Contains(t, err.Error(), "failed to get query")
+}
+
 // --- Tests for Create() parameter validation ---
 
 // TestCreate_RejectsUndefinedParameters tests that Create() rejects queries
@@ -1101,7 +1124,7 @@ func (m *mockQueryRepoForApproval) GetByID(ctx context.Context, projectID, query
 	if q, ok := m.queries[queryID]; ok {
 		return q, nil
 	}
-	return nil, fmt.Errorf("query not found")
+	return nil, fmt.Errorf("query %w", apperrors.ErrNotFound)
 }
 
 func (m *mockQueryRepoForApproval) ListByDatasource(ctx context.Context, projectID, datasourceID uuid.UUID) ([]*models.Query, error) {
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+)
+
+var (
+	// quotedTokenPattern captures short quoted strings in question text, e.g. what does "GMV" mean.
+	quotedTokenPattern = regexp.MustCompile("[\"'`]([^\"'`]{1,40})[\"'`]")
+	// abbreviationPattern captures all-caps tokens such as ARR or SKU_CODE.
+	abbreviationPattern = regexp.MustCompile(`\b[A-Z][A-Z0-9_]{1,11}\b`)
+)
+
+// AnswerFromGlossary answers pending questions that one of the given glossary terms
+// already resolves. A question matches a term when the term or one of its aliases
+// names a column the question affects, is quoted in the question, or is an
+// abbreviation used in the question. The answer is the term's definition, prefixed
+// with the term it came from so the provenance is visible in the question history.
+// Terms without a definition, or flagged for review, never answer questions.
+func (s *ontologyQuestionService) AnswerFromGlossary(ctx context.Context, projectID uuid.UUID, terms []*models.BusinessGlossaryTerm) (int, error) {
+	usable := make([]*models.BusinessGlossaryTerm, 0, len(terms))
+	for _, t := range terms {
+		if t != nil && strings.TrimSpace(t.Definition) != "" && !t.NeedsReview {
+			usable = append(usable, t)
+		}
+	}
+	if len(usable) == 0 {
+		return 0, nil
+	}
+
+	questions, err := s.questionRepo.ListPending(ctx, projectID)
+	if err != nil {
+		return 0, fmt.Errorf("list pending questions: %w", err)
+	}
+
+	answered := 0
+	for _, q := range questions {
+		term := matchQuestionToGlossaryTerm(q, usable)
+		if term == nil {
+			continue
+		}
+		answer := fmt.Sprintf("From glossary term %q: %s", term.Term, strings.TrimSpace(term.Definition))
+		if err := s.questionRepo.SubmitAnswer(ctx, q.ID, answer, nil); err != nil {
+			return answered, fmt.Errorf("answer question %s from glossary: %w", q.ID, err)
+		}
+		s.logger.Info("Answered question from glossary term",
+			zap.String("question_id", q.ID.String()),
+			zap.String("term", term.Term))
+		answered++
+	}
+
+	return answered, nil
+}
+
+// matchQuestionToGlossaryTerm returns the first term whose name or alias matches
+// what the question targets, or nil.
+func matchQuestionToGlossaryTerm(q *models.OntologyQuestion, terms []*models.BusinessGlossaryTerm) *models.BusinessGlossaryTerm {
+	targets := questionGlossaryTargets(q)
+	if len(targets) == 0 {
+		return nil
+	}
+	for _, t := range terms {
+		if targets[normalizeGlossaryKey(t.Term)] {
+			return t
+		}
+		for _, alias := range t.Aliases {
+			if targets[normalizeGlossaryKey(alias)] {
+				return t
+			}
+		}
+	}
+	return nil
+}
+
+// questionGlossaryTargets collects the normalized names a question is asking about:
+// the columns it affects, quoted strings and all-caps abbreviations in its text.
+func questionGlossaryTargets(q *models.OntologyQuestion) map[string]bool {
+	targets := make(map[string]bool)
+	add := func(s string) {
+		if key := normalizeGlossaryKey(s); key != "" {
+			targets[key] = true
+		}
+	}
+
+	if q.Affects != nil {
+		for _, col := range q.Affects.Columns {
+			if _, column, ok := strings.Cut(col, "."); ok {
+				add(column)
+			} else {
+				add(col)
+			}
+		}
+	}
+	for _, m := range quotedTokenPattern.FindAllStringSubmatch(q.Text, -1) {
+		add(m[1])
+	}
+	for _, m := range abbreviationPattern.FindAllString(q.Text, -1) {
+		add(m)
+	}
+	return targets
+}
+
+// normalizeGlossaryKey lowercases s and treats spaces and hyphens as underscores,
+// so "Order Status", "order-status" and "order_status" compare equal.
+func normalizeGlossaryKey(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(s)
+}
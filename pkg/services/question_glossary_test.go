@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+)
+
+func TestAnswerFromGlossary_AnswersQuestionAboutColumnAbbreviation(t *testing.T) {
+	projectID := uuid.New()
+	mrrQuestion := &models.OntologyQuestion{
+		ID:       uuid.New(),
+		Text:     "What does the mrr column on subscriptions represent?",
+		Category: models.QuestionCategoryTerminology,
+		Affects:  &models.QuestionAffects{Columns: []string{"subscriptions.mrr"}},
+		Status:   models.QuestionStatusPending,
+	}
+	statusQuestion := &models.OntologyQuestion{
+		ID:      uuid.New(),
+		Text:    "What does status=3 mean on orders?",
+		Affects: &models.QuestionAffects{Columns: []string{"orders.status"}},
+		Status:  models.QuestionStatusPending,
+	}
+
+	answers := make(map[uuid.UUID]string)
+	questionRepo := &mockQuestionRepo{
+		listPendingFunc: func(ctx context.Context, pid uuid.UUID) ([]*models.OntologyQuestion, error) {
+			return []*models.OntologyQuestion{mrrQuestion, statusQuestion}, nil
+		},
+		submitAnswerFunc: func(ctx context.Context, id uuid.UUID, answer string, answeredBy *uuid.UUID) error {
+			assert.Nil(t, answeredBy, "glossary answers are not attributed to a user")
+			answers[id] = answer
+			return nil
+		},
+	}
+	svc := newTestQuestionService(questionRepo, &mockKnowledgeRepo{}, &mockBuilder{})
+
+	term := &models.BusinessGlossaryTerm{
+		Term:       "Monthly Recurring Revenue",
+		Definition: "Sum of active subscription prices normalized to one month.",
+		Aliases:    []string{"MRR"},
+	}
+	answered, err := svc.AnswerFromGlossary(context.Background(), projectID, []*models.BusinessGlossaryTerm{term})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, answered)
+	assert.Equal(t,
+		`From glossary term "Monthly Recurring Revenue": Sum of active subscription prices normalized to one month.`,
+		answers[mrrQuestion.ID])
+	assert.NotContains(t, answers, statusQuestion.ID)
+}
+
+func TestAnswerFromGlossary_SkipsTermsUnderReview(t *testing.T) {
+	questionRepo := &mockQuestionRepo{
+		listPendingFunc: func(ctx context.Context, pid uuid.UUID) ([]*models.OntologyQuestion, error) {
+			t.Fatal("pending questions should not be loaded without a usable term")
+			return nil, nil
+		},
+	}
+	svc := newTestQuestionService(questionRepo, &mockKnowledgeRepo{}, &mockBuilder{})
+
+	terms := []*models.BusinessGlossaryTerm{
+		{Term: "ARR", Definition: "Annual recurring revenue.", NeedsReview: true},
+		{Term: "GMV", Definition: "  "},
+	}
+	answered, err := svc.AnswerFromGlossary(context.Background(), uuid.New(), terms)
+	require.NoError(t, err)
+	assert.Equal(t, 0, answered)
+}
+
+func TestMatchQuestionToGlossaryTerm(t *testing.T) {
+	gmv := &models.BusinessGlossaryTerm{Term: "GMV", Definition: "Gross merchandise value."}
+	orderStatus := &models.BusinessGlossaryTerm{Term: "Order Status", Definition: "Lifecycle state of an order."}
+	terms := []*models.BusinessGlossaryTerm{gmv, orderStatus}
+
+	tests := []struct {
+		name     string
+		question *models.OntologyQuestion
+		want     *models.BusinessGlossaryTerm
+	}{
+		{
+			name:     "abbreviation in text",
+			question: &models.OntologyQuestion{Text: "How is GMV calculated?"},
+			want:     gmv,
+		},
+		{
+			name:     "quoted term",
+			question: &models.OntologyQuestion{Text: `What does "order status" track?`},
+			want:     orderStatus,
+		},
+		{
+			name: "affected column",
+			question: &models.OntologyQuestion{
+				Text:    "What are the possible values?",
+				Affects: &models.QuestionAffects{Columns: []string{"orders.order_status"}},
+			},
+			want: orderStatus,
+		},
+		{
+			name:     "lowercase word is not an abbreviation",
+			question: &models.OntologyQuestion{Text: "Is gmv net of refunds?"},
+			want:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, matchQuestionToGlossaryTerm(tt.question, terms))
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package services
 
 import (
+	"strings"
+
 	"github.com/google/uuid"
 
 	"github.com/ekaya-inc/ekaya-engine/pkg/models"
@@ -9,7 +11,7 @@ import (
 // OntologyQuestionInput represents a question generated by the LLM during enrichment.
 // This is the common format returned by entity, column, and relationship enrichment prompts.
 type OntologyQuestionInput struct {
-	Category string   `json:"category"`          // terminology | enumeration | relationship | business_rules | temporal | data_quality
+	Category string   `json:"category"`          // One of the question category taxonomy, see SnapQuestionCategory
 	Priority int      `json:"priority"`          // 1=critical | 2=important | 3=nice-to-have
 	Question string   `json:"question"`          // Clear question for domain expert
 	Context  string   `json:"context"`           // Relevant schema/data context
@@ -72,3 +74,98 @@ func ConvertQuestionInputs(
 
 	return questions
 }
+
+// questionCategoryAliases maps category names LLMs commonly invent to the built-in
+// categories. An alias only applies when its category is in the taxonomy.
+var questionCategoryAliases = map[string]string{
+	"enum":            models.QuestionCategoryEnumeration,
+	"enum_meaning":    models.QuestionCategoryEnumeration,
+	"enum_value":      models.QuestionCategoryEnumeration,
+	"enum_values":     models.QuestionCategoryEnumeration,
+	"status_values":   models.QuestionCategoryEnumeration,
+	"code_meaning":    models.QuestionCategoryEnumeration,
+	"business_rule":   models.QuestionCategoryBusinessRules,
+	"business_logic":  models.QuestionCategoryBusinessRules,
+	"rules":           models.QuestionCategoryBusinessRules,
+	"policy":          models.QuestionCategoryBusinessRules,
+	"join":            models.QuestionCategoryRelationship,
+	"foreign_key":     models.QuestionCategoryRelationship,
+	"fk":              models.QuestionCategoryRelationship,
+	"term":            models.QuestionCategoryTerminology,
+	"naming":          models.QuestionCategoryTerminology,
+	"definition":      models.QuestionCategoryTerminology,
+	"glossary":        models.QuestionCategoryTerminology,
+	"meaning":         models.QuestionCategoryTerminology,
+	"time":            models.QuestionCategoryTemporal,
+	"timing":          models.QuestionCategoryTemporal,
+	"date":            models.QuestionCategoryTemporal,
+	"timestamp":       models.QuestionCategoryTemporal,
+	"quality":         models.QuestionCategoryDataQuality,
+	"data_integrity":  models.QuestionCategoryDataQuality,
+	"data_validation": models.QuestionCategoryDataQuality,
+	"nulls":           models.QuestionCategoryDataQuality,
+}
+
+// questionCategoriesOrDefault returns the configured question category taxonomy, or
+// the built-in categories when none is configured.
+func questionCategoriesOrDefault(configured []string) []string {
+	var categories []string
+	for _, c := range configured {
+		if c = normalizeQuestionCategory(c); c != "" {
+			categories = append(categories, c)
+		}
+	}
+	if len(categories) == 0 {
+		return models.ValidQuestionCategories
+	}
+	return categories
+}
+
+// SnapQuestionCategory maps a category returned by the LLM onto the taxonomy:
+// case, spacing and hyphens are normalized, singular and plural forms match each
+// other, and common aliases ("enum_meaning", "business_logic") map to their
+// category. Returns false when the category cannot be mapped.
+func SnapQuestionCategory(category string, taxonomy []string) (string, bool) {
+	normalized := normalizeQuestionCategory(category)
+	if normalized == "" {
+		return "", false
+	}
+
+	candidates := []string{normalized, strings.TrimSuffix(normalized, "s"), normalized + "s"}
+	if alias, ok := questionCategoryAliases[normalized]; ok {
+		candidates = append(candidates, alias)
+	}
+	for _, candidate := range candidates {
+		for _, c := range taxonomy {
+			if candidate == c {
+				return c, true
+			}
+		}
+	}
+	return "", false
+}
+
+// SnapQuestionCategories snaps every question's category onto the taxonomy. A question
+// whose category cannot be mapped keeps it, so the model's intent is not lost; those
+// categories are returned so the caller can flag them. Questions without a category
+// are left alone.
+func SnapQuestionCategories(questions []*models.OntologyQuestion, taxonomy []string) []string {
+	var unmapped []string
+	for _, q := range questions {
+		if q.Category == "" {
+			continue
+		}
+		if snapped, ok := SnapQuestionCategory(q.Category, taxonomy); ok {
+			q.Category = snapped
+		} else {
+			unmapped = append(unmapped, q.Category)
+		}
+	}
+	return unmapped
+}
+
+func normalizeQuestionCategory(category string) string {
+	c := strings.ToLower(strings.TrimSpace(category))
+	c = strings.NewReplacer(" ", "_", "-", "_", "/", "_").Replace(c)
+	return strings.Trim(c, "_")
+}
@@ -166,3 +166,61 @@ func TestConvertQuestionInputs_WorkflowIDOptional(t *testing.T) {
 	require.Len(t, result, 1)
 	assert.Equal(t, &workflowID, result[0].WorkflowID)
 }
+
+func TestSnapQuestionCategory(t *testing.T) {
+	taxonomy := models.ValidQuestionCategories
+
+	tests := []struct {
+		category string
+		want     string
+		wantOK   bool
+	}{
+		{"enumeration", models.QuestionCategoryEnumeration, true},
+		{"Business Rules", models.QuestionCategoryBusinessRules, true},
+		{"business-rule", models.QuestionCategoryBusinessRules, true},
+		{"relationships", models.QuestionCategoryRelationship, true},
+		{"enum_meaning", models.QuestionCategoryEnumeration, true},
+		{"Data Integrity", models.QuestionCategoryDataQuality, true},
+		{"security", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.category, func(t *testing.T) {
+			got, ok := SnapQuestionCategory(tt.category, taxonomy)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSnapQuestionCategory_AliasOutsideTaxonomy(t *testing.T) {
+	// enum_meaning aliases enumeration, which this taxonomy leaves out
+	_, ok := SnapQuestionCategory("enum_meaning", []string{"business_rules", "compliance"})
+	assert.False(t, ok)
+
+	got, ok := SnapQuestionCategory("Compliance", []string{"business_rules", "compliance"})
+	assert.True(t, ok)
+	assert.Equal(t, "compliance", got)
+}
+
+func TestSnapQuestionCategories_FlagsUnmappable(t *testing.T) {
+	questions := []*models.OntologyQuestion{
+		{Text: "What does status 3 mean?", Category: "Enum Meaning"},
+		{Text: "Who can see salaries?", Category: "security"},
+		{Text: "Is phone optional?", Category: ""},
+	}
+
+	unmapped := SnapQuestionCategories(questions, models.ValidQuestionCategories)
+
+	assert.Equal(t, []string{"security"}, unmapped)
+	assert.Equal(t, models.QuestionCategoryEnumeration, questions[0].Category)
+	assert.Equal(t, "security", questions[1].Category, "unmappable categories are kept")
+	assert.Equal(t, "", questions[2].Category)
+}
+
+func TestQuestionCategoriesOrDefault(t *testing.T) {
+	assert.Equal(t, models.ValidQuestionCategories, questionCategoriesOrDefault(nil))
+	assert.Equal(t, models.ValidQuestionCategories, questionCategoriesOrDefault([]string{" "}))
+	assert.Equal(t, []string{"business_rules", "compliance"}, questionCategoriesOrDefault([]string{"Business Rules", "compliance"}))
+}
@@ -3,7 +3,6 @@ package services
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -31,15 +30,20 @@ type relationshipBootstrapService struct {
 	adapterFactory     datasource.DatasourceAdapterFactory
 	schemaRepo         repositories.SchemaRepository
 	columnMetadataRepo repositories.ColumnMetadataRepository
+	projectRepo        repositories.ProjectRepository
+	trustDeclaredFKs   bool
 	logger             *zap.Logger
 }
 
 // NewRelationshipBootstrapService creates a bootstrap service for the FKDiscovery DAG stage.
+// With trustDeclaredFKs, declared foreign keys are recorded without join analysis.
 func NewRelationshipBootstrapService(
 	datasourceService DatasourceService,
 	adapterFactory datasource.DatasourceAdapterFactory,
 	schemaRepo repositories.SchemaRepository,
 	columnMetadataRepo repositories.ColumnMetadataRepository,
+	projectRepo repositories.ProjectRepository,
+	trustDeclaredFKs bool,
 	logger *zap.Logger,
 ) RelationshipBootstrapService {
 	return &relationshipBootstrapService{
@@ -47,6 +51,8 @@ func NewRelationshipBootstrapService(
 		adapterFactory:     adapterFactory,
 		schemaRepo:         schemaRepo,
 		columnMetadataRepo: columnMetadataRepo,
+		projectRepo:        projectRepo,
+		trustDeclaredFKs:   trustDeclaredFKs,
 		logger:             logger.Named("relationship-bootstrap"),
 	}
 }
@@ -62,21 +68,24 @@ func (s *relationshipBootstrapService) Bootstrap(
 	}
 
 	tableByID := make(map[uuid.UUID]*models.SchemaTable, len(tables))
-	tableByQualifiedName := make(map[string]*models.SchemaTable, len(tables))
+	tableByQualifiedName := datasource.NewIdentifierIndex[*models.SchemaTable]()
+	// Bare names are keyed on the normalized form; a name shared across schemas
+	// (or by case-sensitive variants) is ambiguous and must be schema-qualified.
 	uniqueTableByName := make(map[string]*models.SchemaTable, len(tables))
 	ambiguousTableNames := make(map[string]struct{})
 	for _, table := range tables {
 		tableByID[table.ID] = table
-		tableByQualifiedName[fmt.Sprintf("%s.%s", table.SchemaName, table.TableName)] = table
-		if _, isAmbiguous := ambiguousTableNames[table.TableName]; isAmbiguous {
+		tableByQualifiedName.Add(fmt.Sprintf("%s.%s", table.SchemaName, table.TableName), table)
+		nameKey := datasource.NormalizeIdentifier(table.TableName)
+		if _, isAmbiguous := ambiguousTableNames[nameKey]; isAmbiguous {
 			continue
 		}
-		if existing, ok := uniqueTableByName[table.TableName]; ok && existing.ID != table.ID {
-			delete(uniqueTableByName, table.TableName)
-			ambiguousTableNames[table.TableName] = struct{}{}
+		if existing, ok := uniqueTableByName[nameKey]; ok && existing.ID != table.ID {
+			delete(uniqueTableByName, nameKey)
+			ambiguousTableNames[nameKey] = struct{}{}
 			continue
 		}
-		uniqueTableByName[table.TableName] = table
+		uniqueTableByName[nameKey] = table
 	}
 
 	columns, err := s.schemaRepo.ListColumnsByDatasource(ctx, projectID, datasourceID)
@@ -188,7 +197,7 @@ func (s *relationshipBootstrapService) bootstrapColumnFeatureRelationships(
 	projectID uuid.UUID,
 	columns []*models.SchemaColumn,
 	tableByID map[uuid.UUID]*models.SchemaTable,
-	tableByQualifiedName map[string]*models.SchemaTable,
+	tableByQualifiedName *datasource.IdentifierIndex[*models.SchemaTable],
 	uniqueTableByName map[string]*models.SchemaTable,
 	ambiguousTableNames map[string]struct{},
 	metadataByColumnID map[uuid.UUID]*models.ColumnMetadata,
@@ -201,8 +210,21 @@ func (s *relationshipBootstrapService) bootstrapColumnFeatureRelationships(
 		identifier *models.IdentifierFeatures
 	}
 
+	overrides := loadColumnEntityOverrides(ctx, s.projectRepo, projectID, s.logger)
+
 	fkColumns := make([]fkColumn, 0)
 	for _, column := range columns {
+		// A user override decides the target outright, whatever inference concluded.
+		if sourceTable := tableByID[column.SchemaTableID]; sourceTable != nil {
+			if target, ok := columnEntityOverride(overrides, sourceTable.TableName, column.ColumnName); ok {
+				fkColumns = append(fkColumns, fkColumn{
+					column:     column,
+					identifier: &models.IdentifierFeatures{FKTargetTable: target, FKConfidence: 1.0},
+				})
+				continue
+			}
+		}
+
 		metadata := metadataByColumnID[column.ID]
 		if metadata == nil {
 			continue
@@ -250,7 +272,7 @@ func (s *relationshipBootstrapService) bootstrapColumnFeatureRelationships(
 
 		targetColumnName := identifier.FKTargetColumn
 		if targetColumnName == "" {
-			targetColumnName = "id"
+			targetColumnName = primaryKeyColumnName(columns, targetTable.ID)
 		}
 
 		var targetColumn *models.SchemaColumn
@@ -385,18 +407,27 @@ func (s *relationshipBootstrapService) refreshDeclaredFKRelationships(
 		}
 
 		cardinality := models.CardinalityNTo1
-		joinResult, err := discoverer.AnalyzeJoin(
-			ctx,
-			sourceTable.SchemaName, sourceTable.TableName, sourceColumn.ColumnName,
-			targetTable.SchemaName, targetTable.TableName, targetColumn.ColumnName,
-		)
-		if err != nil {
-			s.logger.Warn("Failed to analyze declared FK join; using default cardinality",
-				zap.String("source", fmt.Sprintf("%s.%s.%s", sourceTable.SchemaName, sourceTable.TableName, sourceColumn.ColumnName)),
-				zap.String("target", fmt.Sprintf("%s.%s.%s", targetTable.SchemaName, targetTable.TableName, targetColumn.ColumnName)),
-				zap.Error(err))
+		if s.trustDeclaredFKs {
+			// The database enforces the constraint, so a unique source can only
+			// reference one target row per value
+			if sourceColumn.IsPrimaryKey || sourceColumn.IsUnique {
+				cardinality = models.Cardinality1To1
+			}
+			relationship.Confidence = 1.0
 		} else {
-			cardinality = InferCardinality(sourceColumn.IsPrimaryKey, sourceColumn.IsUnique, joinResult)
+			joinResult, err := discoverer.AnalyzeJoin(
+				ctx,
+				sourceTable.SchemaName, sourceTable.TableName, sourceColumn.ColumnName,
+				targetTable.SchemaName, targetTable.TableName, targetColumn.ColumnName,
+			)
+			if err != nil {
+				s.logger.Warn("Failed to analyze declared FK join; using default cardinality",
+					zap.String("source", fmt.Sprintf("%s.%s.%s", sourceTable.SchemaName, sourceTable.TableName, sourceColumn.ColumnName)),
+					zap.String("target", fmt.Sprintf("%s.%s.%s", targetTable.SchemaName, targetTable.TableName, targetColumn.ColumnName)),
+					zap.Error(err))
+			} else {
+				cardinality = InferCardinality(sourceColumn.IsPrimaryKey, sourceColumn.IsUnique, joinResult)
+			}
 		}
 
 		relationship.Cardinality = cardinality
@@ -452,34 +483,36 @@ func getActiveRelationshipAfterUpsert(
 func resolveBootstrapTargetTable(
 	sourceTable *models.SchemaTable,
 	targetTableName string,
-	tableByQualifiedName map[string]*models.SchemaTable,
+	tableByQualifiedName *datasource.IdentifierIndex[*models.SchemaTable],
 	uniqueTableByName map[string]*models.SchemaTable,
 	ambiguousTableNames map[string]struct{},
 ) *models.SchemaTable {
 	if targetTableName == "" {
 		return nil
 	}
-	if strings.Contains(targetTableName, ".") {
-		return tableByQualifiedName[targetTableName]
+	if schemaName, _ := datasource.SplitQualifiedIdentifier(targetTableName); schemaName != "" {
+		targetTable, _ := tableByQualifiedName.Get(targetTableName)
+		return targetTable
 	}
 
 	if sourceTable != nil {
-		if targetTable := tableByQualifiedName[fmt.Sprintf("%s.%s", sourceTable.SchemaName, targetTableName)]; targetTable != nil {
+		if targetTable, ok := tableByQualifiedName.Get(fmt.Sprintf("%s.%s", sourceTable.SchemaName, targetTableName)); ok {
 			return targetTable
 		}
 	}
 
-	if _, isAmbiguous := ambiguousTableNames[targetTableName]; isAmbiguous {
+	nameKey := datasource.NormalizeIdentifier(targetTableName)
+	if _, isAmbiguous := ambiguousTableNames[nameKey]; isAmbiguous {
 		return nil
 	}
-	return uniqueTableByName[targetTableName]
+	return uniqueTableByName[nameKey]
 }
 
 func (s *relationshipBootstrapService) discoverDeclaredFKRelationships(
 	ctx context.Context,
 	projectID uuid.UUID,
 	existingRelationships []*models.SchemaRelationship,
-	tableByQualifiedName map[string]*models.SchemaTable,
+	tableByQualifiedName *datasource.IdentifierIndex[*models.SchemaTable],
 	columnByTableAndName map[string]*models.SchemaColumn,
 	declaredFKKeys map[string]struct{},
 	discoverer datasource.SchemaDiscoverer,
@@ -497,7 +530,7 @@ func (s *relationshipBootstrapService) discoverDeclaredFKRelationships(
 	relationships = append(relationships, existingRelationships...)
 
 	for _, fk := range discoveredFKs {
-		sourceTable := tableByQualifiedName[fmt.Sprintf("%s.%s", fk.SourceSchema, fk.SourceTable)]
+		sourceTable, _ := tableByQualifiedName.Get(fmt.Sprintf("%s.%s", fk.SourceSchema, fk.SourceTable))
 		if sourceTable == nil {
 			s.logger.Warn("FK source table not found during bootstrap, skipping",
 				zap.String("constraint", fk.ConstraintName),
@@ -505,7 +538,7 @@ func (s *relationshipBootstrapService) discoverDeclaredFKRelationships(
 			continue
 		}
 
-		targetTable := tableByQualifiedName[fmt.Sprintf("%s.%s", fk.TargetSchema, fk.TargetTable)]
+		targetTable, _ := tableByQualifiedName.Get(fmt.Sprintf("%s.%s", fk.TargetSchema, fk.TargetTable))
 		if targetTable == nil {
 			s.logger.Warn("FK target table not found during bootstrap, skipping",
 				zap.String("constraint", fk.ConstraintName),
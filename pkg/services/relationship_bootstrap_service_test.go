@@ -80,6 +80,8 @@ func TestRelationshipBootstrapService_BootstrapCreatesColumnFeaturesRelationship
 		mockAdapterFactory,
 		mockSchemaRepo,
 		mockColumnMetadataRepo,
+		nil,
+		false,
 		logger,
 	)
 
@@ -101,6 +103,77 @@ func TestRelationshipBootstrapService_BootstrapCreatesColumnFeaturesRelationship
 	assert.Equal(t, models.CardinalityNTo1, rel.Cardinality)
 }
 
+func TestRelationshipBootstrapService_BootstrapColumnEntityOverrideRedirectsInferredTarget(t *testing.T) {
+	logger := zap.NewNop()
+	projectID := uuid.New()
+	datasourceID := uuid.New()
+	paymentsTableID := uuid.New()
+	usersTableID := uuid.New()
+	accountsTableID := uuid.New()
+	paymentUserIDColID := uuid.New()
+	accountPKColID := uuid.New()
+
+	mockSchemaRepo := &mockSchemaRepoForBootstrap{
+		tables: []*models.SchemaTable{
+			{ID: paymentsTableID, ProjectID: projectID, DatasourceID: datasourceID, SchemaName: "public", TableName: "payments"},
+			{ID: usersTableID, ProjectID: projectID, DatasourceID: datasourceID, SchemaName: "public", TableName: "users"},
+			{ID: accountsTableID, ProjectID: projectID, DatasourceID: datasourceID, SchemaName: "public", TableName: "accounts"},
+		},
+		columns: []*models.SchemaColumn{
+			{ID: paymentUserIDColID, ProjectID: projectID, SchemaTableID: paymentsTableID, ColumnName: "user_id", DataType: "uuid"},
+			{ID: uuid.New(), ProjectID: projectID, SchemaTableID: usersTableID, ColumnName: "id", DataType: "uuid", IsPrimaryKey: true},
+			{ID: accountPKColID, ProjectID: projectID, SchemaTableID: accountsTableID, ColumnName: "account_id", DataType: "uuid", IsPrimaryKey: true},
+		},
+	}
+
+	// Inference attributes payments.user_id to users; the analyst knows it is an account.
+	mockColumnMetadataRepo := &mockColumnMetadataRepoForBootstrap{
+		metadataByColumnID: map[uuid.UUID]*models.ColumnMetadata{
+			paymentUserIDColID: {
+				SchemaColumnID: paymentUserIDColID,
+				Features: models.ColumnMetadataFeatures{
+					IdentifierFeatures: &models.IdentifierFeatures{
+						FKTargetTable:  "users",
+						FKTargetColumn: "id",
+						FKConfidence:   0.95,
+					},
+				},
+			},
+		},
+	}
+	projectRepo := &mockProjectRepoForHealth{project: &models.Project{
+		ID: projectID,
+		Parameters: map[string]interface{}{
+			"ontology": map[string]interface{}{
+				"column_entity_overrides": map[string]interface{}{"payments.user_id": "accounts"},
+			},
+		},
+	}}
+
+	svc := NewRelationshipBootstrapService(
+		&mockDatasourceServiceForBootstrap{
+			datasource: &models.Datasource{ID: datasourceID, ProjectID: projectID, DatasourceType: "postgres", Config: map[string]any{}},
+		},
+		&mockAdapterFactoryForBootstrap{schemaDiscoverer: &mockSchemaDiscovererForBootstrap{}},
+		mockSchemaRepo,
+		mockColumnMetadataRepo,
+		projectRepo,
+		false,
+		logger,
+	)
+
+	result, err := svc.Bootstrap(context.Background(), projectID, datasourceID, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.ColumnFeatureRelationships)
+	require.Len(t, mockSchemaRepo.upsertedRelationshipsWithMetrics, 1)
+	rel := mockSchemaRepo.upsertedRelationshipsWithMetrics[0]
+	assert.Equal(t, paymentUserIDColID, rel.SourceColumnID)
+	assert.Equal(t, accountsTableID, rel.TargetTableID)
+	assert.Equal(t, accountPKColID, rel.TargetColumnID)
+	assert.Equal(t, 1.0, rel.Confidence)
+}
+
 func TestRelationshipBootstrapService_BootstrapSkipsSoftDeletedColumnFeaturesRelationships(t *testing.T) {
 	logger := zap.NewNop()
 	projectID := uuid.New()
@@ -179,6 +252,8 @@ func TestRelationshipBootstrapService_BootstrapSkipsSoftDeletedColumnFeaturesRel
 		mockAdapterFactory,
 		mockSchemaRepo,
 		mockColumnMetadataRepo,
+		nil,
+		false,
 		logger,
 	)
 
@@ -259,6 +334,8 @@ func TestRelationshipBootstrapService_BootstrapRecomputesDeclaredFKCardinality(t
 		mockAdapterFactory,
 		mockSchemaRepo,
 		nil,
+		nil,
+		false,
 		logger,
 	)
 
@@ -275,6 +352,103 @@ func TestRelationshipBootstrapService_BootstrapRecomputesDeclaredFKCardinality(t
 	assert.True(t, mockSchemaRepo.upsertedRelationships[0].IsValidated)
 }
 
+func TestRelationshipBootstrapService_BootstrapTrustsDeclaredFKsWithoutJoinAnalysis(t *testing.T) {
+	logger := zap.NewNop()
+	projectID := uuid.New()
+	datasourceID := uuid.New()
+	ordersTableID := uuid.New()
+	accountsTableID := uuid.New()
+	paymentsTableID := uuid.New()
+	accountIDColID := uuid.New()
+	ordersAccountIDColID := uuid.New()
+	paymentAccountIDColID := uuid.New()
+	fkMethod := models.InferenceMethodFK
+
+	mockSchemaRepo := &mockSchemaRepoForBootstrap{
+		tables: []*models.SchemaTable{
+			{ID: ordersTableID, ProjectID: projectID, DatasourceID: datasourceID, SchemaName: "public", TableName: "orders"},
+			{ID: accountsTableID, ProjectID: projectID, DatasourceID: datasourceID, SchemaName: "public", TableName: "accounts"},
+			{ID: paymentsTableID, ProjectID: projectID, DatasourceID: datasourceID, SchemaName: "public", TableName: "payments"},
+		},
+		columns: []*models.SchemaColumn{
+			{ID: ordersAccountIDColID, ProjectID: projectID, SchemaTableID: ordersTableID, ColumnName: "account_id", DataType: "uuid"},
+			{ID: accountIDColID, ProjectID: projectID, SchemaTableID: accountsTableID, ColumnName: "id", DataType: "uuid", IsPrimaryKey: true},
+			{ID: paymentAccountIDColID, ProjectID: projectID, SchemaTableID: paymentsTableID, ColumnName: "account_id", DataType: "uuid"},
+		},
+		relationships: []*models.SchemaRelationship{
+			{
+				ID:              uuid.New(),
+				ProjectID:       projectID,
+				SourceTableID:   ordersTableID,
+				SourceColumnID:  ordersAccountIDColID,
+				TargetTableID:   accountsTableID,
+				TargetColumnID:  accountIDColID,
+				Cardinality:     models.CardinalityUnknown,
+				Confidence:      0.9,
+				InferenceMethod: &fkMethod,
+			},
+		},
+	}
+
+	// payments.account_id is inferred from column features, not declared
+	mockColumnMetadataRepo := &mockColumnMetadataRepoForBootstrap{
+		metadataByColumnID: map[uuid.UUID]*models.ColumnMetadata{
+			paymentAccountIDColID: {
+				SchemaColumnID: paymentAccountIDColID,
+				Features: models.ColumnMetadataFeatures{
+					IdentifierFeatures: &models.IdentifierFeatures{
+						FKTargetTable:  "accounts",
+						FKTargetColumn: "id",
+						FKConfidence:   0.95,
+					},
+				},
+			},
+		},
+	}
+
+	mockDatasourceSvc := &mockDatasourceServiceForBootstrap{
+		datasource: &models.Datasource{
+			ID:             datasourceID,
+			ProjectID:      projectID,
+			DatasourceType: "postgres",
+			Config:         map[string]any{},
+		},
+	}
+
+	discoverer := &mockSchemaDiscovererForBootstrap{
+		joinResults: map[string]*datasource.JoinAnalysis{
+			"public.orders.account_id->public.accounts.id":   {JoinCount: 75, SourceMatched: 75, TargetMatched: 50},
+			"public.payments.account_id->public.accounts.id": {JoinCount: 40, SourceMatched: 40, TargetMatched: 30},
+		},
+	}
+
+	svc := NewRelationshipBootstrapService(
+		mockDatasourceSvc,
+		&mockAdapterFactoryForBootstrap{schemaDiscoverer: discoverer},
+		mockSchemaRepo,
+		mockColumnMetadataRepo,
+		nil,
+		true,
+		logger,
+	)
+
+	result, err := svc.Bootstrap(context.Background(), projectID, datasourceID, nil)
+
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, 1, result.DeclaredFKRelationships)
+	assert.Equal(t, 1, result.ColumnFeatureRelationships)
+	assert.Equal(t, []string{"public.payments.account_id->public.accounts.id"}, discoverer.joinCalls,
+		"only the inferred relationship should be join-validated")
+
+	require.Len(t, mockSchemaRepo.upsertedRelationships, 1)
+	declared := mockSchemaRepo.upsertedRelationships[0]
+	assert.Equal(t, ordersAccountIDColID, declared.SourceColumnID)
+	assert.Equal(t, models.CardinalityNTo1, declared.Cardinality)
+	assert.Equal(t, 1.0, declared.Confidence)
+	assert.True(t, declared.IsValidated)
+}
+
 func TestRelationshipBootstrapService_BootstrapDiscoversDeclaredFKRelationshipsFromDatasourceConstraints(t *testing.T) {
 	logger := zap.NewNop()
 	projectID := uuid.New()
@@ -333,6 +507,8 @@ func TestRelationshipBootstrapService_BootstrapDiscoversDeclaredFKRelationshipsF
 		mockAdapterFactory,
 		mockSchemaRepo,
 		nil,
+		nil,
+		false,
 		logger,
 	)
 
@@ -439,6 +615,8 @@ func TestRelationshipBootstrapService_BootstrapSkipsSoftDeletedDiscoveredFKRelat
 		mockAdapterFactory,
 		mockSchemaRepo,
 		mockColumnMetadataRepo,
+		nil,
+		false,
 		logger,
 	)
 
@@ -544,6 +722,8 @@ func TestRelationshipBootstrapService_BootstrapReconcilesEnumMetadataForDeclared
 		mockAdapterFactory,
 		mockSchemaRepo,
 		mockColumnMetadataRepo,
+		nil,
+		false,
 		logger,
 	)
 
@@ -647,6 +827,8 @@ func TestRelationshipBootstrapService_BootstrapPrefersDeclaredFKOverColumnFeatur
 		mockAdapterFactory,
 		mockSchemaRepo,
 		mockColumnMetadataRepo,
+		nil,
+		false,
 		logger,
 	)
 
@@ -724,6 +906,8 @@ func TestRelationshipBootstrapService_BootstrapContinuesWhenColumnMetadataReadFa
 		mockAdapterFactory,
 		mockSchemaRepo,
 		mockColumnMetadataRepo,
+		nil,
+		false,
 		logger,
 	)
 
@@ -794,6 +978,8 @@ func TestRelationshipBootstrapService_BootstrapSkipsAmbiguousUnqualifiedTargetTa
 		mockAdapterFactory,
 		mockSchemaRepo,
 		mockColumnMetadataRepo,
+		nil,
+		false,
 		logger,
 	)
 
@@ -913,6 +1099,7 @@ type mockSchemaDiscovererForBootstrap struct {
 	supportsFKs bool
 	foreignKeys []datasource.ForeignKeyMetadata
 	joinResults map[string]*datasource.JoinAnalysis
+	joinCalls   []string
 }
 
 func (m *mockSchemaDiscovererForBootstrap) DiscoverForeignKeys(_ context.Context) ([]datasource.ForeignKeyMetadata, error) {
@@ -925,6 +1112,7 @@ func (m *mockSchemaDiscovererForBootstrap) SupportsForeignKeys() bool {
 
 func (m *mockSchemaDiscovererForBootstrap) AnalyzeJoin(_ context.Context, sourceSchema, sourceTable, sourceColumn, targetSchema, targetTable, targetColumn string) (*datasource.JoinAnalysis, error) {
 	key := fmt.Sprintf("%s.%s.%s->%s.%s.%s", sourceSchema, sourceTable, sourceColumn, targetSchema, targetTable, targetColumn)
+	m.joinCalls = append(m.joinCalls, key)
 	if joinResult, ok := m.joinResults[key]; ok {
 		return joinResult, nil
 	}
@@ -12,6 +12,7 @@ type RelationshipCandidate struct {
 	SourceDataType      string   `json:"source_data_type"`
 	SourceIsPK          bool     `json:"source_is_pk"`
 	SourceIsUnique      bool     `json:"source_is_unique"`
+	SourceIsArray       bool     `json:"source_is_array,omitempty"` // Array of keys (tag_ids bigint[]); each element references a target row
 	SourceDistinctCount int64    `json:"source_distinct_count"`
 	SourceNullRate      float64  `json:"source_null_rate"`
 	SourceSamples       []string `json:"source_samples"` // Up to 10 sample values
@@ -41,6 +42,7 @@ type RelationshipCandidate struct {
 	// Internal tracking (not passed to LLM)
 	SourceColumnID uuid.UUID `json:"-"`
 	TargetColumnID uuid.UUID `json:"-"`
+	UUIDTextMatch  bool      `json:"-"` // uuid/string pair admitted on UUID-shaped string values
 }
 
 // RelationshipValidationResult is the LLM response for a relationship candidate.
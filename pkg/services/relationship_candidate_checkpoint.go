@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+)
+
+// isConnectionLost reports whether err means the datasource connection dropped. The
+// remaining pairs would all fail the same way, so collection stops instead of
+// rejecting them one by one. Errors from a single failing query (bad SQL, type
+// mismatch) are not connection losses.
+func isConnectionLost(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, net.ErrClosed) || errors.Is(err, driver.ErrBadConn) {
+		return true
+	}
+
+	// Socket and DNS failures
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	// The server ended the session: connection exceptions (class 08) and shutdowns
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return strings.HasPrefix(pgErr.Code, "08") ||
+			pgErr.Code == "57P01" || // admin_shutdown
+			pgErr.Code == "57P02" || // crash_shutdown
+			pgErr.Code == "57P03" // cannot_connect_now
+	}
+
+	// pgx could not connect, or refused to use a connection it had already closed
+	var connectErr *pgconn.ConnectError
+	return errors.As(err, &connectErr) || pgconn.SafeToRetry(err)
+}
+
+// loadCheckpoints returns the join analyses an interrupted run already completed,
+// keyed by the pair's column IDs. Without a checkpoint repository, or when the
+// checkpoints cannot be read, every pair is analyzed again.
+func (c *relationshipCandidateCollector) loadCheckpoints(ctx context.Context, projectID, datasourceID uuid.UUID) map[models.RelationshipPairKey]*models.RelationshipPairCheckpoint {
+	if c.checkpointRepo == nil {
+		return nil
+	}
+	checkpoints, err := c.checkpointRepo.ListByDatasource(ctx, projectID, datasourceID)
+	if err != nil {
+		c.logger.Warn("failed to load relationship discovery checkpoints, analyzing every pair",
+			zap.String("project_id", projectID.String()),
+			zap.Error(err))
+		return nil
+	}
+	byPair := make(map[models.RelationshipPairKey]*models.RelationshipPairCheckpoint, len(checkpoints))
+	for _, cp := range checkpoints {
+		byPair[cp.PairKey()] = cp
+	}
+	if len(byPair) > 0 {
+		c.logger.Info("resuming relationship candidate collection from checkpoint",
+			zap.Int("validated_pairs", len(byPair)),
+			zap.String("project_id", projectID.String()),
+			zap.String("datasource_id", datasourceID.String()))
+	}
+	return byPair
+}
+
+// saveCheckpoint records a candidate's join analysis. A failed write only costs the
+// pair being analyzed again on resume.
+func (c *relationshipCandidateCollector) saveCheckpoint(ctx context.Context, projectID, datasourceID uuid.UUID, candidate *RelationshipCandidate) {
+	if c.checkpointRepo == nil {
+		return
+	}
+	err := c.checkpointRepo.Save(ctx, &models.RelationshipPairCheckpoint{
+		ProjectID:          projectID,
+		DatasourceID:       datasourceID,
+		SourceColumnID:     candidate.SourceColumnID,
+		TargetColumnID:     candidate.TargetColumnID,
+		JoinCount:          candidate.JoinCount,
+		SourceMatched:      candidate.SourceMatched,
+		TargetMatched:      candidate.TargetMatched,
+		OrphanCount:        candidate.OrphanCount,
+		ReverseOrphanCount: candidate.ReverseOrphans,
+	})
+	if err != nil {
+		c.logger.Warn("failed to save relationship discovery checkpoint",
+			zap.String("source", candidate.SourceTable+"."+candidate.SourceColumn),
+			zap.String("target", candidate.TargetTable+"."+candidate.TargetColumn),
+			zap.Error(err))
+	}
+}
+
+// clearCheckpoints removes the checkpoints of a completed run, so the next run
+// analyzes current data.
+func (c *relationshipCandidateCollector) clearCheckpoints(ctx context.Context, projectID, datasourceID uuid.UUID) {
+	if c.checkpointRepo == nil {
+		return
+	}
+	if err := c.checkpointRepo.DeleteByDatasource(ctx, projectID, datasourceID); err != nil {
+		c.logger.Warn("failed to clear relationship discovery checkpoints",
+			zap.String("project_id", projectID.String()),
+			zap.Error(err))
+	}
+}
+
+// pairKey identifies the candidate's checkpoint.
+func (c *RelationshipCandidate) pairKey() models.RelationshipPairKey {
+	return models.RelationshipPairKey{SourceColumnID: c.SourceColumnID, TargetColumnID: c.TargetColumnID}
+}
+
+// applyCheckpoint copies a checkpointed join analysis onto the candidate.
+func applyCheckpoint(candidate *RelationshipCandidate, cp *models.RelationshipPairCheckpoint) {
+	candidate.JoinCount = cp.JoinCount
+	candidate.SourceMatched = cp.SourceMatched
+	candidate.TargetMatched = cp.TargetMatched
+	candidate.OrphanCount = cp.OrphanCount
+	candidate.ReverseOrphans = cp.ReverseOrphanCount
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"syscall"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/adapters/datasource"
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+)
+
+// mockCheckpointRepoForCandidateCollector keeps checkpoints in memory.
+type mockCheckpointRepoForCandidateCollector struct {
+	checkpoints map[models.RelationshipPairKey]*models.RelationshipPairCheckpoint
+	deletes     int
+}
+
+func (m *mockCheckpointRepoForCandidateCollector) Save(ctx context.Context, checkpoint *models.RelationshipPairCheckpoint) error {
+	if m.checkpoints == nil {
+		m.checkpoints = make(map[models.RelationshipPairKey]*models.RelationshipPairCheckpoint)
+	}
+	m.checkpoints[checkpoint.PairKey()] = checkpoint
+	return nil
+}
+
+func (m *mockCheckpointRepoForCandidateCollector) ListByDatasource(ctx context.Context, projectID, datasourceID uuid.UUID) ([]*models.RelationshipPairCheckpoint, error) {
+	var checkpoints []*models.RelationshipPairCheckpoint
+	for _, cp := range m.checkpoints {
+		checkpoints = append(checkpoints, cp)
+	}
+	return checkpoints, nil
+}
+
+func (m *mockCheckpointRepoForCandidateCollector) DeleteByDatasource(ctx context.Context, projectID, datasourceID uuid.UUID) error {
+	m.checkpoints = nil
+	m.deletes++
+	return nil
+}
+
+// flakySchemaDiscoverer records the pairs it analyzes and loses its connection after
+// failAfter successful join analyses (never when failAfter is 0).
+type flakySchemaDiscoverer struct {
+	mockSchemaDiscovererForJoinStats
+	failAfter int
+	analyzed  []string
+}
+
+func (m *flakySchemaDiscoverer) AnalyzeJoin(ctx context.Context, sourceSchema, sourceTable, sourceColumn, targetSchema, targetTable, targetColumn string) (*datasource.JoinAnalysis, error) {
+	if m.failAfter > 0 && len(m.analyzed) >= m.failAfter {
+		return nil, fmt.Errorf("query join analysis: %w", &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET})
+	}
+	m.analyzed = append(m.analyzed, sourceTable+"."+sourceColumn+"->"+targetTable+"."+targetColumn)
+	return &datasource.JoinAnalysis{JoinCount: 100, SourceMatched: 100, TargetMatched: 100}, nil
+}
+
+func TestCollectCandidates_ResumesAfterConnectionDrop(t *testing.T) {
+	projectID := uuid.New()
+	datasourceID := uuid.New()
+	usersTableID := uuid.New()
+
+	isJoinable := true
+	fkRole := models.RoleForeignKey
+	fkClassPath := string(models.ClassificationPathUUID)
+	tables := []*models.SchemaTable{{ID: usersTableID, TableName: "users"}}
+	columns := []*models.SchemaColumn{
+		{ID: uuid.New(), SchemaTableID: usersTableID, ColumnName: "id", DataType: "uuid", IsPrimaryKey: true},
+	}
+	metadataByColumnID := make(map[uuid.UUID]*models.ColumnMetadata)
+	// Two of the source tables share a name in different schemas; each pair keeps
+	// its own checkpoint
+	for _, name := range [][2]string{{"public", "orders"}, {"archive", "orders"}, {"public", "reviews"}} {
+		table := &models.SchemaTable{ID: uuid.New(), SchemaName: name[0], TableName: name[1]}
+		col := &models.SchemaColumn{ID: uuid.New(), SchemaTableID: table.ID, ColumnName: "user_id", DataType: "uuid", IsJoinable: &isJoinable}
+		tables = append(tables, table)
+		columns = append(columns, col)
+		metadataByColumnID[col.ID] = &models.ColumnMetadata{SchemaColumnID: col.ID, Role: &fkRole, ClassificationPath: &fkClassPath}
+	}
+	repo := &mockSchemaRepoForCandidateCollector{allColumns: columns, tables: tables}
+	metadataRepo := &mockColumnMetadataRepoForCandidateCollector{metadataByColumnID: metadataByColumnID}
+	checkpointRepo := &mockCheckpointRepoForCandidateCollector{}
+
+	// First run: the connection drops after two of the three pairs
+	firstAdapter := &flakySchemaDiscoverer{failAfter: 2}
+	collector := NewRelationshipCandidateCollector(repo, metadataRepo,
+		&mockAdapterFactoryForCandidateCollector{schemaDiscoverer: firstAdapter},
+		&mockDatasourceServiceForCandidateCollector{}, checkpointRepo, nil, false, zap.NewNop())
+
+	_, _, err := collector.CollectCandidates(context.Background(), projectID, datasourceID, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "connection lost")
+	require.Len(t, firstAdapter.analyzed, 2)
+	assert.Len(t, checkpointRepo.checkpoints, 2, "validated pairs are checkpointed")
+	assert.Zero(t, checkpointRepo.deletes, "checkpoints survive the failed run")
+
+	// Resume: only the pair the first run never finished is analyzed
+	resumeAdapter := &flakySchemaDiscoverer{}
+	collector = NewRelationshipCandidateCollector(repo, metadataRepo,
+		&mockAdapterFactoryForCandidateCollector{schemaDiscoverer: resumeAdapter},
+		&mockDatasourceServiceForCandidateCollector{}, checkpointRepo, nil, false, zap.NewNop())
+
+	result, stats, err := collector.CollectCandidates(context.Background(), projectID, datasourceID, nil)
+	require.NoError(t, err)
+	assert.Len(t, result, 3)
+	assert.Equal(t, 2, stats.ResumedPairs)
+	assert.Equal(t, 1, stats.JoinAnalysisCalls)
+	require.Len(t, resumeAdapter.analyzed, 1)
+	for _, c := range result {
+		assert.Equal(t, int64(100), c.SourceMatched, "resumed pairs keep their join analysis")
+	}
+	assert.Empty(t, checkpointRepo.checkpoints, "checkpoints are cleared once collection completes")
+}
+
+func TestIsConnectionLost(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"connection reset", fmt.Errorf("analyze join: %w", &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}), true},
+		{"server closed the connection", fmt.Errorf("query join analysis: %w", io.ErrUnexpectedEOF), true},
+		{"bad driver connection", fmt.Errorf("query join analysis: %w", driver.ErrBadConn), true},
+		{"admin shutdown", &pgconn.PgError{Code: "57P01", Message: "terminating connection due to administrator command"}, true},
+		{"connection failure", &pgconn.PgError{Code: "08006", Message: "connection failure"}, true},
+		{"query error", &pgconn.PgError{Code: "42883", Message: "operator does not exist: uuid = text"}, false},
+		{"query error mentioning a connection", errors.New("column connection_closed does not exist"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isConnectionLost(tt.err))
+		})
+	}
+}
@@ -19,8 +19,24 @@ import (
 type RelationshipCandidateCollector interface {
 	// CollectCandidates gathers all potential FK relationship candidates
 	// using deterministic criteria. The candidates are then passed to
-	// the LLM validation phase.
-	CollectCandidates(ctx context.Context, projectID, datasourceID uuid.UUID, progressCallback dag.ProgressCallback) ([]*RelationshipCandidate, error)
+	// the LLM validation phase. The stats count how many candidates each gate rejected.
+	CollectCandidates(ctx context.Context, projectID, datasourceID uuid.UUID, progressCallback dag.ProgressCallback) ([]*RelationshipCandidate, *RelationshipCandidateStats, error)
+}
+
+// RelationshipCandidateStats counts how candidates moved through the collector's gates.
+type RelationshipCandidateStats struct {
+	LikelyFKStats       int `json:"likely_fk_stats"` // Stats-less likely FK columns whose stats were gathered first
+	FKSources           int `json:"fk_sources"`
+	FKTargets           int `json:"fk_targets"`
+	PairsGenerated      int `json:"pairs_generated"`       // Type-compatible source/target pairs
+	JoinAnalysisCalls   int `json:"join_analysis_calls"`   // AnalyzeJoin queries issued against the datasource
+	ResumedPairs        int `json:"resumed_pairs"`         // Pairs whose join analysis came from an interrupted run's checkpoint
+	RejectedJoinError   int `json:"rejected_join_error"`   // Join analysis failed
+	RejectedNonUUID     int `json:"rejected_non_uuid"`     // uuid/string pair whose string values are not UUIDs
+	RejectedNoMatch     int `json:"rejected_no_match"`     // No source value matched the target
+	RejectedOrphans     int `json:"rejected_orphans"`      // Source values missing from the target
+	RejectedMultiTarget int `json:"rejected_multi_target"` // Source matched too many targets to be meaningful
+	Collected           int `json:"collected"`             // Candidates passed on for validation
 }
 
 type relationshipCandidateCollector struct {
@@ -28,23 +44,42 @@ type relationshipCandidateCollector struct {
 	columnMetadataRepo repositories.ColumnMetadataRepository
 	adapterFactory     datasource.DatasourceAdapterFactory
 	dsSvc              DatasourceService
+	checkpointRepo     repositories.RelationshipCheckpointRepository
+	excludedPurposes   map[string]bool // Column purposes never treated as FK sources
 	logger             *zap.Logger
+
+	// uuidTextMatching pairs uuid columns with string columns whose values are UUIDs
+	uuidTextMatching bool
 }
 
 // NewRelationshipCandidateCollector creates a new RelationshipCandidateCollector.
+// Columns whose ColumnMetadata purpose is in excludedPurposes are never FK sources.
+// checkpointRepo records validated pairs so an interrupted run can resume; nil
+// disables checkpointing. With uuidTextMatching, a uuid column is also paired with
+// a string column (text FKs holding UUIDs) when the string's sampled values parse as UUIDs.
 func NewRelationshipCandidateCollector(
 	schemaRepo repositories.SchemaRepository,
 	columnMetadataRepo repositories.ColumnMetadataRepository,
 	adapterFactory datasource.DatasourceAdapterFactory,
 	dsSvc DatasourceService,
+	checkpointRepo repositories.RelationshipCheckpointRepository,
+	excludedPurposes []string,
+	uuidTextMatching bool,
 	logger *zap.Logger,
 ) RelationshipCandidateCollector {
+	excluded := make(map[string]bool, len(excludedPurposes))
+	for _, purpose := range excludedPurposes {
+		excluded[strings.ToLower(strings.TrimSpace(purpose))] = true
+	}
 	return &relationshipCandidateCollector{
 		schemaRepo:         schemaRepo,
 		columnMetadataRepo: columnMetadataRepo,
 		adapterFactory:     adapterFactory,
 		dsSvc:              dsSvc,
+		checkpointRepo:     checkpointRepo,
+		excludedPurposes:   excluded,
 		logger:             logger.Named("relationship-candidate-collector"),
+		uuidTextMatching:   uuidTextMatching,
 	}
 }
 
@@ -68,9 +103,11 @@ type FKSourceColumn struct {
 //   - They are timestamp columns (classification_path = 'timestamp')
 //   - They are boolean columns (classification_path = 'boolean')
 //   - They are JSON columns (classification_path = 'json')
+//   - Their purpose is in the configured excluded purposes
 //
 // Per CLAUDE.md rule #5: We do NOT filter by column name patterns (e.g., _id suffix).
 // All classification is based on ColumnMetadata data and explicit schema metadata.
+// (Names only pick which stats-less columns gatherLikelyFKStats analyzes first.)
 //
 // Returns the FK source columns and a map of all column metadata (keyed by column ID)
 // for use in subsequent processing.
@@ -158,6 +195,9 @@ func (c *relationshipCandidateCollector) identifyFKSources(
 //   - Timestamp columns
 //   - Boolean columns
 //   - JSON columns
+//   - Denormalized date parts (order_year, order_month), which are small integers
+//     that coincidentally match auto-increment PKs
+//   - Columns whose purpose is in the configured excluded purposes
 func (c *relationshipCandidateCollector) shouldExcludeFromFKSources(col *models.SchemaColumn, metadata *models.ColumnMetadata) bool {
 	// Exclude primary keys - they are FK targets, not sources
 	if col.IsPrimaryKey {
@@ -195,6 +235,21 @@ func (c *relationshipCandidateCollector) shouldExcludeFromFKSources(col *models.
 		}
 	}
 
+	// Date parts: classified by feature extraction, or recognized from name and
+	// distinct count when the column has no metadata yet
+	if metadata != nil && metadata.GetDatePartFeatures() != nil {
+		return true
+	}
+	if isDatePartColumn(col) {
+		return true
+	}
+
+	// Configured purpose exclusions override every qualification signal, including
+	// role = foreign_key, since they exist to correct mislabeled columns
+	if metadata != nil && metadata.Purpose != nil && c.excludedPurposes[*metadata.Purpose] {
+		return true
+	}
+
 	return false
 }
 
@@ -204,7 +259,12 @@ func (c *relationshipCandidateCollector) shouldExcludeFromFKSources(col *models.
 //   - ColumnMetadata purpose = 'identifier' (identifiers often reference other tables)
 //   - ClassificationPath = 'uuid' (UUIDs are high-priority FK candidates per design doc)
 //   - is_joinable = true in column statistics (fallback when no metadata)
+//   - An array of integer or UUID keys (tag_ids bigint[]), which references one row per element
 func (c *relationshipCandidateCollector) isQualifiedFKSource(col *models.SchemaColumn, metadata *models.ColumnMetadata) bool {
+	if isArrayKeyType(col.DataType) {
+		return true
+	}
+
 	// Check metadata-based criteria if metadata exists
 	if metadata != nil {
 		// Role explicitly marked as foreign_key
@@ -336,6 +396,15 @@ func areTypesCompatible(sourceType, targetType string) bool {
 	return sourceCategory == targetCategory
 }
 
+// isUUIDTextPair reports whether one type is uuid and the other a string type. The
+// type matrix keeps these apart; they only pair when the string holds UUIDs (see
+// validateUUIDTextValues).
+func isUUIDTextPair(sourceType, targetType string) bool {
+	source := categorizeDataType(strings.ToLower(strings.TrimSpace(sourceType)))
+	target := categorizeDataType(strings.ToLower(strings.TrimSpace(targetType)))
+	return (source == "uuid" && target == "string") || (source == "string" && target == "uuid")
+}
+
 // categorizeDataType returns a category string for the given data type.
 // Types in the same category are considered compatible for FK relationships.
 func categorizeDataType(dataType string) string {
@@ -415,10 +484,22 @@ func categorizeDataType(dataType string) string {
 	return ""
 }
 
+// isArrayKeyType reports whether dataType is an array whose elements could be keys.
+// Text arrays are left out: they are usually tags or labels, not references.
+func isArrayKeyType(dataType string) bool {
+	elem, ok := datasource.ArrayElementType(strings.ToLower(dataType))
+	if !ok {
+		return false
+	}
+	category := categorizeDataType(elem)
+	return category == "integer" || category == "uuid"
+}
+
 // generateCandidatePairs creates relationship candidates for all valid source→target pairs.
 // For each source column, it pairs with each target column if:
 //   - They are not the same column (no self-references)
-//   - Their data types are compatible (uuid→uuid, int→int, etc.)
+//   - Their data types are compatible (uuid→uuid, int→int, etc.), or they are a
+//     uuid/string pair and uuidTextMatching is enabled
 //
 // The method populates ColumnMetadata-derived fields (SourcePurpose, SourceRole, etc.)
 // from the source's and target's ColumnMetadata data.
@@ -439,10 +520,25 @@ func (c *relationshipCandidateCollector) generateCandidatePairs(
 				continue
 			}
 
-			// Skip if data types are incompatible
-			if !areTypesCompatible(source.Column.DataType, target.Column.DataType) {
+			// Array sources pair by element type, and only with primary keys
+			elemType, isArray := datasource.ArrayElementType(source.Column.DataType)
+			if isArray && !target.Column.IsPrimaryKey {
 				continue
 			}
+			sourceType := source.Column.DataType
+			if isArray {
+				sourceType = elemType
+			}
+
+			// Skip if data types are incompatible. uuid/string pairs are kept for
+			// value validation when enabled.
+			uuidText := false
+			if !areTypesCompatible(sourceType, target.Column.DataType) {
+				if !c.uuidTextMatching || !isUUIDTextPair(sourceType, target.Column.DataType) {
+					continue
+				}
+				uuidText = true
+			}
 
 			candidate := &RelationshipCandidate{
 				// Source column info
@@ -451,7 +547,9 @@ func (c *relationshipCandidateCollector) generateCandidatePairs(
 				SourceDataType: source.Column.DataType,
 				SourceIsPK:     source.Column.IsPrimaryKey,
 				SourceIsUnique: source.Column.IsUnique,
+				SourceIsArray:  isArray,
 				SourceColumnID: source.Column.ID,
+				UUIDTextMatch:  uuidText,
 
 				// Target column info
 				TargetTable:    target.TableName,
@@ -498,7 +596,8 @@ func (c *relationshipCandidateCollector) generateCandidatePairs(
 // for a relationship candidate. It populates the JoinCount, OrphanCount, ReverseOrphans,
 // SourceMatched, and TargetMatched fields on the candidate.
 //
-// This uses the SchemaDiscoverer.AnalyzeJoin method which performs:
+// This uses the SchemaDiscoverer.AnalyzeJoin method (AnalyzeArrayJoin for array
+// sources, over their unnested elements) which performs:
 // - Join count and source matched count
 // - Orphan count (source values not in target)
 // - Reverse orphan count (target values not in source)
@@ -510,7 +609,16 @@ func (c *relationshipCandidateCollector) collectJoinStatistics(
 	// Use the adapter's AnalyzeJoin method which handles the SQL generation
 	// We pass empty schema name since our tables may be in different schemas
 	// or the datasource may not use schemas
-	joinAnalysis, err := adapter.AnalyzeJoin(
+	analyzeJoin := adapter.AnalyzeJoin
+	if candidate.SourceIsArray {
+		arrays, ok := datasource.ArrayJoinAnalyzerFor(adapter)
+		if !ok {
+			return fmt.Errorf("array column %s.%s: datasource does not support array join analysis",
+				candidate.SourceTable, candidate.SourceColumn)
+		}
+		analyzeJoin = arrays.AnalyzeArrayJoin
+	}
+	joinAnalysis, err := analyzeJoin(
 		ctx,
 		"", candidate.SourceTable, candidate.SourceColumn,
 		"", candidate.TargetTable, candidate.TargetColumn,
@@ -569,6 +677,35 @@ func (c *relationshipCandidateCollector) collectSampleValues(
 	return nil
 }
 
+// validateUUIDTextValues samples the string side of a uuid/string candidate and
+// reports whether it has values and every sampled value parses as a UUID.
+func (c *relationshipCandidateCollector) validateUUIDTextValues(
+	ctx context.Context,
+	adapter datasource.SchemaDiscoverer,
+	candidate *RelationshipCandidate,
+) (bool, error) {
+	const sampleLimit = 100
+
+	table, column := candidate.SourceTable, candidate.SourceColumn
+	if categorizeDataType(strings.ToLower(strings.TrimSpace(candidate.TargetDataType))) == "string" {
+		table, column = candidate.TargetTable, candidate.TargetColumn
+	}
+
+	values, err := adapter.GetDistinctValues(ctx, "", table, column, sampleLimit)
+	if err != nil {
+		return false, fmt.Errorf("get samples for %s.%s: %w", table, column, err)
+	}
+	if len(values) == 0 {
+		return false, nil
+	}
+	for _, v := range values {
+		if _, err := uuid.Parse(strings.TrimSpace(v)); err != nil {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 // collectDistinctCounts collects the distinct count and null rate for source and target columns.
 // This uses column statistics from the schema discoverer adapter.
 func (c *relationshipCandidateCollector) collectDistinctCounts(
@@ -621,20 +758,28 @@ func (c *relationshipCandidateCollector) collectDistinctCounts(
 
 // CollectCandidates gathers all potential FK relationship candidates using deterministic criteria.
 // This method orchestrates the full candidate collection process:
-// 1. Get datasource and create adapter
-// 2. Identify FK sources (columns that could be foreign keys)
-// 3. Identify FK targets (primary keys and unique columns)
-// 4. Generate candidate pairs with type compatibility checks
-// 5. Collect join statistics and sample values for each candidate
+//  1. Get datasource and create adapter, and gather stats for likely FK columns
+//     that have none (see likelyFKColumnsWithoutStats)
+//  2. Identify FK sources (columns that could be foreign keys)
+//  3. Identify FK targets (primary keys and unique columns)
+//  4. Generate candidate pairs with type compatibility checks
+//  5. Collect join statistics and sample values for each candidate
+//
+// Each pair's join analysis is checkpointed as it completes. If the datasource
+// connection drops, collection stops with an error and the next run (or the DAG's
+// retry of the node) reuses the checkpointed analyses instead of re-running their
+// join queries. Checkpoints are cleared once collection completes.
 //
 // Error handling follows the fail-fast policy per CLAUDE.md:
-// - Fatal errors (schema load, adapter creation): Return error immediately
+// - Fatal errors (schema load, adapter creation, lost connection): Return error immediately
 // - Non-fatal errors (single candidate stats fail): Log warning and continue
 func (c *relationshipCandidateCollector) CollectCandidates(
 	ctx context.Context,
 	projectID, datasourceID uuid.UUID,
 	progressCallback dag.ProgressCallback,
-) ([]*RelationshipCandidate, error) {
+) ([]*RelationshipCandidate, *RelationshipCandidateStats, error) {
+	stats := &RelationshipCandidateStats{}
+
 	// Step 1: Get datasource and create adapter
 	if progressCallback != nil {
 		progressCallback(0, 5, "Loading schema metadata")
@@ -642,21 +787,30 @@ func (c *relationshipCandidateCollector) CollectCandidates(
 
 	ds, err := c.dsSvc.Get(ctx, projectID, datasourceID)
 	if err != nil {
-		return nil, fmt.Errorf("get datasource: %w", err)
+		return nil, nil, fmt.Errorf("get datasource: %w", err)
 	}
 
 	// Create schema discoverer adapter for join analysis and sample value collection
 	adapter, err := c.adapterFactory.NewSchemaDiscoverer(ctx, ds.DatasourceType, ds.Config, projectID, datasourceID, "")
 	if err != nil {
-		return nil, fmt.Errorf("create schema discoverer: %w", err)
+		return nil, nil, fmt.Errorf("create schema discoverer: %w", err)
 	}
 	defer adapter.Close()
 
+	// Stats-less uuid/text references would otherwise fail the joinability check
+	// below, so gather their stats before identifying sources. This only widens
+	// what PK-match sees; on failure the columns are left as they were.
+	stats.LikelyFKStats, err = c.gatherLikelyFKStats(ctx, adapter, projectID, datasourceID)
+	if err != nil {
+		c.logger.Warn("failed to gather stats for likely FK columns", zap.Error(err))
+	}
+
 	// Step 2: Identify FK sources (also returns metadata map for all columns)
 	sources, metadataByColumnID, err := c.identifyFKSources(ctx, projectID, datasourceID)
 	if err != nil {
-		return nil, fmt.Errorf("identify FK sources: %w", err)
+		return nil, nil, fmt.Errorf("identify FK sources: %w", err)
 	}
+	stats.FKSources = len(sources)
 
 	if progressCallback != nil {
 		progressCallback(1, 5, fmt.Sprintf("Found %d potential FK sources", len(sources)))
@@ -665,8 +819,9 @@ func (c *relationshipCandidateCollector) CollectCandidates(
 	// Step 3: Identify FK targets (PKs and unique columns only)
 	targets, err := c.identifyFKTargets(ctx, projectID, datasourceID)
 	if err != nil {
-		return nil, fmt.Errorf("identify FK targets: %w", err)
+		return nil, nil, fmt.Errorf("identify FK targets: %w", err)
 	}
+	stats.FKTargets = len(targets)
 
 	if progressCallback != nil {
 		progressCallback(2, 5, fmt.Sprintf("Found %d FK targets (PKs/unique)", len(targets)))
@@ -674,6 +829,7 @@ func (c *relationshipCandidateCollector) CollectCandidates(
 
 	// Step 4: Generate candidate pairs with type compatibility
 	candidates := c.generateCandidatePairs(sources, targets, metadataByColumnID)
+	stats.PairsGenerated = len(candidates)
 
 	if progressCallback != nil {
 		progressCallback(3, 5, fmt.Sprintf("Generated %d candidate pairs", len(candidates)))
@@ -684,29 +840,61 @@ func (c *relationshipCandidateCollector) CollectCandidates(
 	// - At least one source value matches a target value (SourceMatched > 0)
 	// - Zero orphans (all source values must exist in target for referential integrity)
 	var validCandidates []*RelationshipCandidate
-	var rejectedNoMatch, rejectedOrphans, rejectedError int
+	checkpoints := c.loadCheckpoints(ctx, projectID, datasourceID)
 
 	for i, candidate := range candidates {
-		// Collect join statistics (join count, orphans, etc.)
-		if err := c.collectJoinStatistics(ctx, adapter, candidate); err != nil {
-			c.logger.Debug("failed to collect join stats, rejecting candidate",
-				zap.String("source", candidate.SourceTable+"."+candidate.SourceColumn),
-				zap.String("target", candidate.TargetTable+"."+candidate.TargetColumn),
-				zap.Error(err),
-			)
-			rejectedError++
-			continue
+		// Collect join statistics (join count, orphans, etc.), unless an interrupted
+		// run already did
+		if cp, ok := checkpoints[candidate.pairKey()]; ok {
+			applyCheckpoint(candidate, cp)
+			stats.ResumedPairs++
+		} else {
+			// uuid/string pairs only proceed when the string side holds UUIDs
+			if candidate.UUIDTextMatch {
+				ok, err := c.validateUUIDTextValues(ctx, adapter, candidate)
+				if err != nil {
+					if ctx.Err() != nil || isConnectionLost(err) {
+						return nil, nil, fmt.Errorf("datasource connection lost after analyzing %d of %d candidate pairs: %w", i, len(candidates), err)
+					}
+					c.logger.Debug("failed to sample uuid/string candidate, rejecting",
+						zap.String("source", candidate.SourceTable+"."+candidate.SourceColumn),
+						zap.String("target", candidate.TargetTable+"."+candidate.TargetColumn),
+						zap.Error(err),
+					)
+					stats.RejectedJoinError++
+					continue
+				}
+				if !ok {
+					stats.RejectedNonUUID++
+					continue
+				}
+			}
+
+			stats.JoinAnalysisCalls++
+			if err := c.collectJoinStatistics(ctx, adapter, candidate); err != nil {
+				if ctx.Err() != nil || isConnectionLost(err) {
+					return nil, nil, fmt.Errorf("datasource connection lost after analyzing %d of %d candidate pairs: %w", i, len(candidates), err)
+				}
+				c.logger.Debug("failed to collect join stats, rejecting candidate",
+					zap.String("source", candidate.SourceTable+"."+candidate.SourceColumn),
+					zap.String("target", candidate.TargetTable+"."+candidate.TargetColumn),
+					zap.Error(err),
+				)
+				stats.RejectedJoinError++
+				continue
+			}
+			c.saveCheckpoint(ctx, projectID, datasourceID, candidate)
 		}
 
 		// Filter: Reject if no source values match target (not a relationship)
 		if candidate.SourceMatched == 0 {
-			rejectedNoMatch++
+			stats.RejectedNoMatch++
 			continue
 		}
 
 		// Filter: Reject if any orphans exist (violates referential integrity)
 		if candidate.OrphanCount > 0 {
-			rejectedOrphans++
+			stats.RejectedOrphans++
 			continue
 		}
 
@@ -741,7 +929,11 @@ func (c *relationshipCandidateCollector) CollectCandidates(
 
 	// Filter: If a source column matches >2 targets, the overlap is likely
 	// coincidental (small integers matching auto-increment PKs everywhere).
+	joinValid := len(validCandidates)
 	validCandidates = c.filterMultiTargetCandidates(validCandidates)
+	stats.RejectedMultiTarget = joinValid - len(validCandidates)
+	stats.Collected = len(validCandidates)
+	c.clearCheckpoints(ctx, projectID, datasourceID)
 
 	if progressCallback != nil {
 		progressCallback(5, 5, fmt.Sprintf("Found %d valid candidates", len(validCandidates)))
@@ -752,13 +944,16 @@ func (c *relationshipCandidateCollector) CollectCandidates(
 		zap.Int("targets", len(targets)),
 		zap.Int("initial_candidates", len(candidates)),
 		zap.Int("valid_candidates", len(validCandidates)),
-		zap.Int("rejected_no_match", rejectedNoMatch),
-		zap.Int("rejected_orphans", rejectedOrphans),
-		zap.Int("rejected_error", rejectedError),
+		zap.Int("rejected_no_match", stats.RejectedNoMatch),
+		zap.Int("rejected_orphans", stats.RejectedOrphans),
+		zap.Int("rejected_error", stats.RejectedJoinError),
+		zap.Int("rejected_non_uuid", stats.RejectedNonUUID),
+		zap.Int("rejected_multi_target", stats.RejectedMultiTarget),
+		zap.Int("resumed_pairs", stats.ResumedPairs),
 		zap.String("project_id", projectID.String()),
 	)
 
-	return validCandidates, nil
+	return validCandidates, stats, nil
 }
 
 // filterMultiTargetCandidates removes candidates where a single source column
@@ -30,6 +30,10 @@ type mockSchemaRepoForCandidateCollector struct {
 	// ListTablesByDatasource mock data
 	tables    []*models.SchemaTable
 	tablesErr error
+
+	// UpdateColumnStats / UpdateColumnJoinability tracking
+	statsUpdatedColumnIDs       []uuid.UUID
+	joinabilityUpdatedColumnIDs []uuid.UUID
 }
 
 func (m *mockSchemaRepoForCandidateCollector) ListColumnsByDatasource(ctx context.Context, projectID, datasourceID uuid.UUID) ([]*models.SchemaColumn, error) {
@@ -46,6 +50,30 @@ func (m *mockSchemaRepoForCandidateCollector) ListTablesByDatasource(ctx context
 	return m.tables, nil
 }
 
+func (m *mockSchemaRepoForCandidateCollector) UpdateColumnStats(ctx context.Context, columnID uuid.UUID, distinctCount, nullCount, minLength, maxLength *int64) error {
+	m.statsUpdatedColumnIDs = append(m.statsUpdatedColumnIDs, columnID)
+	for _, col := range m.allColumns {
+		if col.ID == columnID {
+			col.DistinctCount = distinctCount
+			col.NullCount = nullCount
+		}
+	}
+	return nil
+}
+
+func (m *mockSchemaRepoForCandidateCollector) UpdateColumnJoinability(ctx context.Context, columnID uuid.UUID, rowCount, nonNullCount, distinctCount *int64, isJoinable *bool, joinabilityReason *string) error {
+	m.joinabilityUpdatedColumnIDs = append(m.joinabilityUpdatedColumnIDs, columnID)
+	for _, col := range m.allColumns {
+		if col.ID == columnID {
+			col.RowCount = rowCount
+			col.NonNullCount = nonNullCount
+			col.IsJoinable = isJoinable
+			col.JoinabilityReason = joinabilityReason
+		}
+	}
+	return nil
+}
+
 func (m *mockSchemaRepoForCandidateCollector) ListAllTablesByDatasource(ctx context.Context, projectID, datasourceID uuid.UUID) ([]*models.SchemaTable, error) {
 	if m.tablesErr != nil {
 		return nil, m.tablesErr
@@ -660,6 +688,95 @@ func TestIdentifyFKSources_ExcludesJoinableTimestamp(t *testing.T) {
 	assert.Len(t, sources, 0, "timestamp should be excluded even if joinable")
 }
 
+func TestIdentifyFKSources_ExcludedPurposes(t *testing.T) {
+	// Test that a configured purpose exclusion drops columns the type checks let through
+	projectID := uuid.New()
+	datasourceID := uuid.New()
+	ordersTableID := uuid.New()
+
+	isJoinable := true
+	purpose := models.PurposeTimestamp
+
+	// Epoch timestamp stored as bigint: only its purpose marks it as a timestamp
+	epochCol := &models.SchemaColumn{
+		ID:            uuid.New(),
+		SchemaTableID: ordersTableID,
+		ColumnName:    "shipped_epoch",
+		DataType:      "bigint",
+		IsPrimaryKey:  false,
+		IsJoinable:    &isJoinable,
+	}
+
+	schemaRepo := &mockSchemaRepoForCandidateCollector{
+		allColumns: []*models.SchemaColumn{epochCol},
+		tables: []*models.SchemaTable{
+			{ID: ordersTableID, TableName: "orders"},
+		},
+	}
+
+	metadataRepo := &mockColumnMetadataRepoForCandidateCollector{
+		metadataByColumnID: map[uuid.UUID]*models.ColumnMetadata{
+			epochCol.ID: {SchemaColumnID: epochCol.ID, Purpose: &purpose},
+		},
+	}
+
+	// Default: no purposes excluded, so the joinable column is a source
+	collector := NewRelationshipCandidateCollector(schemaRepo, metadataRepo, &mockAdapterFactoryForCandidateCollector{}, &mockDatasourceServiceForCandidateCollector{}, nil, nil, false, zap.NewNop()).(*relationshipCandidateCollector)
+	sources, _, err := collector.identifyFKSources(context.Background(), projectID, datasourceID)
+	require.NoError(t, err)
+	assert.Len(t, sources, 1, "timestamp-purpose column should be a source by default")
+
+	// Excluding timestamp purpose removes it
+	collector = NewRelationshipCandidateCollector(schemaRepo, metadataRepo, &mockAdapterFactoryForCandidateCollector{}, &mockDatasourceServiceForCandidateCollector{}, nil, []string{models.PurposeTimestamp}, false, zap.NewNop()).(*relationshipCandidateCollector)
+	sources, _, err = collector.identifyFKSources(context.Background(), projectID, datasourceID)
+	require.NoError(t, err)
+	assert.Len(t, sources, 0, "timestamp-purpose column should be excluded when configured")
+}
+
+func TestIdentifyFKSources_ExcludesDateParts(t *testing.T) {
+	// order_month holds 1-12, which would match the first rows of any serial PK
+	projectID := uuid.New()
+	datasourceID := uuid.New()
+	ordersTableID := uuid.New()
+
+	isJoinable := true
+	distinct := int64(12)
+
+	monthCol := &models.SchemaColumn{
+		ID:            uuid.New(),
+		SchemaTableID: ordersTableID,
+		ColumnName:    "order_month",
+		DataType:      "integer",
+		IsJoinable:    &isJoinable,
+		DistinctCount: &distinct,
+	}
+
+	schemaRepo := &mockSchemaRepoForCandidateCollector{
+		allColumns: []*models.SchemaColumn{monthCol},
+		tables: []*models.SchemaTable{
+			{ID: ordersTableID, TableName: "orders"},
+		},
+	}
+
+	// Without metadata, name and distinct count identify the date part
+	collector := NewRelationshipCandidateCollector(schemaRepo, &mockColumnMetadataRepoForCandidateCollector{}, &mockAdapterFactoryForCandidateCollector{}, &mockDatasourceServiceForCandidateCollector{}, nil, nil, false, zap.NewNop()).(*relationshipCandidateCollector)
+	sources, _, err := collector.identifyFKSources(context.Background(), projectID, datasourceID)
+	require.NoError(t, err)
+	assert.Len(t, sources, 0, "date part without metadata should not be a source")
+
+	// With date-part features from extraction, even an identifier purpose is ignored
+	purpose := models.PurposeIdentifier
+	meta := &models.ColumnMetadata{SchemaColumnID: monthCol.ID, Purpose: &purpose}
+	meta.Features.DatePartFeatures = &models.DatePartFeatures{Part: models.DatePartMonth, SourceColumn: "order_date"}
+	metadataRepo := &mockColumnMetadataRepoForCandidateCollector{
+		metadataByColumnID: map[uuid.UUID]*models.ColumnMetadata{monthCol.ID: meta},
+	}
+	collector = NewRelationshipCandidateCollector(schemaRepo, metadataRepo, &mockAdapterFactoryForCandidateCollector{}, &mockDatasourceServiceForCandidateCollector{}, nil, nil, false, zap.NewNop()).(*relationshipCandidateCollector)
+	sources, _, err = collector.identifyFKSources(context.Background(), projectID, datasourceID)
+	require.NoError(t, err)
+	assert.Len(t, sources, 0, "classified date part should not be a source")
+}
+
 func TestIdentifyFKSources_NoDuplicates(t *testing.T) {
 	// Test that a column with both metadata and joinable flag is only listed once
 	projectID := uuid.New()
@@ -865,7 +982,7 @@ func TestCollectCandidates_GeneratesCandidatePairs(t *testing.T) {
 		},
 	}
 
-	collector := NewRelationshipCandidateCollector(repo, &mockColumnMetadataRepoForCandidateCollector{metadataByColumnID: metadataByColumnID}, &mockAdapterFactoryForCandidateCollector{}, &mockDatasourceServiceForCandidateCollector{}, zap.NewNop())
+	collector := NewRelationshipCandidateCollector(repo, &mockColumnMetadataRepoForCandidateCollector{metadataByColumnID: metadataByColumnID}, &mockAdapterFactoryForCandidateCollector{}, &mockDatasourceServiceForCandidateCollector{}, nil, nil, false, zap.NewNop())
 
 	// Track progress callbacks
 	progressCalls := 0
@@ -873,7 +990,7 @@ func TestCollectCandidates_GeneratesCandidatePairs(t *testing.T) {
 		progressCalls++
 	}
 
-	result, err := collector.CollectCandidates(context.Background(), projectID, datasourceID, progressCallback)
+	result, _, err := collector.CollectCandidates(context.Background(), projectID, datasourceID, progressCallback)
 	require.NoError(t, err)
 
 	// Should have 1 candidate: orders.user_id → users.id
@@ -919,9 +1036,9 @@ func TestCollectCandidates_NoTargets(t *testing.T) {
 		},
 	}
 
-	collector := NewRelationshipCandidateCollector(repo, &mockColumnMetadataRepoForCandidateCollector{metadataByColumnID: metadataByColumnID}, &mockAdapterFactoryForCandidateCollector{}, &mockDatasourceServiceForCandidateCollector{}, zap.NewNop())
+	collector := NewRelationshipCandidateCollector(repo, &mockColumnMetadataRepoForCandidateCollector{metadataByColumnID: metadataByColumnID}, &mockAdapterFactoryForCandidateCollector{}, &mockDatasourceServiceForCandidateCollector{}, nil, nil, false, zap.NewNop())
 
-	result, err := collector.CollectCandidates(context.Background(), projectID, datasourceID, nil)
+	result, _, err := collector.CollectCandidates(context.Background(), projectID, datasourceID, nil)
 	require.NoError(t, err)
 
 	// No targets = no candidates
@@ -936,13 +1053,100 @@ func TestCollectCandidates_PropagatesError(t *testing.T) {
 		allColumnsErr: errors.New("database error"),
 	}
 
-	collector := NewRelationshipCandidateCollector(repo, &mockColumnMetadataRepoForCandidateCollector{metadataByColumnID: make(map[uuid.UUID]*models.ColumnMetadata)}, &mockAdapterFactoryForCandidateCollector{}, &mockDatasourceServiceForCandidateCollector{}, zap.NewNop())
+	collector := NewRelationshipCandidateCollector(repo, &mockColumnMetadataRepoForCandidateCollector{metadataByColumnID: make(map[uuid.UUID]*models.ColumnMetadata)}, &mockAdapterFactoryForCandidateCollector{}, &mockDatasourceServiceForCandidateCollector{}, nil, nil, false, zap.NewNop())
 
-	_, err := collector.CollectCandidates(context.Background(), projectID, datasourceID, nil)
+	_, _, err := collector.CollectCandidates(context.Background(), projectID, datasourceID, nil)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "identify FK sources")
 }
 
+func TestCollectCandidates_GathersStatsForLikelyFKWithoutStats(t *testing.T) {
+	projectID := uuid.New()
+	datasourceID := uuid.New()
+	projectsTableID := uuid.New()
+	orgsTableID := uuid.New()
+
+	// Freshly synced uuid reference: no stats, no metadata
+	orgRefCol := &models.SchemaColumn{
+		ID:            uuid.New(),
+		SchemaTableID: projectsTableID,
+		ColumnName:    "org_ref",
+		DataType:      "uuid",
+	}
+	projectsPKCol := &models.SchemaColumn{
+		ID:            uuid.New(),
+		SchemaTableID: projectsTableID,
+		ColumnName:    "id",
+		DataType:      "bigint",
+		IsPrimaryKey:  true,
+	}
+	orgsPKCol := &models.SchemaColumn{
+		ID:            uuid.New(),
+		SchemaTableID: orgsTableID,
+		ColumnName:    "id",
+		DataType:      "uuid",
+		IsPrimaryKey:  true,
+	}
+
+	repo := &mockSchemaRepoForCandidateCollector{
+		allColumns: []*models.SchemaColumn{orgRefCol, projectsPKCol, orgsPKCol},
+		tables: []*models.SchemaTable{
+			{ID: projectsTableID, SchemaName: "public", TableName: "projects"},
+			{ID: orgsTableID, SchemaName: "public", TableName: "organizations"},
+		},
+	}
+	adapter := &mockSchemaDiscovererForJoinStats{
+		columnStatsMap: map[string]datasource.ColumnStats{
+			"projects.org_ref": {ColumnName: "org_ref", RowCount: 100, NonNullCount: 100, DistinctCount: 40},
+		},
+	}
+
+	collector := NewRelationshipCandidateCollector(repo, &mockColumnMetadataRepoForCandidateCollector{metadataByColumnID: make(map[uuid.UUID]*models.ColumnMetadata)}, &mockAdapterFactoryForCandidateCollector{schemaDiscoverer: adapter}, &mockDatasourceServiceForCandidateCollector{}, nil, nil, false, zap.NewNop())
+
+	result, stats, err := collector.CollectCandidates(context.Background(), projectID, datasourceID, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []uuid.UUID{orgRefCol.ID}, repo.statsUpdatedColumnIDs, "stats should be stored for org_ref only")
+	assert.Equal(t, []uuid.UUID{orgRefCol.ID}, repo.joinabilityUpdatedColumnIDs)
+	require.NotNil(t, orgRefCol.IsJoinable)
+	assert.True(t, *orgRefCol.IsJoinable)
+	require.NotNil(t, stats)
+	assert.Equal(t, 1, stats.LikelyFKStats)
+
+	require.Len(t, result, 1, "org_ref should be considered by PK-match")
+	assert.Equal(t, "projects", result[0].SourceTable)
+	assert.Equal(t, "org_ref", result[0].SourceColumn)
+	assert.Equal(t, "organizations", result[0].TargetTable)
+	assert.Equal(t, "id", result[0].TargetColumn)
+}
+
+func TestLikelyFKColumnsWithoutStats(t *testing.T) {
+	tableID := uuid.New()
+	tableByID := map[uuid.UUID]*models.SchemaTable{tableID: {ID: tableID, TableName: "t"}}
+	joinable := true
+	col := func(name, dataType string) *models.SchemaColumn {
+		return &models.SchemaColumn{ID: uuid.New(), SchemaTableID: tableID, ColumnName: name, DataType: dataType}
+	}
+
+	pk := col("id", "uuid")
+	pk.IsPrimaryKey = true
+	orgRef := col("org_ref", "uuid")
+	customerKey := col("customer_key", "uuid")
+	ownerID := col("owner_id", "uuid")
+	withStats := col("team_id", "uuid")
+	withStats.IsJoinable = &joinable
+	notAReference := col("nickname", "uuid")
+	integerRef := col("account_id", "bigint") // No integer primary key to match
+	textRef := col("external_ref", "text")    // No text primary key to match
+	otherTable := &models.SchemaColumn{ID: uuid.New(), SchemaTableID: uuid.New(), ColumnName: "user_id", DataType: "uuid"}
+
+	got := likelyFKColumnsWithoutStats(
+		[]*models.SchemaColumn{pk, orgRef, customerKey, ownerID, withStats, notAReference, integerRef, textRef, otherTable},
+		tableByID,
+	)
+	assert.Equal(t, []*models.SchemaColumn{orgRef, customerKey, ownerID}, got)
+}
+
 // ============================================================================
 // identifyFKTargets Tests
 // ============================================================================
@@ -1295,6 +1499,14 @@ func TestAreTypesCompatible_UUIDOnly(t *testing.T) {
 	assert.False(t, areTypesCompatible("uuid", "integer"), "uuid should not match integer")
 }
 
+func TestIsUUIDTextPair(t *testing.T) {
+	assert.True(t, isUUIDTextPair("text", "uuid"))
+	assert.True(t, isUUIDTextPair("UUID", "character varying(36)"))
+	assert.False(t, isUUIDTextPair("uuid", "uuid"), "compatible pairs need no value check")
+	assert.False(t, isUUIDTextPair("bigint", "uuid"))
+	assert.False(t, isUUIDTextPair("text", "bigint"))
+}
+
 func TestAreTypesCompatible_IncompatiblePairs(t *testing.T) {
 	// These pairs should NOT be compatible
 	tests := []struct {
@@ -2375,9 +2587,9 @@ func TestCollectCandidates_DatasourceGetError(t *testing.T) {
 		getErr: errors.New("datasource not found"),
 	}
 
-	collector := NewRelationshipCandidateCollector(repo, &mockColumnMetadataRepoForCandidateCollector{metadataByColumnID: make(map[uuid.UUID]*models.ColumnMetadata)}, &mockAdapterFactoryForCandidateCollector{}, dsSvc, zap.NewNop())
+	collector := NewRelationshipCandidateCollector(repo, &mockColumnMetadataRepoForCandidateCollector{metadataByColumnID: make(map[uuid.UUID]*models.ColumnMetadata)}, &mockAdapterFactoryForCandidateCollector{}, dsSvc, nil, nil, false, zap.NewNop())
 
-	_, err := collector.CollectCandidates(context.Background(), projectID, datasourceID, nil)
+	_, _, err := collector.CollectCandidates(context.Background(), projectID, datasourceID, nil)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "get datasource")
 }
@@ -2395,9 +2607,9 @@ func TestCollectCandidates_AdapterCreationError(t *testing.T) {
 		schemaDiscovererErr: errors.New("connection failed"),
 	}
 
-	collector := NewRelationshipCandidateCollector(repo, &mockColumnMetadataRepoForCandidateCollector{metadataByColumnID: make(map[uuid.UUID]*models.ColumnMetadata)}, adapterFactory, &mockDatasourceServiceForCandidateCollector{}, zap.NewNop())
+	collector := NewRelationshipCandidateCollector(repo, &mockColumnMetadataRepoForCandidateCollector{metadataByColumnID: make(map[uuid.UUID]*models.ColumnMetadata)}, adapterFactory, &mockDatasourceServiceForCandidateCollector{}, nil, nil, false, zap.NewNop())
 
-	_, err := collector.CollectCandidates(context.Background(), projectID, datasourceID, nil)
+	_, _, err := collector.CollectCandidates(context.Background(), projectID, datasourceID, nil)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "create schema discoverer")
 }
@@ -2480,11 +2692,18 @@ func TestCollectCandidates_CollectsJoinStatistics(t *testing.T) {
 		schemaDiscoverer: mockAdapter,
 	}
 
-	collector := NewRelationshipCandidateCollector(repo, &mockColumnMetadataRepoForCandidateCollector{metadataByColumnID: metadataByColumnID}, adapterFactory, &mockDatasourceServiceForCandidateCollector{}, zap.NewNop())
+	collector := NewRelationshipCandidateCollector(repo, &mockColumnMetadataRepoForCandidateCollector{metadataByColumnID: metadataByColumnID}, adapterFactory, &mockDatasourceServiceForCandidateCollector{}, nil, nil, false, zap.NewNop())
 
-	result, err := collector.CollectCandidates(context.Background(), projectID, datasourceID, nil)
+	result, stats, err := collector.CollectCandidates(context.Background(), projectID, datasourceID, nil)
 	require.NoError(t, err)
 	require.Len(t, result, 1)
+	assert.Equal(t, &RelationshipCandidateStats{
+		FKSources:         1,
+		FKTargets:         1,
+		PairsGenerated:    1,
+		JoinAnalysisCalls: 1,
+		Collected:         1,
+	}, stats)
 
 	candidate := result[0]
 
@@ -2506,6 +2725,147 @@ func TestCollectCandidates_CollectsJoinStatistics(t *testing.T) {
 	assert.Equal(t, 0.0, candidate.TargetNullRate)
 }
 
+// uuidTextFixture is an orders.user_ref text column that may reference users.id uuid.
+func uuidTextFixture(samples []string) (*mockSchemaRepoForCandidateCollector, *mockColumnMetadataRepoForCandidateCollector, *mockAdapterFactoryForCandidateCollector) {
+	ordersTableID := uuid.New()
+	usersTableID := uuid.New()
+	isJoinable := true
+	userRefCol := &models.SchemaColumn{
+		ID:            uuid.New(),
+		SchemaTableID: ordersTableID,
+		ColumnName:    "user_ref",
+		DataType:      "text",
+		IsJoinable:    &isJoinable,
+	}
+	usersPKCol := &models.SchemaColumn{
+		ID:            uuid.New(),
+		SchemaTableID: usersTableID,
+		ColumnName:    "id",
+		DataType:      "uuid",
+		IsPrimaryKey:  true,
+	}
+
+	fkRole := models.RoleForeignKey
+	repo := &mockSchemaRepoForCandidateCollector{
+		allColumns: []*models.SchemaColumn{userRefCol, usersPKCol},
+		tables: []*models.SchemaTable{
+			{ID: ordersTableID, TableName: "orders"},
+			{ID: usersTableID, TableName: "users"},
+		},
+	}
+	metadataRepo := &mockColumnMetadataRepoForCandidateCollector{metadataByColumnID: map[uuid.UUID]*models.ColumnMetadata{
+		userRefCol.ID: {SchemaColumnID: userRefCol.ID, Role: &fkRole},
+	}}
+	adapterFactory := &mockAdapterFactoryForCandidateCollector{schemaDiscoverer: &mockSchemaDiscovererForJoinStats{
+		distinctValuesMap: map[string][]string{"orders.user_ref": samples},
+	}}
+	return repo, metadataRepo, adapterFactory
+}
+
+func TestCollectCandidates_MatchesTextUUIDColumnToUUIDPK(t *testing.T) {
+	repo, metadataRepo, adapterFactory := uuidTextFixture([]string{
+		"6f1c1a52-3f0e-4c4e-9d8a-0b7a2f1e5c11",
+		"A3B1C2D4-E5F6-4789-8ABC-DEF012345678",
+	})
+
+	collector := NewRelationshipCandidateCollector(repo, metadataRepo, adapterFactory, &mockDatasourceServiceForCandidateCollector{}, nil, nil, true, zap.NewNop())
+
+	result, stats, err := collector.CollectCandidates(context.Background(), uuid.New(), uuid.New(), nil)
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "orders", result[0].SourceTable)
+	assert.Equal(t, "user_ref", result[0].SourceColumn)
+	assert.Equal(t, "users", result[0].TargetTable)
+	assert.Equal(t, "id", result[0].TargetColumn)
+	assert.True(t, result[0].UUIDTextMatch)
+	assert.Equal(t, 1, stats.JoinAnalysisCalls)
+}
+
+func TestCollectCandidates_RejectsTextColumnWithNonUUIDValues(t *testing.T) {
+	repo, metadataRepo, adapterFactory := uuidTextFixture([]string{"6f1c1a52-3f0e-4c4e-9d8a-0b7a2f1e5c11", "ORD-1001"})
+
+	collector := NewRelationshipCandidateCollector(repo, metadataRepo, adapterFactory, &mockDatasourceServiceForCandidateCollector{}, nil, nil, true, zap.NewNop())
+
+	result, stats, err := collector.CollectCandidates(context.Background(), uuid.New(), uuid.New(), nil)
+	require.NoError(t, err)
+	assert.Empty(t, result)
+	assert.Equal(t, 1, stats.PairsGenerated)
+	assert.Equal(t, 1, stats.RejectedNonUUID)
+	assert.Zero(t, stats.JoinAnalysisCalls, "no join query runs for values that are not UUIDs")
+}
+
+func TestCollectCandidates_UUIDTextMatchingDisabled(t *testing.T) {
+	repo, metadataRepo, adapterFactory := uuidTextFixture([]string{"6f1c1a52-3f0e-4c4e-9d8a-0b7a2f1e5c11"})
+
+	collector := NewRelationshipCandidateCollector(repo, metadataRepo, adapterFactory, &mockDatasourceServiceForCandidateCollector{}, nil, nil, false, zap.NewNop())
+
+	result, stats, err := collector.CollectCandidates(context.Background(), uuid.New(), uuid.New(), nil)
+	require.NoError(t, err)
+	assert.Empty(t, result)
+	assert.Zero(t, stats.PairsGenerated, "the type matrix rejects text/uuid when matching is off")
+}
+
+// mockArraySchemaDiscoverer adds array join analysis, keyed by "source.col->target.col".
+type mockArraySchemaDiscoverer struct {
+	mockSchemaDiscovererForJoinStats
+	arrayJoins map[string]*datasource.JoinAnalysis
+	arrayCalls []string
+}
+
+func (m *mockArraySchemaDiscoverer) AnalyzeArrayJoin(ctx context.Context, sourceSchema, sourceTable, sourceColumn, targetSchema, targetTable, targetColumn string) (*datasource.JoinAnalysis, error) {
+	key := sourceTable + "." + sourceColumn + "->" + targetTable + "." + targetColumn
+	m.arrayCalls = append(m.arrayCalls, key)
+	if result, ok := m.arrayJoins[key]; ok {
+		return result, nil
+	}
+	return &datasource.JoinAnalysis{}, nil
+}
+
+func TestCollectCandidates_ArrayFKColumnResolvesToReferencedTable(t *testing.T) {
+	postsTableID := uuid.New()
+	tagsTableID := uuid.New()
+
+	tagIDsCol := &models.SchemaColumn{ID: uuid.New(), SchemaTableID: postsTableID, ColumnName: "tag_ids", DataType: "int8[]"}
+	postsPK := &models.SchemaColumn{ID: uuid.New(), SchemaTableID: postsTableID, ColumnName: "id", DataType: "int8", IsPrimaryKey: true}
+	tagsPK := &models.SchemaColumn{ID: uuid.New(), SchemaTableID: tagsTableID, ColumnName: "id", DataType: "int8", IsPrimaryKey: true}
+	tagsCode := &models.SchemaColumn{ID: uuid.New(), SchemaTableID: tagsTableID, ColumnName: "code", DataType: "int8", IsUnique: true}
+
+	repo := &mockSchemaRepoForCandidateCollector{
+		allColumns: []*models.SchemaColumn{tagIDsCol, postsPK, tagsPK, tagsCode},
+		tables: []*models.SchemaTable{
+			{ID: postsTableID, TableName: "posts"},
+			{ID: tagsTableID, TableName: "tags"},
+		},
+	}
+
+	// Every tag id in posts.tag_ids exists in tags.id; post ids do not line up
+	adapter := &mockArraySchemaDiscoverer{
+		mockSchemaDiscovererForJoinStats: mockSchemaDiscovererForJoinStats{
+			analyzeJoinErr: errors.New("array columns must use AnalyzeArrayJoin"),
+		},
+		arrayJoins: map[string]*datasource.JoinAnalysis{
+			"posts.tag_ids->tags.id":  {JoinCount: 40, SourceMatched: 12, TargetMatched: 12},
+			"posts.tag_ids->posts.id": {JoinCount: 3, SourceMatched: 3, TargetMatched: 3, OrphanCount: 9},
+		},
+	}
+
+	collector := NewRelationshipCandidateCollector(repo, &mockColumnMetadataRepoForCandidateCollector{},
+		&mockAdapterFactoryForCandidateCollector{schemaDiscoverer: adapter},
+		&mockDatasourceServiceForCandidateCollector{}, nil, nil, false, zap.NewNop())
+
+	result, stats, err := collector.CollectCandidates(context.Background(), uuid.New(), uuid.New(), nil)
+
+	require.NoError(t, err)
+	// Array sources pair with primary keys only, so tags.code is never analyzed
+	assert.ElementsMatch(t, []string{"posts.tag_ids->tags.id", "posts.tag_ids->posts.id"}, adapter.arrayCalls)
+	assert.Equal(t, 1, stats.RejectedOrphans)
+	require.Len(t, result, 1)
+	assert.Equal(t, "tags", result[0].TargetTable)
+	assert.Equal(t, "id", result[0].TargetColumn)
+	assert.True(t, result[0].SourceIsArray)
+	assert.Equal(t, int64(12), result[0].SourceMatched)
+}
+
 func TestCollectCandidates_ContinuesOnNonFatalErrors(t *testing.T) {
 	// Tests that the collector continues processing candidates when
 	// sample value and distinct count collection fails (these are non-fatal).
@@ -2571,10 +2931,10 @@ func TestCollectCandidates_ContinuesOnNonFatalErrors(t *testing.T) {
 		schemaDiscoverer: mockAdapter,
 	}
 
-	collector := NewRelationshipCandidateCollector(repo, &mockColumnMetadataRepoForCandidateCollector{metadataByColumnID: metadataByColumnID}, adapterFactory, &mockDatasourceServiceForCandidateCollector{}, zap.NewNop())
+	collector := NewRelationshipCandidateCollector(repo, &mockColumnMetadataRepoForCandidateCollector{metadataByColumnID: metadataByColumnID}, adapterFactory, &mockDatasourceServiceForCandidateCollector{}, nil, nil, false, zap.NewNop())
 
 	// Should still succeed - sample/stats errors are logged but not fatal
-	result, err := collector.CollectCandidates(context.Background(), projectID, datasourceID, nil)
+	result, _, err := collector.CollectCandidates(context.Background(), projectID, datasourceID, nil)
 	require.NoError(t, err)
 	require.Len(t, result, 1, "should return candidate even if stats collection fails")
 
@@ -2595,6 +2955,57 @@ func TestCollectCandidates_ContinuesOnNonFatalErrors(t *testing.T) {
 	assert.Empty(t, candidate.SourceSamples)
 }
 
+func TestCollectCandidates_StatsCountGateRejections(t *testing.T) {
+	ordersTableID := uuid.New()
+	usersTableID := uuid.New()
+
+	isJoinable := true
+	userIDCol := &models.SchemaColumn{
+		ID:            uuid.New(),
+		SchemaTableID: ordersTableID,
+		ColumnName:    "user_id",
+		DataType:      "uuid",
+		IsJoinable:    &isJoinable,
+	}
+	fkRole := models.RoleForeignKey
+	fkClassPath := string(models.ClassificationPathUUID)
+	metadataByColumnID := map[uuid.UUID]*models.ColumnMetadata{
+		userIDCol.ID: {SchemaColumnID: userIDCol.ID, Role: &fkRole, ClassificationPath: &fkClassPath},
+	}
+	usersPKCol := &models.SchemaColumn{
+		ID:            uuid.New(),
+		SchemaTableID: usersTableID,
+		ColumnName:    "id",
+		DataType:      "uuid",
+		IsPrimaryKey:  true,
+	}
+	repo := &mockSchemaRepoForCandidateCollector{
+		allColumns: []*models.SchemaColumn{userIDCol, usersPKCol},
+		tables: []*models.SchemaTable{
+			{ID: ordersTableID, TableName: "orders"},
+			{ID: usersTableID, TableName: "users"},
+		},
+	}
+
+	// Orphaned source values fail referential integrity
+	adapterFactory := &mockAdapterFactoryForCandidateCollector{
+		schemaDiscoverer: &mockSchemaDiscovererForJoinStats{
+			analyzeJoinResult: &datasource.JoinAnalysis{JoinCount: 100, SourceMatched: 90, OrphanCount: 10},
+		},
+	}
+
+	collector := NewRelationshipCandidateCollector(repo, &mockColumnMetadataRepoForCandidateCollector{metadataByColumnID: metadataByColumnID}, adapterFactory, &mockDatasourceServiceForCandidateCollector{}, nil, nil, false, zap.NewNop())
+
+	result, stats, err := collector.CollectCandidates(context.Background(), uuid.New(), uuid.New(), nil)
+	require.NoError(t, err)
+	assert.Empty(t, result)
+	assert.Equal(t, 1, stats.PairsGenerated)
+	assert.Equal(t, 1, stats.JoinAnalysisCalls)
+	assert.Equal(t, 1, stats.RejectedOrphans)
+	assert.Zero(t, stats.RejectedNoMatch)
+	assert.Zero(t, stats.Collected)
+}
+
 // ============================================================================
 // Multi-Target Filter Tests
 // ============================================================================
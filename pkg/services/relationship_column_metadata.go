@@ -65,6 +65,7 @@ func reconcileRelationshipBackedColumnMetadata(
 	meta.Features.BooleanFeatures = nil
 	meta.Features.EnumFeatures = nil
 	meta.Features.MonetaryFeatures = nil
+	meta.Features.DatePartFeatures = nil
 	if meta.Features.IdentifierFeatures == nil {
 		meta.Features.IdentifierFeatures = &models.IdentifierFeatures{}
 	}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"sort"
+
+	"github.com/google/uuid"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+)
+
+// findRelationshipCycles reports the cycles in the directed graph of approved
+// relationships (source table -> target table). Relationships a user rejected are
+// not part of the graph. Self-references are returned separately: a table pointing
+// at itself (parent_id, manager_id) is an expected hierarchy, not a modeling problem.
+//
+// Every group of tables that can reach one another (a strongly connected component)
+// is reported once, with one concrete cycle through them as the path, so a dense
+// cluster does not explode into every possible cycle.
+func findRelationshipCycles(relationships []*models.RelationshipDetail) ([]models.RelationshipCycle, []models.SelfReferencingRelationship) {
+	selfRefs := []models.SelfReferencingRelationship{}
+	edges := make(map[string][]string)
+	tables := make(map[string]bool)
+	for _, rel := range relationships {
+		if rel.IsApproved != nil && !*rel.IsApproved {
+			continue
+		}
+		if rel.SourceTableName == rel.TargetTableName {
+			selfRefs = append(selfRefs, models.SelfReferencingRelationship{
+				RelationshipID: rel.ID,
+				Table:          rel.SourceTableName,
+				SourceColumn:   rel.SourceColumnName,
+				TargetColumn:   rel.TargetColumnName,
+			})
+			continue
+		}
+		tables[rel.SourceTableName] = true
+		tables[rel.TargetTableName] = true
+		edges[rel.SourceTableName] = append(edges[rel.SourceTableName], rel.TargetTableName)
+	}
+
+	cycles := []models.RelationshipCycle{}
+	for _, component := range stronglyConnectedTables(tables, edges) {
+		if len(component) < 2 {
+			continue
+		}
+		members := make(map[string]bool, len(component))
+		for _, table := range component {
+			members[table] = true
+		}
+
+		cycle := models.RelationshipCycle{
+			Tables:          component,
+			Path:            cyclePath(component[0], edges, members),
+			RelationshipIDs: []uuid.UUID{},
+		}
+		for _, rel := range relationships {
+			if rel.IsApproved != nil && !*rel.IsApproved {
+				continue
+			}
+			if rel.SourceTableName != rel.TargetTableName && members[rel.SourceTableName] && members[rel.TargetTableName] {
+				cycle.RelationshipIDs = append(cycle.RelationshipIDs, rel.ID)
+			}
+		}
+		cycles = append(cycles, cycle)
+	}
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i].Tables[0] < cycles[j].Tables[0] })
+	return cycles, selfRefs
+}
+
+// stronglyConnectedTables groups tables using Tarjan's algorithm. Each group and
+// the tables within it are sorted so reports are stable across runs.
+func stronglyConnectedTables(tables map[string]bool, edges map[string][]string) [][]string {
+	names := make([]string, 0, len(tables))
+	for table := range tables {
+		names = append(names, table)
+	}
+	sort.Strings(names)
+
+	index := make(map[string]int, len(names))
+	lowLink := make(map[string]int, len(names))
+	onStack := make(map[string]bool, len(names))
+	var stack []string
+	var components [][]string
+	next := 0
+
+	var visit func(table string)
+	visit = func(table string) {
+		index[table] = next
+		lowLink[table] = next
+		next++
+		stack = append(stack, table)
+		onStack[table] = true
+
+		for _, target := range edges[table] {
+			if _, seen := index[target]; !seen {
+				visit(target)
+				lowLink[table] = min(lowLink[table], lowLink[target])
+			} else if onStack[target] {
+				lowLink[table] = min(lowLink[table], index[target])
+			}
+		}
+
+		if lowLink[table] == index[table] {
+			var component []string
+			for {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[top] = false
+				component = append(component, top)
+				if top == table {
+					break
+				}
+			}
+			sort.Strings(component)
+			components = append(components, component)
+		}
+	}
+
+	for _, table := range names {
+		if _, seen := index[table]; !seen {
+			visit(table)
+		}
+	}
+	return components
+}
+
+// cyclePath returns the shortest cycle from start back to start that stays within
+// members, as table names with start at both ends. Every table in a strongly
+// connected component lies on such a cycle.
+func cyclePath(start string, edges map[string][]string, members map[string]bool) []string {
+	parent := map[string]string{}
+	queue := []string{start}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		targets := append([]string(nil), edges[current]...)
+		sort.Strings(targets)
+		for _, target := range targets {
+			if !members[target] {
+				continue
+			}
+			if target == start {
+				path := []string{start}
+				for table := current; table != start; table = parent[table] {
+					path = append(path, table)
+				}
+				path = append(path, start)
+				// Built backwards from the closing edge; reverse the middle
+				for i, j := 1, len(path)-2; i < j; i, j = i+1, j-1 {
+					path[i], path[j] = path[j], path[i]
+				}
+				return path
+			}
+			if _, seen := parent[target]; !seen {
+				parent[target] = current
+				queue = append(queue, target)
+			}
+		}
+	}
+	return nil
+}
@@ -12,16 +12,25 @@ import (
 	"github.com/ekaya-inc/ekaya-engine/pkg/models"
 	"github.com/ekaya-inc/ekaya-engine/pkg/repositories"
 	"github.com/ekaya-inc/ekaya-engine/pkg/services/dag"
+	"github.com/ekaya-inc/ekaya-engine/pkg/tracing"
 )
 
 // LLMRelationshipDiscoveryResult contains the results of LLM-validated relationship discovery.
 type LLMRelationshipDiscoveryResult struct {
-	CandidatesEvaluated   int   `json:"candidates_evaluated"`
-	RelationshipsCreated  int   `json:"relationships_created"`
-	RelationshipsRejected int   `json:"relationships_rejected"`
-	PreservedDBFKs        int   `json:"preserved_db_fks"`
-	PreservedColumnFKs    int   `json:"preserved_column_fks"`
-	DurationMs            int64 `json:"duration_ms"`
+	CandidatesEvaluated      int   `json:"candidates_evaluated"`
+	CandidatesAlreadyRelated int   `json:"candidates_already_related"` // Collected candidates skipped because the relationship exists
+	CandidatesOverridden     int   `json:"candidates_overridden"`      // Collected candidates skipped because a column entity override points elsewhere
+	RelationshipsCreated     int   `json:"relationships_created"`
+	RelationshipsRejected    int   `json:"relationships_rejected"` // Rejected by LLM validation
+	PreservedDBFKs           int   `json:"preserved_db_fks"`
+	PreservedColumnFKs       int   `json:"preserved_column_fks"`
+	DurationMs               int64 `json:"duration_ms"`
+
+	// RelationshipsByMethod counts the datasource's relationships after discovery by how
+	// they were found: inference method (fk, column_features, relationship_discovery, ...)
+	// or "manual" for user-added relationships.
+	RelationshipsByMethod map[string]int             `json:"relationships_by_method"`
+	Candidates            RelationshipCandidateStats `json:"candidates"`
 }
 
 // LLMRelationshipDiscoveryService orchestrates the full LLM-validated relationship discovery pipeline.
@@ -44,6 +53,7 @@ type llmRelationshipDiscoveryService struct {
 	adapterFactory     datasource.DatasourceAdapterFactory
 	schemaRepo         repositories.SchemaRepository
 	columnMetadataRepo repositories.ColumnMetadataRepository
+	projectRepo        repositories.ProjectRepository
 	logger             *zap.Logger
 }
 
@@ -55,6 +65,7 @@ func NewLLMRelationshipDiscoveryService(
 	adapterFactory datasource.DatasourceAdapterFactory,
 	schemaRepo repositories.SchemaRepository,
 	columnMetadataRepo repositories.ColumnMetadataRepository,
+	projectRepo repositories.ProjectRepository,
 	logger *zap.Logger,
 ) LLMRelationshipDiscoveryService {
 	return &llmRelationshipDiscoveryService{
@@ -64,6 +75,7 @@ func NewLLMRelationshipDiscoveryService(
 		adapterFactory:     adapterFactory,
 		schemaRepo:         schemaRepo,
 		columnMetadataRepo: columnMetadataRepo,
+		projectRepo:        projectRepo,
 		logger:             logger.Named("llm-relationship-discovery"),
 	}
 }
@@ -76,10 +88,13 @@ func (s *llmRelationshipDiscoveryService) DiscoverRelationships(
 	ctx context.Context,
 	projectID, datasourceID uuid.UUID,
 	progressCallback dag.ProgressCallback,
-) (*LLMRelationshipDiscoveryResult, error) {
+) (_ *LLMRelationshipDiscoveryResult, err error) {
+	ctx, span := tracing.Start(ctx, "relationship_discovery", tracing.AttrProjectID.String(projectID.String()))
+	defer func() { tracing.End(span, err) }()
+
 	startTime := time.Now()
 
-	result := &LLMRelationshipDiscoveryResult{}
+	result := &LLMRelationshipDiscoveryResult{RelationshipsByMethod: make(map[string]int)}
 
 	// Load tables and columns for resolving table/column metadata
 	tables, err := s.schemaRepo.ListTablesByDatasource(ctx, projectID, datasourceID)
@@ -87,11 +102,12 @@ func (s *llmRelationshipDiscoveryService) DiscoverRelationships(
 		return nil, fmt.Errorf("list tables: %w", err)
 	}
 	tableByID := make(map[uuid.UUID]*models.SchemaTable)
-	tableByName := make(map[string]*models.SchemaTable) // "schema.table" and "table" → table
+	// "schema.table" and "table" → table; candidates may name tables quoted or case-folded
+	tableByName := datasource.NewIdentifierIndex[*models.SchemaTable]()
 	for _, t := range tables {
 		tableByID[t.ID] = t
-		tableByName[fmt.Sprintf("%s.%s", t.SchemaName, t.TableName)] = t
-		tableByName[t.TableName] = t // Also allow lookup by table name only
+		tableByName.Add(fmt.Sprintf("%s.%s", t.SchemaName, t.TableName), t)
+		tableByName.Add(t.TableName, t) // Also allow lookup by table name only
 	}
 
 	columns, err := s.schemaRepo.ListColumnsByDatasource(ctx, projectID, datasourceID)
@@ -139,7 +155,7 @@ func (s *llmRelationshipDiscoveryService) DiscoverRelationships(
 		progressCallback(0, 1, "Collecting relationship candidates")
 	}
 
-	candidates, err := s.candidateCollector.CollectCandidates(ctx, projectID, datasourceID, func(current, total int, msg string) {
+	candidates, candidateStats, err := s.candidateCollector.CollectCandidates(ctx, projectID, datasourceID, func(current, total int, msg string) {
 		if progressCallback != nil {
 			// Prefix messages from collector to maintain phase context
 			progressCallback(current, total, "Collecting candidates: "+msg)
@@ -148,6 +164,9 @@ func (s *llmRelationshipDiscoveryService) DiscoverRelationships(
 	if err != nil {
 		return nil, fmt.Errorf("collect candidates: %w", err)
 	}
+	if candidateStats != nil {
+		result.Candidates = *candidateStats
+	}
 
 	s.logger.Info("Collected relationship candidates",
 		zap.Int("count", len(candidates)),
@@ -159,9 +178,20 @@ func (s *llmRelationshipDiscoveryService) DiscoverRelationships(
 		return nil, fmt.Errorf("get existing relationships: %w", err)
 	}
 	existingRelSet := s.buildExistingSchemaRelationshipSet(existingRels, tableByID, columnByID)
+	for _, r := range existingRels {
+		result.RelationshipsByMethod[relationshipMethodKey(r)]++
+	}
+
+	// Overridden columns keep only the candidate pointing at the user's chosen table;
+	// the relationship itself was materialized from the override during FKDiscovery.
+	overrides := loadColumnEntityOverrides(ctx, s.projectRepo, projectID, s.logger)
 
 	var newCandidates []*RelationshipCandidate
 	for _, c := range candidates {
+		if target, ok := columnEntityOverride(overrides, c.SourceTable, c.SourceColumn); ok && !sameTableName(target, c.TargetTable) {
+			result.CandidatesOverridden++
+			continue
+		}
 		key := fmt.Sprintf("%s.%s->%s.%s", c.SourceTable, c.SourceColumn, c.TargetTable, c.TargetColumn)
 		if !existingRelSet[key] {
 			newCandidates = append(newCandidates, c)
@@ -174,6 +204,7 @@ func (s *llmRelationshipDiscoveryService) DiscoverRelationships(
 		zap.Int("existing_count", len(existingRels)))
 
 	result.CandidatesEvaluated = len(newCandidates)
+	result.CandidatesAlreadyRelated = len(candidates) - len(newCandidates) - result.CandidatesOverridden
 
 	// Phase 4: Validate candidates with LLM (if any remain)
 	if len(newCandidates) > 0 {
@@ -213,6 +244,7 @@ func (s *llmRelationshipDiscoveryService) DiscoverRelationships(
 					continue
 				}
 				result.RelationshipsCreated++
+				result.RelationshipsByMethod[models.InferenceMethodRelationshipDiscovery]++
 			} else {
 				result.RelationshipsRejected++
 			}
@@ -231,12 +263,26 @@ func (s *llmRelationshipDiscoveryService) DiscoverRelationships(
 		zap.Int("relationships_rejected", result.RelationshipsRejected),
 		zap.Int("preserved_db_fks", result.PreservedDBFKs),
 		zap.Int("preserved_column_fks", result.PreservedColumnFKs),
+		zap.Any("relationships_by_method", result.RelationshipsByMethod),
+		zap.Int("join_analysis_calls", result.Candidates.JoinAnalysisCalls),
 		zap.Int64("duration_ms", result.DurationMs),
 		zap.String("project_id", projectID.String()))
 
 	return result, nil
 }
 
+// relationshipMethodKey groups a relationship for RelationshipsByMethod: "manual" for
+// user-added relationships, otherwise its inference method, falling back to its type.
+func relationshipMethodKey(r *models.SchemaRelationship) string {
+	if r.RelationshipType == models.RelationshipTypeManual {
+		return models.RelationshipTypeManual
+	}
+	if r.InferenceMethod != nil && *r.InferenceMethod != "" {
+		return *r.InferenceMethod
+	}
+	return r.RelationshipType
+}
+
 // buildExistingSchemaRelationshipSet creates a set of existing relationship keys for deduplication.
 // Uses table/column names resolved from the provided lookups for consistent key formatting.
 func (s *llmRelationshipDiscoveryService) buildExistingSchemaRelationshipSet(
@@ -267,15 +313,15 @@ func (s *llmRelationshipDiscoveryService) createSchemaRelationshipFromValidation
 	ctx context.Context,
 	projectID uuid.UUID,
 	vr *ValidatedRelationship,
-	tableByName map[string]*models.SchemaTable,
+	tableByName *datasource.IdentifierIndex[*models.SchemaTable],
 	columnByID map[uuid.UUID]*models.SchemaColumn,
 ) error {
 	candidate := vr.Candidate
 	result := vr.Result
 
 	// Resolve table IDs from table lookup
-	sourceTable := tableByName[candidate.SourceTable]
-	targetTable := tableByName[candidate.TargetTable]
+	sourceTable, _ := tableByName.Get(candidate.SourceTable)
+	targetTable, _ := tableByName.Get(candidate.TargetTable)
 
 	if sourceTable == nil || targetTable == nil {
 		return fmt.Errorf("missing table: source=%s (%v), target=%s (%v)",
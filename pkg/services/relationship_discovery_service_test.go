@@ -30,6 +30,7 @@ func TestNewLLMRelationshipDiscoveryService(t *testing.T) {
 		nil, // adapterFactory
 		nil, // schemaRepo
 		nil, // columnMetadataRepo
+		nil, // projectRepo
 		logger,
 	)
 
@@ -60,7 +61,7 @@ func TestBuildExistingSchemaRelationshipSet(t *testing.T) {
 	logger := zap.NewNop()
 
 	svc := NewLLMRelationshipDiscoveryService(
-		nil, nil, nil, nil, nil, nil, logger,
+		nil, nil, nil, nil, nil, nil, nil, logger,
 	).(*llmRelationshipDiscoveryService)
 
 	// Create test tables and columns
@@ -114,7 +115,7 @@ func TestBuildExistingSchemaRelationshipSet_EmptyInput(t *testing.T) {
 	logger := zap.NewNop()
 
 	svc := NewLLMRelationshipDiscoveryService(
-		nil, nil, nil, nil, nil, nil, logger,
+		nil, nil, nil, nil, nil, nil, nil, logger,
 	).(*llmRelationshipDiscoveryService)
 
 	relSet := svc.buildExistingSchemaRelationshipSet(
@@ -131,7 +132,7 @@ func TestBuildExistingSchemaRelationshipSet_NilInput(t *testing.T) {
 	logger := zap.NewNop()
 
 	svc := NewLLMRelationshipDiscoveryService(
-		nil, nil, nil, nil, nil, nil, logger,
+		nil, nil, nil, nil, nil, nil, nil, logger,
 	).(*llmRelationshipDiscoveryService)
 
 	relSet := svc.buildExistingSchemaRelationshipSet(nil, nil, nil)
@@ -187,6 +188,10 @@ func (m *mockRelDiscoveryLLMClient) GetEndpoint() string {
 	return "https://test.endpoint"
 }
 
+func (m *mockRelDiscoveryLLMClient) GenerateResponseStream(ctx context.Context, prompt string, systemMessage string, temperature float64) (*llm.ResponseStream, error) {
+	return llm.CompletedResponseStream(m.GenerateResponse(ctx, prompt, systemMessage, temperature, false))
+}
+
 var _ llm.LLMClient = (*mockRelDiscoveryLLMClient)(nil)
 
 // extractCandidateKeyFromPrompt extracts a "source_table.source_column->target_table.target_column" key from a prompt
@@ -255,14 +260,15 @@ var _ llm.LLMClientFactory = (*mockRelDiscoveryLLMClientFactory)(nil)
 // mockRelDiscoveryCandidateCollector is a mock that returns specified candidates
 type mockRelDiscoveryCandidateCollector struct {
 	candidates []*RelationshipCandidate
+	stats      *RelationshipCandidateStats
 	err        error
 }
 
-func (m *mockRelDiscoveryCandidateCollector) CollectCandidates(_ context.Context, _, _ uuid.UUID, _ dag.ProgressCallback) ([]*RelationshipCandidate, error) {
+func (m *mockRelDiscoveryCandidateCollector) CollectCandidates(_ context.Context, _, _ uuid.UUID, _ dag.ProgressCallback) ([]*RelationshipCandidate, *RelationshipCandidateStats, error) {
 	if m.err != nil {
-		return nil, m.err
+		return nil, nil, m.err
 	}
-	return m.candidates, nil
+	return m.candidates, m.stats, nil
 }
 
 var _ RelationshipCandidateCollector = (*mockRelDiscoveryCandidateCollector)(nil)
@@ -437,6 +443,7 @@ func TestRelationshipDiscoveryService_ExistingColumnFeaturesFKsCountedWithoutRec
 		mockAdapterFactory,
 		mockSchemaRepo,
 		mockColumnMetadataRepo,
+		nil,
 		logger,
 	)
 
@@ -458,6 +465,7 @@ func TestRelationshipDiscoveryService_ExistingColumnFeaturesFKsCountedWithoutRec
 	assert.Empty(t, mockSchemaRepo.createdRels, "late discovery should not recreate column_features relationships")
 	assert.Equal(t, 1, result.PreservedColumnFKs, "should have 1 preserved ColumnFeatures FK")
 	assert.Equal(t, 0, result.CandidatesEvaluated, "no candidates should be evaluated (FK was pre-resolved)")
+	assert.Equal(t, map[string]int{models.InferenceMethodColumnFeatures: 1}, result.RelationshipsByMethod)
 	assert.Equal(t, []string{models.InferenceMethodFK, models.InferenceMethodColumnFeatures}, mockSchemaRepo.requestedMethods)
 }
 
@@ -564,6 +572,7 @@ func TestRelationshipDiscoveryService_UUIDTextToUUIDPK_LLMValidates(t *testing.T
 		mockAdapterFactory,
 		mockSchemaRepo,
 		nil, // columnMetadataRepo - no ColumnFeatures FKs in this test
+		nil, // projectRepo
 		logger,
 	)
 
@@ -586,6 +595,7 @@ func TestRelationshipDiscoveryService_UUIDTextToUUIDPK_LLMValidates(t *testing.T
 	assert.Equal(t, 1, result.CandidatesEvaluated, "should evaluate 1 candidate")
 	assert.Equal(t, 1, result.RelationshipsCreated, "should create 1 relationship (LLM accepted)")
 	assert.Equal(t, 0, result.RelationshipsRejected, "should reject 0 relationships")
+	assert.Equal(t, map[string]int{models.InferenceMethodRelationshipDiscovery: 1}, result.RelationshipsByMethod)
 
 	// Verify the relationship was stored with correct cardinality
 	assert.Len(t, mockSchemaRepo.createdRels, 1, "should create 1 relationship in repo")
@@ -698,6 +708,7 @@ func TestRelationshipDiscoveryService_IDToTimestamp_LLMRejects(t *testing.T) {
 		mockAdapterFactory,
 		mockSchemaRepo,
 		nil, // columnMetadataRepo - no ColumnFeatures FKs in this test
+		nil, // projectRepo
 		logger,
 	)
 
@@ -841,6 +852,7 @@ func TestRelationshipDiscoveryService_VerifyLLMCallsSlice(t *testing.T) {
 		mockAdapterFactory,
 		mockSchemaRepo,
 		nil, // columnMetadataRepo - no ColumnFeatures FKs in this test
+		nil, // projectRepo
 		logger,
 	)
 
@@ -969,10 +981,7 @@ func TestLLMRelationshipDiscoveryService_CreateSchemaRelationshipFromValidationR
 		context.Background(),
 		projectID,
 		validated,
-		map[string]*models.SchemaTable{
-			"products":             sourceTable,
-			"distribution_centers": targetTable,
-		},
+		tableIndex(sourceTable, targetTable),
 		map[uuid.UUID]*models.SchemaColumn{
 			distributionCenterIDColID: sourceColumn,
 			targetIDColID:             targetColumn,
@@ -1103,10 +1112,7 @@ func TestLLMRelationshipDiscoveryService_CreateSchemaRelationshipFromValidationS
 		context.Background(),
 		projectID,
 		validated,
-		map[string]*models.SchemaTable{
-			"products":             sourceTable,
-			"distribution_centers": targetTable,
-		},
+		tableIndex(sourceTable, targetTable),
 		map[uuid.UUID]*models.SchemaColumn{
 			distributionCenterIDColID: sourceColumn,
 			targetIDColID:             targetColumn,
@@ -1129,6 +1135,160 @@ func TestLLMRelationshipDiscoveryService_CreateSchemaRelationshipFromValidationS
 	assert.Nil(t, reconciled.Features.IdentifierFeatures)
 }
 
+// TestRelationshipDiscoveryService_ResultSummarizesMethodsAndGates verifies the result
+// reports relationships per inference method, collector gate counts, and candidates
+// skipped because their relationship already exists.
+func TestRelationshipDiscoveryService_ResultSummarizesMethodsAndGates(t *testing.T) {
+	projectID := uuid.New()
+	datasourceID := uuid.New()
+	ordersTableID := uuid.New()
+	usersTableID := uuid.New()
+
+	orderUserID := &models.SchemaColumn{ID: uuid.New(), SchemaTableID: ordersTableID, ColumnName: "user_id", DataType: "uuid"}
+	orderCreatedBy := &models.SchemaColumn{ID: uuid.New(), SchemaTableID: ordersTableID, ColumnName: "created_by", DataType: "uuid"}
+	orderApprovedBy := &models.SchemaColumn{ID: uuid.New(), SchemaTableID: ordersTableID, ColumnName: "approved_by", DataType: "uuid"}
+	usersPK := &models.SchemaColumn{ID: uuid.New(), SchemaTableID: usersTableID, ColumnName: "id", DataType: "uuid", IsPrimaryKey: true}
+
+	fkMethod := models.InferenceMethodFK
+	mockSchemaRepo := &mockSchemaRepoForRelDiscovery{
+		tables: []*models.SchemaTable{
+			{ID: ordersTableID, SchemaName: "public", TableName: "orders"},
+			{ID: usersTableID, SchemaName: "public", TableName: "users"},
+		},
+		columns: []*models.SchemaColumn{orderUserID, orderCreatedBy, orderApprovedBy, usersPK},
+		relationships: []*models.SchemaRelationship{
+			{
+				ID: uuid.New(), ProjectID: projectID, InferenceMethod: &fkMethod,
+				SourceTableID: ordersTableID, SourceColumnID: orderUserID.ID,
+				TargetTableID: usersTableID, TargetColumnID: usersPK.ID,
+			},
+			{
+				ID: uuid.New(), ProjectID: projectID, RelationshipType: models.RelationshipTypeManual,
+				SourceTableID: ordersTableID, SourceColumnID: orderCreatedBy.ID,
+				TargetTableID: usersTableID, TargetColumnID: usersPK.ID,
+			},
+		},
+	}
+
+	candidate := func(col *models.SchemaColumn) *RelationshipCandidate {
+		return &RelationshipCandidate{
+			SourceTable: "orders", SourceColumn: col.ColumnName, SourceColumnID: col.ID,
+			TargetTable: "users", TargetColumn: "id", TargetColumnID: usersPK.ID,
+		}
+	}
+	collectorStats := &RelationshipCandidateStats{
+		FKSources: 3, FKTargets: 1, PairsGenerated: 3, JoinAnalysisCalls: 3,
+		RejectedOrphans: 1, Collected: 2,
+	}
+	mockCollector := &mockRelDiscoveryCandidateCollector{
+		// orders.created_by already has a manual relationship; orders.approved_by is new
+		candidates: []*RelationshipCandidate{candidate(orderCreatedBy), candidate(orderApprovedBy)},
+		stats:      collectorStats,
+	}
+
+	mockLLMClient := &mockRelDiscoveryLLMClient{
+		calls: []string{},
+		responses: map[string]*RelationshipValidationResult{
+			"orders.approved_by->users.id": {IsValidFK: true, Confidence: 0.9, Cardinality: models.CardinalityNTo1},
+		},
+	}
+
+	svc := NewLLMRelationshipDiscoveryService(
+		mockCollector,
+		&mockRelDiscoveryValidator{llmClient: mockLLMClient, logger: zap.NewNop()},
+		&mockDatasourceServiceForRelDiscovery{},
+		&mockAdapterFactoryForRelDiscovery{},
+		mockSchemaRepo,
+		&mockColumnMetadataRepoForRelDiscovery{},
+		nil,
+		zap.NewNop(),
+	)
+
+	result, err := svc.DiscoverRelationships(context.Background(), projectID, datasourceID, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, *collectorStats, result.Candidates)
+	assert.Equal(t, 1, result.CandidatesAlreadyRelated)
+	assert.Equal(t, 1, result.CandidatesEvaluated)
+	assert.Equal(t, 1, result.RelationshipsCreated)
+	assert.Equal(t, map[string]int{
+		models.InferenceMethodFK:                    1,
+		models.RelationshipTypeManual:               1,
+		models.InferenceMethodRelationshipDiscovery: 1,
+	}, result.RelationshipsByMethod)
+}
+
+// TestRelationshipDiscoveryService_ColumnEntityOverrideDropsOtherTargets verifies that
+// a column pinned to a table by the user is never validated against another table.
+func TestRelationshipDiscoveryService_ColumnEntityOverrideDropsOtherTargets(t *testing.T) {
+	projectID := uuid.New()
+	datasourceID := uuid.New()
+	ordersTableID := uuid.New()
+	usersTableID := uuid.New()
+	accountsTableID := uuid.New()
+
+	orderOwnerID := &models.SchemaColumn{ID: uuid.New(), SchemaTableID: ordersTableID, ColumnName: "owner_id", DataType: "uuid"}
+	usersPK := &models.SchemaColumn{ID: uuid.New(), SchemaTableID: usersTableID, ColumnName: "id", DataType: "uuid", IsPrimaryKey: true}
+	accountsPK := &models.SchemaColumn{ID: uuid.New(), SchemaTableID: accountsTableID, ColumnName: "id", DataType: "uuid", IsPrimaryKey: true}
+
+	mockSchemaRepo := &mockSchemaRepoForRelDiscovery{
+		tables: []*models.SchemaTable{
+			{ID: ordersTableID, SchemaName: "public", TableName: "orders"},
+			{ID: usersTableID, SchemaName: "public", TableName: "users"},
+			{ID: accountsTableID, SchemaName: "public", TableName: "accounts"},
+		},
+		columns: []*models.SchemaColumn{orderOwnerID, usersPK, accountsPK},
+	}
+	mockCollector := &mockRelDiscoveryCandidateCollector{
+		candidates: []*RelationshipCandidate{
+			{
+				SourceTable: "orders", SourceColumn: "owner_id", SourceColumnID: orderOwnerID.ID,
+				TargetTable: "users", TargetColumn: "id", TargetColumnID: usersPK.ID,
+			},
+			{
+				SourceTable: "orders", SourceColumn: "owner_id", SourceColumnID: orderOwnerID.ID,
+				TargetTable: "accounts", TargetColumn: "id", TargetColumnID: accountsPK.ID,
+			},
+		},
+	}
+	mockLLMClient := &mockRelDiscoveryLLMClient{
+		calls: []string{},
+		responses: map[string]*RelationshipValidationResult{
+			"orders.owner_id->users.id":    {IsValidFK: true, Confidence: 0.9, Cardinality: models.CardinalityNTo1},
+			"orders.owner_id->accounts.id": {IsValidFK: true, Confidence: 0.9, Cardinality: models.CardinalityNTo1},
+		},
+	}
+	projectRepo := &mockProjectRepoForHealth{project: &models.Project{
+		ID: projectID,
+		Parameters: map[string]interface{}{
+			"ontology": map[string]interface{}{
+				"column_entity_overrides": map[string]interface{}{"Orders.Owner_ID": "public.accounts"},
+			},
+		},
+	}}
+
+	svc := NewLLMRelationshipDiscoveryService(
+		mockCollector,
+		&mockRelDiscoveryValidator{llmClient: mockLLMClient, logger: zap.NewNop()},
+		&mockDatasourceServiceForRelDiscovery{},
+		&mockAdapterFactoryForRelDiscovery{},
+		mockSchemaRepo,
+		&mockColumnMetadataRepoForRelDiscovery{},
+		projectRepo,
+		zap.NewNop(),
+	)
+
+	result, err := svc.DiscoverRelationships(context.Background(), projectID, datasourceID, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.CandidatesOverridden)
+	assert.Equal(t, 0, result.CandidatesAlreadyRelated)
+	assert.Equal(t, 1, result.CandidatesEvaluated)
+	assert.Equal(t, []string{"orders.owner_id->accounts.id"}, mockLLMClient.calls)
+	require.Len(t, mockSchemaRepo.createdRels, 1)
+	assert.Equal(t, accountsTableID, mockSchemaRepo.createdRels[0].TargetTableID)
+}
+
 // ============================================================================
 // Mock implementations for integration tests
 // ============================================================================
@@ -1276,3 +1436,54 @@ func (m *mockColumnMetadataRepoForRelDiscovery) DeleteBySchemaColumnID(_ context
 }
 
 var _ repositories.ColumnMetadataRepository = (*mockColumnMetadataRepoForRelDiscovery)(nil)
+
+// tableIndex indexes tables by qualified and bare name, as DiscoverRelationships does.
+func tableIndex(tables ...*models.SchemaTable) *datasource.IdentifierIndex[*models.SchemaTable] {
+	idx := datasource.NewIdentifierIndex[*models.SchemaTable]()
+	for _, t := range tables {
+		idx.Add(t.SchemaName+"."+t.TableName, t)
+		idx.Add(t.TableName, t)
+	}
+	return idx
+}
+
+func TestLLMRelationshipDiscoveryService_CreateSchemaRelationshipFromValidationResolvesQuotedMixedCaseTables(t *testing.T) {
+	projectID := uuid.New()
+	orderItems := &models.SchemaTable{ID: uuid.New(), ProjectID: projectID, SchemaName: "public", TableName: "OrderItems"}
+	products := &models.SchemaTable{ID: uuid.New(), ProjectID: projectID, SchemaName: "public", TableName: "Products"}
+	sourceColID := uuid.New()
+	targetColID := uuid.New()
+
+	mockSchemaRepo := &mockSchemaRepoForRelDiscovery{}
+	svc := &llmRelationshipDiscoveryService{
+		schemaRepo:         mockSchemaRepo,
+		columnMetadataRepo: &mockColumnMetadataRepoForRelDiscovery{},
+		logger:             zap.NewNop(),
+	}
+
+	validated := &ValidatedRelationship{
+		Candidate: &RelationshipCandidate{
+			// LLM output names the tables quoted and case-folded respectively
+			SourceTable:    `public."OrderItems"`,
+			SourceColumn:   "ProductId",
+			SourceColumnID: sourceColID,
+			TargetTable:    "products",
+			TargetColumn:   "Id",
+			TargetColumnID: targetColID,
+		},
+		Result: &RelationshipValidationResult{IsValidFK: true, Confidence: 0.9, Cardinality: models.CardinalityNTo1},
+	}
+
+	err := svc.createSchemaRelationshipFromValidation(context.Background(), projectID, validated,
+		tableIndex(orderItems, products),
+		map[uuid.UUID]*models.SchemaColumn{
+			sourceColID: {ID: sourceColID, SchemaTableID: orderItems.ID, ColumnName: "ProductId", DataType: "integer"},
+			targetColID: {ID: targetColID, SchemaTableID: products.ID, ColumnName: "Id", DataType: "integer", IsPrimaryKey: true},
+		},
+	)
+
+	require.NoError(t, err)
+	require.Len(t, mockSchemaRepo.createdRels, 1)
+	assert.Equal(t, orderItems.ID, mockSchemaRepo.createdRels[0].SourceTableID)
+	assert.Equal(t, products.ID, mockSchemaRepo.createdRels[0].TargetTableID)
+}
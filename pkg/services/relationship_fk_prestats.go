@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/adapters/datasource"
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+)
+
+// likelyFKNameSuffixes are column name endings that usually mark a reference to
+// another table's key.
+var likelyFKNameSuffixes = []string{"_id", "_key", "_ref"}
+
+// likelyFKColumnsWithoutStats returns the columns of selected tables that look like
+// foreign keys but have no joinability yet because their stats were never gathered:
+// uuid or string columns named *_id, *_key or *_ref whose type matches the primary
+// key of some selected table. Without stats such a column fails every
+// isQualifiedFKSource check that does not come from metadata.
+//
+// The name and type only decide which columns get stats first; whether a column
+// becomes an FK source is still decided by the gathered data.
+func likelyFKColumnsWithoutStats(columns []*models.SchemaColumn, tableByID map[uuid.UUID]*models.SchemaTable) []*models.SchemaColumn {
+	pkCategories := make(map[string]bool)
+	for _, col := range columns {
+		if col.IsPrimaryKey && tableByID[col.SchemaTableID] != nil {
+			pkCategories[categorizeDataType(strings.ToLower(strings.TrimSpace(col.DataType)))] = true
+		}
+	}
+
+	var likely []*models.SchemaColumn
+	for _, col := range columns {
+		if col.IsPrimaryKey || col.IsJoinable != nil || tableByID[col.SchemaTableID] == nil {
+			continue
+		}
+		category := categorizeDataType(strings.ToLower(strings.TrimSpace(col.DataType)))
+		if (category != "uuid" && category != "string") || !pkCategories[category] {
+			continue
+		}
+		if hasLikelyFKSuffix(col.ColumnName) {
+			likely = append(likely, col)
+		}
+	}
+	return likely
+}
+
+func hasLikelyFKSuffix(columnName string) bool {
+	name := strings.ToLower(columnName)
+	for _, suffix := range likelyFKNameSuffixes {
+		if strings.HasSuffix(name, suffix) && len(name) > len(suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// gatherLikelyFKStats gathers and stores stats and joinability for the columns
+// likelyFKColumnsWithoutStats finds, so a freshly synced schema's uuid and text
+// references are not skipped by PK-match for lack of data. Returns how many columns
+// got stats. A table whose stats query fails is logged and skipped; failing to store
+// stats stops the step.
+func (c *relationshipCandidateCollector) gatherLikelyFKStats(
+	ctx context.Context,
+	adapter datasource.SchemaDiscoverer,
+	projectID, datasourceID uuid.UUID,
+) (int, error) {
+	tables, err := c.schemaRepo.ListTablesByDatasource(ctx, projectID, datasourceID)
+	if err != nil {
+		return 0, fmt.Errorf("list tables: %w", err)
+	}
+	columns, err := c.schemaRepo.ListColumnsByDatasource(ctx, projectID, datasourceID)
+	if err != nil {
+		return 0, fmt.Errorf("list columns: %w", err)
+	}
+
+	tableByID := make(map[uuid.UUID]*models.SchemaTable, len(tables))
+	for _, t := range tables {
+		tableByID[t.ID] = t
+	}
+
+	var tableOrder []uuid.UUID
+	columnsByTableID := make(map[uuid.UUID][]*models.SchemaColumn)
+	for _, col := range likelyFKColumnsWithoutStats(columns, tableByID) {
+		if _, seen := columnsByTableID[col.SchemaTableID]; !seen {
+			tableOrder = append(tableOrder, col.SchemaTableID)
+		}
+		columnsByTableID[col.SchemaTableID] = append(columnsByTableID[col.SchemaTableID], col)
+	}
+
+	gathered := 0
+	for _, tableID := range tableOrder {
+		table := tableByID[tableID]
+		tableColumns := columnsByTableID[tableID]
+		columnNames := make([]string, 0, len(tableColumns))
+		for _, col := range tableColumns {
+			columnNames = append(columnNames, col.ColumnName)
+		}
+
+		stats, err := adapter.AnalyzeColumnStats(ctx, table.SchemaName, table.TableName, columnNames)
+		if err != nil {
+			c.logger.Warn("failed to gather stats for likely FK columns",
+				zap.String("table", table.TableName),
+				zap.Strings("columns", columnNames),
+				zap.Error(err))
+			continue
+		}
+		statsByName := make(map[string]datasource.ColumnStats, len(stats))
+		for _, stat := range stats {
+			statsByName[stat.ColumnName] = stat
+		}
+
+		for _, col := range tableColumns {
+			stat, ok := statsByName[col.ColumnName]
+			if !ok {
+				continue
+			}
+			tableRowCount := stat.RowCount
+			if tableRowCount == 0 && table.RowCount != nil {
+				tableRowCount = *table.RowCount
+			}
+			isJoinable, reason := classifyJoinability(col, &stat, tableRowCount)
+
+			distinctCount := stat.DistinctCount
+			nullCount := nullCountFromColumnStats(stat)
+			if err := c.schemaRepo.UpdateColumnStats(ctx, col.ID, &distinctCount, &nullCount, stat.MinLength, stat.MaxLength); err != nil {
+				return gathered, fmt.Errorf("update column stats for %s.%s: %w", table.TableName, col.ColumnName, err)
+			}
+			rowCount := stat.RowCount
+			nonNullCount := stat.NonNullCount
+			if err := c.schemaRepo.UpdateColumnJoinability(ctx, col.ID, &rowCount, &nonNullCount, &distinctCount, &isJoinable, &reason); err != nil {
+				return gathered, fmt.Errorf("update column joinability for %s.%s: %w", table.TableName, col.ColumnName, err)
+			}
+			gathered++
+		}
+	}
+
+	if gathered > 0 {
+		c.logger.Info("gathered stats for likely FK columns before PK-match",
+			zap.Int("count", gathered),
+			zap.String("project_id", projectID.String()),
+			zap.String("datasource_id", datasourceID.String()))
+	}
+	return gathered, nil
+}
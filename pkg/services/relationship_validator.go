@@ -10,6 +10,7 @@ import (
 
 	"github.com/ekaya-inc/ekaya-engine/pkg/adapters/datasource"
 	"github.com/ekaya-inc/ekaya-engine/pkg/llm"
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
 	"github.com/ekaya-inc/ekaya-engine/pkg/repositories"
 	"github.com/ekaya-inc/ekaya-engine/pkg/services/dag"
 )
@@ -99,7 +100,10 @@ func (v *relationshipValidator) ValidateCandidate(ctx context.Context, projectID
 	// Override LLM cardinality with deterministic computation.
 	// The LLM frequently gets cardinality wrong because it's a data property,
 	// not a semantic judgment. Schema constraints and join stats are authoritative.
-	if validationResult.IsValidFK {
+	// An array column links each row to many targets, and each target to many rows.
+	if validationResult.IsValidFK && candidate.SourceIsArray {
+		validationResult.Cardinality = models.CardinalityNToM
+	} else if validationResult.IsValidFK {
 		validationResult.Cardinality = InferCardinality(candidate.SourceIsPK, candidate.SourceIsUnique,
 			&datasource.JoinAnalysis{
 				SourceMatched: candidate.SourceMatched,
@@ -156,6 +160,9 @@ func (v *relationshipValidator) buildValidationPrompt(candidate *RelationshipCan
 	sb.WriteString(fmt.Sprintf("**Table:** %s\n", candidate.SourceTable))
 	sb.WriteString(fmt.Sprintf("**Column:** %s\n", candidate.SourceColumn))
 	sb.WriteString(fmt.Sprintf("**Data Type:** %s\n", candidate.SourceDataType))
+	if candidate.SourceIsArray {
+		sb.WriteString("**Array Column:** each row holds a list of values; the join analysis below compares the individual elements\n")
+	}
 	sb.WriteString(fmt.Sprintf("**Is Primary Key:** %v\n", candidate.SourceIsPK))
 	sb.WriteString(fmt.Sprintf("**Distinct Values:** %d\n", candidate.SourceDistinctCount))
 	sb.WriteString(fmt.Sprintf("**Null Rate:** %.1f%%\n", candidate.SourceNullRate*100))
@@ -243,6 +243,10 @@ func (m *mockValidatorLLMClient) GetEndpoint() string {
 	return "https://test.endpoint"
 }
 
+func (m *mockValidatorLLMClient) GenerateResponseStream(ctx context.Context, prompt string, systemMessage string, temperature float64) (*llm.ResponseStream, error) {
+	return llm.CompletedResponseStream(m.GenerateResponse(ctx, prompt, systemMessage, temperature, false))
+}
+
 var _ llm.LLMClient = (*mockValidatorLLMClient)(nil)
 
 // mockValidatorLLMClientFactory creates mockValidatorLLMClient instances
@@ -842,6 +846,10 @@ func (m *mockParallelLLMClient) GetEndpoint() string {
 	return "https://test.endpoint"
 }
 
+func (m *mockParallelLLMClient) GenerateResponseStream(ctx context.Context, prompt string, systemMessage string, temperature float64) (*llm.ResponseStream, error) {
+	return llm.CompletedResponseStream(m.GenerateResponse(ctx, prompt, systemMessage, temperature, false))
+}
+
 var _ llm.LLMClient = (*mockParallelLLMClient)(nil)
 
 type mockParallelLLMClientFactory struct {
@@ -1394,6 +1402,41 @@ func TestValidateCandidate_LLMNMOverriddenToN1(t *testing.T) {
 	assert.Equal(t, "N:1", result.Cardinality, "LLM N:M should be overridden to N:1 for non-unique source")
 }
 
+func TestValidateCandidate_ArraySourceIsManyToMany(t *testing.T) {
+	mockClient := &mockValidatorLLMClient{
+		responseContent: `{"is_valid_fk": true, "confidence": 0.9, "cardinality": "N:1", "reasoning": "Tag references"}`,
+	}
+
+	validator := NewRelationshipValidator(
+		&mockValidatorLLMClientFactory{client: mockClient},
+		nil,
+		nil,
+		&mockRelValConversationRepo{},
+		nil,
+		zap.NewNop(),
+	)
+
+	candidate := &RelationshipCandidate{
+		SourceTable:    "posts",
+		SourceColumn:   "tag_ids",
+		SourceDataType: "int8[]",
+		SourceIsArray:  true,
+		SourceMatched:  12,
+		TargetTable:    "tags",
+		TargetColumn:   "id",
+		TargetDataType: "int8",
+		TargetIsPK:     true,
+		TargetMatched:  12,
+	}
+
+	result, err := validator.ValidateCandidate(context.Background(), uuid.New(), candidate)
+
+	require.NoError(t, err)
+	assert.True(t, result.IsValidFK)
+	assert.Equal(t, models.CardinalityNToM, result.Cardinality)
+	assert.Contains(t, validator.(*relationshipValidator).buildValidationPrompt(candidate), "**Array Column:**")
+}
+
 func TestValidateCandidate_InvalidFKCardinalityNotOverridden(t *testing.T) {
 	// When LLM says is_valid_fk=false, cardinality should NOT be overridden
 	mockClient := &mockValidatorLLMClient{
@@ -2,8 +2,10 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/google/uuid"
@@ -14,6 +16,7 @@ import (
 	"github.com/ekaya-inc/ekaya-engine/pkg/auth"
 	"github.com/ekaya-inc/ekaya-engine/pkg/models"
 	"github.com/ekaya-inc/ekaya-engine/pkg/repositories"
+	"github.com/ekaya-inc/ekaya-engine/pkg/tracing"
 )
 
 // SchemaService orchestrates schema operations between adapters and repositories.
@@ -23,6 +26,15 @@ type SchemaService interface {
 	// If autoSelect is true, newly created tables and columns will have IsSelected set to true.
 	RefreshDatasourceSchema(ctx context.Context, projectID, datasourceID uuid.UUID, autoSelect bool) (*models.RefreshResult, error)
 
+	// InventoryDatasourceSchema records every table with its row count without reading
+	// columns, statistics or foreign keys, so a very large database can be scoped before
+	// deep introspection. New tables are left unselected.
+	InventoryDatasourceSchema(ctx context.Context, projectID, datasourceID uuid.UUID) (*models.SchemaInventoryResult, error)
+
+	// IntrospectTables syncs columns and FK relationships for the tables picked by req only,
+	// and marks those tables selected. Tables must already be known from an inventory or refresh.
+	IntrospectTables(ctx context.Context, projectID, datasourceID uuid.UUID, req *models.IntrospectTablesRequest) (*models.RefreshResult, error)
+
 	// GetDatasourceSchema returns the complete schema for a datasource.
 	GetDatasourceSchema(ctx context.Context, projectID, datasourceID uuid.UUID) (*models.DatasourceSchema, error)
 
@@ -32,6 +44,11 @@ type SchemaService interface {
 	// AddManualRelationship creates a user-defined relationship between two columns.
 	AddManualRelationship(ctx context.Context, projectID, datasourceID uuid.UUID, req *models.AddRelationshipRequest) (*models.SchemaRelationship, error)
 
+	// AddManualRelationshipByColumns creates a user-defined relationship between two columns picked by ID.
+	// When req.AnalyzeJoin is set, the join is analyzed against the datasource and the result is stored
+	// as the relationship's validation results so callers can warn about orphaned values.
+	AddManualRelationshipByColumns(ctx context.Context, projectID uuid.UUID, req *models.AddColumnRelationshipRequest) (*models.SchemaRelationship, error)
+
 	// UpdateRelationship curates an existing relationship in place without changing its semantic type.
 	UpdateRelationship(ctx context.Context, projectID, relationshipID uuid.UUID, req *models.UpdateRelationshipRequest) (*models.SchemaRelationship, error)
 
@@ -45,6 +62,14 @@ type SchemaService interface {
 	// GetRelationshipsResponse returns enriched relationships with table/column details and empty/orphan tables.
 	GetRelationshipsResponse(ctx context.Context, projectID, datasourceID uuid.UUID) (*models.RelationshipsResponse, error)
 
+	// GetRelationshipStats returns relationship counts for the datasource by inference
+	// method, confidence band and approval status, plus the number of orphan tables.
+	GetRelationshipStats(ctx context.Context, projectID, datasourceID uuid.UUID) (*models.RelationshipStats, error)
+
+	// GetRelationshipMetrics returns the stored discovery metrics for a relationship.
+	// Returns apperrors.ErrNotFound if the relationship does not exist in the project.
+	GetRelationshipMetrics(ctx context.Context, projectID, relationshipID uuid.UUID) (*models.RelationshipMetrics, error)
+
 	// UpdateColumnMetadata updates business_name and/or description for a column.
 	UpdateColumnMetadata(ctx context.Context, projectID, columnID uuid.UUID, businessName, description *string) error
 
@@ -129,7 +154,10 @@ func shouldAutoSelect(tableName string) bool {
 
 // RefreshDatasourceSchema syncs the schema from the datasource into our repository.
 // If autoSelect is true, newly created tables and columns will have IsSelected set to true.
-func (s *schemaService) RefreshDatasourceSchema(ctx context.Context, projectID, datasourceID uuid.UUID, autoSelect bool) (*models.RefreshResult, error) {
+func (s *schemaService) RefreshDatasourceSchema(ctx context.Context, projectID, datasourceID uuid.UUID, autoSelect bool) (_ *models.RefreshResult, err error) {
+	ctx, span := tracing.Start(ctx, "schema.refresh", tracing.AttrProjectID.String(projectID.String()))
+	defer func() { tracing.End(span, err) }()
+
 	// Extract userID from context (JWT claims)
 	userID, err := auth.RequireUserIDFromContext(ctx)
 	if err != nil {
@@ -250,7 +278,7 @@ func (s *schemaService) RefreshDatasourceSchema(ctx context.Context, projectID,
 
 	// Sync foreign key relationships if supported
 	if discoverer.SupportsForeignKeys() {
-		relationshipsCreated, err := s.syncForeignKeys(ctx, discoverer, projectID, datasourceID)
+		relationshipsCreated, err := s.syncForeignKeys(ctx, discoverer, projectID, datasourceID, datasource.IdentifierQuoter(ds.DatasourceType))
 		if err != nil {
 			return nil, fmt.Errorf("failed to sync foreign keys: %w", err)
 		}
@@ -278,6 +306,312 @@ func (s *schemaService) RefreshDatasourceSchema(ctx context.Context, projectID,
 	return result, nil
 }
 
+// openSchemaDiscoverer creates a schema discoverer for the datasource using the caller's
+// identity for connection pooling. The caller must close it.
+func (s *schemaService) openSchemaDiscoverer(ctx context.Context, projectID, datasourceID uuid.UUID) (datasource.SchemaDiscoverer, *models.Datasource, error) {
+	userID, err := auth.RequireUserIDFromContext(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("user ID not found in context: %w", err)
+	}
+
+	ds, err := s.datasourceSvc.Get(ctx, projectID, datasourceID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get datasource: %w", err)
+	}
+
+	discoverer, err := s.adapterFactory.NewSchemaDiscoverer(ctx, ds.DatasourceType, ds.Config, projectID, datasourceID, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create schema discoverer: %w", err)
+	}
+	return discoverer, ds, nil
+}
+
+// InventoryDatasourceSchema runs the cheap first phase of discovery for very large
+// databases. Only the table list and row counts are read from the datasource; existing
+// selections are kept and new tables stay unselected until the user scopes them.
+func (s *schemaService) InventoryDatasourceSchema(ctx context.Context, projectID, datasourceID uuid.UUID) (_ *models.SchemaInventoryResult, err error) {
+	ctx, span := tracing.Start(ctx, "schema.inventory", tracing.AttrProjectID.String(projectID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	existingTables, err := s.schemaRepo.ListAllTablesByDatasource(ctx, projectID, datasourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list existing tables: %w", err)
+	}
+	existingTableNames := make(map[string]bool)
+	for _, t := range existingTables {
+		existingTableNames[t.SchemaName+"."+t.TableName] = true
+	}
+
+	discoverer, _, err := s.openSchemaDiscoverer(ctx, projectID, datasourceID)
+	if err != nil {
+		return nil, err
+	}
+	defer discoverer.Close()
+
+	discoveredTables, err := discoverer.DiscoverTables(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover tables: %w", err)
+	}
+
+	result := &models.SchemaInventoryResult{
+		Tables:            make([]models.SchemaInventoryTable, 0, len(discoveredTables)),
+		NewTableNames:     make([]string, 0),
+		RemovedTableNames: make([]string, 0),
+	}
+	activeTableKeys := make([]repositories.TableKey, len(discoveredTables))
+	discoveredTableNames := make(map[string]bool)
+
+	for i, dt := range discoveredTables {
+		tableFQN := dt.SchemaName + "." + dt.TableName
+		activeTableKeys[i] = repositories.TableKey{
+			SchemaName: dt.SchemaName,
+			TableName:  dt.TableName,
+		}
+		discoveredTableNames[tableFQN] = true
+		if !existingTableNames[tableFQN] {
+			result.NewTableNames = append(result.NewTableNames, tableFQN)
+		}
+
+		rowCount := dt.RowCount
+		table := &models.SchemaTable{
+			ProjectID:    projectID,
+			DatasourceID: datasourceID,
+			SchemaName:   dt.SchemaName,
+			TableName:    dt.TableName,
+			RowCount:     &rowCount,
+		}
+		if err := s.schemaRepo.UpsertTable(ctx, table); err != nil {
+			return nil, fmt.Errorf("failed to upsert table %s.%s: %w", dt.SchemaName, dt.TableName, err)
+		}
+		result.TablesUpserted++
+
+		result.Tables = append(result.Tables, models.SchemaInventoryTable{
+			TableID:    table.ID,
+			SchemaName: dt.SchemaName,
+			TableName:  dt.TableName,
+			RowCount:   rowCount,
+			IsSelected: table.IsSelected,
+		})
+	}
+
+	for tableFQN := range existingTableNames {
+		if !discoveredTableNames[tableFQN] {
+			result.RemovedTableNames = append(result.RemovedTableNames, tableFQN)
+		}
+	}
+
+	tablesDeleted, err := s.schemaRepo.SoftDeleteRemovedTables(ctx, projectID, datasourceID, activeTableKeys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to soft-delete removed tables: %w", err)
+	}
+	result.TablesDeleted = tablesDeleted
+
+	// Largest tables first: that is the order users scope a huge schema in.
+	sort.SliceStable(result.Tables, func(i, j int) bool {
+		if result.Tables[i].RowCount != result.Tables[j].RowCount {
+			return result.Tables[i].RowCount > result.Tables[j].RowCount
+		}
+		return result.Tables[i].SchemaName+"."+result.Tables[i].TableName <
+			result.Tables[j].SchemaName+"."+result.Tables[j].TableName
+	})
+
+	s.logger.Info("Schema inventory completed",
+		zap.String("project_id", projectID.String()),
+		zap.String("datasource_id", datasourceID.String()),
+		zap.Int("tables_upserted", result.TablesUpserted),
+		zap.Int64("tables_deleted", result.TablesDeleted),
+	)
+
+	return result, nil
+}
+
+// IntrospectTables runs the second phase of discovery on the tables picked by req.
+func (s *schemaService) IntrospectTables(ctx context.Context, projectID, datasourceID uuid.UUID, req *models.IntrospectTablesRequest) (_ *models.RefreshResult, err error) {
+	ctx, span := tracing.Start(ctx, "schema.introspect", tracing.AttrProjectID.String(projectID.String()))
+	defer func() { tracing.End(span, err) }()
+
+	if req == nil {
+		req = &models.IntrospectTablesRequest{}
+	}
+	if !models.IsValidIntrospectionRank(req.RankBy) {
+		return nil, fmt.Errorf("unknown introspection rank %q", req.RankBy)
+	}
+	if req.TopN < 0 {
+		return nil, fmt.Errorf("top_n must not be negative")
+	}
+
+	tables, err := s.schemaRepo.ListAllTablesByDatasource(ctx, projectID, datasourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+
+	discoverer, ds, err := s.openSchemaDiscoverer(ctx, projectID, datasourceID)
+	if err != nil {
+		return nil, err
+	}
+	defer discoverer.Close()
+
+	// Foreign keys are needed both to rank by reference count and to sync relationships.
+	var fks []datasource.ForeignKeyMetadata
+	if discoverer.SupportsForeignKeys() {
+		fks, err = discoverer.DiscoverForeignKeys(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to discover foreign keys: %w", err)
+		}
+	}
+
+	scope, err := selectIntrospectionScope(tables, fks, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.RefreshResult{
+		NewTableNames:          make([]string, 0),
+		RemovedTableNames:      make([]string, 0),
+		NewColumns:             make([]models.RefreshColumnChange, 0),
+		RemovedColumns:         make([]models.RefreshColumnChange, 0),
+		ModifiedColumns:        make([]models.RefreshColumnModification, 0),
+		IntrospectedTableNames: make([]string, 0, len(scope)),
+	}
+	inScope := make(map[string]bool, len(scope))
+
+	for _, table := range scope {
+		tableFQN := table.SchemaName + "." + table.TableName
+		inScope[tableFQN] = true
+
+		if !table.IsSelected {
+			if err := s.schemaRepo.UpdateTableSelection(ctx, projectID, table.ID, true); err != nil {
+				return nil, fmt.Errorf("failed to select table %s: %w", tableFQN, err)
+			}
+			table.IsSelected = true
+			result.AutoSelectApplied = true
+		}
+
+		colResult, err := s.syncColumnsForTable(ctx, discoverer, projectID, table, true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sync columns for table %s: %w", tableFQN, err)
+		}
+		result.ColumnsUpserted += colResult.ColumnsUpserted
+		result.ColumnsDeleted += colResult.ColumnsDeleted
+		result.NewColumns = append(result.NewColumns, colResult.NewColumns...)
+		result.RemovedColumns = append(result.RemovedColumns, colResult.RemovedColumns...)
+		result.ModifiedColumns = append(result.ModifiedColumns, colResult.ModifiedColumns...)
+		result.IntrospectedTableNames = append(result.IntrospectedTableNames, tableFQN)
+	}
+
+	// Only constraints touching the scope; the rest are synced when their tables are introspected.
+	var scopedFKs []datasource.ForeignKeyMetadata
+	for _, fk := range fks {
+		if inScope[fk.SourceSchema+"."+fk.SourceTable] || inScope[fk.TargetSchema+"."+fk.TargetTable] {
+			scopedFKs = append(scopedFKs, fk)
+		}
+	}
+	relationshipsCreated, err := s.upsertForeignKeys(ctx, projectID, datasourceID, scopedFKs, datasource.IdentifierQuoter(ds.DatasourceType))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sync foreign keys: %w", err)
+	}
+	result.RelationshipsCreated = relationshipsCreated
+
+	relationshipsDeleted, err := s.schemaRepo.SoftDeleteOrphanedRelationships(ctx, projectID, datasourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to soft-delete orphaned relationships: %w", err)
+	}
+	result.RelationshipsDeleted = relationshipsDeleted
+
+	s.logger.Info("Scoped schema introspection completed",
+		zap.String("project_id", projectID.String()),
+		zap.String("datasource_id", datasourceID.String()),
+		zap.Int("tables_introspected", len(result.IntrospectedTableNames)),
+		zap.Int("columns_upserted", result.ColumnsUpserted),
+		zap.Int("relationships_created", result.RelationshipsCreated),
+	)
+
+	return result, nil
+}
+
+// selectIntrospectionScope resolves req against the known tables. Named tables come first,
+// followed by the top N remaining tables by row count or by incoming FK references. With
+// neither, the currently selected tables are used.
+func selectIntrospectionScope(tables []*models.SchemaTable, fks []datasource.ForeignKeyMetadata, req *models.IntrospectTablesRequest) ([]*models.SchemaTable, error) {
+	byName := make(map[string]*models.SchemaTable, len(tables))
+	for _, t := range tables {
+		byName[t.SchemaName+"."+t.TableName] = t
+	}
+
+	if len(req.TableNames) == 0 && req.TopN == 0 {
+		var selected []*models.SchemaTable
+		for _, t := range tables {
+			if t.IsSelected {
+				selected = append(selected, t)
+			}
+		}
+		if len(selected) == 0 {
+			return nil, apperrors.ErrNoSelectedTables
+		}
+		return selected, nil
+	}
+
+	var scope []*models.SchemaTable
+	picked := make(map[string]bool)
+	for _, name := range req.TableNames {
+		t, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("table %q: %w", name, apperrors.ErrNotFound)
+		}
+		if !picked[name] {
+			picked[name] = true
+			scope = append(scope, t)
+		}
+	}
+
+	if req.TopN > 0 {
+		// Count each constraint once, however many columns it spans.
+		references := make(map[string]int)
+		seenConstraints := make(map[string]bool)
+		for _, fk := range fks {
+			key := foreignKeyConstraintKey(fk)
+			if seenConstraints[key] {
+				continue
+			}
+			seenConstraints[key] = true
+			references[fk.TargetSchema+"."+fk.TargetTable]++
+		}
+
+		ranked := make([]*models.SchemaTable, 0, len(tables))
+		for _, t := range tables {
+			if !picked[t.SchemaName+"."+t.TableName] {
+				ranked = append(ranked, t)
+			}
+		}
+		rowCount := func(t *models.SchemaTable) int64 {
+			if t.RowCount == nil {
+				return 0
+			}
+			return *t.RowCount
+		}
+		sort.SliceStable(ranked, func(i, j int) bool {
+			a, b := ranked[i], ranked[j]
+			aName, bName := a.SchemaName+"."+a.TableName, b.SchemaName+"."+b.TableName
+			if req.RankBy == models.IntrospectionRankReferenceCount && references[aName] != references[bName] {
+				return references[aName] > references[bName]
+			}
+			if rowCount(a) != rowCount(b) {
+				return rowCount(a) > rowCount(b)
+			}
+			return aName < bName
+		})
+		if len(ranked) > req.TopN {
+			ranked = ranked[:req.TopN]
+		}
+		scope = append(scope, ranked...)
+	}
+
+	if len(scope) == 0 {
+		return nil, apperrors.ErrNoSelectedTables
+	}
+	return scope, nil
+}
+
 // columnSyncResult holds detailed results from syncing columns for a table.
 type columnSyncResult struct {
 	ColumnsUpserted int
@@ -334,17 +668,18 @@ func (s *schemaService) syncColumnsForTable(
 		isNewColumn := !found
 
 		column := &models.SchemaColumn{
-			ProjectID:       projectID,
-			SchemaTableID:   table.ID,
-			ColumnName:      dc.ColumnName,
-			DataType:        dc.DataType,
-			IsNullable:      dc.IsNullable,
-			IsPrimaryKey:    dc.IsPrimaryKey,
-			IsUnique:        dc.IsUnique,
-			OrdinalPosition: dc.OrdinalPosition,
-			DefaultValue:    dc.DefaultValue,
-			EnumValues:      dc.EnumValues,
-			IsSelected:      isNewColumn && autoSelect, // Auto-select new columns if requested
+			ProjectID:        projectID,
+			SchemaTableID:    table.ID,
+			ColumnName:       dc.ColumnName,
+			DataType:         dc.DataType,
+			IsNullable:       dc.IsNullable,
+			IsPrimaryKey:     dc.IsPrimaryKey,
+			IsUnique:         dc.IsUnique,
+			OrdinalPosition:  dc.OrdinalPosition,
+			DefaultValue:     dc.DefaultValue,
+			EnumValues:       dc.EnumValues,
+			CheckConstraints: dc.CheckConstraints,
+			IsSelected:       isNewColumn && autoSelect, // Auto-select new columns if requested
 		}
 
 		if isNewColumn {
@@ -391,17 +726,31 @@ func (s *schemaService) syncColumnsForTable(
 	return result, nil
 }
 
-// syncForeignKeys discovers and syncs foreign key relationships.
+// syncForeignKeys discovers and syncs foreign key relationships. quote quotes
+// identifiers in the datasource's dialect for the stored join clauses.
 func (s *schemaService) syncForeignKeys(
 	ctx context.Context,
 	discoverer datasource.SchemaDiscoverer,
 	projectID, datasourceID uuid.UUID,
+	quote func(string) string,
 ) (int, error) {
 	fks, err := discoverer.DiscoverForeignKeys(ctx)
 	if err != nil {
 		return 0, fmt.Errorf("discover foreign keys: %w", err)
 	}
+	return s.upsertForeignKeys(ctx, projectID, datasourceID, fks, quote)
+}
 
+// upsertForeignKeys stores discovered FK constraints as relationships. Constraints whose
+// tables or columns are not in the repository yet are skipped. quote quotes identifiers
+// in the datasource's dialect for the stored join clauses.
+func (s *schemaService) upsertForeignKeys(
+	ctx context.Context,
+	projectID, datasourceID uuid.UUID,
+	fks []datasource.ForeignKeyMetadata,
+	quote func(string) string,
+) (int, error) {
+	compositeClauses := compositeForeignKeyJoinClauses(fks, quote)
 	relationshipsCreated := 0
 
 	for _, fk := range fks {
@@ -461,6 +810,11 @@ func (s *schemaService) syncForeignKeys(
 			Confidence:       1.0,                    // FK constraints have 100% confidence
 			InferenceMethod:  &inferenceMethod,
 		}
+		// Each column of a composite FK is stored as its own relationship; all of them
+		// carry the full multi-column clause. Single-column clauses come from the repository.
+		if clause, ok := compositeClauses[foreignKeyConstraintKey(fk)]; ok {
+			rel.JoinClause = &clause
+		}
 
 		if err := s.schemaRepo.UpsertRelationship(ctx, rel); err != nil {
 			return relationshipsCreated, fmt.Errorf("upsert relationship for FK %s: %w", fk.ConstraintName, err)
@@ -471,6 +825,36 @@ func (s *schemaService) syncForeignKeys(
 	return relationshipsCreated, nil
 }
 
+// compositeForeignKeyJoinClauses builds the ON clause of every FK constraint that
+// spans more than one column, keyed by foreignKeyConstraintKey. Columns keep the
+// order the datasource reported them in.
+func compositeForeignKeyJoinClauses(fks []datasource.ForeignKeyMetadata, quote func(string) string) map[string]string {
+	pairsByConstraint := make(map[string][]models.JoinColumnPair)
+	for _, fk := range fks {
+		key := foreignKeyConstraintKey(fk)
+		pairsByConstraint[key] = append(pairsByConstraint[key], models.JoinColumnPair{
+			SourceColumn: fk.SourceColumn,
+			TargetColumn: fk.TargetColumn,
+		})
+	}
+
+	clauses := make(map[string]string)
+	for _, fk := range fks {
+		key := foreignKeyConstraintKey(fk)
+		if _, done := clauses[key]; done || len(pairsByConstraint[key]) < 2 {
+			continue
+		}
+		clauses[key] = models.BuildJoinClause(quote, fk.SourceSchema, fk.SourceTable, fk.TargetSchema, fk.TargetTable, pairsByConstraint[key])
+	}
+	return clauses
+}
+
+// foreignKeyConstraintKey identifies an FK constraint; constraint names are only
+// unique per table.
+func foreignKeyConstraintKey(fk datasource.ForeignKeyMetadata) string {
+	return fk.SourceSchema + "." + fk.SourceTable + "." + fk.ConstraintName
+}
+
 // GetDatasourceSchema returns the complete schema for a datasource.
 func (s *schemaService) GetDatasourceSchema(ctx context.Context, projectID, datasourceID uuid.UUID) (*models.DatasourceSchema, error) {
 	// Get all tables (including non-selected for UI display)
@@ -696,6 +1080,69 @@ func (s *schemaService) AddManualRelationship(ctx context.Context, projectID, da
 		return nil, fmt.Errorf("target column not found: %w", err)
 	}
 
+	return s.createManualRelationship(ctx, projectID, datasourceID, sourceTable, sourceColumn, targetTable, targetColumn, req.Cardinality, false)
+}
+
+// ErrCrossDatasourceRelationship is returned when a manual relationship would join columns
+// from different datasources, which no single query can do.
+var ErrCrossDatasourceRelationship = errors.New("source and target columns belong to different datasources")
+
+// AddManualRelationshipByColumns creates a user-defined relationship between two columns picked by ID.
+func (s *schemaService) AddManualRelationshipByColumns(ctx context.Context, projectID uuid.UUID, req *models.AddColumnRelationshipRequest) (*models.SchemaRelationship, error) {
+	if req.SourceColumnID == uuid.Nil || req.TargetColumnID == uuid.Nil {
+		return nil, fmt.Errorf("source and target columns are required")
+	}
+	if req.SourceColumnID == req.TargetColumnID {
+		return nil, fmt.Errorf("source and target columns must differ")
+	}
+	if req.Cardinality != "" && !models.IsValidCardinality(req.Cardinality) {
+		return nil, fmt.Errorf("invalid cardinality: %s", req.Cardinality)
+	}
+
+	sourceTable, sourceColumn, err := s.getColumnWithTable(ctx, projectID, req.SourceColumnID)
+	if err != nil {
+		return nil, fmt.Errorf("source column not found: %w", err)
+	}
+	targetTable, targetColumn, err := s.getColumnWithTable(ctx, projectID, req.TargetColumnID)
+	if err != nil {
+		return nil, fmt.Errorf("target column not found: %w", err)
+	}
+	if sourceTable.DatasourceID != targetTable.DatasourceID {
+		return nil, ErrCrossDatasourceRelationship
+	}
+
+	return s.createManualRelationship(ctx, projectID, sourceTable.DatasourceID, sourceTable, sourceColumn, targetTable, targetColumn, req.Cardinality, req.AnalyzeJoin)
+}
+
+// getColumnWithTable loads a column and the table it belongs to.
+// Returns apperrors.ErrNotFound if either does not exist in the project.
+func (s *schemaService) getColumnWithTable(ctx context.Context, projectID, columnID uuid.UUID) (*models.SchemaTable, *models.SchemaColumn, error) {
+	column, err := s.schemaRepo.GetColumnByID(ctx, projectID, columnID)
+	if err != nil && !errors.Is(err, apperrors.ErrNotFound) {
+		return nil, nil, fmt.Errorf("failed to get column: %w", err)
+	}
+	if column == nil {
+		return nil, nil, apperrors.ErrNotFound
+	}
+	table, err := s.schemaRepo.GetTableByID(ctx, projectID, column.SchemaTableID)
+	if err != nil && !errors.Is(err, apperrors.ErrNotFound) {
+		return nil, nil, fmt.Errorf("failed to get table: %w", err)
+	}
+	if table == nil {
+		return nil, nil, apperrors.ErrNotFound
+	}
+	return table, column, nil
+}
+
+// createManualRelationship stores an approved, full-confidence manual relationship between two
+// validated columns. Re-discovery never overwrites manual relationships.
+func (s *schemaService) createManualRelationship(
+	ctx context.Context,
+	projectID, datasourceID uuid.UUID,
+	sourceTable *models.SchemaTable, sourceColumn *models.SchemaColumn,
+	targetTable *models.SchemaTable, targetColumn *models.SchemaColumn,
+	cardinality string, analyzeJoin bool,
+) (*models.SchemaRelationship, error) {
 	// Check if relationship already exists
 	existing, err := s.schemaRepo.GetRelationshipByColumns(ctx, sourceColumn.ID, targetColumn.ID)
 	if err != nil {
@@ -708,9 +1155,8 @@ func (s *schemaService) AddManualRelationship(ctx context.Context, projectID, da
 	// Create relationship
 	isApproved := true
 	manualType := models.RelationshipTypeManual
-	cardinality := models.CardinalityUnknown
-	if req.Cardinality != "" {
-		cardinality = req.Cardinality
+	if cardinality == "" {
+		cardinality = models.CardinalityUnknown
 	}
 	rel := &models.SchemaRelationship{
 		ProjectID:        projectID,
@@ -725,6 +1171,19 @@ func (s *schemaService) AddManualRelationship(ctx context.Context, projectID, da
 		IsApproved:       &isApproved,
 	}
 
+	if analyzeJoin {
+		// The analysis only informs the user; a failure must not block storing the relationship
+		results, err := s.analyzeManualJoin(ctx, projectID, datasourceID, sourceTable, sourceColumn, targetTable, targetColumn)
+		if err != nil {
+			s.logger.Warn("Failed to analyze manual relationship join",
+				zap.String("project_id", projectID.String()),
+				zap.Error(err))
+		} else {
+			rel.ValidationResults = results
+			rel.IsValidated = true
+		}
+	}
+
 	if err := s.schemaRepo.UpsertRelationship(ctx, rel); err != nil {
 		return nil, fmt.Errorf("failed to create relationship: %w", err)
 	}
@@ -732,13 +1191,54 @@ func (s *schemaService) AddManualRelationship(ctx context.Context, projectID, da
 	s.logger.Info("Created manual relationship",
 		zap.String("project_id", projectID.String()),
 		zap.String("relationship_id", rel.ID.String()),
-		zap.String("source", req.SourceTableName+"."+req.SourceColumnName),
-		zap.String("target", req.TargetTableName+"."+req.TargetColumnName),
+		zap.String("source", sourceTable.TableName+"."+sourceColumn.ColumnName),
+		zap.String("target", targetTable.TableName+"."+targetColumn.ColumnName),
 	)
 
 	return rel, nil
 }
 
+// analyzeManualJoin runs AnalyzeJoin against the datasource and summarizes how well the
+// source values match the target.
+func (s *schemaService) analyzeManualJoin(
+	ctx context.Context,
+	projectID, datasourceID uuid.UUID,
+	sourceTable *models.SchemaTable, sourceColumn *models.SchemaColumn,
+	targetTable *models.SchemaTable, targetColumn *models.SchemaColumn,
+) (*models.ValidationResults, error) {
+	userID, err := auth.RequireUserIDFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("user ID not found in context: %w", err)
+	}
+	ds, err := s.datasourceSvc.Get(ctx, projectID, datasourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get datasource: %w", err)
+	}
+	discoverer, err := s.adapterFactory.NewSchemaDiscoverer(ctx, ds.DatasourceType, ds.Config, projectID, datasourceID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create schema discoverer: %w", err)
+	}
+	defer discoverer.Close()
+
+	join, err := discoverer.AnalyzeJoin(ctx,
+		sourceTable.SchemaName, sourceTable.TableName, sourceColumn.ColumnName,
+		targetTable.SchemaName, targetTable.TableName, targetColumn.ColumnName)
+	if err != nil {
+		return nil, fmt.Errorf("analyze join: %w", err)
+	}
+
+	results := &models.ValidationResults{
+		JoinedRowCount: join.JoinCount,
+		OrphanCount:    join.OrphanCount,
+		SourceDistinct: join.SourceMatched + join.OrphanCount,
+	}
+	if results.SourceDistinct > 0 {
+		results.MatchRate = float64(join.SourceMatched) / float64(results.SourceDistinct)
+		results.OrphanRate = float64(join.OrphanCount) / float64(results.SourceDistinct)
+	}
+	return results, nil
+}
+
 // UpdateRelationship curates an existing relationship without changing its relationship_type.
 func (s *schemaService) UpdateRelationship(ctx context.Context, projectID, relationshipID uuid.UUID, req *models.UpdateRelationshipRequest) (*models.SchemaRelationship, error) {
 	if req == nil {
@@ -805,6 +1305,31 @@ func (s *schemaService) RemoveRelationship(ctx context.Context, projectID, relat
 	return nil
 }
 
+// GetRelationshipMetrics returns the stored discovery metrics for a relationship.
+func (s *schemaService) GetRelationshipMetrics(ctx context.Context, projectID, relationshipID uuid.UUID) (*models.RelationshipMetrics, error) {
+	rel, err := s.schemaRepo.GetRelationshipByID(ctx, projectID, relationshipID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get relationship: %w", err)
+	}
+	if rel == nil || rel.ProjectID != projectID {
+		return nil, apperrors.ErrNotFound
+	}
+
+	return models.NewRelationshipMetrics(rel), nil
+}
+
+// GetRelationshipStats returns relationship counts for the datasource.
+func (s *schemaService) GetRelationshipStats(ctx context.Context, projectID, datasourceID uuid.UUID) (*models.RelationshipStats, error) {
+	stats, err := s.schemaRepo.GetRelationshipStats(ctx, projectID, datasourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get relationship stats: %w", err)
+	}
+	return stats, nil
+}
+
 // GetRelationshipsForDatasource returns all relationships for a datasource.
 func (s *schemaService) GetRelationshipsForDatasource(ctx context.Context, projectID, datasourceID uuid.UUID) ([]*models.SchemaRelationship, error) {
 	relationships, err := s.schemaRepo.ListRelationshipsByDatasource(ctx, projectID, datasourceID)
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+)
+
+// schemaFingerprint hashes the shape of the selected schema: every selected column of
+// the given tables with its data type, primary key and nullability. Stats and metadata
+// are left out, so the fingerprint changes only when a schema sync changes the schema.
+func schemaFingerprint(tables []*models.SchemaTable, columns []*models.SchemaColumn) string {
+	tableByID := make(map[uuid.UUID]*models.SchemaTable, len(tables))
+	for _, t := range tables {
+		tableByID[t.ID] = t
+	}
+
+	lines := make([]string, 0, len(columns)+len(tables))
+	for _, t := range tables {
+		lines = append(lines, t.SchemaName+"."+t.TableName)
+	}
+	for _, col := range columns {
+		table, ok := tableByID[col.SchemaTableID]
+		if !ok || !col.IsSelected {
+			continue
+		}
+		lines = append(lines, strings.Join([]string{
+			table.SchemaName + "." + table.TableName + "." + col.ColumnName,
+			strings.ToLower(col.DataType),
+			boolFlag(col.IsPrimaryKey, "pk"),
+			boolFlag(col.IsNullable, "null"),
+		}, " "))
+	}
+	sort.Strings(lines)
+
+	sum := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+func boolFlag(set bool, flag string) string {
+	if set {
+		return flag
+	}
+	return "-"
+}
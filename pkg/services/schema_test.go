@@ -3,6 +3,8 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -97,7 +99,7 @@ func (m *mockSchemaRepository) GetTableByID(ctx context.Context, projectID, tabl
 			return t, nil
 		}
 	}
-	return nil, errors.New("table not found")
+	return nil, fmt.Errorf("table %w", apperrors.ErrNotFound)
 }
 
 func (m *mockSchemaRepository) GetTableByName(ctx context.Context, projectID, datasourceID uuid.UUID, schemaName, tableName string) (*models.SchemaTable, error) {
@@ -209,7 +211,7 @@ func (m *mockSchemaRepository) GetColumnByID(ctx context.Context, projectID, col
 			return c, nil
 		}
 	}
-	return nil, errors.New("column not found")
+	return nil, fmt.Errorf("column %w", apperrors.ErrNotFound)
 }
 
 func (m *mockSchemaRepository) GetColumnByName(ctx context.Context, tableID uuid.UUID, columnName string) (*models.SchemaColumn, error) {
@@ -286,7 +288,7 @@ func (m *mockSchemaRepository) GetRelationshipByID(ctx context.Context, projectI
 			return r, nil
 		}
 	}
-	return nil, errors.New("relationship not found")
+	return nil, fmt.Errorf("relationship %w", apperrors.ErrNotFound)
 }
 
 func (m *mockSchemaRepository) GetRelationshipByColumns(ctx context.Context, sourceColumnID, targetColumnID uuid.UUID) (*models.SchemaRelationship, error) {
@@ -347,6 +349,10 @@ func (m *mockSchemaRepository) GetOrphanTables(ctx context.Context, projectID, d
 	return nil, nil
 }
 
+func (m *mockSchemaRepository) GetRelationshipStats(ctx context.Context, projectID, datasourceID uuid.UUID) (*models.RelationshipStats, error) {
+	return nil, nil
+}
+
 func (m *mockSchemaRepository) UpsertRelationshipWithMetrics(ctx context.Context, rel *models.SchemaRelationship, metrics *models.DiscoveryMetrics) error {
 	if rel.ID == uuid.Nil {
 		rel.ID = uuid.New()
@@ -482,6 +488,11 @@ type mockSchemaDiscoverer struct {
 	discoverTablesErr error
 	discoverColsErr   error
 	discoverFKsErr    error
+	joinAnalysis      *datasource.JoinAnalysis
+
+	// Capture for verification
+	discoveredColumnTables []string // schema.table, in call order
+	analyzeStatsCalls      int
 }
 
 func (m *mockSchemaDiscoverer) DiscoverTables(ctx context.Context) ([]datasource.TableMetadata, error) {
@@ -496,6 +507,7 @@ func (m *mockSchemaDiscoverer) DiscoverColumns(ctx context.Context, schemaName,
 		return nil, m.discoverColsErr
 	}
 	key := schemaName + "." + tableName
+	m.discoveredColumnTables = append(m.discoveredColumnTables, key)
 	return m.columns[key], nil
 }
 
@@ -511,6 +523,7 @@ func (m *mockSchemaDiscoverer) SupportsForeignKeys() bool {
 }
 
 func (m *mockSchemaDiscoverer) AnalyzeColumnStats(ctx context.Context, schemaName, tableName string, columnNames []string) ([]datasource.ColumnStats, error) {
+	m.analyzeStatsCalls++
 	return nil, nil
 }
 
@@ -519,14 +532,21 @@ func (m *mockSchemaDiscoverer) CheckValueOverlap(ctx context.Context, sourceSche
 }
 
 func (m *mockSchemaDiscoverer) AnalyzeJoin(ctx context.Context, sourceSchema, sourceTable, sourceColumn, targetSchema, targetTable, targetColumn string) (*datasource.JoinAnalysis, error) {
-	return nil, nil
+	if m.joinAnalysis == nil {
+		return nil, errors.New("no join analysis configured")
+	}
+	return m.joinAnalysis, nil
 }
 
 func (m *mockSchemaDiscoverer) GetDistinctValues(ctx context.Context, schemaName, tableName, columnName string, limit int) ([]string, error) {
 	return nil, nil
 }
 
-func (m *mockSchemaDiscoverer) GetEnumValueDistribution(ctx context.Context, schemaName, tableName, columnName string, completionTimestampCol string, limit int) (*datasource.EnumDistributionResult, error) {
+func (m *mockSchemaDiscoverer) SampleDistinctValues(ctx context.Context, schemaName, tableName, columnName string, opts datasource.DistinctValueOptions) ([]string, error) {
+	return nil, nil
+}
+
+func (m *mockSchemaDiscoverer) GetEnumValueDistribution(ctx context.Context, schemaName, tableName, columnName string, opts datasource.EnumDistributionOptions) (*datasource.EnumDistributionResult, error) {
 	return nil, nil
 }
 
@@ -657,6 +677,64 @@ func TestSchemaService_RefreshDatasourceSchema_Success(t *testing.T) {
 	}
 }
 
+func TestSchemaService_RefreshDatasourceSchema_CompositeForeignKeyJoinClause(t *testing.T) {
+	projectID := uuid.New()
+	datasourceID := uuid.New()
+
+	repo := &mockSchemaRepository{}
+	dsSvc := &mockDatasourceService{
+		datasource: &models.Datasource{
+			ID:             datasourceID,
+			ProjectID:      projectID,
+			DatasourceType: "postgres",
+			Config:         map[string]any{"host": "localhost"},
+		},
+	}
+	discoverer := &mockSchemaDiscoverer{
+		tables: []datasource.TableMetadata{
+			{SchemaName: "public", TableName: "orders", RowCount: 100},
+			{SchemaName: "sales", TableName: "shipments", RowCount: 500},
+		},
+		columns: map[string][]datasource.ColumnMetadata{
+			"public.orders": {
+				{ColumnName: "region", DataType: "text", IsPrimaryKey: true, OrdinalPosition: 1},
+				{ColumnName: "order_no", DataType: "int", IsPrimaryKey: true, OrdinalPosition: 2},
+			},
+			"sales.shipments": {
+				{ColumnName: "id", DataType: "uuid", IsPrimaryKey: true, OrdinalPosition: 1},
+				{ColumnName: "order_region", DataType: "text", OrdinalPosition: 2},
+				{ColumnName: "order_no", DataType: "int", OrdinalPosition: 3},
+			},
+		},
+		supportsFKs: true,
+		foreignKeys: []datasource.ForeignKeyMetadata{
+			{ConstraintName: "fk_shipments_order", SourceSchema: "sales", SourceTable: "shipments", SourceColumn: "order_region",
+				TargetSchema: "public", TargetTable: "orders", TargetColumn: "region"},
+			{ConstraintName: "fk_shipments_order", SourceSchema: "sales", SourceTable: "shipments", SourceColumn: "order_no",
+				TargetSchema: "public", TargetTable: "orders", TargetColumn: "order_no"},
+		},
+	}
+	factory := &mockSchemaAdapterFactory{discoverer: discoverer}
+
+	service := newTestSchemaService(repo, dsSvc, factory)
+
+	ctx := testContextWithAuth(projectID.String(), "test-user-id")
+	if _, err := service.RefreshDatasourceSchema(ctx, projectID, datasourceID, false); err != nil {
+		t.Fatalf("RefreshDatasourceSchema failed: %v", err)
+	}
+
+	if len(repo.upsertedRelationships) != 2 {
+		t.Fatalf("expected 2 relationships in repo, got %d", len(repo.upsertedRelationships))
+	}
+	expected := `"sales"."shipments"."order_region" = "public"."orders"."region" AND ` +
+		`"sales"."shipments"."order_no" = "public"."orders"."order_no"`
+	for _, rel := range repo.upsertedRelationships {
+		if rel.JoinClause == nil || *rel.JoinClause != expected {
+			t.Errorf("expected composite join clause %q, got %v", expected, rel.JoinClause)
+		}
+	}
+}
+
 func TestSchemaService_RefreshDatasourceSchema_NoTables(t *testing.T) {
 	projectID := uuid.New()
 	datasourceID := uuid.New()
@@ -776,6 +854,155 @@ func TestSchemaService_RefreshDatasourceSchema_NoFKSupport(t *testing.T) {
 	}
 }
 
+func TestSchemaService_InventoryDatasourceSchema_ListsTablesWithoutColumnsOrStats(t *testing.T) {
+	projectID := uuid.New()
+	datasourceID := uuid.New()
+
+	repo := &mockSchemaRepository{}
+	dsSvc := &mockDatasourceService{
+		datasource: &models.Datasource{
+			ID:             datasourceID,
+			ProjectID:      projectID,
+			DatasourceType: "postgres",
+			Config:         map[string]any{"host": "localhost"},
+		},
+	}
+	discoverer := &mockSchemaDiscoverer{
+		tables: []datasource.TableMetadata{
+			{SchemaName: "public", TableName: "users", RowCount: 100},
+			{SchemaName: "public", TableName: "events", RowCount: 90000},
+			{SchemaName: "public", TableName: "orders", RowCount: 500},
+		},
+		columns: map[string][]datasource.ColumnMetadata{
+			"public.users": {{ColumnName: "id", DataType: "uuid", IsPrimaryKey: true, OrdinalPosition: 1}},
+		},
+		supportsFKs: true,
+		foreignKeys: []datasource.ForeignKeyMetadata{
+			{ConstraintName: "fk_orders_user", SourceSchema: "public", SourceTable: "orders", SourceColumn: "user_id",
+				TargetSchema: "public", TargetTable: "users", TargetColumn: "id"},
+		},
+	}
+	service := newTestSchemaService(repo, dsSvc, &mockSchemaAdapterFactory{discoverer: discoverer})
+
+	ctx := testContextWithAuth(projectID.String(), "test-user-id")
+	result, err := service.InventoryDatasourceSchema(ctx, projectID, datasourceID)
+	if err != nil {
+		t.Fatalf("InventoryDatasourceSchema failed: %v", err)
+	}
+
+	if result.TablesUpserted != 3 || len(result.Tables) != 3 {
+		t.Fatalf("expected 3 inventoried tables, got %d upserted and %d listed", result.TablesUpserted, len(result.Tables))
+	}
+	wantOrder := []string{"events", "orders", "users"}
+	wantCounts := []int64{90000, 500, 100}
+	for i, table := range result.Tables {
+		if table.TableName != wantOrder[i] || table.RowCount != wantCounts[i] {
+			t.Errorf("table %d: expected %s with %d rows, got %s with %d rows",
+				i, wantOrder[i], wantCounts[i], table.TableName, table.RowCount)
+		}
+		if table.IsSelected {
+			t.Errorf("expected new table %s to stay unselected", table.TableName)
+		}
+	}
+	for _, table := range repo.upsertedTables {
+		if table.RowCount == nil {
+			t.Errorf("expected row count to be stored for %s", table.TableName)
+		}
+	}
+	if len(result.NewTableNames) != 3 {
+		t.Errorf("expected 3 new tables, got %v", result.NewTableNames)
+	}
+
+	if len(discoverer.discoveredColumnTables) != 0 {
+		t.Errorf("inventory must not fetch columns, fetched for %v", discoverer.discoveredColumnTables)
+	}
+	if discoverer.analyzeStatsCalls != 0 {
+		t.Errorf("inventory must not gather column stats, got %d calls", discoverer.analyzeStatsCalls)
+	}
+	if len(repo.upsertedColumns) != 0 || len(repo.upsertedRelationships) != 0 {
+		t.Errorf("expected no columns or relationships, got %d and %d", len(repo.upsertedColumns), len(repo.upsertedRelationships))
+	}
+}
+
+func TestSchemaService_IntrospectTables_TopNByReferenceCount(t *testing.T) {
+	projectID := uuid.New()
+	datasourceID := uuid.New()
+
+	rows := func(n int64) *int64 { return &n }
+	repo := &mockSchemaRepository{
+		tables: []*models.SchemaTable{
+			{ID: uuid.New(), SchemaName: "public", TableName: "users", RowCount: rows(100)},
+			{ID: uuid.New(), SchemaName: "public", TableName: "orders", RowCount: rows(500)},
+			{ID: uuid.New(), SchemaName: "public", TableName: "events", RowCount: rows(90000)},
+		},
+	}
+	dsSvc := &mockDatasourceService{
+		datasource: &models.Datasource{
+			ID:             datasourceID,
+			ProjectID:      projectID,
+			DatasourceType: "postgres",
+			Config:         map[string]any{"host": "localhost"},
+		},
+	}
+	discoverer := &mockSchemaDiscoverer{
+		columns: map[string][]datasource.ColumnMetadata{
+			"public.users": {{ColumnName: "id", DataType: "uuid", IsPrimaryKey: true, OrdinalPosition: 1}},
+		},
+		supportsFKs: true,
+		foreignKeys: []datasource.ForeignKeyMetadata{
+			{ConstraintName: "fk_orders_user", SourceSchema: "public", SourceTable: "orders", SourceColumn: "user_id",
+				TargetSchema: "public", TargetTable: "users", TargetColumn: "id"},
+			{ConstraintName: "fk_events_user", SourceSchema: "public", SourceTable: "events", SourceColumn: "user_id",
+				TargetSchema: "public", TargetTable: "users", TargetColumn: "id"},
+		},
+	}
+	service := newTestSchemaService(repo, dsSvc, &mockSchemaAdapterFactory{discoverer: discoverer})
+
+	ctx := testContextWithAuth(projectID.String(), "test-user-id")
+	result, err := service.IntrospectTables(ctx, projectID, datasourceID, &models.IntrospectTablesRequest{
+		TopN:   1,
+		RankBy: models.IntrospectionRankReferenceCount,
+	})
+	if err != nil {
+		t.Fatalf("IntrospectTables failed: %v", err)
+	}
+
+	// users has the fewest rows but is referenced by both other tables.
+	if len(result.IntrospectedTableNames) != 1 || result.IntrospectedTableNames[0] != "public.users" {
+		t.Errorf("expected only public.users to be introspected, got %v", result.IntrospectedTableNames)
+	}
+	if len(discoverer.discoveredColumnTables) != 1 || discoverer.discoveredColumnTables[0] != "public.users" {
+		t.Errorf("expected columns fetched for public.users only, got %v", discoverer.discoveredColumnTables)
+	}
+	if result.ColumnsUpserted != 1 {
+		t.Errorf("expected 1 column upserted, got %d", result.ColumnsUpserted)
+	}
+	if !repo.tables[0].IsSelected || repo.tables[1].IsSelected || repo.tables[2].IsSelected {
+		t.Error("expected only the introspected table to be selected")
+	}
+}
+
+func TestSchemaService_IntrospectTables_UnknownTable(t *testing.T) {
+	projectID := uuid.New()
+	datasourceID := uuid.New()
+
+	repo := &mockSchemaRepository{
+		tables: []*models.SchemaTable{{ID: uuid.New(), SchemaName: "public", TableName: "users"}},
+	}
+	dsSvc := &mockDatasourceService{
+		datasource: &models.Datasource{ID: datasourceID, ProjectID: projectID, DatasourceType: "postgres"},
+	}
+	service := newTestSchemaService(repo, dsSvc, &mockSchemaAdapterFactory{discoverer: &mockSchemaDiscoverer{}})
+
+	ctx := testContextWithAuth(projectID.String(), "test-user-id")
+	_, err := service.IntrospectTables(ctx, projectID, datasourceID, &models.IntrospectTablesRequest{
+		TableNames: []string{"public.missing"},
+	})
+	if !errors.Is(err, apperrors.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
 func TestSchemaService_GetDatasourceSchema_Success(t *testing.T) {
 	projectID := uuid.New()
 	datasourceID := uuid.New()
@@ -1461,6 +1688,157 @@ func TestSchemaService_AddManualRelationship_EmptySourceTable(t *testing.T) {
 	}
 }
 
+func manualRelationshipRepo(projectID, sourceDatasourceID, targetDatasourceID uuid.UUID) (*mockSchemaRepository, uuid.UUID, uuid.UUID) {
+	sourceTableID := uuid.New()
+	targetTableID := uuid.New()
+	sourceColumnID := uuid.New()
+	targetColumnID := uuid.New()
+	repo := &mockSchemaRepository{
+		tables: []*models.SchemaTable{
+			{ID: sourceTableID, ProjectID: projectID, DatasourceID: sourceDatasourceID, SchemaName: "public", TableName: "payments"},
+			{ID: targetTableID, ProjectID: projectID, DatasourceID: targetDatasourceID, SchemaName: "public", TableName: "accounts"},
+		},
+		columns: []*models.SchemaColumn{
+			{ID: sourceColumnID, ProjectID: projectID, SchemaTableID: sourceTableID, ColumnName: "account_ref", DataType: "text"},
+			{ID: targetColumnID, ProjectID: projectID, SchemaTableID: targetTableID, ColumnName: "code", DataType: "text"},
+		},
+	}
+	return repo, sourceColumnID, targetColumnID
+}
+
+func TestSchemaService_AddManualRelationshipByColumns_AnalyzesJoin(t *testing.T) {
+	projectID := uuid.New()
+	datasourceID := uuid.New()
+	repo, sourceColumnID, targetColumnID := manualRelationshipRepo(projectID, datasourceID, datasourceID)
+	factory := &mockSchemaAdapterFactory{discoverer: &mockSchemaDiscoverer{
+		joinAnalysis: &datasource.JoinAnalysis{JoinCount: 120, SourceMatched: 45, OrphanCount: 5},
+	}}
+	service := newTestSchemaService(repo, &mockDatasourceService{}, factory)
+
+	ctx := testContextWithAuth(projectID.String(), uuid.New().String())
+	rel, err := service.AddManualRelationshipByColumns(ctx, projectID, &models.AddColumnRelationshipRequest{
+		SourceColumnID: sourceColumnID,
+		TargetColumnID: targetColumnID,
+		AnalyzeJoin:    true,
+	})
+	if err != nil {
+		t.Fatalf("AddManualRelationshipByColumns failed: %v", err)
+	}
+
+	if rel.RelationshipType != models.RelationshipTypeManual || rel.InferenceMethod == nil || *rel.InferenceMethod != models.RelationshipTypeManual {
+		t.Errorf("expected manual relationship, got type %q method %v", rel.RelationshipType, rel.InferenceMethod)
+	}
+	if rel.Confidence != 1.0 || rel.IsApproved == nil || !*rel.IsApproved {
+		t.Errorf("expected approved full-confidence relationship, got confidence %f approved %v", rel.Confidence, rel.IsApproved)
+	}
+	if rel.ValidationResults == nil || !rel.IsValidated {
+		t.Fatal("expected join analysis to be stored as validation results")
+	}
+	if rel.ValidationResults.OrphanCount != 5 || rel.ValidationResults.SourceDistinct != 50 {
+		t.Errorf("expected 5/50 orphaned values, got %d/%d", rel.ValidationResults.OrphanCount, rel.ValidationResults.SourceDistinct)
+	}
+	if len(repo.upsertedRelationships) != 1 {
+		t.Errorf("expected 1 relationship upserted, got %d", len(repo.upsertedRelationships))
+	}
+}
+
+func TestSchemaService_AddManualRelationshipByColumns_JoinAnalysisFailureStillCreates(t *testing.T) {
+	projectID := uuid.New()
+	datasourceID := uuid.New()
+	repo, sourceColumnID, targetColumnID := manualRelationshipRepo(projectID, datasourceID, datasourceID)
+	factory := &mockSchemaAdapterFactory{discoverer: &mockSchemaDiscoverer{}}
+	service := newTestSchemaService(repo, &mockDatasourceService{}, factory)
+
+	ctx := testContextWithAuth(projectID.String(), uuid.New().String())
+	rel, err := service.AddManualRelationshipByColumns(ctx, projectID, &models.AddColumnRelationshipRequest{
+		SourceColumnID: sourceColumnID,
+		TargetColumnID: targetColumnID,
+		AnalyzeJoin:    true,
+	})
+	if err != nil {
+		t.Fatalf("AddManualRelationshipByColumns failed: %v", err)
+	}
+	if rel.ValidationResults != nil {
+		t.Errorf("expected no validation results when analysis fails, got %+v", rel.ValidationResults)
+	}
+}
+
+func TestSchemaService_AddManualRelationshipByColumns_RejectsCrossDatasource(t *testing.T) {
+	projectID := uuid.New()
+	repo, sourceColumnID, targetColumnID := manualRelationshipRepo(projectID, uuid.New(), uuid.New())
+	service := newTestSchemaService(repo, &mockDatasourceService{}, &mockSchemaAdapterFactory{})
+
+	_, err := service.AddManualRelationshipByColumns(context.Background(), projectID, &models.AddColumnRelationshipRequest{
+		SourceColumnID: sourceColumnID,
+		TargetColumnID: targetColumnID,
+	})
+	if !errors.Is(err, ErrCrossDatasourceRelationship) {
+		t.Fatalf("expected ErrCrossDatasourceRelationship, got %v", err)
+	}
+	if len(repo.upsertedRelationships) != 0 {
+		t.Errorf("expected nothing upserted, got %d", len(repo.upsertedRelationships))
+	}
+}
+
+func TestSchemaService_AddManualRelationshipByColumns_ColumnNotFound(t *testing.T) {
+	projectID := uuid.New()
+	repo, sourceColumnID, _ := manualRelationshipRepo(projectID, uuid.New(), uuid.New())
+	service := newTestSchemaService(repo, &mockDatasourceService{}, &mockSchemaAdapterFactory{})
+
+	_, err := service.AddManualRelationshipByColumns(context.Background(), projectID, &models.AddColumnRelationshipRequest{
+		SourceColumnID: sourceColumnID,
+		TargetColumnID: uuid.New(),
+	})
+	if !errors.Is(err, apperrors.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestSchemaService_GetRelationshipMetrics_NotFound(t *testing.T) {
+	service := newTestSchemaService(&mockSchemaRepository{}, &mockDatasourceService{}, &mockSchemaAdapterFactory{})
+
+	_, err := service.GetRelationshipMetrics(context.Background(), uuid.New(), uuid.New())
+	if !errors.Is(err, apperrors.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got: %v", err)
+	}
+}
+
+func TestSchemaService_GetRelationshipMetrics_PropagatesRepositoryError(t *testing.T) {
+	repo := &mockSchemaRepository{getRelationshipByIDErr: errors.New("connection refused")}
+	service := newTestSchemaService(repo, &mockDatasourceService{}, &mockSchemaAdapterFactory{})
+
+	_, err := service.GetRelationshipMetrics(context.Background(), uuid.New(), uuid.New())
+	if err == nil || errors.Is(err, apperrors.ErrNotFound) {
+		t.Fatalf("expected the repository error rather than ErrNotFound, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "connection refused") {
+		t.Errorf("expected the repository error to be wrapped, got: %v", err)
+	}
+}
+
+func TestSchemaService_AddManualRelationshipByColumns_PropagatesRepositoryError(t *testing.T) {
+	projectID := uuid.New()
+	repo, sourceColumnID, targetColumnID := manualRelationshipRepo(projectID, uuid.New(), uuid.New())
+	service := &schemaService{schemaRepo: &failingTableLookupRepo{repo}, logger: zap.NewNop()}
+
+	_, err := service.AddManualRelationshipByColumns(context.Background(), projectID, &models.AddColumnRelationshipRequest{
+		SourceColumnID: sourceColumnID,
+		TargetColumnID: targetColumnID,
+	})
+	if err == nil || errors.Is(err, apperrors.ErrNotFound) {
+		t.Fatalf("expected the repository error rather than ErrNotFound, got: %v", err)
+	}
+}
+
+// failingTableLookupRepo fails table lookups the way a lost connection would.
+type failingTableLookupRepo struct {
+	*mockSchemaRepository
+}
+
+func (r *failingTableLookupRepo) GetTableByID(ctx context.Context, projectID, tableID uuid.UUID) (*models.SchemaTable, error) {
+	return nil, errors.New("connection reset by peer")
+}
+
 func TestSchemaService_RemoveRelationship_Success(t *testing.T) {
 	projectID := uuid.New()
 	relationshipID := uuid.New()
@@ -3,15 +3,18 @@ package services
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/google/uuid"
+	"github.com/jinzhu/inflection"
 	"go.uber.org/zap"
 
 	"github.com/ekaya-inc/ekaya-engine/pkg/llm"
 	"github.com/ekaya-inc/ekaya-engine/pkg/models"
 	"github.com/ekaya-inc/ekaya-engine/pkg/repositories"
 	"github.com/ekaya-inc/ekaya-engine/pkg/services/dag"
+	"github.com/ekaya-inc/ekaya-engine/pkg/tracing"
 )
 
 // TableFeatureExtractionService generates table-level descriptions based on column features.
@@ -23,15 +26,22 @@ import (
 //   - All columns with their ColumnFeatures (PKs, FKs, enums, semantic types, purposes)
 //   - Declared FK relationships from schema introspection
 //   - Row count
+//   - Relationship labels phrased from the relationships FKDiscovery found
+//   - Entity hints from the user's project description that name the table
+//
+// Tables wider than the configured column threshold are analyzed in two passes: each
+// group of related columns is summarized on its own, then the table is described from
+// its key columns and the group summaries (see groupWideTableColumns).
 //
 // Outputs per table (stored in engine_ontology_table_metadata):
 //   - description: What this table represents
 //   - usage_notes: When to use/not use this table
 //   - is_ephemeral: Whether it's transient/temp data
+//   - features.table_split: Primary/extension role for tables sharing a PK 1:1 (detected deterministically)
 type TableFeatureExtractionService interface {
-	// ExtractTableFeatures generates descriptions for all selected tables in the datasource.
-	// Returns the number of tables processed.
-	ExtractTableFeatures(ctx context.Context, projectID, datasourceID uuid.UUID, progressCallback dag.ProgressCallback) (int, error)
+	// ExtractTableFeatures generates descriptions for the selected tables in the datasource,
+	// limited to tableNames when it is non-empty. Returns the number of tables processed.
+	ExtractTableFeatures(ctx context.Context, projectID, datasourceID uuid.UUID, tableNames []string, progressCallback dag.ProgressCallback) (int, error)
 }
 
 type tableFeatureExtractionService struct {
@@ -41,10 +51,18 @@ type tableFeatureExtractionService struct {
 	llmFactory         llm.LLMClientFactory
 	workerPool         *llm.WorkerPool
 	getTenantCtx       TenantContextFunc
-	logger             *zap.Logger
+	// wideTableColumnThreshold is the column count above which a table is analyzed
+	// in column groups; 0 always analyzes a table in one prompt.
+	wideTableColumnThreshold int
+	// minRelationshipConfidence is the confidence an inferred relationship needs to be
+	// shown in the prompt; declared, manual and approved relationships are always shown.
+	minRelationshipConfidence float64
+	logger                    *zap.Logger
 }
 
 // NewTableFeatureExtractionService creates a table feature extraction service with LLM support.
+// Tables with more than wideTableColumnThreshold columns are analyzed in column groups.
+// Inferred relationships below minRelationshipConfidence are left out of the prompts.
 func NewTableFeatureExtractionService(
 	schemaRepo repositories.SchemaRepository,
 	columnMetadataRepo repositories.ColumnMetadataRepository,
@@ -52,16 +70,20 @@ func NewTableFeatureExtractionService(
 	llmFactory llm.LLMClientFactory,
 	workerPool *llm.WorkerPool,
 	getTenantCtx TenantContextFunc,
+	wideTableColumnThreshold int,
+	minRelationshipConfidence float64,
 	logger *zap.Logger,
 ) TableFeatureExtractionService {
 	return &tableFeatureExtractionService{
-		schemaRepo:         schemaRepo,
-		columnMetadataRepo: columnMetadataRepo,
-		tableMetadataRepo:  tableMetadataRepo,
-		llmFactory:         llmFactory,
-		workerPool:         workerPool,
-		getTenantCtx:       getTenantCtx,
-		logger:             logger.Named("table-feature-extraction"),
+		schemaRepo:                schemaRepo,
+		columnMetadataRepo:        columnMetadataRepo,
+		tableMetadataRepo:         tableMetadataRepo,
+		llmFactory:                llmFactory,
+		workerPool:                workerPool,
+		getTenantCtx:              getTenantCtx,
+		wideTableColumnThreshold:  wideTableColumnThreshold,
+		minRelationshipConfidence: minRelationshipConfidence,
+		logger:                    logger.Named("table-feature-extraction"),
 	}
 }
 
@@ -71,12 +93,18 @@ type tableContext struct {
 	Columns            []*models.SchemaColumn
 	Relationships      []*models.RelationshipDetail
 	MetadataByColumnID map[uuid.UUID]*models.ColumnMetadata
+	TableSplit         *models.TableSplitFeatures  // Set when the table shares its PK 1:1 with another table
+	Bridge             *models.TableBridgeFeatures // Set when the table is a pure join table
+	NoPrimaryKey       bool                        // Set when no column of the table is a primary key
+	RelationshipLabels map[string]string           // Known relationship labels keyed by relationshipLabelKey
+	EntityHints        []string                    // User-described hints about what this table represents
 }
 
 // ExtractTableFeatures generates descriptions for all selected tables in the datasource.
 func (s *tableFeatureExtractionService) ExtractTableFeatures(
 	ctx context.Context,
 	projectID, datasourceID uuid.UUID,
+	tableNames []string,
 	progressCallback dag.ProgressCallback,
 ) (int, error) {
 	s.logger.Info("Starting table feature extraction",
@@ -95,6 +123,21 @@ func (s *tableFeatureExtractionService) ExtractTableFeatures(
 		return 0, fmt.Errorf("failed to list tables: %w", err)
 	}
 
+	// Restrict to the requested tables (incremental extraction)
+	if len(tableNames) > 0 {
+		wanted := make(map[string]bool, len(tableNames))
+		for _, name := range tableNames {
+			wanted[name] = true
+		}
+		filtered := tables[:0]
+		for _, t := range tables {
+			if wanted[t.TableName] {
+				filtered = append(filtered, t)
+			}
+		}
+		tables = filtered
+	}
+
 	if len(tables) == 0 {
 		s.logger.Info("No selected tables found")
 		if progressCallback != nil {
@@ -149,6 +192,20 @@ func (s *tableFeatureExtractionService) ExtractTableFeatures(
 	// Build table contexts
 	tableContexts := s.buildTableContexts(tables, columnsByTable, relationships, metadataByColumnID)
 
+	// Attach relationship labels so the LLM builds on the relationships FKDiscovery found
+	if labels := relationshipLabelsFromDetails(relationships); len(labels) > 0 {
+		for _, tc := range tableContexts {
+			tc.RelationshipLabels = labels
+		}
+	}
+
+	// Attach hints from the user's project description to the tables they describe
+	if hints := entityHintsFromPromptContext(ctx); len(hints) > 0 {
+		for _, tc := range tableContexts {
+			tc.EntityHints = hints[entityHintTableKey(tc.Table.TableName)]
+		}
+	}
+
 	if len(tableContexts) == 0 {
 		s.logger.Info("No tables with column features found")
 		if progressCallback != nil {
@@ -235,11 +292,34 @@ func (s *tableFeatureExtractionService) buildTableContexts(
 	relationships []*models.RelationshipDetail,
 	metadataByColumnID map[uuid.UUID]*models.ColumnMetadata,
 ) []*tableContext {
-	// Build a lookup of relationships by source table name
+	// Build a lookup of relationships by source table name, leaving out inferences too
+	// shaky to present to the LLM as joins
 	relsByTable := make(map[string][]*models.RelationshipDetail)
+	excluded := 0
 	for _, rel := range relationships {
+		if !includeRelationshipInPrompt(rel, s.minRelationshipConfidence) {
+			excluded++
+			continue
+		}
 		relsByTable[rel.SourceTableName] = append(relsByTable[rel.SourceTableName], rel)
 	}
+	if excluded > 0 {
+		s.logger.Debug("Left low-confidence relationships out of table analysis prompts",
+			zap.Int("excluded", excluded),
+			zap.Float64("min_confidence", s.minRelationshipConfidence))
+	}
+
+	splits := detectOneToOneSplits(tables, columnsByTable, relationships)
+	bridges := detectBridgeTables(columnsByTable, relationships)
+
+	noPrimaryKey := detectTablesWithoutPrimaryKey(columnsByTable)
+	for _, rel := range relationships {
+		if noPrimaryKey[rel.TargetTableName] {
+			s.logger.Warn("Relationship targets a table without a primary key; it cannot be validated reliably",
+				zap.String("source", rel.SourceTableName+"."+rel.SourceColumnName),
+				zap.String("target", rel.TargetTableName+"."+rel.TargetColumnName))
+		}
+	}
 
 	// Build table contexts for tables with columns
 	contexts := make([]*tableContext, 0)
@@ -254,12 +334,299 @@ func (s *tableFeatureExtractionService) buildTableContexts(
 			Columns:            columns,
 			Relationships:      relsByTable[table.TableName],
 			MetadataByColumnID: metadataByColumnID,
+			TableSplit:         splits[table.TableName],
+			Bridge:             bridges[table.TableName],
+			NoPrimaryKey:       noPrimaryKey[table.TableName],
 		})
 	}
 
 	return contexts
 }
 
+// includeRelationshipInPrompt reports whether a relationship is reliable enough to be
+// given to the LLM as prompt context. Declared FKs, manual and approved relationships
+// always are; inferred ones need at least minConfidence.
+func includeRelationshipInPrompt(rel *models.RelationshipDetail, minConfidence float64) bool {
+	if rel.RelationshipType == models.RelationshipTypeFK || rel.RelationshipType == models.RelationshipTypeManual {
+		return true
+	}
+	if rel.IsApproved != nil && *rel.IsApproved {
+		return true
+	}
+	return rel.Confidence >= minConfidence
+}
+
+// relationshipLabelKey builds the lookup key for a relationship label between two tables.
+func relationshipLabelKey(fromTable, toTable string) string {
+	return strings.ToLower(fromTable) + "->" + strings.ToLower(toTable)
+}
+
+// relationshipLabelsFromDetails describes each discovered relationship in words, keyed
+// by relationshipLabelKey. Labels come from the persisted relationships because the
+// domain summary is only written at finalization, after this node runs.
+func relationshipLabelsFromDetails(relationships []*models.RelationshipDetail) map[string]string {
+	labels := make(map[string]string)
+	for _, rel := range relationships {
+		if rel == nil || rel.SourceTableName == "" || rel.TargetTableName == "" {
+			continue
+		}
+		if rel.IsApproved != nil && !*rel.IsApproved {
+			continue
+		}
+		label := relationshipLabel(rel.SourceTableName, rel.TargetTableName, rel.Cardinality)
+		if label == "" {
+			continue
+		}
+		key := relationshipLabelKey(rel.SourceTableName, rel.TargetTableName)
+		if _, exists := labels[key]; !exists {
+			labels[key] = label
+		}
+	}
+	return labels
+}
+
+// relationshipLabel phrases a relationship from source to target table by its
+// cardinality, e.g. "each order belongs to one customer" for orders N:1 customers.
+func relationshipLabel(sourceTable, targetTable, cardinality string) string {
+	source := inflection.Singular(strings.ToLower(sourceTable))
+	target := inflection.Singular(strings.ToLower(targetTable))
+	switch cardinality {
+	case models.CardinalityNTo1:
+		return fmt.Sprintf("each %s belongs to one %s", source, target)
+	case models.Cardinality1To1:
+		return fmt.Sprintf("each %s has at most one %s", source, target)
+	case models.Cardinality1ToN:
+		return fmt.Sprintf("each %s has many %s", source, inflection.Plural(target))
+	case models.CardinalityNToM:
+		return fmt.Sprintf("many %s relate to many %s", inflection.Plural(source), inflection.Plural(target))
+	default:
+		return ""
+	}
+}
+
+// detectOneToOneSplits finds logical entities split across tables that share a
+// primary key (vertical partitioning, e.g. users / user_profiles both keyed by id).
+// A relationship qualifies when both its source and target columns are the single
+// primary key of their tables and its cardinality is not explicitly something other
+// than 1:1. The referencing table becomes the extension of the referenced table.
+//
+// Returns split features keyed by table name.
+func detectOneToOneSplits(
+	tables []*models.SchemaTable,
+	columnsByTable map[string][]*models.SchemaColumn,
+	relationships []*models.RelationshipDetail,
+) map[string]*models.TableSplitFeatures {
+	// Single-column primary key per table; composite keys are not considered
+	pkByTable := make(map[string]string)
+	for tableName, columns := range columnsByTable {
+		var pks []string
+		for _, col := range columns {
+			if col.IsPrimaryKey {
+				pks = append(pks, col.ColumnName)
+			}
+		}
+		if len(pks) == 1 {
+			pkByTable[tableName] = pks[0]
+		}
+	}
+
+	rowCounts := make(map[string]int64)
+	for _, t := range tables {
+		if t.RowCount != nil {
+			rowCounts[t.TableName] = *t.RowCount
+		}
+	}
+
+	// extension table -> primary table
+	primaryOf := make(map[string]string)
+	for _, rel := range relationships {
+		if rel.SourceTableName == rel.TargetTableName {
+			continue
+		}
+		if rel.Cardinality != "" && rel.Cardinality != models.Cardinality1To1 && rel.Cardinality != models.CardinalityUnknown {
+			continue
+		}
+		if pkByTable[rel.SourceTableName] != rel.SourceColumnName || pkByTable[rel.TargetTableName] != rel.TargetColumnName {
+			continue
+		}
+
+		extension, primary := rel.SourceTableName, rel.TargetTableName
+
+		// Both directions may be stored; keep one pair and pick the primary deterministically
+		if primaryOf[primary] == extension {
+			if !isPreferredSplitPrimary(primary, extension, rowCounts) {
+				continue
+			}
+			delete(primaryOf, primary)
+		}
+		if _, assigned := primaryOf[extension]; assigned {
+			continue
+		}
+		primaryOf[extension] = primary
+	}
+
+	splits := make(map[string]*models.TableSplitFeatures)
+	for extension, primary := range primaryOf {
+		splits[extension] = &models.TableSplitFeatures{
+			Role:         models.TableSplitRoleExtension,
+			LinkedTables: []string{primary},
+		}
+	}
+	for extension, primary := range primaryOf {
+		if splits[primary] != nil && splits[primary].Role == models.TableSplitRoleExtension {
+			continue // Chained splits: the table stays an extension of its own primary
+		}
+		if splits[primary] == nil {
+			splits[primary] = &models.TableSplitFeatures{Role: models.TableSplitRolePrimary}
+		}
+		splits[primary].LinkedTables = append(splits[primary].LinkedTables, extension)
+	}
+	for _, split := range splits {
+		sort.Strings(split.LinkedTables)
+	}
+
+	return splits
+}
+
+// detectBridgeTables finds pure join tables: tables with exactly two outgoing
+// relationships, each from its own column, whose remaining columns are only primary
+// keys or audit timestamps. Such a table implements a many-to-many relationship
+// between its two targets (student_courses between students and courses) and carries
+// no business attributes, so it is documented as that relationship rather than as an
+// entity. A table referencing the same table twice (user_follows) links it to itself.
+//
+// Returns bridge features keyed by table name.
+func detectBridgeTables(
+	columnsByTable map[string][]*models.SchemaColumn,
+	relationships []*models.RelationshipDetail,
+) map[string]*models.TableBridgeFeatures {
+	// source table -> FK column -> target table
+	targetsByTable := make(map[string]map[string]string)
+	for _, rel := range relationships {
+		if rel.SourceTableName == rel.TargetTableName {
+			continue
+		}
+		if targetsByTable[rel.SourceTableName] == nil {
+			targetsByTable[rel.SourceTableName] = make(map[string]string)
+		}
+		targetsByTable[rel.SourceTableName][rel.SourceColumnName] = rel.TargetTableName
+	}
+
+	bridges := make(map[string]*models.TableBridgeFeatures)
+	for tableName, targets := range targetsByTable {
+		if len(targets) != 2 {
+			continue
+		}
+		pure := true
+		for _, col := range columnsByTable[tableName] {
+			if _, isFK := targets[col.ColumnName]; isFK || col.IsPrimaryKey || isAuditTimestampColumn(col) {
+				continue
+			}
+			pure = false
+			break
+		}
+		if !pure || len(columnsByTable[tableName]) == 0 {
+			continue
+		}
+
+		linked := make([]string, 0, 2)
+		for _, target := range targets {
+			linked = append(linked, target)
+		}
+		sort.Strings(linked)
+		bridges[tableName] = &models.TableBridgeFeatures{LinkedTables: linked}
+	}
+	return bridges
+}
+
+// isAuditTimestampColumn reports whether a column is a well-known created or updated
+// timestamp, which a join table may carry without becoming an entity.
+func isAuditTimestampColumn(col *models.SchemaColumn) bool {
+	if !isTimestampType(col.DataType) {
+		return false
+	}
+	for _, names := range [][]string{activityTimestampNames, creationTimestampNames} {
+		for _, name := range names {
+			if strings.EqualFold(col.ColumnName, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// bridgeTableResult describes a pure join table without the LLM: its type, role and
+// linked tables are fully determined by the schema.
+func bridgeTableResult(tc *tableContext) *tableFeatureResult {
+	from, to := tc.Bridge.LinkedTables[0], tc.Bridge.LinkedTables[1]
+	result := &tableFeatureResult{
+		SchemaTableID: tc.Table.ID,
+		TableName:     tc.Table.TableName,
+		TableType:     models.TableTypeJunction,
+		Description:   fmt.Sprintf("Join table implementing the many-to-many relationship between %s and %s; each row links one %s row to one %s row.", from, to, from, to),
+		UsageNotes:    fmt.Sprintf("Join through this table to relate %s to %s. It holds no business attributes of its own.", from, to),
+		Bridge:        tc.Bridge,
+	}
+	if tc.NoPrimaryKey {
+		result.NoPrimaryKey = true
+		result.Description = annotateNoPrimaryKey(result.Description)
+	}
+	return result
+}
+
+// detectTablesWithoutPrimaryKey finds tables none of whose columns is a primary key.
+// These are common for event logs and imports; their rows may not be uniquely
+// addressable, and PK-match has no key to target, so relationships pointing at them
+// cannot be validated reliably.
+//
+// Returns the PK-less table names as a set.
+func detectTablesWithoutPrimaryKey(columnsByTable map[string][]*models.SchemaColumn) map[string]bool {
+	noPrimaryKey := make(map[string]bool)
+	for tableName, columns := range columnsByTable {
+		if len(columns) == 0 {
+			continue
+		}
+		hasPK := false
+		for _, col := range columns {
+			if col.IsPrimaryKey {
+				hasPK = true
+				break
+			}
+		}
+		if !hasPK {
+			noPrimaryKey[tableName] = true
+		}
+	}
+	return noPrimaryKey
+}
+
+// annotateNoPrimaryKey appends models.TableNoPrimaryKeyNote to a table description
+// unless the description already carries it.
+func annotateNoPrimaryKey(description string) string {
+	description = strings.TrimSpace(description)
+	if strings.Contains(description, models.TableNoPrimaryKeyNote) {
+		return description
+	}
+	if description == "" {
+		return models.TableNoPrimaryKeyNote
+	}
+	return description + " " + models.TableNoPrimaryKeyNote
+}
+
+// isPreferredSplitPrimary reports whether candidate should be the primary over other
+// when both tables reference each other by PK. The table with more rows wins (the
+// core entity always has a row; extensions may not), then the shorter name
+// (users over user_profiles), then alphabetical order.
+func isPreferredSplitPrimary(candidate, other string, rowCounts map[string]int64) bool {
+	if rowCounts[candidate] != rowCounts[other] {
+		return rowCounts[candidate] > rowCounts[other]
+	}
+	if len(candidate) != len(other) {
+		return len(candidate) < len(other)
+	}
+	return candidate < other
+}
+
 // tableFeatureResult holds the LLM analysis result for a table.
 type tableFeatureResult struct {
 	SchemaTableID uuid.UUID
@@ -268,6 +635,9 @@ type tableFeatureResult struct {
 	Description   string
 	UsageNotes    string
 	IsEphemeral   bool
+	TableSplit    *models.TableSplitFeatures
+	Bridge        *models.TableBridgeFeatures
+	NoPrimaryKey  bool
 }
 
 // analyzeTable sends an LLM request to analyze a single table.
@@ -275,7 +645,15 @@ func (s *tableFeatureExtractionService) analyzeTable(
 	ctx context.Context,
 	projectID uuid.UUID,
 	tc *tableContext,
-) (*tableFeatureResult, error) {
+) (_ *tableFeatureResult, err error) {
+	ctx, span := tracing.Start(ctx, "table_feature_extraction.table", tracing.AttrTable.String(tc.Table.TableName))
+	defer func() { tracing.End(span, err) }()
+
+	// Pure join tables are described from the schema; there is nothing for the LLM to add
+	if tc.Bridge != nil {
+		return bridgeTableResult(tc), nil
+	}
+
 	// Acquire fresh connection for this analysis to avoid "conn busy" errors
 	workCtx := ctx
 	if s.getTenantCtx != nil {
@@ -288,17 +666,27 @@ func (s *tableFeatureExtractionService) analyzeTable(
 		defer cleanup()
 	}
 
-	// Build the prompt
-	prompt := s.buildPrompt(tc)
-	prompt = prependProjectKnowledgeToPrompt(prompt, buildRelevantProjectKnowledgeSection(workCtx, projectID, s.logger))
-	systemMsg := s.systemMessage()
-
 	// Get LLM client
 	llmClient, err := s.llmFactory.CreateForProject(workCtx, projectID)
 	if err != nil {
 		return nil, fmt.Errorf("create LLM client: %w", err)
 	}
 
+	// Build the prompt
+	prompt := s.buildPrompt(tc)
+	if s.isWideTable(tc) {
+		s.logger.Info("Analyzing wide table in column groups",
+			zap.String("table", tc.Table.TableName),
+			zap.Int("columns", len(tc.Columns)),
+			zap.Int("threshold", s.wideTableColumnThreshold))
+		prompt, err = s.buildWidePrompt(workCtx, llmClient, tc)
+		if err != nil {
+			return nil, err
+		}
+	}
+	prompt = prependProjectKnowledgeToPrompt(prompt, buildRelevantProjectKnowledgeSection(workCtx, projectID, s.logger))
+	systemMsg := s.systemMessage()
+
 	// Call LLM with low temperature for consistent classification
 	result, err := llmClient.GenerateResponse(workCtx, prompt, systemMsg, 0.2, false)
 	if err != nil {
@@ -306,7 +694,17 @@ func (s *tableFeatureExtractionService) analyzeTable(
 	}
 
 	// Parse the response
-	return s.parseResponse(tc.Table.ID, tc.Table.TableName, result.Content)
+	parsed, err := s.parseResponse(tc.Table.ID, tc.Table.TableName, result.Content)
+	if err != nil {
+		return nil, err
+	}
+	parsed.TableSplit = tc.TableSplit
+	if tc.NoPrimaryKey {
+		// Recorded deterministically rather than trusting the LLM to mention it
+		parsed.NoPrimaryKey = true
+		parsed.Description = annotateNoPrimaryKey(parsed.Description)
+	}
+	return parsed, nil
 }
 
 func (s *tableFeatureExtractionService) systemMessage() string {
@@ -324,6 +722,20 @@ Respond with valid JSON only.`
 func (s *tableFeatureExtractionService) buildPrompt(tc *tableContext) string {
 	var sb strings.Builder
 
+	s.writeTableHeader(&sb, tc)
+
+	// Summarize column features
+	sb.WriteString("\n## Column Features Summary\n\n")
+	s.writeCategorizedColumns(&sb, tc.Columns, tc.MetadataByColumnID)
+
+	s.writeTableContext(&sb, tc)
+	s.writeTableTask(&sb)
+
+	return sb.String()
+}
+
+// writeTableHeader writes the table identity and any user-provided hints.
+func (s *tableFeatureExtractionService) writeTableHeader(sb *strings.Builder, tc *tableContext) {
 	sb.WriteString("# Table Analysis\n\n")
 	sb.WriteString(fmt.Sprintf("**Table:** %s\n", tc.Table.TableName))
 	if tc.Table.SchemaName != "" && tc.Table.SchemaName != "public" {
@@ -334,97 +746,118 @@ func (s *tableFeatureExtractionService) buildPrompt(tc *tableContext) string {
 	}
 	sb.WriteString(fmt.Sprintf("**Column count:** %d\n", len(tc.Columns)))
 
-	// Summarize column features
-	sb.WriteString("\n## Column Features Summary\n\n")
-
-	// Group columns by role/purpose using metadata from ColumnMetadata
-	var pks, fks, timestamps, enums, measures, identifiers, others []*models.SchemaColumn
-	for _, col := range tc.Columns {
-		meta := tc.MetadataByColumnID[col.ID]
-		if meta == nil {
-			others = append(others, col)
-			continue
-		}
-
-		// Check role (stored as pointer in ColumnMetadata)
-		role := ""
-		if meta.Role != nil {
-			role = *meta.Role
-		}
-		purpose := ""
-		if meta.Purpose != nil {
-			purpose = *meta.Purpose
-		}
-
-		switch role {
-		case models.RolePrimaryKey:
-			pks = append(pks, col)
-		case models.RoleForeignKey:
-			fks = append(fks, col)
-		case models.RoleMeasure:
-			measures = append(measures, col)
-		default:
-			switch purpose {
-			case models.PurposeTimestamp:
-				timestamps = append(timestamps, col)
-			case models.PurposeEnum:
-				enums = append(enums, col)
-			case models.PurposeIdentifier:
-				identifiers = append(identifiers, col)
-			default:
-				others = append(others, col)
-			}
+	// User-provided context outranks anything inferred from column names
+	if len(tc.EntityHints) > 0 {
+		sb.WriteString("\n## User-Provided Context\n\n")
+		sb.WriteString("The project description says about this table:\n")
+		for _, hint := range tc.EntityHints {
+			sb.WriteString(fmt.Sprintf("- %s\n", hint))
 		}
+		sb.WriteString("Prefer this over assumptions drawn from column names when describing the table.\n")
 	}
+}
 
-	// Write summaries by category
-	if len(pks) > 0 {
-		sb.WriteString("**Primary Keys:**\n")
-		for _, col := range pks {
-			s.writeColumnSummary(&sb, col, tc.MetadataByColumnID[col.ID])
-		}
+// Column categories used to group columns in table prompts, in prompt order.
+const (
+	columnCategoryPrimaryKey = "Primary Keys"
+	columnCategoryForeignKey = "Foreign Keys"
+	columnCategoryTimestamp  = "Timestamps"
+	columnCategoryEnum       = "Enums/Status"
+	columnCategoryMeasure    = "Measures"
+	columnCategoryIdentifier = "Identifiers"
+	columnCategoryOther      = "Other Columns"
+)
+
+var columnCategoryOrder = []string{
+	columnCategoryPrimaryKey,
+	columnCategoryForeignKey,
+	columnCategoryTimestamp,
+	columnCategoryEnum,
+	columnCategoryMeasure,
+	columnCategoryIdentifier,
+	columnCategoryOther,
+}
+
+// columnCategory groups a column by its role, then its purpose. Columns without
+// metadata are "other".
+func columnCategory(meta *models.ColumnMetadata) string {
+	if meta == nil {
+		return columnCategoryOther
 	}
 
-	if len(fks) > 0 {
-		sb.WriteString("\n**Foreign Keys:**\n")
-		for _, col := range fks {
-			s.writeColumnSummary(&sb, col, tc.MetadataByColumnID[col.ID])
-		}
+	// Check role (stored as pointer in ColumnMetadata)
+	role := ""
+	if meta.Role != nil {
+		role = *meta.Role
+	}
+	purpose := ""
+	if meta.Purpose != nil {
+		purpose = *meta.Purpose
 	}
 
-	if len(timestamps) > 0 {
-		sb.WriteString("\n**Timestamps:**\n")
-		for _, col := range timestamps {
-			s.writeColumnSummary(&sb, col, tc.MetadataByColumnID[col.ID])
-		}
+	switch role {
+	case models.RolePrimaryKey:
+		return columnCategoryPrimaryKey
+	case models.RoleForeignKey:
+		return columnCategoryForeignKey
+	case models.RoleMeasure:
+		return columnCategoryMeasure
+	}
+	switch purpose {
+	case models.PurposeTimestamp:
+		return columnCategoryTimestamp
+	case models.PurposeEnum:
+		return columnCategoryEnum
+	case models.PurposeIdentifier:
+		return columnCategoryIdentifier
 	}
+	return columnCategoryOther
+}
 
-	if len(enums) > 0 {
-		sb.WriteString("\n**Enums/Status:**\n")
-		for _, col := range enums {
-			s.writeColumnSummary(&sb, col, tc.MetadataByColumnID[col.ID])
-		}
+// writeCategorizedColumns writes columns under a heading per non-empty category.
+func (s *tableFeatureExtractionService) writeCategorizedColumns(sb *strings.Builder, columns []*models.SchemaColumn, metadataByColumnID map[uuid.UUID]*models.ColumnMetadata) {
+	byCategory := make(map[string][]*models.SchemaColumn)
+	for _, col := range columns {
+		category := columnCategory(metadataByColumnID[col.ID])
+		byCategory[category] = append(byCategory[category], col)
 	}
 
-	if len(measures) > 0 {
-		sb.WriteString("\n**Measures:**\n")
-		for _, col := range measures {
-			s.writeColumnSummary(&sb, col, tc.MetadataByColumnID[col.ID])
+	first := true
+	for _, category := range columnCategoryOrder {
+		if len(byCategory[category]) == 0 {
+			continue
+		}
+		if !first {
+			sb.WriteString("\n")
+		}
+		first = false
+		fmt.Fprintf(sb, "**%s:**\n", category)
+		for _, col := range byCategory[category] {
+			s.writeColumnSummary(sb, col, metadataByColumnID[col.ID])
 		}
 	}
+}
 
-	if len(identifiers) > 0 {
-		sb.WriteString("\n**Identifiers:**\n")
-		for _, col := range identifiers {
-			s.writeColumnSummary(&sb, col, tc.MetadataByColumnID[col.ID])
+// writeTableContext writes the split, primary key and relationship sections.
+func (s *tableFeatureExtractionService) writeTableContext(sb *strings.Builder, tc *tableContext) {
+	// Add one-to-one split context so the table is described as part of one logical entity
+	if tc.TableSplit != nil && len(tc.TableSplit.LinkedTables) > 0 {
+		sb.WriteString("\n## One-to-One Split\n\n")
+		linked := "`" + strings.Join(tc.TableSplit.LinkedTables, "`, `") + "`"
+		if tc.TableSplit.Role == models.TableSplitRoleExtension {
+			sb.WriteString(fmt.Sprintf("This table shares its primary key with %s (1:1). "+
+				"It is an extension of that entity, not a separate entity: describe it as holding additional attributes of %s.\n",
+				linked, linked))
+		} else {
+			sb.WriteString(fmt.Sprintf("%s share this table's primary key (1:1) and hold extension attributes of this entity. "+
+				"Describe this table as the primary record for the entity.\n", linked))
 		}
 	}
 
-	if len(others) > 0 {
-		sb.WriteString("\n**Other Columns:**\n")
-		for _, col := range others {
-			s.writeColumnSummary(&sb, col, tc.MetadataByColumnID[col.ID])
-		}
+	if tc.NoPrimaryKey {
+		sb.WriteString("\n## No Primary Key\n\n")
+		sb.WriteString("This table has no primary key, so its rows may not be uniquely addressable. " +
+			"Mention in the usage notes how to identify or deduplicate rows if the columns suggest a way.\n")
 	}
 
 	// Add relationship context
@@ -436,11 +869,16 @@ func (s *tableFeatureExtractionService) buildPrompt(tc *tableContext) string {
 			if rel.Cardinality != "" {
 				sb.WriteString(fmt.Sprintf(" [%s]", rel.Cardinality))
 			}
+			if label := tc.RelationshipLabels[relationshipLabelKey(rel.SourceTableName, rel.TargetTableName)]; label != "" {
+				sb.WriteString(fmt.Sprintf(" — %s", label))
+			}
 			sb.WriteString("\n")
 		}
 	}
+}
 
-	// Task and response format
+// writeTableTask writes the analysis task and the expected response format.
+func (s *tableFeatureExtractionService) writeTableTask(sb *strings.Builder) {
 	sb.WriteString("\n## Task\n\n")
 	sb.WriteString("Based on the column features and relationships, determine:\n")
 	sb.WriteString("1. The table type classification\n")
@@ -464,8 +902,6 @@ func (s *tableFeatureExtractionService) buildPrompt(tc *tableContext) string {
 	sb.WriteString("  \"is_ephemeral\": false\n")
 	sb.WriteString("}\n")
 	sb.WriteString("```\n")
-
-	return sb.String()
 }
 
 // writeColumnSummary writes a concise summary of a column and its metadata.
@@ -548,6 +984,10 @@ func (s *tableFeatureExtractionService) storeTableMetadata(
 		meta.TableType = &result.TableType
 	}
 
+	meta.Features.TableSplit = result.TableSplit
+	meta.Features.Bridge = result.Bridge
+	meta.Features.NoPrimaryKey = result.NoPrimaryKey
+
 	return s.tableMetadataRepo.UpsertFromExtraction(ctx, meta)
 }
 
@@ -3,7 +3,9 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 
@@ -184,6 +186,180 @@ func TestTableFeatureExtraction_BuildPrompt_NoRelationships(t *testing.T) {
 	}
 }
 
+func TestTableFeatureExtraction_BuildPrompt_MinRelationshipConfidence(t *testing.T) {
+	svc := &tableFeatureExtractionService{
+		minRelationshipConfidence: 0.9,
+		logger:                    zap.NewNop(),
+	}
+
+	approved := true
+	tables := []*models.SchemaTable{{ID: uuid.New(), TableName: "orders"}}
+	columnsByTable := map[string][]*models.SchemaColumn{
+		"orders": {
+			{ID: uuid.New(), ColumnName: "customer_id", DataType: "uuid"},
+			{ID: uuid.New(), ColumnName: "warehouse_id", DataType: "uuid"},
+			{ID: uuid.New(), ColumnName: "promo_code", DataType: "text"},
+			{ID: uuid.New(), ColumnName: "region_id", DataType: "uuid"},
+		},
+	}
+	relationships := []*models.RelationshipDetail{
+		{
+			SourceTableName: "orders", SourceColumnName: "customer_id",
+			TargetTableName: "customers", TargetColumnName: "id",
+			RelationshipType: models.RelationshipTypeFK, Confidence: 0.5,
+		},
+		{
+			SourceTableName: "orders", SourceColumnName: "warehouse_id",
+			TargetTableName: "warehouses", TargetColumnName: "id",
+			RelationshipType: models.RelationshipTypeInferred, Confidence: 0.95,
+		},
+		{
+			SourceTableName: "orders", SourceColumnName: "promo_code",
+			TargetTableName: "promotions", TargetColumnName: "code",
+			RelationshipType: models.RelationshipTypeInferred, Confidence: 0.6,
+		},
+		{
+			SourceTableName: "orders", SourceColumnName: "region_id",
+			TargetTableName: "regions", TargetColumnName: "id",
+			RelationshipType: models.RelationshipTypeInferred, Confidence: 0.6, IsApproved: &approved,
+		},
+	}
+
+	contexts := svc.buildTableContexts(tables, columnsByTable, relationships, map[uuid.UUID]*models.ColumnMetadata{})
+	if len(contexts) != 1 {
+		t.Fatalf("Expected 1 context, got %d", len(contexts))
+	}
+	prompt := svc.buildPrompt(contexts[0])
+
+	if strings.Contains(prompt, "promotions.code") {
+		t.Error("A 0.6-confidence inferred relationship should be excluded at a 0.9 threshold")
+	}
+	for _, want := range []string{"`customers.id`", "`warehouses.id`", "`regions.id`"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("Prompt should include the relationship to %s (declared FK, high confidence or approved), got:\n%s", want, prompt)
+		}
+	}
+}
+
+func TestTableFeatureExtraction_BuildPrompt_RelationshipLabels(t *testing.T) {
+	svc := &tableFeatureExtractionService{
+		logger: zap.NewNop(),
+	}
+
+	colID := uuid.New()
+	tc := &tableContext{
+		Table: &models.SchemaTable{ID: uuid.New(), TableName: "orders"},
+		Columns: []*models.SchemaColumn{
+			{ID: colID, ColumnName: "customer_id", DataType: "uuid"},
+		},
+		Relationships: []*models.RelationshipDetail{
+			{
+				SourceTableName:  "orders",
+				SourceColumnName: "customer_id",
+				TargetTableName:  "customers",
+				TargetColumnName: "id",
+				Cardinality:      "N:1",
+			},
+			{
+				SourceTableName:  "orders",
+				SourceColumnName: "warehouse_id",
+				TargetTableName:  "warehouses",
+				TargetColumnName: "id",
+			},
+		},
+		MetadataByColumnID: map[uuid.UUID]*models.ColumnMetadata{
+			colID: tfeColMeta(colID, "identifier", "foreign_key", "", "", nil),
+		},
+	}
+	tc.RelationshipLabels = relationshipLabelsFromDetails(tc.Relationships)
+
+	prompt := svc.buildPrompt(tc)
+
+	if !strings.Contains(prompt, "- `customer_id` → `customers.id` [N:1] — each order belongs to one customer\n") {
+		t.Errorf("Prompt should contain labeled relationship, got:\n%s", prompt)
+	}
+	if !strings.Contains(prompt, "- `warehouse_id` → `warehouses.id`\n") {
+		t.Error("Relationship of unknown cardinality should be rendered without a label")
+	}
+}
+
+func TestTableFeatureExtraction_RelationshipLabelsFromDetails(t *testing.T) {
+	rejected := false
+	labels := relationshipLabelsFromDetails([]*models.RelationshipDetail{
+		{SourceTableName: "Orders", TargetTableName: "customers", Cardinality: models.CardinalityNTo1},
+		{SourceTableName: "orders", TargetTableName: "customers", Cardinality: models.Cardinality1To1},
+		{SourceTableName: "users", TargetTableName: "user_profiles", Cardinality: models.Cardinality1To1},
+		{SourceTableName: "categories", TargetTableName: "products", Cardinality: models.Cardinality1ToN},
+		{SourceTableName: "students", TargetTableName: "courses", Cardinality: models.CardinalityNToM},
+		{SourceTableName: "orders", TargetTableName: "warehouses", Cardinality: models.CardinalityUnknown},
+		{SourceTableName: "orders", TargetTableName: "coupons", Cardinality: models.CardinalityNTo1, IsApproved: &rejected},
+	})
+
+	assert.Equal(t, map[string]string{
+		"orders->customers":    "each order belongs to one customer",
+		"users->user_profiles": "each user has at most one user_profile",
+		"categories->products": "each category has many products",
+		"students->courses":    "many students relate to many courses",
+	}, labels)
+}
+
+// TestTableFeatureExtraction_LabelsRelationshipsWithoutDomainSummary runs extraction
+// the way a first run does: no domain summary exists yet, only the relationships
+// FKDiscovery persisted. Their labels must still reach the prompt.
+func TestTableFeatureExtraction_LabelsRelationshipsWithoutDomainSummary(t *testing.T) {
+	responseJSON, _ := json.Marshal(tableAnalysisResponse{Description: "desc", UsageNotes: "notes"})
+	client := &promptRecordingLLMClient{
+		mockLLMClientForTableFeatures: mockLLMClientForTableFeatures{responseContent: string(responseJSON)},
+		prompts:                       make(map[string]string),
+	}
+
+	usersID, ordersID := uuid.New(), uuid.New()
+	usersCol, ordersCol, userFKCol := uuid.New(), uuid.New(), uuid.New()
+	schemaRepo := &mockSchemaRepoForTableFeatures{
+		tables: []*models.SchemaTable{
+			{ID: usersID, TableName: "users"},
+			{ID: ordersID, TableName: "orders"},
+		},
+		columns: []*models.SchemaColumn{
+			{ID: usersCol, SchemaTableID: usersID, ColumnName: "id", DataType: "uuid"},
+			{ID: ordersCol, SchemaTableID: ordersID, ColumnName: "id", DataType: "uuid"},
+			{ID: userFKCol, SchemaTableID: ordersID, ColumnName: "user_id", DataType: "uuid"},
+		},
+		relationshipDetails: []*models.RelationshipDetail{
+			{
+				SourceTableName: "orders", SourceColumnName: "user_id",
+				TargetTableName: "users", TargetColumnName: "id",
+				RelationshipType: models.RelationshipTypeFK, Cardinality: models.CardinalityNTo1, Confidence: 1,
+			},
+		},
+	}
+	colMetadataRepo := &mockColumnMetadataRepoForTableFeatures{
+		metadataList: []*models.ColumnMetadata{
+			tfeColMeta(usersCol, "identifier", "", "", "", nil),
+			tfeColMeta(ordersCol, "identifier", "", "", "", nil),
+			tfeColMeta(userFKCol, "identifier", "foreign_key", "", "", nil),
+		},
+	}
+
+	workerPool := llm.NewWorkerPool(llm.WorkerPoolConfig{MaxConcurrent: 1}, zap.NewNop())
+	svc := NewTableFeatureExtractionService(
+		schemaRepo,
+		colMetadataRepo,
+		&mockTableMetadataRepoForTableFeatures{},
+		&promptRecordingLLMFactory{client: client},
+		workerPool,
+		nil,
+		0,
+		0,
+		zap.NewNop(),
+	)
+
+	_, err := svc.ExtractTableFeatures(context.Background(), uuid.New(), uuid.New(), nil, nil)
+	require.NoError(t, err)
+
+	assert.Contains(t, client.prompts["orders"], "- `user_id` → `users.id` [N:1] — each order belongs to one user\n")
+}
+
 func TestTableFeatureExtraction_BuildPrompt_ColumnGrouping(t *testing.T) {
 	svc := &tableFeatureExtractionService{
 		logger: zap.NewNop(),
@@ -573,9 +749,11 @@ func (m *mockTableMetadataRepoForTableFeatures) UpsertFromExtraction(_ context.C
 // mockLLMClientForTableFeatures provides mock LLM responses for table feature extraction.
 type mockLLMClientForTableFeatures struct {
 	responseContent string
-	generateErr     error
-	callCount       int32
-	lastPrompt      string
+	// groupResponseContent answers wide-table column group prompts when set
+	groupResponseContent string
+	generateErr          error
+	callCount            int32
+	lastPrompt           string
 }
 
 func (m *mockLLMClientForTableFeatures) GenerateResponse(_ context.Context, prompt string, _ string, _ float64, _ bool) (*llm.GenerateResponseResult, error) {
@@ -584,8 +762,12 @@ func (m *mockLLMClientForTableFeatures) GenerateResponse(_ context.Context, prom
 	if m.generateErr != nil {
 		return nil, m.generateErr
 	}
+	content := m.responseContent
+	if m.groupResponseContent != "" && strings.Contains(prompt, "# Column Group Analysis") {
+		content = m.groupResponseContent
+	}
 	return &llm.GenerateResponseResult{
-		Content:          m.responseContent,
+		Content:          content,
 		PromptTokens:     100,
 		CompletionTokens: 50,
 		TotalTokens:      150,
@@ -608,6 +790,10 @@ func (m *mockLLMClientForTableFeatures) GetEndpoint() string {
 	return "test-endpoint"
 }
 
+func (m *mockLLMClientForTableFeatures) GenerateResponseStream(ctx context.Context, prompt string, systemMessage string, temperature float64) (*llm.ResponseStream, error) {
+	return llm.CompletedResponseStream(m.GenerateResponse(ctx, prompt, systemMessage, temperature, false))
+}
+
 // mockLLMFactoryForTableFeatures provides the mock LLM client.
 type mockLLMFactoryForTableFeatures struct {
 	client *mockLLMClientForTableFeatures
@@ -652,6 +838,7 @@ func TestTableFeatureExtraction_ExtractTableFeatures_Success(t *testing.T) {
 				SchemaTableID: tableID,
 				ColumnName:    "id",
 				DataType:      "uuid",
+				IsPrimaryKey:  true,
 			},
 		},
 		relationshipDetails: nil,
@@ -674,13 +861,15 @@ func TestTableFeatureExtraction_ExtractTableFeatures_Success(t *testing.T) {
 		&mockLLMFactoryForTableFeatures{client: mockLLM},
 		workerPool,
 		nil, // no tenant context needed for test
+		0,
+		0,
 		zap.NewNop(),
 	)
 
 	// Execute
 	projectID := uuid.New()
 	datasourceID := uuid.New()
-	count, err := svc.ExtractTableFeatures(context.Background(), projectID, datasourceID, nil)
+	count, err := svc.ExtractTableFeatures(context.Background(), projectID, datasourceID, nil, nil)
 
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
@@ -704,6 +893,130 @@ func TestTableFeatureExtraction_ExtractTableFeatures_Success(t *testing.T) {
 	}
 }
 
+func TestTableFeatureExtraction_WideTableUsesGroupedStrategy(t *testing.T) {
+	responseJSON, _ := json.Marshal(tableAnalysisResponse{
+		TableType:   "transactional",
+		Description: "Customer accounts with billing and shipping details.",
+	})
+	groupJSON, _ := json.Marshal(columnGroupResponse{Summary: "Address fields used for invoicing and delivery."})
+	mockLLM := &mockLLMClientForTableFeatures{
+		responseContent:      string(responseJSON),
+		groupResponseContent: string(groupJSON),
+	}
+
+	tableID := uuid.New()
+	var columns []*models.SchemaColumn
+	var metadata []*models.ColumnMetadata
+	addColumn := func(name, purpose, role string) {
+		col := &models.SchemaColumn{ID: uuid.New(), SchemaTableID: tableID, ColumnName: name, DataType: "text", IsPrimaryKey: role == models.RolePrimaryKey}
+		columns = append(columns, col)
+		metadata = append(metadata, tfeColMeta(col.ID, purpose, role, "", "", nil))
+	}
+	addColumn("id", "identifier", models.RolePrimaryKey)
+	addColumn("org_id", "identifier", models.RoleForeignKey)
+	for _, part := range []string{"street", "city", "zip", "state", "country", "phone", "email", "name"} {
+		addColumn("billing_"+part, "text", "")
+		addColumn("shipping_"+part, "text", "")
+	}
+	for _, name := range []string{"created_at", "updated_at", "deleted_at", "approved_at", "closed_at", "opened_at"} {
+		addColumn(name, "timestamp", "")
+	}
+	for i := 1; i <= 12; i++ {
+		addColumn(fmt.Sprintf("attr%d", i), "text", "")
+	}
+
+	mockMetadataRepo := &mockTableMetadataRepoForTableFeatures{}
+	svc := NewTableFeatureExtractionService(
+		&mockSchemaRepoForTableFeatures{
+			tables:  []*models.SchemaTable{{ID: tableID, TableName: "accounts"}},
+			columns: columns,
+		},
+		&mockColumnMetadataRepoForTableFeatures{metadataList: metadata},
+		mockMetadataRepo,
+		&mockLLMFactoryForTableFeatures{client: mockLLM},
+		llm.NewWorkerPool(llm.WorkerPoolConfig{MaxConcurrent: 1}, zap.NewNop()),
+		nil,
+		10,
+		0,
+		zap.NewNop(),
+	)
+
+	count, err := svc.ExtractTableFeatures(context.Background(), uuid.New(), uuid.New(), nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	// billing_* (8), shipping_* (8), timestamps (6) and 12 other columns split in two
+	// groups of 6, then one synthesis call
+	assert.Equal(t, int32(6), atomic.LoadInt32(&mockLLM.callCount))
+
+	prompt := mockLLM.lastPrompt
+	assert.Contains(t, prompt, "**Column Group Summaries:**")
+	assert.Contains(t, prompt, "the remaining 34 columns were analyzed in groups")
+	assert.Contains(t, prompt, "- `billing_*` columns (8 columns): Address fields used for invoicing and delivery.")
+	assert.Contains(t, prompt, "- Timestamps (6 columns):")
+	assert.Contains(t, prompt, "- Other Columns (part 2 of 2) (6 columns):")
+	assert.Contains(t, prompt, "`org_id`", "key columns are listed directly")
+	assert.NotContains(t, prompt, "`attr7`", "grouped columns are summarized, not listed")
+
+	require.Len(t, mockMetadataRepo.upsertedMetadata, 1)
+	assert.Equal(t, "Customer accounts with billing and shipping details.", *mockMetadataRepo.upsertedMetadata[0].Description)
+}
+
+func TestTableFeatureExtraction_ExtractTableFeatures_OnlyRequestedTables(t *testing.T) {
+	responseJSON, _ := json.Marshal(tableAnalysisResponse{
+		Description: "Products offered in the catalog.",
+		UsageNotes:  "Join to order items by product_id.",
+	})
+	mockLLM := &mockLLMClientForTableFeatures{responseContent: string(responseJSON)}
+
+	usersID, productsID := uuid.New(), uuid.New()
+	usersColID, productsColID := uuid.New(), uuid.New()
+	mockSchemaRepo := &mockSchemaRepoForTableFeatures{
+		tables: []*models.SchemaTable{
+			{ID: usersID, TableName: "users"},
+			{ID: productsID, TableName: "products"},
+		},
+		columns: []*models.SchemaColumn{
+			{ID: usersColID, SchemaTableID: usersID, ColumnName: "id", DataType: "uuid"},
+			{ID: productsColID, SchemaTableID: productsID, ColumnName: "id", DataType: "uuid"},
+		},
+	}
+	mockColMetadataRepo := &mockColumnMetadataRepoForTableFeatures{
+		metadataList: []*models.ColumnMetadata{
+			tfeColMeta(usersColID, "identifier", "", "", "", nil),
+			tfeColMeta(productsColID, "identifier", "", "", "", nil),
+		},
+	}
+	mockMetadataRepo := &mockTableMetadataRepoForTableFeatures{}
+
+	workerPool := llm.NewWorkerPool(llm.WorkerPoolConfig{MaxConcurrent: 2}, zap.NewNop())
+	svc := NewTableFeatureExtractionService(
+		mockSchemaRepo,
+		mockColMetadataRepo,
+		mockMetadataRepo,
+		&mockLLMFactoryForTableFeatures{client: mockLLM},
+		workerPool,
+		nil,
+		0,
+		0,
+		zap.NewNop(),
+	)
+
+	count, err := svc.ExtractTableFeatures(context.Background(), uuid.New(), uuid.New(), []string{"products"}, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 table processed, got %d", count)
+	}
+	if len(mockMetadataRepo.upsertedMetadata) != 1 {
+		t.Fatalf("Expected 1 metadata upsert, got %d", len(mockMetadataRepo.upsertedMetadata))
+	}
+	if got := mockMetadataRepo.upsertedMetadata[0].SchemaTableID; got != productsID {
+		t.Errorf("Expected products to be analyzed, got table %s", got)
+	}
+}
+
 func TestTableFeatureExtraction_IncludesRelevantProjectKnowledgeInPrompt(t *testing.T) {
 	response := tableAnalysisResponse{
 		Description: "Stores user account information.",
@@ -746,19 +1059,126 @@ func TestTableFeatureExtraction_IncludesRelevantProjectKnowledgeInPrompt(t *test
 		&mockLLMFactoryForTableFeatures{client: mockLLM},
 		workerPool,
 		nil,
+		0,
+		0,
 		zap.NewNop(),
 	)
 
 	ctx := withProjectKnowledgeFactsForPrompt(context.Background(), []*models.KnowledgeFact{
 		{FactType: models.FactTypeFiscalYear, Value: "Fiscal year ends on June 30"},
 	})
-	_, err := svc.ExtractTableFeatures(ctx, uuid.New(), uuid.New(), nil)
+	_, err := svc.ExtractTableFeatures(ctx, uuid.New(), uuid.New(), nil, nil)
 
 	require.NoError(t, err)
 	assert.Contains(t, mockLLM.lastPrompt, "## Relevant Project Knowledge")
 	assert.Contains(t, mockLLM.lastPrompt, "Fiscal year ends on June 30")
 }
 
+// newTableFeatureServiceForHints builds a service over users and orders tables whose
+// LLM client records every prompt, keyed by the table named in it.
+func newTableFeatureServiceForHints(t *testing.T) (TableFeatureExtractionService, *promptRecordingLLMClient) {
+	t.Helper()
+	responseJSON, _ := json.Marshal(tableAnalysisResponse{Description: "desc", UsageNotes: "notes"})
+	client := &promptRecordingLLMClient{
+		mockLLMClientForTableFeatures: mockLLMClientForTableFeatures{responseContent: string(responseJSON)},
+		prompts:                       make(map[string]string),
+	}
+
+	usersID, ordersID := uuid.New(), uuid.New()
+	usersCol, ordersCol := uuid.New(), uuid.New()
+	schemaRepo := &mockSchemaRepoForTableFeatures{
+		tables: []*models.SchemaTable{
+			{ID: usersID, TableName: "users"},
+			{ID: ordersID, TableName: "orders"},
+		},
+		columns: []*models.SchemaColumn{
+			{ID: usersCol, SchemaTableID: usersID, ColumnName: "id", DataType: "uuid"},
+			{ID: ordersCol, SchemaTableID: ordersID, ColumnName: "id", DataType: "uuid"},
+		},
+	}
+	colMetadataRepo := &mockColumnMetadataRepoForTableFeatures{
+		metadataList: []*models.ColumnMetadata{
+			tfeColMeta(usersCol, "identifier", "", "", "", nil),
+			tfeColMeta(ordersCol, "identifier", "", "", "", nil),
+		},
+	}
+
+	workerPool := llm.NewWorkerPool(llm.WorkerPoolConfig{MaxConcurrent: 1}, zap.NewNop())
+	svc := NewTableFeatureExtractionService(
+		schemaRepo,
+		colMetadataRepo,
+		&mockTableMetadataRepoForTableFeatures{},
+		&promptRecordingLLMFactory{client: client},
+		workerPool,
+		nil,
+		0,
+		0,
+		zap.NewNop(),
+	)
+	return svc, client
+}
+
+type promptRecordingLLMClient struct {
+	mockLLMClientForTableFeatures
+	mu      sync.Mutex
+	prompts map[string]string // table name -> prompt
+}
+
+func (m *promptRecordingLLMClient) GenerateResponse(ctx context.Context, prompt string, systemMessage string, temperature float64, thinking bool) (*llm.GenerateResponseResult, error) {
+	m.mu.Lock()
+	for _, table := range []string{"users", "orders"} {
+		if strings.Contains(prompt, "**Table:** "+table+"\n") {
+			m.prompts[table] = prompt
+		}
+	}
+	m.mu.Unlock()
+	return m.mockLLMClientForTableFeatures.GenerateResponse(ctx, prompt, systemMessage, temperature, thinking)
+}
+
+type promptRecordingLLMFactory struct {
+	mockLLMFactoryForTableFeatures
+	client *promptRecordingLLMClient
+}
+
+func (m *promptRecordingLLMFactory) CreateForProject(_ context.Context, _ uuid.UUID) (llm.LLMClient, error) {
+	return m.client, nil
+}
+
+func TestTableFeatureExtraction_InjectsEntityHintIntoMatchingTablePrompt(t *testing.T) {
+	svc, client := newTableFeatureServiceForHints(t)
+
+	ctx := withProjectKnowledgeFactsForPrompt(context.Background(), []*models.KnowledgeFact{
+		{FactType: models.FactTypeEntityHint, Value: "Users are internal employees, not customers", Context: "public.Users"},
+		{FactType: models.FactTypeTerminology, Value: "A 'seat' is a paid license"},
+	})
+	_, err := svc.ExtractTableFeatures(ctx, uuid.New(), uuid.New(), nil, nil)
+	require.NoError(t, err)
+
+	usersPrompt := client.prompts["users"]
+	assert.Contains(t, usersPrompt, "## User-Provided Context")
+	assert.Contains(t, usersPrompt, "- Users are internal employees, not customers\n")
+	assert.Contains(t, usersPrompt, "A 'seat' is a paid license")
+
+	ordersPrompt := client.prompts["orders"]
+	assert.NotContains(t, ordersPrompt, "## User-Provided Context")
+	assert.NotContains(t, ordersPrompt, "internal employees", "hints are scoped to the tables they name")
+	assert.Contains(t, ordersPrompt, "A 'seat' is a paid license")
+}
+
+func TestTableFeatureExtraction_NoDescriptionLeavesPromptUnchanged(t *testing.T) {
+	svc, client := newTableFeatureServiceForHints(t)
+
+	_, err := svc.ExtractTableFeatures(context.Background(), uuid.New(), uuid.New(), nil, nil)
+	require.NoError(t, err)
+
+	for table, prompt := range client.prompts {
+		assert.NotContains(t, prompt, "## User-Provided Context", table)
+		assert.NotContains(t, prompt, "## Relevant Project Knowledge", table)
+		assert.True(t, strings.HasPrefix(prompt, "# Table Analysis\n"), table)
+	}
+	assert.Len(t, client.prompts, 2)
+}
+
 func TestTableFeatureExtraction_ExtractTableFeatures_NoTables(t *testing.T) {
 	mockSchemaRepo := &mockSchemaRepoForTableFeatures{
 		tables:              []*models.SchemaTable{},
@@ -777,10 +1197,12 @@ func TestTableFeatureExtraction_ExtractTableFeatures_NoTables(t *testing.T) {
 		nil, // no LLM needed
 		workerPool,
 		nil,
+		0,
+		0,
 		zap.NewNop(),
 	)
 
-	count, err := svc.ExtractTableFeatures(context.Background(), uuid.New(), uuid.New(), nil)
+	count, err := svc.ExtractTableFeatures(context.Background(), uuid.New(), uuid.New(), nil, nil)
 
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
@@ -810,10 +1232,12 @@ func TestTableFeatureExtraction_ExtractTableFeatures_NoColumnsWithFeatures(t *te
 		nil,
 		workerPool,
 		nil,
+		0,
+		0,
 		zap.NewNop(),
 	)
 
-	count, err := svc.ExtractTableFeatures(context.Background(), uuid.New(), uuid.New(), nil)
+	count, err := svc.ExtractTableFeatures(context.Background(), uuid.New(), uuid.New(), nil, nil)
 
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
@@ -869,6 +1293,8 @@ func TestTableFeatureExtraction_ExtractTableFeatures_ProgressCallback(t *testing
 		&mockLLMFactoryForTableFeatures{client: mockLLM},
 		workerPool,
 		nil,
+		0,
+		0,
 		zap.NewNop(),
 	)
 
@@ -887,7 +1313,7 @@ func TestTableFeatureExtraction_ExtractTableFeatures_ProgressCallback(t *testing
 		}{current, total, message})
 	}
 
-	count, err := svc.ExtractTableFeatures(context.Background(), uuid.New(), uuid.New(), progressCallback)
+	count, err := svc.ExtractTableFeatures(context.Background(), uuid.New(), uuid.New(), nil, progressCallback)
 
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
@@ -901,3 +1327,330 @@ func TestTableFeatureExtraction_ExtractTableFeatures_ProgressCallback(t *testing
 		t.Errorf("Expected at least 2 progress calls, got %d", len(progressCalls))
 	}
 }
+
+// ============================================================================
+// One-to-One Split Tests
+// ============================================================================
+
+func TestTableFeatureExtraction_DetectOneToOneSplits(t *testing.T) {
+	usersRows := int64(1000)
+	profileRows := int64(800)
+	tables := []*models.SchemaTable{
+		{ID: uuid.New(), TableName: "users", RowCount: &usersRows},
+		{ID: uuid.New(), TableName: "user_profiles", RowCount: &profileRows},
+		{ID: uuid.New(), TableName: "orders"},
+	}
+	columnsByTable := map[string][]*models.SchemaColumn{
+		"users": {
+			{ColumnName: "id", IsPrimaryKey: true},
+			{ColumnName: "email"},
+		},
+		"user_profiles": {
+			{ColumnName: "id", IsPrimaryKey: true},
+			{ColumnName: "bio"},
+		},
+		"orders": {
+			{ColumnName: "id", IsPrimaryKey: true},
+			{ColumnName: "user_id"},
+		},
+	}
+
+	t.Run("shared PK is classified as a 1:1 split", func(t *testing.T) {
+		relationships := []*models.RelationshipDetail{
+			{SourceTableName: "user_profiles", SourceColumnName: "id", TargetTableName: "users", TargetColumnName: "id", Cardinality: models.Cardinality1To1},
+			{SourceTableName: "orders", SourceColumnName: "user_id", TargetTableName: "users", TargetColumnName: "id", Cardinality: models.CardinalityNTo1},
+		}
+
+		splits := detectOneToOneSplits(tables, columnsByTable, relationships)
+
+		if len(splits) != 2 {
+			t.Fatalf("expected 2 tables in split, got %d: %v", len(splits), splits)
+		}
+		ext := splits["user_profiles"]
+		if ext == nil || ext.Role != models.TableSplitRoleExtension || len(ext.LinkedTables) != 1 || ext.LinkedTables[0] != "users" {
+			t.Errorf("expected user_profiles to be an extension of users, got %+v", ext)
+		}
+		primary := splits["users"]
+		if primary == nil || primary.Role != models.TableSplitRolePrimary || len(primary.LinkedTables) != 1 || primary.LinkedTables[0] != "user_profiles" {
+			t.Errorf("expected users to be the primary with extension user_profiles, got %+v", primary)
+		}
+		if splits["orders"] != nil {
+			t.Errorf("orders references users by a non-PK column and must not be a split, got %+v", splits["orders"])
+		}
+	})
+
+	t.Run("both directions stored picks one primary", func(t *testing.T) {
+		relationships := []*models.RelationshipDetail{
+			{SourceTableName: "users", SourceColumnName: "id", TargetTableName: "user_profiles", TargetColumnName: "id", Cardinality: models.Cardinality1To1},
+			{SourceTableName: "user_profiles", SourceColumnName: "id", TargetTableName: "users", TargetColumnName: "id", Cardinality: models.Cardinality1To1},
+		}
+
+		splits := detectOneToOneSplits(tables, columnsByTable, relationships)
+
+		if splits["users"] == nil || splits["users"].Role != models.TableSplitRolePrimary {
+			t.Errorf("expected users (more rows) to be primary, got %+v", splits["users"])
+		}
+		if splits["user_profiles"] == nil || splits["user_profiles"].Role != models.TableSplitRoleExtension {
+			t.Errorf("expected user_profiles to be extension, got %+v", splits["user_profiles"])
+		}
+	})
+
+	t.Run("explicit non 1:1 cardinality is not a split", func(t *testing.T) {
+		relationships := []*models.RelationshipDetail{
+			{SourceTableName: "user_profiles", SourceColumnName: "id", TargetTableName: "users", TargetColumnName: "id", Cardinality: models.CardinalityNTo1},
+		}
+
+		if splits := detectOneToOneSplits(tables, columnsByTable, relationships); len(splits) != 0 {
+			t.Errorf("expected no splits, got %v", splits)
+		}
+	})
+}
+
+func TestTableFeatureExtraction_OneToOneSplitPromptAndStorage(t *testing.T) {
+	responseJSON, _ := json.Marshal(tableAnalysisResponse{
+		TableType:   "transactional",
+		Description: "Extended profile attributes for users.",
+	})
+	mockLLM := &mockLLMClientForTableFeatures{responseContent: string(responseJSON)}
+
+	usersID := uuid.New()
+	profilesID := uuid.New()
+	mockSchemaRepo := &mockSchemaRepoForTableFeatures{
+		tables: []*models.SchemaTable{
+			{ID: usersID, TableName: "users"},
+			{ID: profilesID, TableName: "user_profiles"},
+		},
+		columns: []*models.SchemaColumn{
+			{ID: uuid.New(), SchemaTableID: usersID, ColumnName: "id", DataType: "uuid", IsPrimaryKey: true},
+			{ID: uuid.New(), SchemaTableID: profilesID, ColumnName: "id", DataType: "uuid", IsPrimaryKey: true},
+		},
+		relationshipDetails: []*models.RelationshipDetail{
+			{SourceTableName: "user_profiles", SourceColumnName: "id", TargetTableName: "users", TargetColumnName: "id", Cardinality: models.Cardinality1To1},
+		},
+	}
+	mockMetadataRepo := &mockTableMetadataRepoForTableFeatures{}
+
+	workerPool := llm.NewWorkerPool(llm.WorkerPoolConfig{MaxConcurrent: 2}, zap.NewNop())
+	svc := NewTableFeatureExtractionService(
+		mockSchemaRepo,
+		&mockColumnMetadataRepoForTableFeatures{},
+		mockMetadataRepo,
+		&mockLLMFactoryForTableFeatures{client: mockLLM},
+		workerPool,
+		nil,
+		0,
+		0,
+		zap.NewNop(),
+	)
+
+	if _, err := svc.ExtractTableFeatures(context.Background(), uuid.New(), uuid.New(), nil, nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(mockMetadataRepo.upsertedMetadata) != 2 {
+		t.Fatalf("Expected 2 metadata upserts, got %d", len(mockMetadataRepo.upsertedMetadata))
+	}
+
+	for _, meta := range mockMetadataRepo.upsertedMetadata {
+		split := meta.GetTableSplit()
+		if split == nil {
+			t.Fatalf("Expected table split features for table %s", meta.SchemaTableID)
+		}
+		switch meta.SchemaTableID {
+		case usersID:
+			if split.Role != models.TableSplitRolePrimary {
+				t.Errorf("users role = %q, want %q", split.Role, models.TableSplitRolePrimary)
+			}
+		case profilesID:
+			if split.Role != models.TableSplitRoleExtension {
+				t.Errorf("user_profiles role = %q, want %q", split.Role, models.TableSplitRoleExtension)
+			}
+		}
+	}
+
+	svcImpl := svc.(*tableFeatureExtractionService)
+	prompt := svcImpl.buildPrompt(&tableContext{
+		Table:      &models.SchemaTable{TableName: "user_profiles"},
+		TableSplit: &models.TableSplitFeatures{Role: models.TableSplitRoleExtension, LinkedTables: []string{"users"}},
+	})
+	if !strings.Contains(prompt, "## One-to-One Split") || !strings.Contains(prompt, "extension of that entity") {
+		t.Errorf("Expected extension prompt section, got:\n%s", prompt)
+	}
+}
+
+func TestTableFeatureExtraction_DetectBridgeTables(t *testing.T) {
+	columnsByTable := map[string][]*models.SchemaColumn{
+		"student_courses": {
+			{ColumnName: "student_id", DataType: "uuid", IsPrimaryKey: true},
+			{ColumnName: "course_id", DataType: "uuid", IsPrimaryKey: true},
+			{ColumnName: "created_at", DataType: "timestamp with time zone"},
+		},
+		"enrollments": {
+			{ColumnName: "id", DataType: "uuid", IsPrimaryKey: true},
+			{ColumnName: "student_id", DataType: "uuid"},
+			{ColumnName: "course_id", DataType: "uuid"},
+			{ColumnName: "grade", DataType: "text"},
+		},
+		"orders": {
+			{ColumnName: "id", DataType: "uuid", IsPrimaryKey: true},
+			{ColumnName: "user_id", DataType: "uuid"},
+		},
+	}
+	relationships := []*models.RelationshipDetail{
+		{SourceTableName: "student_courses", SourceColumnName: "student_id", TargetTableName: "students", TargetColumnName: "id"},
+		{SourceTableName: "student_courses", SourceColumnName: "course_id", TargetTableName: "courses", TargetColumnName: "id"},
+		{SourceTableName: "enrollments", SourceColumnName: "student_id", TargetTableName: "students", TargetColumnName: "id"},
+		{SourceTableName: "enrollments", SourceColumnName: "course_id", TargetTableName: "courses", TargetColumnName: "id"},
+		{SourceTableName: "orders", SourceColumnName: "user_id", TargetTableName: "users", TargetColumnName: "id"},
+	}
+
+	bridges := detectBridgeTables(columnsByTable, relationships)
+
+	if len(bridges) != 1 {
+		t.Fatalf("expected only student_courses to be a bridge, got %v", bridges)
+	}
+	bridge := bridges["student_courses"]
+	if bridge == nil || len(bridge.LinkedTables) != 2 || bridge.LinkedTables[0] != "courses" || bridge.LinkedTables[1] != "students" {
+		t.Errorf("expected student_courses to link courses and students, got %+v", bridge)
+	}
+	if bridges["enrollments"] != nil {
+		t.Errorf("enrollments carries a grade and must stay an entity, got %+v", bridges["enrollments"])
+	}
+}
+
+func TestTableFeatureExtraction_BridgeTableIsNotAnalyzedAsEntity(t *testing.T) {
+	responseJSON, _ := json.Marshal(tableAnalysisResponse{
+		TableType:   "transactional",
+		Description: "Students enrolled at the school.",
+	})
+	mockLLM := &mockLLMClientForTableFeatures{responseContent: string(responseJSON)}
+
+	studentsID := uuid.New()
+	bridgeID := uuid.New()
+	mockSchemaRepo := &mockSchemaRepoForTableFeatures{
+		tables: []*models.SchemaTable{
+			{ID: studentsID, TableName: "students"},
+			{ID: bridgeID, TableName: "student_courses"},
+		},
+		columns: []*models.SchemaColumn{
+			{ID: uuid.New(), SchemaTableID: studentsID, ColumnName: "id", DataType: "uuid", IsPrimaryKey: true},
+			{ID: uuid.New(), SchemaTableID: bridgeID, ColumnName: "student_id", DataType: "uuid", IsPrimaryKey: true},
+			{ID: uuid.New(), SchemaTableID: bridgeID, ColumnName: "course_id", DataType: "uuid", IsPrimaryKey: true},
+		},
+		relationshipDetails: []*models.RelationshipDetail{
+			{SourceTableName: "student_courses", SourceColumnName: "student_id", TargetTableName: "students", TargetColumnName: "id"},
+			{SourceTableName: "student_courses", SourceColumnName: "course_id", TargetTableName: "courses", TargetColumnName: "id"},
+		},
+	}
+	mockMetadataRepo := &mockTableMetadataRepoForTableFeatures{}
+
+	workerPool := llm.NewWorkerPool(llm.WorkerPoolConfig{MaxConcurrent: 2}, zap.NewNop())
+	svc := NewTableFeatureExtractionService(
+		mockSchemaRepo,
+		&mockColumnMetadataRepoForTableFeatures{},
+		mockMetadataRepo,
+		&mockLLMFactoryForTableFeatures{client: mockLLM},
+		workerPool,
+		nil,
+		0,
+		0,
+		zap.NewNop(),
+	)
+
+	if _, err := svc.ExtractTableFeatures(context.Background(), uuid.New(), uuid.New(), nil, nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if calls := atomic.LoadInt32(&mockLLM.callCount); calls != 1 {
+		t.Errorf("Expected 1 LLM call (students only), got %d", calls)
+	}
+
+	var bridgeMeta *models.TableMetadata
+	for _, meta := range mockMetadataRepo.upsertedMetadata {
+		if meta.SchemaTableID == bridgeID {
+			bridgeMeta = meta
+		}
+	}
+	if bridgeMeta == nil {
+		t.Fatal("Expected metadata for student_courses")
+	}
+	if bridgeMeta.TableType == nil || *bridgeMeta.TableType != models.TableTypeJunction {
+		t.Errorf("student_courses table type = %v, want %q", bridgeMeta.TableType, models.TableTypeJunction)
+	}
+	if bridge := bridgeMeta.GetBridge(); bridge == nil || len(bridge.LinkedTables) != 2 {
+		t.Errorf("Expected bridge features linking two tables, got %+v", bridge)
+	}
+	if !strings.Contains(*bridgeMeta.Description, "many-to-many relationship between courses and students") {
+		t.Errorf("Expected M:N description, got %q", *bridgeMeta.Description)
+	}
+}
+
+func TestTableFeatureExtraction_DetectsTableWithoutPrimaryKey(t *testing.T) {
+	responseJSON, _ := json.Marshal(tableAnalysisResponse{
+		TableType:   "logging",
+		Description: "Raw click events imported from the web tracker.",
+	})
+	mockLLM := &mockLLMClientForTableFeatures{responseContent: string(responseJSON)}
+
+	usersID := uuid.New()
+	eventsID := uuid.New()
+	mockSchemaRepo := &mockSchemaRepoForTableFeatures{
+		tables: []*models.SchemaTable{
+			{ID: usersID, TableName: "users"},
+			{ID: eventsID, TableName: "click_events"},
+		},
+		columns: []*models.SchemaColumn{
+			{ID: uuid.New(), SchemaTableID: usersID, ColumnName: "id", DataType: "uuid", IsPrimaryKey: true},
+			{ID: uuid.New(), SchemaTableID: eventsID, ColumnName: "user_id", DataType: "uuid"},
+			{ID: uuid.New(), SchemaTableID: eventsID, ColumnName: "clicked_at", DataType: "timestamp"},
+		},
+	}
+	mockMetadataRepo := &mockTableMetadataRepoForTableFeatures{}
+
+	workerPool := llm.NewWorkerPool(llm.WorkerPoolConfig{MaxConcurrent: 2}, zap.NewNop())
+	svc := NewTableFeatureExtractionService(
+		mockSchemaRepo,
+		&mockColumnMetadataRepoForTableFeatures{},
+		mockMetadataRepo,
+		&mockLLMFactoryForTableFeatures{client: mockLLM},
+		workerPool,
+		nil,
+		0,
+		0,
+		zap.NewNop(),
+	)
+
+	if _, err := svc.ExtractTableFeatures(context.Background(), uuid.New(), uuid.New(), nil, nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(mockMetadataRepo.upsertedMetadata) != 2 {
+		t.Fatalf("Expected 2 metadata upserts, got %d", len(mockMetadataRepo.upsertedMetadata))
+	}
+
+	for _, meta := range mockMetadataRepo.upsertedMetadata {
+		switch meta.SchemaTableID {
+		case eventsID:
+			if !meta.Features.NoPrimaryKey {
+				t.Error("Expected click_events to be flagged as having no primary key")
+			}
+			want := "Raw click events imported from the web tracker. " + models.TableNoPrimaryKeyNote
+			if meta.Description == nil || *meta.Description != want {
+				t.Errorf("click_events description = %v, want %q", meta.Description, want)
+			}
+		case usersID:
+			if meta.Features.NoPrimaryKey {
+				t.Error("Expected users not to be flagged as having no primary key")
+			}
+			if meta.Description != nil && strings.Contains(*meta.Description, models.TableNoPrimaryKeyNote) {
+				t.Errorf("users description should not carry the no-PK note: %q", *meta.Description)
+			}
+		}
+	}
+
+	svcImpl := svc.(*tableFeatureExtractionService)
+	prompt := svcImpl.buildPrompt(&tableContext{
+		Table:        &models.SchemaTable{TableName: "click_events"},
+		NoPrimaryKey: true,
+	})
+	if !strings.Contains(prompt, "## No Primary Key") {
+		t.Errorf("Expected no primary key prompt section, got:\n%s", prompt)
+	}
+}
@@ -0,0 +1,344 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+	"github.com/ekaya-inc/ekaya-engine/pkg/repositories"
+)
+
+// TableQualityService computes a deterministic quality score for each table's
+// ontology metadata and stores it in the table's features. The score is LLM-free so
+// it can be refreshed cheaply after every extraction or edit, and lets reviewers
+// work through the weakest tables first.
+type TableQualityService interface {
+	// RefreshProject recomputes the score for every table with metadata and returns
+	// the number of tables scored.
+	RefreshProject(ctx context.Context, projectID uuid.UUID) (int, error)
+
+	// RefreshTable recomputes the score for a single table.
+	RefreshTable(ctx context.Context, projectID uuid.UUID, tableName string) error
+}
+
+type tableQualityService struct {
+	schemaRepo         repositories.SchemaRepository
+	tableMetadataRepo  repositories.TableMetadataRepository
+	columnMetadataRepo repositories.ColumnMetadataRepository
+	questionRepo       repositories.OntologyQuestionRepository
+	logger             *zap.Logger
+}
+
+// NewTableQualityService creates a new table quality service.
+func NewTableQualityService(
+	schemaRepo repositories.SchemaRepository,
+	tableMetadataRepo repositories.TableMetadataRepository,
+	columnMetadataRepo repositories.ColumnMetadataRepository,
+	questionRepo repositories.OntologyQuestionRepository,
+	logger *zap.Logger,
+) TableQualityService {
+	return &tableQualityService{
+		schemaRepo:         schemaRepo,
+		tableMetadataRepo:  tableMetadataRepo,
+		columnMetadataRepo: columnMetadataRepo,
+		questionRepo:       questionRepo,
+		logger:             logger.Named("table-quality"),
+	}
+}
+
+var _ TableQualityService = (*tableQualityService)(nil)
+
+// Component weights for TableQualityFeatures.Score. They sum to 100.
+const (
+	tableQualityWeightDescription   = 35
+	tableQualityWeightKeyColumns    = 25
+	tableQualityWeightRelationships = 20
+	tableQualityWeightQuestions     = 20
+)
+
+// genericDescriptionPhrases mark descriptions that say nothing a reader couldn't
+// guess from the table name.
+var genericDescriptionPhrases = []string{
+	"stores data",
+	"stores information",
+	"contains data",
+	"contains information",
+	"contains records",
+	"holds data",
+	"data about",
+	"information about",
+	"table for storing",
+	"table that stores",
+	"this table stores",
+	"this table contains",
+	"various",
+	"miscellaneous",
+}
+
+// tableQualityInput is everything computeTableQuality looks at for one table.
+type tableQualityInput struct {
+	Metadata        *models.TableMetadata
+	Columns         []*models.SchemaColumn
+	ColumnMetadata  map[uuid.UUID]*models.ColumnMetadata
+	RelatedColumns  map[string]bool // Lowercased columns of this table that take part in a relationship
+	HasRelationship bool
+	PendingRequired int
+	PendingOptional int
+	ComputedAt      time.Time
+}
+
+func (s *tableQualityService) RefreshProject(ctx context.Context, projectID uuid.UUID) (int, error) {
+	tables, err := s.schemaRepo.ListTablesByDatasource(ctx, projectID, uuid.Nil)
+	if err != nil {
+		return 0, fmt.Errorf("list tables: %w", err)
+	}
+	return s.refresh(ctx, projectID, tables)
+}
+
+func (s *tableQualityService) RefreshTable(ctx context.Context, projectID uuid.UUID, tableName string) error {
+	tables, err := s.schemaRepo.GetTablesByNames(ctx, projectID, []string{tableName})
+	if err != nil {
+		return fmt.Errorf("get table: %w", err)
+	}
+	table, ok := tables[tableName]
+	if !ok {
+		return nil
+	}
+	_, err = s.refresh(ctx, projectID, []*models.SchemaTable{table})
+	return err
+}
+
+func (s *tableQualityService) refresh(ctx context.Context, projectID uuid.UUID, tables []*models.SchemaTable) (int, error) {
+	if len(tables) == 0 {
+		return 0, nil
+	}
+
+	tableNames := make([]string, 0, len(tables))
+	for _, t := range tables {
+		tableNames = append(tableNames, t.TableName)
+	}
+
+	metaByTable, err := s.tableMetadataRepo.ListByTableNames(ctx, projectID, tableNames)
+	if err != nil {
+		return 0, fmt.Errorf("list table metadata: %w", err)
+	}
+	if len(metaByTable) == 0 {
+		return 0, nil
+	}
+
+	columnsByTable, err := s.schemaRepo.GetColumnsByTables(ctx, projectID, tableNames)
+	if err != nil {
+		return 0, fmt.Errorf("get columns by tables: %w", err)
+	}
+
+	var columnIDs []uuid.UUID
+	for _, columns := range columnsByTable {
+		for _, col := range columns {
+			columnIDs = append(columnIDs, col.ID)
+		}
+	}
+	columnMeta := make(map[uuid.UUID]*models.ColumnMetadata)
+	if len(columnIDs) > 0 {
+		metas, err := s.columnMetadataRepo.GetBySchemaColumnIDs(ctx, columnIDs)
+		if err != nil {
+			return 0, fmt.Errorf("get column metadata: %w", err)
+		}
+		for _, m := range metas {
+			columnMeta[m.SchemaColumnID] = m
+		}
+	}
+
+	relationships, err := s.schemaRepo.GetRelationshipDetails(ctx, projectID, uuid.Nil)
+	if err != nil {
+		return 0, fmt.Errorf("get relationships: %w", err)
+	}
+	relatedColumns := make(map[string]map[string]bool)
+	markRelated := func(table, column string) {
+		table = strings.ToLower(table)
+		if relatedColumns[table] == nil {
+			relatedColumns[table] = make(map[string]bool)
+		}
+		relatedColumns[table][strings.ToLower(column)] = true
+	}
+	for _, r := range relationships {
+		markRelated(r.SourceTableName, r.SourceColumnName)
+		markRelated(r.TargetTableName, r.TargetColumnName)
+	}
+
+	pendingRequired := make(map[string]int)
+	pendingOptional := make(map[string]int)
+	questions, err := s.questionRepo.ListPending(ctx, projectID)
+	if err != nil {
+		return 0, fmt.Errorf("list pending questions: %w", err)
+	}
+	for _, q := range questions {
+		for table := range questionTables(q) {
+			if q.IsRequired {
+				pendingRequired[table]++
+			} else {
+				pendingOptional[table]++
+			}
+		}
+	}
+
+	now := time.Now().UTC()
+	scored := 0
+	for _, t := range tables {
+		meta, ok := metaByTable[t.TableName]
+		if !ok {
+			continue
+		}
+		key := strings.ToLower(t.TableName)
+		quality := computeTableQuality(tableQualityInput{
+			Metadata:        meta,
+			Columns:         columnsByTable[t.TableName],
+			ColumnMetadata:  columnMeta,
+			RelatedColumns:  relatedColumns[key],
+			HasRelationship: len(relatedColumns[key]) > 0,
+			PendingRequired: pendingRequired[key],
+			PendingOptional: pendingOptional[key],
+			ComputedAt:      now,
+		})
+		if err := s.tableMetadataRepo.UpdateQuality(ctx, meta.SchemaTableID, quality); err != nil {
+			return scored, fmt.Errorf("update quality for %s: %w", t.TableName, err)
+		}
+		scored++
+	}
+
+	s.logger.Debug("Refreshed table quality scores",
+		zap.String("project_id", projectID.String()),
+		zap.Int("tables_scored", scored))
+	return scored, nil
+}
+
+// questionTables returns the lowercased tables a question is about: its source
+// table plus any tables or table-qualified columns it affects.
+func questionTables(q *models.OntologyQuestion) map[string]bool {
+	tables := make(map[string]bool)
+	if q.SourceEntityType == "table" && q.SourceEntityKey != "" {
+		tables[strings.ToLower(q.SourceEntityKey)] = true
+	}
+	if q.Affects != nil {
+		for _, t := range q.Affects.Tables {
+			tables[strings.ToLower(t)] = true
+		}
+		for _, c := range q.Affects.Columns {
+			if table, _, ok := strings.Cut(c, "."); ok {
+				tables[strings.ToLower(table)] = true
+			}
+		}
+	}
+	return tables
+}
+
+// computeTableQuality scores a table's documentation on four components:
+//   - description: present, specific, not generic, with usage notes
+//   - key columns: has a primary key, *_id columns resolve to relationships, columns are described
+//   - relationships: the table joins to something (ephemeral tables are exempt)
+//   - questions: few pending questions remain about the table
+func computeTableQuality(in tableQualityInput) *models.TableQualityFeatures {
+	q := &models.TableQualityFeatures{ComputedAt: in.ComputedAt}
+
+	q.Description, q.Issues = scoreTableDescription(in.Metadata, q.Issues)
+
+	keyColumns, issues := scoreTableKeyColumns(in, q.Issues)
+	q.KeyColumns, q.Issues = keyColumns, issues
+
+	switch {
+	case in.HasRelationship, in.Metadata != nil && in.Metadata.IsEphemeral:
+		q.Relationships = 100
+	default:
+		q.Issues = append(q.Issues, "no relationships to other tables")
+	}
+
+	q.Questions = 100 - 25*in.PendingRequired - 5*in.PendingOptional
+	if q.Questions < 0 {
+		q.Questions = 0
+	}
+	if in.PendingRequired > 0 {
+		q.Issues = append(q.Issues, fmt.Sprintf("%d required question(s) pending", in.PendingRequired))
+	}
+
+	q.Score = (tableQualityWeightDescription*q.Description +
+		tableQualityWeightKeyColumns*q.KeyColumns +
+		tableQualityWeightRelationships*q.Relationships +
+		tableQualityWeightQuestions*q.Questions) / 100
+	return q
+}
+
+func scoreTableDescription(meta *models.TableMetadata, issues []string) (int, []string) {
+	if meta == nil || meta.Description == nil || strings.TrimSpace(*meta.Description) == "" {
+		return 0, append(issues, "missing description")
+	}
+
+	desc := strings.ToLower(strings.TrimSpace(*meta.Description))
+	score := 100
+	if len(strings.Fields(desc)) < 6 {
+		score -= 40
+		issues = append(issues, "description is too short")
+	}
+	for _, phrase := range genericDescriptionPhrases {
+		if strings.Contains(desc, phrase) {
+			score -= 30
+			issues = append(issues, "description is generic")
+			break
+		}
+	}
+	if meta.UsageNotes == nil || strings.TrimSpace(*meta.UsageNotes) == "" {
+		score -= 10
+		issues = append(issues, "missing usage notes")
+	}
+	if score < 0 {
+		score = 0
+	}
+	return score, issues
+}
+
+func scoreTableKeyColumns(in tableQualityInput, issues []string) (int, []string) {
+	if len(in.Columns) == 0 {
+		return 0, append(issues, "no columns")
+	}
+
+	score := 0
+	hasPK := false
+	var idColumns, resolvedIDColumns, described int
+	for _, col := range in.Columns {
+		if col.IsPrimaryKey {
+			hasPK = true
+		}
+		name := strings.ToLower(col.ColumnName)
+		if !col.IsPrimaryKey && strings.HasSuffix(name, "_id") {
+			idColumns++
+			if in.RelatedColumns[name] {
+				resolvedIDColumns++
+			}
+		}
+		if meta := in.ColumnMetadata[col.ID]; meta != nil && meta.Description != nil && strings.TrimSpace(*meta.Description) != "" {
+			described++
+		}
+	}
+
+	if hasPK {
+		score += 40
+	} else {
+		issues = append(issues, "no primary key")
+	}
+	if idColumns == 0 {
+		score += 30
+	} else {
+		score += 30 * resolvedIDColumns / idColumns
+		if unresolved := idColumns - resolvedIDColumns; unresolved > 0 {
+			issues = append(issues, fmt.Sprintf("%d *_id column(s) without a relationship", unresolved))
+		}
+	}
+	score += 30 * described / len(in.Columns)
+	if described < len(in.Columns) {
+		issues = append(issues, fmt.Sprintf("%d of %d columns undescribed", len(in.Columns)-described, len(in.Columns)))
+	}
+	return score, issues
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+)
+
+func TestComputeTableQuality_WellDocumentedScoresAboveGeneric(t *testing.T) {
+	now := time.Now()
+	pk := &models.SchemaColumn{ID: uuid.New(), ColumnName: "id", IsPrimaryKey: true}
+	userID := &models.SchemaColumn{ID: uuid.New(), ColumnName: "user_id"}
+	total := &models.SchemaColumn{ID: uuid.New(), ColumnName: "total_cents"}
+	columns := []*models.SchemaColumn{pk, userID, total}
+
+	documented := computeTableQuality(tableQualityInput{
+		Metadata: &models.TableMetadata{
+			Description: strPtr("One row per checkout; totals are captured at purchase time and never recalculated."),
+			UsageNotes:  strPtr("Use for revenue reporting. Exclude rows where status = 'test'."),
+		},
+		Columns: columns,
+		ColumnMetadata: map[uuid.UUID]*models.ColumnMetadata{
+			pk.ID:     {Description: strPtr("Order identifier")},
+			userID.ID: {Description: strPtr("Buyer who placed the order")},
+			total.ID:  {Description: strPtr("Order total in cents")},
+		},
+		RelatedColumns:  map[string]bool{"user_id": true},
+		HasRelationship: true,
+		ComputedAt:      now,
+	})
+
+	generic := computeTableQuality(tableQualityInput{
+		Metadata: &models.TableMetadata{
+			Description: strPtr("This table stores data."),
+		},
+		Columns:         []*models.SchemaColumn{userID, total},
+		ColumnMetadata:  map[uuid.UUID]*models.ColumnMetadata{},
+		PendingRequired: 2,
+		PendingOptional: 1,
+		ComputedAt:      now,
+	})
+
+	assert.Equal(t, 100, documented.Score)
+	assert.Empty(t, documented.Issues)
+
+	assert.Less(t, generic.Score, documented.Score)
+	assert.Equal(t, 20, generic.Description) // short (-40), generic (-30), no usage notes (-10)
+	assert.Equal(t, 0, generic.KeyColumns)
+	assert.Equal(t, 0, generic.Relationships)
+	assert.Equal(t, 45, generic.Questions)
+	assert.Equal(t, (35*20+20*45)/100, generic.Score)
+	assert.Contains(t, generic.Issues, "description is generic")
+	assert.Contains(t, generic.Issues, "no primary key")
+	assert.Contains(t, generic.Issues, "no relationships to other tables")
+	assert.Contains(t, generic.Issues, "2 required question(s) pending")
+}
+
+func TestComputeTableQuality_EphemeralTableNeedsNoRelationships(t *testing.T) {
+	q := computeTableQuality(tableQualityInput{
+		Metadata: &models.TableMetadata{IsEphemeral: true},
+	})
+
+	assert.Equal(t, 100, q.Relationships)
+	assert.Equal(t, 0, q.Description)
+	assert.Contains(t, q.Issues, "missing description")
+}
+
+func TestQuestionTables(t *testing.T) {
+	q := &models.OntologyQuestion{
+		SourceEntityType: "table",
+		SourceEntityKey:  "Orders",
+		Affects: &models.QuestionAffects{
+			Tables:  []string{"users"},
+			Columns: []string{"payments.status", "unqualified"},
+		},
+	}
+
+	assert.Equal(t, map[string]bool{"orders": true, "users": true, "payments": true}, questionTables(q))
+}
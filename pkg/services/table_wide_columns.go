@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/llm"
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+)
+
+// wideTablePrefixMinColumns is how many columns must share a name prefix
+// (billing_street, billing_city, billing_zip) for the prefix to get its own group.
+const wideTablePrefixMinColumns = 3
+
+// columnGroup is a set of columns of a wide table summarized in one LLM call.
+type columnGroup struct {
+	Label   string
+	Columns []*models.SchemaColumn
+}
+
+// groupWideTableColumns splits a wide table's columns for the grouped strategy.
+// Primary and foreign keys are returned separately: they identify the entity and
+// are shown to the synthesis prompt directly. The remaining columns are grouped by
+// a shared name prefix, then by columnCategory, and groups above maxColumns are
+// split into even parts so no group prompt is wider than the threshold.
+func groupWideTableColumns(
+	columns []*models.SchemaColumn,
+	metadataByColumnID map[uuid.UUID]*models.ColumnMetadata,
+	maxColumns int,
+) ([]*models.SchemaColumn, []columnGroup) {
+	var keyColumns, rest []*models.SchemaColumn
+	prefixCounts := make(map[string]int)
+	for _, col := range columns {
+		switch columnCategory(metadataByColumnID[col.ID]) {
+		case columnCategoryPrimaryKey, columnCategoryForeignKey:
+			keyColumns = append(keyColumns, col)
+		default:
+			rest = append(rest, col)
+			if prefix := columnNamePrefix(col.ColumnName); prefix != "" {
+				prefixCounts[prefix]++
+			}
+		}
+	}
+
+	byPrefix := make(map[string][]*models.SchemaColumn)
+	byCategory := make(map[string][]*models.SchemaColumn)
+	for _, col := range rest {
+		if prefix := columnNamePrefix(col.ColumnName); prefixCounts[prefix] >= wideTablePrefixMinColumns {
+			byPrefix[prefix] = append(byPrefix[prefix], col)
+			continue
+		}
+		category := columnCategory(metadataByColumnID[col.ID])
+		byCategory[category] = append(byCategory[category], col)
+	}
+
+	prefixes := make([]string, 0, len(byPrefix))
+	for prefix := range byPrefix {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+
+	var groups []columnGroup
+	for _, prefix := range prefixes {
+		groups = append(groups, splitColumnGroup(fmt.Sprintf("`%s_*` columns", prefix), byPrefix[prefix], maxColumns)...)
+	}
+	for _, category := range columnCategoryOrder {
+		if len(byCategory[category]) > 0 {
+			groups = append(groups, splitColumnGroup(category, byCategory[category], maxColumns)...)
+		}
+	}
+	return keyColumns, groups
+}
+
+// columnNamePrefix returns the part of a column name before its first underscore,
+// or "" when the name has none.
+func columnNamePrefix(name string) string {
+	prefix, _, found := strings.Cut(strings.ToLower(name), "_")
+	if !found {
+		return ""
+	}
+	return prefix
+}
+
+// splitColumnGroup splits columns into the fewest near-equal parts of at most
+// maxColumns each.
+func splitColumnGroup(label string, columns []*models.SchemaColumn, maxColumns int) []columnGroup {
+	if maxColumns <= 0 || len(columns) <= maxColumns {
+		return []columnGroup{{Label: label, Columns: columns}}
+	}
+	parts := (len(columns) + maxColumns - 1) / maxColumns
+	groups := make([]columnGroup, 0, parts)
+	for i := 0; i < parts; i++ {
+		start, end := i*len(columns)/parts, (i+1)*len(columns)/parts
+		groups = append(groups, columnGroup{
+			Label:   fmt.Sprintf("%s (part %d of %d)", label, i+1, parts),
+			Columns: columns[start:end],
+		})
+	}
+	return groups
+}
+
+// isWideTable reports whether the table has more columns than one prompt can
+// describe well. A threshold of 0 disables the grouped strategy.
+func (s *tableFeatureExtractionService) isWideTable(tc *tableContext) bool {
+	return s.wideTableColumnThreshold > 0 && len(tc.Columns) > s.wideTableColumnThreshold
+}
+
+// columnGroupResponse is the expected JSON response for one column group.
+type columnGroupResponse struct {
+	Summary string `json:"summary"`
+}
+
+// buildWidePrompt summarizes each column group with its own LLM call, then builds the
+// table prompt from the key columns and those summaries in place of every column.
+func (s *tableFeatureExtractionService) buildWidePrompt(ctx context.Context, llmClient llm.LLMClient, tc *tableContext) (string, error) {
+	keyColumns, groups := groupWideTableColumns(tc.Columns, tc.MetadataByColumnID, s.wideTableColumnThreshold)
+
+	summaries := make([]string, 0, len(groups))
+	for _, group := range groups {
+		result, err := llmClient.GenerateResponse(ctx, s.buildColumnGroupPrompt(tc, group), s.systemMessage(), 0.2, false)
+		if err != nil {
+			return "", fmt.Errorf("summarize column group %q: %w", group.Label, err)
+		}
+		response, err := llm.ParseJSONResponse[columnGroupResponse](result.Content)
+		if err != nil {
+			return "", fmt.Errorf("parse column group %q summary: %w", group.Label, err)
+		}
+		summaries = append(summaries, response.Summary)
+	}
+
+	var sb strings.Builder
+	s.writeTableHeader(&sb, tc)
+
+	sb.WriteString("\n## Column Features Summary\n\n")
+	if len(keyColumns) > 0 {
+		s.writeCategorizedColumns(&sb, keyColumns, tc.MetadataByColumnID)
+		sb.WriteString("\n")
+	}
+	fmt.Fprintf(&sb, "**Column Group Summaries:**\n"+
+		"This table has too many columns to list; the remaining %d columns were analyzed in groups:\n",
+		len(tc.Columns)-len(keyColumns))
+	for i, group := range groups {
+		fmt.Fprintf(&sb, "- %s (%d columns): %s\n", group.Label, len(group.Columns), summaries[i])
+	}
+
+	s.writeTableContext(&sb, tc)
+	s.writeTableTask(&sb)
+	return sb.String(), nil
+}
+
+// buildColumnGroupPrompt asks what one group of a wide table's columns says about
+// the table.
+func (s *tableFeatureExtractionService) buildColumnGroupPrompt(tc *tableContext, group columnGroup) string {
+	var sb strings.Builder
+
+	sb.WriteString("# Column Group Analysis\n\n")
+	fmt.Fprintf(&sb, "**Table:** %s\n", tc.Table.TableName)
+	fmt.Fprintf(&sb, "**Column count:** %d (this group: %s, %d columns)\n", len(tc.Columns), group.Label, len(group.Columns))
+
+	sb.WriteString("\n## Columns\n\n")
+	s.writeCategorizedColumns(&sb, group.Columns, tc.MetadataByColumnID)
+
+	sb.WriteString("\n## Task\n\n")
+	sb.WriteString("The table is too wide to analyze at once. In 1-2 sentences, summarize what this group of columns ")
+	sb.WriteString("records and what it suggests about the business entity the table represents. ")
+	sb.WriteString("Name the most important columns.\n")
+
+	sb.WriteString("\n## Response Format\n\n")
+	sb.WriteString("```json\n")
+	sb.WriteString("{\n")
+	sb.WriteString("  \"summary\": \"Billing address fields (billing_street, billing_city) used to invoice the customer.\"\n")
+	sb.WriteString("}\n")
+	sb.WriteString("```\n")
+
+	return sb.String()
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/llm"
+)
+
+// TokenSpend reports the LLM tokens an extraction run has spent against its budget.
+type TokenSpend struct {
+	Spent    int  `json:"spent"`
+	Budget   int  `json:"budget"`   // 0 means unlimited
+	Running  bool `json:"running"`  // The run is still in progress
+	Exceeded bool `json:"exceeded"` // The run was stopped by its budget
+}
+
+// TokenBudgetTracker holds the token budget of each datasource's extraction run, so
+// the spend can be reported while the run is in progress and after it ends. Runs are
+// per datasource, so a project's datasources are tracked separately. It keeps the
+// latest run per datasource in memory; the DAG record keeps the spend across restarts.
+type TokenBudgetTracker struct {
+	mu      sync.Mutex
+	runs    map[uuid.UUID]*llm.TokenBudget
+	running map[uuid.UUID]bool
+}
+
+// NewTokenBudgetTracker creates an empty tracker.
+func NewTokenBudgetTracker() *TokenBudgetTracker {
+	return &TokenBudgetTracker{
+		runs:    make(map[uuid.UUID]*llm.TokenBudget),
+		running: make(map[uuid.UUID]bool),
+	}
+}
+
+// Start begins tracking a run for the datasource with the given ceiling (0 for
+// unlimited) and returns its budget, replacing the previous run's. spent is what the
+// run's DAG spent before it was resumed, and counts against the ceiling.
+func (t *TokenBudgetTracker) Start(datasourceID uuid.UUID, ceiling, spent int) *llm.TokenBudget {
+	budget := llm.NewTokenBudget(ceiling)
+	budget.Add(0, spent)
+	t.mu.Lock()
+	t.runs[datasourceID] = budget
+	t.running[datasourceID] = true
+	t.mu.Unlock()
+	return budget
+}
+
+// Finish marks the datasource's run as ended. Its spend stays available until the next Start.
+func (t *TokenBudgetTracker) Finish(datasourceID uuid.UUID) {
+	t.mu.Lock()
+	delete(t.running, datasourceID)
+	t.mu.Unlock()
+}
+
+// Spend returns the spend of the datasource's current or latest run, or nil if no run
+// has been tracked since the server started.
+func (t *TokenBudgetTracker) Spend(datasourceID uuid.UUID) *TokenSpend {
+	t.mu.Lock()
+	budget, ok := t.runs[datasourceID]
+	running := t.running[datasourceID]
+	t.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	return &TokenSpend{
+		Spent:    budget.Spent(),
+		Budget:   budget.Ceiling(),
+		Running:  running,
+		Exceeded: budget.Err() != nil,
+	}
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenBudgetTracker_ReportsRunSpend(t *testing.T) {
+	tracker := NewTokenBudgetTracker()
+	datasourceID := uuid.New()
+
+	assert.Nil(t, tracker.Spend(datasourceID), "no spend before the first run")
+
+	budget := tracker.Start(datasourceID, 1000, 0)
+	budget.Add(0, 900)
+	spend := tracker.Spend(datasourceID)
+	require.NotNil(t, spend)
+	assert.Equal(t, TokenSpend{Spent: 900, Budget: 1000, Running: true}, *spend)
+
+	require.Error(t, budget.Reserve(200))
+	tracker.Finish(datasourceID)
+	assert.Equal(t, TokenSpend{Spent: 900, Budget: 1000, Exceeded: true}, *tracker.Spend(datasourceID),
+		"the finished run's spend stays available")
+
+	tracker.Start(datasourceID, 0, 0)
+	assert.Equal(t, TokenSpend{Running: true}, *tracker.Spend(datasourceID), "a new run starts from zero")
+}
+
+func TestTokenBudgetTracker_ResumedRunContinuesFromPriorSpend(t *testing.T) {
+	tracker := NewTokenBudgetTracker()
+	datasourceID, otherDatasourceID := uuid.New(), uuid.New()
+
+	budget := tracker.Start(datasourceID, 1500, 900)
+	assert.Equal(t, 900, budget.Spent())
+	require.NoError(t, budget.Reserve(500))
+	assert.Error(t, budget.Reserve(200), "the spend before the resume counts against the ceiling")
+
+	tracker.Start(otherDatasourceID, 0, 0)
+	assert.Equal(t, 900, tracker.Spend(datasourceID).Spent, "each datasource's run is tracked separately")
+	assert.Equal(t, 0, tracker.Spend(otherDatasourceID).Spent)
+}
@@ -0,0 +1,108 @@
+// Package tracing provides OpenTelemetry spans for ontology extraction and discovery.
+//
+// Instrumented code calls Start and End unconditionally. Until Setup installs a
+// tracer provider, spans go to OpenTelemetry's no-op global provider, so tracing
+// costs nothing when it is disabled.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/config"
+)
+
+// instrumentationName identifies the engine's tracer.
+const instrumentationName = "github.com/ekaya-inc/ekaya-engine"
+
+// serviceName is the service.name resource attribute on exported spans.
+const serviceName = "ekaya-engine"
+
+// Span attribute keys shared across instrumented packages.
+const (
+	AttrProjectID        = attribute.Key("ekaya.project_id")
+	AttrTable            = attribute.Key("ekaya.table")
+	AttrTableCount       = attribute.Key("ekaya.table_count")
+	AttrColumnCount      = attribute.Key("ekaya.column_count")
+	AttrDAGNode          = attribute.Key("ekaya.dag_node")
+	AttrTargetTable      = attribute.Key("ekaya.target_table")
+	AttrLLMModel         = attribute.Key("llm.model")
+	AttrPromptTokens     = attribute.Key("llm.prompt_tokens")
+	AttrCompletionTokens = attribute.Key("llm.completion_tokens")
+	AttrTotalTokens      = attribute.Key("llm.total_tokens")
+)
+
+// enabled records whether Setup installed an exporting tracer provider.
+var enabled atomic.Bool
+
+// Enabled reports whether spans are being exported. Instrumentation that costs more
+// than a Start call, such as wrapping an adapter, checks it first.
+func Enabled() bool {
+	return enabled.Load()
+}
+
+// Start starts a span as a child of any span in ctx.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End marks the span failed when err is non-nil, then ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Setup installs a global tracer provider that exports spans to cfg.OTLPEndpoint
+// when tracing is enabled. The returned function flushes pending spans and must be
+// called on shutdown; it is a no-op when tracing is disabled.
+func Setup(ctx context.Context, cfg config.TracingConfig, version string) (func(context.Context) error, error) {
+	if !cfg.Enabled {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	opts := []otlptracehttp.Option{}
+	if strings.Contains(cfg.OTLPEndpoint, "://") {
+		opts = append(opts, otlptracehttp.WithEndpointURL(cfg.OTLPEndpoint))
+	} else {
+		opts = append(opts, otlptracehttp.WithEndpoint(cfg.OTLPEndpoint))
+	}
+	if cfg.Insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("create OTLP trace exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+		semconv.ServiceVersion(version),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("build trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	enabled.Store(true)
+
+	return provider.Shutdown, nil
+}
@@ -1,6 +1,6 @@
 #!/bin/bash
 # Assess deterministic code quality for ontology extraction
-# Usage: ./scripts/assess-deterministic.sh <project-id>
+# Usage: ./scripts/assess-deterministic.sh [-v | -quiet] <project-id>
 #
 # This tool evaluates the DETERMINISTIC portions of ontology extraction:
 # - Input preparation: Did we correctly provide schema information to the LLM?
@@ -22,11 +22,11 @@ SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
 PROJECT_ROOT="$(dirname "$SCRIPT_DIR")"
 
 if [ -z "$1" ]; then
-    echo "Usage: $0 <project-id>" >&2
+    echo "Usage: $0 [-v | -quiet] <project-id>" >&2
     echo "" >&2
     echo "Example: $0 f2324998-64c0-46e7-98d1-8a778be462f2" >&2
     exit 1
 fi
 
 cd "$PROJECT_ROOT"
-go run ./scripts/assess-deterministic "$1"
+go run ./scripts/assess-deterministic "$@"
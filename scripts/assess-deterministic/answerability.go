@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+)
+
+// AnswerableMaxDistinct is the largest number of distinct values a column may have for
+// its questions to count as answerable from the documented enum values alone.
+const AnswerableMaxDistinct = 10
+
+// QuestionAnswerabilityScore reports pending required questions that the gathered data
+// already answers.
+//
+// Many required questions ask what the values of a status or type column mean. When
+// the column's distinct values were gathered and documented (Postgres enum labels or
+// enum_features from column enrichment) and there are only a handful of them, the
+// question can be answered from the data instead of waiting on a user. Each such
+// question is a missed auto-answer and lowers the score.
+type QuestionAnswerabilityScore struct {
+	Score                          int                    `json:"score"`
+	Weight                         int                    `json:"weight"`
+	PendingRequired                int                    `json:"pending_required"`
+	AnswerableFromData             int                    `json:"answerable_from_data"`
+	PendingRequiredAfterAutoAnswer int                    `json:"pending_required_after_auto_answer"`
+	Recommendations                []AnswerRecommendation `json:"recommendations,omitempty"`
+	Issues                         []string               `json:"issues"`
+}
+
+// AnswerRecommendation describes a question that should be auto-answered from the
+// documented values of its source column.
+type AnswerRecommendation struct {
+	QuestionID string   `json:"question_id"`
+	Question   string   `json:"question"`
+	Column     string   `json:"column"` // source_entity_key as stored on the question
+	Values     []string `json:"values"`
+}
+
+// checkQuestionAnswerability flags pending required questions sourced to a column
+// whose documented enum values cover all of its distinct values and number at most
+// AnswerableMaxDistinct.
+func checkQuestionAnswerability(questions []OntologyQuestion, schema []SchemaTable) *QuestionAnswerabilityScore {
+	result := &QuestionAnswerabilityScore{
+		Weight: WeightQuestionAnswerability,
+		Issues: []string{},
+	}
+
+	for _, q := range questions {
+		if !q.IsRequired || q.Status != "pending" {
+			continue
+		}
+		result.PendingRequired++
+
+		if stringOrEmpty(q.SourceEntityType) != "column" {
+			continue
+		}
+		column := findColumn(stringOrEmpty(q.SourceEntityKey), schema)
+		if column == nil || !answerableFromValues(column) {
+			continue
+		}
+
+		result.AnswerableFromData++
+		result.Recommendations = append(result.Recommendations, AnswerRecommendation{
+			QuestionID: q.ID.String(),
+			Question:   truncate(q.Text, 80),
+			Column:     stringOrEmpty(q.SourceEntityKey),
+			Values:     column.EnumValues,
+		})
+		result.Issues = append(result.Issues, fmt.Sprintf(
+			"Question %q is answerable from the %d documented values of '%s'; recommend auto-answering",
+			truncate(q.Text, 60), len(column.EnumValues), stringOrEmpty(q.SourceEntityKey)))
+	}
+
+	result.PendingRequiredAfterAutoAnswer = result.PendingRequired - result.AnswerableFromData
+	if result.PendingRequired == 0 {
+		result.Score = 100
+		return result
+	}
+
+	result.Score = result.PendingRequiredAfterAutoAnswer * 100 / result.PendingRequired
+	return result
+}
+
+// answerableFromValues reports whether the column's documented values are few enough
+// to answer from, and complete: when a distinct count was gathered, every distinct
+// value must be documented.
+func answerableFromValues(c *SchemaColumn) bool {
+	n := len(c.EnumValues)
+	if n == 0 || n > AnswerableMaxDistinct {
+		return false
+	}
+	return c.DistinctCount == nil || *c.DistinctCount <= int64(n)
+}
+
+// findColumn resolves a "table.column" or "schema.table.column" key to a selected
+// column, or returns nil.
+func findColumn(key string, schema []SchemaTable) *SchemaColumn {
+	tableKey, columnName := splitColumnKey(key)
+	if tableKey == "" || columnName == "" {
+		return nil
+	}
+	table := findTable(tableKey, schema)
+	if table == nil || !table.IsSelected {
+		return nil
+	}
+	for i := range table.Columns {
+		c := &table.Columns[i]
+		if identifierMatches(c.ColumnName, columnName) {
+			if !c.IsSelected {
+				return nil
+			}
+			return c
+		}
+	}
+	return nil
+}
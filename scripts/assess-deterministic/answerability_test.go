@@ -0,0 +1,96 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func answerabilitySchema() []SchemaTable {
+	return []SchemaTable{{
+		SchemaName: "public", TableName: "orders", IsSelected: true,
+		Columns: []SchemaColumn{
+			{
+				ColumnName: "status", IsSelected: true, DistinctCount: int64Ptr(4),
+				EnumValues: []string{"pending", "paid", "shipped", "cancelled"},
+			},
+			// Only some of the distinct values were documented
+			{
+				ColumnName: "channel", IsSelected: true, DistinctCount: int64Ptr(6),
+				EnumValues: []string{"web", "app"},
+			},
+			// Low cardinality but no documented values to answer from
+			{ColumnName: "region", IsSelected: true, DistinctCount: int64Ptr(3)},
+		},
+	}}
+}
+
+func TestCheckQuestionAnswerability_FlagsSmallEnumColumn(t *testing.T) {
+	statusQuestion := OntologyQuestion{
+		ID: uuid.New(), Text: "What do the order status values mean?", IsRequired: true, Status: "pending",
+		SourceEntityType: strPtr("column"), SourceEntityKey: strPtr("orders.status"),
+	}
+	questions := []OntologyQuestion{
+		statusQuestion,
+		{ID: uuid.New(), Text: "What does channel mean?", IsRequired: true, Status: "pending",
+			SourceEntityType: strPtr("column"), SourceEntityKey: strPtr("orders.channel")},
+		{ID: uuid.New(), Text: "What does region mean?", IsRequired: true, Status: "pending",
+			SourceEntityType: strPtr("column"), SourceEntityKey: strPtr("orders.region")},
+		{ID: uuid.New(), Text: "What is an order?", IsRequired: true, Status: "pending",
+			SourceEntityType: strPtr("table"), SourceEntityKey: strPtr("orders")},
+		// Not pending-required: ignored
+		{ID: uuid.New(), Text: "Optional status question", IsRequired: false, Status: "pending",
+			SourceEntityType: strPtr("column"), SourceEntityKey: strPtr("orders.status")},
+		{ID: uuid.New(), Text: "Answered status question", IsRequired: true, Status: "answered",
+			SourceEntityType: strPtr("column"), SourceEntityKey: strPtr("orders.status")},
+	}
+
+	result := checkQuestionAnswerability(questions, answerabilitySchema())
+
+	if result.PendingRequired != 4 || result.AnswerableFromData != 1 {
+		t.Fatalf("expected 1/4 pending required answerable, got %d/%d", result.AnswerableFromData, result.PendingRequired)
+	}
+	if result.PendingRequiredAfterAutoAnswer != 3 {
+		t.Errorf("expected 3 pending required after auto-answer, got %d", result.PendingRequiredAfterAutoAnswer)
+	}
+	if result.Score != 75 {
+		t.Errorf("expected score 75, got %d", result.Score)
+	}
+	if len(result.Recommendations) != 1 {
+		t.Fatalf("expected 1 recommendation, got %+v", result.Recommendations)
+	}
+	rec := result.Recommendations[0]
+	if rec.QuestionID != statusQuestion.ID.String() || rec.Column != "orders.status" || len(rec.Values) != 4 {
+		t.Errorf("unexpected recommendation: %+v", rec)
+	}
+	if len(result.Issues) != 1 || !strings.Contains(result.Issues[0], "recommend auto-answering") {
+		t.Errorf("expected auto-answer recommendation issue, got %v", result.Issues)
+	}
+}
+
+func TestCheckQuestionAnswerability_ThresholdAndDeselectedColumns(t *testing.T) {
+	values := make([]string, AnswerableMaxDistinct+1)
+	for i := range values {
+		values[i] = string(rune('a' + i))
+	}
+	schema := []SchemaTable{{
+		TableName: "tickets", IsSelected: true,
+		Columns: []SchemaColumn{
+			{ColumnName: "category", IsSelected: true, EnumValues: values},
+			{ColumnName: "priority", IsSelected: false, EnumValues: []string{"low", "high"}},
+		},
+	}}
+	questions := []OntologyQuestion{
+		{ID: uuid.New(), Text: "Categories?", IsRequired: true, Status: "pending",
+			SourceEntityType: strPtr("column"), SourceEntityKey: strPtr("tickets.category")},
+		{ID: uuid.New(), Text: "Priorities?", IsRequired: true, Status: "pending",
+			SourceEntityType: strPtr("column"), SourceEntityKey: strPtr("tickets.priority")},
+	}
+
+	result := checkQuestionAnswerability(questions, schema)
+
+	if result.AnswerableFromData != 0 || result.Score != 100 {
+		t.Errorf("expected nothing answerable (score 100), got %d answerable (score %d)", result.AnswerableFromData, result.Score)
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+)
+
+// GraphCardinalityScore checks the cardinality labels in the domain summary's
+// relationship graph. Labels are free text written by the LLM, so each one must map
+// to a canonical cardinality (models.NormalizeGraphCardinality) and, where the edge
+// joins tables with discovered relationships, agree with the cardinality computed
+// from the data.
+type GraphCardinalityScore struct {
+	Score        int                     `json:"score"`
+	Weight       int                     `json:"weight"`
+	EdgesChecked int                     `json:"edges_checked"`
+	Unmappable   []GraphCardinalityIssue `json:"unmappable,omitempty"`
+	Mismatches   []GraphCardinalityIssue `json:"mismatches,omitempty"`
+	Issues       []string                `json:"issues"`
+}
+
+// GraphCardinalityIssue is a domain graph edge whose cardinality label is
+// unrecognized or contradicts the discovered relationships.
+type GraphCardinalityIssue struct {
+	From        string `json:"from"`
+	To          string `json:"to"`
+	Cardinality string `json:"cardinality"`
+	Canonical   string `json:"canonical,omitempty"`
+	Discovered  string `json:"discovered,omitempty"` // Canonical cardinalities of the relationships between the tables
+}
+
+// checkGraphCardinality normalizes each labeled edge and compares it with the
+// relationships discovered between the same two tables, in either direction.
+// Edges between tables without a discovered relationship are only checked for
+// a recognizable label.
+func checkGraphCardinality(graph []models.RelationshipEdge, relationships []SchemaRelationship) *GraphCardinalityScore {
+	result := &GraphCardinalityScore{
+		Weight: WeightGraphCardinality,
+		Issues: []string{},
+	}
+
+	discovered := make(map[string]map[string]bool)
+	for _, r := range relationships {
+		canonical, ok := models.NormalizeGraphCardinality(r.Cardinality)
+		if !ok {
+			continue // "unknown" or not yet computed
+		}
+		key := tablePairKey(tableOfColumn(r.SourceColumn), tableOfColumn(r.TargetColumn))
+		if discovered[key] == nil {
+			discovered[key] = make(map[string]bool)
+		}
+		discovered[key][canonical] = true
+	}
+
+	for _, edge := range graph {
+		if edge.Cardinality == "" {
+			continue
+		}
+		result.EdgesChecked++
+
+		canonical, ok := models.NormalizeGraphCardinality(edge.Cardinality)
+		if !ok {
+			result.Unmappable = append(result.Unmappable, GraphCardinalityIssue{
+				From: edge.From, To: edge.To, Cardinality: edge.Cardinality,
+			})
+			result.Issues = append(result.Issues, fmt.Sprintf(
+				"Domain graph edge %s -> %s has unrecognized cardinality %q", edge.From, edge.To, edge.Cardinality))
+			continue
+		}
+
+		known := discovered[tablePairKey(edge.From, edge.To)]
+		if len(known) == 0 || known[canonical] {
+			continue
+		}
+		var discoveredLabels []string
+		for c := range known {
+			discoveredLabels = append(discoveredLabels, c)
+		}
+		sort.Strings(discoveredLabels)
+		result.Mismatches = append(result.Mismatches, GraphCardinalityIssue{
+			From:        edge.From,
+			To:          edge.To,
+			Cardinality: edge.Cardinality,
+			Canonical:   canonical,
+			Discovered:  strings.Join(discoveredLabels, ", "),
+		})
+		result.Issues = append(result.Issues, fmt.Sprintf(
+			"Domain graph labels %s -> %s %s but discovered relationships are %s",
+			edge.From, edge.To, canonical, strings.Join(discoveredLabels, ", ")))
+	}
+
+	if result.EdgesChecked == 0 {
+		result.Score = 100
+		return result
+	}
+
+	consistent := result.EdgesChecked - len(result.Unmappable) - len(result.Mismatches)
+	result.Score = consistent * 100 / result.EdgesChecked
+	return result
+}
+
+// tablePairKey identifies an unordered pair of tables. Names are compared
+// case-insensitively and without a schema prefix.
+func tablePairKey(a, b string) string {
+	a, b = bareTableName(a), bareTableName(b)
+	if a > b {
+		a, b = b, a
+	}
+	return a + "|" + b
+}
+
+func bareTableName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
+
+// tableOfColumn returns the table part of a "table.column" reference.
+func tableOfColumn(ref string) string {
+	table, _, _ := strings.Cut(ref, ".")
+	return table
+}
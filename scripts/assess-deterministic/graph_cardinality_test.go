@@ -0,0 +1,74 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+)
+
+func TestCheckGraphCardinality_NormalizesVariedLabels(t *testing.T) {
+	graph := []models.RelationshipEdge{
+		{From: "users", To: "orders", Cardinality: "1:N"},
+		{From: "orders", To: "users", Cardinality: "many-to-one"},
+		{From: "users", To: "profiles", Cardinality: "One to One"},
+		{From: "orders", To: "products", Cardinality: "N:M"},
+		{From: "users", To: "teams", Cardinality: "many"},
+		{From: "users", To: "sessions"},
+	}
+	relationships := []SchemaRelationship{
+		{SourceColumn: "orders.user_id", TargetColumn: "users.id", Cardinality: models.CardinalityNTo1},
+		{SourceColumn: "profiles.user_id", TargetColumn: "users.id", Cardinality: models.Cardinality1To1},
+	}
+
+	result := checkGraphCardinality(graph, relationships)
+
+	if result.EdgesChecked != 5 {
+		t.Errorf("expected 5 labeled edges checked, got %d", result.EdgesChecked)
+	}
+	if len(result.Unmappable) != 0 || len(result.Mismatches) != 0 {
+		t.Errorf("expected no issues, got unmappable=%+v mismatches=%+v", result.Unmappable, result.Mismatches)
+	}
+	if result.Score != 100 {
+		t.Errorf("expected score 100, got %d", result.Score)
+	}
+}
+
+func TestCheckGraphCardinality_FlagsContradictionAndUnmappable(t *testing.T) {
+	graph := []models.RelationshipEdge{
+		{From: "public.users", To: "Orders", Cardinality: "one-to-one"},
+		{From: "users", To: "profiles", Cardinality: "sometimes"},
+		{From: "users", To: "accounts", Cardinality: "1:N"},
+		{From: "users", To: "invoices", Cardinality: "1:N"},
+	}
+	relationships := []SchemaRelationship{
+		{SourceColumn: "orders.user_id", TargetColumn: "users.id", Cardinality: models.CardinalityNTo1},
+		{SourceColumn: "accounts.user_id", TargetColumn: "users.id", Cardinality: models.CardinalityUnknown},
+	}
+
+	result := checkGraphCardinality(graph, relationships)
+
+	if len(result.Mismatches) != 1 {
+		t.Fatalf("expected 1 mismatch, got %+v", result.Mismatches)
+	}
+	mismatch := result.Mismatches[0]
+	if mismatch.Canonical != models.GraphCardinalityOneToOne || mismatch.Discovered != models.GraphCardinalityOneToMany {
+		t.Errorf("unexpected mismatch: %+v", mismatch)
+	}
+	if len(result.Unmappable) != 1 || result.Unmappable[0].Cardinality != "sometimes" {
+		t.Errorf("expected 'sometimes' unmappable, got %+v", result.Unmappable)
+	}
+	if result.Score != 50 {
+		t.Errorf("expected score 50, got %d", result.Score)
+	}
+	if len(result.Issues) != 2 || !strings.Contains(result.Issues[0], "labels public.users -> Orders one_to_one") {
+		t.Errorf("unexpected issues: %v", result.Issues)
+	}
+}
+
+func TestCheckGraphCardinality_NoGraph(t *testing.T) {
+	result := checkGraphCardinality(nil, nil)
+	if result.Score != 100 || result.EdgesChecked != 0 {
+		t.Errorf("expected score 100 with no edges, got %+v", result)
+	}
+}
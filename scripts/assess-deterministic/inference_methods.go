@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+)
+
+// InferenceMethodScore checks that every stored relationship records how it was found.
+// Discovery deduplicates relationships by method precedence (a declared FK wins over
+// an inferred one) and provenance is audited by method, so a relationship without a
+// known method breaks both. Each one is an integrity issue and lowers the score.
+//
+// Known methods are the engine's (models.IsKnownInferenceMethod), so a method added
+// there is accepted here without changes.
+type InferenceMethodScore struct {
+	Score                int                          `json:"score"`
+	Weight               int                          `json:"weight"`
+	RelationshipsChecked int                          `json:"relationships_checked"`
+	Unknown              []UnknownInferenceMethodJoin `json:"unknown,omitempty"`
+	Issues               []string                     `json:"issues"`
+}
+
+// UnknownInferenceMethodJoin is a relationship without a known inference method.
+// InferenceMethod is empty when none is recorded.
+type UnknownInferenceMethodJoin struct {
+	RelationshipID  string `json:"relationship_id"`
+	SourceColumn    string `json:"source_column"`
+	TargetColumn    string `json:"target_column"`
+	InferenceMethod string `json:"inference_method"`
+}
+
+// checkInferenceMethods flags relationships whose inference method is missing or unknown.
+func checkInferenceMethods(relationships []SchemaRelationship) *InferenceMethodScore {
+	result := &InferenceMethodScore{
+		Weight:               WeightInferenceMethods,
+		RelationshipsChecked: len(relationships),
+		Issues:               []string{},
+	}
+
+	for _, r := range relationships {
+		method := stringOrEmpty(r.InferenceMethod)
+		if models.IsKnownInferenceMethod(method) {
+			continue
+		}
+		result.Unknown = append(result.Unknown, UnknownInferenceMethodJoin{
+			RelationshipID:  r.ID.String(),
+			SourceColumn:    r.SourceColumn,
+			TargetColumn:    r.TargetColumn,
+			InferenceMethod: method,
+		})
+		if method == "" {
+			result.Issues = append(result.Issues, fmt.Sprintf(
+				"Relationship %s -> %s has no inference method recorded", r.SourceColumn, r.TargetColumn))
+		} else {
+			result.Issues = append(result.Issues, fmt.Sprintf(
+				"Relationship %s -> %s has unknown inference method %q", r.SourceColumn, r.TargetColumn, method))
+		}
+	}
+
+	if result.RelationshipsChecked == 0 {
+		result.Score = 100
+		return result
+	}
+
+	known := result.RelationshipsChecked - len(result.Unknown)
+	result.Score = known * 100 / result.RelationshipsChecked
+	return result
+}
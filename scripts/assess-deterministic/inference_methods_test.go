@@ -0,0 +1,56 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+)
+
+func TestCheckInferenceMethods_FlagsMethodlessRelationship(t *testing.T) {
+	fk := models.InferenceMethodFK
+	manual := models.RelationshipTypeManual
+	methodless := SchemaRelationship{
+		ID:           uuid.New(),
+		SourceColumn: "payments.invoice_id",
+		TargetColumn: "invoices.id",
+	}
+	relationships := []SchemaRelationship{
+		{ID: uuid.New(), SourceColumn: "orders.customer_id", TargetColumn: "customers.id", InferenceMethod: &fk},
+		{ID: uuid.New(), SourceColumn: "invoices.order_id", TargetColumn: "orders.id", InferenceMethod: &manual},
+		methodless,
+	}
+
+	result := checkInferenceMethods(relationships)
+
+	if len(result.Unknown) != 1 {
+		t.Fatalf("expected 1 relationship without a method, got %+v", result.Unknown)
+	}
+	if result.Unknown[0].RelationshipID != methodless.ID.String() || result.Unknown[0].InferenceMethod != "" {
+		t.Errorf("expected %s flagged with no method, got %+v", methodless.ID, result.Unknown[0])
+	}
+	if result.Score != 66 {
+		t.Errorf("expected score 66, got %d", result.Score)
+	}
+	if len(result.Issues) != 1 || !strings.Contains(result.Issues[0], "payments.invoice_id -> invoices.id has no inference method") {
+		t.Errorf("unexpected issues: %v", result.Issues)
+	}
+
+	summary := ChecksSummary{InferenceMethods: result}
+	if issues := collectIssues(summary); len(issues) != 1 {
+		t.Errorf("expected the integrity issue in the assessment issues, got %v", issues)
+	}
+}
+
+func TestCheckInferenceMethods_FlagsUnknownMethod(t *testing.T) {
+	legacy := "heuristic"
+	result := checkInferenceMethods([]SchemaRelationship{
+		{ID: uuid.New(), SourceColumn: "refunds.payment_id", TargetColumn: "payments.id", InferenceMethod: &legacy},
+	})
+
+	if result.Score != 0 || len(result.Issues) != 1 || !strings.Contains(result.Issues[0], `unknown inference method "heuristic"`) {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
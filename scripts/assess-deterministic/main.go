@@ -0,0 +1,847 @@
+// assess-deterministic evaluates the DETERMINISTIC portions of ontology extraction.
+//
+// This tool does NOT use an LLM for assessment - all checks are deterministic.
+// It evaluates what the engine code did with LLM output, not the LLM itself:
+//   - Input preparation: Did we correctly provide schema information to the LLM?
+//   - Post-processing: Did we correctly parse and store LLM responses?
+//
+// A score of 100 means the deterministic code is perfect. This is achievable.
+//
+// Separate from assess-extraction which evaluates LLM output quality.
+//
+// Usage: go run ./scripts/assess-deterministic [-v | -quiet] [-format json|md] [-metrics-target url] [-redact-detectors list] [-redact-pattern re]... <project-id>
+//
+//	-v                 verbose progress on stderr (per-sample detail)
+//	-quiet             no progress on stderr; the result on stdout is unchanged
+//	-format            json (default) or md for a Markdown report with score badges
+//	-redact-detectors  redaction detectors to audit stored prompts against
+//	                   (default: $CONVERSATIONS_REDACT_DETECTORS, as the server uses)
+//	-redact-pattern    additional redaction pattern; repeatable
+//
+// Database connection: Uses standard PG* environment variables
+//
+// NOTE: This standalone assessment script uses direct SQL queries rather than
+// the repository layer. This is intentional to keep the script self-contained
+// and avoid circular dependencies.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/logging"
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+	"github.com/ekaya-inc/ekaya-engine/scripts/internal/assesslog"
+	"github.com/ekaya-inc/ekaya-engine/scripts/internal/assessmetrics"
+	"github.com/ekaya-inc/ekaya-engine/scripts/internal/assessreport"
+)
+
+// =============================================================================
+// Check Weights
+// =============================================================================
+
+// Weights are relative; the final score is the weighted average of the checks
+// that ran, so adding a check does not require rebalancing the others.
+const (
+	WeightQuestionSources       = 10 // Stored questions reference real, selected tables/columns
+	WeightRelationshipCoverage  = 15 // Selected tables take part in relationships, weighted by importance
+	WeightStatsCompleteness     = 15 // Selected joinable columns have gathered statistics
+	WeightQuestionAnswerability = 10 // Required questions answerable from documented values are not left pending
+	WeightRelationshipTypes     = 15 // Relationships join columns of compatible types
+	WeightGraphCardinality      = 5  // Domain graph cardinality labels are canonical and match discovered data
+	WeightPromptRelationships   = 10 // Prompts that carry relationship context include the gathered relationships
+	WeightRedactionCompliance   = 10 // Stored prompts hold no values the redaction policy would mask
+	WeightPromptCompleteness    = 10 // Table-targeted prompts carry their table's full schema context
+	WeightInferenceMethods      = 5  // Relationships record a known inference method
+	WeightPromptMix             = 10 // Every prompt type the project's configuration calls for was issued
+)
+
+// =============================================================================
+// Output Data Types
+// =============================================================================
+
+// AssessmentResult contains the full assessment output
+type AssessmentResult struct {
+	CommitInfo     string        `json:"commit_info"`
+	DatasourceName string        `json:"datasource_name"`
+	ProjectID      string        `json:"project_id"`
+	SchemaStats    SchemaStats   `json:"schema_stats"`
+	ChecksSummary  ChecksSummary `json:"checks_summary"`
+	FinalScore     int           `json:"final_score"`
+	SmartSummary   string        `json:"smart_summary"`
+	Issues         []string      `json:"issues"`
+}
+
+// SchemaStats contains basic schema statistics
+type SchemaStats struct {
+	TableCount         int `json:"table_count"`
+	SelectedTableCount int `json:"selected_table_count"`
+	ColumnCount        int `json:"column_count"`
+	RelationshipCount  int `json:"relationship_count"`
+	QuestionCount      int `json:"question_count"`
+	PromptCount        int `json:"prompt_count"`
+}
+
+// ChecksSummary contains scores for all deterministic checks
+type ChecksSummary struct {
+	QuestionSources       *QuestionSourceScore        `json:"question_sources"`
+	RelationshipCoverage  *RelationshipCoverageScore  `json:"relationship_coverage"`
+	StatsCompleteness     *StatsCompletenessScore     `json:"stats_completeness"`
+	QuestionAnswerability *QuestionAnswerabilityScore `json:"question_answerability"`
+	RelationshipTypes     *RelationshipTypeScore      `json:"relationship_types"`
+	InferenceMethods      *InferenceMethodScore       `json:"inference_methods"`
+	GraphCardinality      *GraphCardinalityScore      `json:"graph_cardinality"`
+	PromptRelationships   *PromptRelationshipScore    `json:"prompt_relationships"`
+	PromptCompleteness    *PromptCompletenessScore    `json:"prompt_completeness"`
+	PromptMix             *PromptMixScore             `json:"prompt_mix"`
+	RedactionCompliance   *RedactionComplianceScore   `json:"redaction_compliance,omitempty"`
+}
+
+// =============================================================================
+// Database Types
+// =============================================================================
+
+// SchemaTable represents a table in the schema (selected or not)
+type SchemaTable struct {
+	ID         uuid.UUID      `json:"id"`
+	SchemaName string         `json:"schema_name"`
+	TableName  string         `json:"table_name"`
+	IsSelected bool           `json:"is_selected"`
+	IsCritical bool           `json:"is_critical,omitempty"` // Listed in the project's critical_tables setting
+	RowCount   *int64         `json:"row_count"`
+	Columns    []SchemaColumn `json:"columns"`
+}
+
+// SchemaColumn represents a column
+type SchemaColumn struct {
+	ColumnName    string `json:"column_name"`
+	DataType      string `json:"data_type"`
+	IsPrimaryKey  bool   `json:"is_primary_key"`
+	IsSelected    bool   `json:"is_selected"`
+	IsNullable    bool   `json:"is_nullable"`
+	DistinctCount *int64 `json:"distinct_count"`
+	NullCount     *int64 `json:"null_count"`
+	IsJoinable    *bool  `json:"is_joinable"`
+	// EnumValues are the documented values: Postgres enum labels, or the values
+	// labeled by column enrichment (enum_features).
+	EnumValues []string `json:"enum_values,omitempty"`
+}
+
+// SchemaRelationship represents a relationship between table columns
+type SchemaRelationship struct {
+	ID             uuid.UUID `json:"id"`
+	SourceTableID  uuid.UUID `json:"source_table_id"`
+	SourceColumnID uuid.UUID `json:"source_column_id"`
+	TargetTableID  uuid.UUID `json:"target_table_id"`
+	TargetColumnID uuid.UUID `json:"target_column_id"`
+	SourceColumn   string    `json:"source_column"` // table.column
+	SourceType     string    `json:"source_type"`
+	TargetColumn   string    `json:"target_column"` // table.column
+	TargetType     string    `json:"target_type"`
+	Cardinality    string    `json:"cardinality"` // Computed from data: 1:1, 1:N, N:1, N:M or unknown
+	// InferenceMethod is how the relationship was found (fk, column_features, manual, ...); nil if not recorded
+	InferenceMethod *string `json:"inference_method"`
+}
+
+// OntologyQuestion represents a stored question
+type OntologyQuestion struct {
+	ID               uuid.UUID `json:"id"`
+	Text             string    `json:"text"`
+	IsRequired       bool      `json:"is_required"`
+	SourceEntityType *string   `json:"source_entity_type"`
+	SourceEntityKey  *string   `json:"source_entity_key"`
+	Status           string    `json:"status"`
+}
+
+// =============================================================================
+// Main Entry Point
+// =============================================================================
+
+// logger writes progress to stderr; replaced in main once -v/-quiet are parsed.
+var logger = assesslog.New(os.Stderr, assesslog.LevelNormal)
+
+func main() {
+	var logFlags assesslog.Flags
+	logFlags.Register(flag.CommandLine)
+	var formatFlags assessreport.Flags
+	formatFlags.Register(flag.CommandLine)
+	var metricsFlags assessmetrics.Flags
+	metricsFlags.Register(flag.CommandLine)
+	redactDetectors := flag.String("redact-detectors", os.Getenv("CONVERSATIONS_REDACT_DETECTORS"),
+		"comma-separated redaction detectors to audit stored prompts against ("+strings.Join(logging.DetectorNames(), ", ")+")")
+	var redactPatterns patternList
+	flag.Var(&redactPatterns, "redact-pattern", "additional redaction regular expression (repeatable)")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s [-v | -quiet] [-format json|md] [-redact-detectors list] [-redact-pattern re]... <project-id>\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	logger = logFlags.Logger()
+
+	if flag.NArg() < 1 {
+		flag.Usage()
+		os.Exit(1)
+	}
+	if err := formatFlags.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid -format: %v\n", err)
+		os.Exit(1)
+	}
+	if err := metricsFlags.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid -metrics-target: %v\n", err)
+		os.Exit(1)
+	}
+
+	projectID, err := uuid.Parse(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid project ID: %v\n", err)
+		os.Exit(1)
+	}
+
+	redactor, err := logging.NewRedactor(splitList(*redactDetectors), redactPatterns)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid redaction policy: %v\n", err)
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+
+	// Connect to database
+	connStr := buildConnString()
+	conn, err := pgx.Connect(ctx, connStr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to connect to database: %v\n", err)
+		os.Exit(1)
+	}
+	defer conn.Close(ctx)
+
+	// Phase 1: Load data
+	logger.Progressf("Phase 1: Loading data...\n")
+
+	var datasourceName string
+	if err := conn.QueryRow(ctx, `
+		SELECT name FROM engine_datasources
+		WHERE project_id = $1
+		LIMIT 1
+	`, projectID).Scan(&datasourceName); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to get datasource name: %v\n", err)
+		os.Exit(1)
+	}
+
+	schema, err := loadSchema(ctx, conn, projectID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load schema: %v\n", err)
+		os.Exit(1)
+	}
+
+	criticalTables, err := loadCriticalTables(ctx, conn, projectID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load critical tables: %v\n", err)
+		os.Exit(1)
+	}
+	for _, name := range markCriticalTables(schema, criticalTables) {
+		logger.Progressf("  Critical table %s is not in the schema, ignored\n", name)
+	}
+
+	relationships, err := loadRelationships(ctx, conn, projectID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load relationships: %v\n", err)
+		os.Exit(1)
+	}
+
+	questions, err := loadQuestions(ctx, conn, projectID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load questions: %v\n", err)
+		os.Exit(1)
+	}
+
+	domainGraph, err := loadDomainGraph(ctx, conn, projectID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load domain summary: %v\n", err)
+		os.Exit(1)
+	}
+
+	prompts, err := loadPrompts(ctx, conn, projectID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load LLM prompts: %v\n", err)
+		os.Exit(1)
+	}
+
+	hasDescription, err := loadHasDescription(ctx, conn, projectID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load project description: %v\n", err)
+		os.Exit(1)
+	}
+
+	schemaStats := SchemaStats{
+		TableCount:        len(schema),
+		RelationshipCount: len(relationships),
+		QuestionCount:     len(questions),
+		PromptCount:       len(prompts),
+	}
+	for _, t := range schema {
+		if t.IsSelected {
+			schemaStats.SelectedTableCount++
+		}
+		schemaStats.ColumnCount += len(t.Columns)
+	}
+
+	logger.Progressf("  Tables: %d (%d selected), Columns: %d, Relationships: %d, Questions: %d, Prompts: %d\n",
+		schemaStats.TableCount, schemaStats.SelectedTableCount, schemaStats.ColumnCount,
+		schemaStats.RelationshipCount, schemaStats.QuestionCount, schemaStats.PromptCount)
+
+	// Phase 2: Post-processing checks
+	logger.Progressf("Phase 2: Checking question source references...\n")
+	questionSources := checkQuestionSources(questions, schema)
+	for _, ref := range questionSources.DanglingReferences {
+		logger.Detailf("    dangling %s '%s' (question %s): %s\n", ref.EntityType, ref.EntityKey, ref.QuestionID, ref.Reason)
+	}
+	logger.Progressf("  Checked %d questions, %d dangling (score: %d/100)\n",
+		questionSources.QuestionsChecked, questionSources.DanglingCount, questionSources.Score)
+
+	// Phase 3: Relationship coverage
+	logger.Progressf("Phase 3: Checking relationship coverage...\n")
+	relationshipCoverage := checkRelationshipCoverage(schema, relationships)
+	for _, o := range relationshipCoverage.OrphanTables {
+		logger.Detailf("    orphan %s (importance %.2f)\n", o.TableName, o.Importance)
+	}
+	logger.Progressf("  Coverage: %.1f%% raw, %.1f%% weighted (%d orphan tables)\n",
+		relationshipCoverage.RawCoverage, relationshipCoverage.WeightedCoverage, len(relationshipCoverage.OrphanTables))
+
+	// Phase 4: Stats completeness
+	logger.Progressf("Phase 4: Checking column stats completeness...\n")
+	statsCompleteness := checkStatsCompleteness(schema)
+	for _, c := range statsCompleteness.MissingColumns {
+		logger.Detailf("    missing stats %s (%s)\n", c.Column, strings.Join(c.Missing, ", "))
+	}
+	logger.Progressf("  %d/%d joinable columns have stats (score: %d/100)\n",
+		statsCompleteness.ColumnsWithStats, statsCompleteness.ColumnsChecked, statsCompleteness.Score)
+
+	// Phase 5: Question answerability
+	logger.Progressf("Phase 5: Checking required questions answerable from data...\n")
+	questionAnswerability := checkQuestionAnswerability(questions, schema)
+	for _, r := range questionAnswerability.Recommendations {
+		logger.Detailf("    answerable %s from %s (%s)\n", r.QuestionID, r.Column, strings.Join(r.Values, ", "))
+	}
+	logger.Progressf("  %d/%d pending required questions answerable from data (score: %d/100)\n",
+		questionAnswerability.AnswerableFromData, questionAnswerability.PendingRequired, questionAnswerability.Score)
+
+	// Phase 6: Relationship type compatibility
+	logger.Progressf("Phase 6: Checking relationship column types...\n")
+	relationshipTypes := checkRelationshipTypes(relationships)
+	for _, m := range relationshipTypes.Incompatible {
+		logger.Detailf("    incompatible %s (%s) -> %s (%s)\n", m.SourceColumn, m.SourceType, m.TargetColumn, m.TargetType)
+	}
+	logger.Progressf("  %d/%d relationships join incompatible types (score: %d/100)\n",
+		len(relationshipTypes.Incompatible), relationshipTypes.RelationshipsChecked, relationshipTypes.Score)
+
+	// Phase 7: Relationship inference methods
+	logger.Progressf("Phase 7: Checking relationship inference methods...\n")
+	inferenceMethods := checkInferenceMethods(relationships)
+	for _, u := range inferenceMethods.Unknown {
+		logger.Detailf("    %s -> %s has inference method %q\n", u.SourceColumn, u.TargetColumn, u.InferenceMethod)
+	}
+	logger.Progressf("  %d/%d relationships lack a known inference method (score: %d/100)\n",
+		len(inferenceMethods.Unknown), inferenceMethods.RelationshipsChecked, inferenceMethods.Score)
+
+	// Phase 8: Domain graph cardinality labels
+	logger.Progressf("Phase 8: Checking domain graph cardinality labels...\n")
+	graphCardinality := checkGraphCardinality(domainGraph, relationships)
+	for _, u := range graphCardinality.Unmappable {
+		logger.Detailf("    unrecognized %s -> %s (%q)\n", u.From, u.To, u.Cardinality)
+	}
+	for _, m := range graphCardinality.Mismatches {
+		logger.Detailf("    mismatch %s -> %s labeled %s, discovered %s\n", m.From, m.To, m.Canonical, m.Discovered)
+	}
+	logger.Progressf("  %d edges checked, %d unrecognized, %d contradict discovered relationships (score: %d/100)\n",
+		graphCardinality.EdgesChecked, len(graphCardinality.Unmappable), len(graphCardinality.Mismatches), graphCardinality.Score)
+
+	// Phase 9: Relationships included in prompts
+	logger.Progressf("Phase 9: Checking prompts include gathered relationships...\n")
+	promptRelationships := checkPromptRelationships(prompts, relationships)
+	for _, m := range promptRelationships.Missing {
+		logger.Detailf("    %s %s missing %s\n", m.PromptType, m.ConversationID, m.Relationship)
+	}
+	for _, pt := range relationshipPromptTypes {
+		stat := promptRelationships.ByPromptType[pt]
+		logger.Progressf("  %s: %d prompts, %d/%d relationships included (%.1f%%)\n",
+			pt, stat.Prompts, stat.Included, stat.Expected, stat.Coverage)
+	}
+	logger.Progressf("  %d prompts checked (score: %d/100)\n",
+		promptRelationships.PromptsChecked, promptRelationships.Score)
+
+	// Phase 10: Schema context completeness of table-targeted prompts
+	logger.Progressf("Phase 10: Checking prompts carry their table's schema context...\n")
+	promptCompleteness := checkPromptCompleteness(prompts, schema, relationships)
+	for _, c := range promptCompleteness.Incomplete {
+		logger.Detailf("    %s %s (%s) %.0f%% complete, missing %s\n",
+			c.PromptType, c.ConversationID, c.Table, c.Completeness, strings.Join(c.Missing, ", "))
+	}
+	logger.Progressf("  %d prompts checked, %d incomplete (score: %d/100)\n",
+		promptCompleteness.PromptsChecked, len(promptCompleteness.Incomplete), promptCompleteness.Score)
+
+	// Phase 11: Stored prompts respect the redaction policy
+	logger.Progressf("Phase 11: Checking stored prompts against the redaction policy...\n")
+	redactionCompliance := checkRedactionCompliance(prompts, redactor)
+	if redactionCompliance == nil {
+		logger.Progressf("  No redaction policy configured, skipped\n")
+	} else {
+		for _, v := range redactionCompliance.Violations {
+			logger.Detailf("    %s contains %s values\n", v.ConversationID, strings.Join(v.Detectors, ", "))
+		}
+		logger.Progressf("  %d/%d prompts contain values the policy would redact (score: %d/100)\n",
+			redactionCompliance.PromptsViolating, redactionCompliance.PromptsChecked, redactionCompliance.Score)
+	}
+
+	// Phase 12: Every expected prompt type was issued
+	logger.Progressf("Phase 12: Checking the expected prompt types were issued...\n")
+	promptMix := checkPromptMix(prompts, schemaStats.SelectedTableCount, hasDescription)
+	for _, pt := range promptMix.Expected {
+		logger.Detailf("    %s: %d prompts\n", pt, promptMix.Counts[pt])
+	}
+	logger.Progressf("  %d/%d expected prompt types issued (score: %d/100)\n",
+		len(promptMix.Expected)-len(promptMix.Missing), len(promptMix.Expected), promptMix.Score)
+
+	// Phase 13: Final score
+	logger.Progressf("Phase 13: Calculating final score...\n")
+
+	checksSummary := ChecksSummary{
+		QuestionSources:       questionSources,
+		RelationshipCoverage:  relationshipCoverage,
+		StatsCompleteness:     statsCompleteness,
+		QuestionAnswerability: questionAnswerability,
+		RelationshipTypes:     relationshipTypes,
+		InferenceMethods:      inferenceMethods,
+		GraphCardinality:      graphCardinality,
+		PromptRelationships:   promptRelationships,
+		PromptCompleteness:    promptCompleteness,
+		PromptMix:             promptMix,
+		RedactionCompliance:   redactionCompliance,
+	}
+
+	finalScore, smartSummary, issues := scoreAssessment(schemaStats, checksSummary)
+
+	result := AssessmentResult{
+		CommitInfo:     getCommitInfo(),
+		DatasourceName: datasourceName,
+		ProjectID:      projectID.String(),
+		SchemaStats:    schemaStats,
+		ChecksSummary:  checksSummary,
+		FinalScore:     finalScore,
+		SmartSummary:   smartSummary,
+		Issues:         issues,
+	}
+
+	report := func() assessreport.Report { return markdownReport(&result) }
+	if err := assessreport.Write(os.Stdout, formatFlags.Format, result, report); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write result: %v\n", err)
+		os.Exit(1)
+	}
+	pushMetrics(metricsFlags.Target, &result)
+}
+
+// =============================================================================
+// Data Loading Functions
+// =============================================================================
+
+func buildConnString() string {
+	host := getEnvOrDefault("PGHOST", "localhost")
+	port := getEnvOrDefault("PGPORT", "5432")
+	user := getEnvOrDefault("PGUSER", "postgres")
+	password := os.Getenv("PGPASSWORD")
+	dbname := getEnvOrDefault("PGDATABASE", "ekaya_engine")
+
+	connStr := fmt.Sprintf("host=%s port=%s user=%s dbname=%s sslmode=disable",
+		host, port, user, dbname)
+	if password != "" {
+		connStr += fmt.Sprintf(" password=%s", password)
+	}
+	return connStr
+}
+
+func getEnvOrDefault(key, defaultVal string) string {
+	if val := os.Getenv(key); val != "" {
+		return val
+	}
+	return defaultVal
+}
+
+func getCommitInfo() string {
+	cmd := exec.Command("git", "describe", "--always", "--dirty")
+	output, err := cmd.Output()
+	if err != nil {
+		return "unknown"
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// loadSchema loads all non-deleted tables and columns, including deselected ones,
+// so checks can distinguish "missing" from "deselected".
+func loadSchema(ctx context.Context, conn *pgx.Conn, projectID uuid.UUID) ([]SchemaTable, error) {
+	tableQuery := `
+		SELECT id, schema_name, table_name, is_selected, row_count
+		FROM engine_schema_tables
+		WHERE project_id = $1 AND deleted_at IS NULL
+		ORDER BY schema_name, table_name`
+
+	rows, err := conn.Query(ctx, tableQuery, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tables []SchemaTable
+	for rows.Next() {
+		var t SchemaTable
+		if err := rows.Scan(&t.ID, &t.SchemaName, &t.TableName, &t.IsSelected, &t.RowCount); err != nil {
+			return nil, err
+		}
+		tables = append(tables, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Documented values come from the Postgres enum type when there is one, otherwise
+	// from the values labeled during column enrichment.
+	colQuery := `
+		SELECT c.column_name, c.data_type, c.is_primary_key, c.is_selected, c.is_nullable,
+		       c.distinct_count, c.null_count, c.is_joinable,
+		       COALESCE(
+		           NULLIF(ARRAY(SELECT jsonb_array_elements_text(c.enum_values)), '{}'),
+		           ARRAY(SELECT v->>'value' FROM jsonb_array_elements(m.features->'enum_features'->'values') v)
+		       )
+		FROM engine_schema_columns c
+		LEFT JOIN engine_ontology_column_metadata m ON m.schema_column_id = c.id
+		WHERE c.schema_table_id = $1 AND c.deleted_at IS NULL
+		ORDER BY c.ordinal_position`
+
+	for i := range tables {
+		colRows, err := conn.Query(ctx, colQuery, tables[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		for colRows.Next() {
+			var c SchemaColumn
+			if err := colRows.Scan(&c.ColumnName, &c.DataType, &c.IsPrimaryKey, &c.IsSelected, &c.IsNullable,
+				&c.DistinctCount, &c.NullCount, &c.IsJoinable, &c.EnumValues); err != nil {
+				colRows.Close()
+				return nil, err
+			}
+			tables[i].Columns = append(tables[i].Columns, c)
+		}
+		colRows.Close()
+	}
+
+	return tables, nil
+}
+
+// loadRelationships loads active relationships (rejected candidates are excluded)
+// with the names and types of the columns they join.
+func loadRelationships(ctx context.Context, conn *pgx.Conn, projectID uuid.UUID) ([]SchemaRelationship, error) {
+	query := `
+		SELECT r.id, r.source_table_id, r.source_column_id, r.target_table_id, r.target_column_id,
+		       st.table_name || '.' || sc.column_name, sc.data_type,
+		       tt.table_name || '.' || tc.column_name, tc.data_type, r.cardinality, r.inference_method
+		FROM engine_schema_relationships r
+		JOIN engine_schema_tables st ON st.id = r.source_table_id
+		JOIN engine_schema_columns sc ON sc.id = r.source_column_id
+		JOIN engine_schema_tables tt ON tt.id = r.target_table_id
+		JOIN engine_schema_columns tc ON tc.id = r.target_column_id
+		WHERE r.project_id = $1 AND r.deleted_at IS NULL AND r.rejection_reason IS NULL`
+
+	rows, err := conn.Query(ctx, query, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var relationships []SchemaRelationship
+	for rows.Next() {
+		var r SchemaRelationship
+		if err := rows.Scan(&r.ID, &r.SourceTableID, &r.SourceColumnID, &r.TargetTableID, &r.TargetColumnID,
+			&r.SourceColumn, &r.SourceType, &r.TargetColumn, &r.TargetType, &r.Cardinality, &r.InferenceMethod); err != nil {
+			return nil, err
+		}
+		relationships = append(relationships, r)
+	}
+	return relationships, rows.Err()
+}
+
+// loadPrompts loads the request side of every LLM conversation recorded for the project.
+func loadPrompts(ctx context.Context, conn *pgx.Conn, projectID uuid.UUID) ([]LLMPrompt, error) {
+	rows, err := conn.Query(ctx, `
+		SELECT id, request_messages
+		FROM engine_llm_conversations
+		WHERE project_id = $1
+		ORDER BY created_at ASC`, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var prompts []LLMPrompt
+	for rows.Next() {
+		var id uuid.UUID
+		var messages json.RawMessage
+		if err := rows.Scan(&id, &messages); err != nil {
+			return nil, err
+		}
+		prompts = append(prompts, newLLMPrompt(id, messages))
+	}
+	return prompts, rows.Err()
+}
+
+// loadHasDescription reports whether the project has a non-empty description (its
+// project_overview knowledge fact), which extraction processes with its own prompt.
+func loadHasDescription(ctx context.Context, conn *pgx.Conn, projectID uuid.UUID) (bool, error) {
+	var exists bool
+	err := conn.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM engine_project_knowledge
+			WHERE project_id = $1 AND fact_type = 'project_overview' AND btrim(value) <> ''
+		)
+	`, projectID).Scan(&exists)
+	return exists, err
+}
+
+// loadDomainGraph loads the relationship graph from the project's domain summary.
+// Returns nil when the project has no domain summary.
+func loadDomainGraph(ctx context.Context, conn *pgx.Conn, projectID uuid.UUID) ([]models.RelationshipEdge, error) {
+	var raw []byte
+	if err := conn.QueryRow(ctx, `
+		SELECT domain_summary FROM engine_projects WHERE id = $1
+	`, projectID).Scan(&raw); err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		return nil, nil
+	}
+
+	var summary models.DomainSummary
+	if err := json.Unmarshal(raw, &summary); err != nil {
+		return nil, fmt.Errorf("parse domain summary: %w", err)
+	}
+	return summary.RelationshipGraph, nil
+}
+
+// loadCriticalTables loads the project's business-critical table names from its
+// ontology settings. Returns nil when none are set.
+func loadCriticalTables(ctx context.Context, conn *pgx.Conn, projectID uuid.UUID) ([]string, error) {
+	var raw []byte
+	if err := conn.QueryRow(ctx, `
+		SELECT parameters->'ontology'->'critical_tables' FROM engine_projects WHERE id = $1
+	`, projectID).Scan(&raw); err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		return nil, nil
+	}
+
+	var tables []string
+	if err := json.Unmarshal(raw, &tables); err != nil {
+		return nil, fmt.Errorf("parse critical tables: %w", err)
+	}
+	return tables, nil
+}
+
+// loadQuestions loads ontology questions for a project (excluding soft-deleted ones)
+func loadQuestions(ctx context.Context, conn *pgx.Conn, projectID uuid.UUID) ([]OntologyQuestion, error) {
+	query := `
+		SELECT id, text, is_required, source_entity_type, source_entity_key, status
+		FROM engine_ontology_questions
+		WHERE project_id = $1 AND status <> 'deleted'
+		ORDER BY is_required DESC, priority ASC, created_at ASC`
+
+	rows, err := conn.Query(ctx, query, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var questions []OntologyQuestion
+	for rows.Next() {
+		var q OntologyQuestion
+		if err := rows.Scan(&q.ID, &q.Text, &q.IsRequired, &q.SourceEntityType, &q.SourceEntityKey, &q.Status); err != nil {
+			return nil, err
+		}
+		questions = append(questions, q)
+	}
+	return questions, rows.Err()
+}
+
+// =============================================================================
+// Final Score Calculation
+// =============================================================================
+
+// noSelectedTablesIssue is reported instead of the check results when every table is
+// deselected: extraction had nothing to analyze, and the checks would pass vacuously.
+const noSelectedTablesIssue = "No selected tables - nothing was extracted; select tables in the datasource schema and re-run extraction"
+
+// scoreAssessment returns the final score, smart summary and issues. A project with
+// no selected tables scores 0 rather than a misleading 100.
+func scoreAssessment(stats SchemaStats, summary ChecksSummary) (int, string, []string) {
+	if stats.SelectedTableCount == 0 {
+		return 0, noSelectedTablesIssue, []string{noSelectedTablesIssue}
+	}
+	finalScore := calculateWeightedScore(summary)
+	return finalScore, generateSmartSummary(finalScore, summary), collectIssues(summary)
+}
+
+// calculateWeightedScore returns the weighted average of all checks that ran.
+func calculateWeightedScore(summary ChecksSummary) int {
+	weightedSum, totalWeight := 0, 0
+
+	if summary.QuestionSources != nil {
+		weightedSum += summary.QuestionSources.Score * summary.QuestionSources.Weight
+		totalWeight += summary.QuestionSources.Weight
+	}
+	if summary.RelationshipCoverage != nil {
+		weightedSum += summary.RelationshipCoverage.Score * summary.RelationshipCoverage.Weight
+		totalWeight += summary.RelationshipCoverage.Weight
+	}
+	if summary.StatsCompleteness != nil {
+		weightedSum += summary.StatsCompleteness.Score * summary.StatsCompleteness.Weight
+		totalWeight += summary.StatsCompleteness.Weight
+	}
+	if summary.QuestionAnswerability != nil {
+		weightedSum += summary.QuestionAnswerability.Score * summary.QuestionAnswerability.Weight
+		totalWeight += summary.QuestionAnswerability.Weight
+	}
+	if summary.RelationshipTypes != nil {
+		weightedSum += summary.RelationshipTypes.Score * summary.RelationshipTypes.Weight
+		totalWeight += summary.RelationshipTypes.Weight
+	}
+	if summary.InferenceMethods != nil {
+		weightedSum += summary.InferenceMethods.Score * summary.InferenceMethods.Weight
+		totalWeight += summary.InferenceMethods.Weight
+	}
+	if summary.GraphCardinality != nil {
+		weightedSum += summary.GraphCardinality.Score * summary.GraphCardinality.Weight
+		totalWeight += summary.GraphCardinality.Weight
+	}
+	if summary.PromptRelationships != nil {
+		weightedSum += summary.PromptRelationships.Score * summary.PromptRelationships.Weight
+		totalWeight += summary.PromptRelationships.Weight
+	}
+	if summary.PromptCompleteness != nil {
+		weightedSum += summary.PromptCompleteness.Score * summary.PromptCompleteness.Weight
+		totalWeight += summary.PromptCompleteness.Weight
+	}
+	if summary.PromptMix != nil {
+		weightedSum += summary.PromptMix.Score * summary.PromptMix.Weight
+		totalWeight += summary.PromptMix.Weight
+	}
+	if summary.RedactionCompliance != nil {
+		weightedSum += summary.RedactionCompliance.Score * summary.RedactionCompliance.Weight
+		totalWeight += summary.RedactionCompliance.Weight
+	}
+
+	if totalWeight == 0 {
+		return 100
+	}
+	return weightedSum / totalWeight
+}
+
+// collectIssues flattens the issues of every check into a single list.
+func collectIssues(summary ChecksSummary) []string {
+	issues := []string{}
+	// Security issues lead so the smart summary surfaces them first
+	if summary.RedactionCompliance != nil {
+		issues = append(issues, summary.RedactionCompliance.Issues...)
+	}
+	if summary.QuestionSources != nil {
+		issues = append(issues, summary.QuestionSources.Issues...)
+	}
+	if summary.RelationshipCoverage != nil {
+		issues = append(issues, summary.RelationshipCoverage.Issues...)
+	}
+	if summary.StatsCompleteness != nil {
+		issues = append(issues, summary.StatsCompleteness.Issues...)
+	}
+	if summary.QuestionAnswerability != nil {
+		issues = append(issues, summary.QuestionAnswerability.Issues...)
+	}
+	if summary.RelationshipTypes != nil {
+		issues = append(issues, summary.RelationshipTypes.Issues...)
+	}
+	if summary.InferenceMethods != nil {
+		issues = append(issues, summary.InferenceMethods.Issues...)
+	}
+	if summary.GraphCardinality != nil {
+		issues = append(issues, summary.GraphCardinality.Issues...)
+	}
+	if summary.PromptRelationships != nil {
+		issues = append(issues, summary.PromptRelationships.Issues...)
+	}
+	if summary.PromptCompleteness != nil {
+		issues = append(issues, summary.PromptCompleteness.Issues...)
+	}
+	if summary.PromptMix != nil {
+		issues = append(issues, summary.PromptMix.Issues...)
+	}
+	return issues
+}
+
+func generateSmartSummary(finalScore int, summary ChecksSummary) string {
+	if finalScore == 100 {
+		return "Score 100/100 - All deterministic checks passed."
+	}
+
+	issues := collectIssues(summary)
+	parts := []string{fmt.Sprintf("Score %d/100", finalScore)}
+	if len(issues) > 0 {
+		maxIssues := min(3, len(issues))
+		parts = append(parts, strings.Join(issues[:maxIssues], ". "))
+	}
+	return strings.Join(parts, " - ")
+}
+
+// =============================================================================
+// Utility Functions
+// =============================================================================
+
+// patternList collects a repeatable string flag.
+type patternList []string
+
+func (p *patternList) String() string { return strings.Join(*p, ", ") }
+
+func (p *patternList) Set(v string) error {
+	*p = append(*p, v)
+	return nil
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// truncate shortens s to maxLen characters, ending it with "..." when cut. It counts
+// runes, so multi-byte characters are never split.
+func truncate(s string, maxLen int) string {
+	runes := []rune(s)
+	if len(runes) <= maxLen {
+		return s
+	}
+	return string(runes[:maxLen-3]) + "..."
+}
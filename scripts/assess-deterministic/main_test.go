@@ -0,0 +1,30 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestScoreAssessment_NoSelectedTables(t *testing.T) {
+	// Every check passes vacuously when nothing was extracted
+	summary := ChecksSummary{
+		RelationshipCoverage: checkRelationshipCoverage(nil, nil),
+		StatsCompleteness:    &StatsCompletenessScore{Score: 100, Weight: WeightStatsCompleteness},
+	}
+
+	score, smartSummary, issues := scoreAssessment(SchemaStats{TableCount: 4, SelectedTableCount: 0}, summary)
+	if score != 0 {
+		t.Errorf("expected score 0 with no selected tables, got %d", score)
+	}
+	if !strings.HasPrefix(smartSummary, "No selected tables") {
+		t.Errorf("expected a no selected tables summary, got %q", smartSummary)
+	}
+	if len(issues) != 1 || issues[0] != noSelectedTablesIssue {
+		t.Errorf("expected only the no selected tables issue, got %v", issues)
+	}
+
+	score, _, _ = scoreAssessment(SchemaStats{TableCount: 4, SelectedTableCount: 2}, summary)
+	if score != 100 {
+		t.Errorf("expected checks to be scored with selected tables, got %d", score)
+	}
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// completenessPromptTypes are the prompt types that describe specific tables in full,
+// so every selected column of their target tables is expected in the prompt.
+var completenessPromptTypes = []PromptType{
+	PromptTypeEntityAnalysis,
+}
+
+// PromptCompletenessScore measures, per conversation, how much of the schema context
+// of the table the prompt targets actually reached the prompt. Rather than checking
+// that some table or column appears anywhere, each target table's context is broken
+// into elements and each element is looked up on the prompt line that names it:
+//   - every selected column, with its data type
+//   - the primary key flag on primary key columns
+//   - the nullability of every column ("nullable", "not null")
+//   - the distinct count of columns that have stats, and the table's row count on a
+//     line that mentions rows
+//   - every relationship touching the table
+//
+// The score is the average completeness of the prompts checked.
+type PromptCompletenessScore struct {
+	Score          int                  `json:"score"`
+	Weight         int                  `json:"weight"`
+	PromptsChecked int                  `json:"prompts_checked"`
+	Incomplete     []PromptCompleteness `json:"incomplete,omitempty"`
+	Issues         []string             `json:"issues"`
+}
+
+// PromptCompleteness is the context completeness of one conversation's prompt.
+type PromptCompleteness struct {
+	ConversationID string     `json:"conversation_id"`
+	PromptType     PromptType `json:"prompt_type"`
+	Table          string     `json:"table"`
+	Expected       int        `json:"expected"`
+	Included       int        `json:"included"`
+	Completeness   float64    `json:"completeness"` // Percent of expected elements included
+	Missing        []string   `json:"missing,omitempty"`
+}
+
+// checkPromptCompleteness scores every prompt whose type targets a specific table.
+// Prompts whose target is not a selected table are skipped.
+func checkPromptCompleteness(prompts []LLMPrompt, schema []SchemaTable, relationships []SchemaRelationship) *PromptCompletenessScore {
+	result := &PromptCompletenessScore{
+		Score:  100,
+		Weight: WeightPromptCompleteness,
+		Issues: []string{},
+	}
+
+	targeted := make(map[PromptType]bool, len(completenessPromptTypes))
+	for _, pt := range completenessPromptTypes {
+		targeted[pt] = true
+	}
+
+	total := 0.0
+	for _, p := range prompts {
+		promptType, target := detectPromptType(p)
+		if !targeted[promptType] {
+			continue
+		}
+		table, ok := findSelectedTable(schema, target)
+		if !ok {
+			continue
+		}
+
+		completeness := measurePromptCompleteness(p, table, relationships)
+		completeness.PromptType = promptType
+		result.PromptsChecked++
+		total += completeness.Completeness
+		if completeness.Included < completeness.Expected {
+			result.Incomplete = append(result.Incomplete, completeness)
+		}
+	}
+
+	if result.PromptsChecked == 0 {
+		return result
+	}
+	result.Score = int(total / float64(result.PromptsChecked))
+
+	if len(result.Incomplete) > 0 {
+		worst := result.Incomplete[0]
+		for _, c := range result.Incomplete[1:] {
+			if c.Completeness < worst.Completeness {
+				worst = c
+			}
+		}
+		result.Issues = append(result.Issues, fmt.Sprintf(
+			"%d/%d prompts lack part of their table's schema context (worst: %s for %s, %.0f%% complete, missing %s)",
+			len(result.Incomplete), result.PromptsChecked, worst.PromptType, worst.Table,
+			worst.Completeness, strings.Join(firstN(worst.Missing, 3), ", ")))
+	}
+	return result
+}
+
+// measurePromptCompleteness counts which context elements of table appear in the prompt.
+func measurePromptCompleteness(p LLMPrompt, table SchemaTable, relationships []SchemaRelationship) PromptCompleteness {
+	content := strings.ToLower(p.UserContent + "\n" + p.SystemContent)
+	lines := strings.Split(content, "\n")
+	tableName := strings.ToLower(table.TableName)
+
+	c := PromptCompleteness{
+		ConversationID: p.ConversationID.String(),
+		Table:          table.TableName,
+		Missing:        []string{},
+	}
+	check := func(included bool, element string) {
+		c.Expected++
+		if included {
+			c.Included++
+		} else {
+			c.Missing = append(c.Missing, element)
+		}
+	}
+
+	if table.RowCount != nil {
+		rowCountStated := false
+		for _, line := range linesNaming(lines, strconv.FormatInt(*table.RowCount, 10)) {
+			rowCountStated = rowCountStated || strings.Contains(line, "row")
+		}
+		check(rowCountStated, table.TableName+" row count")
+	}
+
+	for _, col := range table.Columns {
+		if !col.IsSelected {
+			continue
+		}
+		name := table.TableName + "." + col.ColumnName
+		colLines := linesNaming(lines, strings.ToLower(col.ColumnName))
+		described := func(markers ...string) bool {
+			for _, line := range colLines {
+				for _, marker := range markers {
+					if containsIdentifier(line, marker) {
+						return true
+					}
+				}
+			}
+			return false
+		}
+
+		check(len(colLines) > 0, name)
+		check(described(baseDataType(col.DataType)), name+" type")
+		if col.IsPrimaryKey {
+			check(described("pk", "primary key"), name+" primary key")
+		}
+		if col.IsNullable {
+			check(described("nullable", "null") && !described("not null", "non-null"), name+" nullability")
+		} else {
+			check(described("not null", "non-null", "required") || (col.IsPrimaryKey && described("pk", "primary key")), name+" nullability")
+		}
+		if col.DistinctCount != nil {
+			check(described(strconv.FormatInt(*col.DistinctCount, 10)), name+" distinct count")
+		}
+	}
+
+	for _, r := range relationships {
+		sourceTable, sourceColumn := splitQualifiedColumn(r.SourceColumn)
+		targetTable, _ := splitQualifiedColumn(r.TargetColumn)
+		if sourceTable != tableName && targetTable != tableName {
+			continue
+		}
+		check(containsIdentifier(content, strings.ToLower(r.TargetColumn)) &&
+			(containsIdentifier(content, strings.ToLower(r.SourceColumn)) || containsIdentifier(content, sourceColumn)),
+			"relationship "+r.SourceColumn+" -> "+r.TargetColumn)
+	}
+
+	c.Completeness = 100
+	if c.Expected > 0 {
+		c.Completeness = float64(c.Included) * 100 / float64(c.Expected)
+	}
+	sort.Strings(c.Missing)
+	return c
+}
+
+// findSelectedTable finds a selected table by bare or schema-qualified name.
+func findSelectedTable(schema []SchemaTable, name string) (SchemaTable, bool) {
+	name = strings.ToLower(name)
+	if name == "" {
+		return SchemaTable{}, false
+	}
+	for _, t := range schema {
+		if !t.IsSelected {
+			continue
+		}
+		if strings.ToLower(t.TableName) == name || strings.ToLower(t.SchemaName+"."+t.TableName) == name {
+			return t, true
+		}
+	}
+	return SchemaTable{}, false
+}
+
+// linesNaming returns the lines that name ident.
+func linesNaming(lines []string, ident string) []string {
+	var named []string
+	for _, line := range lines {
+		if containsIdentifier(line, ident) {
+			named = append(named, line)
+		}
+	}
+	return named
+}
+
+// baseDataType lowercases a data type and drops its modifiers: "VARCHAR(255)" is "varchar".
+func baseDataType(dataType string) string {
+	t := strings.ToLower(dataType)
+	if i := strings.Index(t, "("); i > 0 {
+		t = t[:i]
+	}
+	return strings.TrimSpace(t)
+}
+
+func firstN(items []string, n int) []string {
+	if len(items) > n {
+		return items[:n]
+	}
+	return items
+}
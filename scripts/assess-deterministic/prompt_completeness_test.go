@@ -0,0 +1,109 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func completenessSchema() []SchemaTable {
+	rows := int64(1200)
+	idDistinct := int64(1200)
+	statusDistinct := int64(4)
+	return []SchemaTable{
+		{
+			TableName:  "orders",
+			SchemaName: "public",
+			IsSelected: true,
+			RowCount:   &rows,
+			Columns: []SchemaColumn{
+				{ColumnName: "id", DataType: "uuid", IsPrimaryKey: true, IsSelected: true, DistinctCount: &idDistinct},
+				{ColumnName: "user_id", DataType: "uuid", IsSelected: true},
+				{ColumnName: "status", DataType: "VARCHAR(20)", IsSelected: true, IsNullable: true, DistinctCount: &statusDistinct},
+				{ColumnName: "internal_notes", DataType: "text", IsNullable: true},
+			},
+		},
+		{TableName: "users", SchemaName: "public", IsSelected: true},
+	}
+}
+
+func entityAnalysisPrompt(tableSchema string) LLMPrompt {
+	return LLMPrompt{
+		ConversationID: uuid.New(),
+		UserContent: "Analyze the table \"orders\".\n## Table Schema\n" + tableSchema +
+			"## Question Classification Rules\n...",
+	}
+}
+
+func TestCheckPromptCompleteness_CompletePrompt(t *testing.T) {
+	relationships := []SchemaRelationship{{ID: uuid.New(), SourceColumn: "orders.user_id", TargetColumn: "users.id"}}
+	prompt := entityAnalysisPrompt(`Rows: 1200
+- id: uuid, PK, 1200 distinct values
+- user_id: uuid, NOT NULL
+- status: varchar, nullable, 4 distinct values
+## Relationships
+- user_id references users.id
+`)
+
+	result := checkPromptCompleteness([]LLMPrompt{prompt}, completenessSchema(), relationships)
+
+	if result.PromptsChecked != 1 || result.Score != 100 {
+		t.Fatalf("expected 1 prompt checked at 100, got %d at %d", result.PromptsChecked, result.Score)
+	}
+	if len(result.Incomplete) != 0 || len(result.Issues) != 0 {
+		t.Errorf("expected a complete prompt, got incomplete %+v and issues %v", result.Incomplete, result.Issues)
+	}
+}
+
+func TestCheckPromptCompleteness_PartialPrompt(t *testing.T) {
+	relationships := []SchemaRelationship{{ID: uuid.New(), SourceColumn: "orders.user_id", TargetColumn: "users.id"}}
+	// Columns are present but status has no type, stats or nullability, and the
+	// row count and relationship are absent
+	prompt := entityAnalysisPrompt(`- id: uuid, PK, 1200 distinct values
+- user_id: uuid, NOT NULL
+- status
+`)
+
+	result := checkPromptCompleteness([]LLMPrompt{prompt}, completenessSchema(), relationships)
+
+	if result.PromptsChecked != 1 || len(result.Incomplete) != 1 {
+		t.Fatalf("expected 1 incomplete prompt, got %+v", result)
+	}
+	got := result.Incomplete[0]
+	// Elements: row count, id (column, type, pk, nullability, distinct), user_id
+	// (column, type, nullability), status (column, type, nullability, distinct), relationship
+	if got.Expected != 14 || got.Included != 9 {
+		t.Errorf("expected 9/14 elements included, got %d/%d", got.Included, got.Expected)
+	}
+	wantMissing := []string{
+		"orders row count",
+		"orders.status distinct count",
+		"orders.status nullability",
+		"orders.status type",
+		"relationship orders.user_id -> users.id",
+	}
+	if !reflect.DeepEqual(got.Missing, wantMissing) {
+		t.Errorf("missing = %v, want %v", got.Missing, wantMissing)
+	}
+	if result.Score != 64 {
+		t.Errorf("expected score 64 (9/14), got %d", result.Score)
+	}
+	if len(result.Issues) != 1 || !strings.Contains(result.Issues[0], "1/1 prompts lack") {
+		t.Errorf("unexpected issues: %v", result.Issues)
+	}
+}
+
+func TestCheckPromptCompleteness_SkipsUntargetedPrompts(t *testing.T) {
+	prompts := []LLMPrompt{
+		{ConversationID: uuid.New(), SystemContent: tier1System, UserContent: "## Tables\n### orders\n- id\n"},
+		{ConversationID: uuid.New(), UserContent: "Analyze the table \"archived\".\n## Table Schema\n- id\n## Question Classification Rules\n"},
+	}
+
+	result := checkPromptCompleteness(prompts, completenessSchema(), nil)
+
+	if result.PromptsChecked != 0 || result.Score != 100 {
+		t.Errorf("expected no prompts checked and score 100, got %d at %d", result.PromptsChecked, result.Score)
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import "fmt"
+
+// PromptMixScore checks that extraction issued every kind of prompt the project's
+// configuration calls for. Selected tables call for entity_analysis and a tier0_domain
+// summary; a project description also calls for description_processing. A missing
+// type means a pipeline stage never reached the LLM (e.g. the domain summary was
+// skipped), which the per-prompt checks cannot see because there is nothing to check.
+type PromptMixScore struct {
+	Score    int                `json:"score"`
+	Weight   int                `json:"weight"`
+	Counts   map[PromptType]int `json:"counts"` // Prompts issued per detected type
+	Expected []PromptType       `json:"expected"`
+	Missing  []PromptType       `json:"missing,omitempty"`
+	Issues   []string           `json:"issues"`
+}
+
+// missingPromptEffects says what a missing prompt type means for the extraction.
+var missingPromptEffects = map[PromptType]string{
+	PromptTypeEntityAnalysis:        "no selected table was analyzed",
+	PromptTypeTier0Domain:           "the domain summary was never generated",
+	PromptTypeDescriptionProcessing: "the project description was never processed",
+}
+
+// expectedPromptTypes returns the prompt types a complete extraction issues for a
+// project with the given number of selected tables and a description or not.
+func expectedPromptTypes(selectedTables int, hasDescription bool) []PromptType {
+	var expected []PromptType
+	if selectedTables > 0 {
+		expected = append(expected, PromptTypeEntityAnalysis, PromptTypeTier0Domain)
+	}
+	if hasDescription {
+		expected = append(expected, PromptTypeDescriptionProcessing)
+	}
+	return expected
+}
+
+// checkPromptMix counts the stored prompts by type and flags each expected type that
+// was never issued. The score is the share of expected types present.
+func checkPromptMix(prompts []LLMPrompt, selectedTables int, hasDescription bool) *PromptMixScore {
+	result := &PromptMixScore{
+		Weight:   WeightPromptMix,
+		Counts:   make(map[PromptType]int),
+		Expected: expectedPromptTypes(selectedTables, hasDescription),
+		Issues:   []string{},
+	}
+
+	for _, p := range prompts {
+		promptType, _ := detectPromptType(p)
+		result.Counts[promptType]++
+	}
+
+	for _, pt := range result.Expected {
+		if result.Counts[pt] > 0 {
+			continue
+		}
+		result.Missing = append(result.Missing, pt)
+		result.Issues = append(result.Issues, fmt.Sprintf(
+			"No %s prompt was issued: %s", pt, missingPromptEffects[pt]))
+	}
+
+	if len(result.Expected) == 0 {
+		result.Score = 100
+		return result
+	}
+	result.Score = (len(result.Expected) - len(result.Missing)) * 100 / len(result.Expected)
+	return result
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestCheckPromptMix_FlagsMissingDomainSummary(t *testing.T) {
+	prompts := []LLMPrompt{
+		entityAnalysisPrompt("- id\n"),
+		entityAnalysisPrompt("- id\n- user_id\n"),
+		{ConversationID: uuid.New(), UserContent: "User's description: a store. Database schema: orders, users. Return entity_hints."},
+	}
+
+	result := checkPromptMix(prompts, 2, true)
+
+	if result.Counts[PromptTypeEntityAnalysis] != 2 || result.Counts[PromptTypeDescriptionProcessing] != 1 {
+		t.Errorf("unexpected prompt counts: %v", result.Counts)
+	}
+	if len(result.Missing) != 1 || result.Missing[0] != PromptTypeTier0Domain {
+		t.Fatalf("expected only tier0_domain missing, got %v", result.Missing)
+	}
+	if result.Score != 66 {
+		t.Errorf("expected score 66, got %d", result.Score)
+	}
+	if len(result.Issues) != 1 || !strings.Contains(result.Issues[0], "No tier0_domain prompt was issued: the domain summary was never generated") {
+		t.Errorf("unexpected issues: %v", result.Issues)
+	}
+
+	summary := ChecksSummary{PromptMix: result}
+	if issues := collectIssues(summary); len(issues) != 1 {
+		t.Errorf("expected the missing prompt type in the assessment issues, got %v", issues)
+	}
+}
+
+func TestCheckPromptMix_DescriptionOnlyExpectedWhenGiven(t *testing.T) {
+	prompts := []LLMPrompt{
+		entityAnalysisPrompt("- id\n"),
+		{
+			ConversationID: uuid.New(),
+			SystemContent:  "You write the domain summary for a database.",
+			UserContent:    "## Entities by Domain\n...\n## Entity Descriptions\n...",
+		},
+	}
+
+	result := checkPromptMix(prompts, 1, false)
+
+	if len(result.Missing) != 0 || result.Score != 100 {
+		t.Errorf("expected no missing prompt types without a description, got %+v", result)
+	}
+	if none := checkPromptMix(nil, 0, false); none.Score != 100 || len(none.Expected) != 0 {
+		t.Errorf("expected nothing expected without selected tables or a description, got %+v", none)
+	}
+}
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// PromptType identifies the kind of extraction prompt a stored LLM conversation carries.
+// Detection uses the same markers as assess-llm-responses.
+type PromptType string
+
+const (
+	PromptTypeEntityAnalysis        PromptType = "entity_analysis"
+	PromptTypeTier1Batch            PromptType = "tier1_batch"
+	PromptTypeTier0Domain           PromptType = "tier0_domain"
+	PromptTypeDescriptionProcessing PromptType = "description_processing"
+	PromptTypeUnknown               PromptType = "unknown"
+)
+
+// relationshipPromptTypes are the prompt types whose input is expected to carry the
+// relationships between the tables they cover.
+var relationshipPromptTypes = []PromptType{
+	PromptTypeEntityAnalysis,
+	PromptTypeTier1Batch,
+	PromptTypeTier0Domain,
+}
+
+var reAnalyzeTable = regexp.MustCompile(`(?i)analyze the table "([^"]+)"`)
+
+// LLMPrompt is the request side of a stored LLM conversation.
+type LLMPrompt struct {
+	ConversationID  uuid.UUID
+	UserContent     string
+	SystemContent   string
+	RequestMessages json.RawMessage // Every stored message, including tool results
+}
+
+// PromptRelationshipScore checks that every prompt expected to carry relationship
+// context actually included the relationships gathered for the tables it covers.
+// A prompt that omits them asks the LLM to describe tables without knowing how they
+// join, which is an input-preparation bug rather than an LLM failure.
+//
+// Expected relationships per prompt type:
+//   - entity_analysis: every relationship touching the analyzed table
+//   - tier1_batch, tier0_domain: every relationship between two tables the prompt mentions
+//
+// A relationship counts as included when the prompt names its target as table.column
+// and its source either as table.column or by column name.
+type PromptRelationshipScore struct {
+	Score          int                                    `json:"score"`
+	Weight         int                                    `json:"weight"`
+	PromptsChecked int                                    `json:"prompts_checked"`
+	ByPromptType   map[PromptType]*PromptRelationshipStat `json:"by_prompt_type"`
+	Missing        []MissingPromptRelationship            `json:"missing,omitempty"`
+	Issues         []string                               `json:"issues"`
+}
+
+// PromptRelationshipStat is the relationship coverage for one prompt type.
+type PromptRelationshipStat struct {
+	Prompts  int     `json:"prompts"`
+	Expected int     `json:"expected"`
+	Included int     `json:"included"`
+	Coverage float64 `json:"coverage"` // Percent of expected relationships included
+}
+
+// MissingPromptRelationship is a relationship a prompt should have carried but did not.
+type MissingPromptRelationship struct {
+	ConversationID string     `json:"conversation_id"`
+	PromptType     PromptType `json:"prompt_type"`
+	Relationship   string     `json:"relationship"` // source table.column -> target table.column
+}
+
+// newLLMPrompt extracts the user and system content from a conversation's request
+// messages. Unparseable messages yield an empty prompt, which detects as unknown.
+func newLLMPrompt(id uuid.UUID, requestMessages json.RawMessage) LLMPrompt {
+	p := LLMPrompt{ConversationID: id, RequestMessages: requestMessages}
+	var messages []map[string]string
+	if err := json.Unmarshal(requestMessages, &messages); err != nil {
+		return p
+	}
+	for _, msg := range messages {
+		switch msg["role"] {
+		case "user":
+			p.UserContent = msg["content"]
+		case "system":
+			p.SystemContent = msg["content"]
+		}
+	}
+	return p
+}
+
+// detectPromptType returns the prompt type and, for entity_analysis, the analyzed table.
+func detectPromptType(p LLMPrompt) (PromptType, string) {
+	user := strings.ToLower(p.UserContent)
+	system := strings.ToLower(p.SystemContent)
+
+	// Most specific first
+	if strings.Contains(user, "## table schema") &&
+		strings.Contains(user, "question classification rules") &&
+		strings.Contains(user, "analyze the table") {
+		target := ""
+		if m := reAnalyzeTable.FindStringSubmatch(user); len(m) >= 2 {
+			target = m[1]
+		}
+		return PromptTypeEntityAnalysis, target
+	}
+	if strings.Contains(user, "entities by domain") &&
+		strings.Contains(user, "entity descriptions") &&
+		strings.Contains(system, "domain summary") {
+		return PromptTypeTier0Domain, ""
+	}
+	if strings.Contains(user, "user's description") &&
+		strings.Contains(user, "database schema") &&
+		strings.Contains(user, "entity_hints") {
+		return PromptTypeDescriptionProcessing, ""
+	}
+	if strings.Contains(user, "## tables") &&
+		strings.Contains(system, "entity summaries") {
+		return PromptTypeTier1Batch, ""
+	}
+	return PromptTypeUnknown, ""
+}
+
+// checkPromptRelationships validates every relationship-carrying prompt against the
+// active relationship set and reports coverage per prompt type.
+func checkPromptRelationships(prompts []LLMPrompt, relationships []SchemaRelationship) *PromptRelationshipScore {
+	result := &PromptRelationshipScore{
+		Weight:       WeightPromptRelationships,
+		ByPromptType: make(map[PromptType]*PromptRelationshipStat),
+		Issues:       []string{},
+	}
+	for _, pt := range relationshipPromptTypes {
+		result.ByPromptType[pt] = &PromptRelationshipStat{}
+	}
+
+	for _, p := range prompts {
+		promptType, target := detectPromptType(p)
+		stat, ok := result.ByPromptType[promptType]
+		if !ok {
+			continue
+		}
+		result.PromptsChecked++
+		stat.Prompts++
+
+		content := strings.ToLower(p.UserContent + "\n" + p.SystemContent)
+		for _, r := range relationships {
+			sourceTable, sourceColumn := splitQualifiedColumn(r.SourceColumn)
+			targetTable, _ := splitQualifiedColumn(r.TargetColumn)
+
+			var expected bool
+			if promptType == PromptTypeEntityAnalysis {
+				expected = target != "" && (sourceTable == strings.ToLower(target) || targetTable == strings.ToLower(target))
+			} else {
+				expected = containsIdentifier(content, sourceTable) && containsIdentifier(content, targetTable)
+			}
+			if !expected {
+				continue
+			}
+
+			stat.Expected++
+			if containsIdentifier(content, strings.ToLower(r.TargetColumn)) &&
+				(containsIdentifier(content, strings.ToLower(r.SourceColumn)) || containsIdentifier(content, sourceColumn)) {
+				stat.Included++
+				continue
+			}
+			result.Missing = append(result.Missing, MissingPromptRelationship{
+				ConversationID: p.ConversationID.String(),
+				PromptType:     promptType,
+				Relationship:   r.SourceColumn + " -> " + r.TargetColumn,
+			})
+		}
+	}
+
+	expected, included := 0, 0
+	for _, pt := range relationshipPromptTypes {
+		stat := result.ByPromptType[pt]
+		expected += stat.Expected
+		included += stat.Included
+		if stat.Expected == 0 {
+			stat.Coverage = 100
+			continue
+		}
+		stat.Coverage = float64(stat.Included) * 100 / float64(stat.Expected)
+		if missing := stat.Expected - stat.Included; missing > 0 {
+			result.Issues = append(result.Issues, fmt.Sprintf(
+				"%s prompts omitted %d/%d gathered relationships (e.g. %s)",
+				pt, missing, stat.Expected, firstMissingRelationship(result.Missing, pt)))
+		}
+	}
+
+	if expected == 0 {
+		result.Score = 100
+		return result
+	}
+	result.Score = included * 100 / expected
+	return result
+}
+
+// splitQualifiedColumn splits a lowercased "table.column" into its parts.
+func splitQualifiedColumn(qualified string) (table, column string) {
+	table, column, _ = strings.Cut(strings.ToLower(qualified), ".")
+	return table, column
+}
+
+// containsIdentifier reports whether ident appears in content delimited by
+// non-identifier characters, so "users" does not match inside "app_users".
+func containsIdentifier(content, ident string) bool {
+	if ident == "" {
+		return false
+	}
+	for start := 0; ; {
+		i := strings.Index(content[start:], ident)
+		if i < 0 {
+			return false
+		}
+		i += start
+		end := i + len(ident)
+		if (i == 0 || !isIdentifierByte(content[i-1])) && (end == len(content) || !isIdentifierByte(content[end])) {
+			return true
+		}
+		start = i + 1
+	}
+}
+
+func isIdentifierByte(b byte) bool {
+	return b == '_' || (b >= 'a' && b <= 'z') || (b >= '0' && b <= '9')
+}
+
+func firstMissingRelationship(missing []MissingPromptRelationship, promptType PromptType) string {
+	var rels []string
+	for _, m := range missing {
+		if m.PromptType == promptType {
+			rels = append(rels, m.Relationship)
+		}
+	}
+	sort.Strings(rels)
+	if len(rels) == 0 {
+		return ""
+	}
+	return rels[0]
+}
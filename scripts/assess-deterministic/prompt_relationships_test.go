@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+const tier1System = "You write entity summaries for database tables."
+
+func TestCheckPromptRelationships_Tier1BatchMissingRelationships(t *testing.T) {
+	relationships := []SchemaRelationship{
+		{ID: uuid.New(), SourceColumn: "orders.user_id", TargetColumn: "users.id"},
+		{ID: uuid.New(), SourceColumn: "payments.order_id", TargetColumn: "orders.id"},
+	}
+	withRelationships := LLMPrompt{
+		ConversationID: uuid.New(),
+		SystemContent:  tier1System,
+		UserContent: "## Tables\n### orders\n- id\n- user_id\n### users\n- id\n" +
+			"## Relationships\n- orders.user_id -> users.id\n",
+	}
+	withoutRelationships := LLMPrompt{
+		ConversationID: uuid.New(),
+		SystemContent:  tier1System,
+		UserContent:    "## Tables\n### payments\n- id\n- order_id\n### orders\n- id\n- user_id\n",
+	}
+
+	result := checkPromptRelationships([]LLMPrompt{withRelationships, withoutRelationships}, relationships)
+
+	stat := result.ByPromptType[PromptTypeTier1Batch]
+	if stat.Prompts != 2 || stat.Expected != 2 || stat.Included != 1 {
+		t.Fatalf("expected 2 tier1 prompts with 1/2 relationships included, got %+v", stat)
+	}
+	if stat.Coverage != 50 || result.Score != 50 {
+		t.Errorf("expected coverage and score 50, got %.1f and %d", stat.Coverage, result.Score)
+	}
+	if len(result.Missing) != 1 {
+		t.Fatalf("expected 1 missing relationship, got %+v", result.Missing)
+	}
+	missing := result.Missing[0]
+	if missing.ConversationID != withoutRelationships.ConversationID.String() ||
+		missing.PromptType != PromptTypeTier1Batch ||
+		missing.Relationship != "payments.order_id -> orders.id" {
+		t.Errorf("unexpected missing relationship: %+v", missing)
+	}
+	if len(result.Issues) != 1 || !strings.Contains(result.Issues[0], "tier1_batch prompts omitted 1/2") {
+		t.Errorf("unexpected issues: %v", result.Issues)
+	}
+	if got := result.ByPromptType[PromptTypeEntityAnalysis]; got.Prompts != 0 || got.Coverage != 100 {
+		t.Errorf("expected no entity_analysis prompts at full coverage, got %+v", got)
+	}
+}
+
+func TestCheckPromptRelationships_EntityAnalysisCoversAnalyzedTable(t *testing.T) {
+	relationships := []SchemaRelationship{
+		{ID: uuid.New(), SourceColumn: "orders.user_id", TargetColumn: "users.id"},
+		{ID: uuid.New(), SourceColumn: "payments.order_id", TargetColumn: "orders.id"},
+		{ID: uuid.New(), SourceColumn: "sessions.user_id", TargetColumn: "users.id"},
+	}
+	prompt := LLMPrompt{
+		ConversationID: uuid.New(),
+		UserContent: `Analyze the table "orders".
+## Table Schema
+- id
+- user_id
+## Relationships
+- user_id references users.id
+## Question Classification Rules
+...`,
+	}
+
+	result := checkPromptRelationships([]LLMPrompt{prompt}, relationships)
+
+	stat := result.ByPromptType[PromptTypeEntityAnalysis]
+	if stat.Expected != 2 || stat.Included != 1 {
+		t.Fatalf("expected 1/2 relationships touching orders included, got %+v", stat)
+	}
+	if len(result.Missing) != 1 || result.Missing[0].Relationship != "payments.order_id -> orders.id" {
+		t.Errorf("unexpected missing relationships: %+v", result.Missing)
+	}
+}
+
+func TestCheckPromptRelationships_IgnoresPromptsWithoutRelationshipContext(t *testing.T) {
+	relationships := []SchemaRelationship{{ID: uuid.New(), SourceColumn: "orders.user_id", TargetColumn: "users.id"}}
+	prompts := []LLMPrompt{
+		{ConversationID: uuid.New(), UserContent: "User's description: a store. Database schema: orders, users. Return entity_hints."},
+		{ConversationID: uuid.New(), UserContent: "Classify the column orders.user_id"},
+	}
+
+	result := checkPromptRelationships(prompts, relationships)
+
+	if result.PromptsChecked != 0 || result.Score != 100 || len(result.Issues) != 0 {
+		t.Errorf("expected no prompts checked and a perfect score, got %+v", result)
+	}
+}
+
+func TestNewLLMPrompt(t *testing.T) {
+	raw, _ := json.Marshal([]map[string]string{
+		{"role": "system", "content": tier1System},
+		{"role": "user", "content": "## Tables\n### orders"},
+	})
+	p := newLLMPrompt(uuid.New(), raw)
+
+	if promptType, _ := detectPromptType(p); promptType != PromptTypeTier1Batch {
+		t.Errorf("expected tier1_batch, got %s", promptType)
+	}
+	if empty := newLLMPrompt(uuid.New(), json.RawMessage(`not json`)); empty.UserContent != "" {
+		t.Errorf("expected empty prompt for unparseable messages, got %+v", empty)
+	}
+}
+
+func TestContainsIdentifier(t *testing.T) {
+	content := "join app_users.id to orders.user_id"
+	if containsIdentifier(content, "users.id") {
+		t.Error("users.id should not match inside app_users.id")
+	}
+	if !containsIdentifier(content, "user_id") {
+		t.Error("user_id should match its qualified form")
+	}
+	if !containsIdentifier(content, "orders") {
+		t.Error("orders should match as a table qualifier")
+	}
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/adapters/datasource"
+)
+
+// QuestionSourceScore reports whether stored questions point at entities that still exist.
+// A dangling reference means a question was generated for a table or column that has since
+// been deselected, renamed, or removed, so answering it can no longer update the ontology.
+type QuestionSourceScore struct {
+	Score              int                `json:"score"`
+	Weight             int                `json:"weight"`
+	QuestionsChecked   int                `json:"questions_checked"`
+	DanglingCount      int                `json:"dangling_count"`
+	DanglingReferences []DanglingQuestion `json:"dangling_references,omitempty"`
+	Issues             []string           `json:"issues"`
+}
+
+// DanglingQuestion describes a question whose source entity could not be resolved.
+type DanglingQuestion struct {
+	QuestionID string `json:"question_id"`
+	Question   string `json:"question"`
+	EntityType string `json:"entity_type"`
+	EntityKey  string `json:"entity_key"`
+	Reason     string `json:"reason"`
+}
+
+// checkQuestionSources validates each question's source_entity_type/source_entity_key
+// against the schema. Questions without a source entity are not checked.
+//
+// Supported entity types:
+//   - "table":  key is a table name, optionally schema-qualified ("orders" or "public.orders")
+//   - "column": key is "table.column" or "schema.table.column"
+func checkQuestionSources(questions []OntologyQuestion, schema []SchemaTable) *QuestionSourceScore {
+	result := &QuestionSourceScore{
+		Weight: WeightQuestionSources,
+		Issues: []string{},
+	}
+
+	for _, q := range questions {
+		entityType := stringOrEmpty(q.SourceEntityType)
+		entityKey := stringOrEmpty(q.SourceEntityKey)
+		if entityType == "" && entityKey == "" {
+			continue
+		}
+		result.QuestionsChecked++
+
+		reason := resolveQuestionSource(entityType, entityKey, schema)
+		if reason == "" {
+			continue
+		}
+
+		result.DanglingCount++
+		result.DanglingReferences = append(result.DanglingReferences, DanglingQuestion{
+			QuestionID: q.ID.String(),
+			Question:   truncate(q.Text, 80),
+			EntityType: entityType,
+			EntityKey:  entityKey,
+			Reason:     reason,
+		})
+		result.Issues = append(result.Issues, fmt.Sprintf("Question %q references %s '%s': %s",
+			truncate(q.Text, 60), entityType, entityKey, reason))
+	}
+
+	if result.QuestionsChecked == 0 {
+		result.Score = 100
+		return result
+	}
+
+	result.Score = (result.QuestionsChecked - result.DanglingCount) * 100 / result.QuestionsChecked
+	return result
+}
+
+// resolveQuestionSource returns an empty string when the reference resolves to a
+// selected table/column, otherwise a short reason describing why it dangles.
+func resolveQuestionSource(entityType, entityKey string, schema []SchemaTable) string {
+	if entityKey == "" {
+		return "source entity key is empty"
+	}
+
+	switch entityType {
+	case "table":
+		table := findTable(entityKey, schema)
+		if table == nil {
+			return "table does not exist in schema"
+		}
+		if !table.IsSelected {
+			return "table is not selected"
+		}
+		return ""
+
+	case "column":
+		tableKey, columnName := splitColumnKey(entityKey)
+		if tableKey == "" || columnName == "" {
+			return "column key must be table.column"
+		}
+		table := findTable(tableKey, schema)
+		if table == nil {
+			return "table does not exist in schema"
+		}
+		if !table.IsSelected {
+			return "table is not selected"
+		}
+		for _, c := range table.Columns {
+			if identifierMatches(c.ColumnName, columnName) {
+				if !c.IsSelected {
+					return "column is not selected"
+				}
+				return ""
+			}
+		}
+		return "column does not exist in table"
+
+	default:
+		return fmt.Sprintf("unknown source entity type '%s'", entityType)
+	}
+}
+
+// findTable looks up a table by bare or schema-qualified name. Names may be quoted
+// (public."OrderItems") and are matched case-insensitively unless an exact match exists.
+func findTable(name string, schema []SchemaTable) *SchemaTable {
+	idx := datasource.NewIdentifierIndex[*SchemaTable]()
+	for i := range schema {
+		t := &schema[i]
+		idx.Add(t.SchemaName+"."+t.TableName, t)
+		idx.Add(t.TableName, t)
+	}
+	t, _ := idx.Get(name)
+	return t
+}
+
+// identifierMatches compares a catalog name against a possibly quoted reference.
+func identifierMatches(catalogName, ref string) bool {
+	return datasource.NormalizeIdentifier(catalogName) == datasource.NormalizeIdentifier(ref)
+}
+
+// splitColumnKey splits "table.column" or "schema.table.column" at the last dot that
+// is not inside a quoted identifier, returning the table reference and unquoted column.
+func splitColumnKey(key string) (tableRef, column string) {
+	var quote byte
+	last := -1
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '`':
+			quote = c
+		case c == '[':
+			quote = ']'
+		case c == '.':
+			last = i
+		}
+	}
+	if last <= 0 {
+		return "", ""
+	}
+	return key[:last], datasource.UnquoteIdentifier(key[last+1:])
+}
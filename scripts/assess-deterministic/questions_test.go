@@ -0,0 +1,139 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+func strPtr(s string) *string { return &s }
+
+func testSchema() []SchemaTable {
+	return []SchemaTable{
+		{
+			SchemaName: "public",
+			TableName:  "users",
+			IsSelected: true,
+			Columns: []SchemaColumn{
+				{ColumnName: "id", IsSelected: true},
+				{ColumnName: "email", IsSelected: true},
+				{ColumnName: "legacy_flag", IsSelected: false},
+			},
+		},
+		{
+			SchemaName: "public",
+			TableName:  "audit_log",
+			IsSelected: false,
+		},
+	}
+}
+
+func TestCheckQuestionSources_AllResolve(t *testing.T) {
+	questions := []OntologyQuestion{
+		{ID: uuid.New(), Text: "What is a user?", SourceEntityType: strPtr("table"), SourceEntityKey: strPtr("users")},
+		{ID: uuid.New(), Text: "Qualified table", SourceEntityType: strPtr("table"), SourceEntityKey: strPtr("public.users")},
+		{ID: uuid.New(), Text: "What is email?", SourceEntityType: strPtr("column"), SourceEntityKey: strPtr("users.email")},
+		{ID: uuid.New(), Text: "No source"},
+	}
+
+	result := checkQuestionSources(questions, testSchema())
+
+	if result.Score != 100 {
+		t.Errorf("expected score 100, got %d (issues: %v)", result.Score, result.Issues)
+	}
+	if result.QuestionsChecked != 3 {
+		t.Errorf("expected 3 questions checked, got %d", result.QuestionsChecked)
+	}
+	if result.DanglingCount != 0 {
+		t.Errorf("expected no dangling references, got %d", result.DanglingCount)
+	}
+}
+
+func TestCheckQuestionSources_MissingTable(t *testing.T) {
+	questions := []OntologyQuestion{
+		{ID: uuid.New(), Text: "What is a user?", SourceEntityType: strPtr("table"), SourceEntityKey: strPtr("users")},
+		{ID: uuid.New(), Text: "What are orders?", SourceEntityType: strPtr("table"), SourceEntityKey: strPtr("orders")},
+	}
+
+	result := checkQuestionSources(questions, testSchema())
+
+	if result.DanglingCount != 1 {
+		t.Fatalf("expected 1 dangling reference, got %d", result.DanglingCount)
+	}
+	if result.Score != 50 {
+		t.Errorf("expected score 50, got %d", result.Score)
+	}
+	ref := result.DanglingReferences[0]
+	if ref.EntityKey != "orders" || ref.Reason != "table does not exist in schema" {
+		t.Errorf("unexpected dangling reference: %+v", ref)
+	}
+	if len(result.Issues) != 1 || !strings.Contains(result.Issues[0], "orders") {
+		t.Errorf("expected issue mentioning orders, got %v", result.Issues)
+	}
+}
+
+func TestCheckQuestionSources_DeselectedAndUnknown(t *testing.T) {
+	questions := []OntologyQuestion{
+		{ID: uuid.New(), Text: "Deselected table", SourceEntityType: strPtr("table"), SourceEntityKey: strPtr("audit_log")},
+		{ID: uuid.New(), Text: "Deselected column", SourceEntityType: strPtr("column"), SourceEntityKey: strPtr("users.legacy_flag")},
+		{ID: uuid.New(), Text: "Renamed column", SourceEntityType: strPtr("column"), SourceEntityKey: strPtr("users.mail")},
+		{ID: uuid.New(), Text: "Unknown type", SourceEntityType: strPtr("widget"), SourceEntityKey: strPtr("users")},
+	}
+
+	result := checkQuestionSources(questions, testSchema())
+
+	wantReasons := []string{
+		"table is not selected",
+		"column is not selected",
+		"column does not exist in table",
+		"unknown source entity type 'widget'",
+	}
+	if result.DanglingCount != len(wantReasons) {
+		t.Fatalf("expected %d dangling references, got %d", len(wantReasons), result.DanglingCount)
+	}
+	for i, want := range wantReasons {
+		if got := result.DanglingReferences[i].Reason; got != want {
+			t.Errorf("reference %d: expected reason %q, got %q", i, want, got)
+		}
+	}
+	if result.Score != 0 {
+		t.Errorf("expected score 0, got %d", result.Score)
+	}
+}
+
+func TestCheckQuestionSources_QuotedMixedCaseIdentifiers(t *testing.T) {
+	schema := append(testSchema(), SchemaTable{
+		SchemaName: "public",
+		TableName:  "OrderItems",
+		IsSelected: true,
+		Columns:    []SchemaColumn{{ColumnName: "ProductId", IsSelected: true}},
+	})
+	questions := []OntologyQuestion{
+		{ID: uuid.New(), Text: "Quoted table", SourceEntityType: strPtr("table"), SourceEntityKey: strPtr(`public."OrderItems"`)},
+		{ID: uuid.New(), Text: "Folded table", SourceEntityType: strPtr("table"), SourceEntityKey: strPtr("orderitems")},
+		{ID: uuid.New(), Text: "Quoted column", SourceEntityType: strPtr("column"), SourceEntityKey: strPtr(`"OrderItems"."ProductId"`)},
+		{ID: uuid.New(), Text: "Qualified column", SourceEntityType: strPtr("column"), SourceEntityKey: strPtr("public.OrderItems.productid")},
+	}
+
+	result := checkQuestionSources(questions, schema)
+
+	if result.DanglingCount != 0 {
+		t.Errorf("expected quoted and case-folded references to resolve, got %+v", result.DanglingReferences)
+	}
+}
+
+func TestTruncate_CutsOnRuneBoundary(t *testing.T) {
+	if got := truncate("short", 10); got != "short" {
+		t.Errorf("truncate(short) = %q, want it unchanged", got)
+	}
+
+	got := truncate("日本語の質問はどのテーブルですか", 8)
+	if got != "日本語の質..." {
+		t.Errorf("truncate() = %q, want %q", got, "日本語の質...")
+	}
+	if !utf8.ValidString(got) {
+		t.Errorf("truncate() returned invalid UTF-8 %q", got)
+	}
+}
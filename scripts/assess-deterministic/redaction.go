@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/logging"
+)
+
+// RedactionComplianceScore checks stored prompts against the current redaction policy
+// (conversations.redact_detectors and redact_patterns). Conversations are stored
+// verbatim and only masked when read back, so values sampled before a detector was
+// enabled still sit in request_messages. Each prompt with a value the policy would
+// redact is a security issue.
+//
+// Only the detector names are reported, never the matched values, so the assessment
+// output does not itself leak what it found.
+type RedactionComplianceScore struct {
+	Score            int                  `json:"score"`
+	Weight           int                  `json:"weight"`
+	PromptsChecked   int                  `json:"prompts_checked"`
+	PromptsViolating int                  `json:"prompts_violating"`
+	ByDetector       map[string]int       `json:"by_detector"` // Prompts flagged per detector
+	Violations       []RedactionViolation `json:"violations,omitempty"`
+	Issues           []string             `json:"issues"`
+}
+
+// RedactionViolation is a stored prompt containing values the policy would redact.
+type RedactionViolation struct {
+	ConversationID string   `json:"conversation_id"`
+	Detectors      []string `json:"detectors"`
+}
+
+// checkRedactionCompliance scans every string in each prompt's request messages with
+// redactor. Returns nil when no redaction policy is configured.
+func checkRedactionCompliance(prompts []LLMPrompt, redactor *logging.Redactor) *RedactionComplianceScore {
+	if redactor == nil {
+		return nil
+	}
+	result := &RedactionComplianceScore{
+		Weight:         WeightRedactionCompliance,
+		PromptsChecked: len(prompts),
+		ByDetector:     make(map[string]int),
+		Issues:         []string{},
+	}
+
+	for _, p := range prompts {
+		var messages any
+		if err := json.Unmarshal(p.RequestMessages, &messages); err != nil {
+			continue
+		}
+		detected := make(map[string]bool)
+		walkStrings(messages, func(s string) {
+			for _, name := range redactor.Detect(s) {
+				detected[name] = true
+			}
+		})
+		if len(detected) == 0 {
+			continue
+		}
+
+		detectors := make([]string, 0, len(detected))
+		for name := range detected {
+			detectors = append(detectors, name)
+			result.ByDetector[name]++
+		}
+		sort.Strings(detectors)
+		result.Violations = append(result.Violations, RedactionViolation{
+			ConversationID: p.ConversationID.String(),
+			Detectors:      detectors,
+		})
+	}
+	result.PromptsViolating = len(result.Violations)
+
+	if result.PromptsViolating > 0 {
+		names := make([]string, 0, len(result.ByDetector))
+		for name := range result.ByDetector {
+			names = append(names, fmt.Sprintf("%s: %d", name, result.ByDetector[name]))
+		}
+		sort.Strings(names)
+		result.Issues = append(result.Issues, fmt.Sprintf(
+			"SECURITY: %d/%d stored prompts contain values the redaction policy would mask (%s)",
+			result.PromptsViolating, result.PromptsChecked, strings.Join(names, ", ")))
+	}
+
+	if result.PromptsChecked == 0 {
+		result.Score = 100
+		return result
+	}
+	result.Score = (result.PromptsChecked - result.PromptsViolating) * 100 / result.PromptsChecked
+	return result
+}
+
+// walkStrings calls fn for every string value in a decoded JSON value.
+func walkStrings(v any, fn func(string)) {
+	switch val := v.(type) {
+	case string:
+		fn(val)
+	case map[string]any:
+		for _, item := range val {
+			walkStrings(item, fn)
+		}
+	case []any:
+		for _, item := range val {
+			walkStrings(item, fn)
+		}
+	}
+}
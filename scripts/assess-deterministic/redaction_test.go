@@ -0,0 +1,48 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/logging"
+)
+
+func TestCheckRedactionCompliance_FlagsEmailWhenDetectorEnabled(t *testing.T) {
+	leaking := newLLMPrompt(uuid.New(), json.RawMessage(`[
+		{"role": "system", "content": "You describe database columns."},
+		{"role": "user", "content": "Column email, sample values: alice@example.com, bob@example.org"}
+	]`))
+	clean := newLLMPrompt(uuid.New(), json.RawMessage(`[
+		{"role": "user", "content": "Column status, sample values: active, closed"}
+	]`))
+	prompts := []LLMPrompt{leaking, clean}
+
+	redactor, err := logging.NewRedactor([]string{"email"}, nil)
+	if err != nil {
+		t.Fatalf("NewRedactor: %v", err)
+	}
+	result := checkRedactionCompliance(prompts, redactor)
+
+	if result.PromptsChecked != 2 || result.PromptsViolating != 1 {
+		t.Fatalf("expected 1/2 prompts violating, got %d/%d", result.PromptsViolating, result.PromptsChecked)
+	}
+	if len(result.Violations) != 1 || result.Violations[0].ConversationID != leaking.ConversationID.String() ||
+		strings.Join(result.Violations[0].Detectors, ",") != "email" {
+		t.Errorf("unexpected violations: %+v", result.Violations)
+	}
+	if result.Score != 50 {
+		t.Errorf("expected score 50, got %d", result.Score)
+	}
+	if len(result.Issues) != 1 || !strings.HasPrefix(result.Issues[0], "SECURITY:") ||
+		strings.Contains(result.Issues[0], "alice@example.com") {
+		t.Errorf("expected one security issue without the matched value, got %v", result.Issues)
+	}
+
+	// Without a policy there is nothing to enforce
+	if got := checkRedactionCompliance(prompts, nil); got != nil {
+		t.Errorf("expected no check without a redaction policy, got %+v", got)
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+)
+
+// RelationshipTypeScore checks that every stored relationship joins columns of
+// compatible types. A type-incompatible relationship (uuid to bigint, say) makes any
+// SQL generated over it fail, so each one lowers the score.
+//
+// The rules are the engine's PK-match type matrix (models.AreTypesCompatibleForFK),
+// so this check agrees with what discovery would have accepted.
+type RelationshipTypeScore struct {
+	Score                int                    `json:"score"`
+	Weight               int                    `json:"weight"`
+	RelationshipsChecked int                    `json:"relationships_checked"`
+	Incompatible         []IncompatibleJoinType `json:"incompatible,omitempty"`
+	Issues               []string               `json:"issues"`
+}
+
+// IncompatibleJoinType is a relationship whose column types cannot be joined.
+type IncompatibleJoinType struct {
+	RelationshipID string `json:"relationship_id"`
+	SourceColumn   string `json:"source_column"`
+	SourceType     string `json:"source_type"`
+	TargetColumn   string `json:"target_column"`
+	TargetType     string `json:"target_type"`
+}
+
+// checkRelationshipTypes flags relationships whose source and target column types
+// are not compatible.
+func checkRelationshipTypes(relationships []SchemaRelationship) *RelationshipTypeScore {
+	result := &RelationshipTypeScore{
+		Weight:               WeightRelationshipTypes,
+		RelationshipsChecked: len(relationships),
+		Issues:               []string{},
+	}
+
+	for _, r := range relationships {
+		if models.AreTypesCompatibleForFK(r.SourceType, r.TargetType) {
+			continue
+		}
+		result.Incompatible = append(result.Incompatible, IncompatibleJoinType{
+			RelationshipID: r.ID.String(),
+			SourceColumn:   r.SourceColumn,
+			SourceType:     r.SourceType,
+			TargetColumn:   r.TargetColumn,
+			TargetType:     r.TargetType,
+		})
+		result.Issues = append(result.Issues, fmt.Sprintf(
+			"Relationship %s (%s) -> %s (%s) joins incompatible types",
+			r.SourceColumn, r.SourceType, r.TargetColumn, r.TargetType))
+	}
+
+	if result.RelationshipsChecked == 0 {
+		result.Score = 100
+		return result
+	}
+
+	compatible := result.RelationshipsChecked - len(result.Incompatible)
+	result.Score = compatible * 100 / result.RelationshipsChecked
+	return result
+}
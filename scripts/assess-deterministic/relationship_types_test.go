@@ -0,0 +1,37 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestCheckRelationshipTypes_FlagsUUIDToBigint(t *testing.T) {
+	bad := SchemaRelationship{
+		ID:           uuid.New(),
+		SourceColumn: "payments.account_id", SourceType: "uuid",
+		TargetColumn: "accounts.id", TargetType: "bigint",
+	}
+	relationships := []SchemaRelationship{
+		{ID: uuid.New(), SourceColumn: "orders.customer_id", SourceType: "integer", TargetColumn: "customers.id", TargetType: "bigint"},
+		{ID: uuid.New(), SourceColumn: "sessions.user_id", SourceType: "text", TargetColumn: "users.id", TargetType: "uuid"},
+		{ID: uuid.New(), SourceColumn: "items.sku", SourceType: "varchar(32)", TargetColumn: "products.sku", TargetType: "character varying(64)"},
+		bad,
+	}
+
+	result := checkRelationshipTypes(relationships)
+
+	if len(result.Incompatible) != 1 {
+		t.Fatalf("expected 1 incompatible relationship, got %+v", result.Incompatible)
+	}
+	if result.Incompatible[0].RelationshipID != bad.ID.String() {
+		t.Errorf("expected %s flagged, got %+v", bad.ID, result.Incompatible[0])
+	}
+	if result.Score != 75 {
+		t.Errorf("expected score 75, got %d", result.Score)
+	}
+	if len(result.Issues) != 1 || !strings.Contains(result.Issues[0], "payments.account_id (uuid) -> accounts.id (bigint)") {
+		t.Errorf("unexpected issues: %v", result.Issues)
+	}
+}
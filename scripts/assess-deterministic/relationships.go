@@ -0,0 +1,173 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// RelationshipCoverageScore measures how many selected tables take part in at
+// least one relationship.
+//
+// Raw coverage treats every table equally. Weighted coverage weights each table
+// by its importance (row count and reference-like columns), so an orphaned
+// 10-million-row fact table costs far more than an orphaned 3-row config table.
+// Tables the project marks business-critical count CriticalTableWeight times as
+// much again. The check score is the weighted coverage.
+type RelationshipCoverageScore struct {
+	Score            int           `json:"score"`
+	Weight           int           `json:"weight"`
+	TablesChecked    int           `json:"tables_checked"`
+	ConnectedTables  int           `json:"connected_tables"`
+	RawCoverage      float64       `json:"raw_coverage"`      // Percent of tables with a relationship
+	WeightedCoverage float64       `json:"weighted_coverage"` // Percent of table importance with a relationship
+	OrphanTables     []OrphanTable `json:"orphan_tables,omitempty"`
+	Issues           []string      `json:"issues"`
+}
+
+// OrphanTable is a selected table with no relationships, ordered by importance.
+type OrphanTable struct {
+	TableName        string  `json:"table_name"`
+	RowCount         *int64  `json:"row_count"`
+	ReferenceColumns int     `json:"reference_columns"`
+	Critical         bool    `json:"critical,omitempty"`
+	Importance       float64 `json:"importance"`
+}
+
+// CriticalTableWeight multiplies the importance of a business-critical table, so a
+// gap there hurts the score more than the same gap in a peripheral table.
+const CriticalTableWeight = 3.0
+
+// maxOrphanIssues caps how many orphan tables are listed individually in issues.
+const maxOrphanIssues = 5
+
+// checkRelationshipCoverage computes raw and importance-weighted relationship
+// coverage over the selected tables.
+func checkRelationshipCoverage(schema []SchemaTable, relationships []SchemaRelationship) *RelationshipCoverageScore {
+	result := &RelationshipCoverageScore{
+		Weight: WeightRelationshipCoverage,
+		Issues: []string{},
+	}
+
+	connected := make(map[uuid.UUID]bool)
+	for _, r := range relationships {
+		connected[r.SourceTableID] = true
+		connected[r.TargetTableID] = true
+	}
+
+	var totalImportance, connectedImportance float64
+	for _, t := range schema {
+		if !t.IsSelected {
+			continue
+		}
+		result.TablesChecked++
+
+		importance := tableImportance(t)
+		totalImportance += importance
+
+		if connected[t.ID] {
+			result.ConnectedTables++
+			connectedImportance += importance
+			continue
+		}
+
+		result.OrphanTables = append(result.OrphanTables, OrphanTable{
+			TableName:        t.TableName,
+			RowCount:         t.RowCount,
+			ReferenceColumns: countReferenceColumns(t),
+			Critical:         t.IsCritical,
+			Importance:       math.Round(importance*100) / 100,
+		})
+	}
+
+	if result.TablesChecked == 0 {
+		result.Score = 100
+		result.RawCoverage = 100
+		result.WeightedCoverage = 100
+		return result
+	}
+
+	result.RawCoverage = roundPercent(float64(result.ConnectedTables) / float64(result.TablesChecked))
+	result.WeightedCoverage = roundPercent(connectedImportance / totalImportance)
+	result.Score = int(math.Round(result.WeightedCoverage))
+
+	// Biggest orphans first so the output says what to fix first
+	sort.SliceStable(result.OrphanTables, func(i, j int) bool {
+		return result.OrphanTables[i].Importance > result.OrphanTables[j].Importance
+	})
+
+	if len(result.OrphanTables) > 0 {
+		result.Issues = append(result.Issues, fmt.Sprintf(
+			"%d/%d tables have no relationships (raw coverage %.1f%%, weighted coverage %.1f%%)",
+			len(result.OrphanTables), result.TablesChecked, result.RawCoverage, result.WeightedCoverage))
+	}
+	for i, o := range result.OrphanTables {
+		if i == maxOrphanIssues {
+			break
+		}
+		rows := "unknown"
+		if o.RowCount != nil {
+			rows = fmt.Sprintf("%d", *o.RowCount)
+		}
+		critical := ""
+		if o.Critical {
+			critical = "business-critical, "
+		}
+		result.Issues = append(result.Issues, fmt.Sprintf("Orphan table %s (%srows: %s, reference columns: %d)",
+			o.TableName, critical, rows, o.ReferenceColumns))
+	}
+
+	return result
+}
+
+// tableImportance weights a table by order of magnitude of its row count plus
+// the number of columns that look like references to other tables, scaled by
+// CriticalTableWeight for business-critical tables.
+// Every table has a base importance of 1 so empty tables still count.
+func tableImportance(t SchemaTable) float64 {
+	importance := 1.0
+	if t.RowCount != nil && *t.RowCount > 0 {
+		importance += math.Log10(float64(*t.RowCount) + 1)
+	}
+	importance += float64(countReferenceColumns(t))
+	if t.IsCritical {
+		importance *= CriticalTableWeight
+	}
+	return importance
+}
+
+// markCriticalTables sets IsCritical on the schema tables named in critical, matched
+// as "table" or "schema.table". It returns the names that match no table.
+func markCriticalTables(schema []SchemaTable, critical []string) []string {
+	var unknown []string
+	for _, name := range critical {
+		t := findTable(name, schema)
+		if t == nil {
+			unknown = append(unknown, name)
+			continue
+		}
+		t.IsCritical = true
+	}
+	return unknown
+}
+
+// countReferenceColumns counts non-PK columns named like foreign keys (e.g. customer_id).
+func countReferenceColumns(t SchemaTable) int {
+	count := 0
+	for _, c := range t.Columns {
+		if c.IsPrimaryKey {
+			continue
+		}
+		if strings.HasSuffix(strings.ToLower(c.ColumnName), "_id") {
+			count++
+		}
+	}
+	return count
+}
+
+func roundPercent(ratio float64) float64 {
+	return math.Round(ratio*1000) / 10
+}
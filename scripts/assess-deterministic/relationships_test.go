@@ -0,0 +1,126 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func int64Ptr(n int64) *int64 { return &n }
+
+func TestCheckRelationshipCoverage_SmallOrphansWeighLess(t *testing.T) {
+	orders := SchemaTable{
+		ID: uuid.New(), TableName: "orders", IsSelected: true, RowCount: int64Ptr(10_000_000),
+		Columns: []SchemaColumn{
+			{ColumnName: "id", IsPrimaryKey: true},
+			{ColumnName: "customer_id"},
+		},
+	}
+	customers := SchemaTable{
+		ID: uuid.New(), TableName: "customers", IsSelected: true, RowCount: int64Ptr(500_000),
+		Columns: []SchemaColumn{{ColumnName: "id", IsPrimaryKey: true}},
+	}
+	schema := []SchemaTable{orders, customers}
+	for _, name := range []string{"settings", "feature_flags", "app_config", "schema_version"} {
+		schema = append(schema, SchemaTable{
+			ID: uuid.New(), TableName: name, IsSelected: true, RowCount: int64Ptr(3),
+			Columns: []SchemaColumn{{ColumnName: "key", IsPrimaryKey: true}},
+		})
+	}
+	// Deselected tables are ignored entirely
+	schema = append(schema, SchemaTable{ID: uuid.New(), TableName: "audit_log", IsSelected: false, RowCount: int64Ptr(50_000_000)})
+
+	relationships := []SchemaRelationship{
+		{SourceTableID: orders.ID, TargetTableID: customers.ID},
+	}
+
+	result := checkRelationshipCoverage(schema, relationships)
+
+	if result.TablesChecked != 6 {
+		t.Fatalf("expected 6 tables checked, got %d", result.TablesChecked)
+	}
+	if result.RawCoverage != 33.3 {
+		t.Errorf("expected raw coverage 33.3, got %.1f", result.RawCoverage)
+	}
+	if result.WeightedCoverage <= result.RawCoverage {
+		t.Errorf("expected weighted coverage above raw when only small tables are orphaned, got weighted=%.1f raw=%.1f",
+			result.WeightedCoverage, result.RawCoverage)
+	}
+	if result.Score != int(result.WeightedCoverage+0.5) {
+		t.Errorf("expected score to follow weighted coverage, got score=%d weighted=%.1f", result.Score, result.WeightedCoverage)
+	}
+	if len(result.OrphanTables) != 4 {
+		t.Errorf("expected 4 orphan tables, got %d", len(result.OrphanTables))
+	}
+}
+
+func TestCheckRelationshipCoverage_LargeOrphanWeighsMore(t *testing.T) {
+	events := SchemaTable{
+		ID: uuid.New(), TableName: "events", IsSelected: true, RowCount: int64Ptr(10_000_000),
+		Columns: []SchemaColumn{
+			{ColumnName: "id", IsPrimaryKey: true},
+			{ColumnName: "user_id"},
+			{ColumnName: "session_id"},
+		},
+	}
+	a := SchemaTable{ID: uuid.New(), TableName: "settings", IsSelected: true, RowCount: int64Ptr(3)}
+	b := SchemaTable{ID: uuid.New(), TableName: "flags", IsSelected: true, RowCount: int64Ptr(3)}
+
+	result := checkRelationshipCoverage(
+		[]SchemaTable{events, a, b},
+		[]SchemaRelationship{{SourceTableID: a.ID, TargetTableID: b.ID}},
+	)
+
+	if result.WeightedCoverage >= result.RawCoverage {
+		t.Errorf("expected weighted coverage below raw when the large table is orphaned, got weighted=%.1f raw=%.1f",
+			result.WeightedCoverage, result.RawCoverage)
+	}
+	if len(result.OrphanTables) != 1 || result.OrphanTables[0].ReferenceColumns != 2 {
+		t.Errorf("expected events orphaned with 2 reference columns, got %+v", result.OrphanTables)
+	}
+}
+
+func TestCheckRelationshipCoverage_NoSelectedTables(t *testing.T) {
+	result := checkRelationshipCoverage(nil, nil)
+	if result.Score != 100 {
+		t.Errorf("expected score 100 with no tables, got %d", result.Score)
+	}
+}
+
+func TestCheckRelationshipCoverage_CriticalTableWeighsMore(t *testing.T) {
+	// Three identical tables; invoices is orphaned, orders and customers are joined.
+	build := func(critical ...string) []SchemaTable {
+		var schema []SchemaTable
+		for _, name := range []string{"invoices", "orders", "customers"} {
+			schema = append(schema, SchemaTable{
+				ID: uuid.NewSHA1(uuid.NameSpaceOID, []byte(name)), SchemaName: "public", TableName: name,
+				IsSelected: true, RowCount: int64Ptr(1000),
+			})
+		}
+		if unknown := markCriticalTables(schema, critical); len(unknown) != 0 {
+			t.Fatalf("unexpected unknown critical tables %v", unknown)
+		}
+		return schema
+	}
+	relationships := []SchemaRelationship{{
+		SourceTableID: uuid.NewSHA1(uuid.NameSpaceOID, []byte("orders")),
+		TargetTableID: uuid.NewSHA1(uuid.NameSpaceOID, []byte("customers")),
+	}}
+
+	baseline := checkRelationshipCoverage(build(), relationships)
+	criticalOrphan := checkRelationshipCoverage(build("public.invoices"), relationships)
+	criticalConnected := checkRelationshipCoverage(build("orders"), relationships)
+
+	if criticalOrphan.Score >= baseline.Score {
+		t.Errorf("expected a critical orphan to lower the score, got %d vs baseline %d", criticalOrphan.Score, baseline.Score)
+	}
+	if criticalConnected.Score <= baseline.Score {
+		t.Errorf("expected a critical connected table to raise the score, got %d vs baseline %d", criticalConnected.Score, baseline.Score)
+	}
+	if criticalOrphan.RawCoverage != baseline.RawCoverage {
+		t.Errorf("expected raw coverage to ignore criticality, got %.1f vs %.1f", criticalOrphan.RawCoverage, baseline.RawCoverage)
+	}
+	if len(criticalOrphan.OrphanTables) != 1 || !criticalOrphan.OrphanTables[0].Critical {
+		t.Errorf("expected invoices reported as a critical orphan, got %+v", criticalOrphan.OrphanTables)
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ekaya-inc/ekaya-engine/scripts/internal/assessmetrics"
+	"github.com/ekaya-inc/ekaya-engine/scripts/internal/assessreport"
+)
+
+// markdownReport builds the -format md report from the same result printed as JSON.
+func markdownReport(result *AssessmentResult) assessreport.Report {
+	report := assessreport.Report{
+		Title: fmt.Sprintf("Deterministic Assessment: %s", result.DatasourceName),
+		Details: []assessreport.Detail{
+			{Name: "Project", Value: result.ProjectID},
+			{Name: "Commit", Value: result.CommitInfo},
+			{Name: "Tables", Value: fmt.Sprintf("%d selected of %d", result.SchemaStats.SelectedTableCount, result.SchemaStats.TableCount)},
+			{Name: "Columns", Value: fmt.Sprint(result.SchemaStats.ColumnCount)},
+			{Name: "Relationships", Value: fmt.Sprint(result.SchemaStats.RelationshipCount)},
+		},
+		FinalScore: result.FinalScore,
+		Summary:    result.SmartSummary,
+		Issues:     result.Issues,
+	}
+
+	add := func(name string, score, weight int, issues []string) {
+		report.Categories = append(report.Categories, assessreport.Category{
+			Name: name, Score: score, Weight: float64(weight), Notes: issues,
+		})
+	}
+	s := result.ChecksSummary
+	if s.QuestionSources != nil {
+		add("Question sources", s.QuestionSources.Score, s.QuestionSources.Weight, s.QuestionSources.Issues)
+	}
+	if s.RelationshipCoverage != nil {
+		add("Relationship coverage", s.RelationshipCoverage.Score, s.RelationshipCoverage.Weight, s.RelationshipCoverage.Issues)
+	}
+	if s.StatsCompleteness != nil {
+		add("Stats completeness", s.StatsCompleteness.Score, s.StatsCompleteness.Weight, s.StatsCompleteness.Issues)
+	}
+	if s.QuestionAnswerability != nil {
+		add("Question answerability", s.QuestionAnswerability.Score, s.QuestionAnswerability.Weight, s.QuestionAnswerability.Issues)
+		for _, r := range s.QuestionAnswerability.Recommendations {
+			report.Recommendations = append(report.Recommendations, fmt.Sprintf(
+				"Auto-answer %q from the documented values of %s (%s)", r.Question, r.Column, strings.Join(r.Values, ", ")))
+		}
+	}
+	if s.RelationshipTypes != nil {
+		add("Relationship types", s.RelationshipTypes.Score, s.RelationshipTypes.Weight, s.RelationshipTypes.Issues)
+	}
+	if s.InferenceMethods != nil {
+		add("Inference methods", s.InferenceMethods.Score, s.InferenceMethods.Weight, s.InferenceMethods.Issues)
+	}
+	if s.GraphCardinality != nil {
+		add("Graph cardinality", s.GraphCardinality.Score, s.GraphCardinality.Weight, s.GraphCardinality.Issues)
+	}
+	if s.PromptRelationships != nil {
+		add("Prompt relationships", s.PromptRelationships.Score, s.PromptRelationships.Weight, s.PromptRelationships.Issues)
+	}
+	if s.PromptCompleteness != nil {
+		add("Prompt completeness", s.PromptCompleteness.Score, s.PromptCompleteness.Weight, s.PromptCompleteness.Issues)
+	}
+	if s.PromptMix != nil {
+		add("Prompt mix", s.PromptMix.Score, s.PromptMix.Weight, s.PromptMix.Issues)
+	}
+	if s.RedactionCompliance != nil {
+		add("Redaction compliance", s.RedactionCompliance.Score, s.RedactionCompliance.Weight, s.RedactionCompliance.Issues)
+	}
+	return report
+}
+
+// pushMetrics sends the scores to the -metrics-target monitoring endpoint, if one is
+// set. The result is already written, so a failed push is only reported.
+func pushMetrics(target string, result *AssessmentResult) {
+	labels := assessmetrics.Labels{ProjectID: result.ProjectID, Datasource: result.DatasourceName, Commit: result.CommitInfo}
+	metrics := assessmetrics.FromReport(markdownReport(result))
+	if err := assessmetrics.Push(context.Background(), target, "deterministic", labels, metrics); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to push metrics: %v\n", err)
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ekaya-inc/ekaya-engine/scripts/internal/assessreport"
+)
+
+func TestMarkdownReport_BuildsFromResult(t *testing.T) {
+	result := &AssessmentResult{
+		DatasourceName: "analytics",
+		ProjectID:      "project-1",
+		SchemaStats:    SchemaStats{TableCount: 10, SelectedTableCount: 8},
+		ChecksSummary: ChecksSummary{
+			QuestionSources:      &QuestionSourceScore{Score: 100, Weight: WeightQuestionSources, Issues: []string{}},
+			RelationshipCoverage: &RelationshipCoverageScore{Score: 40, Weight: WeightRelationshipCoverage, Issues: []string{"3 selected tables have no relationships"}},
+			QuestionAnswerability: &QuestionAnswerabilityScore{Score: 50, Weight: WeightQuestionAnswerability, Recommendations: []AnswerRecommendation{
+				{Question: "What does status mean?", Column: "orders.status", Values: []string{"open", "closed"}},
+			}},
+		},
+		FinalScore:   61,
+		SmartSummary: "Relationship coverage needs work.",
+		Issues:       []string{"3 selected tables have no relationships"},
+	}
+
+	report := markdownReport(result)
+
+	if len(report.Categories) != 3 {
+		t.Fatalf("expected only the checks that ran as categories, got %+v", report.Categories)
+	}
+	md := assessreport.Markdown(report)
+	if strings.Contains(md, "| Commit |") {
+		t.Errorf("expected the empty commit detail to be omitted:\n%s", md)
+	}
+	for _, want := range []string{
+		"# Deterministic Assessment: analytics",
+		"**Final score: 🟡 61/100**",
+		"| Tables | 8 selected of 10 |",
+		"| Relationship coverage | 🔴 40/100 | 42.9% |",
+		`- Auto-answer "What does status mean?" from the documented values of orders.status (open, closed)`,
+		"- Improve **Relationship coverage** (40/100, costs 25.7 points of the final score): 3 selected tables have no relationships",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("report missing %q:\n%s", want, md)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// StatsCompletenessScore measures whether column statistics were gathered for the
+// selected columns that could take part in joins.
+//
+// Relationship discovery and sample-value hydration both depend on these stats, so
+// missing stats mean discovery silently ran on incomplete input. Columns already
+// classified as not joinable are skipped; columns with no joinability classification
+// are checked, since the classification itself comes from the stats scan.
+// Sample values are not persisted, so the persisted distinct/null counts stand in
+// for "stats were gathered".
+type StatsCompletenessScore struct {
+	Score            int                  `json:"score"`
+	Weight           int                  `json:"weight"`
+	ColumnsChecked   int                  `json:"columns_checked"`
+	ColumnsWithStats int                  `json:"columns_with_stats"`
+	Completeness     float64              `json:"completeness"` // Percent of checked columns with complete stats
+	MissingColumns   []ColumnMissingStats `json:"missing_columns,omitempty"`
+	Issues           []string             `json:"issues"`
+}
+
+// ColumnMissingStats lists which stats are missing for a column.
+type ColumnMissingStats struct {
+	Column  string   `json:"column"` // table.column
+	Missing []string `json:"missing"`
+}
+
+// maxMissingStatsIssues caps how many columns are listed individually in issues.
+const maxMissingStatsIssues = 5
+
+// checkStatsCompleteness scores the fraction of selected joinable columns in
+// selected tables that have distinct count, null count and joinability recorded.
+func checkStatsCompleteness(schema []SchemaTable) *StatsCompletenessScore {
+	result := &StatsCompletenessScore{
+		Weight: WeightStatsCompleteness,
+		Issues: []string{},
+	}
+
+	for _, t := range schema {
+		if !t.IsSelected {
+			continue
+		}
+		for _, c := range t.Columns {
+			if !c.IsSelected || (c.IsJoinable != nil && !*c.IsJoinable) {
+				continue
+			}
+			result.ColumnsChecked++
+
+			var missing []string
+			if c.DistinctCount == nil {
+				missing = append(missing, "distinct_count")
+			}
+			if c.NullCount == nil {
+				missing = append(missing, "null_count")
+			}
+			if c.IsJoinable == nil {
+				missing = append(missing, "is_joinable")
+			}
+			if len(missing) == 0 {
+				result.ColumnsWithStats++
+				continue
+			}
+			result.MissingColumns = append(result.MissingColumns, ColumnMissingStats{
+				Column:  t.TableName + "." + c.ColumnName,
+				Missing: missing,
+			})
+		}
+	}
+
+	if result.ColumnsChecked == 0 {
+		result.Score = 100
+		result.Completeness = 100
+		return result
+	}
+
+	result.Completeness = roundPercent(float64(result.ColumnsWithStats) / float64(result.ColumnsChecked))
+	result.Score = int(math.Round(result.Completeness))
+
+	if len(result.MissingColumns) > 0 {
+		result.Issues = append(result.Issues, fmt.Sprintf(
+			"%d/%d joinable columns have no gathered stats (completeness %.1f%%)",
+			len(result.MissingColumns), result.ColumnsChecked, result.Completeness))
+	}
+	for i, m := range result.MissingColumns {
+		if i == maxMissingStatsIssues {
+			break
+		}
+		result.Issues = append(result.Issues, fmt.Sprintf("Missing stats for %s: %s",
+			m.Column, strings.Join(m.Missing, ", ")))
+	}
+
+	return result
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func boolPtr(b bool) *bool { return &b }
+
+// gatheredColumn returns a selected column with complete stats.
+func gatheredColumn(name string) SchemaColumn {
+	return SchemaColumn{
+		ColumnName: name, IsSelected: true,
+		DistinctCount: int64Ptr(100), NullCount: int64Ptr(0), IsJoinable: boolPtr(true),
+	}
+}
+
+func TestCheckStatsCompleteness_PartiallyGathered(t *testing.T) {
+	schema := []SchemaTable{
+		{
+			ID: uuid.New(), TableName: "orders", IsSelected: true,
+			Columns: []SchemaColumn{
+				gatheredColumn("id"),
+				gatheredColumn("customer_id"),
+				gatheredColumn("product_id"),
+				// Stats scan never ran for this column
+				{ColumnName: "store_id", IsSelected: true},
+				// Classified as not joinable: skipped
+				{ColumnName: "notes", IsSelected: true, DistinctCount: int64Ptr(5), NullCount: int64Ptr(1), IsJoinable: boolPtr(false)},
+				// Deselected: skipped
+				{ColumnName: "legacy_id", IsSelected: false},
+			},
+		},
+		{
+			// Deselected tables are ignored entirely
+			ID: uuid.New(), TableName: "audit_log", IsSelected: false,
+			Columns: []SchemaColumn{{ColumnName: "id", IsSelected: true}},
+		},
+	}
+
+	result := checkStatsCompleteness(schema)
+
+	if result.ColumnsChecked != 4 || result.ColumnsWithStats != 3 {
+		t.Fatalf("expected 3/4 columns with stats, got %d/%d", result.ColumnsWithStats, result.ColumnsChecked)
+	}
+	if result.Score != 75 {
+		t.Errorf("expected proportional score 75, got %d", result.Score)
+	}
+	if len(result.MissingColumns) != 1 || result.MissingColumns[0].Column != "orders.store_id" {
+		t.Fatalf("expected orders.store_id to be missing stats, got %+v", result.MissingColumns)
+	}
+	if got := strings.Join(result.MissingColumns[0].Missing, ","); got != "distinct_count,null_count,is_joinable" {
+		t.Errorf("unexpected missing stats: %s", got)
+	}
+	if len(result.Issues) != 2 || !strings.Contains(result.Issues[0], "1/4 joinable columns") {
+		t.Errorf("expected summary and per-column issue, got %v", result.Issues)
+	}
+}
+
+func TestCheckStatsCompleteness_NoStatsGatheredIsNotAPass(t *testing.T) {
+	schema := []SchemaTable{{
+		ID: uuid.New(), TableName: "users", IsSelected: true,
+		Columns: []SchemaColumn{
+			{ColumnName: "id", IsSelected: true},
+			{ColumnName: "account_id", IsSelected: true},
+		},
+	}}
+
+	result := checkStatsCompleteness(schema)
+
+	if result.Score != 0 {
+		t.Errorf("expected score 0 when no stats were gathered, got %d", result.Score)
+	}
+}
+
+func TestCalculateWeightedScore_IncludesStatsCompleteness(t *testing.T) {
+	summary := ChecksSummary{
+		QuestionSources:   &QuestionSourceScore{Score: 100, Weight: WeightQuestionSources},
+		StatsCompleteness: &StatsCompletenessScore{Score: 50, Weight: WeightStatsCompleteness},
+	}
+
+	// (100*10 + 50*15) / 25
+	if got := calculateWeightedScore(summary); got != 70 {
+		t.Errorf("expected weighted score 70, got %d", got)
+	}
+}
@@ -1,6 +1,6 @@
 #!/bin/bash
 # Assess LLM extraction quality for ontology generation
-# Usage: ./scripts/assess-extraction.sh <project-id>
+# Usage: ./scripts/assess-extraction.sh [-v | -quiet] [-judges model1,model2,...] [-max-singleton-domain-ratio N] [-max-domain-share N] [-duplicate-description-similarity N] <project-id>
 #
 # This tool evaluates the LLM's performance during ontology extraction.
 # It assesses how well the model performed GIVEN the input it received.
@@ -23,7 +23,7 @@ SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
 PROJECT_ROOT="$(dirname "$SCRIPT_DIR")"
 
 if [ -z "$1" ]; then
-    echo "Usage: $0 <project-id>" >&2
+    echo "Usage: $0 [-v | -quiet] [-judges model1,model2,...] [-max-singleton-domain-ratio N] [-max-domain-share N] [-duplicate-description-similarity N] <project-id>" >&2
     echo "" >&2
     echo "Example: $0 f2324998-64c0-46e7-98d1-8a778be462f2" >&2
     exit 1
@@ -35,4 +35,4 @@ if [ -z "$ANTHROPIC_API_KEY" ]; then
 fi
 
 cd "$PROJECT_ROOT"
-go run ./scripts/assess-extraction "$1"
+go run ./scripts/assess-extraction "$@"
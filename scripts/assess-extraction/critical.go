@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// loadCriticalTables loads the project's business-critical table names from its
+// ontology settings. Returns nil when none are set.
+func loadCriticalTables(ctx context.Context, conn *pgx.Conn, projectID uuid.UUID) ([]string, error) {
+	var raw []byte
+	if err := conn.QueryRow(ctx, `
+		SELECT parameters->'ontology'->'critical_tables' FROM engine_projects WHERE id = $1
+	`, projectID).Scan(&raw); err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		return nil, nil
+	}
+
+	var tables []string
+	if err := json.Unmarshal(raw, &tables); err != nil {
+		return nil, fmt.Errorf("parse critical tables: %w", err)
+	}
+	return tables, nil
+}
+
+// markCriticalTables sets IsCritical on the schema tables named in critical, matched
+// as "table" or "schema.table" ignoring case.
+func markCriticalTables(schema []SchemaTable, critical []string) {
+	names := make(map[string]bool, len(critical))
+	for _, name := range critical {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if i := strings.LastIndex(name, "."); i >= 0 {
+			name = name[i+1:]
+		}
+		names[name] = true
+	}
+	for i := range schema {
+		schema[i].IsCritical = names[strings.ToLower(schema[i].TableName)]
+	}
+}
+
+// sampleEntities picks up to sampleSize tables to judge: every business-critical
+// table first, then the remaining slots spread evenly over the other tables.
+func sampleEntities(schema []SchemaTable, sampleSize int) []SchemaTable {
+	sampled := make([]SchemaTable, 0, sampleSize)
+	var rest []SchemaTable
+	for _, t := range schema {
+		if t.IsCritical && len(sampled) < sampleSize {
+			sampled = append(sampled, t)
+			continue
+		}
+		if !t.IsCritical {
+			rest = append(rest, t)
+		}
+	}
+
+	slots := sampleSize - len(sampled)
+	if slots <= 0 || len(rest) == 0 {
+		return sampled
+	}
+	step := len(rest) / slots
+	if step < 1 {
+		step = 1
+	}
+	for i := 0; i < len(rest) && len(sampled) < sampleSize; i += step {
+		sampled = append(sampled, rest[i])
+	}
+	return sampled
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func tableNames(tables []SchemaTable) []string {
+	names := make([]string, 0, len(tables))
+	for _, t := range tables {
+		names = append(names, t.TableName)
+	}
+	return names
+}
+
+func TestSampleEntities_CriticalTablesSampledFirst(t *testing.T) {
+	var schema []SchemaTable
+	for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		schema = append(schema, SchemaTable{TableName: name})
+	}
+
+	// Evenly spread without critical tables
+	if got, want := tableNames(sampleEntities(schema, 3)), []string{"a", "c", "e"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("sample = %v, want %v", got, want)
+	}
+
+	markCriticalTables(schema, []string{"public.F"})
+	if got, want := tableNames(sampleEntities(schema, 3)), []string{"f", "a", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("sample with critical f = %v, want %v", got, want)
+	}
+}
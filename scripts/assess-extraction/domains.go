@@ -0,0 +1,162 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Default thresholds for the deterministic domain balance check.
+const (
+	DefaultMaxSingletonDomainRatio = 0.5 // Over-split when more than half of the entities sit alone in their own domain
+	DefaultMaxDomainShare          = 0.8 // Under-grouped when one domain holds more than 80% of the entities
+	DefaultMinEntitiesForBalance   = 4   // Too few entities to judge grouping below this
+)
+
+// DomainBalanceThresholds configures when a domain grouping is considered over-split
+// or under-grouped.
+type DomainBalanceThresholds struct {
+	MaxSingletonDomainRatio float64 // Max share of entities that may be the only member of their domain
+	MaxDomainShare          float64 // Max share of entities that may fall in the largest domain
+	MinEntities             int     // Skip the check for ontologies with fewer entities
+}
+
+// DefaultDomainBalanceThresholds returns the thresholds used when no flags override them.
+func DefaultDomainBalanceThresholds() DomainBalanceThresholds {
+	return DomainBalanceThresholds{
+		MaxSingletonDomainRatio: DefaultMaxSingletonDomainRatio,
+		MaxDomainShare:          DefaultMaxDomainShare,
+		MinEntities:             DefaultMinEntitiesForBalance,
+	}
+}
+
+// Validate reports a threshold outside its 0-1 range, naming the flag that sets it.
+func (t DomainBalanceThresholds) Validate() error {
+	if err := validateShare("max-singleton-domain-ratio", t.MaxSingletonDomainRatio); err != nil {
+		return err
+	}
+	return validateShare("max-domain-share", t.MaxDomainShare)
+}
+
+// validateShare reports an error when the value of the named flag is not a share between 0 and 1.
+func validateShare(flagName string, value float64) error {
+	if math.IsNaN(value) || value < 0 || value > 1 {
+		return fmt.Errorf("-%s must be between 0 and 1, got %v", flagName, value)
+	}
+	return nil
+}
+
+// DomainBalanceResult is the deterministic assessment of how entities are grouped into domains.
+//
+// Balance is the normalized Shannon entropy of the domain sizes (0.0-1.0): 0 means every
+// entity is in one domain, 1 means entities are spread evenly. It is reported for
+// comparison across runs; the score is driven by the over-split and under-grouped thresholds.
+type DomainBalanceResult struct {
+	Score              int      `json:"score"`
+	EntityCount        int      `json:"entity_count"`
+	DomainCount        int      `json:"domain_count"`
+	SingletonDomains   int      `json:"singleton_domains"`
+	SingletonRatio     float64  `json:"singleton_ratio"`
+	LargestDomain      string   `json:"largest_domain,omitempty"`
+	LargestDomainShare float64  `json:"largest_domain_share"`
+	Balance            float64  `json:"balance"`
+	OverSplit          bool     `json:"over_split"`
+	UnderGrouped       bool     `json:"under_grouped"`
+	Skipped            bool     `json:"skipped,omitempty"`
+	Issues             []string `json:"issues"`
+}
+
+// checkDomainBalance flags domain groupings where nearly every entity has its own domain
+// (over-split) or nearly every entity shares one domain (under-grouped). Entities without
+// a domain are ignored; domain names are compared case-insensitively.
+func checkDomainBalance(entities map[string]EntitySummary, thresholds DomainBalanceThresholds) *DomainBalanceResult {
+	result := &DomainBalanceResult{
+		Score:  100,
+		Issues: []string{},
+	}
+
+	sizes := make(map[string]int)
+	for _, e := range entities {
+		domain := strings.ToLower(strings.TrimSpace(e.Domain))
+		if domain == "" {
+			continue
+		}
+		sizes[domain]++
+		result.EntityCount++
+	}
+	result.DomainCount = len(sizes)
+
+	if result.EntityCount < thresholds.MinEntities || result.DomainCount == 0 {
+		result.Skipped = true
+		return result
+	}
+
+	// Sort domain names so the largest-domain tie-break is deterministic
+	domains := make([]string, 0, len(sizes))
+	for d := range sizes {
+		domains = append(domains, d)
+	}
+	sort.Strings(domains)
+
+	largest := 0
+	entropy := 0.0
+	for _, d := range domains {
+		n := sizes[d]
+		if n == 1 {
+			result.SingletonDomains++
+		}
+		if n > largest {
+			largest = n
+			result.LargestDomain = d
+		}
+		p := float64(n) / float64(result.EntityCount)
+		entropy -= p * math.Log(p)
+	}
+
+	result.SingletonRatio = roundTo(float64(result.SingletonDomains)/float64(result.EntityCount), 2)
+	result.LargestDomainShare = roundTo(float64(largest)/float64(result.EntityCount), 2)
+	if result.DomainCount > 1 {
+		result.Balance = roundTo(entropy/math.Log(float64(result.DomainCount)), 2)
+	}
+
+	// A single domain is under-grouped by definition, not over-split
+	overSplitPenalty := 0.0
+	if result.DomainCount > 1 && result.SingletonRatio > thresholds.MaxSingletonDomainRatio {
+		result.OverSplit = true
+		overSplitPenalty = excessPenalty(result.SingletonRatio, thresholds.MaxSingletonDomainRatio)
+		result.Issues = append(result.Issues, fmt.Sprintf(
+			"Domains are over-split: %d of %d entities are alone in their domain (%d domains)",
+			result.SingletonDomains, result.EntityCount, result.DomainCount))
+	}
+
+	underGroupPenalty := 0.0
+	if result.LargestDomainShare > thresholds.MaxDomainShare {
+		result.UnderGrouped = true
+		underGroupPenalty = excessPenalty(result.LargestDomainShare, thresholds.MaxDomainShare)
+		result.Issues = append(result.Issues, fmt.Sprintf(
+			"Domains are under-grouped: '%s' holds %d of %d entities",
+			result.LargestDomain, largest, result.EntityCount))
+	}
+
+	result.Score = 100 - int(math.Round(math.Max(overSplitPenalty, underGroupPenalty)))
+	if result.Score < 0 {
+		result.Score = 0
+	}
+
+	return result
+}
+
+// excessPenalty scales how far value exceeds limit into a 0-100 penalty, reaching 100
+// when value is 1.0.
+func excessPenalty(value, limit float64) float64 {
+	if limit >= 1 {
+		return 0
+	}
+	return (value - limit) / (1 - limit) * 100
+}
+
+func roundTo(v float64, places int) float64 {
+	pow := math.Pow(10, float64(places))
+	return math.Round(v*pow) / pow
+}
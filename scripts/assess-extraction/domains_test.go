@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// entitiesWithDomains builds entity summaries where domains[i] is the domain of table i.
+func entitiesWithDomains(domains ...string) map[string]EntitySummary {
+	entities := make(map[string]EntitySummary, len(domains))
+	for i, d := range domains {
+		name := fmt.Sprintf("table_%d", i)
+		entities[name] = EntitySummary{TableName: name, Domain: d}
+	}
+	return entities
+}
+
+func TestCheckDomainBalance_OverSplit(t *testing.T) {
+	entities := entitiesWithDomains(
+		"users", "orders", "products", "payments", "shipping",
+		"reviews", "inventory", "sales", "sales", "marketing",
+	)
+
+	result := checkDomainBalance(entities, DefaultDomainBalanceThresholds())
+
+	if !result.OverSplit {
+		t.Fatalf("expected over-split, got %+v", result)
+	}
+	if result.UnderGrouped {
+		t.Error("did not expect under-grouped")
+	}
+	if result.DomainCount != 9 || result.SingletonDomains != 8 {
+		t.Errorf("expected 9 domains with 8 singletons, got %d domains with %d singletons",
+			result.DomainCount, result.SingletonDomains)
+	}
+	// Singleton ratio 0.8 against a 0.5 limit: 60% of the way to fully split
+	if result.Score != 40 {
+		t.Errorf("expected score 40, got %d", result.Score)
+	}
+	if len(result.Issues) != 1 || !strings.Contains(result.Issues[0], "over-split") {
+		t.Errorf("expected one over-split issue, got %v", result.Issues)
+	}
+}
+
+func TestCheckDomainBalance_ReasonableGrouping(t *testing.T) {
+	entities := entitiesWithDomains(
+		"sales", "sales", "Sales", "sales",
+		"customer", "customer", "customer",
+		"inventory", "inventory", "hr",
+	)
+
+	result := checkDomainBalance(entities, DefaultDomainBalanceThresholds())
+
+	if result.OverSplit || result.UnderGrouped {
+		t.Fatalf("expected a balanced grouping, got %+v", result)
+	}
+	if result.Score != 100 {
+		t.Errorf("expected score 100, got %d", result.Score)
+	}
+	if result.DomainCount != 4 {
+		t.Errorf("expected domains compared case-insensitively (4 domains), got %d", result.DomainCount)
+	}
+	if result.LargestDomain != "sales" || result.LargestDomainShare != 0.4 {
+		t.Errorf("expected sales to hold 40%% of entities, got %q at %.2f", result.LargestDomain, result.LargestDomainShare)
+	}
+	if result.Balance <= 0.8 || result.Balance >= 1 {
+		t.Errorf("expected a high but imperfect balance, got %.2f", result.Balance)
+	}
+}
+
+func TestCheckDomainBalance_UnderGrouped(t *testing.T) {
+	entities := entitiesWithDomains("core", "core", "core", "core", "core", "core", "core", "core", "core", "billing")
+
+	result := checkDomainBalance(entities, DefaultDomainBalanceThresholds())
+
+	if !result.UnderGrouped {
+		t.Fatalf("expected under-grouped, got %+v", result)
+	}
+	if result.OverSplit {
+		t.Error("did not expect over-split")
+	}
+	// Largest share 0.9 against a 0.8 limit: halfway to a single domain
+	if result.Score != 50 {
+		t.Errorf("expected score 50, got %d", result.Score)
+	}
+}
+
+func TestCheckDomainBalance_ThresholdsAreConfigurable(t *testing.T) {
+	entities := entitiesWithDomains("a", "b", "c", "d", "e", "e")
+
+	if result := checkDomainBalance(entities, DefaultDomainBalanceThresholds()); !result.OverSplit {
+		t.Fatalf("expected over-split with default thresholds, got %+v", result)
+	}
+
+	lenient := DefaultDomainBalanceThresholds()
+	lenient.MaxSingletonDomainRatio = 0.7
+	if result := checkDomainBalance(entities, lenient); result.OverSplit || result.Score != 100 {
+		t.Errorf("expected no finding with a lenient threshold, got %+v", result)
+	}
+}
+
+func TestCheckDomainBalance_SkipsSmallOntologies(t *testing.T) {
+	result := checkDomainBalance(entitiesWithDomains("a", "b", ""), DefaultDomainBalanceThresholds())
+
+	if !result.Skipped {
+		t.Fatalf("expected check to be skipped, got %+v", result)
+	}
+	if result.EntityCount != 2 {
+		t.Errorf("expected entities without a domain to be ignored, got %d", result.EntityCount)
+	}
+}
+
+func TestAssessDomainSummaryQuality_IncludesDomainBalance(t *testing.T) {
+	entityJSON, err := json.Marshal(entitiesWithDomains(
+		"users", "orders", "products", "payments", "shipping",
+		"reviews", "inventory", "sales", "sales", "marketing",
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ontology := &Ontology{
+		DomainSummary:   json.RawMessage(`{"description": "store", "domains": ["sales"]}`),
+		EntitySummaries: entityJSON,
+	}
+	judge := &mockJudge{responses: []string{
+		`{"description_accuracy": 90, "domain_grouping_score": 80, "relationship_accuracy": 70, "sample_question_quality": 60, "issues": []}`,
+	}}
+
+	score := assessDomainSummaryQuality(context.Background(), judge, &judgeTracker{}, nil, nil, ontology, DefaultDomainBalanceThresholds())
+
+	if score.DomainBalance == nil || !score.DomainBalance.OverSplit {
+		t.Fatalf("expected over-split domain balance, got %+v", score.DomainBalance)
+	}
+	// (90 + 80 + 70 + 60 + 40) / 5
+	if score.Score != 68 {
+		t.Errorf("expected score 68, got %d", score.Score)
+	}
+	if len(score.Issues) == 0 || !strings.Contains(score.Issues[0], "over-split") {
+		t.Errorf("expected over-split issue, got %v", score.Issues)
+	}
+}
+
+func TestDomainBalanceThresholds_Validate(t *testing.T) {
+	if err := DefaultDomainBalanceThresholds().Validate(); err != nil {
+		t.Fatalf("default thresholds should be valid, got %v", err)
+	}
+
+	overOne := DefaultDomainBalanceThresholds()
+	overOne.MaxDomainShare = 80
+	if err := overOne.Validate(); err == nil || !strings.Contains(err.Error(), "-max-domain-share") {
+		t.Errorf("expected -max-domain-share error for 80, got %v", err)
+	}
+
+	negative := DefaultDomainBalanceThresholds()
+	negative.MaxSingletonDomainRatio = -0.1
+	if err := negative.Validate(); err == nil || !strings.Contains(err.Error(), "-max-singleton-domain-ratio") {
+		t.Errorf("expected -max-singleton-domain-ratio error for -0.1, got %v", err)
+	}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DefaultDuplicateDescriptionSimilarity is the word-overlap similarity at which two
+// entity descriptions count as copies of each other.
+const DefaultDuplicateDescriptionSimilarity = 0.9
+
+// duplicateDescriptionMaxPenalty is how many points the extracted-info quality score
+// loses when every entity shares its description with another; the penalty scales with
+// the share of entities affected.
+const duplicateDescriptionMaxPenalty = 15
+
+// DuplicateDescriptionResult is the deterministic check that distinct entities have
+// distinct descriptions. Near-identical descriptions usually mean shadow/backup tables
+// or partitions were described as entities, or the LLM copy-pasted a description.
+type DuplicateDescriptionResult struct {
+	Score           int                       `json:"score"`
+	EntitiesChecked int                       `json:"entities_checked"`
+	Threshold       float64                   `json:"threshold"`
+	Groups          []DuplicateDescriptionSet `json:"groups"` // Candidates for review
+	Issues          []string                  `json:"issues"`
+}
+
+// DuplicateDescriptionSet is a set of entities whose descriptions are near-identical.
+type DuplicateDescriptionSet struct {
+	Entities    []string `json:"entities"`
+	Description string   `json:"description"` // Description of the first entity in the set
+}
+
+// checkDuplicateDescriptions groups entities whose descriptions have a word-overlap
+// (Jaccard) similarity of at least threshold. The score is the share of described
+// entities that are not in any group. Entities without a description are skipped.
+func checkDuplicateDescriptions(entities map[string]EntitySummary, threshold float64) *DuplicateDescriptionResult {
+	result := &DuplicateDescriptionResult{
+		Score:     100,
+		Threshold: threshold,
+		Groups:    []DuplicateDescriptionSet{},
+		Issues:    []string{},
+	}
+
+	names := make([]string, 0, len(entities))
+	words := make(map[string]map[string]bool, len(entities))
+	for name, entity := range entities {
+		set := wordSet(entity.Description)
+		if len(set) == 0 {
+			continue
+		}
+		names = append(names, name)
+		words[name] = set
+	}
+	sort.Strings(names)
+	result.EntitiesChecked = len(names)
+
+	// Union-find over similar pairs so A~B and B~C end up in one set.
+	parent := make(map[string]string, len(names))
+	for _, name := range names {
+		parent[name] = name
+	}
+	var find func(string) string
+	find = func(name string) string {
+		if parent[name] != name {
+			parent[name] = find(parent[name])
+		}
+		return parent[name]
+	}
+	for i := 0; i < len(names); i++ {
+		for j := i + 1; j < len(names); j++ {
+			if jaccardSimilarity(words[names[i]], words[names[j]]) >= threshold {
+				parent[find(names[j])] = find(names[i])
+			}
+		}
+	}
+
+	members := make(map[string][]string)
+	for _, name := range names {
+		root := find(name)
+		members[root] = append(members[root], name)
+	}
+
+	duplicated := 0
+	for _, name := range names {
+		group := members[name]
+		if len(group) < 2 || group[0] != name {
+			continue
+		}
+		duplicated += len(group)
+		result.Groups = append(result.Groups, DuplicateDescriptionSet{
+			Entities:    group,
+			Description: entities[name].Description,
+		})
+		result.Issues = append(result.Issues, fmt.Sprintf(
+			"Entities %s have near-identical descriptions; check for shadow/backup tables or copy-pasted descriptions",
+			strings.Join(group, ", ")))
+	}
+
+	if result.EntitiesChecked > 0 {
+		result.Score = (result.EntitiesChecked - duplicated) * 100 / result.EntitiesChecked
+	}
+	return result
+}
+
+// wordSet returns the distinct lower-cased words of s.
+func wordSet(s string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range labelWords(s) {
+		set[w] = true
+	}
+	return set
+}
+
+// jaccardSimilarity is the size of the intersection of a and b over the size of their union.
+func jaccardSimilarity(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for w := range a {
+		if b[w] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
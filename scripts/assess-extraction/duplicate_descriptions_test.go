@@ -0,0 +1,52 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckDuplicateDescriptions_FlagsCopyPasteAndKeepsDistinct(t *testing.T) {
+	entities := map[string]EntitySummary{
+		"orders":        {Description: "Customer orders placed through the web shop, one row per checkout."},
+		"orders_backup": {Description: "Customer orders placed through the web shop, one row per checkout"},
+		"customers":     {Description: "People and companies who buy from the web shop."},
+		"invoices":      {Description: "Billing documents issued to customers for completed orders."},
+		"audit_log":     {Description: ""},
+	}
+
+	result := checkDuplicateDescriptions(entities, DefaultDuplicateDescriptionSimilarity)
+
+	if result.EntitiesChecked != 4 {
+		t.Errorf("expected 4 described entities checked, got %d", result.EntitiesChecked)
+	}
+	if len(result.Groups) != 1 {
+		t.Fatalf("expected one duplicate group, got %+v", result.Groups)
+	}
+	if want := []string{"orders", "orders_backup"}; !reflect.DeepEqual(result.Groups[0].Entities, want) {
+		t.Errorf("expected group %v, got %v", want, result.Groups[0].Entities)
+	}
+	if result.Score != 50 {
+		t.Errorf("expected score 50 with 2 of 4 entities duplicated, got %d", result.Score)
+	}
+	if len(result.Issues) != 1 {
+		t.Errorf("expected one issue, got %v", result.Issues)
+	}
+}
+
+func TestCheckDuplicateDescriptions_DistinctDescriptionsScore100(t *testing.T) {
+	entities := map[string]EntitySummary{
+		"users":    {Description: "Registered user accounts with login credentials."},
+		"sessions": {Description: "Active login sessions for registered user accounts."},
+	}
+
+	result := checkDuplicateDescriptions(entities, DefaultDuplicateDescriptionSimilarity)
+
+	if len(result.Groups) != 0 || result.Score != 100 {
+		t.Errorf("expected no duplicates and score 100, got %+v", result)
+	}
+
+	// Lowering the threshold flags looser overlap (4 of 9 words shared here)
+	if loose := checkDuplicateDescriptions(entities, 0.4); len(loose.Groups) != 1 {
+		t.Errorf("expected the pair to be flagged at similarity 0.4, got %+v", loose.Groups)
+	}
+}
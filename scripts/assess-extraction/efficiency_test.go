@@ -0,0 +1,74 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+)
+
+func TestEfficiencyMetrics_ExcludeTrimmedQuestions(t *testing.T) {
+	trimmedReason := models.QuestionStatusReasonTrimmed
+	otherReason := "not relevant"
+
+	var questions []OntologyQuestion
+	for i := 0; i < 12; i++ {
+		q := OntologyQuestion{Status: "pending"}
+		if i >= 5 {
+			q.Status = "dismissed"
+			q.StatusReason = &trimmedReason
+		}
+		questions = append(questions, q)
+	}
+	// Dismissed by a user for another reason: still counts.
+	questions = append(questions, OntologyQuestion{Status: "dismissed", StatusReason: &otherReason})
+
+	kept, trimmed := withoutTrimmedQuestions(questions)
+	if len(kept) != 6 || trimmed != 7 {
+		t.Fatalf("withoutTrimmedQuestions = (%d kept, %d trimmed), want (6, 7)", len(kept), trimmed)
+	}
+
+	schema := []SchemaTable{{TableName: "orders"}, {TableName: "users"}}
+	score := calculateEfficiencyMetrics(nil, kept, trimmed, schema, false)
+	if score.QuestionsPerTable != 3 {
+		t.Errorf("QuestionsPerTable = %v, want 3", score.QuestionsPerTable)
+	}
+	if score.QuestionsTrimmed != 7 {
+		t.Errorf("QuestionsTrimmed = %d, want 7", score.QuestionsTrimmed)
+	}
+	for _, issue := range score.Issues {
+		if issue == "High number of questions per table" {
+			t.Errorf("trimmed questions should not trigger the questions-per-table penalty")
+		}
+	}
+}
+
+func TestEfficiencyMetrics_CostWeightedFavorsCheaperVerboseModel(t *testing.T) {
+	schema := []SchemaTable{{TableName: "orders"}, {TableName: "users"}}
+	// Two tables at 2500 tokens each on Haiku, 1500 each on Opus
+	verboseHaiku := []LLMConversation{
+		{Model: "claude-haiku-4-5", PromptTokens: 4000, CompletionTokens: 1000, TotalTokens: 5000, Status: "success"},
+	}
+	terseOpus := []LLMConversation{
+		{Model: "claude-opus-4-1", PromptTokens: 2400, CompletionTokens: 600, TotalTokens: 3000, Status: "success"},
+	}
+
+	rawHaiku := calculateEfficiencyMetrics(verboseHaiku, nil, 0, schema, false)
+	rawOpus := calculateEfficiencyMetrics(terseOpus, nil, 0, schema, false)
+	if rawHaiku.Score >= rawOpus.Score {
+		t.Fatalf("raw tokens: expected the terse model to score better, got haiku=%d opus=%d", rawHaiku.Score, rawOpus.Score)
+	}
+
+	haiku := calculateEfficiencyMetrics(verboseHaiku, nil, 0, schema, true)
+	opus := calculateEfficiencyMetrics(terseOpus, nil, 0, schema, true)
+	if haiku.Score <= opus.Score {
+		t.Errorf("cost-weighted: expected the cheaper verbose model to score better, got haiku=%d opus=%d", haiku.Score, opus.Score)
+	}
+	if haiku.Basis != "cost" || haiku.CostPerTable >= opus.CostPerTable {
+		t.Errorf("expected cost basis with haiku cheaper per table, got %+v vs %+v", haiku, opus)
+	}
+	// Haiku is a third of the reference price, Opus five times it
+	if haiku.CostWeightedTokensPerTable >= 1000 || opus.CostWeightedTokensPerTable <= 3000 {
+		t.Errorf("unexpected cost-weighted tokens per table: haiku=%.0f opus=%.0f",
+			haiku.CostWeightedTokensPerTable, opus.CostWeightedTokensPerTable)
+	}
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// GlossaryTerm is a business glossary term with the table it is defined over.
+type GlossaryTerm struct {
+	Term      string
+	Aliases   []string
+	BaseTable string
+}
+
+// GlossaryDomainConflict is an entity named like a glossary term but assigned a
+// different domain than the term implies.
+type GlossaryDomainConflict struct {
+	Entity        string `json:"entity"`
+	EntityDomain  string `json:"entity_domain"`
+	Term          string `json:"term"`
+	ImpliedDomain string `json:"implied_domain"`
+}
+
+func loadGlossary(ctx context.Context, conn *pgx.Conn, projectID uuid.UUID) ([]GlossaryTerm, error) {
+	query := `
+		SELECT g.term, COALESCE(g.base_table, ''),
+		       COALESCE(array_agg(a.alias) FILTER (WHERE a.alias IS NOT NULL), '{}')
+		FROM engine_business_glossary g
+		LEFT JOIN engine_glossary_aliases a ON a.glossary_id = g.id
+		WHERE g.project_id = $1
+		GROUP BY g.id, g.term, g.base_table
+		ORDER BY g.term`
+
+	rows, err := conn.Query(ctx, query, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var terms []GlossaryTerm
+	for rows.Next() {
+		var t GlossaryTerm
+		if err := rows.Scan(&t.Term, &t.BaseTable, &t.Aliases); err != nil {
+			return nil, err
+		}
+		terms = append(terms, t)
+	}
+	return terms, rows.Err()
+}
+
+// checkGlossaryDomains cross-checks entity domains against the domains glossary terms
+// imply. A term implies the domain of the entity for its base table; any other entity
+// whose table or business name matches the term or one of its aliases should share
+// that domain. Returns the number of entities compared and the contradictions.
+// Terms without a base table, and entities or base tables without a domain, are
+// skipped.
+func checkGlossaryDomains(entities map[string]EntitySummary, terms []GlossaryTerm) (int, []GlossaryDomainConflict) {
+	byName := make(map[string][]string) // normalized entity name -> entity keys
+	for key, e := range entities {
+		for _, name := range []string{key, e.TableName, e.BusinessName} {
+			if n := normalizeGlossaryName(name); n != "" && !slices.Contains(byName[n], key) {
+				byName[n] = append(byName[n], key)
+			}
+		}
+	}
+
+	checked := 0
+	conflicts := []GlossaryDomainConflict{}
+	for _, term := range terms {
+		baseKey := glossaryBaseTableKey(term.BaseTable, entities)
+		base, ok := entities[baseKey]
+		if !ok || strings.TrimSpace(base.Domain) == "" {
+			continue
+		}
+		implied := strings.ToLower(strings.TrimSpace(base.Domain))
+
+		matched := make(map[string]bool)
+		for _, name := range append([]string{term.Term}, term.Aliases...) {
+			for _, key := range byName[normalizeGlossaryName(name)] {
+				matched[key] = true
+			}
+		}
+		delete(matched, baseKey)
+
+		keys := make([]string, 0, len(matched))
+		for key := range matched {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			domain := strings.ToLower(strings.TrimSpace(entities[key].Domain))
+			if domain == "" {
+				continue
+			}
+			checked++
+			if domain != implied {
+				conflicts = append(conflicts, GlossaryDomainConflict{
+					Entity:        key,
+					EntityDomain:  domain,
+					Term:          term.Term,
+					ImpliedDomain: implied,
+				})
+			}
+		}
+	}
+	return checked, conflicts
+}
+
+// glossaryBaseTableKey resolves a glossary base table, possibly schema-qualified, to
+// an entity key.
+func glossaryBaseTableKey(baseTable string, entities map[string]EntitySummary) string {
+	baseTable = strings.TrimSpace(baseTable)
+	if baseTable == "" {
+		return ""
+	}
+	if _, ok := entities[baseTable]; ok {
+		return baseTable
+	}
+	if i := strings.LastIndex(baseTable, "."); i >= 0 {
+		if _, ok := entities[baseTable[i+1:]]; ok {
+			return baseTable[i+1:]
+		}
+	}
+	return ""
+}
+
+// normalizeGlossaryName lowercases a name, joins its words with underscores and drops
+// a plural "s", so "Sales Orders", "sales_order" and "SalesOrder" compare equal.
+func normalizeGlossaryName(name string) string {
+	var b strings.Builder
+	prevLower := false
+	for _, r := range strings.TrimSpace(name) {
+		switch {
+		case r >= 'A' && r <= 'Z':
+			if prevLower {
+				b.WriteByte('_')
+			}
+			b.WriteRune(r + ('a' - 'A'))
+			prevLower = false
+		case r >= 'a' && r <= 'z' || r >= '0' && r <= '9':
+			b.WriteRune(r)
+			prevLower = true
+		default:
+			if b.Len() > 0 && !strings.HasSuffix(b.String(), "_") {
+				b.WriteByte('_')
+			}
+			prevLower = false
+		}
+	}
+	n := strings.Trim(b.String(), "_")
+	if len(n) > 3 && strings.HasSuffix(n, "s") && !strings.HasSuffix(n, "ss") {
+		n = strings.TrimSuffix(n, "s")
+	}
+	return n
+}
+
+func formatGlossaryDomainConflict(c GlossaryDomainConflict) string {
+	return fmt.Sprintf("Entity '%s' is in domain '%s' but glossary term '%s' implies '%s'",
+		c.Entity, c.EntityDomain, c.Term, c.ImpliedDomain)
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestCheckGlossaryDomains_FlagsMismatch(t *testing.T) {
+	entities := map[string]EntitySummary{
+		"invoices":       {TableName: "invoices", BusinessName: "Invoice", Domain: "finance"},
+		"revenue_events": {TableName: "revenue_events", BusinessName: "Revenue Event", Domain: "operations"},
+		"shipments":      {TableName: "shipments", BusinessName: "Shipment", Domain: "operations"},
+	}
+	terms := []GlossaryTerm{
+		{Term: "Revenue Events", BaseTable: "public.invoices"},
+		{Term: "Shipment", Aliases: []string{"Deliveries"}, BaseTable: "shipments"},
+	}
+
+	checked, conflicts := checkGlossaryDomains(entities, terms)
+
+	if checked != 1 {
+		t.Errorf("expected 1 entity compared, got %d", checked)
+	}
+	if len(conflicts) != 1 {
+		t.Fatalf("expected 1 conflict, got %+v", conflicts)
+	}
+	want := GlossaryDomainConflict{Entity: "revenue_events", EntityDomain: "operations", Term: "Revenue Events", ImpliedDomain: "finance"}
+	if conflicts[0] != want {
+		t.Errorf("expected %+v, got %+v", want, conflicts[0])
+	}
+}
+
+func TestCheckGlossaryDomains_MatchingDomainsAndAliases(t *testing.T) {
+	entities := map[string]EntitySummary{
+		"orders":      {TableName: "orders", Domain: "sales"},
+		"SalesOrders": {TableName: "SalesOrders", Domain: "Sales"},
+		"refunds":     {TableName: "refunds", Domain: ""},
+	}
+	terms := []GlossaryTerm{
+		{Term: "Booking", Aliases: []string{"sales order"}, BaseTable: "orders"},
+		{Term: "Refund", BaseTable: "orders"},
+		{Term: "Churn", BaseTable: ""},
+	}
+
+	checked, conflicts := checkGlossaryDomains(entities, terms)
+
+	if checked != 1 || len(conflicts) != 0 {
+		t.Errorf("expected 1 consistent entity and no conflicts, got %d checked, %+v", checked, conflicts)
+	}
+}
+
+func TestAssessConsistency_ReportsGlossaryDomainConflict(t *testing.T) {
+	summaries, _ := json.Marshal(map[string]EntitySummary{
+		"invoices":     {TableName: "invoices", Domain: "finance"},
+		"invoice_fees": {TableName: "invoice_fees", BusinessName: "Invoice Fee", Domain: "operations"},
+	})
+	ontology := &Ontology{EntitySummaries: summaries}
+
+	score := assessConsistency(nil, nil, ontology, []GlossaryTerm{{Term: "Invoice Fees", BaseTable: "invoices"}})
+
+	if len(score.GlossaryDomainConflicts) != 1 {
+		t.Fatalf("expected 1 glossary domain conflict, got %+v", score.GlossaryDomainConflicts)
+	}
+	if score.Score != 0 {
+		t.Errorf("expected score 0 with the only check failing, got %d", score.Score)
+	}
+	if len(score.Issues) != 1 || !strings.Contains(score.Issues[0], "glossary term 'Invoice Fees' implies 'finance'") {
+		t.Errorf("unexpected issues: %v", score.Issues)
+	}
+}
+
+func TestNormalizeGlossaryName(t *testing.T) {
+	for input, want := range map[string]string{
+		"Sales Orders": "sales_order",
+		"sales_order":  "sales_order",
+		"SalesOrder":   "sales_order",
+		"Address":      "address",
+		"":             "",
+	} {
+		if got := normalizeGlossaryName(input); got != want {
+			t.Errorf("normalizeGlossaryName(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"github.com/ekaya-inc/ekaya-engine/scripts/internal/assessjudge"
+)
+
+// Response token caps per judge prompt. Question and entity judges return a small
+// fixed-shape JSON object; the domain summary judge also returns an issues list.
+const (
+	questionJudgeMaxTokens      = 500
+	entityJudgeMaxTokens        = 500
+	domainSummaryJudgeMaxTokens = 1000
+)
+
+// JudgeUsage is the token usage of a single judge call.
+type JudgeUsage = assessjudge.Usage
+
+// Judge evaluates an assessment prompt and returns the raw response text, capped
+// at maxTokens.
+// The scoring functions depend on this interface rather than the Anthropic
+// client so they can be tested with canned responses.
+type Judge = assessjudge.Judge
@@ -0,0 +1,444 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// mockJudge returns scripted responses in order and records the prompts it was given.
+type mockJudge struct {
+	responses []string
+	err       error
+	usage     JudgeUsage
+	prompts   []string
+}
+
+func (m *mockJudge) Assess(_ context.Context, prompt string, _ int) (string, JudgeUsage, error) {
+	m.prompts = append(m.prompts, prompt)
+	if m.err != nil {
+		return "", JudgeUsage{}, m.err
+	}
+	if len(m.responses) == 0 {
+		return "", JudgeUsage{}, errors.New("mockJudge: no scripted response")
+	}
+	resp := m.responses[0]
+	m.responses = m.responses[1:]
+	return resp, m.usage, nil
+}
+
+func questionJudgeResponse(inferrable int, misclassified, insightful bool) string {
+	return fmt.Sprintf(`{"inferrable_score": %d, "is_misclassified": %t, "should_be": "optional", "is_insightful": %t, "reasoning": "test"}`,
+		inferrable, misclassified, insightful)
+}
+
+func entityJudgeResponse(generic, domainError, hallucination, insightful bool) string {
+	return fmt.Sprintf(`{"is_generic": %t, "has_domain_error": %t, "has_hallucination": %t, "is_insightful": %t, "reasoning": "made up purpose"}`,
+		generic, domainError, hallucination, insightful)
+}
+
+func TestAssessSingleQuestion(t *testing.T) {
+	tests := []struct {
+		name      string
+		response  string
+		err       error
+		want      questionAssessmentResult
+		wantIssue string
+	}{
+		{
+			name:     "good question",
+			response: questionJudgeResponse(10, false, true),
+			want:     questionAssessmentResult{isInsightful: true},
+		},
+		{
+			name:      "inferrable above threshold",
+			response:  questionJudgeResponse(71, false, false),
+			want:      questionAssessmentResult{isInferrable: true},
+			wantIssue: "Inferrable",
+		},
+		{
+			name:     "inferrable at threshold is not penalized",
+			response: questionJudgeResponse(70, false, false),
+			want:     questionAssessmentResult{},
+		},
+		{
+			name:      "misclassified",
+			response:  questionJudgeResponse(20, true, false),
+			want:      questionAssessmentResult{isMisclassified: true},
+			wantIssue: "Should be optional",
+		},
+		{
+			name:     "JSON wrapped in prose",
+			response: "Here is my evaluation:\n```json\n" + questionJudgeResponse(0, false, true) + "\n```",
+			want:     questionAssessmentResult{isInsightful: true},
+		},
+		{
+			name:      "judge error",
+			err:       errors.New("rate limited"),
+			wantIssue: "Judge error: rate limited",
+		},
+		{
+			name:      "unparseable response",
+			response:  "not json",
+			wantIssue: "Parse error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			judge := &mockJudge{responses: []string{tt.response}, err: tt.err, usage: JudgeUsage{InputTokens: 100, OutputTokens: 20}}
+			tracker := &judgeTracker{}
+			q := OntologyQuestion{ID: uuid.New(), Text: "What does status=3 mean?"}
+
+			got := assessSingleQuestion(context.Background(), judge, tracker, q, "Table: orders\n")
+
+			if got.isInferrable != tt.want.isInferrable || got.isMisclassified != tt.want.isMisclassified || got.isInsightful != tt.want.isInsightful {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+			if tt.wantIssue == "" && got.issue != "" {
+				t.Errorf("expected no issue, got %q", got.issue)
+			}
+			if !strings.Contains(got.issue, tt.wantIssue) {
+				t.Errorf("expected issue containing %q, got %q", tt.wantIssue, got.issue)
+			}
+			if tt.err == nil && (tracker.calls != 1 || tracker.tokens != 120) {
+				t.Errorf("expected 1 call / 120 tokens tracked, got %d / %d", tracker.calls, tracker.tokens)
+			}
+			if !strings.Contains(judge.prompts[0], q.Text) {
+				t.Error("expected prompt to include the question text")
+			}
+		})
+	}
+}
+
+func TestAssessSingleEntity(t *testing.T) {
+	tests := []struct {
+		name      string
+		response  string
+		want      entityAssessmentResult
+		wantIssue string
+	}{
+		{
+			name:     "accurate and insightful",
+			response: entityJudgeResponse(false, false, false, true),
+			want:     entityAssessmentResult{isInsightful: true},
+		},
+		{
+			name:      "hallucination takes precedence in issue",
+			response:  entityJudgeResponse(true, true, true, false),
+			want:      entityAssessmentResult{isGeneric: true, hasDomainError: true, hasHallucination: true},
+			wantIssue: "Hallucination in orders",
+		},
+		{
+			name:      "unparseable response",
+			response:  "{broken",
+			wantIssue: "Parse error for orders",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			judge := &mockJudge{responses: []string{tt.response}}
+			table := SchemaTable{TableName: "orders", Columns: []SchemaColumn{{ColumnName: "id", DataType: "uuid", IsPrimaryKey: true}}}
+			entity := EntitySummary{TableName: "orders", BusinessName: "Order", Description: "A purchase"}
+
+			got := assessSingleEntity(context.Background(), judge, &judgeTracker{}, table, entity)
+
+			if got.isGeneric != tt.want.isGeneric || got.hasDomainError != tt.want.hasDomainError ||
+				got.hasHallucination != tt.want.hasHallucination || got.isInsightful != tt.want.isInsightful {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+			if !strings.Contains(got.issue, tt.wantIssue) {
+				t.Errorf("expected issue containing %q, got %q", tt.wantIssue, got.issue)
+			}
+		})
+	}
+}
+
+func TestAssessQuestionQuality_ScoringMath(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses []string
+		wantScore int
+	}{
+		{
+			name:      "all neutral",
+			responses: repeat(questionJudgeResponse(0, false, false), 5),
+			wantScore: 100,
+		},
+		{
+			name: "penalties add up",
+			responses: []string{
+				questionJudgeResponse(90, false, false), // -10
+				questionJudgeResponse(90, true, false),  // -10, -5
+				questionJudgeResponse(0, true, false),   // -5
+				questionJudgeResponse(0, false, true),   // +5
+				questionJudgeResponse(0, false, false),
+			},
+			wantScore: 75,
+		},
+		{
+			name:      "inferrable and misclassified stack",
+			responses: repeat(questionJudgeResponse(100, true, false), 5),
+			wantScore: 25, // 5 * -15 = -75
+		},
+		{
+			name:      "clamped at 100",
+			responses: repeat(questionJudgeResponse(0, false, true), 5),
+			wantScore: 100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			questions := make([]OntologyQuestion, 5)
+			for i := range questions {
+				questions[i] = OntologyQuestion{ID: uuid.New(), Text: fmt.Sprintf("Question %d", i)}
+			}
+			judge := &mockJudge{responses: tt.responses}
+
+			score := assessQuestionQuality(context.Background(), judge, &judgeTracker{}, questions, nil, &Ontology{})
+
+			if score.Score != tt.wantScore {
+				t.Errorf("expected score %d, got %d (issues: %v)", tt.wantScore, score.Score, score.Issues)
+			}
+			if score.QuestionsSampled != 5 {
+				t.Errorf("expected 5 questions sampled, got %d", score.QuestionsSampled)
+			}
+		})
+	}
+}
+
+func TestAssessQuestionQuality_ClampsAtZero(t *testing.T) {
+	questions := make([]OntologyQuestion, 40)
+	for i := range questions {
+		questions[i] = OntologyQuestion{ID: uuid.New(), Text: fmt.Sprintf("Question %d", i)}
+	}
+	judge := &mockJudge{responses: repeat(questionJudgeResponse(100, true, false), 10)}
+
+	score := assessQuestionQuality(context.Background(), judge, &judgeTracker{}, questions, nil, &Ontology{})
+
+	// 10 samples * -15 = -150, clamped
+	if score.Score != 0 {
+		t.Errorf("expected score clamped to 0, got %d", score.Score)
+	}
+	if score.QuestionsSampled != 10 {
+		t.Errorf("expected sample size capped at 10, got %d", score.QuestionsSampled)
+	}
+}
+
+func TestAssessExtractedInfoQuality_ScoringMath(t *testing.T) {
+	// Every entity lists sensible key columns so only the judge verdicts move the score
+	columns := []SchemaColumn{{ColumnName: "id", IsPrimaryKey: true}, {ColumnName: "name"}}
+	schema := []SchemaTable{
+		{TableName: "orders", Columns: columns},
+		{TableName: "customers", Columns: columns},
+		{TableName: "products", Columns: columns},
+	}
+	summaries := map[string]EntitySummary{
+		"orders":    entityWithKeyColumns("orders", "id", "name"),
+		"customers": entityWithKeyColumns("customers", "id", "name"),
+		"products":  entityWithKeyColumns("products", "id", "name"),
+	}
+	raw, err := json.Marshal(summaries)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		responses []string
+		wantScore int
+	}{
+		{
+			name: "mixed penalties and reward",
+			responses: []string{
+				entityJudgeResponse(true, false, false, false), // -5
+				entityJudgeResponse(false, true, false, false), // -10
+				entityJudgeResponse(false, false, true, true),  // -15, +5
+			},
+			wantScore: 75,
+		},
+		{
+			name:      "rewards clamp at 100",
+			responses: repeat(entityJudgeResponse(false, false, false, true), 3),
+			wantScore: 100,
+		},
+		{
+			name:      "all penalties",
+			responses: repeat(entityJudgeResponse(true, true, true, false), 3),
+			wantScore: 10, // 3 * -30 = -90
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			judge := &mockJudge{responses: tt.responses}
+
+			score := assessExtractedInfoQuality(context.Background(), judge, &judgeTracker{}, schema, &Ontology{EntitySummaries: raw}, DefaultDuplicateDescriptionSimilarity)
+
+			if score.Score != tt.wantScore {
+				t.Errorf("expected score %d, got %d (issues: %v)", tt.wantScore, score.Score, score.Issues)
+			}
+		})
+	}
+}
+
+func TestJudgedPhases_EmptyInputsSkipJudge(t *testing.T) {
+	schema := []SchemaTable{{TableName: "orders", Columns: []SchemaColumn{{ColumnName: "id", IsPrimaryKey: true}}}}
+	summaries, err := json.Marshal(map[string]EntitySummary{"orders": entityWithKeyColumns("orders", "id")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		assess    func(judge Judge) (int, []string)
+		wantScore int
+	}{
+		{
+			name: "no questions",
+			assess: func(judge Judge) (int, []string) {
+				s := assessQuestionQuality(context.Background(), judge, &judgeTracker{}, nil, schema, &Ontology{})
+				return s.Score, s.Issues
+			},
+			wantScore: EmptyQuestionsScore,
+		},
+		{
+			name: "no entity summaries",
+			assess: func(judge Judge) (int, []string) {
+				s := assessExtractedInfoQuality(context.Background(), judge, &judgeTracker{}, schema, &Ontology{}, DefaultDuplicateDescriptionSimilarity)
+				return s.Score, s.Issues
+			},
+			wantScore: EmptyEntitySummariesScore,
+		},
+		{
+			name: "empty entity summaries object",
+			assess: func(judge Judge) (int, []string) {
+				s := assessExtractedInfoQuality(context.Background(), judge, &judgeTracker{}, schema, &Ontology{EntitySummaries: json.RawMessage(`{}`)}, DefaultDuplicateDescriptionSimilarity)
+				return s.Score, s.Issues
+			},
+			wantScore: EmptyEntitySummariesScore,
+		},
+		{
+			name: "no schema tables",
+			assess: func(judge Judge) (int, []string) {
+				s := assessExtractedInfoQuality(context.Background(), judge, &judgeTracker{}, nil, &Ontology{EntitySummaries: summaries}, DefaultDuplicateDescriptionSimilarity)
+				return s.Score, s.Issues
+			},
+			wantScore: EmptyEntitySummariesScore,
+		},
+		{
+			name: "no domain summary",
+			assess: func(judge Judge) (int, []string) {
+				s := assessDomainSummaryQuality(context.Background(), judge, &judgeTracker{}, schema, nil, &Ontology{}, DefaultDomainBalanceThresholds())
+				return s.Score, s.Issues
+			},
+			wantScore: EmptyDomainSummaryScore,
+		},
+		{
+			name: "domain summary with no content",
+			assess: func(judge Judge) (int, []string) {
+				s := assessDomainSummaryQuality(context.Background(), judge, &judgeTracker{}, schema, nil,
+					&Ontology{DomainSummary: json.RawMessage(`{"description": "", "domains": []}`)}, DefaultDomainBalanceThresholds())
+				return s.Score, s.Issues
+			},
+			wantScore: EmptyDomainSummaryScore,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			judge := &mockJudge{}
+
+			score, issues := tt.assess(judge)
+
+			if len(judge.prompts) != 0 {
+				t.Errorf("expected no judge calls, got %d", len(judge.prompts))
+			}
+			if score != tt.wantScore {
+				t.Errorf("expected score %d, got %d (issues: %v)", tt.wantScore, score, issues)
+			}
+			if !strings.Contains(strings.Join(issues, "\n"), "judge skipped") {
+				t.Errorf("expected an issue explaining the skipped judge, got %v", issues)
+			}
+		})
+	}
+}
+
+func repeat(s string, n int) []string {
+	out := make([]string, n)
+	for i := range out {
+		out[i] = s
+	}
+	return out
+}
+
+func TestJudgePanel_MajorityResolvesSplitDecision(t *testing.T) {
+	usage := JudgeUsage{InputTokens: 100, OutputTokens: 20}
+	panel := newJudgePanel(
+		panelMember{model: "judge-a", judge: &mockJudge{responses: []string{questionJudgeResponse(90, true, true)}, usage: usage}},
+		panelMember{model: "judge-b", judge: &mockJudge{responses: []string{questionJudgeResponse(80, true, false)}, usage: usage}},
+		panelMember{model: "judge-c", judge: &mockJudge{responses: []string{questionJudgeResponse(10, false, false)}, usage: usage}},
+	)
+	tracker := &judgeTracker{}
+	q := OntologyQuestion{ID: uuid.New(), Text: "What does status=3 mean?"}
+
+	got := assessSingleQuestion(context.Background(), panel, tracker, q, "Table: orders\n")
+
+	// misclassified 2-1 yes, insightful 2-1 no, inferrable score averages to 60
+	want := questionAssessmentResult{isMisclassified: true}
+	if got.isInferrable != want.isInferrable || got.isMisclassified != want.isMisclassified || got.isInsightful != want.isInsightful {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+	if !strings.Contains(got.issue, "Should be optional") {
+		t.Errorf("expected misclassification issue, got %q", got.issue)
+	}
+	if panel.Calls() != 3 || tracker.tokens != 360 {
+		t.Errorf("expected 3 calls / 360 tokens across judges, got %d / %d", panel.Calls(), tracker.tokens)
+	}
+
+	wantAgreement := map[string]float64{"judge-a": 50, "judge-b": 100, "judge-c": 50}
+	for _, stat := range panel.Stats() {
+		if stat.Verdicts != 2 || stat.Agreement != wantAgreement[stat.Model] {
+			t.Errorf("%s: expected 2 verdicts at %.0f%% agreement, got %+v", stat.Model, wantAgreement[stat.Model], stat)
+		}
+		if stat.InputTokens != 100 || stat.OutputTokens != 20 {
+			t.Errorf("%s: expected per-judge usage 100/20, got %d/%d", stat.Model, stat.InputTokens, stat.OutputTokens)
+		}
+	}
+}
+
+func TestJudgePanel_TieAndFailures(t *testing.T) {
+	panel := newJudgePanel(
+		panelMember{model: "judge-a", judge: &mockJudge{responses: []string{entityJudgeResponse(true, false, false, false)}}},
+		panelMember{model: "judge-b", judge: &mockJudge{responses: []string{entityJudgeResponse(false, false, false, false)}}},
+		panelMember{model: "judge-c", judge: &mockJudge{err: errors.New("rate limited")}},
+	)
+	table := SchemaTable{TableName: "orders"}
+	entity := EntitySummary{TableName: "orders", BusinessName: "Order", Description: "A purchase"}
+
+	got := assessSingleEntity(context.Background(), panel, &judgeTracker{}, table, entity)
+
+	if got.isGeneric || got.issue != "" {
+		t.Errorf("expected a 1-1 tie to resolve to not generic with no issue, got %+v", got)
+	}
+	if stats := panel.Stats(); stats[2].Errors != 1 || stats[2].Calls != 0 {
+		t.Errorf("expected failed judge to record an error, got %+v", stats[2])
+	}
+}
+
+func TestParseJudgeModels(t *testing.T) {
+	models, err := parseJudgeModels(" model-a, ,model-b ")
+	if err != nil || len(models) != 2 || models[0] != "model-a" || models[1] != "model-b" {
+		t.Errorf("expected [model-a model-b], got %v (err %v)", models, err)
+	}
+	if _, err := parseJudgeModels(" , "); err == nil {
+		t.Error("expected error for empty judge list")
+	}
+}
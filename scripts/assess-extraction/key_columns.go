@@ -0,0 +1,152 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Penalties for the deterministic key-column check, per entity.
+const (
+	keyColumnPrimaryKeyOnlyPenalty = 50 // Key columns add nothing beyond the primary key
+	keyColumnAuditPenalty          = 25 // Per audit/system column listed as a key column
+	keyColumnUnknownPenalty        = 25 // Per key column that is not a column of the table
+)
+
+// keyColumnMaxPenalty is how many points the extracted-info quality score loses when
+// every entity's key columns score 0; the penalty scales with the key-column score.
+const keyColumnMaxPenalty = 20
+
+// auditColumnNames are bookkeeping columns that never help a user identify or query
+// an entity.
+var auditColumnNames = map[string]bool{
+	"created_at": true, "created_on": true, "created_by": true, "created_date": true,
+	"updated_at": true, "updated_on": true, "updated_by": true, "updated_date": true,
+	"modified_at": true, "modified_on": true, "modified_by": true, "last_modified": true,
+	"deleted_at": true, "deleted_on": true, "deleted_by": true,
+	"inserted_at": true, "inserted_by": true,
+	"version": true, "row_version": true, "lock_version": true, "etag": true,
+}
+
+// KeyColumnFinding is the key-column assessment of one entity.
+type KeyColumnFinding struct {
+	Table      string   `json:"table"`
+	KeyColumns []string `json:"key_columns"`
+	Score      int      `json:"score"`
+	Problems   []string `json:"problems"`
+}
+
+// KeyColumnResult is the deterministic assessment of the key columns chosen for
+// every entity summary.
+type KeyColumnResult struct {
+	Score           int                `json:"score"`
+	EntitiesChecked int                `json:"entities_checked"`
+	PoorSelections  []KeyColumnFinding `json:"poor_selections"`
+	Issues          []string           `json:"issues"`
+}
+
+// checkKeyColumns scores the key columns of every entity summary without an LLM call.
+// Good key columns are the business identifiers and attributes a user would ask
+// about (email, name, status); an entity is marked down when its key columns are
+// only the primary key, include audit/system columns, or name columns the table does
+// not have. Entities whose table is not in the schema are skipped.
+func checkKeyColumns(schema []SchemaTable, entities map[string]EntitySummary) *KeyColumnResult {
+	result := &KeyColumnResult{
+		Score:          100,
+		PoorSelections: []KeyColumnFinding{},
+		Issues:         []string{},
+	}
+
+	tables := make(map[string]SchemaTable, len(schema))
+	for _, t := range schema {
+		tables[t.TableName] = t
+	}
+
+	names := make([]string, 0, len(entities))
+	for name := range entities {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	total := 0
+	for _, name := range names {
+		table, ok := tables[name]
+		if !ok {
+			continue
+		}
+		finding := scoreKeyColumns(table, entities[name])
+		result.EntitiesChecked++
+		total += finding.Score
+		if len(finding.Problems) > 0 {
+			result.PoorSelections = append(result.PoorSelections, finding)
+			result.Issues = append(result.Issues, fmt.Sprintf("Key columns for %s: %s",
+				finding.Table, strings.Join(finding.Problems, "; ")))
+		}
+	}
+
+	if result.EntitiesChecked > 0 {
+		result.Score = total / result.EntitiesChecked
+	}
+	return result
+}
+
+// scoreKeyColumns scores one entity's key columns from 0 to 100.
+func scoreKeyColumns(table SchemaTable, entity EntitySummary) KeyColumnFinding {
+	finding := KeyColumnFinding{
+		Table:      table.TableName,
+		KeyColumns: make([]string, 0, len(entity.KeyColumns)),
+		Score:      100,
+		Problems:   []string{},
+	}
+	for _, kc := range entity.KeyColumns {
+		finding.KeyColumns = append(finding.KeyColumns, kc.Name)
+	}
+
+	if len(finding.KeyColumns) == 0 {
+		finding.Score = 0
+		finding.Problems = append(finding.Problems, "no key columns listed")
+		return finding
+	}
+
+	columns := make(map[string]SchemaColumn, len(table.Columns))
+	for _, col := range table.Columns {
+		columns[strings.ToLower(col.ColumnName)] = col
+	}
+
+	var audit, unknown []string
+	primaryKeyOnly := true
+	for _, name := range finding.KeyColumns {
+		lower := strings.ToLower(name)
+		col, exists := columns[lower]
+		if !exists {
+			unknown = append(unknown, name)
+		}
+		if auditColumnNames[lower] {
+			audit = append(audit, name)
+		}
+		if !col.IsPrimaryKey && lower != "id" {
+			primaryKeyOnly = false
+		}
+	}
+
+	if primaryKeyOnly {
+		finding.Score -= keyColumnPrimaryKeyOnlyPenalty
+		finding.Problems = append(finding.Problems,
+			"only the primary key is listed; include business identifiers such as email, name or status")
+	}
+	if len(audit) > 0 {
+		finding.Score -= keyColumnAuditPenalty * len(audit)
+		finding.Problems = append(finding.Problems,
+			fmt.Sprintf("audit/system column(s) listed: %s", strings.Join(audit, ", ")))
+	}
+	if len(unknown) > 0 {
+		finding.Score -= keyColumnUnknownPenalty * len(unknown)
+		finding.Problems = append(finding.Problems,
+			fmt.Sprintf("column(s) not in table: %s", strings.Join(unknown, ", ")))
+	}
+
+	if finding.Score < 0 {
+		finding.Score = 0
+	}
+	return finding
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// usersTable is a users table with a primary key, business columns and audit columns.
+func usersTable() SchemaTable {
+	return SchemaTable{
+		TableName: "users",
+		Columns: []SchemaColumn{
+			{ColumnName: "id", DataType: "uuid", IsPrimaryKey: true},
+			{ColumnName: "email", DataType: "text"},
+			{ColumnName: "status", DataType: "text"},
+			{ColumnName: "created_at", DataType: "timestamptz"},
+			{ColumnName: "updated_at", DataType: "timestamptz"},
+		},
+	}
+}
+
+// entityWithKeyColumns builds an entity summary for table listing the given key columns.
+func entityWithKeyColumns(table string, keyColumns ...string) EntitySummary {
+	entity := EntitySummary{TableName: table}
+	for _, name := range keyColumns {
+		entity.KeyColumns = append(entity.KeyColumns, struct {
+			Name     string   `json:"name"`
+			Synonyms []string `json:"synonyms"`
+		}{Name: name})
+	}
+	return entity
+}
+
+func TestScoreKeyColumns_PrimaryKeyOnlyScoresLowerThanBusinessColumns(t *testing.T) {
+	idOnly := scoreKeyColumns(usersTable(), entityWithKeyColumns("users", "id"))
+	business := scoreKeyColumns(usersTable(), entityWithKeyColumns("users", "id", "email", "status"))
+
+	if idOnly.Score >= business.Score {
+		t.Fatalf("id-only score %d should be lower than id, email, status score %d", idOnly.Score, business.Score)
+	}
+	if business.Score != 100 || len(business.Problems) != 0 {
+		t.Errorf("expected id, email, status to score 100 with no problems, got %+v", business)
+	}
+	if len(idOnly.Problems) != 1 || !strings.Contains(idOnly.Problems[0], "only the primary key") {
+		t.Errorf("expected a primary-key-only problem, got %v", idOnly.Problems)
+	}
+}
+
+func TestScoreKeyColumns_FlagsAuditAndUnknownColumns(t *testing.T) {
+	finding := scoreKeyColumns(usersTable(), entityWithKeyColumns("users", "email", "created_at", "nickname"))
+
+	if finding.Score != 100-keyColumnAuditPenalty-keyColumnUnknownPenalty {
+		t.Errorf("score = %d, want %d", finding.Score, 100-keyColumnAuditPenalty-keyColumnUnknownPenalty)
+	}
+	problems := strings.Join(finding.Problems, "; ")
+	if !strings.Contains(problems, "audit/system column(s) listed: created_at") {
+		t.Errorf("expected created_at flagged as an audit column, got %q", problems)
+	}
+	if !strings.Contains(problems, "column(s) not in table: nickname") {
+		t.Errorf("expected nickname flagged as unknown, got %q", problems)
+	}
+
+	if empty := scoreKeyColumns(usersTable(), entityWithKeyColumns("users")); empty.Score != 0 {
+		t.Errorf("expected no key columns to score 0, got %d", empty.Score)
+	}
+}
+
+func TestCheckKeyColumns_ReportsPoorSelectionsPerEntity(t *testing.T) {
+	orders := SchemaTable{
+		TableName: "orders",
+		Columns: []SchemaColumn{
+			{ColumnName: "id", IsPrimaryKey: true},
+			{ColumnName: "order_number"},
+		},
+	}
+	entities := map[string]EntitySummary{
+		"users":  entityWithKeyColumns("users", "id"),
+		"orders": entityWithKeyColumns("orders", "id", "order_number"),
+		"ghosts": entityWithKeyColumns("ghosts", "id"),
+	}
+
+	result := checkKeyColumns([]SchemaTable{usersTable(), orders}, entities)
+
+	if result.EntitiesChecked != 2 {
+		t.Errorf("expected the entity without a table to be skipped, checked %d", result.EntitiesChecked)
+	}
+	if len(result.PoorSelections) != 1 || result.PoorSelections[0].Table != "users" {
+		t.Fatalf("expected only users flagged, got %+v", result.PoorSelections)
+	}
+	if result.Score != (50+100)/2 {
+		t.Errorf("score = %d, want %d", result.Score, (50+100)/2)
+	}
+	if len(result.Issues) != 1 || !strings.HasPrefix(result.Issues[0], "Key columns for users:") {
+		t.Errorf("unexpected issues %v", result.Issues)
+	}
+}
@@ -12,7 +12,18 @@
 //
 // Use this tool to compare models (Haiku vs Sonnet vs Opus) on the same project.
 //
-// Usage: go run ./scripts/assess-extraction <project-id>
+// Usage: go run ./scripts/assess-extraction [-v | -quiet] [-format json|md] [-metrics-target url] [-judges model1,model2,...] [-max-singleton-domain-ratio N] [-max-domain-share N] [-duplicate-description-similarity N] [-cost-weighted-efficiency] <project-id>
+//
+//	-v      verbose progress on stderr (per-sample detail)
+//	-quiet  no progress on stderr; the result on stdout is unchanged
+//	-format json (default) or md for a Markdown report with score badges
+//	-judges                      comma-separated judge models; with several, each prompt goes to
+//	                             every model and verdicts are combined by majority vote (default JudgeModel)
+//	-max-singleton-domain-ratio  share of entities alone in their domain before flagging over-split (default 0.5)
+//	-max-domain-share            share of entities in the largest domain before flagging under-grouping (default 0.8)
+//	                             Both shares must be between 0 and 1.
+//	-cost-weighted-efficiency    score token usage by its cost at list price rather than raw tokens, so a
+//	                             cheaper model that uses more tokens is not penalized against a pricier one
 //
 // Requires: ANTHROPIC_API_KEY environment variable
 // Database connection: Uses standard PG* environment variables
@@ -21,6 +32,7 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"os"
 	"os/exec"
@@ -28,7 +40,14 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
-	"github.com/liushuangls/go-anthropic/v2"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/llm"
+	"github.com/ekaya-inc/ekaya-engine/pkg/models"
+	"github.com/ekaya-inc/ekaya-engine/scripts/internal/assessjudge"
+	"github.com/ekaya-inc/ekaya-engine/scripts/internal/assesslog"
+	"github.com/ekaya-inc/ekaya-engine/scripts/internal/assessmetrics"
+	"github.com/ekaya-inc/ekaya-engine/scripts/internal/assessontology"
+	"github.com/ekaya-inc/ekaya-engine/scripts/internal/assessreport"
 )
 
 // =============================================================================
@@ -43,9 +62,22 @@ const (
 	WeightEfficiency           = 10 // Token usage, completion rate
 )
 
-// Judge model to use for assessments
+// Scores given to a judged phase whose inputs are empty. The phase returns before
+// building any judge prompt, with an issue explaining why it was not judged.
+const (
+	EmptyQuestionsScore       = 100 // No questions asked: nothing to penalize
+	EmptyEntitySummariesScore = 0   // No entity summaries (or no tables to compare them to): nothing was extracted
+	EmptyDomainSummaryScore   = 0   // No domain summary: nothing was extracted
+)
+
+// Default judge model to use for assessments (override with -judges)
 const JudgeModel = "claude-sonnet-4-5-20250929"
 
+// EfficiencyReferenceModel anchors cost-weighted efficiency: each model's tokens are
+// scaled by its list price relative to this model's, so the tokens-per-table
+// thresholds mean the same thing in both modes.
+const EfficiencyReferenceModel = "claude-sonnet-4"
+
 // =============================================================================
 // Output Data Types
 // =============================================================================
@@ -56,7 +88,7 @@ type AssessmentResult struct {
 	DatasourceName         string                 `json:"datasource_name"`
 	ProjectID              string                 `json:"project_id"`
 	ModelUnderTest         string                 `json:"model_under_test"`
-	JudgeModel             string                 `json:"judge_model"`
+	JudgeModel             string                 `json:"judge_model"` // Comma-separated when several judges vote
 	SchemaStats            SchemaStats            `json:"schema_stats"`
 	ChecksSummary          ChecksSummary          `json:"checks_summary"`
 	FinalScore             int                    `json:"final_score"`
@@ -64,6 +96,7 @@ type AssessmentResult struct {
 	ModelComparisonMetrics ModelComparisonMetrics `json:"model_comparison_metrics"`
 	LLMJudgeCalls          int                    `json:"llm_judge_calls"`
 	LLMJudgeTokens         int                    `json:"llm_judge_tokens"`
+	Judges                 []JudgeStat            `json:"judges,omitempty"` // Per-judge usage and agreement; set when several judges vote
 }
 
 // SchemaStats contains basic schema statistics
@@ -105,17 +138,23 @@ type ExtractedInfoQualityScore struct {
 	HallucinationCount int      `json:"hallucination_count"`
 	InsightfulCount    int      `json:"insightful_count"`
 	Issues             []string `json:"issues"`
+
+	KeyColumns            *KeyColumnResult            `json:"key_columns,omitempty"`            // Deterministic key-column selection check over every entity
+	DuplicateDescriptions *DuplicateDescriptionResult `json:"duplicate_descriptions,omitempty"` // Deterministic near-identical description check over every entity
 }
 
 // DomainSummaryQualityScore contains domain summary assessment results
 type DomainSummaryQualityScore struct {
-	Score                 int      `json:"score"`
-	Weight                int      `json:"weight"`
-	DescriptionAccuracy   int      `json:"description_accuracy"`
-	DomainGroupingScore   int      `json:"domain_grouping_score"`
-	RelationshipAccuracy  int      `json:"relationship_accuracy"`
-	SampleQuestionQuality int      `json:"sample_question_quality"`
-	Issues                []string `json:"issues"`
+	Score                 int                  `json:"score"`
+	Weight                int                  `json:"weight"`
+	DescriptionAccuracy   int                  `json:"description_accuracy"`
+	DomainGroupingScore   int                  `json:"domain_grouping_score"`
+	RelationshipAccuracy  int                  `json:"relationship_accuracy"`
+	SampleQuestionQuality int                  `json:"sample_question_quality"`
+	DomainBalance         *DomainBalanceResult `json:"domain_balance,omitempty"` // Deterministic over-split/under-grouped check
+	Issues                []string             `json:"issues"`
+
+	RelationshipLabels *RelationshipLabelResult `json:"relationship_labels,omitempty"` // Deterministic relationship description check
 }
 
 // ConsistencyScore contains consistency assessment results
@@ -125,6 +164,9 @@ type ConsistencyScore struct {
 	CrossRefIssues       int      `json:"cross_ref_issues"`
 	DomainGroupingIssues int      `json:"domain_grouping_issues"`
 	Issues               []string `json:"issues"`
+
+	// Entities named like a glossary term but in a different domain than the term's base table
+	GlossaryDomainConflicts []GlossaryDomainConflict `json:"glossary_domain_conflicts"`
 }
 
 // EfficiencyScore contains efficiency metrics
@@ -133,8 +175,14 @@ type EfficiencyScore struct {
 	Weight            int      `json:"weight"`
 	TokensPerTable    float64  `json:"tokens_per_table"`
 	QuestionsPerTable float64  `json:"questions_per_table"`
+	QuestionsTrimmed  int      `json:"questions_trimmed"` // Dismissed by the per-table cap; excluded from questions_per_table
 	CompletionRate    float64  `json:"completion_rate"`
+	Basis             string   `json:"basis"` // "tokens", or "cost" with -cost-weighted-efficiency
 	Issues            []string `json:"issues"`
+
+	// Cost-weighted mode only: list-price cost, and tokens scaled to EfficiencyReferenceModel prices
+	CostPerTable               float64 `json:"cost_per_table_usd,omitempty"`
+	CostWeightedTokensPerTable float64 `json:"cost_weighted_tokens_per_table,omitempty"`
 }
 
 // ModelComparisonMetrics contains normalized metrics for cross-project comparison
@@ -163,6 +211,7 @@ type OntologyQuestion struct {
 	SourceEntityType *string   `json:"source_entity_type"`
 	SourceEntityKey  *string   `json:"source_entity_key"`
 	Status           string    `json:"status"`
+	StatusReason     *string   `json:"status_reason"`
 }
 
 // LLMConversation represents a stored conversation
@@ -180,10 +229,11 @@ type LLMConversation struct {
 
 // SchemaTable represents a table in the schema
 type SchemaTable struct {
-	ID        uuid.UUID      `json:"id"`
-	TableName string         `json:"table_name"`
-	RowCount  *int64         `json:"row_count"`
-	Columns   []SchemaColumn `json:"columns"`
+	ID         uuid.UUID      `json:"id"`
+	TableName  string         `json:"table_name"`
+	IsCritical bool           `json:"is_critical,omitempty"` // Listed in the project's critical_tables setting
+	RowCount   *int64         `json:"row_count"`
+	Columns    []SchemaColumn `json:"columns"`
 }
 
 // SchemaColumn represents a column
@@ -223,15 +273,18 @@ type EntitySummary struct {
 
 // DomainSummary represents a parsed domain summary
 type DomainSummary struct {
-	Description       string   `json:"description"`
-	Domains           []string `json:"domains"`
-	RelationshipGraph []struct {
-		From        string `json:"from"`
-		To          string `json:"to"`
-		Label       string `json:"label"`
-		Cardinality string `json:"cardinality"`
-	} `json:"relationship_graph"`
-	SampleQuestions []string `json:"sample_questions"`
+	Description       string                  `json:"description"`
+	Domains           []string                `json:"domains"`
+	RelationshipGraph []RelationshipGraphEdge `json:"relationship_graph"`
+	SampleQuestions   []string                `json:"sample_questions"`
+}
+
+// RelationshipGraphEdge is one labeled edge of the domain summary's relationship graph
+type RelationshipGraphEdge struct {
+	From        string `json:"from"`
+	To          string `json:"to"`
+	Label       string `json:"label"`
+	Cardinality string `json:"cardinality"`
 }
 
 // =============================================================================
@@ -252,18 +305,68 @@ func (t *judgeTracker) track(tokens int) {
 // Main Entry Point
 // =============================================================================
 
+// logger writes progress to stderr; replaced in main once -v/-quiet are parsed.
+var logger = assesslog.New(os.Stderr, assesslog.LevelNormal)
+
 func main() {
-	if len(os.Args) < 2 {
-		fmt.Fprintf(os.Stderr, "Usage: %s <project-id>\n", os.Args[0])
+	var logFlags assesslog.Flags
+	logFlags.Register(flag.CommandLine)
+	var formatFlags assessreport.Flags
+	formatFlags.Register(flag.CommandLine)
+	var metricsFlags assessmetrics.Flags
+	metricsFlags.Register(flag.CommandLine)
+	judgesFlag := flag.String("judges", JudgeModel,
+		"comma-separated judge models; with more than one, verdicts are combined by majority vote")
+	domainThresholds := DefaultDomainBalanceThresholds()
+	flag.Float64Var(&domainThresholds.MaxSingletonDomainRatio, "max-singleton-domain-ratio", DefaultMaxSingletonDomainRatio,
+		"share (0-1) of entities that may be alone in their domain before domains count as over-split")
+	flag.Float64Var(&domainThresholds.MaxDomainShare, "max-domain-share", DefaultMaxDomainShare,
+		"share (0-1) of entities the largest domain may hold before domains count as under-grouped")
+	duplicateSimilarity := flag.Float64("duplicate-description-similarity", DefaultDuplicateDescriptionSimilarity,
+		"word-overlap similarity (0-1) at which two entity descriptions are flagged as near-identical")
+	costWeightedEfficiency := flag.Bool("cost-weighted-efficiency", false,
+		"score efficiency on token cost at list price (relative to "+EfficiencyReferenceModel+") instead of raw tokens")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s [-v | -quiet] [-format json|md] [-judges model1,model2,...] [-max-singleton-domain-ratio N] [-max-domain-share N] [-duplicate-description-similarity N] [-cost-weighted-efficiency] <project-id>\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	logger = logFlags.Logger()
+
+	if flag.NArg() < 1 {
+		flag.Usage()
+		os.Exit(1)
+	}
+	thresholdErr := domainThresholds.Validate()
+	if thresholdErr == nil {
+		thresholdErr = validateShare("duplicate-description-similarity", *duplicateSimilarity)
+	}
+	if thresholdErr != nil {
+		fmt.Fprintf(os.Stderr, "Invalid threshold: %v\n", thresholdErr)
+		flag.Usage()
+		os.Exit(1)
+	}
+	if err := formatFlags.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid -format: %v\n", err)
+		os.Exit(1)
+	}
+	if err := metricsFlags.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid -metrics-target: %v\n", err)
 		os.Exit(1)
 	}
 
-	projectID, err := uuid.Parse(os.Args[1])
+	projectID, err := uuid.Parse(flag.Arg(0))
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Invalid project ID: %v\n", err)
 		os.Exit(1)
 	}
 
+	judgeModels, err := parseJudgeModels(*judgesFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid -judges: %v\n", err)
+		os.Exit(1)
+	}
+
 	apiKey := os.Getenv("ANTHROPIC_API_KEY")
 	if apiKey == "" {
 		fmt.Fprintf(os.Stderr, "ANTHROPIC_API_KEY environment variable required\n")
@@ -282,7 +385,7 @@ func main() {
 	defer conn.Close(ctx)
 
 	// Phase 1: Load data
-	fmt.Fprintf(os.Stderr, "Phase 1: Loading data...\n")
+	logger.Progressf("Phase 1: Loading data...\n")
 
 	var datasourceName string
 	if err := conn.QueryRow(ctx, `
@@ -306,6 +409,13 @@ func main() {
 		os.Exit(1)
 	}
 
+	criticalTables, err := loadCriticalTables(ctx, conn, projectID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load critical tables: %v\n", err)
+		os.Exit(1)
+	}
+	markCriticalTables(schema, criticalTables)
+
 	relationships, err := loadRelationships(ctx, conn, projectID)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to load relationships: %v\n", err)
@@ -323,6 +433,13 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Failed to load questions: %v\n", err)
 		os.Exit(1)
 	}
+	questions, trimmedQuestions := withoutTrimmedQuestions(questions)
+
+	glossary, err := loadGlossary(ctx, conn, projectID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load glossary: %v\n", err)
+		os.Exit(1)
+	}
 
 	// Determine model under test
 	modelUnderTest := "unknown"
@@ -339,35 +456,39 @@ func main() {
 		schemaStats.ColumnCount += len(t.Columns)
 	}
 
-	fmt.Fprintf(os.Stderr, "  Tables: %d, Columns: %d, Relationships: %d, Questions: %d\n",
-		schemaStats.TableCount, schemaStats.ColumnCount, schemaStats.RelationshipCount, len(questions))
+	logger.Progressf("  Tables: %d, Columns: %d, Relationships: %d, Questions: %d (%d trimmed)\n",
+		schemaStats.TableCount, schemaStats.ColumnCount, schemaStats.RelationshipCount, len(questions), trimmedQuestions)
 
-	// Create Anthropic client for assessments
-	client := anthropic.NewClient(apiKey)
+	// Create the LLM judge panel for assessments
+	var members []panelMember
+	for _, model := range judgeModels {
+		members = append(members, panelMember{model: model, judge: assessjudge.NewAnthropic(apiKey, model)})
+	}
+	judge := newJudgePanel(members...)
 	tracker := &judgeTracker{}
 
 	// Phase 2: Assess Question Quality (30%)
-	fmt.Fprintf(os.Stderr, "Phase 2: Assessing question quality...\n")
-	questionScore := assessQuestionQuality(ctx, client, tracker, questions, schema, ontology)
+	logger.Progressf("Phase 2: Assessing question quality...\n")
+	questionScore := assessQuestionQuality(ctx, judge, tracker, questions, schema, ontology)
 
 	// Phase 3: Assess Extracted Information Quality (25%)
-	fmt.Fprintf(os.Stderr, "Phase 3: Assessing extracted information quality...\n")
-	extractedInfoScore := assessExtractedInfoQuality(ctx, client, tracker, schema, ontology)
+	logger.Progressf("Phase 3: Assessing extracted information quality...\n")
+	extractedInfoScore := assessExtractedInfoQuality(ctx, judge, tracker, schema, ontology, *duplicateSimilarity)
 
 	// Phase 4: Assess Domain Summary Quality (20%)
-	fmt.Fprintf(os.Stderr, "Phase 4: Assessing domain summary quality...\n")
-	domainSummaryScore := assessDomainSummaryQuality(ctx, client, tracker, schema, relationships, ontology)
+	logger.Progressf("Phase 4: Assessing domain summary quality...\n")
+	domainSummaryScore := assessDomainSummaryQuality(ctx, judge, tracker, schema, relationships, ontology, domainThresholds)
 
 	// Phase 5: Assess Consistency (15%)
-	fmt.Fprintf(os.Stderr, "Phase 5: Assessing consistency...\n")
-	consistencyScore := assessConsistency(schema, relationships, ontology)
+	logger.Progressf("Phase 5: Assessing consistency...\n")
+	consistencyScore := assessConsistency(schema, relationships, ontology, glossary)
 
 	// Phase 6: Calculate Efficiency Metrics (10%)
-	fmt.Fprintf(os.Stderr, "Phase 6: Calculating efficiency metrics...\n")
-	efficiencyScore := calculateEfficiencyMetrics(conversations, questions, schema)
+	logger.Progressf("Phase 6: Calculating efficiency metrics...\n")
+	efficiencyScore := calculateEfficiencyMetrics(conversations, questions, trimmedQuestions, schema, *costWeightedEfficiency)
 
 	// Phase 7: Calculate final score and summary
-	fmt.Fprintf(os.Stderr, "Phase 7: Calculating final score...\n")
+	logger.Progressf("Phase 7: Calculating final score...\n")
 
 	checksSummary := ChecksSummary{
 		QuestionQuality:      questionScore,
@@ -386,19 +507,25 @@ func main() {
 		DatasourceName:         datasourceName,
 		ProjectID:              projectID.String(),
 		ModelUnderTest:         modelUnderTest,
-		JudgeModel:             JudgeModel,
+		JudgeModel:             strings.Join(judge.Models(), ","),
 		SchemaStats:            schemaStats,
 		ChecksSummary:          checksSummary,
 		FinalScore:             finalScore,
 		SmartSummary:           smartSummary,
 		ModelComparisonMetrics: comparisonMetrics,
-		LLMJudgeCalls:          tracker.calls,
+		LLMJudgeCalls:          judge.Calls(),
 		LLMJudgeTokens:         tracker.tokens,
 	}
+	if len(judgeModels) > 1 {
+		result.Judges = judge.Stats()
+	}
 
-	// Output JSON
-	output, _ := json.MarshalIndent(result, "", "  ")
-	fmt.Println(string(output))
+	report := func() assessreport.Report { return markdownReport(&result) }
+	if err := assessreport.Write(os.Stdout, formatFlags.Format, result, report); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write result: %v\n", err)
+		os.Exit(1)
+	}
+	pushMetrics(metricsFlags.Target, &result)
 }
 
 // =============================================================================
@@ -545,26 +672,17 @@ func loadRelationships(ctx context.Context, conn *pgx.Conn, projectID uuid.UUID)
 }
 
 func loadOntology(ctx context.Context, conn *pgx.Conn, projectID uuid.UUID) (*Ontology, error) {
-	query := `
-		SELECT domain_summary, entity_summaries
-		FROM engine_ontologies
-		WHERE project_id = $1 AND is_active = true`
-
-	var o Ontology
-	err := conn.QueryRow(ctx, query, projectID).Scan(&o.DomainSummary, &o.EntitySummaries)
+	o, err := assessontology.Load(ctx, conn, projectID)
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("no active ontology found")
-		}
 		return nil, err
 	}
-	return &o, nil
+	return &Ontology{DomainSummary: o.DomainSummary, EntitySummaries: o.EntitySummaries}, nil
 }
 
 func loadQuestions(ctx context.Context, conn *pgx.Conn, projectID uuid.UUID) ([]OntologyQuestion, error) {
 	query := `
 		SELECT id, text, reasoning, category, priority, is_required,
-		       source_entity_type, source_entity_key, status
+		       source_entity_type, source_entity_key, status, status_reason
 		FROM engine_ontology_questions
 		WHERE project_id = $1
 		ORDER BY is_required DESC, priority ASC`
@@ -579,7 +697,7 @@ func loadQuestions(ctx context.Context, conn *pgx.Conn, projectID uuid.UUID) ([]
 	for rows.Next() {
 		var q OntologyQuestion
 		if err := rows.Scan(&q.ID, &q.Text, &q.Reasoning, &q.Category, &q.Priority,
-			&q.IsRequired, &q.SourceEntityType, &q.SourceEntityKey, &q.Status); err != nil {
+			&q.IsRequired, &q.SourceEntityType, &q.SourceEntityKey, &q.Status, &q.StatusReason); err != nil {
 			return nil, err
 		}
 		questions = append(questions, q)
@@ -587,11 +705,26 @@ func loadQuestions(ctx context.Context, conn *pgx.Conn, projectID uuid.UUID) ([]
 	return questions, rows.Err()
 }
 
+// withoutTrimmedQuestions drops questions the engine dismissed for exceeding the
+// per-table question cap. They were never shown to users, so neither question
+// quality nor efficiency should count them. Returns the kept questions and the
+// number dropped.
+func withoutTrimmedQuestions(questions []OntologyQuestion) ([]OntologyQuestion, int) {
+	kept := make([]OntologyQuestion, 0, len(questions))
+	for _, q := range questions {
+		if q.StatusReason != nil && *q.StatusReason == models.QuestionStatusReasonTrimmed {
+			continue
+		}
+		kept = append(kept, q)
+	}
+	return kept, len(questions) - len(kept)
+}
+
 // =============================================================================
 // Phase 2: Question Quality Assessment (30%)
 // =============================================================================
 
-func assessQuestionQuality(ctx context.Context, client *anthropic.Client, tracker *judgeTracker, questions []OntologyQuestion, schema []SchemaTable, ontology *Ontology) *QuestionQualityScore {
+func assessQuestionQuality(ctx context.Context, judge Judge, tracker *judgeTracker, questions []OntologyQuestion, schema []SchemaTable, ontology *Ontology) *QuestionQualityScore {
 	score := &QuestionQualityScore{
 		Weight:         WeightQuestionQuality,
 		TotalQuestions: len(questions),
@@ -599,7 +732,8 @@ func assessQuestionQuality(ctx context.Context, client *anthropic.Client, tracke
 	}
 
 	if len(questions) == 0 {
-		score.Score = 100 // No questions = nothing to penalize
+		score.Issues = append(score.Issues, "No questions to assess; judge skipped")
+		score.Score = EmptyQuestionsScore
 		return score
 	}
 
@@ -631,7 +765,9 @@ func assessQuestionQuality(ctx context.Context, client *anthropic.Client, tracke
 	var issues []string
 
 	for _, q := range sampled {
-		result := assessSingleQuestion(ctx, client, tracker, q, schemaContext)
+		result := assessSingleQuestion(ctx, judge, tracker, q, schemaContext)
+		logger.Detailf("    question %q: inferrable=%t misclassified=%t insightful=%t\n",
+			truncate(q.Text, 60), result.isInferrable, result.isMisclassified, result.isInsightful)
 		if result.isInferrable {
 			inferrableCount++
 		}
@@ -684,7 +820,7 @@ type questionAssessmentResult struct {
 	issue           string
 }
 
-func assessSingleQuestion(ctx context.Context, client *anthropic.Client, tracker *judgeTracker, q OntologyQuestion, schemaContext string) questionAssessmentResult {
+func assessSingleQuestion(ctx context.Context, judge Judge, tracker *judgeTracker, q OntologyQuestion, schemaContext string) questionAssessmentResult {
 	prompt := fmt.Sprintf(`You are evaluating whether an LLM asked a smart question during database ontology extraction.
 
 ## Schema Context
@@ -720,25 +856,15 @@ Return JSON:
 
 Return ONLY JSON.`, schemaContext, q.Text, q.IsRequired, stringOrEmpty(q.SourceEntityKey))
 
-	resp, err := client.CreateMessages(ctx, anthropic.MessagesRequest{
-		Model:     JudgeModel,
-		MaxTokens: 500,
-		Messages: []anthropic.Message{
-			{Role: anthropic.RoleUser, Content: []anthropic.MessageContent{
-				{Type: "text", Text: &prompt},
-			}},
-		},
-	})
-
+	responseText, usage, err := judge.Assess(ctx, prompt, questionJudgeMaxTokens)
 	if err != nil {
 		return questionAssessmentResult{issue: fmt.Sprintf("Judge error: %v", err)}
 	}
 
 	// Track usage
-	tracker.track(resp.Usage.InputTokens + resp.Usage.OutputTokens)
+	tracker.track(usage.Total())
 
 	// Parse response
-	responseText := extractTextFromResponse(resp)
 	responseText = extractJSON(responseText)
 
 	var result struct {
@@ -773,13 +899,19 @@ Return ONLY JSON.`, schemaContext, q.Text, q.IsRequired, stringOrEmpty(q.SourceE
 // Phase 3: Extracted Information Quality Assessment (25%)
 // =============================================================================
 
-func assessExtractedInfoQuality(ctx context.Context, client *anthropic.Client, tracker *judgeTracker, schema []SchemaTable, ontology *Ontology) *ExtractedInfoQualityScore {
+func assessExtractedInfoQuality(ctx context.Context, judge Judge, tracker *judgeTracker, schema []SchemaTable, ontology *Ontology, duplicateSimilarity float64) *ExtractedInfoQualityScore {
 	score := &ExtractedInfoQualityScore{
 		Weight:        WeightExtractedInfoQuality,
 		TotalEntities: len(schema),
 		Issues:        []string{},
 	}
 
+	if isEmptyJSON(ontology.EntitySummaries) {
+		score.Issues = append(score.Issues, "No entity summaries found; judge skipped")
+		score.Score = EmptyEntitySummariesScore
+		return score
+	}
+
 	// Parse entity summaries from ontology
 	var entitySummaries map[string]EntitySummary
 	if err := json.Unmarshal(ontology.EntitySummaries, &entitySummaries); err != nil {
@@ -789,10 +921,27 @@ func assessExtractedInfoQuality(ctx context.Context, client *anthropic.Client, t
 	}
 
 	if len(entitySummaries) == 0 {
-		score.Issues = append(score.Issues, "No entity summaries found")
-		score.Score = 0
+		score.Issues = append(score.Issues, "No entity summaries found; judge skipped")
+		score.Score = EmptyEntitySummariesScore
 		return score
 	}
+	if len(schema) == 0 {
+		score.Issues = append(score.Issues, "No schema tables to assess entity summaries against; judge skipped")
+		score.Score = EmptyEntitySummariesScore
+		return score
+	}
+
+	// Key-column selection is checked for every entity; it needs no LLM call
+	score.KeyColumns = checkKeyColumns(schema, entitySummaries)
+	for _, finding := range score.KeyColumns.PoorSelections {
+		logger.Detailf("    entity %s: key columns %v scored %d\n", finding.Table, finding.KeyColumns, finding.Score)
+	}
+
+	// So is whether distinct entities were given distinct descriptions
+	score.DuplicateDescriptions = checkDuplicateDescriptions(entitySummaries, duplicateSimilarity)
+	for _, group := range score.DuplicateDescriptions.Groups {
+		logger.Detailf("    entities %v share description %q\n", group.Entities, group.Description)
+	}
 
 	// Sample entities (up to 5 or 20%)
 	sampleSize := len(schema) / 5
@@ -803,15 +952,8 @@ func assessExtractedInfoQuality(ctx context.Context, client *anthropic.Client, t
 		sampleSize = 5
 	}
 
-	// Select evenly distributed samples
-	sampled := make([]SchemaTable, 0, sampleSize)
-	step := len(schema) / sampleSize
-	if step < 1 {
-		step = 1
-	}
-	for i := 0; i < len(schema) && len(sampled) < sampleSize; i += step {
-		sampled = append(sampled, schema[i])
-	}
+	// Business-critical tables first, then evenly distributed samples
+	sampled := sampleEntities(schema, sampleSize)
 	score.EntitiesSampled = len(sampled)
 
 	// Assess each sampled entity
@@ -825,7 +967,9 @@ func assessExtractedInfoQuality(ctx context.Context, client *anthropic.Client, t
 			continue
 		}
 
-		result := assessSingleEntity(ctx, client, tracker, table, entity)
+		result := assessSingleEntity(ctx, judge, tracker, table, entity)
+		logger.Detailf("    entity %s: generic=%t domain_error=%t hallucination=%t insightful=%t\n",
+			table.TableName, result.isGeneric, result.hasDomainError, result.hasHallucination, result.isInsightful)
 		if result.isGeneric {
 			genericCount++
 		}
@@ -850,10 +994,14 @@ func assessExtractedInfoQuality(ctx context.Context, client *anthropic.Client, t
 
 	// Calculate score
 	finalScore := 100
-	finalScore -= genericCount * 5        // -5 per generic description
-	finalScore -= domainErrors * 10       // -10 per domain error
-	finalScore -= hallucinationCount * 15 // -15 per hallucination
-	finalScore += insightfulCount * 5     // +5 per insightful inference
+	finalScore -= genericCount * 5                                           // -5 per generic description
+	finalScore -= domainErrors * 10                                          // -10 per domain error
+	finalScore -= hallucinationCount * 15                                    // -15 per hallucination
+	finalScore += insightfulCount * 5                                        // +5 per insightful inference
+	finalScore -= (100 - score.KeyColumns.Score) * keyColumnMaxPenalty / 100 // up to -20 for poor key columns
+
+	// Up to -15 when entities share near-identical descriptions
+	finalScore -= (100 - score.DuplicateDescriptions.Score) * duplicateDescriptionMaxPenalty / 100
 
 	if finalScore < 0 {
 		finalScore = 0
@@ -874,6 +1022,8 @@ func assessExtractedInfoQuality(ctx context.Context, client *anthropic.Client, t
 		score.Issues = append(score.Issues, fmt.Sprintf("%d hallucinated business purpose(s)", hallucinationCount))
 	}
 	score.Issues = append(score.Issues, issues...)
+	score.Issues = append(score.Issues, score.KeyColumns.Issues...)
+	score.Issues = append(score.Issues, score.DuplicateDescriptions.Issues...)
 
 	return score
 }
@@ -886,7 +1036,7 @@ type entityAssessmentResult struct {
 	issue            string
 }
 
-func assessSingleEntity(ctx context.Context, client *anthropic.Client, tracker *judgeTracker, table SchemaTable, entity EntitySummary) entityAssessmentResult {
+func assessSingleEntity(ctx context.Context, judge Judge, tracker *judgeTracker, table SchemaTable, entity EntitySummary) entityAssessmentResult {
 	// Build table schema description
 	var schemaDesc strings.Builder
 	schemaDesc.WriteString(fmt.Sprintf("Table: %s\n", table.TableName))
@@ -948,23 +1098,13 @@ Return JSON:
 
 Return ONLY JSON.`, schemaDesc.String(), entity.BusinessName, entity.Description, entity.Domain, strings.Join(keyColNames, ", "), entity.Synonyms)
 
-	resp, err := client.CreateMessages(ctx, anthropic.MessagesRequest{
-		Model:     JudgeModel,
-		MaxTokens: 500,
-		Messages: []anthropic.Message{
-			{Role: anthropic.RoleUser, Content: []anthropic.MessageContent{
-				{Type: "text", Text: &prompt},
-			}},
-		},
-	})
-
+	responseText, usage, err := judge.Assess(ctx, prompt, entityJudgeMaxTokens)
 	if err != nil {
 		return entityAssessmentResult{issue: fmt.Sprintf("Judge error for %s: %v", table.TableName, err)}
 	}
 
-	tracker.track(resp.Usage.InputTokens + resp.Usage.OutputTokens)
+	tracker.track(usage.Total())
 
-	responseText := extractTextFromResponse(resp)
 	responseText = extractJSON(responseText)
 
 	var result struct {
@@ -1002,12 +1142,25 @@ Return ONLY JSON.`, schemaDesc.String(), entity.BusinessName, entity.Description
 // Phase 4: Domain Summary Quality Assessment (20%)
 // =============================================================================
 
-func assessDomainSummaryQuality(ctx context.Context, client *anthropic.Client, tracker *judgeTracker, schema []SchemaTable, relationships []SchemaRelationship, ontology *Ontology) *DomainSummaryQualityScore {
+func assessDomainSummaryQuality(ctx context.Context, judge Judge, tracker *judgeTracker, schema []SchemaTable, relationships []SchemaRelationship, ontology *Ontology, thresholds DomainBalanceThresholds) *DomainSummaryQualityScore {
 	score := &DomainSummaryQualityScore{
 		Weight: WeightDomainSummaryQuality,
 		Issues: []string{},
 	}
 
+	// Deterministic domain balance check over entity domain assignments
+	var entitySummaries map[string]EntitySummary
+	if err := json.Unmarshal(ontology.EntitySummaries, &entitySummaries); err == nil {
+		score.DomainBalance = checkDomainBalance(entitySummaries, thresholds)
+		score.Issues = append(score.Issues, score.DomainBalance.Issues...)
+	}
+
+	if isEmptyJSON(ontology.DomainSummary) {
+		score.Issues = append(score.Issues, "No domain summary found; judge skipped")
+		score.Score = EmptyDomainSummaryScore
+		return score
+	}
+
 	// Parse domain summary
 	var domainSummary DomainSummary
 	if err := json.Unmarshal(ontology.DomainSummary, &domainSummary); err != nil {
@@ -1016,6 +1169,17 @@ func assessDomainSummaryQuality(ctx context.Context, client *anthropic.Client, t
 		return score
 	}
 
+	if domainSummary.Description == "" && len(domainSummary.Domains) == 0 &&
+		len(domainSummary.RelationshipGraph) == 0 && len(domainSummary.SampleQuestions) == 0 {
+		score.Issues = append(score.Issues, "Domain summary is empty; judge skipped")
+		score.Score = EmptyDomainSummaryScore
+		return score
+	}
+
+	// Deterministic check that relationship labels are specific enough to re-use
+	score.RelationshipLabels = checkRelationshipLabels(domainSummary.RelationshipGraph)
+	score.Issues = append(score.Issues, score.RelationshipLabels.Issues...)
+
 	// Build schema overview
 	var schemaOverview strings.Builder
 	schemaOverview.WriteString("Tables:\n")
@@ -1079,25 +1243,15 @@ Return JSON:
 
 Return ONLY JSON.`, schemaOverview.String(), domainSummary.Description, domainSummary.Domains, graphStr.String(), domainSummary.SampleQuestions)
 
-	resp, err := client.CreateMessages(ctx, anthropic.MessagesRequest{
-		Model:     JudgeModel,
-		MaxTokens: 1000,
-		Messages: []anthropic.Message{
-			{Role: anthropic.RoleUser, Content: []anthropic.MessageContent{
-				{Type: "text", Text: &prompt},
-			}},
-		},
-	})
-
+	responseText, usage, err := judge.Assess(ctx, prompt, domainSummaryJudgeMaxTokens)
 	if err != nil {
 		score.Issues = append(score.Issues, fmt.Sprintf("Judge error: %v", err))
 		score.Score = 50
 		return score
 	}
 
-	tracker.track(resp.Usage.InputTokens + resp.Usage.OutputTokens)
+	tracker.track(usage.Total())
 
-	responseText := extractTextFromResponse(resp)
 	responseText = extractJSON(responseText)
 
 	var result struct {
@@ -1120,9 +1274,20 @@ Return ONLY JSON.`, schemaOverview.String(), domainSummary.Description, domainSu
 	score.SampleQuestionQuality = result.SampleQuestionQuality
 	score.Issues = append(score.Issues, result.Issues...)
 
-	// Calculate overall score (average of components)
-	score.Score = (result.DescriptionAccuracy + result.DomainGroupingScore +
-		result.RelationshipAccuracy + result.SampleQuestionQuality) / 4
+	// Calculate overall score (average of components, including the deterministic
+	// domain balance and relationship label checks when they had anything to check)
+	total := result.DescriptionAccuracy + result.DomainGroupingScore +
+		result.RelationshipAccuracy + result.SampleQuestionQuality
+	components := 4
+	if score.DomainBalance != nil && !score.DomainBalance.Skipped {
+		total += score.DomainBalance.Score
+		components++
+	}
+	if !score.RelationshipLabels.Skipped {
+		total += score.RelationshipLabels.Score
+		components++
+	}
+	score.Score = total / components
 
 	return score
 }
@@ -1131,7 +1296,7 @@ Return ONLY JSON.`, schemaOverview.String(), domainSummary.Description, domainSu
 // Phase 5: Consistency Assessment (15%)
 // =============================================================================
 
-func assessConsistency(schema []SchemaTable, relationships []SchemaRelationship, ontology *Ontology) *ConsistencyScore {
+func assessConsistency(schema []SchemaTable, relationships []SchemaRelationship, ontology *Ontology, glossary []GlossaryTerm) *ConsistencyScore {
 	score := &ConsistencyScore{
 		Weight: WeightConsistency,
 		Issues: []string{},
@@ -1188,14 +1353,19 @@ func assessConsistency(schema []SchemaTable, relationships []SchemaRelationship,
 	}
 	score.DomainGroupingIssues = domainGroupingIssues
 
+	// Check 3: Glossary domain consistency
+	// Are entities named like a glossary term in the domain the term implies?
+	glossaryChecks, glossaryConflicts := checkGlossaryDomains(entitySummaries, glossary)
+	score.GlossaryDomainConflicts = glossaryConflicts
+
 	// Calculate score
-	totalChecks := len(relationships) * 2 // Two checks per relationship
+	totalChecks := len(relationships)*2 + glossaryChecks // Two checks per relationship, one per glossary-matched entity
 	if totalChecks == 0 {
 		score.Score = 100
 		return score
 	}
 
-	totalIssues := crossRefIssues + domainGroupingIssues
+	totalIssues := crossRefIssues + domainGroupingIssues + len(glossaryConflicts)
 	issueRate := float64(totalIssues) / float64(totalChecks)
 	score.Score = int((1 - issueRate) * 100)
 	if score.Score < 0 {
@@ -1209,6 +1379,9 @@ func assessConsistency(schema []SchemaTable, relationships []SchemaRelationship,
 	if domainGroupingIssues > 0 {
 		score.Issues = append(score.Issues, fmt.Sprintf("%d FK-related tables in unrelated domains", domainGroupingIssues))
 	}
+	for _, c := range glossaryConflicts {
+		score.Issues = append(score.Issues, formatGlossaryDomainConflict(c))
+	}
 
 	return score
 }
@@ -1250,10 +1423,15 @@ func areDomainsRelated(d1, d2 string) bool {
 // Phase 6: Efficiency Metrics (10%)
 // =============================================================================
 
-func calculateEfficiencyMetrics(conversations []LLMConversation, questions []OntologyQuestion, schema []SchemaTable) *EfficiencyScore {
+// calculateEfficiencyMetrics scores token usage, question volume and completion rate.
+// With costWeighted, token usage is judged on cost-weighted tokens per table (see
+// costWeightedTokens) instead of raw tokens.
+func calculateEfficiencyMetrics(conversations []LLMConversation, questions []OntologyQuestion, trimmedQuestions int, schema []SchemaTable, costWeighted bool) *EfficiencyScore {
 	score := &EfficiencyScore{
-		Weight: WeightEfficiency,
-		Issues: []string{},
+		Weight:           WeightEfficiency,
+		Basis:            "tokens",
+		QuestionsTrimmed: trimmedQuestions,
+		Issues:           []string{},
 	}
 
 	if len(schema) == 0 {
@@ -1268,7 +1446,19 @@ func calculateEfficiencyMetrics(conversations []LLMConversation, questions []Ont
 	}
 	score.TokensPerTable = float64(totalTokens) / float64(len(schema))
 
-	// Calculate questions per table
+	usagePerTable, usageLabel := score.TokensPerTable, "token usage"
+	if costWeighted {
+		weightedTokens, cost, unpriced := costWeightedTokens(conversations)
+		score.Basis = "cost"
+		score.CostPerTable = cost / float64(len(schema))
+		score.CostWeightedTokensPerTable = weightedTokens / float64(len(schema))
+		usagePerTable, usageLabel = score.CostWeightedTokensPerTable, "cost-weighted token usage"
+		for _, model := range unpriced {
+			score.Issues = append(score.Issues, fmt.Sprintf("No list price for model %s; its raw tokens were used", model))
+		}
+	}
+
+	// Calculate questions per table (after the engine's per-table cap)
 	score.QuestionsPerTable = float64(len(questions)) / float64(len(schema))
 
 	// Calculate completion rate (% of successful conversations)
@@ -1293,12 +1483,12 @@ func calculateEfficiencyMetrics(conversations []LLMConversation, questions []Ont
 	efficiencyScore := 100
 
 	// Penalize if tokens/table is too high
-	if score.TokensPerTable > 3000 {
+	if usagePerTable > 3000 {
 		efficiencyScore -= 20
-		score.Issues = append(score.Issues, "High token usage per table")
-	} else if score.TokensPerTable > 2000 {
+		score.Issues = append(score.Issues, "High "+usageLabel+" per table")
+	} else if usagePerTable > 2000 {
 		efficiencyScore -= 10
-		score.Issues = append(score.Issues, "Above-average token usage")
+		score.Issues = append(score.Issues, "Above-average "+usageLabel)
 	}
 
 	// Penalize if questions/table is too high (might indicate poor question targeting)
@@ -1322,6 +1512,34 @@ func calculateEfficiencyMetrics(conversations []LLMConversation, questions []Ont
 	return score
 }
 
+// costWeightedTokens returns the conversations' tokens scaled by each model's list
+// price relative to EfficiencyReferenceModel (a model at a third of the price counts
+// a third of its tokens), their total list-price cost in USD, and the models without
+// a price, whose tokens count unscaled.
+func costWeightedTokens(conversations []LLMConversation) (weightedTokens, cost float64, unpriced []string) {
+	reference, _ := llm.LookupPricing(EfficiencyReferenceModel)
+	seenUnpriced := make(map[string]bool)
+	for _, c := range conversations {
+		pricing, ok := llm.LookupPricing(c.Model)
+		if !ok {
+			weightedTokens += float64(c.TotalTokens)
+			if !seenUnpriced[c.Model] {
+				seenUnpriced[c.Model] = true
+				unpriced = append(unpriced, c.Model)
+			}
+			continue
+		}
+		convCost := pricing.Cost(c.PromptTokens, c.CompletionTokens)
+		cost += convCost
+		if referenceCost := reference.Cost(c.PromptTokens, c.CompletionTokens); referenceCost > 0 {
+			weightedTokens += float64(c.TotalTokens) * convCost / referenceCost
+		} else {
+			weightedTokens += float64(c.TotalTokens)
+		}
+	}
+	return weightedTokens, cost, unpriced
+}
+
 // =============================================================================
 // Phase 7: Final Score Calculation
 // =============================================================================
@@ -1407,6 +1625,16 @@ func calculateComparisonMetrics(summary ChecksSummary, stats SchemaStats) ModelC
 // Utility Functions
 // =============================================================================
 
+// isEmptyJSON reports whether raw holds no value: nothing at all, null, {} or [].
+func isEmptyJSON(raw json.RawMessage) bool {
+	switch strings.TrimSpace(string(raw)) {
+	case "", "null", "{}", "[]":
+		return true
+	default:
+		return false
+	}
+}
+
 func buildSchemaContext(schema []SchemaTable) string {
 	var sb strings.Builder
 	for _, t := range schema {
@@ -1423,15 +1651,6 @@ func buildSchemaContext(schema []SchemaTable) string {
 	return sb.String()
 }
 
-func extractTextFromResponse(resp anthropic.MessagesResponse) string {
-	for _, block := range resp.Content {
-		if block.Type == "text" && block.Text != nil {
-			return *block.Text
-		}
-	}
-	return ""
-}
-
 func extractJSON(s string) string {
 	start := strings.Index(s, "{")
 	end := strings.LastIndex(s, "}")
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// JudgeStat is the usage and agreement of one model on a judge panel.
+// Agreement is the share of boolean verdicts where the model matched the
+// panel's majority; it is only meaningful when the panel has several models.
+type JudgeStat struct {
+	Model        string  `json:"model"`
+	Calls        int     `json:"calls"`
+	Errors       int     `json:"errors"`
+	InputTokens  int     `json:"input_tokens"`
+	OutputTokens int     `json:"output_tokens"`
+	Verdicts     int     `json:"verdicts"`
+	Agreements   int     `json:"agreements"`
+	Agreement    float64 `json:"agreement"` // Percent of verdicts matching the majority
+}
+
+// panelMember pairs a judge with the model name it reports under.
+type panelMember struct {
+	model string
+	judge Judge
+}
+
+// judgePanel runs every prompt against each member judge and combines their JSON
+// responses field by field:
+//   - booleans by strict majority (a tie resolves to false, so it neither penalizes
+//     nor rewards)
+//   - numbers by average, rounded to the nearest integer
+//   - strings by the most common value, earliest member first on ties
+//   - string lists by the union of all members' entries
+//
+// With a single member the response is passed through unchanged. Because the panel
+// is itself a Judge, the scoring functions do not know how many models answered.
+type judgePanel struct {
+	members []panelMember
+	stats   []*JudgeStat
+}
+
+var _ Judge = (*judgePanel)(nil)
+
+func newJudgePanel(members ...panelMember) *judgePanel {
+	p := &judgePanel{members: members}
+	for _, m := range members {
+		p.stats = append(p.stats, &JudgeStat{Model: m.model})
+	}
+	return p
+}
+
+// parseJudgeModels splits the -judges flag value into model names.
+func parseJudgeModels(value string) ([]string, error) {
+	var models []string
+	for _, m := range strings.Split(value, ",") {
+		if m = strings.TrimSpace(m); m != "" {
+			models = append(models, m)
+		}
+	}
+	if len(models) == 0 {
+		return nil, fmt.Errorf("no judge models in %q", value)
+	}
+	return models, nil
+}
+
+// Models returns the member model names in panel order.
+func (p *judgePanel) Models() []string {
+	models := make([]string, len(p.members))
+	for i, m := range p.members {
+		models[i] = m.model
+	}
+	return models
+}
+
+// Calls returns the number of successful judge calls across all members.
+func (p *judgePanel) Calls() int {
+	calls := 0
+	for _, s := range p.stats {
+		calls += s.Calls
+	}
+	return calls
+}
+
+// Stats returns per-member usage with agreement percentages filled in.
+func (p *judgePanel) Stats() []JudgeStat {
+	out := make([]JudgeStat, len(p.stats))
+	for i, s := range p.stats {
+		out[i] = *s
+		if s.Verdicts > 0 {
+			out[i].Agreement = float64(s.Agreements) * 100 / float64(s.Verdicts)
+		}
+	}
+	return out
+}
+
+// Assess asks every member and returns the combined response with the summed usage.
+// It fails only when every member fails.
+func (p *judgePanel) Assess(ctx context.Context, prompt string, maxTokens int) (string, JudgeUsage, error) {
+	var (
+		total     JudgeUsage
+		firstErr  error
+		firstText string
+		answered  []int
+		responses []map[string]any
+	)
+	for i, m := range p.members {
+		text, usage, err := m.judge.Assess(ctx, prompt, maxTokens)
+		if err != nil {
+			p.stats[i].Errors++
+			if firstErr == nil {
+				firstErr = fmt.Errorf("%s: %w", m.model, err)
+			}
+			continue
+		}
+		p.stats[i].Calls++
+		p.stats[i].InputTokens += usage.InputTokens
+		p.stats[i].OutputTokens += usage.OutputTokens
+		total.InputTokens += usage.InputTokens
+		total.OutputTokens += usage.OutputTokens
+
+		if firstText == "" {
+			firstText = text
+		}
+		var parsed map[string]any
+		if err := json.Unmarshal([]byte(extractJSON(text)), &parsed); err != nil {
+			logger.Detailf("    Judge %s returned unparseable response: %v\n", m.model, err)
+			continue
+		}
+		answered = append(answered, i)
+		responses = append(responses, parsed)
+	}
+
+	if firstText == "" && firstErr != nil {
+		return "", total, firstErr
+	}
+	if len(p.members) == 1 || len(responses) == 0 {
+		// Nothing to combine; let the caller parse (or fail to parse) the raw text.
+		return firstText, total, nil
+	}
+
+	combined := combineJudgeResponses(responses)
+	for n, i := range answered {
+		for key, v := range responses[n] {
+			verdict, ok := v.(bool)
+			if !ok {
+				continue
+			}
+			p.stats[i].Verdicts++
+			if verdict == combined[key] {
+				p.stats[i].Agreements++
+			}
+		}
+	}
+
+	out, err := json.Marshal(combined)
+	if err != nil {
+		return "", total, fmt.Errorf("marshal combined judge response: %w", err)
+	}
+	return string(out), total, nil
+}
+
+// combineJudgeResponses merges parsed judge responses using the panel rules.
+func combineJudgeResponses(responses []map[string]any) map[string]any {
+	var keys []string
+	seen := make(map[string]bool)
+	for _, r := range responses {
+		for key := range r {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	combined := make(map[string]any, len(keys))
+	for _, key := range keys {
+		var values []any
+		for _, r := range responses {
+			if v, ok := r[key]; ok {
+				values = append(values, v)
+			}
+		}
+		combined[key] = combineJudgeValues(values)
+	}
+	return combined
+}
+
+func combineJudgeValues(values []any) any {
+	switch values[0].(type) {
+	case bool:
+		trues, votes := 0, 0
+		for _, v := range values {
+			if b, ok := v.(bool); ok {
+				votes++
+				if b {
+					trues++
+				}
+			}
+		}
+		return trues*2 > votes
+	case float64:
+		sum, n := 0.0, 0
+		for _, v := range values {
+			if f, ok := v.(float64); ok {
+				sum += f
+				n++
+			}
+		}
+		return math.Round(sum / float64(n))
+	case string:
+		counts := make(map[string]int)
+		best := ""
+		for _, v := range values {
+			s, ok := v.(string)
+			if !ok {
+				continue
+			}
+			counts[s]++
+			if counts[s] > counts[best] || best == "" {
+				best = s
+			}
+		}
+		return best
+	case []any:
+		var union []any
+		seen := make(map[string]bool)
+		for _, v := range values {
+			list, ok := v.([]any)
+			if !ok {
+				continue
+			}
+			for _, item := range list {
+				key := fmt.Sprint(item)
+				if !seen[key] {
+					seen[key] = true
+					union = append(union, item)
+				}
+			}
+		}
+		return union
+	default:
+		return values[0]
+	}
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// minRelationshipLabelWords is the shortest label that can name both entities and
+// how many of one relate to the other ("each order belongs to one customer").
+const minRelationshipLabelWords = 4
+
+// genericRelationshipLabels are labels that say two entities are connected and
+// nothing more.
+var genericRelationshipLabels = map[string]bool{
+	"has":                true,
+	"have":               true,
+	"relates to":         true,
+	"related to":         true,
+	"is related to":      true,
+	"associated with":    true,
+	"is associated with": true,
+	"linked to":          true,
+	"is linked to":       true,
+	"connected to":       true,
+	"is connected to":    true,
+	"references":         true,
+	"refers to":          true,
+	"belongs to":         true,
+	"link":               true,
+	"relationship":       true,
+}
+
+// cardinalityWords signal how many of one entity relate to the other.
+var cardinalityWords = map[string]bool{
+	"each": true, "every": true, "one": true, "single": true, "many": true,
+	"multiple": true, "several": true, "zero": true, "only": true, "exactly": true,
+	"most": true, "least": true, "any": true, "number": true, "unique": true,
+}
+
+// RelationshipLabelResult is the deterministic assessment of the relationship graph's
+// labels. A good label is specific, states cardinality and names both entities.
+type RelationshipLabelResult struct {
+	Score           int                     `json:"score"`
+	Checked         int                     `json:"checked"`
+	PoorlyDescribed []PoorRelationshipLabel `json:"poorly_described"` // Candidates for re-generation
+	Skipped         bool                    `json:"skipped,omitempty"`
+	Issues          []string                `json:"issues"`
+}
+
+// PoorRelationshipLabel is one relationship whose label failed a check.
+type PoorRelationshipLabel struct {
+	From    string   `json:"from"`
+	To      string   `json:"to"`
+	Label   string   `json:"label"`
+	Reasons []string `json:"reasons"`
+}
+
+// checkRelationshipLabels flags relationship graph labels that are generic ("relates
+// to"), too short, lack cardinality language, or do not name both entities. The score
+// is the share of labels passing every check.
+func checkRelationshipLabels(edges []RelationshipGraphEdge) *RelationshipLabelResult {
+	result := &RelationshipLabelResult{
+		Score:           100,
+		PoorlyDescribed: []PoorRelationshipLabel{},
+		Issues:          []string{},
+	}
+	if len(edges) == 0 {
+		result.Skipped = true
+		return result
+	}
+
+	for _, edge := range edges {
+		result.Checked++
+		if reasons := relationshipLabelProblems(edge); len(reasons) > 0 {
+			result.PoorlyDescribed = append(result.PoorlyDescribed, PoorRelationshipLabel{
+				From: edge.From, To: edge.To, Label: edge.Label, Reasons: reasons,
+			})
+		}
+	}
+
+	good := result.Checked - len(result.PoorlyDescribed)
+	result.Score = good * 100 / result.Checked
+	if n := len(result.PoorlyDescribed); n > 0 {
+		result.Issues = append(result.Issues, fmt.Sprintf(
+			"%d of %d relationship descriptions are generic or incomplete and should be re-generated", n, result.Checked))
+	}
+	return result
+}
+
+// relationshipLabelProblems returns why edge's label is a poor description, or nil.
+func relationshipLabelProblems(edge RelationshipGraphEdge) []string {
+	label := strings.TrimSpace(edge.Label)
+	if label == "" {
+		return []string{"missing"}
+	}
+
+	words := labelWords(label)
+	if genericRelationshipLabels[strings.Join(words, " ")] {
+		return []string{"generic"}
+	}
+
+	var reasons []string
+	if len(words) < minRelationshipLabelWords {
+		reasons = append(reasons, "too short")
+	}
+	hasCardinality := false
+	for _, w := range words {
+		if cardinalityWords[w] {
+			hasCardinality = true
+			break
+		}
+	}
+	if !hasCardinality {
+		reasons = append(reasons, "no cardinality")
+	}
+	if !namesEntity(words, edge.From) || !namesEntity(words, edge.To) {
+		reasons = append(reasons, "does not name both entities")
+	}
+	return reasons
+}
+
+// namesEntity reports whether the label words contain the entity's name, compared
+// word by word and ignoring plurals, so "customers" is named by "each customer".
+func namesEntity(words []string, entity string) bool {
+	name := labelWords(splitCamelCase(entity))
+	if len(name) == 0 {
+		return false
+	}
+	for i := range name {
+		name[i] = singularWord(name[i])
+	}
+	for start := 0; start+len(name) <= len(words); start++ {
+		match := true
+		for i, w := range name {
+			if singularWord(words[start+i]) != w {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+// labelWords lowercases a label and splits it into words.
+func labelWords(label string) []string {
+	return strings.FieldsFunc(strings.ToLower(label), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	})
+}
+
+// splitCamelCase puts a space before each upper-case letter that follows a lower-case
+// one, so "LineItem" splits like "line_item".
+func splitCamelCase(s string) string {
+	var b strings.Builder
+	prevLower := false
+	for _, r := range s {
+		if r >= 'A' && r <= 'Z' && prevLower {
+			b.WriteByte(' ')
+		}
+		b.WriteRune(r)
+		prevLower = r >= 'a' && r <= 'z'
+	}
+	return b.String()
+}
+
+func singularWord(w string) string {
+	switch {
+	case strings.HasSuffix(w, "ies") && len(w) > 4:
+		return strings.TrimSuffix(w, "ies") + "y"
+	case strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss") && len(w) > 3:
+		return strings.TrimSuffix(w, "s")
+	default:
+		return w
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckRelationshipLabels_GenericVersusSpecific(t *testing.T) {
+	edges := []RelationshipGraphEdge{
+		{From: "orders", To: "customers", Label: "relates to"},
+		{From: "orders", To: "customers", Label: "Each order belongs to one customer"},
+	}
+
+	result := checkRelationshipLabels(edges)
+
+	if result.Checked != 2 {
+		t.Fatalf("expected 2 labels checked, got %d", result.Checked)
+	}
+	if len(result.PoorlyDescribed) != 1 {
+		t.Fatalf("expected only the generic label flagged, got %+v", result.PoorlyDescribed)
+	}
+	poor := result.PoorlyDescribed[0]
+	if poor.Label != "relates to" || len(poor.Reasons) != 1 || poor.Reasons[0] != "generic" {
+		t.Errorf("expected 'relates to' flagged as generic, got %+v", poor)
+	}
+	if result.Score != 50 {
+		t.Errorf("expected score 50, got %d", result.Score)
+	}
+	if len(result.Issues) != 1 || !strings.Contains(result.Issues[0], "re-generated") {
+		t.Errorf("expected a re-generation issue, got %v", result.Issues)
+	}
+}
+
+func TestRelationshipLabelProblems(t *testing.T) {
+	tests := []struct {
+		name string
+		edge RelationshipGraphEdge
+		want []string
+	}{
+		{
+			name: "specific label with business names",
+			edge: RelationshipGraphEdge{From: "Line Item", To: "Product", Label: "each line item references exactly one product"},
+			want: nil,
+		},
+		{
+			name: "plural entity names",
+			edge: RelationshipGraphEdge{From: "categories", To: "products", Label: "one category groups many products"},
+			want: nil,
+		},
+		{
+			name: "missing",
+			edge: RelationshipGraphEdge{From: "orders", To: "users"},
+			want: []string{"missing"},
+		},
+		{
+			name: "too short without cardinality",
+			edge: RelationshipGraphEdge{From: "orders", To: "users", Label: "placed by"},
+			want: []string{"too short", "no cardinality", "does not name both entities"},
+		},
+		{
+			name: "names only one entity",
+			edge: RelationshipGraphEdge{From: "orders", To: "users", Label: "each order has one buyer"},
+			want: []string{"does not name both entities"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := relationshipLabelProblems(tt.edge)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("relationshipLabelProblems() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckRelationshipLabels_SkippedWithoutEdges(t *testing.T) {
+	result := checkRelationshipLabels(nil)
+	if !result.Skipped || result.Score != 100 {
+		t.Errorf("expected a skipped check scoring 100, got %+v", result)
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/ekaya-inc/ekaya-engine/scripts/internal/assessmetrics"
+	"github.com/ekaya-inc/ekaya-engine/scripts/internal/assessreport"
+)
+
+// markdownReport builds the -format md report from the same result printed as JSON.
+// Issues are listed category by category, the most heavily weighted first.
+func markdownReport(result *AssessmentResult) assessreport.Report {
+	m := result.ModelComparisonMetrics
+	report := assessreport.Report{
+		Title: fmt.Sprintf("Extraction Assessment: %s", result.DatasourceName),
+		Details: []assessreport.Detail{
+			{Name: "Project", Value: result.ProjectID},
+			{Name: "Model under test", Value: result.ModelUnderTest},
+			{Name: "Judge", Value: result.JudgeModel},
+			{Name: "Commit", Value: result.CommitInfo},
+			{Name: "Schema", Value: fmt.Sprintf("%d tables, %d columns, %d relationships",
+				result.SchemaStats.TableCount, result.SchemaStats.ColumnCount, result.SchemaStats.RelationshipCount)},
+			{Name: "Tokens per table", Value: fmt.Sprintf("%.0f", m.TokensPerTable)},
+			{Name: "Completion rate", Value: fmt.Sprintf("%.1f%%", m.CompletionRate)},
+			{Name: "Judge usage", Value: fmt.Sprintf("%d calls, %d tokens", result.LLMJudgeCalls, result.LLMJudgeTokens)},
+		},
+		FinalScore: result.FinalScore,
+		Summary:    result.SmartSummary,
+		Issues:     []string{},
+	}
+
+	add := func(name string, score, weight int, issues []string) {
+		report.Categories = append(report.Categories, assessreport.Category{
+			Name: name, Score: score, Weight: float64(weight), Notes: issues,
+		})
+		report.Issues = append(report.Issues, issues...)
+	}
+	s := result.ChecksSummary
+	if s.QuestionQuality != nil {
+		add("Question quality", s.QuestionQuality.Score, s.QuestionQuality.Weight, s.QuestionQuality.Issues)
+	}
+	if s.ExtractedInfoQuality != nil {
+		add("Extracted info quality", s.ExtractedInfoQuality.Score, s.ExtractedInfoQuality.Weight, s.ExtractedInfoQuality.Issues)
+	}
+	if s.DomainSummaryQuality != nil {
+		add("Domain summary quality", s.DomainSummaryQuality.Score, s.DomainSummaryQuality.Weight, s.DomainSummaryQuality.Issues)
+	}
+	if s.Consistency != nil {
+		add("Consistency", s.Consistency.Score, s.Consistency.Weight, s.Consistency.Issues)
+	}
+	if s.Efficiency != nil {
+		add("Efficiency", s.Efficiency.Score, s.Efficiency.Weight, s.Efficiency.Issues)
+	}
+	return report
+}
+
+// pushMetrics sends the scores to the -metrics-target monitoring endpoint, if one is
+// set. The result is already written, so a failed push is only reported.
+func pushMetrics(target string, result *AssessmentResult) {
+	labels := assessmetrics.Labels{ProjectID: result.ProjectID, Datasource: result.DatasourceName, Commit: result.CommitInfo}
+	var costPerTable float64
+	if result.ChecksSummary.Efficiency != nil {
+		costPerTable = result.ChecksSummary.Efficiency.CostPerTable
+	}
+	metrics := assessmetrics.FromReport(markdownReport(result),
+		assessmetrics.Metric{Name: "judge_calls", Value: float64(result.LLMJudgeCalls)},
+		assessmetrics.Metric{Name: "judge_tokens", Value: float64(result.LLMJudgeTokens)},
+		assessmetrics.Metric{Name: "tokens_per_table", Value: result.ModelComparisonMetrics.TokensPerTable},
+		assessmetrics.Metric{Name: "cost_per_table_usd", Value: costPerTable},
+	)
+	if err := assessmetrics.Push(context.Background(), target, "extraction", labels, metrics); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to push metrics: %v\n", err)
+	}
+}
@@ -1,6 +1,6 @@
 #!/bin/bash
 # Assess LLM response quality for ontology extraction
-# Usage: ./scripts/assess-llm-responses.sh <project-id>
+# Usage: ./scripts/assess-llm-responses.sh [-v | -quiet] [-baseline <file>] <project-id>
 #
 # This tool evaluates the LLM RESPONSE quality during ontology extraction:
 # - Structural validity: Is JSON parseable and well-formed?
@@ -23,11 +23,11 @@ SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
 PROJECT_ROOT="$(dirname "$SCRIPT_DIR")"
 
 if [ -z "$1" ]; then
-    echo "Usage: $0 <project-id>" >&2
+    echo "Usage: $0 [-v | -quiet] [-baseline <file>] <project-id>" >&2
     echo "" >&2
     echo "Example: $0 f2324998-64c0-46e7-98d1-8a778be462f2" >&2
     exit 1
 fi
 
 cd "$PROJECT_ROOT"
-go run ./scripts/assess-llm-responses "$1"
+go run ./scripts/assess-llm-responses "$@"
@@ -4,6 +4,7 @@ package main
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 )
 
@@ -31,6 +32,7 @@ type HallucinationReport struct {
 	HallucinatedTables   int      `json:"hallucinated_tables"`
 	HallucinatedColumns  int      `json:"hallucinated_columns"`
 	HallucinatedSources  int      `json:"hallucinated_sources"`
+	HallucinatedSamples  int      `json:"hallucinated_sample_questions"` // Sample question references to missing tables/columns
 	Examples             []string `json:"examples"`
 	Score                int      `json:"score"` // 0-100
 	ConversationsChecked int      `json:"conversations_checked"`
@@ -73,20 +75,29 @@ func checkAllHallucinations(
 	questionScore, sourceHallucinations := checkQuestionSourceHallucinations(questions, validTables)
 	allHallucinations = append(allHallucinations, sourceHallucinations...)
 
+	// 4.4 Domain Summary Sample Questions
+	sampleScore, sampleHallucinations := checkSampleQuestionHallucinations(
+		tagged, structureResults, validTables, validColumns,
+	)
+	allHallucinations = append(allHallucinations, sampleHallucinations...)
+
 	// Count hallucinations by type
 	report.HallucinatedColumns = len(entityHallucinations) + len(tier1ColumnHallucinations)
 	report.HallucinatedTables = len(tier1TableHallucinations)
 	report.HallucinatedSources = len(sourceHallucinations)
-	report.TotalHallucinations = report.HallucinatedColumns + report.HallucinatedTables + report.HallucinatedSources
+	report.HallucinatedSamples = len(sampleHallucinations)
+	report.TotalHallucinations = report.HallucinatedColumns + report.HallucinatedTables +
+		report.HallucinatedSources + report.HallucinatedSamples
 
 	// Store first 5 examples
 	for i := 0; i < len(allHallucinations) && i < 5; i++ {
 		report.Examples = append(report.Examples, allHallucinations[i])
 	}
 
-	// Count conversations checked (entity_analysis + tier1_batch)
+	// Count conversations checked (entity_analysis + tier1_batch + tier0_domain)
 	for _, tc := range tagged {
-		if tc.PromptType == PromptTypeEntityAnalysis || tc.PromptType == PromptTypeTier1Batch {
+		if tc.PromptType == PromptTypeEntityAnalysis || tc.PromptType == PromptTypeTier1Batch ||
+			tc.PromptType == PromptTypeTier0Domain {
 			report.ConversationsChecked++
 		}
 	}
@@ -95,13 +106,15 @@ func checkAllHallucinations(
 	// Max penalty from entity analysis: 40 points
 	// Max penalty from tier1 batch: 30 points
 	// Max penalty from question sources: 10 points
-	// Total max penalty: 80 points, but score is 0-100
+	// Max penalty from sample questions: 20 points
+	// Total max penalty: 100 points, but score is 0-100
 	//
 	// We combine the sub-scores (each 0-100) weighted by their max penalties:
 	// Entity analysis: 40 points weight
 	// Tier1 batch: 30 points weight
 	// Question sources: 10 points weight
-	// Total weight: 80 (but we normalize to 100)
+	// Sample questions: 10 points weight
+	// Total weight: 90 (but we normalize to 100)
 	//
 	// Final score = weighted average of sub-scores
 	if report.ConversationsChecked > 0 || len(questions) > 0 {
@@ -110,12 +123,14 @@ func checkAllHallucinations(
 			entityWeight   = 50 // Entity analysis is most critical
 			tier1Weight    = 40 // Tier1 batch is important
 			questionWeight = 10 // Question sources are less critical
+			sampleWeight   = 10 // Sample questions only describe the domain
 		)
-		totalWeight := entityWeight + tier1Weight + questionWeight
+		totalWeight := entityWeight + tier1Weight + questionWeight + sampleWeight
 
 		report.Score = (entityAnalysisScore*entityWeight +
 			tier1Score*tier1Weight +
-			questionScore*questionWeight) / totalWeight
+			questionScore*questionWeight +
+			sampleScore*sampleWeight) / totalWeight
 	} else {
 		// No relevant conversations to check - perfect score
 		report.Score = 100
@@ -357,6 +372,213 @@ func checkQuestionSourceHallucinations(
 	return score, hallucinations
 }
 
+// =============================================================================
+// 4.4 Domain Summary Sample Question Validation (20 points max penalty)
+// =============================================================================
+
+var (
+	// reDottedReference matches table.column references
+	reDottedReference = regexp.MustCompile(`\b([a-z_][a-z0-9_]+)\.([a-z_][a-z0-9_]+)\b`)
+	// reBacktickReference matches `identifier` references
+	reBacktickReference = regexp.MustCompile("`([a-z_][a-z0-9_]*)`")
+	// reSnakeCaseReference matches snake_case words, which prose never uses
+	reSnakeCaseReference = regexp.MustCompile(`\b[a-z][a-z0-9]*(?:_[a-z0-9]+)+\b`)
+	// reNamedReference matches "the revenue table", "the amount column"
+	reNamedReference = regexp.MustCompile(`\b([a-z_][a-z0-9_]*)\s+(table|column)s?\b`)
+)
+
+// sampleReferenceStopwords are words that precede "table" or "column" in prose
+// without naming one ("which table", "each column").
+var sampleReferenceStopwords = map[string]bool{
+	"a": true, "an": true, "the": true, "each": true, "every": true, "any": true,
+	"which": true, "what": true, "this": true, "that": true, "same": true, "one": true,
+	"another": true, "other": true, "its": true, "their": true, "lookup": true,
+	"join": true, "parent": true, "child": true, "main": true, "fact": true,
+	"dimension": true, "source": true, "target": true, "of": true, "with": true,
+	"for": true, "in": true, "and": true, "or": true, "to": true, "by": true,
+	"from": true, "per": true,
+}
+
+// checkSampleQuestionHallucinations checks the sample questions of tier0_domain
+// responses for references to tables and columns missing from the schema. Sample
+// questions are prose, so only tokens that clearly name a schema object count as
+// references: table.column, `backticked` and snake_case identifiers, and words
+// followed by "table" or "column" ("the revenue table").
+// Returns a score (0-100) and list of hallucination messages.
+func checkSampleQuestionHallucinations(
+	tagged []TaggedConversation,
+	structureResults []StructureCheckResult,
+	validTables map[string]bool,
+	validColumns map[string]map[string]bool,
+) (int, []string) {
+	var hallucinations []string
+	domainCount := 0
+
+	for i, tc := range tagged {
+		if tc.PromptType != PromptTypeTier0Domain {
+			continue
+		}
+		domainCount++
+
+		if structureResults[i].ParsedResponse == nil {
+			continue
+		}
+		sampleQuestions, ok := structureResults[i].ParsedResponse["sample_questions"].([]interface{})
+		if !ok {
+			continue
+		}
+
+		for _, sq := range sampleQuestions {
+			question, ok := sq.(string)
+			if !ok {
+				continue
+			}
+			for _, ref := range missingSampleQuestionReferences(question, validTables, validColumns) {
+				hallucinations = append(hallucinations,
+					fmt.Sprintf("tier0_domain sample question '%s': references non-existent %s",
+						truncateText(question, 50), ref))
+			}
+		}
+	}
+
+	// Calculate score: -5 points per missing reference, max -20
+	if domainCount == 0 {
+		return 100, hallucinations
+	}
+
+	penalty := len(hallucinations) * 5
+	if penalty > 20 {
+		penalty = 20
+	}
+	return 100 - penalty, hallucinations
+}
+
+// missingSampleQuestionReferences returns the schema references in a sample question
+// that do not exist, described as "table 'revenue'" or "column 'orders.total'".
+func missingSampleQuestionReferences(
+	question string,
+	validTables map[string]bool,
+	validColumns map[string]map[string]bool,
+) []string {
+	text := strings.ToLower(question)
+	var missing []string
+	seen := make(map[string]bool)
+	flag := func(ref string) {
+		if !seen[ref] {
+			seen[ref] = true
+			missing = append(missing, ref)
+		}
+	}
+
+	// Identifiers consumed by a dotted reference are not checked again on their own
+	consumed := make(map[string]bool)
+	for _, m := range reDottedReference.FindAllStringSubmatch(text, -1) {
+		qualifier, name := m[1], m[2]
+		consumed[qualifier], consumed[name] = true, true
+		if table, ok := matchSchemaName(qualifier, validTables); ok {
+			if !columnExists(name, validColumns[table]) {
+				flag(fmt.Sprintf("column '%s.%s'", qualifier, name))
+			}
+			continue
+		}
+		// schema.table is also a valid reference
+		if _, ok := matchSchemaName(name, validTables); !ok {
+			flag(fmt.Sprintf("table or column '%s.%s'", qualifier, name))
+		}
+	}
+
+	for _, m := range reNamedReference.FindAllStringSubmatch(text, -1) {
+		checkNamedReference(m[1], m[2], validTables, validColumns, consumed, flag)
+	}
+
+	var identifiers []string
+	for _, m := range reBacktickReference.FindAllStringSubmatch(text, -1) {
+		identifiers = append(identifiers, m[1])
+	}
+	identifiers = append(identifiers, reSnakeCaseReference.FindAllString(text, -1)...)
+	for _, ident := range identifiers {
+		if consumed[ident] {
+			continue
+		}
+		consumed[ident] = true
+		if _, ok := matchSchemaName(ident, validTables); ok {
+			continue
+		}
+		if !columnExistsAnywhere(ident, validColumns) {
+			flag(fmt.Sprintf("table or column '%s'", ident))
+		}
+	}
+
+	return missing
+}
+
+// checkNamedReference flags a word that prose names as a table or column ("the
+// revenue table") when no such table or column exists.
+func checkNamedReference(
+	name, kind string,
+	validTables map[string]bool,
+	validColumns map[string]map[string]bool,
+	consumed map[string]bool,
+	flag func(string),
+) {
+	if sampleReferenceStopwords[name] || consumed[name] {
+		return
+	}
+	consumed[name] = true
+	if kind == "table" {
+		if _, ok := matchSchemaName(name, validTables); !ok {
+			flag(fmt.Sprintf("table '%s'", name))
+		}
+		return
+	}
+	if !columnExistsAnywhere(name, validColumns) {
+		flag(fmt.Sprintf("column '%s'", name))
+	}
+}
+
+// matchSchemaName finds name among names, allowing for singular and plural forms
+// ("order" matches the orders table). Returns the matching name.
+func matchSchemaName(name string, names map[string]bool) (string, bool) {
+	for _, candidate := range nameVariants(name) {
+		if names[candidate] {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+func columnExists(name string, columns map[string]bool) bool {
+	_, ok := matchSchemaName(name, columns)
+	return ok
+}
+
+func columnExistsAnywhere(name string, validColumns map[string]map[string]bool) bool {
+	for _, columns := range validColumns {
+		if columnExists(name, columns) {
+			return true
+		}
+	}
+	return false
+}
+
+// nameVariants returns name with its likely singular and plural forms.
+func nameVariants(name string) []string {
+	variants := []string{name, name + "s", name + "es"}
+	switch {
+	case strings.HasSuffix(name, "ies"):
+		variants = append(variants, strings.TrimSuffix(name, "ies")+"y")
+	case strings.HasSuffix(name, "y"):
+		variants = append(variants, strings.TrimSuffix(name, "y")+"ies")
+	}
+	if strings.HasSuffix(name, "es") {
+		variants = append(variants, strings.TrimSuffix(name, "es"))
+	}
+	if strings.HasSuffix(name, "s") {
+		variants = append(variants, strings.TrimSuffix(name, "s"))
+	}
+	return variants
+}
+
 // =============================================================================
 // Helper Functions
 // =============================================================================
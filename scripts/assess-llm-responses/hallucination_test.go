@@ -0,0 +1,97 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func sampleQuestionFixture(questions ...string) ([]TaggedConversation, []StructureCheckResult) {
+	var sampleQuestions []interface{}
+	for _, q := range questions {
+		sampleQuestions = append(sampleQuestions, q)
+	}
+	tagged := []TaggedConversation{{PromptType: PromptTypeTier0Domain}}
+	structure := []StructureCheckResult{{
+		ParsedResponse: map[string]interface{}{"sample_questions": sampleQuestions},
+	}}
+	return tagged, structure
+}
+
+func sampleQuestionSchema() (map[string]bool, map[string]map[string]bool) {
+	return buildSchemaLookups([]SchemaTable{
+		{TableName: "orders", Columns: []SchemaColumn{{ColumnName: "id"}, {ColumnName: "total_amount"}, {ColumnName: "status"}}},
+		{TableName: "customers", Columns: []SchemaColumn{{ColumnName: "id"}, {ColumnName: "created_at"}}},
+	})
+}
+
+func TestCheckSampleQuestionHallucinations_FlagsMissingTable(t *testing.T) {
+	validTables, validColumns := sampleQuestionSchema()
+	tagged, structure := sampleQuestionFixture("Which customers drive the most value in the revenue table?")
+
+	score, hallucinations := checkSampleQuestionHallucinations(tagged, structure, validTables, validColumns)
+
+	if len(hallucinations) != 1 {
+		t.Fatalf("expected 1 hallucination, got %v", hallucinations)
+	}
+	if !strings.Contains(hallucinations[0], "non-existent table 'revenue'") {
+		t.Errorf("unexpected message: %s", hallucinations[0])
+	}
+	if score != 95 {
+		t.Errorf("expected score 95, got %d", score)
+	}
+}
+
+func TestCheckSampleQuestionHallucinations_FlagsMissingColumns(t *testing.T) {
+	validTables, validColumns := sampleQuestionSchema()
+	tagged, structure := sampleQuestionFixture(
+		"What is the average orders.discount per customer?",
+		"How does refund_reason vary by status?",
+	)
+
+	_, hallucinations := checkSampleQuestionHallucinations(tagged, structure, validTables, validColumns)
+
+	if len(hallucinations) != 2 {
+		t.Fatalf("expected 2 hallucinations, got %v", hallucinations)
+	}
+	if !strings.Contains(hallucinations[0], "column 'orders.discount'") {
+		t.Errorf("unexpected message: %s", hallucinations[0])
+	}
+	if !strings.Contains(hallucinations[1], "table or column 'refund_reason'") {
+		t.Errorf("unexpected message: %s", hallucinations[1])
+	}
+}
+
+func TestCheckSampleQuestionHallucinations_AcceptsSchemaReferences(t *testing.T) {
+	validTables, validColumns := sampleQuestionSchema()
+	tagged, structure := sampleQuestionFixture(
+		"What is the total_amount of each order?",
+		"How many public.customers signed up by created_at month?",
+		"Which status column value is most common in the orders table?",
+		"Which customer table rows have no orders?",
+		"Which table holds the most data, and what does each column mean?",
+	)
+
+	score, hallucinations := checkSampleQuestionHallucinations(tagged, structure, validTables, validColumns)
+
+	if len(hallucinations) != 0 {
+		t.Errorf("expected no hallucinations, got %v", hallucinations)
+	}
+	if score != 100 {
+		t.Errorf("expected score 100, got %d", score)
+	}
+}
+
+func TestCheckAllHallucinations_CountsSampleQuestions(t *testing.T) {
+	validTables, validColumns := sampleQuestionSchema()
+	tagged, structure := sampleQuestionFixture("Which customers drive the most value in the revenue table?")
+
+	report := checkAllHallucinations(tagged, structure, nil, validTables, validColumns)
+
+	if report.HallucinatedSamples != 1 || report.TotalHallucinations != 1 {
+		t.Errorf("expected 1 sample question hallucination in total, got %d of %d",
+			report.HallucinatedSamples, report.TotalHallucinations)
+	}
+	if report.ConversationsChecked != 1 {
+		t.Errorf("expected 1 conversation checked, got %d", report.ConversationsChecked)
+	}
+}
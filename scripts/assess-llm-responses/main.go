@@ -9,7 +9,13 @@
 // - Completeness: Are all required fields present?
 // - Value validation: Are enum values valid? Priority 1-5? Domains non-empty?
 //
-// Usage: go run ./scripts/assess-llm-responses <project-id>
+// Usage: go run ./scripts/assess-llm-responses [-v | -quiet] [-baseline <file>] [-question-categories <list>] <project-id>
+//
+//	-v                     verbose progress on stderr (per-sample detail)
+//	-quiet                 no progress on stderr; the JSON result on stdout is unchanged
+//	-baseline              previous JSON output; exit 2 if tokens per table regressed
+//	-max-token-regression  allowed tokens-per-table growth in percent (default 10)
+//	-question-categories   comma-separated question category taxonomy (default: the engine's built-in one)
 //
 // Database connection: Uses standard PG* environment variables
 //
@@ -21,6 +27,7 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"os"
 	"os/exec"
@@ -28,6 +35,9 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+
+	"github.com/ekaya-inc/ekaya-engine/scripts/internal/assesslog"
+	"github.com/ekaya-inc/ekaya-engine/scripts/internal/assessontology"
 )
 
 // =============================================================================
@@ -94,18 +104,44 @@ type TaggedConversation struct {
 // Main Entry Point
 // =============================================================================
 
+// logger writes progress to stderr; replaced in main once -v/-quiet are parsed.
+var logger = assesslog.New(os.Stderr, assesslog.LevelNormal)
+
 func main() {
-	if len(os.Args) < 2 {
-		fmt.Fprintf(os.Stderr, "Usage: %s <project-id>\n", os.Args[0])
+	var logFlags assesslog.Flags
+	logFlags.Register(flag.CommandLine)
+	baselinePath := flag.String("baseline", "", "previous assess-llm-responses JSON output to guard tokens per table against")
+	maxTokenRegression := flag.Float64("max-token-regression", DefaultMaxTokenRegressionPct, "allowed tokens-per-table growth over the baseline, in percent")
+	categoryList := flag.String("question-categories", strings.Join(defaultQuestionCategories, ","), "comma-separated question category taxonomy, when the engine is configured with its own")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s [-v | -quiet] [-baseline <file>] [-question-categories <list>] <project-id>\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	logger = logFlags.Logger()
+	questionCategories = splitCategoryList(*categoryList)
+
+	if flag.NArg() < 1 {
+		flag.Usage()
 		os.Exit(1)
 	}
 
-	projectID, err := uuid.Parse(os.Args[1])
+	projectID, err := uuid.Parse(flag.Arg(0))
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Invalid project ID: %v\n", err)
 		os.Exit(1)
 	}
 
+	// Load the baseline up front so a bad path fails before any work is done
+	var baselineTokensPerTable float64
+	if *baselinePath != "" {
+		baselineTokensPerTable, err = loadTokenBaseline(*baselinePath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to load baseline: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
 	ctx := context.Background()
 
 	// Connect to database
@@ -134,7 +170,7 @@ func main() {
 	// =========================================================================
 	// Phase 1: Data Loading
 	// =========================================================================
-	fmt.Fprintf(os.Stderr, "Phase 1: Loading data...\n")
+	logger.Progressf("Phase 1: Loading data...\n")
 
 	// Load LLM conversations
 	conversations, err := loadConversations(ctx, conn, projectID)
@@ -142,7 +178,7 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Failed to load conversations: %v\n", err)
 		os.Exit(1)
 	}
-	fmt.Fprintf(os.Stderr, "  Loaded %d conversations\n", len(conversations))
+	logger.Progressf("  Loaded %d conversations\n", len(conversations))
 
 	// Load schema tables and columns
 	schema, err := loadSchema(ctx, conn, projectID)
@@ -150,7 +186,7 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Failed to load schema: %v\n", err)
 		os.Exit(1)
 	}
-	fmt.Fprintf(os.Stderr, "  Loaded %d tables\n", len(schema))
+	logger.Progressf("  Loaded %d tables\n", len(schema))
 
 	// Load ontology
 	ontology, err := loadOntology(ctx, conn, projectID)
@@ -158,7 +194,7 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Failed to load ontology: %v\n", err)
 		os.Exit(1)
 	}
-	fmt.Fprintf(os.Stderr, "  Ontology loaded\n")
+	logger.Progressf("  Ontology loaded\n")
 
 	// Load questions
 	questions, err := loadQuestions(ctx, conn, projectID)
@@ -166,13 +202,13 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Failed to load questions: %v\n", err)
 		os.Exit(1)
 	}
-	fmt.Fprintf(os.Stderr, "  Loaded %d questions\n", len(questions))
+	logger.Progressf("  Loaded %d questions\n", len(questions))
 
 	// Tag conversations by prompt type
 	taggedConversations := tagConversations(conversations)
 	promptTypeCounts := countPromptTypes(taggedConversations)
 
-	fmt.Fprintf(os.Stderr, "  Prompt types: entity_analysis=%d, tier1_batch=%d, tier0_domain=%d, description_processing=%d, unknown=%d\n",
+	logger.Progressf("  Prompt types: entity_analysis=%d, tier1_batch=%d, tier0_domain=%d, description_processing=%d, unknown=%d\n",
 		promptTypeCounts[PromptTypeEntityAnalysis],
 		promptTypeCounts[PromptTypeTier1Batch],
 		promptTypeCounts[PromptTypeTier0Domain],
@@ -181,7 +217,7 @@ func main() {
 
 	// Build lookup maps for hallucination detection
 	validTables, validColumns := buildSchemaLookups(schema)
-	fmt.Fprintf(os.Stderr, "  Built lookup maps: %d tables, %d total columns\n", len(validTables), countTotalColumns(validColumns))
+	logger.Progressf("  Built lookup maps: %d tables, %d total columns\n", len(validTables), countTotalColumns(validColumns))
 
 	// Determine model under test (from first conversation)
 	modelUnderTest := "unknown"
@@ -192,31 +228,34 @@ func main() {
 	// =========================================================================
 	// Phase 3: Per-Response Structural Checks
 	// =========================================================================
-	fmt.Fprintf(os.Stderr, "Phase 3: Running structural checks...\n")
+	logger.Progressf("Phase 3: Running structural checks...\n")
 
 	structureSummary := checkAllStructures(taggedConversations)
-	fmt.Fprintf(os.Stderr, "  Checked %d conversations, %d passed (%.1f%% average score)\n",
+	logger.Progressf("  Checked %d conversations, %d passed (%.1f%% average score)\n",
 		structureSummary.ConversationsChecked,
 		structureSummary.ConversationsPassed,
 		structureSummary.AverageScore)
+	for _, r := range structureSummary.Results {
+		logger.Detailf("    %s [%s] %s: %d/60\n", r.ConversationID, r.PromptType, r.TargetTable, r.TotalScore)
+	}
 
 	if structureSummary.JSONParseFailures > 0 {
-		fmt.Fprintf(os.Stderr, "  JSON parse failures: %d\n", structureSummary.JSONParseFailures)
+		logger.Progressf("  JSON parse failures: %d\n", structureSummary.JSONParseFailures)
 	}
 	if structureSummary.StatusFailures > 0 {
-		fmt.Fprintf(os.Stderr, "  Status failures: %d\n", structureSummary.StatusFailures)
+		logger.Progressf("  Status failures: %d\n", structureSummary.StatusFailures)
 	}
 	if structureSummary.CompletenessIssues > 0 {
-		fmt.Fprintf(os.Stderr, "  Completeness issues: %d conversations\n", structureSummary.CompletenessIssues)
+		logger.Progressf("  Completeness issues: %d conversations\n", structureSummary.CompletenessIssues)
 	}
 	if structureSummary.FieldTypeMismatches > 0 {
-		fmt.Fprintf(os.Stderr, "  Field type mismatches: %d conversations\n", structureSummary.FieldTypeMismatches)
+		logger.Progressf("  Field type mismatches: %d conversations\n", structureSummary.FieldTypeMismatches)
 	}
 
 	// =========================================================================
 	// Phase 4: Hallucination Detection
 	// =========================================================================
-	fmt.Fprintf(os.Stderr, "Phase 4: Running hallucination detection...\n")
+	logger.Progressf("Phase 4: Running hallucination detection...\n")
 
 	hallucinationReport := checkAllHallucinations(
 		taggedConversations,
@@ -226,77 +265,98 @@ func main() {
 		validColumns,
 	)
 
-	fmt.Fprintf(os.Stderr, "  Checked %d conversations, found %d hallucinations (score: %d/100)\n",
+	logger.Progressf("  Checked %d conversations, found %d hallucinations (score: %d/100)\n",
 		hallucinationReport.ConversationsChecked,
 		hallucinationReport.TotalHallucinations,
 		hallucinationReport.Score)
 
 	if hallucinationReport.HallucinatedTables > 0 {
-		fmt.Fprintf(os.Stderr, "  Hallucinated tables: %d\n", hallucinationReport.HallucinatedTables)
+		logger.Progressf("  Hallucinated tables: %d\n", hallucinationReport.HallucinatedTables)
 	}
 	if hallucinationReport.HallucinatedColumns > 0 {
-		fmt.Fprintf(os.Stderr, "  Hallucinated columns: %d\n", hallucinationReport.HallucinatedColumns)
+		logger.Progressf("  Hallucinated columns: %d\n", hallucinationReport.HallucinatedColumns)
 	}
 	if hallucinationReport.HallucinatedSources > 0 {
-		fmt.Fprintf(os.Stderr, "  Hallucinated question sources: %d\n", hallucinationReport.HallucinatedSources)
+		logger.Progressf("  Hallucinated question sources: %d\n", hallucinationReport.HallucinatedSources)
+	}
+	if hallucinationReport.HallucinatedSamples > 0 {
+		logger.Progressf("  Hallucinated sample question references: %d\n", hallucinationReport.HallucinatedSamples)
 	}
 
 	// =========================================================================
 	// Phase 5: Value Validation
 	// =========================================================================
-	fmt.Fprintf(os.Stderr, "Phase 5: Running value validation...\n")
+	logger.Progressf("Phase 5: Running value validation...\n")
 
 	valueSummary := checkAllValueValidation(taggedConversations, structureSummary.Results, questions)
-	fmt.Fprintf(os.Stderr, "  Checked %d conversations, %d passed (%d%% average score)\n",
+	logger.Progressf("  Checked %d conversations, %d passed (%d%% average score)\n",
 		valueSummary.ConversationsChecked,
 		valueSummary.ConversationsPassed,
 		valueScoreToPercentage(int(valueSummary.AverageScore)))
 
 	if valueSummary.StringFieldIssues > 0 {
-		fmt.Fprintf(os.Stderr, "  String field issues: %d conversations\n", valueSummary.StringFieldIssues)
+		logger.Progressf("  String field issues: %d conversations\n", valueSummary.StringFieldIssues)
 	}
 	if valueSummary.PriorityIssues > 0 {
-		fmt.Fprintf(os.Stderr, "  Priority issues: %d conversations\n", valueSummary.PriorityIssues)
+		logger.Progressf("  Priority issues: %d conversations\n", valueSummary.PriorityIssues)
 	}
 	if valueSummary.BooleanTypeIssues > 0 {
-		fmt.Fprintf(os.Stderr, "  Boolean type issues: %d conversations\n", valueSummary.BooleanTypeIssues)
+		logger.Progressf("  Boolean type issues: %d conversations\n", valueSummary.BooleanTypeIssues)
 	}
 	if valueSummary.CategoryMissing > 0 {
-		fmt.Fprintf(os.Stderr, "  Category missing: %d conversations\n", valueSummary.CategoryMissing)
+		logger.Progressf("  Category missing: %d conversations\n", valueSummary.CategoryMissing)
+	}
+	if valueSummary.CategoryInvalid > 0 {
+		logger.Progressf("  Category outside taxonomy: %d conversations\n", valueSummary.CategoryInvalid)
+	}
+	if valueSummary.AnalysisIssues > 0 {
+		logger.Progressf("  Empty or boilerplate analysis: %d conversations\n", valueSummary.AnalysisIssues)
 	}
 	if valueSummary.InvalidPriorities > 0 {
-		fmt.Fprintf(os.Stderr, "  Stored questions with invalid priority: %d/%d\n",
+		logger.Progressf("  Stored questions with invalid priority: %d/%d\n",
 			valueSummary.InvalidPriorities, valueSummary.QuestionsPriority)
 	}
 	if valueSummary.MissingCategories > 0 {
-		fmt.Fprintf(os.Stderr, "  Stored questions with missing category: %d/%d\n",
+		logger.Progressf("  Stored questions with missing category: %d/%d\n",
 			valueSummary.MissingCategories, valueSummary.QuestionCategories)
 	}
+	if valueSummary.InvalidCategories > 0 {
+		logger.Progressf("  Stored questions with category outside taxonomy: %d/%d\n",
+			valueSummary.InvalidCategories, valueSummary.QuestionCategories)
+	}
 
 	// =========================================================================
 	// Phase 6: Token Metrics
 	// =========================================================================
-	fmt.Fprintf(os.Stderr, "Phase 6: Calculating token metrics...\n")
+	logger.Progressf("Phase 6: Calculating token metrics...\n")
 
 	tokenMetrics := calculateTokenMetrics(taggedConversations, len(schema))
-	fmt.Fprintf(os.Stderr, "  Total tokens: %d across %d conversations\n",
+	logger.Progressf("  Total tokens: %d across %d conversations\n",
 		tokenMetrics.TotalTokens, tokenMetrics.TotalConversations)
-	fmt.Fprintf(os.Stderr, "  Avg tokens per conversation: %.1f\n", tokenMetrics.AvgTokensPerConv)
-	fmt.Fprintf(os.Stderr, "  Max tokens in single conversation: %d\n", tokenMetrics.MaxTokens)
-	fmt.Fprintf(os.Stderr, "  Tokens per table analyzed: %.1f\n", tokenMetrics.TokensPerTable)
-	fmt.Fprintf(os.Stderr, "  Throughput: %.1f tokens/sec (aggregate), %.1f tokens/sec (avg per request)\n",
+	logger.Progressf("  Avg tokens per conversation: %.1f\n", tokenMetrics.AvgTokensPerConv)
+	logger.Progressf("  Max tokens in single conversation: %d\n", tokenMetrics.MaxTokens)
+	logger.Progressf("  Tokens per table analyzed: %.1f\n", tokenMetrics.TokensPerTable)
+	logger.Progressf("  Throughput: %.1f tokens/sec (aggregate), %.1f tokens/sec (avg per request)\n",
 		tokenMetrics.TokensPerSecond, tokenMetrics.AvgTokensPerSecond)
 
 	if len(tokenMetrics.Issues) > 0 {
 		for _, issue := range tokenMetrics.Issues {
-			fmt.Fprintf(os.Stderr, "  Issue: %s\n", issue)
+			logger.Progressf("  Issue: %s\n", issue)
 		}
 	}
 
+	var tokenRegression *TokenRegressionResult
+	if *baselinePath != "" {
+		comparison := compareTokensPerTable(baselineTokensPerTable, tokenMetrics.TokensPerTable, *maxTokenRegression)
+		comparison.BaselineSource = *baselinePath
+		tokenRegression = &comparison
+		logger.Progressf("  Baseline: %s\n", comparison.Message)
+	}
+
 	// =========================================================================
 	// Phase 7: Aggregate Scoring and Summary
 	// =========================================================================
-	fmt.Fprintf(os.Stderr, "Phase 7: Calculating final score...\n")
+	logger.Progressf("Phase 7: Calculating final score...\n")
 
 	scoringResult := calculateFinalScoring(
 		structureSummary,
@@ -304,10 +364,11 @@ func main() {
 		valueSummary,
 		tokenMetrics,
 		taggedConversations,
+		len(schema),
 	)
 
-	fmt.Fprintf(os.Stderr, "  Final score: %d/100\n", scoringResult.FinalScore)
-	fmt.Fprintf(os.Stderr, "  %s\n", scoringResult.SmartSummary)
+	logger.Progressf("  Final score: %d/100\n", scoringResult.FinalScore)
+	logger.Progressf("  %s\n", scoringResult.SmartSummary)
 
 	// Suppress unused variable warnings
 	_ = ontology
@@ -347,12 +408,13 @@ func main() {
 
 		// Phase 4: Hallucination details
 		"hallucination_report": map[string]interface{}{
-			"total_hallucinations": hallucinationReport.TotalHallucinations,
-			"hallucinated_tables":  hallucinationReport.HallucinatedTables,
-			"hallucinated_columns": hallucinationReport.HallucinatedColumns,
-			"hallucinated_sources": hallucinationReport.HallucinatedSources,
-			"examples":             hallucinationReport.Examples,
-			"score":                hallucinationReport.Score,
+			"total_hallucinations":          hallucinationReport.TotalHallucinations,
+			"hallucinated_tables":           hallucinationReport.HallucinatedTables,
+			"hallucinated_columns":          hallucinationReport.HallucinatedColumns,
+			"hallucinated_sources":          hallucinationReport.HallucinatedSources,
+			"hallucinated_sample_questions": hallucinationReport.HallucinatedSamples,
+			"examples":                      hallucinationReport.Examples,
+			"score":                         hallucinationReport.Score,
 		},
 
 		"value_validation": map[string]interface{}{
@@ -365,11 +427,15 @@ func main() {
 			"priority_issues":       valueSummary.PriorityIssues,
 			"boolean_type_issues":   valueSummary.BooleanTypeIssues,
 			"category_missing":      valueSummary.CategoryMissing,
+			"category_invalid":      valueSummary.CategoryInvalid,
+			"analysis_issues":       valueSummary.AnalysisIssues,
+			"analysis_examples":     valueSummary.AnalysisExamples,
 			"stored_questions": map[string]interface{}{
 				"priority_checked":   valueSummary.QuestionsPriority,
 				"invalid_priorities": valueSummary.InvalidPriorities,
 				"category_checked":   valueSummary.QuestionCategories,
 				"missing_categories": valueSummary.MissingCategories,
+				"invalid_categories": valueSummary.InvalidCategories,
 			},
 		},
 
@@ -419,9 +485,18 @@ func main() {
 		"final_score":   scoringResult.FinalScore,
 		"smart_summary": scoringResult.SmartSummary,
 	}
+	if tokenRegression != nil {
+		result["token_regression"] = tokenRegression
+	}
 
 	output, _ := json.MarshalIndent(result, "", "  ")
 	fmt.Println(string(output))
+
+	// Exit non-zero after printing so CI still gets the full comparison
+	if tokenRegression != nil && tokenRegression.Regressed {
+		fmt.Fprintf(os.Stderr, "Token regression: %s\n", tokenRegression.Message)
+		os.Exit(2)
+	}
 }
 
 // =============================================================================
@@ -546,22 +621,13 @@ func loadSchema(ctx context.Context, conn *pgx.Conn, projectID uuid.UUID) ([]Sch
 	return tables, nil
 }
 
-// loadOntology loads the active ontology for a project
+// loadOntology loads the project's extracted ontology
 func loadOntology(ctx context.Context, conn *pgx.Conn, projectID uuid.UUID) (*Ontology, error) {
-	query := `
-		SELECT domain_summary, entity_summaries
-		FROM engine_ontologies
-		WHERE project_id = $1 AND is_active = true`
-
-	var o Ontology
-	err := conn.QueryRow(ctx, query, projectID).Scan(&o.DomainSummary, &o.EntitySummaries)
+	o, err := assessontology.Load(ctx, conn, projectID)
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("no active ontology found")
-		}
 		return nil, err
 	}
-	return &o, nil
+	return &Ontology{DomainSummary: o.DomainSummary, EntitySummaries: o.EntitySummaries}, nil
 }
 
 // loadQuestions loads ontology questions for a project
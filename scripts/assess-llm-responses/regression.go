@@ -0,0 +1,90 @@
+// regression.go implements the tokens-per-table regression guard.
+// Given a baseline from a previous run's JSON output, the run fails when
+// tokens per table grew by more than the allowed percentage. This turns token
+// efficiency into an enforceable budget for CI.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// =============================================================================
+// Data Types for Token Regression Guard
+// =============================================================================
+
+// DefaultMaxTokenRegressionPct is the allowed growth in tokens per table before failing.
+const DefaultMaxTokenRegressionPct = 10.0
+
+// TokenRegressionResult compares current tokens per table against a baseline.
+type TokenRegressionResult struct {
+	BaselineSource         string  `json:"baseline_source"`
+	BaselineTokensPerTable float64 `json:"baseline_tokens_per_table"`
+	CurrentTokensPerTable  float64 `json:"current_tokens_per_table"`
+	ChangePct              float64 `json:"change_pct"` // Positive = more tokens than baseline
+	MaxRegressionPct       float64 `json:"max_regression_pct"`
+	Regressed              bool    `json:"regressed"`
+	Message                string  `json:"message"`
+}
+
+// =============================================================================
+// Baseline Loading
+// =============================================================================
+
+// loadTokenBaseline reads tokens_per_table from a previous assess-llm-responses JSON output.
+func loadTokenBaseline(path string) (float64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("read baseline: %w", err)
+	}
+
+	var baseline struct {
+		TokenMetrics *struct {
+			TokensPerTable *float64 `json:"tokens_per_table"`
+		} `json:"token_metrics"`
+	}
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return 0, fmt.Errorf("parse baseline: %w", err)
+	}
+	if baseline.TokenMetrics == nil || baseline.TokenMetrics.TokensPerTable == nil {
+		return 0, fmt.Errorf("baseline %s has no token_metrics.tokens_per_table", path)
+	}
+	return *baseline.TokenMetrics.TokensPerTable, nil
+}
+
+// =============================================================================
+// Regression Comparison
+// =============================================================================
+
+// compareTokensPerTable checks current tokens per table against the baseline.
+// A run regresses when tokens per table grew by MORE than maxRegressionPct.
+// A missing or zero baseline cannot be compared and never regresses.
+func compareTokensPerTable(baseline, current, maxRegressionPct float64) TokenRegressionResult {
+	result := TokenRegressionResult{
+		BaselineTokensPerTable: baseline,
+		CurrentTokensPerTable:  current,
+		MaxRegressionPct:       maxRegressionPct,
+	}
+
+	if baseline <= 0 {
+		result.Message = "Baseline has no tokens per table; skipping regression check"
+		return result
+	}
+
+	result.ChangePct = (current - baseline) / baseline * 100
+	result.Regressed = result.ChangePct > maxRegressionPct
+
+	switch {
+	case result.Regressed:
+		result.Message = fmt.Sprintf("Tokens per table regressed %.1f%% (%.1f -> %.1f), exceeding the %.1f%% budget",
+			result.ChangePct, baseline, current, maxRegressionPct)
+	case result.ChangePct > 0:
+		result.Message = fmt.Sprintf("Tokens per table grew %.1f%% (%.1f -> %.1f), within the %.1f%% budget",
+			result.ChangePct, baseline, current, maxRegressionPct)
+	default:
+		result.Message = fmt.Sprintf("Tokens per table improved %.1f%% (%.1f -> %.1f)",
+			-result.ChangePct, baseline, current)
+	}
+	return result
+}
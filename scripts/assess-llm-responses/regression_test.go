@@ -0,0 +1,69 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCompareTokensPerTable(t *testing.T) {
+	tests := []struct {
+		name          string
+		baseline      float64
+		current       float64
+		maxRegression float64
+		wantRegressed bool
+		wantChangePct float64
+	}{
+		{name: "improvement", baseline: 4000, current: 3000, maxRegression: 10, wantRegressed: false, wantChangePct: -25},
+		{name: "unchanged", baseline: 4000, current: 4000, maxRegression: 10, wantRegressed: false, wantChangePct: 0},
+		{name: "growth within budget", baseline: 4000, current: 4200, maxRegression: 10, wantRegressed: false, wantChangePct: 5},
+		{name: "growth exactly at budget", baseline: 4000, current: 4400, maxRegression: 10, wantRegressed: false, wantChangePct: 10},
+		{name: "growth beyond budget", baseline: 4000, current: 6000, maxRegression: 10, wantRegressed: true, wantChangePct: 50},
+		{name: "zero budget fails any growth", baseline: 4000, current: 4001, maxRegression: 0, wantRegressed: true, wantChangePct: 0.025},
+		{name: "zero baseline is skipped", baseline: 0, current: 6000, maxRegression: 10, wantRegressed: false, wantChangePct: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := compareTokensPerTable(tt.baseline, tt.current, tt.maxRegression)
+			if result.Regressed != tt.wantRegressed {
+				t.Errorf("Regressed = %v, want %v (%s)", result.Regressed, tt.wantRegressed, result.Message)
+			}
+			if diff := result.ChangePct - tt.wantChangePct; diff > 0.0001 || diff < -0.0001 {
+				t.Errorf("ChangePct = %f, want %f", result.ChangePct, tt.wantChangePct)
+			}
+			if result.Message == "" {
+				t.Error("expected a comparison message")
+			}
+		})
+	}
+}
+
+func TestLoadTokenBaseline(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "valid.json")
+	if err := os.WriteFile(valid, []byte(`{"final_score": 90, "token_metrics": {"tokens_per_table": 4123.5}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	got, err := loadTokenBaseline(valid)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != 4123.5 {
+		t.Errorf("tokens per table = %f, want 4123.5", got)
+	}
+
+	missing := filepath.Join(dir, "missing.json")
+	if err := os.WriteFile(missing, []byte(`{"final_score": 90}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadTokenBaseline(missing); err == nil {
+		t.Error("expected error for baseline without token metrics")
+	}
+
+	if _, err := loadTokenBaseline(filepath.Join(dir, "does-not-exist.json")); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
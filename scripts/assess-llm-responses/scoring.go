@@ -27,6 +27,10 @@ const (
 	ScoreErrorRateWeight = 10
 )
 
+// noSelectedTablesSummary replaces the score summary when the project has no selected
+// tables: extraction had nothing to analyze, so a perfect score would be misleading.
+const noSelectedTablesSummary = "No selected tables - nothing was extracted; select tables in the datasource schema and re-run extraction"
+
 // =============================================================================
 // Data Types for Phase 7: Aggregate Scoring
 // =============================================================================
@@ -73,12 +77,14 @@ type ConversationScore struct {
 // =============================================================================
 
 // calculateFinalScoring computes the final weighted score from all phase results.
+// A project with no selected tables scores 0 with noSelectedTablesSummary.
 func calculateFinalScoring(
 	structureSummary StructureCheckSummary,
 	hallucinationReport HallucinationReport,
 	valueSummary ValueValidationSummary,
 	tokenMetrics TokenMetrics,
 	tagged []TaggedConversation,
+	selectedTables int,
 ) ScoringResult {
 	result := ScoringResult{}
 
@@ -106,6 +112,12 @@ func calculateFinalScoring(
 		result.ChecksSummary.ErrorRate.Score,
 	)
 
+	if selectedTables == 0 {
+		result.FinalScore = 0
+		result.SmartSummary = noSelectedTablesSummary
+		return result
+	}
+
 	// 7.3 Generate smart summary
 	result.SmartSummary = generateSmartSummary(
 		result.FinalScore,
@@ -407,6 +419,9 @@ func buildHallucinationIssues(report HallucinationReport) []string {
 	if report.HallucinatedSources > 0 {
 		issues = append(issues, fmt.Sprintf("%d question(s) with invalid source", report.HallucinatedSources))
 	}
+	if report.HallucinatedSamples > 0 {
+		issues = append(issues, fmt.Sprintf("%d sample question reference(s) to missing tables/columns", report.HallucinatedSamples))
+	}
 
 	// Include specific examples (first 5)
 	issues = append(issues, report.Examples...)
@@ -430,6 +445,12 @@ func buildValueIssues(summary ValueValidationSummary) []string {
 	if summary.CategoryMissing > 0 {
 		issues = append(issues, fmt.Sprintf("%d responses with empty category", summary.CategoryMissing))
 	}
+	if summary.CategoryInvalid > 0 {
+		issues = append(issues, fmt.Sprintf("%d responses with categories outside the taxonomy", summary.CategoryInvalid))
+	}
+	if summary.AnalysisIssues > 0 {
+		issues = append(issues, fmt.Sprintf("%d responses with an empty or boilerplate analysis", summary.AnalysisIssues))
+	}
 
 	// Add stored question issues
 	if summary.InvalidPriorities > 0 {
@@ -438,6 +459,9 @@ func buildValueIssues(summary ValueValidationSummary) []string {
 	if summary.MissingCategories > 0 {
 		issues = append(issues, fmt.Sprintf("%d stored questions with empty category", summary.MissingCategories))
 	}
+	if summary.InvalidCategories > 0 {
+		issues = append(issues, fmt.Sprintf("%d stored questions with categories outside the taxonomy", summary.InvalidCategories))
+	}
 
 	return issues
 }
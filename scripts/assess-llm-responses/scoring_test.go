@@ -0,0 +1,21 @@
+package main
+
+import "testing"
+
+func TestCalculateFinalScoring_NoSelectedTables(t *testing.T) {
+	// Nothing was extracted, so every check passes vacuously
+	hallucinations := HallucinationReport{Score: 100}
+
+	result := calculateFinalScoring(StructureCheckSummary{}, hallucinations, ValueValidationSummary{}, TokenMetrics{}, nil, 3)
+	if result.FinalScore != 100 {
+		t.Fatalf("expected a vacuous 100 with selected tables, got %d", result.FinalScore)
+	}
+
+	result = calculateFinalScoring(StructureCheckSummary{}, hallucinations, ValueValidationSummary{}, TokenMetrics{}, nil, 0)
+	if result.FinalScore != 0 {
+		t.Errorf("expected score 0 with no selected tables, got %d", result.FinalScore)
+	}
+	if result.SmartSummary != noSelectedTablesSummary {
+		t.Errorf("expected the no selected tables summary, got %q", result.SmartSummary)
+	}
+}
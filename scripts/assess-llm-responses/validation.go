@@ -1,10 +1,13 @@
 // validation.go implements Phase 5: Value Validation
 // This phase validates field values beyond just type checking: non-empty strings,
-// priority ranges, boolean types, and category presence.
+// a substantive analysis, priority ranges, boolean types, and category presence and
+// taxonomy membership.
 package main
 
 import (
 	"fmt"
+	"strings"
+	"unicode"
 )
 
 // =============================================================================
@@ -24,6 +27,11 @@ type ValueValidationResult struct {
 	CategoryScore     int `json:"category_score"`      // 5 points max
 	TotalScore        int `json:"total_score"`         // 30 points max
 
+	MissingCategories int `json:"missing_categories,omitempty"` // Questions with an empty category
+	InvalidCategories int `json:"invalid_categories,omitempty"` // Questions with a category outside the taxonomy
+
+	WeakAnalysis bool `json:"weak_analysis,omitempty"` // analysis is empty, too short or boilerplate
+
 	// Issues found during validation
 	Issues []string `json:"issues"`
 }
@@ -41,12 +49,17 @@ type ValueValidationSummary struct {
 	PriorityIssues     int `json:"priority_issues"`     // Conversations with priority issues
 	BooleanTypeIssues  int `json:"boolean_type_issues"` // Conversations with boolean issues
 	CategoryMissing    int `json:"category_missing"`    // Conversations with missing categories
+	CategoryInvalid    int `json:"category_invalid"`    // Conversations with out-of-taxonomy categories
 	QuestionsPriority  int `json:"questions_priority"`  // Questions checked for priority
 	InvalidPriorities  int `json:"invalid_priorities"`  // Questions with invalid priority
 	QuestionsBoolean   int `json:"questions_boolean"`   // Questions checked for boolean
 	InvalidBooleans    int `json:"invalid_booleans"`    // Questions with invalid boolean type
 	QuestionCategories int `json:"question_categories"` // Questions checked for category
 	MissingCategories  int `json:"missing_categories"`  // Questions with missing category
+	InvalidCategories  int `json:"invalid_categories"`  // Questions with out-of-taxonomy category
+	AnalysisIssues     int `json:"analysis_issues"`     // Conversations with an empty or boilerplate analysis
+
+	AnalysisExamples []string `json:"analysis_examples"` // First few weak analyses, for the report
 }
 
 // =============================================================================
@@ -61,7 +74,8 @@ func checkAllValueValidation(
 	questions []OntologyQuestion,
 ) ValueValidationSummary {
 	summary := ValueValidationSummary{
-		Results: make([]ValueValidationResult, 0),
+		Results:          make([]ValueValidationResult, 0),
+		AnalysisExamples: []string{},
 	}
 
 	// Check conversations (entity_analysis responses have fields to validate)
@@ -94,15 +108,26 @@ func checkAllValueValidation(
 		if result.BooleanScore < 5 {
 			summary.BooleanTypeIssues++
 		}
-		if result.CategoryScore < 5 {
+		if result.MissingCategories > 0 {
 			summary.CategoryMissing++
 		}
+		if result.InvalidCategories > 0 {
+			summary.CategoryInvalid++
+		}
+		if result.WeakAnalysis {
+			summary.AnalysisIssues++
+			if len(summary.AnalysisExamples) < 5 {
+				analysis, _ := structureResults[i].ParsedResponse["analysis"].(string)
+				summary.AnalysisExamples = append(summary.AnalysisExamples,
+					fmt.Sprintf("entity_analysis '%s': %q", tc.TargetTable, truncateText(analysis, 80)))
+			}
+		}
 	}
 
 	// Also validate stored questions (priority, boolean, category checks)
 	questionPriorityScore, priorityIssues, priorityChecked, invalidPriorities := checkQuestionPriorities(questions)
 	questionBooleanScore, booleanIssues, booleanChecked, invalidBooleans := checkQuestionBooleans(questions)
-	questionCategoryScore, categoryIssues, categoryChecked, missingCategories := checkQuestionCategories(questions)
+	questionCategoryScore, categoryIssues, categoryChecked, missingCategories, invalidCategories := checkQuestionCategories(questions)
 
 	// Add question validation issues to summary
 	summary.QuestionsPriority = priorityChecked
@@ -111,6 +136,7 @@ func checkAllValueValidation(
 	summary.InvalidBooleans = invalidBooleans
 	summary.QuestionCategories = categoryChecked
 	summary.MissingCategories = missingCategories
+	summary.InvalidCategories = invalidCategories
 
 	// Create a synthetic result for stored questions if there are any
 	if len(questions) > 0 {
@@ -121,6 +147,8 @@ func checkAllValueValidation(
 			PriorityScore:     questionPriorityScore,
 			BooleanScore:      questionBooleanScore,
 			CategoryScore:     questionCategoryScore,
+			MissingCategories: missingCategories,
+			InvalidCategories: invalidCategories,
 			Issues:            append(append(priorityIssues, booleanIssues...), categoryIssues...),
 		}
 		questionResult.TotalScore = questionResult.StringFieldsScore + questionResult.PriorityScore +
@@ -150,8 +178,16 @@ func checkValueValidation(tc TaggedConversation, parsed map[string]interface{})
 		Issues:         []string{},
 	}
 
-	// 5.1 Required String Fields (10 points)
+	// 5.1 Required String Fields (10 points), including a substantive analysis
 	result.StringFieldsScore = checkRequiredStringFields(parsed, &result.Issues)
+	if reason := checkAnalysisField(parsed, tc.TargetTable); reason != "" {
+		result.Issues = append(result.Issues, "analysis is "+reason)
+		result.WeakAnalysis = true
+		result.StringFieldsScore -= analysisPenalty
+		if result.StringFieldsScore < 0 {
+			result.StringFieldsScore = 0
+		}
+	}
 
 	// 5.2 Priority Values (10 points) - check questions array in response
 	result.PriorityScore = checkResponsePriorities(parsed, &result.Issues)
@@ -160,7 +196,7 @@ func checkValueValidation(tc TaggedConversation, parsed map[string]interface{})
 	result.BooleanScore = checkResponseBooleans(parsed, &result.Issues)
 
 	// 5.4 Category Values (5 points) - check category in questions array
-	result.CategoryScore = checkResponseCategories(parsed, &result.Issues)
+	result.CategoryScore, result.MissingCategories, result.InvalidCategories = checkResponseCategories(parsed, &result.Issues)
 
 	result.TotalScore = result.StringFieldsScore + result.PriorityScore +
 		result.BooleanScore + result.CategoryScore
@@ -247,6 +283,72 @@ func checkRequiredStringFields(parsed map[string]interface{}, issues *[]string)
 	return score
 }
 
+// minAnalysisLength is the shortest analysis, in characters, treated as a real one.
+const minAnalysisLength = 40
+
+// minAnalysisContentWords is how many distinct words an analysis needs beyond
+// stopwords, generic table vocabulary and the table's own name. "This table contains
+// data about users." has none left; an analysis that explains anything has several.
+const minAnalysisContentWords = 4
+
+// analysisPenalty is taken from the string fields score for a weak analysis.
+const analysisPenalty = 3
+
+// genericAnalysisWords are stopwords plus the vocabulary boilerplate analyses are made of.
+var genericAnalysisWords = map[string]bool{
+	"a": true, "an": true, "the": true, "this": true, "that": true, "these": true, "it": true, "its": true,
+	"is": true, "are": true, "was": true, "be": true, "of": true, "in": true, "on": true, "for": true,
+	"to": true, "and": true, "or": true, "with": true, "about": true, "by": true, "as": true, "from": true,
+	"table": true, "tables": true, "entity": true, "entities": true, "data": true, "information": true,
+	"records": true, "record": true, "rows": true, "row": true, "columns": true, "column": true,
+	"contains": true, "contain": true, "stores": true, "store": true, "holds": true, "has": true,
+	"represents": true, "related": true, "various": true, "some": true, "analysis": true,
+	"n": true, "na": true, "none": true, "null": true, "tbd": true, "todo": true,
+}
+
+// checkAnalysisField reports why the free-form analysis of an entity_analysis response
+// is not useful, or "" when it is substantive. A missing field is left to the
+// structure checks.
+func checkAnalysisField(parsed map[string]interface{}, targetTable string) string {
+	raw, exists := parsed["analysis"]
+	if !exists {
+		return ""
+	}
+	analysis, ok := raw.(string)
+	if !ok {
+		return "not a valid string"
+	}
+	analysis = strings.TrimSpace(analysis)
+	if analysis == "" {
+		return "empty"
+	}
+
+	tableWords := make(map[string]bool)
+	for _, w := range analysisWords(targetTable) {
+		tableWords[w] = true
+	}
+	content := make(map[string]bool)
+	for _, w := range analysisWords(analysis) {
+		if !genericAnalysisWords[w] && !tableWords[w] {
+			content[w] = true
+		}
+	}
+	if len(content) < minAnalysisContentWords {
+		return "boilerplate"
+	}
+	if len(analysis) < minAnalysisLength {
+		return fmt.Sprintf("too short (%d chars, min %d)", len(analysis), minAnalysisLength)
+	}
+	return ""
+}
+
+// analysisWords lowercases text and splits it into words on anything but letters and digits.
+func analysisWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
 // =============================================================================
 // 5.2 Priority Values (10 points)
 // =============================================================================
@@ -389,17 +491,53 @@ func checkQuestionBooleans(questions []OntologyQuestion) (int, []string, int, in
 // 5.4 Category Values (5 points)
 // =============================================================================
 
-// checkResponseCategories validates category field is non-empty when present in questions.
-// Penalty: -1 per missing/empty category (max -5)
-func checkResponseCategories(parsed map[string]interface{}, issues *[]string) int {
+// defaultQuestionCategories is the engine's built-in question category taxonomy.
+var defaultQuestionCategories = []string{
+	"business_rules", "relationship", "terminology", "enumeration", "temporal", "data_quality",
+}
+
+// questionCategories is the taxonomy categories are checked against; replaced in main
+// when -question-categories is set to match an engine configured with its own.
+var questionCategories = defaultQuestionCategories
+
+// splitCategoryList parses a comma-separated category list, falling back to the
+// built-in taxonomy when it names none.
+func splitCategoryList(list string) []string {
+	var categories []string
+	for _, c := range strings.Split(list, ",") {
+		if c = strings.TrimSpace(c); c != "" {
+			categories = append(categories, c)
+		}
+	}
+	if len(categories) == 0 {
+		return defaultQuestionCategories
+	}
+	return categories
+}
+
+func isKnownQuestionCategory(category string) bool {
+	for _, c := range questionCategories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// checkResponseCategories validates category field is non-empty and in the taxonomy
+// when present in questions.
+// Penalty: -1 per missing/empty or out-of-taxonomy category (max -5)
+// Returns the score and the missing and invalid counts.
+func checkResponseCategories(parsed map[string]interface{}, issues *[]string) (int, int, int) {
 	score := 5
 
 	questions, ok := parsed["questions"].([]interface{})
 	if !ok {
-		return score
+		return score, 0, 0
 	}
 
 	missingCount := 0
+	invalidCount := 0
 	for i, q := range questions {
 		qMap, ok := q.(map[string]interface{})
 		if !ok {
@@ -412,6 +550,9 @@ func checkResponseCategories(parsed map[string]interface{}, issues *[]string) in
 				if categoryStr == "" {
 					*issues = append(*issues, fmt.Sprintf("questions[%d].category is empty", i))
 					missingCount++
+				} else if !isKnownQuestionCategory(categoryStr) {
+					*issues = append(*issues, fmt.Sprintf("questions[%d].category %q is not in the taxonomy", i, categoryStr))
+					invalidCount++
 				}
 			} else if category == nil {
 				*issues = append(*issues, fmt.Sprintf("questions[%d].category is null", i))
@@ -421,7 +562,7 @@ func checkResponseCategories(parsed map[string]interface{}, issues *[]string) in
 		// Note: missing category field is not penalized - may be optional
 	}
 
-	penalty := missingCount
+	penalty := missingCount + invalidCount
 	if penalty > 5 {
 		penalty = 5
 	}
@@ -430,16 +571,18 @@ func checkResponseCategories(parsed map[string]interface{}, issues *[]string) in
 	if score < 0 {
 		score = 0
 	}
-	return score
+	return score, missingCount, invalidCount
 }
 
-// checkQuestionCategories validates stored questions have non-empty category when present.
-// Returns score (0-5), issues list, count checked, and missing count.
-func checkQuestionCategories(questions []OntologyQuestion) (int, []string, int, int) {
+// checkQuestionCategories validates stored questions have a non-empty category in the
+// taxonomy when present.
+// Returns score (0-5), issues list, count checked, missing count and invalid count.
+func checkQuestionCategories(questions []OntologyQuestion) (int, []string, int, int, int) {
 	score := 5
 	var issues []string
 	checkedCount := 0
 	missingCount := 0
+	invalidCount := 0
 
 	for _, q := range questions {
 		// Category is a pointer - nil means not present (allowed)
@@ -453,10 +596,14 @@ func checkQuestionCategories(questions []OntologyQuestion) (int, []string, int,
 			issues = append(issues, fmt.Sprintf("stored question '%s': category is empty",
 				truncateText(q.Text, 40)))
 			missingCount++
+		} else if !isKnownQuestionCategory(*q.Category) {
+			issues = append(issues, fmt.Sprintf("stored question '%s': category %q is not in the taxonomy",
+				truncateText(q.Text, 40), *q.Category))
+			invalidCount++
 		}
 	}
 
-	penalty := missingCount
+	penalty := missingCount + invalidCount
 	if penalty > 5 {
 		penalty = 5
 	}
@@ -465,7 +612,7 @@ func checkQuestionCategories(questions []OntologyQuestion) (int, []string, int,
 	if score < 0 {
 		score = 0
 	}
-	return score, issues, checkedCount, missingCount
+	return score, issues, checkedCount, missingCount, invalidCount
 }
 
 // =============================================================================
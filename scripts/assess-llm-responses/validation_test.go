@@ -0,0 +1,111 @@
+package main
+
+import "testing"
+
+func TestCheckResponseCategories_CountsInvalidCategory(t *testing.T) {
+	parsed := map[string]interface{}{
+		"questions": []interface{}{
+			map[string]interface{}{"category": "enumeration"},
+			map[string]interface{}{"category": "enum_meaning"},
+			map[string]interface{}{"category": ""},
+		},
+	}
+
+	var issues []string
+	score, missing, invalid := checkResponseCategories(parsed, &issues)
+
+	if missing != 1 || invalid != 1 {
+		t.Fatalf("expected 1 missing and 1 invalid category, got %d and %d", missing, invalid)
+	}
+	if score != 3 {
+		t.Errorf("expected score 3, got %d", score)
+	}
+	if len(issues) != 2 || issues[0] != `questions[1].category "enum_meaning" is not in the taxonomy` {
+		t.Errorf("unexpected issues: %v", issues)
+	}
+}
+
+func TestCheckQuestionCategories_UsesConfiguredTaxonomy(t *testing.T) {
+	defer func(saved []string) { questionCategories = saved }(questionCategories)
+	questionCategories = splitCategoryList("business_rules, compliance")
+
+	compliance, enumeration := "compliance", "enumeration"
+	questions := []OntologyQuestion{
+		{Text: "Which records fall under GDPR?", Category: &compliance},
+		{Text: "What does status 3 mean?", Category: &enumeration},
+		{Text: "Uncategorized question"},
+	}
+
+	score, issues, checked, missing, invalid := checkQuestionCategories(questions)
+
+	if checked != 2 || missing != 0 || invalid != 1 {
+		t.Fatalf("expected 2 checked, 0 missing, 1 invalid; got %d, %d, %d", checked, missing, invalid)
+	}
+	if score != 4 || len(issues) != 1 {
+		t.Errorf("expected score 4 with 1 issue, got %d with %v", score, issues)
+	}
+}
+
+func TestSplitCategoryList_EmptyUsesDefault(t *testing.T) {
+	got := splitCategoryList(" , ")
+	if len(got) != len(defaultQuestionCategories) {
+		t.Errorf("expected the default taxonomy, got %v", got)
+	}
+}
+
+func TestCheckAnalysisField_SubstantiveVersusBoilerplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		analysis interface{}
+		want     string
+	}{
+		{
+			name: "substantive",
+			analysis: "Each row is a customer order; status moves from pending to shipped, " +
+				"total_amount is stored in cents and user_id links the buyer.",
+			want: "",
+		},
+		{name: "empty", analysis: "   ", want: "empty"},
+		{name: "boilerplate", analysis: "This table contains data about orders.", want: "boilerplate"},
+		{name: "placeholder", analysis: "N/A", want: "boilerplate"},
+		{name: "short", analysis: "Refund ledger per merchant day.", want: "too short (31 chars, min 40)"},
+		{name: "not a string", analysis: 42.0, want: "not a valid string"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed := map[string]interface{}{"analysis": tt.analysis}
+			if got := checkAnalysisField(parsed, "public.orders"); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+
+	if got := checkAnalysisField(map[string]interface{}{}, "orders"); got != "" {
+		t.Errorf("missing analysis is left to the structure checks, got %q", got)
+	}
+}
+
+func TestCheckValueValidation_WeakAnalysisCostsStringScore(t *testing.T) {
+	tc := TaggedConversation{PromptType: PromptTypeEntityAnalysis, TargetTable: "orders"}
+	parsed := map[string]interface{}{
+		"analysis": "This table stores order records.",
+		"entity_summary": map[string]interface{}{
+			"business_name": "Orders",
+			"description":   "Customer orders placed in the web shop.",
+			"domain":        "sales",
+		},
+	}
+
+	result := checkValueValidation(tc, parsed)
+
+	if !result.WeakAnalysis {
+		t.Fatal("expected the analysis to be flagged")
+	}
+	if result.StringFieldsScore != 10-analysisPenalty {
+		t.Errorf("expected string score %d, got %d", 10-analysisPenalty, result.StringFieldsScore)
+	}
+	if len(result.Issues) != 1 || result.Issues[0] != "analysis is boilerplate" {
+		t.Errorf("unexpected issues: %v", result.Issues)
+	}
+}
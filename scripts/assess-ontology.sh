@@ -15,13 +15,19 @@
 #   - Ambiguous entity descriptions
 #   - Undocumented enumeration values
 #
-# Usage: ./scripts/assess-ontology.sh <project-id>
+# Usage: ./scripts/assess-ontology.sh [-v | -quiet] [-cache-dir <dir> [-refresh]] [-concurrency <n>] <project-id> [<project-id>...]
+#
+# Pass -cache-dir to reuse judge results for unchanged prompts across runs,
+# and -refresh to ignore the cache for one run.
+#
+# Pass several project IDs to assess them in one batch; -concurrency sets how
+# many run in parallel, each on its own database connection.
 #
 # Requires:
 #   - ANTHROPIC_API_KEY environment variable
 #   - PG* environment variables for database connection
 #
-# Output: JSON assessment with final score 0-100
+# Output: JSON assessment with final score 0-100 (a batch result for several projects)
 
 set -e
 
@@ -29,7 +35,7 @@ SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
 PROJECT_ROOT="$(dirname "$SCRIPT_DIR")"
 
 if [ -z "$1" ]; then
-    echo "Usage: $0 <project-id>" >&2
+    echo "Usage: $0 [-v | -quiet] [-cache-dir <dir> [-refresh]] [-concurrency <n>] <project-id> [<project-id>...]" >&2
     echo "" >&2
     echo "Example: $0 f2324998-64c0-46e7-98d1-8a778be462f2" >&2
     exit 1
@@ -41,4 +47,4 @@ if [ -z "$ANTHROPIC_API_KEY" ]; then
 fi
 
 cd "$PROJECT_ROOT"
-go run ./scripts/assess-ontology "$1"
+go run ./scripts/assess-ontology "$@"
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// dbQuerier is the subset of a database connection the loaders use. *pgx.Conn and
+// *pgxpool.Conn satisfy it; tests substitute a stub.
+type dbQuerier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// acquireFunc hands a worker a connection of its own, and the function that returns it.
+type acquireFunc func(ctx context.Context) (dbQuerier, func(), error)
+
+// assessFunc assesses one project over the given connection.
+type assessFunc func(ctx context.Context, db dbQuerier, projectID uuid.UUID) (*AssessmentResult, error)
+
+// BatchAssessmentResult is the output of batch mode. Judge usage is summed from
+// the per-project results, so the totals do not depend on scheduling.
+type BatchAssessmentResult struct {
+	CommitInfo        string             `json:"commit_info"`
+	JudgeModel        string             `json:"judge_model"`
+	Concurrency       int                `json:"concurrency"`
+	Projects          []AssessmentResult `json:"projects"` // In the order given on the command line
+	Failures          []BatchFailure     `json:"failures"`
+	LLMJudgeCalls     int                `json:"llm_judge_calls"`
+	LLMJudgeCacheHits int                `json:"llm_judge_cache_hits"`
+	LLMJudgeTokens    int                `json:"llm_judge_tokens"`
+}
+
+// BatchFailure records a project whose assessment could not complete.
+type BatchFailure struct {
+	ProjectID string `json:"project_id"`
+	Error     string `json:"error"`
+}
+
+// runBatch assesses projectIDs with up to concurrency workers. Each project runs on
+// its own acquired connection, which is released as soon as the project finishes.
+// A failing project is recorded and does not stop the others.
+func runBatch(ctx context.Context, projectIDs []uuid.UUID, concurrency int, acquire acquireFunc, assess assessFunc) *BatchAssessmentResult {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := make([]*AssessmentResult, len(projectIDs))
+	errs := make([]error, len(projectIDs))
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, projectID := range projectIDs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			logger.Progressf("Assessing project %s (%d/%d)...\n", projectID, i+1, len(projectIDs))
+			db, release, err := acquire(ctx)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			defer release()
+			results[i], errs[i] = assess(ctx, db, projectID)
+		}()
+	}
+	wg.Wait()
+
+	batch := &BatchAssessmentResult{
+		JudgeModel:  JudgeModel,
+		Concurrency: concurrency,
+		Projects:    []AssessmentResult{},
+		Failures:    []BatchFailure{},
+	}
+	for i, projectID := range projectIDs {
+		if errs[i] != nil {
+			batch.Failures = append(batch.Failures, BatchFailure{ProjectID: projectID.String(), Error: errs[i].Error()})
+			continue
+		}
+		r := results[i]
+		batch.Projects = append(batch.Projects, *r)
+		batch.LLMJudgeCalls += r.LLMJudgeCalls
+		batch.LLMJudgeCacheHits += r.LLMJudgeCacheHits
+		batch.LLMJudgeTokens += r.LLMJudgeTokens
+	}
+	return batch
+}
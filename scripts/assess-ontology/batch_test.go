@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// stubDB serves an empty project: a datasource name, an empty domain summary and no
+// rows for every other query. It fails any query issued while another is in flight on
+// the same connection, and waits at the datasource lookup until every worker has
+// arrived so the test can prove projects really ran in parallel.
+type stubDB struct {
+	name    string
+	busy    sync.Mutex
+	arrived *sync.WaitGroup
+	misuse  chan<- string
+}
+
+// enter marks the connection busy for the duration of a query; the returned func
+// ends the query.
+func (db *stubDB) enter(sql string) func() {
+	if !db.busy.TryLock() {
+		db.misuse <- fmt.Sprintf("%s used concurrently for %q", db.name, strings.TrimSpace(sql))
+		return func() {}
+	}
+	return db.busy.Unlock
+}
+
+func (db *stubDB) Query(_ context.Context, sql string, _ ...any) (pgx.Rows, error) {
+	defer db.enter(sql)()
+	return &emptyRows{}, nil
+}
+
+func (db *stubDB) QueryRow(_ context.Context, sql string, _ ...any) pgx.Row {
+	defer db.enter(sql)()
+	if strings.Contains(sql, "engine_datasources") {
+		db.arrived.Done()
+		waitCh := make(chan struct{})
+		go func() { db.arrived.Wait(); close(waitCh) }()
+		select {
+		case <-waitCh:
+		case <-time.After(5 * time.Second):
+			return stubRow{err: errors.New("timed out waiting for the other project to start")}
+		}
+		return stubRow{values: []any{db.name}}
+	}
+	return stubRow{values: []any{json.RawMessage(`{}`), json.RawMessage(`{}`)}}
+}
+
+type stubRow struct {
+	values []any
+	err    error
+}
+
+func (r stubRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	for i, d := range dest {
+		switch d := d.(type) {
+		case *string:
+			*d = r.values[i].(string)
+		case *json.RawMessage:
+			*d = r.values[i].(json.RawMessage)
+		}
+	}
+	return nil
+}
+
+type emptyRows struct{}
+
+func (emptyRows) Close()                                       {}
+func (emptyRows) Err() error                                   { return nil }
+func (emptyRows) CommandTag() pgconn.CommandTag                { return pgconn.CommandTag{} }
+func (emptyRows) FieldDescriptions() []pgconn.FieldDescription { return nil }
+func (emptyRows) Next() bool                                   { return false }
+func (emptyRows) Scan(...any) error                            { return nil }
+func (emptyRows) Values() ([]any, error)                       { return nil, nil }
+func (emptyRows) RawValues() [][]byte                          { return nil }
+func (emptyRows) Conn() *pgx.Conn                              { return nil }
+
+func TestRunBatch_AssessesProjectsConcurrentlyWithOwnConnections(t *testing.T) {
+	projectIDs := []uuid.UUID{uuid.New(), uuid.New()}
+	misuse := make(chan string, 16)
+	var arrived sync.WaitGroup
+	arrived.Add(len(projectIDs))
+
+	var mu sync.Mutex
+	acquired := 0
+	acquire := func(context.Context) (dbQuerier, func(), error) {
+		mu.Lock()
+		defer mu.Unlock()
+		acquired++
+		return &stubDB{name: fmt.Sprintf("conn-%d", acquired), arrived: &arrived, misuse: misuse}, func() {}, nil
+	}
+
+	// With no pending questions the questions-impact prompt is skipped, leaving three
+	// judge calls of 120 tokens per project.
+	client := &countingClient{}
+	assess := func(ctx context.Context, db dbQuerier, projectID uuid.UUID) (*AssessmentResult, error) {
+		return assessProject(ctx, db, newJudge(client, nil), projectID, "test", defaultScoreWeights)
+	}
+
+	batch := runBatch(context.Background(), projectIDs, 2, acquire, assess)
+	close(misuse)
+	for m := range misuse {
+		t.Error(m)
+	}
+
+	if len(batch.Failures) != 0 {
+		t.Fatalf("unexpected failures: %+v", batch.Failures)
+	}
+	if len(batch.Projects) != 2 {
+		t.Fatalf("expected 2 project results, got %d", len(batch.Projects))
+	}
+	for i, p := range batch.Projects {
+		if p.ProjectID != projectIDs[i].String() {
+			t.Errorf("result %d is for project %s, want %s", i, p.ProjectID, projectIDs[i])
+		}
+		if p.LLMJudgeCalls != 3 || p.LLMJudgeTokens != 360 {
+			t.Errorf("project %s usage = (%d calls, %d tokens), want (3, 360)", p.ProjectID, p.LLMJudgeCalls, p.LLMJudgeTokens)
+		}
+	}
+	if batch.Projects[0].DatasourceName == batch.Projects[1].DatasourceName {
+		t.Errorf("both projects ran on %s; expected a connection each", batch.Projects[0].DatasourceName)
+	}
+	if batch.LLMJudgeCalls != 6 || batch.LLMJudgeTokens != 720 {
+		t.Errorf("aggregate usage = (%d calls, %d tokens), want (6, 720)", batch.LLMJudgeCalls, batch.LLMJudgeTokens)
+	}
+	if client.calls != batch.LLMJudgeCalls {
+		t.Errorf("client saw %d calls but batch reported %d", client.calls, batch.LLMJudgeCalls)
+	}
+}
+
+func TestRunBatch_RecordsFailuresWithoutStoppingOthers(t *testing.T) {
+	projectIDs := []uuid.UUID{uuid.New(), uuid.New()}
+	acquire := func(context.Context) (dbQuerier, func(), error) { return nil, func() {}, nil }
+	assess := func(_ context.Context, _ dbQuerier, projectID uuid.UUID) (*AssessmentResult, error) {
+		if projectID == projectIDs[0] {
+			return nil, errors.New("failed to load schema: boom")
+		}
+		return &AssessmentResult{ProjectID: projectID.String(), LLMJudgeCalls: 4, LLMJudgeTokens: 480}, nil
+	}
+
+	batch := runBatch(context.Background(), projectIDs, 2, acquire, assess)
+
+	if len(batch.Failures) != 1 || batch.Failures[0].ProjectID != projectIDs[0].String() {
+		t.Fatalf("expected a failure for %s, got %+v", projectIDs[0], batch.Failures)
+	}
+	if len(batch.Projects) != 1 || batch.LLMJudgeCalls != 4 {
+		t.Errorf("expected the other project's result and usage, got %d results and %d calls", len(batch.Projects), batch.LLMJudgeCalls)
+	}
+}
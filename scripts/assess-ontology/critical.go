@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// criticalMarker tags business-critical tables in judge prompts.
+const criticalMarker = " [BUSINESS-CRITICAL]"
+
+// criticalGuidance tells the judge how to treat tables tagged with criticalMarker.
+const criticalGuidance = `
+Tables marked [BUSINESS-CRITICAL] are the ones analysts rely on most. A gap in one of them
+should lower the score more than the same gap in any other table.
+`
+
+// loadCriticalTables loads the project's business-critical table names from its
+// ontology settings. Returns nil when none are set.
+func loadCriticalTables(ctx context.Context, conn dbQuerier, projectID uuid.UUID) ([]string, error) {
+	var raw []byte
+	if err := conn.QueryRow(ctx, `
+		SELECT parameters->'ontology'->'critical_tables' FROM engine_projects WHERE id = $1
+	`, projectID).Scan(&raw); err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		return nil, nil
+	}
+
+	var tables []string
+	if err := json.Unmarshal(raw, &tables); err != nil {
+		return nil, fmt.Errorf("parse critical tables: %w", err)
+	}
+	return tables, nil
+}
+
+// prioritizeCriticalTables marks the schema tables named in critical, matched as
+// "table" or "schema.table" ignoring case, and moves them to the front so judges see
+// them first. The order within each group is kept.
+func prioritizeCriticalTables(schema []SchemaTable, critical []string) {
+	if len(critical) == 0 {
+		return
+	}
+	names := make(map[string]bool, len(critical))
+	for _, name := range critical {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if i := strings.LastIndex(name, "."); i >= 0 {
+			name = name[i+1:]
+		}
+		names[name] = true
+	}
+	for i := range schema {
+		schema[i].IsCritical = names[strings.ToLower(schema[i].TableName)]
+	}
+	sort.SliceStable(schema, func(i, j int) bool {
+		return schema[i].IsCritical && !schema[j].IsCritical
+	})
+}
+
+// hasCriticalTables reports whether any schema table is business-critical.
+func hasCriticalTables(schema []SchemaTable) bool {
+	for _, t := range schema {
+		if t.IsCritical {
+			return true
+		}
+	}
+	return false
+}
+
+// criticalSuffix returns criticalMarker for a business-critical table, otherwise "".
+func criticalSuffix(t SchemaTable) string {
+	if t.IsCritical {
+		return criticalMarker
+	}
+	return ""
+}
+
+// criticalPromptGuidance returns criticalGuidance when the schema has business-critical
+// tables, otherwise "", so prompts for projects without any are unchanged.
+func criticalPromptGuidance(schema []SchemaTable) string {
+	if hasCriticalTables(schema) {
+		return criticalGuidance
+	}
+	return ""
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPrioritizeCriticalTables_MarksAndMovesFirst(t *testing.T) {
+	schema := []SchemaTable{{TableName: "audit_log"}, {TableName: "customers"}, {TableName: "Orders"}, {TableName: "settings"}}
+
+	prioritizeCriticalTables(schema, []string{"public.orders", "customers"})
+
+	var order []string
+	for _, tbl := range schema {
+		order = append(order, tbl.TableName)
+	}
+	if want := []string{"customers", "Orders", "audit_log", "settings"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("order = %v, want %v", order, want)
+	}
+	if !schema[0].IsCritical || !schema[1].IsCritical || schema[2].IsCritical {
+		t.Errorf("unexpected critical flags: %+v", schema)
+	}
+	if criticalPromptGuidance(schema) == "" {
+		t.Error("expected guidance when critical tables are set")
+	}
+}
+
+func TestPrioritizeCriticalTables_NoneLeavesPromptsUnchanged(t *testing.T) {
+	schema := []SchemaTable{{TableName: "orders"}, {TableName: "customers"}}
+
+	prioritizeCriticalTables(schema, nil)
+
+	if schema[0].TableName != "orders" || hasCriticalTables(schema) {
+		t.Errorf("expected schema untouched, got %+v", schema)
+	}
+	if criticalPromptGuidance(schema) != "" || criticalSuffix(schema[0]) != "" {
+		t.Error("expected no prompt changes without critical tables")
+	}
+}
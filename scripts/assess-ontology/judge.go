@@ -0,0 +1,220 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ekaya-inc/ekaya-engine/scripts/internal/assessjudge"
+)
+
+// JudgeModel is the model used for all assessment calls.
+const JudgeModel = "claude-sonnet-4-5-20250929"
+
+// errUnparseableResponse marks judge responses that could not be decoded into the
+// expected result. Callers distinguish it from API failures when reporting.
+var errUnparseableResponse = errors.New("unparseable judge response")
+
+// errNotCached is returned by a cache-only judge for a prompt with no cached result.
+var errNotCached = errors.New("judge result not cached")
+
+// judge sends assessment prompts to the LLM through an assessjudge.Judge, consulting
+// an optional on-disk cache first. It is safe for concurrent use.
+type judge struct {
+	llm   assessjudge.Judge // nil for a cache-only judge
+	cache *judgeCache       // nil disables caching
+
+	mu          sync.Mutex
+	calls       int
+	cacheHits   int
+	cacheMisses int
+	tokens      int
+}
+
+func newJudge(llm assessjudge.Judge, cache *judgeCache) *judge {
+	return &judge{llm: llm, cache: cache}
+}
+
+// newCacheOnlyJudge returns a judge that answers only from cache and never calls
+// the API. Prompts without a cached result fail with errNotCached and are counted
+// by misses, so a rescore can refuse to report scores built on fallback values.
+func newCacheOnlyJudge(cache *judgeCache) *judge {
+	return &judge{cache: cache}
+}
+
+// usage returns the number of API calls, cache hits and API tokens so far.
+// Cached results contribute no tokens, so re-runs aren't double-counted.
+func (j *judge) usage() (calls, cacheHits, tokens int) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.calls, j.cacheHits, j.tokens
+}
+
+func (j *judge) recordCall(usage assessjudge.Usage) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.calls++
+	j.tokens += usage.Total()
+}
+
+func (j *judge) recordCacheHit() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.cacheHits++
+}
+
+// misses returns the number of prompts a cache-only judge could not answer.
+func (j *judge) misses() int {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.cacheMisses
+}
+
+func (j *judge) recordCacheMiss() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.cacheMisses++
+}
+
+// runJudge sends prompt to the judge model and decodes the JSON in its response
+// into result. A cached result for the same model and prompt is returned without
+// calling the API. Only successfully decoded results are cached. A cache-only
+// judge returns errNotCached instead of calling the API.
+func runJudge(ctx context.Context, j *judge, prompt string, maxTokens int, result any) error {
+	key := judgeCacheKey(JudgeModel, prompt)
+	if j.cache != nil {
+		hit, err := j.cache.load(key, result)
+		if err != nil {
+			logger.Progressf("  Ignoring unreadable judge cache entry %s: %v\n", key, err)
+		} else if hit {
+			j.recordCacheHit()
+			return nil
+		}
+	}
+	if j.llm == nil {
+		j.recordCacheMiss()
+		return fmt.Errorf("%w: %s", errNotCached, key)
+	}
+
+	text, usage, err := j.llm.Assess(ctx, prompt, maxTokens)
+	if err != nil {
+		return err
+	}
+	j.recordCall(usage)
+
+	responseText := extractJSON(text)
+	if err := json.Unmarshal([]byte(responseText), result); err != nil {
+		return fmt.Errorf("%w: %v", errUnparseableResponse, err)
+	}
+
+	if j.cache != nil {
+		if err := j.cache.store(key, result); err != nil {
+			logger.Progressf("  Failed to write judge cache entry %s: %v\n", key, err)
+		}
+	}
+	return nil
+}
+
+// judgeCacheKey hashes the model and prompt. Any change to the ontology changes
+// the prompt and therefore the key, so stale entries are never read.
+func judgeCacheKey(model, prompt string) string {
+	h := sha256.New()
+	h.Write([]byte(model))
+	h.Write([]byte{0})
+	h.Write([]byte(prompt))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// judgeCache stores parsed judge results as one JSON file per key.
+type judgeCache struct {
+	dir string
+	// refresh skips reads so every prompt is re-judged; results are still written.
+	refresh bool
+}
+
+// cachedJudgeResult is the on-disk form of a cache entry.
+type cachedJudgeResult struct {
+	CreatedAt time.Time       `json:"created_at"`
+	Result    json.RawMessage `json:"result"`
+}
+
+func newJudgeCache(dir string, refresh bool) (*judgeCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create judge cache dir: %w", err)
+	}
+	return &judgeCache{dir: dir, refresh: refresh}, nil
+}
+
+func (c *judgeCache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+// load decodes the cached result for key into result. It reports false when
+// there is no entry or refresh is set.
+func (c *judgeCache) load(key string, result any) (bool, error) {
+	if c.refresh {
+		return false, nil
+	}
+	data, err := os.ReadFile(c.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	var entry cachedJudgeResult
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal(entry.Result, result); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// store writes result for key. The entry is written to a temp file and renamed
+// into place so concurrent runs never observe a partial entry.
+func (c *judgeCache) store(key string, result any) error {
+	raw, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(cachedJudgeResult{CreatedAt: time.Now().UTC(), Result: raw})
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(c.dir, key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path(key))
+}
+
+func extractJSON(s string) string {
+	// Find JSON object in response
+	start := strings.Index(s, "{")
+	end := strings.LastIndex(s, "}")
+	if start >= 0 && end > start {
+		return s[start : end+1]
+	}
+	return s
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ekaya-inc/ekaya-engine/scripts/internal/assessjudge"
+)
+
+// countingClient answers every prompt with a canned response chosen by a marker in
+// the prompt, and counts API calls.
+type countingClient struct {
+	mu    sync.Mutex
+	calls int
+}
+
+func (c *countingClient) Assess(_ context.Context, prompt string, _ int) (string, assessjudge.Usage, error) {
+	c.mu.Lock()
+	c.calls++
+	c.mu.Unlock()
+
+	var text string
+	switch {
+	case strings.Contains(prompt, "unanswered questions"):
+		text = `{"critical_gaps": ["status meaning"], "affected_queries": [], "enabled_with_answers": [], "impact_score": 30}`
+	case strings.Contains(prompt, "relationship coverage"):
+		text = `{"orphan_tables": [], "missing_relations": [], "coverage_score": 80}`
+	case strings.Contains(prompt, "documentation completeness"):
+		text = `{"well_documented": 2, "completeness_score": 75}`
+	default:
+		text = `Here you go: {"confidence_level": "medium", "confidence_score": 70}`
+	}
+	return text, assessjudge.Usage{InputTokens: 100, OutputTokens: 20}, nil
+}
+
+type assessmentRun struct {
+	Pending      PendingQuestionsImpact
+	Coverage     RelationshipCoverage
+	Completeness EntityCompletenessAssess
+	Readiness    SQLReadinessAssessment
+}
+
+func runAllAssessments(j *judge) assessmentRun {
+	ctx := context.Background()
+	schema := []SchemaTable{{TableName: "orders", Columns: []SchemaColumn{{ColumnName: "status", DataType: "text"}}}}
+	ontology := &Ontology{DomainSummary: json.RawMessage(`{"description": "shop"}`), EntitySummaries: json.RawMessage(`{}`)}
+	questions := []OntologyQuestion{{Text: "What does status=3 mean?", IsRequired: true, Status: "pending"}}
+
+	return assessmentRun{
+		Pending:      assessPendingQuestionsImpact(ctx, j, questions, schema, ontology),
+		Coverage:     assessRelationshipCoverage(ctx, j, schema, nil, ontology),
+		Completeness: assessEntityCompleteness(ctx, j, schema, ontology, questions),
+		Readiness:    assessSQLReadiness(ctx, j, schema, ontology, questions, nil),
+	}
+}
+
+func TestJudgeCache_SecondRunMakesNoAPICalls(t *testing.T) {
+	dir := t.TempDir()
+
+	firstClient := &countingClient{}
+	cache, err := newJudgeCache(dir, false)
+	if err != nil {
+		t.Fatalf("newJudgeCache: %v", err)
+	}
+	first := newJudge(firstClient, cache)
+	firstRun := runAllAssessments(first)
+	if firstClient.calls != 4 {
+		t.Fatalf("expected 4 API calls on first run, got %d", firstClient.calls)
+	}
+	if calls, hits, tokens := first.usage(); calls != 4 || hits != 0 || tokens != 480 {
+		t.Errorf("first run usage = (%d calls, %d hits, %d tokens), want (4, 0, 480)", calls, hits, tokens)
+	}
+
+	secondClient := &countingClient{}
+	second := newJudge(secondClient, cache)
+	secondRun := runAllAssessments(second)
+	if secondClient.calls != 0 {
+		t.Errorf("expected no API calls with a warm cache, got %d", secondClient.calls)
+	}
+	if calls, hits, tokens := second.usage(); calls != 0 || hits != 4 || tokens != 0 {
+		t.Errorf("second run usage = (%d calls, %d hits, %d tokens), want (0, 4, 0)", calls, hits, tokens)
+	}
+	if !reflect.DeepEqual(firstRun, secondRun) {
+		t.Errorf("cached results differ from original:\nfirst:  %+v\nsecond: %+v", firstRun, secondRun)
+	}
+	if secondRun.Readiness.ConfidenceScore != 70 {
+		t.Errorf("expected cached confidence score 70, got %d", secondRun.Readiness.ConfidenceScore)
+	}
+}
+
+func TestJudgeCache_RefreshBypassesCache(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := newJudgeCache(dir, false)
+	if err != nil {
+		t.Fatalf("newJudgeCache: %v", err)
+	}
+	runAllAssessments(newJudge(&countingClient{}, cache))
+
+	refreshClient := &countingClient{}
+	refreshing, err := newJudgeCache(dir, true)
+	if err != nil {
+		t.Fatalf("newJudgeCache: %v", err)
+	}
+	runAllAssessments(newJudge(refreshClient, refreshing))
+	if refreshClient.calls != 4 {
+		t.Errorf("expected -refresh to re-judge all 4 prompts, got %d calls", refreshClient.calls)
+	}
+}
+
+func TestJudgeCache_DoesNotCacheFailures(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := newJudgeCache(dir, false)
+	if err != nil {
+		t.Fatalf("newJudgeCache: %v", err)
+	}
+
+	j := newJudge(&failingClient{}, cache)
+	var result SQLReadinessAssessment
+	if err := runJudge(context.Background(), j, "prompt", 100, &result); err == nil {
+		t.Fatal("expected error from failing client")
+	}
+	if err := runJudge(context.Background(), newJudge(&garbageClient{}, cache), "prompt", 100, &result); !errors.Is(err, errUnparseableResponse) {
+		t.Fatalf("expected errUnparseableResponse, got %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("expected no cache entries after failed calls, found %d (%s)", len(entries), filepath.Join(dir, entries[0].Name()))
+	}
+}
+
+type failingClient struct{}
+
+func (failingClient) Assess(context.Context, string, int) (string, assessjudge.Usage, error) {
+	return "", assessjudge.Usage{}, errors.New("rate limited")
+}
+
+type garbageClient struct{}
+
+func (garbageClient) Assess(context.Context, string, int) (string, assessjudge.Usage, error) {
+	return "not json", assessjudge.Usage{}, nil
+}
@@ -13,24 +13,46 @@
 //   - Missing relationships (unclear how tables connect)
 //   - Ambiguous entity descriptions (LLM might misinterpret)
 //   - Undocumented enumeration values (status/type columns)
+//   - Tables without a primary key (rows may not be uniquely addressable)
 //
-// Usage: go run ./scripts/assess-ontology <project-id>
+// Usage: go run ./scripts/assess-ontology [-v | -quiet] [-format json|md] [-metrics-target url] [-cache-dir <dir> [-refresh | -rescore]] [-weights <spec>] [-concurrency <n>] <project-id> [<project-id>...]
 //
-// Requires: ANTHROPIC_API_KEY environment variable
+//	-v            verbose progress on stderr (per-sample detail)
+//	-quiet        no progress on stderr; the result on stdout is unchanged
+//	-format       json (default) or md for a Markdown report with score badges
+//	-cache-dir    reuse judge results for identical prompts across runs
+//	-refresh      ignore cached judge results and re-judge (the cache is rewritten)
+//	-rescore      recompute scores from cached judge results only; makes no API calls
+//	              and fails if any prompt has no cached result
+//	-weights      override score weights, e.g. "sql=0.5,questions=0.1" (must sum to 1)
+//	-concurrency  projects assessed in parallel when several project IDs are given
+//
+// With several project IDs the script runs in batch mode and prints a single
+// BatchAssessmentResult; each worker uses its own database connection and judge.
+//
+// Requires: ANTHROPIC_API_KEY environment variable (except with -rescore)
 // Database connection: Uses standard PG* environment variables
 package main
 
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
 	"os"
 	"os/exec"
+	"sort"
 	"strings"
 
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5"
-	"github.com/liushuangls/go-anthropic/v2"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/ekaya-inc/ekaya-engine/scripts/internal/assessjudge"
+	"github.com/ekaya-inc/ekaya-engine/scripts/internal/assesslog"
+	"github.com/ekaya-inc/ekaya-engine/scripts/internal/assessmetrics"
+	"github.com/ekaya-inc/ekaya-engine/scripts/internal/assessontology"
+	"github.com/ekaya-inc/ekaya-engine/scripts/internal/assessreport"
 )
 
 // AssessmentResult contains the full assessment output
@@ -39,13 +61,18 @@ type AssessmentResult struct {
 	DatasourceName         string                   `json:"datasource_name"`
 	ProjectID              string                   `json:"project_id"`
 	ModelUsed              string                   `json:"model_used"`
+	JudgeModel             string                   `json:"judge_model"`
 	LLMMetrics             LLMMetrics               `json:"llm_metrics"`
 	PendingQuestionsImpact PendingQuestionsImpact   `json:"pending_questions_impact"`
 	RelationshipCoverage   RelationshipCoverage     `json:"relationship_coverage"`
 	EntityCompleteness     EntityCompletenessAssess `json:"entity_completeness"`
 	SQLReadiness           SQLReadinessAssessment   `json:"sql_readiness"`
+	ScoreWeights           scoreWeights             `json:"score_weights"`
 	FinalScore             int                      `json:"final_score"`
 	FinalAssessment        string                   `json:"final_assessment"`
+	LLMJudgeCalls          int                      `json:"llm_judge_calls"`
+	LLMJudgeCacheHits      int                      `json:"llm_judge_cache_hits"` // Results reused from -cache-dir; no tokens spent
+	LLMJudgeTokens         int                      `json:"llm_judge_tokens"`
 }
 
 // PendingQuestionsImpact assesses what gaps exist due to unanswered questions
@@ -104,6 +131,9 @@ type SQLReadinessAssessment struct {
 	SampleGoodQueries  []string `json:"sample_good_queries"`  // Example questions LLM can answer
 	SampleRiskyQueries []string `json:"sample_risky_queries"` // Example questions that might fail
 	Recommendations    []string `json:"recommendations"`      // What would improve the ontology
+
+	// TablesWithoutPrimaryKey is computed from the schema, not by the judge
+	TablesWithoutPrimaryKey []string `json:"tables_without_primary_key"`
 }
 
 // LLMMetrics contains aggregated LLM performance metrics from extraction
@@ -151,10 +181,11 @@ type LLMConversation struct {
 
 // SchemaTable represents a table in the schema
 type SchemaTable struct {
-	ID        uuid.UUID      `json:"id"`
-	TableName string         `json:"table_name"`
-	RowCount  *int64         `json:"row_count"`
-	Columns   []SchemaColumn `json:"columns"`
+	ID         uuid.UUID      `json:"id"`
+	TableName  string         `json:"table_name"`
+	IsCritical bool           `json:"is_critical,omitempty"` // Listed in the project's critical_tables setting
+	RowCount   *int64         `json:"row_count"`
+	Columns    []SchemaColumn `json:"columns"`
 }
 
 // SchemaColumn represents a column
@@ -179,78 +210,197 @@ type Ontology struct {
 	EntitySummaries json.RawMessage `json:"entity_summaries"`
 }
 
+// logger writes progress to stderr; replaced in main once -v/-quiet are parsed.
+var logger = assesslog.New(os.Stderr, assesslog.LevelNormal)
+
 func main() {
-	if len(os.Args) < 2 {
-		fmt.Fprintf(os.Stderr, "Usage: %s <project-id>\n", os.Args[0])
-		os.Exit(1)
+	var logFlags assesslog.Flags
+	logFlags.Register(flag.CommandLine)
+	var formatFlags assessreport.Flags
+	formatFlags.Register(flag.CommandLine)
+	var metricsFlags assessmetrics.Flags
+	metricsFlags.Register(flag.CommandLine)
+	cacheDir := flag.String("cache-dir", "", "directory for caching judge results across runs (disabled when empty)")
+	refresh := flag.Bool("refresh", false, "ignore cached judge results and re-judge every prompt")
+	rescore := flag.Bool("rescore", false, "recompute scores from cached judge results only, without API calls (requires -cache-dir)")
+	weightsFlag := flag.String("weights", "", "score weights as name=value pairs for sql, relationships, entities and questions (default sql=0.4,relationships=0.25,entities=0.2,questions=0.15)")
+	concurrency := flag.Int("concurrency", 1, "number of projects to assess in parallel in batch mode")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s [-v | -quiet] [-format json|md] [-cache-dir <dir> [-refresh | -rescore]] [-weights <spec>] [-concurrency <n>] <project-id> [<project-id>...]\n", os.Args[0])
+		flag.PrintDefaults()
 	}
+	flag.Parse()
+	logger = logFlags.Logger()
 
-	projectID, err := uuid.Parse(os.Args[1])
+	if flag.NArg() < 1 {
+		flag.Usage()
+		os.Exit(1)
+	}
+	if err := formatFlags.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid -format: %v\n", err)
+		os.Exit(1)
+	}
+	if err := metricsFlags.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid -metrics-target: %v\n", err)
+		os.Exit(1)
+	}
+	if *concurrency < 1 {
+		fmt.Fprintf(os.Stderr, "-concurrency must be at least 1\n")
+		os.Exit(1)
+	}
+	if *rescore && (*cacheDir == "" || *refresh) {
+		fmt.Fprintf(os.Stderr, "-rescore requires -cache-dir and cannot be combined with -refresh\n")
+		os.Exit(1)
+	}
+	weights, err := parseScoreWeights(*weightsFlag)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Invalid project ID: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Invalid -weights: %v\n", err)
 		os.Exit(1)
 	}
 
+	projectIDs := make([]uuid.UUID, 0, flag.NArg())
+	for _, arg := range flag.Args() {
+		projectID, err := uuid.Parse(arg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid project ID %q: %v\n", arg, err)
+			os.Exit(1)
+		}
+		projectIDs = append(projectIDs, projectID)
+	}
+
 	apiKey := os.Getenv("ANTHROPIC_API_KEY")
-	if apiKey == "" {
+	if apiKey == "" && !*rescore {
 		fmt.Fprintf(os.Stderr, "ANTHROPIC_API_KEY environment variable required\n")
 		os.Exit(1)
 	}
 
 	ctx := context.Background()
 
-	// Connect to database
-	connStr := buildConnString()
-	conn, err := pgx.Connect(ctx, connStr)
+	// Each worker acquires its own pooled connection; a single pgx.Conn is not safe
+	// for concurrent use.
+	poolConfig, err := pgxpool.ParseConfig(buildConnString())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid database configuration: %v\n", err)
+		os.Exit(1)
+	}
+	poolConfig.MaxConns = int32(*concurrency)
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to connect to database: %v\n", err)
 		os.Exit(1)
 	}
-	defer conn.Close(ctx)
+	defer pool.Close()
+
+	// Create the judge cache shared by every project's judge
+	var cache *judgeCache
+	if *cacheDir != "" {
+		cache, err = newJudgeCache(*cacheDir, *refresh)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to open judge cache: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	newProjectJudge := func() *judge { return newCacheOnlyJudge(cache) }
+	if !*rescore {
+		llm := assessjudge.NewAnthropic(apiKey, JudgeModel)
+		newProjectJudge = func() *judge { return newJudge(llm, cache) }
+	}
+	commitInfo := getCommitInfo()
 
+	acquire := func(ctx context.Context) (dbQuerier, func(), error) {
+		conn, err := pool.Acquire(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		return conn, conn.Release, nil
+	}
+	assess := func(ctx context.Context, db dbQuerier, projectID uuid.UUID) (*AssessmentResult, error) {
+		return assessProject(ctx, db, newProjectJudge(), projectID, commitInfo, weights)
+	}
+
+	// Single-project mode keeps its original output shape
+	if len(projectIDs) == 1 {
+		db, release, err := acquire(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to connect to database: %v\n", err)
+			os.Exit(1)
+		}
+		result, err := assess(ctx, db, projectIDs[0])
+		release()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		report := func() assessreport.Report { return markdownReport(result) }
+		if err := assessreport.Write(os.Stdout, formatFlags.Format, result, report); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to write result: %v\n", err)
+			os.Exit(1)
+		}
+		pushMetrics(metricsFlags.Target, result)
+		return
+	}
+
+	batch := runBatch(ctx, projectIDs, *concurrency, acquire, assess)
+	batch.CommitInfo = commitInfo
+	if formatFlags.Format == assessreport.FormatMarkdown {
+		fmt.Print(markdownBatch(batch))
+	} else {
+		output, _ := json.MarshalIndent(batch, "", "  ")
+		fmt.Println(string(output))
+	}
+	for i := range batch.Projects {
+		pushMetrics(metricsFlags.Target, &batch.Projects[i])
+	}
+	if len(batch.Failures) > 0 {
+		os.Exit(1)
+	}
+}
+
+// assessProject loads one project's extraction output through db and runs every
+// assessment with j. It is safe to run for several projects concurrently as long as
+// each call has its own db and judge. With a cache-only judge it fails rather than
+// score any assessment whose verdict is not cached.
+func assessProject(ctx context.Context, db dbQuerier, j *judge, projectID uuid.UUID, commitInfo string, weights scoreWeights) (*AssessmentResult, error) {
 	// Get datasource name for this project
 	var datasourceName string
-	if err := conn.QueryRow(ctx, `
+	if err := db.QueryRow(ctx, `
 		SELECT name FROM engine_datasources
 		WHERE project_id = $1
 		LIMIT 1
 	`, projectID).Scan(&datasourceName); err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to get datasource name: %v\n", err)
-		os.Exit(1)
+		return nil, fmt.Errorf("failed to get datasource name: %w", err)
 	}
 
-	// Get commit info
-	commitInfo := getCommitInfo()
-
 	// Load data
-	conversations, err := loadConversations(ctx, conn, projectID)
+	conversations, err := loadConversations(ctx, db, projectID)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to load conversations: %v\n", err)
-		os.Exit(1)
+		return nil, fmt.Errorf("failed to load conversations: %w", err)
 	}
 
-	schema, err := loadSchema(ctx, conn, projectID)
+	schema, err := loadSchema(ctx, db, projectID)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to load schema: %v\n", err)
-		os.Exit(1)
+		return nil, fmt.Errorf("failed to load schema: %w", err)
 	}
 
-	relationships, err := loadRelationships(ctx, conn, projectID)
+	criticalTables, err := loadCriticalTables(ctx, db, projectID)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to load relationships: %v\n", err)
-		os.Exit(1)
+		return nil, fmt.Errorf("failed to load critical tables: %w", err)
 	}
+	prioritizeCriticalTables(schema, criticalTables)
 
-	ontology, err := loadOntology(ctx, conn, projectID)
+	relationships, err := loadRelationships(ctx, db, projectID)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to load ontology: %v\n", err)
-		os.Exit(1)
+		return nil, fmt.Errorf("failed to load relationships: %w", err)
 	}
 
-	questions, err := loadQuestions(ctx, conn, projectID)
+	ontology, err := loadOntology(ctx, db, projectID)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to load questions: %v\n", err)
-		os.Exit(1)
+		return nil, fmt.Errorf("failed to load ontology: %w", err)
+	}
+
+	questions, err := loadQuestions(ctx, db, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load questions: %w", err)
 	}
 
 	// Get model used from conversations
@@ -262,52 +412,52 @@ func main() {
 	// Calculate LLM metrics
 	llmMetrics := calculateLLMMetrics(conversations)
 
-	// Create Anthropic client for assessments
-	client := anthropic.NewClient(apiKey)
-
 	// Run assessments
-	fmt.Fprintf(os.Stderr, "Assessing pending questions impact...\n")
-	pendingImpact := assessPendingQuestionsImpact(ctx, client, questions, schema, ontology)
+	logger.Progressf("Assessing pending questions impact (%s)...\n", projectID)
+	pendingImpact := assessPendingQuestionsImpact(ctx, j, questions, schema, ontology)
 
-	fmt.Fprintf(os.Stderr, "Assessing relationship coverage...\n")
-	relationshipCoverage := assessRelationshipCoverage(ctx, client, schema, relationships, ontology)
+	logger.Progressf("Assessing relationship coverage (%s)...\n", projectID)
+	relationshipCoverage := assessRelationshipCoverage(ctx, j, schema, relationships, ontology)
 
-	fmt.Fprintf(os.Stderr, "Assessing entity completeness...\n")
-	entityCompleteness := assessEntityCompleteness(ctx, client, schema, ontology, questions)
+	logger.Progressf("Assessing entity completeness (%s)...\n", projectID)
+	entityCompleteness := assessEntityCompleteness(ctx, j, schema, ontology, questions)
 
-	fmt.Fprintf(os.Stderr, "Assessing SQL readiness...\n")
-	sqlReadiness := assessSQLReadiness(ctx, client, schema, ontology, questions, relationships)
+	logger.Progressf("Assessing SQL readiness (%s)...\n", projectID)
+	sqlReadiness := assessSQLReadiness(ctx, j, schema, ontology, questions, relationships)
+
+	if misses := j.misses(); misses > 0 {
+		return nil, fmt.Errorf("cannot rescore project %s: %d judge result(s) not cached; run without -rescore to judge them", projectID, misses)
+	}
 
 	// Calculate final score
-	// Weights: SQL Readiness 40%, Relationship Coverage 25%, Entity Completeness 20%, Pending Questions 15%
-	// Note: pendingImpact.ImpactScore is inverted (higher = worse), so we use (100 - score)
-	finalScore := int(
-		float64(sqlReadiness.ConfidenceScore)*0.40 +
-			float64(relationshipCoverage.CoverageScore)*0.25 +
-			float64(entityCompleteness.CompletenessScore)*0.20 +
-			float64(100-pendingImpact.ImpactScore)*0.15,
-	)
+	finalScore := weights.finalScore(sqlReadiness, relationshipCoverage, entityCompleteness, pendingImpact)
 
 	// Generate final assessment summary
 	finalAssessment := generateFinalAssessment(finalScore, sqlReadiness, pendingImpact, relationshipCoverage)
 
-	result := AssessmentResult{
+	judgeCalls, judgeCacheHits, judgeTokens := j.usage()
+	if judgeCacheHits > 0 {
+		logger.Progressf("Reused %d cached judge result(s) (%s)\n", judgeCacheHits, projectID)
+	}
+
+	return &AssessmentResult{
 		CommitInfo:             commitInfo,
 		DatasourceName:         datasourceName,
 		ProjectID:              projectID.String(),
 		ModelUsed:              modelUsed,
+		JudgeModel:             JudgeModel,
 		LLMMetrics:             llmMetrics,
 		PendingQuestionsImpact: pendingImpact,
 		RelationshipCoverage:   relationshipCoverage,
 		EntityCompleteness:     entityCompleteness,
 		SQLReadiness:           sqlReadiness,
+		ScoreWeights:           weights,
 		FinalScore:             finalScore,
 		FinalAssessment:        finalAssessment,
-	}
-
-	// Output JSON
-	output, _ := json.MarshalIndent(result, "", "  ")
-	fmt.Println(string(output))
+		LLMJudgeCalls:          judgeCalls,
+		LLMJudgeCacheHits:      judgeCacheHits,
+		LLMJudgeTokens:         judgeTokens,
+	}, nil
 }
 
 func generateFinalAssessment(score int, sql SQLReadinessAssessment, pending PendingQuestionsImpact, relations RelationshipCoverage) string {
@@ -368,7 +518,7 @@ func getCommitInfo() string {
 	return strings.TrimSpace(string(output))
 }
 
-func loadConversations(ctx context.Context, conn *pgx.Conn, projectID uuid.UUID) ([]LLMConversation, error) {
+func loadConversations(ctx context.Context, conn dbQuerier, projectID uuid.UUID) ([]LLMConversation, error) {
 	query := `
 		SELECT id, model, request_messages, response_content,
 		       COALESCE(prompt_tokens, 0), COALESCE(completion_tokens, 0),
@@ -438,7 +588,7 @@ func calculateLLMMetrics(conversations []LLMConversation) LLMMetrics {
 	return metrics
 }
 
-func loadSchema(ctx context.Context, conn *pgx.Conn, projectID uuid.UUID) ([]SchemaTable, error) {
+func loadSchema(ctx context.Context, conn dbQuerier, projectID uuid.UUID) ([]SchemaTable, error) {
 	// Load tables
 	tableQuery := `
 		SELECT id, table_name, row_count
@@ -490,7 +640,7 @@ func loadSchema(ctx context.Context, conn *pgx.Conn, projectID uuid.UUID) ([]Sch
 	return tables, nil
 }
 
-func loadRelationships(ctx context.Context, conn *pgx.Conn, projectID uuid.UUID) ([]SchemaRelationship, error) {
+func loadRelationships(ctx context.Context, conn dbQuerier, projectID uuid.UUID) ([]SchemaRelationship, error) {
 	query := `
 		SELECT source_table_id, source_column_id, target_table_id, target_column_id
 		FROM engine_schema_relationships
@@ -513,24 +663,15 @@ func loadRelationships(ctx context.Context, conn *pgx.Conn, projectID uuid.UUID)
 	return relationships, rows.Err()
 }
 
-func loadOntology(ctx context.Context, conn *pgx.Conn, projectID uuid.UUID) (*Ontology, error) {
-	query := `
-		SELECT domain_summary, entity_summaries
-		FROM engine_ontologies
-		WHERE project_id = $1 AND is_active = true`
-
-	var o Ontology
-	err := conn.QueryRow(ctx, query, projectID).Scan(&o.DomainSummary, &o.EntitySummaries)
+func loadOntology(ctx context.Context, conn dbQuerier, projectID uuid.UUID) (*Ontology, error) {
+	o, err := assessontology.Load(ctx, conn, projectID)
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("no active ontology found")
-		}
 		return nil, err
 	}
-	return &o, nil
+	return &Ontology{DomainSummary: o.DomainSummary, EntitySummaries: o.EntitySummaries}, nil
 }
 
-func loadQuestions(ctx context.Context, conn *pgx.Conn, projectID uuid.UUID) ([]OntologyQuestion, error) {
+func loadQuestions(ctx context.Context, conn dbQuerier, projectID uuid.UUID) ([]OntologyQuestion, error) {
 	query := `
 		SELECT id, text, reasoning, category, priority, is_required,
 		       source_entity_type, source_entity_key, status
@@ -556,7 +697,7 @@ func loadQuestions(ctx context.Context, conn *pgx.Conn, projectID uuid.UUID) ([]
 	return questions, rows.Err()
 }
 
-func assessPendingQuestionsImpact(ctx context.Context, client *anthropic.Client, questions []OntologyQuestion, schema []SchemaTable, ontology *Ontology) PendingQuestionsImpact {
+func assessPendingQuestionsImpact(ctx context.Context, j *judge, questions []OntologyQuestion, schema []SchemaTable, ontology *Ontology) PendingQuestionsImpact {
 	// Count pending questions
 	var required, optional int
 	var pendingQuestions []OntologyQuestion
@@ -583,6 +724,24 @@ func assessPendingQuestionsImpact(ctx context.Context, client *anthropic.Client,
 		}
 	}
 
+	// Questions about business-critical tables go first
+	criticalKeys := make(map[string]bool)
+	for _, t := range schema {
+		if t.IsCritical {
+			criticalKeys[strings.ToLower(t.TableName)] = true
+		}
+	}
+	onCriticalTable := func(q OntologyQuestion) bool {
+		if q.SourceEntityKey == nil {
+			return false
+		}
+		table, _, _ := strings.Cut(strings.ToLower(*q.SourceEntityKey), ".")
+		return criticalKeys[table]
+	}
+	sort.SliceStable(pendingQuestions, func(i, j int) bool {
+		return onCriticalTable(pendingQuestions[i]) && !onCriticalTable(pendingQuestions[j])
+	})
+
 	// Build questions list for LLM
 	var questionsText strings.Builder
 	questionsText.WriteString("## REQUIRED PENDING QUESTIONS\n")
@@ -590,7 +749,11 @@ func assessPendingQuestionsImpact(ctx context.Context, client *anthropic.Client,
 		if q.IsRequired {
 			questionsText.WriteString(fmt.Sprintf("- %s\n", q.Text))
 			if q.SourceEntityKey != nil {
-				questionsText.WriteString(fmt.Sprintf("  (Table: %s)\n", *q.SourceEntityKey))
+				marker := ""
+				if onCriticalTable(q) {
+					marker = criticalMarker
+				}
+				questionsText.WriteString(fmt.Sprintf("  (Table: %s%s)\n", *q.SourceEntityKey, marker))
 			}
 		}
 	}
@@ -611,7 +774,7 @@ func assessPendingQuestionsImpact(ctx context.Context, client *anthropic.Client,
 
 ## TASK
 Analyze what gaps these unanswered questions create for an LLM trying to write SQL queries.
-
+%s
 Return JSON:
 {
   "critical_gaps": ["What the LLM cannot determine without answers - be specific"],
@@ -627,19 +790,17 @@ Impact scoring guide:
 - 61-80: Severe gaps, LLM will frequently fail
 - 81-100: Critical gaps, LLM cannot reliably generate SQL
 
-Return ONLY JSON.`, string(ontology.DomainSummary), questionsText.String())
+Return ONLY JSON.`, string(ontology.DomainSummary), questionsText.String(), criticalPromptGuidance(schema))
 
-	resp, err := client.CreateMessages(ctx, anthropic.MessagesRequest{
-		Model:     "claude-sonnet-4-5-20250929",
-		MaxTokens: 2000,
-		Messages: []anthropic.Message{
-			{Role: anthropic.RoleUser, Content: []anthropic.MessageContent{
-				{Type: "text", Text: &prompt},
-			}},
-		},
-	})
+	var result struct {
+		CriticalGaps       []string `json:"critical_gaps"`
+		AffectedQueries    []string `json:"affected_queries"`
+		EnabledWithAnswers []string `json:"enabled_with_answers"`
+		ImpactScore        int      `json:"impact_score"`
+	}
 
-	if err != nil {
+	err := runJudge(ctx, j, prompt, 2000, &result)
+	if err != nil && !errors.Is(err, errUnparseableResponse) {
 		return PendingQuestionsImpact{
 			TotalPending:    len(pendingQuestions),
 			RequiredPending: required,
@@ -648,17 +809,7 @@ Return ONLY JSON.`, string(ontology.DomainSummary), questionsText.String())
 			ImpactScore:     50, // Default to moderate impact on error
 		}
 	}
-
-	var result struct {
-		CriticalGaps       []string `json:"critical_gaps"`
-		AffectedQueries    []string `json:"affected_queries"`
-		EnabledWithAnswers []string `json:"enabled_with_answers"`
-		ImpactScore        int      `json:"impact_score"`
-	}
-
-	responseText := extractTextFromResponse(resp)
-	responseText = extractJSON(responseText)
-	if err := json.Unmarshal([]byte(responseText), &result); err != nil {
+	if err != nil {
 		return PendingQuestionsImpact{
 			TotalPending:    len(pendingQuestions),
 			RequiredPending: required,
@@ -679,7 +830,7 @@ Return ONLY JSON.`, string(ontology.DomainSummary), questionsText.String())
 	}
 }
 
-func assessRelationshipCoverage(ctx context.Context, client *anthropic.Client, schema []SchemaTable, relationships []SchemaRelationship, ontology *Ontology) RelationshipCoverage {
+func assessRelationshipCoverage(ctx context.Context, j *judge, schema []SchemaTable, relationships []SchemaRelationship, ontology *Ontology) RelationshipCoverage {
 	// Build table ID lookup
 	tableIDToName := make(map[uuid.UUID]string)
 	for _, t := range schema {
@@ -712,7 +863,7 @@ func assessRelationshipCoverage(ctx context.Context, client *anthropic.Client, s
 		if tablesWithRels[t.TableName] {
 			hasRel = " [HAS RELATIONSHIPS]"
 		}
-		schemaSummary.WriteString(fmt.Sprintf("### %s%s\n", t.TableName, hasRel))
+		schemaSummary.WriteString(fmt.Sprintf("### %s%s%s\n", t.TableName, criticalSuffix(t), hasRel))
 		if t.RowCount != nil {
 			schemaSummary.WriteString(fmt.Sprintf("Rows: %d\n", *t.RowCount))
 		}
@@ -741,7 +892,7 @@ func assessRelationshipCoverage(ctx context.Context, client *anthropic.Client, s
 Analyze the relationship coverage and identify:
 1. For each orphan table: Is it truly standalone, or are relationships missing?
 2. Look for columns that LOOK like foreign keys (ending in _id, named similarly to other tables) but have no documented relationship
-
+%s
 Return JSON:
 {
   "orphan_tables": [
@@ -770,19 +921,16 @@ Coverage scoring guide:
 - 30-49: Significant gaps, many relationships undocumented
 - 0-29: Poor coverage, LLM cannot understand table connections
 
-Return ONLY JSON.`, string(ontology.DomainSummary), schemaSummary.String(), strings.Join(orphanTableNames, ", "))
+Return ONLY JSON.`, string(ontology.DomainSummary), schemaSummary.String(), strings.Join(orphanTableNames, ", "), criticalPromptGuidance(schema))
 
-	resp, err := client.CreateMessages(ctx, anthropic.MessagesRequest{
-		Model:     "claude-sonnet-4-5-20250929",
-		MaxTokens: 3000,
-		Messages: []anthropic.Message{
-			{Role: anthropic.RoleUser, Content: []anthropic.MessageContent{
-				{Type: "text", Text: &prompt},
-			}},
-		},
-	})
+	var result struct {
+		OrphanTables     []OrphanTable     `json:"orphan_tables"`
+		MissingRelations []MissingRelation `json:"missing_relations"`
+		CoverageScore    int               `json:"coverage_score"`
+	}
 
-	if err != nil {
+	err := runJudge(ctx, j, prompt, 3000, &result)
+	if err != nil && !errors.Is(err, errUnparseableResponse) {
 		return RelationshipCoverage{
 			TotalTables:         len(schema),
 			TablesWithRelations: len(tablesWithRels),
@@ -790,16 +938,7 @@ Return ONLY JSON.`, string(ontology.DomainSummary), schemaSummary.String(), stri
 			CoverageScore:       50,
 		}
 	}
-
-	var result struct {
-		OrphanTables     []OrphanTable     `json:"orphan_tables"`
-		MissingRelations []MissingRelation `json:"missing_relations"`
-		CoverageScore    int               `json:"coverage_score"`
-	}
-
-	responseText := extractTextFromResponse(resp)
-	responseText = extractJSON(responseText)
-	if err := json.Unmarshal([]byte(responseText), &result); err != nil {
+	if err != nil {
 		return RelationshipCoverage{
 			TotalTables:         len(schema),
 			TablesWithRelations: len(tablesWithRels),
@@ -816,13 +955,13 @@ Return ONLY JSON.`, string(ontology.DomainSummary), schemaSummary.String(), stri
 	}
 }
 
-func assessEntityCompleteness(ctx context.Context, client *anthropic.Client, schema []SchemaTable, ontology *Ontology, questions []OntologyQuestion) EntityCompletenessAssess {
+func assessEntityCompleteness(ctx context.Context, j *judge, schema []SchemaTable, ontology *Ontology, questions []OntologyQuestion) EntityCompletenessAssess {
 	// Build schema summary with focus on status/type/enum columns
 	var schemaSummary strings.Builder
 	var enumCandidates []string
 
 	for _, t := range schema {
-		schemaSummary.WriteString(fmt.Sprintf("### %s\n", t.TableName))
+		schemaSummary.WriteString(fmt.Sprintf("### %s%s\n", t.TableName, criticalSuffix(t)))
 		for _, c := range t.Columns {
 			// Identify potential enum columns
 			isEnumCandidate := strings.Contains(strings.ToLower(c.ColumnName), "status") ||
@@ -867,7 +1006,7 @@ Assess how well entities are documented for an LLM to generate correct SQL:
 1. Are entity descriptions clear enough to understand their purpose?
 2. Are key enum/status/type columns documented with their possible values?
 3. Are there ambiguous entities that could confuse an LLM?
-
+%s
 Return JSON:
 {
   "well_documented": <count>,
@@ -885,29 +1024,17 @@ Completeness scoring:
 - 30-49: Many entities ambiguous, critical enums unknown
 - 0-29: Documentation insufficient for reliable SQL generation
 
-Return ONLY JSON.`, string(ontology.DomainSummary), string(ontology.EntitySummaries), schemaSummary.String(), strings.Join(enumCandidates, ", "))
-
-	resp, err := client.CreateMessages(ctx, anthropic.MessagesRequest{
-		Model:     "claude-sonnet-4-5-20250929",
-		MaxTokens: 2000,
-		Messages: []anthropic.Message{
-			{Role: anthropic.RoleUser, Content: []anthropic.MessageContent{
-				{Type: "text", Text: &prompt},
-			}},
-		},
-	})
+Return ONLY JSON.`, string(ontology.DomainSummary), string(ontology.EntitySummaries), schemaSummary.String(), strings.Join(enumCandidates, ", "), criticalPromptGuidance(schema))
 
-	if err != nil {
+	var result EntityCompletenessAssess
+	err := runJudge(ctx, j, prompt, 2000, &result)
+	if err != nil && !errors.Is(err, errUnparseableResponse) {
 		return EntityCompletenessAssess{
 			AmbiguousEntities: []string{fmt.Sprintf("Assessment failed: %v", err)},
 			CompletenessScore: 50,
 		}
 	}
-
-	var result EntityCompletenessAssess
-	responseText := extractTextFromResponse(resp)
-	responseText = extractJSON(responseText)
-	if err := json.Unmarshal([]byte(responseText), &result); err != nil {
+	if err != nil {
 		return EntityCompletenessAssess{
 			AmbiguousEntities: []string{fmt.Sprintf("Parse error: %v", err)},
 			CompletenessScore: 50,
@@ -917,11 +1044,11 @@ Return ONLY JSON.`, string(ontology.DomainSummary), string(ontology.EntitySummar
 	return result
 }
 
-func assessSQLReadiness(ctx context.Context, client *anthropic.Client, schema []SchemaTable, ontology *Ontology, questions []OntologyQuestion, relationships []SchemaRelationship) SQLReadinessAssessment {
+func assessSQLReadiness(ctx context.Context, j *judge, schema []SchemaTable, ontology *Ontology, questions []OntologyQuestion, relationships []SchemaRelationship) SQLReadinessAssessment {
 	// Build comprehensive context
 	var schemaSummary strings.Builder
 	for _, t := range schema {
-		schemaSummary.WriteString(fmt.Sprintf("%s: ", t.TableName))
+		schemaSummary.WriteString(fmt.Sprintf("%s%s: ", t.TableName, criticalSuffix(t)))
 		var cols []string
 		for _, c := range t.Columns {
 			cols = append(cols, c.ColumnName)
@@ -938,6 +1065,12 @@ func assessSQLReadiness(ctx context.Context, client *anthropic.Client, schema []
 		}
 	}
 
+	keyless := tablesWithoutPrimaryKey(schema)
+	keylessSummary := "none"
+	if len(keyless) > 0 {
+		keylessSummary = strings.Join(keyless, ", ")
+	}
+
 	prompt := fmt.Sprintf(`You are an expert SQL developer assessing whether an LLM can reliably generate SQL queries for this database.
 
 ## ONTOLOGY
@@ -952,6 +1085,7 @@ Entity Summaries: %s
 - Total tables: %d
 - Documented relationships: %d
 - Pending required questions: %d
+- Tables without a primary key (rows may not be uniquely addressable; JOINs into them may duplicate rows): %s
 
 ## TASK
 Assess how confidently an LLM (like Claude Sonnet) could generate correct SQL queries for business questions.
@@ -961,7 +1095,7 @@ Consider:
 2. Are relationships clear enough to write correct JOINs?
 3. Are column meanings clear enough to select the right fields?
 4. Are there gaps that would cause incorrect SQL?
-
+%s
 Return JSON:
 {
   "confidence_level": "high|medium|low|very_low",
@@ -979,55 +1113,51 @@ Confidence scoring:
 - 50-69: LOW - LLM will frequently produce incorrect or incomplete SQL
 - 0-49: VERY LOW - LLM cannot reliably navigate this database
 
-Return ONLY JSON.`, string(ontology.DomainSummary), string(ontology.EntitySummaries), schemaSummary.String(), len(schema), len(relationships), pendingRequired)
+Return ONLY JSON.`, string(ontology.DomainSummary), string(ontology.EntitySummaries), schemaSummary.String(), len(schema), len(relationships), pendingRequired, keylessSummary, criticalPromptGuidance(schema))
 
-	resp, err := client.CreateMessages(ctx, anthropic.MessagesRequest{
-		Model:     "claude-sonnet-4-5-20250929",
-		MaxTokens: 3000,
-		Messages: []anthropic.Message{
-			{Role: anthropic.RoleUser, Content: []anthropic.MessageContent{
-				{Type: "text", Text: &prompt},
-			}},
-		},
-	})
-
-	if err != nil {
-		return SQLReadinessAssessment{
+	var result SQLReadinessAssessment
+	err := runJudge(ctx, j, prompt, 3000, &result)
+	if err != nil && !errors.Is(err, errUnparseableResponse) {
+		result = SQLReadinessAssessment{
 			ConfidenceLevel: "unknown",
 			ConfidenceScore: 50,
 			WeakAreas:       []string{fmt.Sprintf("Assessment failed: %v", err)},
 		}
-	}
-
-	var result SQLReadinessAssessment
-	responseText := extractTextFromResponse(resp)
-	responseText = extractJSON(responseText)
-	if err := json.Unmarshal([]byte(responseText), &result); err != nil {
-		return SQLReadinessAssessment{
+	} else if err != nil {
+		result = SQLReadinessAssessment{
 			ConfidenceLevel: "unknown",
 			ConfidenceScore: 50,
 			WeakAreas:       []string{fmt.Sprintf("Parse error: %v", err)},
 		}
 	}
 
+	// Recorded deterministically so the risk is reported even if the judge omits it
+	result.TablesWithoutPrimaryKey = keyless
+	if len(keyless) > 0 {
+		result.WeakAreas = append(result.WeakAreas,
+			fmt.Sprintf("%d table(s) without a primary key; rows may not be uniquely addressable: %s", len(keyless), keylessSummary))
+	}
+
 	return result
 }
 
-func extractTextFromResponse(resp anthropic.MessagesResponse) string {
-	for _, block := range resp.Content {
-		if block.Type == "text" && block.Text != nil {
-			return *block.Text
+// tablesWithoutPrimaryKey returns the names of tables with columns but no primary key column.
+func tablesWithoutPrimaryKey(schema []SchemaTable) []string {
+	keyless := []string{}
+	for _, t := range schema {
+		if len(t.Columns) == 0 {
+			continue
+		}
+		hasPK := false
+		for _, c := range t.Columns {
+			if c.IsPrimaryKey {
+				hasPK = true
+				break
+			}
+		}
+		if !hasPK {
+			keyless = append(keyless, t.TableName)
 		}
 	}
-	return ""
-}
-
-func extractJSON(s string) string {
-	// Find JSON object in response
-	start := strings.Index(s, "{")
-	end := strings.LastIndex(s, "}")
-	if start >= 0 && end > start {
-		return s[start : end+1]
-	}
-	return s
+	return keyless
 }
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTablesWithoutPrimaryKey(t *testing.T) {
+	schema := []SchemaTable{
+		{TableName: "users", Columns: []SchemaColumn{{ColumnName: "id", IsPrimaryKey: true}}},
+		{TableName: "click_events", Columns: []SchemaColumn{{ColumnName: "user_id"}, {ColumnName: "clicked_at"}}},
+		{TableName: "empty_view"},
+	}
+
+	got := tablesWithoutPrimaryKey(schema)
+	if want := []string{"click_events"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("tablesWithoutPrimaryKey() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ekaya-inc/ekaya-engine/scripts/internal/assessmetrics"
+	"github.com/ekaya-inc/ekaya-engine/scripts/internal/assessreport"
+)
+
+// markdownReport builds the -format md report from the same result printed as JSON.
+// Pending questions are scored as 100 minus their impact, as in the final score.
+func markdownReport(result *AssessmentResult) assessreport.Report {
+	report := assessreport.Report{
+		Title: fmt.Sprintf("Ontology Assessment: %s", result.DatasourceName),
+		Details: []assessreport.Detail{
+			{Name: "Project", Value: result.ProjectID},
+			{Name: "Extraction model", Value: result.ModelUsed},
+			{Name: "Judge", Value: result.JudgeModel},
+			{Name: "Commit", Value: result.CommitInfo},
+			{Name: "Judge usage", Value: fmt.Sprintf("%d calls, %d cache hits, %d tokens",
+				result.LLMJudgeCalls, result.LLMJudgeCacheHits, result.LLMJudgeTokens)},
+		},
+		FinalScore:      result.FinalScore,
+		Summary:         result.FinalAssessment,
+		Recommendations: result.SQLReadiness.Recommendations,
+	}
+
+	sql := result.SQLReadiness
+	sqlNotes := append([]string(nil), sql.WeakAreas...)
+	if len(sql.TablesWithoutPrimaryKey) > 0 {
+		sqlNotes = append(sqlNotes, "Tables without a primary key: "+strings.Join(sql.TablesWithoutPrimaryKey, ", "))
+	}
+
+	relations := result.RelationshipCoverage
+	var relationNotes []string
+	for _, o := range relations.OrphanTables {
+		relationNotes = append(relationNotes, fmt.Sprintf("Orphan table %s: %s", o.TableName, o.Concern))
+	}
+	for _, m := range relations.MissingRelations {
+		relationNotes = append(relationNotes, fmt.Sprintf("Suspected missing relationship %s.%s → %s (%s confidence)",
+			m.SourceTable, m.SourceColumn, m.TargetTable, m.Confidence))
+	}
+
+	entities := result.EntityCompleteness
+	var entityNotes []string
+	if len(entities.UndocumentedEnums) > 0 {
+		entityNotes = append(entityNotes, "Undocumented enum columns: "+strings.Join(entities.UndocumentedEnums, ", "))
+	}
+	if len(entities.AmbiguousEntities) > 0 {
+		entityNotes = append(entityNotes, "Ambiguous entities: "+strings.Join(entities.AmbiguousEntities, ", "))
+	}
+
+	pending := result.PendingQuestionsImpact
+	pendingNotes := append([]string(nil), pending.CriticalGaps...)
+	if pending.TotalPending > 0 {
+		pendingNotes = append([]string{fmt.Sprintf("%d pending question(s), %d required",
+			pending.TotalPending, pending.RequiredPending)}, pendingNotes...)
+	}
+
+	w := result.ScoreWeights
+	report.Categories = []assessreport.Category{
+		{Name: "SQL readiness", Score: sql.ConfidenceScore, Weight: w.SQLReadiness, Notes: sqlNotes},
+		{Name: "Relationship coverage", Score: relations.CoverageScore, Weight: w.RelationshipCoverage, Notes: relationNotes},
+		{Name: "Entity completeness", Score: entities.CompletenessScore, Weight: w.EntityCompleteness, Notes: entityNotes},
+		{Name: "Pending questions", Score: 100 - pending.ImpactScore, Weight: w.PendingQuestions, Notes: pendingNotes},
+	}
+	for _, c := range report.Categories {
+		report.Issues = append(report.Issues, c.Notes...)
+	}
+	return report
+}
+
+// markdownBatch renders every project's report, in command-line order, followed by
+// the projects that could not be assessed.
+func markdownBatch(batch *BatchAssessmentResult) string {
+	parts := make([]string, 0, len(batch.Projects)+1)
+	for i := range batch.Projects {
+		parts = append(parts, assessreport.Markdown(markdownReport(&batch.Projects[i])))
+	}
+	if len(batch.Failures) > 0 {
+		var sb strings.Builder
+		sb.WriteString("# Failed Projects\n\n")
+		for _, f := range batch.Failures {
+			fmt.Fprintf(&sb, "- %s: %s\n", f.ProjectID, f.Error)
+		}
+		parts = append(parts, sb.String())
+	}
+	return strings.Join(parts, "\n---\n\n")
+}
+
+// pushMetrics sends the scores to the -metrics-target monitoring endpoint, if one is
+// set. The result is already written, so a failed push is only reported.
+func pushMetrics(target string, result *AssessmentResult) {
+	labels := assessmetrics.Labels{ProjectID: result.ProjectID, Datasource: result.DatasourceName, Commit: result.CommitInfo}
+	metrics := assessmetrics.FromReport(markdownReport(result),
+		assessmetrics.Metric{Name: "llm_total_tokens", Value: float64(result.LLMMetrics.TotalTokens)},
+		assessmetrics.Metric{Name: "judge_calls", Value: float64(result.LLMJudgeCalls)},
+		assessmetrics.Metric{Name: "judge_tokens", Value: float64(result.LLMJudgeTokens)},
+	)
+	if err := assessmetrics.Push(context.Background(), target, "ontology", labels, metrics); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to push metrics: %v\n", err)
+	}
+}
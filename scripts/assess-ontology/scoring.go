@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// scoreWeights sets how much each assessment contributes to FinalScore. The weights
+// must sum to 1 so the final score stays on the 0-100 scale.
+type scoreWeights struct {
+	SQLReadiness         float64 `json:"sql_readiness"`
+	RelationshipCoverage float64 `json:"relationship_coverage"`
+	EntityCompleteness   float64 `json:"entity_completeness"`
+	PendingQuestions     float64 `json:"pending_questions"`
+}
+
+// defaultScoreWeights: SQL Readiness 40%, Relationship Coverage 25%, Entity
+// Completeness 20%, Pending Questions 15%.
+var defaultScoreWeights = scoreWeights{
+	SQLReadiness:         0.40,
+	RelationshipCoverage: 0.25,
+	EntityCompleteness:   0.20,
+	PendingQuestions:     0.15,
+}
+
+// parseScoreWeights parses a -weights value such as "sql=0.5,questions=0.1".
+// Assessments not named keep their default weight; the result must sum to 1.
+func parseScoreWeights(s string) (scoreWeights, error) {
+	w := defaultScoreWeights
+	if strings.TrimSpace(s) == "" {
+		return w, nil
+	}
+
+	for _, part := range strings.Split(s, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return scoreWeights{}, fmt.Errorf("invalid weight %q: want name=value", part)
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || v < 0 {
+			return scoreWeights{}, fmt.Errorf("invalid weight %q: value must be a non-negative number", part)
+		}
+		switch strings.TrimSpace(name) {
+		case "sql":
+			w.SQLReadiness = v
+		case "relationships":
+			w.RelationshipCoverage = v
+		case "entities":
+			w.EntityCompleteness = v
+		case "questions":
+			w.PendingQuestions = v
+		default:
+			return scoreWeights{}, fmt.Errorf("unknown weight %q (valid: sql, relationships, entities, questions)", name)
+		}
+	}
+
+	if sum := w.SQLReadiness + w.RelationshipCoverage + w.EntityCompleteness + w.PendingQuestions; math.Abs(sum-1) > 0.001 {
+		return scoreWeights{}, fmt.Errorf("weights must sum to 1, got %.3f", sum)
+	}
+	return w, nil
+}
+
+// finalScore combines the assessment scores. pending.ImpactScore is inverted
+// (higher = worse), so it contributes (100 - score).
+func (w scoreWeights) finalScore(sql SQLReadinessAssessment, relations RelationshipCoverage, entities EntityCompletenessAssess, pending PendingQuestionsImpact) int {
+	return int(
+		float64(sql.ConfidenceScore)*w.SQLReadiness +
+			float64(relations.CoverageScore)*w.RelationshipCoverage +
+			float64(entities.CompletenessScore)*w.EntityCompleteness +
+			float64(100-pending.ImpactScore)*w.PendingQuestions,
+	)
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// assessStubProject runs assessProject against the empty stubDB project.
+func assessStubProject(t *testing.T, j *judge, weights scoreWeights) (*AssessmentResult, error) {
+	t.Helper()
+	var arrived sync.WaitGroup
+	arrived.Add(1)
+	misuse := make(chan string, 16)
+	db := &stubDB{name: "conn", arrived: &arrived, misuse: misuse}
+	result, err := assessProject(context.Background(), db, j, uuid.New(), "test", weights)
+	close(misuse)
+	for m := range misuse {
+		t.Error(m)
+	}
+	return result, err
+}
+
+func TestRescore_DifferentWeightsChangeFinalScoreFromCache(t *testing.T) {
+	cache, err := newJudgeCache(t.TempDir(), false)
+	if err != nil {
+		t.Fatalf("newJudgeCache: %v", err)
+	}
+	client := &countingClient{}
+	original, err := assessStubProject(t, newJudge(client, cache), defaultScoreWeights)
+	if err != nil {
+		t.Fatalf("assessProject: %v", err)
+	}
+	// 70*0.40 + 80*0.25 + 75*0.20 + (100-0)*0.15
+	if original.FinalScore != 78 {
+		t.Fatalf("original FinalScore = %d, want 78", original.FinalScore)
+	}
+
+	sqlOnly, err := parseScoreWeights("sql=1,relationships=0,entities=0,questions=0")
+	if err != nil {
+		t.Fatalf("parseScoreWeights: %v", err)
+	}
+	rescoreJudge := newCacheOnlyJudge(cache)
+	rescored, err := assessStubProject(t, rescoreJudge, sqlOnly)
+	if err != nil {
+		t.Fatalf("rescore: %v", err)
+	}
+	if rescored.FinalScore != 70 {
+		t.Errorf("rescored FinalScore = %d, want the cached SQL readiness score 70", rescored.FinalScore)
+	}
+	if rescored.ScoreWeights != sqlOnly {
+		t.Errorf("rescored weights = %+v, want %+v", rescored.ScoreWeights, sqlOnly)
+	}
+	if rescored.SQLReadiness.ConfidenceScore != original.SQLReadiness.ConfidenceScore ||
+		rescored.RelationshipCoverage.CoverageScore != original.RelationshipCoverage.CoverageScore {
+		t.Errorf("rescore changed per-assessment verdicts: %+v vs %+v", rescored, original)
+	}
+	if calls, hits, tokens := rescoreJudge.usage(); calls != 0 || hits != 3 || tokens != 0 {
+		t.Errorf("rescore usage = (%d calls, %d hits, %d tokens), want (0, 3, 0)", calls, hits, tokens)
+	}
+	if client.calls != 3 {
+		t.Errorf("expected only the original run's 3 API calls, got %d", client.calls)
+	}
+}
+
+func TestRescore_FailsWhenVerdictsAreNotCached(t *testing.T) {
+	cache, err := newJudgeCache(t.TempDir(), false)
+	if err != nil {
+		t.Fatalf("newJudgeCache: %v", err)
+	}
+	_, err = assessStubProject(t, newCacheOnlyJudge(cache), defaultScoreWeights)
+	if err == nil || !strings.Contains(err.Error(), "3 judge result(s) not cached") {
+		t.Fatalf("expected an uncached-results error, got %v", err)
+	}
+
+	var result SQLReadinessAssessment
+	if err := runJudge(context.Background(), newCacheOnlyJudge(cache), "prompt", 100, &result); !errors.Is(err, errNotCached) {
+		t.Errorf("expected errNotCached, got %v", err)
+	}
+}
+
+func TestParseScoreWeights(t *testing.T) {
+	w, err := parseScoreWeights("")
+	if err != nil || w != defaultScoreWeights {
+		t.Errorf("empty spec = (%+v, %v), want defaults", w, err)
+	}
+
+	w, err = parseScoreWeights("sql=0.5, questions=0.05")
+	if err != nil {
+		t.Fatalf("parseScoreWeights: %v", err)
+	}
+	if w.SQLReadiness != 0.5 || w.PendingQuestions != 0.05 || w.RelationshipCoverage != defaultScoreWeights.RelationshipCoverage {
+		t.Errorf("unexpected weights %+v", w)
+	}
+
+	for _, spec := range []string{"sql=0.9", "sql", "sql=-0.1,relationships=0.75", "latency=0"} {
+		if _, err := parseScoreWeights(spec); err == nil {
+			t.Errorf("parseScoreWeights(%q) succeeded, want error", spec)
+		}
+	}
+}
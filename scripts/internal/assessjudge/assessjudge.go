@@ -0,0 +1,73 @@
+// Package assessjudge is the LLM judge shared by the assess-* tools. The tools score
+// extraction output by asking a model to evaluate it; they depend on the Judge
+// interface so their scoring can be tested with canned responses.
+package assessjudge
+
+import (
+	"context"
+
+	"github.com/liushuangls/go-anthropic/v2"
+)
+
+// Usage is the token usage of a single judge call.
+type Usage struct {
+	InputTokens  int
+	OutputTokens int
+}
+
+// Total returns input plus output tokens.
+func (u Usage) Total() int {
+	return u.InputTokens + u.OutputTokens
+}
+
+// Judge evaluates an assessment prompt and returns the raw response text, capped
+// at maxTokens.
+type Judge interface {
+	Assess(ctx context.Context, prompt string, maxTokens int) (string, Usage, error)
+}
+
+// Anthropic is the production Judge backed by the Anthropic Messages API.
+type Anthropic struct {
+	client *anthropic.Client
+	model  anthropic.Model
+}
+
+var _ Judge = (*Anthropic)(nil)
+
+// NewAnthropic creates a judge that sends prompts to model.
+func NewAnthropic(apiKey, model string) *Anthropic {
+	return &Anthropic{
+		client: anthropic.NewClient(apiKey),
+		model:  anthropic.Model(model),
+	}
+}
+
+func (j *Anthropic) Assess(ctx context.Context, prompt string, maxTokens int) (string, Usage, error) {
+	resp, err := j.client.CreateMessages(ctx, anthropic.MessagesRequest{
+		Model:     j.model,
+		MaxTokens: maxTokens,
+		Messages: []anthropic.Message{
+			{Role: anthropic.RoleUser, Content: []anthropic.MessageContent{
+				{Type: "text", Text: &prompt},
+			}},
+		},
+	})
+	if err != nil {
+		return "", Usage{}, err
+	}
+
+	usage := Usage{
+		InputTokens:  resp.Usage.InputTokens,
+		OutputTokens: resp.Usage.OutputTokens,
+	}
+	return textFromResponse(resp), usage, nil
+}
+
+func textFromResponse(resp anthropic.MessagesResponse) string {
+	for _, block := range resp.Content {
+		if block.Type == "text" && block.Text != nil {
+			return *block.Text
+		}
+	}
+	return ""
+}
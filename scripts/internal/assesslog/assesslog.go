@@ -0,0 +1,83 @@
+// Package assesslog provides the leveled progress logger shared by the assess-* tools.
+//
+// The assess tools write their JSON result to stdout and progress to stderr.
+// This logger only ever writes progress, so verbosity never changes stdout.
+package assesslog
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Level controls how much progress output is written.
+type Level int
+
+const (
+	LevelQuiet   Level = iota // No progress output
+	LevelNormal               // Phase-level progress (default)
+	LevelVerbose              // Phase-level progress plus per-sample detail
+)
+
+// Logger writes progress messages at or below its configured level.
+type Logger struct {
+	w     io.Writer
+	level Level
+}
+
+// New creates a Logger writing to w at the given level.
+func New(w io.Writer, level Level) *Logger {
+	return &Logger{w: w, level: level}
+}
+
+// Flags holds the -v and -quiet command-line flags.
+type Flags struct {
+	Verbose bool
+	Quiet   bool
+}
+
+// Register adds -v and -quiet to fs.
+func (f *Flags) Register(fs *flag.FlagSet) {
+	fs.BoolVar(&f.Verbose, "v", false, "verbose progress output (per-sample detail)")
+	fs.BoolVar(&f.Quiet, "quiet", false, "suppress all progress output on stderr")
+}
+
+// Level returns the level selected by the flags. -quiet wins over -v.
+func (f *Flags) Level() Level {
+	switch {
+	case f.Quiet:
+		return LevelQuiet
+	case f.Verbose:
+		return LevelVerbose
+	default:
+		return LevelNormal
+	}
+}
+
+// Logger builds a stderr Logger for the selected level.
+func (f *Flags) Logger() *Logger {
+	return New(os.Stderr, f.Level())
+}
+
+// Progressf logs phase-level progress. Suppressed in quiet mode.
+func (l *Logger) Progressf(format string, args ...any) {
+	l.logf(LevelNormal, format, args...)
+}
+
+// Detailf logs per-sample detail. Only written in verbose mode.
+func (l *Logger) Detailf(format string, args ...any) {
+	l.logf(LevelVerbose, format, args...)
+}
+
+// Verbose reports whether per-sample detail is enabled.
+func (l *Logger) Verbose() bool {
+	return l.level >= LevelVerbose
+}
+
+func (l *Logger) logf(level Level, format string, args ...any) {
+	if l == nil || l.level < level {
+		return
+	}
+	fmt.Fprintf(l.w, format, args...)
+}
@@ -0,0 +1,55 @@
+package assesslog
+
+import (
+	"bytes"
+	"flag"
+	"testing"
+)
+
+func TestLoggerLevels(t *testing.T) {
+	tests := []struct {
+		name  string
+		level Level
+		want  string
+	}{
+		{name: "quiet", level: LevelQuiet, want: ""},
+		{name: "normal", level: LevelNormal, want: "phase\n"},
+		{name: "verbose", level: LevelVerbose, want: "phase\ndetail\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			l := New(&buf, tt.level)
+			l.Progressf("phase\n")
+			l.Detailf("detail\n")
+			if got := buf.String(); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFlagsLevel(t *testing.T) {
+	tests := []struct {
+		args []string
+		want Level
+	}{
+		{args: nil, want: LevelNormal},
+		{args: []string{"-v"}, want: LevelVerbose},
+		{args: []string{"-quiet"}, want: LevelQuiet},
+		{args: []string{"-v", "-quiet"}, want: LevelQuiet},
+	}
+
+	for _, tt := range tests {
+		var f Flags
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		f.Register(fs)
+		if err := fs.Parse(tt.args); err != nil {
+			t.Fatalf("parse %v: %v", tt.args, err)
+		}
+		if got := f.Level(); got != tt.want {
+			t.Errorf("args %v: expected level %d, got %d", tt.args, tt.want, got)
+		}
+	}
+}
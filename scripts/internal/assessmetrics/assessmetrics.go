@@ -0,0 +1,241 @@
+// Package assessmetrics pushes assess-* tool scores to a monitoring stack, so quality
+// scores can be charted and alerted on alongside other SLOs.
+//
+// The target is a StatsD address (statsd://host:port, sent over UDP with DogStatsD
+// tags) or a Prometheus pushgateway URL (http:// or https://, pushed in the text
+// exposition format under job assess_<tool> and the project's grouping key). With
+// no target nothing is sent.
+package assessmetrics
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ekaya-inc/ekaya-engine/scripts/internal/assessreport"
+)
+
+// EnvTarget is the environment variable the -metrics-target flag defaults to.
+const EnvTarget = "ASSESS_METRICS_TARGET"
+
+// pushTimeout bounds a push so an unreachable endpoint cannot hang a run.
+const pushTimeout = 10 * time.Second
+
+// Flags holds the -metrics-target command-line flag.
+type Flags struct {
+	Target string
+}
+
+// Register adds -metrics-target to fs, defaulting to $ASSESS_METRICS_TARGET.
+func (f *Flags) Register(fs *flag.FlagSet) {
+	fs.StringVar(&f.Target, "metrics-target", os.Getenv(EnvTarget),
+		"push scores to statsd://host:port or a pushgateway http(s):// URL (disabled when empty; default $"+EnvTarget+")")
+}
+
+// Validate reports a target that is neither a StatsD address nor a pushgateway URL.
+func (f *Flags) Validate() error {
+	if f.Target == "" {
+		return nil
+	}
+	_, err := newSink(f.Target)
+	return err
+}
+
+// Labels identify the assessed run on every metric.
+type Labels struct {
+	ProjectID  string
+	Datasource string
+	Commit     string
+}
+
+// Metric is one gauge value. Category, when set, is added as a label.
+type Metric struct {
+	Name     string
+	Category string
+	Value    float64
+}
+
+// FromReport returns the final score and each category score of a report, followed
+// by extra, the tool's token and cost gauges.
+func FromReport(r assessreport.Report, extra ...Metric) []Metric {
+	metrics := []Metric{{Name: "final_score", Value: float64(r.FinalScore)}}
+	for _, c := range r.Categories {
+		metrics = append(metrics, Metric{Name: "category_score", Category: snakeCase(c.Name), Value: float64(c.Score)})
+	}
+	return append(metrics, extra...)
+}
+
+// sink delivers encoded metrics to one target.
+type sink interface {
+	send(ctx context.Context, tool string, labels Labels, metrics []Metric) error
+}
+
+// Push sends metrics for tool to target. An empty target sends nothing.
+func Push(ctx context.Context, target, tool string, labels Labels, metrics []Metric) error {
+	if target == "" {
+		return nil
+	}
+	s, err := newSink(target)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, pushTimeout)
+	defer cancel()
+	return s.send(ctx, tool, labels, metrics)
+}
+
+func newSink(target string) (sink, error) {
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil, fmt.Errorf("invalid metrics target %q: %w", target, err)
+	}
+	switch u.Scheme {
+	case "statsd":
+		if u.Host == "" || u.Port() == "" {
+			return nil, fmt.Errorf("invalid metrics target %q: want statsd://host:port", target)
+		}
+		return &statsdSink{addr: u.Host}, nil
+	case "http", "https":
+		if u.Host == "" {
+			return nil, fmt.Errorf("invalid metrics target %q: missing host", target)
+		}
+		return &pushgatewaySink{baseURL: strings.TrimSuffix(target, "/"), client: http.DefaultClient}, nil
+	default:
+		return nil, fmt.Errorf("invalid metrics target %q: want statsd://, http:// or https://", target)
+	}
+}
+
+// statsdSink sends one UDP datagram of gauge lines.
+type statsdSink struct {
+	addr string
+}
+
+func (s *statsdSink) send(ctx context.Context, tool string, labels Labels, metrics []Metric) error {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "udp", s.addr)
+	if err != nil {
+		return fmt.Errorf("dial statsd %s: %w", s.addr, err)
+	}
+	defer conn.Close()
+	if _, err := conn.Write([]byte(StatsDLines(tool, labels, metrics))); err != nil {
+		return fmt.Errorf("send to statsd %s: %w", s.addr, err)
+	}
+	return nil
+}
+
+// pushgatewaySink replaces the metrics of the run's group on a Prometheus pushgateway.
+type pushgatewaySink struct {
+	baseURL string
+	client  *http.Client
+}
+
+func (s *pushgatewaySink) send(ctx context.Context, tool string, labels Labels, metrics []Metric) error {
+	endpoint := s.baseURL + "/metrics/job/" + url.PathEscape("assess_"+tool)
+	if labels.ProjectID != "" {
+		endpoint += "/project_id/" + url.PathEscape(labels.ProjectID)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint, strings.NewReader(OpenMetricsText(tool, labels, metrics)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; version=0.0.4")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("push to %s: %w", s.baseURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("push to %s: status %s", s.baseURL, resp.Status)
+	}
+	return nil
+}
+
+// StatsDLines encodes metrics as StatsD gauges named assess.<tool>.<metric>, with the
+// labels and category as DogStatsD tags, one per line.
+func StatsDLines(tool string, labels Labels, metrics []Metric) string {
+	var sb strings.Builder
+	for _, m := range metrics {
+		tags := labelPairs(labels, m.Category)
+		fmt.Fprintf(&sb, "assess.%s.%s:%s|g", tool, m.Name, formatValue(m.Value))
+		if len(tags) > 0 {
+			sb.WriteString("|#")
+			for i, tag := range tags {
+				if i > 0 {
+					sb.WriteByte(',')
+				}
+				sb.WriteString(tag[0] + ":" + statsdTagValue(tag[1]))
+			}
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// OpenMetricsText encodes metrics in the Prometheus text exposition format as
+// assess_<metric> gauges labelled with the tool, run labels and category.
+func OpenMetricsText(tool string, labels Labels, metrics []Metric) string {
+	var buf bytes.Buffer
+	typed := make(map[string]bool)
+	for _, m := range metrics {
+		name := "assess_" + m.Name
+		if !typed[name] {
+			fmt.Fprintf(&buf, "# TYPE %s gauge\n", name)
+			typed[name] = true
+		}
+		pairs := append([][2]string{{"tool", tool}}, labelPairs(labels, m.Category)...)
+		buf.WriteString(name + "{")
+		for i, p := range pairs {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			fmt.Fprintf(&buf, "%s=\"%s\"", p[0], labelValueEscaper.Replace(p[1]))
+		}
+		fmt.Fprintf(&buf, "} %s\n", formatValue(m.Value))
+	}
+	return buf.String()
+}
+
+// labelValueEscaper escapes a label value for the text exposition format.
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// labelPairs returns the non-empty labels in a fixed order.
+func labelPairs(labels Labels, category string) [][2]string {
+	var pairs [][2]string
+	for _, p := range [][2]string{
+		{"project_id", labels.ProjectID},
+		{"datasource", labels.Datasource},
+		{"commit", labels.Commit},
+		{"category", category},
+	} {
+		if p[1] != "" {
+			pairs = append(pairs, p)
+		}
+	}
+	return pairs
+}
+
+// statsdTagValue strips the characters that delimit StatsD tags.
+func statsdTagValue(v string) string {
+	return strings.NewReplacer(",", "_", "|", "_", "#", "_", " ", "_", "\n", "_").Replace(v)
+}
+
+func formatValue(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// snakeCase turns a category name like "Question quality" into question_quality.
+func snakeCase(name string) string {
+	fields := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	})
+	return strings.Join(fields, "_")
+}
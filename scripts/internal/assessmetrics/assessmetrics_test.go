@@ -0,0 +1,127 @@
+package assessmetrics
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ekaya-inc/ekaya-engine/scripts/internal/assessreport"
+)
+
+func sampleMetrics() []Metric {
+	report := assessreport.Report{
+		FinalScore: 82,
+		Categories: []assessreport.Category{
+			{Name: "Question quality", Score: 75},
+			{Name: "Consistency", Score: 90},
+		},
+	}
+	return FromReport(report,
+		Metric{Name: "judge_tokens", Value: 12500},
+		Metric{Name: "cost_per_table_usd", Value: 0.0125},
+	)
+}
+
+var sampleLabels = Labels{ProjectID: "6f1c2d3e", Datasource: "analytics", Commit: "abc1234"}
+
+func TestPush_StatsD(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer conn.Close()
+
+	if err := Push(context.Background(), "statsd://"+conn.LocalAddr().String(), "extraction", sampleLabels, sampleMetrics()); err != nil {
+		t.Fatalf("push: %v", err)
+	}
+
+	buf := make([]byte, 4096)
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+
+	tags := "project_id:6f1c2d3e,datasource:analytics,commit:abc1234"
+	want := "assess.extraction.final_score:82|g|#" + tags + "\n" +
+		"assess.extraction.category_score:75|g|#" + tags + ",category:question_quality\n" +
+		"assess.extraction.category_score:90|g|#" + tags + ",category:consistency\n" +
+		"assess.extraction.judge_tokens:12500|g|#" + tags + "\n" +
+		"assess.extraction.cost_per_table_usd:0.0125|g|#" + tags + "\n"
+	if got := string(buf[:n]); got != want {
+		t.Errorf("unexpected statsd lines:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestPush_Pushgateway(t *testing.T) {
+	var gotMethod, gotPath, gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotMethod, gotPath, gotBody = r.Method, r.URL.Path, string(body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	if err := Push(context.Background(), server.URL+"/", "ontology", sampleLabels, sampleMetrics()); err != nil {
+		t.Fatalf("push: %v", err)
+	}
+
+	if gotMethod != http.MethodPut || gotPath != "/metrics/job/assess_ontology/project_id/6f1c2d3e" {
+		t.Errorf("unexpected request %s %s", gotMethod, gotPath)
+	}
+	labels := `tool="ontology",project_id="6f1c2d3e",datasource="analytics",commit="abc1234"`
+	for _, line := range []string{
+		"# TYPE assess_final_score gauge",
+		"assess_final_score{" + labels + "} 82",
+		"assess_category_score{" + labels + `,category="question_quality"} 75`,
+		"assess_category_score{" + labels + `,category="consistency"} 90`,
+		"assess_judge_tokens{" + labels + "} 12500",
+	} {
+		if !strings.Contains(gotBody, line+"\n") {
+			t.Errorf("expected line %q in body:\n%s", line, gotBody)
+		}
+	}
+	if strings.Count(gotBody, "# TYPE assess_category_score gauge") != 1 {
+		t.Errorf("expected one TYPE line per metric name:\n%s", gotBody)
+	}
+}
+
+func TestPush_PushgatewayError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	err := Push(context.Background(), server.URL, "ontology", sampleLabels, sampleMetrics())
+	if err == nil || !strings.Contains(err.Error(), "400") {
+		t.Errorf("expected a 400 error, got %v", err)
+	}
+}
+
+func TestPush_NoTargetSendsNothing(t *testing.T) {
+	if err := Push(context.Background(), "", "extraction", sampleLabels, sampleMetrics()); err != nil {
+		t.Errorf("expected no error without a target, got %v", err)
+	}
+}
+
+func TestFlags_Validate(t *testing.T) {
+	for target, wantErr := range map[string]bool{
+		"":                          false,
+		"statsd://localhost:8125":   false,
+		"http://pushgateway:9091":   false,
+		"https://pushgateway.local": false,
+		"statsd://localhost":        true,
+		"udp://localhost:8125":      true,
+		"pushgateway:9091":          true,
+	} {
+		f := Flags{Target: target}
+		if err := f.Validate(); (err != nil) != wantErr {
+			t.Errorf("Validate(%q) error = %v, want error %v", target, err, wantErr)
+		}
+	}
+}
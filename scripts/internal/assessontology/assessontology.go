@@ -0,0 +1,180 @@
+// Package assessontology loads a project's extracted ontology for the assess-* tools.
+//
+// The engine no longer stores the ontology as one record: the domain summary lives
+// on engine_projects, and each table's summary is assembled here from its table
+// metadata, the column metadata of its key columns, and its schema relationships.
+// The result keeps the domain_summary / entity_summaries JSON shape the tools score.
+package assessontology
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrNoOntology is returned when the project has neither a domain summary nor any
+// described table, i.e. extraction has not run.
+var ErrNoOntology = errors.New("no extracted ontology found")
+
+// Querier is the subset of pgx.Conn / pgxpool.Conn the loader needs.
+type Querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// Ontology is a project's extracted ontology as raw JSON. EntitySummaries is an
+// object keyed by table name whose values are EntitySummary objects.
+type Ontology struct {
+	DomainSummary   json.RawMessage `json:"domain_summary"`
+	EntitySummaries json.RawMessage `json:"entity_summaries"`
+}
+
+// EntitySummary is the summary of one table. BusinessName, Domain and Synonyms are
+// not extracted per table any more and are left empty.
+type EntitySummary struct {
+	TableName     string      `json:"table_name"`
+	BusinessName  string      `json:"business_name"`
+	Description   string      `json:"description"`
+	Domain        string      `json:"domain"`
+	Synonyms      []string    `json:"synonyms"`
+	KeyColumns    []KeyColumn `json:"key_columns"`
+	Relationships []string    `json:"relationships"`
+}
+
+// KeyColumn is a column users refer to the table by: one the engine classified as
+// an identifier or dimension, or gave synonyms.
+type KeyColumn struct {
+	Name     string   `json:"name"`
+	Synonyms []string `json:"synonyms"`
+}
+
+// Load reads the project's domain summary and builds its entity summaries.
+func Load(ctx context.Context, q Querier, projectID uuid.UUID) (*Ontology, error) {
+	var domainSummary json.RawMessage
+	err := q.QueryRow(ctx, `SELECT domain_summary FROM engine_projects WHERE id = $1`, projectID).Scan(&domainSummary)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("project %s not found", projectID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load domain summary: %w", err)
+	}
+
+	entities, err := loadEntities(ctx, q, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if len(domainSummary) == 0 && len(entities) == 0 {
+		return nil, ErrNoOntology
+	}
+	if err := loadKeyColumns(ctx, q, projectID, entities); err != nil {
+		return nil, err
+	}
+	if err := loadRelationships(ctx, q, projectID, entities); err != nil {
+		return nil, err
+	}
+
+	entityJSON, err := json.Marshal(entities)
+	if err != nil {
+		return nil, fmt.Errorf("marshal entity summaries: %w", err)
+	}
+	return &Ontology{DomainSummary: domainSummary, EntitySummaries: entityJSON}, nil
+}
+
+// loadEntities returns a summary for every table with a description, keyed by
+// table name.
+func loadEntities(ctx context.Context, q Querier, projectID uuid.UUID) (map[string]*EntitySummary, error) {
+	rows, err := q.Query(ctx, `
+		SELECT t.table_name, tm.description
+		FROM engine_ontology_table_metadata tm
+		JOIN engine_schema_tables t ON tm.schema_table_id = t.id
+		WHERE tm.project_id = $1 AND t.deleted_at IS NULL AND tm.description IS NOT NULL
+		ORDER BY t.table_name`, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("load table metadata: %w", err)
+	}
+	defer rows.Close()
+
+	entities := make(map[string]*EntitySummary)
+	for rows.Next() {
+		var e EntitySummary
+		if err := rows.Scan(&e.TableName, &e.Description); err != nil {
+			return nil, fmt.Errorf("scan table metadata: %w", err)
+		}
+		e.Synonyms = []string{}
+		e.KeyColumns = []KeyColumn{}
+		e.Relationships = []string{}
+		entities[e.TableName] = &e
+	}
+	return entities, rows.Err()
+}
+
+func loadKeyColumns(ctx context.Context, q Querier, projectID uuid.UUID, entities map[string]*EntitySummary) error {
+	rows, err := q.Query(ctx, `
+		SELECT t.table_name, c.column_name, COALESCE(cm.features->'synonyms', '[]'::jsonb)
+		FROM engine_ontology_column_metadata cm
+		JOIN engine_schema_columns c ON cm.schema_column_id = c.id
+		JOIN engine_schema_tables t ON c.schema_table_id = t.id
+		WHERE cm.project_id = $1 AND c.deleted_at IS NULL AND t.deleted_at IS NULL
+		  AND (cm.role IN ('identifier', 'dimension')
+		       OR jsonb_array_length(COALESCE(cm.features->'synonyms', '[]'::jsonb)) > 0)
+		ORDER BY t.table_name, c.ordinal_position`, projectID)
+	if err != nil {
+		return fmt.Errorf("load key columns: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var table string
+		var col KeyColumn
+		var synonyms []byte
+		if err := rows.Scan(&table, &col.Name, &synonyms); err != nil {
+			return fmt.Errorf("scan key column: %w", err)
+		}
+		if err := json.Unmarshal(synonyms, &col.Synonyms); err != nil {
+			return fmt.Errorf("parse synonyms of %s.%s: %w", table, col.Name, err)
+		}
+		if e, ok := entities[table]; ok {
+			e.KeyColumns = append(e.KeyColumns, col)
+		}
+	}
+	return rows.Err()
+}
+
+// loadRelationships lists each table's outgoing relationships that were not
+// rejected, as "column -> target_table.target_column".
+func loadRelationships(ctx context.Context, q Querier, projectID uuid.UUID, entities map[string]*EntitySummary) error {
+	rows, err := q.Query(ctx, `
+		SELECT st.table_name, sc.column_name, tt.table_name, tc.column_name
+		FROM engine_schema_relationships r
+		JOIN engine_schema_tables st ON r.source_table_id = st.id
+		JOIN engine_schema_columns sc ON r.source_column_id = sc.id
+		JOIN engine_schema_tables tt ON r.target_table_id = tt.id
+		JOIN engine_schema_columns tc ON r.target_column_id = tc.id
+		WHERE r.project_id = $1 AND r.deleted_at IS NULL AND r.is_approved IS DISTINCT FROM false`, projectID)
+	if err != nil {
+		return fmt.Errorf("load relationships: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var sourceTable, sourceColumn, targetTable, targetColumn string
+		if err := rows.Scan(&sourceTable, &sourceColumn, &targetTable, &targetColumn); err != nil {
+			return fmt.Errorf("scan relationship: %w", err)
+		}
+		if e, ok := entities[sourceTable]; ok {
+			e.Relationships = append(e.Relationships, sourceColumn+" -> "+targetTable+"."+targetColumn)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, e := range entities {
+		sort.Strings(e.Relationships)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+//go:build integration
+
+package assessontology
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ekaya-inc/ekaya-engine/pkg/testhelpers"
+)
+
+// TestLoad_CurrentSchema loads an ontology from a fully migrated engine database.
+func TestLoad_CurrentSchema(t *testing.T) {
+	engineDB := testhelpers.GetEngineDB(t)
+	ctx := context.Background()
+	scope, err := engineDB.DB.WithoutTenant(ctx)
+	require.NoError(t, err)
+	defer scope.Close()
+	conn := scope.Conn
+
+	projectID := uuid.MustParse("00000000-0000-0000-0000-000000000091")
+	datasourceID := uuid.MustParse("00000000-0000-0000-0000-000000000092")
+	t.Cleanup(func() {
+		_, _ = conn.Exec(context.Background(), `DELETE FROM engine_projects WHERE id = $1`, projectID)
+	})
+	_, _ = conn.Exec(ctx, `DELETE FROM engine_projects WHERE id = $1`, projectID)
+
+	exec := func(sql string, args ...any) {
+		t.Helper()
+		_, err := conn.Exec(ctx, sql, args...)
+		require.NoError(t, err)
+	}
+
+	exec(`INSERT INTO engine_projects (id, name, status) VALUES ($1, 'Assess Ontology Test', 'active')`, projectID)
+
+	// Extraction has not run: no domain summary and no table descriptions
+	_, err = Load(ctx, conn, projectID)
+	require.True(t, errors.Is(err, ErrNoOntology), "got %v", err)
+
+	exec(`UPDATE engine_projects SET domain_summary = $2 WHERE id = $1`, projectID,
+		`{"description": "An online store", "domains": ["sales"]}`)
+	exec(`INSERT INTO engine_datasources (id, project_id, name, datasource_type, datasource_config)
+		VALUES ($1, $2, 'Assess DS', 'postgres', '{}')`, datasourceID, projectID)
+
+	usersID, ordersID := uuid.New(), uuid.New()
+	exec(`INSERT INTO engine_schema_tables (id, project_id, datasource_id, schema_name, table_name, is_selected)
+		VALUES ($1, $3, $4, 'public', 'users', true), ($2, $3, $4, 'public', 'orders', true)`,
+		usersID, ordersID, projectID, datasourceID)
+
+	userPK, userEmail, orderPK, orderUser, orderStatus := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	exec(`INSERT INTO engine_schema_columns (id, project_id, schema_table_id, column_name, data_type, is_nullable, is_primary_key, ordinal_position)
+		VALUES ($1, $6, $7, 'id', 'uuid', false, true, 1),
+		       ($2, $6, $7, 'email', 'text', false, false, 2),
+		       ($3, $6, $8, 'id', 'uuid', false, true, 1),
+		       ($4, $6, $8, 'user_id', 'uuid', false, false, 2),
+		       ($5, $6, $8, 'status', 'text', false, false, 3)`,
+		userPK, userEmail, orderPK, orderUser, orderStatus, projectID, usersID, ordersID)
+
+	exec(`INSERT INTO engine_ontology_table_metadata (project_id, schema_table_id, description)
+		VALUES ($1, $2, 'People who shop'), ($1, $3, 'Purchases made by users')`, projectID, usersID, ordersID)
+	exec(`INSERT INTO engine_ontology_column_metadata (project_id, schema_column_id, role, features)
+		VALUES ($1, $2, 'identifier', '{"synonyms": ["e-mail"]}'),
+		       ($1, $3, 'primary_key', '{}'),
+		       ($1, $4, 'dimension', '{}')`, projectID, userEmail, userPK, orderStatus)
+
+	exec(`INSERT INTO engine_schema_relationships
+		(project_id, source_table_id, source_column_id, target_table_id, target_column_id, relationship_type, source, is_approved)
+		VALUES ($1, $2, $3, $4, $5, 'fk', 'inferred', true),
+		       ($1, $2, $6, $4, $7, 'inferred', 'inferred', false)`,
+		projectID, ordersID, orderUser, usersID, userPK, orderStatus, userEmail)
+
+	o, err := Load(ctx, conn, projectID)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"description": "An online store", "domains": ["sales"]}`, string(o.DomainSummary))
+
+	var entities map[string]EntitySummary
+	require.NoError(t, json.Unmarshal(o.EntitySummaries, &entities))
+	require.Len(t, entities, 2)
+
+	users := entities["users"]
+	assert.Equal(t, "People who shop", users.Description)
+	assert.Equal(t, []KeyColumn{{Name: "email", Synonyms: []string{"e-mail"}}}, users.KeyColumns)
+	assert.Empty(t, users.Relationships)
+
+	orders := entities["orders"]
+	assert.Equal(t, []KeyColumn{{Name: "status", Synonyms: []string{}}}, orders.KeyColumns)
+	assert.Equal(t, []string{"user_id -> users.id"}, orders.Relationships, "rejected relationships are left out")
+}
@@ -0,0 +1,225 @@
+// Package assessreport renders assess-* tool results for the -format flag.
+//
+// JSON, the default, is the result struct itself. The Markdown format is a readable
+// report for pasting into a PR or doc; each tool builds its Report from the same
+// result struct it marshals, so the two formats cannot disagree.
+package assessreport
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Output formats accepted by -format.
+const (
+	FormatJSON     = "json"
+	FormatMarkdown = "md"
+)
+
+// MaxTopIssues is how many issues the Markdown report lists before summarizing the rest.
+const MaxTopIssues = 10
+
+// recommendThreshold is the category score below which a recommendation is added.
+const recommendThreshold = 80
+
+// Flags holds the -format command-line flag.
+type Flags struct {
+	Format string
+}
+
+// Register adds -format to fs.
+func (f *Flags) Register(fs *flag.FlagSet) {
+	fs.StringVar(&f.Format, "format", FormatJSON, "output format: json or md (Markdown report)")
+}
+
+// Validate reports an unknown -format value.
+func (f *Flags) Validate() error {
+	switch f.Format {
+	case FormatJSON, FormatMarkdown:
+		return nil
+	default:
+		return fmt.Errorf("unknown format %q (want %s or %s)", f.Format, FormatJSON, FormatMarkdown)
+	}
+}
+
+// Report is the format-independent view of an assessment result.
+type Report struct {
+	Title           string
+	Details         []Detail // Run metadata shown under the title; empty values are omitted
+	FinalScore      int
+	Summary         string
+	Categories      []Category
+	Issues          []string // Most important first
+	Recommendations []string // Tool-specific recommendations, listed before the category ones
+}
+
+// Detail is one name/value line of run metadata.
+type Detail struct {
+	Name  string
+	Value string
+}
+
+// Category is one scored part of an assessment.
+type Category struct {
+	Name   string
+	Score  int      // 0-100
+	Weight float64  // Relative weight in the final score; 0 when the category is informational
+	Notes  []string // Findings behind the score
+}
+
+// Write writes result as indented JSON, or report as Markdown, to w.
+func Write(w io.Writer, format string, result any, report func() Report) error {
+	if format == FormatMarkdown {
+		_, err := io.WriteString(w, Markdown(report()))
+		return err
+	}
+	output, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(output))
+	return err
+}
+
+// Badge renders a score as a colored status marker: green from 80, yellow from 60,
+// red below.
+func Badge(score int) string {
+	switch {
+	case score >= 80:
+		return fmt.Sprintf("🟢 %d/100", score)
+	case score >= 60:
+		return fmt.Sprintf("🟡 %d/100", score)
+	default:
+		return fmt.Sprintf("🔴 %d/100", score)
+	}
+}
+
+// Markdown renders the report: title and score badge, run details, a category
+// breakdown table with each category's findings, the top issues and recommendations.
+func Markdown(r Report) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "# %s\n\n", r.Title)
+	fmt.Fprintf(&sb, "**Final score: %s**\n\n", Badge(r.FinalScore))
+	if r.Summary != "" {
+		fmt.Fprintf(&sb, "%s\n\n", r.Summary)
+	}
+
+	var details []Detail
+	for _, d := range r.Details {
+		if strings.TrimSpace(d.Value) != "" {
+			details = append(details, d)
+		}
+	}
+	if len(details) > 0 {
+		sb.WriteString("| | |\n|---|---|\n")
+		for _, d := range details {
+			fmt.Fprintf(&sb, "| %s | %s |\n", cell(d.Name), cell(d.Value))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(r.Categories) > 0 {
+		sb.WriteString("## Breakdown\n\n")
+		sb.WriteString("| Category | Score | Weight |\n|---|---|---|\n")
+		for _, c := range r.Categories {
+			fmt.Fprintf(&sb, "| %s | %s | %s |\n", cell(c.Name), Badge(c.Score), percent(r.share(c)))
+		}
+		sb.WriteString("\n")
+		for _, c := range r.Categories {
+			if len(c.Notes) == 0 {
+				continue
+			}
+			fmt.Fprintf(&sb, "### %s\n\n", c.Name)
+			for _, note := range c.Notes {
+				fmt.Fprintf(&sb, "- %s\n", oneLine(note))
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	sb.WriteString("## Top Issues\n\n")
+	if len(r.Issues) == 0 {
+		sb.WriteString("No issues found.\n\n")
+	} else {
+		for i, issue := range r.Issues {
+			if i == MaxTopIssues {
+				fmt.Fprintf(&sb, "\n_…and %d more._\n", len(r.Issues)-MaxTopIssues)
+				break
+			}
+			fmt.Fprintf(&sb, "%d. %s\n", i+1, oneLine(issue))
+		}
+		sb.WriteString("\n")
+	}
+
+	if recommendations := r.recommendations(); len(recommendations) > 0 {
+		sb.WriteString("## Recommendations\n\n")
+		for _, rec := range recommendations {
+			fmt.Fprintf(&sb, "- %s\n", oneLine(rec))
+		}
+		sb.WriteString("\n")
+	}
+
+	return strings.TrimRight(sb.String(), "\n") + "\n"
+}
+
+// recommendations returns the tool's recommendations followed by one for each
+// weighted category scoring below recommendThreshold, the category costing the most
+// final-score points first.
+func (r Report) recommendations() []string {
+	recommendations := append([]string(nil), r.Recommendations...)
+
+	var weak []Category
+	for _, c := range r.Categories {
+		if c.Weight > 0 && c.Score < recommendThreshold {
+			weak = append(weak, c)
+		}
+	}
+	sort.SliceStable(weak, func(i, j int) bool { return r.pointsLost(weak[i]) > r.pointsLost(weak[j]) })
+	for _, c := range weak {
+		rec := fmt.Sprintf("Improve **%s** (%d/100, costs %.1f points of the final score)", c.Name, c.Score, r.pointsLost(c))
+		if len(c.Notes) > 0 {
+			rec += ": " + c.Notes[0]
+		}
+		recommendations = append(recommendations, rec)
+	}
+	return recommendations
+}
+
+// share is the category's weight as a percentage of the total category weight.
+func (r Report) share(c Category) float64 {
+	total := 0.0
+	for _, other := range r.Categories {
+		total += max(other.Weight, 0)
+	}
+	if c.Weight <= 0 || total == 0 {
+		return 0
+	}
+	return c.Weight / total * 100
+}
+
+// pointsLost is how many final-score points the category's shortfall costs.
+func (r Report) pointsLost(c Category) float64 {
+	return float64(100-c.Score) * r.share(c) / 100
+}
+
+func percent(p float64) string {
+	if p <= 0 {
+		return "—"
+	}
+	return strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%.1f", p), "0"), ".") + "%"
+}
+
+// oneLine collapses newlines so a value stays one list item.
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// cell makes s safe inside a table cell.
+func cell(s string) string {
+	return strings.ReplaceAll(oneLine(s), "|", `\|`)
+}
@@ -0,0 +1,105 @@
+package assessreport
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// sampleReport is a deterministic-assessment style report with a weak category,
+// an informational category and more issues than the Markdown report lists.
+func sampleReport() Report {
+	issues := []string{
+		"SECURITY: 2 stored prompt(s) contain values the redaction policy should have removed",
+		"Relationship coverage is low: 3 of 8 tables have no relationships",
+	}
+	for i := 1; i <= 10; i++ {
+		issues = append(issues, fmt.Sprintf("Column orders.col_%d has no statistics", i))
+	}
+	return Report{
+		Title: "Deterministic Assessment: analytics",
+		Details: []Detail{
+			{Name: "Project", Value: "6f1c2d3e-0000-4000-8000-000000000001"},
+			{Name: "Commit", Value: "abc1234 | main"},
+		},
+		FinalScore: 72,
+		Summary:    "Good coverage of question sources;\nrelationships need work.",
+		Categories: []Category{
+			{Name: "Question sources", Score: 95, Weight: 15},
+			{Name: "Relationship coverage", Score: 55, Weight: 20, Notes: []string{
+				"Relationship coverage is low: 3 of 8 tables have no relationships",
+				"Orphan tables: audit_log, tmp_import, events",
+			}},
+			{Name: "Stats completeness", Score: 70, Weight: 15},
+			{Name: "Redaction compliance", Score: 0, Notes: []string{"Informational only"}},
+		},
+		Issues:          issues,
+		Recommendations: []string{"Auto-answer 2 question(s) from documented enum values"},
+	}
+}
+
+func TestMarkdown_Golden(t *testing.T) {
+	got := Markdown(sampleReport())
+
+	golden := filepath.Join("testdata", "report.md")
+	if *update {
+		if err := os.WriteFile(golden, []byte(got), 0o644); err != nil {
+			t.Fatalf("write golden: %v", err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("read golden (run with -update to create it): %v", err)
+	}
+	if got != string(want) {
+		t.Errorf("Markdown output differs from %s (run with -update to accept):\n%s", golden, got)
+	}
+}
+
+func TestBadge(t *testing.T) {
+	for score, want := range map[int]string{100: "🟢 100/100", 80: "🟢 80/100", 79: "🟡 79/100", 60: "🟡 60/100", 59: "🔴 59/100"} {
+		if got := Badge(score); got != want {
+			t.Errorf("Badge(%d) = %q, want %q", score, got, want)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	result := map[string]int{"final_score": 72}
+	report := func() Report { return Report{Title: "Sample", FinalScore: 72} }
+
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatJSON, result, report); err != nil {
+		t.Fatalf("Write json: %v", err)
+	}
+	var decoded map[string]int
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil || decoded["final_score"] != 72 {
+		t.Errorf("expected the result as JSON, got %q (%v)", buf.String(), err)
+	}
+
+	buf.Reset()
+	if err := Write(&buf, FormatMarkdown, result, report); err != nil {
+		t.Fatalf("Write md: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "# Sample\n") {
+		t.Errorf("expected a Markdown report, got %q", buf.String())
+	}
+}
+
+func TestFlags_Validate(t *testing.T) {
+	for _, format := range []string{FormatJSON, FormatMarkdown} {
+		if err := (&Flags{Format: format}).Validate(); err != nil {
+			t.Errorf("Validate(%q) = %v", format, err)
+		}
+	}
+	if err := (&Flags{Format: "html"}).Validate(); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
@@ -208,7 +208,7 @@ func testModel(ctx context.Context, model Model, logger *zap.Logger, timeout tim
 	fmt.Println("--- End Raw Response ---")
 	fmt.Printf("\nTokens: prompt=%d, completion=%d, total=%d\n",
 		resp.PromptTokens, resp.CompletionTokens, resp.TotalTokens)
-	fmt.Printf("Duration: %dms, Throughput: %.1f tok/s\n", result.DurationMs, result.TokensPerSec)
+	fmt.Printf("Duration: %dms, Throughput: %.1f tok/s, Attempts: %d\n", result.DurationMs, result.TokensPerSec, resp.Attempts)
 
 	// Try to extract JSON
 	fmt.Println("\n--- JSON Extraction ---")
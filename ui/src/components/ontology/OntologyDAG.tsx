@@ -77,6 +77,9 @@ const getNodeStatusIcon = (status: DAGNodeStatus, isCurrentNode: boolean) => {
       return <AlertCircle className="h-5 w-5 text-red-500" />;
     case 'skipped':
       return <Circle className="h-5 w-5 text-gray-400" />;
+    case 'cancelled':
+    case 'budget_exceeded':
+      return <AlertCircle className="h-5 w-5 text-amber-500" />;
     default:
       return <Circle className="h-5 w-5 text-gray-300" />;
   }
@@ -620,7 +623,9 @@ export const OntologyDAG = ({
     }
 
     if (isFailed) {
-      const failedNode = dagStatus?.nodes.find((n) => n.status === 'failed');
+      const failedNode = dagStatus?.nodes.find(
+        (n) => n.status === 'failed' || n.status === 'budget_exceeded'
+      );
       return (
         <div className="mb-4 p-3 rounded-lg bg-red-50 border border-red-200 dark:bg-red-900/20 dark:border-red-800">
           <div className="flex items-center gap-2">
@@ -1123,7 +1128,7 @@ export const OntologyDAG = ({
                           </div>
                         </div>
                       )}
-                    {node.status === 'failed' && node.error && (
+                    {(node.status === 'failed' || node.status === 'budget_exceeded') && node.error && (
                       <p className="mt-1 text-sm text-red-600 dark:text-red-400">{node.error}</p>
                     )}
                   </div>
@@ -1138,12 +1143,14 @@ export const OntologyDAG = ({
                             ? 'bg-blue-100 text-blue-700 dark:bg-blue-900/40 dark:text-blue-300'
                             : node.status === 'failed'
                               ? 'bg-red-100 text-red-700 dark:bg-red-900/40 dark:text-red-300'
-                              : node.status === 'skipped'
-                                ? 'bg-gray-100 text-gray-600 dark:bg-gray-800 dark:text-gray-400'
-                                : 'bg-gray-100 text-gray-500 dark:bg-gray-800 dark:text-gray-500'
+                              : node.status === 'cancelled' || node.status === 'budget_exceeded'
+                                ? 'bg-amber-100 text-amber-700 dark:bg-amber-900/40 dark:text-amber-300'
+                                : node.status === 'skipped'
+                                  ? 'bg-gray-100 text-gray-600 dark:bg-gray-800 dark:text-gray-400'
+                                  : 'bg-gray-100 text-gray-500 dark:bg-gray-800 dark:text-gray-500'
                       }`}
                     >
-                      {node.status.charAt(0).toUpperCase() + node.status.slice(1)}
+                      {node.status.charAt(0).toUpperCase() + node.status.slice(1).replace('_', ' ')}
                     </span>
                   </div>
                 </div>
@@ -488,7 +488,14 @@ export type DAGStatus = 'pending' | 'running' | 'completed' | 'failed' | 'cancel
 /**
  * DAG node status values
  */
-export type DAGNodeStatus = 'pending' | 'running' | 'completed' | 'failed' | 'skipped';
+export type DAGNodeStatus =
+  | 'pending'
+  | 'running'
+  | 'completed'
+  | 'failed'
+  | 'skipped'
+  | 'cancelled'
+  | 'budget_exceeded';
 
 /**
  * DAG node names in execution order